@@ -67,6 +67,14 @@ Examples:
 				return err
 			}
 			fmt.Fprintf(cmd.OutOrStdout(), "Meetings adjusted: evaluated=%d updated=%d\n", result.Evaluated, result.Updated)
+			for _, adjustment := range result.Adjustments {
+				reason := "attendance"
+				if adjustment.FrequentlyCanceled {
+					reason = "frequently canceled"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "  %s: every %d -> %d days (%s; held=%d canceled=%d)\n",
+					adjustment.Name, adjustment.FromDays, adjustment.ToDays, reason, adjustment.Held, adjustment.Canceled)
+			}
 		}
 
 		return nil
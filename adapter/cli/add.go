@@ -8,9 +8,13 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/spf13/cobra"
 )
 
+var addRepeat string
+
 var addCmd = &cobra.Command{
 	Use:   "add <description>",
 	Short: "Quick add a task with natural language",
@@ -21,6 +25,9 @@ The command parses your input to extract:
 - Due date: today, tomorrow, next week, monday-sunday, or YYYY-MM-DD
 - Priority: urgent, high, medium, low (or !, !!, !!!)
 - Duration: 30min, 1h, 2 hours, etc.
+- Tags: #work, #errands (any number)
+
+Use --repeat to make the task recur: completing it adds the next one.
 
 Examples:
   orbita add "Buy groceries"
@@ -28,7 +35,9 @@ Examples:
   orbita add "Finish report by friday high priority"
   orbita add "Call mom today !!"
   orbita add "Review PR for 30min"
-  orbita add "Team meeting next monday 2h urgent"`,
+  orbita add "Team meeting next monday 2h urgent"
+  orbita add "Book flights #travel #personal"
+  orbita add "Pay rent 2024-04-01" --repeat monthly`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		app := GetApp()
@@ -39,7 +48,7 @@ Examples:
 		}
 
 		input := strings.Join(args, " ")
-		parsed := parseNaturalLanguage(input)
+		parsed := parseNaturalLanguage(input, WeekStartsOn())
 
 		// Build command
 		durationMins := 0
@@ -54,6 +63,8 @@ Examples:
 			Priority:        parsed.priority,
 			DurationMinutes: durationMins,
 			DueDate:         parsed.dueDate,
+			Recurrence:      addRepeat,
+			Tags:            parsed.tags,
 		}
 
 		result, err := app.CreateTaskHandler.Handle(cmd.Context(), createCmd)
@@ -74,6 +85,12 @@ Examples:
 		if parsed.dueDate != nil {
 			fmt.Printf("  Due: %s\n", parsed.dueDate.Format("Mon, Jan 2 2006"))
 		}
+		if addRepeat != "" {
+			fmt.Printf("  Repeats: %s\n", addRepeat)
+		}
+		if len(parsed.tags) > 0 {
+			fmt.Printf("  Tags: %s\n", strings.Join(parsed.tags, ", "))
+		}
 
 		return nil
 	},
@@ -84,13 +101,17 @@ type parsedInput struct {
 	priority string
 	duration time.Duration
 	dueDate  *time.Time
+	tags     []string
 }
 
-func parseNaturalLanguage(input string) parsedInput {
+func parseNaturalLanguage(input string, weekStartsOn time.Weekday) parsedInput {
 	result := parsedInput{
 		title: input,
 	}
 
+	// Extract tags first, so #urgent is a tag rather than a priority
+	result.tags, result.title = extractTags(result.title)
+
 	// Extract priority
 	result.priority, result.title = extractPriority(result.title)
 
@@ -98,7 +119,7 @@ func parseNaturalLanguage(input string) parsedInput {
 	result.duration, result.title = extractDuration(result.title)
 
 	// Extract due date
-	result.dueDate, result.title = extractDueDate(result.title)
+	result.dueDate, result.title = extractDueDate(result.title, weekStartsOn)
 
 	// Clean up title
 	result.title = cleanTitle(result.title)
@@ -106,16 +127,33 @@ func parseNaturalLanguage(input string) parsedInput {
 	return result
 }
 
-func extractPriority(input string) (string, string) {
-	// Check for !!! or !! or !
-	if strings.Contains(input, "!!!") {
-		return "urgent", strings.ReplaceAll(input, "!!!", "")
+// tagTokenPattern matches a #tag token. Tags must start a word and a
+// letter, so issue numbers such as #123 stay in the title.
+var tagTokenPattern = regexp.MustCompile(`(?:^|\s)#(\p{L}[\p{L}\p{N}_-]*)`)
+
+// extractTags removes every #tag token from input and returns the tags
+// normalized: lower-cased and without duplicates.
+func extractTags(input string) ([]string, string) {
+	var tags []string
+	for _, match := range tagTokenPattern.FindAllStringSubmatch(input, -1) {
+		tags = append(tags, match[1])
+	}
+	if len(tags) == 0 {
+		return nil, input
 	}
-	if strings.Contains(input, "!!") {
-		return "high", strings.ReplaceAll(input, "!!", "")
+	if normalized, err := sharedDomain.NormalizeTags(tags); err == nil {
+		tags = normalized
 	}
-	if strings.Contains(input, "!") && !strings.Contains(input, "!!") {
-		return "medium", strings.ReplaceAll(input, "!", "")
+	return tags, tagTokenPattern.ReplaceAllString(input, " ")
+}
+
+func extractPriority(input string) (string, string) {
+	// Check for !!! or !! or !
+	for _, marker := range value_objects.PriorityMarkers {
+		if strings.Contains(input, marker) {
+			p, _ := value_objects.ParsePriorityMarker(marker)
+			return p.String(), strings.ReplaceAll(input, marker, "")
+		}
 	}
 
 	// Check for priority keywords
@@ -166,7 +204,9 @@ func extractDuration(input string) (time.Duration, string) {
 	return 0, input
 }
 
-func extractDueDate(input string) (*time.Time, string) {
+// extractDueDate resolves relative due dates in input. "next week" is the
+// first day of the following week, where weeks begin on weekStartsOn.
+func extractDueDate(input string, weekStartsOn time.Weekday) (*time.Time, string) {
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	lower := strings.ToLower(input)
@@ -175,7 +215,7 @@ func extractDueDate(input string) (*time.Time, string) {
 	relativeDates := map[string]time.Time{
 		"today":     today,
 		"tomorrow":  today.AddDate(0, 0, 1),
-		"next week": today.AddDate(0, 0, 7),
+		"next week": sharedDomain.StartOfNextWeek(today, weekStartsOn),
 	}
 
 	for keyword, date := range relativeDates {
@@ -250,5 +290,6 @@ func cleanTitle(title string) string {
 }
 
 func init() {
+	addCmd.Flags().StringVar(&addRepeat, "repeat", "", "repeat after completion (daily, weekly, monthly or e.g. \"every 2 weeks\")")
 	rootCmd.AddCommand(addCmd)
 }
@@ -4,7 +4,9 @@ import (
 	"testing"
 	"time"
 
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExtractPriority(t *testing.T) {
@@ -152,7 +154,7 @@ func TestExtractDueDate(t *testing.T) {
 		{
 			name:           "next week",
 			input:          "Review report next week",
-			expectedDate:   func() *time.Time { d := today.AddDate(0, 0, 7); return &d }(),
+			expectedDate:   func() *time.Time { d := sharedDomain.StartOfNextWeek(today, time.Monday); return &d }(),
 			expectedOutput: "Review report ",
 		},
 		{
@@ -171,7 +173,7 @@ func TestExtractDueDate(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			date, output := extractDueDate(tc.input)
+			date, output := extractDueDate(tc.input, time.Monday)
 			if tc.expectedDate == nil {
 				assert.Nil(t, date)
 			} else {
@@ -185,6 +187,23 @@ func TestExtractDueDate(t *testing.T) {
 	}
 }
 
+func TestExtractDueDate_NextWeekHonorsWeekStart(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	mondayDate, _ := extractDueDate("Plan sprint next week", time.Monday)
+	require.NotNil(t, mondayDate)
+	assert.Equal(t, time.Monday, mondayDate.Weekday())
+	assert.True(t, mondayDate.After(today))
+	assert.False(t, mondayDate.After(today.AddDate(0, 0, 7)))
+
+	sundayDate, _ := extractDueDate("Plan sprint next week", time.Sunday)
+	require.NotNil(t, sundayDate)
+	assert.Equal(t, time.Sunday, sundayDate.Weekday())
+	assert.True(t, sundayDate.After(today))
+	assert.False(t, sundayDate.After(today.AddDate(0, 0, 7)))
+}
+
 func TestExtractDueDate_Weekdays(t *testing.T) {
 	// Test weekday parsing - dates will vary based on current day
 	now := time.Now()
@@ -205,7 +224,7 @@ func TestExtractDueDate_Weekdays(t *testing.T) {
 
 	for _, tc := range weekdays {
 		t.Run(tc.input, func(t *testing.T) {
-			date, _ := extractDueDate(tc.input)
+			date, _ := extractDueDate(tc.input, time.Monday)
 			assert.NotNil(t, date)
 
 			// Calculate expected date
@@ -219,7 +238,7 @@ func TestExtractDueDate_Weekdays(t *testing.T) {
 
 func TestExtractDueDate_ByWeekday(t *testing.T) {
 	// Test "by monday" format
-	date, output := extractDueDate("Finish report by friday")
+	date, output := extractDueDate("Finish report by friday", time.Monday)
 	assert.NotNil(t, date)
 	assert.Equal(t, time.Friday, date.Weekday())
 	assert.Contains(t, output, "Finish report")
@@ -227,7 +246,7 @@ func TestExtractDueDate_ByWeekday(t *testing.T) {
 
 func TestExtractDueDate_NextWeekday(t *testing.T) {
 	// Test "next monday" format
-	date, output := extractDueDate("Submit proposal next monday")
+	date, output := extractDueDate("Submit proposal next monday", time.Monday)
 	assert.NotNil(t, date)
 	assert.Equal(t, time.Monday, date.Weekday())
 	assert.Contains(t, output, "Submit proposal")
@@ -364,7 +383,7 @@ func TestParseNaturalLanguage(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			result := parseNaturalLanguage(tc.input)
+			result := parseNaturalLanguage(tc.input, time.Monday)
 			assert.Equal(t, tc.expectedTitle, result.title)
 			assert.Equal(t, tc.expectedPrio, result.priority)
 
@@ -382,3 +401,22 @@ func TestParseNaturalLanguage(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractTags(t *testing.T) {
+	tags, title := extractTags("Book flights #Travel #personal #travel")
+	assert.Equal(t, []string{"personal", "travel"}, tags)
+	assert.Equal(t, "Book flights", cleanTitle(title))
+
+	tags, title = extractTags("Fix #123 in issue#4")
+	assert.Empty(t, tags)
+	assert.Equal(t, "Fix #123 in issue#4", title)
+}
+
+func TestParseNaturalLanguage_TagIsNotPriority(t *testing.T) {
+	result := parseNaturalLanguage("Call the bank #urgent tomorrow", time.Monday)
+
+	assert.Equal(t, "Call the bank", result.title)
+	assert.Equal(t, []string{"urgent"}, result.tags)
+	assert.Empty(t, result.priority)
+	assert.NotNil(t, result.dueDate)
+}
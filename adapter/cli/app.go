@@ -1,9 +1,13 @@
 package cli
 
 import (
+	"context"
+	"time"
+
 	automationApp "github.com/felixgeelhaar/orbita/internal/automations/application"
 	billingDomain "github.com/felixgeelhaar/orbita/internal/billing/domain"
 	calendarApp "github.com/felixgeelhaar/orbita/internal/calendar/application"
+	calendarWorkers "github.com/felixgeelhaar/orbita/internal/calendar/application/workers"
 	calendarDomain "github.com/felixgeelhaar/orbita/internal/calendar/domain"
 	"github.com/felixgeelhaar/orbita/internal/engine/registry"
 	"github.com/felixgeelhaar/orbita/internal/engine/runtime"
@@ -25,6 +29,8 @@ import (
 	projectQueries "github.com/felixgeelhaar/orbita/internal/projects/application/queries"
 	scheduleCommands "github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
 	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	searchQueries "github.com/felixgeelhaar/orbita/internal/search/application/queries"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
 
@@ -36,25 +42,50 @@ type App struct {
 	ArchiveTaskHandler  *commands.ArchiveTaskHandler
 	StartTaskHandler    *commands.StartTaskHandler
 	UpdateTaskHandler   *commands.UpdateTaskHandler
+	ImportTaskHandler   *commands.ImportTaskHandler
+
+	// Task Checklist Handlers
+	AddChecklistItemHandler    *commands.AddChecklistItemHandler
+	ToggleChecklistItemHandler *commands.ToggleChecklistItemHandler
+
+	// Task Block Handlers
+	BlockTaskHandler   *commands.BlockTaskHandler
+	UnblockTaskHandler *commands.UnblockTaskHandler
+
+	// Task Waiting Handlers
+	WaitOnTaskHandler         *commands.WaitOnTaskHandler
+	ResolveWaitingTaskHandler *commands.ResolveWaitingTaskHandler
+
+	// Tagging Handlers
+	BulkTagTasksHandler  *commands.BulkTagTasksHandler
+	BulkTagHabitsHandler *habitCommands.BulkTagHabitsHandler
 
 	// Task Query Handlers
-	ListTasksHandler *queries.ListTasksHandler
-	GetTaskHandler   *queries.GetTaskHandler
+	ListTasksHandler    *queries.ListTasksHandler
+	GetTaskHandler      *queries.GetTaskHandler
+	GetTaskStatsHandler *queries.GetTaskStatsHandler
 
 	// Habit Command Handlers
 	CreateHabitHandler          *habitCommands.CreateHabitHandler
 	LogCompletionHandler        *habitCommands.LogCompletionHandler
+	BulkLogCompletionHandler    *habitCommands.BulkLogCompletionHandler
 	ArchiveHabitHandler         *habitCommands.ArchiveHabitHandler
+	SkipHabitHandler            *habitCommands.SkipHabitHandler
+	FreezeHabitHandler          *habitCommands.FreezeHabitHandler
+	UnfreezeHabitHandler        *habitCommands.UnfreezeHabitHandler
 	AdjustHabitFrequencyHandler *habitCommands.AdjustHabitFrequencyHandler
+	RecommendHabitTimeHandler   *habitCommands.RecommendHabitTimeHandler
 
 	// Habit Query Handlers
-	ListHabitsHandler *habitQueries.ListHabitsHandler
+	ListHabitsHandler   *habitQueries.ListHabitsHandler
+	GetDueHabitsHandler *habitQueries.GetDueHabitsHandler
 
 	// Meeting Command Handlers
 	CreateMeetingHandler        *meetingCommands.CreateMeetingHandler
 	UpdateMeetingHandler        *meetingCommands.UpdateMeetingHandler
 	ArchiveMeetingHandler       *meetingCommands.ArchiveMeetingHandler
 	MarkMeetingHeldHandler      *meetingCommands.MarkMeetingHeldHandler
+	MarkMeetingCanceledHandler  *meetingCommands.MarkMeetingCanceledHandler
 	AdjustMeetingCadenceHandler *meetingCommands.AdjustMeetingCadenceHandler
 
 	// Meeting Query Handlers
@@ -68,11 +99,21 @@ type App struct {
 	RescheduleBlockHandler *scheduleCommands.RescheduleBlockHandler
 	AutoScheduleHandler    *scheduleCommands.AutoScheduleHandler
 	AutoRescheduleHandler  *scheduleCommands.AutoRescheduleHandler
+	RescheduleDayHandler   *scheduleCommands.RescheduleDayHandler
+	StartFocusModeHandler  *scheduleCommands.StartFocusModeHandler
+	EndFocusModeHandler    *scheduleCommands.EndFocusModeHandler
+
+	// Schedule Block Dependency Handlers
+	AddBlockDependencyHandler    *scheduleCommands.AddBlockDependencyHandler
+	RemoveBlockDependencyHandler *scheduleCommands.RemoveBlockDependencyHandler
+	AddBlockAttachmentHandler    *scheduleCommands.AddBlockAttachmentHandler
 
 	// Schedule Query Handlers
 	GetScheduleHandler            *scheduleQueries.GetScheduleHandler
 	FindAvailableSlotsHandler     *scheduleQueries.FindAvailableSlotsHandler
+	GetCapacityHandler            *scheduleQueries.GetCapacityHandler
 	ListRescheduleAttemptsHandler *scheduleQueries.ListRescheduleAttemptsHandler
+	GetScheduleStatsHandler       *scheduleQueries.GetScheduleStatsHandler
 
 	// Inbox Command Handlers
 	CaptureInboxItemHandler *inboxCommands.CaptureInboxItemHandler
@@ -81,11 +122,16 @@ type App struct {
 	// Inbox Query Handlers
 	ListInboxItemsHandler *inboxQueries.ListInboxItemsHandler
 
+	// Search Query Handlers
+	SearchEntitiesHandler *searchQueries.SearchEntitiesHandler
+
 	// Calendar Sync
 	CalendarSyncer   calendarApp.Syncer
 	ProviderRegistry *calendarApp.ProviderRegistry
 	SyncCoordinator  *calendarApp.SyncCoordinator
 	CalendarRepo     calendarDomain.ConnectedCalendarRepository
+	ImportWorker     *calendarWorkers.CalendarImportWorker
+	SyncStateRepo    calendarDomain.SyncStateRepository
 
 	// Settings
 	SettingsService *identitySettings.Service
@@ -106,6 +152,7 @@ type App struct {
 	GetMarketplacePackage     *marketplaceQueries.GetPackageHandler
 	GetMarketplaceFeatured    *marketplaceQueries.GetFeaturedHandler
 	ListInstalledHandler      *marketplaceQueries.ListInstalledHandler
+	CheckUpdatesHandler       *marketplaceQueries.CheckUpdatesHandler
 
 	// Marketplace Command Handlers
 	InstallPackageHandler   *marketplaceCommands.InstallPackageHandler
@@ -141,6 +188,9 @@ type App struct {
 
 	// Current user (configured per environment)
 	CurrentUserID uuid.UUID
+
+	// First day of the week for week views and "next week"
+	WeekStartsOn time.Weekday
 }
 
 // NewApp creates a new CLI application with the provided handlers.
@@ -206,6 +256,7 @@ func NewApp(
 		ListInboxItemsHandler:         listInboxItemsHandler,
 		BillingService:                billingService,
 		CurrentUserID:                 uuid.Nil,
+		WeekStartsOn:                  sharedDomain.DefaultWeekStart,
 	}
 }
 
@@ -214,6 +265,11 @@ func (a *App) SetCurrentUserID(id uuid.UUID) {
 	a.CurrentUserID = id
 }
 
+// SetWeekStartsOn updates the first day of the week.
+func (a *App) SetWeekStartsOn(day time.Weekday) {
+	a.WeekStartsOn = day
+}
+
 // SetCalendarSyncer updates the calendar syncer.
 func (a *App) SetCalendarSyncer(syncer calendarApp.Syncer) {
 	a.CalendarSyncer = syncer
@@ -234,11 +290,164 @@ func (a *App) SetCalendarRepo(repo calendarDomain.ConnectedCalendarRepository) {
 	a.CalendarRepo = repo
 }
 
+// SetImportWorker updates the calendar import worker used for import previews.
+func (a *App) SetImportWorker(worker *calendarWorkers.CalendarImportWorker) {
+	a.ImportWorker = worker
+}
+
+// SetSyncStateRepo updates the calendar sync state repository.
+func (a *App) SetSyncStateRepo(repo calendarDomain.SyncStateRepository) {
+	a.SyncStateRepo = repo
+}
+
 // SetSettingsService updates the settings service.
 func (a *App) SetSettingsService(service *identitySettings.Service) {
 	a.SettingsService = service
 }
 
+// SetTaskStatsHandler updates the task statistics handler.
+func (a *App) SetTaskStatsHandler(handler *queries.GetTaskStatsHandler) {
+	a.GetTaskStatsHandler = handler
+}
+
+// SetRecommendHabitTimeHandler updates the habit time recommendation handler.
+func (a *App) SetRecommendHabitTimeHandler(handler *habitCommands.RecommendHabitTimeHandler) {
+	a.RecommendHabitTimeHandler = handler
+}
+
+// SetFocusModeHandlers updates the focus mode handlers.
+func (a *App) SetFocusModeHandlers(start *scheduleCommands.StartFocusModeHandler, end *scheduleCommands.EndFocusModeHandler) {
+	a.StartFocusModeHandler = start
+	a.EndFocusModeHandler = end
+}
+
+// SetBlockDependencyHandlers updates the block dependency handlers.
+func (a *App) SetBlockDependencyHandlers(add *scheduleCommands.AddBlockDependencyHandler, remove *scheduleCommands.RemoveBlockDependencyHandler) {
+	a.AddBlockDependencyHandler = add
+	a.RemoveBlockDependencyHandler = remove
+}
+
+// SetMarkMeetingCanceledHandler updates the meeting cancellation handler.
+func (a *App) SetMarkMeetingCanceledHandler(handler *meetingCommands.MarkMeetingCanceledHandler) {
+	a.MarkMeetingCanceledHandler = handler
+}
+
+// SetBlockAttachmentHandler updates the block attachment handler.
+func (a *App) SetBlockAttachmentHandler(handler *scheduleCommands.AddBlockAttachmentHandler) {
+	a.AddBlockAttachmentHandler = handler
+}
+
+// SetChecklistHandlers updates the task checklist handlers.
+func (a *App) SetChecklistHandlers(add *commands.AddChecklistItemHandler, toggle *commands.ToggleChecklistItemHandler) {
+	a.AddChecklistItemHandler = add
+	a.ToggleChecklistItemHandler = toggle
+}
+
+// SetBlockTaskHandlers updates the handlers that block and unblock tasks.
+func (a *App) SetBlockTaskHandlers(block *commands.BlockTaskHandler, unblock *commands.UnblockTaskHandler) {
+	a.BlockTaskHandler = block
+	a.UnblockTaskHandler = unblock
+}
+
+// SetBulkLogCompletionHandler updates the handler that logs several habit
+// completions at once.
+func (a *App) SetBulkLogCompletionHandler(handler *habitCommands.BulkLogCompletionHandler) {
+	a.BulkLogCompletionHandler = handler
+}
+
+// SetHabitPauseHandlers updates the handlers that skip a habit for a day and
+// freeze and unfreeze it.
+func (a *App) SetHabitPauseHandlers(skip *habitCommands.SkipHabitHandler, freeze *habitCommands.FreezeHabitHandler, unfreeze *habitCommands.UnfreezeHabitHandler) {
+	a.SkipHabitHandler = skip
+	a.FreezeHabitHandler = freeze
+	a.UnfreezeHabitHandler = unfreeze
+}
+
+// SetWaitingTaskHandlers updates the handlers that mark tasks as waiting on
+// someone and resolve them.
+func (a *App) SetWaitingTaskHandlers(wait *commands.WaitOnTaskHandler, resolve *commands.ResolveWaitingTaskHandler) {
+	a.WaitOnTaskHandler = wait
+	a.ResolveWaitingTaskHandler = resolve
+}
+
+// SetTagHandlers updates the bulk tagging handlers for tasks and habits.
+func (a *App) SetTagHandlers(tasks *commands.BulkTagTasksHandler, habits *habitCommands.BulkTagHabitsHandler) {
+	a.BulkTagTasksHandler = tasks
+	a.BulkTagHabitsHandler = habits
+}
+
+// SetImportTaskHandler updates the handler that imports tasks from other
+// apps without duplicating ones imported before.
+func (a *App) SetImportTaskHandler(handler *commands.ImportTaskHandler) {
+	a.ImportTaskHandler = handler
+}
+
+// SetScheduleStatsHandler updates the schedule statistics handler.
+func (a *App) SetScheduleStatsHandler(handler *scheduleQueries.GetScheduleStatsHandler) {
+	a.GetScheduleStatsHandler = handler
+}
+
+// SetCapacityHandler updates the schedule capacity handler.
+func (a *App) SetCapacityHandler(handler *scheduleQueries.GetCapacityHandler) {
+	a.GetCapacityHandler = handler
+}
+
+// SetDueHabitsHandler updates the due habits handler.
+func (a *App) SetDueHabitsHandler(handler *habitQueries.GetDueHabitsHandler) {
+	a.GetDueHabitsHandler = handler
+}
+
+// SetSearchEntitiesHandler updates the full-text search handler.
+func (a *App) SetSearchEntitiesHandler(handler *searchQueries.SearchEntitiesHandler) {
+	a.SearchEntitiesHandler = handler
+}
+
+// DueHabits returns the habits that still need doing today.
+// Without a due habits handler it falls back to the due-today habit list,
+// which does not account for skips, freezes or weekly targets.
+func (a *App) DueHabits(ctx context.Context) ([]habitQueries.HabitDTO, error) {
+	if a.GetDueHabitsHandler != nil {
+		return a.GetDueHabitsHandler.Handle(ctx, habitQueries.GetDueHabitsQuery{UserID: a.CurrentUserID})
+	}
+	if a.ListHabitsHandler == nil {
+		return nil, nil
+	}
+
+	habits, err := a.ListHabitsHandler.Handle(ctx, habitQueries.ListHabitsQuery{
+		UserID:       a.CurrentUserID,
+		OnlyDueToday: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]habitQueries.HabitDTO, 0, len(habits))
+	for _, habit := range habits {
+		if !habit.CompletedToday {
+			pending = append(pending, habit)
+		}
+	}
+	return pending, nil
+}
+
+// PlanDefaults returns how the current user plans a day when no options are
+// given. Without a settings service, or when they cannot be read, it falls
+// back to the built-in defaults.
+func (a *App) PlanDefaults(ctx context.Context) identitySettings.PlanDefaults {
+	if a.SettingsService == nil {
+		return identitySettings.DefaultPlanDefaults()
+	}
+	defaults, err := a.SettingsService.PlanDefaults(ctx, a.CurrentUserID)
+	if err != nil {
+		return identitySettings.DefaultPlanDefaults()
+	}
+	return defaults
+}
+
+// SetRescheduleDayHandler updates the reschedule day handler.
+func (a *App) SetRescheduleDayHandler(handler *scheduleCommands.RescheduleDayHandler) {
+	a.RescheduleDayHandler = handler
+}
+
 // SetBillingService updates the billing service.
 func (a *App) SetBillingService(service billingDomain.BillingService) {
 	a.BillingService = service
@@ -282,6 +491,29 @@ func (a *App) SetMarketplaceHandlers(
 	a.GetMarketplaceFeatured = featured
 }
 
+// SetListInstalledHandler updates the installed marketplace packages handler.
+func (a *App) SetListInstalledHandler(handler *marketplaceQueries.ListInstalledHandler) {
+	a.ListInstalledHandler = handler
+}
+
+// SetMarketplaceUpdateHandlers updates the handlers that check installed
+// packages for updates and apply them.
+func (a *App) SetMarketplaceUpdateHandlers(check *marketplaceQueries.CheckUpdatesHandler, update *marketplaceCommands.UpdatePackageHandler) {
+	a.CheckUpdatesHandler = check
+	a.UpdatePackageHandler = update
+}
+
+// SetMarketplaceAuthHandlers updates the marketplace login, logout and whoami handlers.
+func (a *App) SetMarketplaceAuthHandlers(
+	login *marketplaceCommands.LoginHandler,
+	logout *marketplaceCommands.LogoutHandler,
+	whoami *marketplaceCommands.WhoAmIHandler,
+) {
+	a.LoginHandler = login
+	a.LogoutHandler = logout
+	a.WhoAmIHandler = whoami
+}
+
 // SetAutomationService updates the automation service.
 func (a *App) SetAutomationService(service *automationApp.Service) {
 	a.AutomationService = service
@@ -305,6 +537,15 @@ func GetApp() *App {
 	return app
 }
 
+// WeekStartsOn returns the configured first day of the week, or the default
+// when no application is configured.
+func WeekStartsOn() time.Weekday {
+	if app == nil {
+		return sharedDomain.DefaultWeekStart
+	}
+	return app.WeekStartsOn
+}
+
 // SetProjectHandlers updates all project handlers.
 func (a *App) SetProjectHandlers(
 	createProject *projectCommands.CreateProjectHandler,
@@ -18,7 +18,10 @@ Examples:
   orbita automation list                  # List all rules
   orbita automation create "Daily report" # Create a new rule
   orbita automation enable <id>           # Enable a rule
-  orbita automation executions            # View execution history`,
+  orbita automation executions            # View execution history
+  orbita automation test <id>             # Dry-run a rule
+  orbita automation export --all          # Share rules as JSON
+  orbita automation import rules.json     # Import shared rules`,
 }
 
 func init() {
@@ -29,4 +32,7 @@ func init() {
 	Cmd.AddCommand(disableCmd)
 	Cmd.AddCommand(deleteCmd)
 	Cmd.AddCommand(executionsCmd)
+	Cmd.AddCommand(testCmd)
+	Cmd.AddCommand(exportCmd)
+	Cmd.AddCommand(importCmd)
 }
@@ -1,13 +1,27 @@
 package automation
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
+	internalApp "github.com/felixgeelhaar/orbita/internal/app"
+	"github.com/felixgeelhaar/orbita/internal/automations/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/automations/application/queries"
 	"github.com/felixgeelhaar/orbita/internal/automations/domain"
+	"github.com/felixgeelhaar/orbita/internal/engine/builtin"
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	"github.com/felixgeelhaar/orbita/pkg/config"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func resetFlags() {
@@ -38,6 +52,14 @@ func resetFlags() {
 	execRuleID = ""
 	execStatus = ""
 	execLimit = 20
+
+	// Test flags
+	testEventType = ""
+	testEventData = nil
+
+	// Export flags
+	exportAll = false
+	exportOutput = ""
 }
 
 // Test commands when app is nil or AutomationService is nil
@@ -345,3 +367,189 @@ func TestExecutionsCmdFlags(t *testing.T) {
 	assert.NotNil(t, executionsCmd.Flags().Lookup("status"))
 	assert.NotNil(t, executionsCmd.Flags().Lookup("limit"))
 }
+
+func TestTestCmd_NoApp(t *testing.T) {
+	resetFlags()
+	cli.SetApp(nil)
+
+	testCmd.SetContext(context.Background())
+
+	err := testCmd.RunE(testCmd, []string{})
+	assert.NoError(t, err)
+}
+
+func TestTestCmd_NoEngineExecutor(t *testing.T) {
+	resetFlags()
+	app := &cli.App{CurrentUserID: uuid.New()}
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	testCmd.SetContext(context.Background())
+
+	err := testCmd.RunE(testCmd, []string{})
+	assert.NoError(t, err)
+}
+
+func TestParseEventData(t *testing.T) {
+	data, err := parseEventData([]string{"priority=3", "urgent=true", "project = Website"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"priority": 3.0, "urgent": true, "project": "Website"}, data)
+
+	_, err = parseEventData([]string{"priority"})
+	assert.Error(t, err)
+}
+
+func TestRenderEvaluation_ShowsSkippedReasonsAndDuration(t *testing.T) {
+	userID := uuid.New()
+	archive := types.AutomationRule{
+		ID:         uuid.New(),
+		Name:       "Archive done tasks",
+		Enabled:    true,
+		Trigger:    types.RuleTrigger{Type: "event", EventTypes: []string{"task.completed"}},
+		Conditions: []types.RuleCondition{{Field: "project", Operator: types.OperatorEquals, Value: "Website"}},
+		Actions:    []types.RuleAction{{Type: "task.archive"}},
+	}
+	followUp := types.AutomationRule{
+		ID:      uuid.New(),
+		Name:    "Follow up",
+		Enabled: true,
+		Trigger: types.RuleTrigger{Type: "event", EventTypes: []string{"task.completed"}},
+		Actions: []types.RuleAction{{Type: "notify"}},
+	}
+	disabled := types.AutomationRule{ID: uuid.New(), Name: "Old rule"}
+
+	engine := builtin.NewDefaultAutomationEngine()
+	output, err := engine.Evaluate(sdk.NewExecutionContext(context.Background(), userID, "test"), types.AutomationInput{
+		Event: types.AutomationEvent{ID: uuid.New(), Type: "task.completed", Data: map[string]any{"project": "Finance"}},
+		Rules: []types.AutomationRule{archive, followUp, disabled},
+	})
+	require.NoError(t, err)
+	output.EvaluationDuration = 1500 * time.Microsecond
+
+	out := new(bytes.Buffer)
+	renderEvaluation(out, "task.completed", 3, output)
+	rendered := out.String()
+
+	assert.Contains(t, rendered, "Evaluated 3 rule(s) for task.completed in 1.5ms")
+	assert.Contains(t, rendered, "✓ Follow up")
+	assert.Contains(t, rendered, "Action: notify")
+	assert.Contains(t, rendered, "Skipped (2)")
+	assert.Contains(t, rendered, "○ Archive done tasks")
+	assert.Contains(t, rendered, "Reason: Condition not met")
+	assert.Contains(t, rendered, "Failed condition: project")
+	assert.Contains(t, rendered, "Reason: Rule is disabled")
+}
+
+func TestRenderExecution_ShowsSkipReasonAndDuration(t *testing.T) {
+	exec := domain.NewRuleExecution(uuid.New(), uuid.New(), "task.completed", nil)
+	exec.Skip("Condition not met")
+	durationMs := 12
+	exec.DurationMs = &durationMs
+
+	out := new(bytes.Buffer)
+	renderExecution(out, exec)
+	rendered := out.String()
+
+	assert.Contains(t, rendered, "Duration: 12ms")
+	assert.Contains(t, rendered, "Trigger: task.completed")
+	assert.Contains(t, rendered, "Skip reason: Condition not met")
+}
+
+func TestExportCmd_NoApp(t *testing.T) {
+	resetFlags()
+	cli.SetApp(nil)
+
+	exportCmd.SetContext(context.Background())
+
+	err := exportCmd.RunE(exportCmd, []string{})
+	assert.NoError(t, err)
+}
+
+func TestImportCmd_NoApp(t *testing.T) {
+	resetFlags()
+	cli.SetApp(nil)
+
+	importCmd.SetContext(context.Background())
+
+	err := importCmd.RunE(importCmd, []string{"rules.json"})
+	assert.NoError(t, err)
+}
+
+// newLocalContainer creates a local-mode container with its own SQLite
+// database, as on a user's own machine.
+func newLocalContainer(t *testing.T, userID uuid.UUID) *internalApp.Container {
+	t.Helper()
+
+	cfg := &config.Config{
+		AppEnv:         "test",
+		LocalMode:      true,
+		DatabaseDriver: "sqlite",
+		SQLitePath:     filepath.Join(t.TempDir(), "test.db"),
+		LogLevel:       "error",
+		UserID:         userID.String(),
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	container, err := internalApp.NewLocalContainer(context.Background(), cfg, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { container.Close() })
+	return container
+}
+
+func TestExportImportCmds_RoundTrip(t *testing.T) {
+	resetFlags()
+	defer resetFlags()
+	ctx := context.Background()
+
+	owner := &cli.App{CurrentUserID: uuid.New()}
+	owner.AutomationService = newLocalContainer(t, owner.CurrentUserID).AutomationService
+	teammate := &cli.App{CurrentUserID: uuid.New()}
+	teammate.AutomationService = newLocalContainer(t, teammate.CurrentUserID).AutomationService
+
+	cli.SetApp(owner)
+	defer cli.SetApp(nil)
+
+	rule, err := owner.AutomationService.CreateRule(ctx, commands.CreateRuleCommand{
+		UserID:        owner.CurrentUserID,
+		Name:          "Notify on completion",
+		TriggerType:   domain.TriggerTypeEvent,
+		TriggerConfig: map[string]any{"event_types": []any{"task.completed"}},
+		Actions:       []types.RuleAction{{Type: "notification.send", Parameters: map[string]any{"message": "Task done"}}},
+	})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	exportCmd.SetOut(&out)
+	exportCmd.SetContext(ctx)
+	err = exportCmd.RunE(exportCmd, nil)
+	assert.ErrorContains(t, err, "pass rule IDs or --all")
+
+	path := filepath.Join(t.TempDir(), "rules.json")
+	exportOutput = path
+	require.NoError(t, exportCmd.RunE(exportCmd, []string{rule.ID.String()}))
+	assert.Contains(t, out.String(), "Exported 1 rule(s)")
+
+	cli.SetApp(teammate)
+	out.Reset()
+	importCmd.SetOut(&out)
+	importCmd.SetContext(ctx)
+	require.NoError(t, importCmd.RunE(importCmd, []string{path}))
+	assert.Contains(t, out.String(), "Imported 1 rule(s)")
+	assert.Contains(t, out.String(), "(was "+rule.ID.String()+")")
+
+	result, err := teammate.AutomationService.ListRules(ctx, queries.ListRulesQuery{UserID: teammate.CurrentUserID})
+	require.NoError(t, err)
+	require.Len(t, result.Rules, 1)
+	assert.NotEqual(t, rule.ID, result.Rules[0].ID)
+	assert.Equal(t, "Notify on completion", result.Rules[0].Name)
+	assert.Equal(t, rule.Actions, result.Rules[0].Actions)
+
+	// A rule the engine rejects is reported and nothing is imported.
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"version":1,"rules":[{"name":"Bad","enabled":true,"trigger_type":"event","trigger_config":{"event_types":["task.created"]},"actions":[{"type":"email.teleport"}]}]}`), 0600))
+	err = importCmd.RunE(importCmd, []string{invalid})
+	assert.ErrorContains(t, err, "email.teleport")
+
+	result, err = teammate.AutomationService.ListRules(ctx, queries.ListRulesQuery{UserID: teammate.CurrentUserID})
+	require.NoError(t, err)
+	assert.Len(t, result.Rules, 1)
+}
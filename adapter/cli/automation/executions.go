@@ -2,6 +2,7 @@ package automation
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
@@ -64,57 +65,64 @@ Examples:
 			return nil
 		}
 
-		fmt.Printf("Rule Executions (%d total)\n", result.Total)
-		fmt.Println(strings.Repeat("-", 80))
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Rule Executions (%d total)\n", result.Total)
+		fmt.Fprintln(out, strings.Repeat("-", 80))
 
 		for _, exec := range result.Executions {
-			statusIcon := getStatusIcon(exec.Status)
+			renderExecution(out, exec)
+		}
 
-			fmt.Printf("%s %-36s  %s\n", statusIcon, exec.ID, exec.StartedAt.Format("2006-01-02 15:04:05"))
-			fmt.Printf("    Rule: %s\n", exec.RuleID)
-			fmt.Printf("    Status: %-10s", exec.Status)
+		fmt.Fprintln(out, strings.Repeat("-", 80))
+		fmt.Fprintf(out, "Showing %d of %d executions\n", len(result.Executions), result.Total)
 
-			if exec.DurationMs != nil {
-				fmt.Printf("  Duration: %dms", *exec.DurationMs)
-			}
-			fmt.Println()
+		return nil
+	},
+}
 
-			if exec.TriggerEventType != "" {
-				fmt.Printf("    Trigger: %s\n", exec.TriggerEventType)
-			}
+// renderExecution prints one execution record, including how long it took
+// and why it was skipped or failed.
+func renderExecution(w io.Writer, exec *domain.RuleExecution) {
+	statusIcon := getStatusIcon(exec.Status)
 
-			if exec.Status == domain.ExecutionStatusSkipped && exec.SkipReason != "" {
-				fmt.Printf("    Skip reason: %s\n", exec.SkipReason)
-			}
+	fmt.Fprintf(w, "%s %-36s  %s\n", statusIcon, exec.ID, exec.StartedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "    Rule: %s\n", exec.RuleID)
+	fmt.Fprintf(w, "    Status: %-10s", exec.Status)
 
-			if exec.Status == domain.ExecutionStatusFailed && exec.ErrorMessage != "" {
-				fmt.Printf("    Error: %s\n", exec.ErrorMessage)
-			}
+	if exec.DurationMs != nil {
+		fmt.Fprintf(w, "  Duration: %dms", *exec.DurationMs)
+	}
+	fmt.Fprintln(w)
 
-			if len(exec.ActionsExecuted) > 0 {
-				fmt.Printf("    Actions: %d executed\n", len(exec.ActionsExecuted))
-				for _, action := range exec.ActionsExecuted {
-					actionIcon := "✓"
-					if action.Status == "failed" {
-						actionIcon = "✗"
-					} else if action.Status == "skipped" {
-						actionIcon = "○"
-					}
-					fmt.Printf("      %s %s\n", actionIcon, action.Action)
-					if action.Error != "" {
-						fmt.Printf("        Error: %s\n", action.Error)
-					}
-				}
-			}
+	if exec.TriggerEventType != "" {
+		fmt.Fprintf(w, "    Trigger: %s\n", exec.TriggerEventType)
+	}
 
-			fmt.Println()
-		}
+	if exec.SkipReason != "" {
+		fmt.Fprintf(w, "    Skip reason: %s\n", exec.SkipReason)
+	}
 
-		fmt.Println(strings.Repeat("-", 80))
-		fmt.Printf("Showing %d of %d executions\n", len(result.Executions), result.Total)
+	if exec.Status == domain.ExecutionStatusFailed && exec.ErrorMessage != "" {
+		fmt.Fprintf(w, "    Error: %s\n", exec.ErrorMessage)
+	}
 
-		return nil
-	},
+	if len(exec.ActionsExecuted) > 0 {
+		fmt.Fprintf(w, "    Actions: %d executed\n", len(exec.ActionsExecuted))
+		for _, action := range exec.ActionsExecuted {
+			actionIcon := "✓"
+			if action.Status == "failed" {
+				actionIcon = "✗"
+			} else if action.Status == "skipped" {
+				actionIcon = "○"
+			}
+			fmt.Fprintf(w, "      %s %s\n", actionIcon, action.Action)
+			if action.Error != "" {
+				fmt.Fprintf(w, "        Error: %s\n", action.Error)
+			}
+		}
+	}
+
+	fmt.Fprintln(w)
 }
 
 func getStatusIcon(status domain.ExecutionStatus) string {
@@ -0,0 +1,182 @@
+package automation
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/automations/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testEventType string
+	testEventData []string
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test [rule-id]",
+	Short: "Dry-run rules against a sample event",
+	Long: `Evaluate automation rules against a sample event without running any
+actions. The output lists which rules would trigger, why the others were
+skipped and how long evaluation took.
+
+Without a rule ID, all of your rules are evaluated. With a rule ID, the event
+type defaults to the first event type the rule listens for.
+
+Examples:
+  orbita automation test --event task.created --data priority=high
+  orbita automation test abc123...
+  orbita automation test abc123... --event task.completed --data project=Website`,
+	Aliases: []string{"dry-run"},
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.AutomationService == nil {
+			fmt.Println("Automation management requires database connection.")
+			fmt.Println("Start services with: docker-compose up -d")
+			return nil
+		}
+		if app.EngineExecutor == nil {
+			fmt.Println("Automation testing requires the engine runtime.")
+			return nil
+		}
+
+		data, err := parseEventData(testEventData)
+		if err != nil {
+			return err
+		}
+
+		var rules []types.AutomationRule
+		eventType := testEventType
+		if len(args) == 1 {
+			ruleID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid rule ID: %w", err)
+			}
+			rule, err := app.AutomationService.GetRule(cmd.Context(), queries.GetRuleQuery{
+				RuleID: ruleID,
+				UserID: app.CurrentUserID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get rule: %w", err)
+			}
+			engineRule := rule.ToEngineRule()
+			if eventType == "" && len(engineRule.Trigger.EventTypes) > 0 {
+				eventType = engineRule.Trigger.EventTypes[0]
+			}
+			rules = append(rules, engineRule)
+		} else {
+			result, err := app.AutomationService.ListRules(cmd.Context(), queries.ListRulesQuery{
+				UserID: app.CurrentUserID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list rules: %w", err)
+			}
+			for _, rule := range result.Rules {
+				rules = append(rules, rule.ToEngineRule())
+			}
+		}
+
+		if eventType == "" {
+			return fmt.Errorf("--event is required")
+		}
+		if len(rules) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No automation rules to test.")
+			return nil
+		}
+
+		now := time.Now()
+		input := types.AutomationInput{
+			Event: types.AutomationEvent{
+				ID:        uuid.New(),
+				Type:      eventType,
+				Timestamp: now,
+				Data:      data,
+			},
+			Rules: rules,
+			Context: types.AutomationContext{
+				UserID: app.CurrentUserID,
+				Now:    now,
+			},
+		}
+
+		output, err := app.EngineExecutor.ExecuteAutomation(cmd.Context(), "", app.CurrentUserID, input)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate rules: %w", err)
+		}
+
+		renderEvaluation(cmd.OutOrStdout(), eventType, len(rules), output)
+		return nil
+	},
+}
+
+// parseEventData turns key=value pairs into event data. Numbers and booleans
+// keep their type so conditions can compare them.
+func parseEventData(pairs []string) (map[string]any, error) {
+	data := make(map[string]any, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid event data %q: expected key=value", pair)
+		}
+		value = strings.TrimSpace(value)
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			data[key] = n
+		} else if b, err := strconv.ParseBool(value); err == nil {
+			data[key] = b
+		} else {
+			data[key] = value
+		}
+	}
+	return data, nil
+}
+
+// renderEvaluation prints the outcome of a rule evaluation: the rules that
+// would trigger with their actions, the rules that were skipped and why, and
+// how long evaluation took.
+func renderEvaluation(w io.Writer, eventType string, evaluated int, output *types.AutomationOutput) {
+	fmt.Fprintf(w, "Evaluated %d rule(s) for %s in %s\n", evaluated, eventType, output.EvaluationDuration)
+	fmt.Fprintln(w, strings.Repeat("-", 60))
+
+	if len(output.TriggeredRules) == 0 {
+		fmt.Fprintln(w, "No rules would trigger.")
+	}
+	for _, triggered := range output.TriggeredRules {
+		fmt.Fprintf(w, "✓ %s (%s)\n", triggered.RuleName, triggered.RuleID)
+		for _, matched := range triggered.MatchedConditions {
+			fmt.Fprintf(w, "    Matched: %s\n", matched)
+		}
+		for _, action := range output.PendingActions {
+			if action.RuleID == triggered.RuleID {
+				fmt.Fprintf(w, "    Action: %s\n", action.Type)
+			}
+		}
+	}
+
+	if len(output.SkippedRules) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Skipped (%d)\n", len(output.SkippedRules))
+		for _, skipped := range output.SkippedRules {
+			fmt.Fprintf(w, "○ %s (%s)\n", skipped.RuleName, skipped.RuleID)
+			fmt.Fprintf(w, "    Reason: %s\n", skipped.Reason)
+			if skipped.FailedCondition != "" {
+				fmt.Fprintf(w, "    Failed condition: %s\n", skipped.FailedCondition)
+			}
+		}
+	}
+
+	fmt.Fprintln(w, strings.Repeat("-", 60))
+	fmt.Fprintln(w, "Dry run: no actions were executed.")
+}
+
+func init() {
+	testCmd.Flags().StringVarP(&testEventType, "event", "e", "", "event type to simulate (e.g. task.created)")
+	testCmd.Flags().StringArrayVarP(&testEventData, "data", "d", nil, "event data as key=value (repeatable)")
+}
@@ -0,0 +1,148 @@
+package automation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/automations/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/automations/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/automations/domain"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportAll    bool
+	exportOutput string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [rule-id...]",
+	Short: "Export automation rules as shareable JSON",
+	Long: `Export automation rules as portable JSON that teammates can load with
+'orbita automation import'. Pass rule IDs to export those rules, or --all
+to export every rule.
+
+Webhook secrets are not exported: webhook actions lose their secret
+parameters and credential headers such as Authorization, which have to be
+filled in again after importing.
+
+Examples:
+  orbita automation export --all > rules.json
+  orbita automation export abc123... def456... --output rules.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.AutomationService == nil {
+			fmt.Println("Automation management requires database connection.")
+			fmt.Println("Start services with: docker-compose up -d")
+			return nil
+		}
+		if exportAll == (len(args) > 0) {
+			return errors.New("pass rule IDs or --all")
+		}
+
+		ruleIDs := make([]uuid.UUID, 0, len(args))
+		for _, arg := range args {
+			ruleID, err := uuid.Parse(arg)
+			if err != nil {
+				return fmt.Errorf("invalid rule ID %q: %w", arg, err)
+			}
+			ruleIDs = append(ruleIDs, ruleID)
+		}
+
+		export, err := app.AutomationService.ExportRules(cmd.Context(), queries.ExportRulesQuery{
+			UserID:  app.CurrentUserID,
+			RuleIDs: ruleIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export rules: %w", err)
+		}
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal rules: %w", err)
+		}
+		data = append(data, '\n')
+
+		if exportOutput == "" {
+			_, err = cmd.OutOrStdout().Write(data)
+			return err
+		}
+		if err := os.WriteFile(exportOutput, data, 0600); err != nil {
+			return fmt.Errorf("failed to write rules: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Exported %d rule(s) to %s\n", len(export.Rules), exportOutput)
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import automation rules from a JSON export",
+	Long: `Import rules written by 'orbita automation export'. Use - to read from
+standard input.
+
+Every rule is validated by the automation engine before anything is created:
+if any rule is invalid, no rule is imported. Imported rules get new IDs, and
+references between the imported rules are updated to match.
+
+Examples:
+  orbita automation import rules.json
+  cat rules.json | orbita automation import -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.AutomationService == nil {
+			fmt.Println("Automation management requires database connection.")
+			fmt.Println("Start services with: docker-compose up -d")
+			return nil
+		}
+
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read rules: %w", err)
+		}
+
+		var export domain.RuleExport
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&export); err != nil {
+			return fmt.Errorf("invalid rules file: %w", err)
+		}
+
+		result, err := app.AutomationService.ImportRules(cmd.Context(), commands.ImportRulesCommand{
+			UserID: app.CurrentUserID,
+			Export: export,
+		})
+		if err != nil {
+			return fmt.Errorf("rules not imported: %w", err)
+		}
+
+		w := cmd.OutOrStdout()
+		fmt.Fprintf(w, "Imported %d rule(s)\n", len(result.Rules))
+		for i, rule := range result.Rules {
+			exportedID := export.Rules[i].ID
+			if exportedID == uuid.Nil {
+				fmt.Fprintf(w, "  %s  %s\n", rule.ID, rule.Name)
+				continue
+			}
+			fmt.Fprintf(w, "  %s  %s (was %s)\n", rule.ID, rule.Name, exportedID)
+		}
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportAll, "all", false, "export all rules")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "output file (default: stdout)")
+}
@@ -33,9 +33,16 @@ var statusCmd = &cobra.Command{
 		}
 
 		fmt.Fprintf(cmd.OutOrStdout(), "Subscription: %s\n", statusLine)
+		if subscription.TrialEndsAt != nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "Trial ends: %s\n", subscription.TrialEndsAt.Local().Format(time.RFC1123))
+		}
 		if subscription.CurrentPeriodEnd != nil {
 			fmt.Fprintf(cmd.OutOrStdout(), "Renews: %s\n", subscription.CurrentPeriodEnd.Local().Format(time.RFC1123))
 		}
+		if subscription.HasScheduledDowngrade() {
+			fmt.Fprintf(cmd.OutOrStdout(), "Scheduled downgrade: %s at %s\n",
+				subscription.PendingPlan, subscription.CurrentPeriodEnd.Local().Format(time.RFC1123))
+		}
 		if subscription.StripeCustomerID != "" {
 			fmt.Fprintf(cmd.OutOrStdout(), "Stripe customer: %s\n", subscription.StripeCustomerID)
 		}
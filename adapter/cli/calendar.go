@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	calendarApp "github.com/felixgeelhaar/orbita/internal/calendar/application"
+	calendarDomain "github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	googleCalendar "github.com/felixgeelhaar/orbita/internal/calendar/infrastructure/google"
+	"github.com/felixgeelhaar/orbita/internal/shared/application/capability"
+	"github.com/spf13/cobra"
+)
+
+var (
+	resyncFull       bool
+	resyncDays       int
+	resyncCalendarID string
+	resyncInterval   time.Duration
+	resyncRestart    bool
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Manage the external calendar sync",
+}
+
+var calendarResyncCmd = &cobra.Command{
+	Use:   "resync",
+	Short: "Clear the sync state and re-push all blocks",
+	Long: `Clear the calendar sync state and re-push every block in the window.
+
+Blocks are pushed one at a time, pausing --interval between pushes to stay
+within provider rate limits. Progress is saved after each block, so an
+interrupted resync resumes where it stopped. Use --restart to start over.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !resyncFull {
+			return errors.New("resync re-pushes every block; pass --full to confirm")
+		}
+		if err := RequireCapability(capability.CalendarSync); err != nil {
+			return err
+		}
+		app := GetApp()
+		if app == nil || app.GetScheduleHandler == nil || app.SyncStateRepo == nil {
+			return errors.New("resync requires database connection")
+		}
+		if app.CalendarSyncer == nil {
+			return FeatureUnavailable(capability.CalendarSync)
+		}
+
+		calendarID := resyncCalendarID
+		if calendarID == "" && app.SettingsService != nil {
+			if storedID, err := app.SettingsService.GetCalendarID(cmd.Context(), app.CurrentUserID); err == nil && storedID != "" {
+				lister, _ := app.CalendarSyncer.(calendarApp.CalendarLister)
+				resolved := calendarApp.ResolveDefaultCalendar(cmd.Context(), lister, app.CurrentUserID, storedID)
+				if resolved.Warning != "" {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", resolved.Warning)
+				}
+				calendarID = resolved.CalendarID
+			}
+		}
+		if calendarID == "" {
+			calendarID = calendarApp.PrimaryCalendarID
+		}
+
+		syncer := app.CalendarSyncer
+		provider := ""
+		if googleSyncer, ok := syncer.(*googleCalendar.Syncer); ok {
+			// Blocks are pushed one at a time, so deleting events missing
+			// from each push would remove the rest of the schedule.
+			syncer = googleSyncer.WithCalendarID(calendarID).WithDeleteMissing(false)
+			provider = string(calendarDomain.ProviderGoogle)
+		}
+
+		blocks, err := GatherScheduleBlocks(cmd.Context(), app, resyncDays)
+		if err != nil {
+			return err
+		}
+
+		service := calendarApp.NewResyncService(syncer, app.SyncStateRepo).WithInterval(resyncInterval)
+		summary, err := service.Resync(cmd.Context(), calendarApp.ResyncRequest{
+			UserID:     app.CurrentUserID,
+			CalendarID: calendarID,
+			Provider:   provider,
+			Blocks:     blocks,
+			Restart:    resyncRestart,
+		})
+		if summary != nil {
+			printResyncSummary(cmd, calendarID, summary)
+		}
+		if err != nil {
+			return fmt.Errorf("%w (run the command again to resume)", err)
+		}
+		return nil
+	},
+}
+
+func printResyncSummary(cmd *cobra.Command, calendarID string, summary *calendarApp.ResyncSummary) {
+	out := cmd.OutOrStdout()
+	if summary.Resumed {
+		fmt.Fprintf(out, "Resumed resync of %s started %s\n", calendarID, summary.StartedAt.Local().Format("2006-01-02 15:04"))
+	} else {
+		fmt.Fprintf(out, "Resync of %s\n", calendarID)
+	}
+	fmt.Fprintf(out, "Blocks: total=%d pushed=%d skipped=%d failed=%d\n",
+		summary.Total, summary.Pushed, summary.AlreadyPushed, summary.Failed)
+	fmt.Fprintf(out, "Events: created=%d updated=%d\n", summary.Created, summary.Updated)
+	switch {
+	case summary.Complete:
+		fmt.Fprintln(out, "Resync complete.")
+	case summary.Failed > 0:
+		fmt.Fprintln(out, "Resync incomplete; run the command again to retry failed blocks.")
+	}
+}
+
+func init() {
+	calendarResyncCmd.Flags().BoolVar(&resyncFull, "full", false, "clear the sync state and re-push every block")
+	calendarResyncCmd.Flags().IntVarP(&resyncDays, "days", "d", 7, "number of days to resync")
+	calendarResyncCmd.Flags().StringVar(&resyncCalendarID, "calendar", "", "calendar ID to resync (default: configured calendar or primary)")
+	calendarResyncCmd.Flags().DurationVar(&resyncInterval, "interval", calendarApp.DefaultResyncInterval, "pause between pushes")
+	calendarResyncCmd.Flags().BoolVar(&resyncRestart, "restart", false, "discard the progress of an interrupted resync and start over")
+	calendarCmd.AddCommand(calendarResyncCmd)
+	rootCmd.AddCommand(calendarCmd)
+}
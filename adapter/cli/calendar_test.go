@@ -0,0 +1,18 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarResyncCmd_RequiresFull(t *testing.T) {
+	prev := resyncFull
+	defer func() { resyncFull = prev }()
+	resyncFull = false
+
+	calendarResyncCmd.SetContext(context.Background())
+	err := calendarResyncCmd.RunE(calendarResyncCmd, nil)
+	assert.EqualError(t, err, "resync re-pushes every block; pass --full to confirm")
+}
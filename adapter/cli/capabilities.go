@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/application/capability"
+)
+
+// capabilities are the optional features the running application provides.
+var capabilities capability.Set
+
+// SetCapabilities sets the optional features the running application provides.
+func SetCapabilities(set capability.Set) {
+	capabilities = set
+}
+
+// RequireCapability returns a "feature unavailable because ..." error when
+// the named capability is reported as missing.
+func RequireCapability(name string) error {
+	return capabilities.Require(name)
+}
+
+// FeatureUnavailable explains why a command cannot use the named capability.
+// It falls back to a generic "not configured" error when no capabilities
+// were reported.
+func FeatureUnavailable(name string) error {
+	if err := capabilities.Require(name); err != nil {
+		return err
+	}
+	return fmt.Errorf("%s not configured", capability.Label(name))
+}
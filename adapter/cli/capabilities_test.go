@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/application/capability"
+	"github.com/felixgeelhaar/orbita/internal/shared/application/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities(t *testing.T) {
+	prev := capabilities
+	defer SetCapabilities(prev)
+
+	t.Run("unreported capabilities fall back to not configured", func(t *testing.T) {
+		SetCapabilities(nil)
+		assert.NoError(t, RequireCapability(capability.CalendarSync))
+		assert.EqualError(t, FeatureUnavailable(capability.CalendarImport), "calendar import not configured")
+	})
+
+	t.Run("sync explains why calendar sync is unavailable", func(t *testing.T) {
+		SetCapabilities(capability.Set{
+			capability.Available(capability.Database),
+			capability.Unavailable(capability.CalendarSync, "OAuth is not configured"),
+		})
+		SetApp(&App{})
+		defer SetApp(nil)
+
+		syncCmd.SetContext(context.Background())
+		err := syncCmd.RunE(syncCmd, nil)
+		assert.ErrorIs(t, err, capability.ErrUnavailable)
+		assert.EqualError(t, err, "calendar sync unavailable because OAuth is not configured")
+	})
+
+	t.Run("doctor lists unavailable features", func(t *testing.T) {
+		prevChecks := healthChecks
+		defer SetHealthChecks(prevChecks)
+		SetHealthChecks([]health.Check{health.Static(health.OK("database", "reachable"))})
+		SetCapabilities(capability.Set{
+			capability.Available(capability.Database),
+			capability.Unavailable(capability.CalendarSync, "OAuth is not configured"),
+			capability.Unavailable(capability.Digests, "DIGESTS_ENABLED is off"),
+		})
+
+		var out bytes.Buffer
+		doctorCmd.SetOut(&out)
+		defer doctorCmd.SetOut(nil)
+		doctorCmd.SetContext(context.Background())
+		require.NoError(t, doctorCmd.RunE(doctorCmd, nil))
+
+		assert.Contains(t, out.String(), "Unavailable features:")
+		assert.Contains(t, out.String(), "  - calendar sync: OAuth is not configured")
+		assert.Contains(t, out.String(), "  - digests: DIGESTS_ENABLED is off")
+		assert.NotContains(t, out.String(), "  - database")
+	})
+}
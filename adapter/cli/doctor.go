@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/application/capability"
+	"github.com/felixgeelhaar/orbita/internal/shared/application/health"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorJSON    bool
+	doctorTimeout = health.DefaultTimeout
+)
+
+// healthChecks are the dependency probes run by `orbita doctor`.
+var healthChecks []health.Check
+
+// SetHealthChecks sets the dependency probes run by `orbita doctor`.
+func SetHealthChecks(checks []health.Check) {
+	healthChecks = checks
+}
+
+// ErrUnhealthy is returned by `orbita doctor` when a dependency is down.
+var ErrUnhealthy = errors.New("one or more dependencies are unhealthy")
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the health of Orbita's dependencies",
+	Long: `Probe every configured dependency (database, Redis, RabbitMQ, calendar
+provider, license) and report its status, with a hint for anything that
+needs fixing, followed by the features that are unavailable and why.
+
+Exits with an error when a dependency is down. Degraded dependencies are
+reported but do not fail the command.`,
+	Example: `  orbita doctor
+  orbita doctor --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		if len(healthChecks) == 0 {
+			return errors.New("health checks not configured")
+		}
+
+		report := health.Run(cmd.Context(), healthChecks, doctorTimeout)
+
+		if doctorJSON {
+			if err := json.NewEncoder(out).Encode(report); err != nil {
+				return err
+			}
+		} else {
+			for _, result := range report.Results {
+				fmt.Fprintf(out, "%s %-10s %s\n", statusIcon(result.Status), result.Name, result.Detail)
+				if result.Hint != "" {
+					fmt.Fprintf(out, "  → %s\n", result.Hint)
+				}
+			}
+			if missing := capabilities.Unavailable(); len(missing) > 0 {
+				fmt.Fprintln(out)
+				fmt.Fprintln(out, "Unavailable features:")
+				for _, c := range missing {
+					fmt.Fprintf(out, "  - %s: %s\n", capability.Label(c.Name), c.Reason)
+				}
+			}
+			fmt.Fprintln(out)
+			fmt.Fprintf(out, "Overall: %s\n", report.Status)
+		}
+
+		if report.Status == health.OverallUnhealthy {
+			cmd.SilenceUsage = true
+			return ErrUnhealthy
+		}
+		return nil
+	},
+}
+
+func statusIcon(status health.Status) string {
+	switch status {
+	case health.StatusOK:
+		return "✓"
+	case health.StatusWarn:
+		return "!"
+	case health.StatusFail:
+		return "✗"
+	default:
+		return "○"
+	}
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "output as JSON")
+	doctorCmd.Flags().DurationVar(&doctorTimeout, "probe-timeout", health.DefaultTimeout, "time limit for each probe")
+	rootCmd.AddCommand(doctorCmd)
+}
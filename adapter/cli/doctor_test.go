@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/application/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctorCmd(t *testing.T) {
+	prev := healthChecks
+	defer SetHealthChecks(prev)
+
+	database := health.Ping("database", func(context.Context) error { return nil }, "")
+	redisDown := health.Static(health.Warn("redis", "not connected; orbit storage is in memory", "Check REDIS_URL"))
+	rabbitDown := health.Ping("rabbitmq", func(context.Context) error { return errors.New("connection refused") }, "Check RABBITMQ_URL")
+
+	run := func(t *testing.T, checks []health.Check, jsonOutput bool) (string, error) {
+		t.Helper()
+		SetHealthChecks(checks)
+		doctorJSON = jsonOutput
+		defer func() { doctorJSON = false }()
+
+		var out bytes.Buffer
+		doctorCmd.SetOut(&out)
+		defer doctorCmd.SetOut(nil)
+		doctorCmd.SetContext(context.Background())
+		err := doctorCmd.RunE(doctorCmd, nil)
+		return out.String(), err
+	}
+
+	t.Run("degraded reports hints without failing", func(t *testing.T) {
+		out, err := run(t, []health.Check{database, redisDown}, false)
+		require.NoError(t, err)
+		assert.Contains(t, out, "✓ database")
+		assert.Contains(t, out, "! redis")
+		assert.Contains(t, out, "→ Check REDIS_URL")
+		assert.Contains(t, out, "Overall: degraded")
+	})
+
+	t.Run("unhealthy fails", func(t *testing.T) {
+		out, err := run(t, []health.Check{database, rabbitDown}, true)
+		assert.ErrorIs(t, err, ErrUnhealthy)
+
+		var report health.Report
+		require.NoError(t, json.Unmarshal([]byte(out), &report))
+		assert.Equal(t, health.OverallUnhealthy, report.Status)
+		require.Len(t, report.Results, 2)
+		assert.Equal(t, "connection refused", report.Results[1].Detail)
+		assert.Equal(t, "Check RABBITMQ_URL", report.Results[1].Hint)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		_, err := run(t, nil, false)
+		assert.Error(t, err)
+	})
+}
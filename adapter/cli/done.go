@@ -9,23 +9,37 @@ import (
 	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
 
+var doneMatch string
+
 var doneCmd = &cobra.Command{
-	Use:   "done <id-prefix>",
+	Use:   "done <id-prefix|title>",
 	Short: "Mark a task or habit as complete",
-	Long: `Quickly mark a task or habit as complete using just the first few characters of its ID.
+	Long: `Quickly mark a task or habit as complete using the first few characters
+of its ID or a word from its title.
+
+The command will search for matching tasks first, then habits. ID prefixes
+win over titles; title matches are case-insensitive and ranked, exact titles
+first. If multiple items match, you'll be shown the options, best match first.
 
-The command will search for matching tasks first, then habits.
-If multiple items match, you'll be shown the options.
+Use --match to restrict matching to IDs or titles.
 
 Examples:
-  orbita done abc1      # Complete task/habit starting with abc1
-  orbita done abc123    # More specific match
-  orbita done           # Show completable items`,
+  orbita done abc1                # Complete task/habit starting with abc1
+  orbita done abc123              # More specific match
+  orbita done report              # Complete the task with "report" in its title
+  orbita done --match title 2024  # Match "2024" against titles only
+  orbita done                     # Show completable items`,
 	Aliases: []string{"complete", "finish", "x"},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		mode, err := ParseMatchMode(doneMatch)
+		if err != nil {
+			return err
+		}
+
 		app := GetApp()
 		if app == nil {
 			fmt.Println("Done command requires database connection.")
@@ -38,8 +52,7 @@ Examples:
 			return showCompletableItems(cmd, app)
 		}
 
-		prefix := strings.ToLower(args[0])
-		return completeByPrefix(cmd.Context(), app, prefix)
+		return completeByPrefix(cmd.Context(), app, strings.Join(args, " "), mode)
 	},
 }
 
@@ -64,37 +77,21 @@ func showCompletableItems(cmd *cobra.Command, app *App) error {
 	}
 
 	// Show due habits
-	if app.ListHabitsHandler != nil {
-		query := habitQueries.ListHabitsQuery{
-			UserID:       app.CurrentUserID,
-			OnlyDueToday: true,
-		}
-		habits, err := app.ListHabitsHandler.Handle(cmd.Context(), query)
-		if err == nil {
-			incomplete := 0
-			for _, h := range habits {
-				if !h.CompletedToday {
-					incomplete++
-				}
-			}
-			if incomplete > 0 {
-				fmt.Println("\n  Habits due today:")
-				for _, h := range habits {
-					if !h.CompletedToday {
-						fmt.Printf("    [%s] %s\n", h.ID.String()[:8], h.Name)
-					}
-				}
-			}
+	habits, err := app.DueHabits(cmd.Context())
+	if err == nil && len(habits) > 0 {
+		fmt.Println("\n  Habits due today:")
+		for _, h := range habits {
+			fmt.Printf("    [%s] %s\n", h.ID.String()[:8], h.Name)
 		}
 	}
 
-	fmt.Println("\n  Usage: orbita done <id-prefix>")
+	fmt.Println("\n  Usage: orbita done <id-prefix|title>")
 	fmt.Println()
 
 	return nil
 }
 
-func completeByPrefix(ctx context.Context, app *App, prefix string) error {
+func completeByPrefix(ctx context.Context, app *App, term string, mode MatchMode) error {
 	// Search tasks first
 	if app.ListTasksHandler != nil && app.CompleteTaskHandler != nil {
 		query := queries.ListTasksQuery{
@@ -103,12 +100,10 @@ func completeByPrefix(ctx context.Context, app *App, prefix string) error {
 		}
 		tasks, err := app.ListTasksHandler.Handle(ctx, query)
 		if err == nil {
-			var matches []queries.TaskDTO
-			for _, t := range tasks {
-				if strings.HasPrefix(strings.ToLower(t.ID.String()), prefix) {
-					matches = append(matches, t)
-				}
-			}
+			matches := RankMatches(tasks, term, mode,
+				func(t queries.TaskDTO) uuid.UUID { return t.ID },
+				func(t queries.TaskDTO) string { return t.Title },
+			)
 
 			if len(matches) == 1 {
 				// Complete the task
@@ -124,19 +119,13 @@ func completeByPrefix(ctx context.Context, app *App, prefix string) error {
 	}
 
 	// Search habits
-	if app.ListHabitsHandler != nil && app.LogCompletionHandler != nil {
-		query := habitQueries.ListHabitsQuery{
-			UserID:       app.CurrentUserID,
-			OnlyDueToday: true,
-		}
-		habits, err := app.ListHabitsHandler.Handle(ctx, query)
+	if app.LogCompletionHandler != nil {
+		habits, err := app.DueHabits(ctx)
 		if err == nil {
-			var matches []habitQueries.HabitDTO
-			for _, h := range habits {
-				if !h.CompletedToday && strings.HasPrefix(strings.ToLower(h.ID.String()), prefix) {
-					matches = append(matches, h)
-				}
-			}
+			matches := RankMatches(habits, term, mode,
+				func(h habitQueries.HabitDTO) uuid.UUID { return h.ID },
+				func(h habitQueries.HabitDTO) string { return h.Name },
+			)
 
 			if len(matches) == 1 {
 				// Complete the habit
@@ -151,7 +140,7 @@ func completeByPrefix(ctx context.Context, app *App, prefix string) error {
 		}
 	}
 
-	fmt.Printf("No pending task or due habit found matching '%s'\n", prefix)
+	fmt.Printf("No pending task or due habit found matching '%s'\n", term)
 	return nil
 }
 
@@ -198,5 +187,6 @@ func completeHabit(ctx context.Context, app *App, habit habitQueries.HabitDTO) e
 }
 
 func init() {
+	doneCmd.Flags().StringVar(&doneMatch, "match", string(MatchAny), "what to match: any (ID prefix, then title), id, or title")
 	rootCmd.AddCommand(doneCmd)
 }
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -30,7 +29,7 @@ var engineListCmd = &cobra.Command{
 			return nil
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Group engines by type
 		type engineInfo struct {
@@ -89,6 +88,9 @@ var engineListCmd = &cobra.Command{
 				if info.Builtin {
 					builtinStr = " [built-in]"
 				}
+				if defaultID, err := app.EngineRegistry.DefaultEngineID(sdk.EngineType(engineType)); err == nil && defaultID == info.ID {
+					builtinStr += " [default]"
+				}
 				fmt.Printf("  %s (v%s)%s\n", info.Name, info.Version, builtinStr)
 				fmt.Printf("    ID: %s\n", info.ID)
 				fmt.Printf("    Status: %s\n", info.Status)
@@ -130,7 +132,7 @@ var engineInfoCmd = &cobra.Command{
 			return fmt.Errorf("engine registry not available")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		engineID := args[0]
 
 		engine, err := app.EngineRegistry.Get(ctx, engineID)
@@ -217,7 +219,7 @@ var engineHealthCmd = &cobra.Command{
 			return fmt.Errorf("engine registry not available")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		if len(args) > 0 {
 			// Check specific engine
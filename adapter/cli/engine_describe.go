@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	"github.com/spf13/cobra"
+)
+
+var engineDescribeJSON bool
+
+// engineDescription is what `engine describe` reports about an engine.
+type engineDescription struct {
+	ID            string                    `json:"id"`
+	Name          string                    `json:"name"`
+	Version       string                    `json:"version"`
+	Type          sdk.EngineType            `json:"type"`
+	Builtin       bool                      `json:"builtin"`
+	Description   string                    `json:"description,omitempty"`
+	Author        string                    `json:"author,omitempty"`
+	MinAPIVersion string                    `json:"min_api_version,omitempty"`
+	Capabilities  []string                  `json:"capabilities"`
+	ConfigSchema  sdk.ConfigSchema          `json:"config_schema"`
+	Operations    []types.Operation         `json:"operations"`
+	Triggers      []types.TriggerDefinition `json:"triggers,omitempty"`
+	Actions       []types.ActionDefinition  `json:"actions,omitempty"`
+	Categories    []types.Category          `json:"categories,omitempty"`
+}
+
+var engineDescribeCmd = &cobra.Command{
+	Use:   "describe <engine-id>",
+	Short: "Describe an engine's capabilities, configuration and inputs/outputs",
+	Long: `Describe what an engine accepts and produces, from its registry metadata.
+
+Shows the engine's type, capabilities, configuration schema, and the
+operations its type supports with their input and output fields. Automation
+engines also list their supported triggers and actions; classifier engines
+list their categories.`,
+	Example: `  orbita engine describe orbita.automation.default
+  orbita engine describe orbita.priority.default --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := GetApp()
+		if app == nil || app.EngineRegistry == nil {
+			return fmt.Errorf("engine registry not available")
+		}
+
+		ctx := cmd.Context()
+		engineID := args[0]
+
+		engine, err := app.EngineRegistry.Get(ctx, engineID)
+		if err != nil {
+			return fmt.Errorf("engine not found: %s", engineID)
+		}
+
+		meta := engine.Metadata()
+		desc := engineDescription{
+			ID:            meta.ID,
+			Name:          meta.Name,
+			Version:       meta.Version,
+			Type:          engine.Type(),
+			Description:   meta.Description,
+			Author:        meta.Author,
+			MinAPIVersion: meta.MinAPIVersion,
+			Capabilities:  meta.Capabilities,
+			ConfigSchema:  engine.ConfigSchema(),
+			Operations:    types.Operations(engine.Type()),
+		}
+		for _, entry := range app.EngineRegistry.List() {
+			if entry.Manifest != nil && entry.Manifest.ID == engineID {
+				desc.Builtin = entry.Builtin
+				break
+			}
+		}
+
+		execCtx := sdk.NewExecutionContext(ctx, app.CurrentUserID, engineID)
+		switch e := engine.(type) {
+		case types.AutomationEngine:
+			if desc.Triggers, err = e.GetSupportedTriggers(execCtx); err != nil {
+				return fmt.Errorf("failed to list triggers: %w", err)
+			}
+			if desc.Actions, err = e.GetSupportedActions(execCtx); err != nil {
+				return fmt.Errorf("failed to list actions: %w", err)
+			}
+		case types.ClassifierEngine:
+			if desc.Categories, err = e.GetCategories(execCtx); err != nil {
+				return fmt.Errorf("failed to list categories: %w", err)
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		if engineDescribeJSON {
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			return enc.Encode(desc)
+		}
+		printEngineDescription(out, desc)
+		return nil
+	},
+}
+
+func printEngineDescription(out io.Writer, desc engineDescription) {
+	fmt.Fprintf(out, "Engine: %s (v%s)\n", desc.Name, desc.Version)
+	fmt.Fprintf(out, "ID: %s\n", desc.ID)
+	builtin := ""
+	if desc.Builtin {
+		builtin = " [built-in]"
+	}
+	fmt.Fprintf(out, "Type: %s%s\n", formatEngineType(desc.Type), builtin)
+	if desc.Description != "" {
+		fmt.Fprintf(out, "Description: %s\n", desc.Description)
+	}
+	if desc.Author != "" {
+		fmt.Fprintf(out, "Author: %s\n", desc.Author)
+	}
+	if desc.MinAPIVersion != "" {
+		fmt.Fprintf(out, "Min API Version: %s\n", desc.MinAPIVersion)
+	}
+
+	if len(desc.Capabilities) > 0 {
+		fmt.Fprintln(out, "\nCapabilities:")
+		for _, capability := range desc.Capabilities {
+			fmt.Fprintf(out, "  - %s\n", capability)
+		}
+	}
+
+	if len(desc.ConfigSchema.Properties) > 0 {
+		fmt.Fprintln(out, "\nConfiguration:")
+		names := make([]string, 0, len(desc.ConfigSchema.Properties))
+		for name := range desc.ConfigSchema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop := desc.ConfigSchema.Properties[name]
+			var details []string
+			for _, r := range desc.ConfigSchema.Required {
+				if r == name {
+					details = append(details, "required")
+					break
+				}
+			}
+			if prop.Default != nil {
+				details = append(details, fmt.Sprintf("default %v", prop.Default))
+			}
+			if prop.Minimum != nil {
+				details = append(details, fmt.Sprintf("min %v", *prop.Minimum))
+			}
+			if prop.Maximum != nil {
+				details = append(details, fmt.Sprintf("max %v", *prop.Maximum))
+			}
+			if len(prop.Enum) > 0 {
+				details = append(details, fmt.Sprintf("one of %v", prop.Enum))
+			}
+			line := fmt.Sprintf("  %s: %s", name, prop.Type)
+			if len(details) > 0 {
+				line += " (" + strings.Join(details, ", ") + ")"
+			}
+			fmt.Fprintln(out, line)
+			if prop.Description != "" {
+				fmt.Fprintf(out, "    %s\n", prop.Description)
+			}
+		}
+	}
+
+	if len(desc.Operations) > 0 {
+		fmt.Fprintln(out, "\nOperations:")
+		for _, op := range desc.Operations {
+			fmt.Fprintf(out, "  %s(%s) -> %s\n", op.Name, op.Input, op.Output)
+			if len(op.InputFields) > 0 {
+				fmt.Fprintf(out, "    in:  %s\n", formatFields(op.InputFields))
+			}
+			if len(op.OutputFields) > 0 {
+				fmt.Fprintf(out, "    out: %s\n", formatFields(op.OutputFields))
+			}
+		}
+	}
+
+	if len(desc.Triggers) > 0 {
+		fmt.Fprintln(out, "\nTriggers:")
+		for _, trigger := range desc.Triggers {
+			fmt.Fprintf(out, "  %s: %s\n", trigger.Type, trigger.Description)
+			printParameters(out, trigger.Parameters)
+		}
+	}
+
+	if len(desc.Actions) > 0 {
+		fmt.Fprintln(out, "\nActions:")
+		for _, action := range desc.Actions {
+			fmt.Fprintf(out, "  %s: %s\n", action.Type, action.Description)
+			printParameters(out, action.Parameters)
+		}
+	}
+
+	if len(desc.Categories) > 0 {
+		fmt.Fprintln(out, "\nCategories:")
+		for _, category := range desc.Categories {
+			fmt.Fprintf(out, "  %s: %s\n", category.ID, category.Description)
+		}
+	}
+}
+
+// formatFields renders fields as "name: type", marking optional ones with "?".
+func formatFields(fields []types.Field) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		optional := ""
+		if f.Optional {
+			optional = "?"
+		}
+		parts[i] = fmt.Sprintf("%s%s: %s", f.Name, optional, f.Type)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func printParameters(out io.Writer, params []types.ParameterDefinition) {
+	for _, p := range params {
+		required := ""
+		if p.Required {
+			required = ", required"
+		}
+		fmt.Fprintf(out, "    - %s (%s%s)", p.Name, p.Type, required)
+		if p.Description != "" {
+			fmt.Fprintf(out, ": %s", p.Description)
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+func init() {
+	engineDescribeCmd.Flags().BoolVar(&engineDescribeJSON, "json", false, "output as JSON")
+	engineCmd.AddCommand(engineDescribeCmd)
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/engine/builtin"
+	"github.com/felixgeelhaar/orbita/internal/engine/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineDescribeCmd(t *testing.T) {
+	reg := registry.NewRegistry(nil)
+	require.NoError(t, reg.RegisterBuiltin(builtin.NewDefaultAutomationEngine()))
+
+	prev := GetApp()
+	SetApp(&App{EngineRegistry: reg})
+	defer SetApp(prev)
+
+	run := func(t *testing.T, jsonOutput bool, args ...string) (string, error) {
+		t.Helper()
+		engineDescribeJSON = jsonOutput
+		defer func() { engineDescribeJSON = false }()
+
+		var out bytes.Buffer
+		engineDescribeCmd.SetOut(&out)
+		defer engineDescribeCmd.SetOut(nil)
+		engineDescribeCmd.SetContext(context.Background())
+		err := engineDescribeCmd.RunE(engineDescribeCmd, args)
+		return out.String(), err
+	}
+
+	t.Run("text", func(t *testing.T) {
+		out, err := run(t, false, "orbita.automation.default")
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "ID: orbita.automation.default")
+		assert.Contains(t, out, "Type: Automation [built-in]")
+		assert.Contains(t, out, "Capabilities:\n  - evaluate\n")
+		assert.Contains(t, out, "  log_all_evaluations: boolean (default false)")
+		assert.Contains(t, out, "  max_actions_per_rule: integer (default 10, min 1, max 50)")
+		assert.Contains(t, out, "  Evaluate(AutomationInput) -> AutomationOutput")
+		assert.Contains(t, out, "    in:  event: AutomationEvent, rules: array, context?: AutomationContext")
+		assert.Contains(t, out, "Triggers:\n  event: Triggered when specific events occur")
+		assert.Contains(t, out, "  task.create: Creates a new task\n    - title (string, required): Task title")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out, err := run(t, true, "orbita.automation.default")
+		require.NoError(t, err)
+
+		var desc engineDescription
+		require.NoError(t, json.Unmarshal([]byte(out), &desc))
+		assert.Equal(t, "automation", string(desc.Type))
+		assert.True(t, desc.Builtin)
+		assert.Contains(t, desc.ConfigSchema.Properties, "max_actions_per_rule")
+		require.NotEmpty(t, desc.Operations)
+		assert.NotEmpty(t, desc.Triggers)
+		assert.NotEmpty(t, desc.Actions)
+	})
+
+	t.Run("unknown engine", func(t *testing.T) {
+		_, err := run(t, false, "acme.nope")
+		assert.ErrorContains(t, err, "engine not found")
+	})
+}
@@ -20,69 +20,87 @@ var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export schedule to various formats",
 	Long: `Export your schedule to ICS (iCalendar) format for import into
-Google Calendar, Outlook, Apple Calendar, and other calendar apps.
+Google Calendar, Outlook, Apple Calendar, and other calendar apps, or to a
+Markdown agenda for pasting into notes.
 
 Examples:
   orbita export --format ics              # Export to stdout
   orbita export --format ics -o cal.ics   # Export to file
-  orbita export --format ics --days 7     # Export next 7 days`,
+  orbita export --format ics --days 7     # Export next 7 days
+  orbita export --format md -o agenda.md  # Export a Markdown agenda`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		app := GetApp()
-		if app == nil || app.GetScheduleHandler == nil {
-			fmt.Println("Export requires database connection.")
-			fmt.Println("Start services with: docker-compose up -d")
-			return nil
-		}
-
-		switch exportFormat {
-		case "ics", "ical":
-			return exportICS(cmd, app)
-		default:
-			return fmt.Errorf("unsupported format: %s (supported: ics)", exportFormat)
-		}
+		return RunExport(cmd, exportFormat, exportOutput, exportDays)
 	},
 }
 
-func exportICS(cmd *cobra.Command, app *App) error {
-	now := time.Now()
-	var allBlocks []scheduleQueries.TimeBlockDTO
+// RunExport exports the schedule for the given number of days starting today.
+// The format is ics or md; the result is written to output, or to stdout when
+// output is empty.
+func RunExport(cmd *cobra.Command, format, output string, days int) error {
+	app := GetApp()
+	if app == nil || app.GetScheduleHandler == nil {
+		fmt.Println("Export requires database connection.")
+		fmt.Println("Start services with: docker-compose up -d")
+		return nil
+	}
 
-	// Gather blocks for the specified number of days
-	for i := 0; i < exportDays; i++ {
-		day := now.AddDate(0, 0, i)
-		query := scheduleQueries.GetScheduleQuery{
-			UserID: app.CurrentUserID,
-			Date:   day,
-		}
+	switch format {
+	case "ics", "ical":
+		return exportICS(cmd, app, output, days)
+	case "md", "markdown":
+		return exportMarkdown(cmd, app, output, days)
+	default:
+		return fmt.Errorf("unsupported format: %s (supported: ics, md)", format)
+	}
+}
 
-		schedule, err := app.GetScheduleHandler.Handle(cmd.Context(), query)
-		if err != nil {
-			continue
-		}
+func exportICS(cmd *cobra.Command, app *App, output string, days int) error {
+	schedules, err := FetchScheduleDays(cmd.Context(), app, days)
+	if err != nil {
+		// Export what loaded; a missing day should not block the rest.
+		fmt.Fprintf(os.Stderr, "Warning: some days could not be loaded: %v\n", err)
+	}
 
+	var allBlocks []scheduleQueries.TimeBlockDTO
+	for _, schedule := range schedules {
 		if schedule != nil {
 			allBlocks = append(allBlocks, schedule.Blocks...)
 		}
 	}
 
 	if len(allBlocks) == 0 {
-		fmt.Fprintf(os.Stderr, "No scheduled blocks found in the next %d days.\n", exportDays)
+		fmt.Fprintf(os.Stderr, "No scheduled blocks found in the next %d days.\n", days)
 		return nil
 	}
 
-	// Generate ICS content
-	ics := generateICS(allBlocks)
+	return writeExport(cmd, generateICS(allBlocks), output, len(allBlocks))
+}
 
-	// Output
-	if exportOutput != "" {
-		if err := os.WriteFile(exportOutput, []byte(ics), 0600); err != nil {
-			return fmt.Errorf("failed to write file: %w", err)
-		}
-		fmt.Fprintf(os.Stderr, "Exported %d blocks to %s\n", len(allBlocks), exportOutput)
-	} else {
-		fmt.Print(ics)
+func exportMarkdown(cmd *cobra.Command, app *App, output string, days int) error {
+	blocks, err := GatherScheduleBlocks(cmd.Context(), app, days)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: some days could not be loaded: %v\n", err)
 	}
 
+	if len(blocks) == 0 {
+		fmt.Fprintf(os.Stderr, "No scheduled blocks found in the next %d days.\n", days)
+		return nil
+	}
+
+	return writeExport(cmd, RenderMarkdownAgenda(blocks), output, len(blocks))
+}
+
+// writeExport writes exported content to the output file, or to the
+// command's stdout when output is empty.
+func writeExport(cmd *cobra.Command, content, output string, count int) error {
+	if output == "" {
+		fmt.Fprint(cmd.OutOrStdout(), content)
+		return nil
+	}
+	if err := os.WriteFile(output, []byte(content), 0600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d blocks to %s\n", count, output)
 	return nil
 }
 
@@ -103,8 +121,12 @@ func generateICS(blocks []scheduleQueries.TimeBlockDTO) string {
 		// UID - unique identifier
 		sb.WriteString(fmt.Sprintf("UID:%s@orbita\r\n", block.ID.String()))
 
-		// Timestamps
-		sb.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSTime(time.Now())))
+		// Timestamps and revision, derived from the block so that
+		// re-importing an export updates events instead of duplicating them
+		modified, sequence := ICSRevision(block.CreatedAt, block.UpdatedAt, block.StartTime)
+		sb.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSTime(modified)))
+		sb.WriteString(fmt.Sprintf("LAST-MODIFIED:%s\r\n", formatICSTime(modified)))
+		sb.WriteString(fmt.Sprintf("SEQUENCE:%d\r\n", sequence))
 		sb.WriteString(fmt.Sprintf("DTSTART:%s\r\n", formatICSTime(block.StartTime)))
 		sb.WriteString(fmt.Sprintf("DTEND:%s\r\n", formatICSTime(block.EndTime)))
 
@@ -140,6 +162,25 @@ func generateICS(blocks []scheduleQueries.TimeBlockDTO) string {
 	return sb.String()
 }
 
+// ICSRevision returns the last-modified time and SEQUENCE number of an
+// exported event. Both come from the block's own timestamps, so exporting an
+// unchanged block twice yields identical events, and every later update
+// yields a higher sequence: the number of seconds between the block's
+// creation and its last update. Blocks without timestamps fall back to
+// their start time and sequence 0.
+func ICSRevision(createdAt, updatedAt, startTime time.Time) (time.Time, int) {
+	if updatedAt.IsZero() {
+		if createdAt.IsZero() {
+			return startTime, 0
+		}
+		return createdAt, 0
+	}
+	if createdAt.IsZero() || !updatedAt.After(createdAt) {
+		return updatedAt, 0
+	}
+	return updatedAt, int(updatedAt.Sub(createdAt) / time.Second)
+}
+
 func formatICSTime(t time.Time) string {
 	return t.UTC().Format("20060102T150405Z")
 }
@@ -154,7 +195,7 @@ func escapeICS(s string) string {
 }
 
 func init() {
-	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "ics", "export format (ics)")
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "ics", "export format (ics, md)")
 	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "output file (default: stdout)")
 	exportCmd.Flags().IntVarP(&exportDays, "days", "d", 7, "number of days to export")
 
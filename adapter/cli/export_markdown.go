@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	calendarApp "github.com/felixgeelhaar/orbita/internal/calendar/application"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"*", "\\*",
+	"_", "\\_",
+	"`", "\\`",
+	"[", "\\[",
+	"]", "\\]",
+	"\r\n", " ",
+	"\n", " ",
+)
+
+// RenderMarkdownAgenda renders blocks as a Markdown agenda with one section
+// per day. Each block is a task list item with its time range, title and
+// type; completed blocks are checked and missed blocks are struck through.
+func RenderMarkdownAgenda(blocks []calendarApp.TimeBlock) string {
+	sorted := make([]calendarApp.TimeBlock, len(blocks))
+	copy(sorted, blocks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	var sb strings.Builder
+	sb.WriteString("# Schedule\n")
+
+	var currentDay string
+	for _, block := range sorted {
+		day := block.StartTime.Format("Monday, January 2, 2006")
+		if day != currentDay {
+			currentDay = day
+			sb.WriteString(fmt.Sprintf("\n## %s\n\n", day))
+		}
+
+		check := " "
+		if block.Completed {
+			check = "x"
+		}
+		title := markdownEscaper.Replace(block.Title)
+		if block.Missed && !block.Completed {
+			title = "~~" + title + "~~"
+		}
+
+		sb.WriteString(fmt.Sprintf("- [%s] %s-%s %s (%s)",
+			check,
+			block.StartTime.Format("15:04"),
+			block.EndTime.Format("15:04"),
+			title,
+			block.BlockType,
+		))
+		switch {
+		case block.Completed:
+			sb.WriteString(" - completed")
+		case block.Missed:
+			sb.WriteString(" - missed")
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
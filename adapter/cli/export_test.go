@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	calendarApp "github.com/felixgeelhaar/orbita/internal/calendar/application"
 	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
 	"github.com/google/uuid"
 )
@@ -57,9 +58,80 @@ func TestGenerateICS_SingleBlock(t *testing.T) {
 	assertContains(t, ics, "END:VCALENDAR\r\n")
 }
 
+func TestGenerateICS_StableRevisions(t *testing.T) {
+	created := time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+	block := scheduleQueries.TimeBlockDTO{
+		ID:        uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+		BlockType: "focus",
+		Title:     "Deep work",
+		StartTime: time.Date(2024, time.May, 2, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.May, 2, 11, 0, 0, 0, time.UTC),
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+
+	first := generateICS([]scheduleQueries.TimeBlockDTO{block})
+	if second := generateICS([]scheduleQueries.TimeBlockDTO{block}); second != first {
+		t.Fatalf("exports of an unchanged block differ:\n%s\n%s", first, second)
+	}
+	assertContains(t, first, "UID:22222222-2222-2222-2222-222222222222@orbita\r\n")
+	assertContains(t, first, "DTSTAMP:20240501T080000Z\r\n")
+	assertContains(t, first, "LAST-MODIFIED:20240501T080000Z\r\n")
+	assertContains(t, first, "SEQUENCE:0\r\n")
+
+	block.StartTime = block.StartTime.Add(time.Hour)
+	block.UpdatedAt = created.Add(90 * time.Second)
+	moved := generateICS([]scheduleQueries.TimeBlockDTO{block})
+	assertContains(t, moved, "UID:22222222-2222-2222-2222-222222222222@orbita\r\n")
+	assertContains(t, moved, "LAST-MODIFIED:20240501T080130Z\r\n")
+	assertContains(t, moved, "SEQUENCE:90\r\n")
+
+	block.Completed = true
+	block.UpdatedAt = created.Add(time.Hour)
+	assertContains(t, generateICS([]scheduleQueries.TimeBlockDTO{block}), "SEQUENCE:3600\r\n")
+}
+
+func TestICSRevision_MissingTimestamps(t *testing.T) {
+	start := time.Date(2024, time.May, 2, 9, 0, 0, 0, time.UTC)
+	created := time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+
+	if modified, seq := ICSRevision(time.Time{}, time.Time{}, start); !modified.Equal(start) || seq != 0 {
+		t.Fatalf("expected start time and sequence 0, got %s and %d", modified, seq)
+	}
+	if modified, seq := ICSRevision(created, time.Time{}, start); !modified.Equal(created) || seq != 0 {
+		t.Fatalf("expected creation time and sequence 0, got %s and %d", modified, seq)
+	}
+	if _, seq := ICSRevision(created, created.Add(-time.Minute), start); seq != 0 {
+		t.Fatalf("expected sequence 0 for an update before creation, got %d", seq)
+	}
+}
+
 func assertContains(t *testing.T, haystack, needle string) {
 	t.Helper()
 	if !strings.Contains(haystack, needle) {
 		t.Fatalf("expected to contain %q", needle)
 	}
 }
+
+func TestRenderMarkdownAgenda_MultiDay(t *testing.T) {
+	day1 := time.Date(2024, time.May, 6, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	blocks := []calendarApp.TimeBlock{
+		{Title: "Write report", BlockType: "task", StartTime: day1.Add(10 * time.Hour), EndTime: day1.Add(11 * time.Hour), Missed: true},
+		{Title: "Standup", BlockType: "meeting", StartTime: day1.Add(9 * time.Hour), EndTime: day1.Add(9*time.Hour + 15*time.Minute), Completed: true},
+		{Title: "Deep work on *core*", BlockType: "focus", StartTime: day2.Add(13 * time.Hour), EndTime: day2.Add(15 * time.Hour)},
+	}
+
+	md := RenderMarkdownAgenda(blocks)
+
+	expected := "# Schedule\n" +
+		"\n## Monday, May 6, 2024\n\n" +
+		"- [x] 09:00-09:15 Standup (meeting) - completed\n" +
+		"- [ ] 10:00-11:00 ~~Write report~~ (task) - missed\n" +
+		"\n## Tuesday, May 7, 2024\n\n" +
+		"- [ ] 13:00-15:00 Deep work on \\*core\\* (focus)\n"
+	if md != expected {
+		t.Fatalf("unexpected markdown:\n%s\nwant:\n%s", md, expected)
+	}
+}
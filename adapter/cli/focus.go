@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	scheduleCommands "github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +18,8 @@ var (
 	focusDuration int
 	focusBreak    int
 	focusTask     string
+	focusProtect  bool
+	focusQuiet    bool
 )
 
 var focusCmd = &cobra.Command{
@@ -30,7 +33,9 @@ using the Pomodoro technique. After the focus period, take a break.
 Examples:
   orbita focus --duration 25           # 25 minute focus session
   orbita focus --duration 25 --break 5 # 25 min focus, 5 min break
-  orbita focus --task abc123           # Focus on specific task`,
+  orbita focus --task abc123           # Focus on specific task
+  orbita focus --protect               # Keep other work out of the schedule
+  orbita focus --quiet                 # Also hold back reminders`,
 	Aliases: []string{"pomodoro", "timer"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		duration := time.Duration(focusDuration) * time.Minute
@@ -64,6 +69,12 @@ Examples:
 		fmt.Println("  Press Ctrl+C to end session early")
 		fmt.Println(strings.Repeat("-", 50))
 
+		// Protect the focus window in the schedule
+		protected := false
+		if focusProtect || focusQuiet {
+			protected = startFocusMode(cmd.Context(), duration)
+		}
+
 		// Create context for cancellation
 		ctx, cancel := context.WithCancel(cmd.Context())
 		defer cancel()
@@ -103,6 +114,10 @@ Examples:
 			}
 		}
 
+		if protected && !completed {
+			endFocusMode(context.WithoutCancel(ctx))
+		}
+
 		// Show session summary
 		elapsed := time.Since(startTime)
 		fmt.Println()
@@ -115,6 +130,57 @@ Examples:
 	},
 }
 
+// startFocusMode protects the focus window in the schedule. It reports
+// whether focus mode was started.
+func startFocusMode(ctx context.Context, duration time.Duration) bool {
+	app := GetApp()
+	if app == nil || app.StartFocusModeHandler == nil {
+		fmt.Println("  Schedule protection requires database connection.")
+		return false
+	}
+
+	start := scheduleCommands.StartFocusModeCommand{
+		UserID:                app.CurrentUserID,
+		Duration:              duration,
+		SuppressNotifications: focusQuiet,
+	}
+	if taskID, err := uuid.Parse(focusTask); err == nil {
+		start.TaskID = taskID
+	}
+
+	result, err := app.StartFocusModeHandler.Handle(ctx, start)
+	if err != nil {
+		fmt.Printf("  Could not protect schedule: %v\n", err)
+		return false
+	}
+
+	fmt.Printf("  Schedule protected until %s\n", result.EndTime.Format("15:04"))
+	if result.SuppressNotifications {
+		fmt.Println("  Reminders are held until focus mode ends")
+	}
+	for _, moved := range result.Displaced {
+		if moved.Scheduled {
+			fmt.Printf("  Moved a block to %s\n", moved.StartTime.Format("15:04"))
+		} else {
+			fmt.Printf("  Could not move a block: %s\n", moved.Reason)
+		}
+	}
+	return true
+}
+
+// endFocusMode frees the rest of the focus window when a session ends early.
+func endFocusMode(ctx context.Context) {
+	app := GetApp()
+	if app == nil || app.EndFocusModeHandler == nil {
+		return
+	}
+	if _, err := app.EndFocusModeHandler.Handle(ctx, scheduleCommands.EndFocusModeCommand{UserID: app.CurrentUserID}); err != nil {
+		fmt.Printf("  Could not end focus mode: %v\n", err)
+		return
+	}
+	fmt.Println("  Schedule protection lifted")
+}
+
 func runTimer(ctx context.Context, label string, duration time.Duration, endTime time.Time) bool {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
@@ -172,6 +238,8 @@ func init() {
 	focusCmd.Flags().IntVarP(&focusDuration, "duration", "d", 25, "focus duration in minutes")
 	focusCmd.Flags().IntVarP(&focusBreak, "break", "b", 0, "break duration in minutes (0 = no break)")
 	focusCmd.Flags().StringVarP(&focusTask, "task", "t", "", "task ID to focus on")
+	focusCmd.Flags().BoolVar(&focusProtect, "protect", false, "keep other work out of the schedule during the session")
+	focusCmd.Flags().BoolVar(&focusQuiet, "quiet", false, "protect the schedule and hold back reminders during the session")
 
 	rootCmd.AddCommand(focusCmd)
 }
@@ -14,6 +14,7 @@ var (
 	duration      int
 	preferredTime string
 	timesPerWeek  int
+	timezone      string
 )
 
 var createCmd = &cobra.Command{
@@ -38,7 +39,8 @@ Preferred times:
 Examples:
   orbita habit create "Morning meditation" -f daily -d 15
   orbita habit create "Exercise" -f weekdays -d 45 -t morning
-  orbita habit create "Read" -f custom --times 3 -d 30`,
+  orbita habit create "Read" -f custom --times 3 -d 30
+  orbita habit create "Call home" -f weekdays --timezone Europe/Lisbon`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		app := cli.GetApp()
@@ -57,6 +59,7 @@ Examples:
 			DurationMins:  duration,
 			PreferredTime: preferredTime,
 			TimesPerWeek:  timesPerWeek,
+			Timezone:      timezone,
 		}
 
 		result, err := app.CreateHabitHandler.Handle(cmd.Context(), createCmd)
@@ -74,6 +77,9 @@ Examples:
 		if frequency == "custom" && timesPerWeek > 0 {
 			fmt.Printf("  Times per week: %d\n", timesPerWeek)
 		}
+		if timezone != "" {
+			fmt.Printf("  Time zone: %s\n", timezone)
+		}
 
 		return nil
 	},
@@ -84,6 +90,7 @@ func init() {
 	createCmd.Flags().IntVarP(&duration, "duration", "d", 15, "session duration in minutes")
 	createCmd.Flags().StringVarP(&preferredTime, "time", "t", "anytime", "preferred time of day (morning, afternoon, evening, night, anytime)")
 	createCmd.Flags().IntVar(&timesPerWeek, "times", 0, "times per week (for custom frequency)")
+	createCmd.Flags().StringVar(&timezone, "timezone", "", "IANA time zone the habit's days are counted in (default: your current one)")
 }
 
 // parseDuration converts minutes to time.Duration
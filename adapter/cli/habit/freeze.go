@@ -0,0 +1,94 @@
+package habit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/habits/application/commands"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var freezeUntil string
+
+var freezeCmd = &cobra.Command{
+	Use:   "freeze [habit-id]",
+	Short: "Pause a habit until a date",
+	Long: `Pause a habit through a date, for example while travelling or ill.
+
+A frozen habit is not due and its streak is kept. Freezing a frozen habit
+moves the end of the pause.
+
+Examples:
+  orbita habit freeze abc123 --until 2024-03-15`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.FreezeHabitHandler == nil {
+			fmt.Println("Habit freezing requires database connection.")
+			fmt.Println("Start services with: docker-compose up -d")
+			return nil
+		}
+
+		habitID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid habit ID: %w", err)
+		}
+		until, err := time.Parse("2006-01-02", freezeUntil)
+		if err != nil {
+			return fmt.Errorf("invalid --until date (use YYYY-MM-DD): %w", err)
+		}
+
+		err = app.FreezeHabitHandler.Handle(cmd.Context(), commands.FreezeHabitCommand{
+			HabitID: habitID,
+			UserID:  app.CurrentUserID,
+			Until:   until,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to freeze habit: %w", err)
+		}
+
+		fmt.Printf("Habit frozen through %s.\n", freezeUntil)
+		return nil
+	},
+}
+
+var unfreezeCmd = &cobra.Command{
+	Use:   "unfreeze [habit-id]",
+	Short: "Resume a frozen habit",
+	Long: `Lift a habit's pause so it is due again from today.
+
+Examples:
+  orbita habit unfreeze abc123`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.UnfreezeHabitHandler == nil {
+			fmt.Println("Habit freezing requires database connection.")
+			fmt.Println("Start services with: docker-compose up -d")
+			return nil
+		}
+
+		habitID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid habit ID: %w", err)
+		}
+
+		err = app.UnfreezeHabitHandler.Handle(cmd.Context(), commands.UnfreezeHabitCommand{
+			HabitID: habitID,
+			UserID:  app.CurrentUserID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to unfreeze habit: %w", err)
+		}
+
+		fmt.Println("Habit unfrozen.")
+		return nil
+	},
+}
+
+func init() {
+	freezeCmd.Flags().StringVar(&freezeUntil, "until", "", "last paused day (YYYY-MM-DD)")
+	_ = freezeCmd.MarkFlagRequired("until")
+}
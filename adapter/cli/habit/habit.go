@@ -16,4 +16,9 @@ func init() {
 	Cmd.AddCommand(listCmd)
 	Cmd.AddCommand(logCmd)
 	Cmd.AddCommand(archiveCmd)
+	Cmd.AddCommand(skipCmd)
+	Cmd.AddCommand(freezeCmd)
+	Cmd.AddCommand(unfreezeCmd)
+	Cmd.AddCommand(recommendCmd)
+	Cmd.AddCommand(tagCmd)
 }
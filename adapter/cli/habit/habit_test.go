@@ -1,15 +1,18 @@
 package habit
 
 import (
+	"bytes"
 	"context"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	internalApp "github.com/felixgeelhaar/orbita/internal/app"
 	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/pkg/config"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +25,14 @@ var testUserID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
 // setupLocalModeTestApp creates a test application with SQLite for integration tests.
 func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 	t.Helper()
+	_, cliApp, cleanup := setupLocalModeTestContainer(t)
+	return cliApp, cleanup
+}
+
+// setupLocalModeTestContainer is setupLocalModeTestApp that also returns the
+// container, for checks the CLI app has no handler for.
+func setupLocalModeTestContainer(t *testing.T) (*internalApp.Container, *cli.App, func()) {
+	t.Helper()
 
 	// Create temp directory for SQLite DB
 	tmpDir, err := os.MkdirTemp("", "habit-cli-test-*")
@@ -42,7 +53,7 @@ func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 		Level: slog.LevelError,
 	}))
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	container, err := internalApp.NewLocalContainer(ctx, cfg, logger)
 	require.NoError(t, err)
 
@@ -78,13 +89,16 @@ func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 		container.BillingService,
 	)
 	cliApp.SetCurrentUserID(testUserID)
+	cliApp.SetRecommendHabitTimeHandler(container.RecommendHabitTimeHandler)
+	cliApp.SetBulkLogCompletionHandler(container.BulkLogCompletionHandler)
+	cliApp.SetHabitPauseHandlers(container.SkipHabitHandler, container.FreezeHabitHandler, container.UnfreezeHabitHandler)
 
 	cleanup := func() {
 		container.Close()
 		os.RemoveAll(tmpDir)
 	}
 
-	return cliApp, cleanup
+	return container, cliApp, cleanup
 }
 
 func TestCreateCmd_CreatesHabit(t *testing.T) {
@@ -94,7 +108,7 @@ func TestCreateCmd_CreatesHabit(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	frequency = "daily"
@@ -120,6 +134,36 @@ func TestCreateCmd_CreatesHabit(t *testing.T) {
 	assert.Equal(t, "morning", habits[0].PreferredTime)
 }
 
+func TestCreateCmd_WithTimezone(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	// Reset flags
+	frequency = "weekdays"
+	duration = 15
+	preferredTime = "anytime"
+	timesPerWeek = 0
+	timezone = "Europe/Lisbon"
+	defer func() { timezone = "" }()
+
+	createCmd.SetContext(ctx)
+
+	err := createCmd.RunE(createCmd, []string{"Call home"})
+	require.NoError(t, err)
+
+	habits, err := app.ListHabitsHandler.Handle(ctx, habitQueries.ListHabitsQuery{
+		UserID: app.CurrentUserID,
+	})
+	require.NoError(t, err)
+	require.Len(t, habits, 1)
+	assert.Equal(t, "Europe/Lisbon", habits[0].Timezone)
+}
+
 func TestCreateCmd_WithCustomFrequency(t *testing.T) {
 	app, cleanup := setupLocalModeTestApp(t)
 	defer cleanup()
@@ -127,7 +171,7 @@ func TestCreateCmd_WithCustomFrequency(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	frequency = "custom"
@@ -160,7 +204,7 @@ func TestListCmd_ShowsHabits(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create some habits
 	frequency = "daily"
@@ -195,7 +239,7 @@ func TestListCmd_EmptyList(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Verify empty list
 	habits, err := app.ListHabitsHandler.Handle(ctx, habitQueries.ListHabitsQuery{
@@ -226,7 +270,7 @@ func TestLogCmd_LogsCompletion(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create a habit first
 	frequency = "daily"
@@ -259,6 +303,45 @@ func TestLogCmd_LogsCompletion(t *testing.T) {
 	assert.True(t, habits[0].CompletedToday)
 }
 
+func TestLogCmd_LogsSeveralHabits(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	frequency = "daily"
+	duration = 15
+	preferredTime = "anytime"
+	timesPerWeek = 0
+	createCmd.SetContext(ctx)
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Meditate"}))
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Stretch"}))
+
+	habits, err := app.ListHabitsHandler.Handle(ctx, habitQueries.ListHabitsQuery{
+		UserID: app.CurrentUserID,
+	})
+	require.NoError(t, err)
+	require.Len(t, habits, 2)
+
+	// Log the first habit alone, then both together: the first is skipped.
+	logCmd.SetContext(ctx)
+	require.NoError(t, logCmd.RunE(logCmd, []string{habits[0].ID.String()}))
+	require.NoError(t, logCmd.RunE(logCmd, []string{habits[0].ID.String(), habits[1].ID.String()}))
+
+	habits, err = app.ListHabitsHandler.Handle(ctx, habitQueries.ListHabitsQuery{
+		UserID: app.CurrentUserID,
+	})
+	require.NoError(t, err)
+	require.Len(t, habits, 2)
+	for _, habit := range habits {
+		assert.Equal(t, 1, habit.TotalDone, habit.Name)
+		assert.True(t, habit.CompletedToday, habit.Name)
+	}
+}
+
 func TestLogCmd_InvalidHabitID(t *testing.T) {
 	app, cleanup := setupLocalModeTestApp(t)
 	defer cleanup()
@@ -266,7 +349,7 @@ func TestLogCmd_InvalidHabitID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	logCmd.SetContext(ctx)
 	err := logCmd.RunE(logCmd, []string{"not-a-uuid"})
@@ -274,6 +357,40 @@ func TestLogCmd_InvalidHabitID(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid habit ID")
 }
 
+func TestRecommendCmd_KeepsTimeWithoutHistory(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	frequency = "daily"
+	duration = 15
+	preferredTime = "evening"
+	timesPerWeek = 0
+	createCmd.SetContext(ctx)
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Evening Walk"}))
+
+	habits, err := app.ListHabitsHandler.Handle(ctx, habitQueries.ListHabitsQuery{
+		UserID: app.CurrentUserID,
+	})
+	require.NoError(t, err)
+	require.Len(t, habits, 1)
+
+	var out bytes.Buffer
+	recommendCmd.SetOut(&out)
+	recommendCmd.SetContext(ctx)
+	defer recommendCmd.SetOut(nil)
+	recommendApply = true
+	defer func() { recommendApply = false }()
+
+	require.NoError(t, recommendCmd.RunE(recommendCmd, []string{habits[0].ID.String()}))
+	assert.Contains(t, out.String(), "Analyzed 0 completions")
+	assert.Contains(t, out.String(), "Keep the current preferred time: evening")
+}
+
 func TestArchiveCmd_ArchivesHabit(t *testing.T) {
 	app, cleanup := setupLocalModeTestApp(t)
 	defer cleanup()
@@ -281,7 +398,7 @@ func TestArchiveCmd_ArchivesHabit(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create a habit first
 	frequency = "daily"
@@ -321,7 +438,7 @@ func TestArchiveCmd_InvalidHabitID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	archiveCmd.SetContext(ctx)
 	err := archiveCmd.RunE(archiveCmd, []string{"invalid-uuid"})
@@ -329,10 +446,93 @@ func TestArchiveCmd_InvalidHabitID(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid habit ID")
 }
 
+func TestSkipCmd_SkipsHabitForToday(t *testing.T) {
+	container, app, cleanup := setupLocalModeTestContainer(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	frequency = "daily"
+	duration = 15
+	preferredTime = "anytime"
+	timesPerWeek = 0
+	createCmd.SetContext(ctx)
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Habit to Skip"}))
+
+	due, err := container.GetDueHabitsHandler.Handle(ctx, habitQueries.GetDueHabitsQuery{UserID: testUserID})
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	habitID := due[0].ID.String()
+
+	skipDate = ""
+	skipCmd.SetContext(ctx)
+	require.NoError(t, skipCmd.RunE(skipCmd, []string{habitID}))
+
+	due, err = container.GetDueHabitsHandler.Handle(ctx, habitQueries.GetDueHabitsQuery{UserID: testUserID})
+	require.NoError(t, err)
+	assert.Empty(t, due)
+}
+
+func TestSkipCmd_InvalidDate(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	skipDate = "tomorrow"
+	defer func() { skipDate = "" }()
+	skipCmd.SetContext(sharedApplication.WithPrincipal(context.Background(), testUserID))
+	err := skipCmd.RunE(skipCmd, []string{uuid.New().String()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid date format")
+}
+
+func TestFreezeCmd_FreezesAndUnfreezesHabit(t *testing.T) {
+	container, app, cleanup := setupLocalModeTestContainer(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	frequency = "daily"
+	duration = 15
+	preferredTime = "anytime"
+	timesPerWeek = 0
+	createCmd.SetContext(ctx)
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Habit to Freeze"}))
+
+	due, err := container.GetDueHabitsHandler.Handle(ctx, habitQueries.GetDueHabitsQuery{UserID: testUserID})
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	habitID := due[0].ID.String()
+
+	freezeUntil = time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	defer func() { freezeUntil = "" }()
+	freezeCmd.SetContext(ctx)
+	require.NoError(t, freezeCmd.RunE(freezeCmd, []string{habitID}))
+
+	due, err = container.GetDueHabitsHandler.Handle(ctx, habitQueries.GetDueHabitsQuery{UserID: testUserID})
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	unfreezeCmd.SetContext(ctx)
+	require.NoError(t, unfreezeCmd.RunE(unfreezeCmd, []string{habitID}))
+
+	due, err = container.GetDueHabitsHandler.Handle(ctx, habitQueries.GetDueHabitsQuery{UserID: testUserID})
+	require.NoError(t, err)
+	assert.Len(t, due, 1)
+}
+
 func TestCreateCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	frequency = "daily"
 	createCmd.SetContext(ctx)
 
@@ -344,7 +544,7 @@ func TestCreateCmd_NoApp(t *testing.T) {
 func TestListCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	listCmd.SetContext(ctx)
 
 	// The command returns nil but prints a message
@@ -32,9 +32,13 @@ Filter Options:
   --broken-streak Show only habits with broken streaks
 
 Sort Options:
-  --sort          Sort by field (streak, best_streak, name, created_at)
+  --sort          Sort by field (streak, best_streak, name, created_at), or by
+                  several, e.g. streak:desc,name:asc
   --order         Sort order (asc, desc)
 
+Without --sort, habits are listed in your default order, set with
+'orbita settings set sort.habits' (default newest first).
+
 Examples:
   orbita habit list                     # All active habits
   orbita habit list --due               # Habits due today
@@ -155,6 +159,6 @@ func init() {
 	listCmd.Flags().BoolVar(&brokenStreak, "broken-streak", false, "show only habits with broken streaks")
 
 	// Sorting
-	listCmd.Flags().StringVar(&habitSortBy, "sort", "", "sort by field (streak, best_streak, name, created_at)")
+	listCmd.Flags().StringVar(&habitSortBy, "sort", "", "sort by field (streak, best_streak, name, created_at), e.g. streak:desc,name:asc")
 	listCmd.Flags().StringVar(&habitSortOrder, "order", "", "sort order (asc, desc)")
 }
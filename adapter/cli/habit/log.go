@@ -14,15 +14,20 @@ var (
 )
 
 var logCmd = &cobra.Command{
-	Use:   "log [habit-id]",
+	Use:   "log [habit-id...]",
 	Short: "Log a habit completion",
 	Long: `Log that you've completed a habit session today.
 
+With several habit IDs, all completions are logged in one transaction.
+Habits that cannot be logged, such as ones already completed today, are
+reported and the rest are still logged.
+
 Examples:
   orbita habit log abc123
-  orbita habit log abc123 --notes "Great session!"`,
+  orbita habit log abc123 --notes "Great session!"
+  orbita habit log abc123 def456 ghi789`,
 	Aliases: []string{"done", "complete"},
-	Args:    cobra.ExactArgs(1),
+	Args:    cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		app := cli.GetApp()
 		if app == nil || app.LogCompletionHandler == nil {
@@ -30,6 +35,9 @@ Examples:
 			fmt.Println("Start services with: docker-compose up -d")
 			return nil
 		}
+		if len(args) > 1 {
+			return logBulk(cmd, app, args)
+		}
 
 		habitID, err := uuid.Parse(args[0])
 		if err != nil {
@@ -50,17 +58,62 @@ Examples:
 		fmt.Printf("Logged completion for habit!\n")
 		fmt.Printf("  Streak: %d\n", result.Streak)
 		fmt.Printf("  Total completions: %d\n", result.TotalDone)
-		if app.AdjustHabitFrequencyHandler != nil {
-			_, _ = app.AdjustHabitFrequencyHandler.Handle(cmd.Context(), commands.AdjustHabitFrequencyCommand{
-				UserID:     app.CurrentUserID,
-				WindowDays: 14,
-			})
-		}
+		adjustFrequencies(cmd, app)
 
 		return nil
 	},
 }
 
+// logBulk logs a completion for every listed habit in one transaction.
+func logBulk(cmd *cobra.Command, app *cli.App, args []string) error {
+	if app.BulkLogCompletionHandler == nil {
+		return fmt.Errorf("application not initialized - database connection required")
+	}
+
+	habitIDs := make([]uuid.UUID, 0, len(args))
+	for _, arg := range args {
+		habitID, err := uuid.Parse(arg)
+		if err != nil {
+			return fmt.Errorf("invalid habit ID %q: %w", arg, err)
+		}
+		habitIDs = append(habitIDs, habitID)
+	}
+
+	result, err := app.BulkLogCompletionHandler.Handle(cmd.Context(), commands.BulkLogCompletionCommand{
+		UserID:   app.CurrentUserID,
+		HabitIDs: habitIDs,
+		Notes:    notes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to log completions: %w", err)
+	}
+
+	logged := 0
+	for _, item := range result.Results {
+		if item.Err != nil {
+			fmt.Printf("  %s  failed: %v\n", item.HabitID, item.Err)
+			continue
+		}
+		logged++
+		fmt.Printf("  %s  streak %d, %d total\n", item.HabitID, item.Streak, item.TotalDone)
+	}
+	fmt.Printf("\nLogged %d of %d habits.\n", logged, len(result.Results))
+	if logged > 0 {
+		adjustFrequencies(cmd, app)
+	}
+	return nil
+}
+
+// adjustFrequencies re-tunes habit frequencies after new completions.
+func adjustFrequencies(cmd *cobra.Command, app *cli.App) {
+	if app.AdjustHabitFrequencyHandler != nil {
+		_, _ = app.AdjustHabitFrequencyHandler.Handle(cmd.Context(), commands.AdjustHabitFrequencyCommand{
+			UserID:     app.CurrentUserID,
+			WindowDays: 14,
+		})
+	}
+}
+
 func init() {
 	logCmd.Flags().StringVarP(&notes, "notes", "n", "", "notes about this session")
 }
@@ -0,0 +1,82 @@
+package habit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/habits/application/commands"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recommendWindowDays int
+	recommendApply      bool
+)
+
+var recommendCmd = &cobra.Command{
+	Use:   "recommend-time [habit-id]",
+	Short: "Recommend the best time of day for a habit",
+	Long: `Analyze when a habit has been completed recently and recommend the
+time of day to schedule it. Use --apply to store the recommendation as the
+habit's preferred time, which the scheduler uses for habit sessions.
+
+Examples:
+  orbita habit recommend-time abc123
+  orbita habit recommend-time abc123 --days 30 --apply`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.RecommendHabitTimeHandler == nil {
+			fmt.Fprintln(cmd.OutOrStdout(), "Habit time recommendations require database connection.")
+			fmt.Fprintln(cmd.OutOrStdout(), "Start services with: docker-compose up -d")
+			return nil
+		}
+
+		habitID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid habit ID: %w", err)
+		}
+
+		result, err := app.RecommendHabitTimeHandler.Handle(cmd.Context(), commands.RecommendHabitTimeCommand{
+			HabitID:    habitID,
+			UserID:     app.CurrentUserID,
+			WindowDays: recommendWindowDays,
+			Apply:      recommendApply,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to recommend habit time: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Analyzed %d completions\n", result.Completions)
+		if result.Recommended == result.Current {
+			fmt.Fprintf(out, "Keep the current preferred time: %s\n", result.Current)
+			return nil
+		}
+
+		fmt.Fprintf(out, "Recommended: %s (%s-%s), %.0f%% of completions\n",
+			result.Recommended,
+			formatTimeOfDay(result.WindowStart),
+			formatTimeOfDay(result.WindowEnd),
+			result.Confidence*100,
+		)
+		fmt.Fprintf(out, "Most often completed around %02d:00\n", result.PeakHour)
+		if result.Applied {
+			fmt.Fprintf(out, "Preferred time updated from %s to %s.\n", result.Current, result.Recommended)
+		} else {
+			fmt.Fprintln(out, "Run with --apply to use this as the preferred time.")
+		}
+		return nil
+	},
+}
+
+func formatTimeOfDay(offset time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(offset.Hours()), int(offset.Minutes())%60)
+}
+
+func init() {
+	recommendCmd.Flags().IntVar(&recommendWindowDays, "days", commands.DefaultRecommendationWindowDays, "days of completion history to analyze")
+	recommendCmd.Flags().BoolVar(&recommendApply, "apply", false, "store the recommendation as the habit's preferred time")
+}
@@ -0,0 +1,66 @@
+package habit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/habits/application/commands"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var skipDate string
+
+var skipCmd = &cobra.Command{
+	Use:   "skip [habit-id]",
+	Short: "Skip a habit for a day",
+	Long: `Skip a habit for a day without breaking its streak.
+
+A skipped day is not due and does not count as missed. Defaults to today.
+
+Examples:
+  orbita habit skip abc123
+  orbita habit skip abc123 --date 2024-03-01`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SkipHabitHandler == nil {
+			fmt.Println("Habit skipping requires database connection.")
+			fmt.Println("Start services with: docker-compose up -d")
+			return nil
+		}
+
+		habitID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid habit ID: %w", err)
+		}
+
+		skipCmd := commands.SkipHabitCommand{
+			HabitID: habitID,
+			UserID:  app.CurrentUserID,
+		}
+		if skipDate != "" {
+			date, err := time.Parse("2006-01-02", skipDate)
+			if err != nil {
+				return fmt.Errorf("invalid date format (use YYYY-MM-DD): %w", err)
+			}
+			skipCmd.Date = date
+		}
+
+		if err := app.SkipHabitHandler.Handle(cmd.Context(), skipCmd); err != nil {
+			return fmt.Errorf("failed to skip habit: %w", err)
+		}
+
+		if skipDate != "" {
+			fmt.Printf("Skipped habit on %s.\n", skipDate)
+		} else {
+			fmt.Println("Skipped habit for today.")
+		}
+		return nil
+	},
+}
+
+func init() {
+	skipCmd.Flags().StringVar(&skipDate, "date", "", "day to skip (YYYY-MM-DD, default today)")
+}
@@ -0,0 +1,86 @@
+package habit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/habits/application/commands"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagAdd    []string
+	tagRemove []string
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag [habit-id...]",
+	Short: "Add or remove tags on several habits at once",
+	Long: `Apply the same tag changes to every listed habit in one transaction.
+Habits that cannot be tagged are reported and the rest are still updated.
+
+Examples:
+  orbita habit tag --add health abc123 def456
+  orbita habit tag --add health --remove evening abc123 def456`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.BulkTagHabitsHandler == nil {
+			return fmt.Errorf("application not initialized - database connection required")
+		}
+
+		habitIDs := make([]uuid.UUID, 0, len(args))
+		for _, arg := range args {
+			habitID, err := uuid.Parse(arg)
+			if err != nil {
+				return fmt.Errorf("invalid habit ID %q: %w", arg, err)
+			}
+			habitIDs = append(habitIDs, habitID)
+		}
+
+		result, err := app.BulkTagHabitsHandler.Handle(cmd.Context(), commands.BulkTagHabitsCommand{
+			UserID:   app.CurrentUserID,
+			HabitIDs: habitIDs,
+			Add:      tagAdd,
+			Remove:   tagRemove,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to tag habits: %w", err)
+		}
+
+		updated := 0
+		for _, item := range result.Results {
+			if item.Err != nil {
+				fmt.Printf("  %s  failed: %v\n", item.HabitID, item.Err)
+				continue
+			}
+			if len(item.Added) == 0 && len(item.Removed) == 0 {
+				fmt.Printf("  %s  unchanged [%s]\n", item.HabitID, strings.Join(item.Tags, ", "))
+				continue
+			}
+			updated++
+			fmt.Printf("  %s  %s [%s]\n", item.HabitID, describeTagChanges(item.Added, item.Removed), strings.Join(item.Tags, ", "))
+		}
+		fmt.Printf("\nUpdated %d of %d habits.\n", updated, len(result.Results))
+		return nil
+	},
+}
+
+// describeTagChanges renders added and removed tags as "+work -old".
+func describeTagChanges(added, removed []string) string {
+	parts := make([]string, 0, len(added)+len(removed))
+	for _, tag := range added {
+		parts = append(parts, "+"+tag)
+	}
+	for _, tag := range removed {
+		parts = append(parts, "-"+tag)
+	}
+	return strings.Join(parts, " ")
+}
+
+func init() {
+	tagCmd.Flags().StringSliceVar(&tagAdd, "add", nil, "Tags to add (repeat or separate with commas)")
+	tagCmd.Flags().StringSliceVar(&tagRemove, "remove", nil, "Tags to remove (repeat or separate with commas)")
+}
@@ -0,0 +1,236 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	habitCommands "github.com/felixgeelhaar/orbita/internal/habits/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/spf13/cobra"
+)
+
+var habiticaCmd = &cobra.Command{
+	Use:   "habitica <file>",
+	Short: "Import dailies and to-dos from a Habitica export",
+	Long: `Import dailies as habits and to-dos as tasks from a Habitica export.
+
+Accepts the user data JSON export (Settings > Export Data > User Data > JSON)
+or the response of the Habitica API's /tasks/user endpoint. Current streaks
+are kept by backfilling the completions they stand for. Completed to-dos,
+rewards and Habitica habits (+/- counters) are skipped.
+
+Examples:
+  orbita import habitica habitica-user-data.json
+  orbita import habitica habitica-user-data.json --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(cmd, args[0], ParseHabitica)
+	},
+}
+
+// habiticaTask is a task from a Habitica export.
+type habiticaTask struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Notes string `json:"notes"`
+	// Priority is the difficulty: 0.1 trivial, 1 easy, 1.5 medium, 2 hard.
+	Priority  float64         `json:"priority"`
+	Date      string          `json:"date"`
+	Completed bool            `json:"completed"`
+	Frequency string          `json:"frequency"`
+	EveryX    int             `json:"everyX"`
+	Repeat    map[string]bool `json:"repeat"`
+	Streak    int             `json:"streak"`
+	Checklist []struct {
+		Text string `json:"text"`
+	} `json:"checklist"`
+}
+
+// Habitica repeat keys by weekday.
+var habiticaWeekdays = map[time.Weekday]string{
+	time.Sunday: "su", time.Monday: "m", time.Tuesday: "t", time.Wednesday: "w",
+	time.Thursday: "th", time.Friday: "f", time.Saturday: "s",
+}
+
+// ParseHabitica maps a Habitica export onto habit and task commands.
+func ParseHabitica(r io.Reader) (*Result, error) {
+	return parseHabitica(r, time.Now())
+}
+
+func parseHabitica(r io.Reader, now time.Time) (*Result, error) {
+	var export struct {
+		// User data export
+		Tasks *struct {
+			Habits  []habiticaTask `json:"habits"`
+			Dailys  []habiticaTask `json:"dailys"`
+			Todos   []habiticaTask `json:"todos"`
+			Rewards []habiticaTask `json:"rewards"`
+		} `json:"tasks"`
+		// API response
+		Data []habiticaTask `json:"data"`
+	}
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, err
+	}
+
+	tasks := export.Data
+	if export.Tasks != nil {
+		buckets := []struct {
+			kind  string
+			tasks []habiticaTask
+		}{
+			{"habit", export.Tasks.Habits},
+			{"daily", export.Tasks.Dailys},
+			{"todo", export.Tasks.Todos},
+			{"reward", export.Tasks.Rewards},
+		}
+		for _, bucket := range buckets {
+			for _, t := range bucket.tasks {
+				t.Type = bucket.kind
+				tasks = append(tasks, t)
+			}
+		}
+	} else if export.Data == nil {
+		return nil, errors.New("not a Habitica export: no tasks or data")
+	}
+
+	result := &Result{}
+	counters := 0
+	for _, t := range tasks {
+		name := strings.TrimSpace(t.Text)
+		switch {
+		case name == "":
+			result.Skipped++
+		case t.Type == "daily":
+			result.Habits = append(result.Habits, mapHabiticaDaily(result, name, t, now))
+		case t.Type == "todo" && !t.Completed:
+			result.Tasks = append(result.Tasks, mapHabiticaTodo(result, name, t))
+		case t.Type == "habit":
+			counters++
+			result.Skipped++
+		default:
+			result.Skipped++
+		}
+	}
+	if counters > 0 {
+		result.warn("habits", "%d Habitica habits skipped; +/- counters have no schedule to map to an Orbita habit", counters)
+	}
+	return result, nil
+}
+
+func mapHabiticaTodo(result *Result, name string, t habiticaTask) commands.CreateTaskCommand {
+	c := commands.CreateTaskCommand{
+		Title:       name,
+		Description: t.Notes,
+	}
+	if t.ID != "" {
+		c.ExternalID = "habitica:" + t.ID
+	}
+	// Habitica has difficulty rather than urgency; harder to-dos rank higher.
+	switch {
+	case t.Priority >= 2:
+		c.Priority = "high"
+	case t.Priority >= 1.5:
+		c.Priority = "medium"
+	case t.Priority > 0:
+		c.Priority = "low"
+	}
+	if t.Date != "" {
+		if due, ok := parseDate(t.Date); ok {
+			c.DueDate = &due
+		} else {
+			result.warn(name, "due date %q not understood", t.Date)
+		}
+	}
+	if len(t.Checklist) > 0 {
+		result.warn(name, "checklist with %d items not imported", len(t.Checklist))
+	}
+	return c
+}
+
+func mapHabiticaDaily(result *Result, name string, t habiticaTask, now time.Time) habitCommands.CreateHabitCommand {
+	c := habitCommands.CreateHabitCommand{
+		Name:         name,
+		Description:  t.Notes,
+		Frequency:    "daily",
+		DurationMins: DefaultHabitMinutes,
+	}
+	every := t.EveryX
+	if every < 1 {
+		every = 1
+	}
+
+	// isDue reports whether Habitica expects the daily on a day, so the
+	// streak can be walked back over due days only.
+	isDue := func(time.Time) bool { return true }
+	step := 1
+	switch t.Frequency {
+	case "", "daily":
+		if every > 1 {
+			c.Frequency = "custom"
+			c.TimesPerWeek = max(1, 7/every)
+			step = every
+			result.warn(name, "repeats every %d days; imported as %d times per week", every, c.TimesPerWeek)
+		}
+	case "weekly":
+		isDue = func(day time.Time) bool { return t.Repeat[habiticaWeekdays[day.Weekday()]] }
+		c.Frequency, c.TimesPerWeek = weeklyFrequency(t.Repeat)
+		if every > 1 {
+			result.warn(name, "repeats every %d weeks; imported as every week", every)
+		}
+	default:
+		c.Frequency = "weekly"
+		result.warn(name, "%s repeats not supported; imported as weekly without its streak", t.Frequency)
+		return c
+	}
+
+	if len(t.Checklist) > 0 {
+		result.warn(name, "checklist with %d items not imported", len(t.Checklist))
+	}
+
+	// Backfill one completion per due day of the current streak, ending today
+	// if the daily is already checked off and yesterday otherwise.
+	day := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, now.Location())
+	if !t.Completed {
+		day = day.AddDate(0, 0, -step)
+	}
+	streak := t.Streak
+	if t.Completed && streak == 0 {
+		streak = 1
+	}
+	for len(c.CompletedOn) < streak && now.Sub(day) < 2*365*24*time.Hour {
+		if isDue(day) {
+			c.CompletedOn = append(c.CompletedOn, day)
+		}
+		day = day.AddDate(0, 0, -step)
+	}
+	return c
+}
+
+// weeklyFrequency maps Habitica repeat days onto the closest Orbita frequency.
+func weeklyFrequency(repeat map[string]bool) (string, int) {
+	days := 0
+	for _, on := range repeat {
+		if on {
+			days++
+		}
+	}
+	weekdays := repeat["m"] && repeat["t"] && repeat["w"] && repeat["th"] && repeat["f"]
+	weekend := repeat["s"] && repeat["su"]
+	switch {
+	case days == 7:
+		return "daily", 0
+	case days == 5 && weekdays:
+		return "weekdays", 0
+	case days == 2 && weekend:
+		return "weekends", 0
+	case days <= 1:
+		return "weekly", 0
+	default:
+		return "custom", days
+	}
+}
@@ -0,0 +1,147 @@
+// Package importer maps task and habit exports from other apps onto Orbita
+// commands, so users can bring their data along when they switch.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	habitCommands "github.com/felixgeelhaar/orbita/internal/habits/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/spf13/cobra"
+)
+
+// DefaultHabitMinutes is the session length given to imported habits; none of
+// the supported apps record one.
+const DefaultHabitMinutes = 15
+
+// Result holds the commands an export maps to. UserID is left unset on every
+// command and filled in when the import runs.
+type Result struct {
+	Tasks  []commands.CreateTaskCommand
+	Habits []habitCommands.CreateHabitCommand
+	// Skipped counts items that were left out on purpose, such as completed tasks.
+	Skipped int
+	// Warnings describe data that could not be carried over.
+	Warnings []string
+}
+
+func (r *Result) warn(item, format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf("%s: %s", item, fmt.Sprintf(format, args...)))
+}
+
+// Cmd groups the import commands.
+var Cmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import tasks and habits from other apps",
+}
+
+var dryRun bool
+
+// runImport parses the export at path and creates what it maps to.
+func runImport(cmd *cobra.Command, path string, parse func(io.Reader) (*Result, error)) error {
+	app := cli.GetApp()
+	if app == nil || app.CreateTaskHandler == nil || app.CreateHabitHandler == nil {
+		fmt.Println("Import requires database connection.")
+		fmt.Println("Start services with: docker-compose up -d")
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	result, err := parse(f)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	out := cmd.OutOrStdout()
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(out, "  warning: %s\n", warning)
+	}
+
+	if dryRun {
+		fmt.Fprintf(out, "Would import %d tasks and %d habits (%d skipped).\n",
+			len(result.Tasks), len(result.Habits), result.Skipped)
+		return nil
+	}
+
+	// Keep going past failures so one bad item does not block the rest.
+	tasks, updated, unchanged, habits, failed := 0, 0, 0, 0, 0
+	for _, c := range result.Tasks {
+		c.UserID = app.CurrentUserID
+		outcome, err := importTask(cmd.Context(), app, c)
+		if err != nil {
+			fmt.Fprintf(out, "  failed: task %q: %v\n", c.Title, err)
+			failed++
+			continue
+		}
+		switch outcome {
+		case commands.ImportUpdated:
+			updated++
+		case commands.ImportUnchanged:
+			unchanged++
+		default:
+			tasks++
+		}
+	}
+	for _, c := range result.Habits {
+		c.UserID = app.CurrentUserID
+		if _, err := app.CreateHabitHandler.Handle(cmd.Context(), c); err != nil {
+			fmt.Fprintf(out, "  failed: habit %q: %v\n", c.Name, err)
+			failed++
+			continue
+		}
+		habits++
+	}
+
+	fmt.Fprintf(out, "Imported %d tasks and %d habits (%d skipped, %d failed).\n",
+		tasks, habits, result.Skipped, failed)
+	if updated > 0 || unchanged > 0 {
+		fmt.Fprintf(out, "Matched %d previously imported tasks (%d updated, %d unchanged).\n",
+			updated+unchanged, updated, unchanged)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d items failed to import", failed)
+	}
+	return nil
+}
+
+// importTask creates the task, or updates the one an earlier import of the
+// same item created when the import handler is available.
+func importTask(ctx context.Context, app *cli.App, c commands.CreateTaskCommand) (commands.ImportOutcome, error) {
+	if app.ImportTaskHandler == nil {
+		_, err := app.CreateTaskHandler.Handle(ctx, c)
+		return commands.ImportCreated, err
+	}
+	result, err := app.ImportTaskHandler.Handle(ctx, c)
+	if err != nil {
+		return "", err
+	}
+	return result.Outcome, nil
+}
+
+// parseDate parses the date formats the supported apps export. Dates without
+// a time zone are taken as local time.
+func parseDate(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02", "2 Jan 2006", "Jan 2 2006"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func init() {
+	Cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "show what would be imported without creating anything")
+
+	Cmd.AddCommand(todoistCmd)
+	Cmd.AddCommand(habiticaCmd)
+}
@@ -0,0 +1,308 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	internalApp "github.com/felixgeelhaar/orbita/internal/app"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openSample(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func localDate(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, time.Local)
+}
+
+func TestParseTodoist_CSV(t *testing.T) {
+	result, err := ParseTodoist(openSample(t, "todoist.csv"))
+	require.NoError(t, err)
+
+	require.Len(t, result.Tasks, 3)
+	assert.Empty(t, result.Habits)
+	assert.Equal(t, 1, result.Skipped, "the note is skipped")
+
+	roadmap := result.Tasks[0]
+	assert.Equal(t, "Draft roadmap", roadmap.Title)
+	assert.Equal(t, "Cover the three big bets", roadmap.Description)
+	assert.Equal(t, "urgent", roadmap.Priority)
+	require.NotNil(t, roadmap.DueDate)
+	assert.Equal(t, localDate(2024, time.July, 15, 0, 0), *roadmap.DueDate)
+	assert.Equal(t, 90, roadmap.DurationMinutes)
+	assert.Equal(t, "todoist-csv:draft roadmap", roadmap.ExternalID)
+
+	feedback := result.Tasks[1]
+	assert.Equal(t, "Collect feedback", feedback.Title)
+	assert.Equal(t, "high", feedback.Priority)
+	assert.Nil(t, feedback.DueDate)
+
+	venue := result.Tasks[2]
+	assert.Equal(t, "", venue.Priority, "p4 is Todoist's default priority")
+	require.NotNil(t, venue.DueDate)
+	assert.Equal(t, localDate(2024, time.August, 15, 0, 0), *venue.DueDate)
+	assert.Zero(t, venue.DurationMinutes)
+
+	assert.Equal(t, []string{
+		`Collect feedback: due date "every monday" not understood`,
+		"Collect feedback: subtask imported as a top-level task",
+		"Book offsite venue: duration in days not imported",
+	}, result.Warnings)
+}
+
+func TestParseTodoist_JSON(t *testing.T) {
+	result, err := ParseTodoist(openSample(t, "todoist_rest.json"))
+	require.NoError(t, err)
+
+	require.Len(t, result.Tasks, 2)
+	assert.Equal(t, 1, result.Skipped, "completed tasks are skipped")
+
+	passport := result.Tasks[0]
+	assert.Equal(t, "Renew passport", passport.Title)
+	assert.Equal(t, "Bring two photos", passport.Description)
+	assert.Equal(t, "urgent", passport.Priority, "API priority 4 is p1")
+	require.NotNil(t, passport.DueDate)
+	assert.Equal(t, localDate(2024, time.September, 1, 0, 0), *passport.DueDate)
+	assert.Equal(t, 45, passport.DurationMinutes)
+	assert.Equal(t, "todoist:2995104339", passport.ExternalID)
+
+	plants := result.Tasks[1]
+	assert.Equal(t, "", plants.Priority)
+	require.NotNil(t, plants.DueDate)
+	assert.Equal(t, localDate(2024, time.August, 20, 18, 0), *plants.DueDate)
+
+	assert.Equal(t, []string{
+		"Renew passport: labels errands not imported",
+		`Water the plants: recurrence "every day at 6pm" not imported; only the next due date is kept`,
+		"Water the plants: subtask imported as a top-level task",
+	}, result.Warnings)
+}
+
+func TestParseTodoist_SyncJSON(t *testing.T) {
+	result, err := ParseTodoist(strings.NewReader(`
+		{"items": [{"content": "Review PR", "priority": 3, "checked": false},
+		           {"content": "Merge PR", "priority": 2, "checked": true}]}`))
+	require.NoError(t, err)
+
+	require.Len(t, result.Tasks, 1)
+	assert.Equal(t, "Review PR", result.Tasks[0].Title)
+	assert.Equal(t, "high", result.Tasks[0].Priority)
+	assert.Equal(t, 1, result.Skipped)
+}
+
+func TestParseTodoist_CSVRepeatedTitles(t *testing.T) {
+	result, err := ParseTodoist(strings.NewReader("TYPE,CONTENT\ntask,Inbox zero\ntask,Call mom\ntask,inbox zero\n"))
+	require.NoError(t, err)
+
+	require.Len(t, result.Tasks, 3)
+	assert.Equal(t, "todoist-csv:inbox zero", result.Tasks[0].ExternalID)
+	assert.Equal(t, "todoist-csv:call mom", result.Tasks[1].ExternalID)
+	assert.Equal(t, "todoist-csv:inbox zero#2", result.Tasks[2].ExternalID)
+}
+
+func TestParseTodoist_Invalid(t *testing.T) {
+	_, err := ParseTodoist(strings.NewReader("name,when\nsomething,today\n"))
+	assert.ErrorContains(t, err, "missing CONTENT column")
+
+	_, err = ParseTodoist(strings.NewReader(`[{"content": `))
+	assert.Error(t, err)
+}
+
+func TestParseHabitica(t *testing.T) {
+	// A Wednesday
+	now := localDate(2024, time.June, 12, 9, 0)
+	noon := func(day int) time.Time { return localDate(2024, time.June, day, 12, 0) }
+
+	result, err := parseHabitica(openSample(t, "habitica.json"), now)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Skipped, "the +/- habit, the reward and the completed to-do")
+
+	require.Len(t, result.Habits, 3)
+
+	meditate := result.Habits[0]
+	assert.Equal(t, "Meditate", meditate.Name)
+	assert.Equal(t, "10 minutes", meditate.Description)
+	assert.Equal(t, "daily", meditate.Frequency)
+	assert.Equal(t, DefaultHabitMinutes, meditate.DurationMins)
+	// Checked off today, so the streak of 4 ends today
+	assert.Equal(t, []time.Time{noon(12), noon(11), noon(10), noon(9)}, meditate.CompletedOn)
+
+	gym := result.Habits[1]
+	assert.Equal(t, "custom", gym.Frequency)
+	assert.Equal(t, 3, gym.TimesPerWeek)
+	// Not done yet today: the streak covers the last three Mon/Wed/Fri
+	assert.Equal(t, []time.Time{noon(10), noon(7), noon(5)}, gym.CompletedOn)
+
+	rent := result.Habits[2]
+	assert.Equal(t, "weekly", rent.Frequency)
+	assert.Empty(t, rent.CompletedOn)
+
+	require.Len(t, result.Tasks, 2)
+
+	taxes := result.Tasks[0]
+	assert.Equal(t, "File taxes", taxes.Title)
+	assert.Equal(t, "Use last year's folder", taxes.Description)
+	assert.Equal(t, "high", taxes.Priority)
+	require.NotNil(t, taxes.DueDate)
+	assert.True(t, taxes.DueDate.Equal(time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "habitica:5f1c2e7a-3b7e-4f2a-9a61-0c1d2e3f4a01", taxes.ExternalID)
+
+	garage := result.Tasks[1]
+	assert.Equal(t, "low", garage.Priority)
+	assert.Nil(t, garage.DueDate)
+
+	assert.Equal(t, []string{
+		"Gym: checklist with 2 items not imported",
+		"Pay rent: monthly repeats not supported; imported as weekly without its streak",
+		"habits: 1 Habitica habits skipped; +/- counters have no schedule to map to an Orbita habit",
+	}, result.Warnings)
+}
+
+func TestParseHabitica_APIResponse(t *testing.T) {
+	now := localDate(2024, time.June, 12, 9, 0)
+	result, err := parseHabitica(strings.NewReader(`{"success": true, "data": [
+		{"type": "daily", "text": "Stretch", "frequency": "weekly", "everyX": 1, "streak": 0,
+		 "repeat": {"m": true, "t": true, "w": true, "th": true, "f": true, "s": false, "su": false}},
+		{"type": "todo", "text": "Email Ana", "priority": 1.5}
+	]}`), now)
+	require.NoError(t, err)
+
+	require.Len(t, result.Habits, 1)
+	assert.Equal(t, "weekdays", result.Habits[0].Frequency)
+	assert.Empty(t, result.Habits[0].CompletedOn)
+	require.Len(t, result.Tasks, 1)
+	assert.Equal(t, "medium", result.Tasks[0].Priority)
+}
+
+func TestParseHabitica_Invalid(t *testing.T) {
+	_, err := parseHabitica(strings.NewReader(`{"profile": {}}`), time.Now())
+	assert.ErrorContains(t, err, "not a Habitica export")
+}
+
+// setupLocalModeTestApp creates a test application backed by a temporary
+// SQLite database.
+func setupLocalModeTestApp(t *testing.T) *cli.App {
+	t.Helper()
+
+	cfg := &config.Config{
+		AppEnv:         "test",
+		LocalMode:      true,
+		DatabaseDriver: "sqlite",
+		SQLitePath:     filepath.Join(t.TempDir(), "test.db"),
+		LogLevel:       "error",
+		UserID:         uuid.New().String(),
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	container, err := internalApp.NewLocalContainer(context.Background(), cfg, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { container.Close() })
+
+	app := cli.NewApp(
+		container.CreateTaskHandler,
+		container.CompleteTaskHandler,
+		container.ArchiveTaskHandler,
+		container.ListTasksHandler,
+		container.CreateHabitHandler,
+		container.LogCompletionHandler,
+		container.ArchiveHabitHandler,
+		container.AdjustHabitFrequencyHandler,
+		container.ListHabitsHandler,
+		container.CreateMeetingHandler,
+		container.UpdateMeetingHandler,
+		container.ArchiveMeetingHandler,
+		container.MarkMeetingHeldHandler,
+		container.AdjustMeetingCadenceHandler,
+		container.ListMeetingsHandler,
+		container.ListMeetingCandidatesHandler,
+		container.AddBlockHandler,
+		container.CompleteBlockHandler,
+		container.RemoveBlockHandler,
+		container.RescheduleBlockHandler,
+		container.AutoScheduleHandler,
+		container.AutoRescheduleHandler,
+		container.GetScheduleHandler,
+		container.FindAvailableSlotsHandler,
+		container.ListRescheduleAttemptsHandler,
+		container.CaptureInboxItemHandler,
+		container.PromoteInboxItemHandler,
+		container.ListInboxItemsHandler,
+		container.BillingService,
+	)
+	app.SetCurrentUserID(uuid.MustParse(cfg.UserID))
+	app.SetImportTaskHandler(container.ImportTaskHandler)
+	return app
+}
+
+func TestRunImport_ReimportUpdatesTasks(t *testing.T) {
+	app := setupLocalModeTestApp(t)
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), app.CurrentUserID)
+	export := filepath.Join(t.TempDir(), "tasks.json")
+	writeExport := func(content string) {
+		require.NoError(t, os.WriteFile(export, []byte(content), 0o600))
+	}
+	run := func() string {
+		var out bytes.Buffer
+		todoistCmd.SetOut(&out)
+		todoistCmd.SetContext(ctx)
+		require.NoError(t, runImport(todoistCmd, export, ParseTodoist))
+		return out.String()
+	}
+	listTasks := func() []queries.TaskDTO {
+		tasks, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID, IncludeAll: true})
+		require.NoError(t, err)
+		return tasks
+	}
+
+	writeExport(`[
+		{"id": "101", "content": "Renew passport", "priority": 4, "due": {"date": "2024-09-01"}},
+		{"id": "102", "content": "Water the plants", "priority": 1}
+	]`)
+	out := run()
+	assert.Contains(t, out, "Imported 2 tasks and 0 habits")
+	require.Len(t, listTasks(), 2)
+
+	// The passport task was renamed and reprioritized in Todoist, and a new
+	// task was added.
+	writeExport(`[
+		{"id": "101", "content": "Renew passport and ID card", "priority": 3, "due": {"date": "2024-09-01"}},
+		{"id": "102", "content": "Water the plants", "priority": 1},
+		{"id": "103", "content": "Book dentist", "priority": 1}
+	]`)
+	out = run()
+	assert.Contains(t, out, "Imported 1 tasks and 0 habits")
+	assert.Contains(t, out, "Matched 2 previously imported tasks (1 updated, 1 unchanged)")
+
+	tasks := listTasks()
+	require.Len(t, tasks, 3)
+	titles := make(map[string]string, len(tasks))
+	for _, task := range tasks {
+		titles[task.Title] = task.Priority
+	}
+	assert.Equal(t, map[string]string{
+		"Renew passport and ID card": "high",
+		"Water the plants":           "none",
+		"Book dentist":               "none",
+	}, titles)
+}
@@ -0,0 +1,246 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/spf13/cobra"
+)
+
+var todoistCmd = &cobra.Command{
+	Use:   "todoist <file>",
+	Short: "Import tasks from a Todoist export",
+	Long: `Import tasks from a Todoist export.
+
+Accepts a project CSV export (Project menu > Export as a template > CSV) or
+JSON task data from the Todoist API. Completed tasks are skipped. Priorities
+and due dates are kept; recurrence, labels and sections are not.
+
+Importing the same export again updates the tasks it created before instead
+of adding duplicates.
+
+Examples:
+  orbita import todoist Work.csv
+  orbita import todoist tasks.json --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(cmd, args[0], ParseTodoist)
+	},
+}
+
+// Todoist priorities p1 (highest) to p4 (the default, no priority).
+var todoistPriorities = map[int]string{1: "urgent", 2: "high", 3: "medium", 4: ""}
+
+// todoistTask is a task from the Todoist REST or Sync API.
+type todoistTask struct {
+	ID          string `json:"id"`
+	Content     string `json:"content"`
+	Description string `json:"description"`
+	// Priority runs from 1 (normal) to 4 (urgent), the reverse of the app.
+	Priority int `json:"priority"`
+	Due      *struct {
+		Date        string `json:"date"`
+		Datetime    string `json:"datetime"`
+		IsRecurring bool   `json:"is_recurring"`
+		String      string `json:"string"`
+	} `json:"due"`
+	Duration *struct {
+		Amount int    `json:"amount"`
+		Unit   string `json:"unit"`
+	} `json:"duration"`
+	Labels      []string `json:"labels"`
+	ParentID    *string  `json:"parent_id"`
+	IsCompleted bool     `json:"is_completed"`
+	Checked     bool     `json:"checked"`
+}
+
+// ParseTodoist maps a Todoist export onto task commands. JSON is either an
+// array of REST API tasks or a Sync API response with an items array;
+// anything else is read as a project CSV export.
+func ParseTodoist(r io.Reader) (*Result, error) {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return &Result{}, nil
+			}
+			return nil, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = br.ReadByte()
+			continue
+		case '[', '{':
+			return parseTodoistJSON(br)
+		}
+		return parseTodoistCSV(br)
+	}
+}
+
+func parseTodoistJSON(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []todoistTask
+	if data[0] == '{' {
+		var sync struct {
+			Items []todoistTask `json:"items"`
+		}
+		if err := json.Unmarshal(data, &sync); err != nil {
+			return nil, err
+		}
+		tasks = sync.Items
+	} else if err := json.Unmarshal(data, &tasks); err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for _, t := range tasks {
+		if t.IsCompleted || t.Checked {
+			result.Skipped++
+			continue
+		}
+		title := strings.TrimSpace(t.Content)
+		if title == "" {
+			result.Skipped++
+			continue
+		}
+
+		c := commands.CreateTaskCommand{
+			Title:       title,
+			Description: t.Description,
+		}
+		if t.ID != "" {
+			c.ExternalID = "todoist:" + t.ID
+		}
+		if priority, ok := todoistPriorities[5-t.Priority]; ok {
+			c.Priority = priority
+		}
+		if t.Due != nil {
+			due := t.Due.Datetime
+			if due == "" {
+				due = t.Due.Date
+			}
+			if parsed, ok := parseDate(due); ok {
+				c.DueDate = &parsed
+			} else if due != "" {
+				result.warn(title, "due date %q not understood", due)
+			}
+			if t.Due.IsRecurring {
+				result.warn(title, "recurrence %q not imported; only the next due date is kept", t.Due.String)
+			}
+		}
+		if t.Duration != nil {
+			if t.Duration.Unit == "minute" {
+				c.DurationMinutes = t.Duration.Amount
+			} else {
+				result.warn(title, "duration in %ss not imported", t.Duration.Unit)
+			}
+		}
+		if len(t.Labels) > 0 {
+			result.warn(title, "labels %s not imported", strings.Join(t.Labels, ", "))
+		}
+		if t.ParentID != nil {
+			result.warn(title, "subtask imported as a top-level task")
+		}
+		result.Tasks = append(result.Tasks, c)
+	}
+	return result, nil
+}
+
+func parseTodoistCSV(r io.Reader) (*Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["CONTENT"]; !ok {
+		return nil, errors.New("not a Todoist CSV export: missing CONTENT column")
+	}
+
+	result := &Result{}
+	seen := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		// Sections, notes and view settings share the file with tasks.
+		if kind := field("TYPE"); kind != "" && kind != "task" {
+			if kind == "note" {
+				result.Skipped++
+			}
+			continue
+		}
+		title := field("CONTENT")
+		if title == "" {
+			continue
+		}
+
+		c := commands.CreateTaskCommand{
+			Title:       title,
+			Description: field("DESCRIPTION"),
+		}
+		// CSV exports carry no task IDs, so tasks are matched by title;
+		// repeated titles are told apart by the order they appear in.
+		c.ExternalID = csvExternalID(title, seen)
+		if p, err := strconv.Atoi(field("PRIORITY")); err == nil {
+			c.Priority = todoistPriorities[p]
+		}
+		if due := field("DATE"); due != "" {
+			if parsed, ok := parseDate(due); ok {
+				c.DueDate = &parsed
+			} else {
+				result.warn(title, "due date %q not understood", due)
+			}
+		}
+		if amount, err := strconv.Atoi(field("DURATION")); err == nil && amount > 0 {
+			if unit := field("DURATION_UNIT"); unit == "" || unit == "minute" {
+				c.DurationMinutes = amount
+			} else {
+				result.warn(title, "duration in %ss not imported", unit)
+			}
+		}
+		if indent, err := strconv.Atoi(field("INDENT")); err == nil && indent > 1 {
+			result.warn(title, "subtask imported as a top-level task")
+		}
+		result.Tasks = append(result.Tasks, c)
+	}
+	return result, nil
+}
+
+// csvExternalID derives an external ID for a task from a CSV export. The
+// first task with a title gets the plain title, later ones a counter.
+func csvExternalID(title string, seen map[string]int) string {
+	key := strings.ToLower(title)
+	seen[key]++
+	if n := seen[key]; n > 1 {
+		return "todoist-csv:" + key + "#" + strconv.Itoa(n)
+	}
+	return "todoist-csv:" + key
+}
@@ -43,7 +43,7 @@ var captureCmd = &cobra.Command{
 			Content:  captureContent,
 			Metadata: metadata,
 			Tags:     captureTags,
-			Source:   captureSource,
+			Source:   domain.NormalizeSource(captureSource, domain.SourceCLI),
 		}
 
 		result, err := app.CaptureInboxItemHandler.Handle(cmd.Context(), command)
@@ -58,7 +58,7 @@ var captureCmd = &cobra.Command{
 
 func init() {
 	captureCmd.Flags().StringVarP(&captureContent, "content", "t", "", "text to capture (required)")
-	captureCmd.Flags().StringVar(&captureSource, "source", "", "source identifier (e.g. gmail, cli)")
+	captureCmd.Flags().StringVar(&captureSource, "source", "", "where the item came from, e.g. email or gmail (default cli)")
 	captureCmd.Flags().StringSliceVar(&captureMetadata, "metadata", nil, "metadata entry as key=value (can repeat)")
 	captureCmd.Flags().StringSliceVar(&captureTags, "tag", nil, "tag for the item (can repeat)")
 	_ = captureCmd.MarkFlagRequired("content")
@@ -11,6 +11,7 @@ import (
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	internalApp "github.com/felixgeelhaar/orbita/internal/app"
 	"github.com/felixgeelhaar/orbita/internal/inbox/application/queries"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/pkg/config"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -44,7 +45,7 @@ func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 		Level: slog.LevelError, // Only log errors in tests
 	}))
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	container, err := internalApp.NewLocalContainer(ctx, cfg, logger)
 	require.NoError(t, err)
 
@@ -96,7 +97,7 @@ func TestCaptureCmd_CreatesInboxItem(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags before test
 	captureContent = "Test inbox content from CLI"
@@ -130,7 +131,7 @@ func TestCaptureCmd_WithMetadata(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	captureContent = "Content with metadata"
@@ -164,7 +165,7 @@ func TestListCmd_ShowsItems(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// First capture some items
 	captureContent = "First item"
@@ -191,6 +192,73 @@ func TestListCmd_ShowsItems(t *testing.T) {
 	assert.Contains(t, contents, "Second item")
 }
 
+func TestCaptureCmd_DefaultsSourceToCLI(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	captureContent = "No source given"
+	captureSource = ""
+	captureMetadata = nil
+	captureTags = nil
+	captureCmd.SetContext(ctx)
+	require.NoError(t, captureCmd.RunE(captureCmd, []string{}))
+
+	items, err := app.ListInboxItemsHandler.Handle(ctx, queries.ListInboxItemsQuery{
+		UserID: app.CurrentUserID,
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "cli", items[0].Source)
+}
+
+func TestListInboxItems_FilterBySource(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	captureMetadata = nil
+	captureTags = nil
+	captureCmd.SetContext(ctx)
+	for content, source := range map[string]string{
+		"Typed in the terminal": "",
+		"Forwarded from mail":   "email",
+	} {
+		captureContent = content
+		captureSource = source
+		require.NoError(t, captureCmd.RunE(captureCmd, []string{}))
+	}
+
+	items, err := app.ListInboxItemsHandler.Handle(ctx, queries.ListInboxItemsQuery{
+		UserID: app.CurrentUserID,
+		Source: "email",
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Forwarded from mail", items[0].Content)
+
+	items, err = app.ListInboxItemsHandler.Handle(ctx, queries.ListInboxItemsQuery{
+		UserID: app.CurrentUserID,
+		Source: "cli",
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Typed in the terminal", items[0].Content)
+
+	listSource = "email"
+	defer func() { listSource = "" }()
+	listCmd.SetContext(ctx)
+	require.NoError(t, listCmd.RunE(listCmd, []string{}))
+}
+
 func TestListCmd_EmptyInbox(t *testing.T) {
 	app, cleanup := setupLocalModeTestApp(t)
 	defer cleanup()
@@ -198,7 +266,7 @@ func TestListCmd_EmptyInbox(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Verify inbox is empty via handler
 	items, err := app.ListInboxItemsHandler.Handle(ctx, queries.ListInboxItemsQuery{
@@ -281,7 +349,7 @@ func TestPromoteCmd_InvalidItemID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	promoteItemID = "not-a-uuid"
 	promoteCmd.SetContext(ctx)
@@ -295,7 +363,7 @@ func TestPromoteCmd_InvalidItemID(t *testing.T) {
 func TestCaptureCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	captureContent = "Test"
 	captureCmd.SetContext(ctx)
 
@@ -308,7 +376,7 @@ func TestCaptureCmd_NoApp(t *testing.T) {
 func TestListCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	listCmd.SetContext(ctx)
 
 	// Test that command runs without error when no app is set
@@ -10,7 +10,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var includePromoted bool
+var (
+	includePromoted bool
+	listSource      string
+)
 
 var listCmd = &cobra.Command{
 	Use:   "list",
@@ -30,6 +33,7 @@ var listCmd = &cobra.Command{
 		query := queries.ListInboxItemsQuery{
 			UserID:          app.CurrentUserID,
 			IncludePromoted: includePromoted,
+			Source:          listSource,
 		}
 
 		items, err := app.ListInboxItemsHandler.Handle(cmd.Context(), query)
@@ -47,11 +51,19 @@ var listCmd = &cobra.Command{
 			if item.Promoted {
 				promoted = fmt.Sprintf(" promoted=%s", item.PromotedTo)
 			}
+			if item.Archived {
+				promoted += " archived"
+			} else if item.Stale {
+				promoted += " stale"
+			}
 			fmt.Printf("%s [%s]%s\n", item.ID, item.Classification, promoted)
 			fmt.Printf("  Content: %s\n", item.Content)
 			if len(item.Tags) > 0 {
 				fmt.Printf("  Tags: %s\n", strings.Join(item.Tags, ", "))
 			}
+			if item.Source != "" {
+				fmt.Printf("  Source: %s\n", item.Source)
+			}
 			fmt.Printf("  Captured: %s\n", item.CapturedAt)
 			if item.PromotedAt != nil {
 				fmt.Printf("  Promoted at: %s\n", *item.PromotedAt)
@@ -64,5 +76,6 @@ var listCmd = &cobra.Command{
 }
 
 func init() {
-	listCmd.Flags().BoolVar(&includePromoted, "include-promoted", false, "include items that already been promoted")
+	listCmd.Flags().BoolVar(&includePromoted, "include-promoted", false, "include items that already been promoted or archived")
+	listCmd.Flags().StringVar(&listSource, "source", "", "only list items captured from this source (e.g. cli, mcp, email)")
 }
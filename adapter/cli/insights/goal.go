@@ -56,7 +56,7 @@ Goal types:
 
 Period types:
   daily   - Resets daily
-  weekly  - Resets weekly (Monday)
+  weekly  - Resets weekly (on WEEK_STARTS_ON, Monday by default)
   monthly - Resets monthly
 
 Examples:
@@ -23,6 +23,7 @@ Shows:
 - Task completion trend
 - Habit completion trend
 - Focus time trend
+- Focus score trend (completed focus time, conflict interruptions and breaks)
 - Best and worst days
 - Peak productivity patterns
 
@@ -69,6 +70,9 @@ Examples:
 		printTrend("Task Completion", trends.TaskCompletionTrend)
 		printTrend("Habit Completion", trends.HabitCompletionTrend)
 		printTrend("Focus Time", trends.FocusTimeTrend)
+		if len(trends.FocusScores) > 0 {
+			printTrend("Focus Score", trends.FocusScoreTrend)
+		}
 
 		// Best and worst days
 		if trends.BestDay != nil || trends.WorstDay != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -45,7 +46,7 @@ var marketplaceSearchCmd = &cobra.Command{
 			searchQuery.Type = &t
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.SearchMarketplacePackages.Handle(ctx, searchQuery)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
@@ -106,7 +107,7 @@ var marketplaceListCmd = &cobra.Command{
 			listQuery.Featured = &featured
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.ListMarketplacePackages.Handle(ctx, listQuery)
 		if err != nil {
 			return fmt.Errorf("failed to list packages: %w", err)
@@ -149,7 +150,7 @@ var marketplaceFeaturedCmd = &cobra.Command{
 
 		limit, _ := cmd.Flags().GetInt("limit")
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.GetMarketplaceFeatured.Handle(ctx, marketplaceQueries.GetFeaturedQuery{
 			Limit: limit,
 		})
@@ -184,8 +185,9 @@ var marketplaceInfoCmd = &cobra.Command{
 		}
 
 		packageID := args[0]
+		out := cmd.OutOrStdout()
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.GetMarketplacePackage.Handle(ctx, marketplaceQueries.GetPackageQuery{
 			PackageID: &packageID,
 		})
@@ -196,36 +198,36 @@ var marketplaceInfoCmd = &cobra.Command{
 			return fmt.Errorf("failed to get package: %w", err)
 		}
 
-		fmt.Printf("\n%s\n", result.Name)
-		fmt.Println(strings.Repeat("=", len(result.Name)))
-		fmt.Printf("ID: %s\n", result.PackageID)
-		fmt.Printf("Type: %s\n", result.Type)
+		fmt.Fprintf(out, "\n%s\n", result.Name)
+		fmt.Fprintln(out, strings.Repeat("=", len(result.Name)))
+		fmt.Fprintf(out, "ID: %s\n", result.PackageID)
+		fmt.Fprintf(out, "Type: %s\n", result.Type)
 
 		if result.Description != "" {
-			fmt.Printf("\n%s\n", result.Description)
+			fmt.Fprintf(out, "\n%s\n", result.Description)
 		}
 
-		fmt.Println()
+		fmt.Fprintln(out)
 
 		if result.Author != "" {
-			fmt.Printf("Author: %s\n", result.Author)
+			fmt.Fprintf(out, "Author: %s\n", result.Author)
 		}
 		if result.License != "" {
-			fmt.Printf("License: %s\n", result.License)
+			fmt.Fprintf(out, "License: %s\n", result.License)
 		}
 		if result.Homepage != "" {
-			fmt.Printf("Homepage: %s\n", result.Homepage)
+			fmt.Fprintf(out, "Homepage: %s\n", result.Homepage)
 		}
 		if len(result.Tags) > 0 {
-			fmt.Printf("Tags: %s\n", strings.Join(result.Tags, ", "))
+			fmt.Fprintf(out, "Tags: %s\n", strings.Join(result.Tags, ", "))
 		}
 
-		fmt.Println()
-		fmt.Printf("Latest Version: %s\n", result.LatestVersion)
-		fmt.Printf("Downloads: %s\n", formatNumber(result.Downloads))
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "Latest Version: %s\n", result.LatestVersion)
+		fmt.Fprintf(out, "Downloads: %s\n", formatNumber(result.Downloads))
 
 		if result.RatingCount > 0 {
-			fmt.Printf("Rating: %.1f/5 (%d reviews)\n", result.Rating, result.RatingCount)
+			fmt.Fprintf(out, "Rating: %.1f/5 (%d reviews)\n", result.Rating, result.RatingCount)
 		}
 
 		badges := []string{}
@@ -236,25 +238,25 @@ var marketplaceInfoCmd = &cobra.Command{
 			badges = append(badges, "Featured")
 		}
 		if len(badges) > 0 {
-			fmt.Printf("Badges: %s\n", strings.Join(badges, ", "))
+			fmt.Fprintf(out, "Badges: %s\n", strings.Join(badges, ", "))
 		}
 
 		// Show publisher if available
 		if result.Publisher != nil {
-			fmt.Printf("\nPublisher: %s", result.Publisher.Name)
+			fmt.Fprintf(out, "\nPublisher: %s", result.Publisher.Name)
 			if result.Publisher.Verified {
-				fmt.Printf(" (Verified)")
+				fmt.Fprintf(out, " (Verified)")
 			}
-			fmt.Println()
+			fmt.Fprintln(out)
 		}
 
 		// Show versions if available
 		if len(result.Versions) > 0 {
-			fmt.Printf("\nVersions (%d):\n", len(result.Versions))
+			fmt.Fprintf(out, "\nVersions (%d):\n", len(result.Versions))
 			maxVersions := 5
 			for i, v := range result.Versions {
 				if i >= maxVersions {
-					fmt.Printf("  ... and %d more versions\n", len(result.Versions)-maxVersions)
+					fmt.Fprintf(out, "  ... and %d more versions\n", len(result.Versions)-maxVersions)
 					break
 				}
 				status := ""
@@ -264,10 +266,15 @@ var marketplaceInfoCmd = &cobra.Command{
 				if v.Deprecated {
 					status = " [deprecated]"
 				}
-				fmt.Printf("  %s%s - %s\n", v.Version, status, v.PublishedAt)
+				fmt.Fprintf(out, "  %s%s - %s\n", v.Version, status, v.PublishedAt)
 			}
 		}
 
+		if latest := findVersion(result.Versions, result.LatestVersion); latest != nil && strings.TrimSpace(latest.Changelog) != "" {
+			fmt.Fprintf(out, "\nChanges in %s:\n", latest.Version)
+			printChangelog(out, "  ", latest.Changelog)
+		}
+
 		return nil
 	},
 }
@@ -283,8 +290,9 @@ var marketplaceVersionsCmd = &cobra.Command{
 		}
 
 		packageID := args[0]
+		out := cmd.OutOrStdout()
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.GetMarketplacePackage.Handle(ctx, marketplaceQueries.GetPackageQuery{
 			PackageID: &packageID,
 		})
@@ -296,12 +304,12 @@ var marketplaceVersionsCmd = &cobra.Command{
 		}
 
 		if len(result.Versions) == 0 {
-			fmt.Printf("No versions found for %s\n", packageID)
+			fmt.Fprintf(out, "No versions found for %s\n", packageID)
 			return nil
 		}
 
-		fmt.Printf("\nVersions for %s:\n", result.Name)
-		fmt.Println(strings.Repeat("-", 60))
+		fmt.Fprintf(out, "\nVersions for %s:\n", result.Name)
+		fmt.Fprintln(out, strings.Repeat("-", 60))
 
 		for _, v := range result.Versions {
 			status := "stable"
@@ -312,17 +320,21 @@ var marketplaceVersionsCmd = &cobra.Command{
 				status = "deprecated"
 			}
 
-			fmt.Printf("\n  %s (%s)\n", v.Version, status)
-			fmt.Printf("    Published: %s\n", v.PublishedAt)
-			fmt.Printf("    Downloads: %s\n", formatNumber(v.Downloads))
+			fmt.Fprintf(out, "\n  %s (%s)\n", v.Version, status)
+			fmt.Fprintf(out, "    Published: %s\n", v.PublishedAt)
+			fmt.Fprintf(out, "    Downloads: %s\n", formatNumber(v.Downloads))
 			if v.MinAPIVersion != "" {
-				fmt.Printf("    Min API: %s\n", v.MinAPIVersion)
+				fmt.Fprintf(out, "    Min API: %s\n", v.MinAPIVersion)
 			}
 			if v.Size > 0 {
-				fmt.Printf("    Size: %s\n", formatBytes(v.Size))
+				fmt.Fprintf(out, "    Size: %s\n", formatBytes(v.Size))
 			}
 			if v.Deprecated && v.DeprecationMessage != "" {
-				fmt.Printf("    Warning: %s\n", v.DeprecationMessage)
+				fmt.Fprintf(out, "    Warning: %s\n", v.DeprecationMessage)
+			}
+			if strings.TrimSpace(v.Changelog) != "" {
+				fmt.Fprintln(out, "    Changes:")
+				printChangelog(out, "      ", v.Changelog)
 			}
 		}
 
@@ -330,6 +342,23 @@ var marketplaceVersionsCmd = &cobra.Command{
 	},
 }
 
+// findVersion returns the version with the given version string, if listed.
+func findVersion(versions []*marketplaceQueries.VersionDTO, version string) *marketplaceQueries.VersionDTO {
+	for _, v := range versions {
+		if v.Version == version {
+			return v
+		}
+	}
+	return nil
+}
+
+// printChangelog writes a changelog line by line, each line indented.
+func printChangelog(out io.Writer, indent, changelog string) {
+	for _, line := range strings.Split(strings.TrimSpace(changelog), "\n") {
+		fmt.Fprintf(out, "%s%s\n", indent, strings.TrimRight(line, " \t\r"))
+	}
+}
+
 func printPackageSummary(pkg *marketplaceQueries.PackageDTO) {
 	badges := ""
 	if pkg.Verified {
@@ -397,7 +426,7 @@ var marketplaceInstallCmd = &cobra.Command{
 		packageSpec := args[0]
 		packageID, version := parsePackageSpec(packageSpec)
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.InstallPackageHandler.Handle(ctx, marketplaceCommands.InstallPackageCommand{
 			PackageID: packageID,
 			Version:   version,
@@ -429,7 +458,7 @@ var marketplaceUninstallCmd = &cobra.Command{
 
 		packageID := args[0]
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.UninstallPackageHandler.Handle(ctx, marketplaceCommands.UninstallPackageCommand{
 			PackageID: packageID,
 			UserID:    app.CurrentUserID,
@@ -456,7 +485,7 @@ var marketplaceUpdateCmd = &cobra.Command{
 			return fmt.Errorf("marketplace not available")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		if len(args) == 0 {
 			// Update all packages
@@ -465,33 +494,42 @@ var marketplaceUpdateCmd = &cobra.Command{
 				fmt.Println("Specify a package to update or use --all to update all packages")
 				return nil
 			}
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-			if app.ListInstalledHandler == nil {
-				return fmt.Errorf("cannot list installed packages")
+			plan, err := planMarketplaceUpdates(ctx, app)
+			if err != nil {
+				return err
 			}
 
-			result, err := app.ListInstalledHandler.Handle(ctx, marketplaceQueries.ListInstalledQuery{
-				UserID: app.CurrentUserID,
-			})
-			if err != nil {
-				return fmt.Errorf("failed to list installed packages: %w", err)
+			out := cmd.OutOrStdout()
+			if plan.Checked == 0 {
+				fmt.Fprintln(out, "No packages installed")
+				return nil
+			}
+			if len(plan.Updates) == 0 {
+				fmt.Fprintln(out, "All packages are up to date!")
+				return nil
 			}
 
-			if len(result.Packages) == 0 {
-				fmt.Println("No packages installed")
+			if dryRun {
+				fmt.Fprintf(out, "Dry run: %d of %d packages would be updated\n", len(plan.Updates), plan.Checked)
+				printUpdatePlan(out, plan)
+				fmt.Fprintln(out)
+				fmt.Fprintln(out, "Run 'orbita marketplace update --all' to apply these updates")
 				return nil
 			}
 
-			for _, pkg := range result.Packages {
+			for _, update := range plan.Updates {
 				updateResult, err := app.UpdatePackageHandler.Handle(ctx, marketplaceCommands.UpdatePackageCommand{
-					PackageID: pkg.PackageID,
+					PackageID: update.PackageID,
+					Version:   update.AvailableVersion,
 					UserID:    app.CurrentUserID,
 				})
 				if err != nil {
-					fmt.Printf("Failed to update %s: %v\n", pkg.PackageID, err)
+					fmt.Fprintf(out, "Failed to update %s: %v\n", update.PackageID, err)
 					continue
 				}
-				fmt.Println(updateResult.Message)
+				fmt.Fprintln(out, updateResult.Message)
 			}
 			return nil
 		}
@@ -535,7 +573,7 @@ var marketplaceInstalledCmd = &cobra.Command{
 			query.Type = &t
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.ListInstalledHandler.Handle(ctx, query)
 		if err != nil {
 			return fmt.Errorf("failed to list installed packages: %w", err)
@@ -574,59 +612,62 @@ var marketplaceCheckUpdatesCmd = &cobra.Command{
 	Long:  "Check all installed packages for available updates without installing them.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		app := GetApp()
-		if app == nil || app.ListInstalledHandler == nil || app.GetMarketplacePackage == nil {
+		if app == nil {
 			return fmt.Errorf("marketplace not available")
 		}
 
-		ctx := context.Background()
-
-		// Get installed packages
-		installed, err := app.ListInstalledHandler.Handle(ctx, marketplaceQueries.ListInstalledQuery{
-			UserID: app.CurrentUserID,
-		})
+		plan, err := planMarketplaceUpdates(cmd.Context(), app)
 		if err != nil {
-			return fmt.Errorf("failed to list installed packages: %w", err)
+			return err
 		}
 
-		if len(installed.Packages) == 0 {
-			fmt.Println("No packages installed")
+		out := cmd.OutOrStdout()
+		if plan.Checked == 0 {
+			fmt.Fprintln(out, "No packages installed")
 			return nil
 		}
 
-		fmt.Printf("\nChecking %d installed packages for updates...\n", len(installed.Packages))
-		fmt.Println(strings.Repeat("-", 60))
+		fmt.Fprintf(out, "\nChecking %d installed packages for updates...\n", plan.Checked)
+		fmt.Fprintln(out, strings.Repeat("-", 60))
 
-		hasUpdates := false
-		for _, pkg := range installed.Packages {
-			// Get latest version from marketplace
-			pkgID := pkg.PackageID
-			latest, err := app.GetMarketplacePackage.Handle(ctx, marketplaceQueries.GetPackageQuery{
-				PackageID: &pkgID,
-			})
-			if err != nil {
-				continue // Skip packages not in marketplace
-			}
-
-			if latest.LatestVersion != pkg.Version {
-				hasUpdates = true
-				fmt.Printf("\n  %s\n", pkg.PackageID)
-				fmt.Printf("    Installed: %s\n", pkg.Version)
-				fmt.Printf("    Available: %s\n", latest.LatestVersion)
-			}
+		if len(plan.Updates) == 0 {
+			fmt.Fprintln(out, "\nAll packages are up to date!")
+			return nil
 		}
 
-		if !hasUpdates {
-			fmt.Println("\nAll packages are up to date!")
-		} else {
-			fmt.Println()
-			fmt.Println("Run 'orbita marketplace update <package>' to update a specific package")
-			fmt.Println("Run 'orbita marketplace update --all' to update all packages")
-		}
+		printUpdatePlan(out, plan)
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Run 'orbita marketplace update <package>' to update a specific package")
+		fmt.Fprintln(out, "Run 'orbita marketplace update --all --dry-run' to preview updating all packages")
+		fmt.Fprintln(out, "Run 'orbita marketplace update --all' to update all packages")
 
 		return nil
 	},
 }
 
+// planMarketplaceUpdates computes which installed packages have a newer
+// version available. It is shared by check-updates and update --all.
+func planMarketplaceUpdates(ctx context.Context, app *App) (*marketplaceQueries.CheckUpdatesResult, error) {
+	if app.CheckUpdatesHandler == nil {
+		return nil, fmt.Errorf("marketplace not available")
+	}
+	plan, err := app.CheckUpdatesHandler.Handle(ctx, marketplaceQueries.CheckUpdatesQuery{
+		UserID: app.CurrentUserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	return plan, nil
+}
+
+func printUpdatePlan(out io.Writer, plan *marketplaceQueries.CheckUpdatesResult) {
+	for _, update := range plan.Updates {
+		fmt.Fprintf(out, "\n  %s\n", update.PackageID)
+		fmt.Fprintf(out, "    Installed: %s\n", update.InstalledVersion)
+		fmt.Fprintf(out, "    Available: %s\n", update.AvailableVersion)
+	}
+}
+
 var marketplaceEnableCmd = &cobra.Command{
 	Use:   "enable <package-id>",
 	Short: "Enable an installed package",
@@ -639,7 +680,7 @@ var marketplaceEnableCmd = &cobra.Command{
 
 		packageID := args[0]
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.EnablePackageHandler.Handle(ctx, marketplaceCommands.EnablePackageCommand{
 			PackageID: packageID,
 			UserID:    app.CurrentUserID,
@@ -665,7 +706,7 @@ var marketplaceDisableCmd = &cobra.Command{
 
 		packageID := args[0]
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.DisablePackageHandler.Handle(ctx, marketplaceCommands.DisablePackageCommand{
 			PackageID: packageID,
 			UserID:    app.CurrentUserID,
@@ -741,7 +782,7 @@ To get an API token:
 			return fmt.Errorf("token is required")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.LoginHandler.Handle(ctx, marketplaceCommands.LoginCommand{
 			Token: token,
 		})
@@ -769,7 +810,7 @@ var marketplaceLogoutCmd = &cobra.Command{
 			return fmt.Errorf("marketplace not available")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.LogoutHandler.Handle(ctx, marketplaceCommands.LogoutCommand{})
 		if err != nil {
 			return fmt.Errorf("logout failed: %w", err)
@@ -789,7 +830,7 @@ var marketplaceWhoAmICmd = &cobra.Command{
 			return fmt.Errorf("marketplace not available")
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.WhoAmIHandler.Handle(ctx, marketplaceCommands.WhoAmICommand{})
 		if err != nil {
 			return fmt.Errorf("failed to get auth status: %w", err)
@@ -843,14 +884,14 @@ Example manifest (orbit.json):
 
 		// Check if logged in
 		if app.WhoAmIHandler != nil {
-			ctx := context.Background()
+			ctx := cmd.Context()
 			whoami, err := app.WhoAmIHandler.Handle(ctx, marketplaceCommands.WhoAmICommand{})
 			if err != nil || !whoami.Authenticated {
 				return fmt.Errorf("not logged in. Use 'orbita marketplace login' first")
 			}
 		}
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 		result, err := app.PublishHandler.Handle(ctx, marketplaceCommands.PublishPackageCommand{
 			PackagePath: packagePath,
 			PublisherID: app.CurrentUserID,
@@ -913,6 +954,7 @@ func init() {
 
 	// Update command
 	marketplaceUpdateCmd.Flags().Bool("all", false, "Update all installed packages")
+	marketplaceUpdateCmd.Flags().Bool("dry-run", false, "With --all, list the updates that would be applied without changing anything")
 	marketplaceCmd.AddCommand(marketplaceUpdateCmd)
 
 	// Installed command
@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	marketplaceCommands "github.com/felixgeelhaar/orbita/internal/marketplace/application/commands"
+	marketplaceQueries "github.com/felixgeelhaar/orbita/internal/marketplace/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeInstalledRepo records mutations; unused methods panic via the nil embed.
+type fakeInstalledRepo struct {
+	domain.InstalledPackageRepository
+	packages  []*domain.InstalledPackage
+	mutations int
+}
+
+func (r *fakeInstalledRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.InstalledPackage, error) {
+	return r.packages, nil
+}
+
+func (r *fakeInstalledRepo) GetByPackageID(ctx context.Context, packageID string, userID uuid.UUID) (*domain.InstalledPackage, error) {
+	for _, pkg := range r.packages {
+		if pkg.PackageID == packageID {
+			return pkg, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeInstalledRepo) Create(ctx context.Context, pkg *domain.InstalledPackage) error {
+	r.mutations++
+	return nil
+}
+
+func (r *fakeInstalledRepo) Update(ctx context.Context, pkg *domain.InstalledPackage) error {
+	r.mutations++
+	return nil
+}
+
+func (r *fakeInstalledRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mutations++
+	return nil
+}
+
+type fakePackageRepo struct {
+	domain.PackageRepository
+	packages  map[string]*domain.Package
+	mutations int
+}
+
+func (r *fakePackageRepo) GetByPackageID(ctx context.Context, packageID string) (*domain.Package, error) {
+	pkg, ok := r.packages[packageID]
+	if !ok {
+		return nil, marketplaceCommands.ErrPackageNotFound
+	}
+	return pkg, nil
+}
+
+func (r *fakePackageRepo) IncrementDownloads(ctx context.Context, id uuid.UUID) error {
+	r.mutations++
+	return nil
+}
+
+func TestMarketplaceUpdateAll_DryRun(t *testing.T) {
+	userID := uuid.New()
+	installDir := t.TempDir()
+
+	installedRepo := &fakeInstalledRepo{packages: []*domain.InstalledPackage{
+		domain.NewInstalledPackage("acme.focus", "1.0.0", domain.PackageTypeOrbit, installDir+"/acme.focus/1.0.0", userID),
+		domain.NewInstalledPackage("acme.current", "2.0.0", domain.PackageTypeEngine, installDir+"/acme.current/2.0.0", userID),
+	}}
+	focus := domain.NewPackage("acme.focus", domain.PackageTypeOrbit, "Focus", "")
+	focus.SetLatestVersion("1.2.0")
+	current := domain.NewPackage("acme.current", domain.PackageTypeEngine, "Current", "")
+	current.SetLatestVersion("2.0.0")
+	packageRepo := &fakePackageRepo{packages: map[string]*domain.Package{
+		"acme.focus":   focus,
+		"acme.current": current,
+	}}
+
+	prev := GetApp()
+	SetApp(&App{
+		CurrentUserID:        userID,
+		CheckUpdatesHandler:  marketplaceQueries.NewCheckUpdatesHandler(installedRepo, packageRepo),
+		UpdatePackageHandler: marketplaceCommands.NewUpdatePackageHandler(packageRepo, nil, installedRepo, installDir),
+	})
+	defer SetApp(prev)
+
+	var checkOutput bytes.Buffer
+	marketplaceCheckUpdatesCmd.SetOut(&checkOutput)
+	defer marketplaceCheckUpdatesCmd.SetOut(nil)
+	require.NoError(t, marketplaceCheckUpdatesCmd.RunE(marketplaceCheckUpdatesCmd, nil))
+
+	var dryRunOutput bytes.Buffer
+	marketplaceUpdateCmd.SetOut(&dryRunOutput)
+	require.NoError(t, marketplaceUpdateCmd.Flags().Set("all", "true"))
+	require.NoError(t, marketplaceUpdateCmd.Flags().Set("dry-run", "true"))
+	defer func() {
+		marketplaceUpdateCmd.SetOut(nil)
+		_ = marketplaceUpdateCmd.Flags().Set("all", "false")
+		_ = marketplaceUpdateCmd.Flags().Set("dry-run", "false")
+	}()
+	require.NoError(t, marketplaceUpdateCmd.RunE(marketplaceUpdateCmd, nil))
+
+	// The dry run lists exactly the plan check-updates reports.
+	plan := "\n  acme.focus\n    Installed: 1.0.0\n    Available: 1.2.0\n"
+	assert.Contains(t, checkOutput.String(), plan)
+	assert.Contains(t, dryRunOutput.String(), "Dry run: 1 of 2 packages would be updated")
+	assert.Contains(t, dryRunOutput.String(), plan)
+	assert.NotContains(t, dryRunOutput.String(), "acme.current")
+
+	// Nothing was changed.
+	assert.Zero(t, installedRepo.mutations)
+	assert.Zero(t, packageRepo.mutations)
+	assert.Equal(t, "1.0.0", installedRepo.packages[0].Version)
+	entries, err := os.ReadDir(installDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+type fakeVersionRepo struct {
+	domain.VersionRepository
+	versions []*domain.Version
+}
+
+func (r *fakeVersionRepo) ListByPackage(ctx context.Context, packageID uuid.UUID) ([]*domain.Version, error) {
+	return r.versions, nil
+}
+
+func setupMarketplaceVersions(t *testing.T) {
+	t.Helper()
+
+	pkg := domain.NewPackage("acme.focus", domain.PackageTypeOrbit, "Focus", "")
+	pkg.SetLatestVersion("1.2.0")
+	latest := domain.NewVersion(pkg.ID, "1.2.0")
+	latest.SetChangelog("Add weekly focus report\nFix timer drift after sleep\n")
+	previous := domain.NewVersion(pkg.ID, "1.1.0")
+
+	prev := GetApp()
+	SetApp(&App{
+		GetMarketplacePackage: marketplaceQueries.NewGetPackageHandler(
+			&fakePackageRepo{packages: map[string]*domain.Package{"acme.focus": pkg}},
+			&fakeVersionRepo{versions: []*domain.Version{latest, previous}},
+			nil,
+		),
+	})
+	t.Cleanup(func() { SetApp(prev) })
+}
+
+func TestMarketplaceVersions_ShowsChangelog(t *testing.T) {
+	setupMarketplaceVersions(t)
+
+	var out bytes.Buffer
+	marketplaceVersionsCmd.SetOut(&out)
+	defer marketplaceVersionsCmd.SetOut(nil)
+	require.NoError(t, marketplaceVersionsCmd.RunE(marketplaceVersionsCmd, []string{"acme.focus"}))
+
+	assert.Contains(t, out.String(), "    Changes:\n      Add weekly focus report\n      Fix timer drift after sleep\n")
+	// The version without a changelog has no Changes section.
+	older := out.String()[strings.Index(out.String(), "1.1.0"):]
+	assert.NotContains(t, older, "Changes:")
+	assert.Equal(t, 1, strings.Count(out.String(), "Changes:"))
+}
+
+func TestMarketplaceInfo_ShowsLatestChangelog(t *testing.T) {
+	setupMarketplaceVersions(t)
+
+	var out bytes.Buffer
+	marketplaceInfoCmd.SetOut(&out)
+	defer marketplaceInfoCmd.SetOut(nil)
+	require.NoError(t, marketplaceInfoCmd.RunE(marketplaceInfoCmd, []string{"acme.focus"}))
+
+	assert.Contains(t, out.String(), "\nChanges in 1.2.0:\n  Add weekly focus report\n  Fix timer drift after sleep\n")
+}
+
+func TestMarketplaceInfo_OmitsMissingChangelog(t *testing.T) {
+	pkg := domain.NewPackage("acme.quiet", domain.PackageTypeEngine, "Quiet", "")
+	pkg.SetLatestVersion("0.1.0")
+
+	prev := GetApp()
+	SetApp(&App{
+		GetMarketplacePackage: marketplaceQueries.NewGetPackageHandler(
+			&fakePackageRepo{packages: map[string]*domain.Package{"acme.quiet": pkg}},
+			&fakeVersionRepo{versions: []*domain.Version{domain.NewVersion(pkg.ID, "0.1.0")}},
+			nil,
+		),
+	})
+	defer SetApp(prev)
+
+	var out bytes.Buffer
+	marketplaceInfoCmd.SetOut(&out)
+	defer marketplaceInfoCmd.SetOut(nil)
+	require.NoError(t, marketplaceInfoCmd.RunE(marketplaceInfoCmd, []string{"acme.quiet"}))
+
+	assert.Contains(t, out.String(), "Latest Version: 0.1.0")
+	assert.NotContains(t, out.String(), "Changes")
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+// MatchMode controls what `orbita done` matches its argument against.
+type MatchMode string
+
+const (
+	// MatchAny matches ID prefixes first and falls back to titles.
+	MatchAny MatchMode = "any"
+	// MatchID matches ID prefixes only.
+	MatchID MatchMode = "id"
+	// MatchTitle matches title substrings only.
+	MatchTitle MatchMode = "title"
+)
+
+// ParseMatchMode parses a match mode, defaulting to MatchAny when empty.
+func ParseMatchMode(s string) (MatchMode, error) {
+	switch mode := MatchMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return MatchAny, nil
+	case MatchAny, MatchID, MatchTitle:
+		return mode, nil
+	default:
+		return "", sharedApplication.NewValidationError(
+			fmt.Sprintf("unsupported match mode: %s (supported: any, id, title)", s))
+	}
+}
+
+// Title match ranks, best first.
+const (
+	rankExactTitle = iota
+	rankTitlePrefix
+	rankWordPrefix
+	rankSubstring
+)
+
+// RankMatches returns the items matching query, best match first.
+//
+// ID prefix matches win: when any item's ID starts with query, only those are
+// returned, in their original order. Otherwise items whose title contains
+// query (case-insensitive) are returned ranked by an exact title, then a
+// title prefix, then a word prefix, then any other substring. Ties go to the
+// shorter title, then to the original order.
+func RankMatches[T any](items []T, query string, mode MatchMode, id func(T) uuid.UUID, title func(T) string) []T {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	if mode != MatchTitle {
+		var matches []T
+		for _, item := range items {
+			if strings.HasPrefix(id(item).String(), query) {
+				matches = append(matches, item)
+			}
+		}
+		if len(matches) > 0 || mode == MatchID {
+			return matches
+		}
+	}
+
+	type ranked struct {
+		item  T
+		rank  int
+		title string
+	}
+	var candidates []ranked
+	for _, item := range items {
+		t := strings.ToLower(title(item))
+		if rank, ok := titleRank(t, query); ok {
+			candidates = append(candidates, ranked{item: item, rank: rank, title: t})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].rank != candidates[j].rank {
+			return candidates[i].rank < candidates[j].rank
+		}
+		return len(candidates[i].title) < len(candidates[j].title)
+	})
+
+	matches := make([]T, 0, len(candidates))
+	for _, c := range candidates {
+		matches = append(matches, c.item)
+	}
+	return matches
+}
+
+// titleRank ranks how well a lowercased title matches a lowercased query.
+func titleRank(title, query string) (int, bool) {
+	switch {
+	case title == query:
+		return rankExactTitle, true
+	case strings.HasPrefix(title, query):
+		return rankTitlePrefix, true
+	case strings.Contains(" "+title, " "+query):
+		return rankWordPrefix, true
+	case strings.Contains(title, query):
+		return rankSubstring, true
+	}
+	return 0, false
+}
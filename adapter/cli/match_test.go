@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"testing"
+
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type matchItem struct {
+	id    uuid.UUID
+	title string
+}
+
+func rankTitles(items []matchItem, term string, mode MatchMode) []string {
+	matches := RankMatches(items, term, mode,
+		func(i matchItem) uuid.UUID { return i.id },
+		func(i matchItem) string { return i.title },
+	)
+	titles := make([]string, 0, len(matches))
+	for _, m := range matches {
+		titles = append(titles, m.title)
+	}
+	return titles
+}
+
+func TestRankMatches(t *testing.T) {
+	items := []matchItem{
+		{id: uuid.MustParse("abc12345-0000-0000-0000-000000000001"), title: "Write quarterly report"},
+		{id: uuid.MustParse("abd12345-0000-0000-0000-000000000002"), title: "Report"},
+		{id: uuid.MustParse("0fa12345-0000-0000-0000-000000000003"), title: "Reporting dashboard"},
+		{id: uuid.MustParse("1de12345-0000-0000-0000-000000000004"), title: "Misreported expenses"},
+		{id: uuid.MustParse("2ce12345-0000-0000-0000-000000000005"), title: "Call the bank"},
+	}
+
+	t.Run("matches ID prefix", func(t *testing.T) {
+		assert.Equal(t, []string{"Write quarterly report"}, rankTitles(items, "abc1", MatchAny))
+		assert.Equal(t, []string{"Write quarterly report", "Report"}, rankTitles(items, "AB", MatchAny))
+	})
+
+	t.Run("ID prefix wins over titles", func(t *testing.T) {
+		withCode := append(items, matchItem{id: uuid.New(), title: "Ship 2ce hotfix"})
+		assert.Equal(t, []string{"Call the bank"}, rankTitles(withCode, "2ce", MatchAny))
+	})
+
+	t.Run("matches title substring case-insensitively", func(t *testing.T) {
+		assert.Equal(t, []string{"Call the bank"}, rankTitles(items, "BANK", MatchAny))
+		assert.Equal(t, []string{"Call the bank"}, rankTitles(items, "the b", MatchAny))
+	})
+
+	t.Run("ranks ambiguous title matches", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"Report",                 // exact title
+			"Reporting dashboard",    // title prefix
+			"Write quarterly report", // word prefix
+			"Misreported expenses",   // substring
+		}, rankTitles(items, "report", MatchAny))
+	})
+
+	t.Run("ties go to the shorter title", func(t *testing.T) {
+		tied := []matchItem{
+			{id: uuid.New(), title: "Plan team offsite"},
+			{id: uuid.New(), title: "Plan trip"},
+		}
+		assert.Equal(t, []string{"Plan trip", "Plan team offsite"}, rankTitles(tied, "plan", MatchAny))
+	})
+
+	t.Run("id mode ignores titles", func(t *testing.T) {
+		assert.Empty(t, rankTitles(items, "bank", MatchID))
+		assert.Equal(t, []string{"Call the bank"}, rankTitles(items, "2ce", MatchID))
+	})
+
+	t.Run("title mode ignores IDs", func(t *testing.T) {
+		assert.Empty(t, rankTitles(items, "abc1", MatchTitle))
+		assert.Equal(t, []string{"Report", "Reporting dashboard", "Write quarterly report", "Misreported expenses"},
+			rankTitles(items, "report", MatchTitle))
+	})
+
+	t.Run("empty term matches nothing", func(t *testing.T) {
+		assert.Empty(t, rankTitles(items, "  ", MatchAny))
+	})
+}
+
+func TestParseMatchMode(t *testing.T) {
+	for input, want := range map[string]MatchMode{"": MatchAny, "any": MatchAny, "ID": MatchID, " title ": MatchTitle} {
+		mode, err := ParseMatchMode(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, mode, input)
+	}
+
+	_, err := ParseMatchMode("fuzzy")
+	assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/felixgeelhaar/orbita/internal/app"
 	mcpinternal "github.com/felixgeelhaar/orbita/internal/mcp"
 	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
@@ -51,7 +52,7 @@ func newServerLogger(out io.Writer, debug bool) *slog.Logger {
 	if debug {
 		level = slog.LevelDebug
 	}
-	return slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{
+	return slog.New(observability.ContextHandler(slog.NewTextHandler(out, &slog.HandlerOptions{
 		Level: level,
-	}))
+	})))
 }
@@ -0,0 +1,70 @@
+package meeting
+
+import (
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	billingDomain "github.com/felixgeelhaar/orbita/internal/billing/domain"
+	meetingCommands "github.com/felixgeelhaar/orbita/internal/meetings/application/commands"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	canceledDate string
+	canceledTime string
+)
+
+var canceledCmd = &cobra.Command{
+	Use:     "canceled [meeting-id]",
+	Aliases: []string{"cancelled", "no-show"},
+	Short:   "Record a canceled meeting or a no-show",
+	Long: `Record that a meeting occurrence was canceled or nobody showed up.
+
+Cancellations are tracked separately from held meetings. A meeting skipped
+once keeps its cadence; one that is frequently canceled gets a slower
+cadence when cadences are adjusted.
+
+Examples:
+  orbita meeting canceled abc123
+  orbita meeting no-show abc123 --date 2024-02-02 --time 09:30`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.MarkMeetingCanceledHandler == nil {
+			fmt.Fprintln(cmd.OutOrStdout(), "Meeting updates require database connection.")
+			return nil
+		}
+		if err := cli.RequireEntitlement(cmd.Context(), app, billingDomain.ModuleSmartMeetings); err != nil {
+			return err
+		}
+
+		meetingID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid meeting ID: %w", err)
+		}
+
+		canceledAt, err := parseOccurredAt(canceledDate, canceledTime)
+		if err != nil {
+			return err
+		}
+
+		command := meetingCommands.MarkMeetingCanceledCommand{
+			UserID:     app.CurrentUserID,
+			MeetingID:  meetingID,
+			CanceledAt: canceledAt,
+		}
+
+		if err := app.MarkMeetingCanceledHandler.Handle(cmd.Context(), command); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), "Meeting marked as canceled.")
+		return nil
+	},
+}
+
+func init() {
+	canceledCmd.Flags().StringVar(&canceledDate, "date", "", "date of the canceled occurrence (YYYY-MM-DD)")
+	canceledCmd.Flags().StringVar(&canceledTime, "time", "", "time of the canceled occurrence (HH:MM)")
+}
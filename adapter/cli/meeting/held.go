@@ -61,20 +61,26 @@ Examples:
 }
 
 func parseHeldAt() (time.Time, error) {
-	if heldDate == "" && heldTime == "" {
+	return parseOccurredAt(heldDate, heldTime)
+}
+
+// parseOccurredAt combines the --date and --time flags of an occurrence,
+// defaulting to now.
+func parseOccurredAt(dateFlag, timeFlag string) (time.Time, error) {
+	if dateFlag == "" && timeFlag == "" {
 		return time.Now(), nil
 	}
 
 	date := time.Now()
-	if heldDate != "" {
-		parsed, err := time.Parse("2006-01-02", heldDate)
+	if dateFlag != "" {
+		parsed, err := time.Parse("2006-01-02", dateFlag)
 		if err != nil {
 			return time.Time{}, fmt.Errorf("invalid date format, use YYYY-MM-DD: %w", err)
 		}
 		date = parsed
 	}
 
-	clock := heldTime
+	clock := timeFlag
 	if clock == "" {
 		clock = "10:00"
 	}
@@ -67,6 +67,9 @@ Examples:
 			fmt.Fprintf(cmd.OutOrStdout(), "    Duration: %d mins\n", m.DurationMins)
 			fmt.Fprintf(cmd.OutOrStdout(), "    Preferred time: %s\n", m.PreferredTime)
 			fmt.Fprintf(cmd.OutOrStdout(), "    Next: %s\n", next)
+			if m.HeldCount+m.CanceledCount > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "    Attendance: %d held, %d canceled\n", m.HeldCount, m.CanceledCount)
+			}
 			fmt.Fprintf(cmd.OutOrStdout(), "    Status: %s\n", status)
 		}
 
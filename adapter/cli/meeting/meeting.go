@@ -15,4 +15,5 @@ func init() {
 	Cmd.AddCommand(updateCmd)
 	Cmd.AddCommand(archiveCmd)
 	Cmd.AddCommand(heldCmd)
+	Cmd.AddCommand(canceledCmd)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	internalApp "github.com/felixgeelhaar/orbita/internal/app"
 	meetingQueries "github.com/felixgeelhaar/orbita/internal/meetings/application/queries"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/pkg/config"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -42,7 +43,7 @@ func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 		Level: slog.LevelError,
 	}))
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	container, err := internalApp.NewLocalContainer(ctx, cfg, logger)
 	require.NoError(t, err)
 
@@ -78,6 +79,7 @@ func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 		container.BillingService,
 	)
 	cliApp.SetCurrentUserID(testUserID)
+	cliApp.SetMarkMeetingCanceledHandler(container.MarkMeetingCanceledHandler)
 
 	cleanup := func() {
 		container.Close()
@@ -94,7 +96,7 @@ func TestCreateCmd_CreatesMeeting(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	createCadence = "weekly"
@@ -127,7 +129,7 @@ func TestCreateCmd_WithCustomCadence(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	createCadence = "custom"
@@ -161,7 +163,7 @@ func TestListCmd_ShowsMeetings(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create some meetings first
 	createCadence = "weekly"
@@ -197,7 +199,7 @@ func TestListCmd_EmptyList(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Verify empty list
 	meetings, err := app.ListMeetingsHandler.Handle(ctx, meetingQueries.ListMeetingsQuery{
@@ -222,7 +224,7 @@ func TestArchiveCmd_ArchivesMeeting(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create a meeting first
 	createCadence = "weekly"
@@ -263,7 +265,7 @@ func TestArchiveCmd_InvalidMeetingID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	archiveCmd.SetContext(ctx)
 	err := archiveCmd.RunE(archiveCmd, []string{"invalid-uuid"})
@@ -278,7 +280,7 @@ func TestHeldCmd_MarksMeetingHeld(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create a meeting first
 	createCadence = "weekly"
@@ -314,6 +316,44 @@ func TestHeldCmd_MarksMeetingHeld(t *testing.T) {
 	assert.NotNil(t, meetings[0].LastHeldAt)
 }
 
+func TestCanceledCmd_RecordsCancellation(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	createCadence = "weekly"
+	createCadenceDays = 0
+	createDurationMins = 30
+	createTime = "10:00"
+	createCmd.SetContext(ctx)
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Test Meeting"}))
+
+	meetings, err := app.ListMeetingsHandler.Handle(ctx, meetingQueries.ListMeetingsQuery{
+		UserID: app.CurrentUserID,
+	})
+	require.NoError(t, err)
+	require.Len(t, meetings, 1)
+	meetingID := meetings[0].ID.String()
+
+	canceledDate = ""
+	canceledTime = ""
+	canceledCmd.SetContext(ctx)
+	require.NoError(t, canceledCmd.RunE(canceledCmd, []string{meetingID}))
+
+	meetings, err = app.ListMeetingsHandler.Handle(ctx, meetingQueries.ListMeetingsQuery{
+		UserID: app.CurrentUserID,
+	})
+	require.NoError(t, err)
+	require.Len(t, meetings, 1)
+	assert.Equal(t, 1, meetings[0].CanceledCount)
+	assert.Zero(t, meetings[0].HeldCount)
+	assert.Nil(t, meetings[0].LastHeldAt)
+}
+
 func TestHeldCmd_WithCustomDateTime(t *testing.T) {
 	app, cleanup := setupLocalModeTestApp(t)
 	defer cleanup()
@@ -321,7 +361,7 @@ func TestHeldCmd_WithCustomDateTime(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create a meeting first
 	createCadence = "weekly"
@@ -367,7 +407,7 @@ func TestHeldCmd_InvalidMeetingID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	heldDate = ""
 	heldTime = ""
@@ -384,7 +424,7 @@ func TestHeldCmd_InvalidDateFormat(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create a meeting first
 	createCadence = "weekly"
@@ -419,7 +459,7 @@ func TestHeldCmd_InvalidTimeFormat(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create a meeting first
 	createCadence = "weekly"
@@ -450,7 +490,7 @@ func TestHeldCmd_InvalidTimeFormat(t *testing.T) {
 func TestCreateCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	createCadence = "weekly"
 	createCmd.SetContext(ctx)
 
@@ -462,7 +502,7 @@ func TestCreateCmd_NoApp(t *testing.T) {
 func TestListCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	listCmd.SetContext(ctx)
 
 	// The command returns nil but prints a message
@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/felixgeelhaar/orbita/internal/orbit/sdk"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	orbitGrantCapabilities  []string
+	orbitGrantForce         bool
+	orbitRevokeCapabilities []string
+)
+
+var orbitPermissionsCmd = &cobra.Command{
+	Use:   "permissions <orbit-id>",
+	Short: "Show the capabilities you have granted an orbit",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := orbitGrantApp()
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		orbitID := args[0]
+		out := cmd.OutOrStdout()
+
+		required, err := app.OrbitRegistry.RequiresGrant(orbitID)
+		if err != nil {
+			return fmt.Errorf("orbit not found: %s", orbitID)
+		}
+		granted, err := app.OrbitRegistry.GrantedCapabilities(ctx, orbitID, app.CurrentUserID)
+		if err != nil {
+			return err
+		}
+		pending, err := app.OrbitRegistry.PendingCapabilities(ctx, orbitID, app.CurrentUserID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Orbit: %s\n", orbitID)
+		if !required {
+			fmt.Fprintln(out, "No grant required: all declared capabilities are available.")
+		}
+		fmt.Fprintf(out, "Granted: %s\n", formatCapabilities(granted))
+		if len(pending) > 0 {
+			fmt.Fprintf(out, "Not granted: %s\n", formatCapabilities(pending))
+			fmt.Fprintf(out, "\nGrant them with: orbita orbit grant %s\n", orbitID)
+		}
+		return nil
+	},
+}
+
+var orbitGrantCmd = &cobra.Command{
+	Use:   "grant <orbit-id>",
+	Short: "Grant an orbit the capabilities it declares",
+	Long: `Grant an orbit the capabilities declared in its manifest.
+
+Orbits discovered on disk can only use the capabilities you grant them.
+Without --capability, every declared capability is granted. Grants are
+saved and apply to every later run.`,
+	Example: `  orbita orbit grant acme.pomodoro
+  orbita orbit grant acme.pomodoro --capability read:tasks --capability write:storage`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := orbitGrantApp()
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		orbitID := args[0]
+		out := cmd.OutOrStdout()
+
+		required, err := app.OrbitRegistry.RequiresGrant(orbitID)
+		if err != nil {
+			return fmt.Errorf("orbit not found: %s", orbitID)
+		}
+		if !required {
+			fmt.Fprintf(out, "Orbit %s does not require a grant.\n", orbitID)
+			return nil
+		}
+
+		caps, err := sdk.ParseCapabilities(orbitGrantCapabilities)
+		if err != nil {
+			return err
+		}
+		if len(caps) == 0 {
+			if caps, err = app.OrbitRegistry.PendingCapabilities(ctx, orbitID, app.CurrentUserID); err != nil {
+				return err
+			}
+			if len(caps) == 0 {
+				fmt.Fprintf(out, "Orbit %s already has every capability it declares.\n", orbitID)
+				return nil
+			}
+		}
+
+		if !orbitGrantForce {
+			ok, err := confirmOrbitGrant(cmd.InOrStdin(), out, orbitID, caps)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(out, "Cancelled.")
+				return nil
+			}
+		}
+
+		if err := app.OrbitRegistry.GrantCapabilities(ctx, orbitID, app.CurrentUserID, caps...); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Granted %s: %s\n", orbitID, formatCapabilities(caps))
+		return nil
+	},
+}
+
+var orbitRevokeCmd = &cobra.Command{
+	Use:   "revoke <orbit-id>",
+	Short: "Revoke capabilities granted to an orbit",
+	Long: `Revoke capabilities granted to an orbit.
+
+With --capability, only those capabilities are revoked and the orbit keeps
+running without them. Without it, the whole grant is removed and the orbit
+asks for permission again on its next run.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := orbitGrantApp()
+		if err != nil {
+			return err
+		}
+
+		orbitID := args[0]
+		out := cmd.OutOrStdout()
+
+		caps, err := sdk.ParseCapabilities(orbitRevokeCapabilities)
+		if err != nil {
+			return err
+		}
+		if err := app.OrbitRegistry.RevokeCapabilities(cmd.Context(), orbitID, app.CurrentUserID, caps...); err != nil {
+			if errors.Is(err, sdk.ErrOrbitNotFound) {
+				return fmt.Errorf("orbit not found: %s", orbitID)
+			}
+			return err
+		}
+
+		if len(caps) == 0 {
+			fmt.Fprintf(out, "Revoked all capabilities granted to %s.\n", orbitID)
+			return nil
+		}
+		fmt.Fprintf(out, "Revoked %s: %s\n", orbitID, formatCapabilities(caps))
+		return nil
+	},
+}
+
+func orbitGrantApp() (*App, error) {
+	app := GetApp()
+	if app == nil || app.OrbitRegistry == nil {
+		return nil, fmt.Errorf("orbit registry not available")
+	}
+	if app.CurrentUserID == uuid.Nil {
+		return nil, errors.New("current user not configured")
+	}
+	return app, nil
+}
+
+// confirmOrbitGrant lists the capabilities an orbit asks for and reads a
+// yes/no answer.
+func confirmOrbitGrant(in io.Reader, out io.Writer, orbitID string, caps []sdk.Capability) (bool, error) {
+	fmt.Fprintf(out, "Orbit %s requests the following capabilities:\n", orbitID)
+	for _, c := range caps {
+		fmt.Fprintf(out, "  - %s\n", c)
+	}
+	fmt.Fprint(out, "Grant them? (y/N): ")
+
+	response, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes", nil
+}
+
+func formatCapabilities(caps []sdk.Capability) string {
+	if len(caps) == 0 {
+		return "none"
+	}
+	names := make([]string, len(caps))
+	for i, c := range caps {
+		names[i] = string(c)
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	orbitGrantCmd.Flags().StringArrayVar(&orbitGrantCapabilities, "capability", nil, "capability to grant (repeatable; default: all declared)")
+	orbitGrantCmd.Flags().BoolVarP(&orbitGrantForce, "force", "f", false, "skip confirmation prompt")
+	orbitRevokeCmd.Flags().StringArrayVar(&orbitRevokeCapabilities, "capability", nil, "capability to revoke (repeatable; default: the whole grant)")
+
+	orbitCmd.AddCommand(orbitPermissionsCmd)
+	orbitCmd.AddCommand(orbitGrantCmd)
+	orbitCmd.AddCommand(orbitRevokeCmd)
+}
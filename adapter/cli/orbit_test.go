@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/orbit/registry"
+	"github.com/felixgeelhaar/orbita/internal/orbit/sdk"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrbitGrantCommands(t *testing.T) {
+	reg := registry.NewRegistry(nil, nil).WithGrantStore(registry.NewMemoryGrantStore())
+	require.NoError(t, reg.RegisterManifest(&registry.Manifest{
+		ID:           "acme.pomodoro",
+		Name:         "Pomodoro",
+		Version:      "1.0.0",
+		Type:         "orbit",
+		Capabilities: []string{"read:tasks", "write:storage"},
+	}, ""))
+	userID := uuid.New()
+
+	prev := GetApp()
+	SetApp(&App{OrbitRegistry: reg, CurrentUserID: userID})
+	defer SetApp(prev)
+
+	run := func(t *testing.T, cmd *cobra.Command, input string, args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetIn(strings.NewReader(input))
+		defer cmd.SetOut(nil)
+		defer cmd.SetIn(nil)
+		cmd.SetContext(context.Background())
+		require.NoError(t, cmd.RunE(cmd, args))
+		return out.String()
+	}
+	granted := func() []sdk.Capability {
+		caps, err := reg.GrantedCapabilities(context.Background(), "acme.pomodoro", userID)
+		require.NoError(t, err)
+		return caps
+	}
+
+	out := run(t, orbitGrantCmd, "n\n", "acme.pomodoro")
+	assert.Contains(t, out, "  - read:tasks\n  - write:storage\n")
+	assert.Contains(t, out, "Cancelled.")
+	assert.Empty(t, granted())
+
+	out = run(t, orbitGrantCmd, "y\n", "acme.pomodoro")
+	assert.Contains(t, out, "Granted acme.pomodoro: read:tasks, write:storage")
+	assert.Equal(t, []sdk.Capability{sdk.CapReadTasks, sdk.CapWriteStorage}, granted())
+
+	orbitRevokeCapabilities = []string{"write:storage"}
+	out = run(t, orbitRevokeCmd, "", "acme.pomodoro")
+	orbitRevokeCapabilities = nil
+	assert.Contains(t, out, "Revoked acme.pomodoro: write:storage")
+	assert.Equal(t, []sdk.Capability{sdk.CapReadTasks}, granted())
+
+	out = run(t, orbitPermissionsCmd, "", "acme.pomodoro")
+	assert.Contains(t, out, "Granted: read:tasks\nNot granted: write:storage\n")
+
+	out = run(t, orbitRevokeCmd, "", "acme.pomodoro")
+	assert.Contains(t, out, "Revoked all capabilities granted to acme.pomodoro.")
+	assert.Empty(t, granted())
+}
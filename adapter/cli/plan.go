@@ -28,10 +28,15 @@ Shows available time slots and pending tasks, then helps you
 schedule them. Use --auto to automatically schedule based on
 priority and estimated duration.
 
+The day planned, the shortest slot shown and whether to auto-schedule
+default to the plan.day_offset, plan.min_slot_minutes and
+plan.auto_schedule settings.
+
 Examples:
   orbita plan                    # Plan for tomorrow
   orbita plan --date 2024-01-15  # Plan specific date
   orbita plan --auto             # Auto-schedule tomorrow
+  orbita plan --auto=false       # Only list, even if auto-scheduling is on
   orbita plan --preview          # Preview without scheduling`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		app := GetApp()
@@ -41,6 +46,12 @@ Examples:
 			return nil
 		}
 
+		defaults := app.PlanDefaults(cmd.Context())
+		auto := defaults.AutoSchedule
+		if cmd.Flags().Changed("auto") {
+			auto = planAuto
+		}
+
 		// Determine target date
 		var targetDate time.Time
 		if planDate != "" {
@@ -50,9 +61,9 @@ Examples:
 				return fmt.Errorf("invalid date format, use YYYY-MM-DD: %w", err)
 			}
 		} else {
-			// Default to tomorrow
+			// Default to the user's day offset, tomorrow unless set
 			now := time.Now()
-			targetDate = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+			targetDate = time.Date(now.Year(), now.Month(), now.Day()+defaults.DayOffset, 0, 0, 0, 0, now.Location())
 		}
 
 		dateStr := targetDate.Format("Monday, January 2, 2006")
@@ -64,7 +75,7 @@ Examples:
 		showExistingSchedule(cmd, app, targetDate)
 
 		// Show available slots
-		slots := showAvailableSlots(cmd, app, targetDate)
+		slots := showAvailableSlots(cmd, app, targetDate, time.Duration(defaults.MinSlotMinutes)*time.Minute)
 
 		// Show pending tasks that could be scheduled
 		tasks := showSchedulableTasks(cmd, app)
@@ -72,8 +83,11 @@ Examples:
 		// Show due habits
 		habits := showSchedulableHabits(cmd, app, targetDate)
 
+		// Warn when the pending work does not fit into the free time
+		showCapacity(cmd, app, targetDate, tasks, habits)
+
 		// If auto mode, schedule automatically
-		if planAuto && !planPreview {
+		if auto && !planPreview {
 			return autoScheduleForDay(cmd, app, targetDate, tasks, habits, slots)
 		}
 
@@ -127,7 +141,7 @@ func showExistingSchedule(cmd *cobra.Command, app *App, date time.Time) {
 	}
 }
 
-func showAvailableSlots(cmd *cobra.Command, app *App, date time.Time) []scheduleQueries.TimeSlotDTO {
+func showAvailableSlots(cmd *cobra.Command, app *App, date time.Time, minSlot time.Duration) []scheduleQueries.TimeSlotDTO {
 	if app.FindAvailableSlotsHandler == nil {
 		return nil
 	}
@@ -135,7 +149,7 @@ func showAvailableSlots(cmd *cobra.Command, app *App, date time.Time) []schedule
 	query := scheduleQueries.FindAvailableSlotsQuery{
 		UserID:      app.CurrentUserID,
 		Date:        date,
-		MinDuration: 15, // Minimum 15 minute slots
+		MinDuration: minSlot,
 	}
 
 	slots, err := app.FindAvailableSlotsHandler.Handle(cmd.Context(), query)
@@ -260,6 +274,37 @@ func showSchedulableHabits(cmd *cobra.Command, app *App, date time.Time) []habit
 	return schedulable
 }
 
+func showCapacity(cmd *cobra.Command, app *App, date time.Time, tasks []queries.TaskDTO, habits []habitQueries.HabitDTO) {
+	if app.GetCapacityHandler == nil {
+		return
+	}
+
+	capacity, err := app.GetCapacityHandler.Handle(cmd.Context(), scheduleQueries.GetCapacityQuery{
+		UserID:    app.CurrentUserID,
+		StartDate: date,
+		EndDate:   date,
+	})
+	if err != nil {
+		return
+	}
+
+	planned := 0
+	for _, t := range tasks {
+		planned += t.DurationMinutes
+	}
+	for _, h := range habits {
+		planned += h.DurationMins
+	}
+
+	fmt.Println("\n  CAPACITY")
+	fmt.Println(strings.Repeat("-", 60))
+	fmt.Printf("    Free: %dm of %dm working time\n", capacity.TotalFreeMins, capacity.TotalWorkingMins)
+	fmt.Printf("    Planned: %dm\n", planned)
+	if planned > capacity.TotalFreeMins {
+		fmt.Printf("    Warning: over-committed by %dm\n", planned-capacity.TotalFreeMins)
+	}
+}
+
 func autoScheduleForDay(cmd *cobra.Command, app *App, date time.Time, tasks []queries.TaskDTO, habits []habitQueries.HabitDTO, slots []scheduleQueries.TimeSlotDTO) error {
 	if app.AutoScheduleHandler == nil {
 		return fmt.Errorf("auto-schedule handler not available")
@@ -336,6 +381,8 @@ func autoScheduleForDay(cmd *cobra.Command, app *App, date time.Time, tasks []qu
 			Priority: p,
 			Duration: time.Duration(t.DurationMinutes) * time.Minute,
 			DueDate:  t.DueDate,
+
+			EarliestStart: t.EarliestStart,
 		})
 	}
 
@@ -394,8 +441,8 @@ func priorityForMeetingTime(preferred time.Duration) int {
 }
 
 func init() {
-	planCmd.Flags().StringVarP(&planDate, "date", "d", "", "date to plan (YYYY-MM-DD, default: tomorrow)")
-	planCmd.Flags().BoolVar(&planAuto, "auto", false, "automatically schedule tasks and habits")
+	planCmd.Flags().StringVarP(&planDate, "date", "d", "", "date to plan (YYYY-MM-DD, default: plan.day_offset days ahead)")
+	planCmd.Flags().BoolVar(&planAuto, "auto", false, "automatically schedule tasks and habits (default: plan.auto_schedule)")
 	planCmd.Flags().BoolVar(&planPreview, "preview", false, "preview without making changes")
 
 	rootCmd.AddCommand(planCmd)
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	marketplaceQueries "github.com/felixgeelhaar/orbita/internal/marketplace/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/marketplace/infrastructure/cliplugin"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// RegisterPluginCommands adds the commands declared by the current user's
+// enabled marketplace packages. Each package's commands are grouped under its
+// namespace and run through the package's plugin binary. Packages that cannot
+// be loaded or whose namespace clashes with an existing command are skipped.
+//
+// Installed packages are read from the marketplace records when the app has
+// them, and otherwise from the packages found in installDir.
+func RegisterPluginCommands(ctx context.Context, installDir string, launcher cliplugin.Launcher) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	packages, err := installedPackages(ctx, installDir)
+	if err != nil {
+		logger.Warn("failed to list installed packages for plugin commands", "error", err)
+		return
+	}
+
+	registerPluginCommands(rootCmd, packages, launcher)
+}
+
+// installedPackages lists the current user's installed packages.
+func installedPackages(ctx context.Context, installDir string) ([]*marketplaceQueries.InstalledPackageDTO, error) {
+	if app != nil && app.ListInstalledHandler != nil {
+		result, err := app.ListInstalledHandler.Handle(ctx, marketplaceQueries.ListInstalledQuery{
+			UserID: app.CurrentUserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.Packages, nil
+	}
+
+	dirs, err := cliplugin.FindInstalled(installDir)
+	if err != nil {
+		return nil, err
+	}
+	packages := make([]*marketplaceQueries.InstalledPackageDTO, 0, len(dirs))
+	for _, dir := range dirs {
+		packages = append(packages, &marketplaceQueries.InstalledPackageDTO{
+			PackageID:   filepath.Base(filepath.Dir(dir)),
+			Version:     filepath.Base(dir),
+			InstallPath: dir,
+			Enabled:     true,
+		})
+	}
+	return packages, nil
+}
+
+func registerPluginCommands(root *cobra.Command, packages []*marketplaceQueries.InstalledPackageDTO, launcher cliplugin.Launcher) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	for _, pkg := range packages {
+		if !pkg.Enabled {
+			continue
+		}
+
+		manifest, err := cliplugin.LoadManifest(pkg.InstallPath)
+		if err != nil {
+			logger.Warn("skipping plugin commands", "package_id", pkg.PackageID, "error", err)
+			continue
+		}
+		if manifest.CLI == nil || len(manifest.CLI.Commands) == 0 {
+			continue
+		}
+
+		namespace := manifest.Namespace()
+		if hasCommand(root, namespace) {
+			logger.Warn("skipping plugin commands, namespace already in use",
+				"package_id", pkg.PackageID,
+				"namespace", namespace,
+			)
+			continue
+		}
+
+		root.AddCommand(newPluginNamespaceCmd(manifest, launcher))
+	}
+}
+
+func hasCommand(root *cobra.Command, name string) bool {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func newPluginNamespaceCmd(manifest *cliplugin.Manifest, launcher cliplugin.Launcher) *cobra.Command {
+	namespaceCmd := &cobra.Command{
+		Use:   manifest.Namespace(),
+		Short: fmt.Sprintf("Commands provided by the %s package", manifest.ID),
+	}
+
+	for _, spec := range manifest.CLI.Commands {
+		name := spec.Name
+		namespaceCmd.AddCommand(&cobra.Command{
+			Use:   name,
+			Short: spec.Short,
+			Long:  spec.Long,
+			// Flags belong to the plugin, so arguments are forwarded untouched.
+			DisableFlagParsing: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return runPluginCommand(cmd, manifest, launcher, name, args)
+			},
+		})
+	}
+
+	return namespaceCmd
+}
+
+func runPluginCommand(cmd *cobra.Command, manifest *cliplugin.Manifest, launcher cliplugin.Launcher, name string, args []string) error {
+	commander, stop, err := launcher.Launch(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to start %s plugin: %w", manifest.ID, err)
+	}
+	defer stop()
+
+	req := cliplugin.CommandRequest{Command: name, Args: args}
+	if app != nil && app.CurrentUserID != uuid.Nil {
+		req.UserID = app.CurrentUserID.String()
+	}
+
+	resp, err := commander.RunCommand(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", manifest.Namespace(), name, err)
+	}
+
+	if resp.Output != "" {
+		fmt.Fprint(cmd.OutOrStdout(), resp.Output)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("%s %s failed: %s", manifest.Namespace(), name, resp.Error)
+	}
+	return nil
+}
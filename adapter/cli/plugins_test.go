@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	marketplaceQueries "github.com/felixgeelhaar/orbita/internal/marketplace/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/marketplace/infrastructure/cliplugin"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-plugin"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCommander is the plugin side of a stub package.
+type stubCommander struct {
+	requests []cliplugin.CommandRequest
+}
+
+func (s *stubCommander) RunCommand(req cliplugin.CommandRequest) (*cliplugin.CommandResponse, error) {
+	s.requests = append(s.requests, req)
+	if req.Command == "fail" {
+		return &cliplugin.CommandResponse{Error: "nothing to do"}, nil
+	}
+	return &cliplugin.CommandResponse{Output: "focus started: " + strings.Join(req.Args, " ") + "\n"}, nil
+}
+
+// rpcLauncher connects to the stub commander over an in-process plugin RPC
+// connection, so calls go through the same bridge as a plugin process.
+type rpcLauncher struct {
+	t         *testing.T
+	commander *stubCommander
+	launched  int
+}
+
+func (l *rpcLauncher) Launch(manifest *cliplugin.Manifest) (cliplugin.Commander, func(), error) {
+	l.launched++
+	client, _ := plugin.TestPluginRPCConn(l.t, cliplugin.PluginMap(l.commander), nil)
+	raw, err := client.Dispense(cliplugin.PluginName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw.(cliplugin.Commander), func() { _ = client.Close() }, nil
+}
+
+func writePluginManifest(t *testing.T, manifest string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "orbit.json"), []byte(manifest), 0600))
+	return dir
+}
+
+func newPluginTestRoot() *cobra.Command {
+	root := &cobra.Command{Use: "orbita", SilenceUsage: true, SilenceErrors: true}
+	root.AddCommand(&cobra.Command{Use: "task"})
+	return root
+}
+
+const focusManifest = `{
+	"id": "acme.focus",
+	"name": "Focus",
+	"version": "1.0.0",
+	"type": "orbit",
+	"cli": {
+		"namespace": "focus",
+		"binary": "bin/focus-plugin",
+		"commands": [
+			{"name": "start", "short": "Start a focus session"},
+			{"name": "fail"}
+		]
+	}
+}`
+
+func TestRegisterPluginCommands_RunsThroughPluginBridge(t *testing.T) {
+	dir := writePluginManifest(t, focusManifest)
+	launcher := &rpcLauncher{t: t, commander: &stubCommander{}}
+
+	userID := uuid.New()
+	prev := GetApp()
+	SetApp(&App{CurrentUserID: userID})
+	defer SetApp(prev)
+
+	root := newPluginTestRoot()
+	registerPluginCommands(root, []*marketplaceQueries.InstalledPackageDTO{
+		{PackageID: "acme.focus", InstallPath: dir, Enabled: true},
+	}, launcher)
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs([]string{"focus", "start", "--minutes", "25", "deep work"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "focus started: --minutes 25 deep work\n", out.String())
+	assert.Equal(t, 1, launcher.launched)
+
+	require.Len(t, launcher.commander.requests, 1)
+	req := launcher.commander.requests[0]
+	assert.Equal(t, "start", req.Command)
+	assert.Equal(t, []string{"--minutes", "25", "deep work"}, req.Args)
+	assert.Equal(t, userID.String(), req.UserID)
+}
+
+func TestRegisterPluginCommands_PluginError(t *testing.T) {
+	dir := writePluginManifest(t, focusManifest)
+	launcher := &rpcLauncher{t: t, commander: &stubCommander{}}
+
+	root := newPluginTestRoot()
+	registerPluginCommands(root, []*marketplaceQueries.InstalledPackageDTO{
+		{PackageID: "acme.focus", InstallPath: dir, Enabled: true},
+	}, launcher)
+
+	root.SetArgs([]string{"focus", "fail"})
+	err := root.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to do")
+}
+
+func TestRegisterPluginCommands_SkipsUnusablePackages(t *testing.T) {
+	launcher := &rpcLauncher{t: t, commander: &stubCommander{}}
+
+	clashing := writePluginManifest(t, `{
+		"id": "acme.tasks",
+		"cli": {"namespace": "task", "binary": "plugin", "commands": [{"name": "sync"}]}
+	}`)
+	escaping := writePluginManifest(t, `{
+		"id": "acme.escape",
+		"cli": {"binary": "../plugin", "commands": [{"name": "run"}]}
+	}`)
+	noCommands := writePluginManifest(t, `{"id": "acme.quiet"}`)
+
+	root := newPluginTestRoot()
+	registerPluginCommands(root, []*marketplaceQueries.InstalledPackageDTO{
+		{PackageID: "acme.focus", InstallPath: writePluginManifest(t, focusManifest), Enabled: false},
+		{PackageID: "acme.tasks", InstallPath: clashing, Enabled: true},
+		{PackageID: "acme.escape", InstallPath: escaping, Enabled: true},
+		{PackageID: "acme.quiet", InstallPath: noCommands, Enabled: true},
+		{PackageID: "acme.missing", InstallPath: t.TempDir(), Enabled: true},
+	}, launcher)
+
+	require.Len(t, root.Commands(), 1)
+	assert.Equal(t, "task", root.Commands()[0].Name())
+	assert.Empty(t, root.Commands()[0].Commands())
+}
+
+func TestRegisterPluginCommands_DefaultsNamespaceToPackageID(t *testing.T) {
+	dir := writePluginManifest(t, `{
+		"id": "acme.pomodoro",
+		"cli": {"binary": "plugin", "commands": [{"name": "start"}]}
+	}`)
+	launcher := &rpcLauncher{t: t, commander: &stubCommander{}}
+
+	root := newPluginTestRoot()
+	registerPluginCommands(root, []*marketplaceQueries.InstalledPackageDTO{
+		{PackageID: "acme.pomodoro", InstallPath: dir, Enabled: true},
+	}, launcher)
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs([]string{"acme.pomodoro", "start"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "focus started: \n", out.String())
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	internalApp "github.com/felixgeelhaar/orbita/internal/app"
 	projectQueries "github.com/felixgeelhaar/orbita/internal/projects/application/queries"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/pkg/config"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -43,7 +44,7 @@ func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 		Level: slog.LevelError,
 	}))
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	container, err := internalApp.NewLocalContainer(ctx, cfg, logger)
 	require.NoError(t, err)
 
@@ -110,7 +111,7 @@ func TestCreateCmd_CreatesProject(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	createDescription = ""
@@ -140,7 +141,7 @@ func TestCreateCmd_WithDescription(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	createDescription = "A comprehensive website redesign project"
@@ -169,7 +170,7 @@ func TestCreateCmd_WithDueDate(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	createDescription = ""
@@ -202,7 +203,7 @@ func TestCreateCmd_InvalidDueDate(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	createDescription = ""
@@ -223,7 +224,7 @@ func TestCreateCmd_InvalidStartDate(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	createDescription = ""
@@ -244,7 +245,7 @@ func TestListCmd_ShowsProjects(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create some projects first
 	createDescription = ""
@@ -274,7 +275,7 @@ func TestListCmd_EmptyList(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Verify empty list
 	projects, err := app.ListProjectsHandler.Handle(ctx, projectQueries.ListProjectsQuery{
@@ -299,7 +300,7 @@ func TestShowCmd_InvalidProjectID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	showCmd.SetContext(ctx)
 	err := showCmd.RunE(showCmd, []string{"not-a-uuid"})
@@ -310,7 +311,7 @@ func TestShowCmd_InvalidProjectID(t *testing.T) {
 func TestCreateCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	createDescription = ""
 	createCmd.SetContext(ctx)
 
@@ -322,7 +323,7 @@ func TestCreateCmd_NoApp(t *testing.T) {
 func TestListCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	listCmd.SetContext(ctx)
 
 	err := listCmd.RunE(listCmd, []string{})
@@ -333,7 +334,7 @@ func TestListCmd_NoApp(t *testing.T) {
 func TestShowCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	showCmd.SetContext(ctx)
 
 	err := showCmd.RunE(showCmd, []string{uuid.NewString()})
@@ -5,8 +5,10 @@ import (
 	"strings"
 	"time"
 
+	billingDomain "github.com/felixgeelhaar/orbita/internal/billing/domain"
 	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	scheduleCommands "github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
 	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
 	"github.com/spf13/cobra"
 )
@@ -18,7 +20,7 @@ var reviewCmd = &cobra.Command{
 
 - Overdue tasks
 - Tasks due today
-- Missed schedule blocks
+- Missed schedule blocks (auto-rescheduled when SCHEDULE_AUTO_RESCHEDULE_MISSED is enabled)
 - Habits with broken streaks
 - Habits due today (not completed)
 
@@ -54,7 +56,8 @@ Examples:
 
 		// Check missed blocks
 		if app.GetScheduleHandler != nil {
-			issues := reviewMissedBlocks(cmd, app, now)
+			rescheduled := autoRescheduleMissedBlocks(cmd, app, now)
+			issues := reviewMissedBlocks(cmd, app, now, rescheduled)
 			totalIssues += issues
 		}
 
@@ -138,7 +141,28 @@ func reviewOverdueTasks(cmd *cobra.Command, app *App, today time.Time) int {
 	return issues
 }
 
-func reviewMissedBlocks(cmd *cobra.Command, app *App, now time.Time) int {
+// autoRescheduleMissedBlocks applies the opt-in missed block policy before the review.
+func autoRescheduleMissedBlocks(cmd *cobra.Command, app *App, now time.Time) *scheduleCommands.AutoRescheduleResult {
+	if app.AutoRescheduleHandler == nil || !app.AutoRescheduleHandler.MissedBlockPolicy().AutoReschedule {
+		return nil
+	}
+	if err := RequireEntitlement(cmd.Context(), app, billingDomain.ModuleAutoRescheduler); err != nil {
+		return nil
+	}
+
+	result, err := app.AutoRescheduleHandler.Handle(cmd.Context(), scheduleCommands.AutoRescheduleCommand{
+		UserID:    app.CurrentUserID,
+		Date:      now,
+		After:     &now,
+		Automatic: true,
+	})
+	if err != nil {
+		return nil
+	}
+	return result
+}
+
+func reviewMissedBlocks(cmd *cobra.Command, app *App, now time.Time, rescheduled *scheduleCommands.AutoRescheduleResult) int {
 	query := scheduleQueries.GetScheduleQuery{
 		UserID: app.CurrentUserID,
 		Date:   now,
@@ -180,6 +204,10 @@ func reviewMissedBlocks(cmd *cobra.Command, app *App, now time.Time) int {
 
 	fmt.Printf("  Today: %d completed | %d missed | %d upcoming\n",
 		completed, missed, upcoming)
+	if rescheduled != nil && (rescheduled.Rescheduled > 0 || rescheduled.Flagged > 0) {
+		fmt.Printf("  Auto-rescheduled: %d | Flagged (missed too often): %d\n",
+			rescheduled.Rescheduled, rescheduled.Flagged)
+	}
 
 	return missed
 }
@@ -213,24 +241,18 @@ func reviewHabits(cmd *cobra.Command, app *App) int {
 		}
 	}
 
-	// Show incomplete habits due today
-	incomplete := 0
-	for _, h := range dueHabits {
-		if !h.CompletedToday {
-			incomplete++
-		}
-	}
+	// Show habits still actionable today
+	pendingHabits, _ := app.DueHabits(cmd.Context())
+	incomplete := len(pendingHabits)
 
 	if incomplete > 0 {
 		fmt.Printf("  Due Today (%d remaining):\n", incomplete)
-		for _, h := range dueHabits {
-			if !h.CompletedToday {
-				streakInfo := ""
-				if h.Streak > 0 {
-					streakInfo = fmt.Sprintf(" (streak: %d)", h.Streak)
-				}
-				fmt.Printf("    [ ] %s%s\n", h.Name, streakInfo)
+		for _, h := range pendingHabits {
+			streakInfo := ""
+			if h.Streak > 0 {
+				streakInfo = fmt.Sprintf(" (streak: %d)", h.Streak)
 			}
+			fmt.Printf("    [ ] %s%s\n", h.Name, streakInfo)
 		}
 	}
 
@@ -2,11 +2,15 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"time"
 
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
@@ -14,12 +18,13 @@ import (
 var (
 	cfgFile string
 	verbose bool
+	timeout time.Duration
 	logger  *slog.Logger
 )
 
 type commandContext struct {
-	correlationID uuid.UUID
-	startedAt     time.Time
+	startedAt time.Time
+	cancel    context.CancelFunc
 }
 
 type commandContextKey struct{}
@@ -38,14 +43,25 @@ that orchestrates tasks, calendars, habits, and meetings.
 			logger = slog.Default()
 		}
 		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
 		info := commandContext{
-			correlationID: uuid.New(),
-			startedAt:     time.Now(),
+			startedAt: time.Now(),
+			cancel:    func() {},
+		}
+		// Handlers log through observability.Logger, so every line for this
+		// command carries the same correlation ID.
+		ctx = observability.WithCorrelationID(ctx, "")
+		if timeout > 0 {
+			ctx, info.cancel = context.WithTimeout(ctx, timeout)
+		}
+		if app != nil && app.CurrentUserID != uuid.Nil {
+			ctx = sharedApplication.WithPrincipal(ctx, app.CurrentUserID)
 		}
 		cmd.SetContext(context.WithValue(ctx, commandContextKey{}, info))
-		logger.Info("command start",
+		observability.Logger(ctx, logger).Info("command start",
 			"command", cmd.CommandPath(),
-			"correlation_id", info.correlationID.String(),
 		)
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
@@ -56,9 +72,9 @@ that orchestrates tasks, calendars, habits, and meetings.
 		if !ok {
 			return
 		}
-		logger.Info("command end",
+		info.cancel()
+		observability.Logger(cmd.Context(), logger).Info("command end",
 			"command", cmd.CommandPath(),
-			"correlation_id", info.correlationID.String(),
 			"duration_ms", time.Since(info.startedAt).Milliseconds(),
 		)
 	},
@@ -66,16 +82,64 @@ that orchestrates tasks, calendars, habits, and meetings.
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	if code := execute(rootCmd, os.Stderr); code != ExitOK {
+		os.Exit(code)
+	}
+}
+
+// execute runs cmd, reports any error to stderr and returns the exit code
+// for the error's category. The --timeout deadline is derived from the
+// context created here, so cancelling it on return releases the timer even
+// when the command fails before its post-run hook.
+func execute(cmd *cobra.Command, stderr io.Writer) int {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		if timeout > 0 && errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("command timed out after %s: %w", timeout, err)
+		}
+		fmt.Fprintln(stderr, err)
+		return ExitCode(err)
 	}
+	return ExitOK
+}
+
+// Exit codes returned by the CLI, by error category.
+const (
+	ExitOK         = 0
+	ExitFailure    = 1
+	ExitValidation = 2
+	ExitNotFound   = 3
+	ExitConflict   = 4
+)
+
+// ExitCode maps an error to the process exit code for its category.
+// Uncategorized errors exit with ExitFailure.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, sharedApplication.ErrValidation):
+		return ExitValidation
+	case errors.Is(err, sharedApplication.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, sharedApplication.ErrConflict):
+		return ExitConflict
+	}
+	return ExitFailure
 }
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file path")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "abort the command after this duration (e.g. 30s, 2m); 0 disables")
+
+	// Bad flags are usage mistakes; report them as validation failures.
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return sharedApplication.Validation(err)
+	})
 }
 
 // AddCommand adds a command to the root command.
@@ -83,6 +147,11 @@ func AddCommand(cmd *cobra.Command) {
 	rootCmd.AddCommand(cmd)
 }
 
+// RootCommand returns the root command with every command registered so far.
+func RootCommand() *cobra.Command {
+	return rootCmd
+}
+
 // SetLogger sets the CLI logger.
 func SetLogger(l *slog.Logger) {
 	logger = l
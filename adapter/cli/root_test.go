@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	habitCommands "github.com/felixgeelhaar/orbita/internal/habits/application/commands"
+	marketplaceCommands "github.com/felixgeelhaar/orbita/internal/marketplace/application/commands"
+	marketplaceQueries "github.com/felixgeelhaar/orbita/internal/marketplace/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	taskCommands "github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"success", nil, ExitOK},
+		{"validation", sharedApplication.NewValidationError("title is required"), ExitValidation},
+		{"not found", fmt.Errorf("failed to complete task: %w", taskCommands.ErrTaskNotFound), ExitNotFound},
+		{"not owner", habitCommands.ErrNotOwner, ExitNotFound},
+		{"conflict", sharedApplication.NewConflictError("already completed"), ExitConflict},
+		{"uncategorized", errors.New("database unavailable"), ExitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestExecute_ExitCodes(t *testing.T) {
+	userID := uuid.New()
+	installedRepo := &fakeInstalledRepo{packages: []*domain.InstalledPackage{
+		domain.NewInstalledPackage("acme.focus", "1.0.0", domain.PackageTypeOrbit, t.TempDir(), userID),
+	}}
+
+	prev := GetApp()
+	defer SetApp(prev)
+
+	var cmdOutput bytes.Buffer
+	rootCmd.SetOut(&cmdOutput)
+	rootCmd.SetErr(&cmdOutput)
+	defer func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		rootCmd.SetArgs(nil)
+	}()
+
+	tests := []struct {
+		name     string
+		app      *App
+		args     []string
+		expected int
+	}{
+		{
+			name:     "validation",
+			app:      &App{CurrentUserID: userID, EnablePackageHandler: marketplaceCommands.NewEnablePackageHandler(installedRepo)},
+			args:     []string{"marketplace", "enable", "acme.focus", "--no-such-flag"},
+			expected: ExitValidation,
+		},
+		{
+			name:     "not found",
+			app:      &App{CurrentUserID: userID, EnablePackageHandler: marketplaceCommands.NewEnablePackageHandler(installedRepo)},
+			args:     []string{"marketplace", "enable", "acme.missing"},
+			expected: ExitNotFound,
+		},
+		{
+			name:     "conflict",
+			app:      &App{CurrentUserID: userID, EnablePackageHandler: marketplaceCommands.NewEnablePackageHandler(installedRepo)},
+			args:     []string{"marketplace", "enable", "acme.focus"},
+			expected: ExitConflict,
+		},
+		{
+			name:     "internal",
+			app:      &App{CurrentUserID: userID},
+			args:     []string{"marketplace", "enable", "acme.focus"},
+			expected: ExitFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetApp(tt.app)
+			rootCmd.SetArgs(tt.args)
+
+			var stderr bytes.Buffer
+			assert.Equal(t, tt.expected, execute(rootCmd, &stderr))
+			assert.NotEmpty(t, stderr.String())
+		})
+	}
+}
+
+// slowInstalledRepo blocks until the caller's context is done.
+type slowInstalledRepo struct {
+	fakeInstalledRepo
+}
+
+func (r *slowInstalledRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.InstalledPackage, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(10 * time.Second):
+		return nil, nil
+	}
+}
+
+func TestExecute_Timeout(t *testing.T) {
+	repo := &slowInstalledRepo{}
+
+	prev := GetApp()
+	SetApp(&App{
+		CurrentUserID:       uuid.New(),
+		CheckUpdatesHandler: marketplaceQueries.NewCheckUpdatesHandler(repo, &fakePackageRepo{}),
+	})
+	defer SetApp(prev)
+
+	var cmdOutput bytes.Buffer
+	rootCmd.SetOut(&cmdOutput)
+	rootCmd.SetErr(&cmdOutput)
+	rootCmd.SetArgs([]string{"marketplace", "check-updates", "--timeout", "50ms"})
+	defer func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		rootCmd.SetArgs(nil)
+		_ = rootCmd.PersistentFlags().Set("timeout", "0")
+	}()
+
+	var stderr bytes.Buffer
+	start := time.Now()
+	code := execute(rootCmd, &stderr)
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, ExitFailure, code)
+	assert.Contains(t, stderr.String(), "command timed out after 50ms")
+}
+
+func TestExecute_CorrelationID(t *testing.T) {
+	var logs bytes.Buffer
+	prevLogger := logger
+	SetLogger(slog.New(slog.NewJSONHandler(&logs, nil)))
+	defer SetLogger(prevLogger)
+
+	prev := GetApp()
+	SetApp(nil)
+	defer SetApp(prev)
+
+	// The handler logs like application handlers do.
+	handlerCmd := &cobra.Command{
+		Use: "log-work",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			observability.Logger(cmd.Context(), slog.New(slog.NewJSONHandler(&logs, nil))).Info("handler work")
+			return nil
+		},
+	}
+	rootCmd.AddCommand(handlerCmd)
+	defer rootCmd.RemoveCommand(handlerCmd)
+	rootCmd.SetArgs([]string{"log-work"})
+	defer rootCmd.SetArgs(nil)
+
+	correlationIDs := func() []string {
+		var ids []string
+		for _, line := range bytes.Split(bytes.TrimSpace(logs.Bytes()), []byte("\n")) {
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(line, &entry))
+			id, _ := entry[observability.CorrelationIDKey].(string)
+			ids = append(ids, id)
+		}
+		logs.Reset()
+		return ids
+	}
+
+	var stderr bytes.Buffer
+	require.Equal(t, ExitOK, execute(rootCmd, &stderr))
+	first := correlationIDs()
+	require.Len(t, first, 3, "command start, handler work, command end")
+	assert.NotEmpty(t, first[0])
+	assert.Equal(t, first[0], first[1])
+	assert.Equal(t, first[0], first[2])
+
+	require.Equal(t, ExitOK, execute(rootCmd, &stderr))
+	second := correlationIDs()
+	require.Len(t, second, 3)
+	assert.NotEqual(t, first[0], second[0], "each command gets its own ID")
+}
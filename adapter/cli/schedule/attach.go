@@ -0,0 +1,72 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	attachDate  string
+	attachLabel string
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach <block-id> <link-or-path>",
+	Short: "Attach a link or file path to a block",
+	Long: `Attach reference material, such as a document for a focus session, to a
+block. Attachments are listed by 'orbita schedule show' and can be included
+in synced event descriptions with 'orbita sync --attachments'.
+
+You can find block IDs using 'orbita schedule show'.
+
+Examples:
+  orbita schedule attach abc123 https://docs.example.com/spec --label "Spec"
+  orbita schedule attach abc123 ~/notes/focus.md --date 2024-01-15`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		out := cmd.OutOrStdout()
+		if app == nil || app.AddBlockAttachmentHandler == nil {
+			fmt.Fprintln(out, "Schedule commands require database connection.")
+			fmt.Fprintln(out, "Start services with: docker-compose up -d")
+			return nil
+		}
+
+		blockID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid block ID: %w", err)
+		}
+
+		date := time.Now()
+		if attachDate != "" {
+			date, err = time.Parse("2006-01-02", attachDate)
+			if err != nil {
+				return fmt.Errorf("invalid date format, use YYYY-MM-DD: %w", err)
+			}
+		}
+
+		attachments, err := app.AddBlockAttachmentHandler.Handle(cmd.Context(), commands.AddBlockAttachmentCommand{
+			UserID:  app.CurrentUserID,
+			Date:    date,
+			BlockID: blockID,
+			Target:  args[1],
+			Label:   attachLabel,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add attachment: %w", err)
+		}
+
+		fmt.Fprintf(out, "Attachment added to block %s (%d total).\n", blockID, len(attachments))
+		return nil
+	},
+}
+
+func init() {
+	attachCmd.Flags().StringVarP(&attachDate, "date", "d", "", "date of the schedule (YYYY-MM-DD, default: today)")
+	attachCmd.Flags().StringVarP(&attachLabel, "label", "l", "", "display name for the attachment")
+}
@@ -6,10 +6,11 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
-	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
 	meetingQueries "github.com/felixgeelhaar/orbita/internal/meetings/application/queries"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +18,7 @@ var (
 	autoDate            string
 	autoIncludeHabits   bool
 	autoIncludeMeetings bool
+	autoExplain         bool
 )
 
 var autoCmd = &cobra.Command{
@@ -33,7 +35,8 @@ Examples:
   orbita schedule auto
   orbita schedule auto --date 2024-01-15
   orbita schedule auto --habits
-  orbita schedule auto --meetings`,
+  orbita schedule auto --meetings
+  orbita schedule auto --explain`,
 	Aliases: []string{"generate", "plan"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		app := cli.GetApp()
@@ -70,20 +73,7 @@ Examples:
 			}
 
 			for _, task := range tasks {
-				// Map priority string to number
-				priority := 3 // default medium
-				switch task.Priority {
-				case "urgent":
-					priority = 1
-				case "high":
-					priority = 2
-				case "medium":
-					priority = 3
-				case "low":
-					priority = 4
-				case "none":
-					priority = 5
-				}
+				priority := value_objects.EngineLevelFor(task.Priority)
 
 				// Default duration if not set
 				duration := time.Duration(task.DurationMinutes) * time.Minute
@@ -98,27 +88,20 @@ Examples:
 					Priority: priority,
 					Duration: duration,
 					DueDate:  task.DueDate,
+
+					EarliestStart: task.EarliestStart,
 				})
 			}
 		}
 
 		// Get due habits if requested
-		if autoIncludeHabits && app.ListHabitsHandler != nil {
-			habitsQuery := habitQueries.ListHabitsQuery{
-				UserID:       app.CurrentUserID,
-				OnlyDueToday: true,
-			}
-			habits, err := app.ListHabitsHandler.Handle(cmd.Context(), habitsQuery)
+		if autoIncludeHabits {
+			habits, err := app.DueHabits(cmd.Context())
 			if err != nil {
 				return fmt.Errorf("failed to list habits: %w", err)
 			}
 
 			for _, habit := range habits {
-				// Skip already completed habits
-				if habit.CompletedToday {
-					continue
-				}
-
 				// Habits get medium-high priority (2) by default
 				priority := 2
 
@@ -190,9 +173,10 @@ Examples:
 
 		// Run auto-schedule
 		cmdData := commands.AutoScheduleCommand{
-			UserID: app.CurrentUserID,
-			Date:   date,
-			Tasks:  items,
+			UserID:  app.CurrentUserID,
+			Date:    date,
+			Tasks:   items,
+			Explain: autoExplain,
 		}
 
 		result, err := app.AutoScheduleHandler.Handle(cmd.Context(), cmdData)
@@ -221,6 +205,7 @@ Examples:
 					item.StartTime.Format("15:04"),
 					item.EndTime.Format("15:04"),
 				)
+				printRationale(item.Rationale)
 			}
 		}
 
@@ -229,6 +214,7 @@ Examples:
 			for _, item := range result.Results {
 				if !item.Scheduled {
 					fmt.Printf("  [%s] %s - %s\n", item.ItemType, item.Title, item.Reason)
+					printRationale(item.Rationale)
 				}
 			}
 		}
@@ -246,6 +232,25 @@ func init() {
 	autoCmd.Flags().StringVarP(&autoDate, "date", "d", "", "date to schedule for (YYYY-MM-DD, default: today)")
 	autoCmd.Flags().BoolVar(&autoIncludeHabits, "habits", false, "include due habits in scheduling")
 	autoCmd.Flags().BoolVar(&autoIncludeMeetings, "meetings", false, "include meeting candidates in scheduling")
+	autoCmd.Flags().BoolVar(&autoExplain, "explain", false, "show why each slot was chosen")
+}
+
+// printRationale prints the scheduler's reasoning for an item, if any.
+func printRationale(rationale *services.SlotRationale) {
+	if rationale == nil {
+		return
+	}
+	fmt.Printf("       why: %s\n", rationale.Rule)
+	if len(rationale.Factors) > 0 {
+		fmt.Printf("       factors: %s\n", strings.Join(rationale.Factors, ", "))
+	}
+	for _, alt := range rationale.Alternatives {
+		fmt.Printf("       skipped %s - %s: %s\n",
+			alt.Slot.Start.Format("15:04"),
+			alt.Slot.End.Format("15:04"),
+			alt.Reason,
+		)
+	}
 }
 
 func priorityForMeetingTime(preferred time.Duration) int {
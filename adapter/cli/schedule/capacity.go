@@ -0,0 +1,123 @@
+package schedule
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	capacityDate  string
+	capacityDays  int
+	capacityWeek  bool
+	capacityStart string
+	capacityEnd   string
+)
+
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Show free capacity within working hours",
+	Long: `Show how much free time you have within working hours, per day and in
+total. Scheduled blocks and events from connected calendars are subtracted.
+
+Examples:
+  orbita schedule capacity                      # Today
+  orbita schedule capacity --week               # This week
+  orbita schedule capacity --date 2024-01-15 --days 5
+  orbita schedule capacity --start 08:00 --end 16:00`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.GetCapacityHandler == nil {
+			fmt.Println("Schedule queries require database connection.")
+			fmt.Println("Start services with: docker-compose up -d")
+			return nil
+		}
+
+		start := time.Now()
+		if capacityDate != "" {
+			var err error
+			start, err = time.Parse("2006-01-02", capacityDate)
+			if err != nil {
+				return fmt.Errorf("invalid date format, use YYYY-MM-DD: %w", err)
+			}
+		}
+		days := capacityDays
+		if capacityWeek {
+			start = sharedDomain.StartOfWeek(start, cli.WeekStartsOn())
+			days = 7
+		}
+		if days < 1 {
+			return fmt.Errorf("--days must be at least 1")
+		}
+
+		workStart, err := parseClock(capacityStart)
+		if err != nil {
+			return fmt.Errorf("invalid start time format, use HH:MM: %w", err)
+		}
+		workEnd, err := parseClock(capacityEnd)
+		if err != nil {
+			return fmt.Errorf("invalid end time format, use HH:MM: %w", err)
+		}
+		if workEnd <= workStart {
+			return fmt.Errorf("end time must be after start time")
+		}
+
+		capacity, err := app.GetCapacityHandler.Handle(cmd.Context(), queries.GetCapacityQuery{
+			UserID:    app.CurrentUserID,
+			StartDate: start,
+			EndDate:   start.AddDate(0, 0, days-1),
+			WorkStart: workStart,
+			WorkEnd:   workEnd,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get capacity: %w", err)
+		}
+
+		renderCapacity(cmd.OutOrStdout(), capacity, capacityStart, capacityEnd)
+		return nil
+	},
+}
+
+// renderCapacity prints free and booked time per day and the totals.
+func renderCapacity(w io.Writer, capacity *queries.CapacityDTO, workStart, workEnd string) {
+	fmt.Fprintf(w, "Capacity %s - %s\n", capacity.StartDate.Format("Jan 2"), capacity.EndDate.Format("Jan 2, 2006"))
+	fmt.Fprintf(w, "Working hours: %s - %s\n", workStart, workEnd)
+	fmt.Fprintln(w, strings.Repeat("-", 50))
+
+	for _, day := range capacity.Days {
+		fmt.Fprintf(w, "  %-16s %8s free  %8s booked\n",
+			day.Date.Format("Mon Jan 2"),
+			formatDuration(time.Duration(day.FreeMins)*time.Minute),
+			formatDuration(time.Duration(day.BookedMins)*time.Minute),
+		)
+	}
+
+	fmt.Fprintln(w, strings.Repeat("-", 50))
+	fmt.Fprintf(w, "Total: %s free of %s\n",
+		formatDuration(time.Duration(capacity.TotalFreeMins)*time.Minute),
+		formatDuration(time.Duration(capacity.TotalWorkingMins)*time.Minute),
+	)
+}
+
+// parseClock parses HH:MM into an offset from midnight.
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func init() {
+	capacityCmd.Flags().StringVarP(&capacityDate, "date", "d", "", "first day (YYYY-MM-DD, default: today)")
+	capacityCmd.Flags().IntVar(&capacityDays, "days", 1, "number of days to include")
+	capacityCmd.Flags().BoolVarP(&capacityWeek, "week", "w", false, "show the whole week")
+	capacityCmd.Flags().StringVar(&capacityStart, "start", "09:00", "workday start time (HH:MM)")
+	capacityCmd.Flags().StringVar(&capacityEnd, "end", "17:00", "workday end time (HH:MM)")
+}
@@ -10,15 +10,23 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	completeNotes   string
+	completeOutcome int
+)
+
 var completeCmd = &cobra.Command{
 	Use:   "complete <schedule-id> <block-id>",
 	Short: "Mark a time block as completed",
 	Long: `Mark a scheduled time block as completed.
 
 You can find the schedule and block IDs using 'orbita schedule show'.
+Optionally record how the block went with notes and an outcome rating
+from 1 (poor) to 5 (excellent).
 
 Examples:
-  orbita schedule complete abc123 def456`,
+  orbita schedule complete abc123 def456
+  orbita schedule complete abc123 def456 --notes "Finished the draft" --outcome 4`,
 	Aliases: []string{"done", "finish"},
 	Args:    cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -40,9 +48,11 @@ Examples:
 		}
 
 		cmdData := commands.CompleteBlockCommand{
-			ScheduleID: scheduleID,
-			BlockID:    blockID,
-			UserID:     app.CurrentUserID,
+			ScheduleID:    scheduleID,
+			BlockID:       blockID,
+			UserID:        app.CurrentUserID,
+			Notes:         completeNotes,
+			OutcomeRating: completeOutcome,
 		}
 
 		if err := app.CompleteBlockHandler.Handle(cmd.Context(), cmdData); err != nil {
@@ -52,11 +62,18 @@ Examples:
 		fmt.Println("Block completed!")
 		fmt.Println(strings.Repeat("-", 40))
 		fmt.Printf("  Block ID: %s\n", blockID)
+		if completeOutcome > 0 {
+			fmt.Printf("  Outcome:  %d/5\n", completeOutcome)
+		}
+		if completeNotes != "" {
+			fmt.Printf("  Notes:    %s\n", completeNotes)
+		}
 
 		return nil
 	},
 }
 
 func init() {
-	// No additional flags needed
+	completeCmd.Flags().StringVarP(&completeNotes, "notes", "n", "", "reflection on how the block went")
+	completeCmd.Flags().IntVarP(&completeOutcome, "outcome", "o", 0, "outcome rating from 1 (poor) to 5 (excellent)")
 }
@@ -0,0 +1,106 @@
+package schedule
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dependDate   string
+	dependRemove bool
+)
+
+var dependCmd = &cobra.Command{
+	Use:   "depend <before-block-id> <after-block-id>",
+	Short: "Require one block to finish before another starts",
+	Long: `Add an ordering constraint between two blocks on the same day.
+
+The after block, and anything that depends on it, is moved behind the
+before block if needed. Rescheduling keeps dependent blocks in order.
+Constraints that cannot be met within working hours are reported as
+warnings.
+
+You can find block IDs using 'orbita schedule show'.
+
+Examples:
+  orbita schedule depend abc123 def456
+  orbita schedule depend abc123 def456 --date 2024-01-15
+  orbita schedule depend abc123 def456 --remove`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		out := cmd.OutOrStdout()
+		if app == nil || app.AddBlockDependencyHandler == nil || app.RemoveBlockDependencyHandler == nil {
+			fmt.Fprintln(out, "Schedule commands require database connection.")
+			fmt.Fprintln(out, "Start services with: docker-compose up -d")
+			return nil
+		}
+
+		beforeID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid block ID: %w", err)
+		}
+		afterID, err := uuid.Parse(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid block ID: %w", err)
+		}
+
+		date := time.Now()
+		if dependDate != "" {
+			date, err = time.Parse("2006-01-02", dependDate)
+			if err != nil {
+				return fmt.Errorf("invalid date format, use YYYY-MM-DD: %w", err)
+			}
+		}
+
+		if dependRemove {
+			err := app.RemoveBlockDependencyHandler.Handle(cmd.Context(), commands.RemoveBlockDependencyCommand{
+				UserID:        app.CurrentUserID,
+				Date:          date,
+				BeforeBlockID: beforeID,
+				AfterBlockID:  afterID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to remove dependency: %w", err)
+			}
+			fmt.Fprintln(out, "Dependency removed.")
+			return nil
+		}
+
+		result, err := app.AddBlockDependencyHandler.Handle(cmd.Context(), commands.AddBlockDependencyCommand{
+			UserID:        app.CurrentUserID,
+			Date:          date,
+			BeforeBlockID: beforeID,
+			AfterBlockID:  afterID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add dependency: %w", err)
+		}
+
+		fmt.Fprintf(out, "Block %s now follows %s.\n", afterID, beforeID)
+		for _, id := range result.Moved {
+			fmt.Fprintf(out, "  Moved: %s\n", id)
+		}
+		printUnmetDependencies(out, result.Unmet)
+		return nil
+	},
+}
+
+// printUnmetDependencies warns about ordering constraints that could not be met.
+func printUnmetDependencies(out io.Writer, unmet []domain.BlockDependency) {
+	for _, dep := range unmet {
+		fmt.Fprintf(out, "Warning: block %s still starts before %s ends; no room left in working hours.\n", dep.AfterID, dep.BeforeID)
+	}
+}
+
+func init() {
+	dependCmd.Flags().StringVarP(&dependDate, "date", "d", "", "date of the schedule (YYYY-MM-DD, default: today)")
+	dependCmd.Flags().BoolVar(&dependRemove, "remove", false, "remove the dependency instead of adding it")
+}
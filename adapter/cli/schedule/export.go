@@ -0,0 +1,33 @@
+package schedule
+
+import (
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string
+	exportOutput string
+	exportDays   int
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export your schedule as ICS or a Markdown agenda",
+	Long: `Export the upcoming schedule as an ICS calendar or as a Markdown agenda
+grouped by day, with times, titles and statuses.
+
+Examples:
+  orbita schedule export --format md               # Markdown agenda to stdout
+  orbita schedule export --format md -o agenda.md  # Markdown agenda to a file
+  orbita schedule export --format ics --days 14    # ICS for the next 14 days`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cli.RunExport(cmd, exportFormat, exportOutput, exportDays)
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "ics", "export format (ics, md)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "output file (default: stdout)")
+	exportCmd.Flags().IntVarP(&exportDays, "days", "d", 7, "number of days to export")
+}
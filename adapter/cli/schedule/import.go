@@ -10,6 +10,7 @@ import (
 	googleCalendar "github.com/felixgeelhaar/orbita/internal/calendar/infrastructure/google"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/felixgeelhaar/orbita/internal/shared/application/capability"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +20,7 @@ var (
 	importTaggedOnly        bool
 	importCalendarID        string
 	importUseConfigCalendar bool
+	importPreview           bool
 )
 
 var importCmd = &cobra.Command{
@@ -29,7 +31,8 @@ var importCmd = &cobra.Command{
 Examples:
   orbita schedule import --days 7 --type meeting
   orbita schedule import --days 3 --tagged-only
-  orbita schedule import --calendar <id> --type focus`,
+  orbita schedule import --calendar <id> --type focus
+  orbita schedule import --preview`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		app := cli.GetApp()
 		if app == nil || app.AddBlockHandler == nil {
@@ -37,8 +40,11 @@ Examples:
 			fmt.Fprintln(cmd.OutOrStdout(), "Start services with: docker-compose up -d")
 			return nil
 		}
+		if importPreview {
+			return previewImport(cmd, app)
+		}
 		if app.CalendarSyncer == nil {
-			return errors.New("calendar sync not configured")
+			return cli.FeatureUnavailable(capability.CalendarSync)
 		}
 
 		googleSyncer, ok := app.CalendarSyncer.(*googleCalendar.Syncer)
@@ -124,10 +130,78 @@ Examples:
 	},
 }
 
+// previewImport prints what the calendar import worker would create and
+// which conflicts it would raise, without applying any of it.
+func previewImport(cmd *cobra.Command, app *cli.App) error {
+	if app.ImportWorker == nil {
+		return cli.FeatureUnavailable(capability.CalendarImport)
+	}
+
+	calendarID := importCalendarID
+	if calendarID == "" && importUseConfigCalendar && app.SettingsService != nil {
+		if storedID, err := app.SettingsService.GetCalendarID(cmd.Context(), app.CurrentUserID); err == nil {
+			calendarID = storedID
+		}
+	}
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+
+	preview, err := app.ImportWorker.PreviewImport(cmd.Context(), app.CurrentUserID, calendarID)
+	if err != nil {
+		return fmt.Errorf("failed to preview import: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Import preview for %s (%s - %s)\n",
+		calendarID, preview.Start.Format("Jan 2"), preview.End.Format("Jan 2"))
+	if !preview.Changed {
+		fmt.Fprintln(out, "Nothing changed since the last import.")
+	}
+
+	fmt.Fprintf(out, "\nEvents to import: %d\n", len(preview.Import))
+	for _, event := range preview.Import {
+		fmt.Fprintf(out, "  %s  %s\n", event.StartTime.In(time.Local).Format("Mon Jan 2 15:04"), event.Summary)
+	}
+	if len(preview.Update) > 0 {
+		fmt.Fprintf(out, "Imported events to update: %d\n", len(preview.Update))
+	}
+	if len(preview.Remove) > 0 {
+		fmt.Fprintf(out, "Blocks to remove for deleted events: %d\n", len(preview.Remove))
+	}
+	if len(preview.Skip) > 0 {
+		fmt.Fprintf(out, "Orbita events skipped: %d\n", len(preview.Skip))
+	}
+
+	if len(preview.Conflicts) > 0 {
+		fmt.Fprintf(out, "\nConflicts: %d\n", len(preview.Conflicts))
+		for _, conflict := range preview.Conflicts {
+			fmt.Fprintf(out, "  %s overlaps block %s-%s (%s)\n",
+				conflict.EventSummary,
+				conflict.BlockStart.In(time.Local).Format("15:04"),
+				conflict.BlockEnd.In(time.Local).Format("15:04"),
+				conflict.Resolution,
+			)
+		}
+	}
+
+	if len(preview.CreateMeetings) > 0 || len(preview.LinkedMeetings) > 0 {
+		fmt.Fprintf(out, "\nMeetings to create: %d (already linked: %d)\n",
+			len(preview.CreateMeetings), len(preview.LinkedMeetings))
+		for _, series := range preview.CreateMeetings {
+			fmt.Fprintf(out, "  %s (%s)\n", series.Summary, series.Cadence)
+		}
+	}
+
+	fmt.Fprintln(out, "\nNo changes were made.")
+	return nil
+}
+
 func init() {
 	importCmd.Flags().IntVarP(&importDays, "days", "d", 7, "number of days to import")
 	importCmd.Flags().StringVarP(&importBlockType, "type", "t", "focus", "block type (task, habit, meeting, focus, break)")
 	importCmd.Flags().BoolVar(&importTaggedOnly, "tagged-only", false, "only import events tagged as orbita")
 	importCmd.Flags().StringVar(&importCalendarID, "calendar", "", "calendar ID to import from (default: primary)")
 	importCmd.Flags().BoolVar(&importUseConfigCalendar, "use-config-calendar", true, "use CALENDAR_ID from config when no --calendar is provided")
+	importCmd.Flags().BoolVar(&importPreview, "preview", false, "show what the calendar import would create and which conflicts it would raise, without applying it")
 }
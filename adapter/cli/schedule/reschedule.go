@@ -79,7 +79,8 @@ Examples:
 			NewEnd:   newEnd,
 		}
 
-		if err := app.RescheduleBlockHandler.Handle(cmd.Context(), cmdData); err != nil {
+		result, err := app.RescheduleBlockHandler.Handle(cmd.Context(), cmdData)
+		if err != nil {
 			return fmt.Errorf("failed to reschedule block: %w", err)
 		}
 
@@ -89,6 +90,10 @@ Examples:
 		fmt.Fprintf(out, "  New time: %s - %s (%s)\n", rescheduleStart, rescheduleEnd, formatDuration(duration))
 		fmt.Fprintf(out, "  Date: %s\n", date.Format("Monday, January 2, 2006"))
 		fmt.Fprintf(out, "  Block ID: %s\n", blockID)
+		for _, id := range result.Moved {
+			fmt.Fprintf(out, "  Moved: %s\n", id)
+		}
+		printUnmetDependencies(out, result.Unmet)
 
 		return nil
 	},
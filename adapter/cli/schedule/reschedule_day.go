@@ -0,0 +1,76 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rescheduleDayFrom string
+	rescheduleDayTo   string
+)
+
+var rescheduleDayCmd = &cobra.Command{
+	Use:   "reschedule-day",
+	Short: "Move a day's incomplete blocks to another day",
+	Long: `Move all incomplete blocks from one day into the next available slots
+on another day. Blocks that do not fit stay where they are and are reported.
+
+Examples:
+  orbita schedule reschedule-day
+  orbita schedule reschedule-day --from 2024-02-02 --to 2024-02-05`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		out := cmd.OutOrStdout()
+		if app == nil || app.RescheduleDayHandler == nil {
+			fmt.Fprintln(out, "Rescheduling requires database connection.")
+			return nil
+		}
+
+		from := time.Now()
+		var err error
+		if rescheduleDayFrom != "" {
+			from, err = time.Parse("2006-01-02", rescheduleDayFrom)
+			if err != nil {
+				return fmt.Errorf("invalid from date format, use YYYY-MM-DD: %w", err)
+			}
+		}
+		to := from.AddDate(0, 0, 1)
+		if rescheduleDayTo != "" {
+			to, err = time.Parse("2006-01-02", rescheduleDayTo)
+			if err != nil {
+				return fmt.Errorf("invalid to date format, use YYYY-MM-DD: %w", err)
+			}
+		}
+
+		result, err := app.RescheduleDayHandler.Handle(cmd.Context(), commands.RescheduleDayCommand{
+			UserID:   app.CurrentUserID,
+			FromDate: from,
+			ToDate:   to,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Moved %d block(s) to %s\n", len(result.Moved), to.Format("Mon, Jan 2"))
+		for _, moved := range result.Moved {
+			fmt.Fprintf(out, "  %s  %s -> %s\n", moved.Title, moved.OldStart.Format("15:04"), moved.NewStart.Format("15:04"))
+		}
+		if len(result.Conflicts) > 0 {
+			fmt.Fprintf(out, "Could not move %d block(s):\n", len(result.Conflicts))
+			for _, conflict := range result.Conflicts {
+				fmt.Fprintf(out, "  %s (%s)\n", conflict.Title, conflict.Reason)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rescheduleDayCmd.Flags().StringVar(&rescheduleDayFrom, "from", "", "day to move blocks from (YYYY-MM-DD, default: today)")
+	rescheduleDayCmd.Flags().StringVar(&rescheduleDayTo, "to", "", "day to move blocks to (YYYY-MM-DD, default: day after --from)")
+}
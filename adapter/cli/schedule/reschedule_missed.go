@@ -64,7 +64,8 @@ Examples:
 			return err
 		}
 
-		fmt.Fprintf(out, "Rescheduled blocks: moved=%d failed=%d\n", result.Rescheduled, result.Failed)
+		fmt.Fprintf(out, "Rescheduled blocks: moved=%d failed=%d flagged=%d\n", result.Rescheduled, result.Failed, result.Flagged)
+		printUnmetDependencies(out, result.UnmetDependencies)
 		return nil
 	},
 }
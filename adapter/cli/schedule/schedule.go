@@ -14,12 +14,17 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.AddCommand(showCmd)
 	Cmd.AddCommand(availableCmd)
+	Cmd.AddCommand(capacityCmd)
 	Cmd.AddCommand(addCmd)
 	Cmd.AddCommand(completeCmd)
 	Cmd.AddCommand(removeCmd)
 	Cmd.AddCommand(rescheduleCmd)
+	Cmd.AddCommand(dependCmd)
+	Cmd.AddCommand(attachCmd)
 	Cmd.AddCommand(rescheduleMissedCmd)
+	Cmd.AddCommand(rescheduleDayCmd)
 	Cmd.AddCommand(rescheduleAttemptsCmd)
 	Cmd.AddCommand(autoCmd)
 	Cmd.AddCommand(importCmd)
+	Cmd.AddCommand(exportCmd)
 }
@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"bytes"
 	"context"
 	"log/slog"
 	"os"
@@ -10,7 +11,9 @@ import (
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	internalApp "github.com/felixgeelhaar/orbita/internal/app"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
 	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/pkg/config"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -43,7 +46,7 @@ func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 		Level: slog.LevelError,
 	}))
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	container, err := internalApp.NewLocalContainer(ctx, cfg, logger)
 	require.NoError(t, err)
 
@@ -79,6 +82,9 @@ func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 		container.BillingService,
 	)
 	cliApp.SetCurrentUserID(testUserID)
+	cliApp.SetBlockDependencyHandlers(container.AddBlockDependencyHandler, container.RemoveBlockDependencyHandler)
+	cliApp.SetBlockAttachmentHandler(container.AddBlockAttachmentHandler)
+	cliApp.SetCapacityHandler(container.GetCapacityHandler)
 
 	cleanup := func() {
 		container.Close()
@@ -95,7 +101,7 @@ func TestShowCmd_EmptySchedule(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Verify empty schedule via handler
 	schedule, err := app.GetScheduleHandler.Handle(ctx, scheduleQueries.GetScheduleQuery{
@@ -120,7 +126,7 @@ func TestShowCmd_WithDateFlag(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Test with a specific date
 	showDate = "2026-02-15"
@@ -137,7 +143,7 @@ func TestShowCmd_InvalidDateFormat(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	showDate = "invalid-date"
 	showCmd.SetContext(ctx)
@@ -154,7 +160,7 @@ func TestAddCmd_AddsBlock(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	today := time.Now().Format("2006-01-02")
 
@@ -190,7 +196,7 @@ func TestAddCmd_InvalidBlockType(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	addBlockType = "invalid"
 	addTitle = "Test"
@@ -213,7 +219,7 @@ func TestAddCmd_InvalidStartTime(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	addBlockType = "focus"
 	addTitle = "Test"
@@ -236,7 +242,7 @@ func TestAddCmd_InvalidEndTime(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	addBlockType = "focus"
 	addTitle = "Test"
@@ -259,7 +265,7 @@ func TestAddCmd_InvalidReferenceID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	addBlockType = "task"
 	addTitle = "Test"
@@ -282,7 +288,7 @@ func TestCompleteCmd_InvalidScheduleID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	completeCmd.SetContext(ctx)
 
@@ -298,7 +304,7 @@ func TestCompleteCmd_InvalidBlockID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	completeCmd.SetContext(ctx)
 
@@ -310,7 +316,7 @@ func TestCompleteCmd_InvalidBlockID(t *testing.T) {
 func TestShowCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	showDate = ""
 	showCmd.SetContext(ctx)
 
@@ -322,7 +328,7 @@ func TestShowCmd_NoApp(t *testing.T) {
 func TestAddCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	addBlockType = "focus"
 	addTitle = "Test"
 	addCmd.SetContext(ctx)
@@ -335,7 +341,7 @@ func TestAddCmd_NoApp(t *testing.T) {
 func TestCompleteCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	completeCmd.SetContext(ctx)
 
 	// The command returns nil but prints a message
@@ -369,7 +375,7 @@ func TestAddCmd_AllBlockTypes(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	today := time.Now().Format("2006-01-02")
 
@@ -410,3 +416,219 @@ func TestAddCmd_AllBlockTypes(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, schedule.Blocks, 5)
 }
+
+func TestExportCmd_Markdown(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+	today := time.Now()
+
+	addBlockType = "focus"
+	addTitle = "Deep work session"
+	addDate = today.Format("2006-01-02")
+	addStartTime = "09:00"
+	addEndTime = "11:00"
+	addReferenceID = ""
+	addCmd.SetContext(ctx)
+	require.NoError(t, addCmd.RunE(addCmd, []string{}))
+
+	exportFormat = "md"
+	exportOutput = ""
+	exportDays = 2
+
+	var out bytes.Buffer
+	exportCmd.SetOut(&out)
+	exportCmd.SetContext(ctx)
+	defer exportCmd.SetOut(nil)
+
+	require.NoError(t, exportCmd.RunE(exportCmd, []string{}))
+
+	assert.Contains(t, out.String(), "# Schedule\n")
+	assert.Contains(t, out.String(), "## "+today.Format("Monday, January 2, 2006")+"\n")
+	assert.Contains(t, out.String(), "- [ ] 09:00-11:00 Deep work session (focus)\n")
+}
+
+func TestCapacityCmd_PartiallyBookedDays(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	addBlockType = "focus"
+	addTitle = "Deep work session"
+	addDate = "2026-03-02"
+	addStartTime = "09:00"
+	addEndTime = "11:00"
+	addReferenceID = ""
+	addCmd.SetContext(ctx)
+	require.NoError(t, addCmd.RunE(addCmd, []string{}))
+
+	capacityDate = "2026-03-02"
+	capacityDays = 2
+	capacityWeek = false
+	capacityStart = "09:00"
+	capacityEnd = "17:00"
+
+	var out bytes.Buffer
+	capacityCmd.SetOut(&out)
+	capacityCmd.SetContext(ctx)
+	defer capacityCmd.SetOut(nil)
+
+	require.NoError(t, capacityCmd.RunE(capacityCmd, []string{}))
+
+	assert.Contains(t, out.String(), "Mon Mar 2              6h free        2h booked\n")
+	assert.Contains(t, out.String(), "Tue Mar 3              8h free        0m booked\n")
+	assert.Contains(t, out.String(), "Total: 14h free of 16h\n")
+}
+
+func TestDependCmd_MovesAfterBlock(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+	date := time.Date(2026, time.February, 16, 0, 0, 0, 0, time.Local)
+	at := func(hour int) time.Time { return date.Add(time.Duration(hour) * time.Hour) }
+
+	review, err := app.AddBlockHandler.Handle(ctx, commands.AddBlockCommand{
+		UserID:    app.CurrentUserID,
+		Date:      date,
+		BlockType: "task",
+		Title:     "Review",
+		StartTime: at(9),
+		EndTime:   at(10),
+	})
+	require.NoError(t, err)
+	draft, err := app.AddBlockHandler.Handle(ctx, commands.AddBlockCommand{
+		UserID:    app.CurrentUserID,
+		Date:      date,
+		BlockType: "task",
+		Title:     "Draft",
+		StartTime: at(11),
+		EndTime:   at(12),
+	})
+	require.NoError(t, err)
+
+	dependDate = date.Format("2006-01-02")
+	dependRemove = false
+	out := new(bytes.Buffer)
+	dependCmd.SetOut(out)
+	dependCmd.SetContext(ctx)
+	defer dependCmd.SetOut(nil)
+
+	err = dependCmd.RunE(dependCmd, []string{draft.BlockID.String(), review.BlockID.String()})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Moved: "+review.BlockID.String())
+	assert.NotContains(t, out.String(), "Warning")
+
+	schedule, err := app.GetScheduleHandler.Handle(ctx, scheduleQueries.GetScheduleQuery{
+		UserID: app.CurrentUserID,
+		Date:   date,
+	})
+	require.NoError(t, err)
+	var reviewStart time.Time
+	for _, block := range schedule.Blocks {
+		if block.ID == review.BlockID {
+			reviewStart = block.StartTime
+		}
+	}
+	assert.True(t, reviewStart.Equal(at(12)), "review should start when the draft ends, got %s", reviewStart)
+
+	dependRemove = true
+	defer func() { dependRemove = false }()
+	err = dependCmd.RunE(dependCmd, []string{draft.BlockID.String(), review.BlockID.String()})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Dependency removed.")
+}
+
+func TestAttachCmd_AddsAttachment(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+	date := time.Date(2026, time.February, 16, 0, 0, 0, 0, time.Local)
+
+	focus, err := app.AddBlockHandler.Handle(ctx, commands.AddBlockCommand{
+		UserID:    app.CurrentUserID,
+		Date:      date,
+		BlockType: "focus",
+		Title:     "Deep work",
+		StartTime: date.Add(9 * time.Hour),
+		EndTime:   date.Add(11 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	attachDate = date.Format("2006-01-02")
+	attachLabel = "Spec"
+	defer func() { attachDate, attachLabel = "", "" }()
+	out := new(bytes.Buffer)
+	attachCmd.SetOut(out)
+	attachCmd.SetContext(ctx)
+	defer attachCmd.SetOut(nil)
+
+	err = attachCmd.RunE(attachCmd, []string{focus.BlockID.String(), "https://docs.example.com/spec"})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "(1 total)")
+
+	err = attachCmd.RunE(attachCmd, []string{focus.BlockID.String(), "https://docs.example.com/spec"})
+	assert.Error(t, err)
+
+	schedule, err := app.GetScheduleHandler.Handle(ctx, scheduleQueries.GetScheduleQuery{
+		UserID: app.CurrentUserID,
+		Date:   date,
+	})
+	require.NoError(t, err)
+	require.Len(t, schedule.Blocks, 1)
+	assert.Equal(t, []scheduleQueries.AttachmentDTO{{Target: "https://docs.example.com/spec", Label: "Spec"}}, schedule.Blocks[0].Attachments)
+}
+
+func TestCompleteCmd_RecordsNotesAndOutcome(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+	date := time.Date(2026, time.February, 16, 0, 0, 0, 0, time.Local)
+
+	focus, err := app.AddBlockHandler.Handle(ctx, commands.AddBlockCommand{
+		UserID:    app.CurrentUserID,
+		Date:      date,
+		BlockType: "focus",
+		Title:     "Deep work",
+		StartTime: date.Add(9 * time.Hour),
+		EndTime:   date.Add(11 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	completeNotes = "Finished the draft"
+	completeOutcome = 4
+	defer func() { completeNotes, completeOutcome = "", 0 }()
+	completeCmd.SetContext(ctx)
+
+	err = completeCmd.RunE(completeCmd, []string{focus.ScheduleID.String(), focus.BlockID.String()})
+	require.NoError(t, err)
+
+	schedule, err := app.GetScheduleHandler.Handle(ctx, scheduleQueries.GetScheduleQuery{
+		UserID: app.CurrentUserID,
+		Date:   date,
+	})
+	require.NoError(t, err)
+	require.Len(t, schedule.Blocks, 1)
+	assert.True(t, schedule.Blocks[0].Completed)
+	assert.Equal(t, "Finished the draft", schedule.Blocks[0].CompletionNotes)
+	assert.Equal(t, 4, schedule.Blocks[0].OutcomeRating)
+}
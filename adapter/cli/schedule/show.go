@@ -80,6 +80,19 @@ Examples:
 				block.DurationMin,
 			)
 			fmt.Printf("    Type: %s | ID: %s\n", block.BlockType, block.ID)
+			for _, attachment := range block.Attachments {
+				if attachment.Label != "" {
+					fmt.Printf("    Attachment: %s (%s)\n", attachment.Label, attachment.Target)
+				} else {
+					fmt.Printf("    Attachment: %s\n", attachment.Target)
+				}
+			}
+			if block.OutcomeRating > 0 {
+				fmt.Printf("    Outcome: %d/5\n", block.OutcomeRating)
+			}
+			if block.CompletionNotes != "" {
+				fmt.Printf("    Notes: %s\n", block.CompletionNotes)
+			}
 		}
 
 		fmt.Println(strings.Repeat("-", 60))
@@ -7,6 +7,7 @@ import (
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -19,7 +20,8 @@ var weekCmd = &cobra.Command{
 	Short: "Show schedule for the week",
 	Long: `Display your schedule for the entire week.
 
-Shows all time blocks for each day from Monday to Sunday,
+Shows all time blocks for each day of the week, starting on the
+configured first day of the week (WEEK_STARTS_ON, Monday by default),
 with summary statistics for the week.
 
 Examples:
@@ -42,9 +44,8 @@ Examples:
 			offset = 1
 		}
 
-		// Calculate week start (Monday)
 		now := time.Now()
-		weekStart := getWeekStart(now).AddDate(0, 0, offset*7)
+		weekStart := sharedDomain.StartOfWeek(now, cli.WeekStartsOn()).AddDate(0, 0, offset*7)
 		weekEnd := weekStart.AddDate(0, 0, 6)
 
 		fmt.Printf("\n  Week of %s - %s\n",
@@ -124,16 +125,6 @@ Examples:
 	},
 }
 
-func getWeekStart(t time.Time) time.Time {
-	// Get Monday of the current week
-	weekday := int(t.Weekday())
-	if weekday == 0 {
-		weekday = 7 // Sunday
-	}
-	daysToMonday := weekday - 1
-	return time.Date(t.Year(), t.Month(), t.Day()-daysToMonday, 0, 0, 0, 0, t.Location())
-}
-
 func isSameDay(a, b time.Time) bool {
 	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
 }
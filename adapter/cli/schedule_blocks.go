@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	calendarApp "github.com/felixgeelhaar/orbita/internal/calendar/application"
+	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+)
+
+// scheduleFetchConcurrency bounds the number of schedule lookups in flight.
+const scheduleFetchConcurrency = 4
+
+type scheduleFetcher func(ctx context.Context, day time.Time) (*scheduleQueries.ScheduleDTO, error)
+
+// FetchScheduleDays loads the schedules for the given number of days starting
+// today. Days are fetched concurrently; the result is indexed by day offset and
+// holds nil for days that failed. Errors for failed days are joined.
+func FetchScheduleDays(ctx context.Context, app *App, days int) ([]*scheduleQueries.ScheduleDTO, error) {
+	if app == nil || app.GetScheduleHandler == nil {
+		return nil, errors.New("schedule queries not available")
+	}
+	fetch := func(ctx context.Context, day time.Time) (*scheduleQueries.ScheduleDTO, error) {
+		return app.GetScheduleHandler.Handle(ctx, scheduleQueries.GetScheduleQuery{
+			UserID: app.CurrentUserID,
+			Date:   day,
+		})
+	}
+	return fetchScheduleDays(ctx, time.Now(), days, scheduleFetchConcurrency, fetch)
+}
+
+// GatherScheduleBlocks returns the scheduled blocks for the given number of
+// days starting today, in day order. Blocks from days that loaded are returned
+// even when other days fail.
+func GatherScheduleBlocks(ctx context.Context, app *App, days int) ([]calendarApp.TimeBlock, error) {
+	schedules, err := FetchScheduleDays(ctx, app, days)
+
+	blocks := make([]calendarApp.TimeBlock, 0)
+	for _, schedule := range schedules {
+		if schedule == nil {
+			continue
+		}
+		for _, block := range schedule.Blocks {
+			blocks = append(blocks, calendarApp.TimeBlock{
+				ID:          block.ID,
+				Title:       block.Title,
+				BlockType:   block.BlockType,
+				StartTime:   block.StartTime,
+				EndTime:     block.EndTime,
+				Completed:   block.Completed,
+				Missed:      block.Missed,
+				CreatedAt:   block.CreatedAt,
+				UpdatedAt:   block.UpdatedAt,
+				Attachments: calendarAttachments(block.Attachments),
+			})
+		}
+	}
+	return blocks, err
+}
+
+func calendarAttachments(attachments []scheduleQueries.AttachmentDTO) []calendarApp.Attachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+	out := make([]calendarApp.Attachment, len(attachments))
+	for i, a := range attachments {
+		out[i] = calendarApp.Attachment{Target: a.Target, Label: a.Label}
+	}
+	return out
+}
+
+func fetchScheduleDays(ctx context.Context, start time.Time, days, limit int, fetch scheduleFetcher) ([]*scheduleQueries.ScheduleDTO, error) {
+	if days <= 0 {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = 1
+	}
+
+	schedules := make([]*scheduleQueries.ScheduleDTO, days)
+	errs := make([]error, days)
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i := 0; i < days; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			day := start.AddDate(0, 0, i)
+			schedule, err := fetch(ctx, day)
+			if err != nil {
+				errs[i] = fmt.Errorf("schedule for %s: %w", day.Format("2006-01-02"), err)
+				return
+			}
+			schedules[i] = schedule
+		}(i)
+	}
+	wg.Wait()
+
+	return schedules, errors.Join(errs...)
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchScheduleDays_PreservesOrder(t *testing.T) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	days := 10
+
+	fetch := func(ctx context.Context, day time.Time) (*scheduleQueries.ScheduleDTO, error) {
+		// Earlier days finish last so completion order differs from day order.
+		offset := int(day.Sub(start).Hours() / 24)
+		time.Sleep(time.Duration(days-offset) * time.Millisecond)
+		return &scheduleQueries.ScheduleDTO{Date: day}, nil
+	}
+
+	schedules, err := fetchScheduleDays(context.Background(), start, days, 4, fetch)
+
+	require.NoError(t, err)
+	require.Len(t, schedules, days)
+	for i, schedule := range schedules {
+		require.NotNil(t, schedule)
+		assert.Equal(t, start.AddDate(0, 0, i), schedule.Date)
+	}
+}
+
+func TestFetchScheduleDays_BoundsInFlight(t *testing.T) {
+	var inFlight, maxInFlight, calls atomic.Int32
+
+	fetch := func(ctx context.Context, day time.Time) (*scheduleQueries.ScheduleDTO, error) {
+		calls.Add(1)
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			prev := maxInFlight.Load()
+			if n <= prev || maxInFlight.CompareAndSwap(prev, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return &scheduleQueries.ScheduleDTO{Date: day}, nil
+	}
+
+	_, err := fetchScheduleDays(context.Background(), time.Now(), 20, 3, fetch)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(20), calls.Load())
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	assert.Greater(t, maxInFlight.Load(), int32(1))
+}
+
+func TestFetchScheduleDays_AggregatesErrors(t *testing.T) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	errDay2 := errors.New("day 2 unavailable")
+	errDay4 := errors.New("day 4 unavailable")
+
+	fetch := func(ctx context.Context, day time.Time) (*scheduleQueries.ScheduleDTO, error) {
+		switch day.Day() {
+		case 3:
+			return nil, errDay2
+		case 5:
+			return nil, errDay4
+		}
+		return &scheduleQueries.ScheduleDTO{Date: day}, nil
+	}
+
+	schedules, err := fetchScheduleDays(context.Background(), start, 6, 2, fetch)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errDay2)
+	assert.ErrorIs(t, err, errDay4)
+	assert.Contains(t, err.Error(), "schedule for 2024-05-03")
+	assert.Contains(t, err.Error(), "schedule for 2024-05-05")
+
+	// Days that loaded are still returned in place.
+	require.Len(t, schedules, 6)
+	assert.Nil(t, schedules[2])
+	assert.Nil(t, schedules[4])
+	for _, i := range []int{0, 1, 3, 5} {
+		require.NotNil(t, schedules[i])
+		assert.Equal(t, start.AddDate(0, 0, i), schedules[i].Date)
+	}
+}
+
+func TestFetchScheduleDays_NoDays(t *testing.T) {
+	schedules, err := fetchScheduleDays(context.Background(), time.Now(), 0, 4, nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, schedules)
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	searchQueries "github.com/felixgeelhaar/orbita/internal/search/application/queries"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchTypes []string
+	searchLimit int
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search tasks, habits, meetings and inbox items",
+	Long: `Search the titles and text of your tasks, habits, meetings and inbox
+items. Every word must match, either whole or as the start of a word, and
+case is ignored. Results of all types are ranked together, with title
+matches first.
+
+Examples:
+  orbita search "quarterly report"
+  orbita search rep --type task
+  orbita search standup --type habit,meeting --limit 5`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := GetApp()
+		if app == nil || app.SearchEntitiesHandler == nil {
+			fmt.Println("Search requires database connection.")
+			fmt.Println("Start services with: docker-compose up -d")
+			return nil
+		}
+
+		results, err := app.SearchEntitiesHandler.Handle(cmd.Context(), searchQueries.SearchEntitiesQuery{
+			UserID: app.CurrentUserID,
+			Query:  strings.Join(args, " "),
+			Types:  searchTypes,
+			Limit:  searchLimit,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to search: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		if len(results) == 0 {
+			fmt.Fprintln(out, "No matches found.")
+			return nil
+		}
+
+		fmt.Fprintf(out, "Found %d match(es):\n\n", len(results))
+		for _, result := range results {
+			fmt.Fprintf(out, "  %-8s %s  %s", result.Type, result.ID.String()[:8], result.Title)
+			if result.Status != "" {
+				fmt.Fprintf(out, " [%s]", result.Status)
+			}
+			fmt.Fprintln(out)
+			if result.Snippet != "" {
+				fmt.Fprintf(out, "           %s\n", searchSnippet(result.Snippet))
+			}
+		}
+		return nil
+	},
+}
+
+// searchSnippetLength caps how much of a result's text is shown.
+const searchSnippetLength = 70
+
+// searchSnippet returns the first line of text, shortened to fit one line.
+func searchSnippet(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	runes := []rune(line)
+	if len(runes) > searchSnippetLength {
+		return string(runes[:searchSnippetLength-3]) + "..."
+	}
+	return line
+}
+
+func init() {
+	searchCmd.Flags().StringSliceVarP(&searchTypes, "type", "t", nil, "limit to types (task, habit, meeting, inbox)")
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", searchQueries.DefaultSearchLimit, "maximum number of results")
+	rootCmd.AddCommand(searchCmd)
+}
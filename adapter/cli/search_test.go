@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchSnippet(t *testing.T) {
+	assert.Equal(t, "first line", searchSnippet("  first line\nsecond line"))
+
+	long := searchSnippet(strings.Repeat("é", 100))
+	assert.Len(t, []rune(long), searchSnippetLength)
+	assert.True(t, strings.HasSuffix(long, "..."))
+}
@@ -0,0 +1,145 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	inboxServices "github.com/felixgeelhaar/orbita/internal/inbox/services"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var classifierCmd = &cobra.Command{
+	Use:   "classifier",
+	Short: "Manage inbox classifier rules",
+	Long: `Manage inbox classifier rules.
+
+A rule maps a keyword to the type an inbox item should be classified as.
+Captured items containing the keyword, in any case, get that type. Rules
+apply before the built-in heuristics; when several match, the longest
+keyword wins. An explicit type in the item's metadata always wins.`,
+}
+
+var classifierGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "List the inbox classifier rules",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		rules, err := app.SettingsService.GetClassifierRules(cmd.Context(), app.CurrentUserID)
+		if err != nil {
+			return err
+		}
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"classifier_rules": rules,
+			})
+		}
+		if len(rules) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No classifier rules set.")
+			return nil
+		}
+		keywords := make([]string, 0, len(rules))
+		for keyword := range rules {
+			keywords = append(keywords, keyword)
+		}
+		sort.Strings(keywords)
+		for _, keyword := range keywords {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s -> %s\n", keyword, rules[keyword])
+		}
+		return nil
+	},
+}
+
+var classifierSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Classify items containing a keyword as a type",
+	Example: `  orbita settings classifier set --keyword standup --type meeting
+  orbita settings classifier set --keyword "1:1" --type meeting`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		classification := strings.ToLower(strings.TrimSpace(classifierType))
+		if !inboxServices.IsClassification(classification) {
+			return fmt.Errorf("unknown type %q: use %s", classifierType, strings.Join(inboxServices.Classifications, ", "))
+		}
+
+		if err := app.SettingsService.SetClassifierRule(cmd.Context(), app.CurrentUserID, classifierKeyword, classification); err != nil {
+			return err
+		}
+		keyword := strings.ToLower(strings.TrimSpace(classifierKeyword))
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"keyword": keyword,
+				"type":    classification,
+				"updated": true,
+			})
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Items containing %q will be classified as %s.\n", keyword, classification)
+		return nil
+	},
+}
+
+var classifierRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove the rule for a keyword",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		if err := app.SettingsService.SetClassifierRule(cmd.Context(), app.CurrentUserID, classifierKeyword, ""); err != nil {
+			return err
+		}
+		keyword := strings.ToLower(strings.TrimSpace(classifierKeyword))
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"keyword": keyword,
+				"removed": true,
+			})
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Classifier rule for %q removed.\n", keyword)
+		return nil
+	},
+}
+
+var classifierKeyword string
+var classifierType string
+
+func init() {
+	classifierSetCmd.Flags().StringVar(&classifierKeyword, "keyword", "", "keyword to match, case-insensitively")
+	classifierSetCmd.Flags().StringVar(&classifierType, "type", "", "type to classify matching items as: task, habit or meeting")
+	_ = classifierSetCmd.MarkFlagRequired("keyword")
+	_ = classifierSetCmd.MarkFlagRequired("type")
+	classifierRemoveCmd.Flags().StringVar(&classifierKeyword, "keyword", "", "keyword of the rule to remove")
+	_ = classifierRemoveCmd.MarkFlagRequired("keyword")
+
+	classifierGetCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+	classifierSetCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+	classifierRemoveCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+
+	classifierCmd.AddCommand(classifierGetCmd)
+	classifierCmd.AddCommand(classifierSetCmd)
+	classifierCmd.AddCommand(classifierRemoveCmd)
+	Cmd.AddCommand(classifierCmd)
+}
@@ -0,0 +1,124 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Manage the scheduled daily or weekly digest",
+	Long: `Manage the scheduled digest sent on your notification channel.
+
+Frequencies:
+  off     No digest (default)
+  daily   Today's schedule, due and overdue tasks, and habits, every day
+  weekly  A review of the past week, on the first day of each week
+
+The digest is sent at the given local time in your time zone. Digests are
+only sent when the server runs with DIGESTS_ENABLED=true.`,
+}
+
+var digestGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get the digest schedule",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		digest, err := app.SettingsService.GetDigest(cmd.Context(), app.CurrentUserID)
+		if err != nil {
+			return err
+		}
+		timezone := digest.Timezone
+		if timezone == "" {
+			timezone = "server"
+		}
+		if settingsJSON {
+			result := map[string]any{
+				"frequency": digest.Frequency.String(),
+				"timezone":  timezone,
+			}
+			if digest.Enabled() {
+				result["time"] = digest.Time()
+			}
+			if digest.LastSentAt != nil {
+				result["last_sent_at"] = digest.LastSentAt
+			}
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(result)
+		}
+		if !digest.Enabled() {
+			fmt.Fprintln(cmd.OutOrStdout(), digest.Frequency)
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s at %s (%s)\n", digest.Frequency, digest.Time(), timezone)
+		return nil
+	},
+}
+
+var digestSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set the digest schedule",
+	Example: `  orbita settings digest set --frequency daily --at 07:30 --timezone Europe/Berlin
+  orbita settings digest set --frequency weekly --at 18:00
+  orbita settings digest set --frequency off`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		if err := app.SettingsService.SetDigest(cmd.Context(), app.CurrentUserID, digestFrequency, digestAt, digestTimezone); err != nil {
+			return err
+		}
+		digest, err := app.SettingsService.GetDigest(cmd.Context(), app.CurrentUserID)
+		if err != nil {
+			return err
+		}
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"frequency": digest.Frequency.String(),
+				"time":      digest.Time(),
+				"timezone":  digest.Timezone,
+				"updated":   true,
+			})
+		}
+		if !digest.Enabled() {
+			fmt.Fprintln(cmd.OutOrStdout(), "Digest turned off.")
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Digest set to %s at %s.\n", digest.Frequency, digest.Time())
+		return nil
+	},
+}
+
+var digestFrequency string
+var digestAt string
+var digestTimezone string
+
+func init() {
+	digestSetCmd.Flags().StringVar(&digestFrequency, "frequency", "", "frequency: off, daily or weekly")
+	digestSetCmd.Flags().StringVar(&digestAt, "at", "08:00", "local time of day to send the digest (HH:MM)")
+	digestSetCmd.Flags().StringVar(&digestTimezone, "timezone", "", "IANA time zone, e.g. America/New_York (default: the server's)")
+	_ = digestSetCmd.MarkFlagRequired("frequency")
+
+	digestGetCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+	digestSetCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+
+	digestCmd.AddCommand(digestGetCmd)
+	digestCmd.AddCommand(digestSetCmd)
+	Cmd.AddCommand(digestCmd)
+}
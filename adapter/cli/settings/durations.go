@@ -0,0 +1,124 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// durationPriorities lists the priorities in display order.
+var durationPriorities = []value_objects.Priority{
+	value_objects.PriorityUrgent,
+	value_objects.PriorityHigh,
+	value_objects.PriorityMedium,
+	value_objects.PriorityLow,
+	value_objects.PriorityNone,
+}
+
+var durationsCmd = &cobra.Command{
+	Use:   "durations",
+	Short: "Manage default task durations by priority",
+	Long: `Manage default task durations by priority.
+
+Tasks created without a duration get the default for their priority, so
+they can be auto-scheduled. A duration given when creating a task always
+wins over the default.`,
+}
+
+var durationsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get the default task durations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		durations, err := app.SettingsService.GetDefaultDurations(cmd.Context(), app.CurrentUserID)
+		if err != nil {
+			return err
+		}
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"default_durations": durations,
+			})
+		}
+		if len(durations) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No default durations set.")
+			return nil
+		}
+		for _, priority := range durationPriorities {
+			if minutes, ok := durations[priority.String()]; ok {
+				fmt.Fprintf(cmd.OutOrStdout(), "%-7s %d min\n", priority, minutes)
+			}
+		}
+		return nil
+	},
+}
+
+var durationsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set the default duration for a priority",
+	Example: `  orbita settings durations set --priority high --minutes 90
+  orbita settings durations set --priority none --minutes 30
+  orbita settings durations set --priority high --minutes 0   # clear`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		priority, err := value_objects.ParsePriority(durationPriority)
+		if err != nil {
+			return fmt.Errorf("%w: use urgent, high, medium, low or none", err)
+		}
+		if durationMinutes < 0 {
+			return errors.New("minutes must not be negative")
+		}
+
+		if err := app.SettingsService.SetDefaultDuration(cmd.Context(), app.CurrentUserID, priority.String(), durationMinutes); err != nil {
+			return err
+		}
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"priority": priority.String(),
+				"minutes":  durationMinutes,
+				"updated":  true,
+			})
+		}
+		if durationMinutes == 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "Default duration for %s priority cleared.\n", priority)
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Default duration for %s priority set to %d min.\n", priority, durationMinutes)
+		return nil
+	},
+}
+
+var durationPriority string
+var durationMinutes int
+
+func init() {
+	durationsSetCmd.Flags().StringVar(&durationPriority, "priority", "", "priority: urgent, high, medium, low or none")
+	durationsSetCmd.Flags().IntVar(&durationMinutes, "minutes", 0, "default duration in minutes (0 clears it)")
+	_ = durationsSetCmd.MarkFlagRequired("priority")
+	_ = durationsSetCmd.MarkFlagRequired("minutes")
+
+	durationsGetCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+	durationsSetCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+
+	durationsCmd.AddCommand(durationsGetCmd)
+	durationsCmd.AddCommand(durationsSetCmd)
+	Cmd.AddCommand(durationsCmd)
+}
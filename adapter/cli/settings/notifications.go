@@ -0,0 +1,119 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var notificationsCmd = &cobra.Command{
+	Use:   "notifications",
+	Short: "Manage how notifications are delivered",
+	Long: `Manage how notifications are delivered.
+
+Channels:
+  none     Notifications are not delivered (default)
+  desktop  Local desktop notifications
+  email    Email to the target address (requires SMTP configuration)
+  webhook  JSON POST to the target URL`,
+}
+
+var notificationsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get the notification channel",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		name, target, err := app.SettingsService.GetNotificationChannel(cmd.Context(), app.CurrentUserID)
+		if err != nil {
+			return err
+		}
+		channel, err := notifications.ParseChannel(name)
+		if err != nil {
+			return err
+		}
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"channel": channel.String(),
+				"target":  target,
+			})
+		}
+		if target != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s (%s)\n", channel, target)
+			return nil
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), channel)
+		return nil
+	},
+}
+
+var notificationsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set the notification channel",
+	Example: `  orbita settings notifications set --channel desktop
+  orbita settings notifications set --channel email --target me@example.com
+  orbita settings notifications set --channel webhook --target https://example.com/hooks/orbita
+  orbita settings notifications set --channel none`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		channel, err := notifications.ParseChannel(notificationChannel)
+		if err != nil {
+			return err
+		}
+		target := strings.TrimSpace(notificationTarget)
+		if channel.NeedsTarget() && target == "" {
+			return fmt.Errorf("%w: use --target with the %s channel", notifications.ErrMissingTarget, channel)
+		}
+		if !channel.NeedsTarget() {
+			target = ""
+		}
+
+		if err := app.SettingsService.SetNotificationChannel(cmd.Context(), app.CurrentUserID, string(channel), target); err != nil {
+			return err
+		}
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"channel": channel.String(),
+				"target":  target,
+				"updated": true,
+			})
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Notification channel set to %s.\n", channel)
+		return nil
+	},
+}
+
+var notificationChannel string
+var notificationTarget string
+
+func init() {
+	notificationsSetCmd.Flags().StringVar(&notificationChannel, "channel", "", "channel: none, desktop, email or webhook")
+	notificationsSetCmd.Flags().StringVar(&notificationTarget, "target", "", "email address or webhook URL")
+	_ = notificationsSetCmd.MarkFlagRequired("channel")
+
+	notificationsGetCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+	notificationsSetCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+
+	notificationsCmd.AddCommand(notificationsGetCmd)
+	notificationsCmd.AddCommand(notificationsSetCmd)
+	Cmd.AddCommand(notificationsCmd)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	googleCalendar "github.com/felixgeelhaar/orbita/internal/calendar/infrastructure/google"
+	"github.com/felixgeelhaar/orbita/internal/shared/application/capability"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
@@ -85,7 +86,7 @@ var calendarListCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		app := cli.GetApp()
 		if app == nil || app.CalendarSyncer == nil {
-			return errors.New("calendar sync not configured")
+			return cli.FeatureUnavailable(capability.CalendarSync)
 		}
 		if app.CurrentUserID == uuid.Nil {
 			return errors.New("current user not configured")
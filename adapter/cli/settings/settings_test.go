@@ -6,12 +6,15 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	googleCalendar "github.com/felixgeelhaar/orbita/internal/calendar/infrastructure/google"
 	identitySettings "github.com/felixgeelhaar/orbita/internal/identity/application/settings"
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
 	"github.com/google/uuid"
 	"golang.org/x/oauth2"
 )
@@ -27,6 +30,12 @@ func (s stubTokenProvider) TokenSource(ctx context.Context, userID uuid.UUID) (o
 type stubSettingsRepo struct {
 	calendarID    string
 	deleteMissing bool
+	channel       string
+	target        string
+	durations     map[string]int
+	rules         map[string]string
+	sorts         map[string]string
+	digest        *notifications.Digest
 }
 
 func (s stubSettingsRepo) GetCalendarID(ctx context.Context, userID uuid.UUID) (string, error) {
@@ -45,10 +54,121 @@ func (s stubSettingsRepo) SetDeleteMissing(ctx context.Context, userID uuid.UUID
 	return nil
 }
 
+func (s stubSettingsRepo) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	return s.channel, s.target, nil
+}
+
+func (s stubSettingsRepo) SetNotificationChannel(ctx context.Context, userID uuid.UUID, channel, target string) error {
+	return nil
+}
+
+func (s stubSettingsRepo) GetDefaultDurations(ctx context.Context, userID uuid.UUID) (map[string]int, error) {
+	durations := map[string]int{}
+	for priority, minutes := range s.durations {
+		durations[priority] = minutes
+	}
+	return durations, nil
+}
+
+func (s stubSettingsRepo) SetDefaultDurations(ctx context.Context, userID uuid.UUID, durations map[string]int) error {
+	for priority := range s.durations {
+		delete(s.durations, priority)
+	}
+	for priority, minutes := range durations {
+		s.durations[priority] = minutes
+	}
+	return nil
+}
+
+func (s stubSettingsRepo) GetClassifierRules(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	rules := map[string]string{}
+	for keyword, classification := range s.rules {
+		rules[keyword] = classification
+	}
+	return rules, nil
+}
+
+func (s stubSettingsRepo) SetClassifierRules(ctx context.Context, userID uuid.UUID, rules map[string]string) error {
+	for keyword := range s.rules {
+		delete(s.rules, keyword)
+	}
+	for keyword, classification := range rules {
+		s.rules[keyword] = classification
+	}
+	return nil
+}
+
+func (s stubSettingsRepo) GetListSorts(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	sorts := map[string]string{}
+	for entity, order := range s.sorts {
+		sorts[entity] = order
+	}
+	return sorts, nil
+}
+
+func (s stubSettingsRepo) SetListSorts(ctx context.Context, userID uuid.UUID, sorts map[string]string) error {
+	for entity := range s.sorts {
+		delete(s.sorts, entity)
+	}
+	for entity, order := range sorts {
+		s.sorts[entity] = order
+	}
+	return nil
+}
+
+func (s stubSettingsRepo) GetHabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+func (s stubSettingsRepo) SetHabitDayRollover(ctx context.Context, userID uuid.UUID, hour int) error {
+	return nil
+}
+
+func (s stubSettingsRepo) GetPlanDefaults(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (s stubSettingsRepo) SetPlanDefaults(ctx context.Context, userID uuid.UUID, defaults map[string]string) error {
+	return nil
+}
+
+func (s stubSettingsRepo) GetDigest(ctx context.Context, userID uuid.UUID) (notifications.Digest, error) {
+	if s.digest == nil {
+		return notifications.Digest{UserID: userID}, nil
+	}
+	return *s.digest, nil
+}
+
+func (s stubSettingsRepo) SetDigest(ctx context.Context, digest notifications.Digest) error {
+	if s.digest != nil {
+		*s.digest = digest
+	}
+	return nil
+}
+
+func (s stubSettingsRepo) ListDigests(ctx context.Context) ([]notifications.Digest, error) {
+	return nil, nil
+}
+
+func (s stubSettingsRepo) MarkDigestSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error {
+	return nil
+}
+
 func resetFlags() {
 	calendarPrimaryOnly = false
 	calendarListJSON = false
 	settingsJSON = false
+	notificationChannel = ""
+	notificationTarget = ""
+	durationPriority = ""
+	durationMinutes = 0
+	classifierKeyword = ""
+	classifierType = ""
+	sortList = ""
+	sortBy = ""
+	digestFrequency = ""
+	digestAt = "08:00"
+	digestTimezone = ""
 }
 
 func TestCalendarListJSON(t *testing.T) {
@@ -591,3 +711,429 @@ func TestCalendarSetNoUser(t *testing.T) {
 		t.Fatalf("expected error for missing user")
 	}
 }
+
+func TestNotificationsGetPlainOutput(t *testing.T) {
+	resetFlags()
+	app := &cli.App{
+		SettingsService: identitySettings.NewService(stubSettingsRepo{channel: "email", target: "me@example.com"}),
+		CurrentUserID:   uuid.New(),
+	}
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	var output strings.Builder
+	cmd := notificationsGetCmd
+	cmd.SetContext(context.Background())
+	cmd.SetOut(&output)
+
+	if err := cmd.RunE(cmd, []string{}); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if strings.TrimSpace(output.String()) != "email (me@example.com)" {
+		t.Fatalf("unexpected output: %s", output.String())
+	}
+}
+
+func TestNotificationsSet(t *testing.T) {
+	resetFlags()
+	app := &cli.App{
+		SettingsService: identitySettings.NewService(stubSettingsRepo{}),
+		CurrentUserID:   uuid.New(),
+	}
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	var output strings.Builder
+	cmd := notificationsSetCmd
+	cmd.SetContext(context.Background())
+	cmd.SetOut(&output)
+
+	notificationChannel = "webhook"
+	if err := cmd.RunE(cmd, []string{}); err == nil {
+		t.Fatalf("expected error for webhook without target")
+	}
+
+	notificationChannel = "pager"
+	if err := cmd.RunE(cmd, []string{}); err == nil {
+		t.Fatalf("expected error for unknown channel")
+	}
+
+	notificationChannel = "webhook"
+	notificationTarget = "https://example.com/hook"
+	settingsJSON = true
+	if err := cmd.RunE(cmd, []string{}); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if !strings.Contains(output.String(), "\"channel\":\"webhook\"") {
+		t.Fatalf("expected JSON output, got: %s", output.String())
+	}
+}
+
+func TestDurationsSetAndGet(t *testing.T) {
+	resetFlags()
+	repo := stubSettingsRepo{durations: map[string]int{}}
+	app := &cli.App{
+		SettingsService: identitySettings.NewService(repo),
+		CurrentUserID:   uuid.New(),
+	}
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	setCmd := durationsSetCmd
+	setCmd.SetContext(context.Background())
+	setCmd.SetOut(&strings.Builder{})
+
+	durationPriority = "urgent-ish"
+	durationMinutes = 30
+	if err := setCmd.RunE(setCmd, []string{}); err == nil {
+		t.Fatalf("expected error for unknown priority")
+	}
+
+	durationPriority = "HIGH"
+	durationMinutes = 90
+	if err := setCmd.RunE(setCmd, []string{}); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	durationPriority = "none"
+	durationMinutes = 30
+	if err := setCmd.RunE(setCmd, []string{}); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	var output strings.Builder
+	getCmd := durationsGetCmd
+	getCmd.SetContext(context.Background())
+	getCmd.SetOut(&output)
+	if err := getCmd.RunE(getCmd, []string{}); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if output.String() != "high    90 min\nnone    30 min\n" {
+		t.Fatalf("unexpected output: %q", output.String())
+	}
+}
+
+func TestSortSetAndGet(t *testing.T) {
+	resetFlags()
+	repo := stubSettingsRepo{sorts: map[string]string{}}
+	app := &cli.App{
+		SettingsService: identitySettings.NewService(repo),
+		CurrentUserID:   uuid.New(),
+	}
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	setCmd := sortSetCmd
+	setCmd.SetContext(context.Background())
+	setCmd.SetOut(&strings.Builder{})
+
+	sortList = "projects"
+	sortBy = "name"
+	if err := setCmd.RunE(setCmd, []string{}); err == nil || !strings.Contains(err.Error(), `unknown list "projects"`) {
+		t.Fatalf("expected unknown list error, got: %v", err)
+	}
+
+	sortList = "habits"
+	sortBy = "priority"
+	if err := setCmd.RunE(setCmd, []string{}); err == nil || !strings.Contains(err.Error(), `unknown field "priority"`) {
+		t.Fatalf("expected unknown field error, got: %v", err)
+	}
+
+	var output strings.Builder
+	setCmd.SetOut(&output)
+	sortList = "Tasks"
+	sortBy = "due_date:asc,priority"
+	if err := setCmd.RunE(setCmd, []string{}); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if output.String() != "Default tasks order set to due_date:asc,priority:desc.\n" {
+		t.Fatalf("unexpected output: %q", output.String())
+	}
+
+	output.Reset()
+	getCmd := sortGetCmd
+	getCmd.SetContext(context.Background())
+	getCmd.SetOut(&output)
+	if err := getCmd.RunE(getCmd, []string{}); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if output.String() != "tasks   due_date:asc,priority:desc\nhabits  (newest first)\n" {
+		t.Fatalf("unexpected output: %q", output.String())
+	}
+
+	sortBy = ""
+	if err := setCmd.RunE(setCmd, []string{}); err != nil {
+		t.Fatalf("reset failed: %v", err)
+	}
+	if len(repo.sorts) != 0 {
+		t.Fatalf("expected default restored, got: %v", repo.sorts)
+	}
+}
+
+func TestClassifierSetAndGet(t *testing.T) {
+	resetFlags()
+	repo := stubSettingsRepo{rules: map[string]string{}}
+	app := &cli.App{
+		SettingsService: identitySettings.NewService(repo),
+		CurrentUserID:   uuid.New(),
+	}
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	setCmd := classifierSetCmd
+	setCmd.SetContext(context.Background())
+	setCmd.SetOut(&strings.Builder{})
+
+	classifierKeyword = "standup"
+	classifierType = "chore"
+	if err := setCmd.RunE(setCmd, []string{}); err == nil {
+		t.Fatalf("expected error for unknown type")
+	}
+
+	classifierKeyword = "Standup"
+	classifierType = "Meeting"
+	if err := setCmd.RunE(setCmd, []string{}); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	classifierKeyword = "stretch"
+	classifierType = "habit"
+	if err := setCmd.RunE(setCmd, []string{}); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	var output strings.Builder
+	getCmd := classifierGetCmd
+	getCmd.SetContext(context.Background())
+	getCmd.SetOut(&output)
+	if err := getCmd.RunE(getCmd, []string{}); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if output.String() != "standup -> meeting\nstretch -> habit\n" {
+		t.Fatalf("unexpected output: %q", output.String())
+	}
+
+	removeCmd := classifierRemoveCmd
+	removeCmd.SetContext(context.Background())
+	removeCmd.SetOut(&strings.Builder{})
+	classifierKeyword = "stretch"
+	if err := removeCmd.RunE(removeCmd, []string{}); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if _, ok := repo.rules["stretch"]; ok {
+		t.Fatalf("expected rule to be removed, got %v", repo.rules)
+	}
+}
+
+func TestDigestSetAndGet(t *testing.T) {
+	resetFlags()
+	repo := stubSettingsRepo{digest: &notifications.Digest{}}
+	app := &cli.App{
+		SettingsService: identitySettings.NewService(repo),
+		CurrentUserID:   uuid.New(),
+	}
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	setCmd := digestSetCmd
+	setCmd.SetContext(context.Background())
+	setCmd.SetOut(&strings.Builder{})
+
+	digestFrequency = "hourly"
+	if err := setCmd.RunE(setCmd, []string{}); err == nil {
+		t.Fatalf("expected error for unknown frequency")
+	}
+
+	digestFrequency = "daily"
+	digestTimezone = "Nowhere/Special"
+	if err := setCmd.RunE(setCmd, []string{}); err == nil {
+		t.Fatalf("expected error for unknown time zone")
+	}
+
+	digestAt = "07:30"
+	digestTimezone = "Asia/Tokyo"
+	if err := setCmd.RunE(setCmd, []string{}); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	var output strings.Builder
+	getCmd := digestGetCmd
+	getCmd.SetContext(context.Background())
+	getCmd.SetOut(&output)
+	if err := getCmd.RunE(getCmd, []string{}); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if output.String() != "daily at 07:30 (Asia/Tokyo)\n" {
+		t.Fatalf("unexpected output: %q", output.String())
+	}
+}
+
+// recordingSettingsRepo keeps the values stubSettingsRepo discards, so
+// settings can be written and read back.
+type recordingSettingsRepo struct {
+	stubSettingsRepo
+	dayRollover  int
+	planDefaults map[string]string
+}
+
+func newRecordingSettingsRepo() *recordingSettingsRepo {
+	return &recordingSettingsRepo{stubSettingsRepo: stubSettingsRepo{
+		durations: map[string]int{},
+		rules:     map[string]string{},
+		sorts:     map[string]string{},
+		digest:    &notifications.Digest{},
+	}, planDefaults: map[string]string{}}
+}
+
+func (r *recordingSettingsRepo) GetCalendarID(ctx context.Context, userID uuid.UUID) (string, error) {
+	return r.calendarID, nil
+}
+
+func (r *recordingSettingsRepo) SetCalendarID(ctx context.Context, userID uuid.UUID, calendarID string) error {
+	r.calendarID = calendarID
+	return nil
+}
+
+func (r *recordingSettingsRepo) GetDeleteMissing(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return r.deleteMissing, nil
+}
+
+func (r *recordingSettingsRepo) SetDeleteMissing(ctx context.Context, userID uuid.UUID, deleteMissing bool) error {
+	r.deleteMissing = deleteMissing
+	return nil
+}
+
+func (r *recordingSettingsRepo) GetHabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error) {
+	return r.dayRollover, nil
+}
+
+func (r *recordingSettingsRepo) SetHabitDayRollover(ctx context.Context, userID uuid.UUID, hour int) error {
+	r.dayRollover = hour
+	return nil
+}
+
+func (r *recordingSettingsRepo) GetPlanDefaults(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	defaults := map[string]string{}
+	for key, value := range r.planDefaults {
+		defaults[key] = value
+	}
+	return defaults, nil
+}
+
+func (r *recordingSettingsRepo) SetPlanDefaults(ctx context.Context, userID uuid.UUID, defaults map[string]string) error {
+	r.planDefaults = defaults
+	return nil
+}
+
+func (r *recordingSettingsRepo) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	return r.channel, r.target, nil
+}
+
+func (r *recordingSettingsRepo) SetNotificationChannel(ctx context.Context, userID uuid.UUID, channel, target string) error {
+	r.channel, r.target = channel, target
+	return nil
+}
+
+func TestSettingsExportImport_RoundTrip(t *testing.T) {
+	resetFlags()
+	source := newRecordingSettingsRepo()
+	source.calendarID = "work"
+	source.deleteMissing = true
+	source.channel, source.target = "webhook", "https://example.com/hooks/orbita"
+	source.durations["high"] = 90
+	source.rules["standup"] = "meeting"
+	source.sorts["habits"] = "streak:desc"
+	source.dayRollover = 4
+	source.planDefaults["day_offset"] = "0"
+	*source.digest = notifications.Digest{Frequency: notifications.DigestDaily, Hour: 7, Minute: 30, Timezone: "Asia/Tokyo"}
+
+	cli.SetApp(&cli.App{SettingsService: identitySettings.NewService(source), CurrentUserID: uuid.New()})
+	defer cli.SetApp(nil)
+
+	path := filepath.Join(t.TempDir(), "settings.json")
+	exportOutput = path
+	defer func() { exportOutput = "" }()
+	exportCmd.SetContext(context.Background())
+	exportCmd.SetOut(&strings.Builder{})
+	if err := exportCmd.RunE(exportCmd, []string{}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	target := newRecordingSettingsRepo()
+	target.rules["lunch"] = "habit"
+	cli.SetApp(&cli.App{SettingsService: identitySettings.NewService(target), CurrentUserID: uuid.New()})
+
+	var output strings.Builder
+	importCmd.SetContext(context.Background())
+	importCmd.SetOut(&output)
+	if err := importCmd.RunE(importCmd, []string{path}); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if !strings.Contains(output.String(), "Imported 16 settings.") {
+		t.Fatalf("unexpected output: %q", output.String())
+	}
+
+	if target.calendarID != "work" || !target.deleteMissing {
+		t.Fatalf("calendar settings not imported: %+v", target.stubSettingsRepo)
+	}
+	if target.channel != "webhook" || target.target != "https://example.com/hooks/orbita" {
+		t.Fatalf("notification channel not imported: %s %s", target.channel, target.target)
+	}
+	if target.durations["high"] != 90 || len(target.durations) != 1 {
+		t.Fatalf("durations not imported: %v", target.durations)
+	}
+	if len(target.rules) != 1 || target.rules["standup"] != "meeting" {
+		t.Fatalf("classifier rules not replaced: %v", target.rules)
+	}
+	if target.sorts["habits"] != "streak:desc" || target.sorts["tasks"] != "priority:desc,due_date:asc" {
+		t.Fatalf("list sorts not imported: %v", target.sorts)
+	}
+	if target.dayRollover != 4 {
+		t.Fatalf("habit day rollover not imported: %d", target.dayRollover)
+	}
+	if target.planDefaults["day_offset"] != "0" || target.planDefaults["min_slot_minutes"] != "15" {
+		t.Fatalf("plan defaults not imported: %v", target.planDefaults)
+	}
+	if target.digest.Frequency != notifications.DigestDaily || target.digest.Time() != "07:30" || target.digest.Timezone != "Asia/Tokyo" {
+		t.Fatalf("digest not imported: %+v", *target.digest)
+	}
+}
+
+func TestSettingsImport_InvalidValues(t *testing.T) {
+	resetFlags()
+	repo := newRecordingSettingsRepo()
+	cli.SetApp(&cli.App{SettingsService: identitySettings.NewService(repo), CurrentUserID: uuid.New()})
+	defer cli.SetApp(nil)
+
+	importCmd.SetContext(context.Background())
+	importCmd.SetOut(&strings.Builder{})
+
+	importCmd.SetIn(strings.NewReader(`{
+  "version": 1,
+  "settings": {
+    "calendar_id": "work",
+    "digest.timezone": "Nowhere/Special",
+    "durations.high": "-5"
+  }
+}`))
+	err := importCmd.RunE(importCmd, []string{"-"})
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{`unknown time zone "Nowhere/Special"`, "durations.high: -5 is outside 0-480"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got: %v", want, err)
+		}
+	}
+	if repo.calendarID != "" {
+		t.Fatalf("expected nothing imported, calendar ID is %q", repo.calendarID)
+	}
+
+	importCmd.SetIn(strings.NewReader(`{"version": 1, "settings": {}, "classifier_rules": {"standup": "party"}}`))
+	if err := importCmd.RunE(importCmd, []string{"-"}); err == nil || !strings.Contains(err.Error(), `unknown type "party"`) {
+		t.Fatalf("expected classifier type error, got: %v", err)
+	}
+
+	importCmd.SetIn(strings.NewReader(`{"version": 1, "settngs": {}}`))
+	if err := importCmd.RunE(importCmd, []string{"-"}); err == nil || !strings.Contains(err.Error(), "invalid settings file") {
+		t.Fatalf("expected decode error, got: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// sortLists lists the lists with a configurable default order, in display
+// order.
+var sortLists = []string{"tasks", "habits"}
+
+var sortCmd = &cobra.Command{
+	Use:   "sort",
+	Short: "Manage default list sort orders",
+	Long: `Manage the order task and habit lists are shown in.
+
+An order is one or more fields with an optional direction, most important
+first, such as priority:desc,due_date:asc. A direction left out is desc.
+The --sort flag of a list command always wins over the default.
+
+Tasks can be sorted by priority, due_date, created_at and title; habits by
+streak, best_streak, name and created_at.`,
+}
+
+var sortGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get the default list sort orders",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		orders := make(map[string]string, len(sortLists))
+		for _, list := range sortLists {
+			order, err := app.SettingsService.ListSort(cmd.Context(), app.CurrentUserID, list)
+			if err != nil {
+				return err
+			}
+			orders[list] = order
+		}
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"list_sorts": orders,
+			})
+		}
+		for _, list := range sortLists {
+			order := orders[list]
+			if order == "" {
+				order = "(newest first)"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%-7s %s\n", list, order)
+		}
+		return nil
+	},
+}
+
+var sortSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set the default sort order of a list",
+	Example: `  orbita settings sort set --list tasks --by priority:desc,due_date:asc
+  orbita settings sort set --list habits --by streak
+  orbita settings sort set --list tasks   # restore the default`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		list := strings.ToLower(strings.TrimSpace(sortList))
+		if !slices.Contains(sortLists, list) {
+			return fmt.Errorf("unknown list %q: use %s", sortList, strings.Join(sortLists, " or "))
+		}
+
+		if err := app.SettingsService.SetListSort(cmd.Context(), app.CurrentUserID, list, sortBy); err != nil {
+			return err
+		}
+		order, err := app.SettingsService.ListSort(cmd.Context(), app.CurrentUserID, list)
+		if err != nil {
+			return err
+		}
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"list":    list,
+				"order":   order,
+				"updated": true,
+			})
+		}
+		if strings.TrimSpace(sortBy) == "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "Default %s order restored.\n", list)
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Default %s order set to %s.\n", list, order)
+		return nil
+	},
+}
+
+var sortList string
+var sortBy string
+
+func init() {
+	sortSetCmd.Flags().StringVar(&sortList, "list", "", "list: tasks or habits")
+	sortSetCmd.Flags().StringVar(&sortBy, "by", "", "fields to sort by, e.g. priority:desc,due_date:asc (empty restores the default)")
+	_ = sortSetCmd.MarkFlagRequired("list")
+
+	sortGetCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+	sortSetCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+
+	sortCmd.AddCommand(sortGetCmd)
+	sortCmd.AddCommand(sortSetCmd)
+	Cmd.AddCommand(sortCmd)
+}
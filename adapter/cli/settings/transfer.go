@@ -0,0 +1,141 @@
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	identitySettings "github.com/felixgeelhaar/orbita/internal/identity/application/settings"
+	inboxServices "github.com/felixgeelhaar/orbita/internal/inbox/services"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all settings as JSON",
+	Long: `Export all of your settings as JSON, to back them up or to set up
+another machine the same way with 'orbita settings import'. Settings you
+have not changed are exported with their defaults.`,
+	Example: `  orbita settings export > orbita-settings.json
+  orbita settings export --output orbita-settings.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		export, err := app.SettingsService.Export(cmd.Context(), app.CurrentUserID)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+
+		if exportOutput == "" {
+			_, err = cmd.OutOrStdout().Write(data)
+			return err
+		}
+		if err := os.WriteFile(exportOutput, data, 0600); err != nil {
+			return fmt.Errorf("failed to write settings: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Settings exported to %s\n", exportOutput)
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import settings from a JSON export",
+	Long: `Import settings written by 'orbita settings export'. Use - to read from
+standard input.
+
+Every value is checked before anything is changed: if any setting is
+invalid, all problems are reported and no setting is applied. Settings
+missing from the file are left as they are; classifier rules in the file
+replace your current rules.`,
+	Example: `  orbita settings import orbita-settings.json
+  cat orbita-settings.json | orbita settings import -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.SettingsService == nil {
+			return errors.New("settings service not configured")
+		}
+		if app.CurrentUserID == uuid.Nil {
+			return errors.New("current user not configured")
+		}
+
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read settings: %w", err)
+		}
+
+		var export identitySettings.Export
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&export); err != nil {
+			return fmt.Errorf("invalid settings file: %w", err)
+		}
+		if err := validateClassifierRules(export.ClassifierRules); err != nil {
+			return err
+		}
+
+		if err := app.SettingsService.Import(cmd.Context(), app.CurrentUserID, &export); err != nil {
+			return fmt.Errorf("settings not imported:\n%w", err)
+		}
+		if settingsJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(map[string]any{
+				"settings": len(export.Settings),
+				"imported": true,
+			})
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Imported %d settings.\n", len(export.Settings))
+		return nil
+	},
+}
+
+// validateClassifierRules checks that every rule classifies as a known type.
+func validateClassifierRules(rules map[string]string) error {
+	keywords := make([]string, 0, len(rules))
+	for keyword := range rules {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	for _, keyword := range keywords {
+		if !inboxServices.IsClassification(rules[keyword]) {
+			return fmt.Errorf("settings not imported: classifier rule %q has unknown type %q: use %s",
+				keyword, rules[keyword], strings.Join(inboxServices.Classifications, ", "))
+		}
+	}
+	return nil
+}
+
+var exportOutput string
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "output file (default: stdout)")
+	importCmd.Flags().BoolVar(&settingsJSON, "json", false, "output as JSON")
+
+	Cmd.AddCommand(exportCmd)
+	Cmd.AddCommand(importCmd)
+}
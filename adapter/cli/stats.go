@@ -60,85 +60,31 @@ Examples:
 }
 
 func showTaskStats(cmd *cobra.Command, app *App) {
-	if app.ListTasksHandler == nil {
+	if app.GetTaskStatsHandler == nil {
 		return
 	}
 
 	fmt.Println("\n  TASKS")
 	fmt.Println(strings.Repeat("-", 60))
 
-	// Get all tasks
-	allQuery := queries.ListTasksQuery{
-		UserID:     app.CurrentUserID,
-		IncludeAll: true,
-	}
-	allTasks, err := app.ListTasksHandler.Handle(cmd.Context(), allQuery)
+	stats, err := app.GetTaskStatsHandler.Handle(cmd.Context(), queries.GetTaskStatsQuery{
+		UserID: app.CurrentUserID,
+	})
 	if err != nil {
 		return
 	}
 
-	// Count by status
-	pending := 0
-	inProgress := 0
-	completed := 0
-	archived := 0
-
-	// Count by priority
-	urgent := 0
-	high := 0
-	medium := 0
-	low := 0
-
-	// Overdue count
-	overdue := 0
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-
-	for _, t := range allTasks {
-		switch t.Status {
-		case "pending":
-			pending++
-		case "in_progress":
-			inProgress++
-		case "completed":
-			completed++
-		case "archived":
-			archived++
-		}
-
-		switch t.Priority {
-		case "urgent":
-			urgent++
-		case "high":
-			high++
-		case "medium":
-			medium++
-		case "low":
-			low++
-		}
-
-		if t.DueDate != nil && t.DueDate.Before(today) && t.Status != "completed" && t.Status != "archived" {
-			overdue++
-		}
-	}
-
-	total := len(allTasks)
-	completionRate := 0.0
-	if total > 0 {
-		completionRate = float64(completed) / float64(total) * 100
-	}
-
-	fmt.Printf("    Total: %d tasks\n", total)
-	fmt.Printf("    Status: %d pending | %d in progress | %d completed | %d archived\n",
-		pending, inProgress, completed, archived)
+	fmt.Printf("    Total: %d tasks\n", stats.Total)
+	fmt.Printf("    Status: %d pending | %d in progress | %d blocked | %d waiting | %d completed | %d archived\n",
+		stats.Pending, stats.InProgress, stats.Blocked, stats.Waiting, stats.Completed, stats.Archived)
 	fmt.Printf("    Priority: %d urgent | %d high | %d medium | %d low\n",
-		urgent, high, medium, low)
+		stats.Urgent, stats.High, stats.Medium, stats.Low)
 
-	if overdue > 0 {
-		fmt.Printf("    Overdue: %d tasks need attention!\n", overdue)
+	if stats.Overdue > 0 {
+		fmt.Printf("    Overdue: %d tasks need attention!\n", stats.Overdue)
 	}
 
-	fmt.Printf("    Completion Rate: %.1f%%\n", completionRate)
+	fmt.Printf("    Completion Rate: %.1f%%\n", stats.CompletionRate)
 }
 
 func showHabitStats(cmd *cobra.Command, app *App) {
@@ -3,11 +3,12 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"time"
+	"strconv"
+	"strings"
 
 	calendarApp "github.com/felixgeelhaar/orbita/internal/calendar/application"
 	googleCalendar "github.com/felixgeelhaar/orbita/internal/calendar/infrastructure/google"
-	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/shared/application/capability"
 	"github.com/spf13/cobra"
 )
 
@@ -18,21 +19,32 @@ var (
 	syncUseConfigCalendar bool
 	syncAttendees         []string
 	syncReminders         []int
+	syncTypeReminders     []string
+	syncAttachments       bool
+	syncConfirmMassDelete bool
 )
 
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Sync schedule to external calendar",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := RequireCapability(capability.CalendarSync); err != nil {
+			return err
+		}
 		app := GetApp()
 		if app == nil || app.GetScheduleHandler == nil {
 			return errors.New("sync requires database connection")
 		}
 		if app.CalendarSyncer == nil {
-			return errors.New("calendar sync not configured")
+			return FeatureUnavailable(capability.CalendarSync)
+		}
+
+		typeReminders, err := parseTypeReminders(syncTypeReminders)
+		if err != nil {
+			return err
 		}
 
-		blocks, err := gatherBlocks(cmd, app, syncDays)
+		blocks, err := GatherScheduleBlocks(cmd.Context(), app, syncDays)
 		if err != nil {
 			return err
 		}
@@ -44,7 +56,12 @@ var syncCmd = &cobra.Command{
 		if syncUseConfigCalendar && app.SettingsService != nil {
 			if syncCalendarID == "" {
 				if storedID, err := app.SettingsService.GetCalendarID(cmd.Context(), app.CurrentUserID); err == nil && storedID != "" {
-					syncCalendarID = storedID
+					lister, _ := app.CalendarSyncer.(calendarApp.CalendarLister)
+					resolved := calendarApp.ResolveDefaultCalendar(cmd.Context(), lister, app.CurrentUserID, storedID)
+					if resolved.Warning != "" {
+						fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", resolved.Warning)
+					}
+					syncCalendarID = resolved.CalendarID
 				}
 			}
 			if !syncDeleteMissing {
@@ -70,10 +87,21 @@ var syncCmd = &cobra.Command{
 			if len(syncReminders) > 0 {
 				googleSyncer = googleSyncer.WithReminders(syncReminders)
 			}
+			if len(typeReminders) > 0 {
+				googleSyncer = googleSyncer.WithBlockTypeReminders(typeReminders)
+			}
+			if syncAttachments {
+				googleSyncer = googleSyncer.WithAttachments(true)
+			}
+			googleSyncer = googleSyncer.WithMassDeleteConfirmed(syncConfirmMassDelete)
 			syncer = googleSyncer
 		}
 
 		result, err := syncer.Sync(cmd.Context(), app.CurrentUserID, blocks)
+		if errors.Is(err, calendarApp.ErrMassDeleteNotConfirmed) {
+			fmt.Printf("Synced blocks: created=%d updated=%d deleted=0 failed=%d\n", result.Created, result.Updated, result.Failed)
+			return fmt.Errorf("%w; no events were deleted, rerun with --confirm-mass-delete to delete them", err)
+		}
 		if err != nil {
 			return err
 		}
@@ -83,40 +111,6 @@ var syncCmd = &cobra.Command{
 	},
 }
 
-func gatherBlocks(cmd *cobra.Command, app *App, days int) ([]calendarApp.TimeBlock, error) {
-	now := time.Now()
-	allBlocks := make([]calendarApp.TimeBlock, 0)
-
-	for i := 0; i < days; i++ {
-		day := now.AddDate(0, 0, i)
-		query := scheduleQueries.GetScheduleQuery{
-			UserID: app.CurrentUserID,
-			Date:   day,
-		}
-
-		schedule, err := app.GetScheduleHandler.Handle(cmd.Context(), query)
-		if err != nil {
-			return nil, err
-		}
-
-		if schedule != nil {
-			for _, block := range schedule.Blocks {
-				allBlocks = append(allBlocks, calendarApp.TimeBlock{
-					ID:        block.ID,
-					Title:     block.Title,
-					BlockType: block.BlockType,
-					StartTime: block.StartTime,
-					EndTime:   block.EndTime,
-					Completed: block.Completed,
-					Missed:    block.Missed,
-				})
-			}
-		}
-	}
-
-	return allBlocks, nil
-}
-
 func init() {
 	syncCmd.Flags().IntVarP(&syncDays, "days", "d", 7, "number of days to sync")
 	syncCmd.Flags().BoolVar(&syncDeleteMissing, "delete-missing", false, "delete remote events missing from this sync set")
@@ -124,5 +118,40 @@ func init() {
 	syncCmd.Flags().BoolVar(&syncUseConfigCalendar, "use-config-calendar", true, "use CALENDAR_ID from config when no --calendar is provided")
 	syncCmd.Flags().StringSliceVar(&syncAttendees, "attendee", nil, "attendee email to include in synced events (repeatable)")
 	syncCmd.Flags().IntSliceVar(&syncReminders, "reminder", nil, "reminder minutes for synced events (repeatable)")
+	syncCmd.Flags().StringArrayVar(&syncTypeReminders, "type-reminder", nil, "reminder minutes per block type, e.g. meeting=10,30 or task=none (repeatable)")
+	syncCmd.Flags().BoolVar(&syncAttachments, "attachments", false, "list block attachments in synced event descriptions")
+	syncCmd.Flags().BoolVar(&syncConfirmMassDelete, "confirm-mass-delete", false, "allow deleting more remote events than CALENDAR_MASS_DELETE_THRESHOLD")
 	rootCmd.AddCommand(syncCmd)
 }
+
+// parseTypeReminders parses --type-reminder values of the form
+// "<block-type>=<minutes>[,<minutes>...]". "none" or an empty list disables
+// reminders for that block type.
+func parseTypeReminders(values []string) (map[string][]int, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	reminders := make(map[string][]int, len(values))
+	for _, value := range values {
+		blockType, list, ok := strings.Cut(value, "=")
+		blockType = strings.TrimSpace(blockType)
+		if !ok || blockType == "" {
+			return nil, fmt.Errorf("invalid --type-reminder %q, use <type>=<minutes>[,<minutes>]", value)
+		}
+
+		minutes := []int{}
+		list = strings.TrimSpace(list)
+		if list != "" && list != "none" {
+			for _, part := range strings.Split(list, ",") {
+				m, err := strconv.Atoi(strings.TrimSpace(part))
+				if err != nil || m <= 0 {
+					return nil, fmt.Errorf("invalid reminder minutes %q for block type %s", part, blockType)
+				}
+				minutes = append(minutes, m)
+			}
+		}
+		reminders[blockType] = minutes
+	}
+	return reminders, nil
+}
@@ -1,15 +1,19 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	googleCalendar "github.com/felixgeelhaar/orbita/internal/calendar/infrastructure/google"
 	identitySettings "github.com/felixgeelhaar/orbita/internal/identity/application/settings"
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
 	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
 	scheduleDomain "github.com/felixgeelhaar/orbita/internal/scheduling/domain"
 	"github.com/google/uuid"
@@ -27,6 +31,8 @@ func (s stubTokenProvider) TokenSource(ctx context.Context, userID uuid.UUID) (o
 type stubSettingsRepo struct {
 	calendarID    string
 	deleteMissing bool
+	channel       string
+	target        string
 }
 
 func (s stubSettingsRepo) GetCalendarID(ctx context.Context, userID uuid.UUID) (string, error) {
@@ -45,6 +51,70 @@ func (s stubSettingsRepo) SetDeleteMissing(ctx context.Context, userID uuid.UUID
 	return nil
 }
 
+func (s stubSettingsRepo) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	return s.channel, s.target, nil
+}
+
+func (s stubSettingsRepo) SetNotificationChannel(ctx context.Context, userID uuid.UUID, channel, target string) error {
+	return nil
+}
+
+func (s stubSettingsRepo) GetDefaultDurations(ctx context.Context, userID uuid.UUID) (map[string]int, error) {
+	return map[string]int{}, nil
+}
+
+func (s stubSettingsRepo) SetDefaultDurations(ctx context.Context, userID uuid.UUID, durations map[string]int) error {
+	return nil
+}
+
+func (s stubSettingsRepo) GetClassifierRules(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (s stubSettingsRepo) SetClassifierRules(ctx context.Context, userID uuid.UUID, rules map[string]string) error {
+	return nil
+}
+
+func (s stubSettingsRepo) GetListSorts(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (s stubSettingsRepo) SetListSorts(ctx context.Context, userID uuid.UUID, sorts map[string]string) error {
+	return nil
+}
+
+func (s stubSettingsRepo) GetHabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+func (s stubSettingsRepo) SetHabitDayRollover(ctx context.Context, userID uuid.UUID, hour int) error {
+	return nil
+}
+
+func (s stubSettingsRepo) GetPlanDefaults(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (s stubSettingsRepo) SetPlanDefaults(ctx context.Context, userID uuid.UUID, defaults map[string]string) error {
+	return nil
+}
+
+func (s stubSettingsRepo) GetDigest(ctx context.Context, userID uuid.UUID) (notifications.Digest, error) {
+	return notifications.Digest{UserID: userID}, nil
+}
+
+func (s stubSettingsRepo) SetDigest(ctx context.Context, digest notifications.Digest) error {
+	return nil
+}
+
+func (s stubSettingsRepo) ListDigests(ctx context.Context) ([]notifications.Digest, error) {
+	return nil, nil
+}
+
+func (s stubSettingsRepo) MarkDigestSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error {
+	return nil
+}
+
 type stubScheduleRepo struct {
 	schedule *scheduleDomain.Schedule
 }
@@ -154,3 +224,106 @@ func TestSyncCommand_UsesSettings(t *testing.T) {
 		t.Fatalf("expected calendar ID %q in path, got %q", calendarID, lastPath)
 	}
 }
+
+func TestParseTypeReminders(t *testing.T) {
+	reminders, err := parseTypeReminders([]string{"meeting=10,30", "task=none", "habit="})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+
+	expected := map[string][]int{
+		"meeting": {10, 30},
+		"task":    {},
+		"habit":   {},
+	}
+	if !reflect.DeepEqual(reminders, expected) {
+		t.Fatalf("expected %v, got %v", expected, reminders)
+	}
+
+	for _, invalid := range []string{"meeting", "=10", "meeting=soon", "meeting=-5"} {
+		if _, err := parseTypeReminders([]string{invalid}); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestSyncCommand_ValidatesDefaultCalendar(t *testing.T) {
+	tests := []struct {
+		name         string
+		storedID     string
+		wantCalendar string
+		wantWarning  bool
+	}{
+		{name: "valid default", storedID: "work", wantCalendar: "work"},
+		{name: "deleted default falls back to primary", storedID: "old-team", wantCalendar: "primary", wantWarning: true},
+		{name: "no default", storedID: "", wantCalendar: "primary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			var postedPaths []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/users/me/calendarList":
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"items": []map[string]any{
+							{"id": "me@example.com", "summary": "Me", "primary": true},
+							{"id": "work", "summary": "Work"},
+						},
+					})
+				case r.Method == http.MethodPost:
+					postedPaths = append(postedPaths, r.URL.Path)
+					w.WriteHeader(http.StatusOK)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
+			syncer := googleCalendar.NewSyncerWithBaseURL(stubTokenProvider{source: source}, nil, server.URL)
+
+			now := time.Now()
+			schedule := scheduleDomain.NewSchedule(userID, now)
+			if _, err := schedule.AddBlock(scheduleDomain.BlockTypeTask, uuid.New(), "Test Block", now.Add(time.Hour), now.Add(2*time.Hour)); err != nil {
+				t.Fatalf("failed to add block: %v", err)
+			}
+
+			SetApp(&App{
+				GetScheduleHandler: scheduleQueries.NewGetScheduleHandler(stubScheduleRepo{schedule: schedule}),
+				CalendarSyncer:     syncer,
+				SettingsService:    identitySettings.NewService(stubSettingsRepo{calendarID: tt.storedID}),
+				CurrentUserID:      userID,
+			})
+			defer SetApp(nil)
+
+			syncDays = 1
+			syncDeleteMissing = false
+			syncCalendarID = ""
+			syncUseConfigCalendar = true
+
+			var stderr bytes.Buffer
+			cmd := syncCmd
+			cmd.SetContext(context.Background())
+			cmd.SetErr(&stderr)
+			defer cmd.SetErr(nil)
+
+			if err := cmd.RunE(cmd, []string{}); err != nil {
+				t.Fatalf("sync failed: %v", err)
+			}
+
+			if len(postedPaths) == 0 {
+				t.Fatal("expected an event to be synced")
+			}
+			wantPath := "/calendars/" + tt.wantCalendar + "/events"
+			if postedPaths[0] != wantPath {
+				t.Fatalf("expected sync to %q, got %q", wantPath, postedPaths[0])
+			}
+			if gotWarning := strings.Contains(stderr.String(), "Warning:"); gotWarning != tt.wantWarning {
+				t.Fatalf("expected warning=%v, stderr: %q", tt.wantWarning, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	blockReason string
+	unblockNote string
+)
+
+var blockCmd = &cobra.Command{
+	Use:   "block [task-id]",
+	Short: "Mark a task as blocked by something outside Orbita",
+	Long: `Mark a task as blocked while it waits on something outside Orbita,
+such as a pull request or an email. The reason is free-form; a link works well.
+
+Blocked tasks are left out of the default list and auto-scheduling until
+they are unblocked. Blocking an already blocked task replaces its reason.
+
+Examples:
+  orbita task block abc123 --reason "https://github.com/acme/api/pull/42"
+  orbita task block abc123 --reason "Waiting on legal's reply"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.BlockTaskHandler == nil {
+			return fmt.Errorf("application not initialized - database connection required")
+		}
+
+		taskID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid task ID: %w", err)
+		}
+
+		if err := app.BlockTaskHandler.Handle(cmd.Context(), commands.BlockTaskCommand{
+			TaskID: taskID,
+			UserID: app.CurrentUserID,
+			Reason: blockReason,
+		}); err != nil {
+			return fmt.Errorf("failed to block task: %w", err)
+		}
+
+		fmt.Printf("Task blocked: %s\n", taskID)
+		if blockReason != "" {
+			fmt.Printf("  Reason: %s\n", blockReason)
+		}
+		return nil
+	},
+}
+
+var unblockCmd = &cobra.Command{
+	Use:   "unblock [task-id]",
+	Short: "Unblock a blocked task",
+	Long: `Clear a task's block and return it to pending, optionally noting how
+the block was resolved.
+
+Examples:
+  orbita task unblock abc123
+  orbita task unblock abc123 --note "PR merged"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.UnblockTaskHandler == nil {
+			return fmt.Errorf("application not initialized - database connection required")
+		}
+
+		taskID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid task ID: %w", err)
+		}
+
+		if err := app.UnblockTaskHandler.Handle(cmd.Context(), commands.UnblockTaskCommand{
+			TaskID: taskID,
+			UserID: app.CurrentUserID,
+			Note:   unblockNote,
+		}); err != nil {
+			return fmt.Errorf("failed to unblock task: %w", err)
+		}
+
+		fmt.Printf("Task unblocked: %s\n", taskID)
+		if unblockNote != "" {
+			fmt.Printf("  Note: %s\n", unblockNote)
+		}
+		return nil
+	},
+}
+
+func init() {
+	blockCmd.Flags().StringVarP(&blockReason, "reason", "r", "", "what the task is waiting on (e.g. a PR link or email subject)")
+	unblockCmd.Flags().StringVarP(&unblockNote, "note", "m", "", "how the block was resolved")
+}
@@ -0,0 +1,92 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var checklistCmd = &cobra.Command{
+	Use:   "checklist",
+	Short: "Manage checklist items within a task",
+	Long: `Add and tick off lightweight steps inside a single task.
+
+Examples:
+  orbita task checklist add abc123 "Write release notes"
+  orbita task checklist toggle abc123 def456`,
+}
+
+var checklistAddCmd = &cobra.Command{
+	Use:   "add [task-id] [title]",
+	Short: "Add a checklist item to a task",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.AddChecklistItemHandler == nil {
+			return fmt.Errorf("application not initialized - database connection required")
+		}
+
+		taskID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid task ID: %w", err)
+		}
+
+		result, err := app.AddChecklistItemHandler.Handle(cmd.Context(), commands.AddChecklistItemCommand{
+			TaskID: taskID,
+			UserID: app.CurrentUserID,
+			Title:  args[1],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add checklist item: %w", err)
+		}
+
+		fmt.Printf("Checklist item added: %s\n", result.ItemID)
+		return nil
+	},
+}
+
+var checklistToggleCmd = &cobra.Command{
+	Use:     "toggle [task-id] [item-id]",
+	Short:   "Mark a checklist item done or not done",
+	Aliases: []string{"check", "tick"},
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.ToggleChecklistItemHandler == nil {
+			return fmt.Errorf("application not initialized - database connection required")
+		}
+
+		taskID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid task ID: %w", err)
+		}
+		itemID, err := uuid.Parse(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid checklist item ID: %w", err)
+		}
+
+		result, err := app.ToggleChecklistItemHandler.Handle(cmd.Context(), commands.ToggleChecklistItemCommand{
+			TaskID: taskID,
+			UserID: app.CurrentUserID,
+			ItemID: itemID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to toggle checklist item: %w", err)
+		}
+
+		state := "not done"
+		if result.Done {
+			state = "done"
+		}
+		fmt.Printf("Checklist item %s marked %s (%d/%d done)\n", itemID, state, result.DoneCount, result.TotalCount)
+		return nil
+	},
+}
+
+func init() {
+	checklistCmd.AddCommand(checklistAddCmd)
+	checklistCmd.AddCommand(checklistToggleCmd)
+}
@@ -2,10 +2,13 @@ package task
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +17,10 @@ var (
 	duration    int
 	description string
 	dueDate     string
+	reminders   []string
+	timezone    string
+	startAfter  string
+	repeat      string
 )
 
 var createCmd = &cobra.Command{
@@ -24,7 +31,12 @@ var createCmd = &cobra.Command{
 Examples:
   orbita task create "Complete project report"
   orbita task create "Review PR" -p high -d 30
-  orbita task create "Write docs" --priority medium --duration 60`,
+  orbita task create "Write docs" --priority medium --duration 60
+  orbita task create "Submit report" --due 2024-03-10 --remind 1d,1h
+  orbita task create "Send Tokyo invoice" --due 2024-03-10 --timezone Asia/Tokyo
+  orbita task create "Publish release notes" --earliest-start 2024-03-08T14:00 --due 2024-03-10
+  orbita task create "Pay rent" --due 2024-04-01 --repeat monthly
+  orbita task create "Water plants" --repeat "every 3 days"`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		app := cli.GetApp()
@@ -41,17 +53,44 @@ Examples:
 			Description:     description,
 			Priority:        priority,
 			DurationMinutes: duration,
+			Timezone:        timezone,
+			Recurrence:      repeat,
+		}
+
+		// A task with its own time zone is due on that day in that zone.
+		loc, err := sharedDomain.LoadTimezone(timezone)
+		if err != nil {
+			return err
+		}
+		if loc == nil {
+			loc = time.UTC
 		}
 
 		// Parse due date if provided
 		if dueDate != "" {
-			parsed, err := time.Parse("2006-01-02", dueDate)
+			parsed, err := time.ParseInLocation("2006-01-02", dueDate, loc)
 			if err != nil {
 				return fmt.Errorf("invalid due date format (use YYYY-MM-DD): %w", err)
 			}
 			createCmd.DueDate = &parsed
 		}
 
+		if startAfter != "" {
+			parsed, err := parseEarliestStart(startAfter, loc)
+			if err != nil {
+				return err
+			}
+			createCmd.EarliestStart = &parsed
+		}
+
+		for _, value := range reminders {
+			offset, err := parseReminderOffset(value)
+			if err != nil {
+				return err
+			}
+			createCmd.ReminderOffsets = append(createCmd.ReminderOffsets, offset)
+		}
+
 		// Execute command
 		ctx := cmd.Context()
 		result, err := app.CreateTaskHandler.Handle(ctx, createCmd)
@@ -67,6 +106,18 @@ Examples:
 		if duration > 0 {
 			fmt.Printf("  duration: %d minutes\n", duration)
 		}
+		if len(reminders) > 0 {
+			fmt.Printf("  reminders: %s before due\n", strings.Join(reminders, ", "))
+		}
+		if timezone != "" {
+			fmt.Printf("  timezone: %s\n", timezone)
+		}
+		if createCmd.EarliestStart != nil {
+			fmt.Printf("  earliest start: %s\n", createCmd.EarliestStart.Format("2006-01-02 15:04"))
+		}
+		if repeat != "" {
+			fmt.Printf("  repeats: %s\n", repeat)
+		}
 
 		return nil
 	},
@@ -77,4 +128,37 @@ func init() {
 	createCmd.Flags().IntVarP(&duration, "duration", "d", 0, "estimated duration in minutes")
 	createCmd.Flags().StringVar(&description, "description", "", "task description")
 	createCmd.Flags().StringVar(&dueDate, "due", "", "due date (YYYY-MM-DD)")
+	createCmd.Flags().StringSliceVar(&reminders, "remind", nil, "remind before the due date (e.g. 1d, 2h, 30m)")
+	createCmd.Flags().StringVar(&timezone, "timezone", "", "IANA time zone the due date belongs to (default: your current one)")
+	createCmd.Flags().StringVar(&startAfter, "earliest-start", "", "don't schedule before this time (YYYY-MM-DD or YYYY-MM-DDTHH:MM)")
+	createCmd.Flags().StringVar(&repeat, "repeat", "", "repeat after completion (daily, weekly, monthly or e.g. \"every 2 weeks\")")
+}
+
+// parseEarliestStart parses an earliest start given as a day or a day and time.
+func parseEarliestStart(value string, loc *time.Location) (time.Time, error) {
+	if parsed, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return parsed, nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02T15:04", value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid earliest start format (use YYYY-MM-DD or YYYY-MM-DDTHH:MM): %w", err)
+	}
+	return parsed, nil
+}
+
+// parseReminderOffset parses a reminder offset such as "1d", "2h" or "30m".
+func parseReminderOffset(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid reminder %q (use e.g. 1d, 2h, 30m)", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	offset, err := time.ParseDuration(value)
+	if err != nil || offset < time.Minute {
+		return 0, fmt.Errorf("invalid reminder %q (use e.g. 1d, 2h, 30m)", value)
+	}
+	return offset, nil
 }
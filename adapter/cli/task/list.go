@@ -13,12 +13,16 @@ import (
 var (
 	showAll        bool
 	showCompleted  bool
+	showBlocked    bool
+	showWaiting    bool
 	status         string
 	filterPriority string
 	overdue        bool
 	dueToday       bool
 	dueBefore      string
 	dueAfter       string
+	filterTags     []string
+	tagMatch       string
 	sortBy         string
 	sortOrder      string
 	limit          int
@@ -30,24 +34,38 @@ var listCmd = &cobra.Command{
 	Long: `List tasks with optional filtering and sorting.
 
 Filter Options:
-  --status      Filter by status (pending, in_progress, completed, archived)
+  --status      Filter by status (pending, in_progress, blocked, waiting, completed, archived)
+  --blocked     Show only blocked tasks
+  --waiting     Show only tasks waiting on someone
   --priority    Filter by priority (urgent, high, medium, low)
   --overdue     Show only overdue tasks
   --due-today   Show only tasks due today
   --due-before  Show tasks due before date (YYYY-MM-DD)
   --due-after   Show tasks due after date (YYYY-MM-DD)
+  --tag         Show tasks with this tag (repeat or separate with commas)
+  --tag-match   Whether tasks need all of the tags or any of them (all, any)
 
 Sort Options:
-  --sort        Sort by field (priority, due_date, created_at)
+  --sort        Sort by field (priority, due_date, created_at, title, score),
+                or by several, e.g. priority:desc,due_date:asc. score ranks
+                tasks with the priority engine
   --order       Sort order (asc, desc)
 
+Without --sort, tasks are listed in your default order, set with
+'orbita settings set sort.tasks' (default priority:desc,due_date:asc).
+
 Examples:
-  orbita task list                          # Pending tasks, sorted by priority
+  orbita task list                          # Pending tasks, in your default order
   orbita task list --all                    # All tasks
   orbita task list --priority urgent        # Only urgent tasks
+  orbita task list --blocked                # Tasks waiting on something external
+  orbita task list --waiting                # Delegated tasks waiting on someone
   orbita task list --overdue                # Overdue tasks
   orbita task list --due-today              # Tasks due today
+  orbita task list --tag work,urgent        # Tasks tagged both work and urgent
+  orbita task list --tag home --tag errands --tag-match any
   orbita task list --sort due_date --order asc  # By due date ascending
+  orbita task list --sort score:desc        # Highest priority engine score first
   orbita task list --limit 5                # Top 5 tasks`,
 	Aliases: []string{"ls"},
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -63,6 +81,8 @@ Examples:
 			Priority:   filterPriority,
 			Overdue:    overdue,
 			DueToday:   dueToday,
+			Tags:       filterTags,
+			TagMatch:   queries.TagMatchMode(tagMatch),
 			SortBy:     sortBy,
 			SortOrder:  sortOrder,
 			Limit:      limit,
@@ -70,6 +90,10 @@ Examples:
 
 		if showCompleted {
 			query.Status = "completed"
+		} else if showBlocked {
+			query.Status = "blocked"
+		} else if showWaiting {
+			query.Status = "waiting"
 		} else if status != "" {
 			query.Status = status
 		}
@@ -130,6 +154,15 @@ Examples:
 			if t.DueDate != nil {
 				fmt.Printf("   Due: %s\n", t.DueDate.Format("2006-01-02"))
 			}
+			if t.BlockedReason != "" {
+				fmt.Printf("   Blocked by: %s\n", t.BlockedReason)
+			}
+			if t.WaitingOn != "" {
+				fmt.Printf("   Waiting on: %s\n", t.WaitingOn)
+			}
+			if len(t.Tags) > 0 {
+				fmt.Printf("   Tags: %s\n", strings.Join(t.Tags, ", "))
+			}
 			fmt.Println()
 		}
 
@@ -145,6 +178,10 @@ func getStatusIcon(status string) string {
 		return "[>]"
 	case "archived":
 		return "[-]"
+	case "blocked":
+		return "[!]"
+	case "waiting":
+		return "[?]"
 	default:
 		return "[ ]"
 	}
@@ -169,7 +206,9 @@ func init() {
 	// Status filters
 	listCmd.Flags().BoolVarP(&showAll, "all", "a", false, "show all tasks including archived")
 	listCmd.Flags().BoolVar(&showCompleted, "completed", false, "show only completed tasks")
-	listCmd.Flags().StringVarP(&status, "status", "s", "", "filter by status (pending, in_progress, completed, archived)")
+	listCmd.Flags().BoolVar(&showBlocked, "blocked", false, "show only blocked tasks")
+	listCmd.Flags().BoolVar(&showWaiting, "waiting", false, "show only tasks waiting on someone")
+	listCmd.Flags().StringVarP(&status, "status", "s", "", "filter by status (pending, in_progress, blocked, waiting, completed, archived)")
 
 	// Priority filter
 	listCmd.Flags().StringVarP(&filterPriority, "priority", "p", "", "filter by priority (urgent, high, medium, low)")
@@ -180,8 +219,12 @@ func init() {
 	listCmd.Flags().StringVar(&dueBefore, "due-before", "", "show tasks due before date (YYYY-MM-DD)")
 	listCmd.Flags().StringVar(&dueAfter, "due-after", "", "show tasks due after date (YYYY-MM-DD)")
 
+	// Tag filters
+	listCmd.Flags().StringSliceVar(&filterTags, "tag", nil, "show tasks with this tag (repeat or separate with commas)")
+	listCmd.Flags().StringVar(&tagMatch, "tag-match", "all", "whether tasks need all of the tags or any (all, any)")
+
 	// Sorting options
-	listCmd.Flags().StringVar(&sortBy, "sort", "", "sort by field (priority, due_date, created_at)")
+	listCmd.Flags().StringVar(&sortBy, "sort", "", "sort by field (priority, due_date, created_at, title, score), e.g. priority:desc,due_date:asc")
 	listCmd.Flags().StringVar(&sortOrder, "order", "", "sort order (asc, desc)")
 
 	// Limit
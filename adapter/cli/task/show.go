@@ -2,6 +2,7 @@ package task
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
@@ -64,12 +65,55 @@ Examples:
 			fmt.Printf("  Due:         %s\n", task.DueDate.Format("2006-01-02 15:04"))
 		}
 
+		if task.EarliestStart != nil {
+			fmt.Printf("  Not before:  %s\n", task.EarliestStart.Format("2006-01-02 15:04"))
+		}
+
+		if task.Recurrence != "" {
+			fmt.Printf("  Repeats:     %s\n", task.Recurrence)
+		}
+
 		if task.CompletedAt != nil {
 			fmt.Printf("  Completed:   %s\n", task.CompletedAt.Format("2006-01-02 15:04"))
 		}
 
+		if task.BlockedAt != nil {
+			fmt.Printf("  Blocked:     %s\n", task.BlockedAt.Format("2006-01-02 15:04"))
+		}
+		if task.BlockedReason != "" {
+			fmt.Printf("  Blocked by:  %s\n", task.BlockedReason)
+		}
+		if task.WaitingOn != "" {
+			fmt.Printf("  Waiting on:  %s\n", task.WaitingOn)
+		}
+		if task.WaitingSince != nil {
+			fmt.Printf("  Waiting:     %s\n", task.WaitingSince.Format("2006-01-02 15:04"))
+		}
+		if task.FollowUpAt != nil {
+			fmt.Printf("  Follow up:   %s\n", task.FollowUpAt.Format("2006-01-02 15:04"))
+		}
+
 		fmt.Printf("  Created:     %s\n", task.CreatedAt.Format("2006-01-02 15:04"))
 
+		if len(task.Tags) > 0 {
+			fmt.Printf("  Tags:        %s\n", strings.Join(task.Tags, ", "))
+		}
+
+		if len(task.Checklist) > 0 {
+			required := ""
+			if task.ChecklistRequired {
+				required = ", required to complete"
+			}
+			fmt.Printf("  Checklist:   %d/%d done%s\n", task.ChecklistDone, len(task.Checklist), required)
+			for _, item := range task.Checklist {
+				mark := " "
+				if item.Done {
+					mark = "x"
+				}
+				fmt.Printf("    [%s] %s  (%s)\n", mark, item.Title, item.ID)
+			}
+		}
+
 		return nil
 	},
 }
@@ -84,6 +128,10 @@ func formatStatus(status string) string {
 		return "Completed"
 	case "archived":
 		return "Archived"
+	case "blocked":
+		return "Blocked"
+	case "waiting":
+		return "Waiting"
 	default:
 		return status
 	}
@@ -0,0 +1,146 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagAdd    []string
+	tagRemove []string
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag [task-id...]",
+	Short: "Add or remove tags on several tasks at once",
+	Long: `Apply the same tag changes to every listed task in one transaction.
+Tasks that cannot be tagged are reported and the rest are still updated.
+
+Use the add and remove subcommands to change the tags of a single task.
+Tags are trimmed and lower-cased, and duplicates are dropped.
+
+Examples:
+  orbita task tag --add work abc123 def456
+  orbita task tag --add work --remove old abc123 def456
+  orbita task tag add abc123 work urgent
+  orbita task tag remove abc123 urgent`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.BulkTagTasksHandler == nil {
+			return fmt.Errorf("application not initialized - database connection required")
+		}
+
+		taskIDs := make([]uuid.UUID, 0, len(args))
+		for _, arg := range args {
+			taskID, err := uuid.Parse(arg)
+			if err != nil {
+				return fmt.Errorf("invalid task ID %q: %w", arg, err)
+			}
+			taskIDs = append(taskIDs, taskID)
+		}
+
+		result, err := app.BulkTagTasksHandler.Handle(cmd.Context(), commands.BulkTagTasksCommand{
+			UserID:  app.CurrentUserID,
+			TaskIDs: taskIDs,
+			Add:     tagAdd,
+			Remove:  tagRemove,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to tag tasks: %w", err)
+		}
+
+		updated := 0
+		for _, item := range result.Results {
+			if item.Err != nil {
+				fmt.Printf("  %s  failed: %v\n", item.TaskID, item.Err)
+				continue
+			}
+			if len(item.Added) == 0 && len(item.Removed) == 0 {
+				fmt.Printf("  %s  unchanged [%s]\n", item.TaskID, strings.Join(item.Tags, ", "))
+				continue
+			}
+			updated++
+			fmt.Printf("  %s  %s [%s]\n", item.TaskID, describeTagChanges(item.Added, item.Removed), strings.Join(item.Tags, ", "))
+		}
+		fmt.Printf("\nUpdated %d of %d tasks.\n", updated, len(result.Results))
+		return nil
+	},
+}
+
+var tagAddCmd = &cobra.Command{
+	Use:   "add [task-id] [tag...]",
+	Short: "Add tags to a task",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeTaskTags(cmd, args[0], args[1:], nil)
+	},
+}
+
+var tagRemoveCmd = &cobra.Command{
+	Use:     "remove [task-id] [tag...]",
+	Short:   "Remove tags from a task",
+	Aliases: []string{"rm"},
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeTaskTags(cmd, args[0], nil, args[1:])
+	},
+}
+
+// changeTaskTags adds and removes tags on a single task.
+func changeTaskTags(cmd *cobra.Command, taskArg string, add, remove []string) error {
+	app := cli.GetApp()
+	if app == nil || app.BulkTagTasksHandler == nil {
+		return fmt.Errorf("application not initialized - database connection required")
+	}
+
+	taskID, err := uuid.Parse(taskArg)
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	result, err := app.BulkTagTasksHandler.Handle(cmd.Context(), commands.BulkTagTasksCommand{
+		UserID:  app.CurrentUserID,
+		TaskIDs: []uuid.UUID{taskID},
+		Add:     add,
+		Remove:  remove,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag task: %w", err)
+	}
+
+	item := result.Results[0]
+	if item.Err != nil {
+		return fmt.Errorf("failed to tag task: %w", item.Err)
+	}
+	if len(item.Added) == 0 && len(item.Removed) == 0 {
+		fmt.Printf("Tags unchanged [%s]\n", strings.Join(item.Tags, ", "))
+		return nil
+	}
+	fmt.Printf("Tags updated: %s [%s]\n", describeTagChanges(item.Added, item.Removed), strings.Join(item.Tags, ", "))
+	return nil
+}
+
+// describeTagChanges renders added and removed tags as "+work -old".
+func describeTagChanges(added, removed []string) string {
+	parts := make([]string, 0, len(added)+len(removed))
+	for _, tag := range added {
+		parts = append(parts, "+"+tag)
+	}
+	for _, tag := range removed {
+		parts = append(parts, "-"+tag)
+	}
+	return strings.Join(parts, " ")
+}
+
+func init() {
+	tagCmd.Flags().StringSliceVar(&tagAdd, "add", nil, "Tags to add (repeat or separate with commas)")
+	tagCmd.Flags().StringSliceVar(&tagRemove, "remove", nil, "Tags to remove (repeat or separate with commas)")
+	tagCmd.AddCommand(tagAddCmd)
+	tagCmd.AddCommand(tagRemoveCmd)
+}
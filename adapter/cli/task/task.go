@@ -19,4 +19,10 @@ func init() {
 	Cmd.AddCommand(updateCmd)
 	Cmd.AddCommand(completeCmd)
 	Cmd.AddCommand(archiveCmd)
+	Cmd.AddCommand(blockCmd)
+	Cmd.AddCommand(unblockCmd)
+	Cmd.AddCommand(waitCmd)
+	Cmd.AddCommand(resolveCmd)
+	Cmd.AddCommand(checklistCmd)
+	Cmd.AddCommand(tagCmd)
 }
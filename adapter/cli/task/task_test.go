@@ -6,10 +6,13 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	internalApp "github.com/felixgeelhaar/orbita/internal/app"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/pkg/config"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -43,7 +46,7 @@ func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 		Level: slog.LevelError, // Only log errors in tests
 	}))
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	container, err := internalApp.NewLocalContainer(ctx, cfg, logger)
 	require.NoError(t, err)
 
@@ -79,6 +82,9 @@ func setupLocalModeTestApp(t *testing.T) (*cli.App, func()) {
 		container.BillingService,
 	)
 	cliApp.SetCurrentUserID(testUserID)
+	cliApp.SetChecklistHandlers(container.AddChecklistItemHandler, container.ToggleChecklistItemHandler)
+	cliApp.SetBlockTaskHandlers(container.BlockTaskHandler, container.UnblockTaskHandler)
+	cliApp.SetWaitingTaskHandlers(container.WaitOnTaskHandler, container.ResolveWaitingTaskHandler)
 
 	cleanup := func() {
 		container.Close()
@@ -95,7 +101,7 @@ func TestCreateCmd_CreatesTask(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags before test
 	priority = "high"
@@ -128,7 +134,7 @@ func TestCreateCmd_WithDueDate(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	priority = "medium"
@@ -156,6 +162,133 @@ func TestCreateCmd_WithDueDate(t *testing.T) {
 	assert.Equal(t, 15, tasks[0].DueDate.Day())
 }
 
+func TestCreateCmd_WithTimezone(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	// Reset flags
+	priority = ""
+	duration = 0
+	description = ""
+	dueDate = "2026-02-15"
+	timezone = "Asia/Tokyo"
+	defer func() { timezone = "" }()
+
+	createCmd.SetContext(ctx)
+
+	err := createCmd.RunE(createCmd, []string{"Send Tokyo invoice"})
+	require.NoError(t, err)
+
+	tasks, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{
+		UserID:     app.CurrentUserID,
+		IncludeAll: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", tasks[0].Timezone)
+	require.NotNil(t, tasks[0].DueDate)
+	assert.Equal(t, time.Date(2026, 2, 15, 0, 0, 0, 0, tokyo), tasks[0].DueDate.In(tokyo))
+
+	timezone = "Mars/Olympus"
+	err = createCmd.RunE(createCmd, []string{"Unknown zone"})
+	assert.Error(t, err)
+}
+
+func TestCreateCmd_WithEarliestStart(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	// Reset flags
+	priority = ""
+	duration = 0
+	description = ""
+	dueDate = "2026-02-15"
+	startAfter = "2026-02-13T14:00"
+	defer func() { startAfter = "" }()
+
+	createCmd.SetContext(ctx)
+
+	err := createCmd.RunE(createCmd, []string{"Publish release notes"})
+	require.NoError(t, err)
+
+	tasks, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{
+		UserID:     app.CurrentUserID,
+		IncludeAll: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.NotNil(t, tasks[0].EarliestStart)
+	assert.True(t, time.Date(2026, 2, 13, 14, 0, 0, 0, time.UTC).Equal(*tasks[0].EarliestStart))
+
+	// The earliest start cannot come after the due date.
+	startAfter = "2026-02-16"
+	err = createCmd.RunE(createCmd, []string{"Impossible window"})
+	assert.Error(t, err)
+}
+
+func TestCreateCmd_WithRepeat(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	// Reset flags
+	priority = ""
+	duration = 0
+	description = ""
+	dueDate = "2026-03-01"
+	repeat = "monthly"
+	defer func() { dueDate, repeat = "", "" }()
+
+	createCmd.SetContext(ctx)
+
+	err := createCmd.RunE(createCmd, []string{"Pay rent"})
+	require.NoError(t, err)
+
+	tasks, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{
+		UserID:     app.CurrentUserID,
+		IncludeAll: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "monthly", tasks[0].Recurrence)
+
+	// Completing the task creates next month's occurrence.
+	err = app.CompleteTaskHandler.Handle(ctx, commands.CompleteTaskCommand{TaskID: tasks[0].ID, UserID: app.CurrentUserID})
+	require.NoError(t, err)
+
+	pending, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{
+		UserID: app.CurrentUserID,
+		Status: "pending",
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.NotEqual(t, tasks[0].ID, pending[0].ID)
+	assert.Equal(t, "monthly", pending[0].Recurrence)
+	require.NotNil(t, pending[0].DueDate)
+	assert.True(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC).Equal(*pending[0].DueDate))
+
+	repeat = "yearly"
+	err = createCmd.RunE(createCmd, []string{"Renew passport"})
+	assert.Error(t, err)
+}
+
 func TestCreateCmd_InvalidDueDate(t *testing.T) {
 	app, cleanup := setupLocalModeTestApp(t)
 	defer cleanup()
@@ -163,7 +296,7 @@ func TestCreateCmd_InvalidDueDate(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Reset flags
 	priority = ""
@@ -185,7 +318,7 @@ func TestListCmd_ShowsTasks(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create some tasks first
 	priority = "high"
@@ -219,7 +352,7 @@ func TestListCmd_EmptyList(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Verify empty list
 	tasks, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{
@@ -254,7 +387,7 @@ func TestCompleteCmd_CompletesTask(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create a task first
 	priority = ""
@@ -288,6 +421,115 @@ func TestCompleteCmd_CompletesTask(t *testing.T) {
 	assert.Equal(t, "completed", tasks[0].Status)
 }
 
+func TestChecklistCmds_AddAndToggle(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	priority = ""
+	duration = 0
+	description = ""
+	dueDate = ""
+	createCmd.SetContext(ctx)
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Release"}))
+
+	listTasks := func() []queries.TaskDTO {
+		tasks, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{
+			UserID:     app.CurrentUserID,
+			IncludeAll: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		return tasks
+	}
+	taskID := listTasks()[0].ID.String()
+
+	checklistAddCmd.SetContext(ctx)
+	require.NoError(t, checklistAddCmd.RunE(checklistAddCmd, []string{taskID, "Tag version"}))
+	require.NoError(t, checklistAddCmd.RunE(checklistAddCmd, []string{taskID, "Publish notes"}))
+
+	checklist := listTasks()[0].Checklist
+	require.Len(t, checklist, 2)
+	assert.Equal(t, "Tag version", checklist[0].Title)
+
+	checklistToggleCmd.SetContext(ctx)
+	require.NoError(t, checklistToggleCmd.RunE(checklistToggleCmd, []string{taskID, checklist[1].ID.String()}))
+
+	tasks := listTasks()
+	assert.Equal(t, 1, tasks[0].ChecklistDone)
+	assert.False(t, tasks[0].Checklist[0].Done)
+	assert.True(t, tasks[0].Checklist[1].Done)
+
+	err := checklistToggleCmd.RunE(checklistToggleCmd, []string{taskID, "not-a-uuid"})
+	assert.ErrorContains(t, err, "invalid checklist item ID")
+}
+
+func TestBlockCmds_BlockFilterAndUnblock(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	priority = ""
+	duration = 0
+	description = ""
+	dueDate = ""
+	createCmd.SetContext(ctx)
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Deploy API"}))
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Write docs"}))
+
+	all, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID, IncludeAll: true})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	var taskID string
+	for _, tk := range all {
+		if tk.Title == "Deploy API" {
+			taskID = tk.ID.String()
+		}
+	}
+
+	blockReason = "https://github.com/acme/api/pull/42"
+	defer func() { blockReason = "" }()
+	blockCmd.SetContext(ctx)
+	require.NoError(t, blockCmd.RunE(blockCmd, []string{taskID}))
+
+	blocked, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID, Status: "blocked"})
+	require.NoError(t, err)
+	require.Len(t, blocked, 1)
+	assert.Equal(t, "Deploy API", blocked[0].Title)
+	assert.Equal(t, "blocked", blocked[0].Status)
+	assert.Equal(t, "https://github.com/acme/api/pull/42", blocked[0].BlockedReason)
+	assert.NotNil(t, blocked[0].BlockedAt)
+
+	pending, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "Write docs", pending[0].Title)
+
+	unblockNote = "PR merged"
+	defer func() { unblockNote = "" }()
+	unblockCmd.SetContext(ctx)
+	require.NoError(t, unblockCmd.RunE(unblockCmd, []string{taskID}))
+
+	blocked, err = app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID, Status: "blocked"})
+	require.NoError(t, err)
+	assert.Empty(t, blocked)
+
+	pending, err = app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID})
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	err = unblockCmd.RunE(unblockCmd, []string{taskID})
+	assert.ErrorContains(t, err, "task is not blocked")
+}
+
 func TestCompleteCmd_InvalidTaskID(t *testing.T) {
 	app, cleanup := setupLocalModeTestApp(t)
 	defer cleanup()
@@ -295,7 +537,7 @@ func TestCompleteCmd_InvalidTaskID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	completeCmd.SetContext(ctx)
 	err := completeCmd.RunE(completeCmd, []string{"not-a-uuid"})
@@ -310,7 +552,7 @@ func TestArchiveCmd_ArchivesTask(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	// Create a task first
 	priority = ""
@@ -351,7 +593,7 @@ func TestArchiveCmd_InvalidTaskID(t *testing.T) {
 	cli.SetApp(app)
 	defer cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 
 	archiveCmd.SetContext(ctx)
 	err := archiveCmd.RunE(archiveCmd, []string{"invalid-uuid"})
@@ -362,7 +604,7 @@ func TestArchiveCmd_InvalidTaskID(t *testing.T) {
 func TestCreateCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	priority = ""
 	createCmd.SetContext(ctx)
 
@@ -374,7 +616,7 @@ func TestCreateCmd_NoApp(t *testing.T) {
 func TestListCmd_NoApp(t *testing.T) {
 	cli.SetApp(nil)
 
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
 	listCmd.SetContext(ctx)
 
 	err := listCmd.RunE(listCmd, []string{})
@@ -422,3 +664,96 @@ func TestGetPriorityBadge(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReminderOffset(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"1d", 24 * time.Hour, false},
+		{"2h", 2 * time.Hour, false},
+		{"30m", 30 * time.Minute, false},
+		{" 1h30m ", 90 * time.Minute, false},
+		{"0d", 0, true},
+		{"10s", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			result, err := parseReminderOffset(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestWaitCmds_WaitFilterAndResolve(t *testing.T) {
+	app, cleanup := setupLocalModeTestApp(t)
+	defer cleanup()
+
+	cli.SetApp(app)
+	defer cli.SetApp(nil)
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), testUserID)
+
+	priority = ""
+	duration = 0
+	description = ""
+	dueDate = ""
+	createCmd.SetContext(ctx)
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Contract review"}))
+	require.NoError(t, createCmd.RunE(createCmd, []string{"Write docs"}))
+
+	all, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID, IncludeAll: true})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	var taskID string
+	for _, tk := range all {
+		if tk.Title == "Contract review" {
+			taskID = tk.ID.String()
+		}
+	}
+
+	waitOn = "Alice (legal)"
+	waitFollowUp = 48 * time.Hour
+	defer func() { waitOn, waitFollowUp = "", 0 }()
+	waitCmd.SetContext(ctx)
+	require.NoError(t, waitCmd.RunE(waitCmd, []string{taskID}))
+
+	waiting, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID, Status: "waiting"})
+	require.NoError(t, err)
+	require.Len(t, waiting, 1)
+	assert.Equal(t, "Contract review", waiting[0].Title)
+	assert.Equal(t, "waiting", waiting[0].Status)
+	assert.Equal(t, "Alice (legal)", waiting[0].WaitingOn)
+	require.NotNil(t, waiting[0].WaitingSince)
+	require.NotNil(t, waiting[0].FollowUpAt)
+	assert.WithinDuration(t, waiting[0].WaitingSince.Add(48*time.Hour), *waiting[0].FollowUpAt, time.Second)
+
+	pending, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "Write docs", pending[0].Title)
+
+	resolveNote = "Signed copy received"
+	defer func() { resolveNote = "" }()
+	resolveCmd.SetContext(ctx)
+	require.NoError(t, resolveCmd.RunE(resolveCmd, []string{taskID}))
+
+	waiting, err = app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID, Status: "waiting"})
+	require.NoError(t, err)
+	assert.Empty(t, waiting)
+
+	pending, err = app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: app.CurrentUserID})
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	err = resolveCmd.RunE(resolveCmd, []string{taskID})
+	assert.ErrorContains(t, err, "task is not waiting on anyone")
+}
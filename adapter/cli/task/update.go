@@ -17,6 +17,9 @@ var (
 	updateDuration    int
 	updateDue         string
 	clearDue          bool
+	updateStartAfter  string
+	clearStartAfter   bool
+	requireChecklist  bool
 )
 
 var updateCmd = &cobra.Command{
@@ -28,7 +31,9 @@ Examples:
   orbita task update abc123 --title "New title"
   orbita task update abc123 --priority high
   orbita task update abc123 --duration 60 --due 2024-12-31
-  orbita task update abc123 --clear-due`,
+  orbita task update abc123 --clear-due
+  orbita task update abc123 --earliest-start 2024-12-20T13:00
+  orbita task update abc123 --require-checklist`,
 	Aliases: []string{"edit", "modify"},
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -50,6 +55,8 @@ Examples:
 			TaskID:       taskID,
 			UserID:       app.CurrentUserID,
 			ClearDueDate: clearDue,
+
+			ClearEarliestStart: clearStartAfter,
 		}
 
 		// Check if any flags were provided
@@ -92,8 +99,26 @@ Examples:
 			flagsProvided = true
 		}
 
+		if cmd.Flags().Changed("earliest-start") {
+			earliestStart, err := parseEarliestStart(updateStartAfter, time.UTC)
+			if err != nil {
+				return err
+			}
+			updateTaskCmd.EarliestStart = &earliestStart
+			flagsProvided = true
+		}
+
+		if clearStartAfter {
+			flagsProvided = true
+		}
+
+		if cmd.Flags().Changed("require-checklist") {
+			updateTaskCmd.RequireChecklist = &requireChecklist
+			flagsProvided = true
+		}
+
 		if !flagsProvided {
-			return fmt.Errorf("no updates provided - use flags like --title, --priority, --duration, --due, or --clear-due")
+			return fmt.Errorf("no updates provided - use flags like --title, --priority, --duration, --due, --clear-due, --earliest-start, or --require-checklist")
 		}
 
 		// Execute command
@@ -114,4 +139,7 @@ func init() {
 	updateCmd.Flags().IntVarP(&updateDuration, "duration", "d", 0, "New estimated duration in minutes")
 	updateCmd.Flags().StringVar(&updateDue, "due", "", "New due date (YYYY-MM-DD or YYYY-MM-DDTHH:MM)")
 	updateCmd.Flags().BoolVar(&clearDue, "clear-due", false, "Clear the due date")
+	updateCmd.Flags().StringVar(&updateStartAfter, "earliest-start", "", "Don't schedule before this time (YYYY-MM-DD or YYYY-MM-DDTHH:MM)")
+	updateCmd.Flags().BoolVar(&clearStartAfter, "clear-earliest-start", false, "Clear the earliest start")
+	updateCmd.Flags().BoolVar(&requireChecklist, "require-checklist", false, "Require all checklist items to be done before completing (use =false to lift)")
 }
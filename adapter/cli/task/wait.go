@@ -0,0 +1,101 @@
+package task
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	waitOn       string
+	waitFollowUp time.Duration
+	resolveNote  string
+)
+
+var waitCmd = &cobra.Command{
+	Use:   "wait [task-id]",
+	Short: "Mark a task as waiting on someone",
+	Long: `Mark delegated work as waiting on a person. If the task is still
+waiting after the follow-up delay, a reminder to follow up is sent.
+
+The delay defaults to TASK_FOLLOW_UP_DELAY (3 days unless configured).
+Waiting tasks are left out of the default list and auto-scheduling until
+they are resolved. Waiting again replaces the person and restarts the delay.
+
+Examples:
+  orbita task wait abc123 --on "Alice"
+  orbita task wait abc123 --on "alice@example.com" --follow-up 48h`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.WaitOnTaskHandler == nil {
+			return fmt.Errorf("application not initialized - database connection required")
+		}
+
+		taskID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid task ID: %w", err)
+		}
+
+		if err := app.WaitOnTaskHandler.Handle(cmd.Context(), commands.WaitOnTaskCommand{
+			TaskID:    taskID,
+			UserID:    app.CurrentUserID,
+			WaitingOn: waitOn,
+			FollowUp:  waitFollowUp,
+		}); err != nil {
+			return fmt.Errorf("failed to mark task as waiting: %w", err)
+		}
+
+		fmt.Printf("Task waiting: %s\n", taskID)
+		fmt.Printf("  Waiting on: %s\n", waitOn)
+		return nil
+	},
+}
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve [task-id]",
+	Short: "Stop a task waiting on someone",
+	Long: `Return a waiting task to pending, cancelling its follow-up reminder,
+optionally noting how the wait was resolved.
+
+Examples:
+  orbita task resolve abc123
+  orbita task resolve abc123 --note "Alice sent the signed contract"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := cli.GetApp()
+		if app == nil || app.ResolveWaitingTaskHandler == nil {
+			return fmt.Errorf("application not initialized - database connection required")
+		}
+
+		taskID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid task ID: %w", err)
+		}
+
+		if err := app.ResolveWaitingTaskHandler.Handle(cmd.Context(), commands.ResolveWaitingTaskCommand{
+			TaskID: taskID,
+			UserID: app.CurrentUserID,
+			Note:   resolveNote,
+		}); err != nil {
+			return fmt.Errorf("failed to resolve waiting task: %w", err)
+		}
+
+		fmt.Printf("Task no longer waiting: %s\n", taskID)
+		if resolveNote != "" {
+			fmt.Printf("  Note: %s\n", resolveNote)
+		}
+		return nil
+	},
+}
+
+func init() {
+	waitCmd.Flags().StringVarP(&waitOn, "on", "o", "", "person or contact the task is waiting on")
+	waitCmd.Flags().DurationVar(&waitFollowUp, "follow-up", 0, "remind to follow up after this long (e.g. 48h; default from TASK_FOLLOW_UP_DELAY)")
+	_ = waitCmd.MarkFlagRequired("on")
+	resolveCmd.Flags().StringVarP(&resolveNote, "note", "m", "", "how the wait was resolved")
+}
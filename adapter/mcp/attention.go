@@ -0,0 +1,157 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/google/uuid"
+)
+
+// defaultFocusLimit is how many items the today dashboard's focus list shows
+// when no limit is given.
+const defaultFocusLimit = 10
+
+// attentionItem is a task or habit on the today dashboard, scored by how
+// urgently it needs attention.
+type attentionItem struct {
+	Type    string     `json:"type"` // "task" or "habit"
+	ID      uuid.UUID  `json:"id"`
+	Title   string     `json:"title"`
+	Score   int        `json:"score"`
+	Reasons []string   `json:"reasons"`
+	DueDate *time.Time `json:"due_date,omitempty"`
+}
+
+// Attention score weights. Overdue work outranks anything due later, and
+// urgency adds to how close the deadline is.
+var (
+	priorityScores = map[string]int{"urgent": 40, "high": 25, "medium": 10}
+
+	scoreOverdue       = 60
+	scoreOverduePerDay = 2
+	maxOverdueDays     = 10
+	scoreDueToday      = 40
+	scoreDueTomorrow   = 25
+	scoreDueThisWeek   = 10
+
+	scoreHabitDue      = 15
+	scoreStreakBroken  = 20
+	scoreStreakAtRisk  = 10
+	minStreakAtRiskLen = 3
+)
+
+// rankAttention scores pending tasks and due habits and returns the limit
+// most pressing, highest score first. Ties go to the earlier due date, then
+// the title. A limit of zero or less uses defaultFocusLimit.
+func rankAttention(tasks []queries.TaskDTO, habits []habitQueries.HabitDTO, now time.Time, limit int) []attentionItem {
+	if limit <= 0 {
+		limit = defaultFocusLimit
+	}
+
+	items := make([]attentionItem, 0, len(tasks)+len(habits))
+	for _, task := range tasks {
+		if task.Status == "completed" || task.Status == "archived" {
+			continue
+		}
+		items = append(items, scoreTask(task, now))
+	}
+	for _, habit := range habits {
+		if habit.CompletedToday || habit.IsArchived {
+			continue
+		}
+		items = append(items, scoreHabit(habit))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if (a.DueDate == nil) != (b.DueDate == nil) {
+			return a.DueDate != nil
+		}
+		if a.DueDate != nil && !a.DueDate.Equal(*b.DueDate) {
+			return a.DueDate.Before(*b.DueDate)
+		}
+		return a.Title < b.Title
+	})
+
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+func scoreTask(task queries.TaskDTO, now time.Time) attentionItem {
+	item := attentionItem{
+		Type:    "task",
+		ID:      task.ID,
+		Title:   task.Title,
+		DueDate: task.DueDate,
+		Reasons: []string{},
+	}
+
+	if score, ok := priorityScores[task.Priority]; ok {
+		item.Score += score
+		item.Reasons = append(item.Reasons, task.Priority+" priority")
+	}
+
+	if task.DueDate != nil {
+		days := daysUntilDue(*task.DueDate, task.Timezone, now)
+		switch {
+		case days < 0:
+			overdue := min(-days, maxOverdueDays)
+			item.Score += scoreOverdue + overdue*scoreOverduePerDay
+			item.Reasons = append(item.Reasons, fmt.Sprintf("overdue by %d day(s)", -days))
+		case days == 0:
+			item.Score += scoreDueToday
+			item.Reasons = append(item.Reasons, "due today")
+		case days == 1:
+			item.Score += scoreDueTomorrow
+			item.Reasons = append(item.Reasons, "due tomorrow")
+		case days <= 7:
+			item.Score += scoreDueThisWeek
+			item.Reasons = append(item.Reasons, fmt.Sprintf("due in %d days", days))
+		}
+	}
+
+	return item
+}
+
+func scoreHabit(habit habitQueries.HabitDTO) attentionItem {
+	item := attentionItem{
+		Type:    "habit",
+		ID:      habit.ID,
+		Title:   habit.Name,
+		Score:   scoreHabitDue,
+		Reasons: []string{"habit due today"},
+	}
+
+	switch {
+	case habit.Streak == 0 && habit.BestStreak > 0:
+		item.Score += scoreStreakBroken
+		item.Reasons = append(item.Reasons, fmt.Sprintf("streak broken (best %d)", habit.BestStreak))
+	case habit.Streak >= minStreakAtRiskLen:
+		item.Score += scoreStreakAtRisk
+		item.Reasons = append(item.Reasons, fmt.Sprintf("keep %d-day streak", habit.Streak))
+	}
+
+	return item
+}
+
+// daysUntilDue counts calendar days from now until the due date, read in the
+// task's own time zone when it has one. It is negative for overdue tasks.
+func daysUntilDue(due time.Time, timezone string, now time.Time) int {
+	loc := now.Location()
+	if tz, err := sharedDomain.LoadTimezone(timezone); err == nil && tz != nil {
+		loc = tz
+	}
+	due, now = due.In(loc), now.In(loc)
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return int(dueDay.Sub(today).Hours() / 24)
+}
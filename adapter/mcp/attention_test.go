@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func attentionTitles(items []attentionItem) []string {
+	titles := make([]string, len(items))
+	for i, item := range items {
+		titles[i] = item.Title
+	}
+	return titles
+}
+
+func TestRankAttention(t *testing.T) {
+	now := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	days := func(n int) *time.Time {
+		due := now.AddDate(0, 0, n)
+		return &due
+	}
+	task := func(title, priority string, due *time.Time) queries.TaskDTO {
+		return queries.TaskDTO{ID: uuid.New(), Title: title, Status: "pending", Priority: priority, DueDate: due}
+	}
+
+	t.Run("overdue and urgent items rank above distant ones", func(t *testing.T) {
+		tasks := []queries.TaskDTO{
+			task("next month", "medium", days(30)),
+			task("someday", "low", nil),
+			task("urgent no date", "urgent", nil),
+			task("overdue", "low", days(-2)),
+			task("due today", "medium", days(0)),
+			task("next week", "high", days(6)),
+		}
+
+		items := rankAttention(tasks, nil, now, 0)
+
+		assert.Equal(t, []string{
+			"overdue",
+			"due today",
+			"urgent no date",
+			"next week",
+			"next month",
+			"someday",
+		}, attentionTitles(items))
+		assert.Contains(t, items[0].Reasons, "overdue by 2 day(s)")
+	})
+
+	t.Run("overdue urgent task outranks overdue low priority task", func(t *testing.T) {
+		tasks := []queries.TaskDTO{
+			task("overdue low", "low", days(-1)),
+			task("overdue urgent", "urgent", days(-1)),
+		}
+
+		items := rankAttention(tasks, nil, now, 0)
+
+		assert.Equal(t, []string{"overdue urgent", "overdue low"}, attentionTitles(items))
+	})
+
+	t.Run("broken streak ranks above a habit without a streak", func(t *testing.T) {
+		habits := []habitQueries.HabitDTO{
+			{ID: uuid.New(), Name: "stretch", IsDueToday: true},
+			{ID: uuid.New(), Name: "read", IsDueToday: true, BestStreak: 12},
+			{ID: uuid.New(), Name: "done already", IsDueToday: true, CompletedToday: true},
+		}
+
+		items := rankAttention(nil, habits, now, 0)
+
+		require.Len(t, items, 2)
+		assert.Equal(t, []string{"read", "stretch"}, attentionTitles(items))
+		assert.Equal(t, "habit", items[0].Type)
+		assert.Contains(t, items[0].Reasons, "streak broken (best 12)")
+	})
+
+	t.Run("applies the limit after ranking", func(t *testing.T) {
+		tasks := []queries.TaskDTO{
+			task("later", "low", days(20)),
+			task("overdue", "low", days(-3)),
+			task("tomorrow", "low", days(1)),
+		}
+
+		items := rankAttention(tasks, nil, now, 2)
+
+		assert.Equal(t, []string{"overdue", "tomorrow"}, attentionTitles(items))
+	})
+
+	t.Run("skips completed tasks", func(t *testing.T) {
+		done := task("done", "urgent", days(-1))
+		done.Status = "completed"
+
+		items := rankAttention([]queries.TaskDTO{done}, nil, now, 0)
+
+		assert.Empty(t, items)
+	})
+}
+
+func TestDaysUntilDue_UsesTaskTimezone(t *testing.T) {
+	now := time.Date(2024, 3, 15, 23, 0, 0, 0, time.UTC)
+	// 01:00 UTC on the 16th is still the 15th in New York.
+	due := time.Date(2024, 3, 16, 1, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 1, daysUntilDue(due, "", now))
+	assert.Equal(t, 0, daysUntilDue(due, "America/New_York", now))
+}
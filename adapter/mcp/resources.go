@@ -10,6 +10,7 @@ import (
 	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
 	productivityQueries "github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
 	schedulingQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 )
 
 // RegisterResources registers MCP resources that expose Orbita data.
@@ -326,7 +327,7 @@ func registerScheduleResources(srv *mcp.Server, deps ToolDependencies) error {
 			}
 
 			now := time.Now()
-			startOfWeek := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+			startOfWeek := sharedDomain.StartOfWeek(now, weekStartsOn(app))
 
 			// Collect schedules for the week
 			weekSchedules := make([]*schedulingQueries.ScheduleDTO, 0, 7)
@@ -1,11 +1,14 @@
 package mcp
 
 import (
+	"context"
 	"errors"
 
 	"github.com/felixgeelhaar/mcp-go"
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	identityOAuth "github.com/felixgeelhaar/orbita/internal/identity/application/oauth"
+	inboxCommands "github.com/felixgeelhaar/orbita/internal/inbox/application/commands"
+	inboxQueries "github.com/felixgeelhaar/orbita/internal/inbox/application/queries"
 	"github.com/felixgeelhaar/orbita/internal/orbit/registry"
 	"github.com/felixgeelhaar/orbita/internal/orbit/runtime"
 	"github.com/google/uuid"
@@ -21,6 +24,20 @@ type ToolDependencies struct {
 	OrbitSandbox  *runtime.Sandbox
 	OrbitExecutor *runtime.Executor
 	DefaultUserID uuid.UUID // Default user ID for orbit tool execution
+
+	// Inbox handlers for cli.capture and cli.inbox (optional)
+	InboxCapturer InboxCapturer
+	InboxLister   InboxLister
+}
+
+// InboxCapturer captures items into the inbox.
+type InboxCapturer interface {
+	Handle(ctx context.Context, cmd inboxCommands.CaptureInboxItemCommand) (*inboxCommands.CaptureInboxItemResult, error)
+}
+
+// InboxLister lists a user's inbox items.
+type InboxLister interface {
+	Handle(ctx context.Context, query inboxQueries.ListInboxItemsQuery) ([]inboxQueries.InboxItemDTO, error)
 }
 
 // RegisterCLITools registers MCP tools that mirror CLI functionality.
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/felixgeelhaar/mcp-go"
 	"github.com/felixgeelhaar/orbita/adapter/cli"
@@ -16,18 +17,30 @@ import (
 	googleCalendar "github.com/felixgeelhaar/orbita/internal/calendar/infrastructure/google"
 	habitCommands "github.com/felixgeelhaar/orbita/internal/habits/application/commands"
 	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
+	inboxCommands "github.com/felixgeelhaar/orbita/internal/inbox/application/commands"
+	inboxQueries "github.com/felixgeelhaar/orbita/internal/inbox/application/queries"
+	inboxDomain "github.com/felixgeelhaar/orbita/internal/inbox/domain"
 	meetingCommands "github.com/felixgeelhaar/orbita/internal/meetings/application/commands"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	projectCommands "github.com/felixgeelhaar/orbita/internal/projects/application/commands"
+	projectQueries "github.com/felixgeelhaar/orbita/internal/projects/application/queries"
 	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/google/uuid"
 )
 
 type addInput struct {
 	Description string `json:"description" jsonschema:"required"`
+	// Repeat makes the task recur: daily, weekly, monthly or e.g. "every 2 weeks".
+	Repeat string `json:"repeat,omitempty"`
 }
 
 type doneInput struct {
 	Prefix string `json:"prefix,omitempty"`
+	// Match is any (default), id or title.
+	Match string `json:"match,omitempty"`
 }
 
 type statsInput struct {
@@ -36,7 +49,7 @@ type statsInput struct {
 
 type planInput struct {
 	Date    string `json:"date,omitempty"`
-	Auto    bool   `json:"auto,omitempty"`
+	Auto    *bool  `json:"auto,omitempty"`
 	Preview bool   `json:"preview,omitempty"`
 }
 
@@ -46,18 +59,25 @@ type focusInput struct {
 	TaskID          string `json:"task_id,omitempty"`
 }
 
+type todayInput struct {
+	// Limit caps the ranked focus list (default 10).
+	Limit int `json:"limit,omitempty"`
+}
+
 type exportInput struct {
 	Format string `json:"format,omitempty"`
 	Days   int    `json:"days,omitempty"`
 }
 
 type syncInput struct {
-	Days              int      `json:"days,omitempty"`
-	DeleteMissing     bool     `json:"delete_missing,omitempty"`
-	CalendarID        string   `json:"calendar_id,omitempty"`
-	UseConfigCalendar bool     `json:"use_config_calendar,omitempty"`
-	Attendees         []string `json:"attendees,omitempty"`
-	Reminders         []int    `json:"reminders,omitempty"`
+	Days              int              `json:"days,omitempty"`
+	DeleteMissing     bool             `json:"delete_missing,omitempty"`
+	CalendarID        string           `json:"calendar_id,omitempty"`
+	UseConfigCalendar bool             `json:"use_config_calendar,omitempty"`
+	Attendees         []string         `json:"attendees,omitempty"`
+	Reminders         []int            `json:"reminders,omitempty"`
+	TypeReminders     map[string][]int `json:"type_reminders,omitempty"`
+	ConfirmMassDelete bool             `json:"confirm_mass_delete,omitempty"`
 }
 
 type adaptInput struct {
@@ -66,6 +86,17 @@ type adaptInput struct {
 	WindowDays int  `json:"window_days,omitempty"`
 }
 
+type captureInput struct {
+	Content string   `json:"content" jsonschema:"required"`
+	Tags    []string `json:"tags,omitempty"`
+	Source  string   `json:"source,omitempty"`
+}
+
+type inboxInput struct {
+	Limit  int    `json:"limit,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
 func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 	app := deps.App
 
@@ -89,7 +120,7 @@ func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 		})
 
 	srv.Tool("cli.add").
-		Description("Quick add a task with natural language").
+		Description("Quick add a task with natural language. A +Project token links the task to that project, creating it if needed; an @context token and #tag tokens tag the task; repeat makes it recur").
 		Handler(func(ctx context.Context, input addInput) (map[string]any, error) {
 			if app == nil || app.CreateTaskHandler == nil {
 				return nil, errors.New("quick add requires database connection")
@@ -98,18 +129,36 @@ func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, errors.New("description is required")
 			}
 
-			parsed := parseNaturalLanguage(input.Description)
+			parsed := parseNaturalLanguage(input.Description, weekStartsOn(app))
 			durationMins := 0
 			if parsed.duration > 0 {
 				durationMins = int(parsed.duration.Minutes())
 			}
 
+			var projectID uuid.UUID
+			projectCreated := false
+			if parsed.project != "" {
+				if app.ListProjectsHandler == nil || app.CreateProjectHandler == nil || app.LinkTaskHandler == nil {
+					return nil, errors.New("linking to a project requires project support")
+				}
+				var err error
+				projectID, projectCreated, err = resolveProject(ctx, app, parsed.project)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if parsed.contextTag != "" && app.BulkTagTasksHandler == nil {
+				return nil, errors.New("tagging a context requires tag support")
+			}
+
 			cmd := commands.CreateTaskCommand{
 				UserID:          app.CurrentUserID,
 				Title:           parsed.title,
 				Priority:        parsed.priority,
 				DurationMinutes: durationMins,
 				DueDate:         parsed.dueDate,
+				Recurrence:      input.Repeat,
+				Tags:            parsed.tags,
 			}
 
 			result, err := app.CreateTaskHandler.Handle(ctx, cmd)
@@ -117,26 +166,61 @@ func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, err
 			}
 
-			return map[string]any{
+			out := map[string]any{
 				"task_id":  result.TaskID,
 				"title":    parsed.title,
 				"priority": parsed.priority,
 				"duration": durationMins,
 				"due_date": parsed.dueDate,
-			}, nil
+			}
+			if input.Repeat != "" {
+				out["repeat"] = input.Repeat
+			}
+			if len(parsed.tags) > 0 {
+				out["tags"] = parsed.tags
+			}
+
+			if parsed.project != "" {
+				if err := app.LinkTaskHandler.Handle(ctx, projectCommands.LinkTaskCommand{
+					ProjectID: projectID,
+					UserID:    app.CurrentUserID,
+					TaskID:    result.TaskID,
+				}); err != nil {
+					return nil, fmt.Errorf("task created but not linked to project: %w", err)
+				}
+				out["project_id"] = projectID
+				out["project"] = parsed.project
+				out["project_created"] = projectCreated
+			}
+			if parsed.contextTag != "" {
+				if _, err := app.BulkTagTasksHandler.Handle(ctx, commands.BulkTagTasksCommand{
+					UserID:  app.CurrentUserID,
+					TaskIDs: []uuid.UUID{result.TaskID},
+					Add:     []string{parsed.contextTag},
+				}); err != nil {
+					return nil, fmt.Errorf("task created but not tagged: %w", err)
+				}
+				out["context"] = parsed.contextTag
+			}
+
+			return out, nil
 		})
 
 	srv.Tool("cli.done").
-		Description("Mark a task or habit complete by ID prefix or list completable items").
-		Handler(func(ctx context.Context, input doneInput) (any, error) {
+		Description("Mark a task or habit complete by ID prefix or title, or list completable items. Ambiguous matches return ranked candidates").
+		Handler(withErrorMapping(func(ctx context.Context, input doneInput) (any, error) {
 			if app == nil {
 				return nil, errors.New("done requires database connection")
 			}
 			if strings.TrimSpace(input.Prefix) == "" {
 				return listCompletableItems(ctx, app)
 			}
-			return completeByPrefix(ctx, app, strings.ToLower(input.Prefix))
-		})
+			mode, err := cli.ParseMatchMode(input.Match)
+			if err != nil {
+				return nil, err
+			}
+			return completeByPrefix(ctx, app, input.Prefix, mode)
+		}))
 
 	srv.Tool("cli.stats").
 		Description("Show productivity statistics").
@@ -187,6 +271,12 @@ func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, errors.New("planning requires database connection")
 			}
 
+			defaults := app.PlanDefaults(ctx)
+			auto := defaults.AutoSchedule
+			if input.Auto != nil {
+				auto = *input.Auto
+			}
+
 			var targetDate time.Time
 			if input.Date != "" {
 				parsed, err := time.Parse(dateLayout, input.Date)
@@ -196,29 +286,40 @@ func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 				targetDate = parsed
 			} else {
 				now := time.Now()
-				targetDate = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+				targetDate = time.Date(now.Year(), now.Month(), now.Day()+defaults.DayOffset, 0, 0, 0, 0, now.Location())
 			}
 
 			schedule := fetchSchedule(ctx, app, targetDate)
-			slots := fetchAvailableSlots(ctx, app, targetDate)
+			slots := fetchAvailableSlots(ctx, app, targetDate, time.Duration(defaults.MinSlotMinutes)*time.Minute)
 			tasks := fetchSchedulableTasks(ctx, app)
 			habits := fetchSchedulableHabits(ctx, app)
+			capacity := fetchCapacity(ctx, app, targetDate)
+			planned := plannedMinutes(tasks, habits)
 
 			var autoResult any
-			if input.Auto && !input.Preview {
+			if auto && !input.Preview {
 				autoResult = runAutoSchedule(ctx, app, targetDate, tasks, habits, nil)
 			}
 
-			return map[string]any{
+			result := map[string]any{
 				"date":         targetDate,
 				"schedule":     schedule,
 				"slots":        slots,
 				"tasks":        tasks,
 				"habits":       habits,
 				"auto_result":  autoResult,
-				"auto_enabled": input.Auto,
+				"auto_enabled": auto,
 				"preview":      input.Preview,
-			}, nil
+			}
+			if capacity != nil {
+				result["capacity"] = capacity
+				result["planned_minutes"] = planned
+				result["overcommitted"] = planned > capacity.TotalFreeMins
+				if warning := overcommitWarning(capacity, planned); warning != "" {
+					result["warning"] = warning
+				}
+			}
+			return result, nil
 		})
 
 	srv.Tool("cli.focus").
@@ -275,22 +376,28 @@ func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 		})
 
 	srv.Tool("cli.today").
-		Description("Show today's dashboard data").
-		Handler(func(ctx context.Context, input struct{}) (map[string]any, error) {
+		Description("Show today's dashboard data, with the items needing attention ranked first").
+		Handler(func(ctx context.Context, input todayInput) (map[string]any, error) {
 			if app == nil {
 				return nil, errors.New("dashboard requires database connection")
 			}
 
 			today := time.Now()
 			schedule := fetchSchedule(ctx, app, today)
-			tasks := fetchPendingTasks(ctx, app, 5)
+			pending := fetchPendingTasks(ctx, app, 0)
 			habits := fetchDueHabits(ctx, app)
 
+			tasks := pending
+			if len(tasks) > 5 {
+				tasks = tasks[:5]
+			}
+
 			return map[string]any{
 				"date":     today,
 				"schedule": schedule,
 				"tasks":    tasks,
 				"habits":   habits,
+				"focus":    rankAttention(pending, habits, today, input.Limit),
 			}, nil
 		})
 
@@ -317,10 +424,16 @@ func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 				}, nil
 			}
 
+			var warnings []string
 			if input.UseConfigCalendar && app.SettingsService != nil {
 				if input.CalendarID == "" {
 					if storedID, err := app.SettingsService.GetCalendarID(ctx, app.CurrentUserID); err == nil && storedID != "" {
-						input.CalendarID = storedID
+						lister, _ := app.CalendarSyncer.(calendarApp.CalendarLister)
+						resolved := calendarApp.ResolveDefaultCalendar(ctx, lister, app.CurrentUserID, storedID)
+						if resolved.Warning != "" {
+							warnings = append(warnings, resolved.Warning)
+						}
+						input.CalendarID = resolved.CalendarID
 					}
 				}
 				if !input.DeleteMissing {
@@ -346,6 +459,10 @@ func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 				if len(input.Reminders) > 0 {
 					googleSyncer = googleSyncer.WithReminders(input.Reminders)
 				}
+				if len(input.TypeReminders) > 0 {
+					googleSyncer = googleSyncer.WithBlockTypeReminders(input.TypeReminders)
+				}
+				googleSyncer = googleSyncer.WithMassDeleteConfirmed(input.ConfirmMassDelete)
 				syncer = googleSyncer
 			}
 
@@ -354,12 +471,16 @@ func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, err
 			}
 
-			return map[string]any{
+			response := map[string]any{
 				"created": result.Created,
 				"updated": result.Updated,
 				"deleted": result.Deleted,
 				"failed":  result.Failed,
-			}, nil
+			}
+			if len(warnings) > 0 {
+				response["warnings"] = warnings
+			}
+			return response, nil
 		})
 
 	srv.Tool("cli.adapt").
@@ -412,6 +533,78 @@ func registerCoreTools(srv *mcp.Server, deps ToolDependencies) error {
 			return result, nil
 		})
 
+	capturer, lister := deps.InboxCapturer, deps.InboxLister
+
+	srv.Tool("cli.capture").
+		Description("Capture a thought into the inbox and get a suggested classification").
+		Handler(func(ctx context.Context, input captureInput) (map[string]any, error) {
+			if capturer == nil {
+				return nil, errors.New("inbox capture requires database connection")
+			}
+			if err := cli.RequireEntitlement(ctx, app, billingDomain.ModuleAIInbox); err != nil {
+				return nil, err
+			}
+			content := strings.TrimSpace(input.Content)
+			if content == "" {
+				return nil, errors.New("content is required")
+			}
+			result, err := capturer.Handle(ctx, inboxCommands.CaptureInboxItemCommand{
+				UserID:   app.CurrentUserID,
+				Content:  content,
+				Metadata: inboxDomain.InboxMetadata{},
+				Tags:     input.Tags,
+				Source:   inboxDomain.NormalizeSource(input.Source, inboxDomain.SourceMCP),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{
+				"item_id":    result.ItemID.String(),
+				"suggestion": result.Classification,
+			}, nil
+		})
+
+	srv.Tool("cli.inbox").
+		Description("List unprocessed inbox items with a suggested classification for each; promote them with inbox.promote").
+		Handler(func(ctx context.Context, input inboxInput) (map[string]any, error) {
+			if lister == nil {
+				return nil, errors.New("inbox listing requires database connection")
+			}
+			if err := cli.RequireEntitlement(ctx, app, billingDomain.ModuleAIInbox); err != nil {
+				return nil, err
+			}
+
+			items, err := lister.Handle(ctx, inboxQueries.ListInboxItemsQuery{UserID: app.CurrentUserID, Source: input.Source})
+			if err != nil {
+				return nil, err
+			}
+
+			pending := make([]map[string]any, 0, len(items))
+			for _, item := range items {
+				if item.Promoted || item.Archived {
+					continue
+				}
+				pending = append(pending, map[string]any{
+					"id":          item.ID.String(),
+					"content":     item.Content,
+					"tags":        item.Tags,
+					"source":      item.Source,
+					"captured_at": item.CapturedAt,
+					"stale":       item.Stale,
+					"suggestion":  item.Classification,
+				})
+			}
+
+			total := len(pending)
+			if input.Limit > 0 && len(pending) > input.Limit {
+				pending = pending[:input.Limit]
+			}
+			return map[string]any{
+				"items": pending,
+				"total": total,
+			}, nil
+		})
+
 	return nil
 }
 
@@ -420,29 +613,109 @@ type parsedInput struct {
 	priority string
 	duration time.Duration
 	dueDate  *time.Time
+	// project, contextTag and tags come from +Project, @context and #tag
+	// tokens.
+	project    string
+	contextTag string
+	tags       []string
 }
 
-func parseNaturalLanguage(input string) parsedInput {
+func parseNaturalLanguage(input string, weekStartsOn time.Weekday) parsedInput {
 	result := parsedInput{
 		title: input,
 	}
 
+	// Tokens go first so a name like +HighScore is not read as a priority.
+	result.project, result.title = extractToken(projectTokenPattern, result.title)
+	result.contextTag, result.title = extractToken(contextTokenPattern, result.title)
+	result.tags, result.title = extractTags(result.title)
 	result.priority, result.title = extractPriority(result.title)
 	result.duration, result.title = extractDuration(result.title)
-	result.dueDate, result.title = extractDueDate(result.title)
+	result.dueDate, result.title = extractDueDate(result.title, weekStartsOn)
 	result.title = cleanTitle(result.title)
 	return result
 }
 
-func extractPriority(input string) (string, string) {
-	if strings.Contains(input, "!!!") {
-		return "urgent", strings.ReplaceAll(input, "!!!", "")
+var (
+	projectTokenPattern = regexp.MustCompile(`(?:^|\s)\+([\p{L}\p{N}][\p{L}\p{N}_-]*)`)
+	contextTokenPattern = regexp.MustCompile(`(?:^|\s)@([\p{L}\p{N}][\p{L}\p{N}_-]*)`)
+	// Tags must start with a letter, so issue numbers such as #123 stay in
+	// the title.
+	tagTokenPattern = regexp.MustCompile(`(?:^|\s)#(\p{L}[\p{L}\p{N}_-]*)`)
+)
+
+// extractTags removes every #tag token from input and returns the tags
+// normalized: lower-cased and without duplicates.
+func extractTags(input string) ([]string, string) {
+	var tags []string
+	for _, match := range tagTokenPattern.FindAllStringSubmatch(input, -1) {
+		tags = append(tags, match[1])
+	}
+	if len(tags) == 0 {
+		return nil, input
+	}
+	if normalized, err := sharedDomain.NormalizeTags(tags); err == nil {
+		tags = normalized
+	}
+	return tags, tagTokenPattern.ReplaceAllString(input, " ")
+}
+
+// extractToken removes the first +project or @context token matched by
+// pattern and returns its name. Tokens must start a word, so email addresses
+// are left alone.
+func extractToken(pattern *regexp.Regexp, input string) (string, string) {
+	loc := pattern.FindStringSubmatchIndex(input)
+	if loc == nil {
+		return "", input
 	}
-	if strings.Contains(input, "!!") {
-		return "high", strings.ReplaceAll(input, "!!", "")
+	return input[loc[2]:loc[3]], input[:loc[0]] + " " + input[loc[1]:]
+}
+
+// resolveProject returns the user's active project matching name, ignoring
+// case and punctuation so +WebsiteRedesign finds "Website Redesign". A new
+// project is created when none matches.
+func resolveProject(ctx context.Context, app *cli.App, name string) (uuid.UUID, bool, error) {
+	projects, err := app.ListProjectsHandler.Handle(ctx, projectQueries.ListProjectsQuery{
+		UserID:     app.CurrentUserID,
+		ActiveOnly: true,
+	})
+	if err != nil {
+		return uuid.Nil, false, err
 	}
-	if strings.Contains(input, "!") && !strings.Contains(input, "!!") {
-		return "medium", strings.ReplaceAll(input, "!", "")
+
+	key := projectNameKey(name)
+	for _, project := range projects {
+		if projectNameKey(project.Name) == key {
+			return project.ID, false, nil
+		}
+	}
+
+	result, err := app.CreateProjectHandler.Handle(ctx, projectCommands.CreateProjectCommand{
+		UserID: app.CurrentUserID,
+		Name:   name,
+	})
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+	return result.ProjectID, true, nil
+}
+
+func projectNameKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func extractPriority(input string) (string, string) {
+	for _, marker := range value_objects.PriorityMarkers {
+		if strings.Contains(input, marker) {
+			p, _ := value_objects.ParsePriorityMarker(marker)
+			return p.String(), strings.ReplaceAll(input, marker, "")
+		}
 	}
 
 	lower := strings.ToLower(input)
@@ -490,7 +763,9 @@ func extractDuration(input string) (time.Duration, string) {
 	return 0, input
 }
 
-func extractDueDate(input string) (*time.Time, string) {
+// extractDueDate resolves relative due dates in input. "next week" is the
+// first day of the following week, where weeks begin on weekStartsOn.
+func extractDueDate(input string, weekStartsOn time.Weekday) (*time.Time, string) {
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	lower := strings.ToLower(input)
@@ -498,7 +773,7 @@ func extractDueDate(input string) (*time.Time, string) {
 	relativeDates := map[string]time.Time{
 		"today":     today,
 		"tomorrow":  today.AddDate(0, 0, 1),
-		"next week": today.AddDate(0, 0, 7),
+		"next week": sharedDomain.StartOfNextWeek(today, weekStartsOn),
 	}
 
 	for keyword, date := range relativeDates {
@@ -569,27 +844,14 @@ func listCompletableItems(ctx context.Context, app *cli.App) (any, error) {
 		}
 	}
 
-	if app.ListHabitsHandler != nil {
-		query := habitQueries.ListHabitsQuery{
-			UserID:       app.CurrentUserID,
-			OnlyDueToday: true,
-		}
-		habits, err := app.ListHabitsHandler.Handle(ctx, query)
-		if err == nil {
-			filtered := make([]habitQueries.HabitDTO, 0, len(habits))
-			for _, habit := range habits {
-				if !habit.CompletedToday {
-					filtered = append(filtered, habit)
-				}
-			}
-			result["habits"] = filtered
-		}
+	if habits, err := app.DueHabits(ctx); err == nil && habits != nil {
+		result["habits"] = habits
 	}
 
 	return result, nil
 }
 
-func completeByPrefix(ctx context.Context, app *cli.App, prefix string) (any, error) {
+func completeByPrefix(ctx context.Context, app *cli.App, term string, mode cli.MatchMode) (any, error) {
 	if app.ListTasksHandler != nil && app.CompleteTaskHandler != nil {
 		query := queries.ListTasksQuery{
 			UserID: app.CurrentUserID,
@@ -597,12 +859,10 @@ func completeByPrefix(ctx context.Context, app *cli.App, prefix string) (any, er
 		}
 		tasks, err := app.ListTasksHandler.Handle(ctx, query)
 		if err == nil {
-			matches := make([]queries.TaskDTO, 0)
-			for _, task := range tasks {
-				if strings.HasPrefix(strings.ToLower(task.ID.String()), prefix) {
-					matches = append(matches, task)
-				}
-			}
+			matches := cli.RankMatches(tasks, term, mode,
+				func(t queries.TaskDTO) uuid.UUID { return t.ID },
+				func(t queries.TaskDTO) string { return t.Title },
+			)
 			if len(matches) == 1 {
 				cmd := commands.CompleteTaskCommand{
 					TaskID: matches[0].ID,
@@ -619,19 +879,13 @@ func completeByPrefix(ctx context.Context, app *cli.App, prefix string) (any, er
 		}
 	}
 
-	if app.ListHabitsHandler != nil && app.LogCompletionHandler != nil {
-		query := habitQueries.ListHabitsQuery{
-			UserID:       app.CurrentUserID,
-			OnlyDueToday: true,
-		}
-		habits, err := app.ListHabitsHandler.Handle(ctx, query)
+	if app.LogCompletionHandler != nil {
+		habits, err := app.DueHabits(ctx)
 		if err == nil {
-			matches := make([]habitQueries.HabitDTO, 0)
-			for _, habit := range habits {
-				if !habit.CompletedToday && strings.HasPrefix(strings.ToLower(habit.ID.String()), prefix) {
-					matches = append(matches, habit)
-				}
-			}
+			matches := cli.RankMatches(habits, term, mode,
+				func(h habitQueries.HabitDTO) uuid.UUID { return h.ID },
+				func(h habitQueries.HabitDTO) string { return h.Name },
+			)
 			if len(matches) == 1 {
 				cmd := habitCommands.LogCompletionCommand{
 					HabitID: matches[0].ID,
@@ -656,77 +910,31 @@ func completeByPrefix(ctx context.Context, app *cli.App, prefix string) (any, er
 }
 
 func buildTaskStats(ctx context.Context, app *cli.App) map[string]any {
-	if app.ListTasksHandler == nil {
+	if app.GetTaskStatsHandler == nil {
 		return map[string]any{}
 	}
 
-	allTasks, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{
-		UserID:     app.CurrentUserID,
-		IncludeAll: true,
+	stats, err := app.GetTaskStatsHandler.Handle(ctx, queries.GetTaskStatsQuery{
+		UserID: app.CurrentUserID,
 	})
 	if err != nil {
 		return map[string]any{"error": err.Error()}
 	}
 
-	pending := 0
-	inProgress := 0
-	completed := 0
-	archived := 0
-	urgent := 0
-	high := 0
-	medium := 0
-	low := 0
-	overdue := 0
-
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-
-	for _, task := range allTasks {
-		switch task.Status {
-		case "pending":
-			pending++
-		case "in_progress":
-			inProgress++
-		case "completed":
-			completed++
-		case "archived":
-			archived++
-		}
-
-		switch task.Priority {
-		case "urgent":
-			urgent++
-		case "high":
-			high++
-		case "medium":
-			medium++
-		case "low":
-			low++
-		}
-
-		if task.DueDate != nil && task.DueDate.Before(today) && task.Status != "completed" && task.Status != "archived" {
-			overdue++
-		}
-	}
-
-	total := len(allTasks)
-	completionRate := 0.0
-	if total > 0 {
-		completionRate = float64(completed) / float64(total) * 100
-	}
-
 	return map[string]any{
-		"total":           total,
-		"pending":         pending,
-		"in_progress":     inProgress,
-		"completed":       completed,
-		"archived":        archived,
-		"urgent":          urgent,
-		"high":            high,
-		"medium":          medium,
-		"low":             low,
-		"overdue":         overdue,
-		"completion_rate": completionRate,
+		"total":           stats.Total,
+		"pending":         stats.Pending,
+		"in_progress":     stats.InProgress,
+		"blocked":         stats.Blocked,
+		"waiting":         stats.Waiting,
+		"completed":       stats.Completed,
+		"archived":        stats.Archived,
+		"urgent":          stats.Urgent,
+		"high":            stats.High,
+		"medium":          stats.Medium,
+		"low":             stats.Low,
+		"overdue":         stats.Overdue,
+		"completion_rate": stats.CompletionRate,
 	}
 }
 
@@ -883,16 +1091,9 @@ func reviewHabits(ctx context.Context, app *cli.App) reviewSection {
 		UserID:       app.CurrentUserID,
 		BrokenStreak: true,
 	})
-	due, _ := app.ListHabitsHandler.Handle(ctx, habitQueries.ListHabitsQuery{
-		UserID:       app.CurrentUserID,
-		OnlyDueToday: true,
-	})
-
-	pending := make([]habitQueries.HabitDTO, 0)
-	for _, habit := range due {
-		if !habit.CompletedToday {
-			pending = append(pending, habit)
-		}
+	pending, _ := app.DueHabits(ctx)
+	if pending == nil {
+		pending = make([]habitQueries.HabitDTO, 0)
 	}
 
 	return reviewSection{
@@ -918,14 +1119,14 @@ func fetchSchedule(ctx context.Context, app *cli.App, date time.Time) *scheduleQ
 	return schedule
 }
 
-func fetchAvailableSlots(ctx context.Context, app *cli.App, date time.Time) []scheduleQueries.TimeSlotDTO {
+func fetchAvailableSlots(ctx context.Context, app *cli.App, date time.Time, minSlot time.Duration) []scheduleQueries.TimeSlotDTO {
 	if app.FindAvailableSlotsHandler == nil {
 		return nil
 	}
 	query := scheduleQueries.FindAvailableSlotsQuery{
 		UserID:      app.CurrentUserID,
 		Date:        date,
-		MinDuration: 15 * time.Minute,
+		MinDuration: minSlot,
 	}
 	slots, err := app.FindAvailableSlotsHandler.Handle(ctx, query)
 	if err != nil {
@@ -934,6 +1135,43 @@ func fetchAvailableSlots(ctx context.Context, app *cli.App, date time.Time) []sc
 	return slots
 }
 
+func fetchCapacity(ctx context.Context, app *cli.App, date time.Time) *scheduleQueries.CapacityDTO {
+	if app.GetCapacityHandler == nil {
+		return nil
+	}
+	capacity, err := app.GetCapacityHandler.Handle(ctx, scheduleQueries.GetCapacityQuery{
+		UserID:    app.CurrentUserID,
+		StartDate: date,
+		EndDate:   date,
+	})
+	if err != nil {
+		return nil
+	}
+	return capacity
+}
+
+// plannedMinutes sums the durations of the tasks and habits up for planning.
+func plannedMinutes(tasks []queries.TaskDTO, habits []habitQueries.HabitDTO) int {
+	total := 0
+	for _, task := range tasks {
+		total += task.DurationMinutes
+	}
+	for _, habit := range habits {
+		total += habit.DurationMins
+	}
+	return total
+}
+
+// overcommitWarning describes how far planned work exceeds free capacity. It
+// returns an empty string when the work fits.
+func overcommitWarning(capacity *scheduleQueries.CapacityDTO, planned int) string {
+	if planned <= capacity.TotalFreeMins {
+		return ""
+	}
+	return fmt.Sprintf("over-committed: %dm planned but only %dm free (%dm over)",
+		planned, capacity.TotalFreeMins, planned-capacity.TotalFreeMins)
+}
+
 func fetchSchedulableTasks(ctx context.Context, app *cli.App) []queries.TaskDTO {
 	if app.ListTasksHandler == nil {
 		return nil
@@ -986,13 +1224,7 @@ func fetchPendingTasks(ctx context.Context, app *cli.App, limit int) []queries.T
 }
 
 func fetchDueHabits(ctx context.Context, app *cli.App) []habitQueries.HabitDTO {
-	if app.ListHabitsHandler == nil {
-		return nil
-	}
-	habits, err := app.ListHabitsHandler.Handle(ctx, habitQueries.ListHabitsQuery{
-		UserID:       app.CurrentUserID,
-		OnlyDueToday: true,
-	})
+	habits, err := app.DueHabits(ctx)
 	if err != nil {
 		return nil
 	}
@@ -1000,27 +1232,9 @@ func fetchDueHabits(ctx context.Context, app *cli.App) []habitQueries.HabitDTO {
 }
 
 func gatherScheduleBlocks(ctx context.Context, app *cli.App, days int) []calendarApp.TimeBlock {
-	now := time.Now()
-	all := make([]calendarApp.TimeBlock, 0)
-	for i := 0; i < days; i++ {
-		day := now.AddDate(0, 0, i)
-		schedule := fetchSchedule(ctx, app, day)
-		if schedule == nil {
-			continue
-		}
-		for _, block := range schedule.Blocks {
-			all = append(all, calendarApp.TimeBlock{
-				ID:        block.ID,
-				Title:     block.Title,
-				BlockType: block.BlockType,
-				StartTime: block.StartTime,
-				EndTime:   block.EndTime,
-				Completed: block.Completed,
-				Missed:    block.Missed,
-			})
-		}
-	}
-	return all
+	// Days that fail to load are skipped.
+	blocks, _ := cli.GatherScheduleBlocks(ctx, app, days)
+	return blocks
 }
 
 func generateICS(blocks []calendarApp.TimeBlock) string {
@@ -1036,7 +1250,10 @@ func generateICS(blocks []calendarApp.TimeBlock) string {
 	for _, block := range blocks {
 		sb.WriteString("BEGIN:VEVENT\r\n")
 		sb.WriteString(fmt.Sprintf("UID:%s@orbita\r\n", block.ID.String()))
-		sb.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSTime(time.Now())))
+		modified, sequence := cli.ICSRevision(block.CreatedAt, block.UpdatedAt, block.StartTime)
+		sb.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSTime(modified)))
+		sb.WriteString(fmt.Sprintf("LAST-MODIFIED:%s\r\n", formatICSTime(modified)))
+		sb.WriteString(fmt.Sprintf("SEQUENCE:%d\r\n", sequence))
 		sb.WriteString(fmt.Sprintf("DTSTART:%s\r\n", formatICSTime(block.StartTime)))
 		sb.WriteString(fmt.Sprintf("DTEND:%s\r\n", formatICSTime(block.EndTime)))
 		sb.WriteString(fmt.Sprintf("SUMMARY:%s\r\n", escapeICS(block.Title)))
@@ -0,0 +1,464 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/mcp-go"
+	"github.com/felixgeelhaar/mcp-go/testutil"
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	internalApp "github.com/felixgeelhaar/orbita/internal/app"
+	inboxCommands "github.com/felixgeelhaar/orbita/internal/inbox/application/commands"
+	inboxQueries "github.com/felixgeelhaar/orbita/internal/inbox/application/queries"
+	taskCommands "github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	projectCommands "github.com/felixgeelhaar/orbita/internal/projects/application/commands"
+	projectQueries "github.com/felixgeelhaar/orbita/internal/projects/application/queries"
+	scheduleCommands "github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNaturalLanguage_ProjectAndContext(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		title      string
+		project    string
+		contextTag string
+		priority   string
+	}{
+		{"project token", "Write launch post +WebsiteRedesign", "Write launch post", "WebsiteRedesign", "", ""},
+		{"context token", "@phone Call the bank", "Call the bank", "", "phone", ""},
+		{"both with priority", "Review copy +Launch @office urgent", "Review copy", "Launch", "office", "urgent"},
+		{"project name is not a priority", "Fix bug +HighScore", "Fix bug", "HighScore", "", ""},
+		{"email is not a context", "Email bob@example.com", "Email bob@example.com", "", "", ""},
+		{"plain plus is kept", "Compare 1 + 2", "Compare 1 + 2", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := parseNaturalLanguage(tt.input, time.Monday)
+			assert.Equal(t, tt.title, parsed.title)
+			assert.Equal(t, tt.project, parsed.project)
+			assert.Equal(t, tt.contextTag, parsed.contextTag)
+			assert.Equal(t, tt.priority, parsed.priority)
+		})
+	}
+}
+
+func TestParseNaturalLanguage_Tags(t *testing.T) {
+	parsed := parseNaturalLanguage("Book flights #Travel @laptop #urgent #travel", time.Monday)
+
+	assert.Equal(t, "Book flights", parsed.title)
+	assert.Equal(t, []string{"travel", "urgent"}, parsed.tags)
+	assert.Equal(t, "laptop", parsed.contextTag)
+	assert.Empty(t, parsed.priority)
+
+	parsed = parseNaturalLanguage("Fix #123", time.Monday)
+	assert.Equal(t, "Fix #123", parsed.title)
+	assert.Empty(t, parsed.tags)
+}
+
+func TestCLIAdd_LinksProject(t *testing.T) {
+	tmpDir := t.TempDir()
+	userID := uuid.New()
+	cfg := &config.Config{
+		AppEnv:         "test",
+		LocalMode:      true,
+		DatabaseDriver: "sqlite",
+		SQLitePath:     filepath.Join(tmpDir, "test.db"),
+		LogLevel:       "error",
+		UserID:         userID.String(),
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), userID)
+	container, err := internalApp.NewLocalContainer(ctx, cfg, logger)
+	require.NoError(t, err)
+	defer container.Close()
+
+	app := &cli.App{
+		CreateTaskHandler: container.CreateTaskHandler,
+		ListTasksHandler:  container.ListTasksHandler,
+	}
+	app.SetCurrentUserID(userID)
+	app.SetTagHandlers(container.BulkTagTasksHandler, container.BulkTagHabitsHandler)
+	app.SetProjectHandlers(
+		container.CreateProjectHandler,
+		container.UpdateProjectHandler,
+		container.DeleteProjectHandler,
+		container.ChangeProjectStatusHandler,
+		container.AddMilestoneHandler,
+		container.UpdateMilestoneHandler,
+		container.DeleteMilestoneHandler,
+		container.LinkTaskHandler,
+		container.UnlinkTaskHandler,
+		container.GetProjectHandler,
+		container.ListProjectsHandler,
+	)
+
+	existing, err := app.CreateProjectHandler.Handle(ctx, projectCommands.CreateProjectCommand{
+		UserID: userID,
+		Name:   "Website Redesign",
+	})
+	require.NoError(t, err)
+
+	srv := mcp.NewServer(mcp.ServerInfo{
+		Name:         "test",
+		Version:      "1.0.0",
+		Capabilities: mcp.Capabilities{Tools: true},
+	})
+	require.NoError(t, RegisterCLITools(srv, ToolDependencies{App: app}))
+	add := func(description string) map[string]any {
+		t.Helper()
+		return callToolAs(t, srv, userID, "cli.add", map[string]any{"description": description})
+	}
+
+	t.Run("resolves an existing project by name", func(t *testing.T) {
+		out := add("Write launch post +websiteredesign @writing")
+		assert.Equal(t, "Write launch post", out["title"])
+		assert.Equal(t, existing.ProjectID.String(), out["project_id"])
+		assert.Equal(t, false, out["project_created"])
+		assert.Equal(t, "writing", out["context"])
+
+		project, err := app.GetProjectHandler.Handle(ctx, projectQueries.GetProjectQuery{ProjectID: existing.ProjectID, UserID: userID})
+		require.NoError(t, err)
+		require.Len(t, project.Tasks, 1)
+		assert.Equal(t, out["task_id"], project.Tasks[0].TaskID.String())
+
+		tasks, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: userID})
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, []string{"writing"}, tasks[0].Tags)
+	})
+
+	t.Run("creates a project that does not exist", func(t *testing.T) {
+		out := add("Draft budget +Finance")
+		assert.Equal(t, true, out["project_created"])
+		assert.NotEqual(t, existing.ProjectID.String(), out["project_id"])
+
+		projects, err := app.ListProjectsHandler.Handle(ctx, projectQueries.ListProjectsQuery{UserID: userID})
+		require.NoError(t, err)
+		assert.Len(t, projects, 2)
+	})
+
+	t.Run("makes the task recur", func(t *testing.T) {
+		out := callToolAs(t, srv, userID, "cli.add", map[string]any{"description": "Pay rent 2030-04-01", "repeat": "monthly"})
+		assert.Equal(t, "monthly", out["repeat"])
+
+		tasks, err := app.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: userID})
+		require.NoError(t, err)
+		var recurrence string
+		for _, task := range tasks {
+			if task.Title == "Pay rent" {
+				recurrence = task.Recurrence
+			}
+		}
+		assert.Equal(t, "monthly", recurrence)
+	})
+}
+
+// toolOutput returns the structured output of a tool call result.
+func toolOutput(t *testing.T, result any) map[string]any {
+	t.Helper()
+	encoded, err := json.Marshal(result)
+	require.NoError(t, err)
+	var decoded struct {
+		Content []struct {
+			Text map[string]any `json:"text"`
+		} `json:"content"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Len(t, decoded.Content, 1)
+	return decoded.Content[0].Text
+}
+
+// callToolAs runs a tool the way the MCP server does once its principal
+// middleware has authenticated the request as userID, and decodes the
+// tool's output.
+func callToolAs(t *testing.T, srv *mcp.Server, userID uuid.UUID, name string, args map[string]any) map[string]any {
+	t.Helper()
+	tool, ok := srv.GetTool(name)
+	require.True(t, ok, "tool %s is registered", name)
+	input, err := json.Marshal(args)
+	require.NoError(t, err)
+
+	result, err := tool.Execute(sharedApplication.WithPrincipal(context.Background(), userID), input)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(result)
+	require.NoError(t, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &out))
+	return out
+}
+
+func TestCLIPlan_WarnsWhenOvercommitted(t *testing.T) {
+	tmpDir := t.TempDir()
+	userID := uuid.New()
+	cfg := &config.Config{
+		AppEnv:         "test",
+		LocalMode:      true,
+		DatabaseDriver: "sqlite",
+		SQLitePath:     filepath.Join(tmpDir, "test.db"),
+		LogLevel:       "error",
+		UserID:         userID.String(),
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), userID)
+	container, err := internalApp.NewLocalContainer(ctx, cfg, logger)
+	require.NoError(t, err)
+	defer container.Close()
+
+	app := &cli.App{
+		CreateTaskHandler:  container.CreateTaskHandler,
+		ListTasksHandler:   container.ListTasksHandler,
+		AddBlockHandler:    container.AddBlockHandler,
+		GetScheduleHandler: container.GetScheduleHandler,
+	}
+	app.SetCurrentUserID(userID)
+	app.SetCapacityHandler(container.GetCapacityHandler)
+
+	date := time.Date(2030, 3, 4, 0, 0, 0, 0, time.UTC)
+	_, err = app.AddBlockHandler.Handle(ctx, scheduleCommands.AddBlockCommand{
+		UserID:      userID,
+		Date:        date,
+		BlockType:   "focus",
+		ReferenceID: uuid.New(),
+		Title:       "Deep work",
+		StartTime:   date.Add(9 * time.Hour),
+		EndTime:     date.Add(15 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	srv := mcp.NewServer(mcp.ServerInfo{
+		Name:         "test",
+		Version:      "1.0.0",
+		Capabilities: mcp.Capabilities{Tools: true},
+	})
+	require.NoError(t, RegisterCLITools(srv, ToolDependencies{App: app}))
+	plan := func() map[string]any {
+		t.Helper()
+		return callToolAs(t, srv, userID, "cli.plan", map[string]any{"date": "2030-03-04"})
+	}
+
+	_, err = app.CreateTaskHandler.Handle(ctx, taskCommands.CreateTaskCommand{UserID: userID, Title: "Write report", DurationMinutes: 90})
+	require.NoError(t, err)
+
+	out := plan()
+	assert.Equal(t, float64(90), out["planned_minutes"])
+	assert.Equal(t, false, out["overcommitted"])
+	assert.NotContains(t, out, "warning")
+
+	_, err = app.CreateTaskHandler.Handle(ctx, taskCommands.CreateTaskCommand{UserID: userID, Title: "Review budget", DurationMinutes: 60})
+	require.NoError(t, err)
+
+	out = plan()
+	assert.Equal(t, float64(150), out["planned_minutes"])
+	assert.Equal(t, true, out["overcommitted"])
+	assert.Equal(t, "over-committed: 150m planned but only 120m free (30m over)", out["warning"])
+
+	capacity, ok := out["capacity"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, float64(480), capacity["TotalWorkingMins"])
+	assert.Equal(t, float64(120), capacity["TotalFreeMins"])
+}
+
+func TestCLIPlan_UsesPlanDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	userID := uuid.New()
+	cfg := &config.Config{
+		AppEnv:         "test",
+		LocalMode:      true,
+		DatabaseDriver: "sqlite",
+		SQLitePath:     filepath.Join(tmpDir, "test.db"),
+		LogLevel:       "error",
+		UserID:         userID.String(),
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), userID)
+	container, err := internalApp.NewLocalContainer(ctx, cfg, logger)
+	require.NoError(t, err)
+	defer container.Close()
+
+	app := &cli.App{
+		CreateTaskHandler:   container.CreateTaskHandler,
+		ListTasksHandler:    container.ListTasksHandler,
+		GetScheduleHandler:  container.GetScheduleHandler,
+		AutoScheduleHandler: container.AutoScheduleHandler,
+	}
+	app.SetCurrentUserID(userID)
+	app.SetSettingsService(container.SettingsService)
+
+	srv := mcp.NewServer(mcp.ServerInfo{
+		Name:         "test",
+		Version:      "1.0.0",
+		Capabilities: mcp.Capabilities{Tools: true},
+	})
+	require.NoError(t, RegisterCLITools(srv, ToolDependencies{App: app}))
+	plan := func(args map[string]any) map[string]any {
+		t.Helper()
+		return callToolAs(t, srv, userID, "cli.plan", args)
+	}
+	dayAhead := func(days int) string {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day()+days, 0, 0, 0, 0, now.Location()).Format(time.RFC3339)
+	}
+
+	_, err = app.CreateTaskHandler.Handle(ctx, taskCommands.CreateTaskCommand{UserID: userID, Title: "Write report", DurationMinutes: 30})
+	require.NoError(t, err)
+
+	// Without settings the plan is for tomorrow and only lists the work.
+	out := plan(map[string]any{})
+	assert.Equal(t, dayAhead(1), out["date"])
+	assert.Equal(t, false, out["auto_enabled"])
+	assert.Nil(t, out["auto_result"])
+
+	require.NoError(t, container.SettingsService.Set(ctx, userID, "plan.day_offset", "0"))
+	require.NoError(t, container.SettingsService.Set(ctx, userID, "plan.auto_schedule", "true"))
+
+	out = plan(map[string]any{})
+	assert.Equal(t, dayAhead(0), out["date"])
+	assert.Equal(t, true, out["auto_enabled"])
+	assert.NotNil(t, out["auto_result"])
+
+	// Explicit arguments still win over the defaults.
+	out = plan(map[string]any{"date": "2030-03-04", "auto": false})
+	assert.Equal(t, time.Date(2030, 3, 4, 0, 0, 0, 0, time.UTC).Format(time.RFC3339), out["date"])
+	assert.Equal(t, false, out["auto_enabled"])
+	assert.Nil(t, out["auto_result"])
+}
+
+type mockInboxCapturer struct {
+	mock.Mock
+}
+
+func (m *mockInboxCapturer) Handle(ctx context.Context, cmd inboxCommands.CaptureInboxItemCommand) (*inboxCommands.CaptureInboxItemResult, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inboxCommands.CaptureInboxItemResult), args.Error(1)
+}
+
+type mockInboxLister struct {
+	mock.Mock
+}
+
+func (m *mockInboxLister) Handle(ctx context.Context, query inboxQueries.ListInboxItemsQuery) ([]inboxQueries.InboxItemDTO, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inboxQueries.InboxItemDTO), args.Error(1)
+}
+
+func newInboxToolClient(t *testing.T, deps ToolDependencies) *testutil.TestClient {
+	t.Helper()
+	srv := mcp.NewServer(mcp.ServerInfo{
+		Name:         "test",
+		Version:      "1.0.0",
+		Capabilities: mcp.Capabilities{Tools: true},
+	})
+	require.NoError(t, RegisterCLITools(srv, deps))
+	tc := testutil.NewTestClient(t, srv)
+	t.Cleanup(func() { tc.Close() })
+	return tc
+}
+
+func TestCLICapture(t *testing.T) {
+	userID := uuid.New()
+	app := &cli.App{}
+	app.SetCurrentUserID(userID)
+
+	t.Run("captures the item and returns the suggestion", func(t *testing.T) {
+		capturer := new(mockInboxCapturer)
+		itemID := uuid.New()
+		capturer.On("Handle", mock.Anything, mock.MatchedBy(func(cmd inboxCommands.CaptureInboxItemCommand) bool {
+			return cmd.UserID == userID && cmd.Content == "Call the dentist" &&
+				cmd.Source == "mcp" && assert.ObjectsAreEqual([]string{"health"}, cmd.Tags)
+		})).Return(&inboxCommands.CaptureInboxItemResult{ItemID: itemID, Classification: "meeting"}, nil)
+
+		tc := newInboxToolClient(t, ToolDependencies{App: app, InboxCapturer: capturer})
+		resp, err := tc.CallToolRaw("cli.capture", map[string]any{"content": "  Call the dentist ", "tags": []string{"health"}})
+		require.NoError(t, err)
+		require.Nil(t, resp.Error)
+
+		out := toolOutput(t, resp.Result)
+		assert.Equal(t, itemID.String(), out["item_id"])
+		assert.Equal(t, "meeting", out["suggestion"])
+		capturer.AssertExpectations(t)
+	})
+
+	t.Run("rejects empty content", func(t *testing.T) {
+		capturer := new(mockInboxCapturer)
+		tc := newInboxToolClient(t, ToolDependencies{App: app, InboxCapturer: capturer})
+		_, err := tc.CallToolRaw("cli.capture", map[string]any{"content": "   "})
+		assert.ErrorContains(t, err, "content is required")
+		capturer.AssertNotCalled(t, "Handle", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires a capture handler", func(t *testing.T) {
+		tc := newInboxToolClient(t, ToolDependencies{App: app})
+		_, err := tc.CallToolRaw("cli.capture", map[string]any{"content": "Call the dentist"})
+		assert.ErrorContains(t, err, "requires database connection")
+	})
+}
+
+func TestCLIInbox(t *testing.T) {
+	userID := uuid.New()
+	app := &cli.App{}
+	app.SetCurrentUserID(userID)
+
+	pending := inboxQueries.InboxItemDTO{ID: uuid.New(), Content: "Daily stretching", Classification: "habit", Source: "mcp"}
+	stale := inboxQueries.InboxItemDTO{ID: uuid.New(), Content: "Fix the gate", Classification: "task", Stale: true}
+	archived := inboxQueries.InboxItemDTO{ID: uuid.New(), Content: "Old idea", Classification: "task", Archived: true}
+
+	lister := new(mockInboxLister)
+	lister.On("Handle", mock.Anything, inboxQueries.ListInboxItemsQuery{UserID: userID}).
+		Return([]inboxQueries.InboxItemDTO{pending, stale, archived}, nil)
+
+	tc := newInboxToolClient(t, ToolDependencies{App: app, InboxLister: lister})
+
+	t.Run("lists unprocessed items with suggestions", func(t *testing.T) {
+		resp, err := tc.CallToolRaw("cli.inbox", map[string]any{})
+		require.NoError(t, err)
+		require.Nil(t, resp.Error)
+
+		out := toolOutput(t, resp.Result)
+		assert.Equal(t, float64(2), out["total"])
+		items, ok := out["items"].([]any)
+		require.True(t, ok)
+		require.Len(t, items, 2)
+
+		first := items[0].(map[string]any)
+		assert.Equal(t, pending.ID.String(), first["id"])
+		assert.Equal(t, "habit", first["suggestion"])
+		second := items[1].(map[string]any)
+		assert.Equal(t, "task", second["suggestion"])
+		assert.Equal(t, true, second["stale"])
+	})
+
+	t.Run("limits the items returned", func(t *testing.T) {
+		resp, err := tc.CallToolRaw("cli.inbox", map[string]any{"limit": 1})
+		require.NoError(t, err)
+		require.Nil(t, resp.Error)
+
+		out := toolOutput(t, resp.Result)
+		assert.Equal(t, float64(2), out["total"])
+		assert.Len(t, out["items"], 1)
+	})
+
+	lister.AssertExpectations(t)
+}
@@ -35,6 +35,11 @@ type engineHealthInput struct {
 	EngineID string `json:"engine_id" jsonschema:"required"`
 }
 
+type engineConfigureInput struct {
+	EngineID string         `json:"engine_id" jsonschema:"required"`
+	Config   map[string]any `json:"config"`
+}
+
 func registerEngineTools(srv *mcp.Server, deps ToolDependencies) error {
 	app := deps.App
 
@@ -161,6 +166,28 @@ func registerEngineTools(srv *mcp.Server, deps ToolDependencies) error {
 			}, nil
 		})
 
+	srv.Tool("engine.configure").
+		Description("Apply a new configuration to an engine; cached results for the engine are dropped").
+		Handler(func(ctx context.Context, input engineConfigureInput) (map[string]any, error) {
+			if app == nil || app.EngineExecutor == nil {
+				return nil, errors.New("engine executor not available")
+			}
+
+			if input.EngineID == "" {
+				return nil, errors.New("engine_id is required")
+			}
+
+			config := sdk.NewEngineConfig(input.EngineID, app.CurrentUserID, input.Config)
+			if err := app.EngineExecutor.Reconfigure(ctx, input.EngineID, config); err != nil {
+				return nil, err
+			}
+
+			return map[string]any{
+				"engine_id":  input.EngineID,
+				"configured": true,
+			}, nil
+		})
+
 	srv.Tool("engine.types").
 		Description("List available engine types").
 		Handler(func(ctx context.Context, input struct{}) ([]map[string]string, error) {
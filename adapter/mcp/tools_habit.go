@@ -3,6 +3,8 @@ package mcp
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/felixgeelhaar/mcp-go"
 	"github.com/felixgeelhaar/orbita/internal/habits/application/commands"
@@ -32,16 +34,32 @@ type habitIDInput struct {
 	HabitID string `json:"habit_id" jsonschema:"required"`
 }
 
+type habitSkipInput struct {
+	HabitID string `json:"habit_id" jsonschema:"required"`
+	Date    string `json:"date,omitempty"`
+}
+
+type habitFreezeInput struct {
+	HabitID string `json:"habit_id" jsonschema:"required"`
+	Until   string `json:"until" jsonschema:"required"`
+}
+
 type habitAdjustInput struct {
 	WindowDays int `json:"window_days,omitempty"`
 }
 
+type habitRecommendTimeInput struct {
+	HabitID    string `json:"habit_id" jsonschema:"required"`
+	WindowDays int    `json:"window_days,omitempty"`
+	Apply      bool   `json:"apply,omitempty"`
+}
+
 func registerHabitTools(srv *mcp.Server, deps ToolDependencies) error {
 	app := deps.App
 
 	srv.Tool("habit.create").
 		Description("Create a new habit").
-		Handler(func(ctx context.Context, input habitCreateInput) (*commands.CreateHabitResult, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input habitCreateInput) (*commands.CreateHabitResult, error) {
 			if app == nil || app.CreateHabitHandler == nil {
 				return nil, errors.New("habit creation requires database connection")
 			}
@@ -66,11 +84,11 @@ func registerHabitTools(srv *mcp.Server, deps ToolDependencies) error {
 				PreferredTime: input.PreferredTime,
 				TimesPerWeek:  input.TimesPerWeek,
 			})
-		})
+		}))
 
 	srv.Tool("habit.list").
 		Description("List habits").
-		Handler(func(ctx context.Context, input habitListInput) ([]queries.HabitDTO, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input habitListInput) ([]queries.HabitDTO, error) {
 			if app == nil || app.ListHabitsHandler == nil {
 				return nil, errors.New("habit listing requires database connection")
 			}
@@ -86,11 +104,11 @@ func registerHabitTools(srv *mcp.Server, deps ToolDependencies) error {
 				SortOrder:       input.SortOrder,
 			}
 			return app.ListHabitsHandler.Handle(ctx, query)
-		})
+		}))
 
 	srv.Tool("habit.log").
 		Description("Log a habit completion").
-		Handler(func(ctx context.Context, input habitIDInput) (*commands.LogCompletionResult, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input habitIDInput) (*commands.LogCompletionResult, error) {
 			if app == nil || app.LogCompletionHandler == nil {
 				return nil, errors.New("habit logging requires database connection")
 			}
@@ -103,11 +121,11 @@ func registerHabitTools(srv *mcp.Server, deps ToolDependencies) error {
 				HabitID: habitID,
 				UserID:  app.CurrentUserID,
 			})
-		})
+		}))
 
 	srv.Tool("habit.archive").
 		Description("Archive a habit").
-		Handler(func(ctx context.Context, input habitIDInput) (map[string]any, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input habitIDInput) (map[string]any, error) {
 			if app == nil || app.ArchiveHabitHandler == nil {
 				return nil, errors.New("habit archive requires database connection")
 			}
@@ -123,11 +141,84 @@ func registerHabitTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, err
 			}
 			return map[string]any{"habit_id": habitID, "archived": true}, nil
-		})
+		}))
+
+	srv.Tool("habit.skip").
+		Description("Skip a habit for a day (YYYY-MM-DD, default today) without breaking its streak").
+		Handler(withErrorMapping(func(ctx context.Context, input habitSkipInput) (map[string]any, error) {
+			if app == nil || app.SkipHabitHandler == nil {
+				return nil, errors.New("habit skipping requires database connection")
+			}
+			habitID, err := parseUUID(input.HabitID)
+			if err != nil {
+				return nil, err
+			}
+			date, err := parseDate(input.Date, time.Time{})
+			if err != nil {
+				return nil, err
+			}
+
+			if err := app.SkipHabitHandler.Handle(ctx, commands.SkipHabitCommand{
+				HabitID: habitID,
+				UserID:  app.CurrentUserID,
+				Date:    date,
+			}); err != nil {
+				return nil, err
+			}
+			return map[string]any{"habit_id": habitID, "skipped": true}, nil
+		}))
+
+	srv.Tool("habit.freeze").
+		Description("Pause a habit through a day (YYYY-MM-DD), keeping its streak").
+		Handler(withErrorMapping(func(ctx context.Context, input habitFreezeInput) (map[string]any, error) {
+			if app == nil || app.FreezeHabitHandler == nil {
+				return nil, errors.New("habit freezing requires database connection")
+			}
+			habitID, err := parseUUID(input.HabitID)
+			if err != nil {
+				return nil, err
+			}
+			if input.Until == "" {
+				return nil, errors.New("until is required")
+			}
+			until, err := parseDate(input.Until, time.Time{})
+			if err != nil {
+				return nil, err
+			}
+
+			if err := app.FreezeHabitHandler.Handle(ctx, commands.FreezeHabitCommand{
+				HabitID: habitID,
+				UserID:  app.CurrentUserID,
+				Until:   until,
+			}); err != nil {
+				return nil, err
+			}
+			return map[string]any{"habit_id": habitID, "frozen_until": until.Format(dateLayout)}, nil
+		}))
+
+	srv.Tool("habit.unfreeze").
+		Description("Resume a frozen habit").
+		Handler(withErrorMapping(func(ctx context.Context, input habitIDInput) (map[string]any, error) {
+			if app == nil || app.UnfreezeHabitHandler == nil {
+				return nil, errors.New("habit freezing requires database connection")
+			}
+			habitID, err := parseUUID(input.HabitID)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := app.UnfreezeHabitHandler.Handle(ctx, commands.UnfreezeHabitCommand{
+				HabitID: habitID,
+				UserID:  app.CurrentUserID,
+			}); err != nil {
+				return nil, err
+			}
+			return map[string]any{"habit_id": habitID, "frozen": false}, nil
+		}))
 
 	srv.Tool("habit.adjust_frequency").
 		Description("Adjust habit frequencies based on completion history").
-		Handler(func(ctx context.Context, input habitAdjustInput) (*commands.AdjustHabitFrequencyResult, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input habitAdjustInput) (*commands.AdjustHabitFrequencyResult, error) {
 			if app == nil || app.AdjustHabitFrequencyHandler == nil {
 				return nil, errors.New("habit adaptive frequency requires database connection")
 			}
@@ -139,7 +230,51 @@ func registerHabitTools(srv *mcp.Server, deps ToolDependencies) error {
 				UserID:     app.CurrentUserID,
 				WindowDays: input.WindowDays,
 			})
-		})
+		}))
+
+	srv.Tool("habit.recommend_time").
+		Description("Recommend the best time of day for a habit from its completion history, optionally applying it as the preferred time").
+		Handler(withErrorMapping(func(ctx context.Context, input habitRecommendTimeInput) (map[string]any, error) {
+			if app == nil || app.RecommendHabitTimeHandler == nil {
+				return nil, errors.New("habit time recommendations require database connection")
+			}
+			habitID, err := parseUUID(input.HabitID)
+			if err != nil {
+				return nil, err
+			}
+
+			result, err := app.RecommendHabitTimeHandler.Handle(ctx, commands.RecommendHabitTimeCommand{
+				HabitID:    habitID,
+				UserID:     app.CurrentUserID,
+				WindowDays: input.WindowDays,
+				Apply:      input.Apply,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			recommendation := map[string]any{
+				"habit_id":    result.HabitID,
+				"current":     result.Current,
+				"recommended": result.Recommended,
+				"confidence":  result.Confidence,
+				"completions": result.Completions,
+				"applied":     result.Applied,
+			}
+			if result.WindowEnd > 0 {
+				recommendation["window_start"] = formatTimeOfDay(result.WindowStart)
+				recommendation["window_end"] = formatTimeOfDay(result.WindowEnd)
+			}
+			if result.Completions > 0 {
+				recommendation["peak_hour"] = result.PeakHour
+			}
+			return recommendation, nil
+		}))
 
 	return nil
 }
+
+// formatTimeOfDay formats an offset from midnight as HH:MM.
+func formatTimeOfDay(offset time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(offset.Hours()), int(offset.Minutes())%60)
+}
@@ -1,10 +1,13 @@
 package mcp
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/felixgeelhaar/mcp-go/protocol"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 )
 
@@ -13,6 +16,9 @@ const (
 	timeLayout = "15:04"
 )
 
+// codeConflict is the application-defined error code for conflict errors.
+const codeConflict = -32009
+
 func parseDate(value string, fallback time.Time) (time.Time, error) {
 	if value == "" {
 		return fallback, nil
@@ -60,3 +66,28 @@ func parseOptionalTime(date time.Time, value string) (*time.Time, error) {
 	}
 	return &parsed, nil
 }
+
+// toolError maps a categorized application error to an MCP protocol error so
+// clients can tell bad input, missing resources and conflicts apart.
+// Uncategorized errors are returned unchanged.
+func toolError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sharedApplication.ErrValidation):
+		return protocol.NewInvalidParams(err.Error())
+	case errors.Is(err, sharedApplication.ErrNotFound):
+		return protocol.NewNotFound(err.Error())
+	case errors.Is(err, sharedApplication.ErrConflict):
+		return &protocol.Error{Code: codeConflict, Message: err.Error()}
+	}
+	return err
+}
+
+// withErrorMapping wraps a tool handler so its errors pass through toolError.
+func withErrorMapping[In, Out any](fn func(context.Context, In) (Out, error)) func(context.Context, In) (Out, error) {
+	return func(ctx context.Context, input In) (Out, error) {
+		out, err := fn(ctx, input)
+		return out, toolError(err)
+	}
+}
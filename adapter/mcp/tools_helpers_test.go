@@ -0,0 +1,49 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felixgeelhaar/mcp-go/protocol"
+	taskCommands "github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"validation", sharedApplication.NewValidationError("title is required"), protocol.CodeInvalidParams},
+		{"not found", taskCommands.ErrTaskNotFound, protocol.CodeNotFound},
+		{"conflict", sharedApplication.NewConflictError("already completed"), codeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mcpErr *protocol.Error
+			require.True(t, errors.As(toolError(tt.err), &mcpErr))
+			assert.Equal(t, tt.code, mcpErr.Code)
+			assert.Equal(t, tt.err.Error(), mcpErr.Message)
+		})
+	}
+
+	plain := errors.New("database unavailable")
+	assert.Same(t, plain, toolError(plain))
+	assert.NoError(t, toolError(nil))
+}
+
+func TestWithErrorMapping(t *testing.T) {
+	handler := withErrorMapping(func(ctx context.Context, input taskIDInput) (string, error) {
+		return "", taskCommands.ErrTaskNotFound
+	})
+
+	_, err := handler(context.Background(), taskIDInput{})
+	var mcpErr *protocol.Error
+	require.True(t, errors.As(err, &mcpErr))
+	assert.Equal(t, protocol.CodeNotFound, mcpErr.Code)
+}
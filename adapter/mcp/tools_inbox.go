@@ -26,7 +26,8 @@ type inboxCaptureInput struct {
 }
 
 type inboxListInput struct {
-	IncludePromoted bool `json:"include_promoted,omitempty"`
+	IncludePromoted bool   `json:"include_promoted,omitempty"`
+	Source          string `json:"source,omitempty"`
 }
 
 type inboxPromoteInput struct {
@@ -77,7 +78,7 @@ func registerInboxTools(srv *mcp.Server, deps ToolDependencies) error {
 			cmd := inboxCommands.CaptureInboxItemCommand{
 				UserID:   app.CurrentUserID,
 				Content:  strings.TrimSpace(input.Content),
-				Source:   input.Source,
+				Source:   domain.NormalizeSource(input.Source, domain.SourceMCP),
 				Metadata: metadata,
 				Tags:     input.Tags,
 			}
@@ -97,6 +98,7 @@ func registerInboxTools(srv *mcp.Server, deps ToolDependencies) error {
 			return app.ListInboxItemsHandler.Handle(ctx, queries.ListInboxItemsQuery{
 				UserID:          app.CurrentUserID,
 				IncludePromoted: input.IncludePromoted,
+				Source:          input.Source,
 			})
 		})
 
@@ -40,6 +40,12 @@ type meetingHeldInput struct {
 	Time      string `json:"time,omitempty"`
 }
 
+type meetingCanceledInput struct {
+	MeetingID string `json:"meeting_id" jsonschema:"required"`
+	Date      string `json:"date,omitempty"`
+	Time      string `json:"time,omitempty"`
+}
+
 type meetingArchiveInput struct {
 	MeetingID string `json:"meeting_id" jsonschema:"required"`
 }
@@ -53,7 +59,7 @@ func registerMeetingTools(srv *mcp.Server, deps ToolDependencies) error {
 
 	srv.Tool("meeting.create").
 		Description("Create a meeting").
-		Handler(func(ctx context.Context, input meetingCreateInput) (*commands.CreateMeetingResult, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input meetingCreateInput) (*commands.CreateMeetingResult, error) {
 			if app == nil || app.CreateMeetingHandler == nil {
 				return nil, errors.New("meeting creation requires database connection")
 			}
@@ -81,11 +87,11 @@ func registerMeetingTools(srv *mcp.Server, deps ToolDependencies) error {
 				DurationMins:  input.DurationMins,
 				PreferredTime: input.Time,
 			})
-		})
+		}))
 
 	srv.Tool("meeting.list").
 		Description("List meetings").
-		Handler(func(ctx context.Context, input meetingListInput) ([]queries.MeetingDTO, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input meetingListInput) ([]queries.MeetingDTO, error) {
 			if app == nil || app.ListMeetingsHandler == nil {
 				return nil, errors.New("meeting listing requires database connection")
 			}
@@ -97,11 +103,11 @@ func registerMeetingTools(srv *mcp.Server, deps ToolDependencies) error {
 				UserID:          app.CurrentUserID,
 				IncludeArchived: input.IncludeArchived,
 			})
-		})
+		}))
 
 	srv.Tool("meeting.update").
 		Description("Update a meeting").
-		Handler(func(ctx context.Context, input meetingUpdateInput) (map[string]any, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input meetingUpdateInput) (map[string]any, error) {
 			if app == nil || app.UpdateMeetingHandler == nil {
 				return nil, errors.New("meeting update requires database connection")
 			}
@@ -125,11 +131,11 @@ func registerMeetingTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, err
 			}
 			return map[string]any{"meeting_id": meetingID, "updated": true}, nil
-		})
+		}))
 
 	srv.Tool("meeting.held").
 		Description("Mark a meeting as held").
-		Handler(func(ctx context.Context, input meetingHeldInput) (map[string]any, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input meetingHeldInput) (map[string]any, error) {
 			if app == nil || app.MarkMeetingHeldHandler == nil {
 				return nil, errors.New("meeting held requires database connection")
 			}
@@ -167,11 +173,51 @@ func registerMeetingTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, err
 			}
 			return map[string]any{"meeting_id": meetingID, "held_at": heldAt}, nil
-		})
+		}))
+
+	srv.Tool("meeting.canceled").
+		Description("Record that a meeting occurrence was canceled or was a no-show").
+		Handler(withErrorMapping(func(ctx context.Context, input meetingCanceledInput) (map[string]any, error) {
+			if app == nil || app.MarkMeetingCanceledHandler == nil {
+				return nil, errors.New("meeting canceled requires database connection")
+			}
+			if err := cli.RequireEntitlement(ctx, app, billingDomain.ModuleSmartMeetings); err != nil {
+				return nil, err
+			}
+			meetingID, err := parseUUID(input.MeetingID)
+			if err != nil {
+				return nil, err
+			}
+
+			date := time.Now()
+			if input.Date != "" {
+				date, err = parseDate(input.Date, date)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			canceledAt := date
+			if input.Time != "" {
+				canceledAt, err = parseTimeOnDate(date, input.Time)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if err := app.MarkMeetingCanceledHandler.Handle(ctx, commands.MarkMeetingCanceledCommand{
+				UserID:     app.CurrentUserID,
+				MeetingID:  meetingID,
+				CanceledAt: canceledAt,
+			}); err != nil {
+				return nil, err
+			}
+			return map[string]any{"meeting_id": meetingID, "canceled_at": canceledAt}, nil
+		}))
 
 	srv.Tool("meeting.archive").
 		Description("Archive a meeting").
-		Handler(func(ctx context.Context, input meetingArchiveInput) (map[string]any, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input meetingArchiveInput) (map[string]any, error) {
 			if app == nil || app.ArchiveMeetingHandler == nil {
 				return nil, errors.New("meeting archive requires database connection")
 			}
@@ -190,11 +236,11 @@ func registerMeetingTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, err
 			}
 			return map[string]any{"meeting_id": meetingID, "archived": true}, nil
-		})
+		}))
 
 	srv.Tool("meeting.adjust_cadence").
 		Description("Adjust meeting cadence based on attendance").
-		Handler(func(ctx context.Context, input struct{}) (*commands.AdjustMeetingCadenceResult, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input struct{}) (*commands.AdjustMeetingCadenceResult, error) {
 			if app == nil || app.AdjustMeetingCadenceHandler == nil {
 				return nil, errors.New("meeting cadence adjustment requires database connection")
 			}
@@ -204,11 +250,11 @@ func registerMeetingTools(srv *mcp.Server, deps ToolDependencies) error {
 			return app.AdjustMeetingCadenceHandler.Handle(ctx, commands.AdjustMeetingCadenceCommand{
 				UserID: app.CurrentUserID,
 			})
-		})
+		}))
 
 	srv.Tool("meeting.candidates").
 		Description("List meeting scheduling candidates for a date").
-		Handler(func(ctx context.Context, input meetingCandidatesInput) ([]queries.MeetingCandidateDTO, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input meetingCandidatesInput) ([]queries.MeetingCandidateDTO, error) {
 			if app == nil || app.ListMeetingCandidatesHandler == nil {
 				return nil, errors.New("meeting candidates require database connection")
 			}
@@ -228,7 +274,7 @@ func registerMeetingTools(srv *mcp.Server, deps ToolDependencies) error {
 				UserID: app.CurrentUserID,
 				Date:   date,
 			})
-		})
+		}))
 
 	return nil
 }
@@ -21,6 +21,7 @@ type OrbitToolBridge struct {
 
 // orbitToolEntry holds a registered orbit tool.
 type orbitToolEntry struct {
+	orbitID  string
 	name     string
 	fullName string // {orbit_id}.{name}
 	handler  sdk.ToolHandler
@@ -47,6 +48,7 @@ func (b *OrbitToolBridge) RegisterTool(name string, handler sdk.ToolHandler, sch
 	}
 
 	b.tools[fullName] = &orbitToolEntry{
+		orbitID:  b.orbitID,
 		name:     name,
 		fullName: fullName,
 		handler:  handler,
@@ -121,6 +123,9 @@ func registerOrbitToolWithMCP(srv *mcp.Server, tool *orbitToolEntry, deps OrbitD
 	// For dynamic orbit tools, we use map[string]any which provides flexibility.
 	// The description provides context about expected parameters.
 	handler := tool.handler
+	if deps.Executor != nil {
+		handler = deps.Executor.InstrumentTool(tool.orbitID, tool.name, handler)
+	}
 
 	// Build a description that includes parameter info from the schema
 	description := tool.schema.Description
@@ -14,9 +14,11 @@ import (
 	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
 	meetingQueries "github.com/felixgeelhaar/orbita/internal/meetings/application/queries"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
 	scheduleCommands "github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
 	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 )
 
 type scheduleShowInput struct {
@@ -28,6 +30,11 @@ type scheduleWeekInput struct {
 	Next   bool `json:"next,omitempty"`
 }
 
+type scheduleStatsInput struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
 type scheduleAvailableInput struct {
 	Date  string `json:"date,omitempty"`
 	Min   int    `json:"min,omitempty"`
@@ -45,8 +52,10 @@ type scheduleAddInput struct {
 }
 
 type scheduleCompleteInput struct {
-	ScheduleID string `json:"schedule_id" jsonschema:"required"`
-	BlockID    string `json:"block_id" jsonschema:"required"`
+	ScheduleID    string `json:"schedule_id" jsonschema:"required"`
+	BlockID       string `json:"block_id" jsonschema:"required"`
+	Notes         string `json:"notes,omitempty"`
+	OutcomeRating int    `json:"outcome_rating,omitempty"`
 }
 
 type scheduleRemoveInput struct {
@@ -66,6 +75,11 @@ type scheduleRescheduleMissedInput struct {
 	After string `json:"after,omitempty"`
 }
 
+type scheduleRescheduleDayInput struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
 type scheduleAttemptsInput struct {
 	Date string `json:"date,omitempty"`
 }
@@ -74,6 +88,7 @@ type scheduleAutoInput struct {
 	Date     string `json:"date,omitempty"`
 	Habits   bool   `json:"habits,omitempty"`
 	Meetings bool   `json:"meetings,omitempty"`
+	Explain  bool   `json:"explain,omitempty"`
 }
 
 type scheduleImportInput struct {
@@ -89,7 +104,7 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 
 	srv.Tool("schedule.show").
 		Description("Get the schedule for a date").
-		Handler(func(ctx context.Context, input scheduleShowInput) (*scheduleQueries.ScheduleDTO, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleShowInput) (*scheduleQueries.ScheduleDTO, error) {
 			if app == nil || app.GetScheduleHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -102,11 +117,11 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 				UserID: app.CurrentUserID,
 				Date:   date,
 			})
-		})
+		}))
 
 	srv.Tool("schedule.week").
 		Description("Get schedule for a week").
-		Handler(func(ctx context.Context, input scheduleWeekInput) (map[string]any, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleWeekInput) (map[string]any, error) {
 			if app == nil || app.GetScheduleHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -116,7 +131,7 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 			}
 
 			now := time.Now()
-			weekStart := getWeekStart(now).AddDate(0, 0, offset*7)
+			weekStart := sharedDomain.StartOfWeek(now, weekStartsOn(app)).AddDate(0, 0, offset*7)
 			weekEnd := weekStart.AddDate(0, 0, 6)
 
 			days := make([]map[string]any, 0, 7)
@@ -154,11 +169,34 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 					"completed":     completedBlocks,
 				},
 			}, nil
-		})
+		}))
+
+	srv.Tool("schedule.stats").
+		Description("Get completed/missed/pending block statistics across a date range (defaults to the current week)").
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleStatsInput) (*scheduleQueries.ScheduleStatsDTO, error) {
+			if app == nil || app.GetScheduleStatsHandler == nil {
+				return nil, errors.New("schedule requires database connection")
+			}
+			weekStart := sharedDomain.StartOfWeek(time.Now(), weekStartsOn(app))
+			start, err := parseDate(input.Start, weekStart)
+			if err != nil {
+				return nil, err
+			}
+			end, err := parseDate(input.End, start.AddDate(0, 0, 6))
+			if err != nil {
+				return nil, err
+			}
+
+			return app.GetScheduleStatsHandler.Handle(ctx, scheduleQueries.GetScheduleStatsQuery{
+				UserID:    app.CurrentUserID,
+				StartDate: start,
+				EndDate:   end,
+			})
+		}))
 
 	srv.Tool("schedule.available").
 		Description("Find available time slots").
-		Handler(func(ctx context.Context, input scheduleAvailableInput) ([]scheduleQueries.TimeSlotDTO, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleAvailableInput) ([]scheduleQueries.TimeSlotDTO, error) {
 			if app == nil || app.FindAvailableSlotsHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -192,11 +230,11 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 				DayEnd:      dayEnd,
 				MinDuration: time.Duration(input.Min) * time.Minute,
 			})
-		})
+		}))
 
 	srv.Tool("schedule.add").
 		Description("Add a time block to schedule").
-		Handler(func(ctx context.Context, input scheduleAddInput) (*scheduleCommands.AddBlockResult, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleAddInput) (*scheduleCommands.AddBlockResult, error) {
 			if app == nil || app.AddBlockHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -231,11 +269,11 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 				StartTime:   startTime,
 				EndTime:     endTime,
 			})
-		})
+		}))
 
 	srv.Tool("schedule.complete").
-		Description("Mark a schedule block as completed").
-		Handler(func(ctx context.Context, input scheduleCompleteInput) (map[string]any, error) {
+		Description("Mark a schedule block as completed, optionally with notes and a 1-5 outcome rating").
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleCompleteInput) (map[string]any, error) {
 			if app == nil || app.CompleteBlockHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -249,17 +287,20 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 			}
 
 			if err := app.CompleteBlockHandler.Handle(ctx, scheduleCommands.CompleteBlockCommand{
-				ScheduleID: scheduleID,
-				BlockID:    blockID,
+				ScheduleID:    scheduleID,
+				BlockID:       blockID,
+				UserID:        app.CurrentUserID,
+				Notes:         input.Notes,
+				OutcomeRating: input.OutcomeRating,
 			}); err != nil {
 				return nil, err
 			}
 			return map[string]any{"schedule_id": scheduleID, "block_id": blockID, "completed": true}, nil
-		})
+		}))
 
 	srv.Tool("schedule.remove").
 		Description("Remove a time block").
-		Handler(func(ctx context.Context, input scheduleRemoveInput) (map[string]any, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleRemoveInput) (map[string]any, error) {
 			if app == nil || app.RemoveBlockHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -292,11 +333,11 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 			}
 
 			return map[string]any{"block_id": blockID, "removed": true, "warnings": warnings}, nil
-		})
+		}))
 
 	srv.Tool("schedule.reschedule").
 		Description("Reschedule a time block").
-		Handler(func(ctx context.Context, input scheduleRescheduleInput) (map[string]any, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleRescheduleInput) (map[string]any, error) {
 			if app == nil || app.RescheduleBlockHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -317,21 +358,31 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, err
 			}
 
-			if err := app.RescheduleBlockHandler.Handle(ctx, scheduleCommands.RescheduleBlockCommand{
+			result, err := app.RescheduleBlockHandler.Handle(ctx, scheduleCommands.RescheduleBlockCommand{
 				UserID:   app.CurrentUserID,
 				BlockID:  blockID,
 				Date:     date,
 				NewStart: startTime,
 				NewEnd:   endTime,
-			}); err != nil {
+			})
+			if err != nil {
 				return nil, err
 			}
-			return map[string]any{"block_id": blockID, "rescheduled": true}, nil
-		})
+			unmet := make([]map[string]any, 0, len(result.Unmet))
+			for _, dep := range result.Unmet {
+				unmet = append(unmet, map[string]any{"before_block_id": dep.BeforeID, "after_block_id": dep.AfterID})
+			}
+			return map[string]any{
+				"block_id":           blockID,
+				"rescheduled":        true,
+				"moved":              result.Moved,
+				"unmet_dependencies": unmet,
+			}, nil
+		}))
 
 	srv.Tool("schedule.reschedule_missed").
 		Description("Auto-reschedule missed blocks").
-		Handler(func(ctx context.Context, input scheduleRescheduleMissedInput) (*scheduleCommands.AutoRescheduleResult, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleRescheduleMissedInput) (*scheduleCommands.AutoRescheduleResult, error) {
 			if app == nil || app.AutoRescheduleHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -352,11 +403,33 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 				Date:   date,
 				After:  after,
 			})
-		})
+		}))
+
+	srv.Tool("schedule.reschedule_day").
+		Description("Move a day's incomplete blocks into free slots on another day").
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleRescheduleDayInput) (*scheduleCommands.RescheduleDayResult, error) {
+			if app == nil || app.RescheduleDayHandler == nil {
+				return nil, errors.New("schedule requires database connection")
+			}
+			from, err := parseDate(input.From, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			to, err := parseDate(input.To, from.AddDate(0, 0, 1))
+			if err != nil {
+				return nil, err
+			}
+
+			return app.RescheduleDayHandler.Handle(ctx, scheduleCommands.RescheduleDayCommand{
+				UserID:   app.CurrentUserID,
+				FromDate: from,
+				ToDate:   to,
+			})
+		}))
 
 	srv.Tool("schedule.reschedule_attempts").
 		Description("List reschedule attempts for a date").
-		Handler(func(ctx context.Context, input scheduleAttemptsInput) ([]scheduleQueries.RescheduleAttemptDTO, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleAttemptsInput) ([]scheduleQueries.RescheduleAttemptDTO, error) {
 			if app == nil || app.ListRescheduleAttemptsHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -369,11 +442,11 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 				UserID: app.CurrentUserID,
 				Date:   date,
 			})
-		})
+		}))
 
 	srv.Tool("schedule.auto").
 		Description("Auto-schedule pending tasks, habits, and meetings").
-		Handler(func(ctx context.Context, input scheduleAutoInput) (*scheduleCommands.AutoScheduleResult, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleAutoInput) (*scheduleCommands.AutoScheduleResult, error) {
 			if app == nil || app.AutoScheduleHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -391,15 +464,16 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 			}
 
 			return app.AutoScheduleHandler.Handle(ctx, scheduleCommands.AutoScheduleCommand{
-				UserID: app.CurrentUserID,
-				Date:   date,
-				Tasks:  items,
+				UserID:  app.CurrentUserID,
+				Date:    date,
+				Tasks:   items,
+				Explain: input.Explain,
 			})
-		})
+		}))
 
 	srv.Tool("schedule.import").
 		Description("Import calendar events into schedule").
-		Handler(func(ctx context.Context, input scheduleImportInput) (map[string]any, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input scheduleImportInput) (map[string]any, error) {
 			if app == nil || app.AddBlockHandler == nil {
 				return nil, errors.New("schedule requires database connection")
 			}
@@ -472,7 +546,7 @@ func registerScheduleTools(srv *mcp.Server, deps ToolDependencies) error {
 			}
 
 			return map[string]any{"created": created, "failed": failed}, nil
-		})
+		}))
 
 	return nil
 }
@@ -493,13 +567,13 @@ func isValidBlockType(blockType domain.BlockType) bool {
 	return false
 }
 
-func getWeekStart(t time.Time) time.Time {
-	weekday := int(t.Weekday())
-	if weekday == 0 {
-		weekday = 7
+// weekStartsOn returns the app's first day of the week, or the default when
+// no app is configured.
+func weekStartsOn(app *cli.App) time.Weekday {
+	if app == nil {
+		return sharedDomain.DefaultWeekStart
 	}
-	daysToMonday := weekday - 1
-	return time.Date(t.Year(), t.Month(), t.Day()-daysToMonday, 0, 0, 0, 0, t.Location())
+	return app.WeekStartsOn
 }
 
 func buildSchedulableItems(ctx context.Context, app *cli.App, date time.Time, includeHabits, includeMeetings bool) []scheduleCommands.SchedulableItem {
@@ -512,19 +586,7 @@ func buildSchedulableItems(ctx context.Context, app *cli.App, date time.Time, in
 		})
 		if err == nil {
 			for _, task := range tasks {
-				priority := 3
-				switch task.Priority {
-				case "urgent":
-					priority = 1
-				case "high":
-					priority = 2
-				case "medium":
-					priority = 3
-				case "low":
-					priority = 4
-				case "none":
-					priority = 5
-				}
+				priority := value_objects.EngineLevelFor(task.Priority)
 
 				duration := time.Duration(task.DurationMinutes) * time.Minute
 				if duration == 0 {
@@ -538,21 +600,17 @@ func buildSchedulableItems(ctx context.Context, app *cli.App, date time.Time, in
 					Priority: priority,
 					Duration: duration,
 					DueDate:  task.DueDate,
+
+					EarliestStart: task.EarliestStart,
 				})
 			}
 		}
 	}
 
-	if includeHabits && app.ListHabitsHandler != nil {
-		habits, err := app.ListHabitsHandler.Handle(ctx, habitQueries.ListHabitsQuery{
-			UserID:       app.CurrentUserID,
-			OnlyDueToday: true,
-		})
+	if includeHabits {
+		habits, err := app.DueHabits(ctx)
 		if err == nil {
 			for _, habit := range habits {
-				if habit.CompletedToday {
-					continue
-				}
 				priority := 2
 				switch habit.PreferredTime {
 				case "morning":
@@ -618,19 +676,7 @@ func runAutoSchedule(ctx context.Context, app *cli.App, date time.Time, tasks []
 	items := make([]scheduleCommands.SchedulableItem, 0)
 
 	for _, task := range tasks {
-		priority := 3
-		switch task.Priority {
-		case "urgent":
-			priority = 1
-		case "high":
-			priority = 2
-		case "medium":
-			priority = 3
-		case "low":
-			priority = 4
-		case "none":
-			priority = 5
-		}
+		priority := value_objects.EngineLevelFor(task.Priority)
 		duration := time.Duration(task.DurationMinutes) * time.Minute
 		if duration == 0 {
 			duration = 30 * time.Minute
@@ -642,6 +688,8 @@ func runAutoSchedule(ctx context.Context, app *cli.App, date time.Time, tasks []
 			Priority: priority,
 			Duration: duration,
 			DueDate:  task.DueDate,
+
+			EarliestStart: task.EarliestStart,
 		})
 	}
 
@@ -20,16 +20,18 @@ type taskCreateInput struct {
 }
 
 type taskListInput struct {
-	IncludeAll bool   `json:"include_all,omitempty"`
-	Status     string `json:"status,omitempty"`
-	Priority   string `json:"priority,omitempty"`
-	Overdue    bool   `json:"overdue,omitempty"`
-	DueToday   bool   `json:"due_today,omitempty"`
-	DueBefore  string `json:"due_before,omitempty"`
-	DueAfter   string `json:"due_after,omitempty"`
-	SortBy     string `json:"sort_by,omitempty"`
-	SortOrder  string `json:"sort_order,omitempty"`
-	Limit      int    `json:"limit,omitempty"`
+	IncludeAll bool     `json:"include_all,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Priority   string   `json:"priority,omitempty"`
+	Overdue    bool     `json:"overdue,omitempty"`
+	DueToday   bool     `json:"due_today,omitempty"`
+	DueBefore  string   `json:"due_before,omitempty"`
+	DueAfter   string   `json:"due_after,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	TagMatch   string   `json:"tag_match,omitempty"`
+	SortBy     string   `json:"sort_by,omitempty"`
+	SortOrder  string   `json:"sort_order,omitempty"`
+	Limit      int      `json:"limit,omitempty"`
 }
 
 type taskIDInput struct {
@@ -41,7 +43,7 @@ func registerTaskTools(srv *mcp.Server, deps ToolDependencies) error {
 
 	srv.Tool("task.create").
 		Description("Create a new task").
-		Handler(func(ctx context.Context, input taskCreateInput) (*commands.CreateTaskResult, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input taskCreateInput) (*commands.CreateTaskResult, error) {
 			if app == nil || app.CreateTaskHandler == nil {
 				return nil, errors.New("task creation requires database connection")
 			}
@@ -66,11 +68,11 @@ func registerTaskTools(srv *mcp.Server, deps ToolDependencies) error {
 				DurationMinutes: input.Duration,
 				DueDate:         due,
 			})
-		})
+		}))
 
 	srv.Tool("task.list").
 		Description("List tasks with filters").
-		Handler(func(ctx context.Context, input taskListInput) ([]queries.TaskDTO, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input taskListInput) ([]queries.TaskDTO, error) {
 			if app == nil || app.ListTasksHandler == nil {
 				return nil, errors.New("task listing requires database connection")
 			}
@@ -82,6 +84,8 @@ func registerTaskTools(srv *mcp.Server, deps ToolDependencies) error {
 				Priority:   input.Priority,
 				Overdue:    input.Overdue,
 				DueToday:   input.DueToday,
+				Tags:       input.Tags,
+				TagMatch:   queries.TagMatchMode(input.TagMatch),
 				SortBy:     input.SortBy,
 				SortOrder:  input.SortOrder,
 				Limit:      input.Limit,
@@ -103,11 +107,11 @@ func registerTaskTools(srv *mcp.Server, deps ToolDependencies) error {
 			}
 
 			return app.ListTasksHandler.Handle(ctx, query)
-		})
+		}))
 
 	srv.Tool("task.complete").
 		Description("Mark a task as complete").
-		Handler(func(ctx context.Context, input taskIDInput) (map[string]any, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input taskIDInput) (map[string]any, error) {
 			if app == nil || app.CompleteTaskHandler == nil {
 				return nil, errors.New("task completion requires database connection")
 			}
@@ -123,11 +127,11 @@ func registerTaskTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, err
 			}
 			return map[string]any{"task_id": taskID, "completed": true}, nil
-		})
+		}))
 
 	srv.Tool("task.archive").
 		Description("Archive a task").
-		Handler(func(ctx context.Context, input taskIDInput) (map[string]any, error) {
+		Handler(withErrorMapping(func(ctx context.Context, input taskIDInput) (map[string]any, error) {
 			if app == nil || app.ArchiveTaskHandler == nil {
 				return nil, errors.New("task archive requires database connection")
 			}
@@ -143,7 +147,7 @@ func registerTaskTools(srv *mcp.Server, deps ToolDependencies) error {
 				return nil, err
 			}
 			return map[string]any{"task_id": taskID, "archived": true}, nil
-		})
+		}))
 
 	return nil
 }
@@ -7,17 +7,21 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/felixgeelhaar/orbita/adapter/cli"
 	"github.com/felixgeelhaar/orbita/internal/app"
 	mcpinternal "github.com/felixgeelhaar/orbita/internal/mcp"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/telemetry"
 	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
 	"github.com/google/uuid"
 )
 
 func main() {
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+	logger := slog.New(observability.ContextHandler(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	})))
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -36,11 +40,28 @@ func main() {
 	}
 
 	if cfg.IsDevelopment() {
-		logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		logger = slog.New(observability.ContextHandler(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 			Level: slog.LevelDebug,
-		}))
+		})))
 	}
 
+	shutdownTelemetry, err := telemetry.Setup(ctx, telemetry.Config{
+		Enabled:        cfg.OTelEnabled,
+		Protocol:       cfg.OTelProtocol,
+		ServiceVersion: cli.Version,
+	})
+	if err != nil {
+		logger.Error("failed to set up telemetry", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTelemetry(shutdownCtx); err != nil {
+			logger.Warn("failed to flush telemetry", "error", err)
+		}
+	}()
+
 	container, err := app.NewContainer(ctx, cfg, logger)
 	if err != nil {
 		logger.Error("failed to initialize container", "error", err)
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	cliAuth "github.com/felixgeelhaar/orbita/adapter/cli/auth"
+	"github.com/felixgeelhaar/orbita/adapter/cli/automation"
+	cliBilling "github.com/felixgeelhaar/orbita/adapter/cli/billing"
+	"github.com/felixgeelhaar/orbita/adapter/cli/habit"
+	"github.com/felixgeelhaar/orbita/adapter/cli/importer"
+	"github.com/felixgeelhaar/orbita/adapter/cli/inbox"
+	"github.com/felixgeelhaar/orbita/adapter/cli/insights"
+	"github.com/felixgeelhaar/orbita/adapter/cli/license"
+	"github.com/felixgeelhaar/orbita/adapter/cli/mcp"
+	"github.com/felixgeelhaar/orbita/adapter/cli/meeting"
+	"github.com/felixgeelhaar/orbita/adapter/cli/project"
+	"github.com/felixgeelhaar/orbita/adapter/cli/schedule"
+	cliSettings "github.com/felixgeelhaar/orbita/adapter/cli/settings"
+	"github.com/felixgeelhaar/orbita/adapter/cli/task"
+	"github.com/felixgeelhaar/orbita/internal/app"
+	calendarDomain "github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	"github.com/felixgeelhaar/orbita/internal/marketplace/infrastructure/cliplugin"
+	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/google/uuid"
+)
+
+// newCLIApp builds the CLI app from the container's handlers and services,
+// acting for the configured user.
+func newCLIApp(cfg *config.Config, container *app.Container, logger *slog.Logger) (*cli.App, error) {
+	cliApp := cli.NewApp(
+		container.CreateTaskHandler,
+		container.CompleteTaskHandler,
+		container.ArchiveTaskHandler,
+		container.ListTasksHandler,
+		container.CreateHabitHandler,
+		container.LogCompletionHandler,
+		container.ArchiveHabitHandler,
+		container.AdjustHabitFrequencyHandler,
+		container.ListHabitsHandler,
+		container.CreateMeetingHandler,
+		container.UpdateMeetingHandler,
+		container.ArchiveMeetingHandler,
+		container.MarkMeetingHeldHandler,
+		container.AdjustMeetingCadenceHandler,
+		container.ListMeetingsHandler,
+		container.ListMeetingCandidatesHandler,
+		container.AddBlockHandler,
+		container.CompleteBlockHandler,
+		container.RemoveBlockHandler,
+		container.RescheduleBlockHandler,
+		container.AutoScheduleHandler,
+		container.AutoRescheduleHandler,
+		container.GetScheduleHandler,
+		container.FindAvailableSlotsHandler,
+		container.ListRescheduleAttemptsHandler,
+		container.CaptureInboxItemHandler,
+		container.PromoteInboxItemHandler,
+		container.ListInboxItemsHandler,
+		container.BillingService,
+	)
+
+	userID, err := uuid.Parse(cfg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	cliApp.SetCurrentUserID(userID)
+	cliApp.SetWeekStartsOn(container.WeekStartsOn)
+	cli.SetHealthChecks(container.HealthChecks(userID))
+	cli.SetCapabilities(container.Capabilities())
+	for _, c := range container.Capabilities().Unavailable() {
+		logger.Debug("feature unavailable", "feature", c.Name, "reason", c.Reason)
+	}
+
+	if container.AuthService != nil {
+		cliAuth.SetService(container.AuthService)
+	}
+	if container.CalendarSyncer != nil {
+		cliApp.SetCalendarSyncer(container.CalendarSyncer)
+	}
+
+	// Wire calendar multi-provider infrastructure
+	if container.ConnectedCalendarRepo != nil {
+		cliAuth.SetCalendarRepo(container.ConnectedCalendarRepo)
+		cliApp.SetCalendarRepo(container.ConnectedCalendarRepo)
+	}
+	if container.CalendarImportWorker != nil {
+		cliApp.SetImportWorker(container.CalendarImportWorker)
+	}
+	if container.SyncStateRepo != nil {
+		cliApp.SetSyncStateRepo(container.SyncStateRepo)
+	}
+	if container.ConnectCalendarService != nil {
+		cliAuth.SetConnectCalendarService(container.ConnectCalendarService)
+	}
+	if container.DisconnectCalendarService != nil {
+		cliAuth.SetDisconnectCalendarService(container.DisconnectCalendarService)
+	}
+	if container.ProviderRegistry != nil {
+		cliAuth.SetProviderRegistry(container.ProviderRegistry)
+		cliApp.SetProviderRegistry(container.ProviderRegistry)
+	}
+	if container.SyncCoordinator != nil {
+		cliAuth.SetSyncCoordinator(container.SyncCoordinator)
+		cliApp.SetSyncCoordinator(container.SyncCoordinator)
+	}
+	if container.MultiProviderOAuth != nil {
+		cliAuth.SetOAuthServiceGetter(func(provider calendarDomain.ProviderType) cliAuth.OAuthService {
+			return container.MultiProviderOAuth.GetCLIService(provider)
+		})
+	}
+	if container.GetTaskStatsHandler != nil {
+		cliApp.SetTaskStatsHandler(container.GetTaskStatsHandler)
+	}
+	cliApp.SetChecklistHandlers(container.AddChecklistItemHandler, container.ToggleChecklistItemHandler)
+	cliApp.SetBlockTaskHandlers(container.BlockTaskHandler, container.UnblockTaskHandler)
+	cliApp.SetWaitingTaskHandlers(container.WaitOnTaskHandler, container.ResolveWaitingTaskHandler)
+	cliApp.SetTagHandlers(container.BulkTagTasksHandler, container.BulkTagHabitsHandler)
+	cliApp.SetBulkLogCompletionHandler(container.BulkLogCompletionHandler)
+	cliApp.SetHabitPauseHandlers(container.SkipHabitHandler, container.FreezeHabitHandler, container.UnfreezeHabitHandler)
+	if container.RecommendHabitTimeHandler != nil {
+		cliApp.SetRecommendHabitTimeHandler(container.RecommendHabitTimeHandler)
+	}
+	cliApp.SetFocusModeHandlers(container.StartFocusModeHandler, container.EndFocusModeHandler)
+	cliApp.SetBlockDependencyHandlers(container.AddBlockDependencyHandler, container.RemoveBlockDependencyHandler)
+	cliApp.SetBlockAttachmentHandler(container.AddBlockAttachmentHandler)
+	cliApp.SetMarkMeetingCanceledHandler(container.MarkMeetingCanceledHandler)
+	if container.GetScheduleStatsHandler != nil {
+		cliApp.SetScheduleStatsHandler(container.GetScheduleStatsHandler)
+	}
+	if container.ImportTaskHandler != nil {
+		cliApp.SetImportTaskHandler(container.ImportTaskHandler)
+	}
+	if container.GetCapacityHandler != nil {
+		cliApp.SetCapacityHandler(container.GetCapacityHandler)
+	}
+	if container.GetDueHabitsHandler != nil {
+		cliApp.SetDueHabitsHandler(container.GetDueHabitsHandler)
+	}
+	if container.SearchEntitiesHandler != nil {
+		cliApp.SetSearchEntitiesHandler(container.SearchEntitiesHandler)
+	}
+	if container.RescheduleDayHandler != nil {
+		cliApp.SetRescheduleDayHandler(container.RescheduleDayHandler)
+	}
+	if container.SettingsService != nil {
+		cliApp.SetSettingsService(container.SettingsService)
+	}
+	if container.BillingService != nil {
+		cliApp.SetBillingService(container.BillingService)
+	}
+	if container.EngineRegistry != nil {
+		cliApp.SetEngineRegistry(container.EngineRegistry)
+	}
+	if container.EngineExecutor != nil {
+		cliApp.SetEngineExecutor(container.EngineExecutor)
+	}
+	if container.AutomationService != nil {
+		cliApp.SetAutomationService(container.AutomationService)
+	}
+	if container.OrbitRegistry != nil {
+		cliApp.SetOrbitRegistry(container.OrbitRegistry)
+		cliApp.SetOrbitSandbox(container.OrbitSandbox)
+		cliApp.SetOrbitExecutor(container.OrbitExecutor)
+	}
+	if container.InsightsService != nil {
+		insights.SetService(container.InsightsService)
+		cliApp.SetInsightsService(container.InsightsService)
+	}
+
+	// Set license service for local mode
+	if container.LicenseService != nil {
+		license.SetLicenseService(container.LicenseService)
+	}
+
+	// Wire project handlers
+	if container.CreateProjectHandler != nil {
+		cliApp.SetProjectHandlers(
+			container.CreateProjectHandler,
+			container.UpdateProjectHandler,
+			container.DeleteProjectHandler,
+			container.ChangeProjectStatusHandler,
+			container.AddMilestoneHandler,
+			container.UpdateMilestoneHandler,
+			container.DeleteMilestoneHandler,
+			container.LinkTaskHandler,
+			container.UnlinkTaskHandler,
+			container.GetProjectHandler,
+			container.ListProjectsHandler,
+		)
+	}
+
+	// Wire marketplace auth handlers
+	if container.MarketplaceWhoAmI != nil {
+		cliApp.SetMarketplaceAuthHandlers(container.MarketplaceLogin, container.MarketplaceLogout, container.MarketplaceWhoAmI)
+	}
+	if container.ListInstalledPackages != nil {
+		cliApp.SetListInstalledHandler(container.ListInstalledPackages)
+	}
+	if container.CheckUpdatesHandler != nil {
+		cliApp.SetMarketplaceUpdateHandlers(container.CheckUpdatesHandler, container.UpdatePackageHandler)
+	}
+
+	return cliApp, nil
+}
+
+// registerCommands adds the built-in commands and the commands contributed
+// by installed marketplace packages to the root command.
+func registerCommands(ctx context.Context, cfg *config.Config, launcher cliplugin.Launcher) {
+	cli.AddCommand(task.Cmd)
+	cli.AddCommand(habit.Cmd)
+	cli.AddCommand(inbox.Cmd)
+	cli.AddCommand(meeting.Cmd)
+	cli.AddCommand(project.Cmd)
+	cli.AddCommand(mcp.Cmd)
+	cli.AddCommand(schedule.Cmd)
+	cli.AddCommand(cliBilling.Cmd)
+	cli.AddCommand(cliAuth.Cmd)
+	cli.AddCommand(cliSettings.Cmd)
+	cli.AddCommand(automation.Cmd)
+	cli.AddCommand(insights.Cmd)
+	cli.AddCommand(importer.Cmd)
+	cli.AddCommand(license.Cmd)
+	cli.AddCommand(license.UpgradeCmd) // Also add at root level for convenience
+
+	cli.RegisterPluginCommands(ctx, cfg.MarketplaceInstallDir, launcher)
+}
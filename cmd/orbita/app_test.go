@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	"github.com/felixgeelhaar/orbita/internal/app"
+	marketplaceDomain "github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	"github.com/felixgeelhaar/orbita/internal/marketplace/infrastructure/cliplugin"
+	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubLauncher serves every plugin command in-process.
+type stubLauncher struct {
+	requests []cliplugin.CommandRequest
+}
+
+func (l *stubLauncher) Launch(manifest *cliplugin.Manifest) (cliplugin.Commander, func(), error) {
+	return l, func() {}, nil
+}
+
+func (l *stubLauncher) RunCommand(req cliplugin.CommandRequest) (*cliplugin.CommandResponse, error) {
+	l.requests = append(l.requests, req)
+	return &cliplugin.CommandResponse{Output: "standup started\n"}, nil
+}
+
+func TestStartup_RegistersInstalledPluginCommands(t *testing.T) {
+	tmpDir := t.TempDir()
+	userID := uuid.New()
+	cfg := &config.Config{
+		AppEnv:                "test",
+		LocalMode:             true,
+		DatabaseDriver:        "sqlite",
+		SQLitePath:            filepath.Join(tmpDir, "test.db"),
+		UserID:                userID.String(),
+		MarketplaceInstallDir: filepath.Join(tmpDir, "packages"),
+	}
+
+	packageDir := filepath.Join(cfg.MarketplaceInstallDir, "orbits", "acme.standup", "1.0.0")
+	require.NoError(t, os.MkdirAll(packageDir, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(packageDir, "orbit.json"), []byte(`{
+		"id": "acme.standup",
+		"cli": {"namespace": "standup", "binary": "bin/standup", "commands": [{"name": "start"}]}
+	}`), 0600))
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	container, err := app.NewLocalContainer(ctx, cfg, logger)
+	require.NoError(t, err)
+	defer container.Close()
+
+	cliApp, err := newCLIApp(cfg, container, logger)
+	require.NoError(t, err)
+	cli.SetApp(cliApp)
+	defer cli.SetApp(nil)
+
+	launcher := &stubLauncher{}
+	registerCommands(ctx, cfg, launcher)
+
+	root := cli.RootCommand()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs([]string{"standup", "start", "deep work"})
+	require.NoError(t, root.Execute())
+
+	assert.Equal(t, "standup started\n", out.String())
+	require.Len(t, launcher.requests, 1)
+	assert.Equal(t, "start", launcher.requests[0].Command)
+	assert.Equal(t, []string{"deep work"}, launcher.requests[0].Args)
+	assert.Equal(t, userID.String(), launcher.requests[0].UserID)
+}
+
+func TestStartup_MarketplaceCheckUpdatesInLocalMode(t *testing.T) {
+	tmpDir := t.TempDir()
+	userID := uuid.New()
+	cfg := &config.Config{
+		AppEnv:                "test",
+		LocalMode:             true,
+		DatabaseDriver:        "sqlite",
+		SQLitePath:            filepath.Join(tmpDir, "test.db"),
+		UserID:                userID.String(),
+		MarketplaceInstallDir: filepath.Join(tmpDir, "packages"),
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	container, err := app.NewLocalContainer(ctx, cfg, logger)
+	require.NoError(t, err)
+	defer container.Close()
+
+	focus := marketplaceDomain.NewPackage("acme.focus", marketplaceDomain.PackageTypeOrbit, "Focus", "")
+	focus.SetLatestVersion("1.2.0")
+	require.NoError(t, container.MarketplacePackageRepo.Create(ctx, focus))
+	require.NoError(t, container.MarketplaceInstalledRepo.Create(ctx,
+		marketplaceDomain.NewInstalledPackage("acme.focus", "1.0.0", marketplaceDomain.PackageTypeOrbit, filepath.Join(cfg.MarketplaceInstallDir, "acme.focus"), userID)))
+
+	cliApp, err := newCLIApp(cfg, container, logger)
+	require.NoError(t, err)
+	cli.SetApp(cliApp)
+	defer cli.SetApp(nil)
+
+	root := cli.RootCommand()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	defer root.SetOut(nil)
+
+	root.SetArgs([]string{"marketplace", "check-updates"})
+	require.NoError(t, root.Execute())
+	assert.Contains(t, out.String(), "acme.focus\n    Installed: 1.0.0\n    Available: 1.2.0")
+
+	out.Reset()
+	root.SetArgs([]string{"marketplace", "update", "--all", "--dry-run"})
+	require.NoError(t, root.Execute())
+	assert.Contains(t, out.String(), "Dry run: 1 of 1 packages would be updated")
+}
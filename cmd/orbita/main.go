@@ -6,32 +6,22 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/adapter/cli"
-	cliAuth "github.com/felixgeelhaar/orbita/adapter/cli/auth"
-	"github.com/felixgeelhaar/orbita/adapter/cli/automation"
-	cliBilling "github.com/felixgeelhaar/orbita/adapter/cli/billing"
-	"github.com/felixgeelhaar/orbita/adapter/cli/habit"
-	"github.com/felixgeelhaar/orbita/adapter/cli/inbox"
-	"github.com/felixgeelhaar/orbita/adapter/cli/insights"
-	"github.com/felixgeelhaar/orbita/adapter/cli/license"
-	"github.com/felixgeelhaar/orbita/adapter/cli/mcp"
-	"github.com/felixgeelhaar/orbita/adapter/cli/meeting"
-	"github.com/felixgeelhaar/orbita/adapter/cli/project"
-	"github.com/felixgeelhaar/orbita/adapter/cli/schedule"
-	cliSettings "github.com/felixgeelhaar/orbita/adapter/cli/settings"
-	"github.com/felixgeelhaar/orbita/adapter/cli/task"
 	"github.com/felixgeelhaar/orbita/internal/app"
-	calendarDomain "github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	"github.com/felixgeelhaar/orbita/internal/marketplace/infrastructure/cliplugin"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/telemetry"
 	"github.com/felixgeelhaar/orbita/pkg/config"
-	"github.com/google/uuid"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
 )
 
 func main() {
 	// Setup logger
-	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+	logger := slog.New(observability.ContextHandler(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	})))
 
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
@@ -55,12 +45,30 @@ func main() {
 
 	// Update logger level based on config
 	if cfg.IsDevelopment() {
-		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		logger = slog.New(observability.ContextHandler(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 			Level: slog.LevelDebug,
-		}))
+		})))
 	}
 	cli.SetLogger(logger)
 
+	// Export engine and orbit telemetry when an OTLP endpoint is configured
+	shutdownTelemetry, err := telemetry.Setup(ctx, telemetry.Config{
+		Enabled:        cfg.OTelEnabled,
+		Protocol:       cfg.OTelProtocol,
+		ServiceVersion: cli.Version,
+	})
+	if err != nil {
+		logger.Warn("failed to set up telemetry, continuing without it", "error", err)
+	} else {
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTelemetry(shutdownCtx); err != nil {
+				logger.Warn("failed to flush telemetry", "error", err)
+			}
+		}()
+	}
+
 	// Initialize container based on mode
 	var cliApp *cli.App
 	var container *app.Container
@@ -81,6 +89,8 @@ func main() {
 	if err != nil {
 		if cfg.IsDevelopment() {
 			logger.Warn("failed to initialize container, running in limited mode", "error", err)
+			cli.SetHealthChecks(app.StartupFailureChecks(cfg, err))
+			cli.SetCapabilities(app.StartupFailureCapabilities(err))
 			// In development, allow CLI to run without database
 			cliApp = nil
 		} else {
@@ -90,9 +100,12 @@ func main() {
 	} else {
 		defer container.Close()
 
+		// Background workers act for every user rather than one principal.
+		workerCtx := sharedApplication.WithSystem(ctx)
+
 		// Start outbox processor in background (optional in CLI, not available in local mode)
 		if cfg.OutboxProcessorEnabled && container.OutboxProcessor != nil {
-			go container.OutboxProcessor.Start(ctx)
+			go container.OutboxProcessor.Start(workerCtx)
 		} else if container.OutboxProcessor == nil {
 			logger.Debug("outbox processor not available in local mode")
 		} else {
@@ -101,121 +114,45 @@ func main() {
 
 		// Start calendar import worker in background (for automatic external calendar sync)
 		if container.CalendarImportWorker != nil {
-			go container.CalendarImportWorker.Run(ctx)
+			go container.CalendarImportWorker.Run(workerCtx)
 			logger.Info("calendar import worker started")
 		}
 
-		// Create CLI app with handlers
-		cliApp = cli.NewApp(
-			container.CreateTaskHandler,
-			container.CompleteTaskHandler,
-			container.ArchiveTaskHandler,
-			container.ListTasksHandler,
-			container.CreateHabitHandler,
-			container.LogCompletionHandler,
-			container.ArchiveHabitHandler,
-			container.AdjustHabitFrequencyHandler,
-			container.ListHabitsHandler,
-			container.CreateMeetingHandler,
-			container.UpdateMeetingHandler,
-			container.ArchiveMeetingHandler,
-			container.MarkMeetingHeldHandler,
-			container.AdjustMeetingCadenceHandler,
-			container.ListMeetingsHandler,
-			container.ListMeetingCandidatesHandler,
-			container.AddBlockHandler,
-			container.CompleteBlockHandler,
-			container.RemoveBlockHandler,
-			container.RescheduleBlockHandler,
-			container.AutoScheduleHandler,
-			container.AutoRescheduleHandler,
-			container.GetScheduleHandler,
-			container.FindAvailableSlotsHandler,
-			container.ListRescheduleAttemptsHandler,
-			container.CaptureInboxItemHandler,
-			container.PromoteInboxItemHandler,
-			container.ListInboxItemsHandler,
-			container.BillingService,
-		)
-
-		userID, err := uuid.Parse(cfg.UserID)
-		if err != nil {
-			logger.Error("invalid ORBITA_USER_ID", "error", err)
-			os.Exit(1)
+		// Start task reminder dispatcher in background
+		if container.ReminderDispatcher != nil {
+			go container.ReminderDispatcher.Run(workerCtx)
 		}
-		cliApp.SetCurrentUserID(userID)
 
-		if container.AuthService != nil {
-			cliAuth.SetService(container.AuthService)
-		}
-		if container.CalendarSyncer != nil {
-			cliApp.SetCalendarSyncer(container.CalendarSyncer)
+		// Start task priority escalator in background
+		if container.PriorityEscalator != nil {
+			go container.PriorityEscalator.Run(workerCtx)
 		}
 
-		// Wire calendar multi-provider infrastructure
-		if container.ConnectedCalendarRepo != nil {
-			cliAuth.SetCalendarRepo(container.ConnectedCalendarRepo)
-			cliApp.SetCalendarRepo(container.ConnectedCalendarRepo)
-		}
-		if container.ConnectCalendarService != nil {
-			cliAuth.SetConnectCalendarService(container.ConnectCalendarService)
-		}
-		if container.DisconnectCalendarService != nil {
-			cliAuth.SetDisconnectCalendarService(container.DisconnectCalendarService)
-		}
-		if container.ProviderRegistry != nil {
-			cliAuth.SetProviderRegistry(container.ProviderRegistry)
-			cliApp.SetProviderRegistry(container.ProviderRegistry)
+		// Start completed task archiver in background
+		if container.TaskArchiver != nil {
+			go container.TaskArchiver.Run(workerCtx)
 		}
-		if container.SyncCoordinator != nil {
-			cliAuth.SetSyncCoordinator(container.SyncCoordinator)
-			cliApp.SetSyncCoordinator(container.SyncCoordinator)
-		}
-		if container.MultiProviderOAuth != nil {
-			cliAuth.SetOAuthServiceGetter(func(provider calendarDomain.ProviderType) cliAuth.OAuthService {
-				return container.MultiProviderOAuth.GetCLIService(provider)
-			})
-		}
-		if container.SettingsService != nil {
-			cliApp.SetSettingsService(container.SettingsService)
-		}
-		if container.BillingService != nil {
-			cliApp.SetBillingService(container.BillingService)
-		}
-		if container.EngineRegistry != nil {
-			cliApp.SetEngineRegistry(container.EngineRegistry)
-		}
-		if container.EngineExecutor != nil {
-			cliApp.SetEngineExecutor(container.EngineExecutor)
-		}
-		if container.AutomationService != nil {
-			cliApp.SetAutomationService(container.AutomationService)
+
+		// Start schedule block retention sweeper in background
+		if container.BlockRetentionSweeper != nil {
+			go container.BlockRetentionSweeper.Run(workerCtx)
 		}
-		if container.InsightsService != nil {
-			insights.SetService(container.InsightsService)
-			cliApp.SetInsightsService(container.InsightsService)
+
+		// Start inbox expiry sweeper in background
+		if container.InboxExpirySweeper != nil {
+			go container.InboxExpirySweeper.Run(workerCtx)
 		}
 
-		// Set license service for local mode
-		if container.LicenseService != nil {
-			license.SetLicenseService(container.LicenseService)
+		// Start scheduled digest sender in background
+		if container.DigestSender != nil {
+			go container.DigestSender.Run(workerCtx)
 		}
 
-		// Wire project handlers
-		if container.CreateProjectHandler != nil {
-			cliApp.SetProjectHandlers(
-				container.CreateProjectHandler,
-				container.UpdateProjectHandler,
-				container.DeleteProjectHandler,
-				container.ChangeProjectStatusHandler,
-				container.AddMilestoneHandler,
-				container.UpdateMilestoneHandler,
-				container.DeleteMilestoneHandler,
-				container.LinkTaskHandler,
-				container.UnlinkTaskHandler,
-				container.GetProjectHandler,
-				container.ListProjectsHandler,
-			)
+		// Create CLI app with handlers
+		cliApp, err = newCLIApp(cfg, container, logger)
+		if err != nil {
+			logger.Error("invalid ORBITA_USER_ID", "error", err)
+			os.Exit(1)
 		}
 	}
 
@@ -223,20 +160,7 @@ func main() {
 	cli.SetApp(cliApp)
 
 	// Register commands
-	cli.AddCommand(task.Cmd)
-	cli.AddCommand(habit.Cmd)
-	cli.AddCommand(inbox.Cmd)
-	cli.AddCommand(meeting.Cmd)
-	cli.AddCommand(project.Cmd)
-	cli.AddCommand(mcp.Cmd)
-	cli.AddCommand(schedule.Cmd)
-	cli.AddCommand(cliBilling.Cmd)
-	cli.AddCommand(cliAuth.Cmd)
-	cli.AddCommand(cliSettings.Cmd)
-	cli.AddCommand(automation.Cmd)
-	cli.AddCommand(insights.Cmd)
-	cli.AddCommand(license.Cmd)
-	cli.AddCommand(license.UpgradeCmd) // Also add at root level for convenience
+	registerCommands(ctx, cfg, cliplugin.NewProcessLauncher(logger))
 
 	// Execute CLI
 	cli.Execute()
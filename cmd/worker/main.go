@@ -11,10 +11,11 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/database"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/database/postgres"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/eventbus"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
 	"github.com/felixgeelhaar/orbita/pkg/config"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 func main() {
@@ -53,7 +54,13 @@ func main() {
 	}
 
 	// Connect to database
-	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	pool, err := postgres.NewPool(ctx, database.Config{
+		URL:             cfg.DatabaseURL,
+		MaxConns:        cfg.DatabaseMaxConns,
+		MinConns:        cfg.DatabaseMinConns,
+		MaxConnLifetime: cfg.DatabaseMaxConnLifetime,
+		MaxConnIdleTime: cfg.DatabaseMaxConnIdleTime,
+	})
 	if err != nil {
 		logger.Error("failed to connect to database", "error", err)
 		os.Exit(1)
@@ -71,7 +78,15 @@ func main() {
 
 	// Create event publisher
 	var publisher eventbus.Publisher
-	rabbitPublisher, err := eventbus.NewRabbitMQPublisher(cfg.RabbitMQURL, logger)
+	rabbitPublisher, err := eventbus.NewRabbitMQPublisherWithConfig(eventbus.RabbitMQPublisherConfig{
+		URL:                     cfg.RabbitMQURL,
+		Logger:                  logger,
+		ReconnectInitialBackoff: cfg.RabbitMQReconnectInitialBackoff,
+		ReconnectMaxBackoff:     cfg.RabbitMQReconnectMaxBackoff,
+		BufferSize:              cfg.RabbitMQPublishBufferSize,
+		ConfirmBatchSize:        cfg.RabbitMQConfirmBatchSize,
+		ConfirmTimeout:          cfg.RabbitMQConfirmTimeout,
+	})
 	if err != nil {
 		if cfg.IsDevelopment() {
 			logger.Warn("RabbitMQ not available, using noop publisher", "error", err)
@@ -139,6 +154,7 @@ func main() {
 				"last_processed_at": stats.LastProcessedAt,
 				"last_error_at":     stats.LastErrorAt,
 				"last_error":        stats.LastError,
+				"database_pool":     postgres.Stats(pool),
 			}
 			w.Header().Set("Content-Type", "application/json")
 			_ = json.NewEncoder(w).Encode(response)
@@ -202,6 +218,15 @@ func main() {
 					"last_error_at", stats.LastErrorAt,
 					"last_error", stats.LastError,
 				)
+				poolStats := postgres.Stats(pool)
+				logger.Info("database pool stats",
+					"max_conns", poolStats.MaxConns,
+					"total_conns", poolStats.TotalConns,
+					"acquired_conns", poolStats.AcquiredConns,
+					"idle_conns", poolStats.IdleConns,
+					"wait_count", poolStats.WaitCount,
+					"wait_duration", poolStats.WaitDuration,
+				)
 			}
 		}
 	}()
@@ -368,6 +368,46 @@ func (q *Queries) GetActiveTimeSession(ctx context.Context, userID pgtype.UUID)
 	return i, err
 }
 
+const getAnalyticsDataChangedAt = `-- name: GetAnalyticsDataChangedAt :one
+SELECT GREATEST(
+    (
+        SELECT MAX(t.updated_at)
+        FROM tasks t
+        WHERE t.user_id = $1::uuid
+          AND t.created_at >= $2::timestamptz
+          AND t.created_at < $3::timestamptz
+    ),
+    (
+        SELECT MAX(b.updated_at)
+        FROM time_blocks b
+        WHERE b.user_id = $1::uuid
+          AND b.start_time >= $2::timestamptz
+          AND b.start_time < $3::timestamptz
+    ),
+    (
+        SELECT MAX(h.updated_at)
+        FROM habits h
+        JOIN habit_completions hc ON hc.habit_id = h.id
+        WHERE h.user_id = $1::uuid
+          AND hc.completed_at >= $2::timestamptz
+          AND hc.completed_at < $3::timestamptz
+    )
+)::timestamptz as changed_at
+`
+
+type GetAnalyticsDataChangedAtParams struct {
+	UserID  pgtype.UUID        `json:"user_id"`
+	StartAt pgtype.Timestamptz `json:"start_at"`
+	EndAt   pgtype.Timestamptz `json:"end_at"`
+}
+
+func (q *Queries) GetAnalyticsDataChangedAt(ctx context.Context, arg GetAnalyticsDataChangedAtParams) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getAnalyticsDataChangedAt, arg.UserID, arg.StartAt, arg.EndAt)
+	var changed_at pgtype.Timestamptz
+	err := row.Scan(&changed_at)
+	return changed_at, err
+}
+
 const getAverageProductivityScore = `-- name: GetAverageProductivityScore :one
 SELECT COALESCE(AVG(productivity_score), 0)::INTEGER as avg_score
 FROM productivity_snapshots
@@ -389,6 +429,54 @@ func (q *Queries) GetAverageProductivityScore(ctx context.Context, arg GetAverag
 	return avg_score, err
 }
 
+const getFocusStatsByDateRange = `-- name: GetFocusStatsByDateRange :one
+SELECT
+    COALESCE(SUM(EXTRACT(EPOCH FROM (end_time - start_time)) / 60) FILTER (WHERE block_type IN ('task', 'habit', 'focus')), 0)::INTEGER as scheduled_minutes,
+    COALESCE(SUM(EXTRACT(EPOCH FROM (end_time - start_time)) / 60) FILTER (WHERE block_type IN ('task', 'habit', 'focus') AND completed = true), 0)::INTEGER as completed_minutes,
+    COUNT(*) FILTER (WHERE block_type = 'break') as breaks_scheduled,
+    COUNT(*) FILTER (WHERE block_type = 'break' AND completed = true) as breaks_taken,
+    (
+        SELECT COUNT(*)
+        FROM reschedule_attempts ra
+        WHERE ra.user_id = $1
+          AND ra.attempt_type = 'auto-conflict'
+          AND ra.success = true
+          AND ra.old_start_time >= $2
+          AND ra.old_start_time < $3
+    ) as interruptions
+FROM time_blocks
+WHERE user_id = $1
+  AND start_time >= $2
+  AND start_time < $3
+`
+
+type GetFocusStatsByDateRangeParams struct {
+	UserID      pgtype.UUID        `json:"user_id"`
+	StartTime   pgtype.Timestamptz `json:"start_time"`
+	StartTime_2 pgtype.Timestamptz `json:"start_time_2"`
+}
+
+type GetFocusStatsByDateRangeRow struct {
+	ScheduledMinutes int32 `json:"scheduled_minutes"`
+	CompletedMinutes int32 `json:"completed_minutes"`
+	BreaksScheduled  int64 `json:"breaks_scheduled"`
+	BreaksTaken      int64 `json:"breaks_taken"`
+	Interruptions    int64 `json:"interruptions"`
+}
+
+func (q *Queries) GetFocusStatsByDateRange(ctx context.Context, arg GetFocusStatsByDateRangeParams) (GetFocusStatsByDateRangeRow, error) {
+	row := q.db.QueryRow(ctx, getFocusStatsByDateRange, arg.UserID, arg.StartTime, arg.StartTime_2)
+	var i GetFocusStatsByDateRangeRow
+	err := row.Scan(
+		&i.ScheduledMinutes,
+		&i.CompletedMinutes,
+		&i.BreaksScheduled,
+		&i.BreaksTaken,
+		&i.Interruptions,
+	)
+	return i, err
+}
+
 const getHabitCompletionsByDateRange = `-- name: GetHabitCompletionsByDateRange :one
 SELECT COUNT(*) as completions
 FROM habit_completions hc
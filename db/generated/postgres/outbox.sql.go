@@ -79,7 +79,16 @@ SELECT id, aggregate_type, aggregate_id, event_type, routing_key, payload, metad
 WHERE published_at IS NULL
   AND dead_lettered_at IS NULL
   AND (next_retry_at IS NULL OR next_retry_at <= NOW())
-ORDER BY created_at
+  AND NOT EXISTS (
+      SELECT 1 FROM outbox earlier
+      WHERE earlier.aggregate_type = outbox.aggregate_type
+        AND earlier.aggregate_id = outbox.aggregate_id
+        AND earlier.id < outbox.id
+        AND earlier.published_at IS NULL
+        AND earlier.dead_lettered_at IS NULL
+        AND earlier.next_retry_at > NOW()
+  )
+ORDER BY created_at, id
 LIMIT $1
 `
 
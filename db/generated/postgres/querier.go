@@ -70,6 +70,7 @@ type Querier interface {
 	GetActiveProductivityGoals(ctx context.Context, userID pgtype.UUID) ([]ProductivityGoal, error)
 	GetActiveProjects(ctx context.Context, userID pgtype.UUID) ([]Project, error)
 	GetActiveTimeSession(ctx context.Context, userID pgtype.UUID) (TimeSession, error)
+	GetAnalyticsDataChangedAt(ctx context.Context, arg GetAnalyticsDataChangedAtParams) (pgtype.Timestamptz, error)
 	// Automation Pending Actions
 	GetAutomationPendingActionByID(ctx context.Context, id pgtype.UUID) (AutomationPendingAction, error)
 	GetAutomationPendingActionsByExecutionID(ctx context.Context, executionID pgtype.UUID) ([]AutomationPendingAction, error)
@@ -91,6 +92,7 @@ type Querier interface {
 	GetEnabledPullCalendarsByUser(ctx context.Context, userID pgtype.UUID) ([]GetEnabledPullCalendarsByUserRow, error)
 	GetEnabledPushCalendarsByUser(ctx context.Context, userID pgtype.UUID) ([]GetEnabledPushCalendarsByUserRow, error)
 	GetFailedEvents(ctx context.Context, arg GetFailedEventsParams) ([]Outbox, error)
+	GetFocusStatsByDateRange(ctx context.Context, arg GetFocusStatsByDateRangeParams) (GetFocusStatsByDateRangeRow, error)
 	GetHabitByID(ctx context.Context, id pgtype.UUID) (Habit, error)
 	GetHabitCompletionsByDateRange(ctx context.Context, arg GetHabitCompletionsByDateRangeParams) (int64, error)
 	GetHabitCompletionsByHabitID(ctx context.Context, habitID pgtype.UUID) ([]HabitCompletion, error)
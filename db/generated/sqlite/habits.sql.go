@@ -102,7 +102,7 @@ func (q *Queries) DeleteHabitCompletionsByHabitID(ctx context.Context, habitID s
 const getActiveHabitsByUserID = `-- name: GetActiveHabitsByUserID :many
 SELECT id, user_id, name, description, frequency, times_per_week,
        duration_minutes, preferred_time, streak, best_streak, total_done,
-       archived, created_at, updated_at
+       archived, created_at, updated_at, version
 FROM habits
 WHERE user_id = ? AND archived = 0
 ORDER BY created_at DESC
@@ -132,6 +132,7 @@ func (q *Queries) GetActiveHabitsByUserID(ctx context.Context, userID string) ([
 			&i.Archived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -149,7 +150,7 @@ func (q *Queries) GetActiveHabitsByUserID(ctx context.Context, userID string) ([
 const getHabitByID = `-- name: GetHabitByID :one
 SELECT id, user_id, name, description, frequency, times_per_week,
        duration_minutes, preferred_time, streak, best_streak, total_done,
-       archived, created_at, updated_at
+       archived, created_at, updated_at, version
 FROM habits
 WHERE id = ?
 `
@@ -172,6 +173,7 @@ func (q *Queries) GetHabitByID(ctx context.Context, id string) (Habit, error) {
 		&i.Archived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -256,7 +258,7 @@ func (q *Queries) GetHabitCompletionsByHabitIDSince(ctx context.Context, arg Get
 const getHabitsByUserID = `-- name: GetHabitsByUserID :many
 SELECT id, user_id, name, description, frequency, times_per_week,
        duration_minutes, preferred_time, streak, best_streak, total_done,
-       archived, created_at, updated_at
+       archived, created_at, updated_at, version
 FROM habits
 WHERE user_id = ?
 ORDER BY created_at DESC
@@ -286,6 +288,7 @@ func (q *Queries) GetHabitsByUserID(ctx context.Context, userID string) ([]Habit
 			&i.Archived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -300,7 +303,7 @@ func (q *Queries) GetHabitsByUserID(ctx context.Context, userID string) ([]Habit
 	return items, nil
 }
 
-const updateHabit = `-- name: UpdateHabit :exec
+const updateHabit = `-- name: UpdateHabit :execrows
 UPDATE habits
 SET name = ?,
     description = ?,
@@ -312,8 +315,9 @@ SET name = ?,
     best_streak = ?,
     total_done = ?,
     archived = ?,
-    updated_at = ?
-WHERE id = ?
+    updated_at = ?,
+    version = version + 1
+WHERE id = ? AND version = ?
 `
 
 type UpdateHabitParams struct {
@@ -329,10 +333,11 @@ type UpdateHabitParams struct {
 	Archived        int64          `json:"archived"`
 	UpdatedAt       string         `json:"updated_at"`
 	ID              string         `json:"id"`
+	Version         int64          `json:"version"`
 }
 
-func (q *Queries) UpdateHabit(ctx context.Context, arg UpdateHabitParams) error {
-	_, err := q.db.ExecContext(ctx, updateHabit,
+func (q *Queries) UpdateHabit(ctx context.Context, arg UpdateHabitParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateHabit,
 		arg.Name,
 		arg.Description,
 		arg.Frequency,
@@ -345,6 +350,10 @@ func (q *Queries) UpdateHabit(ctx context.Context, arg UpdateHabitParams) error
 		arg.Archived,
 		arg.UpdatedAt,
 		arg.ID,
+		arg.Version,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
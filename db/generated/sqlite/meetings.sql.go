@@ -13,8 +13,9 @@ import (
 const createMeeting = `-- name: CreateMeeting :exec
 INSERT INTO meetings (
     id, user_id, name, cadence, cadence_days, duration_minutes,
-    preferred_time_minutes, last_held_at, archived, created_at, updated_at
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+    external_series_id, held_count, canceled_count, last_canceled_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateMeetingParams struct {
@@ -29,6 +30,10 @@ type CreateMeetingParams struct {
 	Archived             int64          `json:"archived"`
 	CreatedAt            string         `json:"created_at"`
 	UpdatedAt            string         `json:"updated_at"`
+	ExternalSeriesID     sql.NullString `json:"external_series_id"`
+	HeldCount            int64          `json:"held_count"`
+	CanceledCount        int64          `json:"canceled_count"`
+	LastCanceledAt       sql.NullString `json:"last_canceled_at"`
 }
 
 func (q *Queries) CreateMeeting(ctx context.Context, arg CreateMeetingParams) error {
@@ -44,6 +49,10 @@ func (q *Queries) CreateMeeting(ctx context.Context, arg CreateMeetingParams) er
 		arg.Archived,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.ExternalSeriesID,
+		arg.HeldCount,
+		arg.CanceledCount,
+		arg.LastCanceledAt,
 	)
 	return err
 }
@@ -59,7 +68,8 @@ func (q *Queries) DeleteMeeting(ctx context.Context, id string) error {
 
 const getActiveMeetingsByUserID = `-- name: GetActiveMeetingsByUserID :many
 SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
-       preferred_time_minutes, last_held_at, archived, created_at, updated_at
+       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+       external_series_id, version, held_count, canceled_count, last_canceled_at
 FROM meetings
 WHERE user_id = ? AND archived = 0
 ORDER BY created_at DESC
@@ -86,6 +96,11 @@ func (q *Queries) GetActiveMeetingsByUserID(ctx context.Context, userID string)
 			&i.Archived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ExternalSeriesID,
+			&i.Version,
+			&i.HeldCount,
+			&i.CanceledCount,
+			&i.LastCanceledAt,
 		); err != nil {
 			return nil, err
 		}
@@ -100,9 +115,47 @@ func (q *Queries) GetActiveMeetingsByUserID(ctx context.Context, userID string)
 	return items, nil
 }
 
+const getMeetingByExternalSeriesID = `-- name: GetMeetingByExternalSeriesID :one
+SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
+       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+       external_series_id, version, held_count, canceled_count, last_canceled_at
+FROM meetings
+WHERE user_id = ? AND external_series_id = ?
+`
+
+type GetMeetingByExternalSeriesIDParams struct {
+	UserID           string         `json:"user_id"`
+	ExternalSeriesID sql.NullString `json:"external_series_id"`
+}
+
+func (q *Queries) GetMeetingByExternalSeriesID(ctx context.Context, arg GetMeetingByExternalSeriesIDParams) (Meeting, error) {
+	row := q.db.QueryRowContext(ctx, getMeetingByExternalSeriesID, arg.UserID, arg.ExternalSeriesID)
+	var i Meeting
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Cadence,
+		&i.CadenceDays,
+		&i.DurationMinutes,
+		&i.PreferredTimeMinutes,
+		&i.LastHeldAt,
+		&i.Archived,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExternalSeriesID,
+		&i.Version,
+		&i.HeldCount,
+		&i.CanceledCount,
+		&i.LastCanceledAt,
+	)
+	return i, err
+}
+
 const getMeetingByID = `-- name: GetMeetingByID :one
 SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
-       preferred_time_minutes, last_held_at, archived, created_at, updated_at
+       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+       external_series_id, version, held_count, canceled_count, last_canceled_at
 FROM meetings
 WHERE id = ?
 `
@@ -122,13 +175,19 @@ func (q *Queries) GetMeetingByID(ctx context.Context, id string) (Meeting, error
 		&i.Archived,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ExternalSeriesID,
+		&i.Version,
+		&i.HeldCount,
+		&i.CanceledCount,
+		&i.LastCanceledAt,
 	)
 	return i, err
 }
 
 const getMeetingsByUserID = `-- name: GetMeetingsByUserID :many
 SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
-       preferred_time_minutes, last_held_at, archived, created_at, updated_at
+       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+       external_series_id, version, held_count, canceled_count, last_canceled_at
 FROM meetings
 WHERE user_id = ?
 ORDER BY created_at DESC
@@ -155,6 +214,11 @@ func (q *Queries) GetMeetingsByUserID(ctx context.Context, userID string) ([]Mee
 			&i.Archived,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ExternalSeriesID,
+			&i.Version,
+			&i.HeldCount,
+			&i.CanceledCount,
+			&i.LastCanceledAt,
 		); err != nil {
 			return nil, err
 		}
@@ -169,7 +233,7 @@ func (q *Queries) GetMeetingsByUserID(ctx context.Context, userID string) ([]Mee
 	return items, nil
 }
 
-const updateMeeting = `-- name: UpdateMeeting :exec
+const updateMeeting = `-- name: UpdateMeeting :execrows
 UPDATE meetings
 SET name = ?,
     cadence = ?,
@@ -178,8 +242,13 @@ SET name = ?,
     preferred_time_minutes = ?,
     last_held_at = ?,
     archived = ?,
-    updated_at = ?
-WHERE id = ?
+    updated_at = ?,
+    external_series_id = ?,
+    held_count = ?,
+    canceled_count = ?,
+    last_canceled_at = ?,
+    version = version + 1
+WHERE id = ? AND version = ?
 `
 
 type UpdateMeetingParams struct {
@@ -191,11 +260,16 @@ type UpdateMeetingParams struct {
 	LastHeldAt           sql.NullString `json:"last_held_at"`
 	Archived             int64          `json:"archived"`
 	UpdatedAt            string         `json:"updated_at"`
+	ExternalSeriesID     sql.NullString `json:"external_series_id"`
+	HeldCount            int64          `json:"held_count"`
+	CanceledCount        int64          `json:"canceled_count"`
+	LastCanceledAt       sql.NullString `json:"last_canceled_at"`
 	ID                   string         `json:"id"`
+	Version              int64          `json:"version"`
 }
 
-func (q *Queries) UpdateMeeting(ctx context.Context, arg UpdateMeetingParams) error {
-	_, err := q.db.ExecContext(ctx, updateMeeting,
+func (q *Queries) UpdateMeeting(ctx context.Context, arg UpdateMeetingParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateMeeting,
 		arg.Name,
 		arg.Cadence,
 		arg.CadenceDays,
@@ -204,7 +278,15 @@ func (q *Queries) UpdateMeeting(ctx context.Context, arg UpdateMeetingParams) er
 		arg.LastHeldAt,
 		arg.Archived,
 		arg.UpdatedAt,
+		arg.ExternalSeriesID,
+		arg.HeldCount,
+		arg.CanceledCount,
+		arg.LastCanceledAt,
 		arg.ID,
+		arg.Version,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
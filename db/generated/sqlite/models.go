@@ -114,6 +114,7 @@ type Habit struct {
 	Archived        int64          `json:"archived"`
 	CreatedAt       string         `json:"created_at"`
 	UpdatedAt       string         `json:"updated_at"`
+	Version         int64          `json:"version"`
 }
 
 type HabitCompletion struct {
@@ -177,6 +178,11 @@ type Meeting struct {
 	Archived             int64          `json:"archived"`
 	CreatedAt            string         `json:"created_at"`
 	UpdatedAt            string         `json:"updated_at"`
+	ExternalSeriesID     sql.NullString `json:"external_series_id"`
+	Version              int64          `json:"version"`
+	HeldCount            int64          `json:"held_count"`
+	CanceledCount        int64          `json:"canceled_count"`
+	LastCanceledAt       sql.NullString `json:"last_canceled_at"`
 }
 
 type Milestone struct {
@@ -326,18 +332,29 @@ type Schedule struct {
 }
 
 type Task struct {
-	ID              string         `json:"id"`
-	UserID          string         `json:"user_id"`
-	Title           string         `json:"title"`
-	Description     sql.NullString `json:"description"`
-	Status          string         `json:"status"`
-	Priority        string         `json:"priority"`
-	DurationMinutes sql.NullInt64  `json:"duration_minutes"`
-	DueDate         sql.NullString `json:"due_date"`
-	CompletedAt     sql.NullString `json:"completed_at"`
-	Version         int64          `json:"version"`
-	CreatedAt       string         `json:"created_at"`
-	UpdatedAt       string         `json:"updated_at"`
+	ID                string         `json:"id"`
+	UserID            string         `json:"user_id"`
+	Title             string         `json:"title"`
+	Description       sql.NullString `json:"description"`
+	Status            string         `json:"status"`
+	Priority          string         `json:"priority"`
+	DurationMinutes   sql.NullInt64  `json:"duration_minutes"`
+	DueDate           sql.NullString `json:"due_date"`
+	CompletedAt       sql.NullString `json:"completed_at"`
+	Version           int64          `json:"version"`
+	CreatedAt         string         `json:"created_at"`
+	UpdatedAt         string         `json:"updated_at"`
+	ChecklistRequired int64          `json:"checklist_required"`
+	BlockedReason     sql.NullString `json:"blocked_reason"`
+	BlockedAt         sql.NullString `json:"blocked_at"`
+	ExternalID        sql.NullString `json:"external_id"`
+	Timezone          sql.NullString `json:"timezone"`
+	WaitingOn         sql.NullString `json:"waiting_on"`
+	WaitingSince      sql.NullString `json:"waiting_since"`
+	FollowUpAt        sql.NullString `json:"follow_up_at"`
+	FollowUpSentAt    sql.NullString `json:"follow_up_sent_at"`
+	EarliestStart     sql.NullString `json:"earliest_start"`
+	Recurrence        sql.NullString `json:"recurrence"`
 }
 
 type TimeBlock struct {
@@ -94,8 +94,17 @@ const getUnpublishedEvents = `-- name: GetUnpublishedEvents :many
 SELECT id, event_id, aggregate_type, aggregate_id, event_type, routing_key, payload, metadata, created_at, published_at, retry_count, last_error, next_retry_at, dead_lettered_at, dead_letter_reason FROM outbox
 WHERE published_at IS NULL
   AND dead_lettered_at IS NULL
-  AND (next_retry_at IS NULL OR next_retry_at <= datetime('now'))
-ORDER BY created_at
+  AND (next_retry_at IS NULL OR datetime(next_retry_at) <= datetime('now'))
+  AND NOT EXISTS (
+      SELECT 1 FROM outbox earlier
+      WHERE earlier.aggregate_type = outbox.aggregate_type
+        AND earlier.aggregate_id = outbox.aggregate_id
+        AND earlier.id < outbox.id
+        AND earlier.published_at IS NULL
+        AND earlier.dead_lettered_at IS NULL
+        AND datetime(earlier.next_retry_at) > datetime('now')
+  )
+ORDER BY created_at, id
 LIMIT ?
 `
 
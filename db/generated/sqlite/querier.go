@@ -105,6 +105,7 @@ type Querier interface {
 	GetLatestProductivitySnapshot(ctx context.Context, userID string) (ProductivitySnapshot, error)
 	GetLatestWeeklySummary(ctx context.Context, userID string) (WeeklySummary, error)
 	GetLongestActiveStreak(ctx context.Context, userID string) (int64, error)
+	GetMeetingByExternalSeriesID(ctx context.Context, arg GetMeetingByExternalSeriesIDParams) (Meeting, error)
 	GetMeetingByID(ctx context.Context, id string) (Meeting, error)
 	GetMeetingsByUserID(ctx context.Context, userID string) ([]Meeting, error)
 	GetMilestoneByID(ctx context.Context, id string) (Milestone, error)
@@ -130,6 +131,7 @@ type Querier interface {
 	// Analytics queries using existing tables
 	GetTaskCompletionsByDateRange(ctx context.Context, arg GetTaskCompletionsByDateRangeParams) (GetTaskCompletionsByDateRangeRow, error)
 	GetTasksByUserID(ctx context.Context, userID string) ([]Task, error)
+	GetTasksByUserIDAfter(ctx context.Context, arg GetTasksByUserIDAfterParams) ([]Task, error)
 	GetTimeBlockByID(ctx context.Context, id string) (TimeBlock, error)
 	GetTimeBlockStatsByDateRange(ctx context.Context, arg GetTimeBlockStatsByDateRangeParams) (GetTimeBlockStatsByDateRangeRow, error)
 	GetTimeBlocksByScheduleID(ctx context.Context, scheduleID string) ([]TimeBlock, error)
@@ -152,8 +154,8 @@ type Querier interface {
 	UpdateAutomationRule(ctx context.Context, arg UpdateAutomationRuleParams) error
 	UpdateAutomationRuleExecution(ctx context.Context, arg UpdateAutomationRuleExecutionParams) error
 	UpdateConnectedCalendar(ctx context.Context, arg UpdateConnectedCalendarParams) error
-	UpdateHabit(ctx context.Context, arg UpdateHabitParams) error
-	UpdateMeeting(ctx context.Context, arg UpdateMeetingParams) error
+	UpdateHabit(ctx context.Context, arg UpdateHabitParams) (int64, error)
+	UpdateMeeting(ctx context.Context, arg UpdateMeetingParams) (int64, error)
 	UpdateMilestone(ctx context.Context, arg UpdateMilestoneParams) (Milestone, error)
 	UpdateProductivityGoal(ctx context.Context, arg UpdateProductivityGoalParams) error
 	UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error)
@@ -48,24 +48,37 @@ func (q *Queries) CountTasksByStatus(ctx context.Context, userID string) ([]Coun
 const createTask = `-- name: CreateTask :one
 INSERT INTO tasks (
     id, user_id, title, description, status, priority,
-    duration_minutes, due_date, version, created_at, updated_at
+    duration_minutes, due_date, checklist_required, blocked_reason, blocked_at,
+    external_id, timezone, waiting_on, waiting_since, follow_up_at,
+    follow_up_sent_at, earliest_start, recurrence, version, created_at, updated_at
 )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at, checklist_required, blocked_reason, blocked_at, external_id, timezone, waiting_on, waiting_since, follow_up_at, follow_up_sent_at, earliest_start, recurrence
 `
 
 type CreateTaskParams struct {
-	ID              string         `json:"id"`
-	UserID          string         `json:"user_id"`
-	Title           string         `json:"title"`
-	Description     sql.NullString `json:"description"`
-	Status          string         `json:"status"`
-	Priority        string         `json:"priority"`
-	DurationMinutes sql.NullInt64  `json:"duration_minutes"`
-	DueDate         sql.NullString `json:"due_date"`
-	Version         int64          `json:"version"`
-	CreatedAt       string         `json:"created_at"`
-	UpdatedAt       string         `json:"updated_at"`
+	ID                string         `json:"id"`
+	UserID            string         `json:"user_id"`
+	Title             string         `json:"title"`
+	Description       sql.NullString `json:"description"`
+	Status            string         `json:"status"`
+	Priority          string         `json:"priority"`
+	DurationMinutes   sql.NullInt64  `json:"duration_minutes"`
+	DueDate           sql.NullString `json:"due_date"`
+	ChecklistRequired int64          `json:"checklist_required"`
+	BlockedReason     sql.NullString `json:"blocked_reason"`
+	BlockedAt         sql.NullString `json:"blocked_at"`
+	ExternalID        sql.NullString `json:"external_id"`
+	Timezone          sql.NullString `json:"timezone"`
+	WaitingOn         sql.NullString `json:"waiting_on"`
+	WaitingSince      sql.NullString `json:"waiting_since"`
+	FollowUpAt        sql.NullString `json:"follow_up_at"`
+	FollowUpSentAt    sql.NullString `json:"follow_up_sent_at"`
+	EarliestStart     sql.NullString `json:"earliest_start"`
+	Recurrence        sql.NullString `json:"recurrence"`
+	Version           int64          `json:"version"`
+	CreatedAt         string         `json:"created_at"`
+	UpdatedAt         string         `json:"updated_at"`
 }
 
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error) {
@@ -78,6 +91,17 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		arg.Priority,
 		arg.DurationMinutes,
 		arg.DueDate,
+		arg.ChecklistRequired,
+		arg.BlockedReason,
+		arg.BlockedAt,
+		arg.ExternalID,
+		arg.Timezone,
+		arg.WaitingOn,
+		arg.WaitingSince,
+		arg.FollowUpAt,
+		arg.FollowUpSentAt,
+		arg.EarliestStart,
+		arg.Recurrence,
 		arg.Version,
 		arg.CreatedAt,
 		arg.UpdatedAt,
@@ -96,6 +120,17 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ChecklistRequired,
+		&i.BlockedReason,
+		&i.BlockedAt,
+		&i.ExternalID,
+		&i.Timezone,
+		&i.WaitingOn,
+		&i.WaitingSince,
+		&i.FollowUpAt,
+		&i.FollowUpSentAt,
+		&i.EarliestStart,
+		&i.Recurrence,
 	)
 	return i, err
 }
@@ -110,7 +145,7 @@ func (q *Queries) DeleteTask(ctx context.Context, id string) error {
 }
 
 const getPendingTasksByUserID = `-- name: GetPendingTasksByUserID :many
-SELECT id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at FROM tasks
+SELECT id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at, checklist_required, blocked_reason, blocked_at, external_id, timezone, waiting_on, waiting_since, follow_up_at, follow_up_sent_at, earliest_start, recurrence FROM tasks
 WHERE user_id = ? AND status IN ('pending', 'in_progress')
 ORDER BY
     CASE priority
@@ -147,6 +182,17 @@ func (q *Queries) GetPendingTasksByUserID(ctx context.Context, userID string) ([
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ChecklistRequired,
+			&i.BlockedReason,
+			&i.BlockedAt,
+			&i.ExternalID,
+			&i.Timezone,
+			&i.WaitingOn,
+			&i.WaitingSince,
+			&i.FollowUpAt,
+			&i.FollowUpSentAt,
+			&i.EarliestStart,
+			&i.Recurrence,
 		); err != nil {
 			return nil, err
 		}
@@ -162,7 +208,7 @@ func (q *Queries) GetPendingTasksByUserID(ctx context.Context, userID string) ([
 }
 
 const getTaskByID = `-- name: GetTaskByID :one
-SELECT id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at FROM tasks WHERE id = ?
+SELECT id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at, checklist_required, blocked_reason, blocked_at, external_id, timezone, waiting_on, waiting_since, follow_up_at, follow_up_sent_at, earliest_start, recurrence FROM tasks WHERE id = ?
 `
 
 func (q *Queries) GetTaskByID(ctx context.Context, id string) (Task, error) {
@@ -181,12 +227,23 @@ func (q *Queries) GetTaskByID(ctx context.Context, id string) (Task, error) {
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ChecklistRequired,
+		&i.BlockedReason,
+		&i.BlockedAt,
+		&i.ExternalID,
+		&i.Timezone,
+		&i.WaitingOn,
+		&i.WaitingSince,
+		&i.FollowUpAt,
+		&i.FollowUpSentAt,
+		&i.EarliestStart,
+		&i.Recurrence,
 	)
 	return i, err
 }
 
 const getTasksByUserID = `-- name: GetTasksByUserID :many
-SELECT id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at FROM tasks
+SELECT id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at, checklist_required, blocked_reason, blocked_at, external_id, timezone, waiting_on, waiting_since, follow_up_at, follow_up_sent_at, earliest_start, recurrence FROM tasks
 WHERE user_id = ?
 ORDER BY created_at DESC
 `
@@ -213,6 +270,77 @@ func (q *Queries) GetTasksByUserID(ctx context.Context, userID string) ([]Task,
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ChecklistRequired,
+			&i.BlockedReason,
+			&i.BlockedAt,
+			&i.ExternalID,
+			&i.Timezone,
+			&i.WaitingOn,
+			&i.WaitingSince,
+			&i.FollowUpAt,
+			&i.FollowUpSentAt,
+			&i.EarliestStart,
+			&i.Recurrence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksByUserIDAfter = `-- name: GetTasksByUserIDAfter :many
+SELECT id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at, checklist_required, blocked_reason, blocked_at, external_id, timezone, waiting_on, waiting_since, follow_up_at, follow_up_sent_at, earliest_start, recurrence FROM tasks
+WHERE user_id = ? AND id > ?
+ORDER BY id
+LIMIT ?
+`
+
+type GetTasksByUserIDAfterParams struct {
+	UserID string `json:"user_id"`
+	ID     string `json:"id"`
+	Limit  int64  `json:"limit"`
+}
+
+func (q *Queries) GetTasksByUserIDAfter(ctx context.Context, arg GetTasksByUserIDAfterParams) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getTasksByUserIDAfter, arg.UserID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.DurationMinutes,
+			&i.DueDate,
+			&i.CompletedAt,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ChecklistRequired,
+			&i.BlockedReason,
+			&i.BlockedAt,
+			&i.ExternalID,
+			&i.Timezone,
+			&i.WaitingOn,
+			&i.WaitingSince,
+			&i.FollowUpAt,
+			&i.FollowUpSentAt,
+			&i.EarliestStart,
+			&i.Recurrence,
 		); err != nil {
 			return nil, err
 		}
@@ -237,22 +365,44 @@ SET
     duration_minutes = ?,
     due_date = ?,
     completed_at = ?,
+    checklist_required = ?,
+    blocked_reason = ?,
+    blocked_at = ?,
+    external_id = ?,
+    timezone = ?,
+    waiting_on = ?,
+    waiting_since = ?,
+    follow_up_at = ?,
+    follow_up_sent_at = ?,
+    earliest_start = ?,
+    recurrence = ?,
     version = version + 1,
     updated_at = datetime('now')
 WHERE id = ? AND version = ?
-RETURNING id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at
+RETURNING id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at, checklist_required, blocked_reason, blocked_at, external_id, timezone, waiting_on, waiting_since, follow_up_at, follow_up_sent_at, earliest_start, recurrence
 `
 
 type UpdateTaskParams struct {
-	Title           string         `json:"title"`
-	Description     sql.NullString `json:"description"`
-	Status          string         `json:"status"`
-	Priority        string         `json:"priority"`
-	DurationMinutes sql.NullInt64  `json:"duration_minutes"`
-	DueDate         sql.NullString `json:"due_date"`
-	CompletedAt     sql.NullString `json:"completed_at"`
-	ID              string         `json:"id"`
-	Version         int64          `json:"version"`
+	Title             string         `json:"title"`
+	Description       sql.NullString `json:"description"`
+	Status            string         `json:"status"`
+	Priority          string         `json:"priority"`
+	DurationMinutes   sql.NullInt64  `json:"duration_minutes"`
+	DueDate           sql.NullString `json:"due_date"`
+	CompletedAt       sql.NullString `json:"completed_at"`
+	ChecklistRequired int64          `json:"checklist_required"`
+	BlockedReason     sql.NullString `json:"blocked_reason"`
+	BlockedAt         sql.NullString `json:"blocked_at"`
+	ExternalID        sql.NullString `json:"external_id"`
+	Timezone          sql.NullString `json:"timezone"`
+	WaitingOn         sql.NullString `json:"waiting_on"`
+	WaitingSince      sql.NullString `json:"waiting_since"`
+	FollowUpAt        sql.NullString `json:"follow_up_at"`
+	FollowUpSentAt    sql.NullString `json:"follow_up_sent_at"`
+	EarliestStart     sql.NullString `json:"earliest_start"`
+	Recurrence        sql.NullString `json:"recurrence"`
+	ID                string         `json:"id"`
+	Version           int64          `json:"version"`
 }
 
 func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, error) {
@@ -264,6 +414,17 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 		arg.DurationMinutes,
 		arg.DueDate,
 		arg.CompletedAt,
+		arg.ChecklistRequired,
+		arg.BlockedReason,
+		arg.BlockedAt,
+		arg.ExternalID,
+		arg.Timezone,
+		arg.WaitingOn,
+		arg.WaitingSince,
+		arg.FollowUpAt,
+		arg.FollowUpSentAt,
+		arg.EarliestStart,
+		arg.Recurrence,
 		arg.ID,
 		arg.Version,
 	)
@@ -281,6 +442,17 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ChecklistRequired,
+		&i.BlockedReason,
+		&i.BlockedAt,
+		&i.ExternalID,
+		&i.Timezone,
+		&i.WaitingOn,
+		&i.WaitingSince,
+		&i.FollowUpAt,
+		&i.FollowUpSentAt,
+		&i.EarliestStart,
+		&i.Recurrence,
 	)
 	return i, err
 }
@@ -322,3 +322,49 @@ WHERE user_id = $1
   AND completed = true
 GROUP BY block_type
 ORDER BY minutes DESC;
+
+-- name: GetFocusStatsByDateRange :one
+SELECT
+    COALESCE(SUM(EXTRACT(EPOCH FROM (end_time - start_time)) / 60) FILTER (WHERE block_type IN ('task', 'habit', 'focus')), 0)::INTEGER as scheduled_minutes,
+    COALESCE(SUM(EXTRACT(EPOCH FROM (end_time - start_time)) / 60) FILTER (WHERE block_type IN ('task', 'habit', 'focus') AND completed = true), 0)::INTEGER as completed_minutes,
+    COUNT(*) FILTER (WHERE block_type = 'break') as breaks_scheduled,
+    COUNT(*) FILTER (WHERE block_type = 'break' AND completed = true) as breaks_taken,
+    (
+        SELECT COUNT(*)
+        FROM reschedule_attempts ra
+        WHERE ra.user_id = $1
+          AND ra.attempt_type = 'auto-conflict'
+          AND ra.success = true
+          AND ra.old_start_time >= $2
+          AND ra.old_start_time < $3
+    ) as interruptions
+FROM time_blocks
+WHERE user_id = $1
+  AND start_time >= $2
+  AND start_time < $3;
+
+-- name: GetAnalyticsDataChangedAt :one
+SELECT GREATEST(
+    (
+        SELECT MAX(t.updated_at)
+        FROM tasks t
+        WHERE t.user_id = sqlc.arg(user_id)::uuid
+          AND t.created_at >= sqlc.arg(start_at)::timestamptz
+          AND t.created_at < sqlc.arg(end_at)::timestamptz
+    ),
+    (
+        SELECT MAX(b.updated_at)
+        FROM time_blocks b
+        WHERE b.user_id = sqlc.arg(user_id)::uuid
+          AND b.start_time >= sqlc.arg(start_at)::timestamptz
+          AND b.start_time < sqlc.arg(end_at)::timestamptz
+    ),
+    (
+        SELECT MAX(h.updated_at)
+        FROM habits h
+        JOIN habit_completions hc ON hc.habit_id = h.id
+        WHERE h.user_id = sqlc.arg(user_id)::uuid
+          AND hc.completed_at >= sqlc.arg(start_at)::timestamptz
+          AND hc.completed_at < sqlc.arg(end_at)::timestamptz
+    )
+)::timestamptz as changed_at;
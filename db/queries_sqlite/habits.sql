@@ -1,14 +1,14 @@
 -- name: GetHabitByID :one
 SELECT id, user_id, name, description, frequency, times_per_week,
        duration_minutes, preferred_time, streak, best_streak, total_done,
-       archived, created_at, updated_at
+       archived, created_at, updated_at, version
 FROM habits
 WHERE id = ?;
 
 -- name: GetHabitsByUserID :many
 SELECT id, user_id, name, description, frequency, times_per_week,
        duration_minutes, preferred_time, streak, best_streak, total_done,
-       archived, created_at, updated_at
+       archived, created_at, updated_at, version
 FROM habits
 WHERE user_id = ?
 ORDER BY created_at DESC;
@@ -16,7 +16,7 @@ ORDER BY created_at DESC;
 -- name: GetActiveHabitsByUserID :many
 SELECT id, user_id, name, description, frequency, times_per_week,
        duration_minutes, preferred_time, streak, best_streak, total_done,
-       archived, created_at, updated_at
+       archived, created_at, updated_at, version
 FROM habits
 WHERE user_id = ? AND archived = 0
 ORDER BY created_at DESC;
@@ -30,7 +30,7 @@ INSERT INTO habits (
     ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 );
 
--- name: UpdateHabit :exec
+-- name: UpdateHabit :execrows
 UPDATE habits
 SET name = ?,
     description = ?,
@@ -42,8 +42,9 @@ SET name = ?,
     best_streak = ?,
     total_done = ?,
     archived = ?,
-    updated_at = ?
-WHERE id = ?;
+    updated_at = ?,
+    version = version + 1
+WHERE id = ? AND version = ?;
 
 -- name: DeleteHabit :exec
 DELETE FROM habits WHERE id = ?;
@@ -1,30 +1,41 @@
 -- name: GetMeetingByID :one
 SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
-       preferred_time_minutes, last_held_at, archived, created_at, updated_at
+       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+       external_series_id, version, held_count, canceled_count, last_canceled_at
 FROM meetings
 WHERE id = ?;
 
 -- name: GetMeetingsByUserID :many
 SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
-       preferred_time_minutes, last_held_at, archived, created_at, updated_at
+       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+       external_series_id, version, held_count, canceled_count, last_canceled_at
 FROM meetings
 WHERE user_id = ?
 ORDER BY created_at DESC;
 
 -- name: GetActiveMeetingsByUserID :many
 SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
-       preferred_time_minutes, last_held_at, archived, created_at, updated_at
+       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+       external_series_id, version, held_count, canceled_count, last_canceled_at
 FROM meetings
 WHERE user_id = ? AND archived = 0
 ORDER BY created_at DESC;
 
+-- name: GetMeetingByExternalSeriesID :one
+SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
+       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+       external_series_id, version, held_count, canceled_count, last_canceled_at
+FROM meetings
+WHERE user_id = ? AND external_series_id = ?;
+
 -- name: CreateMeeting :exec
 INSERT INTO meetings (
     id, user_id, name, cadence, cadence_days, duration_minutes,
-    preferred_time_minutes, last_held_at, archived, created_at, updated_at
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+    preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+    external_series_id, held_count, canceled_count, last_canceled_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
--- name: UpdateMeeting :exec
+-- name: UpdateMeeting :execrows
 UPDATE meetings
 SET name = ?,
     cadence = ?,
@@ -33,8 +44,13 @@ SET name = ?,
     preferred_time_minutes = ?,
     last_held_at = ?,
     archived = ?,
-    updated_at = ?
-WHERE id = ?;
+    updated_at = ?,
+    external_series_id = ?,
+    held_count = ?,
+    canceled_count = ?,
+    last_canceled_at = ?,
+    version = version + 1
+WHERE id = ? AND version = ?;
 
 -- name: DeleteMeeting :exec
 DELETE FROM meetings WHERE id = ?;
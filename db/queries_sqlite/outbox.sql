@@ -10,8 +10,17 @@ RETURNING *;
 SELECT * FROM outbox
 WHERE published_at IS NULL
   AND dead_lettered_at IS NULL
-  AND (next_retry_at IS NULL OR next_retry_at <= datetime('now'))
-ORDER BY created_at
+  AND (next_retry_at IS NULL OR datetime(next_retry_at) <= datetime('now'))
+  AND NOT EXISTS (
+      SELECT 1 FROM outbox earlier
+      WHERE earlier.aggregate_type = outbox.aggregate_type
+        AND earlier.aggregate_id = outbox.aggregate_id
+        AND earlier.id < outbox.id
+        AND earlier.published_at IS NULL
+        AND earlier.dead_lettered_at IS NULL
+        AND datetime(earlier.next_retry_at) > datetime('now')
+  )
+ORDER BY created_at, id
 LIMIT ?;
 
 -- name: MarkEventPublished :exec
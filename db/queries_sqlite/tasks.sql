@@ -1,9 +1,11 @@
 -- name: CreateTask :one
 INSERT INTO tasks (
     id, user_id, title, description, status, priority,
-    duration_minutes, due_date, version, created_at, updated_at
+    duration_minutes, due_date, checklist_required, blocked_reason, blocked_at,
+    external_id, timezone, waiting_on, waiting_since, follow_up_at,
+    follow_up_sent_at, earliest_start, recurrence, version, created_at, updated_at
 )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetTaskByID :one
@@ -14,6 +16,12 @@ SELECT * FROM tasks
 WHERE user_id = ?
 ORDER BY created_at DESC;
 
+-- name: GetTasksByUserIDAfter :many
+SELECT * FROM tasks
+WHERE user_id = ? AND id > ?
+ORDER BY id
+LIMIT ?;
+
 -- name: GetPendingTasksByUserID :many
 SELECT * FROM tasks
 WHERE user_id = ? AND status IN ('pending', 'in_progress')
@@ -39,6 +47,17 @@ SET
     duration_minutes = ?,
     due_date = ?,
     completed_at = ?,
+    checklist_required = ?,
+    blocked_reason = ?,
+    blocked_at = ?,
+    external_id = ?,
+    timezone = ?,
+    waiting_on = ?,
+    waiting_since = ?,
+    follow_up_at = ?,
+    follow_up_sent_at = ?,
+    earliest_start = ?,
+    recurrence = ?,
     version = version + 1,
     updated_at = datetime('now')
 WHERE id = ? AND version = ?
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/application/capability"
+	"github.com/felixgeelhaar/orbita/pkg/config"
+)
+
+// capabilityNames lists every capability the container reports, in order.
+var capabilityNames = []string{
+	capability.Database,
+	capability.EventDelivery,
+	capability.OrbitStorage,
+	capability.CalendarSync,
+	capability.CalendarImport,
+	capability.Reminders,
+	capability.Escalation,
+	capability.Archiving,
+	capability.BlockRetention,
+	capability.InboxExpiry,
+	capability.Digests,
+}
+
+// degrade records that a dependency fell back at startup, so Capabilities
+// can say why the feature is missing.
+func (c *Container) degrade(name, reason string) {
+	if c.degraded == nil {
+		c.degraded = make(map[string]string)
+	}
+	c.degraded[name] = reason
+}
+
+// Capabilities reports which optional features the container was assembled
+// with and, for each missing one, why.
+func (c *Container) Capabilities() capability.Set {
+	cfg := c.Config
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	report := func(name string, available bool, reason string) capability.Capability {
+		if available {
+			return capability.Available(name)
+		}
+		if recorded, ok := c.degraded[name]; ok {
+			reason = recorded
+		}
+		return capability.Unavailable(name, reason)
+	}
+
+	_, brokered := c.EventPublisher.(brokerPinger)
+
+	return capability.Set{
+		report(capability.Database, c.DB != nil || c.DBConn != nil, "no database is connected"),
+		report(capability.EventDelivery, brokered, eventDeliveryReason(cfg)),
+		report(capability.OrbitStorage, c.RedisClient != nil, orbitStorageReason(cfg)),
+		report(capability.CalendarSync, c.CalendarSyncer != nil, calendarSyncReason(cfg)),
+		report(capability.CalendarImport, c.CalendarImportWorker != nil,
+			disabledReason(cfg.CalendarSyncEnabled, "CALENDAR_SYNC_ENABLED", "no calendar importer is configured")),
+		report(capability.Reminders, c.ReminderDispatcher != nil,
+			disabledReason(cfg.TaskRemindersEnabled, "TASK_REMINDERS_ENABLED", "the task store does not support reminders")),
+		report(capability.Escalation, c.PriorityEscalator != nil,
+			disabledReason(cfg.TaskEscalationEnabled, "TASK_ESCALATION_ENABLED", "TASK_ESCALATION_RULES are invalid")),
+		report(capability.Archiving, c.TaskArchiver != nil,
+			disabledReason(cfg.TaskRetentionEnabled && cfg.TaskRetentionDays > 0, "TASK_RETENTION_ENABLED", "the task store does not support archiving")),
+		report(capability.BlockRetention, c.BlockRetentionSweeper != nil,
+			disabledReason(cfg.BlockRetentionEnabled && cfg.BlockRetentionDays > 0, "BLOCK_RETENTION_ENABLED", "BLOCK_RETENTION_ACTION is invalid")),
+		report(capability.InboxExpiry, c.InboxExpirySweeper != nil,
+			disabledReason(cfg.InboxExpiryEnabled && cfg.InboxExpiryDays > 0, "INBOX_EXPIRY_ENABLED", "INBOX_EXPIRY_ACTION is invalid")),
+		report(capability.Digests, c.DigestSender != nil,
+			disabledReason(cfg.DigestsEnabled, "DIGESTS_ENABLED", "digests could not be set up")),
+	}
+}
+
+// StartupFailureCapabilities reports every capability as unavailable for a
+// container that could not be built.
+func StartupFailureCapabilities(err error) capability.Set {
+	reason := "startup failed"
+	if err != nil {
+		reason = fmt.Sprintf("startup failed: %v", err)
+	}
+	set := make(capability.Set, len(capabilityNames))
+	for i, name := range capabilityNames {
+		set[i] = capability.Unavailable(name, reason)
+	}
+	return set
+}
+
+func eventDeliveryReason(cfg *config.Config) string {
+	if cfg.IsLocalMode() {
+		return "local mode has no message broker"
+	}
+	return "RabbitMQ is not connected"
+}
+
+func orbitStorageReason(cfg *config.Config) string {
+	switch {
+	case cfg.IsLocalMode():
+		return "local mode keeps orbit storage in memory"
+	case cfg.RedisURL == "":
+		return "REDIS_URL is not set"
+	default:
+		return "Redis is not connected"
+	}
+}
+
+func calendarSyncReason(cfg *config.Config) string {
+	switch {
+	case cfg.IsLocalMode():
+		return "local mode does not configure OAuth"
+	case cfg.OAuthProvider == "" || cfg.OAuthClientID == "" || cfg.OAuthClientSecret == "" ||
+		cfg.OAuthAuthURL == "" || cfg.OAuthTokenURL == "" || cfg.OAuthRedirectURL == "":
+		return "OAuth is not configured"
+	case cfg.OAuthProvider != "google":
+		return fmt.Sprintf("OAUTH_PROVIDER %q does not support calendar sync", cfg.OAuthProvider)
+	default:
+		return "OAuth is not configured"
+	}
+}
+
+// disabledReason explains a missing background feature: off in the
+// configuration, or enabled but not set up for the given reason.
+func disabledReason(enabled bool, setting, otherwise string) string {
+	if !enabled {
+		return setting + " is off"
+	}
+	return otherwise
+}
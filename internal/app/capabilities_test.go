@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	googleCalendar "github.com/felixgeelhaar/orbita/internal/calendar/infrastructure/google"
+	"github.com/felixgeelhaar/orbita/internal/shared/application/capability"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/eventbus"
+	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_Capabilities(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	oauthCfg := config.Config{
+		OAuthClientID:     "client",
+		OAuthClientSecret: "secret",
+		OAuthAuthURL:      "https://accounts.example.com/auth",
+		OAuthTokenURL:     "https://accounts.example.com/token",
+		OAuthRedirectURL:  "http://localhost/callback",
+	}
+
+	reason := func(t *testing.T, set capability.Set, name string) string {
+		t.Helper()
+		c, ok := set.Get(name)
+		require.True(t, ok, "capability %s not reported", name)
+		assert.False(t, c.Available, "capability %s", name)
+		return c.Reason
+	}
+
+	t.Run("reports every capability", func(t *testing.T) {
+		set := (&Container{}).Capabilities()
+		require.Len(t, set, len(capabilityNames))
+		for i, name := range capabilityNames {
+			assert.Equal(t, name, set[i].Name)
+		}
+	})
+
+	t.Run("no OAuth", func(t *testing.T) {
+		c := &Container{Config: &config.Config{}}
+		set := c.Capabilities()
+		assert.Equal(t, "OAuth is not configured", reason(t, set, capability.CalendarSync))
+		assert.EqualError(t, set.Require(capability.CalendarSync), "calendar sync unavailable because OAuth is not configured")
+	})
+
+	t.Run("OAuth provider without calendar sync", func(t *testing.T) {
+		cfg := oauthCfg
+		cfg.OAuthProvider = "github"
+		set := (&Container{Config: &cfg}).Capabilities()
+		assert.Equal(t, `OAUTH_PROVIDER "github" does not support calendar sync`, reason(t, set, capability.CalendarSync))
+	})
+
+	t.Run("invalid encryption key", func(t *testing.T) {
+		cfg := oauthCfg
+		cfg.OAuthProvider = "google"
+		c := &Container{Config: &cfg}
+		c.degrade(capability.CalendarSync, "ENCRYPTION_KEY is missing or invalid")
+		assert.Equal(t, "ENCRYPTION_KEY is missing or invalid", reason(t, c.Capabilities(), capability.CalendarSync))
+	})
+
+	t.Run("calendar sync configured", func(t *testing.T) {
+		c := &Container{Config: &config.Config{}, CalendarSyncer: googleCalendar.NewSyncer(nil, logger)}
+		assert.NoError(t, c.Capabilities().Require(capability.CalendarSync))
+	})
+
+	t.Run("Redis not configured", func(t *testing.T) {
+		set := (&Container{Config: &config.Config{}}).Capabilities()
+		assert.Equal(t, "REDIS_URL is not set", reason(t, set, capability.OrbitStorage))
+	})
+
+	t.Run("Redis unreachable", func(t *testing.T) {
+		c := &Container{Config: &config.Config{RedisURL: "redis://localhost:6379/0"}}
+		assert.Equal(t, "Redis is not connected", reason(t, c.Capabilities(), capability.OrbitStorage))
+
+		c.degrade(capability.OrbitStorage, "Redis is not reachable")
+		assert.Equal(t, "Redis is not reachable", reason(t, c.Capabilities(), capability.OrbitStorage))
+	})
+
+	t.Run("RabbitMQ unreachable", func(t *testing.T) {
+		c := &Container{Config: &config.Config{}, EventPublisher: eventbus.NewNoopPublisher(logger)}
+		c.degrade(capability.EventDelivery, "RabbitMQ is not reachable")
+		assert.Equal(t, "RabbitMQ is not reachable", reason(t, c.Capabilities(), capability.EventDelivery))
+	})
+
+	t.Run("RabbitMQ connected", func(t *testing.T) {
+		c := &Container{Config: &config.Config{}, EventPublisher: &stubBroker{}}
+		assert.NoError(t, c.Capabilities().Require(capability.EventDelivery))
+	})
+
+	t.Run("background workers", func(t *testing.T) {
+		c := &Container{Config: &config.Config{TaskEscalationEnabled: true}}
+		set := c.Capabilities()
+		assert.Equal(t, "TASK_REMINDERS_ENABLED is off", reason(t, set, capability.Reminders))
+		assert.Equal(t, "TASK_ESCALATION_RULES are invalid", reason(t, set, capability.Escalation))
+		assert.Equal(t, "DIGESTS_ENABLED is off", reason(t, set, capability.Digests))
+	})
+}
+
+func TestContainer_Capabilities_LocalMode(t *testing.T) {
+	cfg := &config.Config{
+		AppEnv:               "test",
+		LocalMode:            true,
+		DatabaseDriver:       "sqlite",
+		SQLitePath:           filepath.Join(t.TempDir(), "test.db"),
+		UserID:               "00000000-0000-0000-0000-000000000001",
+		TaskRemindersEnabled: true,
+	}
+	container, err := NewLocalContainer(context.Background(), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	defer container.Close()
+
+	set := container.Capabilities()
+	assert.NoError(t, set.Require(capability.Database))
+	assert.NoError(t, set.Require(capability.Reminders))
+	assert.EqualError(t, set.Require(capability.EventDelivery), "event delivery unavailable because local mode has no message broker")
+	assert.EqualError(t, set.Require(capability.OrbitStorage), "orbit storage unavailable because local mode keeps orbit storage in memory")
+	assert.EqualError(t, set.Require(capability.CalendarSync), "calendar sync unavailable because local mode does not configure OAuth")
+}
+
+func TestStartupFailureCapabilities(t *testing.T) {
+	set := StartupFailureCapabilities(errors.New("failed to connect to database: refused"))
+
+	require.Len(t, set, len(capabilityNames))
+	for _, c := range set {
+		assert.False(t, c.Available)
+	}
+	err := set.Require(capability.CalendarSync)
+	assert.ErrorIs(t, err, capability.ErrUnavailable)
+	assert.EqualError(t, err, "calendar sync unavailable because startup failed: failed to connect to database: refused")
+}
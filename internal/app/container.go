@@ -3,14 +3,18 @@ package app
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	automationApp "github.com/felixgeelhaar/orbita/internal/automations/application"
+	automationDomain "github.com/felixgeelhaar/orbita/internal/automations/domain"
+	automationServices "github.com/felixgeelhaar/orbita/internal/automations/application/services"
 	automationPersistence "github.com/felixgeelhaar/orbita/internal/automations/infrastructure/persistence"
 	db "github.com/felixgeelhaar/orbita/db/generated/postgres"
 	insightsApp "github.com/felixgeelhaar/orbita/internal/insights/application"
+	insightsCommands "github.com/felixgeelhaar/orbita/internal/insights/application/commands"
 	insightsPersistence "github.com/felixgeelhaar/orbita/internal/insights/infrastructure/persistence"
 	billingApp "github.com/felixgeelhaar/orbita/internal/billing/application"
 	billingDomain "github.com/felixgeelhaar/orbita/internal/billing/domain"
@@ -24,6 +28,8 @@ import (
 	licensingApp "github.com/felixgeelhaar/orbita/internal/licensing/application"
 	licensingCrypto "github.com/felixgeelhaar/orbita/internal/licensing/infrastructure/crypto"
 	licensingPersistence "github.com/felixgeelhaar/orbita/internal/licensing/infrastructure/persistence"
+	marketplaceCommands "github.com/felixgeelhaar/orbita/internal/marketplace/application/commands"
+	marketplaceCredentials "github.com/felixgeelhaar/orbita/internal/marketplace/infrastructure/credentials"
 	marketplaceDomain "github.com/felixgeelhaar/orbita/internal/marketplace/domain"
 	marketplaceQueries "github.com/felixgeelhaar/orbita/internal/marketplace/application/queries"
 	marketplacePersistence "github.com/felixgeelhaar/orbita/internal/marketplace/infrastructure/persistence"
@@ -31,7 +37,12 @@ import (
 	"github.com/felixgeelhaar/orbita/internal/engine/builtin"
 	"github.com/felixgeelhaar/orbita/internal/engine/registry"
 	"github.com/felixgeelhaar/orbita/internal/engine/runtime"
+	engineSDK "github.com/felixgeelhaar/orbita/internal/engine/sdk"
 	habitsDomain "github.com/felixgeelhaar/orbita/internal/habits/domain"
+	notificationServices "github.com/felixgeelhaar/orbita/internal/notifications/application/services"
+	notificationWorkers "github.com/felixgeelhaar/orbita/internal/notifications/application/workers"
+	notificationDomain "github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	notificationInfra "github.com/felixgeelhaar/orbita/internal/notifications/infrastructure"
 	orbitAPI "github.com/felixgeelhaar/orbita/internal/orbit/api"
 	"github.com/felixgeelhaar/orbita/internal/orbit/builtin/focusmode"
 	"github.com/felixgeelhaar/orbita/internal/orbit/builtin/idealweek"
@@ -47,6 +58,8 @@ import (
 	identityPersistence "github.com/felixgeelhaar/orbita/internal/identity/infrastructure/persistence"
 	inboxCommands "github.com/felixgeelhaar/orbita/internal/inbox/application/commands"
 	inboxQueries "github.com/felixgeelhaar/orbita/internal/inbox/application/queries"
+	inboxWorkers "github.com/felixgeelhaar/orbita/internal/inbox/application/workers"
+	inboxDomain "github.com/felixgeelhaar/orbita/internal/inbox/domain"
 	inboxPersistence "github.com/felixgeelhaar/orbita/internal/inbox/persistence"
 	inboxServices "github.com/felixgeelhaar/orbita/internal/inbox/services"
 	meetingCommands "github.com/felixgeelhaar/orbita/internal/meetings/application/commands"
@@ -55,6 +68,7 @@ import (
 	meetingPersistence "github.com/felixgeelhaar/orbita/internal/meetings/infrastructure/persistence"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	productivityWorkers "github.com/felixgeelhaar/orbita/internal/productivity/application/workers"
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
 	"github.com/felixgeelhaar/orbita/internal/productivity/infrastructure/persistence"
 	projectCommands "github.com/felixgeelhaar/orbita/internal/projects/application/commands"
@@ -62,13 +76,18 @@ import (
 	projectsDomain "github.com/felixgeelhaar/orbita/internal/projects/domain"
 	scheduleCommands "github.com/felixgeelhaar/orbita/internal/scheduling/application/commands"
 	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	scheduleWorkers "github.com/felixgeelhaar/orbita/internal/scheduling/application/workers"
 	schedulerServices "github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
 	scheduleSubs "github.com/felixgeelhaar/orbita/internal/scheduling/application/subscribers"
 	schedulingDomain "github.com/felixgeelhaar/orbita/internal/scheduling/domain"
 	schedulePersistence "github.com/felixgeelhaar/orbita/internal/scheduling/infrastructure/persistence"
+	searchQueries "github.com/felixgeelhaar/orbita/internal/search/application/queries"
 	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/application/capability"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/database"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/database/postgres"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/migrations"
 	_ "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/database/sqlite" // Register SQLite driver
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/eventbus"
@@ -84,6 +103,9 @@ type Container struct {
 	Config *config.Config
 	Logger *slog.Logger
 
+	// WeekStartsOn is the first day of the week for weekly views and aggregates.
+	WeekStartsOn time.Weekday
+
 	// Database
 	DB       *pgxpool.Pool
 	DBConn   database.Connection // Abstract connection for driver-agnostic access
@@ -117,26 +139,59 @@ type Container struct {
 	ArchiveTaskHandler  *commands.ArchiveTaskHandler
 	StartTaskHandler    *commands.StartTaskHandler
 	UpdateTaskHandler   *commands.UpdateTaskHandler
+	ImportTaskHandler   *commands.ImportTaskHandler
+
+	// Task Checklist Handlers
+	AddChecklistItemHandler    *commands.AddChecklistItemHandler
+	ToggleChecklistItemHandler *commands.ToggleChecklistItemHandler
+
+	// Task Block Handlers
+	BlockTaskHandler   *commands.BlockTaskHandler
+	UnblockTaskHandler *commands.UnblockTaskHandler
+
+	// Task Waiting Handlers
+	WaitOnTaskHandler         *commands.WaitOnTaskHandler
+	ResolveWaitingTaskHandler *commands.ResolveWaitingTaskHandler
+
+	// Tagging Handlers
+	BulkTagTasksHandler  *commands.BulkTagTasksHandler
+	BulkTagHabitsHandler *habitCommands.BulkTagHabitsHandler
 
 	// Task Query Handlers
-	ListTasksHandler *queries.ListTasksHandler
-	GetTaskHandler   *queries.GetTaskHandler
+	ListTasksHandler    *queries.ListTasksHandler
+	GetTaskHandler      *queries.GetTaskHandler
+	GetTaskStatsHandler *queries.GetTaskStatsHandler
+
+	// Task Reminders
+	ReminderDispatcher *productivityWorkers.ReminderDispatcher
+	PriorityEscalator  *productivityWorkers.PriorityEscalator
+	TaskArchiver       *productivityWorkers.TaskArchiver
+
+	// Schedule block retention
+	BlockRetentionSweeper *scheduleWorkers.BlockRetentionSweeper
 
 	// Habit Command Handlers
 	CreateHabitHandler          *habitCommands.CreateHabitHandler
 	LogCompletionHandler        *habitCommands.LogCompletionHandler
+	BulkLogCompletionHandler    *habitCommands.BulkLogCompletionHandler
 	ArchiveHabitHandler         *habitCommands.ArchiveHabitHandler
+	SkipHabitHandler            *habitCommands.SkipHabitHandler
+	FreezeHabitHandler          *habitCommands.FreezeHabitHandler
+	UnfreezeHabitHandler        *habitCommands.UnfreezeHabitHandler
 	AdjustHabitFrequencyHandler *habitCommands.AdjustHabitFrequencyHandler
+	RecommendHabitTimeHandler   *habitCommands.RecommendHabitTimeHandler
 
 	// Habit Query Handlers
-	ListHabitsHandler *habitQueries.ListHabitsHandler
-	GetHabitHandler   *habitQueries.GetHabitHandler
+	ListHabitsHandler   *habitQueries.ListHabitsHandler
+	GetDueHabitsHandler *habitQueries.GetDueHabitsHandler
+	GetHabitHandler     *habitQueries.GetHabitHandler
 
 	// Meeting Command Handlers
 	CreateMeetingHandler        *meetingCommands.CreateMeetingHandler
 	UpdateMeetingHandler        *meetingCommands.UpdateMeetingHandler
 	ArchiveMeetingHandler       *meetingCommands.ArchiveMeetingHandler
 	MarkMeetingHeldHandler      *meetingCommands.MarkMeetingHeldHandler
+	MarkMeetingCanceledHandler  *meetingCommands.MarkMeetingCanceledHandler
 	AdjustMeetingCadenceHandler *meetingCommands.AdjustMeetingCadenceHandler
 
 	// Meeting Query Handlers
@@ -151,6 +206,14 @@ type Container struct {
 	RescheduleBlockHandler *scheduleCommands.RescheduleBlockHandler
 	AutoScheduleHandler   *scheduleCommands.AutoScheduleHandler
 	AutoRescheduleHandler *scheduleCommands.AutoRescheduleHandler
+	RescheduleDayHandler  *scheduleCommands.RescheduleDayHandler
+	StartFocusModeHandler *scheduleCommands.StartFocusModeHandler
+	EndFocusModeHandler   *scheduleCommands.EndFocusModeHandler
+
+	// Schedule Block Dependency Handlers
+	AddBlockDependencyHandler    *scheduleCommands.AddBlockDependencyHandler
+	RemoveBlockDependencyHandler *scheduleCommands.RemoveBlockDependencyHandler
+	AddBlockAttachmentHandler    *scheduleCommands.AddBlockAttachmentHandler
 
 	// Scheduler Engine
 	SchedulerEngine *schedulerServices.SchedulerEngine
@@ -180,19 +243,26 @@ type Container struct {
 	SchedulingSubscriber   *scheduleSubs.SchedulingSubscriber
 	CalendarSyncSubscriber *calendarSubs.CalendarSyncSubscriber
 	InProcessEventBus      *eventbus.InProcessEventBus
+	EventUpcasters         *eventbus.UpcasterRegistry
 
 	// Schedule Query Handlers
 	GetScheduleHandler            *scheduleQueries.GetScheduleHandler
 	FindAvailableSlotsHandler     *scheduleQueries.FindAvailableSlotsHandler
+	GetCapacityHandler            *scheduleQueries.GetCapacityHandler
 	ListRescheduleAttemptsHandler *scheduleQueries.ListRescheduleAttemptsHandler
+	GetScheduleStatsHandler       *scheduleQueries.GetScheduleStatsHandler
 
 	// Inbox
-	InboxRepo               *inboxPersistence.PostgresInboxRepository
+	InboxRepo               inboxDomain.InboxRepository
 	InboxClassifier         *inboxServices.Classifier
 	CaptureInboxItemHandler *inboxCommands.CaptureInboxItemHandler
 	PromoteInboxItemHandler *inboxCommands.PromoteInboxItemHandler
 	ListInboxItemsHandler   *inboxQueries.ListInboxItemsHandler
 	GetInboxItemHandler     *inboxQueries.GetInboxItemHandler
+	InboxExpirySweeper      *inboxWorkers.ExpirySweeper
+
+	// Search
+	SearchEntitiesHandler *searchQueries.SearchEntitiesHandler
 
 	// Outbox Processor
 	OutboxProcessor *outbox.Processor
@@ -207,6 +277,7 @@ type Container struct {
 	OrbitExecutor *orbitRuntime.Executor
 
 	// Marketplace
+	MarketplaceRegistry      marketplaceDomain.Registry
 	MarketplacePackageRepo   marketplaceDomain.PackageRepository
 	MarketplaceVersionRepo   marketplaceDomain.VersionRepository
 	MarketplacePublisherRepo marketplaceDomain.PublisherRepository
@@ -214,9 +285,22 @@ type Container struct {
 	SearchMarketplacePackages *marketplaceQueries.SearchPackagesHandler
 	GetMarketplacePackage    *marketplaceQueries.GetPackageHandler
 	GetMarketplaceFeatured   *marketplaceQueries.GetFeaturedHandler
+	MarketplaceInstalledRepo marketplaceDomain.InstalledPackageRepository
+	ListInstalledPackages    *marketplaceQueries.ListInstalledHandler
+	CheckUpdatesHandler      *marketplaceQueries.CheckUpdatesHandler
+	UpdatePackageHandler     *marketplaceCommands.UpdatePackageHandler
+	MarketplaceAPITokenRepo  marketplaceCommands.APITokenRepository
+	MarketplaceLogin         *marketplaceCommands.LoginHandler
+	MarketplaceLogout        *marketplaceCommands.LogoutHandler
+	MarketplaceWhoAmI        *marketplaceCommands.WhoAmIHandler
 
 	// Automations
-	AutomationService *automationApp.Service
+	AutomationService        *automationApp.Service
+	AutomationActionExecutor *automationServices.ActionExecutor
+
+	// Notifications
+	NotificationDispatcher *notificationServices.Dispatcher
+	DigestSender           *notificationWorkers.DigestSender
 
 	// Insights
 	InsightsService *insightsApp.Service
@@ -238,17 +322,26 @@ type Container struct {
 	// Project Query Handlers
 	GetProjectHandler   *projectQueries.GetProjectHandler
 	ListProjectsHandler *projectQueries.ListProjectsHandler
+
+	// degraded records why a dependency fell back at startup, by capability.
+	degraded map[string]string
 }
 
 // NewContainer creates and wires all dependencies.
 func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Container, error) {
 	c := &Container{
-		Config: cfg,
-		Logger: logger,
+		Config:       cfg,
+		Logger:       logger,
+		WeekStartsOn: weekStartFromConfig(cfg, logger),
+	}
+
+	fields, err := fieldEncrypter(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	// Connect to PostgreSQL
-	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	pool, err := postgres.NewPool(ctx, postgresConfig(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -260,7 +353,7 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 	}
 
 	c.DB = pool
-	logger.Info("connected to database")
+	logger.Info("connected to database", "max_conns", pool.Config().MaxConns)
 
 	// Connect to Redis (optional in development)
 	if cfg.RedisURL != "" {
@@ -271,6 +364,7 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 				return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
 			}
 			logger.Warn("invalid Redis URL, orbit storage will use in-memory fallback", "error", err)
+			c.degrade(capability.OrbitStorage, "REDIS_URL is invalid")
 		} else {
 			redisClient := redis.NewClient(opt)
 			if err := redisClient.Ping(ctx).Err(); err != nil {
@@ -279,6 +373,7 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 					return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 				}
 				logger.Warn("Redis not available, orbit storage will use in-memory fallback", "error", err)
+				c.degrade(capability.OrbitStorage, "Redis is not reachable")
 			} else {
 				c.RedisClient = redisClient
 				logger.Info("connected to Redis")
@@ -287,28 +382,33 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 	}
 
 	// Create repositories
-	c.TaskRepo = persistence.NewPostgresTaskRepositoryFromPool(pool)
-	c.HabitRepo = habitPersistence.NewPostgresHabitRepository(pool)
-	c.MeetingRepo = meetingPersistence.NewPostgresMeetingRepository(pool)
+	taskStore := persistence.NewPostgresTaskRepositoryFromPool(pool).WithFieldEncrypter(fields)
+	c.TaskRepo = persistence.NewGuardedTaskRepository(taskStore)
+	c.HabitRepo = habitPersistence.NewGuardedHabitRepository(habitPersistence.NewPostgresHabitRepository(pool).WithFieldEncrypter(fields))
+	c.MeetingRepo = meetingPersistence.NewGuardedMeetingRepository(meetingPersistence.NewPostgresMeetingRepository(pool))
 	c.EntitlementRepo = billingPersistence.NewPostgresEntitlementRepository(pool)
 	c.SubscriptionRepo = billingPersistence.NewPostgresSubscriptionRepository(pool)
-	c.ScheduleRepo = schedulePersistence.NewPostgresScheduleRepository(pool)
+	scheduleStore := schedulePersistence.NewPostgresScheduleRepository(pool)
+	c.ScheduleRepo = schedulePersistence.NewGuardedScheduleRepository(scheduleStore)
 	c.RescheduleAttemptRepo = schedulePersistence.NewPostgresRescheduleAttemptRepository(pool)
 	c.OAuthTokenRepo = identityPersistence.NewOAuthTokenRepository(pool)
 	c.SettingsRepo = identityPersistence.NewSettingsRepository(pool)
+	c.NotificationDispatcher = newNotificationDispatcher(cfg, c.SettingsRepo, logger)
 	c.UserRepo = identityPersistence.NewPostgresUserRepository(pool)
 	c.OutboxRepo = outbox.NewPostgresRepository(pool)
 	c.UnitOfWork = sharedPersistence.NewPostgresUnitOfWork(pool)
-	c.InboxRepo = inboxPersistence.NewPostgresInboxRepository(pool)
+	inboxStore := inboxPersistence.NewPostgresInboxRepository(pool)
+	c.InboxRepo = inboxPersistence.NewGuardedInboxRepository(inboxStore)
 	c.InboxClassifier = inboxServices.NewClassifier()
 
 	// Create event publisher
-	publisher, err := eventbus.NewRabbitMQPublisher(cfg.RabbitMQURL, logger)
+	publisher, err := eventbus.NewRabbitMQPublisherWithConfig(rabbitMQPublisherConfig(cfg, logger))
 	if err != nil {
 		// Fall back to noop publisher in development
 		if cfg.IsDevelopment() {
 			logger.Warn("RabbitMQ not available, using noop publisher")
 			c.EventPublisher = eventbus.NewNoopPublisher(logger)
+			c.degrade(capability.EventDelivery, "RabbitMQ is not reachable")
 		} else {
 			pool.Close()
 			return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
@@ -323,19 +423,39 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 	c.ArchiveTaskHandler = commands.NewArchiveTaskHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork)
 	c.StartTaskHandler = commands.NewStartTaskHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork)
 	c.UpdateTaskHandler = commands.NewUpdateTaskHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork)
+	c.ImportTaskHandler = commands.NewImportTaskHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork, c.CreateTaskHandler)
+	c.AddChecklistItemHandler = commands.NewAddChecklistItemHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork)
+	c.ToggleChecklistItemHandler = commands.NewToggleChecklistItemHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork)
+	c.BlockTaskHandler = commands.NewBlockTaskHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork)
+	c.UnblockTaskHandler = commands.NewUnblockTaskHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork)
+	c.WaitOnTaskHandler = commands.NewWaitOnTaskHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork).
+		WithFollowUpDelay(cfg.TaskFollowUpDelay)
+	c.ResolveWaitingTaskHandler = commands.NewResolveWaitingTaskHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork)
+	c.BulkTagTasksHandler = commands.NewBulkTagTasksHandler(c.TaskRepo, c.OutboxRepo, c.UnitOfWork)
 
 	// Create task query handlers
 	c.ListTasksHandler = queries.NewListTasksHandler(c.TaskRepo)
 	c.GetTaskHandler = queries.NewGetTaskHandler(c.TaskRepo)
+	c.GetTaskStatsHandler = queries.NewGetTaskStatsHandler(c.TaskRepo)
+	c.ReminderDispatcher = newReminderDispatcher(cfg, taskStore, c.OutboxRepo, c.UnitOfWork, logger)
+	c.PriorityEscalator = newPriorityEscalator(cfg, taskStore, c.OutboxRepo, c.UnitOfWork, logger)
+	c.TaskArchiver = newTaskArchiver(cfg, taskStore, c.ArchiveTaskHandler, logger)
 
 	// Create habit command handlers
 	c.CreateHabitHandler = habitCommands.NewCreateHabitHandler(c.HabitRepo, c.OutboxRepo, c.UnitOfWork)
 	c.LogCompletionHandler = habitCommands.NewLogCompletionHandler(c.HabitRepo, c.OutboxRepo, c.UnitOfWork)
+	c.BulkLogCompletionHandler = habitCommands.NewBulkLogCompletionHandler(c.HabitRepo, c.OutboxRepo, c.UnitOfWork)
 	c.ArchiveHabitHandler = habitCommands.NewArchiveHabitHandler(c.HabitRepo, c.OutboxRepo, c.UnitOfWork)
+	c.SkipHabitHandler = habitCommands.NewSkipHabitHandler(c.HabitRepo, c.OutboxRepo, c.UnitOfWork)
+	c.FreezeHabitHandler = habitCommands.NewFreezeHabitHandler(c.HabitRepo, c.OutboxRepo, c.UnitOfWork)
+	c.UnfreezeHabitHandler = habitCommands.NewUnfreezeHabitHandler(c.HabitRepo, c.OutboxRepo, c.UnitOfWork)
 	c.AdjustHabitFrequencyHandler = habitCommands.NewAdjustHabitFrequencyHandler(c.HabitRepo, c.OutboxRepo, c.UnitOfWork)
+	c.RecommendHabitTimeHandler = habitCommands.NewRecommendHabitTimeHandler(c.HabitRepo, c.UnitOfWork)
+	c.BulkTagHabitsHandler = habitCommands.NewBulkTagHabitsHandler(c.HabitRepo, c.OutboxRepo, c.UnitOfWork)
 
 	// Create habit query handlers
 	c.ListHabitsHandler = habitQueries.NewListHabitsHandler(c.HabitRepo)
+	c.GetDueHabitsHandler = habitQueries.NewGetDueHabitsHandler(c.HabitRepo)
 	c.GetHabitHandler = habitQueries.NewGetHabitHandler(c.HabitRepo)
 
 	// Create meeting command handlers
@@ -343,6 +463,7 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 	c.UpdateMeetingHandler = meetingCommands.NewUpdateMeetingHandler(c.MeetingRepo, c.OutboxRepo, c.UnitOfWork)
 	c.ArchiveMeetingHandler = meetingCommands.NewArchiveMeetingHandler(c.MeetingRepo, c.OutboxRepo, c.UnitOfWork)
 	c.MarkMeetingHeldHandler = meetingCommands.NewMarkMeetingHeldHandler(c.MeetingRepo, c.UnitOfWork)
+	c.MarkMeetingCanceledHandler = meetingCommands.NewMarkMeetingCanceledHandler(c.MeetingRepo, c.UnitOfWork)
 	c.AdjustMeetingCadenceHandler = meetingCommands.NewAdjustMeetingCadenceHandler(c.MeetingRepo, c.OutboxRepo, c.UnitOfWork)
 
 	// Create meeting query handlers
@@ -360,28 +481,58 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 		c.CreateHabitHandler,
 		c.CreateMeetingHandler,
 	)
+	c.InboxExpirySweeper = newInboxExpirySweeper(cfg, inboxStore, c.NotificationDispatcher, logger)
+	c.SearchEntitiesHandler = newSearchEntitiesHandler(c.TaskRepo, c.HabitRepo, c.MeetingRepo, c.InboxRepo)
 
 	// Create scheduler engine
-	c.SchedulerEngine = schedulerServices.NewSchedulerEngine(schedulerServices.DefaultSchedulerConfig())
+	c.SchedulerEngine = schedulerServices.NewSchedulerEngine(schedulerConfig(cfg))
 
 	// Create schedule command handlers
 	c.AddBlockHandler = scheduleCommands.NewAddBlockHandler(c.ScheduleRepo, c.OutboxRepo, c.UnitOfWork)
 	c.CompleteBlockHandler = scheduleCommands.NewCompleteBlockHandler(c.ScheduleRepo, c.OutboxRepo, c.UnitOfWork)
 	c.RemoveBlockHandler = scheduleCommands.NewRemoveBlockHandler(c.ScheduleRepo, c.OutboxRepo, c.UnitOfWork)
-	c.RescheduleBlockHandler = scheduleCommands.NewRescheduleBlockHandler(c.ScheduleRepo, c.OutboxRepo, c.UnitOfWork)
+	c.RescheduleBlockHandler = scheduleCommands.NewRescheduleBlockHandler(c.ScheduleRepo, c.OutboxRepo, c.UnitOfWork).WithSchedulerConfig(schedulerConfig(cfg))
+	c.AddBlockDependencyHandler = scheduleCommands.NewAddBlockDependencyHandler(c.ScheduleRepo, c.OutboxRepo, c.UnitOfWork).WithSchedulerConfig(schedulerConfig(cfg))
+	c.RemoveBlockDependencyHandler = scheduleCommands.NewRemoveBlockDependencyHandler(c.ScheduleRepo, c.UnitOfWork)
+	c.AddBlockAttachmentHandler = scheduleCommands.NewAddBlockAttachmentHandler(c.ScheduleRepo, c.UnitOfWork)
+	c.RescheduleDayHandler = scheduleCommands.NewRescheduleDayHandler(c.ScheduleRepo, c.OutboxRepo, c.UnitOfWork).WithSchedulerConfig(schedulerConfig(cfg))
 	c.AutoScheduleHandler = scheduleCommands.NewAutoScheduleHandler(c.ScheduleRepo, c.OutboxRepo, c.UnitOfWork, c.SchedulerEngine, logger)
-	c.AutoRescheduleHandler = scheduleCommands.NewAutoRescheduleHandler(c.ScheduleRepo, c.RescheduleAttemptRepo, c.OutboxRepo, c.UnitOfWork, c.SchedulerEngine)
+	c.AutoRescheduleHandler = scheduleCommands.NewAutoRescheduleHandler(c.ScheduleRepo, c.RescheduleAttemptRepo, c.OutboxRepo, c.UnitOfWork, c.SchedulerEngine).
+		WithMissedBlockPolicy(missedBlockPolicy(cfg))
+	c.StartFocusModeHandler = scheduleCommands.NewStartFocusModeHandler(c.ScheduleRepo, c.OutboxRepo, c.UnitOfWork, c.SchedulerEngine)
+	c.EndFocusModeHandler = scheduleCommands.NewEndFocusModeHandler(c.ScheduleRepo, c.OutboxRepo, c.UnitOfWork)
+	if c.ReminderDispatcher != nil {
+		c.ReminderDispatcher.WithFocusChecker(schedulerServices.NewFocusModeGuard(c.ScheduleRepo)).
+			WithNotifier(c.NotificationDispatcher)
+	}
 
 	// Create schedule query handlers
 	c.GetScheduleHandler = scheduleQueries.NewGetScheduleHandler(c.ScheduleRepo)
+	c.BlockRetentionSweeper = newBlockRetentionSweeper(cfg, scheduleStore, logger)
 	c.FindAvailableSlotsHandler = scheduleQueries.NewFindAvailableSlotsHandler(c.ScheduleRepo)
+	c.GetCapacityHandler = scheduleQueries.NewGetCapacityHandler(c.ScheduleRepo)
+	c.GetScheduleStatsHandler = scheduleQueries.NewGetScheduleStatsHandler(c.ScheduleRepo)
 	c.ListRescheduleAttemptsHandler = scheduleQueries.NewListRescheduleAttemptsHandler(c.RescheduleAttemptRepo)
 
 	// Create settings service
 	c.SettingsService = identitySettings.NewService(c.SettingsRepo)
+	if c.ReminderDispatcher != nil {
+		c.ReminderDispatcher.WithUserLocations(c.SettingsService)
+	}
+	c.CreateTaskHandler.WithDefaultDurations(c.SettingsService)
+	c.ListTasksHandler.WithSortDefaults(c.SettingsService)
+	c.ListHabitsHandler.WithSortDefaults(c.SettingsService)
+	c.ListHabitsHandler.WithDayRollovers(c.SettingsService)
+	c.GetHabitHandler.WithDayRollovers(c.SettingsService)
+	c.GetDueHabitsHandler.WithDayRollovers(c.SettingsService)
+	c.LogCompletionHandler.WithDayRollovers(c.SettingsService)
+	c.BulkLogCompletionHandler.WithDayRollovers(c.SettingsService)
+	c.SkipHabitHandler.WithDayRollovers(c.SettingsService)
+	c.InboxClassifier.WithRules(c.SettingsService)
 	c.BillingService = billingApp.NewService(c.EntitlementRepo, c.SubscriptionRepo)
 
 	// Create marketplace repositories
+	c.MarketplaceRegistry = marketplaceDomain.NewRegistry(cfg.MarketplaceURL, cfg.MarketplaceMirrors...)
 	c.MarketplacePackageRepo = marketplacePersistence.NewPostgresPackageRepository(pool)
 	c.MarketplaceVersionRepo = marketplacePersistence.NewPostgresVersionRepository(pool)
 	c.MarketplacePublisherRepo = marketplacePersistence.NewPostgresPublisherRepository(pool)
@@ -392,12 +543,30 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 	c.GetMarketplacePackage = marketplaceQueries.NewGetPackageHandler(c.MarketplacePackageRepo, c.MarketplaceVersionRepo, c.MarketplacePublisherRepo)
 	c.GetMarketplaceFeatured = marketplaceQueries.NewGetFeaturedHandler(c.MarketplacePackageRepo)
 
+	// Create installed package handlers
+	c.MarketplaceInstalledRepo = marketplacePersistence.NewInstalledPackageRepository(pool)
+	c.ListInstalledPackages = marketplaceQueries.NewListInstalledHandler(c.MarketplaceInstalledRepo)
+	c.CheckUpdatesHandler = marketplaceQueries.NewCheckUpdatesHandler(c.MarketplaceInstalledRepo, c.MarketplacePackageRepo)
+	c.UpdatePackageHandler = marketplaceCommands.NewUpdatePackageHandler(c.MarketplacePackageRepo, c.MarketplaceVersionRepo, c.MarketplaceInstalledRepo, cfg.MarketplaceInstallDir).
+		WithRegistry(c.MarketplaceRegistry)
+
+	// Create marketplace auth handlers
+	c.MarketplaceAPITokenRepo = marketplacePersistence.NewPostgresAPITokenRepository(pool)
+	c.MarketplaceWhoAmI = marketplaceCommands.NewWhoAmIHandler(cfg.MarketplaceConfigDir)
+	if credentialStore, err := marketplaceCredentialStore(cfg); err != nil {
+		logger.Warn("marketplace login not available", "error", err)
+	} else {
+		c.MarketplaceLogin = marketplaceCommands.NewLoginHandler(c.MarketplaceAPITokenRepo, c.MarketplacePublisherRepo, cfg.MarketplaceConfigDir, credentialStore)
+		c.MarketplaceLogout = marketplaceCommands.NewLogoutHandler(cfg.MarketplaceConfigDir, credentialStore)
+	}
+
 	// Create automation repositories and service
 	automationQueries := db.New(pool)
 	automationRuleRepo := automationPersistence.NewRuleRepository(automationQueries)
 	automationExecRepo := automationPersistence.NewExecutionRepository(automationQueries)
 	automationPendingRepo := automationPersistence.NewPendingActionRepository(automationQueries)
 	c.AutomationService = automationApp.NewService(automationRuleRepo, automationExecRepo, automationPendingRepo)
+	c.AutomationActionExecutor = newAutomationActionExecutor(automationPendingRepo, c.NotificationDispatcher, c.PromoteInboxItemHandler, logger)
 
 	// Create insights repositories and service
 	insightsQueries := db.New(pool)
@@ -406,7 +575,11 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 	summaryRepo := insightsPersistence.NewSummaryRepository(insightsQueries)
 	goalRepo := insightsPersistence.NewGoalRepository(insightsQueries)
 	analyticsDataSource := insightsPersistence.NewAnalyticsDataSource(insightsQueries)
-	c.InsightsService = insightsApp.NewService(snapshotRepo, sessionRepo, summaryRepo, goalRepo, analyticsDataSource)
+	c.InsightsService = insightsApp.NewService(snapshotRepo, sessionRepo, summaryRepo, goalRepo, analyticsDataSource).
+		WithSnapshotRefresh(insightsCommands.DefaultSnapshotMaxAge).
+		WithWeekStart(c.WeekStartsOn)
+	c.DigestSender = newDigestSender(cfg, c.SettingsRepo, notificationServices.NewDigestComposer(c.ListTasksHandler, c.GetScheduleHandler, c.ListHabitsHandler).
+		WithWeeklyReview(c.InsightsService), c.NotificationDispatcher, c.WeekStartsOn, logger)
 
 	// Create auth service if configured
 	scopes := identityOAuth.ScopesFromEnv(cfg.OAuthScopes)
@@ -414,6 +587,7 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 		encrypter, err := sharedCrypto.NewAESGCMFromBase64Key(cfg.EncryptionKey)
 		if err != nil {
 			logger.Warn("auth encryption not configured", "error", err)
+			c.degrade(capability.CalendarSync, "ENCRYPTION_KEY is missing or invalid")
 		} else {
 			service, err := identityOAuth.NewService(
 				cfg.OAuthProvider,
@@ -428,8 +602,9 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 			)
 			if err != nil {
 				logger.Warn("failed to initialize auth service", "error", err)
+				c.degrade(capability.CalendarSync, "the OAuth service failed to start")
 			} else {
-				c.AuthService = service
+				c.AuthService = service.WithExpirySkew(cfg.OAuthTokenExpirySkew)
 			}
 		}
 	}
@@ -443,6 +618,7 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 
 	// Create connected calendar repository
 	c.ConnectedCalendarRepo = calendarPersistence.NewPostgresConnectedCalendarRepository(pool)
+	c.SyncStateRepo = calendarPersistence.NewPostgresSyncStateRepository(pool)
 
 	// Create provider registry and register available providers
 	c.ProviderRegistry = calendarApp.NewProviderRegistry()
@@ -471,12 +647,15 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 		logger,
 	)
 
-	// Create calendar syncer if provider is supported (legacy single-provider mode)
+	// Create calendar syncer if provider is supported (legacy single-provider mode).
+	// It syncs the default account only; SyncCoordinator syncs every connected
+	// calendar with the tokens of the account it belongs to.
 	if c.AuthService != nil && cfg.OAuthProvider == "google" {
 		syncer := googleCalendar.NewSyncer(c.AuthService, logger)
 		if cfg.CalendarDeleteMissing {
 			syncer.WithDeleteMissing(true)
 		}
+		syncer.WithMassDeleteThreshold(cfg.CalendarMassDeleteThreshold)
 		if cfg.CalendarID != "" {
 			syncer.WithCalendarID(cfg.CalendarID)
 		}
@@ -498,6 +677,9 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 	if err := c.EngineRegistry.RegisterBuiltin(builtin.NewDefaultSchedulerEngine()); err != nil {
 		logger.Warn("failed to register default scheduler engine", "error", err)
 	}
+	if err := c.EngineRegistry.RegisterBuiltin(builtin.NewWorkloadSchedulerEngine()); err != nil {
+		logger.Warn("failed to register workload scheduler engine", "error", err)
+	}
 	if err := c.EngineRegistry.RegisterBuiltin(builtin.NewDefaultPriorityEngine()); err != nil {
 		logger.Warn("failed to register default priority engine", "error", err)
 	}
@@ -509,14 +691,22 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 	}
 
 	// Create engine executor with circuit breaker
-	executorConfig := runtime.DefaultExecutorConfig()
+	executorConfig := engineExecutorConfig(cfg, c.EngineRegistry)
 	metricsCollector := runtime.NewMetricsCollector()
 	c.EngineExecutor = runtime.NewExecutor(c.EngineRegistry, metricsCollector, logger, executorConfig)
+	c.AutomationService.WithRuleValidator(c.EngineExecutor)
+	c.ListTasksHandler.WithPriorityScorer(c.EngineExecutor)
 
 	logger.Info("registered engines", "count", c.EngineRegistry.Count())
+	selectDefaultEngines(cfg, c.EngineRegistry, logger)
 
 	// Create orbit registry and register built-in orbits
-	c.OrbitRegistry = orbitRegistry.NewRegistry(logger, c.BillingService)
+	orbitGrantsPath := cfg.OrbitGrantsPath
+	if orbitGrantsPath == "" {
+		orbitGrantsPath = orbitRegistry.DefaultGrantsPath()
+	}
+	c.OrbitRegistry = orbitRegistry.NewRegistry(logger, c.BillingService).
+		WithGrantStore(orbitRegistry.NewFileGrantStore(orbitGrantsPath))
 
 	// Register built-in orbits
 	wellnessOrbit := wellness.New()
@@ -585,6 +775,7 @@ func NewContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger)
 		ListHabitHandler:   c.ListHabitsHandler,
 		GetHabitHandler:    c.GetHabitHandler,
 		ScheduleHandler:    c.GetScheduleHandler,
+		WeekStartsOn:       c.WeekStartsOn,
 		ListMeetingHandler: c.ListMeetingsHandler,
 		GetMeetingHandler:  c.GetMeetingHandler,
 		ListInboxHandler:   c.ListInboxItemsHandler,
@@ -639,6 +830,36 @@ func (c *Container) Close() {
 		}
 	}
 
+	// Stop task reminder dispatcher
+	if c.ReminderDispatcher != nil && c.ReminderDispatcher.IsRunning() {
+		c.ReminderDispatcher.Stop()
+	}
+
+	// Stop task priority escalator
+	if c.PriorityEscalator != nil && c.PriorityEscalator.IsRunning() {
+		c.PriorityEscalator.Stop()
+	}
+
+	// Stop completed task archiver
+	if c.TaskArchiver != nil && c.TaskArchiver.IsRunning() {
+		c.TaskArchiver.Stop()
+	}
+
+	// Stop block retention sweeper
+	if c.BlockRetentionSweeper != nil && c.BlockRetentionSweeper.IsRunning() {
+		c.BlockRetentionSweeper.Stop()
+	}
+
+	// Stop inbox expiry sweeper
+	if c.InboxExpirySweeper != nil && c.InboxExpirySweeper.IsRunning() {
+		c.InboxExpirySweeper.Stop()
+	}
+
+	// Stop digest sender
+	if c.DigestSender != nil && c.DigestSender.IsRunning() {
+		c.DigestSender.Stop()
+	}
+
 	// Stop calendar import worker
 	if c.CalendarImportWorker != nil && c.CalendarImportWorker.IsRunning() {
 		c.CalendarImportWorker.Stop()
@@ -681,8 +902,9 @@ func (c *Container) Close() {
 // NewDevelopmentContainer creates a container for local development without external services.
 func NewDevelopmentContainer(logger *slog.Logger) *Container {
 	c := &Container{
-		Config: &config.Config{AppEnv: "development"},
-		Logger: logger,
+		Config:       &config.Config{AppEnv: "development"},
+		Logger:       logger,
+		WeekStartsOn: sharedDomain.DefaultWeekStart,
 	}
 
 	// Use in-memory repositories
@@ -699,8 +921,14 @@ func NewDevelopmentContainer(logger *slog.Logger) *Container {
 // This provides zero-config operation without requiring PostgreSQL, Redis, or RabbitMQ.
 func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*Container, error) {
 	c := &Container{
-		Config: cfg,
-		Logger: logger,
+		Config:       cfg,
+		Logger:       logger,
+		WeekStartsOn: weekStartFromConfig(cfg, logger),
+	}
+
+	fields, err := fieldEncrypter(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	// Initialize SQLite database
@@ -710,38 +938,44 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 	}
 
 	// Create repository factory
-	factory := NewRepositoryFactory(conn)
+	factory := NewRepositoryFactory(conn).WithFieldEncrypter(fields)
 
 	// Create repositories using factory
-	taskRepo, err := factory.TaskRepository()
+	taskStore, err := factory.TaskRepository()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create task repository: %w", err)
 	}
+	taskRepo := persistence.NewGuardedTaskRepository(taskStore)
 	c.TaskRepo = taskRepo
 
-	habitRepo, err := factory.HabitRepository()
+	habitStore, err := factory.HabitRepository()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create habit repository: %w", err)
 	}
+	habitRepo := habitPersistence.NewGuardedHabitRepository(habitStore)
 	c.HabitRepo = habitRepo
 
-	meetingRepo, err := factory.MeetingRepository()
+	meetingStore, err := factory.MeetingRepository()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create meeting repository: %w", err)
 	}
+	meetingRepo := meetingPersistence.NewGuardedMeetingRepository(meetingStore)
 	c.MeetingRepo = meetingRepo
 
-	scheduleRepo, err := factory.ScheduleRepository()
+	scheduleStore, err := factory.ScheduleRepository()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create schedule repository: %w", err)
 	}
+	scheduleRepo := schedulePersistence.NewGuardedScheduleRepository(scheduleStore)
 	c.ScheduleRepo = scheduleRepo
+	c.BlockRetentionSweeper = newBlockRetentionSweeper(cfg, scheduleStore, logger)
 
 	settingsRepo, err := factory.SettingsRepository()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create settings repository: %w", err)
 	}
 	c.SettingsRepo = settingsRepo
+	c.NotificationDispatcher = newNotificationDispatcher(cfg, settingsRepo, logger)
 
 	userRepo, err := factory.UserRepository()
 	if err != nil {
@@ -767,19 +1001,39 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 	c.ArchiveTaskHandler = commands.NewArchiveTaskHandler(taskRepo, outboxRepo, c.UnitOfWork)
 	c.StartTaskHandler = commands.NewStartTaskHandler(taskRepo, outboxRepo, c.UnitOfWork)
 	c.UpdateTaskHandler = commands.NewUpdateTaskHandler(taskRepo, outboxRepo, c.UnitOfWork)
+	c.ImportTaskHandler = commands.NewImportTaskHandler(taskRepo, outboxRepo, c.UnitOfWork, c.CreateTaskHandler)
+	c.AddChecklistItemHandler = commands.NewAddChecklistItemHandler(taskRepo, outboxRepo, c.UnitOfWork)
+	c.ToggleChecklistItemHandler = commands.NewToggleChecklistItemHandler(taskRepo, outboxRepo, c.UnitOfWork)
+	c.BlockTaskHandler = commands.NewBlockTaskHandler(taskRepo, outboxRepo, c.UnitOfWork)
+	c.UnblockTaskHandler = commands.NewUnblockTaskHandler(taskRepo, outboxRepo, c.UnitOfWork)
+	c.WaitOnTaskHandler = commands.NewWaitOnTaskHandler(taskRepo, outboxRepo, c.UnitOfWork).
+		WithFollowUpDelay(cfg.TaskFollowUpDelay)
+	c.ResolveWaitingTaskHandler = commands.NewResolveWaitingTaskHandler(taskRepo, outboxRepo, c.UnitOfWork)
+	c.BulkTagTasksHandler = commands.NewBulkTagTasksHandler(taskRepo, outboxRepo, c.UnitOfWork)
 
 	// Create task query handlers
 	c.ListTasksHandler = queries.NewListTasksHandler(taskRepo)
 	c.GetTaskHandler = queries.NewGetTaskHandler(taskRepo)
+	c.GetTaskStatsHandler = queries.NewGetTaskStatsHandler(taskRepo)
+	c.ReminderDispatcher = newReminderDispatcher(cfg, taskStore, outboxRepo, c.UnitOfWork, logger)
+	c.PriorityEscalator = newPriorityEscalator(cfg, taskStore, outboxRepo, c.UnitOfWork, logger)
+	c.TaskArchiver = newTaskArchiver(cfg, taskStore, c.ArchiveTaskHandler, logger)
 
 	// Create habit command handlers
 	c.CreateHabitHandler = habitCommands.NewCreateHabitHandler(habitRepo, outboxRepo, c.UnitOfWork)
 	c.LogCompletionHandler = habitCommands.NewLogCompletionHandler(habitRepo, outboxRepo, c.UnitOfWork)
+	c.BulkLogCompletionHandler = habitCommands.NewBulkLogCompletionHandler(habitRepo, outboxRepo, c.UnitOfWork)
 	c.ArchiveHabitHandler = habitCommands.NewArchiveHabitHandler(habitRepo, outboxRepo, c.UnitOfWork)
+	c.SkipHabitHandler = habitCommands.NewSkipHabitHandler(habitRepo, outboxRepo, c.UnitOfWork)
+	c.FreezeHabitHandler = habitCommands.NewFreezeHabitHandler(habitRepo, outboxRepo, c.UnitOfWork)
+	c.UnfreezeHabitHandler = habitCommands.NewUnfreezeHabitHandler(habitRepo, outboxRepo, c.UnitOfWork)
 	c.AdjustHabitFrequencyHandler = habitCommands.NewAdjustHabitFrequencyHandler(habitRepo, outboxRepo, c.UnitOfWork)
+	c.RecommendHabitTimeHandler = habitCommands.NewRecommendHabitTimeHandler(habitRepo, c.UnitOfWork)
+	c.BulkTagHabitsHandler = habitCommands.NewBulkTagHabitsHandler(habitRepo, outboxRepo, c.UnitOfWork)
 
 	// Create habit query handlers
 	c.ListHabitsHandler = habitQueries.NewListHabitsHandler(habitRepo)
+	c.GetDueHabitsHandler = habitQueries.NewGetDueHabitsHandler(habitRepo)
 	c.GetHabitHandler = habitQueries.NewGetHabitHandler(habitRepo)
 
 	// Create meeting command handlers
@@ -787,6 +1041,7 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 	c.UpdateMeetingHandler = meetingCommands.NewUpdateMeetingHandler(meetingRepo, outboxRepo, c.UnitOfWork)
 	c.ArchiveMeetingHandler = meetingCommands.NewArchiveMeetingHandler(meetingRepo, outboxRepo, c.UnitOfWork)
 	c.MarkMeetingHeldHandler = meetingCommands.NewMarkMeetingHeldHandler(meetingRepo, c.UnitOfWork)
+	c.MarkMeetingCanceledHandler = meetingCommands.NewMarkMeetingCanceledHandler(meetingRepo, c.UnitOfWork)
 	c.AdjustMeetingCadenceHandler = meetingCommands.NewAdjustMeetingCadenceHandler(meetingRepo, outboxRepo, c.UnitOfWork)
 
 	// Create meeting query handlers
@@ -795,22 +1050,38 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 	c.ListMeetingCandidatesHandler = meetingQueries.NewListMeetingCandidatesHandler(meetingRepo)
 
 	// Create scheduler engine
-	c.SchedulerEngine = schedulerServices.NewSchedulerEngine(schedulerServices.DefaultSchedulerConfig())
+	c.SchedulerEngine = schedulerServices.NewSchedulerEngine(schedulerConfig(cfg))
 
 	// Create schedule command handlers
 	c.AddBlockHandler = scheduleCommands.NewAddBlockHandler(scheduleRepo, outboxRepo, c.UnitOfWork)
 	c.CompleteBlockHandler = scheduleCommands.NewCompleteBlockHandler(scheduleRepo, outboxRepo, c.UnitOfWork)
 	c.RemoveBlockHandler = scheduleCommands.NewRemoveBlockHandler(scheduleRepo, outboxRepo, c.UnitOfWork)
-	c.RescheduleBlockHandler = scheduleCommands.NewRescheduleBlockHandler(scheduleRepo, outboxRepo, c.UnitOfWork)
+	c.RescheduleBlockHandler = scheduleCommands.NewRescheduleBlockHandler(scheduleRepo, outboxRepo, c.UnitOfWork).WithSchedulerConfig(schedulerConfig(cfg))
+	c.AddBlockDependencyHandler = scheduleCommands.NewAddBlockDependencyHandler(scheduleRepo, outboxRepo, c.UnitOfWork).WithSchedulerConfig(schedulerConfig(cfg))
+	c.RemoveBlockDependencyHandler = scheduleCommands.NewRemoveBlockDependencyHandler(scheduleRepo, c.UnitOfWork)
+	c.AddBlockAttachmentHandler = scheduleCommands.NewAddBlockAttachmentHandler(scheduleRepo, c.UnitOfWork)
+	c.RescheduleDayHandler = scheduleCommands.NewRescheduleDayHandler(scheduleRepo, outboxRepo, c.UnitOfWork).WithSchedulerConfig(schedulerConfig(cfg))
 	c.AutoScheduleHandler = scheduleCommands.NewAutoScheduleHandler(scheduleRepo, outboxRepo, c.UnitOfWork, c.SchedulerEngine, logger)
+	c.StartFocusModeHandler = scheduleCommands.NewStartFocusModeHandler(scheduleRepo, outboxRepo, c.UnitOfWork, c.SchedulerEngine)
+	c.EndFocusModeHandler = scheduleCommands.NewEndFocusModeHandler(scheduleRepo, outboxRepo, c.UnitOfWork)
+	if c.ReminderDispatcher != nil {
+		c.ReminderDispatcher.WithFocusChecker(schedulerServices.NewFocusModeGuard(scheduleRepo)).
+			WithNotifier(c.NotificationDispatcher)
+	}
 
 	// Create schedule query handlers
 	c.GetScheduleHandler = scheduleQueries.NewGetScheduleHandler(scheduleRepo)
 	c.FindAvailableSlotsHandler = scheduleQueries.NewFindAvailableSlotsHandler(scheduleRepo)
+	c.GetCapacityHandler = scheduleQueries.NewGetCapacityHandler(scheduleRepo)
+	c.GetScheduleStatsHandler = scheduleQueries.NewGetScheduleStatsHandler(scheduleRepo)
 
 	// Create conflict resolver
 	conflictConfig := schedulerServices.ConflictResolverConfig{
-		Strategy: schedulingDomain.ConflictResolutionStrategy(cfg.CalendarConflictStrategy),
+		Strategy:           schedulingDomain.ConflictResolutionStrategy(cfg.CalendarConflictStrategy),
+		CalendarStrategies: make(map[string]schedulingDomain.ConflictResolutionStrategy, len(cfg.CalendarConflictStrategies)),
+	}
+	for calendarID, strategy := range cfg.CalendarConflictStrategies {
+		conflictConfig.CalendarStrategies[calendarID] = schedulingDomain.ConflictResolutionStrategy(strategy)
 	}
 	c.ConflictResolver = schedulerServices.NewConflictResolver(scheduleRepo, c.SchedulerEngine, conflictConfig, logger)
 
@@ -853,6 +1124,10 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 	// Create in-process event bus for local mode (no RabbitMQ)
 	c.InProcessEventBus = eventbus.NewInProcessEventBus(logger)
 
+	// Bring events written in an older schema version to the current shape
+	c.EventUpcasters = eventbus.NewUpcasterRegistry()
+	c.InProcessEventBus.SetUpcasters(c.EventUpcasters)
+
 	// Create scheduling subscriber (auto-schedule tasks/habits/meetings)
 	c.SchedulingSubscriber = scheduleSubs.NewSchedulingSubscriber(
 		c.AutoScheduleHandler,
@@ -882,6 +1157,11 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 		logger.Info("calendar sync subscriber enabled")
 	}
 
+	// Subtract external calendar events from free capacity when they can be read
+	if c.CalendarImporter != nil {
+		c.GetCapacityHandler.WithCalendarEvents(c.CalendarImporter)
+	}
+
 	// Create calendar import worker (imports external events and handles conflicts)
 	if cfg.CalendarSyncEnabled && c.CalendarImporter != nil {
 		// Create conflict handler adapter for calendar import worker
@@ -897,6 +1177,10 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 			MaxSyncErrors:    5,
 			BatchSize:        10,
 			SkipOrbitaEvents: true,
+
+			ImportRecurringMeetings: cfg.CalendarImportRecurringMeetings,
+			ImportEventBlocks:       cfg.CalendarImportEventBlocks,
+			ConflictConcurrency:     cfg.CalendarImportConcurrency,
 		}
 		c.CalendarImportWorker = calendarWorkers.NewCalendarImportWorker(
 			c.CalendarImporter,
@@ -904,15 +1188,34 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 			conflictHandler,
 			workerConfig,
 			logger,
+		).WithMeetingSeriesImporter(
+			meetingCommands.NewImportMeetingSeriesHandler(c.MeetingRepo, c.OutboxRepo, c.UnitOfWork),
+		).WithEventBlockImporter(
+			schedulerServices.NewEventBlockImporter(scheduleRepo),
 		)
 		logger.Info("calendar import worker configured",
 			"interval", cfg.CalendarSyncInterval,
 			"look_ahead_days", cfg.CalendarSyncLookAheadDays,
+			"import_recurring_meetings", cfg.CalendarImportRecurringMeetings,
+			"import_event_blocks", cfg.CalendarImportEventBlocks,
+			"conflict_concurrency", cfg.CalendarImportConcurrency,
 		)
 	}
 
 	// Create settings service
 	c.SettingsService = identitySettings.NewService(settingsRepo)
+	if c.ReminderDispatcher != nil {
+		c.ReminderDispatcher.WithUserLocations(c.SettingsService)
+	}
+	c.CreateTaskHandler.WithDefaultDurations(c.SettingsService)
+	c.ListTasksHandler.WithSortDefaults(c.SettingsService)
+	c.ListHabitsHandler.WithSortDefaults(c.SettingsService)
+	c.ListHabitsHandler.WithDayRollovers(c.SettingsService)
+	c.GetHabitHandler.WithDayRollovers(c.SettingsService)
+	c.GetDueHabitsHandler.WithDayRollovers(c.SettingsService)
+	c.LogCompletionHandler.WithDayRollovers(c.SettingsService)
+	c.BulkLogCompletionHandler.WithDayRollovers(c.SettingsService)
+	c.SkipHabitHandler.WithDayRollovers(c.SettingsService)
 
 	// Create project repository
 	projectRepo, err := factory.ProjectRepository()
@@ -948,11 +1251,12 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 	c.BillingService = licensingApp.NewLocalBillingService(c.LicenseService)
 
 	// Create inbox repository and handlers
-	inboxRepo, err := factory.InboxRepository()
+	inboxStore, err := factory.InboxRepository()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create inbox repository: %w", err)
 	}
-	c.InboxClassifier = inboxServices.NewClassifier()
+	inboxRepo := inboxPersistence.NewGuardedInboxRepository(inboxStore)
+	c.InboxClassifier = inboxServices.NewClassifier().WithRules(c.SettingsService)
 	c.CaptureInboxItemHandler = inboxCommands.NewCaptureInboxItemHandler(inboxRepo, c.InboxClassifier, c.UnitOfWork)
 	c.ListInboxItemsHandler = inboxQueries.NewListInboxItemsHandler(inboxRepo)
 	c.GetInboxItemHandler = inboxQueries.NewGetInboxItemHandler(inboxRepo)
@@ -962,6 +1266,8 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 		c.CreateHabitHandler,
 		c.CreateMeetingHandler,
 	)
+	c.InboxExpirySweeper = newInboxExpirySweeper(cfg, inboxStore, c.NotificationDispatcher, logger)
+	c.SearchEntitiesHandler = newSearchEntitiesHandler(taskRepo, c.HabitRepo, c.MeetingRepo, inboxRepo)
 
 	// Create automation repositories and service
 	ruleRepo, err := factory.RuleRepository()
@@ -977,6 +1283,18 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 		return nil, fmt.Errorf("failed to create automation pending action repository: %w", err)
 	}
 	c.AutomationService = automationApp.NewService(ruleRepo, execRepo, pendingRepo)
+	c.AutomationActionExecutor = newAutomationActionExecutor(pendingRepo, c.NotificationDispatcher, c.PromoteInboxItemHandler, logger)
+
+	// Create marketplace repositories and update handlers. Installed package
+	// listing stays on the install directory, which is what local mode
+	// registers plugin commands from.
+	c.MarketplaceRegistry = marketplaceDomain.NewRegistry(cfg.MarketplaceURL, cfg.MarketplaceMirrors...)
+	c.MarketplacePackageRepo = marketplacePersistence.NewSQLitePackageRepository(conn.DB())
+	c.MarketplaceVersionRepo = marketplacePersistence.NewSQLiteVersionRepository(conn.DB())
+	c.MarketplaceInstalledRepo = marketplacePersistence.NewSQLiteInstalledPackageRepository(conn.DB())
+	c.CheckUpdatesHandler = marketplaceQueries.NewCheckUpdatesHandler(c.MarketplaceInstalledRepo, c.MarketplacePackageRepo)
+	c.UpdatePackageHandler = marketplaceCommands.NewUpdatePackageHandler(c.MarketplacePackageRepo, c.MarketplaceVersionRepo, c.MarketplaceInstalledRepo, cfg.MarketplaceInstallDir).
+		WithRegistry(c.MarketplaceRegistry)
 
 	// Create insights repositories and service
 	snapshotRepo, err := factory.SnapshotRepository()
@@ -999,14 +1317,19 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 	if err != nil {
 		return nil, fmt.Errorf("failed to create insights analytics data source: %w", err)
 	}
-	c.InsightsService = insightsApp.NewService(snapshotRepo, sessionRepo, summaryRepo, goalRepo, analyticsDS)
+	c.InsightsService = insightsApp.NewService(snapshotRepo, sessionRepo, summaryRepo, goalRepo, analyticsDS).
+		WithSnapshotRefresh(insightsCommands.DefaultSnapshotMaxAge).
+		WithWeekStart(c.WeekStartsOn)
+	c.DigestSender = newDigestSender(cfg, c.SettingsRepo, notificationServices.NewDigestComposer(c.ListTasksHandler, c.GetScheduleHandler, c.ListHabitsHandler).
+		WithWeeklyReview(c.InsightsService), c.NotificationDispatcher, c.WeekStartsOn, logger)
 
 	// Create reschedule attempt repository and handler
 	rescheduleAttemptRepo, err := factory.RescheduleAttemptRepository()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create reschedule attempt repository: %w", err)
 	}
-	c.AutoRescheduleHandler = scheduleCommands.NewAutoRescheduleHandler(scheduleRepo, rescheduleAttemptRepo, outboxRepo, c.UnitOfWork, c.SchedulerEngine)
+	c.AutoRescheduleHandler = scheduleCommands.NewAutoRescheduleHandler(scheduleRepo, rescheduleAttemptRepo, outboxRepo, c.UnitOfWork, c.SchedulerEngine).
+		WithMissedBlockPolicy(missedBlockPolicy(cfg))
 	c.ListRescheduleAttemptsHandler = scheduleQueries.NewListRescheduleAttemptsHandler(rescheduleAttemptRepo)
 
 	// Create engine registry and register built-in engines
@@ -1016,6 +1339,9 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 	if err := c.EngineRegistry.RegisterBuiltin(builtin.NewDefaultSchedulerEngine()); err != nil {
 		logger.Warn("failed to register default scheduler engine", "error", err)
 	}
+	if err := c.EngineRegistry.RegisterBuiltin(builtin.NewWorkloadSchedulerEngine()); err != nil {
+		logger.Warn("failed to register workload scheduler engine", "error", err)
+	}
 	if err := c.EngineRegistry.RegisterBuiltin(builtin.NewDefaultPriorityEngine()); err != nil {
 		logger.Warn("failed to register default priority engine", "error", err)
 	}
@@ -1027,14 +1353,22 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 	}
 
 	// Create engine executor with circuit breaker
-	executorConfig := runtime.DefaultExecutorConfig()
+	executorConfig := engineExecutorConfig(cfg, c.EngineRegistry)
 	metricsCollector := runtime.NewMetricsCollector()
 	c.EngineExecutor = runtime.NewExecutor(c.EngineRegistry, metricsCollector, logger, executorConfig)
+	c.AutomationService.WithRuleValidator(c.EngineExecutor)
+	c.ListTasksHandler.WithPriorityScorer(c.EngineExecutor)
 
 	logger.Info("registered engines", "count", c.EngineRegistry.Count())
+	selectDefaultEngines(cfg, c.EngineRegistry, logger)
 
 	// Create orbit registry and register built-in orbits
-	c.OrbitRegistry = orbitRegistry.NewRegistry(logger, c.BillingService)
+	orbitGrantsPath := cfg.OrbitGrantsPath
+	if orbitGrantsPath == "" {
+		orbitGrantsPath = orbitRegistry.DefaultGrantsPath()
+	}
+	c.OrbitRegistry = orbitRegistry.NewRegistry(logger, c.BillingService).
+		WithGrantStore(orbitRegistry.NewFileGrantStore(orbitGrantsPath))
 
 	// Register built-in orbits
 	wellnessOrbit := wellness.New()
@@ -1097,6 +1431,7 @@ func NewLocalContainer(ctx context.Context, cfg *config.Config, logger *slog.Log
 		ListHabitHandler:   c.ListHabitsHandler,
 		GetHabitHandler:    c.GetHabitHandler,
 		ScheduleHandler:    c.GetScheduleHandler,
+		WeekStartsOn:       c.WeekStartsOn,
 		ListMeetingHandler: c.ListMeetingsHandler,
 		GetMeetingHandler:  c.GetMeetingHandler,
 		ListInboxHandler:   c.ListInboxItemsHandler,
@@ -1148,6 +1483,319 @@ type sqliteConnection interface {
 	DB() *sql.DB
 }
 
+// fieldEncrypter builds the encrypter for task and habit free text. Without
+// ORBITA_ENCRYPT_NOTES a configured key is still used to read values that
+// were encrypted earlier, while new values are stored as plaintext.
+func fieldEncrypter(cfg *config.Config) (*sharedCrypto.FieldEncrypter, error) {
+	if cfg.EncryptionKey == "" {
+		if cfg.EncryptNotes {
+			return nil, errors.New("ORBITA_ENCRYPT_NOTES requires ORBITA_ENCRYPTION_KEY")
+		}
+		return nil, nil
+	}
+	fields, err := sharedCrypto.NewFieldEncrypter(cfg.EncryptionKey)
+	if err != nil {
+		if cfg.EncryptNotes {
+			return nil, fmt.Errorf("invalid ORBITA_ENCRYPTION_KEY: %w", err)
+		}
+		return nil, nil
+	}
+	if !cfg.EncryptNotes {
+		return fields.ReadOnly(), nil
+	}
+	return fields, nil
+}
+
+// schedulerConfig builds the scheduler engine settings from configuration.
+func schedulerConfig(cfg *config.Config) schedulerServices.SchedulerConfig {
+	schedulerConfig := schedulerServices.DefaultSchedulerConfig()
+	schedulerConfig.Backtracking = cfg.ScheduleBacktracking
+	schedulerConfig.BacktrackBudget = cfg.ScheduleBacktrackBudget
+	return schedulerConfig
+}
+
+// missedBlockPolicy builds the missed block reschedule policy from configuration.
+func missedBlockPolicy(cfg *config.Config) scheduleCommands.MissedBlockPolicy {
+	return scheduleCommands.MissedBlockPolicy{
+		AutoReschedule: cfg.ScheduleAutoRescheduleMissed,
+		MaxAttempts:    cfg.ScheduleMaxRescheduleAttempts,
+	}
+}
+
+// newReminderDispatcher builds the task reminder dispatcher from configuration.
+// It returns nil when reminders are disabled or the repository cannot look up
+// pending reminders.
+func newReminderDispatcher(cfg *config.Config, taskRepo task.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork, logger *slog.Logger) *productivityWorkers.ReminderDispatcher {
+	if !cfg.TaskRemindersEnabled {
+		return nil
+	}
+	reminderRepo, ok := taskRepo.(productivityWorkers.ReminderTaskRepository)
+	if !ok {
+		return nil
+	}
+
+	quietHours, err := task.ParseQuietHours(cfg.TaskReminderQuietStart, cfg.TaskReminderQuietEnd)
+	if err != nil {
+		logger.Warn("invalid reminder quiet hours, ignoring", "error", err)
+		quietHours = task.QuietHours{}
+	}
+
+	dispatcherConfig := productivityWorkers.DefaultReminderDispatcherConfig()
+	dispatcherConfig.Interval = cfg.TaskReminderInterval
+	dispatcherConfig.QuietHours = quietHours
+
+	return productivityWorkers.NewReminderDispatcher(reminderRepo, outboxRepo, uow, dispatcherConfig, logger)
+}
+
+// newNotificationDispatcher builds the notification dispatcher from
+// configuration. The desktop channel is offered when enabled and the email
+// channel when SMTP is configured; the webhook channel is always available.
+// Notifications share the task reminder quiet hours.
+func newNotificationDispatcher(cfg *config.Config, settings notificationServices.ChannelSettings, logger *slog.Logger) *notificationServices.Dispatcher {
+	dispatcherConfig := notificationServices.DefaultDispatcherConfig()
+	dispatcherConfig.RateLimit = cfg.NotificationRateLimit
+	dispatcherConfig.RateWindow = cfg.NotificationRateWindow
+
+	quietHours, err := task.ParseQuietHours(cfg.TaskReminderQuietStart, cfg.TaskReminderQuietEnd)
+	if err != nil {
+		logger.Warn("invalid notification quiet hours, ignoring", "error", err)
+	} else if quietHours.IsEnabled() {
+		dispatcherConfig.QuietHours = quietHours
+	}
+
+	dispatcher := notificationServices.NewDispatcher(settings, dispatcherConfig, logger).
+		WithNotifier(notificationDomain.ChannelWebhook, notificationInfra.NewWebhookNotifier(nil))
+	if cfg.NotificationsDesktop {
+		dispatcher.WithNotifier(notificationDomain.ChannelDesktop, notificationInfra.NewDesktopNotifier())
+	}
+
+	smtpConfig := notificationInfra.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+	}
+	if smtpConfig.IsConfigured() {
+		dispatcher.WithNotifier(notificationDomain.ChannelEmail, notificationInfra.NewEmailNotifier(smtpConfig))
+	}
+
+	return dispatcher
+}
+
+// newDigestSender builds the scheduled digest sender from configuration.
+// Weekly digests go out on the first day of the week. It returns nil when
+// digests are disabled.
+func newDigestSender(cfg *config.Config, store notificationWorkers.DigestStore, composer notificationWorkers.DigestComposer, notifier notificationDomain.Notifier, weekStart time.Weekday, logger *slog.Logger) *notificationWorkers.DigestSender {
+	if !cfg.DigestsEnabled {
+		return nil
+	}
+
+	senderConfig := notificationWorkers.DefaultDigestSenderConfig()
+	senderConfig.Interval = cfg.DigestInterval
+	senderConfig.MaxDelay = cfg.DigestMaxDelay
+	senderConfig.WeekStart = weekStart
+
+	return notificationWorkers.NewDigestSender(store, composer, notifier, senderConfig, logger)
+}
+
+// newAutomationActionExecutor builds the executor for pending automation
+// actions, with notifications delivered through the dispatcher and inbox
+// items promoted through the promoter.
+func newAutomationActionExecutor(pendingRepo automationDomain.PendingActionRepository, notifier *notificationServices.Dispatcher, promoter automationServices.InboxPromoter, logger *slog.Logger) *automationServices.ActionExecutor {
+	executor := automationServices.NewActionExecutor(pendingRepo, logger)
+	executor.RegisterHandler(automationServices.NewNotificationActionHandler(logger).WithNotifier(notifier))
+	executor.RegisterHandler(automationServices.NewLogActionHandler(logger))
+	executor.RegisterHandler(automationServices.NewInboxPromoteActionHandler(promoter, logger))
+	return executor
+}
+
+// engineExecutorConfig builds the engine executor configuration. When a
+// result cache TTL is configured, results of the built-in priority and
+// classifier engines are cached, since they depend only on their input.
+func engineExecutorConfig(cfg *config.Config, reg *registry.Registry) runtime.ExecutorConfig {
+	executorConfig := runtime.DefaultExecutorConfig()
+	executorConfig.ResultCacheMaxEntries = cfg.EngineResultCacheMaxEntries
+	if cfg.EngineResultCacheTTL <= 0 {
+		return executorConfig
+	}
+
+	executorConfig.ResultCacheTTLs = make(map[string]time.Duration)
+	for _, engineType := range []engineSDK.EngineType{engineSDK.EngineTypePriority, engineSDK.EngineTypeClassifier} {
+		for _, entry := range reg.ListByType(engineType) {
+			if entry.Builtin && entry.Engine != nil {
+				executorConfig.ResultCacheTTLs[entry.Engine.Metadata().ID] = cfg.EngineResultCacheTTL
+			}
+		}
+	}
+	return executorConfig
+}
+
+// selectDefaultEngines applies the configured default engine per type.
+// A selection that is not a registered engine of that type is logged and
+// the built-in engine stays the default.
+func selectDefaultEngines(cfg *config.Config, reg *registry.Registry, logger *slog.Logger) {
+	selections := []struct {
+		engineType engineSDK.EngineType
+		engineID   string
+	}{
+		{engineSDK.EngineTypeScheduler, cfg.SchedulerEngine},
+		{engineSDK.EngineTypePriority, cfg.PriorityEngine},
+		{engineSDK.EngineTypeClassifier, cfg.ClassifierEngine},
+		{engineSDK.EngineTypeAutomation, cfg.AutomationEngine},
+	}
+	for _, selection := range selections {
+		if selection.engineID == "" {
+			continue
+		}
+		if err := reg.SetDefault(selection.engineType, selection.engineID); err != nil {
+			logger.Warn("ignoring configured default engine, using built-in",
+				"type", selection.engineType,
+				"engine_id", selection.engineID,
+				"error", err,
+			)
+		}
+	}
+}
+
+// weekStartFromConfig parses the configured first day of the week, falling
+// back to the default when it is not a valid day name.
+func weekStartFromConfig(cfg *config.Config, logger *slog.Logger) time.Weekday {
+	day, err := sharedDomain.ParseWeekStart(cfg.WeekStartsOn)
+	if err != nil {
+		logger.Warn("invalid week start, using default", "error", err, "default", day)
+	}
+	return day
+}
+
+// newPriorityEscalator builds the task priority escalator from configuration.
+// It returns nil when escalation is disabled, the rules are invalid or the
+// repository cannot look up tasks to escalate.
+func newPriorityEscalator(cfg *config.Config, taskRepo task.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork, logger *slog.Logger) *productivityWorkers.PriorityEscalator {
+	if !cfg.TaskEscalationEnabled {
+		return nil
+	}
+	escalationRepo, ok := taskRepo.(productivityWorkers.EscalationTaskRepository)
+	if !ok {
+		return nil
+	}
+
+	policy, err := task.ParseEscalationPolicy(cfg.TaskEscalationRules)
+	if err != nil {
+		logger.Warn("invalid task escalation rules, escalation disabled", "error", err)
+		return nil
+	}
+
+	escalatorConfig := productivityWorkers.DefaultPriorityEscalatorConfig()
+	escalatorConfig.Interval = cfg.TaskEscalationInterval
+	escalatorConfig.Policy = policy
+
+	return productivityWorkers.NewPriorityEscalator(escalationRepo, outboxRepo, uow, escalatorConfig, logger)
+}
+
+// newTaskArchiver builds the completed task archiver from configuration.
+func newTaskArchiver(cfg *config.Config, taskRepo task.Repository, archiveHandler *commands.ArchiveTaskHandler, logger *slog.Logger) *productivityWorkers.TaskArchiver {
+	if !cfg.TaskRetentionEnabled || cfg.TaskRetentionDays <= 0 {
+		return nil
+	}
+	retentionRepo, ok := taskRepo.(task.RetentionRepository)
+	if !ok {
+		return nil
+	}
+
+	archiverConfig := productivityWorkers.DefaultTaskArchiverConfig()
+	archiverConfig.Interval = cfg.TaskRetentionInterval
+	archiverConfig.RetainFor = time.Duration(cfg.TaskRetentionDays) * 24 * time.Hour
+
+	return productivityWorkers.NewTaskArchiver(retentionRepo, archiveHandler, archiverConfig, logger)
+}
+
+// newBlockRetentionSweeper builds the schedule block retention sweeper from
+// configuration. It returns nil when retention is disabled, the action is
+// invalid or the repository cannot remove blocks.
+func newBlockRetentionSweeper(cfg *config.Config, scheduleRepo schedulingDomain.ScheduleRepository, logger *slog.Logger) *scheduleWorkers.BlockRetentionSweeper {
+	if !cfg.BlockRetentionEnabled || cfg.BlockRetentionDays <= 0 {
+		return nil
+	}
+	retentionRepo, ok := scheduleRepo.(schedulingDomain.BlockRetentionRepository)
+	if !ok {
+		return nil
+	}
+
+	action, err := schedulingDomain.ParseBlockRetentionAction(cfg.BlockRetentionAction)
+	if err != nil {
+		logger.Warn("invalid block retention action, retention disabled", "error", err)
+		return nil
+	}
+
+	sweeperConfig := scheduleWorkers.DefaultBlockRetentionConfig()
+	sweeperConfig.Interval = cfg.BlockRetentionInterval
+	sweeperConfig.RetainFor = time.Duration(cfg.BlockRetentionDays) * 24 * time.Hour
+	sweeperConfig.Action = action
+
+	return scheduleWorkers.NewBlockRetentionSweeper(retentionRepo, sweeperConfig, logger)
+}
+
+// newSearchEntitiesHandler builds the search handler over whichever
+// repositories support full-text search.
+func newSearchEntitiesHandler(tasks task.Repository, habits habitsDomain.Repository, meetings meetingsDomain.Repository, inbox inboxDomain.InboxRepository) *searchQueries.SearchEntitiesHandler {
+	taskSearch, _ := tasks.(task.SearchRepository)
+	habitSearch, _ := habits.(habitsDomain.SearchRepository)
+	meetingSearch, _ := meetings.(meetingsDomain.SearchRepository)
+	inboxSearch, _ := inbox.(inboxDomain.SearchRepository)
+	return searchQueries.NewSearchEntitiesHandler(taskSearch, habitSearch, meetingSearch, inboxSearch)
+}
+
+// newInboxExpirySweeper builds the inbox expiry sweeper from configuration.
+// It returns nil when expiry is disabled, the action is invalid or the
+// repository cannot expire items.
+func newInboxExpirySweeper(cfg *config.Config, inboxRepo inboxDomain.InboxRepository, notifier notificationDomain.Notifier, logger *slog.Logger) *inboxWorkers.ExpirySweeper {
+	if !cfg.InboxExpiryEnabled || cfg.InboxExpiryDays <= 0 {
+		return nil
+	}
+	expiryRepo, ok := inboxRepo.(inboxDomain.ExpiryRepository)
+	if !ok {
+		return nil
+	}
+
+	action, err := inboxDomain.ParseExpiryAction(cfg.InboxExpiryAction)
+	if err != nil {
+		logger.Warn("invalid inbox expiry action, expiry disabled", "error", err)
+		return nil
+	}
+
+	sweeperConfig := inboxWorkers.DefaultExpirySweeperConfig()
+	sweeperConfig.Interval = cfg.InboxExpiryInterval
+	sweeperConfig.MaxAge = time.Duration(cfg.InboxExpiryDays) * 24 * time.Hour
+	sweeperConfig.Action = action
+
+	return inboxWorkers.NewExpirySweeper(expiryRepo, sweeperConfig, logger).WithNotifier(notifier)
+}
+
+// postgresConfig builds the PostgreSQL connection settings, including pool
+// tuning, from configuration.
+func postgresConfig(cfg *config.Config) database.Config {
+	return database.Config{
+		Driver:          database.DriverPostgres,
+		URL:             cfg.DatabaseURL,
+		MaxConns:        cfg.DatabaseMaxConns,
+		MinConns:        cfg.DatabaseMinConns,
+		MaxConnLifetime: cfg.DatabaseMaxConnLifetime,
+		MaxConnIdleTime: cfg.DatabaseMaxConnIdleTime,
+	}
+}
+
+// rabbitMQPublisherConfig maps application config onto the RabbitMQ publisher config.
+func rabbitMQPublisherConfig(cfg *config.Config, logger *slog.Logger) eventbus.RabbitMQPublisherConfig {
+	return eventbus.RabbitMQPublisherConfig{
+		URL:                     cfg.RabbitMQURL,
+		Logger:                  logger,
+		ReconnectInitialBackoff: cfg.RabbitMQReconnectInitialBackoff,
+		ReconnectMaxBackoff:     cfg.RabbitMQReconnectMaxBackoff,
+		BufferSize:              cfg.RabbitMQPublishBufferSize,
+	}
+}
+
 // initSQLiteConnection initializes the SQLite database connection with auto-migration.
 func initSQLiteConnection(ctx context.Context, cfg *config.Config, logger *slog.Logger) (sqliteConnection, error) {
 	// Create SQLite connection
@@ -1217,3 +1865,19 @@ func ensureLocalUserExists(ctx context.Context, db *sql.DB, userID string, logge
 	logger.Info("created local user", "user_id", userID)
 	return nil
 }
+
+// marketplaceCredentialStore builds the store for the marketplace API token.
+// The token is encrypted with ORBITA_ENCRYPTION_KEY, like OAuth tokens, and
+// kept in the OS keyring when there is one. Without a key the store generates
+// its own, which also goes to the keyring when available.
+func marketplaceCredentialStore(cfg *config.Config) (*marketplaceCredentials.TokenStore, error) {
+	var encrypter sharedCrypto.Encrypter
+	if cfg.EncryptionKey != "" {
+		enc, err := sharedCrypto.NewAESGCMFromBase64Key(cfg.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ORBITA_ENCRYPTION_KEY: %w", err)
+		}
+		encrypter = enc
+	}
+	return marketplaceCredentials.NewTokenStore(cfg.MarketplaceConfigDir, encrypter, marketplaceCredentials.NewSystemKeyring()), nil
+}
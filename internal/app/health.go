@@ -0,0 +1,223 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	calendarDomain "github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	licensingDomain "github.com/felixgeelhaar/orbita/internal/licensing/domain"
+	"github.com/felixgeelhaar/orbita/internal/shared/application/health"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/eventbus"
+	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// calendarSyncStaleAfter is how long a pull calendar may go without syncing
+// before it is reported.
+const calendarSyncStaleAfter = 24 * time.Hour
+
+// HealthChecks returns a probe for each dependency the container was built
+// with, as seen by the given user.
+func (c *Container) HealthChecks(userID uuid.UUID) []health.Check {
+	cfg := c.Config
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	var ping func(ctx context.Context) error
+	switch {
+	case c.DB != nil:
+		ping = c.DB.Ping
+	case c.DBConn != nil:
+		ping = c.DBConn.Ping
+	}
+
+	var redisPing func(ctx context.Context) error
+	if c.RedisClient != nil {
+		redisPing = func(ctx context.Context) error { return c.RedisClient.Ping(ctx).Err() }
+	}
+
+	var tokens calendarTokenSource
+	if c.AuthService != nil {
+		tokens = c.AuthService
+	}
+
+	var license licenseChecker
+	if c.LicenseService != nil {
+		license = c.LicenseService
+	}
+
+	return []health.Check{
+		databaseCheck(cfg, ping),
+		redisCheck(cfg, redisPing),
+		rabbitMQCheck(cfg, c.EventPublisher),
+		calendarCheck(c.ConnectedCalendarRepo, tokens, userID, time.Now),
+		licenseCheck(license),
+	}
+}
+
+// StartupFailureChecks reports a container that could not be built, so
+// `orbita doctor` still has something to say when nothing else works.
+func StartupFailureChecks(cfg *config.Config, err error) []health.Check {
+	hint := "Check DATABASE_URL and that PostgreSQL is running (docker-compose up -d), or use local mode with ORBITA_LOCAL_MODE=true."
+	if cfg != nil && cfg.IsLocalMode() {
+		hint = "Check that SQLITE_PATH points to a writable location."
+	}
+	return []health.Check{health.Static(health.Fail("startup", err, hint))}
+}
+
+func databaseCheck(cfg *config.Config, ping func(ctx context.Context) error) health.Check {
+	name := "database"
+	if ping == nil {
+		return health.Static(health.Fail(name, errors.New("not connected"), "Run orbita with a valid database configuration."))
+	}
+	if cfg.IsLocalMode() {
+		return health.Ping(name, ping, "Check that SQLITE_PATH points to a writable SQLite file.")
+	}
+	return health.Ping(name, ping, "Check DATABASE_URL and that PostgreSQL is running (docker-compose up -d).")
+}
+
+func redisCheck(cfg *config.Config, ping func(ctx context.Context) error) health.Check {
+	name := "redis"
+	switch {
+	case ping != nil:
+		return health.Ping(name, ping, "Check REDIS_URL and that Redis is running (docker-compose up -d).")
+	case cfg.IsLocalMode():
+		return health.Static(health.Skipped(name, "not used in local mode"))
+	case cfg.RedisURL == "":
+		return health.Static(health.Skipped(name, "not configured"))
+	default:
+		return health.Static(health.Warn(name, "not connected; orbit storage is in memory",
+			"Check REDIS_URL and that Redis is running (docker-compose up -d)."))
+	}
+}
+
+// brokerPinger is implemented by publishers connected to a real broker.
+type brokerPinger interface {
+	Ping(ctx context.Context) error
+}
+
+func rabbitMQCheck(cfg *config.Config, publisher eventbus.Publisher) health.Check {
+	name := "rabbitmq"
+	hint := "Check RABBITMQ_URL and that RabbitMQ is running (docker-compose up -d)."
+	if pinger, ok := publisher.(brokerPinger); ok {
+		return health.Ping(name, pinger.Ping, hint)
+	}
+	if cfg.IsLocalMode() {
+		return health.Static(health.Skipped(name, "not used in local mode"))
+	}
+	return health.Static(health.Warn(name, "not connected; events are not delivered", hint))
+}
+
+// calendarLister lists a user's connected calendars.
+type calendarLister interface {
+	FindByUser(ctx context.Context, userID uuid.UUID) ([]*calendarDomain.ConnectedCalendar, error)
+}
+
+// calendarTokenSource provides OAuth tokens for the Google calendar provider.
+type calendarTokenSource interface {
+	TokenSource(ctx context.Context, userID uuid.UUID) (oauth2.TokenSource, error)
+}
+
+func calendarCheck(calendars calendarLister, tokens calendarTokenSource, userID uuid.UUID, now func() time.Time) health.Check {
+	name := "calendar"
+	return health.Check{
+		Name: name,
+		Probe: func(ctx context.Context) health.Result {
+			if calendars == nil || userID == uuid.Nil {
+				return health.Skipped(name, "not configured")
+			}
+
+			connected, err := calendars.FindByUser(ctx, userID)
+			if err != nil {
+				return health.Fail(name, err, "Check the database; connected calendars could not be loaded.")
+			}
+
+			var enabled []*calendarDomain.ConnectedCalendar
+			usesGoogle := false
+			for _, cal := range connected {
+				if !cal.IsEnabled() {
+					continue
+				}
+				enabled = append(enabled, cal)
+				if cal.Provider() == calendarDomain.ProviderGoogle {
+					usesGoogle = true
+				}
+			}
+			if len(enabled) == 0 {
+				return health.Skipped(name, "no calendars connected")
+			}
+
+			if usesGoogle {
+				if tokens == nil {
+					return health.Warn(name, "google calendar connected but OAuth is not configured",
+						"Set OAUTH_PROVIDER=google and the OAUTH_* client settings.")
+				}
+				source, err := tokens.TokenSource(ctx, userID)
+				if err == nil {
+					_, err = source.Token()
+				}
+				if err != nil {
+					return health.Fail(name, err, "Reconnect with: orbita auth connect google")
+				}
+			}
+
+			for _, cal := range enabled {
+				if !cal.SyncPull() {
+					continue
+				}
+				if !cal.HasSynced() {
+					return health.Warn(name, fmt.Sprintf("%s has never synced", cal.Name()), "Run: orbita sync")
+				}
+				if age := now().Sub(cal.LastSyncAt()); age > calendarSyncStaleAfter {
+					return health.Warn(name, fmt.Sprintf("%s last synced %s ago", cal.Name(), age.Round(time.Hour)), "Run: orbita sync")
+				}
+			}
+
+			return health.OK(name, fmt.Sprintf("%d calendar(s) connected", len(enabled)))
+		},
+	}
+}
+
+// licenseChecker loads the local license and reports its status.
+type licenseChecker interface {
+	GetCurrent(ctx context.Context) (*licensingDomain.License, error)
+	GetStatus(license *licensingDomain.License) licensingDomain.LicenseStatus
+}
+
+func licenseCheck(licenses licenseChecker) health.Check {
+	name := "license"
+	return health.Check{
+		Name: name,
+		Probe: func(ctx context.Context) health.Result {
+			if licenses == nil {
+				return health.Skipped(name, "managed by billing")
+			}
+
+			license, err := licenses.GetCurrent(ctx)
+			if err != nil {
+				return health.Fail(name, err, "Check that the license file is readable, or re-activate with: orbita license activate <key>")
+			}
+
+			switch status := licenses.GetStatus(license); status {
+			case licensingDomain.LicenseStatusActive:
+				return health.OK(name, "active")
+			case licensingDomain.LicenseStatusTrial:
+				return health.OK(name, fmt.Sprintf("trial, %d day(s) left", license.TrialDaysRemaining()))
+			case licensingDomain.LicenseStatusGracePeriod:
+				return health.Warn(name, "expired, in grace period", "Renew with: orbita license upgrade")
+			case licensingDomain.LicenseStatusExpired:
+				return health.Warn(name, "expired; Pro features are disabled", "Renew with: orbita license upgrade")
+			case licensingDomain.LicenseStatusFreeTier:
+				return health.OK(name, "free tier")
+			case licensingDomain.LicenseStatusInvalid:
+				return health.Fail(name, errors.New("license signature is invalid"), "Re-activate with: orbita license activate <key>")
+			default:
+				return health.Warn(name, string(status), "Check with: orbita license status")
+			}
+		},
+	}
+}
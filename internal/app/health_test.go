@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	calendarDomain "github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	licensingDomain "github.com/felixgeelhaar/orbita/internal/licensing/domain"
+	"github.com/felixgeelhaar/orbita/internal/shared/application/health"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/eventbus"
+	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type stubBroker struct {
+	eventbus.NoopPublisher
+	err error
+}
+
+func (b *stubBroker) Ping(context.Context) error { return b.err }
+
+type stubCalendars struct {
+	calendars []*calendarDomain.ConnectedCalendar
+	err       error
+}
+
+func (s stubCalendars) FindByUser(context.Context, uuid.UUID) ([]*calendarDomain.ConnectedCalendar, error) {
+	return s.calendars, s.err
+}
+
+type stubTokens struct {
+	err error
+}
+
+func (s stubTokens) TokenSource(context.Context, uuid.UUID) (oauth2.TokenSource, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), nil
+}
+
+type stubLicenses struct {
+	status licensingDomain.LicenseStatus
+}
+
+func (s stubLicenses) GetCurrent(context.Context) (*licensingDomain.License, error) {
+	return licensingDomain.NewTrialLicense(), nil
+}
+
+func (s stubLicenses) GetStatus(*licensingDomain.License) licensingDomain.LicenseStatus {
+	return s.status
+}
+
+func TestHealthChecks(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	serverCfg := &config.Config{RedisURL: "redis://localhost:6379/0"}
+
+	newCalendar := func(t *testing.T, synced bool) *calendarDomain.ConnectedCalendar {
+		t.Helper()
+		cal, err := calendarDomain.NewConnectedCalendar(userID, calendarDomain.ProviderGoogle, "primary", "Work")
+		require.NoError(t, err)
+		cal.SetSyncPull(true)
+		if synced {
+			cal.MarkSyncedSimple()
+		}
+		return cal
+	}
+
+	run := func(checks ...health.Check) health.Report {
+		return health.Run(context.Background(), checks, time.Second)
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		report := run(
+			databaseCheck(serverCfg, func(context.Context) error { return nil }),
+			redisCheck(serverCfg, func(context.Context) error { return nil }),
+			rabbitMQCheck(serverCfg, &stubBroker{}),
+			calendarCheck(stubCalendars{calendars: []*calendarDomain.ConnectedCalendar{newCalendar(t, true)}}, stubTokens{}, userID, func() time.Time { return now }),
+			licenseCheck(stubLicenses{status: licensingDomain.LicenseStatusActive}),
+		)
+
+		assert.Equal(t, health.OverallHealthy, report.Status)
+		for _, result := range report.Results {
+			assert.Equal(t, health.StatusOK, result.Status, result.Name)
+			assert.Empty(t, result.Hint, result.Name)
+		}
+	})
+
+	t.Run("degraded", func(t *testing.T) {
+		report := run(
+			databaseCheck(serverCfg, func(context.Context) error { return nil }),
+			redisCheck(serverCfg, nil),
+			rabbitMQCheck(serverCfg, eventbus.NewNoopPublisher(nil)),
+			calendarCheck(stubCalendars{calendars: []*calendarDomain.ConnectedCalendar{newCalendar(t, true)}}, stubTokens{}, userID, func() time.Time { return now.Add(48 * time.Hour) }),
+			licenseCheck(stubLicenses{status: licensingDomain.LicenseStatusGracePeriod}),
+		)
+
+		assert.Equal(t, health.OverallDegraded, report.Status)
+		statuses := map[string]health.Status{}
+		for _, result := range report.Results {
+			statuses[result.Name] = result.Status
+			if result.Status == health.StatusWarn {
+				assert.NotEmpty(t, result.Hint, result.Name)
+			}
+		}
+		assert.Equal(t, map[string]health.Status{
+			"database": health.StatusOK,
+			"redis":    health.StatusWarn,
+			"rabbitmq": health.StatusWarn,
+			"calendar": health.StatusWarn,
+			"license":  health.StatusWarn,
+		}, statuses)
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		report := run(
+			databaseCheck(serverCfg, func(context.Context) error { return errors.New("connection refused") }),
+			rabbitMQCheck(serverCfg, &stubBroker{err: eventbus.ErrPublisherDisconnected}),
+			calendarCheck(stubCalendars{calendars: []*calendarDomain.ConnectedCalendar{newCalendar(t, false)}}, stubTokens{err: errors.New("token not found")}, userID, time.Now),
+		)
+
+		assert.Equal(t, health.OverallUnhealthy, report.Status)
+		for _, result := range report.Results {
+			assert.Equal(t, health.StatusFail, result.Status, result.Name)
+			assert.NotEmpty(t, result.Hint, result.Name)
+		}
+		assert.Contains(t, report.Results[2].Hint, "orbita auth connect google")
+	})
+
+	t.Run("local mode skips server dependencies", func(t *testing.T) {
+		localCfg := &config.Config{LocalMode: true}
+		report := run(
+			redisCheck(localCfg, nil),
+			rabbitMQCheck(localCfg, eventbus.NewNoopPublisher(nil)),
+			calendarCheck(stubCalendars{}, nil, userID, time.Now),
+		)
+
+		assert.Equal(t, health.OverallHealthy, report.Status)
+		for _, result := range report.Results {
+			assert.Equal(t, health.StatusSkipped, result.Status, result.Name)
+		}
+	})
+}
+
+func TestContainerHealthChecks_LocalMode(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+
+	cfg := &config.Config{
+		AppEnv:         "test",
+		LocalMode:      true,
+		DatabaseDriver: "sqlite",
+		SQLitePath:     filepath.Join(tempDir, "test.db"),
+		UserID:         "00000000-0000-0000-0000-000000000001",
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	container, err := NewLocalContainer(context.Background(), cfg, logger)
+	require.NoError(t, err)
+	defer container.Close()
+
+	report := health.Run(context.Background(), container.HealthChecks(uuid.MustParse(cfg.UserID)), time.Second)
+
+	assert.Equal(t, health.OverallHealthy, report.Status)
+	statuses := map[string]health.Status{}
+	for _, result := range report.Results {
+		statuses[result.Name] = result.Status
+	}
+	assert.Equal(t, map[string]health.Status{
+		"database": health.StatusOK,
+		"redis":    health.StatusSkipped,
+		"rabbitmq": health.StatusSkipped,
+		"calendar": health.StatusSkipped,
+		"license":  health.StatusOK,
+	}, statuses)
+}
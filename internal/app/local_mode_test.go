@@ -9,11 +9,16 @@ import (
 	"testing"
 	"time"
 
+	automationDomain "github.com/felixgeelhaar/orbita/internal/automations/domain"
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
 	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	inboxCommands "github.com/felixgeelhaar/orbita/internal/inbox/application/commands"
+	inboxQueries "github.com/felixgeelhaar/orbita/internal/inbox/application/queries"
 	meetingsDomain "github.com/felixgeelhaar/orbita/internal/meetings/domain"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
 	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
 	schedulingDomain "github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/pkg/config"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -116,6 +121,83 @@ func TestLocalModeTaskWorkflow(t *testing.T) {
 	assert.Equal(t, "completed", tasksAfter[0].Status)
 }
 
+// TestLocalModeTaskScoreSort tests that task lists sorted by score are
+// ranked by the priority engine.
+func TestLocalModeTaskScoreSort(t *testing.T) {
+	container, ctx, userID, sqlDB := setupLocalModeContainer(t)
+	defer container.Close()
+	defer sqlDB.Close()
+
+	tomorrow := time.Now().Add(24 * time.Hour)
+	for _, cmd := range []commands.CreateTaskCommand{
+		{UserID: userID, Title: "High, no deadline", Priority: "high", DurationMinutes: 30},
+		{UserID: userID, Title: "Low, due tomorrow", Priority: "low", DurationMinutes: 30, DueDate: &tomorrow},
+	} {
+		_, err := container.CreateTaskHandler.Handle(ctx, cmd)
+		require.NoError(t, err)
+	}
+
+	tasks, err := container.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{
+		UserID: userID,
+		SortBy: "score:desc",
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	// The deadline outweighs the higher priority.
+	assert.Equal(t, "Low, due tomorrow", tasks[0].Title)
+	assert.Equal(t, "High, no deadline", tasks[1].Title)
+}
+
+// TestLocalModeInboxPromoteAction tests that a pending inbox.promote
+// automation action promotes its inbox item into a task.
+func TestLocalModeInboxPromoteAction(t *testing.T) {
+	container, ctx, userID, sqlDB := setupLocalModeContainer(t)
+	defer container.Close()
+	defer sqlDB.Close()
+
+	captured, err := container.CaptureInboxItemHandler.Handle(ctx, inboxCommands.CaptureInboxItemCommand{
+		UserID:  userID,
+		Content: "Renew passport",
+	})
+	require.NoError(t, err)
+
+	factory := NewRepositoryFactory(container.DBConn)
+	ruleRepo, err := factory.RuleRepository()
+	require.NoError(t, err)
+	execRepo, err := factory.ExecutionRepository()
+	require.NoError(t, err)
+	pendingRepo, err := factory.PendingActionRepository()
+	require.NoError(t, err)
+
+	params := map[string]any{"item_id": captured.ItemID.String(), "priority": "high"}
+	rule, err := automationDomain.NewAutomationRule(userID, "Promote captures", automationDomain.TriggerTypeEvent,
+		map[string]any{"event_types": []any{"inbox.captured"}},
+		[]types.RuleAction{{Type: "inbox.promote", Parameters: params}})
+	require.NoError(t, err)
+	require.NoError(t, ruleRepo.Create(ctx, rule))
+	execution := automationDomain.NewRuleExecution(rule.ID, userID, "inbox.captured", nil)
+	require.NoError(t, execRepo.Create(ctx, execution))
+	action := automationDomain.NewPendingAction(execution.ID, rule.ID, userID, "inbox.promote", params, time.Now().Add(-time.Minute))
+	require.NoError(t, pendingRepo.Create(ctx, action))
+
+	// Pending actions run in the background, outside any user's request.
+	result, err := container.AutomationActionExecutor.ExecutePending(sharedApplication.WithSystem(context.Background()), 10)
+	require.NoError(t, err)
+	require.Len(t, result.Results, 1)
+	require.Equal(t, "success", result.Results[0].Status, result.Results[0].Error)
+
+	tasks, err := container.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: userID})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Renew passport", tasks[0].Title)
+	assert.Equal(t, "high", tasks[0].Priority)
+	assert.Equal(t, tasks[0].ID.String(), result.Results[0].Result["promoted_id"])
+
+	items, err := container.ListInboxItemsHandler.Handle(ctx, inboxQueries.ListInboxItemsQuery{UserID: userID})
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
 // TestLocalModeHabitWorkflow tests creating and listing habits in local mode.
 func TestLocalModeHabitWorkflow(t *testing.T) {
 	container, ctx, userID, sqlDB := setupLocalModeContainer(t)
@@ -248,7 +330,7 @@ func setupLocalModeContainer(t *testing.T) (*Container, context.Context, uuid.UU
 	}))
 
 	// Create context
-	ctx := context.Background()
+	ctx := sharedApplication.WithPrincipal(context.Background(), userID)
 
 	// Create local container
 	container, err := NewLocalContainer(ctx, cfg, logger)
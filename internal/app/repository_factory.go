@@ -28,6 +28,7 @@ import (
 	projectsPersistence "github.com/felixgeelhaar/orbita/internal/projects/infrastructure/persistence"
 	schedulingDomain "github.com/felixgeelhaar/orbita/internal/scheduling/domain"
 	schedulingPersistence "github.com/felixgeelhaar/orbita/internal/scheduling/infrastructure/persistence"
+	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/database"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -37,6 +38,7 @@ import (
 type RepositoryFactory struct {
 	conn   database.Connection
 	driver database.Driver
+	fields *sharedCrypto.FieldEncrypter
 }
 
 // NewRepositoryFactory creates a new repository factory.
@@ -47,6 +49,13 @@ func NewRepositoryFactory(conn database.Connection) *RepositoryFactory {
 	}
 }
 
+// WithFieldEncrypter makes the task and habit repositories encrypt free text
+// at rest.
+func (f *RepositoryFactory) WithFieldEncrypter(fields *sharedCrypto.FieldEncrypter) *RepositoryFactory {
+	f.fields = fields
+	return f
+}
+
 // TaskRepository creates a task repository for the configured driver.
 func (f *RepositoryFactory) TaskRepository() (task.Repository, error) {
 	switch f.driver {
@@ -55,14 +64,14 @@ func (f *RepositoryFactory) TaskRepository() (task.Repository, error) {
 		if err != nil {
 			return nil, err
 		}
-		return productivityPersistence.NewPostgresTaskRepositoryFromPool(pool), nil
+		return productivityPersistence.NewPostgresTaskRepositoryFromPool(pool).WithFieldEncrypter(f.fields), nil
 
 	case database.DriverSQLite:
 		db, err := f.getSQLiteDB()
 		if err != nil {
 			return nil, err
 		}
-		return productivityPersistence.NewSQLiteTaskRepository(db), nil
+		return productivityPersistence.NewSQLiteTaskRepository(db).WithFieldEncrypter(f.fields), nil
 
 	default:
 		return nil, fmt.Errorf("unsupported driver: %s", f.driver)
@@ -77,14 +86,14 @@ func (f *RepositoryFactory) HabitRepository() (habitsDomain.Repository, error) {
 		if err != nil {
 			return nil, err
 		}
-		return habitsPersistence.NewPostgresHabitRepository(pool), nil
+		return habitsPersistence.NewPostgresHabitRepository(pool).WithFieldEncrypter(f.fields), nil
 
 	case database.DriverSQLite:
 		db, err := f.getSQLiteDB()
 		if err != nil {
 			return nil, err
 		}
-		return habitsPersistence.NewSQLiteHabitRepository(db), nil
+		return habitsPersistence.NewSQLiteHabitRepository(db).WithFieldEncrypter(f.fields), nil
 
 	default:
 		return nil, fmt.Errorf("unsupported driver: %s", f.driver)
@@ -62,12 +62,30 @@ func setupTestDB(t *testing.T) *sql.DB {
 	sqlDB, err := sql.Open("sqlite", ":memory:")
 	require.NoError(t, err)
 
-	schemaPath := filepath.Join("..", "..", "migrations", "sqlite", "000001_initial_schema.up.sql")
-	schema, err := os.ReadFile(schemaPath)
-	require.NoError(t, err)
-
-	_, err = sqlDB.Exec(string(schema))
-	require.NoError(t, err)
+	migrations := []string{
+		"000001_initial_schema.up.sql",
+		"000007_habit_skips_freeze.up.sql",
+		"000008_task_reminders.up.sql",
+		"000009_task_checklist.up.sql",
+		"000010_meeting_external_series.up.sql",
+		"000012_tags.up.sql",
+		"000019_task_blocked.up.sql",
+		"000025_task_external_id.up.sql",
+		"000027_task_habit_timezone.up.sql",
+		"000029_task_waiting.up.sql",
+		"000030_task_earliest_start.up.sql",
+		"000033_task_recurrence.up.sql",
+		"000034_search_index.up.sql",
+	}
+
+	for _, migration := range migrations {
+		schemaPath := filepath.Join("..", "..", "migrations", "sqlite", migration)
+		schema, err := os.ReadFile(schemaPath)
+		require.NoError(t, err)
+
+		_, err = sqlDB.Exec(string(schema))
+		require.NoError(t, err)
+	}
 
 	return sqlDB
 }
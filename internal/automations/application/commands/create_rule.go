@@ -2,10 +2,10 @@ package commands
 
 import (
 	"context"
-	"errors"
 
 	"github.com/felixgeelhaar/orbita/internal/automations/domain"
 	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 )
 
@@ -32,16 +32,16 @@ type CreateRuleCommand struct {
 // Validate validates the command.
 func (c CreateRuleCommand) Validate() error {
 	if c.UserID == uuid.Nil {
-		return errors.New("user_id is required")
+		return sharedApplication.NewValidationError("user_id is required")
 	}
 	if c.Name == "" {
-		return errors.New("name is required")
+		return sharedApplication.NewValidationError("name is required")
 	}
 	if c.TriggerType == "" {
-		return errors.New("trigger_type is required")
+		return sharedApplication.NewValidationError("trigger_type is required")
 	}
 	if len(c.Actions) == 0 {
-		return errors.New("at least one action is required")
+		return sharedApplication.NewValidationError("at least one action is required")
 	}
 	return nil
 }
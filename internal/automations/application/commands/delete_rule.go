@@ -2,9 +2,9 @@ package commands
 
 import (
 	"context"
-	"errors"
 
 	"github.com/felixgeelhaar/orbita/internal/automations/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 )
 
@@ -17,10 +17,10 @@ type DeleteRuleCommand struct {
 // Validate validates the command.
 func (c DeleteRuleCommand) Validate() error {
 	if c.RuleID == uuid.Nil {
-		return errors.New("rule_id is required")
+		return sharedApplication.NewValidationError("rule_id is required")
 	}
 	if c.UserID == uuid.Nil {
-		return errors.New("user_id is required")
+		return sharedApplication.NewValidationError("user_id is required")
 	}
 	return nil
 }
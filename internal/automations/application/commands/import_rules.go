@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/internal/automations/domain"
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+// ErrRuleValidatorUnavailable is returned when rules are imported without an
+// automation engine to validate them.
+var ErrRuleValidatorUnavailable = errors.New("importing automation rules requires the engine runtime")
+
+// RuleValidator validates rule definitions against an automation engine.
+// An empty engineID uses the default automation engine.
+type RuleValidator interface {
+	ValidateAutomationRule(ctx context.Context, engineID string, userID uuid.UUID, rule types.AutomationRule) error
+}
+
+// ImportRulesCommand contains exported rules to create for a user.
+type ImportRulesCommand struct {
+	UserID uuid.UUID
+	Export domain.RuleExport
+}
+
+// Validate validates the command.
+func (c ImportRulesCommand) Validate() error {
+	if c.UserID == uuid.Nil {
+		return sharedApplication.NewValidationError("user_id is required")
+	}
+	if c.Export.Version < 1 || c.Export.Version > domain.RuleExportVersion {
+		return fmt.Errorf("%w: %d", domain.ErrUnsupportedExportVersion, c.Export.Version)
+	}
+	if len(c.Export.Rules) == 0 {
+		return sharedApplication.NewValidationError("no rules to import")
+	}
+	return nil
+}
+
+// ImportRulesResult contains the imported rules and the ID each exported
+// rule was given.
+type ImportRulesResult struct {
+	Rules []*domain.AutomationRule
+	IDMap map[uuid.UUID]uuid.UUID
+}
+
+// ImportRulesHandler handles the ImportRulesCommand.
+type ImportRulesHandler struct {
+	ruleRepo  domain.RuleRepository
+	validator RuleValidator
+}
+
+// NewImportRulesHandler creates a new ImportRulesHandler.
+func NewImportRulesHandler(ruleRepo domain.RuleRepository) *ImportRulesHandler {
+	return &ImportRulesHandler{ruleRepo: ruleRepo}
+}
+
+// WithValidator sets the engine used to validate imported rules.
+func (h *ImportRulesHandler) WithValidator(validator RuleValidator) *ImportRulesHandler {
+	h.validator = validator
+	return h
+}
+
+// Handle executes the ImportRulesCommand. Every rule gets a new ID, and
+// references to exported rule IDs in trigger configs and action parameters
+// are remapped to the new IDs. All rules are validated before any is
+// created, so an invalid rule leaves the user's rules unchanged.
+func (h *ImportRulesHandler) Handle(ctx context.Context, cmd ImportRulesCommand) (*ImportRulesResult, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+	if h.validator == nil {
+		return nil, ErrRuleValidatorUnavailable
+	}
+
+	rules := make([]*domain.AutomationRule, 0, len(cmd.Export.Rules))
+	idMap := make(map[uuid.UUID]uuid.UUID, len(cmd.Export.Rules))
+	for _, exported := range cmd.Export.Rules {
+		actions := append([]types.RuleAction(nil), exported.Actions...)
+		rule, err := domain.NewAutomationRule(cmd.UserID, exported.Name, exported.TriggerType, exported.TriggerConfig, actions)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rule %q: %v", domain.ErrInvalidRule, exported.Name, err)
+		}
+		applyExportedSettings(rule, exported)
+		if exported.ID != uuid.Nil {
+			idMap[exported.ID] = rule.ID
+		}
+		rules = append(rules, rule)
+	}
+
+	for _, rule := range rules {
+		rule.TriggerConfig = remapRuleIDs(rule.TriggerConfig, idMap)
+		for i := range rule.Actions {
+			rule.Actions[i].Parameters = remapRuleIDs(rule.Actions[i].Parameters, idMap)
+		}
+		if err := h.validator.ValidateAutomationRule(ctx, "", cmd.UserID, rule.ToEngineRule()); err != nil {
+			return nil, fmt.Errorf("%w: rule %q: %v", domain.ErrInvalidRule, rule.Name, err)
+		}
+	}
+
+	for _, rule := range rules {
+		if err := h.ruleRepo.Create(ctx, rule); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ImportRulesResult{Rules: rules, IDMap: idMap}, nil
+}
+
+// applyExportedSettings copies the optional settings of an exported rule.
+func applyExportedSettings(rule *domain.AutomationRule, exported domain.ExportedRule) {
+	if exported.Description != "" {
+		rule.SetDescription(exported.Description)
+	}
+	if exported.Priority != 0 {
+		rule.SetPriority(exported.Priority)
+	}
+	if len(exported.Conditions) > 0 {
+		operator := exported.ConditionOperator
+		if operator == "" {
+			operator = domain.ConditionOperatorAND
+		}
+		rule.SetConditions(exported.Conditions, operator)
+	}
+	if exported.CooldownSeconds > 0 {
+		rule.SetCooldown(exported.CooldownSeconds)
+	}
+	if exported.MaxExecutionsPerHour != nil {
+		rule.SetMaxExecutionsPerHour(exported.MaxExecutionsPerHour)
+	}
+	for _, tag := range exported.Tags {
+		rule.AddTag(tag)
+	}
+	if !exported.Enabled {
+		rule.Disable()
+	}
+}
+
+// remapRuleIDs returns a copy of params with string values naming an exported
+// rule replaced by the imported rule's ID.
+func remapRuleIDs(params map[string]any, idMap map[uuid.UUID]uuid.UUID) map[string]any {
+	if params == nil {
+		return nil
+	}
+	remapped := make(map[string]any, len(params))
+	for key, value := range params {
+		if s, ok := value.(string); ok {
+			if id, err := uuid.Parse(s); err == nil {
+				if newID, ok := idMap[id]; ok {
+					value = newID.String()
+				}
+			}
+		}
+		remapped[key] = value
+	}
+	return remapped
+}
@@ -2,10 +2,10 @@ package commands
 
 import (
 	"context"
-	"errors"
 
 	"github.com/felixgeelhaar/orbita/internal/automations/domain"
 	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 )
 
@@ -35,10 +35,10 @@ type UpdateRuleCommand struct {
 // Validate validates the command.
 func (c UpdateRuleCommand) Validate() error {
 	if c.RuleID == uuid.Nil {
-		return errors.New("rule_id is required")
+		return sharedApplication.NewValidationError("rule_id is required")
 	}
 	if c.UserID == uuid.Nil {
-		return errors.New("user_id is required")
+		return sharedApplication.NewValidationError("user_id is required")
 	}
 	return nil
 }
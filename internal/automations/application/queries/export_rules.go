@@ -0,0 +1,65 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/automations/domain"
+	"github.com/google/uuid"
+)
+
+// ExportRulesQuery exports automation rules in the portable format.
+type ExportRulesQuery struct {
+	UserID  uuid.UUID
+	RuleIDs []uuid.UUID // Empty exports all of the user's rules
+}
+
+// Validate validates the query.
+func (q ExportRulesQuery) Validate() error {
+	if q.UserID == uuid.Nil {
+		return errors.New("user_id is required")
+	}
+	return nil
+}
+
+// ExportRulesHandler handles the ExportRulesQuery.
+type ExportRulesHandler struct {
+	ruleRepo domain.RuleRepository
+}
+
+// NewExportRulesHandler creates a new ExportRulesHandler.
+func NewExportRulesHandler(ruleRepo domain.RuleRepository) *ExportRulesHandler {
+	return &ExportRulesHandler{ruleRepo: ruleRepo}
+}
+
+// Handle executes the ExportRulesQuery.
+func (h *ExportRulesHandler) Handle(ctx context.Context, q ExportRulesQuery) (*domain.RuleExport, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	var rules []*domain.AutomationRule
+	if len(q.RuleIDs) == 0 {
+		all, err := h.ruleRepo.GetByUserID(ctx, q.UserID)
+		if err != nil {
+			return nil, err
+		}
+		rules = all
+	} else {
+		for _, id := range q.RuleIDs {
+			rule, err := h.ruleRepo.GetByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			// Authorization check
+			if rule.UserID != q.UserID {
+				return nil, domain.ErrRuleNotFound
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	export := domain.NewRuleExport(rules, time.Now().UTC())
+	return &export, nil
+}
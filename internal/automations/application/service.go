@@ -12,16 +12,18 @@ import (
 // Service provides a facade for automation rule operations.
 type Service struct {
 	// Command handlers
-	createRuleHandler *commands.CreateRuleHandler
-	updateRuleHandler *commands.UpdateRuleHandler
-	deleteRuleHandler *commands.DeleteRuleHandler
-	toggleRuleHandler *commands.ToggleRuleHandler
+	createRuleHandler  *commands.CreateRuleHandler
+	updateRuleHandler  *commands.UpdateRuleHandler
+	deleteRuleHandler  *commands.DeleteRuleHandler
+	toggleRuleHandler  *commands.ToggleRuleHandler
+	importRulesHandler *commands.ImportRulesHandler
 
 	// Query handlers
 	getRuleHandler        *queries.GetRuleHandler
 	listRulesHandler      *queries.ListRulesHandler
 	getExecutionHandler   *queries.GetExecutionHandler
 	listExecutionsHandler *queries.ListExecutionsHandler
+	exportRulesHandler    *queries.ExportRulesHandler
 }
 
 // NewService creates a new automation service.
@@ -32,19 +34,27 @@ func NewService(
 ) *Service {
 	return &Service{
 		// Command handlers
-		createRuleHandler: commands.NewCreateRuleHandler(ruleRepo),
-		updateRuleHandler: commands.NewUpdateRuleHandler(ruleRepo),
-		deleteRuleHandler: commands.NewDeleteRuleHandler(ruleRepo, pendingActionRepo),
-		toggleRuleHandler: commands.NewToggleRuleHandler(ruleRepo, pendingActionRepo),
+		createRuleHandler:  commands.NewCreateRuleHandler(ruleRepo),
+		updateRuleHandler:  commands.NewUpdateRuleHandler(ruleRepo),
+		deleteRuleHandler:  commands.NewDeleteRuleHandler(ruleRepo, pendingActionRepo),
+		toggleRuleHandler:  commands.NewToggleRuleHandler(ruleRepo, pendingActionRepo),
+		importRulesHandler: commands.NewImportRulesHandler(ruleRepo),
 
 		// Query handlers
 		getRuleHandler:        queries.NewGetRuleHandler(ruleRepo),
 		listRulesHandler:      queries.NewListRulesHandler(ruleRepo),
 		getExecutionHandler:   queries.NewGetExecutionHandler(executionRepo),
 		listExecutionsHandler: queries.NewListExecutionsHandler(executionRepo, ruleRepo),
+		exportRulesHandler:    queries.NewExportRulesHandler(ruleRepo),
 	}
 }
 
+// WithRuleValidator sets the automation engine that validates imported rules.
+func (s *Service) WithRuleValidator(validator commands.RuleValidator) *Service {
+	s.importRulesHandler.WithValidator(validator)
+	return s
+}
+
 // CreateRule creates a new automation rule.
 func (s *Service) CreateRule(ctx context.Context, cmd commands.CreateRuleCommand) (*domain.AutomationRule, error) {
 	return s.createRuleHandler.Handle(ctx, cmd)
@@ -70,6 +80,11 @@ func (s *Service) DisableRule(ctx context.Context, cmd commands.DisableRuleComma
 	return s.toggleRuleHandler.Disable(ctx, cmd)
 }
 
+// ImportRules validates and creates rules from a portable export.
+func (s *Service) ImportRules(ctx context.Context, cmd commands.ImportRulesCommand) (*commands.ImportRulesResult, error) {
+	return s.importRulesHandler.Handle(ctx, cmd)
+}
+
 // GetRule retrieves a single automation rule.
 func (s *Service) GetRule(ctx context.Context, q queries.GetRuleQuery) (*domain.AutomationRule, error) {
 	return s.getRuleHandler.Handle(ctx, q)
@@ -80,6 +95,11 @@ func (s *Service) ListRules(ctx context.Context, q queries.ListRulesQuery) (*que
 	return s.listRulesHandler.Handle(ctx, q)
 }
 
+// ExportRules exports automation rules in a portable format.
+func (s *Service) ExportRules(ctx context.Context, q queries.ExportRulesQuery) (*domain.RuleExport, error) {
+	return s.exportRulesHandler.Handle(ctx, q)
+}
+
 // GetExecution retrieves a single rule execution.
 func (s *Service) GetExecution(ctx context.Context, q queries.GetExecutionQuery) (*domain.RuleExecution, error) {
 	return s.getExecutionHandler.Handle(ctx, q)
@@ -2,12 +2,15 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/automations/application/commands"
 	"github.com/felixgeelhaar/orbita/internal/automations/application/queries"
 	"github.com/felixgeelhaar/orbita/internal/automations/domain"
+	"github.com/felixgeelhaar/orbita/internal/engine/builtin"
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
 	"github.com/felixgeelhaar/orbita/internal/engine/types"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -625,3 +628,166 @@ func TestService_ListExecutions(t *testing.T) {
 		execRepo.AssertExpectations(t)
 	})
 }
+
+// engineValidator validates rules with a built-in automation engine, the way
+// the engine runtime does.
+type engineValidator struct {
+	engine types.AutomationEngine
+}
+
+func (v engineValidator) ValidateAutomationRule(ctx context.Context, _ string, userID uuid.UUID, rule types.AutomationRule) error {
+	return v.engine.ValidateRule(sdk.NewExecutionContext(ctx, userID, v.engine.Metadata().ID), rule)
+}
+
+func TestService_ExportImportRules_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	teammateID := uuid.New()
+
+	notify, err := domain.NewAutomationRule(ownerID, "Notify on completion", domain.TriggerTypeEvent,
+		map[string]any{"event_types": []string{"task.completed"}},
+		[]types.RuleAction{{Type: "notification.send", Parameters: map[string]any{"message": "Done: {{event.entity_id}}"}}},
+	)
+	require.NoError(t, err)
+	notify.SetDescription("Tell me when a task is done")
+	notify.SetPriority(5)
+	notify.SetConditions([]types.RuleCondition{{Field: "priority", Operator: types.OperatorEquals, Value: "high"}}, domain.ConditionOperatorOR)
+	notify.SetCooldown(60)
+	maxPerHour := 10
+	notify.SetMaxExecutionsPerHour(&maxPerHour)
+	notify.AddTag("team")
+
+	followUp, err := domain.NewAutomationRule(ownerID, "Follow up", domain.TriggerTypeSchedule,
+		map[string]any{"schedule": "0 9 * * 1"},
+		[]types.RuleAction{{Type: "task.create", Parameters: map[string]any{"title": "Weekly review", "after_rule": notify.ID.String()}}},
+	)
+	require.NoError(t, err)
+	followUp.Disable()
+
+	ruleRepo := new(mockRuleRepo)
+	ruleRepo.On("GetByUserID", ctx, ownerID).Return([]*domain.AutomationRule{notify, followUp}, nil)
+	var created []*domain.AutomationRule
+	ruleRepo.On("Create", ctx, mock.AnythingOfType("*domain.AutomationRule")).
+		Run(func(args mock.Arguments) { created = append(created, args.Get(1).(*domain.AutomationRule)) }).
+		Return(nil)
+
+	svc := NewService(ruleRepo, new(mockExecutionRepo), new(mockPendingActionRepo)).
+		WithRuleValidator(engineValidator{engine: builtin.NewDefaultAutomationEngine()})
+
+	export, err := svc.ExportRules(ctx, queries.ExportRulesQuery{UserID: ownerID})
+	require.NoError(t, err)
+	require.Len(t, export.Rules, 2)
+
+	// Share as JSON.
+	data, err := json.Marshal(export)
+	require.NoError(t, err)
+	var shared domain.RuleExport
+	require.NoError(t, json.Unmarshal(data, &shared))
+
+	result, err := svc.ImportRules(ctx, commands.ImportRulesCommand{UserID: teammateID, Export: shared})
+	require.NoError(t, err)
+	require.Len(t, result.Rules, 2)
+	assert.Equal(t, result.Rules, created)
+
+	imported := result.Rules[0]
+	assert.NotEqual(t, notify.ID, imported.ID)
+	assert.Equal(t, imported.ID, result.IDMap[notify.ID])
+	assert.Equal(t, teammateID, imported.UserID)
+	assert.Equal(t, "Notify on completion", imported.Name)
+	assert.Equal(t, "Tell me when a task is done", imported.Description)
+	assert.True(t, imported.Enabled)
+	assert.Equal(t, 5, imported.Priority)
+	assert.Equal(t, domain.TriggerTypeEvent, imported.TriggerType)
+	assert.Equal(t, []string{"task.completed"}, imported.ToEngineRule().Trigger.EventTypes)
+	assert.Equal(t, domain.ConditionOperatorOR, imported.ConditionOperator)
+	require.Len(t, imported.Conditions, 1)
+	assert.Equal(t, "priority", imported.Conditions[0].Field)
+	assert.Equal(t, 60, imported.CooldownSeconds)
+	assert.Equal(t, &maxPerHour, imported.MaxExecutionsPerHour)
+	assert.Equal(t, []string{"team"}, imported.Tags)
+	assert.Equal(t, notify.Actions, imported.Actions)
+	assert.Nil(t, imported.LastTriggeredAt)
+
+	// References between the shared rules point at the imported rules.
+	importedFollowUp := result.Rules[1]
+	assert.False(t, importedFollowUp.Enabled)
+	assert.Equal(t, result.IDMap[followUp.ID], importedFollowUp.ID)
+	assert.Equal(t, imported.ID.String(), importedFollowUp.Actions[0].Parameters["after_rule"])
+	assert.Equal(t, notify.ID.String(), shared.Rules[1].Actions[0].Parameters["after_rule"])
+
+	ruleRepo.AssertExpectations(t)
+}
+
+func TestService_ImportRules_ValidationFailure(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	export := domain.RuleExport{
+		Version: domain.RuleExportVersion,
+		Rules: []domain.ExportedRule{
+			{
+				ID:            uuid.New(),
+				Name:          "Valid",
+				Enabled:       true,
+				TriggerType:   domain.TriggerTypeEvent,
+				TriggerConfig: map[string]any{"event_types": []any{"task.created"}},
+				Actions:       []types.RuleAction{{Type: "notification.send"}},
+			},
+			{
+				ID:            uuid.New(),
+				Name:          "Unknown action",
+				Enabled:       true,
+				TriggerType:   domain.TriggerTypeEvent,
+				TriggerConfig: map[string]any{"event_types": []any{"task.created"}},
+				Actions:       []types.RuleAction{{Type: "email.teleport"}},
+			},
+		},
+	}
+
+	t.Run("rejects the whole import", func(t *testing.T) {
+		ruleRepo := new(mockRuleRepo)
+		svc := NewService(ruleRepo, new(mockExecutionRepo), new(mockPendingActionRepo)).
+			WithRuleValidator(engineValidator{engine: builtin.NewDefaultAutomationEngine()})
+
+		result, err := svc.ImportRules(ctx, commands.ImportRulesCommand{UserID: userID, Export: export})
+
+		require.ErrorIs(t, err, domain.ErrInvalidRule)
+		assert.ErrorContains(t, err, `"Unknown action"`)
+		assert.ErrorContains(t, err, "email.teleport")
+		assert.Nil(t, result)
+		ruleRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects newer export versions", func(t *testing.T) {
+		svc := NewService(new(mockRuleRepo), new(mockExecutionRepo), new(mockPendingActionRepo)).
+			WithRuleValidator(engineValidator{engine: builtin.NewDefaultAutomationEngine()})
+
+		newer := export
+		newer.Version = domain.RuleExportVersion + 1
+		_, err := svc.ImportRules(ctx, commands.ImportRulesCommand{UserID: userID, Export: newer})
+
+		assert.ErrorIs(t, err, domain.ErrUnsupportedExportVersion)
+	})
+
+	t.Run("requires a validator", func(t *testing.T) {
+		svc := NewService(new(mockRuleRepo), new(mockExecutionRepo), new(mockPendingActionRepo))
+
+		_, err := svc.ImportRules(ctx, commands.ImportRulesCommand{UserID: userID, Export: export})
+
+		assert.ErrorIs(t, err, commands.ErrRuleValidatorUnavailable)
+	})
+}
+
+func TestService_ExportRules_RejectsOtherUsersRules(t *testing.T) {
+	ctx := context.Background()
+	rule := createTestRule(uuid.New())
+
+	ruleRepo := new(mockRuleRepo)
+	ruleRepo.On("GetByID", ctx, rule.ID).Return(rule, nil)
+	svc := NewService(ruleRepo, new(mockExecutionRepo), new(mockPendingActionRepo))
+
+	export, err := svc.ExportRules(ctx, queries.ExportRulesQuery{UserID: uuid.New(), RuleIDs: []uuid.UUID{rule.ID}})
+
+	assert.ErrorIs(t, err, domain.ErrRuleNotFound)
+	assert.Nil(t, export)
+}
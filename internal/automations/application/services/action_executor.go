@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/automations/domain"
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
 	"github.com/google/uuid"
 )
 
@@ -117,10 +119,10 @@ func (e *ActionExecutor) executeAction(ctx context.Context, action *domain.Pendi
 	// Find handler for this action type
 	handler, ok := e.handlers[action.ActionType]
 	if !ok {
-		e.logger.Warn("no handler for action type", "action_type", action.ActionType)
+		observability.Logger(ctx, e.logger).Warn("no handler for action type", "action_type", action.ActionType)
 		action.Fail(fmt.Sprintf("no handler for action type: %s", action.ActionType))
 		if err := e.pendingRepo.Update(ctx, action); err != nil {
-			e.logger.Error("failed to update action", "action_id", action.ID, "error", err)
+			observability.Logger(ctx, e.logger).Error("failed to update action", "action_id", action.ID, "error", err)
 		}
 		result.Status = "failed"
 		result.Error = fmt.Sprintf("no handler for action type: %s", action.ActionType)
@@ -133,7 +135,7 @@ func (e *ActionExecutor) executeAction(ctx context.Context, action *domain.Pendi
 	result.Duration = time.Since(startTime)
 
 	if err != nil {
-		e.logger.Error("action execution failed",
+		observability.Logger(ctx, e.logger).Error("action execution failed",
 			"action_id", action.ID,
 			"action_type", action.ActionType,
 			"error", err,
@@ -141,7 +143,7 @@ func (e *ActionExecutor) executeAction(ctx context.Context, action *domain.Pendi
 
 		action.Fail(err.Error())
 		if updateErr := e.pendingRepo.Update(ctx, action); updateErr != nil {
-			e.logger.Error("failed to update action", "action_id", action.ID, "error", updateErr)
+			observability.Logger(ctx, e.logger).Error("failed to update action", "action_id", action.ID, "error", updateErr)
 		}
 
 		if action.CanRetry() {
@@ -156,10 +158,10 @@ func (e *ActionExecutor) executeAction(ctx context.Context, action *domain.Pendi
 	// Success
 	action.Execute(actionResult)
 	if err := e.pendingRepo.Update(ctx, action); err != nil {
-		e.logger.Error("failed to update action", "action_id", action.ID, "error", err)
+		observability.Logger(ctx, e.logger).Error("failed to update action", "action_id", action.ID, "error", err)
 	}
 
-	e.logger.Info("action executed successfully",
+	observability.Logger(ctx, e.logger).Info("action executed successfully",
 		"action_id", action.ID,
 		"action_type", action.ActionType,
 		"duration_ms", result.Duration.Milliseconds(),
@@ -182,7 +184,7 @@ func (e *ActionExecutor) CancelPendingForRule(ctx context.Context, ruleID uuid.U
 		if action.Status == domain.PendingActionStatusPending {
 			action.Cancel()
 			if err := e.pendingRepo.Update(ctx, action); err != nil {
-				e.logger.Error("failed to cancel action", "action_id", action.ID, "error", err)
+				observability.Logger(ctx, e.logger).Error("failed to cancel action", "action_id", action.ID, "error", err)
 				continue
 			}
 			cancelled++
@@ -196,7 +198,8 @@ func (e *ActionExecutor) CancelPendingForRule(ctx context.Context, ruleID uuid.U
 
 // NotificationActionHandler handles notification actions.
 type NotificationActionHandler struct {
-	logger *slog.Logger
+	notifier notifications.Notifier
+	logger   *slog.Logger
 }
 
 // NewNotificationActionHandler creates a new notification action handler.
@@ -204,6 +207,13 @@ func NewNotificationActionHandler(logger *slog.Logger) *NotificationActionHandle
 	return &NotificationActionHandler{logger: logger}
 }
 
+// WithNotifier delivers notifications through the given notifier. Without
+// one, notifications are only logged.
+func (h *NotificationActionHandler) WithNotifier(notifier notifications.Notifier) *NotificationActionHandler {
+	h.notifier = notifier
+	return h
+}
+
 // ActionType returns the action type.
 func (h *NotificationActionHandler) ActionType() string {
 	return "notification.send"
@@ -219,16 +229,22 @@ func (h *NotificationActionHandler) Execute(ctx context.Context, userID uuid.UUI
 		return nil, fmt.Errorf("notification title is required")
 	}
 
-	h.logger.Info("sending notification",
+	observability.Logger(ctx, h.logger).Info("sending notification",
 		"user_id", userID,
 		"title", title,
 		"body", body,
 		"priority", priority,
 	)
 
-	// In a real implementation, this would send via push notification, email, etc.
+	notification := notifications.NewNotification(userID, title, body, priority)
+	if h.notifier != nil {
+		if err := h.notifier.Send(ctx, notification); err != nil {
+			return nil, fmt.Errorf("failed to send notification: %w", err)
+		}
+	}
+
 	return map[string]any{
-		"notification_id": uuid.New().String(),
+		"notification_id": notification.ID.String(),
 		"delivered_at":    time.Now(),
 	}, nil
 }
@@ -255,13 +271,13 @@ func (h *LogActionHandler) Execute(ctx context.Context, userID uuid.UUID, target
 
 	switch level {
 	case "error":
-		h.logger.Error(message, "user_id", userID, "params", params)
+		observability.Logger(ctx, h.logger).Error(message, "user_id", userID, "params", params)
 	case "warn":
-		h.logger.Warn(message, "user_id", userID, "params", params)
+		observability.Logger(ctx, h.logger).Warn(message, "user_id", userID, "params", params)
 	case "debug":
-		h.logger.Debug(message, "user_id", userID, "params", params)
+		observability.Logger(ctx, h.logger).Debug(message, "user_id", userID, "params", params)
 	default:
-		h.logger.Info(message, "user_id", userID, "params", params)
+		observability.Logger(ctx, h.logger).Info(message, "user_id", userID, "params", params)
 	}
 
 	return map[string]any{
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/automations/domain"
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -298,6 +299,40 @@ func TestNotificationActionHandler_Execute_MissingTitle(t *testing.T) {
 	assert.Contains(t, err.Error(), "title is required")
 }
 
+type recordingNotifier struct {
+	sent []notifications.Notification
+	err  error
+}
+
+func (r *recordingNotifier) Send(ctx context.Context, n notifications.Notification) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.sent = append(r.sent, n)
+	return nil
+}
+
+func TestNotificationActionHandler_Execute_WithNotifier(t *testing.T) {
+	notifier := &recordingNotifier{}
+	handler := NewNotificationActionHandler(testLogger()).WithNotifier(notifier)
+	userID := uuid.New()
+
+	result, err := handler.Execute(context.Background(), userID, "", map[string]any{
+		"title":    "Inbox zero",
+		"body":     "All items processed",
+		"priority": "low",
+	})
+	require.NoError(t, err)
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, userID, notifier.sent[0].UserID)
+	assert.Equal(t, "Inbox zero", notifier.sent[0].Title)
+	assert.Equal(t, notifier.sent[0].ID.String(), result["notification_id"])
+
+	notifier.err = notifications.ErrRateLimited
+	_, err = handler.Execute(context.Background(), userID, "", map[string]any{"title": "Again"})
+	assert.ErrorIs(t, err, notifications.ErrRateLimited)
+}
+
 func TestLogActionHandler_Execute(t *testing.T) {
 	handler := NewLogActionHandler(testLogger())
 
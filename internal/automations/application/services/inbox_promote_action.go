@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	habitCommands "github.com/felixgeelhaar/orbita/internal/habits/application/commands"
+	inboxCommands "github.com/felixgeelhaar/orbita/internal/inbox/application/commands"
+	meetingCommands "github.com/felixgeelhaar/orbita/internal/meetings/application/commands"
+	productivityCommands "github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
+	"github.com/google/uuid"
+)
+
+// InboxPromoter promotes inbox items into tasks, habits or meetings.
+type InboxPromoter interface {
+	Handle(ctx context.Context, cmd inboxCommands.PromoteInboxItemCommand) (*inboxCommands.PromoteInboxItemResult, error)
+}
+
+// InboxPromoteActionHandler handles inbox promotion actions.
+type InboxPromoteActionHandler struct {
+	promoter InboxPromoter
+	logger   *slog.Logger
+}
+
+// NewInboxPromoteActionHandler creates a new inbox promotion action handler.
+func NewInboxPromoteActionHandler(promoter InboxPromoter, logger *slog.Logger) *InboxPromoteActionHandler {
+	return &InboxPromoteActionHandler{promoter: promoter, logger: logger}
+}
+
+// ActionType returns the action type.
+func (h *InboxPromoteActionHandler) ActionType() string {
+	return "inbox.promote"
+}
+
+// Execute promotes the inbox item referenced by the item_id parameter. The
+// target defaults to a task. The optional title replaces the item content as
+// the name of the promoted entity, duration applies to every target, priority
+// to tasks, frequency to habits and cadence to meetings.
+func (h *InboxPromoteActionHandler) Execute(ctx context.Context, userID uuid.UUID, target string, params map[string]any) (map[string]any, error) {
+	rawID, _ := params["item_id"].(string)
+	if rawID == "" {
+		return nil, fmt.Errorf("inbox item id is required")
+	}
+	itemID, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inbox item id: %s", rawID)
+	}
+
+	rawTarget, _ := params["target"].(string)
+	if rawTarget == "" {
+		rawTarget = string(inboxCommands.PromoteTargetTask)
+	}
+	promoteTarget, err := inboxCommands.ParsePromoteTarget(rawTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	title, _ := params["title"].(string)
+	priority, _ := params["priority"].(string)
+	frequency, _ := params["frequency"].(string)
+	cadence, _ := params["cadence"].(string)
+	duration := intParam(params, "duration")
+
+	cmd := inboxCommands.PromoteInboxItemCommand{
+		UserID: userID,
+		ItemID: itemID,
+		Target: promoteTarget,
+	}
+	switch promoteTarget {
+	case inboxCommands.PromoteTargetTask:
+		cmd.TaskArgs = &productivityCommands.CreateTaskCommand{
+			Title:           title,
+			Priority:        priority,
+			DurationMinutes: duration,
+		}
+	case inboxCommands.PromoteTargetHabit:
+		cmd.HabitArgs = &habitCommands.CreateHabitCommand{
+			Name:         title,
+			Frequency:    frequency,
+			DurationMins: duration,
+		}
+	case inboxCommands.PromoteTargetMeeting:
+		cmd.MeetingArgs = &meetingCommands.CreateMeetingCommand{
+			Name:         title,
+			Cadence:      cadence,
+			DurationMins: duration,
+		}
+	}
+
+	result, err := h.promoter.Handle(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote inbox item %s: %w", itemID, err)
+	}
+
+	observability.Logger(ctx, h.logger).Info("promoted inbox item",
+		"user_id", userID,
+		"item_id", itemID,
+		"target", result.Target,
+		"promoted_id", result.PromotedID,
+	)
+
+	return map[string]any{
+		"item_id":     itemID.String(),
+		"target":      string(result.Target),
+		"promoted_id": result.PromotedID.String(),
+	}, nil
+}
+
+// intParam reads an integer parameter, accepting the float64 values produced
+// by JSON decoding.
+func intParam(params map[string]any, key string) int {
+	switch v := params[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/automations/domain"
+	inboxCommands "github.com/felixgeelhaar/orbita/internal/inbox/application/commands"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errInboxItemNotFound = errors.New("inbox item not found")
+
+// stubInboxPromoter promotes the items it knows about and records the commands.
+type stubInboxPromoter struct {
+	items    map[uuid.UUID]bool
+	commands []inboxCommands.PromoteInboxItemCommand
+}
+
+func (s *stubInboxPromoter) Handle(ctx context.Context, cmd inboxCommands.PromoteInboxItemCommand) (*inboxCommands.PromoteInboxItemResult, error) {
+	s.commands = append(s.commands, cmd)
+	if !s.items[cmd.ItemID] {
+		return nil, errInboxItemNotFound
+	}
+	return &inboxCommands.PromoteInboxItemResult{PromotedID: uuid.New(), Target: cmd.Target}, nil
+}
+
+func TestInboxPromoteActionHandler_ActionType(t *testing.T) {
+	handler := NewInboxPromoteActionHandler(&stubInboxPromoter{}, testLogger())
+	assert.Equal(t, "inbox.promote", handler.ActionType())
+}
+
+func TestInboxPromoteActionHandler_PromotesToTask(t *testing.T) {
+	pendingRepo := newMockPendingActionRepo()
+	userID := uuid.New()
+	itemID := uuid.New()
+
+	action := domain.NewPendingAction(
+		uuid.New(),
+		uuid.New(),
+		userID,
+		"inbox.promote",
+		map[string]any{
+			"item_id":  itemID.String(),
+			"priority": "high",
+			"duration": float64(45),
+		},
+		time.Now().Add(-1*time.Minute),
+	)
+	_ = pendingRepo.Create(context.Background(), action)
+
+	promoter := &stubInboxPromoter{items: map[uuid.UUID]bool{itemID: true}}
+	executor := NewActionExecutor(pendingRepo, testLogger())
+	executor.RegisterHandler(NewInboxPromoteActionHandler(promoter, testLogger()))
+
+	result, err := executor.ExecutePending(context.Background(), 100)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SuccessCount)
+	assert.Equal(t, itemID.String(), result.Results[0].Result["item_id"])
+	assert.Equal(t, "task", result.Results[0].Result["target"])
+	assert.NotEmpty(t, result.Results[0].Result["promoted_id"])
+
+	require.Len(t, promoter.commands, 1)
+	cmd := promoter.commands[0]
+	assert.Equal(t, userID, cmd.UserID)
+	assert.Equal(t, itemID, cmd.ItemID)
+	assert.Equal(t, inboxCommands.PromoteTargetTask, cmd.Target)
+	require.NotNil(t, cmd.TaskArgs)
+	assert.Equal(t, "high", cmd.TaskArgs.Priority)
+	assert.Equal(t, 45, cmd.TaskArgs.DurationMinutes)
+
+	updatedAction, _ := pendingRepo.GetByID(context.Background(), action.ID)
+	assert.Equal(t, domain.PendingActionStatusExecuted, updatedAction.Status)
+}
+
+func TestInboxPromoteActionHandler_PromotesToMeeting(t *testing.T) {
+	itemID := uuid.New()
+	promoter := &stubInboxPromoter{items: map[uuid.UUID]bool{itemID: true}}
+	handler := NewInboxPromoteActionHandler(promoter, testLogger())
+
+	result, err := handler.Execute(context.Background(), uuid.New(), "", map[string]any{
+		"item_id": itemID.String(),
+		"target":  "meeting",
+		"title":   "Weekly sync",
+		"cadence": "weekly",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "meeting", result["target"])
+
+	require.Len(t, promoter.commands, 1)
+	require.NotNil(t, promoter.commands[0].MeetingArgs)
+	assert.Equal(t, "Weekly sync", promoter.commands[0].MeetingArgs.Name)
+	assert.Equal(t, "weekly", promoter.commands[0].MeetingArgs.Cadence)
+}
+
+func TestInboxPromoteActionHandler_MissingItemFails(t *testing.T) {
+	pendingRepo := newMockPendingActionRepo()
+	itemID := uuid.New()
+
+	action := domain.NewPendingAction(
+		uuid.New(),
+		uuid.New(),
+		uuid.New(),
+		"inbox.promote",
+		map[string]any{"item_id": itemID.String()},
+		time.Now().Add(-1*time.Minute),
+	)
+	_ = pendingRepo.Create(context.Background(), action)
+
+	executor := NewActionExecutor(pendingRepo, testLogger())
+	executor.RegisterHandler(NewInboxPromoteActionHandler(&stubInboxPromoter{}, testLogger()))
+
+	result, err := executor.ExecutePending(context.Background(), 100)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.SuccessCount)
+	assert.Equal(t, 1, result.RetryCount)
+	assert.Contains(t, result.Results[0].Error, itemID.String())
+	assert.Contains(t, result.Results[0].Error, errInboxItemNotFound.Error())
+
+	updatedAction, _ := pendingRepo.GetByID(context.Background(), action.ID)
+	assert.Equal(t, 1, updatedAction.RetryCount)
+}
+
+func TestInboxPromoteActionHandler_InvalidParams(t *testing.T) {
+	handler := NewInboxPromoteActionHandler(&stubInboxPromoter{}, testLogger())
+
+	tests := []struct {
+		name   string
+		params map[string]any
+		errMsg string
+	}{
+		{"missing item id", map[string]any{}, "inbox item id is required"},
+		{"invalid item id", map[string]any{"item_id": "not-a-uuid"}, "invalid inbox item id"},
+		{"unsupported target", map[string]any{"item_id": uuid.NewString(), "target": "project"}, "unsupported promote target"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.Execute(context.Background(), uuid.New(), "", tt.params)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	"github.com/google/uuid"
+)
+
+// RuleExportVersion is the version of the portable rule format.
+const RuleExportVersion = 1
+
+// ErrUnsupportedExportVersion is returned when importing rules exported in a
+// format this version of Orbita does not understand.
+var ErrUnsupportedExportVersion = errors.New("unsupported automation rule export version")
+
+// webhookActionType is the action type whose parameters can carry secrets.
+const webhookActionType = "webhook.call"
+
+// RuleExport is a portable set of automation rules that can be shared and
+// imported by another user.
+type RuleExport struct {
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Rules      []ExportedRule `json:"rules"`
+}
+
+// ExportedRule is the portable form of an automation rule. It leaves out the
+// owner and execution history, and keeps the original ID only so references
+// between exported rules can be remapped on import.
+type ExportedRule struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Enabled     bool      `json:"enabled"`
+	Priority    int       `json:"priority,omitempty"`
+
+	TriggerType   TriggerType    `json:"trigger_type"`
+	TriggerConfig map[string]any `json:"trigger_config,omitempty"`
+
+	Conditions        []types.RuleCondition `json:"conditions,omitempty"`
+	ConditionOperator ConditionOperator     `json:"condition_operator,omitempty"`
+
+	Actions []types.RuleAction `json:"actions"`
+
+	CooldownSeconds      int      `json:"cooldown_seconds,omitempty"`
+	MaxExecutionsPerHour *int     `json:"max_executions_per_hour,omitempty"`
+	Tags                 []string `json:"tags,omitempty"`
+}
+
+// NewRuleExport exports rules in the portable format.
+func NewRuleExport(rules []*AutomationRule, exportedAt time.Time) RuleExport {
+	export := RuleExport{
+		Version:    RuleExportVersion,
+		ExportedAt: exportedAt,
+		Rules:      make([]ExportedRule, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		export.Rules = append(export.Rules, NewExportedRule(rule))
+	}
+	return export
+}
+
+// NewExportedRule converts a rule to its portable form. Webhook secrets are
+// not exported: webhook actions lose their secret parameters and credential
+// headers, which the importing user has to fill in again.
+func NewExportedRule(rule *AutomationRule) ExportedRule {
+	actions := make([]types.RuleAction, len(rule.Actions))
+	for i, action := range rule.Actions {
+		if action.Type == webhookActionType {
+			action.Parameters = withoutSecrets(action.Parameters)
+		}
+		actions[i] = action
+	}
+
+	return ExportedRule{
+		ID:                   rule.ID,
+		Name:                 rule.Name,
+		Description:          rule.Description,
+		Enabled:              rule.Enabled,
+		Priority:             rule.Priority,
+		TriggerType:          rule.TriggerType,
+		TriggerConfig:        rule.TriggerConfig,
+		Conditions:           rule.Conditions,
+		ConditionOperator:    rule.ConditionOperator,
+		Actions:              actions,
+		CooldownSeconds:      rule.CooldownSeconds,
+		MaxExecutionsPerHour: rule.MaxExecutionsPerHour,
+		Tags:                 rule.Tags,
+	}
+}
+
+// withoutSecrets returns a copy of webhook parameters without secret values,
+// including secret headers. The original map is left untouched.
+func withoutSecrets(params map[string]any) map[string]any {
+	if params == nil {
+		return nil
+	}
+	cleaned := make(map[string]any, len(params))
+	for key, value := range params {
+		if isSecretKey(key) {
+			continue
+		}
+		if key == "headers" {
+			if headers, ok := value.(map[string]any); ok {
+				kept := make(map[string]any, len(headers))
+				for name, v := range headers {
+					if !isSecretKey(name) {
+						kept[name] = v
+					}
+				}
+				value = kept
+			}
+		}
+		cleaned[key] = value
+	}
+	return cleaned
+}
+
+// isSecretKey reports whether a parameter or header name holds a credential.
+func isSecretKey(name string) bool {
+	name = strings.ToLower(name)
+	if name == "authorization" || name == "proxy-authorization" {
+		return true
+	}
+	for _, marker := range []string{"secret", "token", "password", "api_key", "api-key", "apikey", "signature"} {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
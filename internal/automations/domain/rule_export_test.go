@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRuleExport_ExcludesWebhookSecrets(t *testing.T) {
+	headers := map[string]any{
+		"Content-Type":    "application/json",
+		"Authorization":   "Bearer abc123",
+		"X-Webhook-Token": "t0k3n",
+	}
+	actions := []types.RuleAction{
+		{Type: "notification.send", Parameters: map[string]any{"message": "done", "token_count": 3}},
+		{Type: "webhook.call", Parameters: map[string]any{
+			"url":     "https://hooks.example.com/orbita",
+			"method":  "POST",
+			"secret":  "s3cr3t",
+			"headers": headers,
+		}},
+	}
+	rule, err := NewAutomationRule(uuid.New(), "Notify team", TriggerTypeEvent,
+		map[string]any{"event_types": []any{"task.completed"}}, actions)
+	require.NoError(t, err)
+	rule.AddTag("team")
+
+	exportedAt := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	export := NewRuleExport([]*AutomationRule{rule}, exportedAt)
+
+	assert.Equal(t, RuleExportVersion, export.Version)
+	assert.Equal(t, exportedAt, export.ExportedAt)
+	require.Len(t, export.Rules, 1)
+	exported := export.Rules[0]
+	assert.Equal(t, rule.ID, exported.ID)
+	assert.Equal(t, "Notify team", exported.Name)
+	assert.Equal(t, []string{"team"}, exported.Tags)
+
+	// Only webhook actions are scrubbed.
+	assert.Equal(t, actions[0].Parameters, exported.Actions[0].Parameters)
+
+	webhook := exported.Actions[1].Parameters
+	assert.Equal(t, "https://hooks.example.com/orbita", webhook["url"])
+	assert.Equal(t, "POST", webhook["method"])
+	assert.NotContains(t, webhook, "secret")
+	assert.Equal(t, map[string]any{"Content-Type": "application/json"}, webhook["headers"])
+
+	// The rule itself keeps its secrets.
+	assert.Equal(t, "s3cr3t", rule.Actions[1].Parameters["secret"])
+	assert.Len(t, headers, 3)
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/billing/domain"
 	"github.com/google/uuid"
@@ -11,11 +12,18 @@ import (
 type Service struct {
 	entitlements  domain.EntitlementRepository
 	subscriptions domain.SubscriptionRepository
+	clock         func() time.Time
 }
 
 // NewService creates a new billing service.
 func NewService(entitlements domain.EntitlementRepository, subscriptions domain.SubscriptionRepository) *Service {
-	return &Service{entitlements: entitlements, subscriptions: subscriptions}
+	return &Service{entitlements: entitlements, subscriptions: subscriptions, clock: time.Now}
+}
+
+// WithClock sets the clock used to evaluate trials and scheduled downgrades.
+func (s *Service) WithClock(clock func() time.Time) *Service {
+	s.clock = clock
+	return s
 }
 
 // GetSubscription returns the user's subscription, if any.
@@ -27,11 +35,34 @@ func (s *Service) GetSubscription(ctx context.Context, userID uuid.UUID) (*domai
 }
 
 // ListEntitlements returns all entitlements for the user.
+// An active trial lists every Pro module; once a scheduled downgrade takes
+// effect, modules outside the new plan are listed as inactive.
 func (s *Service) ListEntitlements(ctx context.Context, userID uuid.UUID) ([]domain.Entitlement, error) {
 	if s == nil || s.entitlements == nil {
 		return nil, nil
 	}
-	return s.entitlements.List(ctx, userID)
+	list, err := s.entitlements.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.GetSubscription(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+
+	switch {
+	case subscription.IsTrialActive(now):
+		return trialEntitlements(userID, list), nil
+	case subscription.IsDowngradeEffective(now):
+		for i := range list {
+			if list[i].Active && !domain.PlanIncludesModule(subscription.PendingPlan, list[i].Module) {
+				list[i].Active = false
+			}
+		}
+	}
+	return list, nil
 }
 
 // SetEntitlement updates a module entitlement.
@@ -46,10 +77,31 @@ func (s *Service) SetEntitlement(ctx context.Context, userID uuid.UUID, module s
 }
 
 // HasEntitlement reports whether the user can access the module.
+// An active trial grants every module. After a trial ends, access reverts to
+// explicitly granted entitlements. A scheduled downgrade keeps the current
+// entitlements until the period ends and then restricts access to the new plan.
 func (s *Service) HasEntitlement(ctx context.Context, userID uuid.UUID, module string) (bool, error) {
 	if s == nil || s.entitlements == nil {
 		return true, nil
 	}
+
+	subscription, err := s.GetSubscription(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	now := s.now()
+
+	switch {
+	case subscription.IsTrialActive(now):
+		return true, nil
+	case subscription.IsTrialExpired(now):
+		return s.entitlements.IsActive(ctx, userID, module)
+	case subscription.IsDowngradeEffective(now):
+		if !domain.PlanIncludesModule(subscription.PendingPlan, module) {
+			return false, nil
+		}
+	}
+
 	list, err := s.entitlements.List(ctx, userID)
 	if err != nil {
 		return false, err
@@ -59,3 +111,26 @@ func (s *Service) HasEntitlement(ctx context.Context, userID uuid.UUID, module s
 	}
 	return s.entitlements.IsActive(ctx, userID, module)
 }
+
+func (s *Service) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock()
+}
+
+// trialEntitlements overlays all Pro modules as active on the stored entitlements.
+func trialEntitlements(userID uuid.UUID, stored []domain.Entitlement) []domain.Entitlement {
+	result := make([]domain.Entitlement, 0, len(domain.ProModules)+len(stored))
+	seen := make(map[string]bool, len(domain.ProModules))
+	for _, module := range domain.ProModules {
+		result = append(result, domain.Entitlement{UserID: userID, Module: module, Active: true, Source: "trial"})
+		seen[module] = true
+	}
+	for _, entitlement := range stored {
+		if !seen[entitlement.Module] {
+			result = append(result, entitlement)
+		}
+	}
+	return result
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/billing/domain"
 	"github.com/google/uuid"
@@ -158,3 +159,108 @@ func (f *fakeSubscriptionRepoWithSub) Upsert(ctx context.Context, subscription *
 func (f *fakeSubscriptionRepoWithSub) FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.Subscription, error) {
 	return f.sub, nil
 }
+
+func fixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+func TestHasEntitlement_TrialGrantsAllUntilTrialEnd(t *testing.T) {
+	userID := uuid.New()
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	sub := &domain.Subscription{UserID: userID, Plan: domain.PlanFree}
+	sub.StartTrial(start, 14*24*time.Hour)
+
+	entitlements := fakeEntitlementRepo{
+		list:   []domain.Entitlement{{UserID: userID, Module: domain.ModuleSmartMeetings, Active: true, Source: "manual"}},
+		active: map[string]bool{domain.ModuleSmartMeetings: true},
+	}
+
+	// One minute before the trial ends every module is available.
+	svc := NewService(entitlements, &fakeSubscriptionRepoWithSub{sub: sub}).
+		WithClock(fixedClock(start.Add(14*24*time.Hour - time.Minute)))
+	allowed, err := svc.HasEntitlement(context.Background(), userID, domain.ModuleAIInbox)
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	// At the trial end access reverts to explicit entitlements.
+	svc.WithClock(fixedClock(start.Add(14 * 24 * time.Hour)))
+	allowed, err = svc.HasEntitlement(context.Background(), userID, domain.ModuleAIInbox)
+	require.NoError(t, err)
+	require.False(t, allowed)
+
+	allowed, err = svc.HasEntitlement(context.Background(), userID, domain.ModuleSmartMeetings)
+	require.NoError(t, err)
+	require.True(t, allowed)
+}
+
+func TestHasEntitlement_ExpiredTrialIgnoresDefaultAllow(t *testing.T) {
+	userID := uuid.New()
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	sub := &domain.Subscription{UserID: userID}
+	sub.StartTrial(start, 7*24*time.Hour)
+
+	svc := NewService(fakeEntitlementRepo{}, &fakeSubscriptionRepoWithSub{sub: sub}).
+		WithClock(fixedClock(start.Add(8 * 24 * time.Hour)))
+
+	allowed, err := svc.HasEntitlement(context.Background(), userID, domain.ModuleSmartHabits)
+	require.NoError(t, err)
+	require.False(t, allowed)
+}
+
+func TestHasEntitlement_ScheduledDowngradeAtPeriodEnd(t *testing.T) {
+	userID := uuid.New()
+	periodEnd := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	sub := &domain.Subscription{
+		UserID:           userID,
+		Plan:             domain.PlanPro,
+		Status:           domain.SubscriptionActive,
+		CurrentPeriodEnd: &periodEnd,
+	}
+	require.NoError(t, sub.ScheduleDowngrade(domain.PlanFree))
+
+	entitlements := fakeEntitlementRepo{
+		list:   []domain.Entitlement{{UserID: userID, Module: domain.ModuleSmartHabits, Active: true, Source: "stripe"}},
+		active: map[string]bool{domain.ModuleSmartHabits: true},
+	}
+
+	// Before the period ends the current entitlements are kept.
+	svc := NewService(entitlements, &fakeSubscriptionRepoWithSub{sub: sub}).
+		WithClock(fixedClock(periodEnd.Add(-time.Second)))
+	allowed, err := svc.HasEntitlement(context.Background(), userID, domain.ModuleSmartHabits)
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	// From the period end the downgraded plan applies.
+	svc.WithClock(fixedClock(periodEnd))
+	allowed, err = svc.HasEntitlement(context.Background(), userID, domain.ModuleSmartHabits)
+	require.NoError(t, err)
+	require.False(t, allowed)
+}
+
+func TestListEntitlements_TrialAndDowngrade(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	stored := []domain.Entitlement{{UserID: userID, Module: domain.ModuleSmartHabits, Active: true, Source: "stripe"}}
+
+	trial := &domain.Subscription{UserID: userID}
+	trial.StartTrial(now.Add(-24*time.Hour), 14*24*time.Hour)
+	svc := NewService(fakeEntitlementRepo{list: stored}, &fakeSubscriptionRepoWithSub{sub: trial}).
+		WithClock(fixedClock(now))
+	list, err := svc.ListEntitlements(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, list, len(domain.ProModules))
+	for _, entitlement := range list {
+		require.True(t, entitlement.Active)
+		require.Equal(t, "trial", entitlement.Source)
+	}
+
+	periodEnd := now.Add(-time.Hour)
+	downgraded := &domain.Subscription{UserID: userID, Plan: domain.PlanPro, CurrentPeriodEnd: &periodEnd}
+	require.NoError(t, downgraded.ScheduleDowngrade(domain.PlanFree))
+	svc = NewService(fakeEntitlementRepo{list: []domain.Entitlement{stored[0]}}, &fakeSubscriptionRepoWithSub{sub: downgraded}).
+		WithClock(fixedClock(now))
+	list, err = svc.ListEntitlements(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.False(t, list[0].Active)
+}
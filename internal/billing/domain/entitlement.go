@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
 
 // Module names for entitlement checks.
 const (
@@ -12,6 +16,33 @@ const (
 	ModulePriorityEngine    = "priority-engine"
 )
 
+// Plan names.
+const (
+	PlanFree = "free"
+	PlanPro  = "pro"
+)
+
+// ProModules lists the modules unlocked by the Pro plan and by trials.
+var ProModules = []string{
+	ModuleSmartHabits,
+	ModuleSmartMeetings,
+	ModuleAutoRescheduler,
+	ModuleAIInbox,
+	ModulePriorityEngine,
+	ModuleAdaptiveFrequency,
+}
+
+// PlanIncludesModule reports whether a plan grants access to the module.
+// Unknown plans grant nothing.
+func PlanIncludesModule(plan, module string) bool {
+	switch plan {
+	case PlanPro:
+		return slices.Contains(ProModules, module)
+	default:
+		return false
+	}
+}
+
 // Entitlement represents access to a module.
 type Entitlement struct {
 	UserID uuid.UUID
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +17,9 @@ const (
 	SubscriptionCanceled SubscriptionStatus = "canceled"
 )
 
+// ErrNoBillingPeriod is returned when a downgrade is scheduled without a period end.
+var ErrNoBillingPeriod = errors.New("subscription has no current period end")
+
 // Subscription represents a user's subscription.
 type Subscription struct {
 	ID                   uuid.UUID
@@ -23,8 +27,73 @@ type Subscription struct {
 	Plan                 string
 	Status               SubscriptionStatus
 	CurrentPeriodEnd     *time.Time
+	TrialEndsAt          *time.Time
+	PendingPlan          string
 	StripeCustomerID     string
 	StripeSubscriptionID string
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 }
+
+// StartTrial puts the subscription into a trial that ends after the given duration.
+func (s *Subscription) StartTrial(now time.Time, duration time.Duration) {
+	trialEnd := now.Add(duration)
+	s.Status = SubscriptionTrialing
+	s.TrialEndsAt = &trialEnd
+}
+
+// ScheduleDowngrade records a plan change that takes effect when the current period ends.
+// Entitlements of the current plan are kept until then.
+func (s *Subscription) ScheduleDowngrade(plan string) error {
+	if s.CurrentPeriodEnd == nil {
+		return ErrNoBillingPeriod
+	}
+	s.PendingPlan = plan
+	return nil
+}
+
+// CancelScheduledDowngrade removes a pending plan change.
+func (s *Subscription) CancelScheduledDowngrade() {
+	s.PendingPlan = ""
+}
+
+// IsTrialActive reports whether the subscription is in a trial that has not ended at now.
+func (s *Subscription) IsTrialActive(now time.Time) bool {
+	if s == nil || s.Status != SubscriptionTrialing || s.TrialEndsAt == nil {
+		return false
+	}
+	return now.Before(*s.TrialEndsAt)
+}
+
+// IsTrialExpired reports whether the subscription is still marked as trialing
+// but the trial ended at or before now.
+func (s *Subscription) IsTrialExpired(now time.Time) bool {
+	if s == nil || s.Status != SubscriptionTrialing || s.TrialEndsAt == nil {
+		return false
+	}
+	return !now.Before(*s.TrialEndsAt)
+}
+
+// HasScheduledDowngrade reports whether a plan change is pending.
+func (s *Subscription) HasScheduledDowngrade() bool {
+	return s != nil && s.PendingPlan != "" && s.CurrentPeriodEnd != nil
+}
+
+// IsDowngradeEffective reports whether a scheduled downgrade has taken effect at now.
+func (s *Subscription) IsDowngradeEffective(now time.Time) bool {
+	if !s.HasScheduledDowngrade() {
+		return false
+	}
+	return !now.Before(*s.CurrentPeriodEnd)
+}
+
+// EffectivePlan returns the plan in force at now, accounting for scheduled downgrades.
+func (s *Subscription) EffectivePlan(now time.Time) string {
+	if s == nil {
+		return ""
+	}
+	if s.IsDowngradeEffective(now) {
+		return s.PendingPlan
+	}
+	return s.Plan
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscription_ScheduleDowngradeRequiresPeriodEnd(t *testing.T) {
+	sub := &Subscription{Plan: PlanPro}
+	require.ErrorIs(t, sub.ScheduleDowngrade(PlanFree), ErrNoBillingPeriod)
+	require.False(t, sub.HasScheduledDowngrade())
+}
+
+func TestSubscription_EffectivePlan(t *testing.T) {
+	periodEnd := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	sub := &Subscription{Plan: PlanPro, CurrentPeriodEnd: &periodEnd}
+	require.NoError(t, sub.ScheduleDowngrade(PlanFree))
+
+	require.Equal(t, PlanPro, sub.EffectivePlan(periodEnd.Add(-time.Nanosecond)))
+	require.Equal(t, PlanFree, sub.EffectivePlan(periodEnd))
+
+	sub.CancelScheduledDowngrade()
+	require.Equal(t, PlanPro, sub.EffectivePlan(periodEnd.Add(time.Hour)))
+}
+
+func TestSubscription_TrialBoundaries(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	sub := &Subscription{}
+	sub.StartTrial(start, 24*time.Hour)
+
+	require.Equal(t, SubscriptionTrialing, sub.Status)
+	require.True(t, sub.IsTrialActive(start.Add(23*time.Hour)))
+	require.False(t, sub.IsTrialExpired(start.Add(23*time.Hour)))
+	require.False(t, sub.IsTrialActive(start.Add(24*time.Hour)))
+	require.True(t, sub.IsTrialExpired(start.Add(24*time.Hour)))
+
+	var missing *Subscription
+	require.False(t, missing.IsTrialActive(start))
+	require.False(t, missing.IsDowngradeEffective(start))
+}
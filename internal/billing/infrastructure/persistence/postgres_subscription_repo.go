@@ -25,13 +25,15 @@ func NewPostgresSubscriptionRepository(pool *pgxpool.Pool) *PostgresSubscription
 func (r *PostgresSubscriptionRepository) Upsert(ctx context.Context, subscription *domain.Subscription) error {
 	query := `
 		INSERT INTO subscriptions (
-			id, user_id, plan, status, current_period_end,
+			id, user_id, plan, status, current_period_end, trial_ends_at, pending_plan,
 			stripe_customer_id, stripe_subscription_id, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (user_id) DO UPDATE SET
 			plan = EXCLUDED.plan,
 			status = EXCLUDED.status,
 			current_period_end = EXCLUDED.current_period_end,
+			trial_ends_at = EXCLUDED.trial_ends_at,
+			pending_plan = EXCLUDED.pending_plan,
 			stripe_customer_id = EXCLUDED.stripe_customer_id,
 			stripe_subscription_id = EXCLUDED.stripe_subscription_id,
 			updated_at = NOW()
@@ -42,6 +44,8 @@ func (r *PostgresSubscriptionRepository) Upsert(ctx context.Context, subscriptio
 		subscription.Plan,
 		string(subscription.Status),
 		subscription.CurrentPeriodEnd,
+		subscription.TrialEndsAt,
+		subscription.PendingPlan,
 		subscription.StripeCustomerID,
 		subscription.StripeSubscriptionID,
 		subscription.CreatedAt,
@@ -53,7 +57,7 @@ func (r *PostgresSubscriptionRepository) Upsert(ctx context.Context, subscriptio
 // FindByUserID returns the subscription for a user.
 func (r *PostgresSubscriptionRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.Subscription, error) {
 	query := `
-		SELECT id, user_id, plan, status, current_period_end,
+		SELECT id, user_id, plan, status, current_period_end, trial_ends_at, pending_plan,
 		       stripe_customer_id, stripe_subscription_id, created_at, updated_at
 		FROM subscriptions
 		WHERE user_id = $1
@@ -64,6 +68,8 @@ func (r *PostgresSubscriptionRepository) FindByUserID(ctx context.Context, userI
 		plan                 string
 		status               string
 		currentPeriodEnd     *time.Time
+		trialEndsAt          *time.Time
+		pendingPlan          string
 		stripeCustomerID     string
 		stripeSubscriptionID string
 		createdAt            time.Time
@@ -76,6 +82,8 @@ func (r *PostgresSubscriptionRepository) FindByUserID(ctx context.Context, userI
 		&row.plan,
 		&row.status,
 		&row.currentPeriodEnd,
+		&row.trialEndsAt,
+		&row.pendingPlan,
 		&row.stripeCustomerID,
 		&row.stripeSubscriptionID,
 		&row.createdAt,
@@ -94,6 +102,8 @@ func (r *PostgresSubscriptionRepository) FindByUserID(ctx context.Context, userI
 		Plan:                 row.plan,
 		Status:               domain.SubscriptionStatus(row.status),
 		CurrentPeriodEnd:     row.currentPeriodEnd,
+		TrialEndsAt:          row.trialEndsAt,
+		PendingPlan:          row.pendingPlan,
 		StripeCustomerID:     row.stripeCustomerID,
 		StripeSubscriptionID: row.stripeSubscriptionID,
 		CreatedAt:            row.createdAt,
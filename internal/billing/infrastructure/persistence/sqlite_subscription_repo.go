@@ -45,15 +45,25 @@ func (r *SQLiteSubscriptionRepository) Upsert(ctx context.Context, subscription
 		}
 	}
 
+	var trialEndsAt sql.NullString
+	if subscription.TrialEndsAt != nil {
+		trialEndsAt = sql.NullString{
+			String: subscription.TrialEndsAt.Format(time.RFC3339),
+			Valid:  true,
+		}
+	}
+
 	query := `
 		INSERT INTO subscriptions (
-			id, user_id, plan, status, current_period_end,
+			id, user_id, plan, status, current_period_end, trial_ends_at, pending_plan,
 			stripe_customer_id, stripe_subscription_id, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT (user_id) DO UPDATE SET
 			plan = excluded.plan,
 			status = excluded.status,
 			current_period_end = excluded.current_period_end,
+			trial_ends_at = excluded.trial_ends_at,
+			pending_plan = excluded.pending_plan,
 			stripe_customer_id = excluded.stripe_customer_id,
 			stripe_subscription_id = excluded.stripe_subscription_id,
 			updated_at = excluded.updated_at
@@ -74,6 +84,8 @@ func (r *SQLiteSubscriptionRepository) Upsert(ctx context.Context, subscription
 		subscription.Plan,
 		string(subscription.Status),
 		currentPeriodEnd,
+		trialEndsAt,
+		subscription.PendingPlan,
 		subscription.StripeCustomerID,
 		subscription.StripeSubscriptionID,
 		createdAt,
@@ -86,7 +98,7 @@ func (r *SQLiteSubscriptionRepository) Upsert(ctx context.Context, subscription
 func (r *SQLiteSubscriptionRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.Subscription, error) {
 	db := r.getDB(ctx)
 	query := `
-		SELECT id, user_id, plan, status, current_period_end,
+		SELECT id, user_id, plan, status, current_period_end, trial_ends_at, pending_plan,
 		       stripe_customer_id, stripe_subscription_id, created_at, updated_at
 		FROM subscriptions
 		WHERE user_id = ?
@@ -98,6 +110,8 @@ func (r *SQLiteSubscriptionRepository) FindByUserID(ctx context.Context, userID
 		plan                 string
 		status               string
 		currentPeriodEndStr  sql.NullString
+		trialEndsAtStr       sql.NullString
+		pendingPlan          string
 		stripeCustomerID     string
 		stripeSubscriptionID string
 		createdAtStr         string
@@ -110,6 +124,8 @@ func (r *SQLiteSubscriptionRepository) FindByUserID(ctx context.Context, userID
 		&plan,
 		&status,
 		&currentPeriodEndStr,
+		&trialEndsAtStr,
+		&pendingPlan,
 		&stripeCustomerID,
 		&stripeSubscriptionID,
 		&createdAtStr,
@@ -133,12 +149,20 @@ func (r *SQLiteSubscriptionRepository) FindByUserID(ctx context.Context, userID
 		currentPeriodEnd = &t
 	}
 
+	var trialEndsAt *time.Time
+	if trialEndsAtStr.Valid {
+		t, _ := time.Parse(time.RFC3339, trialEndsAtStr.String)
+		trialEndsAt = &t
+	}
+
 	return &domain.Subscription{
 		ID:                   id,
 		UserID:               parsedUserID,
 		Plan:                 plan,
 		Status:               domain.SubscriptionStatus(status),
 		CurrentPeriodEnd:     currentPeriodEnd,
+		TrialEndsAt:          trialEndsAt,
+		PendingPlan:          pendingPlan,
 		StripeCustomerID:     stripeCustomerID,
 		StripeSubscriptionID: stripeSubscriptionID,
 		CreatedAt:            createdAt,
@@ -0,0 +1,35 @@
+package application
+
+// ProviderCapabilities describes which optional features a calendar provider
+// implementation supports. The zero value supports none of them.
+type ProviderCapabilities struct {
+	// DeleteEvents means events removed from Orbita can be deleted remotely.
+	DeleteEvents bool
+	// Reminders means per-event reminders are synced.
+	Reminders bool
+	// Colors means event colors are synced.
+	Colors bool
+	// IncrementalSync means changes can be fetched since the last sync
+	// instead of listing every event again.
+	IncrementalSync bool
+}
+
+// adaptBlocks drops the block fields a provider cannot store, so syncers only
+// receive what they support. The input slice is left untouched.
+func (c ProviderCapabilities) adaptBlocks(blocks []TimeBlock) []TimeBlock {
+	if c.Colors && c.Reminders {
+		return blocks
+	}
+
+	adapted := make([]TimeBlock, len(blocks))
+	for i, block := range blocks {
+		if !c.Colors {
+			block.Color = ""
+		}
+		if !c.Reminders {
+			block.Reminders = nil
+		}
+		adapted[i] = block
+	}
+	return adapted
+}
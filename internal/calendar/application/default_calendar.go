@@ -0,0 +1,53 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// PrimaryCalendarID is the provider alias for the user's primary calendar.
+const PrimaryCalendarID = "primary"
+
+// CalendarLister lists the calendars available to a user.
+type CalendarLister interface {
+	ListCalendars(ctx context.Context, userID uuid.UUID) ([]Calendar, error)
+}
+
+// DefaultCalendar is the outcome of resolving a user's stored default calendar.
+type DefaultCalendar struct {
+	CalendarID string
+	// Warning explains why the stored calendar could not be used or verified.
+	// It is empty when the stored calendar was confirmed to exist.
+	Warning string
+}
+
+// ResolveDefaultCalendar checks that a stored default calendar still exists.
+// A calendar that has been deleted falls back to the primary calendar. If the
+// calendars cannot be listed, the stored calendar is kept and the sync itself
+// reports any error. A nil lister skips validation.
+func ResolveDefaultCalendar(ctx context.Context, lister CalendarLister, userID uuid.UUID, storedID string) DefaultCalendar {
+	if storedID == "" || storedID == PrimaryCalendarID || lister == nil {
+		return DefaultCalendar{CalendarID: storedID}
+	}
+
+	calendars, err := lister.ListCalendars(ctx, userID)
+	if err != nil {
+		return DefaultCalendar{
+			CalendarID: storedID,
+			Warning:    fmt.Sprintf("could not verify default calendar %q: %v", storedID, err),
+		}
+	}
+
+	for _, cal := range calendars {
+		if cal.ID == storedID {
+			return DefaultCalendar{CalendarID: storedID}
+		}
+	}
+
+	return DefaultCalendar{
+		CalendarID: PrimaryCalendarID,
+		Warning:    fmt.Sprintf("default calendar %q no longer exists, falling back to %s", storedID, PrimaryCalendarID),
+	}
+}
@@ -0,0 +1,73 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubCalendarLister struct {
+	calendars []application.Calendar
+	err       error
+	calls     int
+}
+
+func (s *stubCalendarLister) ListCalendars(ctx context.Context, userID uuid.UUID) ([]application.Calendar, error) {
+	s.calls++
+	return s.calendars, s.err
+}
+
+func TestResolveDefaultCalendar(t *testing.T) {
+	calendars := []application.Calendar{
+		{ID: "primary-id", Name: "Me", Primary: true},
+		{ID: "work", Name: "Work"},
+	}
+
+	t.Run("existing calendar is kept", func(t *testing.T) {
+		lister := &stubCalendarLister{calendars: calendars}
+
+		resolved := application.ResolveDefaultCalendar(context.Background(), lister, uuid.New(), "work")
+
+		assert.Equal(t, "work", resolved.CalendarID)
+		assert.Empty(t, resolved.Warning)
+	})
+
+	t.Run("deleted calendar falls back to primary", func(t *testing.T) {
+		lister := &stubCalendarLister{calendars: calendars}
+
+		resolved := application.ResolveDefaultCalendar(context.Background(), lister, uuid.New(), "old-team")
+
+		assert.Equal(t, application.PrimaryCalendarID, resolved.CalendarID)
+		assert.Contains(t, resolved.Warning, `"old-team" no longer exists`)
+	})
+
+	t.Run("no default skips validation", func(t *testing.T) {
+		lister := &stubCalendarLister{calendars: calendars}
+
+		resolved := application.ResolveDefaultCalendar(context.Background(), lister, uuid.New(), "")
+
+		assert.Empty(t, resolved.CalendarID)
+		assert.Empty(t, resolved.Warning)
+		assert.Zero(t, lister.calls)
+	})
+
+	t.Run("listing failure keeps the stored calendar", func(t *testing.T) {
+		lister := &stubCalendarLister{err: errors.New("token expired")}
+
+		resolved := application.ResolveDefaultCalendar(context.Background(), lister, uuid.New(), "work")
+
+		assert.Equal(t, "work", resolved.CalendarID)
+		assert.Contains(t, resolved.Warning, "token expired")
+	})
+
+	t.Run("nil lister skips validation", func(t *testing.T) {
+		resolved := application.ResolveDefaultCalendar(context.Background(), nil, uuid.New(), "work")
+
+		assert.Equal(t, "work", resolved.CalendarID)
+		assert.Empty(t, resolved.Warning)
+	})
+}
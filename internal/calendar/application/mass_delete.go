@@ -0,0 +1,40 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultMassDeleteThreshold is how many events a sync may delete as missing
+// before it needs confirmation.
+const DefaultMassDeleteThreshold = 10
+
+// ErrMassDeleteNotConfirmed is returned when a sync would delete more missing
+// events than the threshold allows and the deletion was not confirmed. It
+// usually means the sync set or the Orbita event marker is wrong, so nothing
+// is deleted.
+var ErrMassDeleteNotConfirmed = errors.New("sync would delete more events than allowed without confirmation")
+
+// MassDeleteGuard stops syncs with delete-missing enabled from wiping a
+// calendar by accident.
+type MassDeleteGuard struct {
+	// Threshold is the number of events that may be deleted without
+	// confirmation. Zero or less disables the guard.
+	Threshold int
+	// Confirmed allows deleting any number of events.
+	Confirmed bool
+}
+
+// NewMassDeleteGuard creates a guard with the default threshold.
+func NewMassDeleteGuard() MassDeleteGuard {
+	return MassDeleteGuard{Threshold: DefaultMassDeleteThreshold}
+}
+
+// Check returns ErrMassDeleteNotConfirmed when deleting count events needs a
+// confirmation that was not given.
+func (g MassDeleteGuard) Check(count int) error {
+	if g.Confirmed || g.Threshold <= 0 || count <= g.Threshold {
+		return nil
+	}
+	return fmt.Errorf("%w: %d events would be deleted, threshold is %d", ErrMassDeleteNotConfirmed, count, g.Threshold)
+}
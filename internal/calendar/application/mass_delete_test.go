@@ -0,0 +1,23 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMassDeleteGuard_Check(t *testing.T) {
+	guard := NewMassDeleteGuard()
+
+	assert.NoError(t, guard.Check(0))
+	assert.NoError(t, guard.Check(DefaultMassDeleteThreshold))
+
+	err := guard.Check(DefaultMassDeleteThreshold + 1)
+	assert.ErrorIs(t, err, ErrMassDeleteNotConfirmed)
+	assert.ErrorContains(t, err, "11 events would be deleted, threshold is 10")
+
+	guard.Confirmed = true
+	assert.NoError(t, guard.Check(500))
+
+	assert.NoError(t, MassDeleteGuard{Threshold: 0}.Check(500), "a zero threshold disables the guard")
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Series cadences, matching the cadences supported by meetings.
+const (
+	SeriesCadenceWeekly   = "weekly"
+	SeriesCadenceBiweekly = "biweekly"
+	SeriesCadenceMonthly  = "monthly"
+	SeriesCadenceCustom   = "custom"
+)
+
+// RecurringSeries describes a recurring external event series detected from
+// its imported occurrences.
+type RecurringSeries struct {
+	ID          string // RecurringEventID shared by all occurrences
+	Summary     string
+	Cadence     string
+	CadenceDays int
+	Duration    time.Duration
+	StartTime   time.Time // start of the earliest occurrence seen
+	Occurrences int
+}
+
+// DetectRecurringSeries groups recurring occurrences by their series and
+// infers each series' cadence from the gap between consecutive occurrences.
+// A series seen only once in the window is assumed to be weekly. All-day,
+// cancelled and Orbita-created events are ignored. Series are returned in
+// order of their first occurrence.
+func DetectRecurringSeries(events []CalendarEvent) []RecurringSeries {
+	occurrences := make(map[string][]CalendarEvent)
+	var order []string
+	for _, event := range events {
+		if event.RecurringEventID == "" || event.IsAllDay || event.IsOrbitaEvent || event.Status == "cancelled" {
+			continue
+		}
+		if _, seen := occurrences[event.RecurringEventID]; !seen {
+			order = append(order, event.RecurringEventID)
+		}
+		occurrences[event.RecurringEventID] = append(occurrences[event.RecurringEventID], event)
+	}
+
+	series := make([]RecurringSeries, 0, len(order))
+	for _, id := range order {
+		items := occurrences[id]
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].StartTime.Before(items[j].StartTime)
+		})
+
+		first := items[0]
+		cadence, days := inferCadence(items)
+		series = append(series, RecurringSeries{
+			ID:          id,
+			Summary:     first.Summary,
+			Cadence:     cadence,
+			CadenceDays: days,
+			Duration:    first.EndTime.Sub(first.StartTime),
+			StartTime:   first.StartTime,
+			Occurrences: len(items),
+		})
+	}
+
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].StartTime.Before(series[j].StartTime)
+	})
+	return series
+}
+
+// inferCadence derives the cadence from the shortest gap, in whole days,
+// between consecutive occurrences sorted by start time.
+func inferCadence(items []CalendarEvent) (string, int) {
+	gap := 0
+	for i := 1; i < len(items); i++ {
+		days := int(math.Round(items[i].StartTime.Sub(items[i-1].StartTime).Hours() / 24))
+		if days > 0 && (gap == 0 || days < gap) {
+			gap = days
+		}
+	}
+
+	switch {
+	case gap == 0, gap == 7:
+		return SeriesCadenceWeekly, 7
+	case gap == 14:
+		return SeriesCadenceBiweekly, 14
+	case gap >= 28 && gap <= 31:
+		return SeriesCadenceMonthly, 30
+	default:
+		return SeriesCadenceCustom, gap
+	}
+}
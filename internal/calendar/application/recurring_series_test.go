@@ -0,0 +1,86 @@
+package application_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/application"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func occurrence(seriesID, summary string, start time.Time) application.CalendarEvent {
+	return application.CalendarEvent{
+		ID:               seriesID + "_" + start.Format("20060102"),
+		Summary:          summary,
+		StartTime:        start,
+		EndTime:          start.Add(30 * time.Minute),
+		IsRecurring:      true,
+		RecurringEventID: seriesID,
+	}
+}
+
+func TestDetectRecurringSeries(t *testing.T) {
+	monday := time.Date(2024, time.May, 6, 9, 0, 0, 0, time.UTC)
+
+	events := []application.CalendarEvent{
+		occurrence("standup", "Standup", monday.Add(7*24*time.Hour)),
+		occurrence("standup", "Standup", monday),
+		occurrence("retro", "Retro", monday.Add(-2*24*time.Hour)),
+		occurrence("retro", "Retro", monday.Add(12*24*time.Hour)),
+		occurrence("planning", "Planning", monday.Add(time.Hour)),
+		{ID: "one-off", Summary: "Dentist", StartTime: monday, EndTime: monday.Add(time.Hour)},
+	}
+
+	series := application.DetectRecurringSeries(events)
+
+	require.Len(t, series, 3)
+
+	assert.Equal(t, "retro", series[0].ID)
+	assert.Equal(t, application.SeriesCadenceBiweekly, series[0].Cadence)
+	assert.Equal(t, 14, series[0].CadenceDays)
+
+	assert.Equal(t, "standup", series[1].ID)
+	assert.Equal(t, "Standup", series[1].Summary)
+	assert.Equal(t, application.SeriesCadenceWeekly, series[1].Cadence)
+	assert.Equal(t, monday, series[1].StartTime)
+	assert.Equal(t, 30*time.Minute, series[1].Duration)
+	assert.Equal(t, 2, series[1].Occurrences)
+
+	// A single occurrence in the window is assumed to be weekly.
+	assert.Equal(t, "planning", series[2].ID)
+	assert.Equal(t, application.SeriesCadenceWeekly, series[2].Cadence)
+	assert.Equal(t, 1, series[2].Occurrences)
+}
+
+func TestDetectRecurringSeries_InfersMonthlyAndCustom(t *testing.T) {
+	start := time.Date(2024, time.January, 15, 14, 0, 0, 0, time.UTC)
+
+	series := application.DetectRecurringSeries([]application.CalendarEvent{
+		occurrence("monthly", "Board", start),
+		occurrence("monthly", "Board", start.AddDate(0, 1, 0)),
+		occurrence("custom", "Sprint review", start),
+		occurrence("custom", "Sprint review", start.AddDate(0, 0, 21)),
+	})
+
+	require.Len(t, series, 2)
+	byID := map[string]application.RecurringSeries{series[0].ID: series[0], series[1].ID: series[1]}
+	assert.Equal(t, application.SeriesCadenceMonthly, byID["monthly"].Cadence)
+	assert.Equal(t, application.SeriesCadenceCustom, byID["custom"].Cadence)
+	assert.Equal(t, 21, byID["custom"].CadenceDays)
+}
+
+func TestDetectRecurringSeries_IgnoresUnsuitableEvents(t *testing.T) {
+	start := time.Date(2024, time.May, 6, 9, 0, 0, 0, time.UTC)
+
+	allDay := occurrence("holiday", "Company holiday", start)
+	allDay.IsAllDay = true
+	cancelled := occurrence("cancelled", "Old sync", start)
+	cancelled.Status = "cancelled"
+	orbita := occurrence("orbita", "Focus", start)
+	orbita.IsOrbitaEvent = true
+
+	series := application.DetectRecurringSeries([]application.CalendarEvent{allDay, cancelled, orbita})
+
+	assert.Empty(t, series)
+}
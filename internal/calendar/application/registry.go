@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -25,6 +26,7 @@ type ProviderRegistry struct {
 	syncerFactories map[domain.ProviderType]SyncerFactory
 	importerFactories map[domain.ProviderType]ImporterFactory
 	bidirectionalFactories map[domain.ProviderType]BidirectionalSyncerFactory
+	capabilities           map[domain.ProviderType]ProviderCapabilities
 }
 
 // NewProviderRegistry creates a new provider registry.
@@ -33,6 +35,7 @@ func NewProviderRegistry() *ProviderRegistry {
 		syncerFactories:        make(map[domain.ProviderType]SyncerFactory),
 		importerFactories:      make(map[domain.ProviderType]ImporterFactory),
 		bidirectionalFactories: make(map[domain.ProviderType]BidirectionalSyncerFactory),
+		capabilities:           make(map[domain.ProviderType]ProviderCapabilities),
 	}
 }
 
@@ -66,6 +69,21 @@ func (r *ProviderRegistry) RegisterBidirectional(provider domain.ProviderType, f
 	}
 }
 
+// RegisterCapabilities declares which optional features a provider supports.
+func (r *ProviderRegistry) RegisterCapabilities(provider domain.ProviderType, capabilities ProviderCapabilities) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.capabilities[provider] = capabilities
+}
+
+// Capabilities returns the features a provider declared. Providers that
+// declared nothing support none of the optional features.
+func (r *ProviderRegistry) Capabilities(provider domain.ProviderType) ProviderCapabilities {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.capabilities[provider]
+}
+
 // CreateSyncer creates a syncer for the given calendar.
 func (r *ProviderRegistry) CreateSyncer(ctx context.Context, calendar *domain.ConnectedCalendar) (Syncer, error) {
 	r.mu.RLock()
@@ -132,24 +150,42 @@ func (r *ProviderRegistry) SupportedProviders() []domain.ProviderType {
 }
 
 // MultiSyncResult aggregates sync results from multiple providers.
+// A provider's result sums those of all its calendars, which may belong to
+// different accounts; Calendars and CalendarErrors keep them apart.
 type MultiSyncResult struct {
 	Results  map[domain.ProviderType]*SyncResult
 	Errors   map[domain.ProviderType]error
 	Total    *SyncResult
+
+	Calendars      map[uuid.UUID]*SyncResult
+	CalendarErrors map[uuid.UUID]error
 }
 
 // NewMultiSyncResult creates a new multi-sync result.
 func NewMultiSyncResult() *MultiSyncResult {
 	return &MultiSyncResult{
-		Results: make(map[domain.ProviderType]*SyncResult),
-		Errors:  make(map[domain.ProviderType]error),
-		Total:   &SyncResult{},
+		Results:        make(map[domain.ProviderType]*SyncResult),
+		Errors:         make(map[domain.ProviderType]error),
+		Total:          &SyncResult{},
+		Calendars:      make(map[uuid.UUID]*SyncResult),
+		CalendarErrors: make(map[uuid.UUID]error),
 	}
 }
 
-// AddResult adds a provider's sync result.
+// AddResult adds a provider's sync result. Results added for the same
+// provider are summed.
 func (m *MultiSyncResult) AddResult(provider domain.ProviderType, result *SyncResult) {
-	m.Results[provider] = result
+	existing, ok := m.Results[provider]
+	if !ok || existing == nil {
+		m.Results[provider] = result
+	} else if result != nil {
+		m.Results[provider] = &SyncResult{
+			Created: existing.Created + result.Created,
+			Updated: existing.Updated + result.Updated,
+			Deleted: existing.Deleted + result.Deleted,
+			Failed:  existing.Failed + result.Failed,
+		}
+	}
 	if result != nil {
 		m.Total.Created += result.Created
 		m.Total.Updated += result.Updated
@@ -158,9 +194,24 @@ func (m *MultiSyncResult) AddResult(provider domain.ProviderType, result *SyncRe
 	}
 }
 
-// AddError adds a provider's error.
+// AddError adds a provider's error. Errors added for the same provider are
+// joined.
 func (m *MultiSyncResult) AddError(provider domain.ProviderType, err error) {
-	m.Errors[provider] = err
+	m.Errors[provider] = errors.Join(m.Errors[provider], err)
+}
+
+// AddCalendarResult adds a calendar's sync result, also counting it for the
+// calendar's provider.
+func (m *MultiSyncResult) AddCalendarResult(cal *domain.ConnectedCalendar, result *SyncResult) {
+	m.Calendars[cal.ID()] = result
+	m.AddResult(cal.Provider(), result)
+}
+
+// AddCalendarError adds a calendar's error, also recording it for the
+// calendar's provider.
+func (m *MultiSyncResult) AddCalendarError(cal *domain.ConnectedCalendar, err error) {
+	m.CalendarErrors[cal.ID()] = errors.Join(m.CalendarErrors[cal.ID()], err)
+	m.AddError(cal.Provider(), err)
 }
 
 // HasErrors returns true if any provider had an error.
@@ -191,6 +242,9 @@ func NewSyncCoordinator(
 }
 
 // SyncAll syncs blocks to all enabled push calendars for a user.
+// Each calendar only receives the block fields its provider supports, and
+// is synced with the tokens of the account it belongs to, so calendars of
+// several accounts of one provider are synced independently.
 func (c *SyncCoordinator) SyncAll(ctx context.Context, userID uuid.UUID, blocks []TimeBlock) (*MultiSyncResult, error) {
 	calendars, err := c.calendarRepo.FindEnabledPushCalendars(ctx, userID)
 	if err != nil {
@@ -202,21 +256,22 @@ func (c *SyncCoordinator) SyncAll(ctx context.Context, userID uuid.UUID, blocks
 	for _, cal := range calendars {
 		syncer, err := c.registry.CreateSyncer(ctx, cal)
 		if err != nil {
-			result.AddError(cal.Provider(), fmt.Errorf("failed to create syncer: %w", err))
+			result.AddCalendarError(cal, fmt.Errorf("failed to create syncer: %w", err))
 			continue
 		}
 
-		syncResult, err := syncer.Sync(ctx, userID, blocks)
+		calBlocks := c.registry.Capabilities(cal.Provider()).adaptBlocks(blocks)
+		syncResult, err := syncer.Sync(ctx, userID, calBlocks)
 		if err != nil {
-			result.AddError(cal.Provider(), err)
+			result.AddCalendarError(cal, err)
 			continue
 		}
 
-		result.AddResult(cal.Provider(), syncResult)
+		result.AddCalendarResult(cal, syncResult)
 		cal.MarkSyncedSimple()
 		if saveErr := c.calendarRepo.Save(ctx, cal); saveErr != nil {
 			// Log but don't fail the sync
-			result.AddError(cal.Provider(), fmt.Errorf("sync succeeded but failed to update last sync time: %w", saveErr))
+			result.AddCalendarError(cal, fmt.Errorf("sync succeeded but failed to update last sync time: %w", saveErr))
 		}
 	}
 
@@ -252,7 +307,7 @@ func (c *SyncCoordinator) SyncToProvider(ctx context.Context, userID uuid.UUID,
 		return nil, fmt.Errorf("failed to create syncer: %w", err)
 	}
 
-	result, err := syncer.Sync(ctx, userID, blocks)
+	result, err := syncer.Sync(ctx, userID, c.registry.Capabilities(provider).adaptBlocks(blocks))
 	if err != nil {
 		return nil, err
 	}
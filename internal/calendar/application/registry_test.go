@@ -15,11 +15,13 @@ import (
 
 // Mock implementations
 type mockSyncer struct {
-	result *application.SyncResult
-	err    error
+	result   *application.SyncResult
+	err      error
+	received []application.TimeBlock
 }
 
 func (m *mockSyncer) Sync(ctx context.Context, userID uuid.UUID, blocks []application.TimeBlock) (*application.SyncResult, error) {
+	m.received = blocks
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -274,6 +276,14 @@ func TestProviderRegistry_SupportedProviders(t *testing.T) {
 	assert.Contains(t, providers, domain.ProviderMicrosoft)
 }
 
+func TestProviderRegistry_Capabilities(t *testing.T) {
+	registry := application.NewProviderRegistry()
+	registry.RegisterCapabilities(domain.ProviderGoogle, application.ProviderCapabilities{Colors: true, Reminders: true})
+
+	assert.True(t, registry.Capabilities(domain.ProviderGoogle).Colors)
+	assert.Equal(t, application.ProviderCapabilities{}, registry.Capabilities(domain.ProviderCalDAV))
+}
+
 func TestMultiSyncResult(t *testing.T) {
 	result := application.NewMultiSyncResult()
 
@@ -396,6 +406,52 @@ func TestSyncCoordinator_SyncAll_SyncerError(t *testing.T) {
 	assert.Equal(t, 0, result.SuccessCount())
 }
 
+func TestSyncCoordinator_SyncAll_AdaptsBlocksToCapabilities(t *testing.T) {
+	registry := application.NewProviderRegistry()
+	userID := uuid.New()
+
+	googleCal, err := domain.NewConnectedCalendar(userID, domain.ProviderGoogle, "primary", "Personal")
+	require.NoError(t, err)
+	caldavCal, err := domain.NewConnectedCalendar(userID, domain.ProviderCalDAV, "cal", "Work")
+	require.NoError(t, err)
+
+	repo := &mockCalendarRepo{
+		pushCalendars: []*domain.ConnectedCalendar{googleCal, caldavCal},
+	}
+
+	googleSyncer := &mockSyncer{result: &application.SyncResult{Created: 1}}
+	caldavSyncer := &mockSyncer{result: &application.SyncResult{Created: 1}}
+	registry.RegisterSyncer(domain.ProviderGoogle, func(ctx context.Context, c *domain.ConnectedCalendar) (application.Syncer, error) {
+		return googleSyncer, nil
+	})
+	registry.RegisterSyncer(domain.ProviderCalDAV, func(ctx context.Context, c *domain.ConnectedCalendar) (application.Syncer, error) {
+		return caldavSyncer, nil
+	})
+	registry.RegisterCapabilities(domain.ProviderGoogle, application.ProviderCapabilities{DeleteEvents: true, Reminders: true, Colors: true})
+	registry.RegisterCapabilities(domain.ProviderCalDAV, application.ProviderCapabilities{DeleteEvents: true})
+
+	blocks := []application.TimeBlock{
+		{ID: uuid.New(), Title: "Focus", Color: "#4CAF50", Reminders: []int{10}},
+	}
+
+	coordinator := application.NewSyncCoordinator(registry, repo)
+	result, err := coordinator.SyncAll(context.Background(), userID, blocks)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.SuccessCount())
+
+	require.Len(t, googleSyncer.received, 1)
+	assert.Equal(t, "#4CAF50", googleSyncer.received[0].Color)
+	assert.Equal(t, []int{10}, googleSyncer.received[0].Reminders)
+
+	require.Len(t, caldavSyncer.received, 1)
+	assert.Empty(t, caldavSyncer.received[0].Color)
+	assert.Nil(t, caldavSyncer.received[0].Reminders)
+	assert.Equal(t, "Focus", caldavSyncer.received[0].Title)
+
+	// The caller's blocks are not modified.
+	assert.Equal(t, "#4CAF50", blocks[0].Color)
+}
+
 func TestSyncCoordinator_SyncToProvider(t *testing.T) {
 	registry := application.NewProviderRegistry()
 	userID := uuid.New()
@@ -784,3 +840,59 @@ func TestSyncCoordinator_GetImporterForProvider_CreateImporterError(t *testing.T
 	assert.Nil(t, importer)
 	assert.Nil(t, resultCal)
 }
+
+func TestSyncCoordinator_SyncAll_AccountsSyncIndependently(t *testing.T) {
+	registry := application.NewProviderRegistry()
+	userID := uuid.New()
+
+	work, err := domain.NewConnectedCalendar(userID, domain.ProviderGoogle, "primary", "Work")
+	require.NoError(t, err)
+	work.SetAccount("work")
+	personal, err := domain.NewConnectedCalendar(userID, domain.ProviderGoogle, "primary", "Personal")
+	require.NoError(t, err)
+	personal.SetAccount("personal")
+
+	repo := &mockCalendarRepo{
+		pushCalendars: []*domain.ConnectedCalendar{work, personal},
+	}
+
+	syncers := map[string]*mockSyncer{
+		"work":     {err: errors.New("work token revoked")},
+		"personal": {result: &application.SyncResult{Created: 2}},
+	}
+	registry.RegisterSyncer(domain.ProviderGoogle, func(ctx context.Context, c *domain.ConnectedCalendar) (application.Syncer, error) {
+		return syncers[c.Account()], nil
+	})
+
+	blocks := []application.TimeBlock{{ID: uuid.New(), Title: "Focus"}}
+
+	coordinator := application.NewSyncCoordinator(registry, repo)
+	result, err := coordinator.SyncAll(context.Background(), userID, blocks)
+	require.NoError(t, err)
+
+	// The failing account does not stop the other from syncing.
+	assert.Len(t, syncers["work"].received, 1)
+	assert.Len(t, syncers["personal"].received, 1)
+	assert.Equal(t, 2, result.Calendars[personal.ID()].Created)
+	assert.NotContains(t, result.Calendars, work.ID())
+	assert.ErrorContains(t, result.CalendarErrors[work.ID()], "work token revoked")
+	assert.NotContains(t, result.CalendarErrors, personal.ID())
+	assert.Equal(t, []*domain.ConnectedCalendar{personal}, repo.savedCalendars)
+}
+
+func TestMultiSyncResult_SumsCalendarsOfOneProvider(t *testing.T) {
+	userID := uuid.New()
+	work, err := domain.NewConnectedCalendar(userID, domain.ProviderGoogle, "primary", "Work")
+	require.NoError(t, err)
+	personal, err := domain.NewConnectedCalendar(userID, domain.ProviderGoogle, "primary", "Personal")
+	require.NoError(t, err)
+
+	result := application.NewMultiSyncResult()
+	result.AddCalendarResult(work, &application.SyncResult{Created: 1, Updated: 2})
+	result.AddCalendarResult(personal, &application.SyncResult{Created: 3})
+
+	assert.Equal(t, &application.SyncResult{Created: 4, Updated: 2}, result.Results[domain.ProviderGoogle])
+	assert.Equal(t, 4, result.Total.Created)
+	assert.Equal(t, 1, result.Calendars[work.ID()].Created)
+	assert.Equal(t, 3, result.Calendars[personal.ID()].Created)
+}
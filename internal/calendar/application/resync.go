@@ -0,0 +1,162 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	"github.com/google/uuid"
+)
+
+// DefaultResyncInterval is the pause between pushes during a full resync,
+// keeping a large resync within provider rate limits.
+const DefaultResyncInterval = 250 * time.Millisecond
+
+// ResyncRequest describes a full resync of one calendar.
+type ResyncRequest struct {
+	UserID     uuid.UUID
+	CalendarID string
+	Provider   string
+	// Blocks are the blocks in the resync window. Blocks already pushed by
+	// an interrupted resync are skipped when it resumes.
+	Blocks []TimeBlock
+	// Restart discards the progress of an interrupted resync and starts over.
+	Restart bool
+}
+
+// ResyncSummary reports what a full resync did.
+type ResyncSummary struct {
+	Total int
+	// AlreadyPushed counts blocks skipped because an earlier, interrupted
+	// run had pushed them.
+	AlreadyPushed int
+	Pushed        int
+	Created       int
+	Updated       int
+	Failed        int
+	// Resumed is true when the run continued an interrupted resync.
+	Resumed bool
+	// Complete is true when every block has been pushed and the resync ended.
+	Complete  bool
+	StartedAt time.Time
+}
+
+// ResyncService clears a calendar's sync state and re-pushes every block,
+// one at a time, persisting progress after each push so that an interrupted
+// resync can resume.
+type ResyncService struct {
+	syncer   Syncer
+	states   domain.SyncStateRepository
+	interval time.Duration
+}
+
+// NewResyncService creates a new ResyncService.
+func NewResyncService(syncer Syncer, states domain.SyncStateRepository) *ResyncService {
+	return &ResyncService{
+		syncer:   syncer,
+		states:   states,
+		interval: DefaultResyncInterval,
+	}
+}
+
+// WithInterval sets the pause between pushes. Zero disables rate limiting.
+func (s *ResyncService) WithInterval(interval time.Duration) *ResyncService {
+	if interval >= 0 {
+		s.interval = interval
+	}
+	return s
+}
+
+// Resync re-pushes the requested blocks. It resumes an interrupted resync of
+// the same calendar unless the request asks to restart. When the run is
+// interrupted, the summary so far is returned along with the error.
+func (s *ResyncService) Resync(ctx context.Context, req ResyncRequest) (*ResyncSummary, error) {
+	state, err := s.states.FindByUserAndCalendar(ctx, req.UserID, req.CalendarID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sync state: %w", err)
+	}
+	if state == nil {
+		state = domain.NewSyncState(req.UserID, req.CalendarID, req.Provider)
+	}
+
+	summary := &ResyncSummary{Total: len(req.Blocks)}
+	if state.IsResyncing() && !req.Restart {
+		summary.Resumed = true
+	} else {
+		state.StartResync()
+		if err := s.states.Save(ctx, state); err != nil {
+			return nil, fmt.Errorf("failed to save sync state: %w", err)
+		}
+	}
+	progress := state.Resync()
+	summary.StartedAt = progress.StartedAt
+
+	pushes := 0
+	for i, block := range req.Blocks {
+		if progress.HasPushed(block.ID) {
+			summary.AlreadyPushed++
+			continue
+		}
+
+		if pushes > 0 {
+			if err := s.wait(ctx); err != nil {
+				return summary, s.interrupted(ctx, state, summary, i, err)
+			}
+		}
+		pushes++
+
+		result, err := s.syncer.Sync(ctx, req.UserID, []TimeBlock{block})
+		if err != nil {
+			return summary, s.interrupted(ctx, state, summary, i, err)
+		}
+		summary.Created += result.Created
+		summary.Updated += result.Updated
+		if result.Failed > 0 {
+			// Left unmarked so the next run retries it.
+			summary.Failed++
+			continue
+		}
+
+		state.MarkResynced(block.ID)
+		if err := s.states.Save(ctx, state); err != nil {
+			return summary, fmt.Errorf("failed to save resync progress: %w", err)
+		}
+		summary.Pushed++
+	}
+
+	if summary.Failed == 0 {
+		state.CompleteResync()
+		summary.Complete = true
+		if err := s.states.Save(ctx, state); err != nil {
+			return summary, fmt.Errorf("failed to save sync state: %w", err)
+		}
+	}
+
+	return summary, nil
+}
+
+// wait pauses for the configured interval or until the context is done.
+func (s *ResyncService) wait(ctx context.Context) error {
+	if s.interval == 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(s.interval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// interrupted records the failure on the sync state, keeping the progress so
+// far, and describes where the resync stopped.
+func (s *ResyncService) interrupted(ctx context.Context, state *domain.SyncState, summary *ResyncSummary, index int, cause error) error {
+	state.MarkSyncFailure(cause.Error())
+	if err := s.states.Save(context.WithoutCancel(ctx), state); err != nil {
+		return fmt.Errorf("failed to save resync progress: %w", err)
+	}
+	return fmt.Errorf("resync interrupted after %d of %d blocks: %w", index, summary.Total, cause)
+}
@@ -0,0 +1,223 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// snapshotSyncStateRepo keeps a copy of each saved state, so a later run only
+// sees what was persisted.
+type snapshotSyncStateRepo struct {
+	states map[string]*domain.SyncState
+}
+
+func newSnapshotSyncStateRepo() *snapshotSyncStateRepo {
+	return &snapshotSyncStateRepo{states: make(map[string]*domain.SyncState)}
+}
+
+func (r *snapshotSyncStateRepo) Save(ctx context.Context, state *domain.SyncState) error {
+	r.states[state.UserID().String()+state.CalendarID()] = copySyncState(state)
+	return nil
+}
+
+func (r *snapshotSyncStateRepo) FindByUserAndCalendar(ctx context.Context, userID uuid.UUID, calendarID string) (*domain.SyncState, error) {
+	state, ok := r.states[userID.String()+calendarID]
+	if !ok {
+		return nil, nil
+	}
+	return copySyncState(state), nil
+}
+
+func (r *snapshotSyncStateRepo) FindByUser(ctx context.Context, userID uuid.UUID) ([]*domain.SyncState, error) {
+	return nil, nil
+}
+
+func (r *snapshotSyncStateRepo) FindPendingSync(ctx context.Context, olderThan time.Duration, limit int) ([]*domain.SyncState, error) {
+	return nil, nil
+}
+
+func (r *snapshotSyncStateRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func copySyncState(state *domain.SyncState) *domain.SyncState {
+	copied := domain.RehydrateSyncState(
+		state.ID(), state.UserID(), state.CalendarID(), state.Provider(),
+		state.SyncToken(), state.LastSyncedAt(), state.LastSyncHash(),
+		state.SyncErrors(), state.LastError(), state.ImportedBlocks(),
+		state.CreatedAt(), state.UpdatedAt(),
+	)
+	copied.RehydrateResync(state.Resync())
+	return copied
+}
+
+// resyncSyncer records pushed blocks and fails as configured.
+type resyncSyncer struct {
+	pushed []uuid.UUID
+	// errAt makes the push of the block at this call index (1-based) fail.
+	errAt  int
+	failed map[uuid.UUID]bool
+	calls  int
+}
+
+func (s *resyncSyncer) Sync(ctx context.Context, userID uuid.UUID, blocks []TimeBlock) (*SyncResult, error) {
+	s.calls++
+	if s.calls == s.errAt {
+		return nil, errors.New("connection reset")
+	}
+	if s.failed[blocks[0].ID] {
+		return &SyncResult{Failed: 1}, nil
+	}
+	s.pushed = append(s.pushed, blocks[0].ID)
+	return &SyncResult{Created: 1}, nil
+}
+
+func resyncBlocks(n int) []TimeBlock {
+	blocks := make([]TimeBlock, n)
+	for i := range blocks {
+		blocks[i] = TimeBlock{ID: uuid.New(), Title: "Focus"}
+	}
+	return blocks
+}
+
+func TestResyncService_Resync(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("pushes every block and clears the sync state", func(t *testing.T) {
+		states := newSnapshotSyncStateRepo()
+		existing := domain.NewSyncState(userID, "primary", "google")
+		existing.MarkSyncSuccess("stale-token", "stale-hash")
+		require.NoError(t, states.Save(ctx, existing))
+
+		blocks := resyncBlocks(3)
+		syncer := &resyncSyncer{}
+		summary, err := NewResyncService(syncer, states).WithInterval(0).Resync(ctx, ResyncRequest{
+			UserID: userID, CalendarID: "primary", Provider: "google", Blocks: blocks,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ResyncSummary{Total: 3, Pushed: 3, Created: 3, Complete: true, StartedAt: summary.StartedAt}, *summary)
+		assert.Len(t, syncer.pushed, 3)
+
+		state, err := states.FindByUserAndCalendar(ctx, userID, "primary")
+		require.NoError(t, err)
+		assert.False(t, state.IsResyncing())
+		assert.Empty(t, state.SyncToken())
+		assert.Empty(t, state.LastSyncHash())
+	})
+
+	t.Run("resumes after an interruption without pushing blocks again", func(t *testing.T) {
+		states := newSnapshotSyncStateRepo()
+		blocks := resyncBlocks(5)
+		req := ResyncRequest{UserID: userID, CalendarID: "primary", Provider: "google", Blocks: blocks}
+
+		first := &resyncSyncer{errAt: 3}
+		summary, err := NewResyncService(first, states).WithInterval(0).Resync(ctx, req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resync interrupted after 2 of 5 blocks")
+		assert.Equal(t, 2, summary.Pushed)
+		assert.False(t, summary.Complete)
+
+		state, err := states.FindByUserAndCalendar(ctx, userID, "primary")
+		require.NoError(t, err)
+		require.True(t, state.IsResyncing())
+		assert.Equal(t, []uuid.UUID{blocks[0].ID, blocks[1].ID}, state.Resync().Pushed)
+		assert.Equal(t, 1, state.SyncErrors())
+
+		second := &resyncSyncer{}
+		summary, err = NewResyncService(second, states).WithInterval(0).Resync(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, summary.Resumed)
+		assert.True(t, summary.Complete)
+		assert.Equal(t, 2, summary.AlreadyPushed)
+		assert.Equal(t, 3, summary.Pushed)
+		assert.Equal(t, []uuid.UUID{blocks[2].ID, blocks[3].ID, blocks[4].ID}, second.pushed)
+
+		state, err = states.FindByUserAndCalendar(ctx, userID, "primary")
+		require.NoError(t, err)
+		assert.False(t, state.IsResyncing())
+		assert.Zero(t, state.SyncErrors())
+	})
+
+	t.Run("resumes after the context is cancelled mid-resync", func(t *testing.T) {
+		states := newSnapshotSyncStateRepo()
+		blocks := resyncBlocks(4)
+		req := ResyncRequest{UserID: userID, CalendarID: "primary", Provider: "google", Blocks: blocks}
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		syncer := &cancellingSyncer{cancelAfter: 2, cancel: cancel}
+		_, err := NewResyncService(syncer, states).WithInterval(time.Millisecond).Resync(cancelCtx, req)
+		require.ErrorIs(t, err, context.Canceled)
+
+		state, err := states.FindByUserAndCalendar(ctx, userID, "primary")
+		require.NoError(t, err)
+		require.True(t, state.IsResyncing())
+		assert.Len(t, state.Resync().Pushed, 2)
+
+		second := &resyncSyncer{}
+		summary, err := NewResyncService(second, states).WithInterval(0).Resync(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{blocks[2].ID, blocks[3].ID}, second.pushed)
+		assert.True(t, summary.Complete)
+	})
+
+	t.Run("restart discards earlier progress", func(t *testing.T) {
+		states := newSnapshotSyncStateRepo()
+		blocks := resyncBlocks(3)
+		req := ResyncRequest{UserID: userID, CalendarID: "primary", Provider: "google", Blocks: blocks}
+
+		_, err := NewResyncService(&resyncSyncer{errAt: 2}, states).WithInterval(0).Resync(ctx, req)
+		require.Error(t, err)
+
+		req.Restart = true
+		syncer := &resyncSyncer{}
+		summary, err := NewResyncService(syncer, states).WithInterval(0).Resync(ctx, req)
+		require.NoError(t, err)
+		assert.False(t, summary.Resumed)
+		assert.Zero(t, summary.AlreadyPushed)
+		assert.Len(t, syncer.pushed, 3)
+	})
+
+	t.Run("failed blocks keep the resync open for a retry", func(t *testing.T) {
+		states := newSnapshotSyncStateRepo()
+		blocks := resyncBlocks(3)
+		req := ResyncRequest{UserID: userID, CalendarID: "primary", Provider: "google", Blocks: blocks}
+
+		syncer := &resyncSyncer{failed: map[uuid.UUID]bool{blocks[1].ID: true}}
+		summary, err := NewResyncService(syncer, states).WithInterval(0).Resync(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.Failed)
+		assert.Equal(t, 2, summary.Pushed)
+		assert.False(t, summary.Complete)
+
+		retry := &resyncSyncer{}
+		summary, err = NewResyncService(retry, states).WithInterval(0).Resync(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, summary.Complete)
+		assert.Equal(t, []uuid.UUID{blocks[1].ID}, retry.pushed)
+	})
+}
+
+// cancellingSyncer cancels the context after a number of successful pushes,
+// as an interrupt would.
+type cancellingSyncer struct {
+	cancelAfter int
+	cancel      context.CancelFunc
+	pushed      int
+}
+
+func (s *cancellingSyncer) Sync(ctx context.Context, userID uuid.UUID, blocks []TimeBlock) (*SyncResult, error) {
+	s.pushed++
+	if s.pushed == s.cancelAfter {
+		s.cancel()
+	}
+	return &SyncResult{Updated: 1}, nil
+}
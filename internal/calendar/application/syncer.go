@@ -2,6 +2,8 @@ package application
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,42 @@ type TimeBlock struct {
 	EndTime   time.Time
 	Completed bool
 	Missed    bool
+	// Reminders overrides the syncer's reminder minutes for this block.
+	// Nil falls back to the syncer configuration; empty means no reminders.
+	Reminders []int
+	// Color is the event color as a hex value (#RRGGBB). Empty leaves the
+	// calendar's default color.
+	Color string
+	// CreatedAt and UpdatedAt track the block's revisions, so exports can
+	// tell clients when an event changed.
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Attachments are links or file paths referenced by the block.
+	Attachments []Attachment
+}
+
+// Attachment is a link or file path attached to a block.
+type Attachment struct {
+	Target string
+	Label  string
+}
+
+// AttachmentsDescription renders attachments as a section for an event
+// description. It is empty when there are no attachments.
+func AttachmentsDescription(attachments []Attachment) string {
+	if len(attachments) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n\nAttachments:")
+	for _, a := range attachments {
+		if a.Label != "" {
+			fmt.Fprintf(&b, "\n- %s: %s", a.Label, a.Target)
+		} else {
+			fmt.Fprintf(&b, "\n- %s", a.Target)
+		}
+	}
+	return b.String()
 }
 
 // SyncResult describes the outcome of a sync run.
@@ -36,10 +74,16 @@ type CalendarEvent struct {
 	EndTime     time.Time
 	IsAllDay    bool
 	IsRecurring bool
+	// RecurringEventID identifies the series a recurring occurrence belongs
+	// to. It is the same for every occurrence of the series.
+	RecurringEventID string
 	Organizer   string
 	Attendees   []string
 	Status      string // confirmed, tentative, cancelled
 	IsOrbitaEvent bool  // true if this event was created by Orbita
+	// CalendarID is the calendar the event was read from. It is empty when
+	// the source calendar is unknown.
+	CalendarID string
 }
 
 // ImportResult describes the outcome of an import run.
@@ -3,6 +3,7 @@ package workers
 import (
 	"context"
 	"log/slog"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -17,31 +18,79 @@ const DefaultImportInterval = 5 * time.Minute
 // DefaultLookAheadDays is how far ahead to look for events.
 const DefaultLookAheadDays = 7
 
-// DefaultMaxSyncErrors is the maximum number of consecutive sync errors before giving up.
+// DefaultMaxSyncErrors is the number of consecutive failed syncs after which
+// the worker logs a calendar as persistently failing. It keeps being retried
+// at the backed-off interval.
 const DefaultMaxSyncErrors = 5
 
+// DefaultMaxBackoffInterval caps how long a failing calendar waits before its
+// next sync attempt.
+const DefaultMaxBackoffInterval = time.Hour
+
+// DefaultConflictConcurrency is how many events have their conflicts handled
+// at once unless configured otherwise.
+const DefaultConflictConcurrency = 4
+
 // ConflictHandler handles conflicts between external events and Orbita blocks.
+// The worker calls it for several events at once when ConflictConcurrency is
+// above one, so implementations must serialize work on the same block.
 type ConflictHandler interface {
 	HandleConflict(ctx context.Context, external application.CalendarEvent, existing interface{}) error
 }
 
+// MeetingSeriesImporter turns recurring external event series into meetings.
+// Implementations must be idempotent: importing a series that is already
+// linked to a meeting reports created as false and changes nothing.
+type MeetingSeriesImporter interface {
+	ImportSeries(ctx context.Context, userID uuid.UUID, series application.RecurringSeries) (created bool, err error)
+}
+
+// EventBlockImporter turns external events into schedule blocks. The worker
+// keys blocks by external event ID in the sync state, so an event imported
+// again updates its block instead of creating another one.
+type EventBlockImporter interface {
+	// ImportEventBlock creates a block for the event, or updates the block
+	// imported for it earlier when existing is non-nil, and returns the block
+	// now linked to the event.
+	ImportEventBlock(ctx context.Context, userID uuid.UUID, event application.CalendarEvent, existing *domain.ImportedBlock) (domain.ImportedBlock, error)
+	// RemoveEventBlock removes the block imported for an event that was
+	// deleted from the external calendar.
+	RemoveEventBlock(ctx context.Context, userID uuid.UUID, block domain.ImportedBlock) error
+}
+
 // CalendarImportWorkerConfig configures the import worker.
 type CalendarImportWorkerConfig struct {
-	Interval       time.Duration
-	LookAheadDays  int
-	MaxSyncErrors  int
-	BatchSize      int
+	Interval         time.Duration
+	LookAheadDays    int
+	MaxSyncErrors    int
+	BatchSize        int
 	SkipOrbitaEvents bool
+	// MaxBackoffInterval caps the exponential backoff applied to Interval
+	// for a calendar after consecutive failed syncs. Zero uses
+	// DefaultMaxBackoffInterval.
+	MaxBackoffInterval time.Duration
+	// ImportRecurringMeetings creates a meeting for each recurring external
+	// event series found during import. It requires a MeetingSeriesImporter.
+	ImportRecurringMeetings bool
+	// ImportEventBlocks creates a schedule block for each external event
+	// found during import. It requires an EventBlockImporter.
+	ImportEventBlocks bool
+	// ConflictConcurrency is how many events of an import have their
+	// conflicts handled at once. Zero or one handles them one at a time.
+	ConflictConcurrency int
 }
 
 // DefaultImportWorkerConfig returns the default configuration.
 func DefaultImportWorkerConfig() CalendarImportWorkerConfig {
 	return CalendarImportWorkerConfig{
-		Interval:         DefaultImportInterval,
-		LookAheadDays:    DefaultLookAheadDays,
-		MaxSyncErrors:    DefaultMaxSyncErrors,
-		BatchSize:        10,
-		SkipOrbitaEvents: true,
+		Interval:           DefaultImportInterval,
+		LookAheadDays:      DefaultLookAheadDays,
+		MaxSyncErrors:      DefaultMaxSyncErrors,
+		BatchSize:          10,
+		SkipOrbitaEvents:   true,
+		MaxBackoffInterval: DefaultMaxBackoffInterval,
+
+		ConflictConcurrency: DefaultConflictConcurrency,
 	}
 }
 
@@ -50,6 +99,8 @@ type CalendarImportWorker struct {
 	importer        application.Importer
 	syncStateRepo   domain.SyncStateRepository
 	conflictHandler ConflictHandler
+	meetingImporter MeetingSeriesImporter
+	blockImporter   EventBlockImporter
 	config          CalendarImportWorkerConfig
 	logger          *slog.Logger
 	running         atomic.Bool
@@ -77,6 +128,13 @@ func NewCalendarImportWorker(
 	}
 }
 
+// WithMeetingSeriesImporter sets the importer used to create meetings from
+// recurring external events when ImportRecurringMeetings is enabled.
+func (w *CalendarImportWorker) WithMeetingSeriesImporter(importer MeetingSeriesImporter) *CalendarImportWorker {
+	w.meetingImporter = importer
+	return w
+}
+
 // Run starts the worker and blocks until context is cancelled or Stop() is called.
 func (w *CalendarImportWorker) Run(ctx context.Context) error {
 	if w.importer == nil {
@@ -112,6 +170,53 @@ func (w *CalendarImportWorker) Run(ctx context.Context) error {
 	}
 }
 
+// RetryDelay returns how long a calendar waits before its next sync attempt
+// after syncErrors consecutive failures. The configured interval doubles with
+// each failure, up to MaxBackoffInterval.
+func (w *CalendarImportWorker) RetryDelay(syncErrors int) time.Duration {
+	maxInterval := w.config.MaxBackoffInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultMaxBackoffInterval
+	}
+	if maxInterval < w.config.Interval {
+		maxInterval = w.config.Interval
+	}
+
+	delay := w.config.Interval
+	for i := 1; i < syncErrors; i++ {
+		delay *= 2
+		if delay >= maxInterval {
+			return maxInterval
+		}
+	}
+	return delay
+}
+
+// recordSyncFailure marks the sync of a calendar as failed and backs that
+// calendar off, leaving other calendars on the normal interval.
+func (w *CalendarImportWorker) recordSyncFailure(ctx context.Context, state *domain.SyncState, err error) {
+	state.MarkSyncFailure(err.Error())
+	delay := w.RetryDelay(state.SyncErrors())
+	state.DeferNextAttempt(time.Now().Add(delay))
+
+	logArgs := []any{
+		"user_id", state.UserID(),
+		"calendar_id", state.CalendarID(),
+		"sync_errors", state.SyncErrors(),
+		"retry_in", delay,
+		"error", err,
+	}
+	if w.config.MaxSyncErrors > 0 && state.SyncErrors() >= w.config.MaxSyncErrors {
+		w.logger.Error("calendar sync keeps failing, backing off", logArgs...)
+	} else {
+		w.logger.Warn("calendar sync failed, backing off", logArgs...)
+	}
+
+	if saveErr := w.syncStateRepo.Save(ctx, state); saveErr != nil {
+		w.logger.Error("failed to save sync state", "error", saveErr)
+	}
+}
+
 // Stop signals the worker to stop gracefully.
 func (w *CalendarImportWorker) Stop() {
 	if w.running.Load() {
@@ -124,7 +229,16 @@ func (w *CalendarImportWorker) IsRunning() bool {
 	return w.running.Load()
 }
 
+// WithEventBlockImporter sets the importer used to create schedule blocks for
+// external events when ImportEventBlocks is enabled.
+func (w *CalendarImportWorker) WithEventBlockImporter(importer EventBlockImporter) *CalendarImportWorker {
+	w.blockImporter = importer
+	return w
+}
+
 // runImportCycle runs a single import cycle for all users needing sync.
+// Calendars that are backing off after failures are not returned by
+// FindPendingSync until their next attempt is due.
 func (w *CalendarImportWorker) runImportCycle(ctx context.Context) {
 	w.logger.Debug("starting import cycle")
 
@@ -153,7 +267,8 @@ func (w *CalendarImportWorker) runImportCycle(ctx context.Context) {
 }
 
 // importForUser imports events for a specific user's calendar.
-func (w *CalendarImportWorker) importForUser(ctx context.Context, state *domain.SyncState) {
+// It returns false if events could not be fetched or the sync state not saved.
+func (w *CalendarImportWorker) importForUser(ctx context.Context, state *domain.SyncState) bool {
 	w.logger.Debug("importing events for user",
 		"user_id", state.UserID(),
 		"calendar_id", state.CalendarID(),
@@ -166,18 +281,12 @@ func (w *CalendarImportWorker) importForUser(ctx context.Context, state *domain.
 	// Fetch events from external calendar
 	events, err := w.importer.ListEvents(ctx, state.UserID(), start, end, !w.config.SkipOrbitaEvents)
 	if err != nil {
-		w.logger.Error("failed to list events from calendar",
-			"user_id", state.UserID(),
-			"calendar_id", state.CalendarID(),
-			"error", err,
-		)
-		state.MarkSyncFailure(err.Error())
-		if saveErr := w.syncStateRepo.Save(ctx, state); saveErr != nil {
-			w.logger.Error("failed to save sync state", "error", saveErr)
-		}
-		return
+		w.recordSyncFailure(ctx, state, err)
+		return false
 	}
 
+	withSourceCalendar(events, state.CalendarID())
+
 	w.logger.Debug("fetched events from calendar",
 		"user_id", state.UserID(),
 		"event_count", len(events),
@@ -185,28 +294,34 @@ func (w *CalendarImportWorker) importForUser(ctx context.Context, state *domain.
 
 	// Process events
 	imported, skipped, conflicts := 0, 0, 0
+	candidates := make([]application.CalendarEvent, 0, len(events))
 	for _, event := range events {
 		if event.IsOrbitaEvent && w.config.SkipOrbitaEvents {
 			skipped++
 			continue
 		}
+		candidates = append(candidates, event)
+	}
 
-		// TODO: Check for conflicts with existing blocks
-		// For now, just count external events
-		if w.conflictHandler != nil {
-			if err := w.conflictHandler.HandleConflict(ctx, event, nil); err != nil {
-				w.logger.Warn("conflict detected for event",
-					"event_id", event.ID,
-					"event_summary", event.Summary,
-				)
-				conflicts++
-				continue
-			}
+	conflictErrs := w.handleConflicts(ctx, candidates)
+	accepted := make([]application.CalendarEvent, 0, len(candidates))
+	for i, event := range candidates {
+		if conflictErrs[i] != nil {
+			w.logger.Warn("conflict detected for event",
+				"event_id", event.ID,
+				"event_summary", event.Summary,
+			)
+			conflicts++
+			continue
 		}
 
 		imported++
+		accepted = append(accepted, event)
 	}
 
+	blocksImported, blocksRemoved := w.importEventBlocks(ctx, state, events, accepted, start, end)
+	meetingsCreated := w.importRecurringMeetings(ctx, state.UserID(), events)
+
 	// Calculate sync hash for change detection
 	syncHash := calculateSyncHash(events)
 
@@ -214,7 +329,7 @@ func (w *CalendarImportWorker) importForUser(ctx context.Context, state *domain.
 	state.MarkSyncSuccess("", syncHash)
 	if err := w.syncStateRepo.Save(ctx, state); err != nil {
 		w.logger.Error("failed to save sync state", "error", err)
-		return
+		return false
 	}
 
 	w.logger.Info("import completed for user",
@@ -222,7 +337,136 @@ func (w *CalendarImportWorker) importForUser(ctx context.Context, state *domain.
 		"imported", imported,
 		"skipped", skipped,
 		"conflicts", conflicts,
+		"blocks_imported", blocksImported,
+		"blocks_removed", blocksRemoved,
+		"meetings_created", meetingsCreated,
 	)
+	return true
+}
+
+// handleConflicts runs the conflict handler for each event, at most
+// ConflictConcurrency at a time, and returns the handler's error for each
+// event in order. Without a conflict handler every event is accepted.
+func (w *CalendarImportWorker) handleConflicts(ctx context.Context, events []application.CalendarEvent) []error {
+	errs := make([]error, len(events))
+	if w.conflictHandler == nil {
+		return errs
+	}
+
+	limit := w.config.ConflictConcurrency
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, event := range events {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = w.conflictHandler.HandleConflict(ctx, event, nil)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// importEventBlocks creates or updates the block for each accepted external
+// event and removes blocks whose event is no longer listed. Links between
+// events and blocks are recorded in the sync state. Failures are logged and
+// do not fail the import.
+func (w *CalendarImportWorker) importEventBlocks(ctx context.Context, state *domain.SyncState, events, accepted []application.CalendarEvent, start, end time.Time) (imported, removed int) {
+	if !w.importsEventBlocks() {
+		return 0, 0
+	}
+
+	seen := make(map[string]bool, len(events))
+	for _, event := range events {
+		seen[event.ID] = true
+	}
+
+	for _, event := range accepted {
+		if event.IsOrbitaEvent {
+			continue
+		}
+
+		var existing *domain.ImportedBlock
+		if block, ok := state.ImportedBlockFor(event.ID); ok {
+			existing = &block
+		}
+
+		block, err := w.blockImporter.ImportEventBlock(ctx, state.UserID(), event, existing)
+		if err != nil {
+			w.logger.Warn("failed to import event block",
+				"user_id", state.UserID(),
+				"event_id", event.ID,
+				"error", err,
+			)
+			continue
+		}
+		state.LinkImportedBlock(event.ID, block)
+		imported++
+	}
+
+	for eventID, block := range state.ImportedBlocks() {
+		if seen[eventID] {
+			continue
+		}
+		// Events outside the look-ahead window were not listed, so only
+		// their link is dropped; their blocks are history, not deletions.
+		if block.Start.Before(start) || !block.Start.Before(end) {
+			state.UnlinkImportedBlock(eventID)
+			continue
+		}
+
+		if err := w.blockImporter.RemoveEventBlock(ctx, state.UserID(), block); err != nil {
+			w.logger.Warn("failed to remove block for deleted event",
+				"user_id", state.UserID(),
+				"event_id", eventID,
+				"block_id", block.BlockID,
+				"error", err,
+			)
+			continue
+		}
+		state.UnlinkImportedBlock(eventID)
+		removed++
+	}
+
+	return imported, removed
+}
+
+// importRecurringMeetings creates meetings for recurring event series that are
+// not linked to one yet and returns how many were created. Failures are
+// logged and do not fail the import.
+func (w *CalendarImportWorker) importRecurringMeetings(ctx context.Context, userID uuid.UUID, events []application.CalendarEvent) int {
+	if !w.config.ImportRecurringMeetings || w.meetingImporter == nil {
+		return 0
+	}
+
+	created := 0
+	for _, series := range application.DetectRecurringSeries(events) {
+		ok, err := w.meetingImporter.ImportSeries(ctx, userID, series)
+		if err != nil {
+			w.logger.Warn("failed to import recurring event series as meeting",
+				"user_id", userID,
+				"series_id", series.ID,
+				"error", err,
+			)
+			continue
+		}
+		if ok {
+			created++
+			w.logger.Info("created meeting from recurring event series",
+				"user_id", userID,
+				"series_id", series.ID,
+				"cadence", series.Cadence,
+			)
+		}
+	}
+	return created
 }
 
 // InitializeSyncState creates a sync state for a user if it doesn't exist.
@@ -282,3 +526,13 @@ func calculateSyncHash(events []application.CalendarEvent) string {
 	}
 	return hash
 }
+
+// withSourceCalendar records the calendar events were read from, so conflicts
+// they raise can be resolved with that calendar's strategy.
+func withSourceCalendar(events []application.CalendarEvent, calendarID string) {
+	for i := range events {
+		if events[i].CalendarID == "" {
+			events[i].CalendarID = calendarID
+		}
+	}
+}
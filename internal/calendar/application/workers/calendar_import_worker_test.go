@@ -3,6 +3,8 @@ package workers
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -92,12 +94,14 @@ func (m *mockSyncStateRepo) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 type mockConflictHandler struct {
-	err   error
-	calls int
+	err       error
+	calls     int
+	calendars []string
 }
 
 func (m *mockConflictHandler) HandleConflict(ctx context.Context, external application.CalendarEvent, existing interface{}) error {
 	m.calls++
+	m.calendars = append(m.calendars, external.CalendarID)
 	return m.err
 }
 
@@ -732,8 +736,9 @@ func TestCalendarImportWorker_ImportForUser_ConflictHandlerSuccess(t *testing.T)
 	ctx := context.Background()
 	worker.runImportCycle(ctx)
 
-	// Conflict handler should have been called
+	// Conflict handler should have been called with the event's source calendar
 	assert.Equal(t, 1, conflictHandler.calls)
+	assert.Equal(t, []string{calendarID}, conflictHandler.calendars)
 
 	// Sync state should have been saved with success (no errors)
 	require.Len(t, repo.savedStates, 1)
@@ -812,3 +817,408 @@ func (m *contextCancellingImporter) ListEvents(ctx context.Context, userID uuid.
 func (m *contextCancellingImporter) ListCalendars(ctx context.Context, userID uuid.UUID) ([]application.Calendar, error) {
 	return nil, nil
 }
+
+func TestCalendarImportWorker_RetryDelay(t *testing.T) {
+	config := DefaultImportWorkerConfig()
+	config.Interval = time.Minute
+	config.MaxBackoffInterval = 10 * time.Minute
+	worker := NewCalendarImportWorker(&mockImporter{}, &mockSyncStateRepo{}, nil, config, nil)
+
+	expected := []time.Duration{
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		8 * time.Minute,
+		10 * time.Minute, // capped
+		10 * time.Minute, // keeps retrying past MaxSyncErrors
+	}
+	for i, want := range expected {
+		assert.Equal(t, want, worker.RetryDelay(i+1), "after failure %d", i+1)
+	}
+}
+
+func TestCalendarImportWorker_BacksOffFailingCalendar(t *testing.T) {
+	state := domain.NewSyncState(uuid.New(), "primary", "google")
+	repo := &mockSyncStateRepo{pendingStates: []*domain.SyncState{state}}
+	config := DefaultImportWorkerConfig()
+	config.Interval = time.Minute
+
+	worker := NewCalendarImportWorker(&mockImporter{err: errors.New("provider unavailable")}, repo, nil, config, nil)
+	ctx := context.Background()
+
+	worker.runImportCycle(ctx)
+	worker.runImportCycle(ctx)
+
+	assert.Equal(t, 2, state.SyncErrors())
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), state.NextAttemptAt(), 5*time.Second)
+	assert.False(t, state.IsDue(time.Now()))
+	require.NotEmpty(t, repo.savedStates)
+	assert.Equal(t, state.NextAttemptAt(), repo.savedStates[len(repo.savedStates)-1].NextAttemptAt())
+}
+
+func TestCalendarImportWorker_RetriesCalendarPastMaxSyncErrors(t *testing.T) {
+	state := domain.NewSyncState(uuid.New(), "primary", "google")
+	for i := 0; i < DefaultMaxSyncErrors+2; i++ {
+		state.MarkSyncFailure("provider unavailable")
+	}
+	// The calendar's backoff has expired
+	state.DeferNextAttempt(time.Now().Add(-time.Second))
+	require.True(t, state.IsDue(time.Now()))
+
+	importer := &mockImporter{}
+	repo := &mockSyncStateRepo{pendingStates: []*domain.SyncState{state}}
+	worker := NewCalendarImportWorker(importer, repo, nil, DefaultImportWorkerConfig(), nil)
+	worker.runImportCycle(context.Background())
+
+	assert.Len(t, importer.calls, 1)
+	assert.Zero(t, state.SyncErrors())
+	assert.True(t, state.NextAttemptAt().IsZero())
+}
+
+func TestCalendarImportWorker_FailingCalendarDoesNotDelayOthers(t *testing.T) {
+	failing := domain.NewSyncState(uuid.New(), "primary", "google")
+	healthy := domain.NewSyncState(uuid.New(), "primary", "google")
+
+	importer := &perUserImporter{failFor: failing.UserID()}
+	repo := &mockSyncStateRepo{pendingStates: []*domain.SyncState{failing, healthy}}
+	config := DefaultImportWorkerConfig()
+	config.Interval = time.Minute
+
+	worker := NewCalendarImportWorker(importer, repo, nil, config, nil)
+	worker.runImportCycle(context.Background())
+
+	assert.False(t, failing.IsDue(time.Now()))
+	assert.True(t, healthy.IsDue(time.Now()))
+	assert.True(t, healthy.NextAttemptAt().IsZero())
+}
+
+type perUserImporter struct {
+	failFor uuid.UUID
+}
+
+func (p *perUserImporter) ListEvents(ctx context.Context, userID uuid.UUID, start, end time.Time, includeOrbitaEvents bool) ([]application.CalendarEvent, error) {
+	if userID == p.failFor {
+		return nil, errors.New("provider unavailable")
+	}
+	return nil, nil
+}
+
+func (p *perUserImporter) ListCalendars(ctx context.Context, userID uuid.UUID) ([]application.Calendar, error) {
+	return nil, nil
+}
+
+// fakeMeetingSeriesImporter records created meetings keyed by series ID and,
+// like the real importer, does nothing for series it has already seen.
+type fakeMeetingSeriesImporter struct {
+	meetings map[string]application.RecurringSeries
+	calls    int
+	err      error
+}
+
+func (f *fakeMeetingSeriesImporter) ImportSeries(ctx context.Context, userID uuid.UUID, series application.RecurringSeries) (bool, error) {
+	f.calls++
+	if f.err != nil {
+		return false, f.err
+	}
+	if _, ok := f.meetings[series.ID]; ok {
+		return false, nil
+	}
+	if f.meetings == nil {
+		f.meetings = make(map[string]application.RecurringSeries)
+	}
+	f.meetings[series.ID] = series
+	return true, nil
+}
+
+func recurringMeetingEvents() []application.CalendarEvent {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	return []application.CalendarEvent{
+		{
+			ID:               "standup_1",
+			Summary:          "Standup",
+			StartTime:        start,
+			EndTime:          start.Add(15 * time.Minute),
+			IsRecurring:      true,
+			RecurringEventID: "standup",
+		},
+		{
+			ID:               "standup_2",
+			Summary:          "Standup",
+			StartTime:        start.AddDate(0, 0, 7),
+			EndTime:          start.AddDate(0, 0, 7).Add(15 * time.Minute),
+			IsRecurring:      true,
+			RecurringEventID: "standup",
+		},
+		{
+			ID:        "dentist",
+			Summary:   "Dentist",
+			StartTime: start.Add(2 * time.Hour),
+			EndTime:   start.Add(3 * time.Hour),
+		},
+	}
+}
+
+func TestCalendarImportWorker_ImportsRecurringMeetings(t *testing.T) {
+	userID := uuid.New()
+	importer := &mockImporter{events: recurringMeetingEvents()}
+	meetings := &fakeMeetingSeriesImporter{}
+	repo := &mockSyncStateRepo{}
+
+	config := DefaultImportWorkerConfig()
+	config.ImportRecurringMeetings = true
+	worker := NewCalendarImportWorker(importer, repo, nil, config, nil).
+		WithMeetingSeriesImporter(meetings)
+
+	state := domain.NewSyncState(userID, "primary", "google")
+	require.True(t, worker.importForUser(context.Background(), state))
+
+	require.Len(t, meetings.meetings, 1)
+	series := meetings.meetings["standup"]
+	assert.Equal(t, "Standup", series.Summary)
+	assert.Equal(t, application.SeriesCadenceWeekly, series.Cadence)
+	assert.Equal(t, 15*time.Minute, series.Duration)
+	assert.Equal(t, 2, series.Occurrences)
+}
+
+func TestCalendarImportWorker_ReimportDoesNotDuplicateMeetings(t *testing.T) {
+	userID := uuid.New()
+	importer := &mockImporter{events: recurringMeetingEvents()}
+	meetings := &fakeMeetingSeriesImporter{}
+	repo := &mockSyncStateRepo{}
+
+	config := DefaultImportWorkerConfig()
+	config.ImportRecurringMeetings = true
+	worker := NewCalendarImportWorker(importer, repo, nil, config, nil).
+		WithMeetingSeriesImporter(meetings)
+
+	state := domain.NewSyncState(userID, "primary", "google")
+	require.True(t, worker.importForUser(context.Background(), state))
+	require.True(t, worker.importForUser(context.Background(), state))
+
+	assert.Equal(t, 2, meetings.calls)
+	assert.Len(t, meetings.meetings, 1)
+}
+
+func TestCalendarImportWorker_RecurringMeetingsDisabled(t *testing.T) {
+	importer := &mockImporter{events: recurringMeetingEvents()}
+	meetings := &fakeMeetingSeriesImporter{}
+
+	worker := NewCalendarImportWorker(importer, &mockSyncStateRepo{}, nil, DefaultImportWorkerConfig(), nil).
+		WithMeetingSeriesImporter(meetings)
+
+	state := domain.NewSyncState(uuid.New(), "primary", "google")
+	require.True(t, worker.importForUser(context.Background(), state))
+
+	assert.Zero(t, meetings.calls)
+}
+
+func TestCalendarImportWorker_RecurringMeetingErrorDoesNotFailImport(t *testing.T) {
+	importer := &mockImporter{events: recurringMeetingEvents()}
+	meetings := &fakeMeetingSeriesImporter{err: errors.New("database locked")}
+	repo := &mockSyncStateRepo{}
+
+	config := DefaultImportWorkerConfig()
+	config.ImportRecurringMeetings = true
+	worker := NewCalendarImportWorker(importer, repo, nil, config, nil).
+		WithMeetingSeriesImporter(meetings)
+
+	state := domain.NewSyncState(uuid.New(), "primary", "google")
+	assert.True(t, worker.importForUser(context.Background(), state))
+	assert.Equal(t, 1, meetings.calls)
+	require.Len(t, repo.savedStates, 1)
+}
+
+// fakeEventBlockImporter keeps blocks in memory and, like the real importer,
+// reuses the block it is handed for an event imported before.
+type fakeEventBlockImporter struct {
+	blocks  map[uuid.UUID]application.CalendarEvent
+	creates int
+	removed []uuid.UUID
+}
+
+func (f *fakeEventBlockImporter) ImportEventBlock(ctx context.Context, userID uuid.UUID, event application.CalendarEvent, existing *domain.ImportedBlock) (domain.ImportedBlock, error) {
+	if f.blocks == nil {
+		f.blocks = make(map[uuid.UUID]application.CalendarEvent)
+	}
+	blockID := uuid.New()
+	if existing != nil {
+		blockID = existing.BlockID
+	} else {
+		f.creates++
+	}
+	f.blocks[blockID] = event
+	return domain.ImportedBlock{BlockID: blockID, Start: event.StartTime}, nil
+}
+
+func (f *fakeEventBlockImporter) RemoveEventBlock(ctx context.Context, userID uuid.UUID, block domain.ImportedBlock) error {
+	delete(f.blocks, block.BlockID)
+	f.removed = append(f.removed, block.BlockID)
+	return nil
+}
+
+func newBlockImportWorker(importer *mockImporter, blocks *fakeEventBlockImporter) *CalendarImportWorker {
+	config := DefaultImportWorkerConfig()
+	config.ImportEventBlocks = true
+	return NewCalendarImportWorker(importer, &mockSyncStateRepo{}, nil, config, nil).
+		WithEventBlockImporter(blocks)
+}
+
+func TestCalendarImportWorker_ReimportUpdatesEventBlocks(t *testing.T) {
+	events := recurringMeetingEvents()
+	importer := &mockImporter{events: events}
+	blocks := &fakeEventBlockImporter{}
+	worker := newBlockImportWorker(importer, blocks)
+
+	state := domain.NewSyncState(uuid.New(), "primary", "google")
+	require.True(t, worker.importForUser(context.Background(), state))
+	require.Len(t, blocks.blocks, 3)
+	first, ok := state.ImportedBlockFor("dentist")
+	require.True(t, ok)
+
+	// The dentist moves; importing again updates its block in place.
+	moved := append([]application.CalendarEvent(nil), events...)
+	moved[2].StartTime = moved[2].StartTime.Add(time.Hour)
+	moved[2].EndTime = moved[2].EndTime.Add(time.Hour)
+	importer.events = moved
+	require.True(t, worker.importForUser(context.Background(), state))
+	require.True(t, worker.importForUser(context.Background(), state))
+
+	assert.Equal(t, 3, blocks.creates)
+	assert.Len(t, blocks.blocks, 3)
+	assert.Len(t, state.ImportedBlocks(), 3)
+	second, ok := state.ImportedBlockFor("dentist")
+	require.True(t, ok)
+	assert.Equal(t, first.BlockID, second.BlockID)
+	assert.Equal(t, moved[2].StartTime, second.Start)
+	assert.Equal(t, moved[2].StartTime, blocks.blocks[second.BlockID].StartTime)
+}
+
+func TestCalendarImportWorker_RemovesBlocksForDeletedEvents(t *testing.T) {
+	events := recurringMeetingEvents()
+	importer := &mockImporter{events: events}
+	blocks := &fakeEventBlockImporter{}
+	worker := newBlockImportWorker(importer, blocks)
+
+	state := domain.NewSyncState(uuid.New(), "primary", "google")
+	require.True(t, worker.importForUser(context.Background(), state))
+	dentist, ok := state.ImportedBlockFor("dentist")
+	require.True(t, ok)
+
+	// A block imported for an event that has since passed out of the window
+	// is left alone.
+	past := domain.ImportedBlock{BlockID: uuid.New(), Start: time.Now().Add(-48 * time.Hour)}
+	state.LinkImportedBlock("last_week", past)
+
+	importer.events = events[:2]
+	require.True(t, worker.importForUser(context.Background(), state))
+
+	assert.Equal(t, []uuid.UUID{dentist.BlockID}, blocks.removed)
+	assert.NotContains(t, blocks.blocks, dentist.BlockID)
+	_, ok = state.ImportedBlockFor("dentist")
+	assert.False(t, ok)
+	_, ok = state.ImportedBlockFor("last_week")
+	assert.False(t, ok)
+	assert.Len(t, state.ImportedBlocks(), 2)
+}
+
+func TestCalendarImportWorker_EventBlocksDisabled(t *testing.T) {
+	blocks := &fakeEventBlockImporter{}
+	worker := NewCalendarImportWorker(&mockImporter{events: recurringMeetingEvents()}, &mockSyncStateRepo{}, nil, DefaultImportWorkerConfig(), nil).
+		WithEventBlockImporter(blocks)
+
+	state := domain.NewSyncState(uuid.New(), "primary", "google")
+	require.True(t, worker.importForUser(context.Background(), state))
+
+	assert.Empty(t, blocks.blocks)
+	assert.Empty(t, state.ImportedBlocks())
+}
+
+// trackingConflictHandler records how many events it handles at once and
+// rejects the events listed in conflicting.
+type trackingConflictHandler struct {
+	conflicting map[string]bool
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	calls       int
+}
+
+func (h *trackingConflictHandler) HandleConflict(ctx context.Context, external application.CalendarEvent, existing interface{}) error {
+	h.mu.Lock()
+	h.calls++
+	h.inFlight++
+	if h.inFlight > h.maxInFlight {
+		h.maxInFlight = h.inFlight
+	}
+	h.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	h.mu.Lock()
+	h.inFlight--
+	h.mu.Unlock()
+
+	if h.conflicting[external.ID] {
+		return errors.New("conflict detected")
+	}
+	return nil
+}
+
+func concurrentImportEvents(n int) []application.CalendarEvent {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	events := make([]application.CalendarEvent, n)
+	for i := range events {
+		events[i] = application.CalendarEvent{
+			ID:        fmt.Sprintf("event-%d", i),
+			Summary:   fmt.Sprintf("Event %d", i),
+			StartTime: start.Add(time.Duration(i) * time.Hour),
+			EndTime:   start.Add(time.Duration(i)*time.Hour + 30*time.Minute),
+		}
+	}
+	return events
+}
+
+func TestCalendarImportWorker_HandlesConflictsConcurrently(t *testing.T) {
+	events := concurrentImportEvents(10)
+	handler := &trackingConflictHandler{
+		conflicting: map[string]bool{"event-2": true, "event-7": true},
+	}
+	blocks := &fakeEventBlockImporter{}
+
+	config := DefaultImportWorkerConfig()
+	config.ImportEventBlocks = true
+	config.ConflictConcurrency = 3
+	worker := NewCalendarImportWorker(&mockImporter{events: events}, &mockSyncStateRepo{}, handler, config, nil).
+		WithEventBlockImporter(blocks)
+
+	state := domain.NewSyncState(uuid.New(), "primary", "google")
+	require.True(t, worker.importForUser(context.Background(), state))
+
+	assert.Equal(t, 10, handler.calls)
+	assert.Greater(t, handler.maxInFlight, 1)
+	assert.LessOrEqual(t, handler.maxInFlight, 3)
+
+	// Every event is accepted or rejected exactly as if handled one by one.
+	assert.Len(t, state.ImportedBlocks(), 8)
+	for _, event := range events {
+		_, ok := state.ImportedBlockFor(event.ID)
+		assert.Equal(t, !handler.conflicting[event.ID], ok, event.ID)
+	}
+}
+
+func TestCalendarImportWorker_ConflictConcurrencyOfOneIsSequential(t *testing.T) {
+	handler := &trackingConflictHandler{}
+
+	for _, concurrency := range []int{0, 1} {
+		config := DefaultImportWorkerConfig()
+		config.ConflictConcurrency = concurrency
+		worker := NewCalendarImportWorker(&mockImporter{events: concurrentImportEvents(4)}, &mockSyncStateRepo{}, handler, config, nil)
+
+		state := domain.NewSyncState(uuid.New(), "primary", "google")
+		require.True(t, worker.importForUser(context.Background(), state))
+	}
+
+	assert.Equal(t, 8, handler.calls)
+	assert.Equal(t, 1, handler.maxInFlight)
+}
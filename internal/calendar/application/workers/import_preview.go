@@ -0,0 +1,183 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/application"
+	"github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	"github.com/google/uuid"
+)
+
+// ErrImporterNotConfigured is returned when an import is previewed without a
+// calendar importer.
+var ErrImporterNotConfigured = errors.New("calendar importer not configured")
+
+// ImportConflict describes an Orbita block that an external event would
+// clash with, and what the conflict strategy would do about it.
+type ImportConflict struct {
+	EventID      string
+	EventSummary string
+	BlockID      uuid.UUID
+	BlockStart   time.Time
+	BlockEnd     time.Time
+	Resolution   string
+}
+
+// ConflictPreviewer is implemented by conflict handlers that can report the
+// conflicts an event would cause without resolving them.
+type ConflictPreviewer interface {
+	PreviewConflicts(ctx context.Context, userID uuid.UUID, event application.CalendarEvent) ([]ImportConflict, error)
+}
+
+// MeetingSeriesPreviewer is implemented by meeting importers that can tell
+// whether a series is already linked to a meeting without importing it.
+type MeetingSeriesPreviewer interface {
+	IsSeriesImported(ctx context.Context, userID uuid.UUID, seriesID string) (bool, error)
+}
+
+// ImportPreview lists what an import would do, without applying any of it.
+type ImportPreview struct {
+	UserID     uuid.UUID
+	CalendarID string
+	Start      time.Time
+	End        time.Time
+	// Import holds the external events the import would take in.
+	Import []application.CalendarEvent
+	// Update holds events whose imported block would be updated in place.
+	Update []application.CalendarEvent
+	// Remove holds imported blocks whose event was deleted externally.
+	Remove []domain.ImportedBlock
+	// Skip holds Orbita-created events the import would ignore.
+	Skip []application.CalendarEvent
+	// Conflicts lists clashes between imported events and Orbita blocks.
+	Conflicts []ImportConflict
+	// CreateMeetings holds recurring series that would become new meetings.
+	CreateMeetings []application.RecurringSeries
+	// LinkedMeetings holds recurring series already linked to a meeting,
+	// which the import leaves unchanged.
+	LinkedMeetings []application.RecurringSeries
+	// Changed is true when the calendar differs from the last import, so
+	// applying it would update the stored sync state.
+	Changed bool
+}
+
+// PreviewImport reports what importing the user's calendar would create,
+// update or remove and which conflicts it would raise. Nothing is saved,
+// resolved or imported.
+func (w *CalendarImportWorker) PreviewImport(ctx context.Context, userID uuid.UUID, calendarID string) (*ImportPreview, error) {
+	if w.importer == nil {
+		return nil, ErrImporterNotConfigured
+	}
+
+	start := time.Now()
+	end := start.AddDate(0, 0, w.config.LookAheadDays)
+
+	events, err := w.importer.ListEvents(ctx, userID, start, end, !w.config.SkipOrbitaEvents)
+	if err != nil {
+		return nil, err
+	}
+	withSourceCalendar(events, calendarID)
+
+	state, err := w.syncStateRepo.FindByUserAndCalendar(ctx, userID, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	linked := map[string]domain.ImportedBlock{}
+	if state != nil && w.importsEventBlocks() {
+		linked = state.ImportedBlocks()
+	}
+
+	preview := &ImportPreview{
+		UserID:     userID,
+		CalendarID: calendarID,
+		Start:      start,
+		End:        end,
+		Changed:    state == nil || state.LastSyncHash() != calculateSyncHash(events),
+	}
+
+	previewer, _ := w.conflictHandler.(ConflictPreviewer)
+	seen := make(map[string]bool, len(events))
+	for _, event := range events {
+		seen[event.ID] = true
+		if event.IsOrbitaEvent && w.config.SkipOrbitaEvents {
+			preview.Skip = append(preview.Skip, event)
+			continue
+		}
+
+		block, imported := linked[event.ID]
+		if previewer != nil {
+			conflicts, err := previewer.PreviewConflicts(ctx, userID, event)
+			if err != nil {
+				return nil, err
+			}
+			// An event always overlaps the block imported for it.
+			if imported {
+				conflicts = withoutBlock(conflicts, block.BlockID)
+			}
+			if len(conflicts) > 0 {
+				preview.Conflicts = append(preview.Conflicts, conflicts...)
+				continue
+			}
+		}
+
+		if imported {
+			preview.Update = append(preview.Update, event)
+			continue
+		}
+		preview.Import = append(preview.Import, event)
+	}
+
+	for eventID, block := range linked {
+		if !seen[eventID] && !block.Start.Before(start) && block.Start.Before(end) {
+			preview.Remove = append(preview.Remove, block)
+		}
+	}
+
+	if err := w.previewRecurringMeetings(ctx, userID, events, preview); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// importsEventBlocks reports whether imports create schedule blocks.
+func (w *CalendarImportWorker) importsEventBlocks() bool {
+	return w.config.ImportEventBlocks && w.blockImporter != nil
+}
+
+// withoutBlock drops the conflicts with the given block.
+func withoutBlock(conflicts []ImportConflict, blockID uuid.UUID) []ImportConflict {
+	kept := conflicts[:0]
+	for _, conflict := range conflicts {
+		if conflict.BlockID != blockID {
+			kept = append(kept, conflict)
+		}
+	}
+	return kept
+}
+
+// previewRecurringMeetings sorts recurring series into those the import would
+// turn into meetings and those already linked to one.
+func (w *CalendarImportWorker) previewRecurringMeetings(ctx context.Context, userID uuid.UUID, events []application.CalendarEvent, preview *ImportPreview) error {
+	if !w.config.ImportRecurringMeetings || w.meetingImporter == nil {
+		return nil
+	}
+
+	previewer, _ := w.meetingImporter.(MeetingSeriesPreviewer)
+	for _, series := range application.DetectRecurringSeries(events) {
+		if previewer != nil {
+			linked, err := previewer.IsSeriesImported(ctx, userID, series.ID)
+			if err != nil {
+				return err
+			}
+			if linked {
+				preview.LinkedMeetings = append(preview.LinkedMeetings, series)
+				continue
+			}
+		}
+		preview.CreateMeetings = append(preview.CreateMeetings, series)
+	}
+	return nil
+}
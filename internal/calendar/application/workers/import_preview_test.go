@@ -0,0 +1,146 @@
+package workers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/application"
+	"github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// previewConflictHandler reports a conflict for the configured events and
+// records any attempt to resolve one.
+type previewConflictHandler struct {
+	mockConflictHandler
+	conflicting map[string]ImportConflict
+}
+
+func (p *previewConflictHandler) PreviewConflicts(ctx context.Context, userID uuid.UUID, event application.CalendarEvent) ([]ImportConflict, error) {
+	if conflict, ok := p.conflicting[event.ID]; ok {
+		return []ImportConflict{conflict}, nil
+	}
+	return nil, nil
+}
+
+// previewMeetingImporter is a fakeMeetingSeriesImporter that can also report
+// which series are linked without importing them.
+type previewMeetingImporter struct {
+	fakeMeetingSeriesImporter
+}
+
+func (p *previewMeetingImporter) IsSeriesImported(ctx context.Context, userID uuid.UUID, seriesID string) (bool, error) {
+	_, ok := p.meetings[seriesID]
+	return ok, nil
+}
+
+func TestCalendarImportWorker_PreviewImport(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("reports conflicts without applying anything", func(t *testing.T) {
+		events := recurringMeetingEvents()
+		events = append(events, application.CalendarEvent{
+			ID:            "orbita_block",
+			Summary:       "Deep work",
+			StartTime:     events[0].StartTime,
+			EndTime:       events[0].EndTime,
+			IsOrbitaEvent: true,
+		})
+		blockID := uuid.New()
+		conflicts := &previewConflictHandler{
+			conflicting: map[string]ImportConflict{
+				"dentist": {EventID: "dentist", EventSummary: "Dentist", BlockID: blockID, Resolution: "rescheduled"},
+			},
+		}
+		meetings := &previewMeetingImporter{}
+		repo := &mockSyncStateRepo{}
+
+		config := DefaultImportWorkerConfig()
+		config.ImportRecurringMeetings = true
+		worker := NewCalendarImportWorker(&mockImporter{events: events}, repo, conflicts, config, nil).
+			WithMeetingSeriesImporter(meetings)
+
+		preview, err := worker.PreviewImport(context.Background(), userID, "primary")
+		require.NoError(t, err)
+
+		assert.Len(t, preview.Import, 2)
+		require.Len(t, preview.Skip, 1)
+		assert.Equal(t, "orbita_block", preview.Skip[0].ID)
+		require.Len(t, preview.Conflicts, 1)
+		assert.Equal(t, "dentist", preview.Conflicts[0].EventID)
+		assert.Equal(t, blockID, preview.Conflicts[0].BlockID)
+		require.Len(t, preview.CreateMeetings, 1)
+		assert.Equal(t, "standup", preview.CreateMeetings[0].ID)
+		assert.Empty(t, preview.LinkedMeetings)
+		assert.True(t, preview.Changed)
+
+		// Nothing was resolved, imported or saved.
+		assert.Zero(t, conflicts.calls)
+		assert.Zero(t, meetings.calls)
+		assert.Empty(t, meetings.meetings)
+		assert.Empty(t, repo.savedStates)
+	})
+
+	t.Run("reports unchanged calendars and linked meetings", func(t *testing.T) {
+		events := recurringMeetingEvents()
+		meetings := &previewMeetingImporter{}
+		repo := &mockSyncStateRepo{}
+
+		config := DefaultImportWorkerConfig()
+		config.ImportRecurringMeetings = true
+		worker := NewCalendarImportWorker(&mockImporter{events: events}, repo, nil, config, nil).
+			WithMeetingSeriesImporter(meetings)
+
+		state := domain.NewSyncState(userID, "primary", "google")
+		require.True(t, worker.importForUser(context.Background(), state))
+		repo.states = []*domain.SyncState{state}
+		saved := len(repo.savedStates)
+
+		preview, err := worker.PreviewImport(context.Background(), userID, "primary")
+		require.NoError(t, err)
+
+		assert.False(t, preview.Changed)
+		assert.Empty(t, preview.CreateMeetings)
+		require.Len(t, preview.LinkedMeetings, 1)
+		assert.Equal(t, "standup", preview.LinkedMeetings[0].ID)
+		assert.Len(t, repo.savedStates, saved)
+	})
+
+	t.Run("reports updates and removals of imported blocks", func(t *testing.T) {
+		events := recurringMeetingEvents()
+		importer := &mockImporter{events: events}
+		blocks := &fakeEventBlockImporter{}
+		repo := &mockSyncStateRepo{}
+
+		config := DefaultImportWorkerConfig()
+		config.ImportEventBlocks = true
+		worker := NewCalendarImportWorker(importer, repo, nil, config, nil).
+			WithEventBlockImporter(blocks)
+
+		state := domain.NewSyncState(userID, "primary", "google")
+		require.True(t, worker.importForUser(context.Background(), state))
+		repo.states = []*domain.SyncState{state}
+		dentist, ok := state.ImportedBlockFor("dentist")
+		require.True(t, ok)
+
+		importer.events = events[:2]
+		preview, err := worker.PreviewImport(context.Background(), userID, "primary")
+		require.NoError(t, err)
+
+		assert.Empty(t, preview.Import)
+		assert.Len(t, preview.Update, 2)
+		require.Len(t, preview.Remove, 1)
+		assert.Equal(t, dentist.BlockID, preview.Remove[0].BlockID)
+		assert.Empty(t, blocks.removed)
+		assert.Len(t, state.ImportedBlocks(), 3)
+	})
+
+	t.Run("requires an importer", func(t *testing.T) {
+		worker := NewCalendarImportWorker(nil, &mockSyncStateRepo{}, nil, DefaultImportWorkerConfig(), nil)
+
+		_, err := worker.PreviewImport(context.Background(), userID, "primary")
+		assert.ErrorIs(t, err, ErrImporterNotConfigured)
+	})
+}
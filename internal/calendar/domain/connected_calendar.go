@@ -286,6 +286,21 @@ func (c *ConnectedCalendar) CalDAVUsername() string {
 	return c.ConfigValue(ConfigCalDAVUsername)
 }
 
+// ConfigAccount names the provider account a calendar belongs to, when the
+// user connected more than one account of the provider.
+const ConfigAccount = "account"
+
+// Account returns the provider account the calendar belongs to. Empty is
+// the default account.
+func (c *ConnectedCalendar) Account() string {
+	return c.ConfigValue(ConfigAccount)
+}
+
+// SetAccount sets the provider account the calendar belongs to.
+func (c *ConnectedCalendar) SetAccount(account string) {
+	c.SetConfig(ConfigAccount, account)
+}
+
 // RehydrateConnectedCalendar recreates a connected calendar from persisted data.
 // This does NOT record domain events as it's rehydrating existing state.
 func RehydrateConnectedCalendar(
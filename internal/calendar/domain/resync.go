@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResyncProgress tracks a full resync, so that an interrupted run can resume
+// where it stopped instead of pushing every block again.
+type ResyncProgress struct {
+	StartedAt time.Time
+	// Pushed lists the blocks already re-pushed, in order.
+	Pushed []uuid.UUID
+}
+
+// HasPushed reports whether the block was already re-pushed.
+func (p *ResyncProgress) HasPushed(blockID uuid.UUID) bool {
+	for _, id := range p.Pushed {
+		if id == blockID {
+			return true
+		}
+	}
+	return false
+}
+
+// Resync returns a copy of the progress of the running full resync, or nil
+// when none is running.
+func (s *SyncState) Resync() *ResyncProgress {
+	if s.resync == nil {
+		return nil
+	}
+	return &ResyncProgress{
+		StartedAt: s.resync.StartedAt,
+		Pushed:    append([]uuid.UUID(nil), s.resync.Pushed...),
+	}
+}
+
+// IsResyncing reports whether a full resync was started and not completed.
+func (s *SyncState) IsResyncing() bool {
+	return s.resync != nil
+}
+
+// StartResync clears the sync state and starts a new full resync, discarding
+// the progress of any earlier one.
+func (s *SyncState) StartResync() {
+	s.syncToken = ""
+	s.lastSyncHash = ""
+	s.syncErrors = 0
+	s.lastError = ""
+	s.nextAttemptAt = time.Time{}
+	s.resync = &ResyncProgress{StartedAt: time.Now()}
+	s.Touch()
+}
+
+// MarkResynced records that a block was re-pushed by the running resync.
+func (s *SyncState) MarkResynced(blockID uuid.UUID) {
+	if s.resync == nil || s.resync.HasPushed(blockID) {
+		return
+	}
+	s.resync.Pushed = append(s.resync.Pushed, blockID)
+	s.Touch()
+}
+
+// CompleteResync ends the running resync and records it as a successful sync.
+func (s *SyncState) CompleteResync() {
+	s.resync = nil
+	s.MarkSyncSuccess("", "")
+}
+
+// RehydrateResync restores the progress of a resync loaded from storage.
+func (s *SyncState) RehydrateResync(progress *ResyncProgress) {
+	s.resync = progress
+}
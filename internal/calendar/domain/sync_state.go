@@ -19,6 +19,19 @@ type SyncState struct {
 	lastSyncHash string    // Hash of last synced state for change detection
 	syncErrors   int       // Count of consecutive sync errors
 	lastError    string    // Last error message if any
+	// importedBlocks links external event IDs to the blocks imported for them.
+	importedBlocks map[string]ImportedBlock
+	// resync is the progress of a running full resync, nil when none is.
+	resync *ResyncProgress
+	// nextAttemptAt is when a failing calendar may be synced again; zero
+	// when it is not backing off.
+	nextAttemptAt time.Time
+}
+
+// ImportedBlock is the schedule block imported for an external event.
+type ImportedBlock struct {
+	BlockID uuid.UUID
+	Start   time.Time // Start of the event when it was last imported
 }
 
 // NewSyncState creates a new sync state for a user's calendar.
@@ -46,6 +59,40 @@ func (s *SyncState) LastSyncHash() string    { return s.lastSyncHash }
 func (s *SyncState) SyncErrors() int         { return s.syncErrors }
 func (s *SyncState) LastError() string       { return s.lastError }
 
+// ImportedBlocks returns a copy of the event-to-block links.
+func (s *SyncState) ImportedBlocks() map[string]ImportedBlock {
+	blocks := make(map[string]ImportedBlock, len(s.importedBlocks))
+	for eventID, block := range s.importedBlocks {
+		blocks[eventID] = block
+	}
+	return blocks
+}
+
+// ImportedBlockFor returns the block imported for an external event.
+func (s *SyncState) ImportedBlockFor(eventID string) (ImportedBlock, bool) {
+	block, ok := s.importedBlocks[eventID]
+	return block, ok
+}
+
+// LinkImportedBlock records the block imported for an external event,
+// replacing any earlier link.
+func (s *SyncState) LinkImportedBlock(eventID string, block ImportedBlock) {
+	if s.importedBlocks == nil {
+		s.importedBlocks = make(map[string]ImportedBlock)
+	}
+	s.importedBlocks[eventID] = block
+	s.Touch()
+}
+
+// UnlinkImportedBlock forgets the block imported for an external event.
+func (s *SyncState) UnlinkImportedBlock(eventID string) {
+	if _, ok := s.importedBlocks[eventID]; !ok {
+		return
+	}
+	delete(s.importedBlocks, eventID)
+	s.Touch()
+}
+
 // HasSynced returns true if at least one successful sync has occurred.
 func (s *SyncState) HasSynced() bool {
 	return !s.lastSyncedAt.IsZero()
@@ -63,6 +110,7 @@ func (s *SyncState) MarkSyncSuccess(syncToken, syncHash string) {
 	s.lastSyncedAt = time.Now()
 	s.syncErrors = 0
 	s.lastError = ""
+	s.nextAttemptAt = time.Time{}
 	s.Touch()
 }
 
@@ -73,6 +121,29 @@ func (s *SyncState) MarkSyncFailure(err string) {
 	s.Touch()
 }
 
+// NextAttemptAt returns when a failing calendar may be synced again, or the
+// zero time when it is not backing off.
+func (s *SyncState) NextAttemptAt() time.Time {
+	return s.nextAttemptAt
+}
+
+// DeferNextAttempt backs the calendar off until at, so it is not picked up
+// for syncing again before then. A successful sync clears the backoff.
+func (s *SyncState) DeferNextAttempt(at time.Time) {
+	s.nextAttemptAt = at
+	s.Touch()
+}
+
+// IsDue reports whether the calendar's backoff, if any, has expired by now.
+func (s *SyncState) IsDue(now time.Time) bool {
+	return s.nextAttemptAt.IsZero() || !now.Before(s.nextAttemptAt)
+}
+
+// RehydrateNextAttempt restores the persisted backoff of the sync state.
+func (s *SyncState) RehydrateNextAttempt(at time.Time) {
+	s.nextAttemptAt = at
+}
+
 // ResetSyncToken clears the sync token to force a full sync.
 func (s *SyncState) ResetSyncToken() {
 	s.syncToken = ""
@@ -96,6 +167,7 @@ func RehydrateSyncState(
 	lastSyncHash string,
 	syncErrors int,
 	lastError string,
+	importedBlocks map[string]ImportedBlock,
 	createdAt, updatedAt time.Time,
 ) *SyncState {
 	return &SyncState{
@@ -108,6 +180,8 @@ func RehydrateSyncState(
 		lastSyncHash: lastSyncHash,
 		syncErrors:   syncErrors,
 		lastError:    lastError,
+
+		importedBlocks: importedBlocks,
 	}
 }
 
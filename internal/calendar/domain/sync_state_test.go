@@ -117,6 +117,22 @@ func TestSyncState_ShouldRetry(t *testing.T) {
 	assert.True(t, state.ShouldRetry(3))
 }
 
+func TestSyncState_DeferNextAttempt(t *testing.T) {
+	state := domain.NewSyncState(uuid.New(), "primary", "google")
+	now := time.Now()
+	assert.True(t, state.IsDue(now))
+
+	state.MarkSyncFailure("error 1")
+	state.DeferNextAttempt(now.Add(time.Minute))
+	assert.False(t, state.IsDue(now))
+	assert.True(t, state.IsDue(now.Add(time.Minute)))
+
+	// A successful sync clears the backoff
+	state.MarkSyncSuccess("token", "hash")
+	assert.True(t, state.NextAttemptAt().IsZero())
+	assert.True(t, state.IsDue(now))
+}
+
 func TestRehydrateSyncState(t *testing.T) {
 	id := uuid.New()
 	userID := uuid.New()
@@ -133,7 +149,7 @@ func TestRehydrateSyncState(t *testing.T) {
 	state := domain.RehydrateSyncState(
 		id, userID, calendarID, provider,
 		syncToken, lastSyncedAt, lastSyncHash,
-		syncErrors, lastError, createdAt, updatedAt,
+		syncErrors, lastError, nil, createdAt, updatedAt,
 	)
 
 	require.NotNil(t, state)
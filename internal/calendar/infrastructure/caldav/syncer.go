@@ -3,6 +3,7 @@ package caldav
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -25,6 +26,13 @@ const (
 // Custom property for Orbita events
 const PropXOrbita = "X-ORBITA"
 
+// Capabilities declares the optional features the CalDAV syncer supports.
+func Capabilities() calendarApp.ProviderCapabilities {
+	return calendarApp.ProviderCapabilities{
+		DeleteEvents: true,
+	}
+}
+
 // Syncer syncs schedule blocks to a CalDAV calendar (Apple Calendar, Fastmail, Nextcloud, etc.).
 type Syncer struct {
 	baseURL       string
@@ -33,6 +41,8 @@ type Syncer struct {
 	calendarPath  string // Specific calendar path, or empty for default
 	logger        *slog.Logger
 	deleteMissing bool
+	// massDelete limits how many missing events one sync may delete.
+	massDelete calendarApp.MassDeleteGuard
 }
 
 // NewSyncer creates a CalDAV calendar syncer.
@@ -46,6 +56,7 @@ func NewSyncer(baseURL, username, password string, logger *slog.Logger) *Syncer
 		password:      password,
 		logger:        logger,
 		deleteMissing: false,
+		massDelete:    calendarApp.NewMassDeleteGuard(),
 	}
 }
 
@@ -55,6 +66,20 @@ func (s *Syncer) WithDeleteMissing(enabled bool) *Syncer {
 	return s
 }
 
+// WithMassDeleteThreshold sets how many missing events a sync may delete
+// without confirmation. Zero or less disables the check.
+func (s *Syncer) WithMassDeleteThreshold(threshold int) *Syncer {
+	s.massDelete.Threshold = threshold
+	return s
+}
+
+// WithMassDeleteConfirmed allows a sync to delete more missing events than
+// the threshold.
+func (s *Syncer) WithMassDeleteConfirmed(confirmed bool) *Syncer {
+	s.massDelete.Confirmed = confirmed
+	return s
+}
+
 // WithCalendarPath sets the specific calendar path to use.
 func (s *Syncer) WithCalendarPath(path string) *Syncer {
 	s.calendarPath = path
@@ -97,6 +122,9 @@ func (s *Syncer) Sync(ctx context.Context, userID uuid.UUID, blocks []calendarAp
 
 	if s.deleteMissing {
 		deleted, err := s.deleteMissingEvents(ctx, client, calPath, keepPaths)
+		if errors.Is(err, calendarApp.ErrMassDeleteNotConfirmed) {
+			return result, err
+		}
 		if err != nil {
 			s.logger.Warn("caldav delete missing failed", "error", err)
 		} else {
@@ -297,19 +325,25 @@ func (s *Syncer) deleteMissingEvents(ctx context.Context, client *caldav.Client,
 		return 0, err
 	}
 
-	deleted := 0
+	var missing []string
 	for _, obj := range objects {
 		// Check if it's an Orbita event
 		if !isOrbitaEvent(&obj) {
 			continue
 		}
 
-		if _, ok := keepPaths[obj.Path]; ok {
-			continue
+		if _, ok := keepPaths[obj.Path]; !ok {
+			missing = append(missing, obj.Path)
 		}
+	}
+	if err := s.massDelete.Check(len(missing)); err != nil {
+		return 0, err
+	}
 
-		if err := client.RemoveAll(ctx, obj.Path); err != nil {
-			s.logger.Warn("failed to delete caldav event", "path", obj.Path, "error", err)
+	deleted := 0
+	for _, path := range missing {
+		if err := client.RemoveAll(ctx, path); err != nil {
+			s.logger.Warn("failed to delete caldav event", "path", path, "error", err)
 			continue
 		}
 		deleted++
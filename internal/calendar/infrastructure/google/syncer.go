@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -21,6 +22,15 @@ type tokenSourceProvider interface {
 	TokenSource(ctx context.Context, userID uuid.UUID) (oauth2.TokenSource, error)
 }
 
+// Capabilities declares the optional features the Google Calendar syncer supports.
+func Capabilities() calendarApp.ProviderCapabilities {
+	return calendarApp.ProviderCapabilities{
+		DeleteEvents: true,
+		Reminders:    true,
+		Colors:       true,
+	}
+}
+
 // Syncer syncs schedule blocks to Google Calendar.
 type Syncer struct {
 	oauthService  tokenSourceProvider
@@ -30,6 +40,12 @@ type Syncer struct {
 	calendarID    string
 	attendees     []string
 	reminders     []int
+	// typeReminders overrides reminders per block type.
+	typeReminders map[string][]int
+	// includeAttachments lists block attachments in event descriptions.
+	includeAttachments bool
+	// massDelete limits how many missing events one sync may delete.
+	massDelete calendarApp.MassDeleteGuard
 }
 
 // NewSyncer creates a Google Calendar syncer.
@@ -45,6 +61,7 @@ func NewSyncer(oauthService tokenSourceProvider, logger *slog.Logger) *Syncer {
 		calendarID:    "primary",
 		attendees:     nil,
 		reminders:     nil,
+		massDelete:    calendarApp.NewMassDeleteGuard(),
 	}
 }
 
@@ -64,6 +81,7 @@ func NewSyncerWithBaseURL(oauthService tokenSourceProvider, logger *slog.Logger,
 		calendarID:    "primary",
 		attendees:     nil,
 		reminders:     nil,
+		massDelete:    calendarApp.NewMassDeleteGuard(),
 	}
 }
 
@@ -73,6 +91,20 @@ func (s *Syncer) WithDeleteMissing(enabled bool) *Syncer {
 	return s
 }
 
+// WithMassDeleteThreshold sets how many missing events a sync may delete
+// without confirmation. Zero or less disables the check.
+func (s *Syncer) WithMassDeleteThreshold(threshold int) *Syncer {
+	s.massDelete.Threshold = threshold
+	return s
+}
+
+// WithMassDeleteConfirmed allows a sync to delete more missing events than
+// the threshold.
+func (s *Syncer) WithMassDeleteConfirmed(confirmed bool) *Syncer {
+	s.massDelete.Confirmed = confirmed
+	return s
+}
+
 // WithCalendarID sets the calendar ID for sync.
 func (s *Syncer) WithCalendarID(calendarID string) *Syncer {
 	if calendarID != "" {
@@ -93,6 +125,31 @@ func (s *Syncer) WithReminders(reminders []int) *Syncer {
 	return s
 }
 
+// WithBlockTypeReminders sets reminder minutes per block type, replacing the
+// global reminders for blocks of that type. An empty list means no reminders.
+func (s *Syncer) WithBlockTypeReminders(reminders map[string][]int) *Syncer {
+	s.typeReminders = reminders
+	return s
+}
+
+// WithAttachments lists each block's attachments in its event description.
+func (s *Syncer) WithAttachments(enabled bool) *Syncer {
+	s.includeAttachments = enabled
+	return s
+}
+
+// remindersFor resolves the reminder minutes for a block: the block's own
+// reminders if set, then its block type's, then the global reminders.
+func (s *Syncer) remindersFor(block calendarApp.TimeBlock) []int {
+	if block.Reminders != nil {
+		return block.Reminders
+	}
+	if reminders, ok := s.typeReminders[block.BlockType]; ok {
+		return reminders
+	}
+	return s.reminders
+}
+
 // Sync pushes schedule blocks into the primary calendar.
 func (s *Syncer) Sync(ctx context.Context, userID uuid.UUID, blocks []calendarApp.TimeBlock) (*calendarApp.SyncResult, error) {
 	if s.oauthService == nil {
@@ -122,10 +179,18 @@ func (s *Syncer) Sync(ctx context.Context, userID uuid.UUID, blocks []calendarAp
 	result := &calendarApp.SyncResult{}
 	keepIDs := make(map[string]struct{}, len(blocks))
 	for _, block := range blocks {
-		event := toGoogleEvent(block, s.attendees, s.reminders)
+		event := toGoogleEvent(block, s.attendees, s.remindersFor(block))
+		if s.includeAttachments {
+			event.Description += calendarApp.AttachmentsDescription(block.Attachments)
+		}
 		keepIDs[event.ID] = struct{}{}
 		updated, err := upsertEvent(ctx, &client, s.baseURL, s.calendarID, event)
 		if err != nil {
+			// A cancelled or expired context fails every remaining request;
+			// stop instead of counting them all as failures.
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return result, ctxErr
+			}
 			s.logger.Warn("calendar sync failed", "event_id", event.ID, "error", err)
 			result.Failed++
 			continue
@@ -138,7 +203,10 @@ func (s *Syncer) Sync(ctx context.Context, userID uuid.UUID, blocks []calendarAp
 	}
 
 	if s.deleteMissing {
-		deleted, err := deleteMissingEvents(ctx, &client, s.baseURL, s.calendarID, keepIDs)
+		deleted, err := deleteMissingEvents(ctx, &client, s.baseURL, s.calendarID, keepIDs, s.massDelete)
+		if errors.Is(err, calendarApp.ErrMassDeleteNotConfirmed) {
+			return result, err
+		}
 		if err != nil {
 			s.logger.Warn("calendar delete missing failed", "error", err)
 		} else {
@@ -153,6 +221,7 @@ type googleEvent struct {
 	ID                 string `json:"id,omitempty"`
 	Summary            string `json:"summary"`
 	Description        string `json:"description,omitempty"`
+	ColorID            string `json:"colorId,omitempty"`
 	ExtendedProperties struct {
 		Private map[string]string `json:"private,omitempty"`
 	} `json:"extendedProperties,omitempty"`
@@ -190,6 +259,7 @@ func toGoogleEvent(block calendarApp.TimeBlock, attendees []string, reminders []
 	}
 	event.Start.DateTime = block.StartTime.Format(time.RFC3339)
 	event.End.DateTime = block.EndTime.Format(time.RFC3339)
+	event.ColorID = eventColorID(block.Color)
 
 	if len(attendees) > 0 {
 		event.Attendees = make([]struct {
@@ -231,6 +301,44 @@ func toGoogleEvent(block calendarApp.TimeBlock, attendees []string, reminders []
 	return event
 }
 
+// eventColors maps Google Calendar event color IDs to their background colors.
+var eventColors = map[string][3]int{
+	"1":  {0xa4, 0xbd, 0xfc}, // Lavender
+	"2":  {0x7a, 0xe7, 0xbf}, // Sage
+	"3":  {0xdb, 0xad, 0xff}, // Grape
+	"4":  {0xff, 0x88, 0x7c}, // Flamingo
+	"5":  {0xfb, 0xd7, 0x5b}, // Banana
+	"6":  {0xff, 0xb8, 0x78}, // Tangerine
+	"7":  {0x46, 0xd6, 0xdb}, // Peacock
+	"8":  {0xe1, 0xe1, 0xe1}, // Graphite
+	"9":  {0x54, 0x84, 0xed}, // Blueberry
+	"10": {0x51, 0xb7, 0x49}, // Basil
+	"11": {0xdc, 0x21, 0x27}, // Tomato
+}
+
+// eventColorID returns the Google event color closest to a #RRGGBB color.
+// Google only accepts its fixed palette, so arbitrary colors are approximated.
+// Empty or malformed colors leave the calendar default.
+func eventColorID(hex string) string {
+	var r, g, b int
+	if len(hex) != 7 || hex[0] != '#' {
+		return ""
+	}
+	if _, err := fmt.Sscanf(hex[1:], "%02x%02x%02x", &r, &g, &b); err != nil {
+		return ""
+	}
+
+	bestID, bestDist := "", -1
+	for id, c := range eventColors {
+		dr, dg, db := r-c[0], g-c[1], b-c[2]
+		dist := dr*dr + dg*dg + db*db
+		if bestDist < 0 || dist < bestDist || (dist == bestDist && id < bestID) {
+			bestID, bestDist = id, dist
+		}
+	}
+	return bestID
+}
+
 func upsertEvent(ctx context.Context, client *http.Client, baseURL, calendarID string, event googleEvent) (bool, error) {
 	body, err := json.Marshal(event)
 	if err != nil {
@@ -279,7 +387,7 @@ func responseError(resp *http.Response) error {
 	return fmt.Errorf("calendar sync failed: status=%d body=%s", resp.StatusCode, string(body))
 }
 
-func deleteMissingEvents(ctx context.Context, client *http.Client, baseURL, calendarID string, keepIDs map[string]struct{}) (int, error) {
+func deleteMissingEvents(ctx context.Context, client *http.Client, baseURL, calendarID string, keepIDs map[string]struct{}, guard calendarApp.MassDeleteGuard) (int, error) {
 	listURL := fmt.Sprintf("%s/calendars/%s/events?privateExtendedProperty=orbita=1", baseURL, calendarID)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL, nil)
 	if err != nil {
@@ -303,12 +411,19 @@ func deleteMissingEvents(ctx context.Context, client *http.Client, baseURL, cale
 		return 0, err
 	}
 
-	deleted := 0
+	var missing []string
 	for _, item := range list.Items {
-		if _, ok := keepIDs[item.ID]; ok {
-			continue
+		if _, ok := keepIDs[item.ID]; !ok {
+			missing = append(missing, item.ID)
 		}
-		deleteURL := fmt.Sprintf("%s/calendars/%s/events/%s", baseURL, calendarID, item.ID)
+	}
+	if err := guard.Check(len(missing)); err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, id := range missing {
+		deleteURL := fmt.Sprintf("%s/calendars/%s/events/%s", baseURL, calendarID, id)
 		req, err := http.NewRequestWithContext(ctx, http.MethodDelete, deleteURL, nil)
 		if err != nil {
 			return deleted, err
@@ -515,6 +630,7 @@ func (s *Syncer) ListEvents(ctx context.Context, userID uuid.UUID, start, end ti
 			Status:      item.Status,
 			Organizer:   item.Organizer.Email,
 			IsRecurring: item.RecurringEventId != "",
+			RecurringEventID: item.RecurringEventId,
 		}
 
 		// Check if this is an Orbita-created event
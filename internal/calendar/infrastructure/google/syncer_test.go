@@ -3,6 +3,7 @@ package google
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -367,6 +369,105 @@ func TestSyncer_GoldenRequestPayload(t *testing.T) {
 	}
 }
 
+func TestSyncer_GoldenRequestPayload_RemindersByBlockType(t *testing.T) {
+	golden := map[string]string{
+		"22222222-2222-2222-2222-222222222222": "event_request_meeting.json",
+		"33333333-3333-3333-3333-333333333333": "event_request_task.json",
+		"44444444-4444-4444-4444-444444444444": "event_request_habit.json",
+		"55555555-5555-5555-5555-555555555555": "event_request_block_override.json",
+	}
+
+	received := make(map[string]map[string]any)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var actual map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&actual); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		id, _ := actual["id"].(string)
+		received[id] = actual
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
+	syncer := NewSyncerWithBaseURL(stubTokenSourceProvider{source: source}, nil, server.URL).
+		WithReminders([]int{5}).
+		WithBlockTypeReminders(map[string][]int{
+			"meeting": {10, 30},
+			"task":    {},
+		})
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, time.May, 1, hour, minute, 0, 0, time.UTC)
+	}
+	blocks := []calendarApp.TimeBlock{
+		{
+			ID:        uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+			Title:     "1:1 with Sam",
+			BlockType: "meeting",
+			StartTime: at(9, 0),
+			EndTime:   at(9, 30),
+		},
+		{
+			ID:        uuid.MustParse("33333333-3333-3333-3333-333333333333"),
+			Title:     "Write report",
+			BlockType: "task",
+			StartTime: at(10, 0),
+			EndTime:   at(11, 0),
+		},
+		{
+			// No type override: falls back to the global reminders.
+			ID:        uuid.MustParse("44444444-4444-4444-4444-444444444444"),
+			Title:     "Stretch",
+			BlockType: "habit",
+			StartTime: at(12, 0),
+			EndTime:   at(12, 15),
+		},
+		{
+			// Block metadata wins over the meeting type reminders.
+			ID:        uuid.MustParse("55555555-5555-5555-5555-555555555555"),
+			Title:     "Board prep",
+			BlockType: "meeting",
+			StartTime: at(14, 0),
+			EndTime:   at(15, 0),
+			Reminders: []int{60},
+		},
+	}
+
+	result, err := syncer.Sync(context.Background(), uuid.New(), blocks)
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if result.Failed != 0 {
+		t.Fatalf("expected no failures, got %d", result.Failed)
+	}
+
+	for id, file := range golden {
+		expectedBytes, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Fatalf("failed to read golden file: %v", err)
+		}
+		var expected map[string]any
+		if err := json.Unmarshal(expectedBytes, &expected); err != nil {
+			t.Fatalf("invalid golden json in %s: %v", file, err)
+		}
+
+		actual, ok := received[id]
+		if !ok {
+			t.Fatalf("no request received for block %s", id)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			actualJSON, _ := json.MarshalIndent(actual, "", "  ")
+			t.Errorf("payload for %s does not match %s:\n%s", id, file, actualJSON)
+		}
+	}
+}
+
 func TestNewSyncer(t *testing.T) {
 	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
 	provider := stubTokenSourceProvider{source: source}
@@ -494,6 +595,57 @@ func TestSyncer_Sync_MissedBlock(t *testing.T) {
 	}
 }
 
+func TestSyncer_Sync_Attachments(t *testing.T) {
+	var descriptions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		desc, _ := payload["description"].(string)
+		descriptions = append(descriptions, desc)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
+	blocks := []calendarApp.TimeBlock{
+		{
+			ID:        uuid.New(),
+			Title:     "Deep work",
+			BlockType: "focus",
+			StartTime: time.Now().Add(1 * time.Hour),
+			EndTime:   time.Now().Add(2 * time.Hour),
+			Attachments: []calendarApp.Attachment{
+				{Target: "https://docs.example.com/spec", Label: "Spec"},
+				{Target: "~/notes/focus.md"},
+			},
+		},
+	}
+
+	syncer := NewSyncerWithBaseURL(stubTokenSourceProvider{source: source}, nil, server.URL)
+	if _, err := syncer.Sync(context.Background(), uuid.New(), blocks); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	syncer = NewSyncerWithBaseURL(stubTokenSourceProvider{source: source}, nil, server.URL).WithAttachments(true)
+	if _, err := syncer.Sync(context.Background(), uuid.New(), blocks); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+
+	if len(descriptions) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(descriptions))
+	}
+	if descriptions[0] != "Type: focus" {
+		t.Errorf("expected attachments to be left out by default, got %q", descriptions[0])
+	}
+	want := "Type: focus\n\nAttachments:\n- Spec: https://docs.example.com/spec\n- ~/notes/focus.md"
+	if descriptions[1] != want {
+		t.Errorf("expected description %q, got %q", want, descriptions[1])
+	}
+}
+
 func TestSyncer_WithEmptyAttendees(t *testing.T) {
 	var seenAttendees []any
 
@@ -667,6 +819,112 @@ func TestSyncer_DeleteMissingEvents_DeleteFailure(t *testing.T) {
 	}
 }
 
+// newMassDeleteServer serves one kept event plus stale orbita events and
+// counts the DELETE requests it receives.
+func newMassDeleteServer(t *testing.T, keepID uuid.UUID, stale int, deletes *int32) *httptest.Server {
+	t.Helper()
+	items := []map[string]any{{"id": keepID.String()}}
+	for i := 0; i < stale; i++ {
+		items = append(items, map[string]any{"id": uuid.New().String()})
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			if strings.Contains(r.URL.RawQuery, "privateExtendedProperty=orbita=1") {
+				_ = json.NewEncoder(w).Encode(map[string]any{"items": items})
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case http.MethodDelete:
+			atomic.AddInt32(deletes, 1)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func massDeleteBlocks(keepID uuid.UUID) []calendarApp.TimeBlock {
+	return []calendarApp.TimeBlock{
+		{
+			ID:        keepID,
+			Title:     "Keep",
+			BlockType: "task",
+			StartTime: time.Now().Add(1 * time.Hour),
+			EndTime:   time.Now().Add(2 * time.Hour),
+		},
+	}
+}
+
+func TestSyncer_MassDelete_UnderThreshold(t *testing.T) {
+	keepID := uuid.New()
+	var deletes int32
+	server := newMassDeleteServer(t, keepID, 3, &deletes)
+
+	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
+	syncer := NewSyncerWithBaseURL(stubTokenSourceProvider{source: source}, nil, server.URL).
+		WithDeleteMissing(true).
+		WithMassDeleteThreshold(3)
+
+	result, err := syncer.Sync(context.Background(), uuid.New(), massDeleteBlocks(keepID))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Deleted != 3 {
+		t.Errorf("expected 3 deleted, got %d", result.Deleted)
+	}
+	if got := atomic.LoadInt32(&deletes); got != 3 {
+		t.Errorf("expected 3 delete requests, got %d", got)
+	}
+}
+
+func TestSyncer_MassDelete_OverThresholdAborts(t *testing.T) {
+	keepID := uuid.New()
+	var deletes int32
+	server := newMassDeleteServer(t, keepID, 4, &deletes)
+
+	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
+	syncer := NewSyncerWithBaseURL(stubTokenSourceProvider{source: source}, nil, server.URL).
+		WithDeleteMissing(true).
+		WithMassDeleteThreshold(3)
+
+	result, err := syncer.Sync(context.Background(), uuid.New(), massDeleteBlocks(keepID))
+	if !errors.Is(err, calendarApp.ErrMassDeleteNotConfirmed) {
+		t.Fatalf("expected ErrMassDeleteNotConfirmed, got %v", err)
+	}
+	if result == nil || result.Created != 1 {
+		t.Fatalf("expected upserts to be reported, got %+v", result)
+	}
+	if result.Deleted != 0 {
+		t.Errorf("expected 0 deleted, got %d", result.Deleted)
+	}
+	if got := atomic.LoadInt32(&deletes); got != 0 {
+		t.Errorf("expected no delete requests, got %d", got)
+	}
+}
+
+func TestSyncer_MassDelete_Confirmed(t *testing.T) {
+	keepID := uuid.New()
+	var deletes int32
+	server := newMassDeleteServer(t, keepID, 4, &deletes)
+
+	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
+	syncer := NewSyncerWithBaseURL(stubTokenSourceProvider{source: source}, nil, server.URL).
+		WithDeleteMissing(true).
+		WithMassDeleteThreshold(3).
+		WithMassDeleteConfirmed(true)
+
+	result, err := syncer.Sync(context.Background(), uuid.New(), massDeleteBlocks(keepID))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Deleted != 4 {
+		t.Errorf("expected 4 deleted, got %d", result.Deleted)
+	}
+}
+
 func TestSyncer_ListCalendars_Error(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -843,6 +1101,9 @@ func TestSyncer_ListEvents_WithAttendees(t *testing.T) {
 	if !events[0].IsRecurring {
 		t.Error("expected event to be recurring")
 	}
+	if events[0].RecurringEventID != "recurring-123" {
+		t.Errorf("expected recurring event ID 'recurring-123', got %s", events[0].RecurringEventID)
+	}
 }
 
 func TestSyncer_ListEvents_InvalidTimes(t *testing.T) {
@@ -1046,3 +1307,107 @@ func TestSyncer_WithCalendarID_Empty(t *testing.T) {
 		t.Errorf("expected 'primary', got %s", syncer.calendarID)
 	}
 }
+
+func TestSyncer_Sync_AbortsAtDeadline(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
+	syncer := NewSyncerWithBaseURL(stubTokenSourceProvider{source: source}, nil, server.URL)
+
+	blocks := make([]calendarApp.TimeBlock, 3)
+	for i := range blocks {
+		blocks[i] = calendarApp.TimeBlock{
+			ID:        uuid.New(),
+			Title:     fmt.Sprintf("Slow event %d", i),
+			BlockType: "task",
+			StartTime: time.Now().Add(time.Duration(i+1) * time.Hour),
+			EndTime:   time.Now().Add(time.Duration(i+2) * time.Hour),
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result, err := syncer.Sync(ctx, uuid.New(), blocks)
+	elapsed := time.Since(start)
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed > 5*time.Second {
+		t.Fatalf("sync did not abort at the deadline, took %s", elapsed)
+	}
+	if result == nil || result.Failed != 0 || result.Created != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected sync to stop after the first request, got %d", n)
+	}
+}
+
+func TestEventColorID(t *testing.T) {
+	tests := []struct {
+		color string
+		want  string
+	}{
+		{"#dc2127", "11"},
+		{"#4CAF50", "10"},
+		{"#2196F3", "9"},
+		{"", ""},
+		{"green", ""},
+		{"#12345", ""},
+		{"#zzzzzz", ""},
+	}
+	for _, tt := range tests {
+		if got := eventColorID(tt.color); got != tt.want {
+			t.Errorf("eventColorID(%q) = %q, want %q", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestSyncer_Sync_SetsEventColor(t *testing.T) {
+	var seenColor any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		seenColor = payload["colorId"]
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	source := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
+	syncer := NewSyncerWithBaseURL(stubTokenSourceProvider{source: source}, nil, server.URL)
+
+	blocks := []calendarApp.TimeBlock{
+		{
+			ID:        uuid.New(),
+			Title:     "Deep work",
+			BlockType: "focus",
+			StartTime: time.Now().Add(1 * time.Hour),
+			EndTime:   time.Now().Add(2 * time.Hour),
+			Color:     "#4CAF50",
+		},
+	}
+
+	if _, err := syncer.Sync(context.Background(), uuid.New(), blocks); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if seenColor != "10" {
+		t.Errorf("expected colorId 10, got %v", seenColor)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -35,6 +36,13 @@ type tokenSourceProvider interface {
 	TokenSource(ctx context.Context, userID uuid.UUID) (oauth2.TokenSource, error)
 }
 
+// Capabilities declares the optional features the Microsoft Calendar syncer supports.
+func Capabilities() calendarApp.ProviderCapabilities {
+	return calendarApp.ProviderCapabilities{
+		DeleteEvents: true,
+	}
+}
+
 // Syncer syncs schedule blocks to Microsoft Outlook Calendar via Graph API.
 type Syncer struct {
 	oauthService  tokenSourceProvider
@@ -42,6 +50,8 @@ type Syncer struct {
 	baseURL       string
 	deleteMissing bool
 	calendarID    string // "primary" uses default calendar, or specific calendar ID
+	// massDelete limits how many missing events one sync may delete.
+	massDelete calendarApp.MassDeleteGuard
 }
 
 // NewSyncer creates a Microsoft Calendar syncer.
@@ -55,6 +65,7 @@ func NewSyncer(oauthService tokenSourceProvider, logger *slog.Logger) *Syncer {
 		baseURL:       defaultBaseURL,
 		deleteMissing: false,
 		calendarID:    "primary",
+		massDelete:    calendarApp.NewMassDeleteGuard(),
 	}
 }
 
@@ -72,6 +83,7 @@ func NewSyncerWithBaseURL(oauthService tokenSourceProvider, logger *slog.Logger,
 		baseURL:       baseURL,
 		deleteMissing: false,
 		calendarID:    "primary",
+		massDelete:    calendarApp.NewMassDeleteGuard(),
 	}
 }
 
@@ -81,6 +93,20 @@ func (s *Syncer) WithDeleteMissing(enabled bool) *Syncer {
 	return s
 }
 
+// WithMassDeleteThreshold sets how many missing events a sync may delete
+// without confirmation. Zero or less disables the check.
+func (s *Syncer) WithMassDeleteThreshold(threshold int) *Syncer {
+	s.massDelete.Threshold = threshold
+	return s
+}
+
+// WithMassDeleteConfirmed allows a sync to delete more missing events than
+// the threshold.
+func (s *Syncer) WithMassDeleteConfirmed(confirmed bool) *Syncer {
+	s.massDelete.Confirmed = confirmed
+	return s
+}
+
 // WithCalendarID sets the calendar ID for sync.
 func (s *Syncer) WithCalendarID(calendarID string) *Syncer {
 	if calendarID != "" {
@@ -123,6 +149,9 @@ func (s *Syncer) Sync(ctx context.Context, userID uuid.UUID, blocks []calendarAp
 
 	if s.deleteMissing {
 		deleted, err := s.deleteMissingEvents(ctx, client, keepIDs)
+		if errors.Is(err, calendarApp.ErrMassDeleteNotConfirmed) {
+			return result, err
+		}
 		if err != nil {
 			s.logger.Warn("calendar delete missing failed", "error", err)
 		} else {
@@ -534,19 +563,25 @@ func (s *Syncer) deleteMissingEvents(ctx context.Context, client *http.Client, k
 		return 0, err
 	}
 
-	deleted := 0
+	var missing []string
 	for _, item := range payload.Value {
 		// Extract Orbita ID from subject
 		orbitaID := extractOrbitaID(item.Subject)
 		if orbitaID == "" {
 			continue
 		}
-		if _, ok := keepIDs[orbitaID]; ok {
-			continue
+		if _, ok := keepIDs[orbitaID]; !ok {
+			missing = append(missing, item.ID)
 		}
+	}
+	if err := s.massDelete.Check(len(missing)); err != nil {
+		return 0, err
+	}
 
+	deleted := 0
+	for _, id := range missing {
 		// Delete this event
-		deleteURL := fmt.Sprintf("%s/%s", s.eventsURL(), item.ID)
+		deleteURL := fmt.Sprintf("%s/%s", s.eventsURL(), id)
 		req, err := http.NewRequestWithContext(ctx, http.MethodDelete, deleteURL, nil)
 		if err != nil {
 			return deleted, err
@@ -0,0 +1,44 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	"github.com/google/uuid"
+)
+
+// importedBlockRecord is the stored form of a domain.ImportedBlock.
+type importedBlockRecord struct {
+	BlockID uuid.UUID `json:"block_id"`
+	Start   time.Time `json:"start"`
+}
+
+// encodeImportedBlocks serializes event-to-block links as a JSON object keyed
+// by external event ID.
+func encodeImportedBlocks(blocks map[string]domain.ImportedBlock) ([]byte, error) {
+	records := make(map[string]importedBlockRecord, len(blocks))
+	for eventID, block := range blocks {
+		records[eventID] = importedBlockRecord{BlockID: block.BlockID, Start: block.Start}
+	}
+	return json.Marshal(records)
+}
+
+// decodeImportedBlocks parses links written by encodeImportedBlocks. Empty
+// input yields no links.
+func decodeImportedBlocks(data []byte) (map[string]domain.ImportedBlock, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var records map[string]importedBlockRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+
+	blocks := make(map[string]domain.ImportedBlock, len(records))
+	for eventID, record := range records {
+		blocks[eventID] = domain.ImportedBlock{BlockID: record.BlockID, Start: record.Start}
+	}
+	return blocks, nil
+}
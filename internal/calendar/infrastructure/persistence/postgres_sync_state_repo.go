@@ -27,9 +27,9 @@ func (r *PostgresSyncStateRepository) Save(ctx context.Context, state *domain.Sy
 		INSERT INTO calendar_sync_state (
 			id, user_id, calendar_id, provider, sync_token,
 			last_synced_at, last_sync_hash, sync_errors, last_error,
-			created_at, updated_at
+			imported_blocks, resync_progress, next_attempt_at, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		ON CONFLICT (user_id, calendar_id) DO UPDATE SET
 			provider = EXCLUDED.provider,
 			sync_token = EXCLUDED.sync_token,
@@ -37,6 +37,9 @@ func (r *PostgresSyncStateRepository) Save(ctx context.Context, state *domain.Sy
 			last_sync_hash = EXCLUDED.last_sync_hash,
 			sync_errors = EXCLUDED.sync_errors,
 			last_error = EXCLUDED.last_error,
+			imported_blocks = EXCLUDED.imported_blocks,
+			resync_progress = EXCLUDED.resync_progress,
+			next_attempt_at = EXCLUDED.next_attempt_at,
 			updated_at = EXCLUDED.updated_at
 	`
 
@@ -46,7 +49,22 @@ func (r *PostgresSyncStateRepository) Save(ctx context.Context, state *domain.Sy
 		lastSyncedAt = &t
 	}
 
-	_, err := r.pool.Exec(ctx, query,
+	var nextAttemptAt *time.Time
+	if !state.NextAttemptAt().IsZero() {
+		t := state.NextAttemptAt()
+		nextAttemptAt = &t
+	}
+
+	importedBlocks, err := encodeImportedBlocks(state.ImportedBlocks())
+	if err != nil {
+		return err
+	}
+	resyncProgress, err := encodeResyncProgress(state.Resync())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pool.Exec(ctx, query,
 		state.ID(),
 		state.UserID(),
 		state.CalendarID(),
@@ -56,6 +74,9 @@ func (r *PostgresSyncStateRepository) Save(ctx context.Context, state *domain.Sy
 		nullString(state.LastSyncHash()),
 		state.SyncErrors(),
 		nullString(state.LastError()),
+		importedBlocks,
+		resyncProgress,
+		nextAttemptAt,
 		state.CreatedAt(),
 		state.UpdatedAt(),
 	)
@@ -67,7 +88,7 @@ func (r *PostgresSyncStateRepository) FindByUserAndCalendar(ctx context.Context,
 	query := `
 		SELECT id, user_id, calendar_id, provider, sync_token,
 			   last_synced_at, last_sync_hash, sync_errors, last_error,
-			   created_at, updated_at
+			   imported_blocks, resync_progress, next_attempt_at, created_at, updated_at
 		FROM calendar_sync_state
 		WHERE user_id = $1 AND calendar_id = $2
 	`
@@ -81,7 +102,7 @@ func (r *PostgresSyncStateRepository) FindByUser(ctx context.Context, userID uui
 	query := `
 		SELECT id, user_id, calendar_id, provider, sync_token,
 			   last_synced_at, last_sync_hash, sync_errors, last_error,
-			   created_at, updated_at
+			   imported_blocks, resync_progress, next_attempt_at, created_at, updated_at
 		FROM calendar_sync_state
 		WHERE user_id = $1
 		ORDER BY calendar_id
@@ -112,15 +133,15 @@ func (r *PostgresSyncStateRepository) FindPendingSync(ctx context.Context, older
 	query := `
 		SELECT id, user_id, calendar_id, provider, sync_token,
 			   last_synced_at, last_sync_hash, sync_errors, last_error,
-			   created_at, updated_at
+			   imported_blocks, resync_progress, next_attempt_at, created_at, updated_at
 		FROM calendar_sync_state
 		WHERE (last_synced_at IS NULL OR last_synced_at < $1)
-		  AND sync_errors < 5
+		  AND (next_attempt_at IS NULL OR next_attempt_at <= $2)
 		ORDER BY last_synced_at NULLS FIRST, sync_errors ASC
-		LIMIT $2
+		LIMIT $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, cutoff, limit)
+	rows, err := r.pool.Query(ctx, query, cutoff, time.Now(), limit)
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +177,9 @@ func (r *PostgresSyncStateRepository) scanSyncState(row pgx.Row) (*domain.SyncSt
 		lastSyncHash sql.NullString
 		syncErrors   int
 		lastError    sql.NullString
+		importedJSON []byte
+		resyncJSON   []byte
+		nextAttempt  sql.NullTime
 		createdAt    time.Time
 		updatedAt    time.Time
 	)
@@ -163,7 +187,7 @@ func (r *PostgresSyncStateRepository) scanSyncState(row pgx.Row) (*domain.SyncSt
 	err := row.Scan(
 		&id, &userID, &calendarID, &provider, &syncToken,
 		&lastSyncedAt, &lastSyncHash, &syncErrors, &lastError,
-		&createdAt, &updatedAt,
+		&importedJSON, &resyncJSON, &nextAttempt, &createdAt, &updatedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -172,15 +196,29 @@ func (r *PostgresSyncStateRepository) scanSyncState(row pgx.Row) (*domain.SyncSt
 		return nil, err
 	}
 
-	return domain.RehydrateSyncState(
+	importedBlocks, err := decodeImportedBlocks(importedJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	resync, err := decodeResyncProgress(resyncJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	state := domain.RehydrateSyncState(
 		id, userID, calendarID, provider,
 		syncToken.String,
 		lastSyncedAt.Time,
 		lastSyncHash.String,
 		syncErrors,
 		lastError.String,
+		importedBlocks,
 		createdAt, updatedAt,
-	), nil
+	)
+	state.RehydrateResync(resync)
+	state.RehydrateNextAttempt(nextAttempt.Time)
+	return state, nil
 }
 
 func (r *PostgresSyncStateRepository) scanSyncStateRows(rows pgx.Rows) (*domain.SyncState, error) {
@@ -194,6 +232,9 @@ func (r *PostgresSyncStateRepository) scanSyncStateRows(rows pgx.Rows) (*domain.
 		lastSyncHash sql.NullString
 		syncErrors   int
 		lastError    sql.NullString
+		importedJSON []byte
+		resyncJSON   []byte
+		nextAttempt  sql.NullTime
 		createdAt    time.Time
 		updatedAt    time.Time
 	)
@@ -201,21 +242,35 @@ func (r *PostgresSyncStateRepository) scanSyncStateRows(rows pgx.Rows) (*domain.
 	err := rows.Scan(
 		&id, &userID, &calendarID, &provider, &syncToken,
 		&lastSyncedAt, &lastSyncHash, &syncErrors, &lastError,
-		&createdAt, &updatedAt,
+		&importedJSON, &resyncJSON, &nextAttempt, &createdAt, &updatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return domain.RehydrateSyncState(
+	importedBlocks, err := decodeImportedBlocks(importedJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	resync, err := decodeResyncProgress(resyncJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	state := domain.RehydrateSyncState(
 		id, userID, calendarID, provider,
 		syncToken.String,
 		lastSyncedAt.Time,
 		lastSyncHash.String,
 		syncErrors,
 		lastError.String,
+		importedBlocks,
 		createdAt, updatedAt,
-	), nil
+	)
+	state.RehydrateResync(resync)
+	state.RehydrateNextAttempt(nextAttempt.Time)
+	return state, nil
 }
 
 // nullString converts a string to sql.NullString.
@@ -0,0 +1,43 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	"github.com/google/uuid"
+)
+
+// resyncProgressRecord is the stored form of a domain.ResyncProgress.
+type resyncProgressRecord struct {
+	StartedAt time.Time   `json:"started_at"`
+	Pushed    []uuid.UUID `json:"pushed"`
+}
+
+// encodeResyncProgress serializes the progress of a running resync. No
+// running resync is stored as NULL.
+func encodeResyncProgress(progress *domain.ResyncProgress) (*string, error) {
+	if progress == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(resyncProgressRecord{StartedAt: progress.StartedAt, Pushed: progress.Pushed})
+	if err != nil {
+		return nil, err
+	}
+	encoded := string(data)
+	return &encoded, nil
+}
+
+// decodeResyncProgress parses progress written by encodeResyncProgress.
+// Empty input yields no running resync.
+func decodeResyncProgress(data []byte) (*domain.ResyncProgress, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var record resyncProgressRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return &domain.ResyncProgress{StartedAt: record.StartedAt, Pushed: record.Pushed}, nil
+}
@@ -57,6 +57,30 @@ func setupCalendarTestDB(t *testing.T) *sql.DB {
 	_, err = sqlDB.Exec(string(versionSchema))
 	require.NoError(t, err, "Failed to apply connected_calendars_version migration")
 
+	// Apply imported blocks migration for import idempotency
+	importedPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", "000011_calendar_imported_blocks.up.sql")
+	importedSchema, err := os.ReadFile(importedPath)
+	require.NoError(t, err, "Failed to read calendar_imported_blocks migration")
+
+	_, err = sqlDB.Exec(string(importedSchema))
+	require.NoError(t, err, "Failed to apply calendar_imported_blocks migration")
+
+	// Apply resync progress migration for resumable full resyncs
+	resyncPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", "000020_calendar_resync_progress.up.sql")
+	resyncSchema, err := os.ReadFile(resyncPath)
+	require.NoError(t, err, "Failed to read calendar_resync_progress migration")
+
+	_, err = sqlDB.Exec(string(resyncSchema))
+	require.NoError(t, err, "Failed to apply calendar_resync_progress migration")
+
+	// Apply next attempt migration for per-calendar sync backoff
+	nextAttemptPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", "000036_calendar_sync_next_attempt.up.sql")
+	nextAttemptSchema, err := os.ReadFile(nextAttemptPath)
+	require.NoError(t, err, "Failed to read calendar_sync_next_attempt migration")
+
+	_, err = sqlDB.Exec(string(nextAttemptSchema))
+	require.NoError(t, err, "Failed to apply calendar_sync_next_attempt migration")
+
 	return sqlDB
 }
 
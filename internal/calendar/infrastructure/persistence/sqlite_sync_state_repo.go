@@ -25,9 +25,9 @@ func (r *SQLiteSyncStateRepository) Save(ctx context.Context, state *domain.Sync
 		INSERT INTO calendar_sync_state (
 			id, user_id, calendar_id, provider, sync_token,
 			last_synced_at, last_sync_hash, sync_errors, last_error,
-			created_at, updated_at
+			imported_blocks, resync_progress, next_attempt_at, created_at, updated_at
 		)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT (user_id, calendar_id) DO UPDATE SET
 			provider = excluded.provider,
 			sync_token = excluded.sync_token,
@@ -35,6 +35,9 @@ func (r *SQLiteSyncStateRepository) Save(ctx context.Context, state *domain.Sync
 			last_sync_hash = excluded.last_sync_hash,
 			sync_errors = excluded.sync_errors,
 			last_error = excluded.last_error,
+			imported_blocks = excluded.imported_blocks,
+			resync_progress = excluded.resync_progress,
+			next_attempt_at = excluded.next_attempt_at,
 			updated_at = excluded.updated_at
 	`
 
@@ -44,6 +47,13 @@ func (r *SQLiteSyncStateRepository) Save(ctx context.Context, state *domain.Sync
 		lastSyncedAt = &t
 	}
 
+	// Stored in UTC so FindPendingSync can compare it as text.
+	var nextAttemptAt *string
+	if !state.NextAttemptAt().IsZero() {
+		t := state.NextAttemptAt().UTC().Format(time.RFC3339)
+		nextAttemptAt = &t
+	}
+
 	var syncToken, lastSyncHash, lastError *string
 	if s := state.SyncToken(); s != "" {
 		syncToken = &s
@@ -55,7 +65,16 @@ func (r *SQLiteSyncStateRepository) Save(ctx context.Context, state *domain.Sync
 		lastError = &s
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	importedBlocks, err := encodeImportedBlocks(state.ImportedBlocks())
+	if err != nil {
+		return err
+	}
+	resyncProgress, err := encodeResyncProgress(state.Resync())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
 		state.ID().String(),
 		state.UserID().String(),
 		state.CalendarID(),
@@ -65,6 +84,9 @@ func (r *SQLiteSyncStateRepository) Save(ctx context.Context, state *domain.Sync
 		lastSyncHash,
 		state.SyncErrors(),
 		lastError,
+		string(importedBlocks),
+		resyncProgress,
+		nextAttemptAt,
 		state.CreatedAt().Format(time.RFC3339),
 		state.UpdatedAt().Format(time.RFC3339),
 	)
@@ -76,7 +98,7 @@ func (r *SQLiteSyncStateRepository) FindByUserAndCalendar(ctx context.Context, u
 	query := `
 		SELECT id, user_id, calendar_id, provider, sync_token,
 			   last_synced_at, last_sync_hash, sync_errors, last_error,
-			   created_at, updated_at
+			   imported_blocks, resync_progress, next_attempt_at, created_at, updated_at
 		FROM calendar_sync_state
 		WHERE user_id = ? AND calendar_id = ?
 	`
@@ -90,7 +112,7 @@ func (r *SQLiteSyncStateRepository) FindByUser(ctx context.Context, userID uuid.
 	query := `
 		SELECT id, user_id, calendar_id, provider, sync_token,
 			   last_synced_at, last_sync_hash, sync_errors, last_error,
-			   created_at, updated_at
+			   imported_blocks, resync_progress, next_attempt_at, created_at, updated_at
 		FROM calendar_sync_state
 		WHERE user_id = ?
 		ORDER BY calendar_id
@@ -121,15 +143,16 @@ func (r *SQLiteSyncStateRepository) FindPendingSync(ctx context.Context, olderTh
 	query := `
 		SELECT id, user_id, calendar_id, provider, sync_token,
 			   last_synced_at, last_sync_hash, sync_errors, last_error,
-			   created_at, updated_at
+			   imported_blocks, resync_progress, next_attempt_at, created_at, updated_at
 		FROM calendar_sync_state
 		WHERE (last_synced_at IS NULL OR last_synced_at < ?)
-		  AND sync_errors < 5
+		  AND (next_attempt_at IS NULL OR next_attempt_at <= ?)
 		ORDER BY CASE WHEN last_synced_at IS NULL THEN 0 ELSE 1 END, last_synced_at, sync_errors ASC
 		LIMIT ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, cutoff, limit)
+	now := time.Now().UTC().Format(time.RFC3339)
+	rows, err := r.db.QueryContext(ctx, query, cutoff, now, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +188,9 @@ func (r *SQLiteSyncStateRepository) scanSyncState(row *sql.Row) (*domain.SyncSta
 		lastSyncHash  sql.NullString
 		syncErrors    int
 		lastError     sql.NullString
+		importedJSON  string
+		resyncJSON    sql.NullString
+		nextAttempt   sql.NullString
 		createdAtStr  string
 		updatedAtStr  string
 	)
@@ -172,7 +198,7 @@ func (r *SQLiteSyncStateRepository) scanSyncState(row *sql.Row) (*domain.SyncSta
 	err := row.Scan(
 		&idStr, &userIDStr, &calendarID, &provider, &syncToken,
 		&lastSyncedAt, &lastSyncHash, &syncErrors, &lastError,
-		&createdAtStr, &updatedAtStr,
+		&importedJSON, &resyncJSON, &nextAttempt, &createdAtStr, &updatedAtStr,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -184,7 +210,7 @@ func (r *SQLiteSyncStateRepository) scanSyncState(row *sql.Row) (*domain.SyncSta
 	return r.buildSyncState(
 		idStr, userIDStr, calendarID, provider,
 		syncToken, lastSyncedAt, lastSyncHash,
-		syncErrors, lastError,
+		syncErrors, lastError, importedJSON, resyncJSON, nextAttempt,
 		createdAtStr, updatedAtStr,
 	)
 }
@@ -200,6 +226,9 @@ func (r *SQLiteSyncStateRepository) scanSyncStateRows(rows *sql.Rows) (*domain.S
 		lastSyncHash  sql.NullString
 		syncErrors    int
 		lastError     sql.NullString
+		importedJSON  string
+		resyncJSON    sql.NullString
+		nextAttempt   sql.NullString
 		createdAtStr  string
 		updatedAtStr  string
 	)
@@ -207,7 +236,7 @@ func (r *SQLiteSyncStateRepository) scanSyncStateRows(rows *sql.Rows) (*domain.S
 	err := rows.Scan(
 		&idStr, &userIDStr, &calendarID, &provider, &syncToken,
 		&lastSyncedAt, &lastSyncHash, &syncErrors, &lastError,
-		&createdAtStr, &updatedAtStr,
+		&importedJSON, &resyncJSON, &nextAttempt, &createdAtStr, &updatedAtStr,
 	)
 	if err != nil {
 		return nil, err
@@ -216,7 +245,7 @@ func (r *SQLiteSyncStateRepository) scanSyncStateRows(rows *sql.Rows) (*domain.S
 	return r.buildSyncState(
 		idStr, userIDStr, calendarID, provider,
 		syncToken, lastSyncedAt, lastSyncHash,
-		syncErrors, lastError,
+		syncErrors, lastError, importedJSON, resyncJSON, nextAttempt,
 		createdAtStr, updatedAtStr,
 	)
 }
@@ -226,6 +255,9 @@ func (r *SQLiteSyncStateRepository) buildSyncState(
 	syncToken, lastSyncedAtStr, lastSyncHash sql.NullString,
 	syncErrors int,
 	lastError sql.NullString,
+	importedJSON string,
+	resyncJSON sql.NullString,
+	nextAttemptStr sql.NullString,
 	createdAtStr, updatedAtStr string,
 ) (*domain.SyncState, error) {
 	id, err := uuid.Parse(idStr)
@@ -256,13 +288,35 @@ func (r *SQLiteSyncStateRepository) buildSyncState(
 		}
 	}
 
-	return domain.RehydrateSyncState(
+	var nextAttemptAt time.Time
+	if nextAttemptStr.Valid {
+		nextAttemptAt, err = time.Parse(time.RFC3339, nextAttemptStr.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	importedBlocks, err := decodeImportedBlocks([]byte(importedJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	resync, err := decodeResyncProgress([]byte(resyncJSON.String))
+	if err != nil {
+		return nil, err
+	}
+
+	state := domain.RehydrateSyncState(
 		id, userID, calendarID, provider,
 		syncToken.String,
 		lastSyncedAt,
 		lastSyncHash.String,
 		syncErrors,
 		lastError.String,
+		importedBlocks,
 		createdAt, updatedAt,
-	), nil
+	)
+	state.RehydrateResync(resync)
+	state.RehydrateNextAttempt(nextAttemptAt)
+	return state, nil
 }
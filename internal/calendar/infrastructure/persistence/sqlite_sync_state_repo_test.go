@@ -66,6 +66,69 @@ func TestSQLiteSyncStateRepository_Save_Update(t *testing.T) {
 	assert.False(t, found.LastSyncedAt().IsZero())
 }
 
+func TestSQLiteSyncStateRepository_ImportedBlocks(t *testing.T) {
+	sqlDB := setupCalendarTestDB(t)
+	defer sqlDB.Close()
+
+	repo := NewSQLiteSyncStateRepository(sqlDB)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	state := domain.NewSyncState(userID, "primary", "google")
+	blockID := uuid.New()
+	start := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	state.LinkImportedBlock("event-1", domain.ImportedBlock{BlockID: blockID, Start: start})
+	require.NoError(t, repo.Save(ctx, state))
+
+	found, err := repo.FindByUserAndCalendar(ctx, userID, "primary")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	block, ok := found.ImportedBlockFor("event-1")
+	require.True(t, ok)
+	assert.Equal(t, blockID, block.BlockID)
+	assert.True(t, start.Equal(block.Start))
+
+	found.UnlinkImportedBlock("event-1")
+	require.NoError(t, repo.Save(ctx, found))
+
+	found, err = repo.FindByUserAndCalendar(ctx, userID, "primary")
+	require.NoError(t, err)
+	assert.Empty(t, found.ImportedBlocks())
+}
+
+func TestSQLiteSyncStateRepository_ResyncProgress(t *testing.T) {
+	sqlDB := setupCalendarTestDB(t)
+	defer sqlDB.Close()
+
+	repo := NewSQLiteSyncStateRepository(sqlDB)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	state := domain.NewSyncState(userID, "primary", "google")
+	state.MarkSyncSuccess("token123", "hash456")
+	state.StartResync()
+	first, second := uuid.New(), uuid.New()
+	state.MarkResynced(first)
+	state.MarkResynced(second)
+	require.NoError(t, repo.Save(ctx, state))
+
+	found, err := repo.FindByUserAndCalendar(ctx, userID, "primary")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	require.True(t, found.IsResyncing())
+	assert.Empty(t, found.SyncToken())
+	assert.Equal(t, []uuid.UUID{first, second}, found.Resync().Pushed)
+	assert.WithinDuration(t, state.Resync().StartedAt, found.Resync().StartedAt, time.Second)
+
+	found.CompleteResync()
+	require.NoError(t, repo.Save(ctx, found))
+
+	found, err = repo.FindByUserAndCalendar(ctx, userID, "primary")
+	require.NoError(t, err)
+	assert.False(t, found.IsResyncing())
+	assert.Nil(t, found.Resync())
+}
+
 func TestSQLiteSyncStateRepository_Save_WithError(t *testing.T) {
 	sqlDB := setupCalendarTestDB(t)
 	defer sqlDB.Close()
@@ -173,7 +236,7 @@ func TestSQLiteSyncStateRepository_FindPendingSync(t *testing.T) {
 	assert.True(t, foundNeverSynced, "Never-synced calendar should be in pending sync list")
 }
 
-func TestSQLiteSyncStateRepository_FindPendingSync_ExcludesHighErrors(t *testing.T) {
+func TestSQLiteSyncStateRepository_FindPendingSync_RetriesFailingCalendarAfterBackoff(t *testing.T) {
 	sqlDB := setupCalendarTestDB(t)
 	defer sqlDB.Close()
 
@@ -182,20 +245,36 @@ func TestSQLiteSyncStateRepository_FindPendingSync_ExcludesHighErrors(t *testing
 
 	userID := uuid.New()
 
-	// Create a state with many errors (>= 5 should be excluded)
+	// A calendar that failed many times in a row is backing off
 	errorState := domain.NewSyncState(userID, "error-cal", "google")
-	for i := 0; i < 5; i++ {
+	for i := 0; i < 7; i++ {
 		errorState.MarkSyncFailure("error " + string(rune('0'+i)))
 	}
+	errorState.DeferNextAttempt(time.Now().Add(time.Hour))
 	require.NoError(t, repo.Save(ctx, errorState))
 
-	// Find pending - should not include the error state
-	states, err := repo.FindPendingSync(ctx, 1*time.Minute, 10)
-	require.NoError(t, err)
-
-	for _, s := range states {
-		assert.NotEqual(t, "error-cal", s.CalendarID(), "Calendar with 5+ errors should not be in pending sync")
+	pendingIDs := func() []string {
+		states, err := repo.FindPendingSync(ctx, 1*time.Minute, 10)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(states))
+		for _, s := range states {
+			ids = append(ids, s.CalendarID())
+		}
+		return ids
 	}
+
+	assert.NotContains(t, pendingIDs(), "error-cal", "Calendar should not be retried while backing off")
+
+	// Once its backoff expires it is picked up again, however many errors it had
+	errorState.DeferNextAttempt(time.Now().Add(-time.Second))
+	require.NoError(t, repo.Save(ctx, errorState))
+
+	assert.Contains(t, pendingIDs(), "error-cal", "Calendar should be retried once its backoff expires")
+
+	found, err := repo.FindByUserAndCalendar(ctx, userID, "error-cal")
+	require.NoError(t, err)
+	assert.Equal(t, 7, found.SyncErrors())
+	assert.WithinDuration(t, errorState.NextAttemptAt(), found.NextAttemptAt(), time.Second)
 }
 
 func TestSQLiteSyncStateRepository_FindPendingSync_RespectsLimit(t *testing.T) {
@@ -19,6 +19,37 @@ type OAuthTokenProvider interface {
 	TokenSource(ctx context.Context, userID uuid.UUID) (oauth2.TokenSource, error)
 }
 
+// AccountTokenProvider provides OAuth2 tokens for one of several accounts a
+// user connected with the same provider.
+type AccountTokenProvider interface {
+	TokenSourceForAccount(ctx context.Context, userID uuid.UUID, account string) (oauth2.TokenSource, error)
+}
+
+// accountTokenProvider serves the tokens of one account to syncers that ask
+// for a user's tokens.
+type accountTokenProvider struct {
+	provider AccountTokenProvider
+	account  string
+}
+
+func (p accountTokenProvider) TokenSource(ctx context.Context, userID uuid.UUID) (oauth2.TokenSource, error) {
+	return p.provider.TokenSourceForAccount(ctx, userID, p.account)
+}
+
+// tokenProviderFor returns the token provider for the account a calendar
+// belongs to. Calendars of the default account use the provider as is.
+func tokenProviderFor(provider OAuthTokenProvider, cal *domain.ConnectedCalendar) (OAuthTokenProvider, error) {
+	account := cal.Account()
+	if account == "" {
+		return provider, nil
+	}
+	accounts, ok := provider.(AccountTokenProvider)
+	if !ok {
+		return nil, fmt.Errorf("%s does not support multiple accounts", cal.Provider().DisplayName())
+	}
+	return accountTokenProvider{provider: accounts, account: account}, nil
+}
+
 // CalDAVCredentialProvider provides CalDAV credentials for a user.
 type CalDAVCredentialProvider interface {
 	GetCredentials(ctx context.Context, userID uuid.UUID, provider domain.ProviderType) (username, password string, err error)
@@ -42,24 +73,34 @@ func RegisterProviders(registry *application.ProviderRegistry, config ProviderCo
 	// Register Google Calendar provider
 	if config.GoogleOAuth != nil {
 		registry.RegisterBidirectional(domain.ProviderGoogle, func(ctx context.Context, cal *domain.ConnectedCalendar) (application.BidirectionalSyncer, error) {
-			syncer := googleCal.NewSyncer(config.GoogleOAuth, logger)
+			tokens, err := tokenProviderFor(config.GoogleOAuth, cal)
+			if err != nil {
+				return nil, err
+			}
+			syncer := googleCal.NewSyncer(tokens, logger)
 			if cal.CalendarID() != "" && cal.CalendarID() != "primary" {
 				syncer.WithCalendarID(cal.CalendarID())
 			}
 			return syncer, nil
 		})
+		registry.RegisterCapabilities(domain.ProviderGoogle, googleCal.Capabilities())
 		logger.Debug("registered Google Calendar provider")
 	}
 
 	// Register Microsoft Calendar provider
 	if config.MicrosoftOAuth != nil {
 		registry.RegisterBidirectional(domain.ProviderMicrosoft, func(ctx context.Context, cal *domain.ConnectedCalendar) (application.BidirectionalSyncer, error) {
-			syncer := microsoftCal.NewSyncer(config.MicrosoftOAuth, logger)
+			tokens, err := tokenProviderFor(config.MicrosoftOAuth, cal)
+			if err != nil {
+				return nil, err
+			}
+			syncer := microsoftCal.NewSyncer(tokens, logger)
 			if cal.CalendarID() != "" && cal.CalendarID() != "primary" {
 				syncer.WithCalendarID(cal.CalendarID())
 			}
 			return syncer, nil
 		})
+		registry.RegisterCapabilities(domain.ProviderMicrosoft, microsoftCal.Capabilities())
 		logger.Debug("registered Microsoft Calendar provider")
 	}
 
@@ -83,6 +124,7 @@ func RegisterProviders(registry *application.ProviderRegistry, config ProviderCo
 			}
 			return syncer, nil
 		})
+		registry.RegisterCapabilities(domain.ProviderApple, caldav.Capabilities())
 		logger.Debug("registered Apple Calendar provider")
 
 		// Generic CalDAV (Fastmail, Nextcloud, etc.)
@@ -103,6 +145,7 @@ func RegisterProviders(registry *application.ProviderRegistry, config ProviderCo
 			}
 			return syncer, nil
 		})
+		registry.RegisterCapabilities(domain.ProviderCalDAV, caldav.Capabilities())
 		logger.Debug("registered CalDAV provider")
 	}
 }
@@ -61,6 +61,27 @@ func TestRegisterProviders_AllProviders(t *testing.T) {
 	assert.True(t, registry.HasProvider(domain.ProviderCalDAV))
 }
 
+func TestRegisterProviders_DeclaresCapabilities(t *testing.T) {
+	registry := application.NewProviderRegistry()
+	RegisterProviders(registry, ProviderConfig{
+		GoogleOAuth:    &mockOAuthProvider{},
+		MicrosoftOAuth: &mockOAuthProvider{},
+		CalDAVCreds:    &mockCalDAVCredProvider{username: "user", password: "pass"},
+	})
+
+	google := registry.Capabilities(domain.ProviderGoogle)
+	assert.True(t, google.DeleteEvents)
+	assert.True(t, google.Reminders)
+	assert.True(t, google.Colors)
+
+	for _, provider := range []domain.ProviderType{domain.ProviderMicrosoft, domain.ProviderApple, domain.ProviderCalDAV} {
+		caps := registry.Capabilities(provider)
+		assert.True(t, caps.DeleteEvents, provider)
+		assert.False(t, caps.Reminders, provider)
+		assert.False(t, caps.Colors, provider)
+	}
+}
+
 func TestRegisterProviders_OnlyGoogle(t *testing.T) {
 	registry := application.NewProviderRegistry()
 	config := ProviderConfig{
@@ -378,3 +399,52 @@ func TestRegisterProviders_CalDAVFactoryWithCalendarPath(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, syncer)
 }
+
+// Mock OAuth token provider with a token per account
+type mockAccountOAuthProvider struct {
+	mockOAuthProvider
+	tokens map[string]string
+}
+
+func (m *mockAccountOAuthProvider) TokenSourceForAccount(ctx context.Context, userID uuid.UUID, account string) (oauth2.TokenSource, error) {
+	token, ok := m.tokens[account]
+	if !ok {
+		return nil, errors.New("account not connected")
+	}
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}), nil
+}
+
+func TestTokenProviderFor_UsesCalendarAccount(t *testing.T) {
+	userID := uuid.New()
+	provider := &mockAccountOAuthProvider{
+		mockOAuthProvider: mockOAuthProvider{token: &oauth2.Token{AccessToken: "default-token"}},
+		tokens:            map[string]string{"work": "work-token", "personal": "personal-token"},
+	}
+
+	accessToken := func(account string) string {
+		cal, err := domain.NewConnectedCalendar(userID, domain.ProviderGoogle, "primary", "Calendar")
+		require.NoError(t, err)
+		cal.SetAccount(account)
+
+		tokens, err := tokenProviderFor(provider, cal)
+		require.NoError(t, err)
+		source, err := tokens.TokenSource(context.Background(), userID)
+		require.NoError(t, err)
+		token, err := source.Token()
+		require.NoError(t, err)
+		return token.AccessToken
+	}
+
+	assert.Equal(t, "work-token", accessToken("work"))
+	assert.Equal(t, "personal-token", accessToken("personal"))
+	assert.Equal(t, "default-token", accessToken(""))
+}
+
+func TestTokenProviderFor_AccountWithoutSupport(t *testing.T) {
+	cal, err := domain.NewConnectedCalendar(uuid.New(), domain.ProviderGoogle, "primary", "Work")
+	require.NoError(t, err)
+	cal.SetAccount("work")
+
+	_, err = tokenProviderFor(&mockOAuthProvider{}, cal)
+	assert.Error(t, err)
+}
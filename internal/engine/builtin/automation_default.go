@@ -462,6 +462,7 @@ func (e *DefaultAutomationEngine) GetSupportedActions(ctx *sdk.ExecutionContext)
 				{Name: "duration", Type: "string", Required: true, Description: "Block duration"},
 			},
 		},
+		inboxPromoteAction,
 	}, nil
 }
 
@@ -473,9 +474,27 @@ func (e *DefaultAutomationEngine) getSupportedActionTypes() []string {
 		"task.complete",
 		"notification.send",
 		"schedule.block",
+		"inbox.promote",
 	}
 }
 
+// inboxPromoteAction describes the inbox.promote action shared by the built-in
+// automation engines.
+var inboxPromoteAction = types.ActionDefinition{
+	Type:        "inbox.promote",
+	Name:        "Promote Inbox Item",
+	Description: "Promotes an inbox item to a task, habit or meeting",
+	Parameters: []types.ParameterDefinition{
+		{Name: "item_id", Type: "string", Required: true, Description: "Inbox item ID to promote"},
+		{Name: "target", Type: "string", Required: false, Description: "Promote to task, habit or meeting", Default: "task"},
+		{Name: "title", Type: "string", Required: false, Description: "Name of the promoted entity (defaults to the item content)"},
+		{Name: "priority", Type: "string", Required: false, Description: "Task priority"},
+		{Name: "duration", Type: "integer", Required: false, Description: "Duration in minutes"},
+		{Name: "frequency", Type: "string", Required: false, Description: "Habit frequency"},
+		{Name: "cadence", Type: "string", Required: false, Description: "Meeting cadence"},
+	},
+}
+
 // matchesWildcard checks if a pattern with wildcards matches a string.
 func matchesWildcard(pattern, s string) bool {
 	if pattern == "*" {
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
@@ -96,6 +97,19 @@ func (e *AutomationEnginePro) ConfigSchema() sdk.ConfigSchema {
 					Order:  3,
 				},
 			},
+			"evaluation_concurrency": {
+				Type:        "integer",
+				Title:       "Evaluation Concurrency",
+				Description: "Number of rules matched in parallel (1 evaluates rules sequentially)",
+				Default:     1,
+				Minimum:     floatPtr(1),
+				Maximum:     floatPtr(32),
+				UIHints: sdk.UIHints{
+					Widget: "number",
+					Group:  "Evaluation",
+					Order:  4,
+				},
+			},
 
 			// Action Settings
 			"max_actions_per_rule": {
@@ -219,56 +233,32 @@ func (e *AutomationEnginePro) Evaluate(ctx *sdk.ExecutionContext, input types.Au
 
 	// Sort rules by priority
 	rules := e.sortRulesByPriority(input.Rules)
+	if len(rules) > maxRules {
+		rules = rules[:maxRules]
+	}
 
-	// Evaluate each rule
-	for i, rule := range rules {
-		if i >= maxRules {
-			break
-		}
-
-		if !rule.Enabled {
-			output.SkippedRules = append(output.SkippedRules, types.SkippedRule{
-				RuleID:   rule.ID,
-				RuleName: rule.Name,
-				Reason:   "rule is disabled",
-			})
-			continue
-		}
-
-		// Check trigger
-		triggerMatch, triggerReason := e.evaluateTrigger(rule.Trigger, input.Event, input.Context)
-		if !triggerMatch {
-			output.SkippedRules = append(output.SkippedRules, types.SkippedRule{
-				RuleID:   rule.ID,
-				RuleName: rule.Name,
-				Reason:   "trigger did not match: " + triggerReason,
-			})
-			continue
+	// Rules are matched independently, so with concurrency enabled they are
+	// all matched up front. Results are still collected in priority order
+	// below, which keeps triggering and stop-on-match identical to the
+	// sequential path.
+	evaluate := func(i int) ruleEvaluation {
+		return e.evaluateRule(rules[i], input)
+	}
+	if concurrency := e.getInt("evaluation_concurrency", 1); concurrency > 1 && len(rules) > 1 {
+		evaluations := e.evaluateRulesConcurrently(rules, input, concurrency)
+		evaluate = func(i int) ruleEvaluation {
+			return evaluations[i]
 		}
+	}
 
-		// Check conditions
-		conditionMatch, failedCondition := e.evaluateConditions(rule.Conditions, input.Event, input.Context)
-		if !conditionMatch {
-			output.SkippedRules = append(output.SkippedRules, types.SkippedRule{
-				RuleID:          rule.ID,
-				RuleName:        rule.Name,
-				Reason:          "condition not met",
-				FailedCondition: failedCondition,
-			})
+	for i, rule := range rules {
+		evaluation := evaluate(i)
+		if evaluation.skipped != nil {
+			output.SkippedRules = append(output.SkippedRules, *evaluation.skipped)
 			continue
 		}
 
-		// Rule matched!
-		matchedConditions := make([]string, len(rule.Conditions))
-		for i, c := range rule.Conditions {
-			matchedConditions[i] = fmt.Sprintf("%s %s %v", c.Field, c.Operator, c.Value)
-		}
-
-		output.TriggeredRules = append(output.TriggeredRules, types.TriggeredRule{
-			RuleID:            rule.ID,
-			RuleName:          rule.Name,
-			MatchedConditions: matchedConditions,
-		})
+		output.TriggeredRules = append(output.TriggeredRules, *evaluation.triggered)
 
 		// Create pending actions
 		actions := e.createPendingActions(rule, input.Event, input.Context)
@@ -291,6 +281,78 @@ func (e *AutomationEnginePro) Evaluate(ctx *sdk.ExecutionContext, input types.Au
 	return output, nil
 }
 
+// ruleEvaluation is the outcome of matching a single rule against an event.
+// Exactly one of skipped or triggered is set.
+type ruleEvaluation struct {
+	skipped   *types.SkippedRule
+	triggered *types.TriggeredRule
+}
+
+// evaluateRule matches a rule's trigger and conditions against the input.
+func (e *AutomationEnginePro) evaluateRule(rule types.AutomationRule, input types.AutomationInput) ruleEvaluation {
+	if !rule.Enabled {
+		return ruleEvaluation{skipped: &types.SkippedRule{
+			RuleID:   rule.ID,
+			RuleName: rule.Name,
+			Reason:   "rule is disabled",
+		}}
+	}
+
+	// Check trigger
+	triggerMatch, triggerReason := e.evaluateTrigger(rule.Trigger, input.Event, input.Context)
+	if !triggerMatch {
+		return ruleEvaluation{skipped: &types.SkippedRule{
+			RuleID:   rule.ID,
+			RuleName: rule.Name,
+			Reason:   "trigger did not match: " + triggerReason,
+		}}
+	}
+
+	// Check conditions
+	conditionMatch, failedCondition := e.evaluateConditions(rule.Conditions, input.Event, input.Context)
+	if !conditionMatch {
+		return ruleEvaluation{skipped: &types.SkippedRule{
+			RuleID:          rule.ID,
+			RuleName:        rule.Name,
+			Reason:          "condition not met",
+			FailedCondition: failedCondition,
+		}}
+	}
+
+	// Rule matched!
+	matchedConditions := make([]string, len(rule.Conditions))
+	for i, c := range rule.Conditions {
+		matchedConditions[i] = fmt.Sprintf("%s %s %v", c.Field, c.Operator, c.Value)
+	}
+
+	return ruleEvaluation{triggered: &types.TriggeredRule{
+		RuleID:            rule.ID,
+		RuleName:          rule.Name,
+		MatchedConditions: matchedConditions,
+	}}
+}
+
+// evaluateRulesConcurrently matches all rules using at most concurrency
+// goroutines. The evaluations are returned in the same order as rules.
+func (e *AutomationEnginePro) evaluateRulesConcurrently(rules []types.AutomationRule, input types.AutomationInput, concurrency int) []ruleEvaluation {
+	evaluations := make([]ruleEvaluation, len(rules))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i := range rules {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			evaluations[i] = e.evaluateRule(rules[i], input)
+		}(i)
+	}
+	wg.Wait()
+
+	return evaluations
+}
+
 // ValidateRule validates an automation rule definition.
 func (e *AutomationEnginePro) ValidateRule(ctx *sdk.ExecutionContext, rule types.AutomationRule) error {
 	// Validate trigger
@@ -460,6 +522,7 @@ func (e *AutomationEnginePro) GetSupportedActions(ctx *sdk.ExecutionContext) ([]
 				{Name: "reason", Type: "string", Required: false, Description: "Reason for skipping"},
 			},
 		},
+		inboxPromoteAction,
 	}
 
 	// Add webhook action if enabled
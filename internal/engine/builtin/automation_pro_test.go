@@ -2,6 +2,7 @@ package builtin
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -47,6 +48,7 @@ func TestAutomationEnginePro_ConfigSchema(t *testing.T) {
 	assert.Contains(t, schema.Properties, "max_rules_per_event")
 	assert.Contains(t, schema.Properties, "evaluation_timeout_ms")
 	assert.Contains(t, schema.Properties, "stop_on_first_match")
+	assert.Contains(t, schema.Properties, "evaluation_concurrency")
 	assert.Contains(t, schema.Properties, "max_actions_per_rule")
 	assert.Contains(t, schema.Properties, "default_action_delay")
 	assert.Contains(t, schema.Properties, "webhooks_enabled")
@@ -397,6 +399,132 @@ func TestAutomationEnginePro_Evaluate_StopOnMatch(t *testing.T) {
 	assert.Len(t, output.TriggeredRules, 1)
 }
 
+// concurrencyTestInput builds an event and a rule set mixing disabled rules,
+// trigger mismatches, failed conditions and matches across priorities.
+func concurrencyTestInput(userID uuid.UUID) types.AutomationInput {
+	now := time.Date(2024, time.May, 6, 9, 0, 0, 0, time.UTC)
+	rules := make([]types.AutomationRule, 0, 40)
+	for i := 0; i < 40; i++ {
+		rule := types.AutomationRule{
+			ID:       uuid.New(),
+			Name:     fmt.Sprintf("Rule %d", i),
+			Enabled:  i%7 != 0,
+			Priority: i % 5,
+			Trigger:  types.RuleTrigger{Type: "event", EventTypes: []string{"task.created"}},
+			Actions: []types.RuleAction{{
+				Type:       "notification.send",
+				Parameters: map[string]any{"title": fmt.Sprintf("rule %d for {{event.entity_id}}", i)},
+			}},
+		}
+		switch i % 4 {
+		case 1:
+			rule.Trigger.EventTypes = []string{"habit.completed"}
+		case 2:
+			rule.Conditions = []types.RuleCondition{{Field: "priority", Operator: types.OperatorEquals, Value: "high"}}
+		case 3:
+			rule.Conditions = []types.RuleCondition{{Field: "priority", Operator: types.OperatorEquals, Value: "low"}}
+		}
+		rules = append(rules, rule)
+	}
+
+	return types.AutomationInput{
+		Event: types.AutomationEvent{
+			ID:           uuid.New(),
+			Type:         "task.created",
+			EntityID:     uuid.New(),
+			Timestamp:    now,
+			CurrentState: map[string]any{"priority": "high"},
+		},
+		Rules:   rules,
+		Context: types.AutomationContext{UserID: userID, Now: now},
+	}
+}
+
+func evaluateWithConfig(t *testing.T, userID uuid.UUID, raw map[string]any, input types.AutomationInput) *types.AutomationOutput {
+	t.Helper()
+	engine := NewAutomationEnginePro()
+	require.NoError(t, engine.Initialize(context.Background(), sdk.NewEngineConfig("orbita.automation.pro", userID, raw)))
+
+	execCtx := sdk.NewExecutionContext(context.Background(), userID, "orbita.automation.pro")
+	output, err := engine.Evaluate(execCtx, input)
+	require.NoError(t, err)
+
+	// Pending action IDs are random; clear them so outputs can be compared.
+	for i := range output.PendingActions {
+		output.PendingActions[i].ID = uuid.Nil
+	}
+	output.EvaluationDuration = 0
+	return output
+}
+
+func TestAutomationEnginePro_Evaluate_ConcurrentMatchesSequential(t *testing.T) {
+	userID := uuid.New()
+	input := concurrencyTestInput(userID)
+
+	sequential := evaluateWithConfig(t, userID, nil, input)
+	require.NotEmpty(t, sequential.TriggeredRules)
+	require.NotEmpty(t, sequential.SkippedRules)
+
+	for _, concurrency := range []int{2, 4, 16} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			concurrent := evaluateWithConfig(t, userID, map[string]any{
+				"evaluation_concurrency": concurrency,
+			}, input)
+
+			assert.Equal(t, sequential, concurrent)
+		})
+	}
+}
+
+func TestAutomationEnginePro_Evaluate_ConcurrentRespectsMaxRules(t *testing.T) {
+	userID := uuid.New()
+	input := concurrencyTestInput(userID)
+
+	sequential := evaluateWithConfig(t, userID, map[string]any{"max_rules_per_event": 10}, input)
+	concurrent := evaluateWithConfig(t, userID, map[string]any{
+		"max_rules_per_event":    10,
+		"evaluation_concurrency": 4,
+	}, input)
+
+	assert.Equal(t, sequential, concurrent)
+	assert.Equal(t, 10, len(concurrent.TriggeredRules)+len(concurrent.SkippedRules))
+}
+
+func TestAutomationEnginePro_Evaluate_ConcurrentStopOnMatch(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("stop on first match", func(t *testing.T) {
+		input := concurrencyTestInput(userID)
+		sequential := evaluateWithConfig(t, userID, map[string]any{"stop_on_first_match": true}, input)
+		require.Len(t, sequential.TriggeredRules, 1)
+
+		for i := 0; i < 20; i++ {
+			concurrent := evaluateWithConfig(t, userID, map[string]any{
+				"stop_on_first_match":    true,
+				"evaluation_concurrency": 8,
+			}, input)
+			assert.Equal(t, sequential, concurrent)
+		}
+	})
+
+	t.Run("rule stop on match", func(t *testing.T) {
+		input := concurrencyTestInput(userID)
+		// Rule 2 matches; giving it the highest priority and stopping there
+		// leaves every other match untriggered.
+		input.Rules[2].StopOnMatch = true
+		input.Rules[2].Priority = 10
+
+		sequential := evaluateWithConfig(t, userID, nil, input)
+		require.Len(t, sequential.TriggeredRules, 1)
+		assert.Equal(t, input.Rules[2].ID, sequential.TriggeredRules[0].RuleID)
+
+		for i := 0; i < 20; i++ {
+			concurrent := evaluateWithConfig(t, userID, map[string]any{"evaluation_concurrency": 8}, input)
+			assert.Equal(t, sequential, concurrent)
+		}
+	})
+}
+
 func TestAutomationEnginePro_ValidateRule(t *testing.T) {
 	engine := NewAutomationEnginePro()
 	userID := uuid.New()
@@ -274,13 +274,13 @@ func (e *DefaultPriorityEngine) calculateScore(input types.PriorityInput) (float
 // priorityToBase converts priority value to base score.
 func (e *DefaultPriorityEngine) priorityToBase(priority int) float64 {
 	switch priority {
-	case 1: // Urgent
+	case types.PriorityLevelUrgent:
 		return 1.0
-	case 2: // High
+	case types.PriorityLevelHigh:
 		return 0.8
-	case 3: // Medium
+	case types.PriorityLevelMedium:
 		return 0.6
-	case 4: // Low
+	case types.PriorityLevelLow:
 		return 0.4
 	default: // None
 		return 0.2
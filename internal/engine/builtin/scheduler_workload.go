@@ -0,0 +1,526 @@
+package builtin
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	"github.com/google/uuid"
+)
+
+// WorkloadSchedulerEngine spreads tasks over the coming days so no single day
+// is overloaded. Tasks are still placed in priority order, but each one goes
+// to the day that leaves the daily workload most even.
+type WorkloadSchedulerEngine struct {
+	config sdk.EngineConfig
+}
+
+// NewWorkloadSchedulerEngine creates a new workload balancing scheduler engine.
+func NewWorkloadSchedulerEngine() *WorkloadSchedulerEngine {
+	return &WorkloadSchedulerEngine{}
+}
+
+// Metadata returns engine metadata.
+func (e *WorkloadSchedulerEngine) Metadata() sdk.EngineMetadata {
+	return sdk.EngineMetadata{
+		ID:            "orbita.scheduler.workload",
+		Name:          "Workload Balancing Scheduler",
+		Version:       "1.0.0",
+		Author:        "Orbita",
+		Description:   "Built-in scheduler that distributes tasks across days to keep the daily workload even",
+		License:       "Proprietary",
+		Homepage:      "https://orbita.app",
+		Tags:          []string{"scheduler", "builtin", "workload-balancing"},
+		MinAPIVersion: "1.0.0",
+		Capabilities: []string{
+			"schedule_tasks",
+			"find_optimal_slot",
+			"reschedule_conflicts",
+			"calculate_utilization",
+			"workload_balancing",
+		},
+	}
+}
+
+// Type returns the engine type.
+func (e *WorkloadSchedulerEngine) Type() sdk.EngineType {
+	return sdk.EngineTypeScheduler
+}
+
+// ConfigSchema returns the configuration schema.
+func (e *WorkloadSchedulerEngine) ConfigSchema() sdk.ConfigSchema {
+	return sdk.ConfigSchema{
+		Schema: "https://json-schema.org/draft/2020-12/schema",
+		Properties: map[string]sdk.PropertySchema{
+			"horizon_days": {
+				Type:        "integer",
+				Title:       "Planning Horizon (days)",
+				Description: "Number of working days tasks are spread across",
+				Default:     5,
+				Minimum:     floatPtr(1),
+				Maximum:     floatPtr(14),
+				UIHints: sdk.UIHints{
+					Widget:   "slider",
+					Group:    "Balancing",
+					Order:    1,
+					HelpText: "Tasks are never placed after their due date",
+				},
+			},
+			"skip_weekends": {
+				Type:        "boolean",
+				Title:       "Skip Weekends",
+				Description: "Do not place tasks on Saturdays and Sundays",
+				Default:     true,
+				UIHints: sdk.UIHints{
+					Widget: "checkbox",
+					Group:  "Balancing",
+					Order:  2,
+				},
+			},
+			"work_start_hour": {
+				Type:        "integer",
+				Title:       "Work Start Hour",
+				Description: "Hour when work day starts (0-23), used when no working hours are given",
+				Default:     9,
+				Minimum:     floatPtr(0),
+				Maximum:     floatPtr(23),
+				UIHints: sdk.UIHints{
+					Widget: "slider",
+					Group:  "Work Hours",
+					Order:  3,
+				},
+			},
+			"work_end_hour": {
+				Type:        "integer",
+				Title:       "Work End Hour",
+				Description: "Hour when work day ends (0-23), used when no working hours are given",
+				Default:     17,
+				Minimum:     floatPtr(0),
+				Maximum:     floatPtr(23),
+				UIHints: sdk.UIHints{
+					Widget: "slider",
+					Group:  "Work Hours",
+					Order:  4,
+				},
+			},
+			"min_break_minutes": {
+				Type:        "integer",
+				Title:       "Minimum Break Between Tasks",
+				Description: "Minimum break between scheduled tasks in minutes",
+				Default:     5,
+				Minimum:     floatPtr(0),
+				Maximum:     floatPtr(60),
+				UIHints: sdk.UIHints{
+					Widget: "slider",
+					Group:  "Work Hours",
+					Order:  5,
+				},
+			},
+		},
+		Required: []string{},
+	}
+}
+
+// Initialize initializes the engine with configuration.
+func (e *WorkloadSchedulerEngine) Initialize(ctx context.Context, config sdk.EngineConfig) error {
+	e.config = config
+	return nil
+}
+
+// HealthCheck returns the engine health status.
+func (e *WorkloadSchedulerEngine) HealthCheck(ctx context.Context) sdk.HealthStatus {
+	return sdk.HealthStatus{
+		Healthy: true,
+		Message: "workload scheduler engine is healthy",
+	}
+}
+
+// Shutdown gracefully shuts down the engine.
+func (e *WorkloadSchedulerEngine) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// workloadDay tracks the free time and booked load of one day in the horizon.
+type workloadDay struct {
+	date     time.Time
+	free     []types.TimeSlot
+	load     time.Duration
+	capacity time.Duration
+}
+
+// ScheduleTasks places tasks in priority order, each on the day within the
+// horizon (and not after its due date) that gives the lowest variance of
+// daily load. Within a day the earliest free slot is used.
+func (e *WorkloadSchedulerEngine) ScheduleTasks(ctx *sdk.ExecutionContext, input types.ScheduleTasksInput) (*types.ScheduleTasksOutput, error) {
+	tasks := make([]types.SchedulableTask, len(input.Tasks))
+	copy(tasks, input.Tasks)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority < tasks[j].Priority // Lower number = higher priority
+		}
+		if !sameDue(tasks[i].DueDate, tasks[j].DueDate) {
+			return dueBefore(tasks[i].DueDate, tasks[j].DueDate)
+		}
+		return tasks[i].Duration > tasks[j].Duration
+	})
+
+	days := e.buildDays(input.Date, input.WorkingHours, input.ExistingBlocks)
+	buffer := time.Duration(e.getIntWithDefault("min_break_minutes", 5)) * time.Minute
+
+	ctx.Logger.Debug("scheduling tasks with workload balancing",
+		"tasks", len(tasks),
+		"days", len(days),
+	)
+
+	output := &types.ScheduleTasksOutput{
+		Results: make([]types.ScheduleResult, 0, len(tasks)),
+	}
+	var totalScheduled, totalCapacity time.Duration
+	for _, day := range days {
+		totalCapacity += day.capacity
+	}
+
+	for _, task := range tasks {
+		day := e.pickDay(days, task.Duration, task.DueDate)
+		if day == nil {
+			reason := "no suitable slot available"
+			if task.DueDate != nil {
+				reason = "no suitable slot available before due date"
+			}
+			output.Results = append(output.Results, types.ScheduleResult{
+				TaskID:    task.ID,
+				Scheduled: false,
+				Reason:    reason,
+			})
+			continue
+		}
+
+		start := day.place(task.Duration, buffer)
+		output.Results = append(output.Results, types.ScheduleResult{
+			TaskID:    task.ID,
+			BlockID:   uuid.New(),
+			StartTime: start,
+			EndTime:   start.Add(task.Duration),
+			Scheduled: true,
+		})
+		output.TotalScheduled++
+		totalScheduled += task.Duration
+	}
+
+	if totalCapacity > 0 {
+		output.UtilizationPercent = float64(totalScheduled) / float64(totalCapacity) * 100
+	}
+	return output, nil
+}
+
+// FindOptimalSlot returns the earliest free slot on the least loaded day of
+// the horizon, or the preferred start when it is free.
+func (e *WorkloadSchedulerEngine) FindOptimalSlot(ctx *sdk.ExecutionContext, input types.FindSlotInput) (*types.TimeSlot, error) {
+	days := e.buildDays(input.Date, input.WorkingHours, input.ExistingBlocks)
+
+	if input.PreferredStart != nil {
+		preferred := types.TimeSlot{Start: *input.PreferredStart, End: input.PreferredStart.Add(input.Duration)}
+		for _, day := range days {
+			for _, slot := range day.free {
+				if !preferred.Start.Before(slot.Start) && !preferred.End.After(slot.End) {
+					return &types.TimeSlot{
+						Start:  preferred.Start,
+						End:    preferred.End,
+						Score:  1.0,
+						Reason: "preferred time is free",
+					}, nil
+				}
+			}
+		}
+	}
+
+	day := e.pickDay(days, input.Duration, nil)
+	if day == nil {
+		return nil, sdk.ErrNoSlotAvailable
+	}
+
+	start := day.place(input.Duration, 0)
+	return &types.TimeSlot{
+		Start:  start,
+		End:    start.Add(input.Duration),
+		Score:  1.0,
+		Reason: "least loaded day in the planning horizon",
+	}, nil
+}
+
+// RescheduleConflicts moves movable blocks that overlap the new block to the
+// days that keep the workload most even.
+func (e *WorkloadSchedulerEngine) RescheduleConflicts(ctx *sdk.ExecutionContext, input types.RescheduleInput) (*types.RescheduleOutput, error) {
+	conflicts := make([]types.ExistingBlock, 0)
+	remaining := []types.ExistingBlock{input.NewBlock}
+	for _, block := range input.ExistingBlocks {
+		if !block.Immovable && block.Start.Before(input.NewBlock.End) && block.End.After(input.NewBlock.Start) {
+			conflicts = append(conflicts, block)
+			continue
+		}
+		remaining = append(remaining, block)
+	}
+
+	output := &types.RescheduleOutput{
+		Results: make([]types.ScheduleResult, 0, len(conflicts)),
+	}
+	if len(conflicts) == 0 {
+		return output, nil
+	}
+
+	days := e.buildDays(input.Date, input.WorkingHours, remaining)
+	buffer := time.Duration(e.getIntWithDefault("min_break_minutes", 5)) * time.Minute
+
+	for _, conflict := range conflicts {
+		duration := conflict.End.Sub(conflict.Start)
+		day := e.pickDay(days, duration, nil)
+		if day == nil {
+			output.UnresolvedConflicts = append(output.UnresolvedConflicts, conflict.ID)
+			output.Results = append(output.Results, types.ScheduleResult{
+				TaskID:    conflict.ID,
+				Scheduled: false,
+				Reason:    "no alternative slot available",
+			})
+			continue
+		}
+
+		start := day.place(duration, buffer)
+		output.Results = append(output.Results, types.ScheduleResult{
+			TaskID:    conflict.ID,
+			BlockID:   conflict.ID,
+			StartTime: start,
+			EndTime:   start.Add(duration),
+			Scheduled: true,
+		})
+		output.ConflictsResolved++
+	}
+
+	return output, nil
+}
+
+// CalculateUtilization calculates how much of the day's working time is booked.
+func (e *WorkloadSchedulerEngine) CalculateUtilization(ctx *sdk.ExecutionContext, input types.UtilizationInput) (*types.UtilizationOutput, error) {
+	day := e.buildDay(startOfDay(input.Date), e.workingHours(input.WorkingHours), input.ExistingBlocks)
+
+	byBlockType := make(map[string]time.Duration)
+	for _, block := range input.ExistingBlocks {
+		byBlockType[block.Type] += block.End.Sub(block.Start)
+	}
+
+	percent := 0.0
+	if day.capacity > 0 {
+		percent = float64(day.load) / float64(day.capacity) * 100
+	}
+
+	return &types.UtilizationOutput{
+		Percent:        percent,
+		TotalAvailable: day.capacity,
+		TotalScheduled: day.load,
+		ByBlockType:    byBlockType,
+	}, nil
+}
+
+// buildDays returns the days of the planning horizon starting at date, with
+// their free time and the load already booked by existing blocks.
+func (e *WorkloadSchedulerEngine) buildDays(date time.Time, workingHours types.WorkingHours, existingBlocks []types.ExistingBlock) []*workloadDay {
+	horizon := e.getIntWithDefault("horizon_days", 5)
+	if horizon < 1 {
+		horizon = 1
+	}
+	skipWeekends := e.getBoolWithDefault("skip_weekends", true)
+	workingHours = e.workingHours(workingHours)
+
+	days := make([]*workloadDay, 0, horizon)
+	for day := startOfDay(date); len(days) < horizon; day = day.AddDate(0, 0, 1) {
+		if skipWeekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+			continue
+		}
+		days = append(days, e.buildDay(day, workingHours, existingBlocks))
+	}
+	return days
+}
+
+// buildDay computes free time and load for a single day.
+func (e *WorkloadSchedulerEngine) buildDay(date time.Time, workingHours types.WorkingHours, existingBlocks []types.ExistingBlock) *workloadDay {
+	workStart := date.Add(workingHours.Start)
+	workEnd := date.Add(workingHours.End)
+
+	day := &workloadDay{date: date}
+	if !workEnd.After(workStart) {
+		return day
+	}
+	day.free = []types.TimeSlot{{Start: workStart, End: workEnd}}
+
+	for _, brk := range workingHours.Breaks {
+		day.free = subtractTimeSlot(day.free, types.TimeSlot{Start: date.Add(brk.Start), End: date.Add(brk.End)})
+	}
+	day.capacity = freeTime(day.free)
+
+	for _, block := range existingBlocks {
+		day.free = subtractTimeSlot(day.free, types.TimeSlot{Start: block.Start, End: block.End})
+	}
+	day.load = day.capacity - freeTime(day.free)
+
+	return day
+}
+
+// pickDay returns the day the duration fits on that leaves the lowest
+// variance of daily load, preferring earlier days on a tie. Days after the
+// due date are not considered; overdue tasks may use the first day.
+func (e *WorkloadSchedulerEngine) pickDay(days []*workloadDay, duration time.Duration, dueDate *time.Time) *workloadDay {
+	if len(days) == 0 {
+		return nil
+	}
+
+	var last time.Time
+	if dueDate != nil {
+		last = startOfDay(dueDate.In(days[0].date.Location()))
+		if last.Before(days[0].date) {
+			last = days[0].date
+		}
+	}
+
+	var best *workloadDay
+	bestScore := 0.0
+	for _, day := range days {
+		if dueDate != nil && day.date.After(last) {
+			break
+		}
+		if !day.fits(duration) {
+			continue
+		}
+
+		day.load += duration
+		score := loadVariance(days)
+		day.load -= duration
+
+		if best == nil || score < bestScore {
+			best, bestScore = day, score
+		}
+	}
+	return best
+}
+
+// fits reports whether a free slot on the day can hold the duration.
+func (d *workloadDay) fits(duration time.Duration) bool {
+	for _, slot := range d.free {
+		if slot.Duration() >= duration {
+			return true
+		}
+	}
+	return false
+}
+
+// place books the duration into the earliest free slot that holds it and
+// returns the start time. The buffer is kept free after the booking.
+func (d *workloadDay) place(duration, buffer time.Duration) time.Time {
+	for _, slot := range d.free {
+		if slot.Duration() < duration {
+			continue
+		}
+		start := slot.Start
+		d.free = subtractTimeSlot(d.free, types.TimeSlot{Start: start, End: start.Add(duration + buffer)})
+		d.load += duration
+		return start
+	}
+	return time.Time{}
+}
+
+// workingHours returns the given working hours, or the configured ones when
+// none are given.
+func (e *WorkloadSchedulerEngine) workingHours(workingHours types.WorkingHours) types.WorkingHours {
+	if workingHours.End > workingHours.Start {
+		return workingHours
+	}
+	return types.WorkingHours{
+		Start:  time.Duration(e.getIntWithDefault("work_start_hour", 9)) * time.Hour,
+		End:    time.Duration(e.getIntWithDefault("work_end_hour", 17)) * time.Hour,
+		Breaks: workingHours.Breaks,
+	}
+}
+
+func (e *WorkloadSchedulerEngine) getIntWithDefault(key string, defaultVal int) int {
+	if e.config.Has(key) {
+		return e.config.GetInt(key)
+	}
+	return defaultVal
+}
+
+func (e *WorkloadSchedulerEngine) getBoolWithDefault(key string, defaultVal bool) bool {
+	if e.config.Has(key) {
+		return e.config.GetBool(key)
+	}
+	return defaultVal
+}
+
+// loadVariance returns the variance of the daily loads in hours.
+func loadVariance(days []*workloadDay) float64 {
+	if len(days) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, day := range days {
+		sum += day.load.Hours()
+	}
+	mean := sum / float64(len(days))
+
+	var variance float64
+	for _, day := range days {
+		diff := day.load.Hours() - mean
+		variance += diff * diff
+	}
+	return variance / float64(len(days))
+}
+
+// subtractTimeSlot removes the busy interval from each of the free slots.
+func subtractTimeSlot(free []types.TimeSlot, busy types.TimeSlot) []types.TimeSlot {
+	result := make([]types.TimeSlot, 0, len(free)+1)
+	for _, slot := range free {
+		if !busy.Start.Before(slot.End) || !busy.End.After(slot.Start) {
+			result = append(result, slot)
+			continue
+		}
+		if busy.Start.After(slot.Start) {
+			result = append(result, types.TimeSlot{Start: slot.Start, End: busy.Start})
+		}
+		if busy.End.Before(slot.End) {
+			result = append(result, types.TimeSlot{Start: busy.End, End: slot.End})
+		}
+	}
+	return result
+}
+
+func freeTime(slots []types.TimeSlot) time.Duration {
+	var total time.Duration
+	for _, slot := range slots {
+		total += slot.Duration()
+	}
+	return total
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func sameDue(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// dueBefore orders tasks with a due date before tasks without one.
+func dueBefore(a, b *time.Time) bool {
+	if a == nil {
+		return false
+	}
+	if b == nil {
+		return true
+	}
+	return a.Before(*b)
+}
+
+// Ensure WorkloadSchedulerEngine implements types.SchedulerEngine
+var _ types.SchedulerEngine = (*WorkloadSchedulerEngine)(nil)
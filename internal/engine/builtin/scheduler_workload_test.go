@@ -0,0 +1,252 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// workloadMonday is a Monday used as the first day of the horizon.
+var workloadMonday = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+var workloadHours = types.WorkingHours{Start: 9 * time.Hour, End: 17 * time.Hour}
+
+func newWorkloadTestEngine(t *testing.T, values map[string]any) (*WorkloadSchedulerEngine, *sdk.ExecutionContext) {
+	t.Helper()
+	engine := NewWorkloadSchedulerEngine()
+	userID := uuid.New()
+	require.NoError(t, engine.Initialize(context.Background(), sdk.NewEngineConfig("orbita.scheduler.workload", userID, values)))
+	return engine, sdk.NewExecutionContext(context.Background(), userID, "orbita.scheduler.workload")
+}
+
+func workloadBlock(day, hour, minutes int) types.ExistingBlock {
+	start := workloadMonday.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour)
+	return types.ExistingBlock{
+		ID:    uuid.New(),
+		Type:  "meeting",
+		Start: start,
+		End:   start.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+// dailyLoads returns the booked hours per day from existing blocks and
+// scheduled results.
+func dailyLoads(days int, blocks []types.ExistingBlock, results []types.ScheduleResult) []float64 {
+	loads := make([]float64, days)
+	add := func(start, end time.Time) {
+		day := int(start.Sub(workloadMonday).Hours() / 24)
+		loads[day] += end.Sub(start).Hours()
+	}
+	for _, block := range blocks {
+		add(block.Start, block.End)
+	}
+	for _, result := range results {
+		if result.Scheduled {
+			add(result.StartTime, result.EndTime)
+		}
+	}
+	return loads
+}
+
+func variance(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var total float64
+	for _, v := range values {
+		total += (v - mean) * (v - mean)
+	}
+	return total / float64(len(values))
+}
+
+// greedyDailyLoads places each task, in the given order, on the first day
+// with room left, the way a first-available-slot scheduler fills a week.
+func greedyDailyLoads(days int, blocks []types.ExistingBlock, tasks []types.SchedulableTask) []float64 {
+	loads := dailyLoads(days, blocks, nil)
+	capacity := (workloadHours.End - workloadHours.Start).Hours()
+	for _, task := range tasks {
+		for day := range loads {
+			if loads[day]+task.Duration.Hours() <= capacity {
+				loads[day] += task.Duration.Hours()
+				break
+			}
+		}
+	}
+	return loads
+}
+
+func TestWorkloadSchedulerEngine_Metadata(t *testing.T) {
+	engine := NewWorkloadSchedulerEngine()
+	meta := engine.Metadata()
+
+	assert.Equal(t, "orbita.scheduler.workload", meta.ID)
+	assert.Contains(t, meta.Tags, "builtin")
+	assert.Contains(t, meta.Capabilities, "schedule_tasks")
+	assert.Contains(t, meta.Capabilities, "workload_balancing")
+	assert.Equal(t, sdk.EngineTypeScheduler, engine.Type())
+	assert.Contains(t, engine.ConfigSchema().Properties, "horizon_days")
+	assert.Contains(t, engine.ConfigSchema().Properties, "skip_weekends")
+}
+
+func TestWorkloadSchedulerEngine_SpreadsMoreEvenlyThanGreedy(t *testing.T) {
+	engine, execCtx := newWorkloadTestEngine(t, map[string]any{"min_break_minutes": 0})
+
+	blocks := []types.ExistingBlock{
+		workloadBlock(0, 9, 180),  // Monday 3h
+		workloadBlock(2, 13, 120), // Wednesday 2h
+	}
+	durations := []int{120, 90, 60, 60, 45, 90, 30, 120, 60, 30}
+	tasks := make([]types.SchedulableTask, 0, len(durations))
+	for i, minutes := range durations {
+		tasks = append(tasks, types.SchedulableTask{
+			ID:       uuid.New(),
+			Title:    "task",
+			Priority: i%3 + 1,
+			Duration: time.Duration(minutes) * time.Minute,
+		})
+	}
+
+	output, err := engine.ScheduleTasks(execCtx, types.ScheduleTasksInput{
+		Date:           workloadMonday,
+		Tasks:          tasks,
+		ExistingBlocks: blocks,
+		WorkingHours:   workloadHours,
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(tasks), output.TotalScheduled)
+
+	booked := append([]types.ExistingBlock{}, blocks...)
+	for _, result := range output.Results {
+		require.True(t, result.Scheduled)
+		day := workloadMonday.AddDate(0, 0, int(result.StartTime.Sub(workloadMonday).Hours()/24))
+		assert.False(t, result.StartTime.Before(day.Add(workloadHours.Start)), "starts before working hours")
+		assert.False(t, result.EndTime.After(day.Add(workloadHours.End)), "ends after working hours")
+		for _, other := range booked {
+			assert.False(t, result.StartTime.Before(other.End) && result.EndTime.After(other.Start), "overlaps another block")
+		}
+		booked = append(booked, types.ExistingBlock{Start: result.StartTime, End: result.EndTime})
+	}
+
+	balanced := dailyLoads(5, blocks, output.Results)
+	greedy := greedyDailyLoads(5, blocks, tasks)
+	assert.Less(t, variance(balanced), variance(greedy))
+	assert.InDelta(t, 16.75, balanced[0]+balanced[1]+balanced[2]+balanced[3]+balanced[4], 0.001)
+}
+
+func TestWorkloadSchedulerEngine_RespectsDueDates(t *testing.T) {
+	engine, execCtx := newWorkloadTestEngine(t, nil)
+
+	blocks := []types.ExistingBlock{
+		workloadBlock(0, 9, 360), // Monday 6h
+		workloadBlock(1, 9, 240), // Tuesday 4h
+	}
+	tuesday := workloadMonday.AddDate(0, 0, 1).Add(17 * time.Hour)
+	dueTask := types.SchedulableTask{ID: uuid.New(), Priority: 2, Duration: time.Hour, DueDate: &tuesday}
+	openTask := types.SchedulableTask{ID: uuid.New(), Priority: 2, Duration: time.Hour}
+
+	output, err := engine.ScheduleTasks(execCtx, types.ScheduleTasksInput{
+		Date:           workloadMonday,
+		Tasks:          []types.SchedulableTask{openTask, dueTask},
+		ExistingBlocks: blocks,
+		WorkingHours:   workloadHours,
+	})
+	require.NoError(t, err)
+	require.Len(t, output.Results, 2)
+
+	// The task with a due date is placed first and not after Tuesday.
+	assert.Equal(t, dueTask.ID, output.Results[0].TaskID)
+	assert.Equal(t, time.Date(2025, 6, 3, 13, 0, 0, 0, time.UTC), output.Results[0].StartTime)
+	// The open task goes to the emptiest day.
+	assert.Equal(t, openTask.ID, output.Results[1].TaskID)
+	assert.Equal(t, time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC), output.Results[1].StartTime)
+}
+
+func TestWorkloadSchedulerEngine_NoRoomBeforeDueDate(t *testing.T) {
+	engine, execCtx := newWorkloadTestEngine(t, nil)
+
+	monday := workloadMonday.Add(17 * time.Hour)
+	output, err := engine.ScheduleTasks(execCtx, types.ScheduleTasksInput{
+		Date: workloadMonday,
+		Tasks: []types.SchedulableTask{
+			{ID: uuid.New(), Priority: 1, Duration: 2 * time.Hour, DueDate: &monday},
+		},
+		ExistingBlocks: []types.ExistingBlock{workloadBlock(0, 9, 420)},
+		WorkingHours:   workloadHours,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, output.TotalScheduled)
+	assert.False(t, output.Results[0].Scheduled)
+	assert.Equal(t, "no suitable slot available before due date", output.Results[0].Reason)
+}
+
+func TestWorkloadSchedulerEngine_SkipsWeekends(t *testing.T) {
+	engine, execCtx := newWorkloadTestEngine(t, map[string]any{"horizon_days": 2})
+	friday := workloadMonday.AddDate(0, 0, 4)
+
+	output, err := engine.ScheduleTasks(execCtx, types.ScheduleTasksInput{
+		Date: friday,
+		Tasks: []types.SchedulableTask{
+			{ID: uuid.New(), Priority: 1, Duration: 4 * time.Hour},
+			{ID: uuid.New(), Priority: 1, Duration: 4 * time.Hour},
+		},
+		WorkingHours: workloadHours,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, output.TotalScheduled)
+
+	assert.Equal(t, time.Friday, output.Results[0].StartTime.Weekday())
+	assert.Equal(t, time.Monday, output.Results[1].StartTime.Weekday())
+}
+
+func TestWorkloadSchedulerEngine_FindOptimalSlot(t *testing.T) {
+	engine, execCtx := newWorkloadTestEngine(t, map[string]any{"horizon_days": 3})
+	blocks := []types.ExistingBlock{
+		workloadBlock(0, 9, 120),
+		workloadBlock(1, 9, 60),
+		workloadBlock(2, 9, 180),
+	}
+
+	slot, err := engine.FindOptimalSlot(execCtx, types.FindSlotInput{
+		Date:           workloadMonday,
+		Duration:       time.Hour,
+		ExistingBlocks: blocks,
+		WorkingHours:   workloadHours,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 3, 10, 0, 0, 0, time.UTC), slot.Start)
+
+	preferred := time.Date(2025, 6, 2, 14, 0, 0, 0, time.UTC)
+	slot, err = engine.FindOptimalSlot(execCtx, types.FindSlotInput{
+		Date:           workloadMonday,
+		Duration:       time.Hour,
+		PreferredStart: &preferred,
+		ExistingBlocks: blocks,
+		WorkingHours:   workloadHours,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, preferred, slot.Start)
+}
+
+func TestWorkloadSchedulerEngine_CalculateUtilization(t *testing.T) {
+	engine, execCtx := newWorkloadTestEngine(t, nil)
+
+	output, err := engine.CalculateUtilization(execCtx, types.UtilizationInput{
+		Date:           workloadMonday,
+		ExistingBlocks: []types.ExistingBlock{workloadBlock(0, 9, 120)},
+		WorkingHours:   workloadHours,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 8*time.Hour, output.TotalAvailable)
+	assert.Equal(t, 2*time.Hour, output.TotalScheduled)
+	assert.InDelta(t, 25.0, output.Percent, 0.001)
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+
+	enginepb "github.com/felixgeelhaar/orbita/internal/engine/grpc/proto"
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProtoContext converts an execution context for a call to a plugin.
+// Hosts should make the call with ec.Context() so gRPC propagates the
+// deadline and cancels the call on the plugin side; the deadline is also
+// sent in the message for plugins that only look at the request.
+func ToProtoContext(ec *sdk.ExecutionContext) *enginepb.ExecutionContext {
+	pc := &enginepb.ExecutionContext{
+		UserId:    ec.UserID.String(),
+		EngineId:  ec.EngineID,
+		RequestId: ec.RequestID,
+		StartTime: timestamppb.New(ec.StartTime),
+	}
+	if deadline, ok := ec.Deadline(); ok {
+		pc.Deadline = timestamppb.New(deadline)
+	}
+	return pc
+}
+
+// FromProtoContext rebuilds the host's execution context on the plugin side.
+// ctx is the context of the incoming call, which gRPC cancels when the host
+// gives up; the deadline from the message is applied on top of it, so a
+// plugin sees the host's deadline through Deadline, Remaining and Done. The
+// returned cancel function must be called once the call is handled.
+func FromProtoContext(ctx context.Context, pc *enginepb.ExecutionContext) (*sdk.ExecutionContext, context.CancelFunc) {
+	cancel := context.CancelFunc(func() {})
+	if deadline := pc.GetDeadline(); deadline != nil {
+		ctx, cancel = context.WithDeadline(ctx, deadline.AsTime())
+	}
+
+	userID, _ := uuid.Parse(pc.GetUserId())
+	ec := sdk.NewExecutionContext(ctx, userID, pc.GetEngineId())
+	if pc.GetRequestId() != "" {
+		ec.RequestID = pc.GetRequestId()
+	}
+	if start := pc.GetStartTime(); start != nil {
+		ec.StartTime = start.AsTime()
+	}
+	return ec, cancel
+}
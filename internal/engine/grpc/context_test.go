@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	enginepb "github.com/felixgeelhaar/orbita/internal/engine/grpc/proto"
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// abortingClassifier is a plugin that works until its execution context is
+// done and then aborts, reporting what it observed.
+type abortingClassifier struct {
+	enginepb.UnimplementedClassifierEngineServer
+	started chan *sdk.ExecutionContext
+	aborted chan error
+}
+
+func (c *abortingClassifier) Classify(ctx context.Context, req *enginepb.ClassifyRequest) (*enginepb.ClassifyResponse, error) {
+	ec, cancel := FromProtoContext(ctx, req.GetContext())
+	defer cancel()
+	c.started <- ec
+
+	<-ec.Done()
+	c.aborted <- ec.Err()
+	return nil, status.FromContextError(ec.Err()).Err()
+}
+
+func startClassifierPlugin(t *testing.T) (*abortingClassifier, enginepb.ClassifierEngineClient) {
+	t.Helper()
+	plugin := &abortingClassifier{
+		started: make(chan *sdk.ExecutionContext, 1),
+		aborted: make(chan error, 1),
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	enginepb.RegisterClassifierEngineServer(server, plugin)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///plugin",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return plugin, enginepb.NewClassifierEngineClient(conn)
+}
+
+func TestExecutionContext_PluginObservesDeadline(t *testing.T) {
+	plugin, client := startClassifierPlugin(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	userID := uuid.New()
+	ec := sdk.NewExecutionContext(ctx, userID, "acme.classifier")
+
+	_, err := client.Classify(ec.Context(), &enginepb.ClassifyRequest{Context: ToProtoContext(ec)})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	observed := <-plugin.started
+	hostDeadline, _ := ec.Deadline()
+	pluginDeadline, ok := observed.Deadline()
+	require.True(t, ok)
+	assert.True(t, hostDeadline.Equal(pluginDeadline), "plugin deadline %s, host %s", pluginDeadline, hostDeadline)
+	assert.Equal(t, userID, observed.UserID)
+	assert.Equal(t, "acme.classifier", observed.EngineID)
+	assert.Equal(t, ec.RequestID, observed.RequestID)
+
+	// The plugin either sees the deadline pass or the host give up on the
+	// call, whichever reaches it first.
+	assert.Error(t, <-plugin.aborted)
+	assert.False(t, time.Now().Before(hostDeadline))
+}
+
+func TestExecutionContext_PluginObservesCancellation(t *testing.T) {
+	plugin, client := startClassifierPlugin(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ec := sdk.NewExecutionContext(ctx, uuid.New(), "acme.classifier")
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.Classify(ec.Context(), &enginepb.ClassifyRequest{Context: ToProtoContext(ec)})
+		errs <- err
+	}()
+
+	observed := <-plugin.started
+	_, ok := observed.Remaining()
+	assert.False(t, ok)
+	cancel()
+
+	assert.Equal(t, codes.Canceled, status.Code(<-errs))
+	select {
+	case err := <-plugin.aborted:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("plugin did not observe the cancellation")
+	}
+}
+
+func TestToProtoContext_WithoutDeadline(t *testing.T) {
+	ec := sdk.NewExecutionContext(context.Background(), uuid.New(), "acme.priority")
+
+	pc := ToProtoContext(ec)
+	assert.Nil(t, pc.GetDeadline())
+
+	restored, cancel := FromProtoContext(context.Background(), pc)
+	defer cancel()
+	_, ok := restored.Deadline()
+	assert.False(t, ok)
+	assert.True(t, ec.StartTime.Equal(restored.StartTime))
+}
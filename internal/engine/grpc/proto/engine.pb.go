@@ -978,11 +978,14 @@ func (x *ShutdownResponse) GetError() string {
 
 // ExecutionContext provides context for engine operations.
 type ExecutionContext struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	EngineId      string                 `protobuf:"bytes,2,opt,name=engine_id,json=engineId,proto3" json:"engine_id,omitempty"`
-	RequestId     string                 `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EngineId  string                 `protobuf:"bytes,2,opt,name=engine_id,json=engineId,proto3" json:"engine_id,omitempty"`
+	RequestId string                 `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// When the host stops waiting for the result; unset without a deadline.
+	// Plugins should abort once it passes.
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1045,6 +1048,13 @@ func (x *ExecutionContext) GetStartTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *ExecutionContext) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
 var File_internal_engine_grpc_proto_engine_proto protoreflect.FileDescriptor
 
 const file_internal_engine_grpc_proto_engine_proto_rawDesc = "" +
@@ -1130,14 +1140,15 @@ const file_internal_engine_grpc_proto_engine_proto_rawDesc = "" +
 	"\x0fShutdownRequest\"B\n" +
 	"\x10ShutdownResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\xa2\x01\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xda\x01\n" +
 	"\x10ExecutionContext\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
 	"\tengine_id\x18\x02 \x01(\tR\bengineId\x12\x1d\n" +
 	"\n" +
 	"request_id\x18\x03 \x01(\tR\trequestId\x129\n" +
 	"\n" +
-	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime*\x96\x01\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x126\n" +
+	"\bdeadline\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline*\x96\x01\n" +
 	"\n" +
 	"EngineType\x12\x1b\n" +
 	"\x17ENGINE_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
@@ -1201,22 +1212,23 @@ var file_internal_engine_grpc_proto_engine_proto_depIdxs = []int32{
 	17, // 9: orbita.engine.v1.HealthCheckResponse.details:type_name -> google.protobuf.Struct
 	18, // 10: orbita.engine.v1.HealthCheckResponse.checked_at:type_name -> google.protobuf.Timestamp
 	18, // 11: orbita.engine.v1.ExecutionContext.start_time:type_name -> google.protobuf.Timestamp
-	5,  // 12: orbita.engine.v1.ConfigSchemaResponse.PropertiesEntry.value:type_name -> orbita.engine.v1.PropertySchema
-	1,  // 13: orbita.engine.v1.Engine.Metadata:input_type -> orbita.engine.v1.MetadataRequest
-	3,  // 14: orbita.engine.v1.Engine.ConfigSchema:input_type -> orbita.engine.v1.ConfigSchemaRequest
-	8,  // 15: orbita.engine.v1.Engine.Initialize:input_type -> orbita.engine.v1.InitializeRequest
-	10, // 16: orbita.engine.v1.Engine.HealthCheck:input_type -> orbita.engine.v1.HealthCheckRequest
-	12, // 17: orbita.engine.v1.Engine.Shutdown:input_type -> orbita.engine.v1.ShutdownRequest
-	2,  // 18: orbita.engine.v1.Engine.Metadata:output_type -> orbita.engine.v1.MetadataResponse
-	4,  // 19: orbita.engine.v1.Engine.ConfigSchema:output_type -> orbita.engine.v1.ConfigSchemaResponse
-	9,  // 20: orbita.engine.v1.Engine.Initialize:output_type -> orbita.engine.v1.InitializeResponse
-	11, // 21: orbita.engine.v1.Engine.HealthCheck:output_type -> orbita.engine.v1.HealthCheckResponse
-	13, // 22: orbita.engine.v1.Engine.Shutdown:output_type -> orbita.engine.v1.ShutdownResponse
-	18, // [18:23] is the sub-list for method output_type
-	13, // [13:18] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	18, // 12: orbita.engine.v1.ExecutionContext.deadline:type_name -> google.protobuf.Timestamp
+	5,  // 13: orbita.engine.v1.ConfigSchemaResponse.PropertiesEntry.value:type_name -> orbita.engine.v1.PropertySchema
+	1,  // 14: orbita.engine.v1.Engine.Metadata:input_type -> orbita.engine.v1.MetadataRequest
+	3,  // 15: orbita.engine.v1.Engine.ConfigSchema:input_type -> orbita.engine.v1.ConfigSchemaRequest
+	8,  // 16: orbita.engine.v1.Engine.Initialize:input_type -> orbita.engine.v1.InitializeRequest
+	10, // 17: orbita.engine.v1.Engine.HealthCheck:input_type -> orbita.engine.v1.HealthCheckRequest
+	12, // 18: orbita.engine.v1.Engine.Shutdown:input_type -> orbita.engine.v1.ShutdownRequest
+	2,  // 19: orbita.engine.v1.Engine.Metadata:output_type -> orbita.engine.v1.MetadataResponse
+	4,  // 20: orbita.engine.v1.Engine.ConfigSchema:output_type -> orbita.engine.v1.ConfigSchemaResponse
+	9,  // 21: orbita.engine.v1.Engine.Initialize:output_type -> orbita.engine.v1.InitializeResponse
+	11, // 22: orbita.engine.v1.Engine.HealthCheck:output_type -> orbita.engine.v1.HealthCheckResponse
+	13, // 23: orbita.engine.v1.Engine.Shutdown:output_type -> orbita.engine.v1.ShutdownResponse
+	19, // [19:24] is the sub-list for method output_type
+	14, // [14:19] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_internal_engine_grpc_proto_engine_proto_init() }
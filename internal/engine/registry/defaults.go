@@ -0,0 +1,77 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
+)
+
+// SetDefault selects the engine used for a type when callers do not name
+// one. The engine must be registered and of that type.
+func (r *Registry) SetDefault(engineType sdk.EngineType, id string) error {
+	if !engineType.IsValid() {
+		return fmt.Errorf("unknown engine type: %s", engineType)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, exists := r.engines[id]
+	if !exists {
+		return fmt.Errorf("%w: %s", sdk.ErrEngineNotFound, id)
+	}
+	if entryType := entry.engineType(); entryType != engineType {
+		return fmt.Errorf("%w: %s is a %s engine, not a %s engine", sdk.ErrEngineTypeMismatch, id, entryType, engineType)
+	}
+
+	r.defaults[engineType] = id
+	r.logger.Info("selected default engine",
+		"type", engineType,
+		"engine_id", id,
+	)
+	return nil
+}
+
+// DefaultEngineID returns the engine to use for a type: the selected
+// default while it is registered and has not failed to load, otherwise the
+// built-in engine of that type.
+func (r *Registry) DefaultEngineID(engineType sdk.EngineType) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if id, ok := r.defaults[engineType]; ok {
+		entry, exists := r.engines[id]
+		if exists && entry.Status != StatusFailed {
+			return id, nil
+		}
+		r.logger.Warn("selected default engine unavailable, falling back to built-in",
+			"type", engineType,
+			"engine_id", id,
+		)
+	}
+
+	var builtins []string
+	for id, entry := range r.engines {
+		if entry.Builtin && entry.engineType() == engineType {
+			builtins = append(builtins, id)
+		}
+	}
+	if len(builtins) == 0 {
+		return "", fmt.Errorf("%w: no %s engine registered", sdk.ErrEngineNotFound, engineType)
+	}
+	sort.Strings(builtins)
+	return builtins[0], nil
+}
+
+// engineType returns the entry's engine type, from the loaded engine when
+// available and otherwise from its manifest.
+func (e EngineEntry) engineType() sdk.EngineType {
+	if e.Engine != nil {
+		return e.Engine.Type()
+	}
+	if e.Manifest != nil {
+		return sdk.EngineType(e.Manifest.Type)
+	}
+	return ""
+}
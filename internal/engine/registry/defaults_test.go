@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDefaultsTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+	reg := NewRegistry(testLogger())
+	require.NoError(t, reg.RegisterBuiltin(newMockEngine("orbita.priority.default", "Default Priority", sdk.EngineTypePriority)))
+	require.NoError(t, reg.RegisterBuiltin(newMockEngine("orbita.scheduler.default", "Default Scheduler", sdk.EngineTypeScheduler)))
+	require.NoError(t, reg.RegisterFactory("acme.priority.eisenhower", func() (sdk.Engine, error) {
+		return newMockEngine("acme.priority.eisenhower", "Eisenhower", sdk.EngineTypePriority), nil
+	}, &Manifest{ID: "acme.priority.eisenhower", Type: "priority"}))
+	return reg
+}
+
+func TestDefaultEngineID_BuiltinWithoutSelection(t *testing.T) {
+	reg := newDefaultsTestRegistry(t)
+
+	id, err := reg.DefaultEngineID(sdk.EngineTypePriority)
+	require.NoError(t, err)
+	assert.Equal(t, "orbita.priority.default", id)
+
+	_, err = reg.DefaultEngineID(sdk.EngineTypeClassifier)
+	assert.ErrorIs(t, err, sdk.ErrEngineNotFound)
+}
+
+func TestSetDefault(t *testing.T) {
+	reg := newDefaultsTestRegistry(t)
+
+	require.NoError(t, reg.SetDefault(sdk.EngineTypePriority, "acme.priority.eisenhower"))
+	id, err := reg.DefaultEngineID(sdk.EngineTypePriority)
+	require.NoError(t, err)
+	assert.Equal(t, "acme.priority.eisenhower", id)
+
+	// Other types keep their built-in engine.
+	id, err = reg.DefaultEngineID(sdk.EngineTypeScheduler)
+	require.NoError(t, err)
+	assert.Equal(t, "orbita.scheduler.default", id)
+}
+
+func TestSetDefault_Validation(t *testing.T) {
+	reg := newDefaultsTestRegistry(t)
+
+	err := reg.SetDefault(sdk.EngineTypePriority, "acme.missing")
+	assert.ErrorIs(t, err, sdk.ErrEngineNotFound)
+
+	err = reg.SetDefault(sdk.EngineTypeScheduler, "acme.priority.eisenhower")
+	assert.ErrorIs(t, err, sdk.ErrEngineTypeMismatch)
+
+	err = reg.SetDefault("nonsense", "orbita.priority.default")
+	assert.Error(t, err)
+
+	// A rejected selection leaves the built-in default in place.
+	id, err := reg.DefaultEngineID(sdk.EngineTypePriority)
+	require.NoError(t, err)
+	assert.Equal(t, "orbita.priority.default", id)
+}
+
+func TestDefaultEngineID_FallsBackWhenSelectedIsMissing(t *testing.T) {
+	t.Run("unregistered", func(t *testing.T) {
+		reg := newDefaultsTestRegistry(t)
+		require.NoError(t, reg.SetDefault(sdk.EngineTypePriority, "acme.priority.eisenhower"))
+		require.NoError(t, reg.Unregister("acme.priority.eisenhower"))
+
+		id, err := reg.DefaultEngineID(sdk.EngineTypePriority)
+		require.NoError(t, err)
+		assert.Equal(t, "orbita.priority.default", id)
+	})
+
+	t.Run("failed to load", func(t *testing.T) {
+		reg := NewRegistry(testLogger())
+		require.NoError(t, reg.RegisterBuiltin(newMockEngine("orbita.priority.default", "Default Priority", sdk.EngineTypePriority)))
+		require.NoError(t, reg.RegisterFactory("acme.priority.broken", func() (sdk.Engine, error) {
+			return nil, errors.New("plugin crashed")
+		}, &Manifest{ID: "acme.priority.broken", Type: "priority"}))
+		require.NoError(t, reg.SetDefault(sdk.EngineTypePriority, "acme.priority.broken"))
+
+		_, err := reg.Get(context.Background(), "acme.priority.broken")
+		require.Error(t, err)
+
+		id, err := reg.DefaultEngineID(sdk.EngineTypePriority)
+		require.NoError(t, err)
+		assert.Equal(t, "orbita.priority.default", id)
+	})
+}
@@ -12,9 +12,10 @@ import (
 
 // Registry manages engine registration and lookup.
 type Registry struct {
-	mu      sync.RWMutex
-	engines map[string]EngineEntry
-	logger  *slog.Logger
+	mu       sync.RWMutex
+	engines  map[string]EngineEntry
+	defaults map[sdk.EngineType]string
+	logger   *slog.Logger
 }
 
 // EngineEntry holds a registered engine and its metadata.
@@ -64,8 +65,9 @@ func NewRegistry(logger *slog.Logger) *Registry {
 		logger = slog.Default()
 	}
 	return &Registry{
-		engines: make(map[string]EngineEntry),
-		logger:  logger,
+		engines:  make(map[string]EngineEntry),
+		defaults: make(map[sdk.EngineType]string),
+		logger:   logger,
 	}
 }
 
@@ -0,0 +1,112 @@
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// resultCache holds engine results keyed by a hash of their input.
+// Entries are grouped per engine so one engine can be invalidated without
+// touching the others. Each engine holds at most maxEntries results; a full
+// engine drops its expired entries first and then the ones closest to expiry.
+type resultCache struct {
+	mu         sync.Mutex
+	entries    map[string]map[string]cacheEntry
+	maxEntries int
+	now        func() time.Time
+}
+
+type cacheEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+func newResultCache(maxEntries int) *resultCache {
+	return &resultCache{
+		entries:    make(map[string]map[string]cacheEntry),
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+// get returns the cached value for key if it has not expired.
+func (c *resultCache) get(engineID, key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := c.entries[engineID]
+	entry, ok := entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set stores a value for key until ttl has elapsed.
+func (c *resultCache) set(engineID, key string, value any, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, ok := c.entries[engineID]
+	if !ok {
+		entries = make(map[string]cacheEntry)
+		c.entries[engineID] = entries
+	}
+	if _, exists := entries[key]; !exists && c.maxEntries > 0 && len(entries) >= c.maxEntries {
+		c.evict(entries)
+	}
+	entries[key] = cacheEntry{value: value, expiresAt: c.now().Add(ttl)}
+}
+
+// evict makes room for one more entry. It drops every expired entry and,
+// when none have expired, the entry closest to expiry.
+func (c *resultCache) evict(entries map[string]cacheEntry) {
+	now := c.now()
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range entries {
+		if !now.Before(entry.expiresAt) {
+			delete(entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.expiresAt.Before(oldest) {
+			oldestKey, oldest = key, entry.expiresAt
+		}
+	}
+	if len(entries) >= c.maxEntries && oldestKey != "" {
+		delete(entries, oldestKey)
+	}
+}
+
+// invalidate drops every cached result for an engine.
+func (c *resultCache) invalidate(engineID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, engineID)
+}
+
+// cacheKey hashes an operation, user and input into a cache key. Inputs that
+// cannot be marshalled are not cacheable.
+func cacheKey(operation string, userID uuid.UUID, input any) (string, bool) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", false
+	}
+
+	h := sha256.New()
+	h.Write([]byte(operation))
+	h.Write([]byte{0})
+	h.Write(userID[:])
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), true
+}
@@ -3,24 +3,42 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/engine/registry"
 	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
 	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/telemetry"
 	"github.com/google/uuid"
 	"github.com/sony/gobreaker/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Attributes on engine spans and metrics.
+const (
+	AttrEngineID        = attribute.Key("orbita.engine.id")
+	AttrEngineOperation = attribute.Key("orbita.engine.operation")
+)
+
+// OutcomeCircuitOpen marks a call rejected by an open circuit breaker.
+const OutcomeCircuitOpen = "circuit_open"
+
 // Executor manages engine execution with circuit breakers and metrics.
 type Executor struct {
-	registry *registry.Registry
-	breakers map[string]*gobreaker.CircuitBreaker[any]
-	metrics  *MetricsCollector
-	logger   *slog.Logger
-	config   ExecutorConfig
+	registry   *registry.Registry
+	breakers   map[string]*gobreaker.CircuitBreaker[any]
+	breakersMu sync.Mutex
+	metrics    *MetricsCollector
+	logger     *slog.Logger
+	config     ExecutorConfig
+	cache      *resultCache
+	tracing    *telemetry.Instruments
 }
 
 // ExecutorConfig configures the executor behavior.
@@ -43,17 +61,37 @@ type ExecutorConfig struct {
 
 	// DefaultTimeout is the default timeout for engine operations.
 	DefaultTimeout time.Duration
+
+	// MaxParallelEvaluations bounds how many inputs ExecuteParallelPriority
+	// evaluates at once. Zero or less evaluates one input at a time.
+	MaxParallelEvaluations int
+
+	// ResultCacheTTLs enables result caching for engines whose priority and
+	// classification results depend only on their input, keyed by engine ID.
+	// Engines without an entry are always called.
+	ResultCacheTTLs map[string]time.Duration
+
+	// ResultCacheMaxEntries caps how many results are cached per engine.
+	// Zero or less leaves the cache unbounded.
+	ResultCacheMaxEntries int
+
+	// TracerProvider and MeterProvider receive a span and metrics for each
+	// engine call. Nil uses the global OpenTelemetry providers.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
 }
 
 // DefaultExecutorConfig returns a sensible default configuration.
 func DefaultExecutorConfig() ExecutorConfig {
 	return ExecutorConfig{
-		CircuitBreakerEnabled: true,
-		MaxRequests:           3,
-		Interval:              10 * time.Second,
-		Timeout:               30 * time.Second,
-		FailureThreshold:      5,
-		DefaultTimeout:        10 * time.Second,
+		CircuitBreakerEnabled:  true,
+		MaxRequests:            3,
+		Interval:               10 * time.Second,
+		Timeout:                30 * time.Second,
+		FailureThreshold:       5,
+		DefaultTimeout:         10 * time.Second,
+		MaxParallelEvaluations: 4,
+		ResultCacheMaxEntries:  1000,
 	}
 }
 
@@ -71,6 +109,8 @@ func NewExecutor(reg *registry.Registry, metrics *MetricsCollector, logger *slog
 		metrics:  metrics,
 		logger:   logger,
 		config:   config,
+		cache:    newResultCache(config.ResultCacheMaxEntries),
+		tracing:  telemetry.NewInstruments("github.com/felixgeelhaar/orbita/internal/engine/runtime", "orbita.engine", config.TracerProvider, config.MeterProvider),
 	}
 }
 
@@ -80,6 +120,9 @@ func (e *Executor) getBreaker(engineID string) *gobreaker.CircuitBreaker[any] {
 		return nil
 	}
 
+	e.breakersMu.Lock()
+	defer e.breakersMu.Unlock()
+
 	if breaker, exists := e.breakers[engineID]; exists {
 		return breaker
 	}
@@ -109,6 +152,10 @@ func (e *Executor) getBreaker(engineID string) *gobreaker.CircuitBreaker[any] {
 
 // execute runs an operation with circuit breaker protection.
 func (e *Executor) execute(ctx context.Context, engineID string, operation string, fn func() (any, error)) (any, error) {
+	_, call := e.tracing.Start(ctx, "engine."+operation,
+		AttrEngineID.String(engineID),
+		AttrEngineOperation.String(operation),
+	)
 	start := time.Now()
 
 	breaker := e.getBreaker(engineID)
@@ -121,6 +168,7 @@ func (e *Executor) execute(ctx context.Context, engineID string, operation strin
 		})
 		if err == gobreaker.ErrOpenState {
 			e.metrics.RecordCircuitOpen(engineID, operation)
+			call.End(OutcomeCircuitOpen, sdk.ErrCircuitOpen)
 			return nil, sdk.ErrCircuitOpen
 		}
 	} else {
@@ -129,10 +177,40 @@ func (e *Executor) execute(ctx context.Context, engineID string, operation strin
 
 	duration := time.Since(start)
 	e.metrics.RecordOperation(engineID, operation, duration, err)
+	call.End(telemetry.OutcomeOf(err), err)
 
 	return result, err
 }
 
+// cached returns a cached result for the input when the engine has result
+// caching enabled, and otherwise calls fn, caching successful results.
+func (e *Executor) cached(engineID, operation string, userID uuid.UUID, input any, fn func() (any, error)) (any, error) {
+	ttl := e.config.ResultCacheTTLs[engineID]
+	if ttl <= 0 {
+		return fn()
+	}
+
+	key, ok := cacheKey(operation, userID, input)
+	if !ok {
+		return fn()
+	}
+
+	if result, hit := e.cache.get(engineID, key); hit {
+		e.logger.Debug("engine result cache hit",
+			"engine_id", engineID,
+			"operation", operation,
+		)
+		return result, nil
+	}
+
+	result, err := fn()
+	if err != nil {
+		return nil, err
+	}
+	e.cache.set(engineID, key, result, ttl)
+	return result, nil
+}
+
 // createContext creates an ExecutionContext for an operation.
 func (e *Executor) createContext(ctx context.Context, userID uuid.UUID, engineID string) *sdk.ExecutionContext {
 	execCtx := sdk.NewExecutionContext(ctx, userID, engineID)
@@ -141,9 +219,44 @@ func (e *Executor) createContext(ctx context.Context, userID uuid.UUID, engineID
 	return execCtx
 }
 
+// resolve returns the engine to run. An empty engineID selects the
+// registry's default engine for the type, falling back to the built-in
+// engine if the selected one fails to load.
+func (e *Executor) resolve(ctx context.Context, engineID string, engineType sdk.EngineType) (string, sdk.Engine, error) {
+	if engineID != "" {
+		engine, err := e.registry.Get(ctx, engineID)
+		return engineID, engine, err
+	}
+
+	id, err := e.registry.DefaultEngineID(engineType)
+	if err != nil {
+		return "", nil, err
+	}
+	engine, err := e.registry.Get(ctx, id)
+	if err == nil {
+		return id, engine, nil
+	}
+
+	// A failed load marks the engine failed, so the registry now resolves
+	// to the built-in engine.
+	fallback, fallbackErr := e.registry.DefaultEngineID(engineType)
+	if fallbackErr != nil || fallback == id {
+		return "", nil, err
+	}
+	e.logger.Warn("default engine failed to load, using built-in",
+		"type", engineType,
+		"engine_id", id,
+		"fallback", fallback,
+		"error", err,
+	)
+	engine, err = e.registry.Get(ctx, fallback)
+	return fallback, engine, err
+}
+
 // ExecuteScheduler executes a scheduler engine operation.
+// An empty engineID uses the default scheduler engine.
 func (e *Executor) ExecuteScheduler(ctx context.Context, engineID string, userID uuid.UUID, input types.ScheduleTasksInput) (*types.ScheduleTasksOutput, error) {
-	engine, err := e.registry.Get(ctx, engineID)
+	engineID, engine, err := e.resolve(ctx, engineID, sdk.EngineTypeScheduler)
 	if err != nil {
 		return nil, err
 	}
@@ -166,8 +279,9 @@ func (e *Executor) ExecuteScheduler(ctx context.Context, engineID string, userID
 }
 
 // ExecuteFindSlot executes a find slot operation.
+// An empty engineID uses the default scheduler engine.
 func (e *Executor) ExecuteFindSlot(ctx context.Context, engineID string, userID uuid.UUID, input types.FindSlotInput) (*types.TimeSlot, error) {
-	engine, err := e.registry.Get(ctx, engineID)
+	engineID, engine, err := e.resolve(ctx, engineID, sdk.EngineTypeScheduler)
 	if err != nil {
 		return nil, err
 	}
@@ -190,8 +304,9 @@ func (e *Executor) ExecuteFindSlot(ctx context.Context, engineID string, userID
 }
 
 // ExecutePriority executes a priority calculation.
+// An empty engineID uses the default priority engine.
 func (e *Executor) ExecutePriority(ctx context.Context, engineID string, userID uuid.UUID, input types.PriorityInput) (*types.PriorityOutput, error) {
-	engine, err := e.registry.Get(ctx, engineID)
+	engineID, engine, err := e.resolve(ctx, engineID, sdk.EngineTypePriority)
 	if err != nil {
 		return nil, err
 	}
@@ -203,19 +318,24 @@ func (e *Executor) ExecutePriority(ctx context.Context, engineID string, userID
 
 	execCtx := e.createContext(ctx, userID, engineID)
 
-	result, err := e.execute(ctx, engineID, "calculate_priority", func() (any, error) {
-		return priority.CalculatePriority(execCtx, input)
+	result, err := e.cached(engineID, "calculate_priority", userID, input, func() (any, error) {
+		return e.execute(ctx, engineID, "calculate_priority", func() (any, error) {
+			return priority.CalculatePriority(execCtx, input)
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return result.(*types.PriorityOutput), nil
+	// Copy so callers cannot modify a cached result.
+	output := *result.(*types.PriorityOutput)
+	return &output, nil
 }
 
 // ExecuteBatchPriority executes batch priority calculation.
+// An empty engineID uses the default priority engine.
 func (e *Executor) ExecuteBatchPriority(ctx context.Context, engineID string, userID uuid.UUID, inputs []types.PriorityInput) ([]types.PriorityOutput, error) {
-	engine, err := e.registry.Get(ctx, engineID)
+	engineID, engine, err := e.resolve(ctx, engineID, sdk.EngineTypePriority)
 	if err != nil {
 		return nil, err
 	}
@@ -227,19 +347,92 @@ func (e *Executor) ExecuteBatchPriority(ctx context.Context, engineID string, us
 
 	execCtx := e.createContext(ctx, userID, engineID)
 
-	result, err := e.execute(ctx, engineID, "batch_calculate", func() (any, error) {
-		return priority.BatchCalculate(execCtx, inputs)
+	result, err := e.cached(engineID, "batch_calculate", userID, inputs, func() (any, error) {
+		return e.execute(ctx, engineID, "batch_calculate", func() (any, error) {
+			return priority.BatchCalculate(execCtx, inputs)
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return result.([]types.PriorityOutput), nil
+	// Copy so callers cannot modify a cached result.
+	outputs := result.([]types.PriorityOutput)
+	return append([]types.PriorityOutput(nil), outputs...), nil
+}
+
+// ExecuteParallelPriority calculates the priority of each input separately,
+// evaluating up to MaxParallelEvaluations inputs at once. Outputs are in the
+// same order as inputs. Unlike ExecuteBatchPriority, each input goes through
+// the circuit breaker and result cache on its own, so unchanged inputs are
+// served from the cache when the rest of the list changes.
+// An empty engineID uses the default priority engine.
+func (e *Executor) ExecuteParallelPriority(ctx context.Context, engineID string, userID uuid.UUID, inputs []types.PriorityInput) ([]types.PriorityOutput, error) {
+	engineID, engine, err := e.resolve(ctx, engineID, sdk.EngineTypePriority)
+	if err != nil {
+		return nil, err
+	}
+
+	priority, ok := engine.(types.PriorityEngine)
+	if !ok {
+		return nil, fmt.Errorf("engine %s is not a priority engine", engineID)
+	}
+
+	limit := e.config.MaxParallelEvaluations
+	if limit <= 0 {
+		limit = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	execCtx := e.createContext(ctx, userID, engineID)
+
+	outputs := make([]types.PriorityOutput, len(inputs))
+	errs := make([]error, len(inputs))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i := range inputs {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			input := inputs[i]
+			result, err := e.cached(engineID, "calculate_priority", userID, input, func() (any, error) {
+				return e.execute(ctx, engineID, "calculate_priority", func() (any, error) {
+					return priority.CalculatePriority(execCtx, input)
+				})
+			})
+			if err != nil {
+				errs[i] = fmt.Errorf("priority for %s: %w", input.ID, err)
+				// Stop starting new evaluations once one has failed.
+				cancel()
+				return
+			}
+			outputs[i] = *result.(*types.PriorityOutput)
+		}(i)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return outputs, nil
 }
 
 // ExecuteClassify executes a classification.
+// An empty engineID uses the default classifier engine.
 func (e *Executor) ExecuteClassify(ctx context.Context, engineID string, userID uuid.UUID, input types.ClassifyInput) (*types.ClassifyOutput, error) {
-	engine, err := e.registry.Get(ctx, engineID)
+	engineID, engine, err := e.resolve(ctx, engineID, sdk.EngineTypeClassifier)
 	if err != nil {
 		return nil, err
 	}
@@ -251,19 +444,24 @@ func (e *Executor) ExecuteClassify(ctx context.Context, engineID string, userID
 
 	execCtx := e.createContext(ctx, userID, engineID)
 
-	result, err := e.execute(ctx, engineID, "classify", func() (any, error) {
-		return classifier.Classify(execCtx, input)
+	result, err := e.cached(engineID, "classify", userID, input, func() (any, error) {
+		return e.execute(ctx, engineID, "classify", func() (any, error) {
+			return classifier.Classify(execCtx, input)
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return result.(*types.ClassifyOutput), nil
+	// Copy so callers cannot modify a cached result.
+	output := *result.(*types.ClassifyOutput)
+	return &output, nil
 }
 
 // ExecuteAutomation executes automation rules.
+// An empty engineID uses the default automation engine.
 func (e *Executor) ExecuteAutomation(ctx context.Context, engineID string, userID uuid.UUID, input types.AutomationInput) (*types.AutomationOutput, error) {
-	engine, err := e.registry.Get(ctx, engineID)
+	engineID, engine, err := e.resolve(ctx, engineID, sdk.EngineTypeAutomation)
 	if err != nil {
 		return nil, err
 	}
@@ -285,6 +483,23 @@ func (e *Executor) ExecuteAutomation(ctx context.Context, engineID string, userI
 	return result.(*types.AutomationOutput), nil
 }
 
+// ValidateAutomationRule checks a rule definition against an automation
+// engine. An empty engineID uses the default automation engine. A rejected
+// rule is not an engine failure, so validation bypasses the circuit breaker.
+func (e *Executor) ValidateAutomationRule(ctx context.Context, engineID string, userID uuid.UUID, rule types.AutomationRule) error {
+	engineID, engine, err := e.resolve(ctx, engineID, sdk.EngineTypeAutomation)
+	if err != nil {
+		return err
+	}
+
+	automation, ok := engine.(types.AutomationEngine)
+	if !ok {
+		return fmt.Errorf("engine %s is not an automation engine", engineID)
+	}
+
+	return automation.ValidateRule(e.createContext(ctx, userID, engineID), rule)
+}
+
 // HealthCheck checks the health of an engine.
 func (e *Executor) HealthCheck(ctx context.Context, engineID string) (sdk.HealthStatus, error) {
 	engine, err := e.registry.Get(ctx, engineID)
@@ -302,7 +517,9 @@ func (e *Executor) GetMetrics() map[string]EngineMetrics {
 
 // GetCircuitBreakerState returns the circuit breaker state for an engine.
 func (e *Executor) GetCircuitBreakerState(engineID string) string {
+	e.breakersMu.Lock()
 	breaker := e.breakers[engineID]
+	e.breakersMu.Unlock()
 	if breaker == nil {
 		return "none"
 	}
@@ -311,6 +528,32 @@ func (e *Executor) GetCircuitBreakerState(engineID string) string {
 
 // ResetCircuitBreaker resets the circuit breaker for an engine.
 func (e *Executor) ResetCircuitBreaker(engineID string) {
+	e.breakersMu.Lock()
 	delete(e.breakers, engineID)
+	e.breakersMu.Unlock()
 	e.logger.Info("circuit breaker reset", "engine_id", engineID)
 }
+
+// InvalidateCache drops all cached results for an engine.
+func (e *Executor) InvalidateCache(engineID string) {
+	e.cache.invalidate(engineID)
+}
+
+// Reconfigure re-initializes an engine with a new configuration. Cached
+// results for the engine are dropped, since they were computed under the
+// previous configuration.
+func (e *Executor) Reconfigure(ctx context.Context, engineID string, config sdk.EngineConfig) error {
+	engine, err := e.registry.Get(ctx, engineID)
+	if err != nil {
+		return err
+	}
+
+	err = engine.Initialize(ctx, config)
+	e.InvalidateCache(engineID)
+	if err != nil {
+		return fmt.Errorf("failed to reconfigure engine %s: %w", engineID, err)
+	}
+
+	e.logger.Info("engine reconfigured", "engine_id", engineID)
+	return nil
+}
@@ -2,15 +2,27 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/felixgeelhaar/orbita/internal/engine/builtin"
 	"github.com/felixgeelhaar/orbita/internal/engine/registry"
 	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/telemetry"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 // mockEngine is a simple mock engine for testing.
@@ -158,6 +170,219 @@ func TestExecutorResetCircuitBreaker(t *testing.T) {
 	exec.ResetCircuitBreaker("test.engine")
 }
 
+// countingPriorityEngine scores items by their priority times a configurable
+// weight and counts how often it is called.
+type countingPriorityEngine struct {
+	*mockEngine
+	weight float64
+	calls  int
+}
+
+func newCountingPriorityEngine(id string) *countingPriorityEngine {
+	return &countingPriorityEngine{
+		mockEngine: newMockEngine(id, "Counting Priority", sdk.EngineTypePriority),
+		weight:     1,
+	}
+}
+
+func (e *countingPriorityEngine) Initialize(ctx context.Context, config sdk.EngineConfig) error {
+	if w := config.GetFloat("weight"); w > 0 {
+		e.weight = w
+	}
+	return nil
+}
+
+func (e *countingPriorityEngine) CalculatePriority(ctx *sdk.ExecutionContext, input types.PriorityInput) (*types.PriorityOutput, error) {
+	e.calls++
+	return &types.PriorityOutput{ID: input.ID, Score: float64(input.Priority) * e.weight}, nil
+}
+
+func (e *countingPriorityEngine) BatchCalculate(ctx *sdk.ExecutionContext, inputs []types.PriorityInput) ([]types.PriorityOutput, error) {
+	e.calls++
+	outputs := make([]types.PriorityOutput, 0, len(inputs))
+	for _, input := range inputs {
+		outputs = append(outputs, types.PriorityOutput{ID: input.ID, Score: float64(input.Priority) * e.weight})
+	}
+	return outputs, nil
+}
+
+func (e *countingPriorityEngine) ExplainFactors(ctx *sdk.ExecutionContext, input types.PriorityInput) (*types.PriorityExplanation, error) {
+	return &types.PriorityExplanation{}, nil
+}
+
+func newCachingExecutor(t *testing.T, engine *countingPriorityEngine, ttl time.Duration) *Executor {
+	t.Helper()
+	reg := registry.NewRegistry(testLogger())
+	require.NoError(t, reg.RegisterBuiltin(engine))
+
+	config := DefaultExecutorConfig()
+	config.ResultCacheTTLs = map[string]time.Duration{engine.metadata.ID: ttl}
+	return NewExecutor(reg, NewMetricsCollector(), testLogger(), config)
+}
+
+func TestExecutorResultCache_HitAndMiss(t *testing.T) {
+	engine := newCountingPriorityEngine("test.priority")
+	exec := newCachingExecutor(t, engine, time.Minute)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	input := types.PriorityInput{ID: uuid.New(), Priority: 2}
+
+	first, err := exec.ExecutePriority(ctx, "test.priority", userID, input)
+	require.NoError(t, err)
+	second, err := exec.ExecutePriority(ctx, "test.priority", userID, input)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, engine.calls, "identical input should be served from the cache")
+	assert.Equal(t, first.Score, second.Score)
+
+	// A different input misses the cache.
+	_, err = exec.ExecutePriority(ctx, "test.priority", userID, types.PriorityInput{ID: input.ID, Priority: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 2, engine.calls)
+
+	// So does the same input for another user.
+	_, err = exec.ExecutePriority(ctx, "test.priority", uuid.New(), input)
+	require.NoError(t, err)
+	assert.Equal(t, 3, engine.calls)
+
+	// Batch calculations are cached separately.
+	inputs := []types.PriorityInput{input}
+	_, err = exec.ExecuteBatchPriority(ctx, "test.priority", userID, inputs)
+	require.NoError(t, err)
+	_, err = exec.ExecuteBatchPriority(ctx, "test.priority", userID, inputs)
+	require.NoError(t, err)
+	assert.Equal(t, 4, engine.calls)
+}
+
+func TestExecutorResultCache_CachedResultIsCopied(t *testing.T) {
+	engine := newCountingPriorityEngine("test.priority")
+	exec := newCachingExecutor(t, engine, time.Minute)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	input := types.PriorityInput{ID: uuid.New(), Priority: 2}
+
+	first, err := exec.ExecutePriority(ctx, "test.priority", userID, input)
+	require.NoError(t, err)
+	first.Score = 99
+
+	second, err := exec.ExecutePriority(ctx, "test.priority", userID, input)
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), second.Score)
+}
+
+func TestExecutorResultCache_Expires(t *testing.T) {
+	engine := newCountingPriorityEngine("test.priority")
+	exec := newCachingExecutor(t, engine, time.Minute)
+
+	now := time.Now()
+	exec.cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	userID := uuid.New()
+	input := types.PriorityInput{ID: uuid.New(), Priority: 2}
+
+	_, err := exec.ExecutePriority(ctx, "test.priority", userID, input)
+	require.NoError(t, err)
+
+	now = now.Add(59 * time.Second)
+	_, err = exec.ExecutePriority(ctx, "test.priority", userID, input)
+	require.NoError(t, err)
+	assert.Equal(t, 1, engine.calls)
+
+	now = now.Add(time.Second)
+	_, err = exec.ExecutePriority(ctx, "test.priority", userID, input)
+	require.NoError(t, err)
+	assert.Equal(t, 2, engine.calls)
+}
+
+func TestExecutorResultCache_CapsEntriesPerEngine(t *testing.T) {
+	engine := newCountingPriorityEngine("test.priority")
+	reg := registry.NewRegistry(testLogger())
+	require.NoError(t, reg.RegisterBuiltin(engine))
+
+	config := DefaultExecutorConfig()
+	config.ResultCacheTTLs = map[string]time.Duration{"test.priority": time.Minute}
+	config.ResultCacheMaxEntries = 2
+	exec := NewExecutor(reg, NewMetricsCollector(), testLogger(), config)
+
+	now := time.Now()
+	exec.cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	userID := uuid.New()
+	inputs := []types.PriorityInput{
+		{ID: uuid.New(), Priority: 1},
+		{ID: uuid.New(), Priority: 2},
+		{ID: uuid.New(), Priority: 3},
+	}
+	for _, input := range inputs {
+		_, err := exec.ExecutePriority(ctx, "test.priority", userID, input)
+		require.NoError(t, err)
+		now = now.Add(time.Second)
+	}
+	assert.Len(t, exec.cache.entries["test.priority"], 2)
+
+	// The entry closest to expiry was evicted to make room.
+	_, err := exec.ExecutePriority(ctx, "test.priority", userID, inputs[2])
+	require.NoError(t, err)
+	assert.Equal(t, 3, engine.calls)
+	_, err = exec.ExecutePriority(ctx, "test.priority", userID, inputs[0])
+	require.NoError(t, err)
+	assert.Equal(t, 4, engine.calls)
+
+	// Expired entries are dropped before live ones.
+	now = now.Add(time.Minute)
+	_, err = exec.ExecutePriority(ctx, "test.priority", userID, inputs[1])
+	require.NoError(t, err)
+	assert.Len(t, exec.cache.entries["test.priority"], 1)
+}
+
+func TestExecutorResultCache_DisabledByDefault(t *testing.T) {
+	engine := newCountingPriorityEngine("test.priority")
+	reg := registry.NewRegistry(testLogger())
+	require.NoError(t, reg.RegisterBuiltin(engine))
+	exec := NewExecutor(reg, NewMetricsCollector(), testLogger(), DefaultExecutorConfig())
+
+	ctx := context.Background()
+	input := types.PriorityInput{ID: uuid.New(), Priority: 2}
+	for i := 0; i < 2; i++ {
+		_, err := exec.ExecutePriority(ctx, "test.priority", uuid.New(), input)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, engine.calls)
+}
+
+func TestExecutorResultCache_InvalidatedOnReconfigure(t *testing.T) {
+	engine := newCountingPriorityEngine("test.priority")
+	exec := newCachingExecutor(t, engine, time.Minute)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	input := types.PriorityInput{ID: uuid.New(), Priority: 2}
+
+	before, err := exec.ExecutePriority(ctx, "test.priority", userID, input)
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), before.Score)
+
+	config := sdk.NewEngineConfig("test.priority", userID, map[string]any{"weight": 10.0})
+	require.NoError(t, exec.Reconfigure(ctx, "test.priority", config))
+
+	after, err := exec.ExecutePriority(ctx, "test.priority", userID, input)
+	require.NoError(t, err)
+	assert.Equal(t, 2, engine.calls)
+	assert.Equal(t, float64(20), after.Score)
+}
+
+func TestExecutorReconfigureNotFound(t *testing.T) {
+	exec := NewExecutor(registry.NewRegistry(testLogger()), nil, testLogger(), DefaultExecutorConfig())
+
+	err := exec.Reconfigure(context.Background(), "nonexistent.engine", sdk.EngineConfig{})
+	assert.Error(t, err)
+}
+
 func TestMetricsCollector(t *testing.T) {
 	metrics := NewMetricsCollector()
 	assert.NotNil(t, metrics)
@@ -307,3 +532,249 @@ func TestMetricsRecorderInterface(t *testing.T) {
 	metrics.Histogram("test.histogram", 100)
 	metrics.Timing("test.timing", 100*time.Millisecond)
 }
+
+// slowPriorityEngine sleeps before scoring, longer for lower priorities,
+// and records the largest number of calculations running at once.
+type slowPriorityEngine struct {
+	*countingPriorityEngine
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+	failOn      int
+}
+
+func (e *slowPriorityEngine) CalculatePriority(ctx *sdk.ExecutionContext, input types.PriorityInput) (*types.PriorityOutput, error) {
+	n := e.inFlight.Add(1)
+	defer e.inFlight.Add(-1)
+	for {
+		peak := e.maxInFlight.Load()
+		if n <= peak || e.maxInFlight.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	time.Sleep(time.Duration(10-input.Priority) * time.Millisecond)
+	if e.failOn != 0 && input.Priority == e.failOn {
+		return nil, errors.New("priority failed")
+	}
+	return &types.PriorityOutput{ID: input.ID, Score: float64(input.Priority)}, nil
+}
+
+func newParallelExecutor(t *testing.T, engine *slowPriorityEngine, limit int) *Executor {
+	t.Helper()
+	reg := registry.NewRegistry(testLogger())
+	require.NoError(t, reg.RegisterBuiltin(engine))
+
+	config := DefaultExecutorConfig()
+	config.MaxParallelEvaluations = limit
+	return NewExecutor(reg, NewMetricsCollector(), testLogger(), config)
+}
+
+func priorityInputs(n int) []types.PriorityInput {
+	inputs := make([]types.PriorityInput, n)
+	for i := range inputs {
+		inputs[i] = types.PriorityInput{ID: uuid.New(), Priority: i%5 + 1}
+	}
+	return inputs
+}
+
+func TestExecuteParallelPriority_PreservesOrder(t *testing.T) {
+	engine := &slowPriorityEngine{countingPriorityEngine: newCountingPriorityEngine("test.priority")}
+	exec := newParallelExecutor(t, engine, 4)
+
+	inputs := priorityInputs(20)
+	outputs, err := exec.ExecuteParallelPriority(context.Background(), "test.priority", uuid.New(), inputs)
+	require.NoError(t, err)
+	require.Len(t, outputs, len(inputs))
+	for i, input := range inputs {
+		assert.Equal(t, input.ID, outputs[i].ID)
+		assert.Equal(t, float64(input.Priority), outputs[i].Score)
+	}
+}
+
+func TestExecuteParallelPriority_BoundsConcurrency(t *testing.T) {
+	engine := &slowPriorityEngine{countingPriorityEngine: newCountingPriorityEngine("test.priority")}
+	exec := newParallelExecutor(t, engine, 3)
+
+	_, err := exec.ExecuteParallelPriority(context.Background(), "test.priority", uuid.New(), priorityInputs(30))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, engine.maxInFlight.Load(), int32(3))
+	assert.Greater(t, engine.maxInFlight.Load(), int32(1), "inputs should be evaluated concurrently")
+}
+
+func TestExecuteParallelPriority_NoLimitRunsSerially(t *testing.T) {
+	engine := &slowPriorityEngine{countingPriorityEngine: newCountingPriorityEngine("test.priority")}
+	exec := newParallelExecutor(t, engine, 0)
+
+	_, err := exec.ExecuteParallelPriority(context.Background(), "test.priority", uuid.New(), priorityInputs(5))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), engine.maxInFlight.Load())
+}
+
+func TestExecuteParallelPriority_ReturnsError(t *testing.T) {
+	engine := &slowPriorityEngine{countingPriorityEngine: newCountingPriorityEngine("test.priority"), failOn: 3}
+	exec := newParallelExecutor(t, engine, 2)
+
+	outputs, err := exec.ExecuteParallelPriority(context.Background(), "test.priority", uuid.New(), priorityInputs(10))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "priority failed")
+	assert.Nil(t, outputs)
+}
+
+func TestExecuteParallelPriority_Empty(t *testing.T) {
+	engine := &slowPriorityEngine{countingPriorityEngine: newCountingPriorityEngine("test.priority")}
+	exec := newParallelExecutor(t, engine, 4)
+
+	outputs, err := exec.ExecuteParallelPriority(context.Background(), "test.priority", uuid.New(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, outputs)
+}
+
+// failingPriorityEngine fails every priority calculation.
+type failingPriorityEngine struct {
+	*countingPriorityEngine
+}
+
+func (e *failingPriorityEngine) CalculatePriority(ctx *sdk.ExecutionContext, input types.PriorityInput) (*types.PriorityOutput, error) {
+	return nil, errors.New("priority failed")
+}
+
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+	attrs := make(map[attribute.Key]string)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+	return attrs
+}
+
+func TestExecutorTelemetry_Spans(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	reg := registry.NewRegistry(testLogger())
+	require.NoError(t, reg.RegisterBuiltin(newCountingPriorityEngine("test.priority")))
+	require.NoError(t, reg.RegisterBuiltin(&failingPriorityEngine{newCountingPriorityEngine("test.failing")}))
+
+	config := DefaultExecutorConfig()
+	config.FailureThreshold = 1
+	config.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	config.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	exec := NewExecutor(reg, NewMetricsCollector(), testLogger(), config)
+
+	ctx := context.Background()
+	input := types.PriorityInput{ID: uuid.New(), Priority: 2}
+
+	_, err := exec.ExecutePriority(ctx, "test.priority", uuid.New(), input)
+	require.NoError(t, err)
+	_, err = exec.ExecutePriority(ctx, "test.failing", uuid.New(), input)
+	require.Error(t, err)
+	// The first failure opened the breaker.
+	_, err = exec.ExecutePriority(ctx, "test.failing", uuid.New(), input)
+	require.ErrorIs(t, err, sdk.ErrCircuitOpen)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 3)
+
+	wantOutcomes := []struct {
+		engineID string
+		outcome  string
+		status   codes.Code
+	}{
+		{"test.priority", telemetry.OutcomeSuccess, codes.Unset},
+		{"test.failing", telemetry.OutcomeError, codes.Error},
+		{"test.failing", OutcomeCircuitOpen, codes.Error},
+	}
+	for i, want := range wantOutcomes {
+		span := ended[i]
+		assert.Equal(t, "engine.calculate_priority", span.Name())
+		attrs := spanAttrs(span)
+		assert.Equal(t, want.engineID, attrs[AttrEngineID])
+		assert.Equal(t, "calculate_priority", attrs[AttrEngineOperation])
+		assert.Equal(t, want.outcome, attrs[telemetry.AttrOutcome])
+		assert.Equal(t, want.status, span.Status().Code)
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var calls int64
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "orbita.engine.calls" {
+			continue
+		}
+		for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+			calls += dp.Value
+		}
+	}
+	assert.Equal(t, int64(3), calls)
+}
+
+func TestExecutor_RoutesToDefaultEngine(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	input := types.PriorityInput{ID: uuid.New(), Priority: 2}
+
+	builtin := newCountingPriorityEngine("orbita.priority.default")
+	installed := newCountingPriorityEngine("acme.priority.weighted")
+	installed.weight = 10
+
+	reg := registry.NewRegistry(testLogger())
+	require.NoError(t, reg.RegisterBuiltin(builtin))
+	require.NoError(t, reg.RegisterFactory("acme.priority.weighted", func() (sdk.Engine, error) {
+		return installed, nil
+	}, &registry.Manifest{ID: "acme.priority.weighted", Type: "priority"}))
+	exec := NewExecutor(reg, NewMetricsCollector(), testLogger(), DefaultExecutorConfig())
+
+	output, err := exec.ExecutePriority(ctx, "", userID, input)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, output.Score, "the built-in engine is the default")
+
+	require.NoError(t, reg.SetDefault(sdk.EngineTypePriority, "acme.priority.weighted"))
+	output, err = exec.ExecutePriority(ctx, "", userID, input)
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, output.Score)
+	assert.Equal(t, 1, installed.calls)
+
+	// Naming an engine still bypasses the default.
+	output, err = exec.ExecutePriority(ctx, "orbita.priority.default", userID, input)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, output.Score)
+}
+
+func TestExecutor_DefaultEngineFallsBackWhenLoadFails(t *testing.T) {
+	builtin := newCountingPriorityEngine("orbita.priority.default")
+	reg := registry.NewRegistry(testLogger())
+	require.NoError(t, reg.RegisterBuiltin(builtin))
+	require.NoError(t, reg.RegisterFactory("acme.priority.broken", func() (sdk.Engine, error) {
+		return nil, errors.New("plugin crashed")
+	}, &registry.Manifest{ID: "acme.priority.broken", Type: "priority"}))
+	require.NoError(t, reg.SetDefault(sdk.EngineTypePriority, "acme.priority.broken"))
+	exec := NewExecutor(reg, NewMetricsCollector(), testLogger(), DefaultExecutorConfig())
+
+	outputs, err := exec.ExecuteBatchPriority(context.Background(), "", uuid.New(), []types.PriorityInput{{ID: uuid.New(), Priority: 3}})
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	assert.Equal(t, 3.0, outputs[0].Score)
+	assert.Equal(t, 1, builtin.calls)
+}
+
+func TestExecutor_ValidateAutomationRule(t *testing.T) {
+	reg := registry.NewRegistry(testLogger())
+	require.NoError(t, reg.RegisterBuiltin(builtin.NewDefaultAutomationEngine()))
+	exec := NewExecutor(reg, NewMetricsCollector(), testLogger(), DefaultExecutorConfig())
+
+	rule := types.AutomationRule{
+		ID:      uuid.New(),
+		Name:    "Notify on completion",
+		Trigger: types.RuleTrigger{Type: "event", EventTypes: []string{"task.completed"}},
+		Actions: []types.RuleAction{{Type: "notification.send"}},
+	}
+	require.NoError(t, exec.ValidateAutomationRule(context.Background(), "", uuid.New(), rule))
+
+	rule.Actions = []types.RuleAction{{Type: "email.teleport"}}
+	for i := 0; i < 10; i++ {
+		err := exec.ValidateAutomationRule(context.Background(), "", uuid.New(), rule)
+		assert.ErrorContains(t, err, "email.teleport")
+	}
+	assert.NotEqual(t, "open", exec.GetCircuitBreakerState("orbita.automation.default"), "rejected rules do not trip the breaker")
+}
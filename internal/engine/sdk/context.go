@@ -56,6 +56,17 @@ func (ec *ExecutionContext) Deadline() (deadline time.Time, ok bool) {
 	return ec.ctx.Deadline()
 }
 
+// Remaining returns how long is left before the deadline, or false when the
+// context has none. Long-running engines can use it to stop early with a
+// partial result rather than being cut off.
+func (ec *ExecutionContext) Remaining() (time.Duration, bool) {
+	deadline, ok := ec.ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return time.Until(deadline), true
+}
+
 // Done returns a channel that's closed when work done on behalf of this context should be canceled.
 func (ec *ExecutionContext) Done() <-chan struct{} {
 	return ec.ctx.Done()
@@ -147,6 +147,25 @@ func TestExecutionContext_ContextInterface(t *testing.T) {
 	})
 }
 
+func TestExecutionContext_Remaining(t *testing.T) {
+	t.Run("without deadline", func(t *testing.T) {
+		ec := NewExecutionContext(context.Background(), uuid.New(), "test")
+		_, ok := ec.Remaining()
+		assert.False(t, ok)
+	})
+
+	t.Run("with deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		ec := NewExecutionContext(ctx, uuid.New(), "test")
+
+		remaining, ok := ec.Remaining()
+		require.True(t, ok)
+		assert.Greater(t, remaining, 59*time.Second)
+		assert.LessOrEqual(t, remaining, time.Minute)
+	})
+}
+
 func TestExecutionContext_WithLogger(t *testing.T) {
 	t.Run("sets custom logger with context fields", func(t *testing.T) {
 		userID := uuid.New()
@@ -10,6 +10,9 @@ var (
 	// ErrEngineNotFound is returned when an engine cannot be found in the registry.
 	ErrEngineNotFound = errors.New("engine not found")
 
+	// ErrEngineTypeMismatch is returned when an engine is used as a different type than it is.
+	ErrEngineTypeMismatch = errors.New("engine type mismatch")
+
 	// ErrEngineAlreadyExists is returned when trying to register a duplicate engine.
 	ErrEngineAlreadyExists = errors.New("engine already exists")
 
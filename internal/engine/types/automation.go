@@ -370,6 +370,9 @@ var StandardActionTypes = []string{
 	"habit.skip",
 	"habit.adjust_frequency",
 
+	// Inbox actions
+	"inbox.promote",
+
 	// Webhook actions
 	"webhook.call",
 }
@@ -0,0 +1,165 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
+	"github.com/google/uuid"
+)
+
+// Operation describes a call an engine type accepts: what it consumes and
+// what it produces.
+type Operation struct {
+	// Name is the method name (e.g., "ScheduleTasks").
+	Name string `json:"name"`
+
+	// Input is the input type name, empty if the operation takes none.
+	Input string `json:"input,omitempty"`
+
+	// InputFields are the input's JSON fields.
+	InputFields []Field `json:"input_fields,omitempty"`
+
+	// Output is the output type name.
+	Output string `json:"output"`
+
+	// OutputFields are the output's JSON fields.
+	OutputFields []Field `json:"output_fields,omitempty"`
+}
+
+// Field describes one JSON field of an operation's input or output.
+type Field struct {
+	// Name is the JSON field name.
+	Name string `json:"name"`
+
+	// Type is the JSON-level type (string, integer, number, boolean, array,
+	// object, uuid, datetime, duration) or the name of a nested type.
+	Type string `json:"type"`
+
+	// Optional indicates the field may be omitted.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// engineInterfaces maps each engine type to the interface its engines implement.
+var engineInterfaces = map[sdk.EngineType]reflect.Type{
+	sdk.EngineTypeScheduler:  reflect.TypeOf((*SchedulerEngine)(nil)).Elem(),
+	sdk.EngineTypePriority:   reflect.TypeOf((*PriorityEngine)(nil)).Elem(),
+	sdk.EngineTypeClassifier: reflect.TypeOf((*ClassifierEngine)(nil)).Elem(),
+	sdk.EngineTypeAutomation: reflect.TypeOf((*AutomationEngine)(nil)).Elem(),
+}
+
+var baseEngine = reflect.TypeOf((*sdk.Engine)(nil)).Elem()
+
+// Operations returns the operations engines of the given type implement,
+// sorted by name. It returns nil for unknown engine types.
+func Operations(engineType sdk.EngineType) []Operation {
+	iface, ok := engineInterfaces[engineType]
+	if !ok {
+		return nil
+	}
+
+	var ops []Operation
+	for i := 0; i < iface.NumMethod(); i++ {
+		method := iface.Method(i)
+		if _, ok := baseEngine.MethodByName(method.Name); ok {
+			continue
+		}
+
+		op := Operation{Name: method.Name}
+		// The first parameter is always the execution context.
+		if method.Type.NumIn() > 1 {
+			input := method.Type.In(1)
+			op.Input = typeName(input)
+			op.InputFields = fields(input)
+		}
+		if method.Type.NumOut() > 0 {
+			output := method.Type.Out(0)
+			op.Output = typeName(output)
+			op.OutputFields = fields(output)
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// typeName returns a short name for t, such as "ScheduleTasksInput" or
+// "[]PriorityOutput". Pointers are dropped.
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + typeName(t.Elem())
+	}
+	return t.Name()
+}
+
+// fields lists the JSON fields of a struct type, or of the element type of
+// a slice of structs.
+func fields(t reflect.Type) []Field {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var result []Field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		optional := strings.Contains(opts, "omitempty") || f.Type.Kind() == reflect.Pointer
+		result = append(result, Field{Name: name, Type: jsonType(f.Type), Optional: optional})
+	}
+	return result
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+)
+
+// jsonType names the JSON-level type of a Go type.
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return "datetime"
+	case durationType:
+		return "duration"
+	case uuidType:
+		return "uuid"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Interface:
+		return "object"
+	case reflect.Struct:
+		return t.Name()
+	}
+	return t.Kind().String()
+}
@@ -23,6 +23,15 @@ type PriorityEngine interface {
 	ExplainFactors(ctx *sdk.ExecutionContext, input PriorityInput) (*PriorityExplanation, error)
 }
 
+// User-assigned priority levels as passed to engines (lower is more important).
+const (
+	PriorityLevelUrgent = 1
+	PriorityLevelHigh   = 2
+	PriorityLevelMedium = 3
+	PriorityLevelLow    = 4
+	PriorityLevelNone   = 5
+)
+
 // PriorityInput contains the signals used to calculate priority.
 type PriorityInput struct {
 	// ID is the unique identifier for the item being scored.
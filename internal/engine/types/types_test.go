@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/felixgeelhaar/orbita/internal/engine/sdk"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -107,3 +108,23 @@ func TestPriorityEngineCapabilities_Constants(t *testing.T) {
 	assert.Equal(t, "streak_aware", CapabilityStreakAware)
 	assert.Equal(t, "meeting_cadence", CapabilityMeetingCadence)
 }
+
+func TestOperations(t *testing.T) {
+	ops := Operations(sdk.EngineTypePriority)
+	names := make([]string, len(ops))
+	for i, op := range ops {
+		names[i] = op.Name
+	}
+	assert.Equal(t, []string{"BatchCalculate", "CalculatePriority", "ExplainFactors"}, names)
+
+	calculate := ops[1]
+	assert.Equal(t, "PriorityInput", calculate.Input)
+	assert.Equal(t, "PriorityOutput", calculate.Output)
+	assert.Contains(t, calculate.InputFields, Field{Name: "id", Type: "uuid"})
+	assert.Contains(t, calculate.InputFields, Field{Name: "due_date", Type: "datetime", Optional: true})
+	assert.Contains(t, calculate.InputFields, Field{Name: "duration", Type: "duration"})
+	assert.Contains(t, calculate.OutputFields, Field{Name: "score", Type: "number"})
+
+	assert.Equal(t, "[]PriorityInput", ops[0].Input)
+	assert.Nil(t, Operations("unknown"))
+}
@@ -33,7 +33,7 @@ func NewArchiveHabitHandler(habitRepo domain.Repository, outboxRepo outbox.Repos
 
 // Handle executes the ArchiveHabitCommand.
 func (h *ArchiveHabitHandler) Handle(ctx context.Context, cmd ArchiveHabitCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		// Find the habit
 		habit, err := h.habitRepo.FindByID(txCtx, cmd.HabitID)
 		if err != nil {
@@ -70,4 +70,5 @@ func (h *ArchiveHabitHandler) Handle(ctx context.Context, cmd ArchiveHabitComman
 		}
 		return h.outboxRepo.SaveBatch(txCtx, msgs)
 	})
+	return classifyHabitError(err)
 }
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// BulkLogCompletionCommand logs today's completion for several habits.
+type BulkLogCompletionCommand struct {
+	UserID   uuid.UUID
+	HabitIDs []uuid.UUID
+	Notes    string
+}
+
+// HabitCompletionResult reports what a bulk completion did to one habit. Err
+// is set when the habit was skipped because it is missing, not owned,
+// archived or already completed today.
+type HabitCompletionResult struct {
+	HabitID      uuid.UUID
+	CompletionID uuid.UUID
+	Streak       int
+	TotalDone    int
+	Err          error
+}
+
+// BulkLogCompletionResult contains one result per requested habit, in order.
+type BulkLogCompletionResult struct {
+	Results []HabitCompletionResult
+}
+
+// BulkLogCompletionHandler handles the BulkLogCompletionCommand.
+type BulkLogCompletionHandler struct {
+	habitRepo    domain.Repository
+	outboxRepo   outbox.Repository
+	uow          sharedApplication.UnitOfWork
+	dayRollovers DayRollovers
+}
+
+// NewBulkLogCompletionHandler creates a new BulkLogCompletionHandler.
+func NewBulkLogCompletionHandler(habitRepo domain.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *BulkLogCompletionHandler {
+	return &BulkLogCompletionHandler{
+		habitRepo:  habitRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// WithDayRollovers counts completions logged before the user's day rollover
+// hour toward the previous day.
+func (h *BulkLogCompletionHandler) WithDayRollovers(rollovers DayRollovers) *BulkLogCompletionHandler {
+	h.dayRollovers = rollovers
+	return h
+}
+
+// Handle logs a completion for every habit in one transaction. Habits that
+// cannot be completed are reported in their result and do not stop the
+// others; a storage failure rolls back the whole batch.
+func (h *BulkLogCompletionHandler) Handle(ctx context.Context, cmd BulkLogCompletionCommand) (*BulkLogCompletionResult, error) {
+	if len(cmd.HabitIDs) == 0 {
+		return nil, ErrNoHabitsToLog
+	}
+
+	var result *BulkLogCompletionResult
+	now := time.Now()
+
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		result = &BulkLogCompletionResult{Results: make([]HabitCompletionResult, 0, len(cmd.HabitIDs))}
+		var msgs []*outbox.Message
+
+		for _, habitID := range cmd.HabitIDs {
+			item := HabitCompletionResult{HabitID: habitID}
+
+			habit, err := h.habitRepo.FindByID(txCtx, habitID)
+			if err != nil {
+				return err
+			}
+			var completion *domain.HabitCompletion
+			switch {
+			case habit == nil:
+				item.Err = ErrHabitNotFound
+			case habit.UserID() != cmd.UserID:
+				item.Err = ErrNotOwner
+			default:
+				if err := applyDayRollover(txCtx, h.dayRollovers, cmd.UserID, habit); err != nil {
+					return err
+				}
+				completion, item.Err = habit.LogCompletion(now, cmd.Notes)
+			}
+			if item.Err != nil {
+				item.Err = classifyHabitError(item.Err)
+				result.Results = append(result.Results, item)
+				continue
+			}
+
+			// Save the habit
+			if err := h.habitRepo.Save(txCtx, habit); err != nil {
+				return err
+			}
+
+			events := habit.DomainEvents()
+			sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+			for _, event := range events {
+				msg, err := outbox.NewMessage(event)
+				if err != nil {
+					return err
+				}
+				msgs = append(msgs, msg)
+			}
+
+			item.CompletionID = completion.ID()
+			item.Streak = habit.Streak()
+			item.TotalDone = habit.TotalDone()
+			result.Results = append(result.Results, item)
+		}
+
+		// Save domain events to outbox
+		if len(msgs) == 0 {
+			return nil
+		}
+		return h.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+	if err != nil {
+		return nil, classifyHabitError(err)
+	}
+
+	return result, nil
+}
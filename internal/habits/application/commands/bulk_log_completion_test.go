@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkLogCompletionHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("logs a batch of mixed habits with per-habit streaks", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		fresh := createTestHabit(userID, "Meditate")
+		onStreak := createTestHabit(userID, "Run")
+		_, err := onStreak.LogCompletion(time.Now().AddDate(0, 0, -1), "")
+		require.NoError(t, err)
+		onStreak.ClearDomainEvents()
+		doneToday := createTestHabit(userID, "Journal")
+		_, err = doneToday.LogCompletion(time.Now(), "")
+		require.NoError(t, err)
+		doneToday.ClearDomainEvents()
+		archived := createTestHabit(userID, "Stretch")
+		archived.Archive()
+		notMine := createTestHabit(uuid.New(), "Read")
+		missing := uuid.New()
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		for _, habit := range []*domain.Habit{fresh, onStreak, doneToday, archived, notMine} {
+			repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+		}
+		repo.On("FindByID", txCtx, missing).Return(nil, nil)
+		repo.On("Save", txCtx, fresh).Return(nil)
+		repo.On("Save", txCtx, onStreak).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.MatchedBy(func(msgs []*outbox.Message) bool {
+			return len(msgs) == 2 && msgs[0].RoutingKey == "habits.habit.completed"
+		})).Return(nil)
+
+		result, err := NewBulkLogCompletionHandler(repo, outboxRepo, uow).Handle(ctx, BulkLogCompletionCommand{
+			UserID:   userID,
+			HabitIDs: []uuid.UUID{fresh.ID(), onStreak.ID(), doneToday.ID(), archived.ID(), notMine.ID(), missing},
+			Notes:    "end of day review",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 6)
+
+		assert.NoError(t, result.Results[0].Err)
+		assert.Equal(t, fresh.ID(), result.Results[0].HabitID)
+		assert.NotEqual(t, uuid.Nil, result.Results[0].CompletionID)
+		assert.Equal(t, 1, result.Results[0].Streak)
+		assert.Equal(t, 1, result.Results[0].TotalDone)
+
+		assert.NoError(t, result.Results[1].Err)
+		assert.Equal(t, 2, result.Results[1].Streak)
+		assert.Equal(t, 2, result.Results[1].TotalDone)
+
+		assert.ErrorIs(t, result.Results[2].Err, domain.ErrHabitAlreadyLogged)
+		assert.ErrorIs(t, result.Results[2].Err, sharedApplication.ErrConflict)
+		assert.Equal(t, uuid.Nil, result.Results[2].CompletionID)
+		assert.Equal(t, 1, doneToday.TotalDone())
+		assert.ErrorIs(t, result.Results[3].Err, domain.ErrHabitArchived)
+		assert.ErrorIs(t, result.Results[4].Err, ErrNotOwner)
+		assert.Equal(t, 0, notMine.TotalDone())
+		assert.ErrorIs(t, result.Results[5].Err, ErrHabitNotFound)
+
+		repo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("nothing to save when every habit is skipped", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		uow := new(mockHabitUnitOfWork)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(userID, "Meditate")
+		_, err := habit.LogCompletion(time.Now(), "")
+		require.NoError(t, err)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+
+		result, err := NewBulkLogCompletionHandler(repo, new(mockHabitOutboxRepo), uow).Handle(ctx, BulkLogCompletionCommand{
+			UserID:   userID,
+			HabitIDs: []uuid.UUID{habit.ID()},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 1)
+		assert.ErrorIs(t, result.Results[0].Err, domain.ErrHabitAlreadyLogged)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("storage failure rolls back the batch", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		uow := new(mockHabitUnitOfWork)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(userID, "Meditate")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+		repo.On("Save", txCtx, habit).Return(errors.New("disk full"))
+
+		_, err := NewBulkLogCompletionHandler(repo, new(mockHabitOutboxRepo), uow).Handle(ctx, BulkLogCompletionCommand{
+			UserID:   userID,
+			HabitIDs: []uuid.UUID{habit.ID()},
+		})
+
+		assert.EqualError(t, err, "disk full")
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("rejects an empty batch", func(t *testing.T) {
+		_, err := NewBulkLogCompletionHandler(new(mockHabitRepo), new(mockHabitOutboxRepo), new(mockHabitUnitOfWork)).Handle(context.Background(), BulkLogCompletionCommand{UserID: userID})
+		assert.ErrorIs(t, err, ErrNoHabitsToLog)
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+	})
+}
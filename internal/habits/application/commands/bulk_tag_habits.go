@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// BulkTagHabitsCommand adds and removes the same tags on several habits.
+type BulkTagHabitsCommand struct {
+	UserID   uuid.UUID
+	HabitIDs []uuid.UUID
+	Add      []string
+	Remove   []string
+}
+
+// HabitTagResult reports what a bulk tag change did to one habit. Err is set
+// when the habit was skipped because it is missing, not owned or archived.
+type HabitTagResult struct {
+	HabitID uuid.UUID
+	Added   []string
+	Removed []string
+	Tags    []string
+	Err     error
+}
+
+// BulkTagHabitsResult contains one result per requested habit, in order.
+type BulkTagHabitsResult struct {
+	Results []HabitTagResult
+}
+
+// BulkTagHabitsHandler handles the BulkTagHabitsCommand.
+type BulkTagHabitsHandler struct {
+	habitRepo  domain.Repository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+}
+
+// NewBulkTagHabitsHandler creates a new BulkTagHabitsHandler.
+func NewBulkTagHabitsHandler(habitRepo domain.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *BulkTagHabitsHandler {
+	return &BulkTagHabitsHandler{
+		habitRepo:  habitRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// Handle applies the tag changes to every habit in one transaction. Habits
+// that cannot be tagged are reported in their result and do not stop the
+// others; a storage failure rolls back the whole batch.
+func (h *BulkTagHabitsHandler) Handle(ctx context.Context, cmd BulkTagHabitsCommand) (*BulkTagHabitsResult, error) {
+	add, remove, err := normalizeTagChanges(cmd.Add, cmd.Remove)
+	if err != nil {
+		return nil, classifyHabitError(err)
+	}
+	if len(cmd.HabitIDs) == 0 {
+		return nil, ErrNoHabitsToTag
+	}
+
+	var result *BulkTagHabitsResult
+
+	err = sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		result = &BulkTagHabitsResult{Results: make([]HabitTagResult, 0, len(cmd.HabitIDs))}
+		var msgs []*outbox.Message
+
+		for _, habitID := range cmd.HabitIDs {
+			item := HabitTagResult{HabitID: habitID}
+
+			habit, err := h.habitRepo.FindByID(txCtx, habitID)
+			if err != nil {
+				return err
+			}
+			switch {
+			case habit == nil:
+				item.Err = ErrHabitNotFound
+			case habit.UserID() != cmd.UserID:
+				item.Err = ErrNotOwner
+			default:
+				item.Added, item.Removed, item.Err = applyHabitTags(habit, add, remove)
+			}
+			if item.Err != nil {
+				item.Err = classifyHabitError(item.Err)
+				result.Results = append(result.Results, item)
+				continue
+			}
+			item.Tags = habit.Tags()
+			result.Results = append(result.Results, item)
+
+			if len(item.Added) == 0 && len(item.Removed) == 0 {
+				continue
+			}
+
+			habit.AddDomainEvent(domain.NewHabitTagsChanged(habit))
+
+			// Save the habit
+			if err := h.habitRepo.Save(txCtx, habit); err != nil {
+				return err
+			}
+
+			events := habit.DomainEvents()
+			sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+			for _, event := range events {
+				msg, err := outbox.NewMessage(event)
+				if err != nil {
+					return err
+				}
+				msgs = append(msgs, msg)
+			}
+		}
+
+		// Save domain events to outbox
+		if len(msgs) == 0 {
+			return nil
+		}
+		return h.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+	if err != nil {
+		return nil, classifyHabitError(err)
+	}
+
+	return result, nil
+}
+
+// applyHabitTags adds and removes the tags on a habit and reports which ones
+// actually changed.
+func applyHabitTags(habit *domain.Habit, add, remove []string) (added, removed []string, err error) {
+	for _, tag := range add {
+		changed, err := habit.AddTag(tag)
+		if err != nil {
+			return nil, nil, err
+		}
+		if changed {
+			added = append(added, tag)
+		}
+	}
+	for _, tag := range remove {
+		changed, err := habit.RemoveTag(tag)
+		if err != nil {
+			return nil, nil, err
+		}
+		if changed {
+			removed = append(removed, tag)
+		}
+	}
+	return added, removed, nil
+}
+
+// normalizeTagChanges normalizes the tags to add and remove and rejects
+// requests that change nothing or both add and remove the same tag.
+func normalizeTagChanges(add, remove []string) ([]string, []string, error) {
+	add, err := sharedDomain.NormalizeTags(add)
+	if err != nil {
+		return nil, nil, err
+	}
+	remove, err = sharedDomain.NormalizeTags(remove)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, nil, ErrNoTagChanges
+	}
+	for _, tag := range remove {
+		for _, other := range add {
+			if tag == other {
+				return nil, nil, fmt.Errorf("%w: %q", ErrConflictingTagChange, tag)
+			}
+		}
+	}
+	return add, remove, nil
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkTagHabitsHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	newHabit := func(owner uuid.UUID, name string, tags ...string) *domain.Habit {
+		habit := createTestHabit(owner, name)
+		habit.RehydrateTags(tags)
+		return habit
+	}
+
+	t.Run("adds and removes tags across the batch with per-habit results", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		first := newHabit(userID, "Meditate", "evening")
+		second := newHabit(userID, "Run", "health")
+		archived := newHabit(userID, "Journal", "evening")
+		archived.Archive()
+		notMine := newHabit(uuid.New(), "Stretch")
+		missing := uuid.New()
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		for _, habit := range []*domain.Habit{first, second, archived, notMine} {
+			repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+		}
+		repo.On("FindByID", txCtx, missing).Return(nil, nil)
+		repo.On("Save", txCtx, first).Return(nil)
+		repo.On("Save", txCtx, second).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.MatchedBy(func(msgs []*outbox.Message) bool {
+			return len(msgs) == 2 && msgs[0].RoutingKey == "habits.habit.tags_changed"
+		})).Return(nil)
+
+		result, err := NewBulkTagHabitsHandler(repo, outboxRepo, uow).Handle(ctx, BulkTagHabitsCommand{
+			UserID:   userID,
+			HabitIDs: []uuid.UUID{first.ID(), second.ID(), archived.ID(), notMine.ID(), missing},
+			Add:      []string{"Health", "morning"},
+			Remove:   []string{"evening"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 5)
+
+		assert.NoError(t, result.Results[0].Err)
+		assert.Equal(t, []string{"health", "morning"}, result.Results[0].Added)
+		assert.Equal(t, []string{"evening"}, result.Results[0].Removed)
+		assert.Equal(t, []string{"health", "morning"}, first.Tags())
+
+		assert.NoError(t, result.Results[1].Err)
+		assert.Equal(t, []string{"morning"}, result.Results[1].Added)
+		assert.Empty(t, result.Results[1].Removed)
+		assert.Equal(t, []string{"health", "morning"}, result.Results[1].Tags)
+
+		assert.ErrorIs(t, result.Results[2].Err, domain.ErrHabitArchived)
+		assert.ErrorIs(t, result.Results[2].Err, sharedApplication.ErrConflict)
+		assert.Equal(t, []string{"evening"}, archived.Tags())
+		assert.ErrorIs(t, result.Results[3].Err, ErrNotOwner)
+		assert.Empty(t, notMine.Tags())
+		assert.ErrorIs(t, result.Results[4].Err, ErrHabitNotFound)
+
+		repo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("storage failure rolls back the batch", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		uow := new(mockHabitUnitOfWork)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := newHabit(userID, "Meditate")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+		repo.On("Save", txCtx, habit).Return(errors.New("disk full"))
+
+		_, err := NewBulkTagHabitsHandler(repo, new(mockHabitOutboxRepo), uow).Handle(ctx, BulkTagHabitsCommand{
+			UserID:   userID,
+			HabitIDs: []uuid.UUID{habit.ID()},
+			Add:      []string{"health"},
+		})
+
+		assert.EqualError(t, err, "disk full")
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid tag changes before touching any habit", func(t *testing.T) {
+		handler := NewBulkTagHabitsHandler(new(mockHabitRepo), new(mockHabitOutboxRepo), new(mockHabitUnitOfWork))
+		ids := []uuid.UUID{uuid.New()}
+		ctx := context.Background()
+
+		_, err := handler.Handle(ctx, BulkTagHabitsCommand{UserID: userID, HabitIDs: ids, Add: []string{"a,b"}})
+		assert.ErrorIs(t, err, sharedDomain.ErrInvalidTag)
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+
+		_, err = handler.Handle(ctx, BulkTagHabitsCommand{UserID: userID, HabitIDs: ids})
+		assert.ErrorIs(t, err, ErrNoTagChanges)
+
+		_, err = handler.Handle(ctx, BulkTagHabitsCommand{UserID: userID, HabitIDs: ids, Add: []string{"health"}, Remove: []string{"health"}})
+		assert.ErrorIs(t, err, ErrConflictingTagChange)
+
+		_, err = handler.Handle(ctx, BulkTagHabitsCommand{UserID: userID, Remove: []string{"health"}})
+		assert.ErrorIs(t, err, ErrNoHabitsToTag)
+	})
+}
@@ -19,6 +19,12 @@ type CreateHabitCommand struct {
 	TimesPerWeek  int
 	DurationMins  int
 	PreferredTime string
+	// Timezone is an IANA time zone the habit's days are counted in, for
+	// habits tied to a place other than where the user currently is.
+	Timezone string
+	// CompletedOn backfills past completions, e.g. history imported from
+	// another app; the streak is rebuilt from them.
+	CompletedOn []time.Time
 }
 
 // CreateHabitResult contains the result of creating a habit.
@@ -81,6 +87,18 @@ func (h *CreateHabitHandler) Handle(ctx context.Context, cmd CreateHabitCommand)
 			habit.SetPreferredTime(domain.PreferredTime(cmd.PreferredTime))
 		}
 
+		if cmd.Timezone != "" {
+			if err := habit.SetTimezone(cmd.Timezone); err != nil {
+				return err
+			}
+		}
+
+		if len(cmd.CompletedOn) > 0 {
+			if err := habit.BackfillCompletions(cmd.CompletedOn); err != nil {
+				return err
+			}
+		}
+
 		// Save the habit
 		if err := h.habitRepo.Save(txCtx, habit); err != nil {
 			return err
@@ -106,7 +124,7 @@ func (h *CreateHabitHandler) Handle(ctx context.Context, cmd CreateHabitCommand)
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, classifyHabitError(err)
 	}
 
 	return result, nil
@@ -199,6 +199,40 @@ func TestCreateHabitHandler_Handle(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("backfills past completions", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewCreateHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		var saved *domain.Habit
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("Save", txCtx, mock.AnythingOfType("*domain.Habit")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*domain.Habit) }).
+			Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		today := time.Now()
+		cmd := CreateHabitCommand{
+			UserID:       userID,
+			Name:         "Meditate",
+			Frequency:    "daily",
+			DurationMins: 10,
+			CompletedOn:  []time.Time{today, today.AddDate(0, 0, -1), today.AddDate(0, 0, -2)},
+		}
+
+		_, err := handler.Handle(ctx, cmd)
+
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, 3, saved.Streak())
+		assert.Equal(t, 3, saved.TotalDone())
+	})
+
 	t.Run("creates habit with invalid frequency defaults to daily", func(t *testing.T) {
 		repo := new(mockHabitRepo)
 		outboxRepo := new(mockHabitOutboxRepo)
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+)
+
+var (
+	ErrHabitNotFound = sharedApplication.NewNotFoundError("habit not found")
+	ErrNotOwner      = sharedApplication.NewNotFoundError("user does not own this habit")
+
+	ErrNoHabitsToTag        = sharedApplication.NewValidationError("no habits to tag")
+	ErrNoTagChanges         = sharedApplication.NewValidationError("no tags to add or remove")
+	ErrConflictingTagChange = sharedApplication.NewValidationError("tag is both added and removed")
+
+	ErrNoHabitsToLog = sharedApplication.NewValidationError("no habits to log")
+)
+
+// classifyHabitError tags habit domain errors with an application error category.
+// Errors it does not recognise are returned unchanged and count as internal.
+func classifyHabitError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrHabitEmptyName),
+		errors.Is(err, domain.ErrHabitInvalidFreq),
+		errors.Is(err, domain.ErrHabitInvalidDuration),
+		errors.Is(err, sharedDomain.ErrInvalidTag):
+		return sharedApplication.Validation(err)
+	case errors.Is(err, domain.ErrHabitArchived),
+		errors.Is(err, domain.ErrHabitAlreadyLogged),
+		errors.Is(err, domain.ErrHabitAlreadyDone):
+		return sharedApplication.Conflict(err)
+	}
+	return err
+}
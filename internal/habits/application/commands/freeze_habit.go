@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// FreezeHabitCommand contains the data needed to pause a habit.
+type FreezeHabitCommand struct {
+	HabitID uuid.UUID
+	UserID  uuid.UUID
+	Until   time.Time // last paused day, inclusive
+}
+
+// FreezeHabitHandler handles the FreezeHabitCommand.
+type FreezeHabitHandler struct {
+	habitRepo  domain.Repository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+}
+
+// NewFreezeHabitHandler creates a new FreezeHabitHandler.
+func NewFreezeHabitHandler(habitRepo domain.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *FreezeHabitHandler {
+	return &FreezeHabitHandler{
+		habitRepo:  habitRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// Handle executes the FreezeHabitCommand. Freezing an already frozen habit
+// moves the end of the pause.
+func (h *FreezeHabitHandler) Handle(ctx context.Context, cmd FreezeHabitCommand) error {
+	if cmd.Until.IsZero() {
+		return sharedApplication.NewValidationError("freeze end date is required")
+	}
+
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		// Find the habit
+		habit, err := h.habitRepo.FindByID(txCtx, cmd.HabitID)
+		if err != nil {
+			return err
+		}
+		if habit == nil {
+			return ErrHabitNotFound
+		}
+
+		// Verify ownership
+		if habit.UserID() != cmd.UserID {
+			return ErrNotOwner
+		}
+
+		if err := habit.Freeze(cmd.Until); err != nil {
+			return err
+		}
+
+		// Save the habit
+		if err := h.habitRepo.Save(txCtx, habit); err != nil {
+			return err
+		}
+
+		// Save domain events to outbox
+		events := habit.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		return h.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+	return classifyHabitError(err)
+}
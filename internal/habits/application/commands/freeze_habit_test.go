@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreezeHabitHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+	habitID := uuid.New()
+
+	t.Run("pauses the habit through the given day", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewFreezeHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(userID, "Morning Run")
+		until := time.Now().AddDate(0, 0, 7)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habitID).Return(habit, nil)
+		repo.On("Save", txCtx, habit).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		err := handler.Handle(ctx, FreezeHabitCommand{HabitID: habitID, UserID: userID, Until: until})
+
+		require.NoError(t, err)
+		assert.True(t, habit.IsFrozenOn(until))
+		assert.False(t, habit.IsFrozenOn(until.AddDate(0, 0, 1)))
+		repo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("requires an end date", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewFreezeHabitHandler(repo, outboxRepo, uow)
+
+		err := handler.Handle(context.Background(), FreezeHabitCommand{HabitID: habitID, UserID: userID})
+
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+		uow.AssertNotCalled(t, "Begin", mock.Anything)
+	})
+
+	t.Run("rejects an archived habit", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewFreezeHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(userID, "Morning Run")
+		habit.Archive()
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habitID).Return(habit, nil)
+
+		err := handler.Handle(ctx, FreezeHabitCommand{HabitID: habitID, UserID: userID, Until: time.Now()})
+
+		assert.ErrorIs(t, err, domain.ErrHabitArchived)
+		assert.ErrorIs(t, err, sharedApplication.ErrConflict)
+	})
+
+	t.Run("returns ErrNotOwner when user does not own habit", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewFreezeHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(uuid.New(), "Someone else's habit")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habitID).Return(habit, nil)
+
+		err := handler.Handle(ctx, FreezeHabitCommand{HabitID: habitID, UserID: userID, Until: time.Now()})
+
+		assert.ErrorIs(t, err, ErrNotOwner)
+	})
+}
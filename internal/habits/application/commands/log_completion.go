@@ -2,7 +2,6 @@ package commands
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/habits/domain"
@@ -11,11 +10,6 @@ import (
 	"github.com/google/uuid"
 )
 
-var (
-	ErrHabitNotFound = errors.New("habit not found")
-	ErrNotOwner      = errors.New("user does not own this habit")
-)
-
 // LogCompletionCommand contains the data needed to log a habit completion.
 type LogCompletionCommand struct {
 	HabitID uuid.UUID
@@ -30,11 +24,17 @@ type LogCompletionResult struct {
 	TotalDone    int
 }
 
+// DayRollovers provides the hour at which a user's habit days end.
+type DayRollovers interface {
+	HabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
 // LogCompletionHandler handles the LogCompletionCommand.
 type LogCompletionHandler struct {
-	habitRepo  domain.Repository
-	outboxRepo outbox.Repository
-	uow        sharedApplication.UnitOfWork
+	habitRepo    domain.Repository
+	outboxRepo   outbox.Repository
+	uow          sharedApplication.UnitOfWork
+	dayRollovers DayRollovers
 }
 
 // NewLogCompletionHandler creates a new LogCompletionHandler.
@@ -46,6 +46,13 @@ func NewLogCompletionHandler(habitRepo domain.Repository, outboxRepo outbox.Repo
 	}
 }
 
+// WithDayRollovers counts completions logged before the user's day rollover
+// hour toward the previous day.
+func (h *LogCompletionHandler) WithDayRollovers(rollovers DayRollovers) *LogCompletionHandler {
+	h.dayRollovers = rollovers
+	return h
+}
+
 // Handle executes the LogCompletionCommand.
 func (h *LogCompletionHandler) Handle(ctx context.Context, cmd LogCompletionCommand) (*LogCompletionResult, error) {
 	var result *LogCompletionResult
@@ -64,6 +71,9 @@ func (h *LogCompletionHandler) Handle(ctx context.Context, cmd LogCompletionComm
 		if habit.UserID() != cmd.UserID {
 			return ErrNotOwner
 		}
+		if err := applyDayRollover(txCtx, h.dayRollovers, cmd.UserID, habit); err != nil {
+			return err
+		}
 
 		// Log the completion
 		completion, err := habit.LogCompletion(time.Now(), cmd.Notes)
@@ -100,8 +110,25 @@ func (h *LogCompletionHandler) Handle(ctx context.Context, cmd LogCompletionComm
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, classifyHabitError(err)
 	}
 
 	return result, nil
 }
+
+// applyDayRollover ends the habits' days at the user's day rollover hour.
+func applyDayRollover(ctx context.Context, rollovers DayRollovers, userID uuid.UUID, habits ...*domain.Habit) error {
+	if rollovers == nil {
+		return nil
+	}
+	hour, err := rollovers.HabitDayRollover(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, habit := range habits {
+		if err := habit.SetDayRollover(hour); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/application/services"
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultRecommendationWindowDays is how far back completions are analyzed.
+	DefaultRecommendationWindowDays = 90
+	// DefaultRecommendationMinCompletions is the number of recent completions
+	// needed before a window is recommended.
+	DefaultRecommendationMinCompletions = 5
+)
+
+// RecommendHabitTimeCommand asks for the best time of day to schedule a habit.
+type RecommendHabitTimeCommand struct {
+	HabitID        uuid.UUID
+	UserID         uuid.UUID
+	WindowDays     int
+	MinCompletions int
+	// Apply stores the recommendation as the habit's preferred time.
+	Apply bool
+}
+
+// RecommendHabitTimeResult contains the recommended scheduling window.
+type RecommendHabitTimeResult struct {
+	HabitID     uuid.UUID
+	Current     domain.PreferredTime
+	Recommended domain.PreferredTime
+	WindowStart time.Duration // Offset from midnight, zero for anytime
+	WindowEnd   time.Duration
+	PeakHour    int     // Hour of day with the most completions
+	Confidence  float64 // Share of completions inside the recommended window
+	Completions int     // Completions analyzed
+	Applied     bool
+}
+
+// RecommendHabitTimeHandler recommends a preferred time window for a habit
+// from when it has been completed recently.
+type RecommendHabitTimeHandler struct {
+	habitRepo  domain.Repository
+	uow        sharedApplication.UnitOfWork
+	calculator *services.OptimalTimeCalculator
+}
+
+// NewRecommendHabitTimeHandler creates a new RecommendHabitTimeHandler.
+func NewRecommendHabitTimeHandler(habitRepo domain.Repository, uow sharedApplication.UnitOfWork) *RecommendHabitTimeHandler {
+	return &RecommendHabitTimeHandler{
+		habitRepo:  habitRepo,
+		uow:        uow,
+		calculator: services.NewOptimalTimeCalculator(habitRepo),
+	}
+}
+
+// Handle executes the RecommendHabitTimeCommand. Without enough recent
+// completions, or without a clear favourite window, the current preferred
+// time is recommended and nothing is applied.
+func (h *RecommendHabitTimeHandler) Handle(ctx context.Context, cmd RecommendHabitTimeCommand) (*RecommendHabitTimeResult, error) {
+	if cmd.WindowDays <= 0 {
+		cmd.WindowDays = DefaultRecommendationWindowDays
+	}
+	if cmd.MinCompletions <= 0 {
+		cmd.MinCompletions = DefaultRecommendationMinCompletions
+	}
+
+	var result *RecommendHabitTimeResult
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		habit, err := h.habitRepo.FindByID(txCtx, cmd.HabitID)
+		if err != nil {
+			return err
+		}
+		if habit == nil {
+			return ErrHabitNotFound
+		}
+		if habit.UserID() != cmd.UserID {
+			return ErrNotOwner
+		}
+
+		since := time.Now().AddDate(0, 0, -cmd.WindowDays)
+		recent := make([]*domain.HabitCompletion, 0, len(habit.Completions()))
+		for _, completion := range habit.Completions() {
+			if completion.CompletedAt().Before(since) {
+				continue
+			}
+			recent = append(recent, completion)
+		}
+
+		stats := h.calculator.AnalyzeCompletions(habit.ID(), recent)
+		result = &RecommendHabitTimeResult{
+			HabitID:     habit.ID(),
+			Current:     habit.PreferredTime(),
+			Recommended: habit.PreferredTime(),
+			PeakHour:    stats.MostFrequentHour,
+			Confidence:  stats.OptimalConfidence,
+			Completions: stats.TotalCompletions,
+		}
+		if stats.TotalCompletions >= cmd.MinCompletions && stats.OptimalTime != domain.PreferredAnytime {
+			result.Recommended = stats.OptimalTime
+		}
+		if window, ok := services.WindowFor(result.Recommended); ok {
+			result.WindowStart = window.Start
+			result.WindowEnd = window.End
+		}
+
+		if !cmd.Apply || result.Recommended == result.Current {
+			return nil
+		}
+		if habit.IsArchived() {
+			return domain.ErrHabitArchived
+		}
+
+		habit.SetPreferredTime(result.Recommended)
+		if err := h.habitRepo.Save(txCtx, habit); err != nil {
+			return err
+		}
+		result.Applied = true
+		return nil
+	})
+	if err != nil {
+		return nil, classifyHabitError(err)
+	}
+
+	return result, nil
+}
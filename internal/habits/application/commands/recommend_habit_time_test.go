@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// habitCompletedAt builds a habit with one completion per entry in hours at
+// that hour and minute, one every four days starting yesterday.
+func habitCompletedAt(userID uuid.UUID, preferred domain.PreferredTime, hours ...[2]int) *domain.Habit {
+	habitID := uuid.New()
+	now := time.Now()
+	completions := make([]*domain.HabitCompletion, 0, len(hours))
+	for i, hm := range hours {
+		day := now.AddDate(0, 0, -(4*i + 1))
+		completedAt := time.Date(day.Year(), day.Month(), day.Day(), hm[0], hm[1], 0, 0, time.Local)
+		completions = append(completions, domain.RehydrateHabitCompletion(uuid.New(), habitID, completedAt, ""))
+	}
+	return domain.RehydrateHabit(
+		habitID, userID, "Run", "", domain.FrequencyDaily, 7, 30*time.Minute,
+		preferred, 0, 0, len(completions), false, now.AddDate(0, -6, 0), now, completions,
+	)
+}
+
+func TestRecommendHabitTimeHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("recommends the window most completions fall in", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewRecommendHabitTimeHandler(repo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := habitCompletedAt(userID, domain.PreferredMorning,
+			[2]int{18, 5}, [2]int{18, 40}, [2]int{19, 15}, [2]int{18, 20},
+			[2]int{20, 0}, [2]int{18, 55}, [2]int{8, 30},
+		)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+
+		result, err := handler.Handle(ctx, RecommendHabitTimeCommand{HabitID: habit.ID(), UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.PreferredMorning, result.Current)
+		assert.Equal(t, domain.PreferredEvening, result.Recommended)
+		assert.Equal(t, 17*time.Hour, result.WindowStart)
+		assert.Equal(t, 21*time.Hour, result.WindowEnd)
+		assert.Equal(t, 18, result.PeakHour)
+		assert.InDelta(t, 6.0/7.0, result.Confidence, 0.001)
+		assert.Equal(t, 7, result.Completions)
+		assert.False(t, result.Applied)
+		assert.Equal(t, domain.PreferredMorning, habit.PreferredTime())
+
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("applies the recommendation to the habit", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewRecommendHabitTimeHandler(repo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := habitCompletedAt(userID, domain.PreferredAnytime,
+			[2]int{6, 30}, [2]int{7, 0}, [2]int{6, 45}, [2]int{7, 10}, [2]int{6, 50},
+		)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+		repo.On("Save", txCtx, habit).Return(nil)
+
+		result, err := handler.Handle(ctx, RecommendHabitTimeCommand{HabitID: habit.ID(), UserID: userID, Apply: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.PreferredMorning, result.Recommended)
+		assert.Equal(t, 6, result.PeakHour)
+		assert.True(t, result.Applied)
+		assert.Equal(t, domain.PreferredMorning, habit.PreferredTime())
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("keeps the current time when completions are spread out", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewRecommendHabitTimeHandler(repo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := habitCompletedAt(userID, domain.PreferredAfternoon,
+			[2]int{8, 0}, [2]int{13, 0}, [2]int{18, 0}, [2]int{22, 0}, [2]int{9, 0}, [2]int{14, 0},
+		)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+
+		result, err := handler.Handle(ctx, RecommendHabitTimeCommand{HabitID: habit.ID(), UserID: userID, Apply: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.PreferredAfternoon, result.Recommended)
+		assert.Equal(t, 12*time.Hour, result.WindowStart)
+		assert.False(t, result.Applied)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("needs enough recent completions", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewRecommendHabitTimeHandler(repo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := habitCompletedAt(userID, domain.PreferredMorning,
+			[2]int{19, 0}, [2]int{19, 30}, [2]int{20, 0},
+		)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+
+		result, err := handler.Handle(ctx, RecommendHabitTimeCommand{HabitID: habit.ID(), UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.PreferredMorning, result.Recommended)
+		assert.Equal(t, 3, result.Completions)
+	})
+
+	t.Run("ignores completions outside the window", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewRecommendHabitTimeHandler(repo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := habitCompletedAt(userID, domain.PreferredMorning,
+			[2]int{19, 0}, [2]int{19, 0}, [2]int{19, 0}, [2]int{19, 0}, [2]int{19, 0},
+		)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+
+		result, err := handler.Handle(ctx, RecommendHabitTimeCommand{HabitID: habit.ID(), UserID: userID, WindowDays: 7})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Completions)
+		assert.Equal(t, domain.PreferredMorning, result.Recommended)
+	})
+
+	t.Run("rejects habits owned by another user", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewRecommendHabitTimeHandler(repo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := habitCompletedAt(uuid.New(), domain.PreferredMorning)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habit.ID()).Return(habit, nil)
+
+		_, err := handler.Handle(ctx, RecommendHabitTimeCommand{HabitID: habit.ID(), UserID: userID})
+
+		assert.ErrorIs(t, err, ErrNotOwner)
+		assert.True(t, errors.Is(err, sharedApplication.ErrNotFound))
+	})
+}
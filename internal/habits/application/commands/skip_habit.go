@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// SkipHabitCommand contains the data needed to skip a habit for a day.
+type SkipHabitCommand struct {
+	HabitID uuid.UUID
+	UserID  uuid.UUID
+	Date    time.Time // zero skips the habit's current day
+}
+
+// SkipHabitHandler handles the SkipHabitCommand.
+type SkipHabitHandler struct {
+	habitRepo    domain.Repository
+	outboxRepo   outbox.Repository
+	uow          sharedApplication.UnitOfWork
+	dayRollovers DayRollovers
+}
+
+// NewSkipHabitHandler creates a new SkipHabitHandler.
+func NewSkipHabitHandler(habitRepo domain.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *SkipHabitHandler {
+	return &SkipHabitHandler{
+		habitRepo:  habitRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// WithDayRollovers reads the habit's current day with the user's day
+// rollover hour applied.
+func (h *SkipHabitHandler) WithDayRollovers(rollovers DayRollovers) *SkipHabitHandler {
+	h.dayRollovers = rollovers
+	return h
+}
+
+// Handle executes the SkipHabitCommand. Skipping a day that is already
+// skipped is a no-op; skipping a completed day is a conflict.
+func (h *SkipHabitHandler) Handle(ctx context.Context, cmd SkipHabitCommand) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		// Find the habit
+		habit, err := h.habitRepo.FindByID(txCtx, cmd.HabitID)
+		if err != nil {
+			return err
+		}
+		if habit == nil {
+			return ErrHabitNotFound
+		}
+
+		// Verify ownership
+		if habit.UserID() != cmd.UserID {
+			return ErrNotOwner
+		}
+		if err := applyDayRollover(txCtx, h.dayRollovers, cmd.UserID, habit); err != nil {
+			return err
+		}
+
+		date := cmd.Date
+		if date.IsZero() {
+			date = habit.Today(time.Now())
+		}
+		if err := habit.Skip(date); err != nil {
+			return err
+		}
+
+		// Save the habit
+		if err := h.habitRepo.Save(txCtx, habit); err != nil {
+			return err
+		}
+
+		// Save domain events to outbox
+		events := habit.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		return h.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+	return classifyHabitError(err)
+}
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipHabitHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+	habitID := uuid.New()
+
+	t.Run("skips the given day", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewSkipHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(userID, "Morning Run")
+		day := time.Now().AddDate(0, 0, -1)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habitID).Return(habit, nil)
+		repo.On("Save", txCtx, habit).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		err := handler.Handle(ctx, SkipHabitCommand{HabitID: habitID, UserID: userID, Date: day})
+
+		require.NoError(t, err)
+		assert.True(t, habit.IsSkippedOn(day))
+		assert.False(t, habit.IsSkippedOn(time.Now()))
+		repo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("defaults to today", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewSkipHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(userID, "Morning Run")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habitID).Return(habit, nil)
+		repo.On("Save", txCtx, habit).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		err := handler.Handle(ctx, SkipHabitCommand{HabitID: habitID, UserID: userID})
+
+		require.NoError(t, err)
+		assert.True(t, habit.IsSkippedOn(habit.Today(time.Now())))
+	})
+
+	t.Run("rejects a day that is already completed", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewSkipHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(userID, "Morning Run")
+		_, err := habit.LogCompletion(time.Now(), "")
+		require.NoError(t, err)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habitID).Return(habit, nil)
+
+		err = handler.Handle(ctx, SkipHabitCommand{HabitID: habitID, UserID: userID, Date: time.Now()})
+
+		assert.ErrorIs(t, err, domain.ErrHabitAlreadyDone)
+		assert.ErrorIs(t, err, sharedApplication.ErrConflict)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns ErrNotOwner when user does not own habit", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewSkipHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(uuid.New(), "Someone else's habit")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habitID).Return(habit, nil)
+
+		err := handler.Handle(ctx, SkipHabitCommand{HabitID: habitID, UserID: userID})
+
+		assert.ErrorIs(t, err, ErrNotOwner)
+	})
+
+	t.Run("returns ErrHabitNotFound when habit does not exist", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewSkipHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habitID).Return(nil, nil)
+
+		err := handler.Handle(ctx, SkipHabitCommand{HabitID: habitID, UserID: userID})
+
+		assert.ErrorIs(t, err, ErrHabitNotFound)
+	})
+}
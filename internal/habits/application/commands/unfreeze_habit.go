@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// UnfreezeHabitCommand contains the data needed to lift a habit's pause.
+type UnfreezeHabitCommand struct {
+	HabitID uuid.UUID
+	UserID  uuid.UUID
+}
+
+// UnfreezeHabitHandler handles the UnfreezeHabitCommand.
+type UnfreezeHabitHandler struct {
+	habitRepo  domain.Repository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+}
+
+// NewUnfreezeHabitHandler creates a new UnfreezeHabitHandler.
+func NewUnfreezeHabitHandler(habitRepo domain.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *UnfreezeHabitHandler {
+	return &UnfreezeHabitHandler{
+		habitRepo:  habitRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// Handle executes the UnfreezeHabitCommand. Unfreezing a habit that is not
+// frozen is a no-op.
+func (h *UnfreezeHabitHandler) Handle(ctx context.Context, cmd UnfreezeHabitCommand) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		// Find the habit
+		habit, err := h.habitRepo.FindByID(txCtx, cmd.HabitID)
+		if err != nil {
+			return err
+		}
+		if habit == nil {
+			return ErrHabitNotFound
+		}
+
+		// Verify ownership
+		if habit.UserID() != cmd.UserID {
+			return ErrNotOwner
+		}
+
+		habit.Unfreeze()
+
+		// Save the habit
+		if err := h.habitRepo.Save(txCtx, habit); err != nil {
+			return err
+		}
+
+		// Save domain events to outbox
+		events := habit.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		return h.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+	return classifyHabitError(err)
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnfreezeHabitHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+	habitID := uuid.New()
+
+	t.Run("lifts an active freeze", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewUnfreezeHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(userID, "Morning Run")
+		require.NoError(t, habit.Freeze(time.Now().AddDate(0, 0, 7)))
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habitID).Return(habit, nil)
+		repo.On("Save", txCtx, habit).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		err := handler.Handle(ctx, UnfreezeHabitCommand{HabitID: habitID, UserID: userID})
+
+		require.NoError(t, err)
+		assert.Nil(t, habit.FrozenUntil())
+		assert.False(t, habit.IsFrozenOn(time.Now()))
+		repo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("returns ErrNotOwner when user does not own habit", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		outboxRepo := new(mockHabitOutboxRepo)
+		uow := new(mockHabitUnitOfWork)
+		handler := NewUnfreezeHabitHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		habit := createTestHabit(uuid.New(), "Someone else's habit")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, habitID).Return(habit, nil)
+
+		err := handler.Handle(ctx, UnfreezeHabitCommand{HabitID: habitID, UserID: userID})
+
+		assert.ErrorIs(t, err, ErrNotOwner)
+	})
+}
@@ -0,0 +1,73 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/google/uuid"
+)
+
+// GetDueHabitsQuery contains the parameters for listing actionable habits.
+type GetDueHabitsQuery struct {
+	UserID uuid.UUID
+	Date   time.Time // Day to evaluate; defaults to today
+}
+
+// GetDueHabitsHandler handles the GetDueHabitsQuery.
+type GetDueHabitsHandler struct {
+	habitRepo    domain.Repository
+	dayRollovers DayRollovers
+	clock        sharedDomain.Clock
+}
+
+// NewGetDueHabitsHandler creates a new GetDueHabitsHandler.
+func NewGetDueHabitsHandler(habitRepo domain.Repository) *GetDueHabitsHandler {
+	return &GetDueHabitsHandler{habitRepo: habitRepo, clock: sharedDomain.SystemClock}
+}
+
+// WithClock sets the clock that decides what today is when the query has no
+// date.
+func (h *GetDueHabitsHandler) WithClock(clock sharedDomain.Clock) *GetDueHabitsHandler {
+	h.clock = clock
+	return h
+}
+
+// WithDayRollovers decides what today is with the user's day rollover hour
+// when the query has no date.
+func (h *GetDueHabitsHandler) WithDayRollovers(rollovers DayRollovers) *GetDueHabitsHandler {
+	h.dayRollovers = rollovers
+	return h
+}
+
+// Handle executes the GetDueHabitsQuery.
+// Only habits that still need doing on the day are returned: habits that are
+// not due, already completed, skipped, frozen or past their weekly target are
+// left out.
+func (h *GetDueHabitsHandler) Handle(ctx context.Context, query GetDueHabitsQuery) ([]HabitDTO, error) {
+	habits, err := h.habitRepo.FindActiveByUserID(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyDayRollover(ctx, h.dayRollovers, query.UserID, habits); err != nil {
+		return nil, err
+	}
+
+	now := h.clock()
+	due := make([]*domain.Habit, 0, len(habits))
+	for _, habit := range habits {
+		date := query.Date
+		if date.IsZero() {
+			date = habit.Today(now)
+		}
+		if habit.IsActionableOn(date) {
+			due = append(due, habit)
+		}
+	}
+
+	if query.Date.IsZero() {
+		return toHabitDTOs(due, now), nil
+	}
+	return toHabitDTOsOn(due, query.Date), nil
+}
@@ -0,0 +1,183 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newDueTestHabit(userID uuid.UUID, name string, freq domain.Frequency, timesPerWeek int, createdAt time.Time) *domain.Habit {
+	return domain.RehydrateHabit(
+		uuid.New(), userID, name, "",
+		freq, timesPerWeek, 30*time.Minute, domain.PreferredAnytime,
+		0, 0, 0, false,
+		createdAt, createdAt,
+		nil,
+	)
+}
+
+type fixedDayRollover int
+
+func (f fixedDayRollover) HabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error) {
+	return int(f), nil
+}
+
+func dueHabitNames(dtos []HabitDTO) []string {
+	names := make([]string, len(dtos))
+	for i, dto := range dtos {
+		names[i] = dto.Name
+	}
+	return names
+}
+
+func TestGetDueHabitsHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+	wednesday := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	lastWednesday := wednesday.AddDate(0, 0, -7)
+	lastMonday := wednesday.AddDate(0, 0, -9)
+
+	t.Run("respects frequency", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		handler := NewGetDueHabitsHandler(repo)
+
+		habits := []*domain.Habit{
+			newDueTestHabit(userID, "Daily", domain.FrequencyDaily, 7, lastWednesday),
+			newDueTestHabit(userID, "Weekdays", domain.FrequencyWeekdays, 5, lastWednesday),
+			newDueTestHabit(userID, "Weekends", domain.FrequencyWeekends, 2, lastWednesday),
+			newDueTestHabit(userID, "Weekly on Wednesday", domain.FrequencyWeekly, 1, lastWednesday),
+			newDueTestHabit(userID, "Weekly on Monday", domain.FrequencyWeekly, 1, lastMonday),
+		}
+		repo.On("FindActiveByUserID", mock.Anything, userID).Return(habits, nil)
+
+		result, err := handler.Handle(context.Background(), GetDueHabitsQuery{UserID: userID, Date: wednesday})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Daily", "Weekdays", "Weekly on Wednesday"}, dueHabitNames(result))
+		for _, dto := range result {
+			assert.True(t, dto.IsDueToday)
+			assert.False(t, dto.CompletedToday)
+		}
+
+		result, err = handler.Handle(context.Background(), GetDueHabitsQuery{UserID: userID, Date: saturday})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Daily", "Weekends"}, dueHabitNames(result))
+	})
+
+	t.Run("excludes habits completed on the day", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		handler := NewGetDueHabitsHandler(repo)
+
+		done := newDueTestHabit(userID, "Done", domain.FrequencyDaily, 7, lastWednesday)
+		_, err := done.LogCompletion(wednesday, "")
+		require.NoError(t, err)
+		pending := newDueTestHabit(userID, "Pending", domain.FrequencyDaily, 7, lastWednesday)
+
+		repo.On("FindActiveByUserID", mock.Anything, userID).Return([]*domain.Habit{done, pending}, nil)
+
+		result, err := handler.Handle(context.Background(), GetDueHabitsQuery{UserID: userID, Date: wednesday})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Pending"}, dueHabitNames(result))
+	})
+
+	t.Run("excludes skipped and frozen habits", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		handler := NewGetDueHabitsHandler(repo)
+
+		skipped := newDueTestHabit(userID, "Skipped", domain.FrequencyDaily, 7, lastWednesday)
+		require.NoError(t, skipped.Skip(wednesday))
+		skippedYesterday := newDueTestHabit(userID, "Skipped yesterday", domain.FrequencyDaily, 7, lastWednesday)
+		require.NoError(t, skippedYesterday.Skip(wednesday.AddDate(0, 0, -1)))
+		frozen := newDueTestHabit(userID, "Frozen", domain.FrequencyDaily, 7, lastWednesday)
+		require.NoError(t, frozen.Freeze(wednesday.AddDate(0, 0, 2)))
+		thawed := newDueTestHabit(userID, "Thawed", domain.FrequencyDaily, 7, lastWednesday)
+		require.NoError(t, thawed.Freeze(wednesday.AddDate(0, 0, -1)))
+
+		repo.On("FindActiveByUserID", mock.Anything, userID).
+			Return([]*domain.Habit{skipped, skippedYesterday, frozen, thawed}, nil)
+
+		result, err := handler.Handle(context.Background(), GetDueHabitsQuery{UserID: userID, Date: wednesday})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Skipped yesterday", "Thawed"}, dueHabitNames(result))
+	})
+
+	t.Run("custom habits drop out once weekly target is met", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		handler := NewGetDueHabitsHandler(repo)
+
+		met := newDueTestHabit(userID, "Target met", domain.FrequencyCustom, 2, lastWednesday)
+		_, err := met.LogCompletion(wednesday.AddDate(0, 0, -2), "") // Monday
+		require.NoError(t, err)
+		_, err = met.LogCompletion(wednesday.AddDate(0, 0, -1), "") // Tuesday
+		require.NoError(t, err)
+
+		open := newDueTestHabit(userID, "Target open", domain.FrequencyCustom, 2, lastWednesday)
+		_, err = open.LogCompletion(wednesday.AddDate(0, 0, -3), "") // previous Sunday
+		require.NoError(t, err)
+		_, err = open.LogCompletion(wednesday.AddDate(0, 0, -1), "")
+		require.NoError(t, err)
+
+		repo.On("FindActiveByUserID", mock.Anything, userID).Return([]*domain.Habit{met, open}, nil)
+
+		result, err := handler.Handle(context.Background(), GetDueHabitsQuery{UserID: userID, Date: wednesday})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Target open"}, dueHabitNames(result))
+	})
+
+	t.Run("defaults to today by the handler clock", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		handler := NewGetDueHabitsHandler(repo).WithClock(sharedDomain.FixedClock(saturday))
+
+		habits := []*domain.Habit{
+			newDueTestHabit(userID, "Weekdays", domain.FrequencyWeekdays, 5, lastWednesday),
+			newDueTestHabit(userID, "Weekends", domain.FrequencyWeekends, 2, lastWednesday),
+		}
+		repo.On("FindActiveByUserID", mock.Anything, userID).Return(habits, nil)
+
+		result, err := handler.Handle(context.Background(), GetDueHabitsQuery{UserID: userID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Weekends"}, dueHabitNames(result))
+	})
+
+	t.Run("applies the user's day rollover", func(t *testing.T) {
+		// 1am on Saturday is still Friday with a 4am rollover.
+		now := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+		repo := new(mockHabitRepo)
+		handler := NewGetDueHabitsHandler(repo).
+			WithClock(sharedDomain.FixedClock(now)).
+			WithDayRollovers(fixedDayRollover(4))
+
+		done := newDueTestHabit(userID, "Journal", domain.FrequencyDaily, 7, lastWednesday)
+		_, err := done.LogCompletion(time.Date(2026, 10, 17, 0, 30, 0, 0, time.UTC), "")
+		require.NoError(t, err)
+		habits := []*domain.Habit{
+			done,
+			newDueTestHabit(userID, "Weekdays", domain.FrequencyWeekdays, 5, lastWednesday),
+			newDueTestHabit(userID, "Weekends", domain.FrequencyWeekends, 2, lastWednesday),
+		}
+		repo.On("FindActiveByUserID", mock.Anything, userID).Return(habits, nil)
+
+		result, err := handler.Handle(context.Background(), GetDueHabitsQuery{UserID: userID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Weekdays"}, dueHabitNames(result))
+		assert.True(t, done.IsCompletedOn(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		handler := NewGetDueHabitsHandler(repo)
+
+		repo.On("FindActiveByUserID", mock.Anything, userID).Return(nil, errors.New("db error"))
+
+		result, err := handler.Handle(context.Background(), GetDueHabitsQuery{UserID: userID, Date: wednesday})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
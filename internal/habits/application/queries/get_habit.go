@@ -3,9 +3,9 @@ package queries
 import (
 	"context"
 	"errors"
-	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
 
@@ -20,12 +20,26 @@ type GetHabitQuery struct {
 
 // GetHabitHandler handles the GetHabitQuery.
 type GetHabitHandler struct {
-	habitRepo domain.Repository
+	habitRepo    domain.Repository
+	dayRollovers DayRollovers
+	clock        sharedDomain.Clock
 }
 
 // NewGetHabitHandler creates a new GetHabitHandler.
 func NewGetHabitHandler(habitRepo domain.Repository) *GetHabitHandler {
-	return &GetHabitHandler{habitRepo: habitRepo}
+	return &GetHabitHandler{habitRepo: habitRepo, clock: sharedDomain.SystemClock}
+}
+
+// WithClock sets the clock that decides what today is.
+func (h *GetHabitHandler) WithClock(clock sharedDomain.Clock) *GetHabitHandler {
+	h.clock = clock
+	return h
+}
+
+// WithDayRollovers decides what today is with the user's day rollover hour.
+func (h *GetHabitHandler) WithDayRollovers(rollovers DayRollovers) *GetHabitHandler {
+	h.dayRollovers = rollovers
+	return h
 }
 
 // Handle executes the GetHabitQuery.
@@ -42,8 +56,11 @@ func (h *GetHabitHandler) Handle(ctx context.Context, query GetHabitQuery) (*Hab
 	if habit.UserID() != query.UserID {
 		return nil, ErrHabitNotFound
 	}
+	if err := applyDayRollover(ctx, h.dayRollovers, query.UserID, []*domain.Habit{habit}); err != nil {
+		return nil, err
+	}
 
-	today := time.Now()
+	now := h.clock()
 	dto := HabitDTO{
 		ID:             habit.ID(),
 		Name:           habit.Name(),
@@ -56,8 +73,9 @@ func (h *GetHabitHandler) Handle(ctx context.Context, query GetHabitQuery) (*Hab
 		BestStreak:     habit.BestStreak(),
 		TotalDone:      habit.TotalDone(),
 		IsArchived:     habit.IsArchived(),
-		IsDueToday:     habit.IsDueOn(today),
-		CompletedToday: habit.IsCompletedOn(today),
+		IsDueToday:     habit.IsDueToday(now),
+		CompletedToday: habit.IsCompletedToday(now),
+		Timezone:       habit.Timezone(),
 		CreatedAt:      habit.CreatedAt(),
 	}
 
@@ -2,9 +2,13 @@ package queries
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
 
@@ -23,6 +27,7 @@ type HabitDTO struct {
 	IsArchived    bool
 	IsDueToday    bool
 	CompletedToday bool
+	Timezone      string // IANA time zone override, empty for the user's
 	CreatedAt     time.Time
 }
 
@@ -35,26 +40,73 @@ type ListHabitsQuery struct {
 	PreferredTime   string // Filter by preferred time: "morning", "afternoon", "evening"
 	HasStreak       bool   // Only show habits with active streaks
 	BrokenStreak    bool   // Only show habits with broken streaks
-	SortBy          string // "streak", "name", "created_at", "best_streak"
-	SortOrder       string // "asc", "desc"
+	SortBy          string // "streak", "name", "created_at", "best_streak", or fields such as "streak:desc,name:asc"
+	SortOrder       string // "asc", "desc"; applies to every sort field
+}
+
+// habitSortFields lists the fields habits can be sorted by.
+var habitSortFields = []string{"streak", "best_streak", "name", "created_at"}
+
+// SortDefaults provides a user's default list sort order.
+type SortDefaults interface {
+	// ListSort returns the sort order of the entity's lists, such as
+	// "streak:desc" for "habits". An empty order keeps the stored order.
+	ListSort(ctx context.Context, userID uuid.UUID, entity string) (string, error)
+}
+
+// DayRollovers provides the hour at which a user's habit days end.
+type DayRollovers interface {
+	HabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error)
 }
 
 // ListHabitsHandler handles the ListHabitsQuery.
 type ListHabitsHandler struct {
-	habitRepo domain.Repository
+	habitRepo    domain.Repository
+	sortDefaults SortDefaults
+	dayRollovers DayRollovers
+	clock        sharedDomain.Clock
 }
 
 // NewListHabitsHandler creates a new ListHabitsHandler.
 func NewListHabitsHandler(habitRepo domain.Repository) *ListHabitsHandler {
-	return &ListHabitsHandler{habitRepo: habitRepo}
+	return &ListHabitsHandler{habitRepo: habitRepo, clock: sharedDomain.SystemClock}
+}
+
+// WithClock sets the clock that decides what today is for the due and
+// completed flags.
+func (h *ListHabitsHandler) WithClock(clock sharedDomain.Clock) *ListHabitsHandler {
+	h.clock = clock
+	return h
+}
+
+// WithSortDefaults sorts lists without a SortBy in the user's default order
+// instead of the order habits are stored in.
+func (h *ListHabitsHandler) WithSortDefaults(defaults SortDefaults) *ListHabitsHandler {
+	h.sortDefaults = defaults
+	return h
+}
+
+// WithDayRollovers decides what today is for the due and completed flags
+// with the user's day rollover hour.
+func (h *ListHabitsHandler) WithDayRollovers(rollovers DayRollovers) *ListHabitsHandler {
+	h.dayRollovers = rollovers
+	return h
 }
 
 // Handle executes the ListHabitsQuery.
 func (h *ListHabitsHandler) Handle(ctx context.Context, query ListHabitsQuery) ([]HabitDTO, error) {
+	sortKeys, err := h.sortKeys(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
 	var habits []*domain.Habit
-	var err error
 
-	if query.OnlyDueToday {
+	if query.OnlyDueToday && h.dayRollovers != nil {
+		// The repository does not know the rollover, so decide what is due
+		// here once it is applied.
+		habits, err = h.habitRepo.FindActiveByUserID(ctx, query.UserID)
+	} else if query.OnlyDueToday {
 		habits, err = h.habitRepo.FindDueToday(ctx, query.UserID)
 	} else if query.IncludeArchived {
 		habits, err = h.habitRepo.FindByUserID(ctx, query.UserID)
@@ -65,6 +117,14 @@ func (h *ListHabitsHandler) Handle(ctx context.Context, query ListHabitsQuery) (
 	if err != nil {
 		return nil, err
 	}
+	if err := applyDayRollover(ctx, h.dayRollovers, query.UserID, habits); err != nil {
+		return nil, err
+	}
+
+	now := h.clock()
+	if query.OnlyDueToday && h.dayRollovers != nil {
+		habits = filterDueToday(habits, now)
+	}
 
 	// Apply filters
 	if query.Frequency != "" {
@@ -81,9 +141,19 @@ func (h *ListHabitsHandler) Handle(ctx context.Context, query ListHabitsQuery) (
 	}
 
 	// Sort habits
-	habits = sortHabits(habits, query.SortBy, query.SortOrder)
+	habits = sortHabits(habits, sortKeys)
 
-	return toHabitDTOs(habits), nil
+	return toHabitDTOs(habits, now), nil
+}
+
+func filterDueToday(habits []*domain.Habit, now time.Time) []*domain.Habit {
+	var filtered []*domain.Habit
+	for _, h := range habits {
+		if h.IsDueToday(now) {
+			filtered = append(filtered, h)
+		}
+	}
+	return filtered
 }
 
 func filterByFrequency(habits []*domain.Habit, frequency string) []*domain.Habit {
@@ -127,65 +197,87 @@ func filterBrokenStreak(habits []*domain.Habit) []*domain.Habit {
 	return filtered
 }
 
-func sortHabits(habits []*domain.Habit, sortBy, sortOrder string) []*domain.Habit {
-	if sortBy == "" {
-		return habits // No sorting requested
+// sortKeys resolves the order of the list: the query's SortBy, or else the
+// user's default. A SortOrder sets the direction of every field.
+func (h *ListHabitsHandler) sortKeys(ctx context.Context, query ListHabitsQuery) ([]sharedApplication.SortKey, error) {
+	keys := h.defaultSortKeys(ctx, query.UserID)
+	if query.SortBy != "" {
+		var err error
+		keys, err = sharedApplication.ParseSort(query.SortBy, habitSortFields)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sharedApplication.ApplySortOrder(keys, query.SortOrder)
+}
+
+// defaultSortKeys returns the user's default order. Without one, or when the
+// lookup fails, habits keep the order they are stored in.
+func (h *ListHabitsHandler) defaultSortKeys(ctx context.Context, userID uuid.UUID) []sharedApplication.SortKey {
+	if h.sortDefaults == nil {
+		return nil
+	}
+	spec, err := h.sortDefaults.ListSort(ctx, userID, "habits")
+	if err != nil {
+		return nil
+	}
+	keys, err := sharedApplication.ParseSort(spec, habitSortFields)
+	if err != nil {
+		return nil
 	}
-	if sortOrder == "" {
-		sortOrder = "desc"
+	return keys
+}
+
+func sortHabits(habits []*domain.Habit, keys []sharedApplication.SortKey) []*domain.Habit {
+	if len(keys) == 0 {
+		return habits // No sorting requested
 	}
 
 	sorted := make([]*domain.Habit, len(habits))
 	copy(sorted, habits)
 
-	switch sortBy {
-	case "streak":
-		for i := 0; i < len(sorted)-1; i++ {
-			for j := i + 1; j < len(sorted); j++ {
-				shouldSwap := (sortOrder == "desc" && sorted[i].Streak() < sorted[j].Streak()) ||
-					(sortOrder == "asc" && sorted[i].Streak() > sorted[j].Streak())
-				if shouldSwap {
-					sorted[i], sorted[j] = sorted[j], sorted[i]
-				}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		for _, key := range keys {
+			if c := compareHabits(sorted[i], sorted[j], key); c != 0 {
+				return c < 0
 			}
 		}
+		return false
+	})
+	return sorted
+}
+
+// compareHabits compares two habits by one sort key.
+func compareHabits(a, b *domain.Habit, key sharedApplication.SortKey) int {
+	var c int
+	switch key.Field {
+	case "streak":
+		c = a.Streak() - b.Streak()
 	case "best_streak":
-		for i := 0; i < len(sorted)-1; i++ {
-			for j := i + 1; j < len(sorted); j++ {
-				shouldSwap := (sortOrder == "desc" && sorted[i].BestStreak() < sorted[j].BestStreak()) ||
-					(sortOrder == "asc" && sorted[i].BestStreak() > sorted[j].BestStreak())
-				if shouldSwap {
-					sorted[i], sorted[j] = sorted[j], sorted[i]
-				}
-			}
-		}
+		c = a.BestStreak() - b.BestStreak()
 	case "name":
-		for i := 0; i < len(sorted)-1; i++ {
-			for j := i + 1; j < len(sorted); j++ {
-				shouldSwap := (sortOrder == "asc" && sorted[i].Name() > sorted[j].Name()) ||
-					(sortOrder == "desc" && sorted[i].Name() < sorted[j].Name())
-				if shouldSwap {
-					sorted[i], sorted[j] = sorted[j], sorted[i]
-				}
-			}
-		}
+		c = strings.Compare(a.Name(), b.Name())
 	case "created_at":
-		for i := 0; i < len(sorted)-1; i++ {
-			for j := i + 1; j < len(sorted); j++ {
-				shouldSwap := (sortOrder == "asc" && sorted[i].CreatedAt().After(sorted[j].CreatedAt())) ||
-					(sortOrder == "desc" && sorted[i].CreatedAt().Before(sorted[j].CreatedAt()))
-				if shouldSwap {
-					sorted[i], sorted[j] = sorted[j], sorted[i]
-				}
-			}
-		}
+		c = a.CreatedAt().Compare(b.CreatedAt())
+	}
+	if key.Order == sharedApplication.SortDesc {
+		return -c
 	}
+	return c
+}
 
-	return sorted
+func toHabitDTOs(habits []*domain.Habit, now time.Time) []HabitDTO {
+	dtos := toHabitDTOsOn(habits, now)
+	// Today is the current day in each habit's own time zone.
+	for i, h := range habits {
+		dtos[i].IsDueToday = h.IsDueToday(now)
+		dtos[i].CompletedToday = h.IsCompletedToday(now)
+	}
+	return dtos
 }
 
-func toHabitDTOs(habits []*domain.Habit) []HabitDTO {
-	today := time.Now()
+// toHabitDTOsOn converts habits to DTOs with due/completed flags evaluated on the given day.
+func toHabitDTOsOn(habits []*domain.Habit, today time.Time) []HabitDTO {
 	dtos := make([]HabitDTO, len(habits))
 
 	for i, h := range habits {
@@ -203,9 +295,27 @@ func toHabitDTOs(habits []*domain.Habit) []HabitDTO {
 			IsArchived:     h.IsArchived(),
 			IsDueToday:     h.IsDueOn(today),
 			CompletedToday: h.IsCompletedOn(today),
+			Timezone:       h.Timezone(),
 			CreatedAt:      h.CreatedAt(),
 		}
 	}
 
 	return dtos
 }
+
+// applyDayRollover ends the habits' days at the user's day rollover hour.
+func applyDayRollover(ctx context.Context, rollovers DayRollovers, userID uuid.UUID, habits []*domain.Habit) error {
+	if rollovers == nil {
+		return nil
+	}
+	hour, err := rollovers.HabitDayRollover(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, habit := range habits {
+		if err := habit.SetDayRollover(hour); err != nil {
+			return err
+		}
+	}
+	return nil
+}
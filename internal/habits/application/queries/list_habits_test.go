@@ -425,3 +425,59 @@ func TestNewListHabitsHandler(t *testing.T) {
 
 	require.NotNil(t, handler)
 }
+
+type stubSortDefaults struct {
+	orders map[string]string
+}
+
+func (s stubSortDefaults) ListSort(ctx context.Context, userID uuid.UUID, entity string) (string, error) {
+	return s.orders[entity], nil
+}
+
+func TestListHabitsHandler_ConfiguredDefaultSort(t *testing.T) {
+	userID := uuid.New()
+	habits := []*domain.Habit{
+		createTestHabit(userID, "Yoga"),
+		createTestHabit(userID, "Journal"),
+		createTestHabit(userID, "Meditation"),
+	}
+	names := func(result []HabitDTO) []string {
+		var names []string
+		for _, dto := range result {
+			names = append(names, dto.Name)
+		}
+		return names
+	}
+
+	t.Run("without a default habits keep the stored order", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		repo.On("FindActiveByUserID", mock.Anything, userID).Return(habits, nil)
+		handler := NewListHabitsHandler(repo).WithSortDefaults(stubSortDefaults{})
+
+		result, err := handler.Handle(context.Background(), ListHabitsQuery{UserID: userID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Yoga", "Journal", "Meditation"}, names(result))
+	})
+
+	t.Run("configured default is applied", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		repo.On("FindActiveByUserID", mock.Anything, userID).Return(habits, nil)
+		handler := NewListHabitsHandler(repo).
+			WithSortDefaults(stubSortDefaults{orders: map[string]string{"habits": "name:asc"}})
+
+		result, err := handler.Handle(context.Background(), ListHabitsQuery{UserID: userID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Journal", "Meditation", "Yoga"}, names(result))
+	})
+
+	t.Run("SortBy overrides the configured default", func(t *testing.T) {
+		repo := new(mockHabitRepo)
+		repo.On("FindActiveByUserID", mock.Anything, userID).Return(habits, nil)
+		handler := NewListHabitsHandler(repo).
+			WithSortDefaults(stubSortDefaults{orders: map[string]string{"habits": "name:asc"}})
+
+		result, err := handler.Handle(context.Background(), ListHabitsQuery{UserID: userID, SortBy: "name", SortOrder: "desc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Yoga", "Meditation", "Journal"}, names(result))
+	})
+}
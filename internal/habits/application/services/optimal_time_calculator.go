@@ -71,10 +71,20 @@ func (c *OptimalTimeCalculator) CalculateOptimalTime(
 		}, nil
 	}
 
+	return c.AnalyzeCompletions(habitID, completions), nil
+}
+
+// AnalyzeCompletions computes completion time statistics from a habit's
+// completion history. Without completions the optimal time is anytime.
+func (c *OptimalTimeCalculator) AnalyzeCompletions(habitID uuid.UUID, completions []*domain.HabitCompletion) *CompletionTimeStats {
 	stats := &CompletionTimeStats{
 		HabitID:          habitID,
 		TotalCompletions: len(completions),
 	}
+	if len(completions) == 0 {
+		stats.OptimalTime = domain.PreferredAnytime
+		return stats
+	}
 
 	var totalHour float64
 	hourCounts := make(map[int]int)
@@ -108,10 +118,10 @@ func (c *OptimalTimeCalculator) CalculateOptimalTime(
 	// Calculate average hour
 	stats.AverageHour = totalHour / float64(len(completions))
 
-	// Find most frequent hour
+	// Find most frequent hour, preferring the earliest on ties
 	maxCount := 0
-	for hour, count := range hourCounts {
-		if count > maxCount {
+	for hour := 0; hour < 24; hour++ {
+		if count := hourCounts[hour]; count > maxCount {
 			maxCount = count
 			stats.MostFrequentHour = hour
 		}
@@ -120,7 +130,24 @@ func (c *OptimalTimeCalculator) CalculateOptimalTime(
 	// Determine optimal time window based on highest count
 	stats.OptimalTime, stats.OptimalConfidence = c.determineOptimalWindow(stats)
 
-	return stats, nil
+	return stats
+}
+
+// WindowFor returns the time of day window for a preferred time, and false
+// for anytime.
+func WindowFor(pt domain.PreferredTime) (TimeOfDayWindow, bool) {
+	switch pt {
+	case domain.PreferredMorning:
+		return MorningWindow, true
+	case domain.PreferredAfternoon:
+		return AfternoonWindow, true
+	case domain.PreferredEvening:
+		return EveningWindow, true
+	case domain.PreferredNight:
+		return NightWindow, true
+	default:
+		return TimeOfDayWindow{}, false
+	}
 }
 
 // determineOptimalWindow finds the best time window based on completion statistics.
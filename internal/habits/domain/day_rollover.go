@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxDayRolloverHour is the latest hour a habit day can roll over at.
+const MaxDayRolloverHour = 12
+
+var ErrHabitInvalidDayRollover = errors.New("day rollover must be an hour from 0 to 12")
+
+// DayRollover returns the hour after midnight at which the habit's day ends.
+func (h *Habit) DayRollover() int {
+	return int(h.dayRollover / time.Hour)
+}
+
+// SetDayRollover ends the habit's days at the given hour instead of
+// midnight, so that a completion logged at 1am with a 4am rollover still
+// counts for the day before. The rollover is a user setting, so it is
+// applied after the habit is loaded and not stored with it.
+func (h *Habit) SetDayRollover(hour int) error {
+	if hour < 0 || hour > MaxDayRolloverHour {
+		return ErrHabitInvalidDayRollover
+	}
+	h.dayRollover = time.Duration(hour) * time.Hour
+	return nil
+}
+
+// Today returns the day now counts toward: now in the habit's time zone,
+// moved back by the rollover so that the hours before it still belong to
+// the previous day.
+func (h *Habit) Today(now time.Time) time.Time {
+	return h.habitDay(h.LocalTime(now))
+}
+
+// habitDay moves t back by the rollover so that its calendar day is the
+// habit day it belongs to.
+func (h *Habit) habitDay(t time.Time) time.Time {
+	return t.Add(-h.dayRollover)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHabit_SetDayRollover(t *testing.T) {
+	habit, err := NewHabit(uuid.New(), "Read", FrequencyDaily, 20*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 0, habit.DayRollover())
+
+	require.NoError(t, habit.SetDayRollover(4))
+	assert.Equal(t, 4, habit.DayRollover())
+
+	assert.ErrorIs(t, habit.SetDayRollover(-1), ErrHabitInvalidDayRollover)
+	assert.ErrorIs(t, habit.SetDayRollover(MaxDayRolloverHour+1), ErrHabitInvalidDayRollover)
+	assert.Equal(t, 4, habit.DayRollover())
+}
+
+func TestHabit_DayRollover_CompletionCountsForPriorDay(t *testing.T) {
+	habit, err := NewHabit(uuid.New(), "Journal", FrequencyDaily, 10*time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, habit.SetDayRollover(4))
+
+	monday := time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+	// 1am on Tuesday is still Monday's habit day.
+	_, err = habit.LogCompletion(time.Date(2025, 6, 10, 1, 0, 0, 0, time.UTC), "")
+	require.NoError(t, err)
+
+	assert.True(t, habit.IsCompletedOn(monday))
+	assert.False(t, habit.IsCompletedOn(monday.AddDate(0, 0, 1)))
+	assert.True(t, habit.IsCompletedToday(time.Date(2025, 6, 10, 3, 59, 0, 0, time.UTC)))
+	assert.False(t, habit.IsCompletedToday(time.Date(2025, 6, 10, 4, 0, 0, 0, time.UTC)))
+
+	// A second completion before the rollover is the same day.
+	_, err = habit.LogCompletion(time.Date(2025, 6, 10, 2, 0, 0, 0, time.UTC), "")
+	assert.ErrorIs(t, err, ErrHabitAlreadyLogged)
+
+	// After the rollover Tuesday starts and continues the streak.
+	_, err = habit.LogCompletion(time.Date(2025, 6, 10, 21, 0, 0, 0, time.UTC), "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, habit.Streak())
+}
+
+func TestHabit_DayRollover_IsDueToday(t *testing.T) {
+	habit, err := NewHabit(uuid.New(), "Plan the week", FrequencyWeekdays, 15*time.Minute)
+	require.NoError(t, err)
+
+	// 1am on Saturday.
+	now := time.Date(2025, 6, 7, 1, 0, 0, 0, time.UTC)
+	assert.False(t, habit.IsDueToday(now))
+
+	require.NoError(t, habit.SetDayRollover(4))
+	assert.True(t, habit.IsDueToday(now))
+	assert.Equal(t, time.Friday, habit.Today(now).Weekday())
+}
+
+func TestHabit_DayRollover_Backfill(t *testing.T) {
+	habit, err := NewHabit(uuid.New(), "Meditate", FrequencyDaily, 10*time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, habit.SetDayRollover(4))
+
+	day := time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, habit.BackfillCompletions([]time.Time{day}))
+
+	assert.True(t, habit.IsCompletedOn(day))
+	assert.False(t, habit.IsCompletedOn(day.AddDate(0, 0, -1)))
+}
@@ -128,3 +128,21 @@ func NewHabitFrequencyChanged(h *Habit) *HabitFrequencyChanged {
 		TimesPerWeek: h.TimesPerWeek(),
 	}
 }
+
+// HabitTagsChanged is emitted when tags are added to or removed from a habit.
+type HabitTagsChanged struct {
+	sharedDomain.BaseEvent
+	HabitID uuid.UUID `json:"habit_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Tags    []string  `json:"tags"`
+}
+
+// NewHabitTagsChanged creates a HabitTagsChanged event.
+func NewHabitTagsChanged(h *Habit) *HabitTagsChanged {
+	return &HabitTagsChanged{
+		BaseEvent: sharedDomain.NewBaseEvent(h.ID(), aggregateType, "habits.habit.tags_changed"),
+		HabitID:   h.ID(),
+		UserID:    h.UserID(),
+		Tags:      h.Tags(),
+	}
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ var (
 	ErrHabitArchived        = errors.New("habit is archived")
 	ErrHabitAlreadyLogged   = errors.New("habit already logged for this date")
 	ErrHabitInvalidDuration = errors.New("duration must be positive")
+	ErrHabitAlreadyDone     = errors.New("habit already completed for this date")
 )
 
 // Frequency represents how often a habit should be performed.
@@ -64,6 +66,12 @@ type Habit struct {
 	totalDone     int // Total completions
 	archived      bool
 	completions   []*HabitCompletion
+	skips         []time.Time // Days explicitly skipped
+	frozenUntil   *time.Time  // Habit is paused through this day
+	tags          []string
+	timezone      string         // Overrides the user's time zone for "today"
+	location      *time.Location // Resolved timezone, nil without an override
+	dayRollover   time.Duration  // How long after midnight the habit's day ends
 }
 
 // NewHabit creates a new habit.
@@ -114,6 +122,8 @@ func (h *Habit) BestStreak() int                 { return h.bestStreak }
 func (h *Habit) TotalDone() int                  { return h.totalDone }
 func (h *Habit) IsArchived() bool                { return h.archived }
 func (h *Habit) Completions() []*HabitCompletion { return h.completions }
+func (h *Habit) Skips() []time.Time              { return h.skips }
+func (h *Habit) FrozenUntil() *time.Time         { return h.frozenUntil }
 
 // SetName updates the habit name.
 func (h *Habit) SetName(name string) error {
@@ -188,10 +198,9 @@ func (h *Habit) LogCompletion(completedAt time.Time, notes string) (*HabitComple
 	}
 
 	// Check if already completed on this day
-	for _, c := range h.completions {
-		if sameDay(c.completedAt, completedAt) {
-			return nil, ErrHabitAlreadyLogged
-		}
+	day := h.habitDay(completedAt)
+	if h.IsCompletedOn(day) {
+		return nil, ErrHabitAlreadyLogged
 	}
 
 	completion := &HabitCompletion{
@@ -203,7 +212,7 @@ func (h *Habit) LogCompletion(completedAt time.Time, notes string) (*HabitComple
 
 	h.completions = append(h.completions, completion)
 	h.totalDone++
-	h.updateStreak(completedAt)
+	h.updateStreak(day)
 	h.Touch()
 
 	h.AddDomainEvent(NewHabitCompleted(h, completion))
@@ -211,6 +220,125 @@ func (h *Habit) LogCompletion(completedAt time.Time, notes string) (*HabitComple
 	return completion, nil
 }
 
+// BackfillCompletions records completions from before the habit was tracked
+// in Orbita, such as history imported from another app, and rebuilds the
+// streak from them. Days that already have a completion are ignored. Unlike
+// LogCompletion, no events are raised.
+func (h *Habit) BackfillCompletions(dates []time.Time) error {
+	if h.archived {
+		return ErrHabitArchived
+	}
+
+	sorted := append([]time.Time(nil), dates...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	for _, date := range sorted {
+		if h.IsCompletedOn(date) {
+			continue
+		}
+		// Stamp the completion at the start of the habit day so the
+		// rollover does not move it to the day before.
+		h.completions = append(h.completions, &HabitCompletion{
+			id:          uuid.New(),
+			habitID:     h.ID(),
+			completedAt: date.Add(h.dayRollover),
+		})
+		h.totalDone++
+		h.updateStreak(date)
+	}
+	h.Touch()
+	return nil
+}
+
+// Skip excuses the habit for a single day without breaking the streak.
+// Skipping a day twice is a no-op.
+func (h *Habit) Skip(date time.Time) error {
+	if h.archived {
+		return ErrHabitArchived
+	}
+	if h.IsCompletedOn(date) {
+		return ErrHabitAlreadyDone
+	}
+	if h.IsSkippedOn(date) {
+		return nil
+	}
+	h.skips = append(h.skips, date)
+	h.Touch()
+	return nil
+}
+
+// Freeze pauses the habit through the given day (inclusive).
+func (h *Habit) Freeze(until time.Time) error {
+	if h.archived {
+		return ErrHabitArchived
+	}
+	h.frozenUntil = &until
+	h.Touch()
+	return nil
+}
+
+// Unfreeze lifts an active freeze.
+func (h *Habit) Unfreeze() {
+	if h.frozenUntil != nil {
+		h.frozenUntil = nil
+		h.Touch()
+	}
+}
+
+// IsSkippedOn checks if the habit was skipped on a given date.
+func (h *Habit) IsSkippedOn(date time.Time) bool {
+	for _, s := range h.skips {
+		if sameDay(s, date) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFrozenOn checks if the habit is paused on a given date.
+func (h *Habit) IsFrozenOn(date time.Time) bool {
+	if h.frozenUntil == nil {
+		return false
+	}
+	return sameDay(*h.frozenUntil, date) || date.Before(*h.frozenUntil)
+}
+
+// IsActionableOn checks if the habit still needs to be done on a given date:
+// it is due, not completed, not skipped and not frozen. Custom habits stop
+// being actionable once their weekly target has been met.
+func (h *Habit) IsActionableOn(date time.Time) bool {
+	if !h.IsDueOn(date) || h.IsCompletedOn(date) || h.IsSkippedOn(date) || h.IsFrozenOn(date) {
+		return false
+	}
+	if h.frequency == FrequencyCustom && h.timesPerWeek > 0 {
+		return h.completionsInWeekOf(date) < h.timesPerWeek
+	}
+	return true
+}
+
+// completionsInWeekOf counts completions in the Monday-based week containing date.
+func (h *Habit) completionsInWeekOf(date time.Time) int {
+	offset := (int(date.Weekday()) + 6) % 7
+	y, m, d := date.AddDate(0, 0, -offset).Date()
+	weekStart := time.Date(y, m, d, 0, 0, 0, 0, date.Location())
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	count := 0
+	for _, c := range h.completions {
+		day := h.habitDay(c.completedAt)
+		if !day.Before(weekStart) && day.Before(weekEnd) {
+			count++
+		}
+	}
+	return count
+}
+
+// RehydratePauses restores persisted skips and freeze state.
+func (h *Habit) RehydratePauses(skips []time.Time, frozenUntil *time.Time) {
+	h.skips = skips
+	h.frozenUntil = frozenUntil
+}
+
 // Archive marks the habit as archived.
 func (h *Habit) Archive() {
 	if !h.archived {
@@ -255,9 +383,10 @@ func (h *Habit) IsDueOn(date time.Time) bool {
 }
 
 // IsCompletedOn checks if the habit was completed on a given date.
+// Completions before the day rollover count for the day before.
 func (h *Habit) IsCompletedOn(date time.Time) bool {
 	for _, c := range h.completions {
-		if sameDay(c.completedAt, date) {
+		if sameDay(h.habitDay(c.completedAt), date) {
 			return true
 		}
 	}
@@ -277,7 +406,7 @@ func (h *Habit) CompletionRate(days int) float64 {
 		return 0
 	}
 
-	now := time.Now()
+	now := h.habitDay(time.Now())
 	startDate := now.AddDate(0, 0, -days+1)
 
 	dueCount := 0
@@ -313,8 +442,8 @@ func (h *Habit) updateStreak(latestDate time.Time) {
 		streak++
 		checkDate = checkDate.AddDate(0, 0, -1)
 
-		// For non-daily habits, skip days that weren't due
-		for !h.IsDueOn(checkDate) && streak < 365 {
+		// For non-daily habits, skip days that weren't due or were skipped
+		for (!h.IsDueOn(checkDate) || h.IsSkippedOn(checkDate)) && streak < 365 {
 			checkDate = checkDate.AddDate(0, 0, -1)
 		}
 
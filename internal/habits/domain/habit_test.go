@@ -156,6 +156,33 @@ func TestHabit_Streak(t *testing.T) {
 	assert.Equal(t, 4, habit.BestStreak())
 }
 
+func TestHabit_BackfillCompletions(t *testing.T) {
+	habit, _ := NewHabit(uuid.New(), "Exercise", FrequencyDaily, 30*time.Minute)
+	habit.ClearDomainEvents()
+	today := time.Now()
+
+	// A five-day run, a gap, then the current three-day run, out of order
+	var dates []time.Time
+	for i := 0; i < 3; i++ {
+		dates = append(dates, today.AddDate(0, 0, -i))
+	}
+	for i := 10; i < 15; i++ {
+		dates = append(dates, today.AddDate(0, 0, -i))
+	}
+	dates = append(dates, today) // duplicate day
+
+	require.NoError(t, habit.BackfillCompletions(dates))
+
+	assert.Equal(t, 3, habit.Streak())
+	assert.Equal(t, 5, habit.BestStreak())
+	assert.Equal(t, 8, habit.TotalDone())
+	assert.Len(t, habit.Completions(), 8)
+	assert.Empty(t, habit.DomainEvents())
+
+	habit.Archive()
+	assert.ErrorIs(t, habit.BackfillCompletions(dates), ErrHabitArchived)
+}
+
 func TestHabit_Archive(t *testing.T) {
 	userID := uuid.New()
 	habit, _ := NewHabit(userID, "Test", FrequencyDaily, 15*time.Minute)
@@ -568,3 +595,61 @@ func findDateWithWeekday(target time.Weekday) time.Time {
 	}
 	return now
 }
+
+func TestHabit_Skip(t *testing.T) {
+	habit, _ := NewHabit(uuid.New(), "Exercise", FrequencyDaily, 30*time.Minute)
+	today := time.Now()
+
+	require.NoError(t, habit.Skip(today))
+	require.NoError(t, habit.Skip(today)) // idempotent
+
+	assert.Len(t, habit.Skips(), 1)
+	assert.True(t, habit.IsSkippedOn(today))
+	assert.False(t, habit.IsActionableOn(today))
+	assert.True(t, habit.IsActionableOn(today.AddDate(0, 0, 1)))
+}
+
+func TestHabit_Skip_AlreadyCompleted(t *testing.T) {
+	habit, _ := NewHabit(uuid.New(), "Exercise", FrequencyDaily, 30*time.Minute)
+	today := time.Now()
+	_, err := habit.LogCompletion(today, "")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, habit.Skip(today), ErrHabitAlreadyDone)
+}
+
+func TestHabit_Skip_KeepsStreak(t *testing.T) {
+	habit, _ := NewHabit(uuid.New(), "Exercise", FrequencyDaily, 30*time.Minute)
+	today := time.Now()
+
+	_, err := habit.LogCompletion(today.AddDate(0, 0, -2), "")
+	require.NoError(t, err)
+	require.NoError(t, habit.Skip(today.AddDate(0, 0, -1)))
+	_, err = habit.LogCompletion(today, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, habit.Streak())
+}
+
+func TestHabit_Freeze(t *testing.T) {
+	habit, _ := NewHabit(uuid.New(), "Exercise", FrequencyDaily, 30*time.Minute)
+	today := time.Now()
+
+	require.NoError(t, habit.Freeze(today.AddDate(0, 0, 1)))
+	assert.True(t, habit.IsFrozenOn(today))
+	assert.True(t, habit.IsFrozenOn(today.AddDate(0, 0, 1)))
+	assert.False(t, habit.IsFrozenOn(today.AddDate(0, 0, 2)))
+	assert.False(t, habit.IsActionableOn(today))
+
+	habit.Unfreeze()
+	assert.Nil(t, habit.FrozenUntil())
+	assert.True(t, habit.IsActionableOn(today))
+}
+
+func TestHabit_SkipAndFreeze_Archived(t *testing.T) {
+	habit, _ := NewHabit(uuid.New(), "Exercise", FrequencyDaily, 30*time.Minute)
+	habit.Archive()
+
+	assert.ErrorIs(t, habit.Skip(time.Now()), ErrHabitArchived)
+	assert.ErrorIs(t, habit.Freeze(time.Now()), ErrHabitArchived)
+}
@@ -26,3 +26,11 @@ type Repository interface {
 	// Delete removes a habit.
 	Delete(ctx context.Context, id uuid.UUID) error
 }
+
+// SearchRepository finds a user's habits by full-text search.
+type SearchRepository interface {
+	// Search returns up to limit of the user's habits whose name or
+	// description contains every word of query, most relevant first. Words
+	// match as prefixes, ignoring case.
+	Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*Habit, error)
+}
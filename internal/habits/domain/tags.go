@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"sort"
+
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+)
+
+// Tags returns the habit's tags in alphabetical order.
+func (h *Habit) Tags() []string {
+	tags := make([]string, len(h.tags))
+	copy(tags, h.tags)
+	return tags
+}
+
+// HasTag reports whether the habit carries the tag.
+func (h *Habit) HasTag(tag string) bool {
+	tag, err := sharedDomain.NormalizeTag(tag)
+	if err != nil {
+		return false
+	}
+	_, found := h.tagIndex(tag)
+	return found
+}
+
+// AddTag tags the habit. It reports false when the habit already had the tag.
+func (h *Habit) AddTag(tag string) (bool, error) {
+	if h.archived {
+		return false, ErrHabitArchived
+	}
+	tag, err := sharedDomain.NormalizeTag(tag)
+	if err != nil {
+		return false, err
+	}
+
+	i, found := h.tagIndex(tag)
+	if found {
+		return false, nil
+	}
+	h.tags = append(h.tags, "")
+	copy(h.tags[i+1:], h.tags[i:])
+	h.tags[i] = tag
+	h.Touch()
+	return true, nil
+}
+
+// RemoveTag removes a tag from the habit. It reports false when the habit did
+// not have the tag.
+func (h *Habit) RemoveTag(tag string) (bool, error) {
+	if h.archived {
+		return false, ErrHabitArchived
+	}
+	tag, err := sharedDomain.NormalizeTag(tag)
+	if err != nil {
+		return false, err
+	}
+
+	i, found := h.tagIndex(tag)
+	if !found {
+		return false, nil
+	}
+	h.tags = append(h.tags[:i], h.tags[i+1:]...)
+	h.Touch()
+	return true, nil
+}
+
+// RehydrateTags restores the habit's tags from persistence.
+func (h *Habit) RehydrateTags(tags []string) {
+	h.tags = make([]string, len(tags))
+	copy(h.tags, tags)
+	sort.Strings(h.tags)
+}
+
+func (h *Habit) tagIndex(tag string) (int, bool) {
+	i := sort.SearchStrings(h.tags, tag)
+	return i, i < len(h.tags) && h.tags[i] == tag
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHabit_Tags(t *testing.T) {
+	habit, err := NewHabit(uuid.New(), "Stretch", FrequencyDaily, 10*time.Minute)
+	require.NoError(t, err)
+
+	added, err := habit.AddTag("Health")
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = habit.AddTag("health")
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, []string{"health"}, habit.Tags())
+
+	removed, err := habit.RemoveTag("health")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	assert.Empty(t, habit.Tags())
+
+	_, err = habit.AddTag("")
+	assert.ErrorIs(t, err, sharedDomain.ErrInvalidTag)
+
+	habit.Archive()
+	_, err = habit.AddTag("health")
+	assert.ErrorIs(t, err, ErrHabitArchived)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+)
+
+// Timezone returns the IANA time zone the habit's days are counted in, or an
+// empty string when the habit follows the user's current time zone.
+func (h *Habit) Timezone() string {
+	return h.timezone
+}
+
+// SetTimezone pins the habit to a time zone, so that "due today" means today
+// where the habit is done rather than where the user currently is. An empty
+// name clears the override.
+func (h *Habit) SetTimezone(name string) error {
+	loc, err := sharedDomain.LoadTimezone(name)
+	if err != nil {
+		return err
+	}
+	h.location = loc
+	h.timezone = ""
+	if loc != nil {
+		h.timezone = loc.String()
+	}
+	h.Touch()
+	return nil
+}
+
+// RehydrateTimezone restores the time zone from persistence. A name the
+// local time zone database does not know is kept but has no effect.
+func (h *Habit) RehydrateTimezone(name string) {
+	h.timezone = name
+	h.location, _ = sharedDomain.LoadTimezone(name)
+}
+
+// LocalTime returns t in the habit's time zone, or unchanged when the habit
+// follows the user's time zone.
+func (h *Habit) LocalTime(t time.Time) time.Time {
+	if h.location == nil {
+		return t
+	}
+	return t.In(h.location)
+}
+
+// IsDueToday reports whether the habit is due on the day of now, read in the
+// habit's time zone and with the day rollover applied.
+func (h *Habit) IsDueToday(now time.Time) bool {
+	return h.IsDueOn(h.Today(now))
+}
+
+// IsCompletedToday reports whether the habit was completed on the day of
+// now, with completions and now read in the habit's time zone and with the
+// day rollover applied.
+func (h *Habit) IsCompletedToday(now time.Time) bool {
+	today := h.Today(now)
+	for _, c := range h.completions {
+		if sameDay(h.Today(c.completedAt), today) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHabit_SetTimezone(t *testing.T) {
+	habit, err := NewHabit(uuid.New(), "Stretch", FrequencyDaily, 10*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "", habit.Timezone())
+
+	require.NoError(t, habit.SetTimezone("Asia/Tokyo"))
+	assert.Equal(t, "Asia/Tokyo", habit.Timezone())
+
+	err = habit.SetTimezone("Nowhere/Special")
+	assert.ErrorIs(t, err, sharedDomain.ErrInvalidTimezone)
+	assert.Equal(t, "Asia/Tokyo", habit.Timezone())
+
+	require.NoError(t, habit.SetTimezone(""))
+	assert.Equal(t, "", habit.Timezone())
+}
+
+func TestHabit_IsDueToday_HabitTimezone(t *testing.T) {
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	// Friday evening in Los Angeles is already Saturday in Tokyo.
+	now := time.Date(2025, 6, 6, 20, 0, 0, 0, losAngeles)
+
+	habit, err := NewHabit(uuid.New(), "Team standup notes", FrequencyWeekdays, 15*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, habit.IsDueToday(now))
+
+	require.NoError(t, habit.SetTimezone("Asia/Tokyo"))
+	assert.False(t, habit.IsDueToday(now))
+}
+
+func TestHabit_IsCompletedToday_HabitTimezone(t *testing.T) {
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	now := time.Date(2025, 6, 6, 20, 0, 0, 0, losAngeles)
+
+	habit, err := NewHabit(uuid.New(), "Journal", FrequencyDaily, 10*time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, habit.SetTimezone("Asia/Tokyo"))
+
+	// Saturday morning in Tokyo, Friday afternoon in Los Angeles.
+	_, err = habit.LogCompletion(time.Date(2025, 6, 7, 0, 30, 0, 0, time.UTC), "")
+	require.NoError(t, err)
+	assert.True(t, habit.IsCompletedToday(now))
+	assert.False(t, habit.IsCompletedToday(now.AddDate(0, 0, -1)))
+}
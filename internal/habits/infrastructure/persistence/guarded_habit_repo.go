@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+// GuardedHabitRepository wraps a habit repository and rejects any call that
+// targets a user other than the authenticated principal in the context, or
+// that runs without a principal outside a system context.
+// Queries keyed by user are checked before they reach the database; lookups
+// by ID are checked against the owner of the loaded habit.
+type GuardedHabitRepository struct {
+	inner domain.Repository
+}
+
+// NewGuardedHabitRepository wraps inner with a principal check.
+func NewGuardedHabitRepository(inner domain.Repository) *GuardedHabitRepository {
+	return &GuardedHabitRepository{inner: inner}
+}
+
+// Save persists a habit owned by the principal.
+func (r *GuardedHabitRepository) Save(ctx context.Context, h *domain.Habit) error {
+	if err := sharedApplication.CheckPrincipal(ctx, h.UserID()); err != nil {
+		return err
+	}
+	return r.inner.Save(ctx, h)
+}
+
+// FindByID loads a habit and rejects it if it belongs to another user.
+func (r *GuardedHabitRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Habit, error) {
+	h, err := r.inner.FindByID(ctx, id)
+	if err != nil || h == nil {
+		return h, err
+	}
+	if err := sharedApplication.CheckPrincipal(ctx, h.UserID()); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// FindByUserID finds all habits for the principal.
+func (r *GuardedHabitRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Habit, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindByUserID(ctx, userID)
+}
+
+// FindActiveByUserID finds the principal's non-archived habits.
+func (r *GuardedHabitRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Habit, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindActiveByUserID(ctx, userID)
+}
+
+// FindDueToday finds the principal's habits due today.
+func (r *GuardedHabitRepository) FindDueToday(ctx context.Context, userID uuid.UUID) ([]*domain.Habit, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindDueToday(ctx, userID)
+}
+
+// Search finds the principal's habits matching query. It returns nothing
+// when the wrapped repository cannot search.
+func (r *GuardedHabitRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*domain.Habit, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	searcher, ok := r.inner.(domain.SearchRepository)
+	if !ok {
+		return nil, nil
+	}
+	return searcher.Search(ctx, userID, query, limit)
+}
+
+// Delete removes a habit after checking that it belongs to the principal.
+// A system context deletes the habit unchecked.
+func (r *GuardedHabitRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := sharedApplication.RequirePrincipal(ctx); err != nil {
+		return err
+	}
+	if _, ok := sharedApplication.PrincipalFromContext(ctx); ok {
+		if _, err := r.FindByID(ctx, id); err != nil {
+			return err
+		}
+	}
+	return r.inner.Delete(ctx, id)
+}
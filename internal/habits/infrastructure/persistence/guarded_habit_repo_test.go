@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardedHabitRepository(t *testing.T) {
+	sqlDB := setupHabitTestDB(t)
+	defer sqlDB.Close()
+
+	owner := uuid.New()
+	other := uuid.New()
+	createHabitTestUser(t, sqlDB, owner)
+	createHabitTestUser(t, sqlDB, other)
+
+	repo := NewGuardedHabitRepository(NewSQLiteHabitRepository(sqlDB))
+	ownerCtx := sharedApplication.WithPrincipal(context.Background(), owner)
+	otherCtx := sharedApplication.WithPrincipal(context.Background(), other)
+
+	habit, err := domain.NewHabit(owner, "Read", domain.FrequencyDaily, 20*time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ownerCtx, habit))
+
+	t.Run("owner can read their habits", func(t *testing.T) {
+		found, err := repo.FindByID(ownerCtx, habit.ID())
+		require.NoError(t, err)
+		assert.Equal(t, habit.ID(), found.ID())
+
+		habits, err := repo.FindByUserID(ownerCtx, owner)
+		require.NoError(t, err)
+		assert.Len(t, habits, 1)
+	})
+
+	t.Run("rejects another user's habits", func(t *testing.T) {
+		_, err := repo.FindByID(otherCtx, habit.ID())
+		assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+		_, err = repo.FindActiveByUserID(otherCtx, owner)
+		assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+		assert.ErrorIs(t, repo.Save(otherCtx, habit), sharedApplication.ErrPrincipalMismatch)
+		assert.ErrorIs(t, repo.Delete(otherCtx, habit.ID()), sharedApplication.ErrPrincipalMismatch)
+	})
+
+	t.Run("rejects a context without a principal", func(t *testing.T) {
+		_, err := repo.FindDueToday(context.Background(), owner)
+		assert.ErrorIs(t, err, sharedApplication.ErrNoPrincipal)
+
+		assert.ErrorIs(t, repo.Delete(context.Background(), habit.ID()), sharedApplication.ErrNoPrincipal)
+	})
+
+	t.Run("allows a system context", func(t *testing.T) {
+		habits, err := repo.FindByUserID(sharedApplication.WithSystem(context.Background()), owner)
+		require.NoError(t, err)
+		assert.Len(t, habits, 1)
+	})
+}
@@ -3,9 +3,11 @@ package persistence
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
 	sharedPersistence "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/persistence"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -18,7 +20,8 @@ var (
 
 // PostgresHabitRepository implements domain.Repository using PostgreSQL.
 type PostgresHabitRepository struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	fields *sharedCrypto.FieldEncrypter
 }
 
 // NewPostgresHabitRepository creates a new PostgreSQL habit repository.
@@ -26,6 +29,12 @@ func NewPostgresHabitRepository(pool *pgxpool.Pool) *PostgresHabitRepository {
 	return &PostgresHabitRepository{pool: pool}
 }
 
+// WithFieldEncrypter encrypts habit descriptions and completion notes at rest.
+func (r *PostgresHabitRepository) WithFieldEncrypter(fields *sharedCrypto.FieldEncrypter) *PostgresHabitRepository {
+	r.fields = fields
+	return r
+}
+
 // habitRow represents a database row for habits.
 type habitRow struct {
 	ID              uuid.UUID
@@ -40,6 +49,8 @@ type habitRow struct {
 	BestStreak      int
 	TotalDone       int
 	Archived        bool
+	FrozenUntil     *time.Time
+	Timezone        *string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
@@ -73,13 +84,24 @@ func (r *PostgresHabitRepository) Save(ctx context.Context, habit *domain.Habit)
 }
 
 func (r *PostgresHabitRepository) saveWithTx(ctx context.Context, tx pgx.Tx, habit *domain.Habit) error {
+	description, err := r.fields.Encrypt(habit.UserID(), habit.Description())
+	if err != nil {
+		return fmt.Errorf("failed to encrypt description: %w", err)
+	}
+
+	var timezone *string
+	if habit.Timezone() != "" {
+		name := habit.Timezone()
+		timezone = &name
+	}
+
 	// Upsert the habit
 	query := `
 		INSERT INTO habits (
 			id, user_id, name, description, frequency, times_per_week,
 			duration_minutes, preferred_time, streak, best_streak, total_done,
-			archived, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			archived, frozen_until, timezone, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		ON CONFLICT (id) DO UPDATE SET
 			name = EXCLUDED.name,
 			description = EXCLUDED.description,
@@ -91,14 +113,16 @@ func (r *PostgresHabitRepository) saveWithTx(ctx context.Context, tx pgx.Tx, hab
 			best_streak = EXCLUDED.best_streak,
 			total_done = EXCLUDED.total_done,
 			archived = EXCLUDED.archived,
+			frozen_until = EXCLUDED.frozen_until,
+			timezone = EXCLUDED.timezone,
 			updated_at = NOW()
 	`
 
-	_, err := tx.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		habit.ID(),
 		habit.UserID(),
 		habit.Name(),
-		habit.Description(),
+		description,
 		string(habit.Frequency()),
 		habit.TimesPerWeek(),
 		int(habit.Duration().Minutes()),
@@ -107,6 +131,8 @@ func (r *PostgresHabitRepository) saveWithTx(ctx context.Context, tx pgx.Tx, hab
 		habit.BestStreak(),
 		habit.TotalDone(),
 		habit.IsArchived(),
+		habit.FrozenUntil(),
+		timezone,
 		habit.CreatedAt(),
 		habit.UpdatedAt(),
 	)
@@ -116,6 +142,10 @@ func (r *PostgresHabitRepository) saveWithTx(ctx context.Context, tx pgx.Tx, hab
 
 	// Save completions
 	for _, c := range habit.Completions() {
+		notes, err := r.fields.Encrypt(habit.UserID(), c.Notes())
+		if err != nil {
+			return fmt.Errorf("failed to encrypt completion notes: %w", err)
+		}
 		completionQuery := `
 			INSERT INTO habit_completions (id, habit_id, completed_at, notes, created_at)
 			VALUES ($1, $2, $3, $4, $5)
@@ -125,7 +155,7 @@ func (r *PostgresHabitRepository) saveWithTx(ctx context.Context, tx pgx.Tx, hab
 			c.ID(),
 			c.HabitID(),
 			c.CompletedAt(),
-			c.Notes(),
+			notes,
 			c.CompletedAt(),
 		)
 		if err != nil {
@@ -133,7 +163,41 @@ func (r *PostgresHabitRepository) saveWithTx(ctx context.Context, tx pgx.Tx, hab
 		}
 	}
 
-	return nil
+	// Save skips
+	for _, skip := range habit.Skips() {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO habit_skips (habit_id, skip_date)
+			VALUES ($1, $2)
+			ON CONFLICT (habit_id, skip_date) DO NOTHING
+		`, habit.ID(), skip)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Replace tags
+	if _, err = tx.Exec(ctx, `DELETE FROM habit_tags WHERE habit_id = $1`, habit.ID()); err != nil {
+		return err
+	}
+	for _, tag := range habit.Tags() {
+		_, err = tx.Exec(ctx, `INSERT INTO habit_tags (habit_id, tag) VALUES ($1, $2)`, habit.ID(), tag)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The description is left out of the search index when it is stored
+	// encrypted.
+	doc := sharedPersistence.SearchDocument{
+		EntityType: sharedPersistence.SearchEntityHabit,
+		EntityID:   habit.ID(),
+		UserID:     habit.UserID(),
+		Title:      habit.Name(),
+	}
+	if !r.fields.Encrypts() {
+		doc.Body = habit.Description()
+	}
+	return sharedPersistence.IndexPostgresSearchDocument(ctx, tx, doc)
 }
 
 // FindByID retrieves a habit by its ID.
@@ -141,7 +205,7 @@ func (r *PostgresHabitRepository) FindByID(ctx context.Context, id uuid.UUID) (*
 	query := `
 		SELECT id, user_id, name, description, frequency, times_per_week,
 		       duration_minutes, preferred_time, streak, best_streak, total_done,
-		       archived, created_at, updated_at
+		       archived, frozen_until, timezone, created_at, updated_at
 		FROM habits
 		WHERE id = $1
 	`
@@ -160,6 +224,8 @@ func (r *PostgresHabitRepository) FindByID(ctx context.Context, id uuid.UUID) (*
 		&row.BestStreak,
 		&row.TotalDone,
 		&row.Archived,
+		&row.FrozenUntil,
+		&row.Timezone,
 		&row.CreatedAt,
 		&row.UpdatedAt,
 	)
@@ -171,13 +237,28 @@ func (r *PostgresHabitRepository) FindByID(ctx context.Context, id uuid.UUID) (*
 		return nil, err
 	}
 
+	row.Description, err = r.fields.Decrypt(row.UserID, row.Description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt description: %w", err)
+	}
+
 	// Load completions
-	completions, err := r.loadCompletions(ctx, row.ID)
+	completions, err := r.loadCompletions(ctx, row.UserID, row.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.rowToHabit(row, completions), nil
+	skips, err := r.loadSkips(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := r.loadTags(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.rowToHabit(row, completions, skips, tags), nil
 }
 
 // FindByUserID retrieves all habits for a user.
@@ -185,7 +266,7 @@ func (r *PostgresHabitRepository) FindByUserID(ctx context.Context, userID uuid.
 	query := `
 		SELECT id, user_id, name, description, frequency, times_per_week,
 		       duration_minutes, preferred_time, streak, best_streak, total_done,
-		       archived, created_at, updated_at
+		       archived, frozen_until, timezone, created_at, updated_at
 		FROM habits
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -205,7 +286,7 @@ func (r *PostgresHabitRepository) FindActiveByUserID(ctx context.Context, userID
 	query := `
 		SELECT id, user_id, name, description, frequency, times_per_week,
 		       duration_minutes, preferred_time, streak, best_streak, total_done,
-		       archived, created_at, updated_at
+		       archived, frozen_until, timezone, created_at, updated_at
 		FROM habits
 		WHERE user_id = $1 AND archived = FALSE
 		ORDER BY created_at DESC
@@ -222,16 +303,16 @@ func (r *PostgresHabitRepository) FindActiveByUserID(ctx context.Context, userID
 
 // FindDueToday retrieves habits that are due today for a user.
 func (r *PostgresHabitRepository) FindDueToday(ctx context.Context, userID uuid.UUID) ([]*domain.Habit, error) {
-	// First get all active habits, then filter in memory based on IsDueOn
+	// First get all active habits, then filter in memory based on IsDueToday
 	habits, err := r.FindActiveByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	today := time.Now()
+	now := time.Now()
 	dueHabits := make([]*domain.Habit, 0)
 	for _, h := range habits {
-		if h.IsDueOn(today) {
+		if h.IsDueToday(now) {
 			dueHabits = append(dueHabits, h)
 		}
 	}
@@ -239,8 +320,33 @@ func (r *PostgresHabitRepository) FindDueToday(ctx context.Context, userID uuid.
 	return dueHabits, nil
 }
 
+// Search returns up to limit of the user's habits matching query, most
+// relevant first.
+func (r *PostgresHabitRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*domain.Habit, error) {
+	ids, err := sharedPersistence.SearchPostgresIndex(ctx, sharedPersistence.Executor(ctx, r.pool), sharedPersistence.SearchEntityHabit, userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	habits := make([]*domain.Habit, 0, len(ids))
+	for _, id := range ids {
+		habit, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if habit != nil {
+			habits = append(habits, habit)
+		}
+	}
+	return habits, nil
+}
+
 // Delete removes a habit from the database.
 func (r *PostgresHabitRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := sharedPersistence.RemovePostgresSearchDocument(ctx, r.pool, sharedPersistence.SearchEntityHabit, id); err != nil {
+		return err
+	}
+
 	query := `DELETE FROM habits WHERE id = $1`
 	result, err := r.pool.Exec(ctx, query, id)
 	if err != nil {
@@ -252,7 +358,7 @@ func (r *PostgresHabitRepository) Delete(ctx context.Context, id uuid.UUID) erro
 	return nil
 }
 
-func (r *PostgresHabitRepository) loadCompletions(ctx context.Context, habitID uuid.UUID) ([]*domain.HabitCompletion, error) {
+func (r *PostgresHabitRepository) loadCompletions(ctx context.Context, userID, habitID uuid.UUID) ([]*domain.HabitCompletion, error) {
 	query := `
 		SELECT id, habit_id, completed_at, notes
 		FROM habit_completions
@@ -272,11 +378,15 @@ func (r *PostgresHabitRepository) loadCompletions(ctx context.Context, habitID u
 		if err := rows.Scan(&row.ID, &row.HabitID, &row.CompletedAt, &row.Notes); err != nil {
 			return nil, err
 		}
+		notes, err := r.fields.Decrypt(userID, row.Notes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt completion notes: %w", err)
+		}
 		completions = append(completions, domain.RehydrateHabitCompletion(
 			row.ID,
 			row.HabitID,
 			row.CompletedAt,
-			row.Notes,
+			notes,
 		))
 	}
 
@@ -287,6 +397,59 @@ func (r *PostgresHabitRepository) loadCompletions(ctx context.Context, habitID u
 	return completions, nil
 }
 
+func (r *PostgresHabitRepository) loadSkips(ctx context.Context, habitID uuid.UUID) ([]time.Time, error) {
+	query := `
+		SELECT skip_date
+		FROM habit_skips
+		WHERE habit_id = $1
+		ORDER BY skip_date
+	`
+
+	rows, err := r.pool.Query(ctx, query, habitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skips := make([]time.Time, 0)
+	for rows.Next() {
+		var skip time.Time
+		if err := rows.Scan(&skip); err != nil {
+			return nil, err
+		}
+		skips = append(skips, skip)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return skips, nil
+}
+
+func (r *PostgresHabitRepository) loadTags(ctx context.Context, habitID uuid.UUID) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT tag FROM habit_tags WHERE habit_id = $1 ORDER BY tag`, habitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
 func (r *PostgresHabitRepository) scanHabits(ctx context.Context, rows pgx.Rows) ([]*domain.Habit, error) {
 	habits := make([]*domain.Habit, 0)
 
@@ -305,6 +468,8 @@ func (r *PostgresHabitRepository) scanHabits(ctx context.Context, rows pgx.Rows)
 			&row.BestStreak,
 			&row.TotalDone,
 			&row.Archived,
+			&row.FrozenUntil,
+			&row.Timezone,
 			&row.CreatedAt,
 			&row.UpdatedAt,
 		)
@@ -312,13 +477,28 @@ func (r *PostgresHabitRepository) scanHabits(ctx context.Context, rows pgx.Rows)
 			return nil, err
 		}
 
+		row.Description, err = r.fields.Decrypt(row.UserID, row.Description)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt description: %w", err)
+		}
+
 		// Load completions for each habit
-		completions, err := r.loadCompletions(ctx, row.ID)
+		completions, err := r.loadCompletions(ctx, row.UserID, row.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		skips, err := r.loadSkips(ctx, row.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		tags, err := r.loadTags(ctx, row.ID)
 		if err != nil {
 			return nil, err
 		}
 
-		habits = append(habits, r.rowToHabit(row, completions))
+		habits = append(habits, r.rowToHabit(row, completions, skips, tags))
 	}
 
 	if err := rows.Err(); err != nil {
@@ -328,8 +508,8 @@ func (r *PostgresHabitRepository) scanHabits(ctx context.Context, rows pgx.Rows)
 	return habits, nil
 }
 
-func (r *PostgresHabitRepository) rowToHabit(row habitRow, completions []*domain.HabitCompletion) *domain.Habit {
-	return domain.RehydrateHabit(
+func (r *PostgresHabitRepository) rowToHabit(row habitRow, completions []*domain.HabitCompletion, skips []time.Time, tags []string) *domain.Habit {
+	habit := domain.RehydrateHabit(
 		row.ID,
 		row.UserID,
 		row.Name,
@@ -346,4 +526,10 @@ func (r *PostgresHabitRepository) rowToHabit(row habitRow, completions []*domain
 		row.UpdatedAt,
 		completions,
 	)
+	habit.RehydratePauses(skips, row.FrozenUntil)
+	habit.RehydrateTags(tags)
+	if row.Timezone != nil {
+		habit.RehydrateTimezone(*row.Timezone)
+	}
+	return habit
 }
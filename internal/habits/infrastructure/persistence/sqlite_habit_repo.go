@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	db "github.com/felixgeelhaar/orbita/db/generated/sqlite"
 	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
 	sharedPersistence "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/persistence"
 	"github.com/google/uuid"
 )
@@ -15,6 +18,7 @@ import (
 // SQLiteHabitRepository implements domain.Repository using SQLite.
 type SQLiteHabitRepository struct {
 	dbConn *sql.DB
+	fields *sharedCrypto.FieldEncrypter
 }
 
 // NewSQLiteHabitRepository creates a new SQLite habit repository.
@@ -22,6 +26,12 @@ func NewSQLiteHabitRepository(dbConn *sql.DB) *SQLiteHabitRepository {
 	return &SQLiteHabitRepository{dbConn: dbConn}
 }
 
+// WithFieldEncrypter encrypts habit descriptions and completion notes at rest.
+func (r *SQLiteHabitRepository) WithFieldEncrypter(fields *sharedCrypto.FieldEncrypter) *SQLiteHabitRepository {
+	r.fields = fields
+	return r
+}
+
 // getQuerier returns the appropriate querier (transaction or connection) based on context.
 func (r *SQLiteHabitRepository) getQuerier(ctx context.Context) *db.Queries {
 	if info, ok := sharedPersistence.SQLiteTxInfoFromContext(ctx); ok {
@@ -30,6 +40,18 @@ func (r *SQLiteHabitRepository) getQuerier(ctx context.Context) *db.Queries {
 	return db.New(r.dbConn)
 }
 
+// getDB returns the raw database handle (transaction or connection) based on context.
+func (r *SQLiteHabitRepository) getDB(ctx context.Context) interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+} {
+	if info, ok := sharedPersistence.SQLiteTxInfoFromContext(ctx); ok {
+		return info.Tx
+	}
+	return r.dbConn
+}
+
 // Save persists a habit to the database.
 func (r *SQLiteHabitRepository) Save(ctx context.Context, habit *domain.Habit) error {
 	queries := r.getQuerier(ctx)
@@ -51,11 +73,16 @@ func (r *SQLiteHabitRepository) create(ctx context.Context, habit *domain.Habit)
 	// Use existing transaction from context (via UnitOfWork) or direct connection
 	queries := r.getQuerier(ctx)
 
-	err := queries.CreateHabit(ctx, db.CreateHabitParams{
+	description, err := r.fields.Encrypt(habit.UserID(), habit.Description())
+	if err != nil {
+		return fmt.Errorf("failed to encrypt description: %w", err)
+	}
+
+	err = queries.CreateHabit(ctx, db.CreateHabitParams{
 		ID:              habit.ID().String(),
 		UserID:          habit.UserID().String(),
 		Name:            habit.Name(),
-		Description:     toNullString(habit.Description()),
+		Description:     toNullString(description),
 		Frequency:       string(habit.Frequency()),
 		TimesPerWeek:    int64(habit.TimesPerWeek()),
 		DurationMinutes: int64(habit.Duration().Minutes()),
@@ -73,11 +100,15 @@ func (r *SQLiteHabitRepository) create(ctx context.Context, habit *domain.Habit)
 
 	// Save completions
 	for _, c := range habit.Completions() {
+		notes, err := r.fields.Encrypt(habit.UserID(), c.Notes())
+		if err != nil {
+			return fmt.Errorf("failed to encrypt completion notes: %w", err)
+		}
 		err = queries.CreateHabitCompletion(ctx, db.CreateHabitCompletionParams{
 			ID:          c.ID().String(),
 			HabitID:     c.HabitID().String(),
 			CompletedAt: c.CompletedAt().Format(time.RFC3339),
-			Notes:       toNullString(c.Notes()),
+			Notes:       toNullString(notes),
 			CreatedAt:   c.CompletedAt().Format(time.RFC3339),
 		})
 		if err != nil {
@@ -85,17 +116,33 @@ func (r *SQLiteHabitRepository) create(ctx context.Context, habit *domain.Habit)
 		}
 	}
 
-	return nil
+	if err := r.savePauses(ctx, habit); err != nil {
+		return err
+	}
+	if err := r.saveTags(ctx, habit); err != nil {
+		return err
+	}
+	if err := r.saveTimezone(ctx, habit); err != nil {
+		return err
+	}
+	return sharedPersistence.IndexSQLiteSearchDocument(ctx, r.getDB(ctx), r.searchDocument(habit))
 }
 
 func (r *SQLiteHabitRepository) update(ctx context.Context, habit *domain.Habit) error {
 	// Use existing transaction from context (via UnitOfWork) or direct connection
 	queries := r.getQuerier(ctx)
 
-	err := queries.UpdateHabit(ctx, db.UpdateHabitParams{
+	description, err := r.fields.Encrypt(habit.UserID(), habit.Description())
+	if err != nil {
+		return fmt.Errorf("failed to encrypt description: %w", err)
+	}
+
+	// The update only applies if nobody saved the habit since it was loaded.
+	affected, err := queries.UpdateHabit(ctx, db.UpdateHabitParams{
 		ID:              habit.ID().String(),
+		Version:         int64(habit.Version()),
 		Name:            habit.Name(),
-		Description:     toNullString(habit.Description()),
+		Description:     toNullString(description),
 		Frequency:       string(habit.Frequency()),
 		TimesPerWeek:    int64(habit.TimesPerWeek()),
 		DurationMinutes: int64(habit.Duration().Minutes()),
@@ -109,22 +156,114 @@ func (r *SQLiteHabitRepository) update(ctx context.Context, habit *domain.Habit)
 	if err != nil {
 		return err
 	}
+	if affected == 0 {
+		return fmt.Errorf("%w: habit %s was modified by another process", sharedDomain.ErrConcurrentModification, habit.ID())
+	}
+	habit.SetVersion(habit.Version() + 1)
 
 	// Upsert completions - insert only new ones (ON CONFLICT DO NOTHING equivalent)
 	for _, c := range habit.Completions() {
+		notes, err := r.fields.Encrypt(habit.UserID(), c.Notes())
+		if err != nil {
+			return fmt.Errorf("failed to encrypt completion notes: %w", err)
+		}
 		// Try to insert, ignore if already exists
 		_ = queries.CreateHabitCompletion(ctx, db.CreateHabitCompletionParams{
 			ID:          c.ID().String(),
 			HabitID:     c.HabitID().String(),
 			CompletedAt: c.CompletedAt().Format(time.RFC3339),
-			Notes:       toNullString(c.Notes()),
+			Notes:       toNullString(notes),
 			CreatedAt:   c.CompletedAt().Format(time.RFC3339),
 		})
 	}
 
+	if err := r.savePauses(ctx, habit); err != nil {
+		return err
+	}
+	if err := r.saveTags(ctx, habit); err != nil {
+		return err
+	}
+	if err := r.saveTimezone(ctx, habit); err != nil {
+		return err
+	}
+	return sharedPersistence.IndexSQLiteSearchDocument(ctx, r.getDB(ctx), r.searchDocument(habit))
+}
+
+// searchDocument returns the habit's search index entry. The description is
+// left out when it is stored encrypted.
+func (r *SQLiteHabitRepository) searchDocument(habit *domain.Habit) sharedPersistence.SearchDocument {
+	doc := sharedPersistence.SearchDocument{
+		EntityType: sharedPersistence.SearchEntityHabit,
+		EntityID:   habit.ID(),
+		UserID:     habit.UserID(),
+		Title:      habit.Name(),
+	}
+	if !r.fields.Encrypts() {
+		doc.Body = habit.Description()
+	}
+	return doc
+}
+
+// savePauses persists the habit's freeze state and any new skips.
+func (r *SQLiteHabitRepository) savePauses(ctx context.Context, habit *domain.Habit) error {
+	conn := r.getDB(ctx)
+
+	var frozenUntil sql.NullString
+	if until := habit.FrozenUntil(); until != nil {
+		frozenUntil = sql.NullString{String: until.Format(time.DateOnly), Valid: true}
+	}
+	if _, err := conn.ExecContext(ctx,
+		"UPDATE habits SET frozen_until = ? WHERE id = ?",
+		frozenUntil, habit.ID().String(),
+	); err != nil {
+		return err
+	}
+
+	for _, skip := range habit.Skips() {
+		if _, err := conn.ExecContext(ctx,
+			"INSERT OR IGNORE INTO habit_skips (habit_id, skip_date) VALUES (?, ?)",
+			habit.ID().String(), skip.Format(time.DateOnly),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveTags replaces the habit's tags.
+func (r *SQLiteHabitRepository) saveTags(ctx context.Context, habit *domain.Habit) error {
+	conn := r.getDB(ctx)
+
+	if _, err := conn.ExecContext(ctx, "DELETE FROM habit_tags WHERE habit_id = ?", habit.ID().String()); err != nil {
+		return err
+	}
+
+	for _, tag := range habit.Tags() {
+		if _, err := conn.ExecContext(ctx,
+			"INSERT INTO habit_tags (habit_id, tag) VALUES (?, ?)",
+			habit.ID().String(), tag,
+		); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// saveTimezone records the time zone the habit's days are counted in.
+func (r *SQLiteHabitRepository) saveTimezone(ctx context.Context, habit *domain.Habit) error {
+	var timezone sql.NullString
+	if habit.Timezone() != "" {
+		timezone = sql.NullString{String: habit.Timezone(), Valid: true}
+	}
+	_, err := r.getDB(ctx).ExecContext(ctx,
+		"UPDATE habits SET timezone = ? WHERE id = ?",
+		timezone, habit.ID().String(),
+	)
+	return err
+}
+
 // FindByID retrieves a habit by its ID.
 func (r *SQLiteHabitRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Habit, error) {
 	queries := r.getQuerier(ctx)
@@ -136,12 +275,29 @@ func (r *SQLiteHabitRepository) FindByID(ctx context.Context, id uuid.UUID) (*do
 		return nil, err
 	}
 
-	completions, err := r.loadCompletions(ctx, id)
+	userID, err := uuid.Parse(row.UserID)
+	if err != nil {
+		return nil, err
+	}
+	completions, err := r.loadCompletions(ctx, userID, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.rowToHabit(row, completions), nil
+	habit, err := r.rowToHabit(row, completions)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadPauses(ctx, habit); err != nil {
+		return nil, err
+	}
+	if err := r.loadTags(ctx, habit); err != nil {
+		return nil, err
+	}
+	if err := r.loadTimezone(ctx, habit); err != nil {
+		return nil, err
+	}
+	return habit, nil
 }
 
 // FindByUserID retrieves all habits for a user.
@@ -168,16 +324,16 @@ func (r *SQLiteHabitRepository) FindActiveByUserID(ctx context.Context, userID u
 
 // FindDueToday retrieves habits that are due today for a user.
 func (r *SQLiteHabitRepository) FindDueToday(ctx context.Context, userID uuid.UUID) ([]*domain.Habit, error) {
-	// First get all active habits, then filter in memory based on IsDueOn
+	// First get all active habits, then filter in memory based on IsDueToday
 	habits, err := r.FindActiveByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	today := time.Now()
+	now := time.Now()
 	dueHabits := make([]*domain.Habit, 0)
 	for _, h := range habits {
-		if h.IsDueOn(today) {
+		if h.IsDueToday(now) {
 			dueHabits = append(dueHabits, h)
 		}
 	}
@@ -185,14 +341,38 @@ func (r *SQLiteHabitRepository) FindDueToday(ctx context.Context, userID uuid.UU
 	return dueHabits, nil
 }
 
+// Search returns up to limit of the user's habits matching query, most
+// relevant first.
+func (r *SQLiteHabitRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*domain.Habit, error) {
+	ids, err := sharedPersistence.SearchSQLiteIndex(ctx, r.getDB(ctx), sharedPersistence.SearchEntityHabit, userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	habits := make([]*domain.Habit, 0, len(ids))
+	for _, id := range ids {
+		habit, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if habit != nil {
+			habits = append(habits, habit)
+		}
+	}
+	return habits, nil
+}
+
 // Delete removes a habit from the database.
 func (r *SQLiteHabitRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := sharedPersistence.RemoveSQLiteSearchDocument(ctx, r.getDB(ctx), sharedPersistence.SearchEntityHabit, id); err != nil {
+		return err
+	}
 	queries := r.getQuerier(ctx)
 	// Completions are deleted via CASCADE
 	return queries.DeleteHabit(ctx, id.String())
 }
 
-func (r *SQLiteHabitRepository) loadCompletions(ctx context.Context, habitID uuid.UUID) ([]*domain.HabitCompletion, error) {
+func (r *SQLiteHabitRepository) loadCompletions(ctx context.Context, userID, habitID uuid.UUID) ([]*domain.HabitCompletion, error) {
 	queries := r.getQuerier(ctx)
 	rows, err := queries.GetHabitCompletionsByHabitID(ctx, habitID.String())
 	if err != nil {
@@ -213,18 +393,107 @@ func (r *SQLiteHabitRepository) loadCompletions(ctx context.Context, habitID uui
 		if err != nil {
 			return nil, err
 		}
+		notes, err := r.fields.Decrypt(userID, fromNullString(row.Notes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt completion notes: %w", err)
+		}
 
 		completions = append(completions, domain.RehydrateHabitCompletion(
 			id,
 			hid,
 			completedAt,
-			fromNullString(row.Notes),
+			notes,
 		))
 	}
 
 	return completions, nil
 }
 
+// loadPauses restores the habit's skips and freeze state.
+func (r *SQLiteHabitRepository) loadPauses(ctx context.Context, habit *domain.Habit) error {
+	conn := r.getDB(ctx)
+
+	var frozen sql.NullString
+	if err := conn.QueryRowContext(ctx,
+		"SELECT frozen_until FROM habits WHERE id = ?", habit.ID().String(),
+	).Scan(&frozen); err != nil {
+		return err
+	}
+	var frozenUntil *time.Time
+	if frozen.Valid {
+		until, err := time.Parse(time.DateOnly, frozen.String)
+		if err != nil {
+			return err
+		}
+		frozenUntil = &until
+	}
+
+	rows, err := conn.QueryContext(ctx,
+		"SELECT skip_date FROM habit_skips WHERE habit_id = ? ORDER BY skip_date", habit.ID().String(),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	skips := make([]time.Time, 0)
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return err
+		}
+		skip, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return err
+		}
+		skips = append(skips, skip)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	habit.RehydratePauses(skips, frozenUntil)
+	return nil
+}
+
+// loadTimezone restores the time zone the habit's days are counted in.
+func (r *SQLiteHabitRepository) loadTimezone(ctx context.Context, habit *domain.Habit) error {
+	var timezone sql.NullString
+	if err := r.getDB(ctx).QueryRowContext(ctx,
+		"SELECT timezone FROM habits WHERE id = ?", habit.ID().String(),
+	).Scan(&timezone); err != nil {
+		return err
+	}
+	habit.RehydrateTimezone(timezone.String)
+	return nil
+}
+
+// loadTags restores the habit's tags.
+func (r *SQLiteHabitRepository) loadTags(ctx context.Context, habit *domain.Habit) error {
+	rows, err := r.getDB(ctx).QueryContext(ctx,
+		"SELECT tag FROM habit_tags WHERE habit_id = ? ORDER BY tag", habit.ID().String(),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return err
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	habit.RehydrateTags(tags)
+	return nil
+}
+
 func (r *SQLiteHabitRepository) rowsToHabits(ctx context.Context, rows []db.Habit) ([]*domain.Habit, error) {
 	habits := make([]*domain.Habit, 0, len(rows))
 
@@ -233,29 +502,51 @@ func (r *SQLiteHabitRepository) rowsToHabits(ctx context.Context, rows []db.Habi
 		if err != nil {
 			return nil, err
 		}
+		userID, err := uuid.Parse(row.UserID)
+		if err != nil {
+			return nil, err
+		}
 
-		completions, err := r.loadCompletions(ctx, id)
+		completions, err := r.loadCompletions(ctx, userID, id)
 		if err != nil {
 			return nil, err
 		}
 
-		habits = append(habits, r.rowToHabit(row, completions))
+		habit, err := r.rowToHabit(row, completions)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.loadPauses(ctx, habit); err != nil {
+			return nil, err
+		}
+		if err := r.loadTags(ctx, habit); err != nil {
+			return nil, err
+		}
+		if err := r.loadTimezone(ctx, habit); err != nil {
+			return nil, err
+		}
+		habits = append(habits, habit)
 	}
 
 	return habits, nil
 }
 
-func (r *SQLiteHabitRepository) rowToHabit(row db.Habit, completions []*domain.HabitCompletion) *domain.Habit {
+func (r *SQLiteHabitRepository) rowToHabit(row db.Habit, completions []*domain.HabitCompletion) (*domain.Habit, error) {
 	id, _ := uuid.Parse(row.ID)
 	userID, _ := uuid.Parse(row.UserID)
 	createdAt, _ := time.Parse(time.RFC3339, row.CreatedAt)
 	updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
 
-	return domain.RehydrateHabit(
+	description, err := r.fields.Decrypt(userID, fromNullString(row.Description))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt description: %w", err)
+	}
+
+	habit := domain.RehydrateHabit(
 		id,
 		userID,
 		row.Name,
-		fromNullString(row.Description),
+		description,
 		domain.Frequency(row.Frequency),
 		int(row.TimesPerWeek),
 		time.Duration(row.DurationMinutes)*time.Minute,
@@ -268,6 +559,8 @@ func (r *SQLiteHabitRepository) rowToHabit(row db.Habit, completions []*domain.H
 		updatedAt,
 		completions,
 	)
+	habit.SetVersion(int(row.Version))
+	return habit, nil
 }
 
 // Helper functions
@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"os"
 	"path/filepath"
 	"testing"
@@ -10,6 +11,8 @@ import (
 
 	db "github.com/felixgeelhaar/orbita/db/generated/sqlite"
 	"github.com/felixgeelhaar/orbita/internal/habits/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,13 +28,24 @@ func setupHabitTestDB(t *testing.T) *sql.DB {
 	sqlDB, err := sql.Open("sqlite", ":memory:")
 	require.NoError(t, err)
 
-	// Read and execute the schema
-	schemaPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", "000001_initial_schema.up.sql")
-	schema, err := os.ReadFile(schemaPath)
-	require.NoError(t, err, "Failed to read SQLite schema file")
+	// Apply migrations in order
+	migrations := []string{
+		"000001_initial_schema.up.sql",
+		"000007_habit_skips_freeze.up.sql",
+		"000012_tags.up.sql",
+		"000017_aggregate_versions.up.sql",
+		"000027_task_habit_timezone.up.sql",
+		"000034_search_index.up.sql",
+	}
+
+	for _, migration := range migrations {
+		schemaPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", migration)
+		schema, err := os.ReadFile(schemaPath)
+		require.NoError(t, err, "Failed to read SQLite schema file: %s", migration)
 
-	_, err = sqlDB.Exec(string(schema))
-	require.NoError(t, err, "Failed to apply SQLite schema")
+		_, err = sqlDB.Exec(string(schema))
+		require.NoError(t, err, "Failed to apply SQLite schema: %s", migration)
+	}
 
 	return sqlDB
 }
@@ -129,6 +143,43 @@ func TestSQLiteHabitRepository_Save_Update(t *testing.T) {
 	assert.Equal(t, 30*time.Minute, retrieved.Duration())
 }
 
+func TestSQLiteHabitRepository_Save_StaleWrite(t *testing.T) {
+	sqlDB := setupHabitTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createHabitTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteHabitRepository(sqlDB)
+	ctx := context.Background()
+
+	habit, err := domain.NewHabit(userID, "Reading", domain.FrequencyDaily, 20*time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, habit))
+
+	// Two devices load the same habit
+	first, err := repo.FindByID(ctx, habit.ID())
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, habit.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, first.SetName("Morning Reading"))
+	require.NoError(t, repo.Save(ctx, first))
+
+	// The second save was based on the old version
+	require.NoError(t, second.SetName("Evening Reading"))
+	err = repo.Save(ctx, second)
+	assert.ErrorIs(t, err, sharedDomain.ErrConcurrentModification)
+
+	retrieved, err := repo.FindByID(ctx, habit.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Morning Reading", retrieved.Name())
+
+	// The winning copy can keep saving
+	require.NoError(t, first.SetName("Reading"))
+	assert.NoError(t, repo.Save(ctx, first))
+}
+
 func TestSQLiteHabitRepository_Save_WithCompletion(t *testing.T) {
 	sqlDB := setupHabitTestDB(t)
 	defer sqlDB.Close()
@@ -163,6 +214,62 @@ func TestSQLiteHabitRepository_Save_WithCompletion(t *testing.T) {
 	assert.Equal(t, 1, retrieved.TotalDone())
 }
 
+func TestSQLiteHabitRepository_EncryptedFreeText(t *testing.T) {
+	sqlDB := setupHabitTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createHabitTestUser(t, sqlDB, userID)
+
+	fields, err := sharedCrypto.NewFieldEncrypter(base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Written before encryption was turned on
+	plainRepo := NewSQLiteHabitRepository(sqlDB)
+	habit, err := domain.NewHabit(userID, "Journal", domain.FrequencyDaily, 10*time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, habit.SetDescription("Three lines before bed"))
+	_, err = habit.LogCompletion(time.Now().AddDate(0, 0, -1), "Wrote about the move")
+	require.NoError(t, err)
+	require.NoError(t, plainRepo.Save(ctx, habit))
+
+	repo := NewSQLiteHabitRepository(sqlDB).WithFieldEncrypter(fields)
+	loaded, err := repo.FindByID(ctx, habit.ID())
+	require.NoError(t, err)
+	_, err = loaded.LogCompletion(time.Now(), "Slept badly")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, loaded))
+
+	var description string
+	require.NoError(t, sqlDB.QueryRow("SELECT description FROM habits WHERE id = ?", habit.ID().String()).Scan(&description))
+	assert.NotContains(t, description, "bed")
+	var notes []string
+	rows, err := sqlDB.Query("SELECT notes FROM habit_completions WHERE habit_id = ?", habit.ID().String())
+	require.NoError(t, err)
+	for rows.Next() {
+		var note string
+		require.NoError(t, rows.Scan(&note))
+		notes = append(notes, note)
+	}
+	require.NoError(t, rows.Close())
+	assert.Contains(t, notes, "Wrote about the move", "existing completions keep their plaintext notes")
+	assert.NotContains(t, notes, "Slept badly")
+
+	// Plaintext and encrypted notes read back side by side
+	retrieved, err := repo.FindByID(ctx, habit.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Three lines before bed", retrieved.Description())
+	var readNotes []string
+	for _, c := range retrieved.Completions() {
+		readNotes = append(readNotes, c.Notes())
+	}
+	assert.ElementsMatch(t, []string{"Wrote about the move", "Slept badly"}, readNotes)
+
+	_, err = plainRepo.FindByID(ctx, habit.ID())
+	assert.ErrorIs(t, err, sharedCrypto.ErrNoFieldKey)
+}
+
 func TestSQLiteHabitRepository_FindByID_NotFound(t *testing.T) {
 	sqlDB := setupHabitTestDB(t)
 	defer sqlDB.Close()
@@ -507,3 +614,96 @@ func TestSQLiteHabitRepository_ArchiveAndUnarchive(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, retrieved.IsArchived())
 }
+
+func TestSQLiteHabitRepository_SkipsAndFreeze(t *testing.T) {
+	sqlDB := setupHabitTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createHabitTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteHabitRepository(sqlDB)
+	ctx := context.Background()
+
+	habit, err := domain.NewHabit(userID, "Meditate", domain.FrequencyDaily, 10*time.Minute)
+	require.NoError(t, err)
+	today := time.Now()
+	require.NoError(t, habit.Skip(today))
+	require.NoError(t, repo.Save(ctx, habit))
+
+	found, err := repo.FindByID(ctx, habit.ID())
+	require.NoError(t, err)
+	assert.True(t, found.IsSkippedOn(today))
+	assert.Nil(t, found.FrozenUntil())
+
+	require.NoError(t, found.Freeze(today.AddDate(0, 0, 3)))
+	require.NoError(t, found.Skip(today.AddDate(0, 0, 1)))
+	require.NoError(t, repo.Save(ctx, found))
+
+	habits, err := repo.FindActiveByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, habits, 1)
+	assert.Len(t, habits[0].Skips(), 2)
+	assert.True(t, habits[0].IsFrozenOn(today.AddDate(0, 0, 3)))
+	assert.False(t, habits[0].IsFrozenOn(today.AddDate(0, 0, 4)))
+}
+
+func TestSQLiteHabitRepository_Tags(t *testing.T) {
+	sqlDB := setupHabitTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createHabitTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteHabitRepository(sqlDB)
+	ctx := context.Background()
+
+	habit, err := domain.NewHabit(userID, "Meditate", domain.FrequencyDaily, 10*time.Minute)
+	require.NoError(t, err)
+	_, err = habit.AddTag("health")
+	require.NoError(t, err)
+	_, err = habit.AddTag("morning")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, habit))
+
+	found, err := repo.FindByID(ctx, habit.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"health", "morning"}, found.Tags())
+
+	_, err = found.RemoveTag("morning")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, found))
+
+	habits, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, habits, 1)
+	assert.Equal(t, []string{"health"}, habits[0].Tags())
+}
+
+func TestSQLiteHabitRepository_Timezone(t *testing.T) {
+	sqlDB := setupHabitTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createHabitTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteHabitRepository(sqlDB)
+	ctx := context.Background()
+
+	habit, err := domain.NewHabit(userID, "Call home", domain.FrequencyWeekdays, 15*time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, habit.SetTimezone("Europe/Lisbon"))
+	require.NoError(t, repo.Save(ctx, habit))
+
+	found, err := repo.FindByID(ctx, habit.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Lisbon", found.Timezone())
+
+	require.NoError(t, found.SetTimezone(""))
+	require.NoError(t, repo.Save(ctx, found))
+
+	habits, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, habits, 1)
+	assert.Empty(t, habits[0].Timezone())
+}
@@ -4,23 +4,44 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 	"time"
 
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
 	"github.com/google/uuid"
 	"golang.org/x/oauth2"
 )
 
+// DefaultTokenExpirySkew is how long before its expiry a token is refreshed
+// unless configured otherwise. It covers request latency and a host clock
+// that runs behind the provider's.
+const DefaultTokenExpirySkew = time.Minute
+
+// ErrAccountsNotSupported is returned when a named account is requested
+// from a token repository that only stores one token per provider.
+var ErrAccountsNotSupported = errors.New("oauth token repository does not support multiple accounts")
+
 // TokenRepository defines persistence for encrypted OAuth tokens.
+// FindByUserAndProvider returns the token of the default account.
 type TokenRepository interface {
 	Save(ctx context.Context, token StoredToken) error
 	FindByUserAndProvider(ctx context.Context, userID uuid.UUID, provider string) (*StoredToken, error)
 }
 
+// AccountTokenRepository is implemented by token repositories that store a
+// token per account, so a user can connect several accounts of one provider.
+type AccountTokenRepository interface {
+	FindByUserProviderAndAccount(ctx context.Context, userID uuid.UUID, provider, account string) (*StoredToken, error)
+}
+
 // StoredToken is the encrypted representation of an OAuth token.
 type StoredToken struct {
-	UserID       uuid.UUID
-	Provider     string
+	UserID   uuid.UUID
+	Provider string
+	// Account names one of several accounts of the provider, such as "work".
+	// Empty is the default account.
+	Account      string
 	AccessToken  []byte
 	RefreshToken []byte
 	TokenType    string
@@ -28,17 +49,76 @@ type StoredToken struct {
 	Scopes       []string
 }
 
-// TokenSource returns a token source for the given user.
+// TokenSource returns a token source for the given user. The stored token is
+// served until TokenExpired reports it expired, then it is refreshed.
 func (s *Service) TokenSource(ctx context.Context, userID uuid.UUID) (oauth2.TokenSource, error) {
-	token, err := s.loadToken(ctx, userID)
+	return s.TokenSourceForAccount(ctx, userID, "")
+}
+
+// TokenSourceForAccount returns a token source for one of the user's
+// accounts. An empty account is the default one.
+func (s *Service) TokenSourceForAccount(ctx context.Context, userID uuid.UUID, account string) (oauth2.TokenSource, error) {
+	token, err := s.loadToken(ctx, userID, account)
 	if err != nil {
 		return nil, err
 	}
-	return s.oauthConfig.TokenSource(ctx, token), nil
+	return &refreshingTokenSource{ctx: ctx, service: s, token: token}, nil
 }
 
-func (s *Service) loadToken(ctx context.Context, userID uuid.UUID) (*oauth2.Token, error) {
-	stored, err := s.repo.FindByUserAndProvider(ctx, userID, s.provider)
+// TokenExpired reports whether the token is expired by the service clock, or
+// expires within the skew tolerance. Tokens without an expiry never expire.
+func (s *Service) TokenExpired(token *oauth2.Token) bool {
+	if token == nil || token.AccessToken == "" {
+		return true
+	}
+	if token.Expiry.IsZero() {
+		return false
+	}
+	return !s.clock().Add(s.expirySkew).Before(token.Expiry)
+}
+
+// refreshingTokenSource serves a token until the service considers it
+// expired. Expiry is decided by the service clock and skew tolerance rather
+// than the oauth2 package's own check against the host clock.
+type refreshingTokenSource struct {
+	ctx     context.Context
+	service *Service
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func (ts *refreshingTokenSource) Token() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if !ts.service.TokenExpired(ts.token) {
+		return ts.token, nil
+	}
+
+	// A token without an access token is never valid, so the config's
+	// source goes straight to the refresh.
+	refresh := &oauth2.Token{RefreshToken: ts.token.RefreshToken}
+	token, err := ts.service.oauthConfig.TokenSource(ts.ctx, refresh).Token()
+	if err != nil {
+		return nil, err
+	}
+	ts.token = token
+	return token, nil
+}
+
+func (s *Service) loadToken(ctx context.Context, userID uuid.UUID, account string) (*oauth2.Token, error) {
+	var stored *StoredToken
+	var err error
+	if account == "" {
+		stored, err = s.repo.FindByUserAndProvider(ctx, userID, s.provider)
+	} else {
+		accounts, ok := s.repo.(AccountTokenRepository)
+		if !ok {
+			return nil, ErrAccountsNotSupported
+		}
+		stored, err = accounts.FindByUserProviderAndAccount(ctx, userID, s.provider, account)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +152,8 @@ type Service struct {
 	scopes      []string
 	repo        TokenRepository
 	encrypter   sharedCrypto.Encrypter
+	clock       sharedDomain.Clock
+	expirySkew  time.Duration
 }
 
 // NewService creates a new OAuth service.
@@ -113,9 +195,27 @@ func NewService(
 		scopes:      scopes,
 		repo:        repo,
 		encrypter:   encrypter,
+		clock:       sharedDomain.SystemClock,
+		expirySkew:  DefaultTokenExpirySkew,
 	}, nil
 }
 
+// WithClock sets the clock tokens are checked for expiry against.
+func (s *Service) WithClock(clock sharedDomain.Clock) *Service {
+	s.clock = clock
+	return s
+}
+
+// WithExpirySkew sets how long before its expiry a token is refreshed. A
+// negative skew is treated as zero.
+func (s *Service) WithExpirySkew(skew time.Duration) *Service {
+	if skew < 0 {
+		skew = 0
+	}
+	s.expirySkew = skew
+	return s
+}
+
 // AuthURL returns the provider authorization URL.
 func (s *Service) AuthURL(state string) string {
 	return s.oauthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline)
@@ -123,6 +223,17 @@ func (s *Service) AuthURL(state string) string {
 
 // ExchangeAndStore exchanges a code for a token and stores it encrypted.
 func (s *Service) ExchangeAndStore(ctx context.Context, userID uuid.UUID, code string) (*oauth2.Token, error) {
+	return s.ExchangeAndStoreForAccount(ctx, userID, "", code)
+}
+
+// ExchangeAndStoreForAccount exchanges a code for a token and stores it
+// encrypted under one of the user's accounts, leaving the tokens of their
+// other accounts in place. An empty account is the default one.
+func (s *Service) ExchangeAndStoreForAccount(ctx context.Context, userID uuid.UUID, account, code string) (*oauth2.Token, error) {
+	if _, ok := s.repo.(AccountTokenRepository); account != "" && !ok {
+		return nil, ErrAccountsNotSupported
+	}
+
 	token, err := s.oauthConfig.Exchange(ctx, code)
 	if err != nil {
 		return nil, err
@@ -144,6 +255,7 @@ func (s *Service) ExchangeAndStore(ctx context.Context, userID uuid.UUID, code s
 	stored := StoredToken{
 		UserID:       userID,
 		Provider:     s.provider,
+		Account:      account,
 		AccessToken:  accessEnc,
 		RefreshToken: refreshEnc,
 		TokenType:    token.TokenType,
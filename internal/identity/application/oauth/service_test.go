@@ -4,15 +4,19 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/identity/application/oauth"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
 )
 
 type inMemoryRepo struct {
@@ -331,3 +335,218 @@ func TestNewService_ValidationErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenExpired_SkewTolerance(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	encrypter, err := sharedCrypto.NewAESGCMFromBase64Key(key)
+	require.NoError(t, err)
+
+	service, err := oauth.NewService(
+		"google",
+		"client-id",
+		"client-secret",
+		"http://auth.example.com/authorize",
+		"http://auth.example.com/token",
+		"http://localhost/callback",
+		[]string{"calendar"},
+		&inMemoryRepo{},
+		encrypter,
+	)
+	require.NoError(t, err)
+
+	now := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	service.WithClock(sharedDomain.FixedClock(now)).WithExpirySkew(2 * time.Minute)
+
+	expiringIn := func(d time.Duration) *oauth2.Token {
+		return &oauth2.Token{AccessToken: "access", Expiry: now.Add(d)}
+	}
+
+	require.True(t, service.TokenExpired(expiringIn(-time.Minute)), "already expired")
+	require.True(t, service.TokenExpired(expiringIn(90*time.Second)), "expires within the skew")
+	require.False(t, service.TokenExpired(expiringIn(5*time.Minute)))
+	require.False(t, service.TokenExpired(&oauth2.Token{AccessToken: "access"}), "no expiry")
+	require.True(t, service.TokenExpired(&oauth2.Token{Expiry: now.Add(time.Hour)}), "no access token")
+
+	service.WithExpirySkew(0)
+	require.False(t, service.TokenExpired(expiringIn(90*time.Second)))
+	service.WithExpirySkew(-time.Minute)
+	require.False(t, service.TokenExpired(expiringIn(time.Second)), "negative skew counts as zero")
+}
+
+func TestTokenSource_RefreshesByServiceClock(t *testing.T) {
+	var refreshes atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refreshes.Add(1)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		require.Equal(t, "refresh-token", r.PostForm.Get("refresh_token"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "fresh-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	encrypter, err := sharedCrypto.NewAESGCMFromBase64Key(key)
+	require.NoError(t, err)
+
+	// The stored token expires 30 seconds after the fake clock's time.
+	now := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	access, err := encrypter.Encrypt([]byte("stale-token"))
+	require.NoError(t, err)
+	refresh, err := encrypter.Encrypt([]byte("refresh-token"))
+	require.NoError(t, err)
+	repo := &inMemoryRepo{stored: oauth.StoredToken{
+		Provider:     "google",
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		Expiry:       now.Add(30 * time.Second),
+	}}
+
+	service, err := oauth.NewService(
+		"google",
+		"client-id",
+		"client-secret",
+		"http://auth.example.com/authorize",
+		tokenServer.URL,
+		"http://localhost/callback",
+		[]string{"calendar"},
+		repo,
+		encrypter,
+	)
+	require.NoError(t, err)
+
+	t.Run("serves the stored token outside the skew", func(t *testing.T) {
+		service.WithClock(sharedDomain.FixedClock(now)).WithExpirySkew(10 * time.Second)
+
+		source, err := service.TokenSource(context.Background(), uuid.New())
+		require.NoError(t, err)
+		token, err := source.Token()
+		require.NoError(t, err)
+		require.Equal(t, "stale-token", token.AccessToken)
+		require.Equal(t, int32(0), refreshes.Load())
+	})
+
+	t.Run("refreshes a token expiring within the skew", func(t *testing.T) {
+		service.WithClock(sharedDomain.FixedClock(now)).WithExpirySkew(time.Minute)
+
+		source, err := service.TokenSource(context.Background(), uuid.New())
+		require.NoError(t, err)
+		token, err := source.Token()
+		require.NoError(t, err)
+		require.Equal(t, "fresh-token", token.AccessToken)
+		require.Equal(t, "refresh-token", token.RefreshToken)
+		require.Equal(t, int32(1), refreshes.Load())
+
+		// The refreshed token is reused until it nears expiry in turn.
+		again, err := source.Token()
+		require.NoError(t, err)
+		require.Equal(t, "fresh-token", again.AccessToken)
+		require.Equal(t, int32(1), refreshes.Load())
+	})
+}
+
+// accountRepo stores a token per provider account.
+type accountRepo struct {
+	tokens map[string]oauth.StoredToken
+}
+
+func (r *accountRepo) Save(ctx context.Context, token oauth.StoredToken) error {
+	r.tokens[token.Account] = token
+	return nil
+}
+
+func (r *accountRepo) FindByUserAndProvider(ctx context.Context, userID uuid.UUID, provider string) (*oauth.StoredToken, error) {
+	return r.FindByUserProviderAndAccount(ctx, userID, provider, "")
+}
+
+func (r *accountRepo) FindByUserProviderAndAccount(ctx context.Context, userID uuid.UUID, provider, account string) (*oauth.StoredToken, error) {
+	token, ok := r.tokens[account]
+	if !ok {
+		return nil, errors.New("token not found")
+	}
+	return &token, nil
+}
+
+func TestExchangeAndStoreForAccount_KeepsAccountsApart(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": r.PostForm.Get("code") + "-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	encrypter, err := sharedCrypto.NewAESGCMFromBase64Key(key)
+	require.NoError(t, err)
+
+	repo := &accountRepo{tokens: make(map[string]oauth.StoredToken)}
+	service, err := oauth.NewService(
+		"google",
+		"client-id",
+		"client-secret",
+		"http://auth.example",
+		tokenServer.URL,
+		"http://localhost/callback",
+		[]string{"calendar"},
+		repo,
+		encrypter,
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	_, err = service.ExchangeAndStoreForAccount(ctx, userID, "work", "work")
+	require.NoError(t, err)
+	_, err = service.ExchangeAndStoreForAccount(ctx, userID, "personal", "personal")
+	require.NoError(t, err)
+
+	require.Len(t, repo.tokens, 2)
+	require.Equal(t, "work", repo.tokens["work"].Account)
+
+	accessToken := func(account string) string {
+		source, err := service.TokenSourceForAccount(ctx, userID, account)
+		require.NoError(t, err)
+		token, err := source.Token()
+		require.NoError(t, err)
+		return token.AccessToken
+	}
+	require.Equal(t, "work-token", accessToken("work"))
+	require.Equal(t, "personal-token", accessToken("personal"))
+
+	// The default account is separate from the named ones.
+	_, err = service.TokenSource(ctx, userID)
+	require.Error(t, err)
+}
+
+func TestAccounts_RequireAccountRepository(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	encrypter, err := sharedCrypto.NewAESGCMFromBase64Key(key)
+	require.NoError(t, err)
+
+	service, err := oauth.NewService(
+		"google",
+		"client-id",
+		"client-secret",
+		"http://auth.example.com/authorize",
+		"http://auth.example.com/token",
+		"http://localhost/callback",
+		[]string{"calendar"},
+		&inMemoryRepo{},
+		encrypter,
+	)
+	require.NoError(t, err)
+
+	_, err = service.TokenSourceForAccount(context.Background(), uuid.New(), "work")
+	require.ErrorIs(t, err, oauth.ErrAccountsNotSupported)
+	_, err = service.ExchangeAndStoreForAccount(context.Background(), uuid.New(), "work", "code")
+	require.ErrorIs(t, err, oauth.ErrAccountsNotSupported)
+}
@@ -0,0 +1,150 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/google/uuid"
+)
+
+// ExportVersion is the version of the settings export format.
+const ExportVersion = 1
+
+// ErrUnsupportedExportVersion is returned when importing an export written
+// in a format this version does not understand.
+var ErrUnsupportedExportVersion = errors.New("unsupported settings export version")
+
+// Export is a portable copy of a user's settings, used to back them up or to
+// set up another machine the same way.
+type Export struct {
+	Version int `json:"version"`
+	// Settings maps schema keys to their values.
+	Settings map[string]string `json:"settings"`
+	// Notifications is the notification channel and its target.
+	Notifications *NotificationExport `json:"notifications,omitempty"`
+	// ClassifierRules maps inbox keywords to the classification they imply.
+	ClassifierRules map[string]string `json:"classifier_rules"`
+}
+
+// NotificationExport is the exported notification channel.
+type NotificationExport struct {
+	Channel string `json:"channel"`
+	Target  string `json:"target,omitempty"`
+}
+
+// Export returns every setting of the user. Settings the user has not set
+// are exported with their defaults.
+func (s *Service) Export(ctx context.Context, userID uuid.UUID) (*Export, error) {
+	export := &Export{
+		Version:  ExportVersion,
+		Settings: make(map[string]string, len(definitions)),
+	}
+	for _, def := range definitions {
+		value, err := s.Get(ctx, userID, def.Key)
+		if err != nil {
+			return nil, err
+		}
+		export.Settings[def.Key] = value
+	}
+
+	channel, target, err := s.repo.GetNotificationChannel(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := notifications.ParseChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	export.Notifications = &NotificationExport{Channel: parsed.String(), Target: target}
+
+	rules, err := s.repo.GetClassifierRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		rules = map[string]string{}
+	}
+	export.ClassifierRules = rules
+
+	return export, nil
+}
+
+// Validate checks the export against the settings schema and returns every
+// problem found, so an import can be fixed in one go.
+func (e *Export) Validate() error {
+	if e.Version != ExportVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedExportVersion, e.Version)
+	}
+
+	keys := make([]string, 0, len(e.Settings))
+	for key := range e.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		if _, err := validate(key, e.Settings[key]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if e.Notifications != nil {
+		channel, err := notifications.ParseChannel(e.Notifications.Channel)
+		if err != nil {
+			errs = append(errs, err)
+		} else if channel.NeedsTarget() && strings.TrimSpace(e.Notifications.Target) == "" {
+			errs = append(errs, fmt.Errorf("%w: %s", notifications.ErrMissingTarget, channel))
+		}
+	}
+
+	for keyword := range e.ClassifierRules {
+		if strings.TrimSpace(keyword) == "" {
+			errs = append(errs, errors.New("classifier rule keyword is required"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Import validates the export and applies it. Nothing is changed when any
+// value is invalid. Settings missing from the export are left as they are;
+// classifier rules, when present, replace the user's rules.
+func (s *Service) Import(ctx context.Context, userID uuid.UUID, export *Export) error {
+	if err := export.Validate(); err != nil {
+		return err
+	}
+
+	for key, value := range export.Settings {
+		if err := s.Set(ctx, userID, key, value); err != nil {
+			return err
+		}
+	}
+
+	if export.Notifications != nil {
+		channel, _ := notifications.ParseChannel(export.Notifications.Channel)
+		target := strings.TrimSpace(export.Notifications.Target)
+		if !channel.NeedsTarget() {
+			target = ""
+		}
+		if err := s.repo.SetNotificationChannel(ctx, userID, string(channel), target); err != nil {
+			return err
+		}
+	}
+
+	if export.ClassifierRules != nil {
+		rules := make(map[string]string, len(export.ClassifierRules))
+		for keyword, classification := range export.ClassifierRules {
+			rules[strings.ToLower(strings.TrimSpace(keyword))] = classification
+		}
+		if err := s.repo.SetClassifierRules(ctx, userID, rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ExportImport_RoundTrip(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	source, target := uuid.New(), uuid.New()
+
+	require.NoError(t, service.SetCalendarID(ctx, source, "work"))
+	require.NoError(t, service.SetDeleteMissing(ctx, source, true))
+	require.NoError(t, service.SetNotificationChannel(ctx, source, "email", "me@example.com"))
+	require.NoError(t, service.SetDefaultDuration(ctx, source, "high", 90))
+	require.NoError(t, service.SetClassifierRule(ctx, source, "standup", "meeting"))
+	require.NoError(t, service.SetDigest(ctx, source, "weekly", "18:15", "Europe/Berlin"))
+
+	exported, err := service.Export(ctx, source)
+	require.NoError(t, err)
+	assert.Equal(t, ExportVersion, exported.Version)
+	assert.Equal(t, "work", exported.Settings[KeyCalendarID])
+	assert.Equal(t, "90", exported.Settings["durations.high"])
+	assert.Equal(t, "0", exported.Settings["durations.low"])
+	assert.Equal(t, &NotificationExport{Channel: "email", Target: "me@example.com"}, exported.Notifications)
+
+	data, err := json.Marshal(exported)
+	require.NoError(t, err)
+	var decoded Export
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	// Existing rules on the target are replaced.
+	require.NoError(t, service.SetClassifierRule(ctx, target, "lunch", "habit"))
+	require.NoError(t, service.Import(ctx, target, &decoded))
+
+	imported, err := service.Export(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, exported, imported)
+	assert.Equal(t, map[string]string{"standup": "meeting"}, repo.rules[target])
+}
+
+func TestService_Import_InvalidValues(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	err := service.Import(ctx, userID, &Export{
+		Version: ExportVersion,
+		Settings: map[string]string{
+			KeyCalendarID:     "work",
+			KeyDigestTimezone: "Mars/Olympus_Mons",
+			"durations.high":  "-30",
+			"theme":           "dark",
+		},
+		Notifications: &NotificationExport{Channel: "webhook"},
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidSetting)
+	assert.ErrorIs(t, err, ErrUnknownSetting)
+	assert.ErrorContains(t, err, `unknown time zone "Mars/Olympus_Mons"`)
+	assert.ErrorContains(t, err, "durations.high: -30 is outside 0-480")
+	assert.ErrorContains(t, err, "notification channel requires a target")
+
+	// Nothing was applied, not even the valid calendar ID.
+	assert.Empty(t, repo.calendarIDs)
+	assert.Empty(t, repo.durations)
+	assert.Empty(t, repo.digests)
+	assert.Empty(t, repo.channels)
+}
+
+func TestService_Import_UnsupportedVersion(t *testing.T) {
+	service := NewService(newMockRepository())
+
+	err := service.Import(context.Background(), uuid.New(), &Export{Version: 2})
+	assert.ErrorIs(t, err, ErrUnsupportedExportVersion)
+}
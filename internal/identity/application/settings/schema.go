@@ -0,0 +1,219 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	habits "github.com/felixgeelhaar/orbita/internal/habits/domain"
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+)
+
+var (
+	// ErrUnknownSetting is returned for a key that is not in the schema.
+	ErrUnknownSetting = errors.New("unknown setting")
+	// ErrInvalidSetting is returned when a value does not fit the setting's
+	// type or bounds.
+	ErrInvalidSetting = errors.New("invalid setting value")
+)
+
+// Kind is the type of a setting's value.
+type Kind string
+
+const (
+	KindString   Kind = "string"
+	KindBool     Kind = "bool"
+	KindInt      Kind = "int"
+	KindTime     Kind = "time"
+	KindTimezone Kind = "timezone"
+	KindEnum     Kind = "enum"
+	KindSort     Kind = "sort"
+)
+
+// Setting keys.
+const (
+	KeyCalendarID      = "calendar_id"
+	KeyDeleteMissing   = "delete_missing"
+	KeyDigestFrequency = "digest.frequency"
+	KeyDigestTime      = "digest.time"
+	KeyDigestTimezone  = "digest.timezone"
+	KeyTaskSort        = "sort.tasks"
+	KeyHabitSort       = "sort.habits"
+	KeyHabitRollover   = "habits.day_rollover"
+	KeyPlanDayOffset   = "plan.day_offset"
+	KeyPlanMinSlot     = "plan.min_slot_minutes"
+	KeyPlanAuto        = "plan.auto_schedule"
+)
+
+// durationKeyPrefix prefixes the per-priority default duration keys, e.g.
+// "durations.high".
+const durationKeyPrefix = "durations."
+
+// sortKeyPrefix prefixes the default list sort order keys, e.g.
+// "sort.tasks".
+const sortKeyPrefix = "sort."
+
+// planKeyPrefix prefixes the planning default keys, e.g. "plan.day_offset".
+const planKeyPrefix = "plan."
+
+// Fields task and habit lists can be sorted by.
+var (
+	TaskSortFields  = []string{"priority", "due_date", "created_at", "title", "score"}
+	HabitSortFields = []string{"streak", "best_streak", "name", "created_at"}
+)
+
+// Bounds for default task durations in minutes. Zero clears the default.
+const (
+	MinDefaultDurationMinutes = 0
+	MaxDefaultDurationMinutes = 480
+)
+
+// Bounds for planning defaults: how many days ahead a plan is for and the
+// shortest free slot in minutes it offers.
+const (
+	MaxPlanDayOffset      = 14
+	MinPlanMinSlotMinutes = 5
+	MaxPlanMinSlotMinutes = 240
+)
+
+// Definition declares a setting: its key, value type, default and bounds.
+type Definition struct {
+	Key         string
+	Kind        Kind
+	Description string
+	// Default is returned when the user has not set a value.
+	Default string
+	// Min and Max bound KindInt values.
+	Min int
+	Max int
+	// Options lists the allowed KindEnum values and the KindSort fields.
+	Options []string
+}
+
+var definitions = buildDefinitions()
+
+func buildDefinitions() []Definition {
+	defs := []Definition{
+		{Key: KeyCalendarID, Kind: KindString, Description: "Calendar used for sync and import", Default: "primary"},
+		{Key: KeyDeleteMissing, Kind: KindBool, Description: "Delete calendar events for removed blocks on sync", Default: "false"},
+		{Key: KeyDigestFrequency, Kind: KindEnum, Description: "How often the digest is sent", Default: "off", Options: []string{"off", "daily", "weekly"}},
+		{Key: KeyDigestTime, Kind: KindTime, Description: "Local time of day the digest is sent at", Default: "08:00"},
+		{Key: KeyDigestTimezone, Kind: KindTimezone, Description: "IANA time zone of the digest time and reminder quiet hours, empty for the server's", Default: ""},
+		{Key: KeyTaskSort, Kind: KindSort, Description: "Default task list order, such as priority:desc,due_date:asc", Default: "priority:desc,due_date:asc", Options: TaskSortFields},
+		{Key: KeyHabitSort, Kind: KindSort, Description: "Default habit list order, empty for newest first", Default: "", Options: HabitSortFields},
+		{Key: KeyHabitRollover, Kind: KindInt, Description: "Hour after midnight at which habit days end, 0 for midnight", Default: "0", Max: habits.MaxDayRolloverHour},
+		{Key: KeyPlanDayOffset, Kind: KindInt, Description: "Days ahead a plan is for by default, 0 for today", Default: "1", Max: MaxPlanDayOffset},
+		{Key: KeyPlanMinSlot, Kind: KindInt, Description: "Shortest free slot in minutes offered when planning", Default: "15", Min: MinPlanMinSlotMinutes, Max: MaxPlanMinSlotMinutes},
+		{Key: KeyPlanAuto, Kind: KindBool, Description: "Auto-schedule when planning without --auto", Default: "false"},
+	}
+	for _, priority := range []string{"urgent", "high", "medium", "low", "none"} {
+		defs = append(defs, Definition{
+			Key:         durationKeyPrefix + priority,
+			Kind:        KindInt,
+			Description: fmt.Sprintf("Default duration in minutes for %s priority tasks, 0 for none", priority),
+			Default:     "0",
+			Min:         MinDefaultDurationMinutes,
+			Max:         MaxDefaultDurationMinutes,
+		})
+	}
+	return defs
+}
+
+// Definitions returns every setting in the schema.
+func Definitions() []Definition {
+	defs := make([]Definition, len(definitions))
+	copy(defs, definitions)
+	return defs
+}
+
+// Lookup returns the definition of a setting.
+func Lookup(key string) (Definition, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	for _, def := range definitions {
+		if def.Key == key {
+			return def, nil
+		}
+	}
+	return Definition{}, fmt.Errorf("%w: %s", ErrUnknownSetting, key)
+}
+
+// Validate checks a value against the definition and returns it in its
+// canonical form.
+func (d Definition) Validate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch d.Kind {
+	case KindBool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", d.invalid("%q is not true or false", value)
+		}
+		return strconv.FormatBool(parsed), nil
+
+	case KindInt:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return "", d.invalid("%q is not a whole number", value)
+		}
+		if parsed < d.Min || parsed > d.Max {
+			return "", d.invalid("%d is outside %d-%d", parsed, d.Min, d.Max)
+		}
+		return strconv.Itoa(parsed), nil
+
+	case KindTime:
+		hour, minute, err := notifications.ParseDigestTime(value)
+		if err != nil {
+			return "", d.invalid("%q is not a time in HH:MM form", value)
+		}
+		return fmt.Sprintf("%02d:%02d", hour, minute), nil
+
+	case KindTimezone:
+		if value == "" {
+			return "", nil
+		}
+		if _, err := time.LoadLocation(value); err != nil {
+			return "", d.invalid("unknown time zone %q, use an IANA name such as Europe/Berlin", value)
+		}
+		return value, nil
+
+	case KindEnum:
+		lower := strings.ToLower(value)
+		for _, option := range d.Options {
+			if lower == option {
+				return option, nil
+			}
+		}
+		return "", d.invalid("%q is not one of %s", value, strings.Join(d.Options, ", "))
+
+	case KindSort:
+		keys, err := sharedApplication.ParseSort(value, d.Options)
+		if err != nil {
+			return "", d.invalid("%v", err)
+		}
+		return sharedApplication.FormatSort(keys), nil
+	}
+
+	return value, nil
+}
+
+func (d Definition) invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s: %s", ErrInvalidSetting, d.Key, fmt.Sprintf(format, args...))
+}
+
+// definitionDefault returns the default of a setting in the schema.
+func definitionDefault(key string) string {
+	def, _ := Lookup(key)
+	return def.Default
+}
+
+// validate checks a value for key, which must be in the schema.
+func validate(key, value string) (string, error) {
+	def, err := Lookup(key)
+	if err != nil {
+		return "", err
+	}
+	return def.Validate(value)
+}
@@ -0,0 +1,70 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	def, err := Lookup(" Digest.Timezone ")
+	require.NoError(t, err)
+	assert.Equal(t, KeyDigestTimezone, def.Key)
+	assert.Equal(t, KindTimezone, def.Kind)
+
+	_, err = Lookup("theme")
+	assert.ErrorIs(t, err, ErrUnknownSetting)
+}
+
+func TestDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "valid timezone", key: KeyDigestTimezone, value: "America/New_York", want: "America/New_York"},
+		{name: "empty timezone uses the server's", key: KeyDigestTimezone, value: " ", want: ""},
+		{name: "unknown timezone", key: KeyDigestTimezone, value: "Mars/Olympus_Mons", wantErr: `digest.timezone: unknown time zone "Mars/Olympus_Mons"`},
+		{name: "duration within bounds", key: "durations.high", value: "90", want: "90"},
+		{name: "zero duration clears", key: "durations.high", value: "0", want: "0"},
+		{name: "negative duration", key: "durations.high", value: "-15", wantErr: "durations.high: -15 is outside 0-480"},
+		{name: "duration above maximum", key: "durations.low", value: "481", wantErr: "durations.low: 481 is outside 0-480"},
+		{name: "duration not a number", key: "durations.low", value: "1h", wantErr: `"1h" is not a whole number`},
+		{name: "bool", key: KeyDeleteMissing, value: "TRUE", want: "true"},
+		{name: "invalid bool", key: KeyDeleteMissing, value: "maybe", wantErr: "not true or false"},
+		{name: "time", key: KeyDigestTime, value: "7:05", want: "07:05"},
+		{name: "invalid time", key: KeyDigestTime, value: "7pm", wantErr: "not a time in HH:MM form"},
+		{name: "enum", key: KeyDigestFrequency, value: "Weekly", want: "weekly"},
+		{name: "invalid enum", key: KeyDigestFrequency, value: "hourly", wantErr: "not one of off, daily, weekly"},
+		{name: "sort", key: KeyTaskSort, value: "due_date:ASC, priority", want: "due_date:asc,priority:desc"},
+		{name: "empty sort restores the default", key: KeyHabitSort, value: "", want: ""},
+		{name: "sort by unknown field", key: KeyHabitSort, value: "priority", wantErr: `sort.habits: invalid sort order: unknown field "priority"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, err := Lookup(tt.key)
+			require.NoError(t, err)
+
+			got, err := def.Validate(tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidSetting)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefinitions_DefaultsAreValid(t *testing.T) {
+	for _, def := range Definitions() {
+		got, err := def.Validate(def.Default)
+		require.NoError(t, err, def.Key)
+		assert.Equal(t, def.Default, got, def.Key)
+	}
+}
@@ -2,7 +2,13 @@ package settings
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
 	"github.com/google/uuid"
 )
 
@@ -12,6 +18,22 @@ type Repository interface {
 	SetCalendarID(ctx context.Context, userID uuid.UUID, calendarID string) error
 	GetDeleteMissing(ctx context.Context, userID uuid.UUID) (bool, error)
 	SetDeleteMissing(ctx context.Context, userID uuid.UUID, deleteMissing bool) error
+	GetNotificationChannel(ctx context.Context, userID uuid.UUID) (channel, target string, err error)
+	SetNotificationChannel(ctx context.Context, userID uuid.UUID, channel, target string) error
+	GetDefaultDurations(ctx context.Context, userID uuid.UUID) (map[string]int, error)
+	SetDefaultDurations(ctx context.Context, userID uuid.UUID, durations map[string]int) error
+	GetClassifierRules(ctx context.Context, userID uuid.UUID) (map[string]string, error)
+	SetClassifierRules(ctx context.Context, userID uuid.UUID, rules map[string]string) error
+	GetListSorts(ctx context.Context, userID uuid.UUID) (map[string]string, error)
+	SetListSorts(ctx context.Context, userID uuid.UUID, sorts map[string]string) error
+	GetHabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error)
+	SetHabitDayRollover(ctx context.Context, userID uuid.UUID, hour int) error
+	GetPlanDefaults(ctx context.Context, userID uuid.UUID) (map[string]string, error)
+	SetPlanDefaults(ctx context.Context, userID uuid.UUID, defaults map[string]string) error
+	GetDigest(ctx context.Context, userID uuid.UUID) (notifications.Digest, error)
+	SetDigest(ctx context.Context, digest notifications.Digest) error
+	ListDigests(ctx context.Context) ([]notifications.Digest, error)
+	MarkDigestSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error
 }
 
 // Service manages user settings.
@@ -43,3 +65,340 @@ func (s *Service) GetDeleteMissing(ctx context.Context, userID uuid.UUID) (bool,
 func (s *Service) SetDeleteMissing(ctx context.Context, userID uuid.UUID, deleteMissing bool) error {
 	return s.repo.SetDeleteMissing(ctx, userID, deleteMissing)
 }
+
+// GetNotificationChannel returns the channel notifications are delivered on
+// and the channel's target, such as an email address or webhook URL.
+func (s *Service) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	return s.repo.GetNotificationChannel(ctx, userID)
+}
+
+// SetNotificationChannel updates the notification channel for a user.
+func (s *Service) SetNotificationChannel(ctx context.Context, userID uuid.UUID, channel, target string) error {
+	return s.repo.SetNotificationChannel(ctx, userID, channel, target)
+}
+
+// GetDefaultDurations returns the default task duration in minutes per priority.
+func (s *Service) GetDefaultDurations(ctx context.Context, userID uuid.UUID) (map[string]int, error) {
+	return s.repo.GetDefaultDurations(ctx, userID)
+}
+
+// SetDefaultDuration sets the default duration in minutes for tasks of the
+// given priority. Zero clears it; negative values and values above
+// MaxDefaultDurationMinutes are rejected.
+func (s *Service) SetDefaultDuration(ctx context.Context, userID uuid.UUID, priority string, minutes int) error {
+	if _, err := validate(durationKeyPrefix+priority, strconv.Itoa(minutes)); err != nil {
+		return err
+	}
+	durations, err := s.repo.GetDefaultDurations(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if durations == nil {
+		durations = map[string]int{}
+	}
+	if minutes > 0 {
+		durations[priority] = minutes
+	} else {
+		delete(durations, priority)
+	}
+	return s.repo.SetDefaultDurations(ctx, userID, durations)
+}
+
+// DefaultDuration returns the default duration for tasks of the given
+// priority, or zero if none is set.
+func (s *Service) DefaultDuration(ctx context.Context, userID uuid.UUID, priority string) (time.Duration, error) {
+	durations, err := s.repo.GetDefaultDurations(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(durations[priority]) * time.Minute, nil
+}
+
+// GetClassifierRules returns the user's inbox classifier rules, mapping a
+// keyword to the classification it implies.
+func (s *Service) GetClassifierRules(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	return s.repo.GetClassifierRules(ctx, userID)
+}
+
+// SetClassifierRule maps a keyword to a classification. Keywords match
+// case-insensitively, so they are stored in lower case. An empty
+// classification removes the rule.
+func (s *Service) SetClassifierRule(ctx context.Context, userID uuid.UUID, keyword, classification string) error {
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	if keyword == "" {
+		return errors.New("keyword is required")
+	}
+	rules, err := s.repo.GetClassifierRules(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if rules == nil {
+		rules = map[string]string{}
+	}
+	if classification != "" {
+		rules[keyword] = classification
+	} else {
+		delete(rules, keyword)
+	}
+	return s.repo.SetClassifierRules(ctx, userID, rules)
+}
+
+// SetListSort sets the default sort order of the entity's lists, such as
+// "priority:desc,due_date:asc" for "tasks". An empty order restores the
+// default.
+func (s *Service) SetListSort(ctx context.Context, userID uuid.UUID, entity, order string) error {
+	order, err := validate(sortKeyPrefix+entity, order)
+	if err != nil {
+		return err
+	}
+	sorts, err := s.repo.GetListSorts(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if sorts == nil {
+		sorts = map[string]string{}
+	}
+	if order != "" {
+		sorts[entity] = order
+	} else {
+		delete(sorts, entity)
+	}
+	return s.repo.SetListSorts(ctx, userID, sorts)
+}
+
+// ListSort returns the sort order of the entity's lists, or the default
+// when the user has not set one.
+func (s *Service) ListSort(ctx context.Context, userID uuid.UUID, entity string) (string, error) {
+	return s.Get(ctx, userID, sortKeyPrefix+entity)
+}
+
+// HabitDayRollover returns the hour after midnight at which the user's
+// habit days end, so that late-night completions count for the day before.
+func (s *Service) HabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.repo.GetHabitDayRollover(ctx, userID)
+}
+
+// SetHabitDayRollover sets the hour after midnight at which the user's habit
+// days end. Zero ends them at midnight.
+func (s *Service) SetHabitDayRollover(ctx context.Context, userID uuid.UUID, hour int) error {
+	if _, err := validate(KeyHabitRollover, strconv.Itoa(hour)); err != nil {
+		return err
+	}
+	return s.repo.SetHabitDayRollover(ctx, userID, hour)
+}
+
+// PlanDefaults are how a user plans a day when no options are given.
+type PlanDefaults struct {
+	// DayOffset is how many days ahead of today the plan is for.
+	DayOffset int
+	// MinSlotMinutes is the shortest free slot offered for scheduling.
+	MinSlotMinutes int
+	// AutoSchedule schedules the pending work instead of only listing it.
+	AutoSchedule bool
+}
+
+// DefaultPlanDefaults returns the planning defaults of a user who has not
+// set any.
+func DefaultPlanDefaults() PlanDefaults {
+	return decodePlanDefaults(nil)
+}
+
+// PlanDefaults returns the user's planning defaults, falling back to the
+// schema defaults for those they have not set.
+func (s *Service) PlanDefaults(ctx context.Context, userID uuid.UUID) (PlanDefaults, error) {
+	stored, err := s.repo.GetPlanDefaults(ctx, userID)
+	if err != nil {
+		return PlanDefaults{}, err
+	}
+	return decodePlanDefaults(stored), nil
+}
+
+// decodePlanDefaults reads the stored planning defaults, which are keyed
+// without the "plan." prefix.
+func decodePlanDefaults(stored map[string]string) PlanDefaults {
+	value := func(key string) string {
+		if v := stored[strings.TrimPrefix(key, planKeyPrefix)]; v != "" {
+			return v
+		}
+		return definitionDefault(key)
+	}
+
+	var defaults PlanDefaults
+	defaults.DayOffset, _ = strconv.Atoi(value(KeyPlanDayOffset))
+	defaults.MinSlotMinutes, _ = strconv.Atoi(value(KeyPlanMinSlot))
+	defaults.AutoSchedule, _ = strconv.ParseBool(value(KeyPlanAuto))
+	return defaults
+}
+
+// GetDigest returns the user's scheduled digest preference.
+func (s *Service) GetDigest(ctx context.Context, userID uuid.UUID) (notifications.Digest, error) {
+	return s.repo.GetDigest(ctx, userID)
+}
+
+// UserLocation returns the user's time zone, set with digest.timezone, or
+// nil when they have not set one.
+func (s *Service) UserLocation(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+	digest, err := s.repo.GetDigest(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return digest.Location(nil)
+}
+
+// SetDigest updates how often the user receives a digest, the local time of
+// day it is sent at in HH:MM form, and the IANA time zone that time is in.
+// An empty time zone uses the server's.
+func (s *Service) SetDigest(ctx context.Context, userID uuid.UUID, frequency, at, timezone string) error {
+	parsed, err := notifications.ParseDigestFrequency(frequency)
+	if err != nil {
+		return err
+	}
+	hour, minute, err := notifications.ParseDigestTime(at)
+	if err != nil {
+		return err
+	}
+	timezone, err = validate(KeyDigestTimezone, timezone)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SetDigest(ctx, notifications.Digest{
+		UserID:    userID,
+		Frequency: parsed,
+		Hour:      hour,
+		Minute:    minute,
+		Timezone:  timezone,
+	})
+}
+
+// ListDigests returns every digest preference that is turned on.
+func (s *Service) ListDigests(ctx context.Context) ([]notifications.Digest, error) {
+	return s.repo.ListDigests(ctx)
+}
+
+// MarkDigestSent records when the user's digest was delivered.
+func (s *Service) MarkDigestSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error {
+	return s.repo.MarkDigestSent(ctx, userID, sentAt)
+}
+
+// Get returns the value of a setting in the schema as a string, or its
+// default when the user has not set it.
+func (s *Service) Get(ctx context.Context, userID uuid.UUID, key string) (string, error) {
+	def, err := Lookup(key)
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	switch {
+	case def.Key == KeyCalendarID:
+		value, err = s.repo.GetCalendarID(ctx, userID)
+	case def.Key == KeyDeleteMissing:
+		var deleteMissing bool
+		deleteMissing, err = s.repo.GetDeleteMissing(ctx, userID)
+		value = strconv.FormatBool(deleteMissing)
+	case strings.HasPrefix(def.Key, "digest."):
+		var digest notifications.Digest
+		digest, err = s.repo.GetDigest(ctx, userID)
+		value = digestValue(def.Key, digest)
+	case strings.HasPrefix(def.Key, durationKeyPrefix):
+		var durations map[string]int
+		durations, err = s.repo.GetDefaultDurations(ctx, userID)
+		if minutes := durations[strings.TrimPrefix(def.Key, durationKeyPrefix)]; minutes > 0 {
+			value = strconv.Itoa(minutes)
+		}
+	case strings.HasPrefix(def.Key, sortKeyPrefix):
+		var sorts map[string]string
+		sorts, err = s.repo.GetListSorts(ctx, userID)
+		value = sorts[strings.TrimPrefix(def.Key, sortKeyPrefix)]
+	case def.Key == KeyHabitRollover:
+		var hour int
+		hour, err = s.repo.GetHabitDayRollover(ctx, userID)
+		value = strconv.Itoa(hour)
+	case strings.HasPrefix(def.Key, planKeyPrefix):
+		var defaults map[string]string
+		defaults, err = s.repo.GetPlanDefaults(ctx, userID)
+		value = defaults[strings.TrimPrefix(def.Key, planKeyPrefix)]
+	}
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		return def.Default, nil
+	}
+	return value, nil
+}
+
+// Set validates a value against the schema and stores it. Invalid values
+// return an error wrapping ErrInvalidSetting and are not stored.
+func (s *Service) Set(ctx context.Context, userID uuid.UUID, key, value string) error {
+	def, err := Lookup(key)
+	if err != nil {
+		return err
+	}
+	value, err = def.Validate(value)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case def.Key == KeyCalendarID:
+		return s.repo.SetCalendarID(ctx, userID, value)
+	case def.Key == KeyDeleteMissing:
+		return s.repo.SetDeleteMissing(ctx, userID, value == "true")
+	case strings.HasPrefix(def.Key, "digest."):
+		digest, err := s.repo.GetDigest(ctx, userID)
+		if err != nil {
+			return err
+		}
+		frequency, at, timezone := digest.Frequency.String(), digestValue(KeyDigestTime, digest), digest.Timezone
+		if at == "" {
+			at = definitionDefault(KeyDigestTime)
+		}
+		switch def.Key {
+		case KeyDigestFrequency:
+			frequency = value
+		case KeyDigestTime:
+			at = value
+		case KeyDigestTimezone:
+			timezone = value
+		}
+		return s.SetDigest(ctx, userID, frequency, at, timezone)
+	case strings.HasPrefix(def.Key, durationKeyPrefix):
+		minutes, _ := strconv.Atoi(value)
+		return s.SetDefaultDuration(ctx, userID, strings.TrimPrefix(def.Key, durationKeyPrefix), minutes)
+	case strings.HasPrefix(def.Key, sortKeyPrefix):
+		return s.SetListSort(ctx, userID, strings.TrimPrefix(def.Key, sortKeyPrefix), value)
+	case def.Key == KeyHabitRollover:
+		hour, _ := strconv.Atoi(value)
+		return s.SetHabitDayRollover(ctx, userID, hour)
+	case strings.HasPrefix(def.Key, planKeyPrefix):
+		defaults, err := s.repo.GetPlanDefaults(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if defaults == nil {
+			defaults = map[string]string{}
+		}
+		defaults[strings.TrimPrefix(def.Key, planKeyPrefix)] = value
+		return s.repo.SetPlanDefaults(ctx, userID, defaults)
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownSetting, key)
+}
+
+// digestValue returns the digest field a key refers to. The send time of a
+// digest that was never set up is empty so the default applies.
+func digestValue(key string, digest notifications.Digest) string {
+	switch key {
+	case KeyDigestFrequency:
+		return digest.Frequency.String()
+	case KeyDigestTime:
+		if !digest.Enabled() && digest.Hour == 0 && digest.Minute == 0 {
+			return ""
+		}
+		return digest.Time()
+	case KeyDigestTimezone:
+		return digest.Timezone
+	}
+	return ""
+}
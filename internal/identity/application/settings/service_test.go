@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,6 +16,13 @@ import (
 type mockRepository struct {
 	calendarIDs   map[uuid.UUID]string
 	deleteMissing map[uuid.UUID]bool
+	channels      map[uuid.UUID][2]string
+	durations     map[uuid.UUID]map[string]int
+	rules         map[uuid.UUID]map[string]string
+	sorts         map[uuid.UUID]map[string]string
+	rollovers     map[uuid.UUID]int
+	plans         map[uuid.UUID]map[string]string
+	digests       map[uuid.UUID]notifications.Digest
 	err           error
 }
 
@@ -21,6 +30,13 @@ func newMockRepository() *mockRepository {
 	return &mockRepository{
 		calendarIDs:   make(map[uuid.UUID]string),
 		deleteMissing: make(map[uuid.UUID]bool),
+		channels:      make(map[uuid.UUID][2]string),
+		durations:     make(map[uuid.UUID]map[string]int),
+		rules:         make(map[uuid.UUID]map[string]string),
+		sorts:         make(map[uuid.UUID]map[string]string),
+		rollovers:     make(map[uuid.UUID]int),
+		plans:         make(map[uuid.UUID]map[string]string),
+		digests:       make(map[uuid.UUID]notifications.Digest),
 	}
 }
 
@@ -54,6 +70,156 @@ func (m *mockRepository) SetDeleteMissing(ctx context.Context, userID uuid.UUID,
 	return nil
 }
 
+func (m *mockRepository) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	if m.err != nil {
+		return "", "", m.err
+	}
+	channel := m.channels[userID]
+	return channel[0], channel[1], nil
+}
+
+func (m *mockRepository) SetNotificationChannel(ctx context.Context, userID uuid.UUID, channel, target string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.channels[userID] = [2]string{channel, target}
+	return nil
+}
+
+func (m *mockRepository) GetDefaultDurations(ctx context.Context, userID uuid.UUID) (map[string]int, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	durations := map[string]int{}
+	for priority, minutes := range m.durations[userID] {
+		durations[priority] = minutes
+	}
+	return durations, nil
+}
+
+func (m *mockRepository) SetDefaultDurations(ctx context.Context, userID uuid.UUID, durations map[string]int) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.durations[userID] = durations
+	return nil
+}
+
+func (m *mockRepository) GetClassifierRules(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	rules := map[string]string{}
+	for keyword, classification := range m.rules[userID] {
+		rules[keyword] = classification
+	}
+	return rules, nil
+}
+
+func (m *mockRepository) SetClassifierRules(ctx context.Context, userID uuid.UUID, rules map[string]string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.rules[userID] = rules
+	return nil
+}
+
+func (m *mockRepository) GetListSorts(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	sorts := map[string]string{}
+	for entity, order := range m.sorts[userID] {
+		sorts[entity] = order
+	}
+	return sorts, nil
+}
+
+func (m *mockRepository) SetListSorts(ctx context.Context, userID uuid.UUID, sorts map[string]string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sorts[userID] = sorts
+	return nil
+}
+
+func (m *mockRepository) GetHabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.rollovers[userID], nil
+}
+
+func (m *mockRepository) SetHabitDayRollover(ctx context.Context, userID uuid.UUID, hour int) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.rollovers[userID] = hour
+	return nil
+}
+
+func (m *mockRepository) GetPlanDefaults(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	defaults := map[string]string{}
+	for key, value := range m.plans[userID] {
+		defaults[key] = value
+	}
+	return defaults, nil
+}
+
+func (m *mockRepository) SetPlanDefaults(ctx context.Context, userID uuid.UUID, defaults map[string]string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.plans[userID] = defaults
+	return nil
+}
+
+func (m *mockRepository) GetDigest(ctx context.Context, userID uuid.UUID) (notifications.Digest, error) {
+	if m.err != nil {
+		return notifications.Digest{}, m.err
+	}
+	digest, ok := m.digests[userID]
+	if !ok {
+		return notifications.Digest{UserID: userID}, nil
+	}
+	return digest, nil
+}
+
+func (m *mockRepository) SetDigest(ctx context.Context, digest notifications.Digest) error {
+	if m.err != nil {
+		return m.err
+	}
+	digest.LastSentAt = m.digests[digest.UserID].LastSentAt
+	m.digests[digest.UserID] = digest
+	return nil
+}
+
+func (m *mockRepository) ListDigests(ctx context.Context) ([]notifications.Digest, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var digests []notifications.Digest
+	for _, digest := range m.digests {
+		if digest.Enabled() {
+			digests = append(digests, digest)
+		}
+	}
+	return digests, nil
+}
+
+func (m *mockRepository) MarkDigestSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error {
+	if m.err != nil {
+		return m.err
+	}
+	digest := m.digests[userID]
+	digest.LastSentAt = &sentAt
+	m.digests[userID] = digest
+	return nil
+}
+
 func TestNewService(t *testing.T) {
 	repo := newMockRepository()
 	service := NewService(repo)
@@ -199,3 +365,265 @@ func TestService_MultipleUsers(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, del2)
 }
+
+func TestService_NotificationChannel(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	err := service.SetNotificationChannel(ctx, userID, "webhook", "https://example.com/hook")
+	require.NoError(t, err)
+
+	channel, target, err := service.GetNotificationChannel(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "webhook", channel)
+	assert.Equal(t, "https://example.com/hook", target)
+}
+
+func TestService_DefaultDurations(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	duration, err := service.DefaultDuration(ctx, userID, "high")
+	require.NoError(t, err)
+	assert.Zero(t, duration)
+
+	require.NoError(t, service.SetDefaultDuration(ctx, userID, "high", 90))
+	require.NoError(t, service.SetDefaultDuration(ctx, userID, "low", 15))
+
+	duration, err = service.DefaultDuration(ctx, userID, "high")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, duration)
+
+	// Zero clears only that priority
+	require.NoError(t, service.SetDefaultDuration(ctx, userID, "high", 0))
+	durations, err := service.GetDefaultDurations(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"low": 15}, durations)
+}
+
+func TestService_ListSort(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	order, err := service.ListSort(ctx, userID, "tasks")
+	require.NoError(t, err)
+	assert.Equal(t, "priority:desc,due_date:asc", order)
+
+	require.NoError(t, service.SetListSort(ctx, userID, "tasks", "due_date:asc,title"))
+	require.NoError(t, service.Set(ctx, userID, KeyHabitSort, "streak"))
+
+	order, err = service.ListSort(ctx, userID, "tasks")
+	require.NoError(t, err)
+	assert.Equal(t, "due_date:asc,title:desc", order)
+	order, err = service.Get(ctx, userID, KeyHabitSort)
+	require.NoError(t, err)
+	assert.Equal(t, "streak:desc", order)
+
+	err = service.SetListSort(ctx, userID, "tasks", "streak")
+	assert.ErrorIs(t, err, ErrInvalidSetting)
+
+	// An empty order restores only that list's default
+	require.NoError(t, service.SetListSort(ctx, userID, "tasks", ""))
+	assert.Equal(t, map[string]string{"habits": "streak:desc"}, repo.sorts[userID])
+}
+
+func TestService_HabitDayRollover(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	got, err := service.Get(ctx, userID, KeyHabitRollover)
+	require.NoError(t, err)
+	assert.Equal(t, "0", got)
+
+	require.NoError(t, service.Set(ctx, userID, KeyHabitRollover, "4"))
+	hour, err := service.HabitDayRollover(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, hour)
+
+	err = service.Set(ctx, userID, KeyHabitRollover, "13")
+	assert.ErrorIs(t, err, ErrInvalidSetting)
+	assert.ErrorIs(t, service.SetHabitDayRollover(ctx, userID, -1), ErrInvalidSetting)
+	assert.Equal(t, 4, repo.rollovers[userID])
+}
+
+func TestService_PlanDefaults(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	defaults, err := service.PlanDefaults(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, PlanDefaults{DayOffset: 1, MinSlotMinutes: 15}, defaults)
+	assert.Equal(t, defaults, DefaultPlanDefaults())
+
+	require.NoError(t, service.Set(ctx, userID, KeyPlanDayOffset, "0"))
+	require.NoError(t, service.Set(ctx, userID, KeyPlanMinSlot, "45"))
+	require.NoError(t, service.Set(ctx, userID, KeyPlanAuto, "true"))
+	defaults, err = service.PlanDefaults(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, PlanDefaults{DayOffset: 0, MinSlotMinutes: 45, AutoSchedule: true}, defaults)
+
+	got, err := service.Get(ctx, userID, KeyPlanMinSlot)
+	require.NoError(t, err)
+	assert.Equal(t, "45", got)
+
+	assert.ErrorIs(t, service.Set(ctx, userID, KeyPlanMinSlot, "1"), ErrInvalidSetting)
+	assert.ErrorIs(t, service.Set(ctx, userID, KeyPlanDayOffset, "15"), ErrInvalidSetting)
+	assert.Equal(t, "45", repo.plans[userID]["min_slot_minutes"])
+}
+
+func TestService_ClassifierRules(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	require.NoError(t, service.SetClassifierRule(ctx, userID, "  Standup ", "habit"))
+	require.NoError(t, service.SetClassifierRule(ctx, userID, "sync", "meeting"))
+
+	rules, err := service.GetClassifierRules(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"standup": "habit", "sync": "meeting"}, rules)
+
+	// An empty classification removes only that rule
+	require.NoError(t, service.SetClassifierRule(ctx, userID, "STANDUP", ""))
+	rules, err = service.GetClassifierRules(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"sync": "meeting"}, rules)
+
+	assert.Error(t, service.SetClassifierRule(ctx, userID, " ", "task"))
+}
+
+func TestService_Digest(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	digest, err := service.GetDigest(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, digest.Enabled())
+
+	require.NoError(t, service.SetDigest(ctx, userID, "Daily", "07:30", "Europe/Berlin"))
+	digest, err = service.GetDigest(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, notifications.DigestDaily, digest.Frequency)
+	assert.Equal(t, "07:30", digest.Time())
+	assert.Equal(t, "Europe/Berlin", digest.Timezone)
+
+	digests, err := service.ListDigests(ctx)
+	require.NoError(t, err)
+	assert.Len(t, digests, 1)
+
+	require.NoError(t, service.SetDigest(ctx, userID, "off", "07:30", ""))
+	digests, err = service.ListDigests(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, digests)
+}
+
+func TestService_SetDigest_Invalid(t *testing.T) {
+	service := NewService(newMockRepository())
+	ctx := context.Background()
+	userID := uuid.New()
+
+	err := service.SetDigest(ctx, userID, "hourly", "07:30", "")
+	assert.ErrorIs(t, err, notifications.ErrUnknownDigestFrequency)
+
+	err = service.SetDigest(ctx, userID, "daily", "7pm", "")
+	assert.ErrorIs(t, err, notifications.ErrInvalidDigestTime)
+
+	err = service.SetDigest(ctx, userID, "daily", "07:30", "Mars/Olympus_Mons")
+	assert.ErrorContains(t, err, "unknown time zone")
+}
+
+func TestService_Get_Defaults(t *testing.T) {
+	service := NewService(newMockRepository())
+	ctx := context.Background()
+	userID := uuid.New()
+
+	for key, want := range map[string]string{
+		KeyCalendarID:      "primary",
+		KeyDeleteMissing:   "false",
+		KeyDigestFrequency: "off",
+		KeyDigestTime:      "08:00",
+		KeyDigestTimezone:  "",
+		"durations.high":   "0",
+	} {
+		got, err := service.Get(ctx, userID, key)
+		require.NoError(t, err, key)
+		assert.Equal(t, want, got, key)
+	}
+
+	_, err := service.Get(ctx, userID, "theme")
+	assert.ErrorIs(t, err, ErrUnknownSetting)
+}
+
+func TestService_Set(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	require.NoError(t, service.Set(ctx, userID, KeyCalendarID, " work "))
+	require.NoError(t, service.Set(ctx, userID, KeyDeleteMissing, "true"))
+	require.NoError(t, service.Set(ctx, userID, KeyDigestTimezone, "Europe/Berlin"))
+	require.NoError(t, service.Set(ctx, userID, KeyDigestFrequency, "daily"))
+	require.NoError(t, service.Set(ctx, userID, "durations.medium", "45"))
+
+	assert.Equal(t, "work", repo.calendarIDs[userID])
+	assert.True(t, repo.deleteMissing[userID])
+	assert.Equal(t, 45, repo.durations[userID]["medium"])
+
+	digest := repo.digests[userID]
+	assert.Equal(t, notifications.DigestDaily, digest.Frequency)
+	assert.Equal(t, "08:00", digest.Time())
+	assert.Equal(t, "Europe/Berlin", digest.Timezone)
+
+	got, err := service.Get(ctx, userID, KeyDigestTime)
+	require.NoError(t, err)
+	assert.Equal(t, "08:00", got)
+}
+
+func TestService_Set_Invalid(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	err := service.Set(ctx, userID, KeyDigestTimezone, "Mars/Olympus_Mons")
+	assert.ErrorIs(t, err, ErrInvalidSetting)
+	assert.ErrorContains(t, err, "unknown time zone")
+
+	err = service.Set(ctx, userID, "durations.high", "-30")
+	assert.ErrorIs(t, err, ErrInvalidSetting)
+
+	err = service.Set(ctx, userID, "durations.high", "600")
+	assert.ErrorIs(t, err, ErrInvalidSetting)
+
+	err = service.Set(ctx, userID, "theme", "dark")
+	assert.ErrorIs(t, err, ErrUnknownSetting)
+
+	assert.Empty(t, repo.digests)
+	assert.Empty(t, repo.durations)
+}
+
+func TestService_SetDefaultDuration_OutOfBounds(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	assert.ErrorIs(t, service.SetDefaultDuration(ctx, userID, "high", -1), ErrInvalidSetting)
+	assert.ErrorIs(t, service.SetDefaultDuration(ctx, userID, "high", MaxDefaultDurationMinutes+1), ErrInvalidSetting)
+	assert.ErrorIs(t, service.SetDefaultDuration(ctx, userID, "critical", 30), ErrUnknownSetting)
+	assert.Empty(t, repo.durations)
+}
@@ -19,14 +19,14 @@ func NewOAuthTokenRepository(pool *pgxpool.Pool) *OAuthTokenRepository {
 	return &OAuthTokenRepository{pool: pool}
 }
 
-// Save upserts a token for a user/provider.
+// Save upserts a token for a user/provider/account.
 func (r *OAuthTokenRepository) Save(ctx context.Context, token oauth.StoredToken) error {
 	query := `
 		INSERT INTO oauth_tokens (
-			user_id, provider, access_token, refresh_token, token_type, expiry, scopes,
+			user_id, provider, account, access_token, refresh_token, token_type, expiry, scopes,
 			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
-		ON CONFLICT (user_id, provider) DO UPDATE SET
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		ON CONFLICT (user_id, provider, account) DO UPDATE SET
 			access_token = EXCLUDED.access_token,
 			refresh_token = EXCLUDED.refresh_token,
 			token_type = EXCLUDED.token_type,
@@ -37,6 +37,7 @@ func (r *OAuthTokenRepository) Save(ctx context.Context, token oauth.StoredToken
 	_, err := r.pool.Exec(ctx, query,
 		token.UserID,
 		token.Provider,
+		token.Account,
 		token.AccessToken,
 		token.RefreshToken,
 		token.TokenType,
@@ -46,21 +47,27 @@ func (r *OAuthTokenRepository) Save(ctx context.Context, token oauth.StoredToken
 	return err
 }
 
-// FindByUserAndProvider fetches a token for a user/provider.
+// FindByUserAndProvider fetches the default account's token for a user/provider.
 func (r *OAuthTokenRepository) FindByUserAndProvider(ctx context.Context, userID uuid.UUID, provider string) (*oauth.StoredToken, error) {
+	return r.FindByUserProviderAndAccount(ctx, userID, provider, "")
+}
+
+// FindByUserProviderAndAccount fetches a token for a user/provider/account.
+func (r *OAuthTokenRepository) FindByUserProviderAndAccount(ctx context.Context, userID uuid.UUID, provider, account string) (*oauth.StoredToken, error) {
 	query := `
-		SELECT user_id, provider, access_token, refresh_token, token_type, expiry, scopes,
+		SELECT user_id, provider, account, access_token, refresh_token, token_type, expiry, scopes,
 		       created_at, updated_at
 		FROM oauth_tokens
-		WHERE user_id = $1 AND provider = $2
+		WHERE user_id = $1 AND provider = $2 AND account = $3
 	`
 
 	var token oauth.StoredToken
 	var createdAt time.Time
 	var updatedAt time.Time
-	err := r.pool.QueryRow(ctx, query, userID, provider).Scan(
+	err := r.pool.QueryRow(ctx, query, userID, provider, account).Scan(
 		&token.UserID,
 		&token.Provider,
+		&token.Account,
 		&token.AccessToken,
 		&token.RefreshToken,
 		&token.TokenType,
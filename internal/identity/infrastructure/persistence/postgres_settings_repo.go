@@ -2,7 +2,10 @@ package persistence
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -81,3 +84,364 @@ func (r *SettingsRepository) SetDeleteMissing(ctx context.Context, userID uuid.U
 	_, err := r.pool.Exec(ctx, query, userID, deleteMissing)
 	return err
 }
+
+// GetNotificationChannel returns the stored notification channel and target.
+func (r *SettingsRepository) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	query := `
+		SELECT notification_channel, notification_target
+		FROM user_settings
+		WHERE user_id = $1
+	`
+
+	var channel, target string
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&channel, &target)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+	return channel, target, nil
+}
+
+// SetNotificationChannel upserts the notification channel and target.
+func (r *SettingsRepository) SetNotificationChannel(ctx context.Context, userID uuid.UUID, channel, target string) error {
+	query := `
+		INSERT INTO user_settings (user_id, notification_channel, notification_target, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			notification_channel = EXCLUDED.notification_channel,
+			notification_target = EXCLUDED.notification_target,
+			updated_at = NOW()
+	`
+	_, err := r.pool.Exec(ctx, query, userID, channel, target)
+	return err
+}
+
+// GetDefaultDurations returns the stored default task duration in minutes per priority.
+func (r *SettingsRepository) GetDefaultDurations(ctx context.Context, userID uuid.UUID) (map[string]int, error) {
+	query := `
+		SELECT default_durations
+		FROM user_settings
+		WHERE user_id = $1
+	`
+
+	var raw string
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&raw)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return map[string]int{}, nil
+		}
+		return nil, err
+	}
+	return decodeDefaultDurations(raw)
+}
+
+// SetDefaultDurations upserts the default task duration in minutes per priority.
+func (r *SettingsRepository) SetDefaultDurations(ctx context.Context, userID uuid.UUID, durations map[string]int) error {
+	raw, err := json.Marshal(durations)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO user_settings (user_id, default_durations, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			default_durations = EXCLUDED.default_durations,
+			updated_at = NOW()
+	`
+	_, err = r.pool.Exec(ctx, query, userID, string(raw))
+	return err
+}
+
+// GetClassifierRules returns the stored inbox classifier rules.
+func (r *SettingsRepository) GetClassifierRules(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	query := `
+		SELECT classifier_rules
+		FROM user_settings
+		WHERE user_id = $1
+	`
+
+	var raw string
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&raw)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return decodeClassifierRules(raw)
+}
+
+// SetClassifierRules upserts the inbox classifier rules.
+func (r *SettingsRepository) SetClassifierRules(ctx context.Context, userID uuid.UUID, rules map[string]string) error {
+	raw, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO user_settings (user_id, classifier_rules, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			classifier_rules = EXCLUDED.classifier_rules,
+			updated_at = NOW()
+	`
+	_, err = r.pool.Exec(ctx, query, userID, string(raw))
+	return err
+}
+
+// GetListSorts returns the stored default list sort order per entity.
+func (r *SettingsRepository) GetListSorts(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	query := `
+		SELECT list_sorts
+		FROM user_settings
+		WHERE user_id = $1
+	`
+
+	var raw string
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&raw)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return decodeListSorts(raw)
+}
+
+// SetListSorts upserts the default list sort order per entity.
+func (r *SettingsRepository) SetListSorts(ctx context.Context, userID uuid.UUID, sorts map[string]string) error {
+	raw, err := json.Marshal(sorts)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO user_settings (user_id, list_sorts, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			list_sorts = EXCLUDED.list_sorts,
+			updated_at = NOW()
+	`
+	_, err = r.pool.Exec(ctx, query, userID, string(raw))
+	return err
+}
+
+// GetHabitDayRollover returns the stored hour at which habit days end.
+func (r *SettingsRepository) GetHabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT habit_day_rollover
+		FROM user_settings
+		WHERE user_id = $1
+	`
+
+	var hour int
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&hour)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return hour, nil
+}
+
+// SetHabitDayRollover upserts the hour at which habit days end.
+func (r *SettingsRepository) SetHabitDayRollover(ctx context.Context, userID uuid.UUID, hour int) error {
+	query := `
+		INSERT INTO user_settings (user_id, habit_day_rollover, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			habit_day_rollover = EXCLUDED.habit_day_rollover,
+			updated_at = NOW()
+	`
+	_, err := r.pool.Exec(ctx, query, userID, hour)
+	return err
+}
+
+// GetPlanDefaults returns the stored planning defaults per setting.
+func (r *SettingsRepository) GetPlanDefaults(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	query := `
+		SELECT plan_defaults
+		FROM user_settings
+		WHERE user_id = $1
+	`
+
+	var raw string
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&raw)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return decodePlanDefaults(raw)
+}
+
+// SetPlanDefaults upserts the planning defaults per setting.
+func (r *SettingsRepository) SetPlanDefaults(ctx context.Context, userID uuid.UUID, defaults map[string]string) error {
+	raw, err := json.Marshal(defaults)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO user_settings (user_id, plan_defaults, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			plan_defaults = EXCLUDED.plan_defaults,
+			updated_at = NOW()
+	`
+	_, err = r.pool.Exec(ctx, query, userID, string(raw))
+	return err
+}
+
+// GetDigest returns the stored digest preference.
+func (r *SettingsRepository) GetDigest(ctx context.Context, userID uuid.UUID) (notifications.Digest, error) {
+	query := `
+		SELECT digest_frequency, digest_time, digest_timezone, digest_last_sent_at
+		FROM user_settings
+		WHERE user_id = $1
+	`
+
+	var frequency, at, timezone string
+	var lastSentAt *time.Time
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&frequency, &at, &timezone, &lastSentAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return notifications.Digest{UserID: userID}, nil
+		}
+		return notifications.Digest{}, err
+	}
+	return decodeDigest(userID, frequency, at, timezone, lastSentAt)
+}
+
+// SetDigest upserts the digest preference. The last delivery is kept.
+func (r *SettingsRepository) SetDigest(ctx context.Context, digest notifications.Digest) error {
+	query := `
+		INSERT INTO user_settings (user_id, digest_frequency, digest_time, digest_timezone, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			digest_frequency = EXCLUDED.digest_frequency,
+			digest_time = EXCLUDED.digest_time,
+			digest_timezone = EXCLUDED.digest_timezone,
+			updated_at = NOW()
+	`
+	_, err := r.pool.Exec(ctx, query, digest.UserID, string(digest.Frequency), digest.Time(), digest.Timezone)
+	return err
+}
+
+// ListDigests returns every user's digest preference that is turned on.
+func (r *SettingsRepository) ListDigests(ctx context.Context) ([]notifications.Digest, error) {
+	query := `
+		SELECT user_id, digest_frequency, digest_time, digest_timezone, digest_last_sent_at
+		FROM user_settings
+		WHERE digest_frequency <> ''
+		ORDER BY user_id
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var digests []notifications.Digest
+	for rows.Next() {
+		var userID uuid.UUID
+		var frequency, at, timezone string
+		var lastSentAt *time.Time
+		if err := rows.Scan(&userID, &frequency, &at, &timezone, &lastSentAt); err != nil {
+			return nil, err
+		}
+		digest, err := decodeDigest(userID, frequency, at, timezone, lastSentAt)
+		if err != nil {
+			return nil, err
+		}
+		digests = append(digests, digest)
+	}
+	return digests, rows.Err()
+}
+
+// MarkDigestSent records when a user's digest was delivered.
+func (r *SettingsRepository) MarkDigestSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error {
+	query := `
+		UPDATE user_settings
+		SET digest_last_sent_at = $2
+		WHERE user_id = $1
+	`
+	_, err := r.pool.Exec(ctx, query, userID, sentAt)
+	return err
+}
+
+// decodeDigest builds a digest preference from its stored columns. An empty
+// frequency means the digest is off.
+func decodeDigest(userID uuid.UUID, frequency, at, timezone string, lastSentAt *time.Time) (notifications.Digest, error) {
+	digest := notifications.Digest{UserID: userID, Timezone: timezone, LastSentAt: lastSentAt}
+
+	parsed, err := notifications.ParseDigestFrequency(frequency)
+	if err != nil {
+		return notifications.Digest{}, err
+	}
+	digest.Frequency = parsed
+	if at != "" {
+		if digest.Hour, digest.Minute, err = notifications.ParseDigestTime(at); err != nil {
+			return notifications.Digest{}, err
+		}
+	}
+	return digest, nil
+}
+
+// decodeDefaultDurations parses the stored default durations. An empty value
+// means none are set.
+func decodeDefaultDurations(raw string) (map[string]int, error) {
+	durations := map[string]int{}
+	if raw == "" {
+		return durations, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &durations); err != nil {
+		return nil, err
+	}
+	return durations, nil
+}
+
+// decodeClassifierRules parses the stored classifier rules. An empty value
+// means none are set.
+func decodeClassifierRules(raw string) (map[string]string, error) {
+	rules := map[string]string{}
+	if raw == "" {
+		return rules, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// decodeListSorts parses the stored list sort orders. An empty value means
+// none are set.
+func decodeListSorts(raw string) (map[string]string, error) {
+	sorts := map[string]string{}
+	if raw == "" {
+		return sorts, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &sorts); err != nil {
+		return nil, err
+	}
+	return sorts, nil
+}
+
+// decodePlanDefaults parses the stored planning defaults. An empty value
+// means none are set.
+func decodePlanDefaults(raw string) (map[string]string, error) {
+	defaults := map[string]string{}
+	if raw == "" {
+		return defaults, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &defaults); err != nil {
+		return nil, err
+	}
+	return defaults, nil
+}
@@ -3,10 +3,12 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
 	db "github.com/felixgeelhaar/orbita/db/generated/sqlite"
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
 	sharedPersistence "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/persistence"
 	"github.com/google/uuid"
 )
@@ -29,6 +31,18 @@ func (r *SQLiteSettingsRepository) getQuerier(ctx context.Context) *db.Queries {
 	return db.New(r.dbConn)
 }
 
+// getDB returns the raw database handle (transaction or connection) based on context.
+func (r *SQLiteSettingsRepository) getDB(ctx context.Context) interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+} {
+	if info, ok := sharedPersistence.SQLiteTxInfoFromContext(ctx); ok {
+		return info.Tx
+	}
+	return r.dbConn
+}
+
 // GetCalendarID returns the stored calendar ID for a user.
 func (r *SQLiteSettingsRepository) GetCalendarID(ctx context.Context, userID uuid.UUID) (string, error) {
 	queries := r.getQuerier(ctx)
@@ -78,3 +92,284 @@ func (r *SQLiteSettingsRepository) SetDeleteMissing(ctx context.Context, userID
 		UpdatedAt:     time.Now().Format(time.RFC3339),
 	})
 }
+
+// GetNotificationChannel returns the stored notification channel and target.
+func (r *SQLiteSettingsRepository) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	var channel, target string
+	err := r.getDB(ctx).QueryRowContext(ctx,
+		"SELECT notification_channel, notification_target FROM user_settings WHERE user_id = ?",
+		userID.String(),
+	).Scan(&channel, &target)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+	return channel, target, nil
+}
+
+// SetNotificationChannel upserts the notification channel and target.
+func (r *SQLiteSettingsRepository) SetNotificationChannel(ctx context.Context, userID uuid.UUID, channel, target string) error {
+	_, err := r.getDB(ctx).ExecContext(ctx, `
+		INSERT INTO user_settings (user_id, notification_channel, notification_target, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			notification_channel = excluded.notification_channel,
+			notification_target = excluded.notification_target,
+			updated_at = excluded.updated_at`,
+		userID.String(), channel, target, time.Now().Format(time.RFC3339),
+	)
+	return err
+}
+
+// GetDefaultDurations returns the stored default task duration in minutes per priority.
+func (r *SQLiteSettingsRepository) GetDefaultDurations(ctx context.Context, userID uuid.UUID) (map[string]int, error) {
+	var raw string
+	err := r.getDB(ctx).QueryRowContext(ctx,
+		"SELECT default_durations FROM user_settings WHERE user_id = ?",
+		userID.String(),
+	).Scan(&raw)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return map[string]int{}, nil
+		}
+		return nil, err
+	}
+	return decodeDefaultDurations(raw)
+}
+
+// SetDefaultDurations upserts the default task duration in minutes per priority.
+func (r *SQLiteSettingsRepository) SetDefaultDurations(ctx context.Context, userID uuid.UUID, durations map[string]int) error {
+	raw, err := json.Marshal(durations)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.getDB(ctx).ExecContext(ctx, `
+		INSERT INTO user_settings (user_id, default_durations, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			default_durations = excluded.default_durations,
+			updated_at = excluded.updated_at`,
+		userID.String(), string(raw), time.Now().Format(time.RFC3339),
+	)
+	return err
+}
+
+// GetClassifierRules returns the stored inbox classifier rules.
+func (r *SQLiteSettingsRepository) GetClassifierRules(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	var raw string
+	err := r.getDB(ctx).QueryRowContext(ctx,
+		"SELECT classifier_rules FROM user_settings WHERE user_id = ?",
+		userID.String(),
+	).Scan(&raw)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return decodeClassifierRules(raw)
+}
+
+// SetClassifierRules upserts the inbox classifier rules.
+func (r *SQLiteSettingsRepository) SetClassifierRules(ctx context.Context, userID uuid.UUID, rules map[string]string) error {
+	raw, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.getDB(ctx).ExecContext(ctx, `
+		INSERT INTO user_settings (user_id, classifier_rules, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			classifier_rules = excluded.classifier_rules,
+			updated_at = excluded.updated_at`,
+		userID.String(), string(raw), time.Now().Format(time.RFC3339),
+	)
+	return err
+}
+
+// GetListSorts returns the stored default list sort order per entity.
+func (r *SQLiteSettingsRepository) GetListSorts(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	var raw string
+	err := r.getDB(ctx).QueryRowContext(ctx,
+		"SELECT list_sorts FROM user_settings WHERE user_id = ?",
+		userID.String(),
+	).Scan(&raw)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return decodeListSorts(raw)
+}
+
+// SetListSorts upserts the default list sort order per entity.
+func (r *SQLiteSettingsRepository) SetListSorts(ctx context.Context, userID uuid.UUID, sorts map[string]string) error {
+	raw, err := json.Marshal(sorts)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.getDB(ctx).ExecContext(ctx, `
+		INSERT INTO user_settings (user_id, list_sorts, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			list_sorts = excluded.list_sorts,
+			updated_at = excluded.updated_at`,
+		userID.String(), string(raw), time.Now().Format(time.RFC3339),
+	)
+	return err
+}
+
+// GetHabitDayRollover returns the stored hour at which habit days end.
+func (r *SQLiteSettingsRepository) GetHabitDayRollover(ctx context.Context, userID uuid.UUID) (int, error) {
+	var hour int
+	err := r.getDB(ctx).QueryRowContext(ctx,
+		"SELECT habit_day_rollover FROM user_settings WHERE user_id = ?",
+		userID.String(),
+	).Scan(&hour)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return hour, nil
+}
+
+// SetHabitDayRollover upserts the hour at which habit days end.
+func (r *SQLiteSettingsRepository) SetHabitDayRollover(ctx context.Context, userID uuid.UUID, hour int) error {
+	_, err := r.getDB(ctx).ExecContext(ctx, `
+		INSERT INTO user_settings (user_id, habit_day_rollover, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			habit_day_rollover = excluded.habit_day_rollover,
+			updated_at = excluded.updated_at`,
+		userID.String(), hour, time.Now().Format(time.RFC3339),
+	)
+	return err
+}
+
+// GetPlanDefaults returns the stored planning defaults per setting.
+func (r *SQLiteSettingsRepository) GetPlanDefaults(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	var raw string
+	err := r.getDB(ctx).QueryRowContext(ctx,
+		"SELECT plan_defaults FROM user_settings WHERE user_id = ?",
+		userID.String(),
+	).Scan(&raw)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return decodePlanDefaults(raw)
+}
+
+// SetPlanDefaults upserts the planning defaults per setting.
+func (r *SQLiteSettingsRepository) SetPlanDefaults(ctx context.Context, userID uuid.UUID, defaults map[string]string) error {
+	raw, err := json.Marshal(defaults)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.getDB(ctx).ExecContext(ctx, `
+		INSERT INTO user_settings (user_id, plan_defaults, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			plan_defaults = excluded.plan_defaults,
+			updated_at = excluded.updated_at`,
+		userID.String(), string(raw), time.Now().Format(time.RFC3339),
+	)
+	return err
+}
+
+// GetDigest returns the stored digest preference.
+func (r *SQLiteSettingsRepository) GetDigest(ctx context.Context, userID uuid.UUID) (notifications.Digest, error) {
+	var frequency, at, timezone string
+	var lastSentAt sql.NullString
+	err := r.getDB(ctx).QueryRowContext(ctx,
+		"SELECT digest_frequency, digest_time, digest_timezone, digest_last_sent_at FROM user_settings WHERE user_id = ?",
+		userID.String(),
+	).Scan(&frequency, &at, &timezone, &lastSentAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return notifications.Digest{UserID: userID}, nil
+		}
+		return notifications.Digest{}, err
+	}
+	return decodeSQLiteDigest(userID.String(), frequency, at, timezone, lastSentAt)
+}
+
+// SetDigest upserts the digest preference. The last delivery is kept.
+func (r *SQLiteSettingsRepository) SetDigest(ctx context.Context, digest notifications.Digest) error {
+	_, err := r.getDB(ctx).ExecContext(ctx, `
+		INSERT INTO user_settings (user_id, digest_frequency, digest_time, digest_timezone, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			digest_frequency = excluded.digest_frequency,
+			digest_time = excluded.digest_time,
+			digest_timezone = excluded.digest_timezone,
+			updated_at = excluded.updated_at`,
+		digest.UserID.String(), string(digest.Frequency), digest.Time(), digest.Timezone, time.Now().Format(time.RFC3339),
+	)
+	return err
+}
+
+// ListDigests returns every user's digest preference that is turned on.
+func (r *SQLiteSettingsRepository) ListDigests(ctx context.Context) ([]notifications.Digest, error) {
+	rows, err := r.getDB(ctx).QueryContext(ctx, `
+		SELECT user_id, digest_frequency, digest_time, digest_timezone, digest_last_sent_at
+		FROM user_settings
+		WHERE digest_frequency <> ''
+		ORDER BY user_id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var digests []notifications.Digest
+	for rows.Next() {
+		var userID, frequency, at, timezone string
+		var lastSentAt sql.NullString
+		if err := rows.Scan(&userID, &frequency, &at, &timezone, &lastSentAt); err != nil {
+			return nil, err
+		}
+		digest, err := decodeSQLiteDigest(userID, frequency, at, timezone, lastSentAt)
+		if err != nil {
+			return nil, err
+		}
+		digests = append(digests, digest)
+	}
+	return digests, rows.Err()
+}
+
+// MarkDigestSent records when a user's digest was delivered.
+func (r *SQLiteSettingsRepository) MarkDigestSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error {
+	_, err := r.getDB(ctx).ExecContext(ctx,
+		"UPDATE user_settings SET digest_last_sent_at = ? WHERE user_id = ?",
+		sentAt.UTC().Format(time.RFC3339), userID.String(),
+	)
+	return err
+}
+
+func decodeSQLiteDigest(userID, frequency, at, timezone string, lastSentAt sql.NullString) (notifications.Digest, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return notifications.Digest{}, err
+	}
+	var sentAt *time.Time
+	if lastSentAt.Valid && lastSentAt.String != "" {
+		parsed, err := time.Parse(time.RFC3339, lastSentAt.String)
+		if err != nil {
+			return notifications.Digest{}, err
+		}
+		sentAt = &parsed
+	}
+	return decodeDigest(id, frequency, at, timezone, sentAt)
+}
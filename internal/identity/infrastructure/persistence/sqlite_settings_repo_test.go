@@ -9,6 +9,7 @@ import (
 	"time"
 
 	db "github.com/felixgeelhaar/orbita/db/generated/sqlite"
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,12 +26,14 @@ func setupSettingsTestDB(t *testing.T) *sql.DB {
 	require.NoError(t, err)
 
 	// Read and execute the schema
-	schemaPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", "000001_initial_schema.up.sql")
-	schema, err := os.ReadFile(schemaPath)
-	require.NoError(t, err, "Failed to read SQLite schema file")
+	for _, name := range []string{"000001_initial_schema.up.sql", "000013_notification_channel.up.sql", "000015_default_durations.up.sql", "000018_digest_settings.up.sql", "000022_classifier_rules.up.sql", "000028_list_sorts.up.sql", "000031_habit_day_rollover.up.sql", "000032_plan_defaults.up.sql"} {
+		schemaPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", name)
+		schema, err := os.ReadFile(schemaPath)
+		require.NoError(t, err, "Failed to read SQLite schema file")
 
-	_, err = sqlDB.Exec(string(schema))
-	require.NoError(t, err, "Failed to apply SQLite schema")
+		_, err = sqlDB.Exec(string(schema))
+		require.NoError(t, err, "Failed to apply SQLite schema")
+	}
 
 	return sqlDB
 }
@@ -262,3 +265,225 @@ func TestSQLiteSettingsRepository_FullCycle(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, del)
 }
+
+func TestSQLiteSettingsRepository_NotificationChannel(t *testing.T) {
+	sqlDB := setupSettingsTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createSettingsTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteSettingsRepository(sqlDB)
+	ctx := context.Background()
+
+	// Not set
+	channel, target, err := repo.GetNotificationChannel(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, channel)
+	assert.Empty(t, target)
+
+	require.NoError(t, repo.SetCalendarID(ctx, userID, "work"))
+	require.NoError(t, repo.SetNotificationChannel(ctx, userID, "email", "me@example.com"))
+
+	channel, target, err = repo.GetNotificationChannel(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "email", channel)
+	assert.Equal(t, "me@example.com", target)
+
+	// Other settings are left alone
+	calendarID, err := repo.GetCalendarID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "work", calendarID)
+}
+
+func TestSQLiteSettingsRepository_DefaultDurations(t *testing.T) {
+	sqlDB := setupSettingsTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createSettingsTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteSettingsRepository(sqlDB)
+	ctx := context.Background()
+
+	// Not set
+	durations, err := repo.GetDefaultDurations(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, durations)
+
+	require.NoError(t, repo.SetNotificationChannel(ctx, userID, "desktop", ""))
+	require.NoError(t, repo.SetDefaultDurations(ctx, userID, map[string]int{"high": 60, "low": 15}))
+
+	durations, err = repo.GetDefaultDurations(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"high": 60, "low": 15}, durations)
+
+	// Other settings are left alone
+	channel, _, err := repo.GetNotificationChannel(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "desktop", channel)
+}
+
+func TestSQLiteSettingsRepository_ClassifierRules(t *testing.T) {
+	sqlDB := setupSettingsTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createSettingsTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteSettingsRepository(sqlDB)
+	ctx := context.Background()
+
+	// Not set
+	rules, err := repo.GetClassifierRules(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	require.NoError(t, repo.SetDefaultDurations(ctx, userID, map[string]int{"high": 60}))
+	require.NoError(t, repo.SetClassifierRules(ctx, userID, map[string]string{"standup": "meeting", "stretch": "habit"}))
+
+	rules, err = repo.GetClassifierRules(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"standup": "meeting", "stretch": "habit"}, rules)
+
+	// Other settings are left alone
+	durations, err := repo.GetDefaultDurations(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"high": 60}, durations)
+}
+
+func TestSQLiteSettingsRepository_ListSorts(t *testing.T) {
+	sqlDB := setupSettingsTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createSettingsTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteSettingsRepository(sqlDB)
+	ctx := context.Background()
+
+	// Not set
+	sorts, err := repo.GetListSorts(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, sorts)
+
+	require.NoError(t, repo.SetClassifierRules(ctx, userID, map[string]string{"standup": "meeting"}))
+	require.NoError(t, repo.SetListSorts(ctx, userID, map[string]string{"tasks": "due_date:asc,priority:desc"}))
+
+	sorts, err = repo.GetListSorts(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tasks": "due_date:asc,priority:desc"}, sorts)
+
+	// Other settings are left alone
+	rules, err := repo.GetClassifierRules(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"standup": "meeting"}, rules)
+}
+
+func TestSQLiteSettingsRepository_HabitDayRollover(t *testing.T) {
+	sqlDB := setupSettingsTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createSettingsTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteSettingsRepository(sqlDB)
+	ctx := context.Background()
+
+	// Not set
+	hour, err := repo.GetHabitDayRollover(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, hour)
+
+	require.NoError(t, repo.SetCalendarID(ctx, userID, "work"))
+	require.NoError(t, repo.SetHabitDayRollover(ctx, userID, 4))
+
+	hour, err = repo.GetHabitDayRollover(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, hour)
+
+	// Other settings are left alone
+	calendarID, err := repo.GetCalendarID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "work", calendarID)
+}
+
+func TestSQLiteSettingsRepository_PlanDefaults(t *testing.T) {
+	sqlDB := setupSettingsTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createSettingsTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteSettingsRepository(sqlDB)
+	ctx := context.Background()
+
+	// Not set
+	defaults, err := repo.GetPlanDefaults(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, defaults)
+
+	require.NoError(t, repo.SetHabitDayRollover(ctx, userID, 4))
+	require.NoError(t, repo.SetPlanDefaults(ctx, userID, map[string]string{"day_offset": "0", "auto_schedule": "true"}))
+
+	defaults, err = repo.GetPlanDefaults(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"day_offset": "0", "auto_schedule": "true"}, defaults)
+
+	// Other settings are left alone
+	hour, err := repo.GetHabitDayRollover(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, hour)
+}
+
+func TestSQLiteSettingsRepository_Digest(t *testing.T) {
+	sqlDB := setupSettingsTestDB(t)
+	defer sqlDB.Close()
+
+	alice, bob := uuid.New(), uuid.New()
+	createSettingsTestUser(t, sqlDB, alice)
+	createSettingsTestUser(t, sqlDB, bob)
+
+	repo := NewSQLiteSettingsRepository(sqlDB)
+	ctx := context.Background()
+
+	// Not set
+	digest, err := repo.GetDigest(ctx, alice)
+	require.NoError(t, err)
+	assert.False(t, digest.Enabled())
+
+	require.NoError(t, repo.SetCalendarID(ctx, bob, "work"))
+	require.NoError(t, repo.SetDigest(ctx, notifications.Digest{
+		UserID:    alice,
+		Frequency: notifications.DigestWeekly,
+		Hour:      18,
+		Minute:    5,
+		Timezone:  "Asia/Tokyo",
+	}))
+
+	digests, err := repo.ListDigests(ctx)
+	require.NoError(t, err)
+	require.Len(t, digests, 1, "users without a digest are not listed")
+	assert.Equal(t, alice, digests[0].UserID)
+	assert.Equal(t, notifications.DigestWeekly, digests[0].Frequency)
+	assert.Equal(t, "18:05", digests[0].Time())
+	assert.Equal(t, "Asia/Tokyo", digests[0].Timezone)
+	assert.Nil(t, digests[0].LastSentAt)
+
+	sentAt := time.Date(2024, time.March, 4, 9, 5, 0, 0, time.UTC)
+	require.NoError(t, repo.MarkDigestSent(ctx, alice, sentAt))
+
+	// Changing the preference keeps the last delivery
+	require.NoError(t, repo.SetDigest(ctx, notifications.Digest{
+		UserID:    alice,
+		Frequency: notifications.DigestDaily,
+		Hour:      7,
+	}))
+	digest, err = repo.GetDigest(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, notifications.DigestDaily, digest.Frequency)
+	assert.Equal(t, "07:00", digest.Time())
+	assert.Empty(t, digest.Timezone)
+	require.NotNil(t, digest.LastSentAt)
+	assert.True(t, sentAt.Equal(*digest.LastSentAt))
+}
@@ -16,12 +16,15 @@ type CaptureInboxItemCommand struct {
 	Content  string
 	Metadata domain.InboxMetadata
 	Tags     []string
-	Source   string
+	// Source is where the item was captured. Each entry point fills in its
+	// own name when the caller gives none.
+	Source string
 }
 
-// CaptureInboxItemResult returns the saved ID.
+// CaptureInboxItemResult returns the saved ID and the suggested classification.
 type CaptureInboxItemResult struct {
-	ItemID uuid.UUID
+	ItemID         uuid.UUID
+	Classification string
 }
 
 // CaptureInboxItemHandler persists inbox items.
@@ -42,7 +45,7 @@ func (h *CaptureInboxItemHandler) Handle(ctx context.Context, cmd CaptureInboxIt
 	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		now := time.Now().UTC()
 		itemID := uuid.New()
-		classification := h.classifier.Classify(cmd.Content, cmd.Metadata)
+		classification := h.classifier.ClassifyForUser(txCtx, cmd.UserID, cmd.Content, cmd.Metadata)
 
 		item := domain.InboxItem{
 			ID:             itemID,
@@ -50,7 +53,7 @@ func (h *CaptureInboxItemHandler) Handle(ctx context.Context, cmd CaptureInboxIt
 			Content:        cmd.Content,
 			Metadata:       cmd.Metadata,
 			Tags:           cmd.Tags,
-			Source:         cmd.Source,
+			Source:         domain.NormalizeSource(cmd.Source, ""),
 			Classification: classification,
 			CapturedAt:     now,
 		}
@@ -58,7 +61,7 @@ func (h *CaptureInboxItemHandler) Handle(ctx context.Context, cmd CaptureInboxIt
 		if err := h.repo.Save(txCtx, item); err != nil {
 			return err
 		}
-		result = &CaptureInboxItemResult{ItemID: itemID}
+		result = &CaptureInboxItemResult{ItemID: itemID, Classification: classification}
 		return nil
 	})
 	if err != nil {
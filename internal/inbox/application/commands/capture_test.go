@@ -62,3 +62,35 @@ func TestCaptureInboxItemHandler_Handle(t *testing.T) {
 	require.Contains(t, cmd.Tags, repo.saved.Tags[0])
 	require.NotZero(t, repo.saved.CapturedAt)
 }
+
+type stubClassifierRules map[string]string
+
+func (s stubClassifierRules) GetClassifierRules(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	return s, nil
+}
+
+func TestCaptureInboxItemHandler_Handle_UserRules(t *testing.T) {
+	repo := &stubInboxRepoForCapture{}
+	classifier := services.NewClassifier().WithRules(stubClassifierRules{"call": "task"})
+	handler := NewCaptureInboxItemHandler(repo, classifier, stubUnitOfWork{})
+
+	_, err := handler.Handle(context.Background(), CaptureInboxItemCommand{
+		UserID:  uuid.New(),
+		Content: "Call the plumber",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "task", repo.saved.Classification)
+}
+
+func TestCaptureInboxItemHandler_Handle_NormalizesSource(t *testing.T) {
+	repo := &stubInboxRepoForCapture{}
+	handler := NewCaptureInboxItemHandler(repo, services.NewClassifier(), stubUnitOfWork{})
+
+	_, err := handler.Handle(context.Background(), CaptureInboxItemCommand{
+		UserID:  uuid.New(),
+		Content: "Forwarded newsletter",
+		Source:  " Webhook ",
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.SourceWebhook, repo.saved.Source)
+}
@@ -54,6 +54,8 @@ func (h *GetInboxItemHandler) Handle(ctx context.Context, query GetInboxItemQuer
 		Promoted:       item.Promoted,
 		PromotedTo:     item.PromotedTo,
 		PromotedAt:     promotedAt,
+		Archived:       item.Archived,
+		Stale:          item.IsStale(),
 	}
 
 	return &dto, nil
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/inbox/domain"
@@ -12,6 +13,9 @@ import (
 type ListInboxItemsQuery struct {
 	UserID         uuid.UUID
 	IncludePromoted bool
+	// Source limits the list to items captured from one source. Empty lists
+	// items from every source.
+	Source string
 }
 
 // InboxItemDTO is view model.
@@ -25,6 +29,8 @@ type InboxItemDTO struct {
 	Promoted       bool
 	PromotedTo     string
 	PromotedAt     *string
+	Archived       bool
+	Stale          bool
 }
 
 // ListInboxItemsHandler returns items.
@@ -44,6 +50,10 @@ func (h *ListInboxItemsHandler) Handle(ctx context.Context, query ListInboxItems
 		return nil, err
 	}
 
+	if source := strings.TrimSpace(query.Source); source != "" {
+		items = filterBySource(items, source)
+	}
+
 	dtos := make([]InboxItemDTO, len(items))
 	for i, item := range items {
 		var promotedAt *string
@@ -61,7 +71,20 @@ func (h *ListInboxItemsHandler) Handle(ctx context.Context, query ListInboxItems
 			Promoted:       item.Promoted,
 			PromotedTo:     item.PromotedTo,
 			PromotedAt:     promotedAt,
+			Archived:       item.Archived,
+			Stale:          item.IsStale(),
 		}
 	}
 	return dtos, nil
 }
+
+// filterBySource keeps the items captured from source, ignoring case.
+func filterBySource(items []domain.InboxItem, source string) []domain.InboxItem {
+	filtered := make([]domain.InboxItem, 0, len(items))
+	for _, item := range items {
+		if strings.EqualFold(item.Source, source) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
@@ -192,6 +192,32 @@ func TestListInboxItemsHandler_Handle(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("filters by source", func(t *testing.T) {
+		repo := new(mockInboxRepo)
+		handler := NewListInboxItemsHandler(repo)
+
+		now := time.Now()
+		items := []domain.InboxItem{
+			{ID: uuid.New(), UserID: userID, Content: "From the terminal", Source: domain.SourceCLI, CapturedAt: now},
+			{ID: uuid.New(), UserID: userID, Content: "From a mail", Source: domain.SourceEmail, CapturedAt: now},
+			{ID: uuid.New(), UserID: userID, Content: "Also from a mail", Source: domain.SourceEmail, CapturedAt: now},
+		}
+
+		repo.On("ListByUser", mock.Anything, userID, false).Return(items, nil)
+
+		result, err := handler.Handle(context.Background(), ListInboxItemsQuery{
+			UserID: userID,
+			Source: "EMAIL",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, "From a mail", result[0].Content)
+		assert.Equal(t, "Also from a mail", result[1].Content)
+
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("fails when repository error", func(t *testing.T) {
 		repo := new(mockInboxRepo)
 		handler := NewListInboxItemsHandler(repo)
@@ -0,0 +1,233 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/inbox/domain"
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/google/uuid"
+)
+
+// DefaultExpiryInterval is the default interval between sweep cycles.
+const DefaultExpiryInterval = time.Hour
+
+// DefaultExpiryBatchSize is the maximum number of items loaded per query.
+const DefaultExpiryBatchSize = 100
+
+// DefaultExpiryMaxAge is how long items stay in the inbox unprocessed by default.
+const DefaultExpiryMaxAge = 14 * 24 * time.Hour
+
+// ExpirySweeperConfig configures the inbox expiry sweeper.
+type ExpirySweeperConfig struct {
+	Interval  time.Duration
+	BatchSize int
+	// MaxAge is how long an item may stay unprocessed before it expires.
+	MaxAge time.Duration
+	// Action is applied to expired items.
+	Action domain.ExpiryAction
+}
+
+// DefaultExpirySweeperConfig returns the default configuration.
+func DefaultExpirySweeperConfig() ExpirySweeperConfig {
+	return ExpirySweeperConfig{
+		Interval:  DefaultExpiryInterval,
+		BatchSize: DefaultExpiryBatchSize,
+		MaxAge:    DefaultExpiryMaxAge,
+		Action:    domain.ExpiryArchive,
+	}
+}
+
+// ExpirySweeper periodically archives, or flags as stale, inbox items that
+// were captured longer ago than the maximum age without being promoted, so
+// the inbox stays actionable. Users are told how many of their items expired.
+type ExpirySweeper struct {
+	repo     domain.ExpiryRepository
+	notifier notifications.Notifier
+	config   ExpirySweeperConfig
+	logger   *slog.Logger
+	running  atomic.Bool
+	stopCh   chan struct{}
+}
+
+// NewExpirySweeper creates a new inbox expiry sweeper.
+func NewExpirySweeper(repo domain.ExpiryRepository, config ExpirySweeperConfig, logger *slog.Logger) *ExpirySweeper {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultExpiryInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultExpiryBatchSize
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = DefaultExpiryMaxAge
+	}
+	if config.Action == "" {
+		config.Action = domain.ExpiryArchive
+	}
+	return &ExpirySweeper{
+		repo:   repo,
+		config: config,
+		logger: logger,
+		stopCh: make(chan struct{}),
+	}
+}
+
+// WithNotifier tells each user how many of their inbox items expired in a
+// sweep. A notification that fails or is held back is logged and dropped;
+// the items stay expired.
+func (s *ExpirySweeper) WithNotifier(notifier notifications.Notifier) *ExpirySweeper {
+	s.notifier = notifier
+	return s
+}
+
+// Run starts the sweeper and blocks until context is cancelled or Stop() is called.
+func (s *ExpirySweeper) Run(ctx context.Context) error {
+	s.running.Store(true)
+	s.logger.Info("inbox expiry sweeper started",
+		"interval", s.config.Interval,
+		"max_age", s.config.MaxAge,
+		"action", s.config.Action,
+	)
+
+	s.runCycle(ctx)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.running.Store(false)
+			s.logger.Info("inbox expiry sweeper stopped (context cancelled)")
+			return ctx.Err()
+		case <-s.stopCh:
+			s.running.Store(false)
+			s.logger.Info("inbox expiry sweeper stopped (stop signal)")
+			return nil
+		case <-ticker.C:
+			s.runCycle(ctx)
+		}
+	}
+}
+
+// Stop signals the sweeper to stop gracefully.
+func (s *ExpirySweeper) Stop() {
+	if s.running.Load() {
+		close(s.stopCh)
+	}
+}
+
+// IsRunning returns true if the sweeper is currently running.
+func (s *ExpirySweeper) IsRunning() bool {
+	return s.running.Load()
+}
+
+func (s *ExpirySweeper) runCycle(ctx context.Context) {
+	expired, err := s.Sweep(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("failed to expire inbox items", "error", err)
+		return
+	}
+	if expired > 0 {
+		s.logger.Info("inbox items expired", "count", expired, "action", s.config.Action)
+	}
+}
+
+// Sweep applies the expiry action to every unprocessed item captured at or
+// before now minus the maximum age and returns the number of items expired.
+// Items that fail to update are logged and left for the next cycle.
+func (s *ExpirySweeper) Sweep(ctx context.Context, now time.Time) (int, error) {
+	cutoff := now.Add(-s.config.MaxAge)
+	// Stale items are only picked up again when they should be archived.
+	includeStale := s.config.Action == domain.ExpiryArchive
+
+	perUser := make(map[uuid.UUID]int)
+	var users []uuid.UUID
+	expired := 0
+	defer func() { s.notify(ctx, users, perUser) }()
+
+	for {
+		items, err := s.repo.FindUnprocessedBefore(ctx, cutoff, includeStale, s.config.BatchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		batchExpired := 0
+		for _, item := range items {
+			if err := ctx.Err(); err != nil {
+				return expired, err
+			}
+
+			if err := s.expire(ctx, item, now); err != nil {
+				s.logger.Error("failed to expire inbox item",
+					"item_id", item.ID,
+					"error", err,
+				)
+				continue
+			}
+			if perUser[item.UserID] == 0 {
+				users = append(users, item.UserID)
+			}
+			perUser[item.UserID]++
+			batchExpired++
+		}
+		expired += batchExpired
+
+		// A short batch means nothing is left; a batch that expired nothing
+		// would only return the same items again.
+		if len(items) < s.config.BatchSize || batchExpired == 0 {
+			return expired, nil
+		}
+	}
+}
+
+func (s *ExpirySweeper) expire(ctx context.Context, item domain.InboxItem, now time.Time) error {
+	if s.config.Action == domain.ExpiryFlagStale {
+		return s.repo.MarkStale(ctx, item.ID, now)
+	}
+	return s.repo.MarkArchived(ctx, item.ID, now)
+}
+
+// notify tells each user how many of their items expired, if a notifier is set.
+func (s *ExpirySweeper) notify(ctx context.Context, users []uuid.UUID, perUser map[uuid.UUID]int) {
+	if s.notifier == nil {
+		return
+	}
+
+	age := s.config.MaxAge.String()
+	if days := int(s.config.MaxAge.Hours() / 24); days > 0 {
+		age = fmt.Sprintf("%d days", days)
+	}
+	for _, userID := range users {
+		count := perUser[userID]
+		noun := "items"
+		if count == 1 {
+			noun = "item"
+		}
+		body := fmt.Sprintf("Archived %d inbox %s older than %s.", count, noun, age)
+		if s.config.Action == domain.ExpiryFlagStale {
+			body = fmt.Sprintf("Flagged %d inbox %s older than %s as stale.", count, noun, age)
+		}
+
+		err := s.notifier.Send(ctx, notifications.NewNotification(userID, "Inbox cleanup", body, "low"))
+		if notifications.IsHeldBack(err) {
+			s.logger.Debug("inbox expiry notification held back",
+				"user_id", userID,
+				"reason", err,
+			)
+			continue
+		}
+		if err != nil {
+			s.logger.Error("failed to send inbox expiry notification",
+				"user_id", userID,
+				"error", err,
+			)
+		}
+	}
+}
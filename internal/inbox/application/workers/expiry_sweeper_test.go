@@ -0,0 +1,231 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/inbox/domain"
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubExpiryRepo filters its items the way the database query does.
+type stubExpiryRepo struct {
+	items   []*domain.InboxItem
+	findErr error
+	markErr error
+}
+
+func (s *stubExpiryRepo) FindUnprocessedBefore(ctx context.Context, before time.Time, includeStale bool, limit int) ([]domain.InboxItem, error) {
+	if s.findErr != nil {
+		return nil, s.findErr
+	}
+	sort.Slice(s.items, func(i, j int) bool { return s.items[i].CapturedAt.Before(s.items[j].CapturedAt) })
+
+	var result []domain.InboxItem
+	for _, item := range s.items {
+		if item.Promoted || item.Archived || item.CapturedAt.After(before) {
+			continue
+		}
+		if item.IsStale() && !includeStale {
+			continue
+		}
+		result = append(result, *item)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (s *stubExpiryRepo) find(id uuid.UUID) *domain.InboxItem {
+	for _, item := range s.items {
+		if item.ID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *stubExpiryRepo) MarkArchived(ctx context.Context, id uuid.UUID, archivedAt time.Time) error {
+	if s.markErr != nil {
+		return s.markErr
+	}
+	item := s.find(id)
+	item.Archived = true
+	item.ArchivedAt = &archivedAt
+	return nil
+}
+
+func (s *stubExpiryRepo) MarkStale(ctx context.Context, id uuid.UUID, staleAt time.Time) error {
+	if s.markErr != nil {
+		return s.markErr
+	}
+	s.find(id).StaleAt = &staleAt
+	return nil
+}
+
+type recordingNotifier struct {
+	sent []notifications.Notification
+	err  error
+}
+
+func (n *recordingNotifier) Send(ctx context.Context, notification notifications.Notification) error {
+	n.sent = append(n.sent, notification)
+	return n.err
+}
+
+func newInboxItem(userID uuid.UUID, capturedAt time.Time) *domain.InboxItem {
+	return &domain.InboxItem{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Content:    "Look into standing desks",
+		Source:     "cli",
+		CapturedAt: capturedAt,
+	}
+}
+
+func TestExpirySweeper_Sweep(t *testing.T) {
+	now := time.Date(2024, time.June, 30, 12, 0, 0, 0, time.UTC)
+	config := DefaultExpirySweeperConfig()
+	config.MaxAge = 14 * 24 * time.Hour
+
+	t.Run("archives items older than the max age", func(t *testing.T) {
+		userID := uuid.New()
+		expired := newInboxItem(userID, now.AddDate(0, 0, -20))
+		boundary := newInboxItem(userID, now.AddDate(0, 0, -14))
+		recent := newInboxItem(userID, now.AddDate(0, 0, -13))
+		promoted := newInboxItem(userID, now.AddDate(0, 0, -30))
+		promoted.Promoted = true
+
+		repo := &stubExpiryRepo{items: []*domain.InboxItem{expired, boundary, recent, promoted}}
+		notifier := &recordingNotifier{}
+		sweeper := NewExpirySweeper(repo, config, nil).WithNotifier(notifier)
+
+		count, err := sweeper.Sweep(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		assert.True(t, expired.Archived)
+		assert.Equal(t, now, *expired.ArchivedAt)
+		assert.True(t, boundary.Archived)
+		assert.False(t, recent.Archived)
+		assert.False(t, promoted.Archived)
+
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, userID, notifier.sent[0].UserID)
+		assert.Equal(t, "Archived 2 inbox items older than 14 days.", notifier.sent[0].Body)
+
+		// A day later the recent item expires too.
+		count, err = sweeper.Sweep(context.Background(), now.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.True(t, recent.Archived)
+	})
+
+	t.Run("flags items stale instead of archiving them", func(t *testing.T) {
+		userID := uuid.New()
+		expired := newInboxItem(userID, now.AddDate(0, 0, -20))
+		recent := newInboxItem(userID, now.AddDate(0, 0, -1))
+
+		repo := &stubExpiryRepo{items: []*domain.InboxItem{expired, recent}}
+		notifier := &recordingNotifier{}
+		flagging := config
+		flagging.Action = domain.ExpiryFlagStale
+		sweeper := NewExpirySweeper(repo, flagging, nil).WithNotifier(notifier)
+
+		count, err := sweeper.Sweep(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.True(t, expired.IsStale())
+		assert.Equal(t, now, *expired.StaleAt)
+		assert.False(t, expired.Archived)
+		assert.False(t, recent.IsStale())
+
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, "Flagged 1 inbox item older than 14 days as stale.", notifier.sent[0].Body)
+
+		// Items already flagged are not flagged or announced again.
+		count, err = sweeper.Sweep(context.Background(), now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, count)
+		assert.Equal(t, now, *expired.StaleAt)
+		assert.Len(t, notifier.sent, 1)
+	})
+
+	t.Run("archives items flagged stale earlier", func(t *testing.T) {
+		staleAt := now.AddDate(0, 0, -2)
+		stale := newInboxItem(uuid.New(), now.AddDate(0, 0, -20))
+		stale.StaleAt = &staleAt
+
+		repo := &stubExpiryRepo{items: []*domain.InboxItem{stale}}
+		count, err := NewExpirySweeper(repo, config, nil).Sweep(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.True(t, stale.Archived)
+	})
+
+	t.Run("works through more items than one batch and notifies each user once", func(t *testing.T) {
+		alice, bob := uuid.New(), uuid.New()
+		var items []*domain.InboxItem
+		for i := 0; i < 5; i++ {
+			items = append(items, newInboxItem(alice, now.AddDate(0, -3, i)))
+		}
+		items = append(items, newInboxItem(bob, now.AddDate(0, -2, 0)))
+
+		repo := &stubExpiryRepo{items: items}
+		notifier := &recordingNotifier{}
+		batched := config
+		batched.BatchSize = 2
+		sweeper := NewExpirySweeper(repo, batched, nil).WithNotifier(notifier)
+
+		count, err := sweeper.Sweep(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 6, count)
+
+		require.Len(t, notifier.sent, 2)
+		assert.Equal(t, alice, notifier.sent[0].UserID)
+		assert.Equal(t, "Archived 5 inbox items older than 14 days.", notifier.sent[0].Body)
+		assert.Equal(t, bob, notifier.sent[1].UserID)
+		assert.Equal(t, "Archived 1 inbox item older than 14 days.", notifier.sent[1].Body)
+	})
+
+	t.Run("leaves items that fail to update for the next cycle", func(t *testing.T) {
+		expired := newInboxItem(uuid.New(), now.AddDate(0, 0, -20))
+		repo := &stubExpiryRepo{items: []*domain.InboxItem{expired}, markErr: errors.New("disk full")}
+		notifier := &recordingNotifier{}
+		batched := config
+		batched.BatchSize = 1
+		sweeper := NewExpirySweeper(repo, batched, nil).WithNotifier(notifier)
+
+		count, err := sweeper.Sweep(context.Background(), now)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+		assert.False(t, expired.Archived)
+		assert.Empty(t, notifier.sent)
+	})
+
+	t.Run("keeps items expired when notifications fail", func(t *testing.T) {
+		expired := newInboxItem(uuid.New(), now.AddDate(0, 0, -20))
+		repo := &stubExpiryRepo{items: []*domain.InboxItem{expired}}
+		notifier := &recordingNotifier{err: notifications.ErrQuietHours}
+		sweeper := NewExpirySweeper(repo, config, nil).WithNotifier(notifier)
+
+		count, err := sweeper.Sweep(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.True(t, expired.Archived)
+	})
+
+	t.Run("returns query errors", func(t *testing.T) {
+		repo := &stubExpiryRepo{findErr: errors.New("db down")}
+
+		_, err := NewExpirySweeper(repo, config, nil).Sweep(context.Background(), now)
+		assert.EqualError(t, err, "db down")
+	})
+}
@@ -2,11 +2,33 @@ package domain
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Capture sources orbita sets itself. Callers may record any other source
+// name, such as the mail provider an item was forwarded from.
+const (
+	SourceCLI     = "cli"
+	SourceMCP     = "mcp"
+	SourceWebhook = "webhook"
+	SourceEmail   = "email"
+)
+
+// NormalizeSource returns source trimmed and lower-cased, or fallback when
+// source is blank.
+func NormalizeSource(source, fallback string) string {
+	source = strings.ToLower(strings.TrimSpace(source))
+	if source == "" {
+		return fallback
+	}
+	return source
+}
+
 // InboxMetadata is user-supplied metadata stored with the item.
 type InboxMetadata map[string]string
 
@@ -24,6 +46,52 @@ type InboxItem struct {
 	PromotedTo     string
 	PromotedID     uuid.UUID
 	PromotedAt     *time.Time
+	// Archived items were expired without being processed. They are hidden
+	// from the inbox like promoted items.
+	Archived   bool
+	ArchivedAt *time.Time
+	// StaleAt is set when an expired item was flagged stale instead of
+	// being archived.
+	StaleAt *time.Time
+}
+
+// IsStale reports whether the item was flagged stale by inbox expiry.
+func (i InboxItem) IsStale() bool {
+	return i.StaleAt != nil
+}
+
+// IsExpired reports whether the item is still unprocessed and was captured
+// at or before now minus maxAge. A non-positive maxAge never expires items.
+func (i InboxItem) IsExpired(now time.Time, maxAge time.Duration) bool {
+	if i.Promoted || i.Archived || maxAge <= 0 {
+		return false
+	}
+	return !i.CapturedAt.After(now.Add(-maxAge))
+}
+
+// ExpiryAction is what happens to an inbox item once it expires.
+type ExpiryAction string
+
+const (
+	// ExpiryArchive archives expired items, removing them from the inbox.
+	ExpiryArchive ExpiryAction = "archive"
+	// ExpiryFlagStale keeps expired items in the inbox but flags them stale.
+	ExpiryFlagStale ExpiryAction = "flag"
+)
+
+// ErrUnknownExpiryAction is returned when an expiry action is not recognized.
+var ErrUnknownExpiryAction = errors.New("unknown inbox expiry action")
+
+// ParseExpiryAction parses an expiry action. The empty string means archive.
+func ParseExpiryAction(value string) (ExpiryAction, error) {
+	switch ExpiryAction(strings.ToLower(strings.TrimSpace(value))) {
+	case "", ExpiryArchive:
+		return ExpiryArchive, nil
+	case ExpiryFlagStale, "stale":
+		return ExpiryFlagStale, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownExpiryAction, value)
+	}
 }
 
 // MarshalJSON ensures metadata is encoded as JSON.
@@ -3,6 +3,7 @@ package domain
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -96,3 +97,52 @@ func TestInboxMetadata_RoundTrip(t *testing.T) {
 		assert.Equal(t, original, restored)
 	})
 }
+
+func TestInboxItem_IsExpired(t *testing.T) {
+	now := time.Date(2024, time.June, 30, 12, 0, 0, 0, time.UTC)
+	maxAge := 14 * 24 * time.Hour
+	archivedAt := now.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name string
+		item InboxItem
+		want bool
+	}{
+		{"older than max age", InboxItem{CapturedAt: now.AddDate(0, 0, -20)}, true},
+		{"exactly max age", InboxItem{CapturedAt: now.Add(-maxAge)}, true},
+		{"younger than max age", InboxItem{CapturedAt: now.AddDate(0, 0, -13)}, false},
+		{"promoted", InboxItem{CapturedAt: now.AddDate(0, 0, -20), Promoted: true}, false},
+		{"archived", InboxItem{CapturedAt: now.AddDate(0, 0, -20), Archived: true, ArchivedAt: &archivedAt}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.item.IsExpired(now, maxAge))
+		})
+	}
+
+	assert.False(t, InboxItem{CapturedAt: now.AddDate(-1, 0, 0)}.IsExpired(now, 0), "zero max age never expires")
+}
+
+func TestParseExpiryAction(t *testing.T) {
+	for input, want := range map[string]ExpiryAction{
+		"":        ExpiryArchive,
+		"archive": ExpiryArchive,
+		" Flag ":  ExpiryFlagStale,
+		"stale":   ExpiryFlagStale,
+	} {
+		got, err := ParseExpiryAction(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseExpiryAction("delete")
+	assert.ErrorIs(t, err, ErrUnknownExpiryAction)
+}
+
+func TestNormalizeSource(t *testing.T) {
+	assert.Equal(t, SourceCLI, NormalizeSource("", SourceCLI))
+	assert.Equal(t, SourceCLI, NormalizeSource("   ", SourceCLI))
+	assert.Equal(t, "email", NormalizeSource(" Email ", SourceCLI))
+	assert.Equal(t, "gmail", NormalizeSource("gmail", SourceMCP))
+	assert.Equal(t, "", NormalizeSource("", ""))
+}
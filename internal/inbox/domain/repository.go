@@ -14,3 +14,23 @@ type InboxRepository interface {
 	FindByID(ctx context.Context, userID, id uuid.UUID) (*InboxItem, error)
 	MarkPromoted(ctx context.Context, id uuid.UUID, promotedTo string, promotedID uuid.UUID, promotedAt time.Time) error
 }
+
+// ExpiryRepository is implemented by inbox repositories that support
+// expiring unprocessed items.
+type ExpiryRepository interface {
+	// FindUnprocessedBefore returns up to limit items, across all users,
+	// captured at or before the given time that are neither promoted nor
+	// archived, oldest first. Items already flagged stale are left out
+	// unless includeStale is set.
+	FindUnprocessedBefore(ctx context.Context, before time.Time, includeStale bool, limit int) ([]InboxItem, error)
+	MarkArchived(ctx context.Context, id uuid.UUID, archivedAt time.Time) error
+	MarkStale(ctx context.Context, id uuid.UUID, staleAt time.Time) error
+}
+
+// SearchRepository finds a user's inbox items by full-text search.
+type SearchRepository interface {
+	// Search returns up to limit of the user's items whose content contains
+	// every word of query, most relevant first. Words match as prefixes,
+	// ignoring case.
+	Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]InboxItem, error)
+}
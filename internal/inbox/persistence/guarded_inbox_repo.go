@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/inbox/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+// GuardedInboxRepository wraps an inbox repository and rejects any call
+// that targets a user other than the authenticated principal in the context,
+// or that runs without a principal outside a system context.
+type GuardedInboxRepository struct {
+	inner domain.InboxRepository
+}
+
+// NewGuardedInboxRepository wraps inner with a principal check.
+func NewGuardedInboxRepository(inner domain.InboxRepository) *GuardedInboxRepository {
+	return &GuardedInboxRepository{inner: inner}
+}
+
+// Save persists an inbox item owned by the principal.
+func (r *GuardedInboxRepository) Save(ctx context.Context, item domain.InboxItem) error {
+	if err := sharedApplication.CheckPrincipal(ctx, item.UserID); err != nil {
+		return err
+	}
+	return r.inner.Save(ctx, item)
+}
+
+// ListByUser lists the principal's inbox items.
+func (r *GuardedInboxRepository) ListByUser(ctx context.Context, userID uuid.UUID, includePromoted bool) ([]domain.InboxItem, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.ListByUser(ctx, userID, includePromoted)
+}
+
+// FindByID finds one of the principal's inbox items.
+func (r *GuardedInboxRepository) FindByID(ctx context.Context, userID, id uuid.UUID) (*domain.InboxItem, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindByID(ctx, userID, id)
+}
+
+// MarkPromoted records the promotion of an item after checking that it
+// belongs to the principal. A system context marks the item unchecked.
+func (r *GuardedInboxRepository) MarkPromoted(ctx context.Context, id uuid.UUID, promotedTo string, promotedID uuid.UUID, promotedAt time.Time) error {
+	if err := sharedApplication.RequirePrincipal(ctx); err != nil {
+		return err
+	}
+	if principal, ok := sharedApplication.PrincipalFromContext(ctx); ok {
+		if _, err := r.inner.FindByID(ctx, principal, id); err != nil {
+			return err
+		}
+	}
+	return r.inner.MarkPromoted(ctx, id, promotedTo, promotedID, promotedAt)
+}
+
+// Search finds the principal's inbox items matching query. It returns
+// nothing when the wrapped repository cannot search.
+func (r *GuardedInboxRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]domain.InboxItem, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	searcher, ok := r.inner.(domain.SearchRepository)
+	if !ok {
+		return nil, nil
+	}
+	return searcher.Search(ctx, userID, query, limit)
+}
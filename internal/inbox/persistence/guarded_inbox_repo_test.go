@@ -0,0 +1,68 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/inbox/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardedInboxRepository(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	owner := uuid.New()
+	other := uuid.New()
+	createTestUser(t, sqlDB, owner)
+	createTestUser(t, sqlDB, other)
+
+	repo := NewGuardedInboxRepository(NewSQLiteInboxRepository(sqlDB))
+	ownerCtx := sharedApplication.WithPrincipal(context.Background(), owner)
+	otherCtx := sharedApplication.WithPrincipal(context.Background(), other)
+
+	item := domain.InboxItem{
+		ID:         uuid.New(),
+		UserID:     owner,
+		Content:    "Call the dentist",
+		Source:     "cli",
+		CapturedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, repo.Save(ownerCtx, item))
+
+	t.Run("owner can read their items", func(t *testing.T) {
+		items, err := repo.ListByUser(ownerCtx, owner, false)
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+	})
+
+	t.Run("rejects another user's items", func(t *testing.T) {
+		_, err := repo.FindByID(otherCtx, owner, item.ID)
+		assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+		assert.ErrorIs(t, repo.Save(otherCtx, item), sharedApplication.ErrPrincipalMismatch)
+
+		err = repo.MarkPromoted(otherCtx, item.ID, "task", uuid.New(), time.Now())
+		assert.Error(t, err)
+
+		found, err := repo.FindByID(ownerCtx, owner, item.ID)
+		require.NoError(t, err)
+		assert.False(t, found.Promoted)
+	})
+
+	t.Run("rejects a context without a principal", func(t *testing.T) {
+		_, err := repo.ListByUser(context.Background(), owner, false)
+		assert.ErrorIs(t, err, sharedApplication.ErrNoPrincipal)
+
+		err = repo.MarkPromoted(context.Background(), item.ID, "task", uuid.New(), time.Now())
+		assert.ErrorIs(t, err, sharedApplication.ErrNoPrincipal)
+	})
+
+	t.Run("owner can promote their items", func(t *testing.T) {
+		require.NoError(t, repo.MarkPromoted(ownerCtx, item.ID, "task", uuid.New(), time.Now()))
+	})
+}
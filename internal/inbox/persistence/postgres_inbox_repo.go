@@ -2,11 +2,14 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/inbox/domain"
-	"github.com/jackc/pgx/v5/pgxpool"
+	sharedPersistence "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/persistence"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // PostgresInboxRepository stores inbox items in Postgres.
@@ -36,19 +39,44 @@ func (r *PostgresInboxRepository) Save(ctx context.Context, item domain.InboxIte
 		item.Classification,
 		item.CapturedAt,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	return sharedPersistence.IndexPostgresSearchDocument(ctx, r.pool, itemSearchDocument(item))
+}
+
+// Search returns up to limit of the user's items matching query, most
+// relevant first.
+func (r *PostgresInboxRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]domain.InboxItem, error) {
+	ids, err := sharedPersistence.SearchPostgresIndex(ctx, r.pool, sharedPersistence.SearchEntityInbox, userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.InboxItem, 0, len(ids))
+	for _, id := range ids {
+		item, err := r.FindByID(ctx, userID, id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	return items, nil
 }
 
 // ListByUser returns a user's inbox items.
 func (r *PostgresInboxRepository) ListByUser(ctx context.Context, userID uuid.UUID, includePromoted bool) ([]domain.InboxItem, error) {
 	query := `
 		SELECT id, user_id, content, metadata, tags, source, classification, captured_at,
-		       promoted, promoted_to, promoted_id, promoted_at
+		       promoted, promoted_to, promoted_id, promoted_at, archived, archived_at, stale_at
 		FROM inbox_items
 		WHERE user_id = $1
 	`
 	if !includePromoted {
-		query += " AND promoted = false"
+		query += " AND promoted = false AND archived = false"
 	}
 	query += " ORDER BY captured_at DESC"
 
@@ -58,31 +86,87 @@ func (r *PostgresInboxRepository) ListByUser(ctx context.Context, userID uuid.UU
 	}
 	defer rows.Close()
 
+	return scanItems(rows)
+}
+
+// FindByID returns an inbox item.
+func (r *PostgresInboxRepository) FindByID(ctx context.Context, userID, id uuid.UUID) (*domain.InboxItem, error) {
+	query := `
+		SELECT id, user_id, content, metadata, tags, source, classification, captured_at,
+		       promoted, promoted_to, promoted_id, promoted_at, archived, archived_at, stale_at
+		FROM inbox_items
+		WHERE id = $1 AND user_id = $2
+	`
+	item, err := scanItem(r.pool.QueryRow(ctx, query, id, userID))
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// MarkPromoted marks an item promoted.
+func (r *PostgresInboxRepository) MarkPromoted(ctx context.Context, id uuid.UUID, promotedTo string, promotedID uuid.UUID, promotedAt time.Time) error {
+	query := `
+		UPDATE inbox_items
+		SET promoted = true, promoted_to = $2, promoted_id = $3, promoted_at = $4
+		WHERE id = $1
+	`
+	_, err := r.pool.Exec(ctx, query, id, promotedTo, promotedID, promotedAt)
+	return err
+}
+
+// FindUnprocessedBefore returns unprocessed items captured at or before the
+// given time, oldest first.
+func (r *PostgresInboxRepository) FindUnprocessedBefore(ctx context.Context, before time.Time, includeStale bool, limit int) ([]domain.InboxItem, error) {
+	query := `
+		SELECT id, user_id, content, metadata, tags, source, classification, captured_at,
+		       promoted, promoted_to, promoted_id, promoted_at, archived, archived_at, stale_at
+		FROM inbox_items
+		WHERE promoted = false AND archived = false AND captured_at <= $1
+	`
+	if !includeStale {
+		query += " AND stale_at IS NULL"
+	}
+	query += " ORDER BY captured_at ASC LIMIT $2"
+
+	rows, err := r.pool.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanItems(rows)
+}
+
+// MarkArchived archives an unprocessed item.
+func (r *PostgresInboxRepository) MarkArchived(ctx context.Context, id uuid.UUID, archivedAt time.Time) error {
+	query := `
+		UPDATE inbox_items
+		SET archived = true, archived_at = $2
+		WHERE id = $1 AND promoted = false
+	`
+	_, err := r.pool.Exec(ctx, query, id, archivedAt)
+	return err
+}
+
+// MarkStale flags an unprocessed item as stale.
+func (r *PostgresInboxRepository) MarkStale(ctx context.Context, id uuid.UUID, staleAt time.Time) error {
+	query := `
+		UPDATE inbox_items
+		SET stale_at = $2
+		WHERE id = $1 AND promoted = false AND archived = false
+	`
+	_, err := r.pool.Exec(ctx, query, id, staleAt)
+	return err
+}
+
+func scanItems(rows pgx.Rows) ([]domain.InboxItem, error) {
 	var items []domain.InboxItem
 	for rows.Next() {
-		var item domain.InboxItem
-		var metadata map[string]string
-		var tags []string
-		var promotedAt *time.Time
-		if err := rows.Scan(
-			&item.ID,
-			&item.UserID,
-			&item.Content,
-			&metadata,
-			&tags,
-			&item.Source,
-			&item.Classification,
-			&item.CapturedAt,
-			&item.Promoted,
-			&item.PromotedTo,
-			&item.PromotedID,
-			&promotedAt,
-		); err != nil {
+		item, err := scanItem(rows)
+		if err != nil {
 			return nil, err
 		}
-		item.Metadata = metadata
-		item.Tags = tags
-		item.PromotedAt = promotedAt
 		items = append(items, item)
 	}
 	if rows.Err() != nil {
@@ -91,19 +175,12 @@ func (r *PostgresInboxRepository) ListByUser(ctx context.Context, userID uuid.UU
 	return items, nil
 }
 
-// FindByID returns an inbox item.
-func (r *PostgresInboxRepository) FindByID(ctx context.Context, userID, id uuid.UUID) (*domain.InboxItem, error) {
-	query := `
-		SELECT id, user_id, content, metadata, tags, source, classification, captured_at,
-		       promoted, promoted_to, promoted_id, promoted_at
-		FROM inbox_items
-		WHERE id = $1 AND user_id = $2
-	`
+func scanItem(row pgx.Row) (domain.InboxItem, error) {
 	var item domain.InboxItem
 	var metadata map[string]string
 	var tags []string
 	var promotedAt *time.Time
-	err := r.pool.QueryRow(ctx, query, id, userID).Scan(
+	if err := row.Scan(
 		&item.ID,
 		&item.UserID,
 		&item.Content,
@@ -116,23 +193,14 @@ func (r *PostgresInboxRepository) FindByID(ctx context.Context, userID, id uuid.
 		&item.PromotedTo,
 		&item.PromotedID,
 		&promotedAt,
-	)
-	if err != nil {
-		return nil, err
+		&item.Archived,
+		&item.ArchivedAt,
+		&item.StaleAt,
+	); err != nil {
+		return item, err
 	}
 	item.Metadata = metadata
 	item.Tags = tags
 	item.PromotedAt = promotedAt
-	return &item, nil
-}
-
-// MarkPromoted marks an item promoted.
-func (r *PostgresInboxRepository) MarkPromoted(ctx context.Context, id uuid.UUID, promotedTo string, promotedID uuid.UUID, promotedAt time.Time) error {
-	query := `
-		UPDATE inbox_items
-		SET promoted = true, promoted_to = $2, promoted_id = $3, promoted_at = $4
-		WHERE id = $1
-	`
-	_, err := r.pool.Exec(ctx, query, id, promotedTo, promotedID, promotedAt)
-	return err
+	return item, nil
 }
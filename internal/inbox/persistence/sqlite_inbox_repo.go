@@ -65,7 +65,32 @@ func (r *SQLiteInboxRepository) Save(ctx context.Context, item domain.InboxItem)
 		item.Classification,
 		item.CapturedAt.Format(time.RFC3339),
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	return sharedPersistence.IndexSQLiteSearchDocument(ctx, exec, itemSearchDocument(item))
+}
+
+// Search returns up to limit of the user's items matching query, most
+// relevant first.
+func (r *SQLiteInboxRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]domain.InboxItem, error) {
+	ids, err := sharedPersistence.SearchSQLiteIndex(ctx, r.getExecer(ctx), sharedPersistence.SearchEntityInbox, userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.InboxItem, 0, len(ids))
+	for _, id := range ids {
+		item, err := r.FindByID(ctx, userID, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	return items, nil
 }
 
 // ListByUser returns a user's inbox items.
@@ -73,12 +98,12 @@ func (r *SQLiteInboxRepository) ListByUser(ctx context.Context, userID uuid.UUID
 	exec := r.getExecer(ctx)
 	query := `
 		SELECT id, user_id, content, metadata, tags, source, classification, captured_at,
-		       promoted, promoted_to, promoted_id, promoted_at
+		       promoted, promoted_to, promoted_id, promoted_at, archived, archived_at, stale_at
 		FROM inbox_items
 		WHERE user_id = ?
 	`
 	if !includePromoted {
-		query += " AND promoted = 0"
+		query += " AND promoted = 0 AND archived = 0"
 	}
 	query += " ORDER BY captured_at DESC"
 
@@ -107,7 +132,7 @@ func (r *SQLiteInboxRepository) FindByID(ctx context.Context, userID, id uuid.UU
 	exec := r.getExecer(ctx)
 	query := `
 		SELECT id, user_id, content, metadata, tags, source, classification, captured_at,
-		       promoted, promoted_to, promoted_id, promoted_at
+		       promoted, promoted_to, promoted_id, promoted_at, archived, archived_at, stale_at
 		FROM inbox_items
 		WHERE id = ? AND user_id = ?
 	`
@@ -134,14 +159,74 @@ func (r *SQLiteInboxRepository) MarkPromoted(ctx context.Context, id uuid.UUID,
 	return err
 }
 
+// FindUnprocessedBefore returns unprocessed items captured at or before the
+// given time, oldest first.
+func (r *SQLiteInboxRepository) FindUnprocessedBefore(ctx context.Context, before time.Time, includeStale bool, limit int) ([]domain.InboxItem, error) {
+	exec := r.getExecer(ctx)
+	query := `
+		SELECT id, user_id, content, metadata, tags, source, classification, captured_at,
+		       promoted, promoted_to, promoted_id, promoted_at, archived, archived_at, stale_at
+		FROM inbox_items
+		WHERE promoted = 0 AND archived = 0 AND captured_at <= ?
+	`
+	if !includeStale {
+		query += " AND stale_at IS NULL"
+	}
+	query += " ORDER BY captured_at ASC LIMIT ?"
+
+	rows, err := exec.QueryContext(ctx, query, before.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.InboxItem
+	for rows.Next() {
+		item, err := r.scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return items, nil
+}
+
+// MarkArchived archives an unprocessed item.
+func (r *SQLiteInboxRepository) MarkArchived(ctx context.Context, id uuid.UUID, archivedAt time.Time) error {
+	exec := r.getExecer(ctx)
+	query := `
+		UPDATE inbox_items
+		SET archived = 1, archived_at = ?
+		WHERE id = ? AND promoted = 0
+	`
+	_, err := exec.ExecContext(ctx, query, archivedAt.UTC().Format(time.RFC3339), id.String())
+	return err
+}
+
+// MarkStale flags an unprocessed item as stale.
+func (r *SQLiteInboxRepository) MarkStale(ctx context.Context, id uuid.UUID, staleAt time.Time) error {
+	exec := r.getExecer(ctx)
+	query := `
+		UPDATE inbox_items
+		SET stale_at = ?
+		WHERE id = ? AND promoted = 0 AND archived = 0
+	`
+	_, err := exec.ExecContext(ctx, query, staleAt.UTC().Format(time.RFC3339), id.String())
+	return err
+}
+
 // scanItem scans an inbox item from a rows result.
 func (r *SQLiteInboxRepository) scanItem(rows *sql.Rows) (domain.InboxItem, error) {
 	var item domain.InboxItem
 	var idStr, userIDStr string
 	var metadataStr, tagsStr string
 	var capturedAtStr string
-	var promoted int
+	var promoted, archived int
 	var promotedTo, promotedIDStr, promotedAtStr sql.NullString
+	var archivedAtStr, staleAtStr sql.NullString
 
 	err := rows.Scan(
 		&idStr,
@@ -156,6 +241,9 @@ func (r *SQLiteInboxRepository) scanItem(rows *sql.Rows) (domain.InboxItem, erro
 		&promotedTo,
 		&promotedIDStr,
 		&promotedAtStr,
+		&archived,
+		&archivedAtStr,
+		&staleAtStr,
 	)
 	if err != nil {
 		return item, err
@@ -205,6 +293,10 @@ func (r *SQLiteInboxRepository) scanItem(rows *sql.Rows) (domain.InboxItem, erro
 		}
 	}
 
+	item.Archived = archived == 1
+	item.ArchivedAt = parseOptionalTime(archivedAtStr)
+	item.StaleAt = parseOptionalTime(staleAtStr)
+
 	return item, nil
 }
 
@@ -214,8 +306,9 @@ func (r *SQLiteInboxRepository) scanItemRow(row *sql.Row) (domain.InboxItem, err
 	var idStr, userIDStr string
 	var metadataStr, tagsStr string
 	var capturedAtStr string
-	var promoted int
+	var promoted, archived int
 	var promotedTo, promotedIDStr, promotedAtStr sql.NullString
+	var archivedAtStr, staleAtStr sql.NullString
 
 	err := row.Scan(
 		&idStr,
@@ -230,6 +323,9 @@ func (r *SQLiteInboxRepository) scanItemRow(row *sql.Row) (domain.InboxItem, err
 		&promotedTo,
 		&promotedIDStr,
 		&promotedAtStr,
+		&archived,
+		&archivedAtStr,
+		&staleAtStr,
 	)
 	if err != nil {
 		return item, err
@@ -279,5 +375,31 @@ func (r *SQLiteInboxRepository) scanItemRow(row *sql.Row) (domain.InboxItem, err
 		}
 	}
 
+	item.Archived = archived == 1
+	item.ArchivedAt = parseOptionalTime(archivedAtStr)
+	item.StaleAt = parseOptionalTime(staleAtStr)
+
 	return item, nil
 }
+
+// parseOptionalTime parses a nullable RFC 3339 column, ignoring bad values.
+// itemSearchDocument returns the item's search index entry.
+func itemSearchDocument(item domain.InboxItem) sharedPersistence.SearchDocument {
+	return sharedPersistence.SearchDocument{
+		EntityType: sharedPersistence.SearchEntityInbox,
+		EntityID:   item.ID,
+		UserID:     item.UserID,
+		Title:      item.Content,
+	}
+}
+
+func parseOptionalTime(value sql.NullString) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value.String)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
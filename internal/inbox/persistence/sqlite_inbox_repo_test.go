@@ -26,12 +26,14 @@ func setupSQLiteTestDB(t *testing.T) *sql.DB {
 	require.NoError(t, err)
 
 	// Read and execute the schema
-	schemaPath := filepath.Join("..", "..", "..", "migrations", "sqlite", "000001_initial_schema.up.sql")
-	schema, err := os.ReadFile(schemaPath)
-	require.NoError(t, err, "Failed to read SQLite schema file")
+	for _, file := range []string{"000001_initial_schema.up.sql", "000016_inbox_expiry.up.sql", "000034_search_index.up.sql"} {
+		schemaPath := filepath.Join("..", "..", "..", "migrations", "sqlite", file)
+		schema, err := os.ReadFile(schemaPath)
+		require.NoError(t, err, "Failed to read SQLite schema file")
 
-	_, err = sqlDB.Exec(string(schema))
-	require.NoError(t, err, "Failed to apply SQLite schema")
+		_, err = sqlDB.Exec(string(schema))
+		require.NoError(t, err, "Failed to apply SQLite schema")
+	}
 
 	return sqlDB
 }
@@ -301,6 +303,80 @@ func TestSQLiteInboxRepository_MarkPromoted(t *testing.T) {
 	assert.Equal(t, promotedAt.Unix(), found.PromotedAt.Unix())
 }
 
+func TestSQLiteInboxRepository_Expiry(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteInboxRepository(sqlDB)
+	ctx := context.Background()
+	now := time.Date(2024, time.June, 30, 12, 0, 0, 0, time.UTC)
+
+	newItem := func(content string, capturedAt time.Time) domain.InboxItem {
+		item := domain.InboxItem{
+			ID:         uuid.New(),
+			UserID:     userID,
+			Content:    content,
+			Source:     "cli",
+			CapturedAt: capturedAt,
+		}
+		require.NoError(t, repo.Save(ctx, item))
+		return item
+	}
+	oldest := newItem("Oldest", now.AddDate(0, 0, -30))
+	old := newItem("Old", now.AddDate(0, 0, -20))
+	promoted := newItem("Promoted", now.AddDate(0, 0, -25))
+	recent := newItem("Recent", now.AddDate(0, 0, -1))
+	require.NoError(t, repo.MarkPromoted(ctx, promoted.ID, "task", uuid.New(), now))
+
+	cutoff := now.AddDate(0, 0, -14)
+	items, err := repo.FindUnprocessedBefore(ctx, cutoff, false, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, oldest.ID, items[0].ID, "oldest items come first")
+	assert.Equal(t, old.ID, items[1].ID)
+
+	// Flagging an item stale keeps it in the inbox.
+	require.NoError(t, repo.MarkStale(ctx, old.ID, now))
+	found, err := repo.FindByID(ctx, userID, old.ID)
+	require.NoError(t, err)
+	assert.True(t, found.IsStale())
+	assert.Equal(t, now, found.StaleAt.UTC())
+
+	items, err = repo.FindUnprocessedBefore(ctx, cutoff, false, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, oldest.ID, items[0].ID)
+
+	items, err = repo.FindUnprocessedBefore(ctx, cutoff, true, 10)
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	// Archiving hides the item from the inbox.
+	require.NoError(t, repo.MarkArchived(ctx, oldest.ID, now))
+	listed, err := repo.ListByUser(ctx, userID, false)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, recent.ID, listed[0].ID)
+	assert.Equal(t, old.ID, listed[1].ID)
+
+	found, err = repo.FindByID(ctx, userID, oldest.ID)
+	require.NoError(t, err)
+	assert.True(t, found.Archived)
+	require.NotNil(t, found.ArchivedAt)
+
+	items, err = repo.FindUnprocessedBefore(ctx, cutoff, true, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, old.ID, items[0].ID)
+
+	all, err := repo.ListByUser(ctx, userID, true)
+	require.NoError(t, err)
+	assert.Len(t, all, 4)
+}
+
 func TestSQLiteInboxRepository_WithMetadata(t *testing.T) {
 	sqlDB := setupSQLiteTestDB(t)
 	defer sqlDB.Close()
@@ -477,3 +553,33 @@ func TestSQLiteInboxRepository_AllClassifications(t *testing.T) {
 		})
 	}
 }
+
+func TestSQLiteInboxRepository_Search(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+	createTestUser(t, sqlDB, otherUserID)
+
+	repo := NewSQLiteInboxRepository(sqlDB)
+	ctx := context.Background()
+
+	idea := domain.InboxItem{ID: uuid.New(), UserID: userID, Content: "Look into Kubernetes autoscaling", Source: "cli", CapturedAt: time.Now()}
+	errand := domain.InboxItem{ID: uuid.New(), UserID: userID, Content: "Buy milk", Source: "cli", CapturedAt: time.Now()}
+	other := domain.InboxItem{ID: uuid.New(), UserID: otherUserID, Content: "kubernetes upgrade", Source: "cli", CapturedAt: time.Now()}
+	for _, item := range []domain.InboxItem{idea, errand, other} {
+		require.NoError(t, repo.Save(ctx, item))
+	}
+
+	found, err := repo.Search(ctx, userID, "KUBE auto", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, idea.ID, found[0].ID)
+	assert.Equal(t, "Look into Kubernetes autoscaling", found[0].Content)
+
+	found, err = repo.Search(ctx, userID, "kubernetes milk", 10)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
@@ -1,19 +1,49 @@
 package services
 
 import (
+	"context"
+	"sort"
 	"strings"
 
 	"github.com/felixgeelhaar/orbita/internal/inbox/domain"
+	"github.com/google/uuid"
 )
 
+// Classifications lists the labels the classifier can assign.
+var Classifications = []string{"task", "habit", "meeting"}
+
+// IsClassification reports whether label is a known classification.
+func IsClassification(label string) bool {
+	for _, known := range Classifications {
+		if label == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassifierRules provides a user's classification rules, mapping a keyword to
+// the classification it implies.
+type ClassifierRules interface {
+	GetClassifierRules(ctx context.Context, userID uuid.UUID) (map[string]string, error)
+}
+
 // Classifier determines a suggested classification from content/metadata.
-type Classifier struct{}
+type Classifier struct {
+	rules ClassifierRules
+}
 
 // NewClassifier returns a classifier instance.
 func NewClassifier() *Classifier {
 	return &Classifier{}
 }
 
+// WithRules layers the user's keyword rules on top of the built-in heuristics.
+func (c *Classifier) WithRules(rules ClassifierRules) *Classifier {
+	c.rules = rules
+	return c
+}
+
 // Classify returns classification label.
 func (c *Classifier) Classify(content string, metadata domain.InboxMetadata) string {
 	text := strings.ToLower(content)
@@ -31,3 +61,48 @@ func (c *Classifier) Classify(content string, metadata domain.InboxMetadata) str
 	}
 	return "task"
 }
+
+// ClassifyForUser returns the classification label, applying the user's rules
+// before the built-in heuristics. An explicit metadata type still wins. When
+// several rules match, the longest keyword does. A failed rule lookup falls
+// back to the built-in heuristics rather than failing the capture.
+func (c *Classifier) ClassifyForUser(ctx context.Context, userID uuid.UUID, content string, metadata domain.InboxMetadata) string {
+	if metadataType, ok := metadata["type"]; ok && IsClassification(metadataType) {
+		return metadataType
+	}
+	if c.rules != nil {
+		if rules, err := c.rules.GetClassifierRules(ctx, userID); err == nil {
+			if label, ok := matchRule(rules, content); ok {
+				return label
+			}
+		}
+	}
+	return c.Classify(content, metadata)
+}
+
+// matchRule returns the classification of the longest keyword found in the
+// content. Ties go to the alphabetically first keyword.
+func matchRule(rules map[string]string, content string) (string, bool) {
+	keywords := make([]string, 0, len(rules))
+	for keyword := range rules {
+		keywords = append(keywords, keyword)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if len(keywords[i]) != len(keywords[j]) {
+			return len(keywords[i]) > len(keywords[j])
+		}
+		return keywords[i] < keywords[j]
+	})
+
+	text := strings.ToLower(content)
+	for _, keyword := range keywords {
+		label := rules[keyword]
+		if keyword == "" || !IsClassification(label) {
+			continue
+		}
+		if strings.Contains(text, strings.ToLower(keyword)) {
+			return label, true
+		}
+	}
+	return "", false
+}
@@ -1,9 +1,12 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/felixgeelhaar/orbita/internal/inbox/domain"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -102,3 +105,83 @@ func TestClassifier_Classify(t *testing.T) {
 		})
 	}
 }
+
+type stubClassifierRules struct {
+	rules map[string]string
+	err   error
+}
+
+func (s stubClassifierRules) GetClassifierRules(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	return s.rules, s.err
+}
+
+func TestClassifier_ClassifyForUser(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	rules := stubClassifierRules{rules: map[string]string{
+		"call":       "task",
+		"call mom":   "habit",
+		"standup":    "meeting",
+		"renovation": "project",
+	}}
+	c := NewClassifier().WithRules(rules)
+
+	tests := []struct {
+		name     string
+		content  string
+		metadata domain.InboxMetadata
+		expected string
+	}{
+		{
+			name:     "user rule overrides default",
+			content:  "Call the plumber",
+			metadata: domain.InboxMetadata{},
+			expected: "task",
+		},
+		{
+			name:     "user rule matches case-insensitively",
+			content:  "Prepare STANDUP notes",
+			metadata: domain.InboxMetadata{},
+			expected: "meeting",
+		},
+		{
+			name:     "longest keyword wins",
+			content:  "call mom on sunday",
+			metadata: domain.InboxMetadata{},
+			expected: "habit",
+		},
+		{
+			name:     "falls back to defaults when no rule matches",
+			content:  "Daily stretching",
+			metadata: domain.InboxMetadata{},
+			expected: "habit",
+		},
+		{
+			name:     "rules with an unknown type are ignored",
+			content:  "Kitchen renovation quotes",
+			metadata: domain.InboxMetadata{},
+			expected: "task",
+		},
+		{
+			name:     "metadata type takes precedence over rules",
+			content:  "Call the plumber",
+			metadata: domain.InboxMetadata{"type": "meeting"},
+			expected: "meeting",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, c.ClassifyForUser(ctx, userID, tc.content, tc.metadata))
+		})
+	}
+
+	t.Run("without rules uses defaults", func(t *testing.T) {
+		assert.Equal(t, "meeting", NewClassifier().ClassifyForUser(ctx, userID, "Call the plumber", domain.InboxMetadata{}))
+	})
+
+	t.Run("failed rule lookup uses defaults", func(t *testing.T) {
+		failing := NewClassifier().WithRules(stubClassifierRules{err: errors.New("db down")})
+		assert.Equal(t, "meeting", failing.ClassifyForUser(ctx, userID, "Call the plumber", domain.InboxMetadata{}))
+	})
+}
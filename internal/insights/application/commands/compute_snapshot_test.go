@@ -105,6 +105,14 @@ func (m *mockDataSource) GetTimeByCategory(ctx context.Context, userID uuid.UUID
 	return args.Get(0).(map[string]int), args.Error(1)
 }
 
+func (m *mockDataSource) GetFocusStats(ctx context.Context, userID uuid.UUID, start, end time.Time) (*domain.FocusStats, error) {
+	args := m.Called(ctx, userID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusStats), args.Error(1)
+}
+
 func createCompletedFocusSession(userID uuid.UUID) *domain.TimeSession {
 	session := domain.NewTimeSession(userID, domain.SessionTypeFocus, "Focus")
 	_ = session.Complete()
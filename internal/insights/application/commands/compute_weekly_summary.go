@@ -6,13 +6,14 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/insights/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
 
 // ComputeWeeklySummaryCommand contains the data to compute a weekly summary.
 type ComputeWeeklySummaryCommand struct {
 	UserID    uuid.UUID
-	WeekStart time.Time // Must be a Monday
+	WeekStart time.Time // Any day of the week to summarize
 }
 
 // ComputeWeeklySummaryResult contains the computed summary.
@@ -21,6 +22,9 @@ type ComputeWeeklySummaryResult struct {
 	DaysWithData   int
 	ProductivityTrend string
 	IsComplete     bool
+	// FocusScores holds the focus score of each day of the week that had
+	// focus work scheduled.
+	FocusScores []domain.DailyFocusScore
 }
 
 // ComputeWeeklySummaryHandler handles weekly summary computation.
@@ -28,6 +32,8 @@ type ComputeWeeklySummaryHandler struct {
 	snapshotRepo domain.SnapshotRepository
 	summaryRepo  domain.SummaryRepository
 	sessionRepo  domain.SessionRepository
+	focusStats   domain.FocusStatsSource
+	weekStartsOn time.Weekday
 }
 
 // NewComputeWeeklySummaryHandler creates a new compute weekly summary handler.
@@ -40,14 +46,27 @@ func NewComputeWeeklySummaryHandler(
 		snapshotRepo: snapshotRepo,
 		summaryRepo:  summaryRepo,
 		sessionRepo:  sessionRepo,
+		weekStartsOn: sharedDomain.DefaultWeekStart,
 	}
 }
 
+// WithWeekStart sets the first day of the summarized weeks.
+func (h *ComputeWeeklySummaryHandler) WithWeekStart(day time.Weekday) *ComputeWeeklySummaryHandler {
+	h.weekStartsOn = day
+	return h
+}
+
+// WithFocusStats adds the week's focus score, computed from schedule data,
+// to the summary.
+func (h *ComputeWeeklySummaryHandler) WithFocusStats(source domain.FocusStatsSource) *ComputeWeeklySummaryHandler {
+	h.focusStats = source
+	return h
+}
+
 // Handle computes the weekly summary.
 func (h *ComputeWeeklySummaryHandler) Handle(ctx context.Context, cmd ComputeWeeklySummaryCommand) (*ComputeWeeklySummaryResult, error) {
-	// Normalize week start to Monday
-	weekStart := normalizeToMonday(cmd.WeekStart)
-	weekEnd := weekStart.AddDate(0, 0, 6) // Sunday
+	weekStart := sharedDomain.StartOfWeek(cmd.WeekStart, h.weekStartsOn)
+	weekEnd := weekStart.AddDate(0, 0, 6)
 
 	// Get all snapshots for the week
 	snapshots, err := h.snapshotRepo.GetDateRange(ctx, cmd.UserID, weekStart, weekEnd.Add(24*time.Hour))
@@ -56,7 +75,7 @@ func (h *ComputeWeeklySummaryHandler) Handle(ctx context.Context, cmd ComputeWee
 	}
 
 	// Create or update the summary
-	summary := domain.NewWeeklySummary(cmd.UserID, weekStart)
+	summary := domain.NewWeeklySummaryWithWeekStart(cmd.UserID, weekStart, h.weekStartsOn)
 
 	// Calculate totals
 	var totalTasks, totalHabits, totalBlocks, totalFocusMinutes int
@@ -126,6 +145,19 @@ func (h *ComputeWeeklySummaryHandler) Handle(ctx context.Context, cmd ComputeWee
 
 	summary.CalculateTrends(previousSummary)
 
+	var focusScores []domain.DailyFocusScore
+	if h.focusStats != nil {
+		focusScores, err = domain.ComputeDailyFocusScores(ctx, h.focusStats, cmd.UserID, weekStart, weekEnd.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute focus scores: %w", err)
+		}
+		previousScores, err := domain.ComputeDailyFocusScores(ctx, h.focusStats, cmd.UserID, previousWeekStart, weekStart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute focus scores: %w", err)
+		}
+		summary.SetFocusScore(domain.AverageFocusScore(focusScores), domain.AverageFocusScore(previousScores))
+	}
+
 	// Save the summary
 	if err := h.summaryRepo.Save(ctx, summary); err != nil {
 		return nil, fmt.Errorf("failed to save summary: %w", err)
@@ -140,20 +172,10 @@ func (h *ComputeWeeklySummaryHandler) Handle(ctx context.Context, cmd ComputeWee
 		DaysWithData:      daysWithData,
 		ProductivityTrend: summary.TrendDirection(),
 		IsComplete:        isComplete,
+		FocusScores:       focusScores,
 	}, nil
 }
 
-// normalizeToMonday returns the Monday of the week containing the given time.
-func normalizeToMonday(t time.Time) time.Time {
-	weekday := int(t.Weekday())
-	daysToSubtract := weekday - 1
-	if daysToSubtract < 0 {
-		daysToSubtract = 6
-	}
-	monday := t.AddDate(0, 0, -daysToSubtract)
-	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, monday.Location())
-}
-
 // ComputeCurrentWeekSummaryCommand is a convenience command to compute summary for the current week.
 type ComputeCurrentWeekSummaryCommand struct {
 	UserID uuid.UUID
@@ -175,6 +197,18 @@ func NewComputeCurrentWeekSummaryHandler(
 	}
 }
 
+// WithFocusStats adds the week's focus score to the summary.
+func (h *ComputeCurrentWeekSummaryHandler) WithFocusStats(source domain.FocusStatsSource) *ComputeCurrentWeekSummaryHandler {
+	h.handler.WithFocusStats(source)
+	return h
+}
+
+// WithWeekStart sets the first day of the summarized weeks.
+func (h *ComputeCurrentWeekSummaryHandler) WithWeekStart(day time.Weekday) *ComputeCurrentWeekSummaryHandler {
+	h.handler.WithWeekStart(day)
+	return h
+}
+
 // Handle computes the current week's summary.
 func (h *ComputeCurrentWeekSummaryHandler) Handle(ctx context.Context, cmd ComputeCurrentWeekSummaryCommand) (*ComputeWeeklySummaryResult, error) {
 	return h.handler.Handle(ctx, ComputeWeeklySummaryCommand{
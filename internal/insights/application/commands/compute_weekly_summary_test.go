@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/insights/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -64,7 +65,7 @@ func TestComputeWeeklySummaryHandler_Success(t *testing.T) {
 	sessionRepo := new(mockSessionRepo)
 
 	userID := uuid.New()
-	weekStart := normalizeToMonday(time.Now().AddDate(0, 0, -7))
+	weekStart := sharedDomain.StartOfWeek(time.Now().AddDate(0, 0, -7), time.Monday)
 
 	// Create week's worth of snapshots
 	snapshots := make([]*domain.ProductivitySnapshot, 7)
@@ -96,13 +97,43 @@ func TestComputeWeeklySummaryHandler_Success(t *testing.T) {
 	summaryRepo.AssertExpectations(t)
 }
 
+func TestComputeWeeklySummaryHandler_SundayWeekStart(t *testing.T) {
+	snapshotRepo := new(mockSnapshotRepo)
+	summaryRepo := new(mockSummaryRepo)
+	sessionRepo := new(mockSessionRepo)
+
+	userID := uuid.New()
+	// Wednesday, January 17, 2024 belongs to the week starting Sunday, January 14.
+	wednesday := time.Date(2024, 1, 17, 12, 0, 0, 0, time.UTC)
+	sunday := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)
+	nextSunday := time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)
+
+	snapshotRepo.On("GetDateRange", mock.Anything, userID, sunday, nextSunday).Return([]*domain.ProductivitySnapshot{}, nil)
+	summaryRepo.On("GetByWeek", mock.Anything, userID, sunday.AddDate(0, 0, -7)).Return(nil, nil)
+	summaryRepo.On("Save", mock.Anything, mock.AnythingOfType("*domain.WeeklySummary")).Return(nil)
+
+	handler := NewComputeWeeklySummaryHandler(snapshotRepo, summaryRepo, sessionRepo).WithWeekStart(time.Sunday)
+
+	result, err := handler.Handle(context.Background(), ComputeWeeklySummaryCommand{
+		UserID:    userID,
+		WeekStart: wednesday,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, sunday, result.Summary.WeekStart)
+	assert.Equal(t, time.Saturday, result.Summary.WeekEnd.Weekday())
+
+	snapshotRepo.AssertExpectations(t)
+	summaryRepo.AssertExpectations(t)
+}
+
 func TestComputeWeeklySummaryHandler_NoData(t *testing.T) {
 	snapshotRepo := new(mockSnapshotRepo)
 	summaryRepo := new(mockSummaryRepo)
 	sessionRepo := new(mockSessionRepo)
 
 	userID := uuid.New()
-	weekStart := normalizeToMonday(time.Now())
+	weekStart := sharedDomain.StartOfWeek(time.Now(), time.Monday)
 
 	snapshotRepo.On("GetDateRange", mock.Anything, userID, mock.Anything, mock.Anything).Return([]*domain.ProductivitySnapshot{}, nil)
 	summaryRepo.On("GetByWeek", mock.Anything, userID, mock.Anything).Return(nil, nil)
@@ -129,7 +160,7 @@ func TestComputeWeeklySummaryHandler_PartialWeek(t *testing.T) {
 	sessionRepo := new(mockSessionRepo)
 
 	userID := uuid.New()
-	weekStart := normalizeToMonday(time.Now().AddDate(0, 0, -14))
+	weekStart := sharedDomain.StartOfWeek(time.Now().AddDate(0, 0, -14), time.Monday)
 
 	// Only 3 days of data
 	snapshots := make([]*domain.ProductivitySnapshot, 3)
@@ -162,8 +193,8 @@ func TestComputeWeeklySummaryHandler_CalculatesTrends(t *testing.T) {
 	sessionRepo := new(mockSessionRepo)
 
 	userID := uuid.New()
-	currentWeekStart := normalizeToMonday(time.Now().AddDate(0, 0, -7))
-	previousWeekStart := normalizeToMonday(time.Now().AddDate(0, 0, -14))
+	currentWeekStart := sharedDomain.StartOfWeek(time.Now().AddDate(0, 0, -7), time.Monday)
+	previousWeekStart := sharedDomain.StartOfWeek(time.Now().AddDate(0, 0, -14), time.Monday)
 
 	// Previous week summary with lower score
 	previousSummary := domain.NewWeeklySummary(userID, previousWeekStart)
@@ -202,7 +233,7 @@ func TestComputeWeeklySummaryHandler_FindsBestWorstDays(t *testing.T) {
 	sessionRepo := new(mockSessionRepo)
 
 	userID := uuid.New()
-	weekStart := normalizeToMonday(time.Now().AddDate(0, 0, -7))
+	weekStart := sharedDomain.StartOfWeek(time.Now().AddDate(0, 0, -7), time.Monday)
 
 	// Create varied scores
 	scores := []int{50, 70, 90, 60, 80, 40, 75}
@@ -279,8 +310,46 @@ func TestNormalizeToMonday(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			result := normalizeToMonday(tc.input)
+			result := sharedDomain.StartOfWeek(tc.input, time.Monday)
 			assert.Equal(t, tc.expected, result.Weekday())
 		})
 	}
 }
+
+func TestComputeWeeklySummaryHandler_FocusScore(t *testing.T) {
+	snapshotRepo := new(mockSnapshotRepo)
+	summaryRepo := new(mockSummaryRepo)
+	sessionRepo := new(mockSessionRepo)
+	dataSource := new(mockDataSource)
+
+	userID := uuid.New()
+	weekStart := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	previousWeekStart := weekStart.AddDate(0, 0, -7)
+
+	clean := &domain.FocusStats{ScheduledMinutes: 120, CompletedMinutes: 120, BreaksScheduled: 2, BreaksTaken: 2}
+	disrupted := &domain.FocusStats{ScheduledMinutes: 120, CompletedMinutes: 60, Interruptions: 2, BreaksScheduled: 2}
+
+	snapshotRepo.On("GetDateRange", mock.Anything, userID, mock.Anything, mock.Anything).Return([]*domain.ProductivitySnapshot{}, nil)
+	summaryRepo.On("GetByWeek", mock.Anything, userID, previousWeekStart).Return(nil, nil)
+	summaryRepo.On("Save", mock.Anything, mock.AnythingOfType("*domain.WeeklySummary")).Return(nil)
+	// Monday is clean and Tuesday disrupted; the previous week only has a
+	// disrupted Monday.
+	dataSource.On("GetFocusStats", mock.Anything, userID, weekStart, mock.Anything).Return(clean, nil)
+	dataSource.On("GetFocusStats", mock.Anything, userID, weekStart.AddDate(0, 0, 1), mock.Anything).Return(disrupted, nil)
+	dataSource.On("GetFocusStats", mock.Anything, userID, previousWeekStart, mock.Anything).Return(disrupted, nil)
+	dataSource.On("GetFocusStats", mock.Anything, userID, mock.Anything, mock.Anything).Return(&domain.FocusStats{}, nil)
+
+	handler := NewComputeWeeklySummaryHandler(snapshotRepo, summaryRepo, sessionRepo).WithFocusStats(dataSource)
+
+	result, err := handler.Handle(context.Background(), ComputeWeeklySummaryCommand{
+		UserID:    userID,
+		WeekStart: weekStart,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.FocusScores, 2)
+	assert.Equal(t, 100, result.FocusScores[0].Score)
+	assert.Equal(t, 35, result.FocusScores[1].Score)
+	assert.Equal(t, 67.5, result.Summary.AvgFocusScore)
+	assert.InDelta(t, 92.86, result.Summary.FocusScoreTrend, 0.01) // 67.5 against 35
+}
@@ -2,8 +2,10 @@ package commands
 
 import (
 	"context"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/insights/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
 
@@ -17,19 +19,27 @@ type CreateGoalCommand struct {
 
 // CreateGoalHandler handles create goal commands.
 type CreateGoalHandler struct {
-	goalRepo domain.GoalRepository
+	goalRepo     domain.GoalRepository
+	weekStartsOn time.Weekday
 }
 
 // NewCreateGoalHandler creates a new create goal handler.
 func NewCreateGoalHandler(goalRepo domain.GoalRepository) *CreateGoalHandler {
 	return &CreateGoalHandler{
-		goalRepo: goalRepo,
+		goalRepo:     goalRepo,
+		weekStartsOn: sharedDomain.DefaultWeekStart,
 	}
 }
 
+// WithWeekStart sets the first day of weekly goal periods.
+func (h *CreateGoalHandler) WithWeekStart(day time.Weekday) *CreateGoalHandler {
+	h.weekStartsOn = day
+	return h
+}
+
 // Handle executes the create goal command.
 func (h *CreateGoalHandler) Handle(ctx context.Context, cmd CreateGoalCommand) (*domain.ProductivityGoal, error) {
-	goal, err := domain.NewProductivityGoal(cmd.UserID, cmd.GoalType, cmd.TargetValue, cmd.PeriodType)
+	goal, err := domain.NewProductivityGoalWithWeekStart(cmd.UserID, cmd.GoalType, cmd.TargetValue, cmd.PeriodType, h.weekStartsOn)
 	if err != nil {
 		return nil, err
 	}
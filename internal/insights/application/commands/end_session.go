@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/felixgeelhaar/orbita/internal/insights/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 )
 
@@ -60,4 +61,4 @@ func (h *EndSessionHandler) Handle(ctx context.Context, cmd EndSessionCommand) (
 }
 
 // ErrNoActiveSession indicates no active session was found.
-var ErrNoActiveSession = errors.New("no active session found")
+var ErrNoActiveSession = sharedApplication.NewNotFoundError("no active session found")
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/insights/domain"
+	"github.com/google/uuid"
+)
+
+// DefaultSnapshotMaxAge is how long a snapshot of a day that is still in
+// progress is served before it is recomputed.
+const DefaultSnapshotMaxAge = 15 * time.Minute
+
+// snapshotComputer computes and stores the snapshot for a single day.
+type snapshotComputer interface {
+	Handle(ctx context.Context, cmd ComputeSnapshotCommand) (*domain.ProductivitySnapshot, error)
+}
+
+// ChangeTracker reports when the data a day's snapshot is computed from last
+// changed, so edits backdated to a past day are noticed.
+type ChangeTracker interface {
+	DataChangedAt(ctx context.Context, userID uuid.UUID, start, end time.Time) (time.Time, error)
+}
+
+// RefreshSnapshotsCommand requests up-to-date snapshots for every day in an
+// inclusive date range.
+type RefreshSnapshotsCommand struct {
+	UserID uuid.UUID
+	Start  time.Time
+	End    time.Time
+}
+
+// RefreshSnapshotsResult contains the snapshots for the range, oldest first.
+type RefreshSnapshotsResult struct {
+	Snapshots  []*domain.ProductivitySnapshot
+	Reused     int
+	Recomputed int
+}
+
+// RefreshSnapshotsHandler serves daily stats from stored snapshots and only
+// recomputes the days that changed since they were last snapshotted.
+//
+// A snapshot computed after its day ended is reused until the day's data
+// changes, which is only noticed with a change tracker. The snapshot for a
+// day still in progress is reused until it is older than the max age.
+// Missing days are computed. Days after today are skipped.
+type RefreshSnapshotsHandler struct {
+	snapshotRepo domain.SnapshotRepository
+	computer     snapshotComputer
+	changes      ChangeTracker
+	maxAge       time.Duration
+	now          func() time.Time
+}
+
+// NewRefreshSnapshotsHandler creates a new refresh snapshots handler. A
+// non-positive maxAge uses DefaultSnapshotMaxAge.
+func NewRefreshSnapshotsHandler(
+	snapshotRepo domain.SnapshotRepository,
+	computer snapshotComputer,
+	maxAge time.Duration,
+) *RefreshSnapshotsHandler {
+	if maxAge <= 0 {
+		maxAge = DefaultSnapshotMaxAge
+	}
+	return &RefreshSnapshotsHandler{
+		snapshotRepo: snapshotRepo,
+		computer:     computer,
+		maxAge:       maxAge,
+		now:          time.Now,
+	}
+}
+
+// WithChangeTracker recomputes the snapshot of a past day when its data
+// changed after the snapshot was computed.
+func (h *RefreshSnapshotsHandler) WithChangeTracker(changes ChangeTracker) *RefreshSnapshotsHandler {
+	h.changes = changes
+	return h
+}
+
+// Handle executes the refresh snapshots command.
+func (h *RefreshSnapshotsHandler) Handle(ctx context.Context, cmd RefreshSnapshotsCommand) (*RefreshSnapshotsResult, error) {
+	start := startOfDay(cmd.Start)
+	end := startOfDay(cmd.End)
+	now := h.now()
+	if today := startOfDay(now.In(start.Location())); end.After(today) {
+		end = today
+	}
+
+	result := &RefreshSnapshotsResult{Snapshots: []*domain.ProductivitySnapshot{}}
+	if end.Before(start) {
+		return result, nil
+	}
+
+	existing, err := h.snapshotRepo.GetDateRange(ctx, cmd.UserID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	byDay := make(map[string]*domain.ProductivitySnapshot, len(existing))
+	for _, s := range existing {
+		byDay[s.SnapshotDate.Format("2006-01-02")] = s
+	}
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		snapshot := byDay[day.Format("2006-01-02")]
+		if snapshot != nil {
+			fresh, err := h.isFresh(ctx, cmd.UserID, snapshot, day, now)
+			if err != nil {
+				return nil, err
+			}
+			if fresh {
+				result.Snapshots = append(result.Snapshots, snapshot)
+				result.Reused++
+				continue
+			}
+		}
+
+		snapshot, err := h.computer.Handle(ctx, ComputeSnapshotCommand{UserID: cmd.UserID, Date: day})
+		if err != nil {
+			return nil, err
+		}
+		result.Snapshots = append(result.Snapshots, snapshot)
+		result.Recomputed++
+	}
+
+	return result, nil
+}
+
+// Refresh brings the snapshots in the range up to date.
+func (h *RefreshSnapshotsHandler) Refresh(ctx context.Context, userID uuid.UUID, start, end time.Time) error {
+	_, err := h.Handle(ctx, RefreshSnapshotsCommand{UserID: userID, Start: start, End: end})
+	return err
+}
+
+// isFresh reports whether a stored snapshot can be served for the given day.
+func (h *RefreshSnapshotsHandler) isFresh(ctx context.Context, userID uuid.UUID, snapshot *domain.ProductivitySnapshot, day, now time.Time) (bool, error) {
+	dayEnd := day.AddDate(0, 0, 1)
+	if snapshot.ComputedAt.Before(dayEnd) {
+		return now.Sub(snapshot.ComputedAt) < h.maxAge, nil
+	}
+	if h.changes == nil {
+		return true, nil
+	}
+
+	changedAt, err := h.changes.DataChangedAt(ctx, userID, day, dayEnd)
+	if err != nil {
+		return false, err
+	}
+	// Stored times may be truncated to the second, so a change within the
+	// second the snapshot was computed in also counts.
+	return changedAt.Before(snapshot.ComputedAt.Truncate(time.Second)), nil
+}
+
+// startOfDay truncates a time to midnight in its location.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
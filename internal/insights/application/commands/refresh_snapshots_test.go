@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/insights/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSnapshotComputer records the days it is asked to compute.
+type fakeSnapshotComputer struct {
+	computed []time.Time
+	err      error
+	now      time.Time
+}
+
+func (f *fakeSnapshotComputer) Handle(ctx context.Context, cmd ComputeSnapshotCommand) (*domain.ProductivitySnapshot, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.computed = append(f.computed, cmd.Date)
+	snapshot := domain.NewProductivitySnapshot(cmd.UserID, cmd.Date)
+	snapshot.ComputedAt = f.now
+	return snapshot, nil
+}
+
+func snapshotComputedAt(userID uuid.UUID, day, computedAt time.Time) *domain.ProductivitySnapshot {
+	snapshot := domain.NewProductivitySnapshot(userID, day)
+	snapshot.ComputedAt = computedAt
+	return snapshot
+}
+
+// fakeChangeTracker reports a fixed change time per day.
+type fakeChangeTracker map[time.Time]time.Time
+
+func (f fakeChangeTracker) DataChangedAt(ctx context.Context, userID uuid.UUID, start, end time.Time) (time.Time, error) {
+	return f[start], nil
+}
+
+func newTestRefreshHandler(repo domain.SnapshotRepository, computer snapshotComputer, now time.Time) *RefreshSnapshotsHandler {
+	handler := NewRefreshSnapshotsHandler(repo, computer, 15*time.Minute)
+	handler.now = func() time.Time { return now }
+	return handler
+}
+
+func TestRefreshSnapshotsHandler_ReusesFreshSnapshots(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2024, time.May, 8, 14, 0, 0, 0, time.UTC)
+	today := time.Date(2024, time.May, 8, 0, 0, 0, 0, time.UTC)
+	start := today.AddDate(0, 0, -2)
+
+	stored := []*domain.ProductivitySnapshot{
+		// Computed after their day ended, so final.
+		snapshotComputedAt(userID, start, start.AddDate(0, 0, 1).Add(time.Hour)),
+		snapshotComputedAt(userID, start.AddDate(0, 0, 1), today.Add(30*time.Minute)),
+		// Computed five minutes ago, within the max age.
+		snapshotComputedAt(userID, today, now.Add(-5*time.Minute)),
+	}
+
+	repo := new(mockSnapshotRepo)
+	repo.On("GetDateRange", mock.Anything, userID, start, today).Return(stored, nil)
+	computer := &fakeSnapshotComputer{now: now}
+
+	result, err := newTestRefreshHandler(repo, computer, now).Handle(context.Background(), RefreshSnapshotsCommand{
+		UserID: userID,
+		Start:  start,
+		End:    today,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, computer.computed)
+	assert.Equal(t, 3, result.Reused)
+	assert.Equal(t, 0, result.Recomputed)
+	assert.Equal(t, stored, result.Snapshots)
+	repo.AssertExpectations(t)
+}
+
+func TestRefreshSnapshotsHandler_RecomputesOnlyTheDelta(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2024, time.May, 8, 14, 0, 0, 0, time.UTC)
+	today := time.Date(2024, time.May, 8, 0, 0, 0, 0, time.UTC)
+	start := today.AddDate(0, 0, -3)
+
+	final := snapshotComputedAt(userID, start, start.AddDate(0, 0, 1).Add(time.Minute))
+	// Computed while its day was in progress and never finalized.
+	partial := snapshotComputedAt(userID, today.AddDate(0, 0, -1), today.AddDate(0, 0, -1).Add(10*time.Hour))
+	// Today's snapshot is older than the max age.
+	stale := snapshotComputedAt(userID, today, now.Add(-time.Hour))
+
+	repo := new(mockSnapshotRepo)
+	repo.On("GetDateRange", mock.Anything, userID, start, today).
+		Return([]*domain.ProductivitySnapshot{final, partial, stale}, nil)
+	computer := &fakeSnapshotComputer{now: now}
+
+	result, err := newTestRefreshHandler(repo, computer, now).Handle(context.Background(), RefreshSnapshotsCommand{
+		UserID: userID,
+		Start:  start,
+		End:    today,
+	})
+
+	require.NoError(t, err)
+	// The missing day, the unfinished day and today's stale snapshot are recomputed.
+	assert.Equal(t, []time.Time{
+		today.AddDate(0, 0, -2),
+		today.AddDate(0, 0, -1),
+		today,
+	}, computer.computed)
+	assert.Equal(t, 1, result.Reused)
+	assert.Equal(t, 3, result.Recomputed)
+
+	require.Len(t, result.Snapshots, 4)
+	assert.Same(t, final, result.Snapshots[0])
+	for i, s := range result.Snapshots {
+		assert.Equal(t, start.AddDate(0, 0, i), s.SnapshotDate)
+	}
+	assert.Equal(t, now, result.Snapshots[3].ComputedAt)
+}
+
+func TestRefreshSnapshotsHandler_RecomputesPastDaysWithChangedData(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2024, time.May, 8, 14, 0, 0, 0, time.UTC)
+	today := time.Date(2024, time.May, 8, 0, 0, 0, 0, time.UTC)
+	start := today.AddDate(0, 0, -2)
+	computedAt := today.Add(time.Hour)
+
+	unchanged := snapshotComputedAt(userID, start, computedAt)
+	edited := snapshotComputedAt(userID, start.AddDate(0, 0, 1), computedAt)
+
+	repo := new(mockSnapshotRepo)
+	repo.On("GetDateRange", mock.Anything, userID, start, start.AddDate(0, 0, 1)).
+		Return([]*domain.ProductivitySnapshot{unchanged, edited}, nil)
+	computer := &fakeSnapshotComputer{now: now}
+	changes := fakeChangeTracker{
+		start:                  computedAt.Add(-time.Hour),
+		start.AddDate(0, 0, 1): computedAt.Add(time.Hour),
+	}
+
+	result, err := newTestRefreshHandler(repo, computer, now).
+		WithChangeTracker(changes).
+		Handle(context.Background(), RefreshSnapshotsCommand{
+			UserID: userID,
+			Start:  start,
+			End:    start.AddDate(0, 0, 1),
+		})
+
+	require.NoError(t, err)
+	// Only the day edited after its snapshot was computed is recomputed.
+	assert.Equal(t, []time.Time{start.AddDate(0, 0, 1)}, computer.computed)
+	assert.Equal(t, 1, result.Reused)
+	assert.Equal(t, 1, result.Recomputed)
+	require.Len(t, result.Snapshots, 2)
+	assert.Same(t, unchanged, result.Snapshots[0])
+	assert.Equal(t, now, result.Snapshots[1].ComputedAt)
+}
+
+func TestRefreshSnapshotsHandler_SkipsFutureDays(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2024, time.May, 8, 14, 0, 0, 0, time.UTC)
+	today := time.Date(2024, time.May, 8, 0, 0, 0, 0, time.UTC)
+
+	repo := new(mockSnapshotRepo)
+	repo.On("GetDateRange", mock.Anything, userID, today, today).Return([]*domain.ProductivitySnapshot{}, nil)
+	computer := &fakeSnapshotComputer{now: now}
+
+	result, err := newTestRefreshHandler(repo, computer, now).Handle(context.Background(), RefreshSnapshotsCommand{
+		UserID: userID,
+		Start:  today,
+		End:    today.AddDate(0, 0, 5),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{today}, computer.computed)
+	assert.Len(t, result.Snapshots, 1)
+}
+
+func TestRefreshSnapshotsHandler_ComputeError(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2024, time.May, 8, 14, 0, 0, 0, time.UTC)
+	today := time.Date(2024, time.May, 8, 0, 0, 0, 0, time.UTC)
+
+	repo := new(mockSnapshotRepo)
+	repo.On("GetDateRange", mock.Anything, userID, today, today).Return([]*domain.ProductivitySnapshot{}, nil)
+	computer := &fakeSnapshotComputer{err: errors.New("data source unavailable")}
+
+	err := newTestRefreshHandler(repo, computer, now).Refresh(context.Background(), userID, today, today)
+
+	assert.EqualError(t, err, "data source unavailable")
+}
+
+func TestNewRefreshSnapshotsHandler_DefaultMaxAge(t *testing.T) {
+	handler := NewRefreshSnapshotsHandler(new(mockSnapshotRepo), &fakeSnapshotComputer{}, 0)
+	assert.Equal(t, DefaultSnapshotMaxAge, handler.maxAge)
+}
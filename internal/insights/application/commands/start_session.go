@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/felixgeelhaar/orbita/internal/insights/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 )
 
@@ -58,7 +59,7 @@ func (h *StartSessionHandler) Handle(ctx context.Context, cmd StartSessionComman
 }
 
 // ErrSessionAlreadyActive indicates a session is already active.
-var ErrSessionAlreadyActive = errors.New("a session is already active")
+var ErrSessionAlreadyActive = sharedApplication.NewConflictError("a session is already active")
 
 // ErrNotFound indicates a resource was not found.
 var ErrNotFound = errors.New("not found")
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/insights/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
 
@@ -36,12 +37,20 @@ type DashboardResult struct {
 	TotalFocusThisWeek   int
 }
 
+// SnapshotRefresher brings stored snapshots up to date for a date range,
+// recomputing only the days that are missing or stale.
+type SnapshotRefresher interface {
+	Refresh(ctx context.Context, userID uuid.UUID, start, end time.Time) error
+}
+
 // GetDashboardHandler handles dashboard queries.
 type GetDashboardHandler struct {
 	snapshotRepo domain.SnapshotRepository
 	sessionRepo  domain.SessionRepository
 	summaryRepo  domain.SummaryRepository
 	goalRepo     domain.GoalRepository
+	refresher    SnapshotRefresher
+	weekStartsOn time.Weekday
 }
 
 // NewGetDashboardHandler creates a new get dashboard handler.
@@ -56,9 +65,23 @@ func NewGetDashboardHandler(
 		sessionRepo:  sessionRepo,
 		summaryRepo:  summaryRepo,
 		goalRepo:     goalRepo,
+		weekStartsOn: sharedDomain.DefaultWeekStart,
 	}
 }
 
+// WithSnapshotRefresher refreshes the snapshots shown on the dashboard before
+// they are read.
+func (h *GetDashboardHandler) WithSnapshotRefresher(refresher SnapshotRefresher) *GetDashboardHandler {
+	h.refresher = refresher
+	return h
+}
+
+// WithWeekStart sets the first day of the week the dashboard reports on.
+func (h *GetDashboardHandler) WithWeekStart(day time.Weekday) *GetDashboardHandler {
+	h.weekStartsOn = day
+	return h
+}
+
 // Handle executes the get dashboard query.
 func (h *GetDashboardHandler) Handle(ctx context.Context, query GetDashboardQuery) (*DashboardResult, error) {
 	result := &DashboardResult{
@@ -68,7 +91,13 @@ func (h *GetDashboardHandler) Handle(ctx context.Context, query GetDashboardQuer
 
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	weekStart := startOfWeek(now)
+	weekStart := sharedDomain.StartOfWeek(now, h.weekStartsOn)
+	sevenDaysAgo := today.AddDate(0, 0, -7)
+
+	// Refresh stale snapshots; on failure the stored snapshots are served as-is.
+	if h.refresher != nil {
+		_ = h.refresher.Refresh(ctx, query.UserID, sevenDaysAgo, today)
+	}
 
 	// Get today's snapshot
 	todaySnapshot, err := h.snapshotRepo.GetByDate(ctx, query.UserID, today)
@@ -95,7 +124,6 @@ func (h *GetDashboardHandler) Handle(ctx context.Context, query GetDashboardQuer
 	}
 
 	// Get recent snapshots (last 7 days)
-	sevenDaysAgo := today.AddDate(0, 0, -7)
 	recentSnapshots, err := h.snapshotRepo.GetDateRange(ctx, query.UserID, sevenDaysAgo, today)
 	if err == nil && recentSnapshots != nil {
 		result.RecentSnapshots = recentSnapshots
@@ -116,14 +144,3 @@ func (h *GetDashboardHandler) Handle(ctx context.Context, query GetDashboardQuer
 
 	return result, nil
 }
-
-// startOfWeek returns the Monday of the week containing the given time.
-func startOfWeek(t time.Time) time.Time {
-	weekday := int(t.Weekday())
-	daysToSubtract := weekday - 1
-	if daysToSubtract < 0 {
-		daysToSubtract = 6
-	}
-	monday := t.AddDate(0, 0, -daysToSubtract)
-	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, monday.Location())
-}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/insights/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -379,7 +380,7 @@ func TestStartOfWeek(t *testing.T) {
 	t.Run("returns Monday for a Wednesday", func(t *testing.T) {
 		// Wednesday Jan 8, 2025
 		wed := time.Date(2025, 1, 8, 15, 30, 0, 0, time.UTC)
-		monday := startOfWeek(wed)
+		monday := sharedDomain.StartOfWeek(wed, time.Monday)
 
 		// Should be Monday Jan 6, 2025
 		assert.Equal(t, time.Monday, monday.Weekday())
@@ -391,7 +392,7 @@ func TestStartOfWeek(t *testing.T) {
 	t.Run("returns same day for Monday", func(t *testing.T) {
 		// Monday Jan 6, 2025
 		mon := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
-		monday := startOfWeek(mon)
+		monday := sharedDomain.StartOfWeek(mon, time.Monday)
 
 		assert.Equal(t, time.Monday, monday.Weekday())
 		assert.Equal(t, 6, monday.Day())
@@ -400,7 +401,7 @@ func TestStartOfWeek(t *testing.T) {
 	t.Run("returns previous Monday for Sunday", func(t *testing.T) {
 		// Sunday Jan 12, 2025
 		sun := time.Date(2025, 1, 12, 23, 59, 0, 0, time.UTC)
-		monday := startOfWeek(sun)
+		monday := sharedDomain.StartOfWeek(sun, time.Monday)
 
 		assert.Equal(t, time.Monday, monday.Weekday())
 		assert.Equal(t, 6, monday.Day())
@@ -24,6 +24,11 @@ type TrendsResult struct {
 	TaskCompletionTrend  TrendMetric
 	HabitCompletionTrend TrendMetric
 	FocusTimeTrend       TrendMetric
+	FocusScoreTrend      TrendMetric
+
+	// Focus score of each day in the period that had focus work scheduled,
+	// oldest first. Empty without a focus stats source.
+	FocusScores []domain.DailyFocusScore
 
 	// Period comparison
 	CurrentPeriodAvg  float64
@@ -59,6 +64,8 @@ type DaySummary struct {
 // GetTrendsHandler handles trends queries.
 type GetTrendsHandler struct {
 	snapshotRepo domain.SnapshotRepository
+	refresher    SnapshotRefresher
+	focusStats   domain.FocusStatsSource
 }
 
 // NewGetTrendsHandler creates a new get trends handler.
@@ -68,6 +75,20 @@ func NewGetTrendsHandler(snapshotRepo domain.SnapshotRepository) *GetTrendsHandl
 	}
 }
 
+// WithSnapshotRefresher refreshes the snapshots of both compared periods
+// before they are read.
+func (h *GetTrendsHandler) WithSnapshotRefresher(refresher SnapshotRefresher) *GetTrendsHandler {
+	h.refresher = refresher
+	return h
+}
+
+// WithFocusStats adds the daily focus scores and their trend, computed from
+// schedule data, to the result.
+func (h *GetTrendsHandler) WithFocusStats(source domain.FocusStatsSource) *GetTrendsHandler {
+	h.focusStats = source
+	return h
+}
+
 // Handle executes the get trends query.
 func (h *GetTrendsHandler) Handle(ctx context.Context, query GetTrendsQuery) (*TrendsResult, error) {
 	result := &TrendsResult{
@@ -89,6 +110,11 @@ func (h *GetTrendsHandler) Handle(ctx context.Context, query GetTrendsQuery) (*T
 	previousStart := currentStart.AddDate(0, 0, -query.Days)
 	previousEnd := currentStart
 
+	// Refresh stale snapshots; on failure the stored snapshots are served as-is.
+	if h.refresher != nil {
+		_ = h.refresher.Refresh(ctx, query.UserID, previousStart, currentEnd)
+	}
+
 	// Get current period snapshots
 	currentSnapshots, err := h.snapshotRepo.GetDateRange(ctx, query.UserID, currentStart, currentEnd)
 	if err != nil {
@@ -123,6 +149,19 @@ func (h *GetTrendsHandler) Handle(ctx context.Context, query GetTrendsQuery) (*T
 		extractScores(previousSnapshots, func(s *domain.ProductivitySnapshot) float64 { return float64(s.TotalFocusMinutes) }),
 	)
 
+	if h.focusStats != nil {
+		currentScores, err := domain.ComputeDailyFocusScores(ctx, h.focusStats, query.UserID, currentStart, currentEnd)
+		if err != nil {
+			return nil, err
+		}
+		previousScores, err := domain.ComputeDailyFocusScores(ctx, h.focusStats, query.UserID, previousStart, previousEnd)
+		if err != nil {
+			return nil, err
+		}
+		result.FocusScores = currentScores
+		result.FocusScoreTrend = calculateTrend(focusScoreValues(currentScores), focusScoreValues(previousScores))
+	}
+
 	// Overall period comparison
 	result.CurrentPeriodAvg = result.ProductivityTrend.CurrentAvg
 	result.PreviousPeriodAvg = result.ProductivityTrend.PreviousAvg
@@ -146,6 +185,14 @@ func extractScores(snapshots []*domain.ProductivitySnapshot, extractor func(*dom
 	return scores
 }
 
+func focusScoreValues(scores []domain.DailyFocusScore) []float64 {
+	values := make([]float64, len(scores))
+	for i, s := range scores {
+		values[i] = float64(s.Score)
+	}
+	return values
+}
+
 func calculateTrend(current, previous []float64) TrendMetric {
 	currentAvg := average(current)
 	previousAvg := average(previous)
@@ -442,3 +442,39 @@ func TestNewGetTrendsHandler(t *testing.T) {
 
 	require.NotNil(t, handler)
 }
+
+type stubFocusStats struct {
+	current, previous *domain.FocusStats
+	boundary          time.Time
+}
+
+func (s *stubFocusStats) GetFocusStats(ctx context.Context, userID uuid.UUID, start, end time.Time) (*domain.FocusStats, error) {
+	if start.Before(s.boundary) {
+		return s.previous, nil
+	}
+	return s.current, nil
+}
+
+func TestGetTrendsHandler_FocusScore(t *testing.T) {
+	userID := uuid.New()
+	snapshotRepo := new(mockSnapshotRepo)
+	snapshotRepo.On("GetDateRange", mock.Anything, userID, mock.Anything, mock.Anything).Return([]*domain.ProductivitySnapshot{}, nil)
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	source := &stubFocusStats{
+		current:  &domain.FocusStats{ScheduledMinutes: 120, CompletedMinutes: 120, BreaksScheduled: 2, BreaksTaken: 2},
+		previous: &domain.FocusStats{ScheduledMinutes: 120, CompletedMinutes: 60, Interruptions: 2, BreaksScheduled: 2},
+		boundary: today.AddDate(0, 0, -7),
+	}
+	handler := NewGetTrendsHandler(snapshotRepo).WithFocusStats(source)
+
+	result, err := handler.Handle(context.Background(), GetTrendsQuery{UserID: userID, Days: 7})
+
+	require.NoError(t, err)
+	require.Len(t, result.FocusScores, 7)
+	assert.Equal(t, 100, result.FocusScores[0].Score)
+	assert.Equal(t, "up", result.FocusScoreTrend.Direction)
+	assert.Equal(t, 100.0, result.FocusScoreTrend.CurrentAvg)
+	assert.Equal(t, 35.0, result.FocusScoreTrend.PreviousAvg)
+}
@@ -3,6 +3,7 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/insights/application/commands"
 	"github.com/felixgeelhaar/orbita/internal/insights/application/queries"
@@ -16,12 +17,16 @@ type Service struct {
 	endSessionHandler     *commands.EndSessionHandler
 	computeSnapshotHandler *commands.ComputeSnapshotHandler
 	createGoalHandler     *commands.CreateGoalHandler
+	computeWeeklySummaryHandler *commands.ComputeWeeklySummaryHandler
 
 	// Query handlers
 	getDashboardHandler     *queries.GetDashboardHandler
 	getTrendsHandler        *queries.GetTrendsHandler
 	getActiveGoalsHandler   *queries.GetActiveGoalsHandler
 	getAchievedGoalsHandler *queries.GetAchievedGoalsHandler
+
+	snapshotRepo domain.SnapshotRepository
+	dataSource   domain.AnalyticsDataSource
 }
 
 // NewService creates a new insights service.
@@ -38,13 +43,41 @@ func NewService(
 		endSessionHandler:      commands.NewEndSessionHandler(sessionRepo),
 		computeSnapshotHandler: commands.NewComputeSnapshotHandler(snapshotRepo, sessionRepo, dataSource),
 		createGoalHandler:      commands.NewCreateGoalHandler(goalRepo),
+		computeWeeklySummaryHandler: commands.NewComputeWeeklySummaryHandler(snapshotRepo, summaryRepo, sessionRepo).
+			WithFocusStats(dataSource),
 
 		// Query handlers
 		getDashboardHandler:     queries.NewGetDashboardHandler(snapshotRepo, sessionRepo, summaryRepo, goalRepo),
-		getTrendsHandler:        queries.NewGetTrendsHandler(snapshotRepo),
+		getTrendsHandler:        queries.NewGetTrendsHandler(snapshotRepo).WithFocusStats(dataSource),
 		getActiveGoalsHandler:   queries.NewGetActiveGoalsHandler(goalRepo),
 		getAchievedGoalsHandler: queries.NewGetAchievedGoalsHandler(goalRepo),
+
+		snapshotRepo: snapshotRepo,
+		dataSource:   dataSource,
+	}
+}
+
+// WithSnapshotRefresh makes dashboard and trend queries bring their snapshots
+// up to date before reading them. Only missing days, snapshots older than
+// maxAge for days still in progress and, when the data source tracks
+// changes, past days whose data changed since are recomputed.
+func (s *Service) WithSnapshotRefresh(maxAge time.Duration) *Service {
+	refresher := commands.NewRefreshSnapshotsHandler(s.snapshotRepo, s.computeSnapshotHandler, maxAge)
+	if changes, ok := s.dataSource.(commands.ChangeTracker); ok {
+		refresher.WithChangeTracker(changes)
 	}
+	s.getDashboardHandler.WithSnapshotRefresher(refresher)
+	s.getTrendsHandler.WithSnapshotRefresher(refresher)
+	return s
+}
+
+// WithWeekStart sets the first day of the week used for weekly goals, weekly
+// summaries and the dashboard's weekly figures.
+func (s *Service) WithWeekStart(day time.Weekday) *Service {
+	s.createGoalHandler.WithWeekStart(day)
+	s.computeWeeklySummaryHandler.WithWeekStart(day)
+	s.getDashboardHandler.WithWeekStart(day)
+	return s
 }
 
 // StartSession starts a new focus session.
@@ -67,6 +100,11 @@ func (s *Service) CreateGoal(ctx context.Context, cmd commands.CreateGoalCommand
 	return s.createGoalHandler.Handle(ctx, cmd)
 }
 
+// ComputeWeeklySummary computes and stores the summary of a week.
+func (s *Service) ComputeWeeklySummary(ctx context.Context, cmd commands.ComputeWeeklySummaryCommand) (*commands.ComputeWeeklySummaryResult, error) {
+	return s.computeWeeklySummaryHandler.Handle(ctx, cmd)
+}
+
 // GetDashboard returns the insights dashboard.
 func (s *Service) GetDashboard(ctx context.Context, query queries.GetDashboardQuery) (*queries.DashboardResult, error) {
 	return s.getDashboardHandler.Handle(ctx, query)
@@ -247,6 +247,14 @@ func (m *mockDataSource) GetTimeByCategory(ctx context.Context, userID uuid.UUID
 	return args.Get(0).(map[string]int), args.Error(1)
 }
 
+func (m *mockDataSource) GetFocusStats(ctx context.Context, userID uuid.UUID, start, end time.Time) (*domain.FocusStats, error) {
+	args := m.Called(ctx, userID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusStats), args.Error(1)
+}
+
 // Tests
 
 func TestNewService(t *testing.T) {
@@ -505,6 +513,77 @@ func TestService_GetDashboard(t *testing.T) {
 	})
 }
 
+func TestService_GetDashboard_WithSnapshotRefresh(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	t.Run("serves fresh snapshots without recomputing", func(t *testing.T) {
+		snapshotRepo := new(mockSnapshotRepo)
+		sessionRepo := new(mockSessionRepo)
+		summaryRepo := new(mockSummaryRepo)
+		goalRepo := new(mockGoalRepo)
+		dataSource := new(mockDataSource)
+
+		svc := NewService(snapshotRepo, sessionRepo, summaryRepo, goalRepo, dataSource).
+			WithSnapshotRefresh(commands.DefaultSnapshotMaxAge)
+
+		snapshots := make([]*domain.ProductivitySnapshot, 0, 8)
+		for day := today.AddDate(0, 0, -7); !day.After(today); day = day.AddDate(0, 0, 1) {
+			snapshot := domain.NewProductivitySnapshot(userID, day)
+			snapshot.ComputedAt = now
+			snapshots = append(snapshots, snapshot)
+		}
+
+		snapshotRepo.On("GetByDate", mock.Anything, userID, today).Return(snapshots[7], nil)
+		snapshotRepo.On("GetDateRange", mock.Anything, userID, today.AddDate(0, 0, -7), today).Return(snapshots, nil)
+		snapshotRepo.On("GetAverageScore", mock.Anything, userID, mock.Anything, mock.Anything).Return(0, nil)
+		summaryRepo.On("GetByWeek", mock.Anything, userID, mock.Anything).Return(nil, commands.ErrNotFound)
+		sessionRepo.On("GetActive", mock.Anything, userID).Return(nil, commands.ErrNotFound)
+		sessionRepo.On("GetTotalFocusMinutes", mock.Anything, userID, mock.Anything, mock.Anything).Return(0, nil)
+		goalRepo.On("GetActive", mock.Anything, userID).Return([]*domain.ProductivityGoal{}, nil)
+
+		result, err := svc.GetDashboard(context.Background(), queries.GetDashboardQuery{UserID: userID})
+
+		require.NoError(t, err)
+		assert.Same(t, snapshots[7], result.Today)
+		snapshotRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		dataSource.AssertNotCalled(t, "GetTaskStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("computes missing days before reading", func(t *testing.T) {
+		snapshotRepo := new(mockSnapshotRepo)
+		sessionRepo := new(mockSessionRepo)
+		summaryRepo := new(mockSummaryRepo)
+		goalRepo := new(mockGoalRepo)
+		dataSource := new(mockDataSource)
+
+		svc := NewService(snapshotRepo, sessionRepo, summaryRepo, goalRepo, dataSource).
+			WithSnapshotRefresh(commands.DefaultSnapshotMaxAge)
+
+		snapshotRepo.On("GetDateRange", mock.Anything, userID, mock.Anything, mock.Anything).Return([]*domain.ProductivitySnapshot{}, nil)
+		dataSource.On("GetTaskStats", mock.Anything, userID, mock.Anything, mock.Anything).Return(&domain.TaskStats{}, nil)
+		dataSource.On("GetBlockStats", mock.Anything, userID, mock.Anything, mock.Anything).Return(&domain.BlockStats{}, nil)
+		dataSource.On("GetHabitStats", mock.Anything, userID, mock.Anything, mock.Anything).Return(&domain.HabitStats{}, nil)
+		dataSource.On("GetPeakHours", mock.Anything, userID, mock.Anything, mock.Anything).Return([]domain.PeakHour{}, nil)
+		dataSource.On("GetTimeByCategory", mock.Anything, userID, mock.Anything, mock.Anything).Return(map[string]int{}, nil)
+		sessionRepo.On("GetTotalFocusMinutes", mock.Anything, userID, mock.Anything, mock.Anything).Return(0, nil)
+		sessionRepo.On("GetByDateRange", mock.Anything, userID, mock.Anything, mock.Anything).Return([]*domain.TimeSession{}, nil)
+		snapshotRepo.On("Save", mock.Anything, mock.AnythingOfType("*domain.ProductivitySnapshot")).Return(nil)
+		snapshotRepo.On("GetByDate", mock.Anything, userID, today).Return(nil, commands.ErrNotFound)
+		snapshotRepo.On("GetAverageScore", mock.Anything, userID, mock.Anything, mock.Anything).Return(0, nil)
+		summaryRepo.On("GetByWeek", mock.Anything, userID, mock.Anything).Return(nil, commands.ErrNotFound)
+		sessionRepo.On("GetActive", mock.Anything, userID).Return(nil, commands.ErrNotFound)
+		goalRepo.On("GetActive", mock.Anything, userID).Return([]*domain.ProductivityGoal{}, nil)
+
+		_, err := svc.GetDashboard(context.Background(), queries.GetDashboardQuery{UserID: userID})
+
+		require.NoError(t, err)
+		// The last seven days and today.
+		snapshotRepo.AssertNumberOfCalls(t, "Save", 8)
+	})
+}
+
 func TestService_GetTrends(t *testing.T) {
 	userID := uuid.New()
 
@@ -523,6 +602,7 @@ func TestService_GetTrends(t *testing.T) {
 			{UserID: userID, SnapshotDate: now, ProductivityScore: 75},
 		}
 		snapshotRepo.On("GetDateRange", mock.Anything, userID, mock.Anything, mock.Anything).Return(snapshots, nil)
+		dataSource.On("GetFocusStats", mock.Anything, userID, mock.Anything, mock.Anything).Return(&domain.FocusStats{}, nil)
 
 		query := queries.GetTrendsQuery{
 			UserID: userID,
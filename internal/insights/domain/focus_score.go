@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Weights of the focus score components. When no breaks were scheduled the
+// break weight is left out and the score is scaled over the others.
+const (
+	focusCompletionWeight   = 0.5
+	focusInterruptionWeight = 0.3
+	focusBreakWeight        = 0.2
+
+	// focusMaxInterruptions is the number of interruptions that brings the
+	// interruption component to zero.
+	focusMaxInterruptions = 3
+)
+
+// FocusStats contains the schedule data a focus score is computed from.
+type FocusStats struct {
+	// ScheduledMinutes and CompletedMinutes cover focus work: task, habit and
+	// focus blocks.
+	ScheduledMinutes int
+	CompletedMinutes int
+	// Interruptions counts blocks moved to resolve a calendar conflict.
+	Interruptions   int
+	BreaksScheduled int
+	BreaksTaken     int
+}
+
+// HasFocusWork reports whether any focus work was scheduled. Days without
+// focus work have no focus score.
+func (s FocusStats) HasFocusWork() bool {
+	return s.ScheduledMinutes > 0
+}
+
+// Score returns the focus score (0-100): how much of the scheduled focus
+// time was completed, how few blocks were interrupted by conflicts and how
+// many scheduled breaks were taken. Days without focus work score 0.
+func (s FocusStats) Score() int {
+	if !s.HasFocusWork() {
+		return 0
+	}
+
+	completion := float64(s.CompletedMinutes) / float64(s.ScheduledMinutes)
+	if completion > 1 {
+		completion = 1
+	}
+
+	interruptions := s.Interruptions
+	if interruptions > focusMaxInterruptions {
+		interruptions = focusMaxInterruptions
+	}
+	calm := 1 - float64(interruptions)/focusMaxInterruptions
+
+	score := completion*focusCompletionWeight + calm*focusInterruptionWeight
+	weights := focusCompletionWeight + focusInterruptionWeight
+	if s.BreaksScheduled > 0 {
+		adherence := float64(s.BreaksTaken) / float64(s.BreaksScheduled)
+		if adherence > 1 {
+			adherence = 1
+		}
+		score += adherence * focusBreakWeight
+		weights += focusBreakWeight
+	}
+
+	return int(score/weights*100 + 0.5)
+}
+
+// DailyFocusScore is the focus score of one day.
+type DailyFocusScore struct {
+	Date  time.Time
+	Score int
+	Stats FocusStats
+}
+
+// FocusStatsSource provides the schedule data focus scores are computed from.
+type FocusStatsSource interface {
+	// GetFocusStats retrieves focus statistics for a date range.
+	GetFocusStats(ctx context.Context, userID uuid.UUID, start, end time.Time) (*FocusStats, error)
+}
+
+// ComputeDailyFocusScores returns the focus score of each day from start
+// (inclusive) to end (exclusive) that had focus work scheduled, oldest first.
+func ComputeDailyFocusScores(ctx context.Context, source FocusStatsSource, userID uuid.UUID, start, end time.Time) ([]DailyFocusScore, error) {
+	var scores []DailyFocusScore
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		stats, err := source.GetFocusStats(ctx, userID, day, day.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, err
+		}
+		if stats == nil || !stats.HasFocusWork() {
+			continue
+		}
+		scores = append(scores, DailyFocusScore{Date: day, Score: stats.Score(), Stats: *stats})
+	}
+	return scores, nil
+}
+
+// AverageFocusScore returns the mean of the scores, or 0 without scores.
+func AverageFocusScore(scores []DailyFocusScore) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+	total := 0
+	for _, s := range scores {
+		total += s.Score
+	}
+	return float64(total) / float64(len(scores))
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFocusStats_Score(t *testing.T) {
+	t.Run("clean day", func(t *testing.T) {
+		stats := FocusStats{
+			ScheduledMinutes: 120,
+			CompletedMinutes: 120,
+			BreaksScheduled:  2,
+			BreaksTaken:      2,
+		}
+		assert.Equal(t, 100, stats.Score())
+	})
+
+	t.Run("disrupted day", func(t *testing.T) {
+		stats := FocusStats{
+			ScheduledMinutes: 120,
+			CompletedMinutes: 60,
+			Interruptions:    2,
+			BreaksScheduled:  2,
+			BreaksTaken:      0,
+		}
+		// 0.5*0.5 + 0.3*(1/3) + 0.2*0
+		assert.Equal(t, 35, stats.Score())
+	})
+
+	t.Run("interruptions beyond the maximum count as the maximum", func(t *testing.T) {
+		stats := FocusStats{ScheduledMinutes: 60, CompletedMinutes: 60, Interruptions: 10}
+		assert.Equal(t, 63, stats.Score())
+	})
+
+	t.Run("without scheduled breaks the other components carry the score", func(t *testing.T) {
+		stats := FocusStats{ScheduledMinutes: 90, CompletedMinutes: 90}
+		assert.Equal(t, 100, stats.Score())
+	})
+
+	t.Run("no focus work scores zero", func(t *testing.T) {
+		stats := FocusStats{BreaksScheduled: 1, BreaksTaken: 1}
+		assert.False(t, stats.HasFocusWork())
+		assert.Equal(t, 0, stats.Score())
+	})
+}
+
+type stubFocusSource struct {
+	days map[time.Time]*FocusStats
+	err  error
+}
+
+func (s *stubFocusSource) GetFocusStats(ctx context.Context, userID uuid.UUID, start, end time.Time) (*FocusStats, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if stats, ok := s.days[start]; ok {
+		return stats, nil
+	}
+	return &FocusStats{}, nil
+}
+
+func TestComputeDailyFocusScores(t *testing.T) {
+	monday := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	source := &stubFocusSource{days: map[time.Time]*FocusStats{
+		monday:  {ScheduledMinutes: 120, CompletedMinutes: 120, BreaksScheduled: 2, BreaksTaken: 2},
+		tuesday: {ScheduledMinutes: 120, CompletedMinutes: 60, Interruptions: 2, BreaksScheduled: 2},
+	}}
+
+	scores, err := ComputeDailyFocusScores(context.Background(), source, uuid.New(), monday, monday.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	require.Len(t, scores, 2, "days without focus work are skipped")
+	assert.Equal(t, monday, scores[0].Date)
+	assert.Equal(t, 100, scores[0].Score)
+	assert.Equal(t, tuesday, scores[1].Date)
+	assert.Equal(t, 35, scores[1].Score)
+	assert.Equal(t, 67.5, AverageFocusScore(scores))
+
+	_, err = ComputeDailyFocusScores(context.Background(), &stubFocusSource{err: errors.New("boom")}, uuid.New(), monday, tuesday)
+	assert.Error(t, err)
+
+	assert.Equal(t, 0.0, AverageFocusScore(nil))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
 
@@ -56,14 +57,21 @@ var (
 	ErrInvalidTargetValue  = errors.New("target value must be positive")
 )
 
-// NewProductivityGoal creates a new productivity goal.
+// NewProductivityGoal creates a new productivity goal. Weekly goals run from
+// Monday to Sunday.
 func NewProductivityGoal(userID uuid.UUID, goalType GoalType, targetValue int, periodType PeriodType) (*ProductivityGoal, error) {
+	return NewProductivityGoalWithWeekStart(userID, goalType, targetValue, periodType, sharedDomain.DefaultWeekStart)
+}
+
+// NewProductivityGoalWithWeekStart creates a new productivity goal whose
+// weekly periods begin on weekStartsOn.
+func NewProductivityGoalWithWeekStart(userID uuid.UUID, goalType GoalType, targetValue int, periodType PeriodType, weekStartsOn time.Weekday) (*ProductivityGoal, error) {
 	if targetValue <= 0 {
 		return nil, ErrInvalidTargetValue
 	}
 
 	now := time.Now()
-	periodStart, periodEnd := calculatePeriod(now, periodType)
+	periodStart, periodEnd := calculatePeriod(now, periodType, weekStartsOn)
 
 	return &ProductivityGoal{
 		ID:           uuid.New(),
@@ -81,19 +89,13 @@ func NewProductivityGoal(userID uuid.UUID, goalType GoalType, targetValue int, p
 }
 
 // calculatePeriod calculates the start and end dates for a period.
-func calculatePeriod(now time.Time, periodType PeriodType) (start, end time.Time) {
+func calculatePeriod(now time.Time, periodType PeriodType, weekStartsOn time.Weekday) (start, end time.Time) {
 	switch periodType {
 	case PeriodTypeDaily:
 		start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 		end = start.AddDate(0, 0, 1).Add(-time.Nanosecond)
 	case PeriodTypeWeekly:
-		// Start from Monday
-		weekday := int(now.Weekday())
-		daysToSubtract := weekday - 1
-		if daysToSubtract < 0 {
-			daysToSubtract = 6
-		}
-		start = time.Date(now.Year(), now.Month(), now.Day()-daysToSubtract, 0, 0, 0, 0, now.Location())
+		start = sharedDomain.StartOfWeek(now, weekStartsOn)
 		end = start.AddDate(0, 0, 7).Add(-time.Nanosecond)
 	case PeriodTypeMonthly:
 		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
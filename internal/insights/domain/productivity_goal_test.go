@@ -250,7 +250,7 @@ func TestCalculatePeriod(t *testing.T) {
 	refTime := time.Date(2024, 1, 10, 14, 30, 0, 0, time.UTC)
 
 	t.Run("daily period", func(t *testing.T) {
-		start, end := calculatePeriod(refTime, PeriodTypeDaily)
+		start, end := calculatePeriod(refTime, PeriodTypeDaily, time.Monday)
 
 		assert.Equal(t, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), start)
 		// End should be end of day (just before midnight of next day)
@@ -262,7 +262,7 @@ func TestCalculatePeriod(t *testing.T) {
 	})
 
 	t.Run("weekly period starts on Monday", func(t *testing.T) {
-		start, end := calculatePeriod(refTime, PeriodTypeWeekly)
+		start, end := calculatePeriod(refTime, PeriodTypeWeekly, time.Monday)
 
 		// January 10, 2024 is Wednesday, so Monday would be January 8
 		assert.Equal(t, 2024, start.Year())
@@ -279,15 +279,27 @@ func TestCalculatePeriod(t *testing.T) {
 	t.Run("weekly period from Sunday", func(t *testing.T) {
 		// Sunday, January 14, 2024
 		sundayTime := time.Date(2024, 1, 14, 10, 0, 0, 0, time.UTC)
-		start, _ := calculatePeriod(sundayTime, PeriodTypeWeekly)
+		start, _ := calculatePeriod(sundayTime, PeriodTypeWeekly, time.Monday)
 
 		// Should go back to Monday January 8
 		assert.Equal(t, 8, start.Day())
 		assert.Equal(t, time.Monday, start.Weekday())
 	})
 
+	t.Run("weekly period starts on Sunday when configured", func(t *testing.T) {
+		sundayTime := time.Date(2024, 1, 14, 10, 0, 0, 0, time.UTC)
+		start, end := calculatePeriod(sundayTime, PeriodTypeWeekly, time.Sunday)
+
+		assert.Equal(t, time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC), start)
+		assert.Equal(t, time.Saturday, end.Weekday())
+		assert.Equal(t, 20, end.Day())
+
+		start, _ = calculatePeriod(refTime, PeriodTypeWeekly, time.Sunday)
+		assert.Equal(t, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), start)
+	})
+
 	t.Run("monthly period", func(t *testing.T) {
-		start, end := calculatePeriod(refTime, PeriodTypeMonthly)
+		start, end := calculatePeriod(refTime, PeriodTypeMonthly, time.Monday)
 
 		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), start)
 		// End should be end of January
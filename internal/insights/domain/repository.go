@@ -111,6 +111,9 @@ type AnalyticsDataSource interface {
 
 	// GetTimeByCategory retrieves time spent by category for a date range.
 	GetTimeByCategory(ctx context.Context, userID uuid.UUID, start, end time.Time) (map[string]int, error)
+
+	// GetFocusStats retrieves focus statistics for a date range.
+	GetFocusStats(ctx context.Context, userID uuid.UUID, start, end time.Time) (*FocusStats, error)
 }
 
 // TaskStats contains task-related statistics.
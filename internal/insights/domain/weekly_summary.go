@@ -3,6 +3,7 @@ package domain
 import (
 	"time"
 
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
 
@@ -10,8 +11,8 @@ import (
 type WeeklySummary struct {
 	ID        uuid.UUID
 	UserID    uuid.UUID
-	WeekStart time.Time // Monday
-	WeekEnd   time.Time // Sunday
+	WeekStart time.Time // First day of the week
+	WeekEnd   time.Time // Last day of the week
 
 	// Totals
 	TotalTasksCompleted  int
@@ -35,16 +36,28 @@ type WeeklySummary struct {
 	HabitsWithStreak int
 	LongestStreak    int
 
+	// Focus score averaged over the days with focus work, and its change
+	// from the previous week in percent. Both are computed from schedule
+	// data with the summary and are not stored.
+	AvgFocusScore   float64
+	FocusScoreTrend float64
+
 	// Metadata
 	ComputedAt time.Time
 	CreatedAt  time.Time
 }
 
-// NewWeeklySummary creates a new weekly summary.
+// NewWeeklySummary creates a new weekly summary for the Monday-based week
+// containing weekStart.
 func NewWeeklySummary(userID uuid.UUID, weekStart time.Time) *WeeklySummary {
-	// Ensure weekStart is a Monday
-	weekStart = startOfWeek(weekStart)
-	weekEnd := weekStart.AddDate(0, 0, 6) // Sunday
+	return NewWeeklySummaryWithWeekStart(userID, weekStart, sharedDomain.DefaultWeekStart)
+}
+
+// NewWeeklySummaryWithWeekStart creates a new weekly summary for the week
+// containing date, where weeks begin on weekStartsOn.
+func NewWeeklySummaryWithWeekStart(userID uuid.UUID, date time.Time, weekStartsOn time.Weekday) *WeeklySummary {
+	weekStart := sharedDomain.StartOfWeek(date, weekStartsOn)
+	weekEnd := weekStart.AddDate(0, 0, 6)
 
 	now := time.Now()
 	return &WeeklySummary{
@@ -57,22 +70,6 @@ func NewWeeklySummary(userID uuid.UUID, weekStart time.Time) *WeeklySummary {
 	}
 }
 
-// startOfWeek returns the Monday of the week containing the given time.
-func startOfWeek(t time.Time) time.Time {
-	// Get the weekday (Sunday = 0, Monday = 1, ..., Saturday = 6)
-	weekday := int(t.Weekday())
-	// Calculate days to subtract to get to Monday
-	// If Sunday (0), we need to go back 6 days
-	// If Monday (1), we need to go back 0 days
-	daysToSubtract := weekday - 1
-	if daysToSubtract < 0 {
-		daysToSubtract = 6
-	}
-	monday := t.AddDate(0, 0, -daysToSubtract)
-	// Return at start of day
-	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, monday.Location())
-}
-
 // SetTotals sets the weekly totals.
 func (s *WeeklySummary) SetTotals(tasks, habits, blocks, focusMinutes int) {
 	s.TotalTasksCompleted = tasks
@@ -105,6 +102,17 @@ func (s *WeeklySummary) SetStreakInfo(habitsWithStreak, longestStreak int) {
 	s.LongestStreak = longestStreak
 }
 
+// SetFocusScore sets the average focus score and its trend against the
+// previous week's average. A previous average of zero leaves the trend at
+// zero.
+func (s *WeeklySummary) SetFocusScore(avg, previousAvg float64) {
+	s.AvgFocusScore = avg
+	s.FocusScoreTrend = 0
+	if previousAvg > 0 {
+		s.FocusScoreTrend = (avg - previousAvg) / previousAvg * 100
+	}
+}
+
 // CalculateTrends calculates trends compared to a previous summary.
 func (s *WeeklySummary) CalculateTrends(previous *WeeklySummary) {
 	if previous == nil {
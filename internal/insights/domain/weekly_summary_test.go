@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,6 +32,27 @@ func TestNewWeeklySummary(t *testing.T) {
 	assert.False(t, summary.CreatedAt.IsZero())
 }
 
+func TestNewWeeklySummaryWithWeekStart(t *testing.T) {
+	userID := uuid.New()
+	// Sunday, January 14, 2024
+	sunday := time.Date(2024, 1, 14, 14, 30, 0, 0, time.UTC)
+
+	t.Run("Monday start puts Sunday at the end of the week", func(t *testing.T) {
+		summary := NewWeeklySummaryWithWeekStart(userID, sunday, time.Monday)
+
+		assert.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), summary.WeekStart)
+		assert.Equal(t, time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC), summary.WeekEnd)
+	})
+
+	t.Run("Sunday start puts Sunday at the beginning of the week", func(t *testing.T) {
+		summary := NewWeeklySummaryWithWeekStart(userID, sunday, time.Sunday)
+
+		assert.Equal(t, time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC), summary.WeekStart)
+		assert.Equal(t, time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), summary.WeekEnd)
+		assert.Equal(t, time.Saturday, summary.WeekEnd.Weekday())
+	})
+}
+
 func TestStartOfWeek(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -78,7 +100,7 @@ func TestStartOfWeek(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := startOfWeek(tt.input)
+			result := sharedDomain.StartOfWeek(tt.input, time.Monday)
 
 			assert.Equal(t, time.Monday, result.Weekday())
 			assert.Equal(t, tt.expectedDay, result.Day())
@@ -119,6 +141,18 @@ func TestWeeklySummary_SetTrends(t *testing.T) {
 	assert.InDelta(t, -5.2, summary.FocusTrend, 0.01)
 }
 
+func TestWeeklySummary_SetFocusScore(t *testing.T) {
+	summary := NewWeeklySummary(uuid.New(), time.Now())
+
+	summary.SetFocusScore(72, 60)
+	assert.Equal(t, 72.0, summary.AvgFocusScore)
+	assert.InDelta(t, 20.0, summary.FocusScoreTrend, 0.001)
+
+	summary.SetFocusScore(50, 0)
+	assert.Equal(t, 50.0, summary.AvgFocusScore)
+	assert.Equal(t, 0.0, summary.FocusScoreTrend)
+}
+
 func TestWeeklySummary_SetBestWorstDays(t *testing.T) {
 	summary := NewWeeklySummary(uuid.New(), time.Now())
 	bestDay := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
@@ -127,3 +127,40 @@ func (s *AnalyticsDataSource) GetTimeByCategory(ctx context.Context, userID uuid
 	}
 	return timeByCategory, nil
 }
+
+// GetFocusStats retrieves focus statistics for a date range.
+func (s *AnalyticsDataSource) GetFocusStats(ctx context.Context, userID uuid.UUID, start, end time.Time) (*domain.FocusStats, error) {
+	row, err := s.queries.GetFocusStatsByDateRange(ctx, db.GetFocusStatsByDateRangeParams{
+		UserID:      toPgUUID(userID),
+		StartTime:   toPgTimestamptz(start),
+		StartTime_2: toPgTimestamptz(end),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.FocusStats{
+		ScheduledMinutes: int(row.ScheduledMinutes),
+		CompletedMinutes: int(row.CompletedMinutes),
+		Interruptions:    int(row.Interruptions),
+		BreaksScheduled:  int(row.BreaksScheduled),
+		BreaksTaken:      int(row.BreaksTaken),
+	}, nil
+}
+
+// DataChangedAt returns when the tasks, time blocks and habit completions
+// counted for a date range last changed, or the zero time when there are
+// none. Completions record no time of their own, so a habit counts as
+// changed on every day it has completions on.
+func (s *AnalyticsDataSource) DataChangedAt(ctx context.Context, userID uuid.UUID, start, end time.Time) (time.Time, error) {
+	changedAt, err := s.queries.GetAnalyticsDataChangedAt(ctx, db.GetAnalyticsDataChangedAtParams{
+		UserID:  toPgUUID(userID),
+		StartAt: toPgTimestamptz(start),
+		EndAt:   toPgTimestamptz(end),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return fromPgTimestamptz(changedAt), nil
+}
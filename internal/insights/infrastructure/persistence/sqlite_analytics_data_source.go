@@ -204,3 +204,95 @@ func (s *SQLiteAnalyticsDataSource) GetTimeByCategory(ctx context.Context, userI
 
 	return timeByCategory, nil
 }
+
+// GetFocusStats retrieves focus statistics for a date range.
+func (s *SQLiteAnalyticsDataSource) GetFocusStats(ctx context.Context, userID uuid.UUID, start, end time.Time) (*domain.FocusStats, error) {
+	blocksQuery := `
+		SELECT
+			COALESCE(SUM(CASE WHEN block_type IN ('task', 'habit', 'focus') THEN CAST(ROUND((julianday(end_time) - julianday(start_time)) * 24 * 60) AS INTEGER) ELSE 0 END), 0) as scheduled_minutes,
+			COALESCE(SUM(CASE WHEN block_type IN ('task', 'habit', 'focus') AND completed = 1 THEN CAST(ROUND((julianday(end_time) - julianday(start_time)) * 24 * 60) AS INTEGER) ELSE 0 END), 0) as completed_minutes,
+			COALESCE(SUM(CASE WHEN block_type = 'break' THEN 1 ELSE 0 END), 0) as breaks_scheduled,
+			COALESCE(SUM(CASE WHEN block_type = 'break' AND completed = 1 THEN 1 ELSE 0 END), 0) as breaks_taken
+		FROM time_blocks
+		WHERE user_id = ? AND julianday(start_time) >= julianday(?) AND julianday(start_time) < julianday(?)
+	`
+
+	var stats domain.FocusStats
+	err := s.db.QueryRowContext(ctx, blocksQuery,
+		userID.String(),
+		start.Format(time.RFC3339),
+		end.Format(time.RFC3339),
+	).Scan(&stats.ScheduledMinutes, &stats.CompletedMinutes, &stats.BreaksScheduled, &stats.BreaksTaken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blocks moved to make way for a conflicting calendar event.
+	interruptionsQuery := `
+		SELECT COUNT(*)
+		FROM reschedule_attempts
+		WHERE user_id = ? AND attempt_type = 'auto-conflict' AND success = 1
+			AND julianday(old_start_time) >= julianday(?) AND julianday(old_start_time) < julianday(?)
+	`
+	err = s.db.QueryRowContext(ctx, interruptionsQuery,
+		userID.String(),
+		start.Format(time.RFC3339),
+		end.Format(time.RFC3339),
+	).Scan(&stats.Interruptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// DataChangedAt returns when the tasks, time blocks and habit completions
+// counted for a date range last changed, or the zero time when there are
+// none. Completions record no time of their own, so a habit counts as
+// changed on every day it has completions on.
+func (s *SQLiteAnalyticsDataSource) DataChangedAt(ctx context.Context, userID uuid.UUID, start, end time.Time) (time.Time, error) {
+	query := `
+		SELECT COALESCE(MAX(datetime(updated_at)), '')
+		FROM tasks
+		WHERE user_id = ? AND created_at >= ? AND created_at <= ?
+		UNION ALL
+		SELECT COALESCE(MAX(datetime(updated_at)), '')
+		FROM time_blocks
+		WHERE user_id = ? AND start_time >= ? AND start_time <= ?
+		UNION ALL
+		SELECT COALESCE(MAX(datetime(h.updated_at)), '')
+		FROM habits h
+		JOIN habit_completions hc ON hc.habit_id = h.id
+		WHERE h.user_id = ? AND hc.completed_at >= ? AND hc.completed_at <= ?
+	`
+
+	args := []any{userID.String(), start.Format(time.RFC3339), end.Format(time.RFC3339)}
+	rows, err := s.db.QueryContext(ctx, query, append(append(append([]any{}, args...), args...), args...)...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer rows.Close()
+
+	var changedAt time.Time
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return time.Time{}, err
+		}
+		if value == "" {
+			continue
+		}
+		at, err := time.Parse("2006-01-02 15:04:05", value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if at.After(changedAt) {
+			changedAt = at
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return time.Time{}, err
+	}
+
+	return changedAt, nil
+}
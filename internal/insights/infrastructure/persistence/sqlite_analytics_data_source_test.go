@@ -0,0 +1,106 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func insertFocusTestBlock(t *testing.T, sqlDB *sql.DB, userID uuid.UUID, scheduleID, blockType string, start time.Time, minutes int, completed bool) string {
+	t.Helper()
+
+	id := uuid.New().String()
+	done := 0
+	if completed {
+		done = 1
+	}
+	_, err := sqlDB.Exec(`INSERT INTO time_blocks (id, user_id, schedule_id, block_type, title, start_time, end_time, completed)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, userID.String(), scheduleID, blockType, blockType,
+		start.Format(time.RFC3339), start.Add(time.Duration(minutes)*time.Minute).Format(time.RFC3339), done)
+	require.NoError(t, err)
+	return id
+}
+
+func TestSQLiteAnalyticsDataSource_GetFocusStats(t *testing.T) {
+	sqlDB := setupInsightsTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createInsightsTestUser(t, sqlDB, userID)
+
+	day := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	scheduleID := uuid.New().String()
+	_, err := sqlDB.Exec(`INSERT INTO schedules (id, user_id, schedule_date) VALUES (?, ?, ?)`,
+		scheduleID, userID.String(), day.Format("2006-01-02"))
+	require.NoError(t, err)
+
+	insertFocusTestBlock(t, sqlDB, userID, scheduleID, "focus", day.Add(9*time.Hour), 90, true)
+	moved := insertFocusTestBlock(t, sqlDB, userID, scheduleID, "task", day.Add(11*time.Hour), 30, false)
+	insertFocusTestBlock(t, sqlDB, userID, scheduleID, "break", day.Add(10*time.Hour+30*time.Minute), 15, true)
+	insertFocusTestBlock(t, sqlDB, userID, scheduleID, "break", day.Add(15*time.Hour), 15, false)
+	insertFocusTestBlock(t, sqlDB, userID, scheduleID, "meeting", day.Add(13*time.Hour), 60, true)
+	// Next day, outside the range.
+	insertFocusTestBlock(t, sqlDB, userID, scheduleID, "focus", day.Add(33*time.Hour), 60, true)
+
+	_, err = sqlDB.Exec(`INSERT INTO reschedule_attempts (id, user_id, schedule_id, block_id, attempt_type, success, old_start_time, old_end_time, attempted_at)
+		VALUES (?, ?, ?, ?, 'auto-conflict', 1, ?, ?, ?), (?, ?, ?, ?, 'auto-missed', 1, ?, ?, ?)`,
+		uuid.New().String(), userID.String(), scheduleID, moved, day.Add(11*time.Hour).Format(time.RFC3339), day.Add(11*time.Hour+30*time.Minute).Format(time.RFC3339), day.Format(time.RFC3339),
+		uuid.New().String(), userID.String(), scheduleID, moved, day.Add(11*time.Hour).Format(time.RFC3339), day.Add(11*time.Hour+30*time.Minute).Format(time.RFC3339), day.Format(time.RFC3339))
+	require.NoError(t, err)
+
+	source := NewSQLiteAnalyticsDataSource(sqlDB)
+	stats, err := source.GetFocusStats(context.Background(), userID, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+
+	assert.Equal(t, 120, stats.ScheduledMinutes)
+	assert.Equal(t, 90, stats.CompletedMinutes)
+	assert.Equal(t, 2, stats.BreaksScheduled)
+	assert.Equal(t, 1, stats.BreaksTaken)
+	assert.Equal(t, 1, stats.Interruptions, "only conflict reschedules interrupt focus")
+}
+
+func TestSQLiteAnalyticsDataSource_DataChangedAt(t *testing.T) {
+	sqlDB := setupInsightsTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createInsightsTestUser(t, sqlDB, userID)
+
+	day := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	source := NewSQLiteAnalyticsDataSource(sqlDB)
+
+	changedAt, err := source.DataChangedAt(context.Background(), userID, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.True(t, changedAt.IsZero(), "a day without data never changed")
+
+	// A task backdated to the day days later, stored with a non-UTC offset.
+	addedAt := time.Date(2024, time.March, 10, 8, 30, 0, 0, time.UTC)
+	taskID := uuid.New().String()
+	_, err = sqlDB.Exec(`INSERT INTO tasks (id, user_id, title, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		taskID, userID.String(), "Backdated", day.Add(10*time.Hour).Format(time.RFC3339),
+		addedAt.In(time.FixedZone("CET", 3600)).Format(time.RFC3339))
+	require.NoError(t, err)
+	// Changed later, but counted for the next day.
+	_, err = sqlDB.Exec(`INSERT INTO tasks (id, user_id, title, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		uuid.New().String(), userID.String(), "Next day", day.Add(34*time.Hour).Format(time.RFC3339),
+		addedAt.Add(time.Hour).Format(time.RFC3339))
+	require.NoError(t, err)
+
+	changedAt, err = source.DataChangedAt(context.Background(), userID, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.True(t, addedAt.Equal(changedAt), "got %s", changedAt)
+
+	before := time.Now().Truncate(time.Second)
+	_, err = sqlDB.Exec(`UPDATE tasks SET status = 'completed' WHERE id = ?`, taskID)
+	require.NoError(t, err)
+
+	changedAt, err = source.DataChangedAt(context.Background(), userID, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.False(t, changedAt.Before(before), "an edit is seen as a change, got %s", changedAt)
+}
@@ -147,17 +147,8 @@ func (s *LocalBillingService) GetLicense(ctx context.Context) (*licensingDomain.
 
 // allProEntitlements returns all Pro module entitlements.
 func allProEntitlements(userID uuid.UUID, source string) []billingDomain.Entitlement {
-	modules := []string{
-		billingDomain.ModuleSmartHabits,
-		billingDomain.ModuleSmartMeetings,
-		billingDomain.ModuleAutoRescheduler,
-		billingDomain.ModuleAIInbox,
-		billingDomain.ModulePriorityEngine,
-		billingDomain.ModuleAdaptiveFrequency,
-	}
-
-	entitlements := make([]billingDomain.Entitlement, 0, len(modules))
-	for _, module := range modules {
+	entitlements := make([]billingDomain.Entitlement, 0, len(billingDomain.ProModules))
+	for _, module := range billingDomain.ProModules {
 		entitlements = append(entitlements, billingDomain.Entitlement{
 			UserID: userID,
 			Module: module,
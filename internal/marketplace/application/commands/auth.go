@@ -13,16 +13,18 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/security"
 	"github.com/google/uuid"
 )
 
 var (
 	// ErrInvalidCredentials is returned when login credentials are invalid.
-	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidCredentials = sharedApplication.NewValidationError("invalid credentials")
 	// ErrPublisherNotFound is returned when publisher is not found.
-	ErrPublisherNotFound = errors.New("publisher not found")
+	ErrPublisherNotFound = sharedApplication.NewNotFoundError("publisher not found")
 	// ErrNotAuthenticated is returned when not logged in.
-	ErrNotAuthenticated = errors.New("not authenticated")
+	ErrNotAuthenticated = sharedApplication.NewValidationError("not authenticated")
 )
 
 // APIToken represents a marketplace API token.
@@ -47,6 +49,15 @@ type APITokenRepository interface {
 	ListByPublisher(ctx context.Context, publisherID uuid.UUID) ([]*APIToken, error)
 }
 
+// CredentialStore keeps the marketplace API token secret at rest.
+type CredentialStore interface {
+	Save(token string) error
+	// Load returns the stored token, or a not-found error (see
+	// sharedApplication.ErrNotFound) when none is stored.
+	Load() (string, error)
+	Erase() error
+}
+
 // LoginCommand represents a login command.
 type LoginCommand struct {
 	Token string // API token provided by marketplace website
@@ -65,14 +76,17 @@ type LoginHandler struct {
 	tokenRepo     APITokenRepository
 	publisherRepo domain.PublisherRepository
 	configDir     string
+	credentials   CredentialStore
 }
 
-// NewLoginHandler creates a new login handler.
-func NewLoginHandler(tokenRepo APITokenRepository, publisherRepo domain.PublisherRepository, configDir string) *LoginHandler {
+// NewLoginHandler creates a new login handler. The API token is kept in
+// store; configDir only receives the non-secret login details.
+func NewLoginHandler(tokenRepo APITokenRepository, publisherRepo domain.PublisherRepository, configDir string, store CredentialStore) *LoginHandler {
 	return &LoginHandler{
 		tokenRepo:     tokenRepo,
 		publisherRepo: publisherRepo,
 		configDir:     configDir,
+		credentials:   store,
 	}
 }
 
@@ -127,13 +141,17 @@ func (h *LoginHandler) saveToken(token string, publisherID uuid.UUID, publisherN
 		return err
 	}
 
-	// Save token (in production, this should be encrypted)
+	// The token itself goes to the encrypted credential store; the config
+	// file only records who is logged in.
+	if err := h.credentials.Save(token); err != nil {
+		return err
+	}
+
 	content := fmt.Sprintf(`{
-  "token": "%s",
   "publisher_id": "%s",
   "publisher_name": "%s",
   "logged_in_at": "%s"
-}`, token, publisherID.String(), publisherName, time.Now().Format(time.RFC3339))
+}`, publisherID.String(), publisherName, time.Now().Format(time.RFC3339))
 
 	return os.WriteFile(configPath, []byte(content), 0600)
 }
@@ -148,19 +166,28 @@ type LogoutResult struct {
 
 // LogoutHandler handles marketplace logout.
 type LogoutHandler struct {
-	configDir string
+	configDir   string
+	credentials CredentialStore
 }
 
-// NewLogoutHandler creates a new logout handler.
-func NewLogoutHandler(configDir string) *LogoutHandler {
-	return &LogoutHandler{configDir: configDir}
+// NewLogoutHandler creates a new logout handler that erases the token from store.
+func NewLogoutHandler(configDir string, store CredentialStore) *LogoutHandler {
+	return &LogoutHandler{
+		configDir:   configDir,
+		credentials: store,
+	}
 }
 
 // Handle executes the logout command.
 func (h *LogoutHandler) Handle(ctx context.Context, cmd LogoutCommand) (*LogoutResult, error) {
-	configPath := filepath.Join(h.configDir, "marketplace.json")
+	if err := h.credentials.Erase(); err != nil {
+		return nil, fmt.Errorf("failed to erase credentials: %w", err)
+	}
 
-	if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
+	// Older versions kept the token in plaintext in the config file, so it is
+	// overwritten rather than just unlinked.
+	configPath := filepath.Join(h.configDir, "marketplace.json")
+	if err := security.SecureRemove(configPath); err != nil {
 		return nil, fmt.Errorf("failed to remove credentials: %w", err)
 	}
 
@@ -221,8 +248,17 @@ func (h *WhoAmIHandler) Handle(ctx context.Context, cmd WhoAmICommand) (*WhoAmIR
 	return result, nil
 }
 
-// GetStoredToken retrieves the stored API token.
-func GetStoredToken(configDir string) (string, error) {
+// GetStoredToken retrieves the stored API token from store, falling back to
+// the plaintext config file written by older versions.
+func GetStoredToken(store CredentialStore, configDir string) (string, error) {
+	token, err := store.Load()
+	if err == nil {
+		return token, nil
+	}
+	if !errors.Is(err, sharedApplication.ErrNotFound) {
+		return "", err
+	}
+
 	configPath := filepath.Join(configDir, "marketplace.json")
 
 	// #nosec G304 - configPath is internally constructed from application configDir + fixed filename
@@ -234,7 +270,7 @@ func GetStoredToken(configDir string) (string, error) {
 		return "", err
 	}
 
-	token := findJSONValue(string(data), "token")
+	token = findJSONValue(string(data), "token")
 	if token == "" {
 		return "", ErrNotAuthenticated
 	}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	"github.com/felixgeelhaar/orbita/internal/marketplace/infrastructure/credentials"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -112,6 +114,23 @@ func (m *mockPublisherRepo) Search(ctx context.Context, query string, offset, li
 	return args.Get(0).([]*domain.Publisher), args.Get(1).(int64), args.Error(2)
 }
 
+// memoryStore is an in-memory CredentialStore.
+type memoryStore struct {
+	token string
+}
+
+func newMemoryStore() *memoryStore { return &memoryStore{} }
+
+func (s *memoryStore) Save(token string) error { s.token = token; return nil }
+func (s *memoryStore) Erase() error            { s.token = ""; return nil }
+
+func (s *memoryStore) Load() (string, error) {
+	if s.token == "" {
+		return "", sharedApplication.NewNotFoundError("no token stored")
+	}
+	return s.token, nil
+}
+
 func TestLoginHandler_Handle(t *testing.T) {
 	publisherID := uuid.New()
 	token := "test-api-token-12345"
@@ -125,7 +144,7 @@ func TestLoginHandler_Handle(t *testing.T) {
 		require.NoError(t, err)
 		defer os.RemoveAll(tmpDir)
 
-		handler := NewLoginHandler(tokenRepo, publisherRepo, tmpDir)
+		handler := NewLoginHandler(tokenRepo, publisherRepo, tmpDir, newMemoryStore())
 
 		apiToken := &APIToken{
 			ID:          uuid.New(),
@@ -172,7 +191,7 @@ func TestLoginHandler_Handle(t *testing.T) {
 		require.NoError(t, err)
 		defer os.RemoveAll(tmpDir)
 
-		handler := NewLoginHandler(tokenRepo, publisherRepo, tmpDir)
+		handler := NewLoginHandler(tokenRepo, publisherRepo, tmpDir, newMemoryStore())
 
 		tokenRepo.On("GetByHash", mock.Anything, mock.Anything).Return(nil, nil)
 
@@ -196,7 +215,7 @@ func TestLoginHandler_Handle(t *testing.T) {
 		require.NoError(t, err)
 		defer os.RemoveAll(tmpDir)
 
-		handler := NewLoginHandler(tokenRepo, publisherRepo, tmpDir)
+		handler := NewLoginHandler(tokenRepo, publisherRepo, tmpDir, newMemoryStore())
 
 		revokedAt := time.Now()
 		apiToken := &APIToken{
@@ -229,7 +248,7 @@ func TestLoginHandler_Handle(t *testing.T) {
 		require.NoError(t, err)
 		defer os.RemoveAll(tmpDir)
 
-		handler := NewLoginHandler(tokenRepo, publisherRepo, tmpDir)
+		handler := NewLoginHandler(tokenRepo, publisherRepo, tmpDir, newMemoryStore())
 
 		expiresAt := time.Now().Add(-1 * time.Hour) // Expired
 		apiToken := &APIToken{
@@ -262,7 +281,7 @@ func TestLoginHandler_Handle(t *testing.T) {
 		require.NoError(t, err)
 		defer os.RemoveAll(tmpDir)
 
-		handler := NewLoginHandler(tokenRepo, publisherRepo, tmpDir)
+		handler := NewLoginHandler(tokenRepo, publisherRepo, tmpDir, newMemoryStore())
 
 		apiToken := &APIToken{
 			ID:          uuid.New(),
@@ -292,7 +311,7 @@ func TestNewLoginHandler(t *testing.T) {
 	tokenRepo := new(mockAPITokenRepo)
 	publisherRepo := new(mockPublisherRepo)
 
-	handler := NewLoginHandler(tokenRepo, publisherRepo, "/tmp")
+	handler := NewLoginHandler(tokenRepo, publisherRepo, "/tmp", newMemoryStore())
 
 	require.NotNil(t, handler)
 }
@@ -308,7 +327,7 @@ func TestLogoutHandler_Handle(t *testing.T) {
 		err = os.WriteFile(configPath, []byte(`{"token": "test"}`), 0600)
 		require.NoError(t, err)
 
-		handler := NewLogoutHandler(tmpDir)
+		handler := NewLogoutHandler(tmpDir, newMemoryStore())
 
 		result, err := handler.Handle(context.Background(), LogoutCommand{})
 
@@ -326,7 +345,7 @@ func TestLogoutHandler_Handle(t *testing.T) {
 		require.NoError(t, err)
 		defer os.RemoveAll(tmpDir)
 
-		handler := NewLogoutHandler(tmpDir)
+		handler := NewLogoutHandler(tmpDir, newMemoryStore())
 
 		result, err := handler.Handle(context.Background(), LogoutCommand{})
 
@@ -337,7 +356,7 @@ func TestLogoutHandler_Handle(t *testing.T) {
 }
 
 func TestNewLogoutHandler(t *testing.T) {
-	handler := NewLogoutHandler("/tmp")
+	handler := NewLogoutHandler("/tmp", newMemoryStore())
 
 	require.NotNil(t, handler)
 }
@@ -406,7 +425,7 @@ func TestGetStoredToken(t *testing.T) {
 		err = os.WriteFile(configPath, []byte(configContent), 0600)
 		require.NoError(t, err)
 
-		token, err := GetStoredToken(tmpDir)
+		token, err := GetStoredToken(newMemoryStore(), tmpDir)
 
 		require.NoError(t, err)
 		assert.Equal(t, "my-secret-token", token)
@@ -417,7 +436,7 @@ func TestGetStoredToken(t *testing.T) {
 		require.NoError(t, err)
 		defer os.RemoveAll(tmpDir)
 
-		token, err := GetStoredToken(tmpDir)
+		token, err := GetStoredToken(newMemoryStore(), tmpDir)
 
 		assert.ErrorIs(t, err, ErrNotAuthenticated)
 		assert.Empty(t, token)
@@ -435,7 +454,7 @@ func TestGetStoredToken(t *testing.T) {
 		err = os.WriteFile(configPath, []byte(configContent), 0600)
 		require.NoError(t, err)
 
-		token, err := GetStoredToken(tmpDir)
+		token, err := GetStoredToken(newMemoryStore(), tmpDir)
 
 		assert.ErrorIs(t, err, ErrNotAuthenticated)
 		assert.Empty(t, token)
@@ -492,3 +511,70 @@ func TestFindJSONValue(t *testing.T) {
 		})
 	}
 }
+
+func TestLoginLogout_EncryptedToken(t *testing.T) {
+	publisherID := uuid.New()
+	token := "publisher-secret-token"
+
+	tokenRepo := new(mockAPITokenRepo)
+	publisherRepo := new(mockPublisherRepo)
+	tmpDir := t.TempDir()
+	store := credentials.NewTokenStore(tmpDir, nil, nil)
+
+	publisher := domain.NewPublisher("Test Publisher", "test-publisher", "test@example.com")
+	publisher.ID = publisherID
+	apiToken := &APIToken{ID: uuid.New(), PublisherID: publisherID, TokenHash: hashToken(token), CreatedAt: time.Now()}
+
+	tokenRepo.On("GetByHash", mock.Anything, hashToken(token)).Return(apiToken, nil)
+	tokenRepo.On("UpdateLastUsed", mock.Anything, apiToken.ID).Return(nil)
+	publisherRepo.On("GetByID", mock.Anything, publisherID).Return(publisher, nil)
+
+	_, err := NewLoginHandler(tokenRepo, publisherRepo, tmpDir, store).Handle(context.Background(), LoginCommand{Token: token})
+	require.NoError(t, err)
+
+	// Nothing on disk holds the token in plaintext.
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(tmpDir, entry.Name()))
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), token, entry.Name())
+	}
+
+	stored, err := GetStoredToken(store, tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, token, stored)
+
+	whoami, err := NewWhoAmIHandler(tmpDir).Handle(context.Background(), WhoAmICommand{})
+	require.NoError(t, err)
+	assert.True(t, whoami.Authenticated)
+	assert.Equal(t, "Test Publisher", whoami.PublisherName)
+
+	_, err = NewLogoutHandler(tmpDir, store).Handle(context.Background(), LogoutCommand{})
+	require.NoError(t, err)
+
+	_, err = GetStoredToken(store, tmpDir)
+	assert.ErrorIs(t, err, ErrNotAuthenticated)
+	assert.NoFileExists(t, filepath.Join(tmpDir, "marketplace.token"))
+	assert.NoFileExists(t, filepath.Join(tmpDir, "marketplace.json"))
+}
+
+type erasingStore struct {
+	erased bool
+	err    error
+}
+
+func (s *erasingStore) Save(token string) error { return nil }
+func (s *erasingStore) Load() (string, error)   { return "", ErrNotAuthenticated }
+func (s *erasingStore) Erase() error            { s.erased = true; return s.err }
+
+func TestLogoutHandler_ErasesCredentialStore(t *testing.T) {
+	store := &erasingStore{}
+	_, err := NewLogoutHandler(t.TempDir(), store).Handle(context.Background(), LogoutCommand{})
+	require.NoError(t, err)
+	assert.True(t, store.erased)
+
+	failing := &erasingStore{err: assert.AnError}
+	_, err = NewLogoutHandler(t.TempDir(), failing).Handle(context.Background(), LogoutCommand{})
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -2,16 +2,16 @@ package commands
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 )
 
 var (
 	// ErrPackageAlreadyDisabled is returned when trying to disable an already disabled package.
-	ErrPackageAlreadyDisabled = errors.New("package is already disabled")
+	ErrPackageAlreadyDisabled = sharedApplication.NewConflictError("package is already disabled")
 )
 
 // DisablePackageCommand represents a command to disable an installed package.
@@ -2,16 +2,16 @@ package commands
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 )
 
 var (
 	// ErrPackageAlreadyEnabled is returned when trying to enable an already enabled package.
-	ErrPackageAlreadyEnabled = errors.New("package is already enabled")
+	ErrPackageAlreadyEnabled = sharedApplication.NewConflictError("package is already enabled")
 )
 
 // EnablePackageCommand represents a command to enable an installed package.
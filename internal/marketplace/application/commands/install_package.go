@@ -15,22 +15,23 @@ import (
 	"strings"
 
 	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 )
 
 var (
 	// ErrPackageNotFound is returned when a package is not found in the marketplace.
-	ErrPackageNotFound = errors.New("package not found")
+	ErrPackageNotFound = sharedApplication.NewNotFoundError("package not found")
 	// ErrVersionNotFound is returned when a specific version is not found.
-	ErrVersionNotFound = errors.New("version not found")
+	ErrVersionNotFound = sharedApplication.NewNotFoundError("version not found")
 	// ErrPackageAlreadyInstalled is returned when trying to install an already installed package.
-	ErrPackageAlreadyInstalled = errors.New("package already installed")
+	ErrPackageAlreadyInstalled = sharedApplication.NewConflictError("package already installed")
 	// ErrChecksumMismatch is returned when the downloaded package checksum doesn't match.
-	ErrChecksumMismatch = errors.New("checksum mismatch")
+	ErrChecksumMismatch = sharedApplication.NewValidationError("checksum mismatch")
 	// ErrDownloadFailed is returned when package download fails.
 	ErrDownloadFailed = errors.New("download failed")
 	// ErrFileTooLarge is returned when an extracted file exceeds the size limit.
-	ErrFileTooLarge = errors.New("extracted file exceeds size limit")
+	ErrFileTooLarge = sharedApplication.NewValidationError("extracted file exceeds size limit")
 )
 
 const (
@@ -60,6 +61,7 @@ type InstallPackageHandler struct {
 	installedRepo domain.InstalledPackageRepository
 	installDir    string
 	httpClient    *http.Client
+	registry      domain.Registry
 }
 
 // NewInstallPackageHandler creates a new install package handler.
@@ -75,9 +77,17 @@ func NewInstallPackageHandler(
 		installedRepo: installedRepo,
 		installDir:    installDir,
 		httpClient:    &http.Client{},
+		registry:      domain.NewRegistry(""),
 	}
 }
 
+// WithRegistry sets the registry packages are downloaded from. Downloads
+// that fail on the registry are retried on its mirrors in order.
+func (h *InstallPackageHandler) WithRegistry(registry domain.Registry) *InstallPackageHandler {
+	h.registry = registry
+	return h
+}
+
 // Handle executes the install package command.
 func (h *InstallPackageHandler) Handle(ctx context.Context, cmd InstallPackageCommand) (*InstallPackageResult, error) {
 	// Check if already installed
@@ -158,6 +168,18 @@ func (h *InstallPackageHandler) downloadPackage(ctx context.Context, url, destPa
 		return nil
 	}
 
+	var err error
+	for _, candidate := range h.registry.Candidates(url) {
+		if err = h.download(ctx, candidate, destPath); err == nil || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// download fetches url to destPath, replacing anything a failed attempt
+// left behind.
+func (h *InstallPackageHandler) download(ctx context.Context, url, destPath string) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return err
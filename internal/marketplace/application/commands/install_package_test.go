@@ -496,6 +496,100 @@ func TestInstallPackageHandler_Handle(t *testing.T) {
 		versionRepo.AssertExpectations(t)
 	})
 
+	t.Run("falls back to a mirror when the registry fails", func(t *testing.T) {
+		packageRepo := new(mockPackageRepo)
+		versionRepo := new(mockVersionRepo)
+		installedRepo := new(mockInstalledPackageRepo)
+
+		tmpDir, err := os.MkdirTemp("", "test-install-*")
+		require.NoError(t, err)
+		defer os.RemoveAll(tmpDir)
+
+		archivePath, checksum, cleanup := createTestArchive(t)
+		defer cleanup()
+
+		registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer registry.Close()
+		// The first mirror is unreachable.
+		deadMirror := httptest.NewServer(http.NotFoundHandler())
+		deadMirror.Close()
+		var mirrorPath string
+		archiveData := mustReadFile(t, archivePath)
+		mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mirrorPath = r.URL.Path
+			w.WriteHeader(http.StatusOK)
+			w.Write(archiveData)
+		}))
+		defer mirror.Close()
+
+		handler := NewInstallPackageHandler(packageRepo, versionRepo, installedRepo, tmpDir).
+			WithRegistry(domain.NewRegistry(registry.URL, deadMirror.URL, mirror.URL))
+
+		userID := uuid.New()
+		packageID := "acme.test-orbit"
+		pkg := createTestPackage(packageID, domain.PackageTypeOrbit)
+		version := createTestVersion(pkg.ID, "1.0.0")
+		version.DownloadURL = registry.URL + "/packages/acme.test-orbit/1.0.0/download"
+		version.Checksum = "sha256:" + checksum
+
+		installedRepo.On("GetByPackageID", mock.Anything, packageID, userID).Return(nil, nil)
+		packageRepo.On("GetByPackageID", mock.Anything, packageID).Return(pkg, nil)
+		versionRepo.On("GetByPackageAndVersion", mock.Anything, pkg.ID, "1.0.0").Return(version, nil)
+		installedRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.InstalledPackage")).Return(nil)
+		packageRepo.On("IncrementDownloads", mock.Anything, pkg.ID).Return(nil)
+
+		result, err := handler.Handle(context.Background(), InstallPackageCommand{
+			PackageID: packageID,
+			UserID:    userID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", result.InstalledPackage.Version)
+		assert.Equal(t, "/packages/acme.test-orbit/1.0.0/download", mirrorPath)
+		assert.FileExists(t, filepath.Join(result.InstalledPackage.InstallPath, "README.md"))
+	})
+
+	t.Run("fails when the registry and every mirror fail", func(t *testing.T) {
+		packageRepo := new(mockPackageRepo)
+		versionRepo := new(mockVersionRepo)
+		installedRepo := new(mockInstalledPackageRepo)
+
+		tmpDir, err := os.MkdirTemp("", "test-install-*")
+		require.NoError(t, err)
+		defer os.RemoveAll(tmpDir)
+
+		requests := 0
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer failing.Close()
+
+		handler := NewInstallPackageHandler(packageRepo, versionRepo, installedRepo, tmpDir).
+			WithRegistry(domain.NewRegistry(failing.URL+"/registry", failing.URL+"/mirror"))
+
+		userID := uuid.New()
+		packageID := "acme.test-orbit"
+		pkg := createTestPackage(packageID, domain.PackageTypeOrbit)
+		version := createTestVersion(pkg.ID, "1.0.0")
+		version.DownloadURL = failing.URL + "/registry/packages/acme.test-orbit/1.0.0/download"
+
+		installedRepo.On("GetByPackageID", mock.Anything, packageID, userID).Return(nil, nil)
+		packageRepo.On("GetByPackageID", mock.Anything, packageID).Return(pkg, nil)
+		versionRepo.On("GetByPackageAndVersion", mock.Anything, pkg.ID, "1.0.0").Return(version, nil)
+
+		result, err := handler.Handle(context.Background(), InstallPackageCommand{
+			PackageID: packageID,
+			UserID:    userID,
+		})
+
+		assert.ErrorIs(t, err, ErrDownloadFailed)
+		assert.Nil(t, result)
+		assert.Equal(t, 2, requests)
+	})
+
 	t.Run("fails when download returns non-200 status", func(t *testing.T) {
 		packageRepo := new(mockPackageRepo)
 		versionRepo := new(mockVersionRepo)
@@ -7,7 +7,6 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -15,19 +14,20 @@ import (
 	"strings"
 
 	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/security"
 	"github.com/google/uuid"
 )
 
 var (
 	// ErrManifestNotFound is returned when package manifest is not found.
-	ErrManifestNotFound = errors.New("manifest file not found (orbit.json or engine.json)")
+	ErrManifestNotFound = sharedApplication.NewNotFoundError("manifest file not found (orbit.json or engine.json)")
 	// ErrInvalidManifest is returned when manifest is invalid.
-	ErrInvalidManifest = errors.New("invalid manifest")
+	ErrInvalidManifest = sharedApplication.NewValidationError("invalid manifest")
 	// ErrPackageExists is returned when trying to publish an existing version.
-	ErrPackageExists = errors.New("package version already exists")
+	ErrPackageExists = sharedApplication.NewConflictError("package version already exists")
 	// ErrUnauthorized is returned when not authorized to publish.
-	ErrUnauthorized = errors.New("unauthorized to publish this package")
+	ErrUnauthorized = sharedApplication.NewValidationError("unauthorized to publish this package")
 )
 
 // PackageManifest represents the manifest file for a package.
@@ -43,6 +43,7 @@ type PackageManifest struct {
 	Tags          []string `json:"tags,omitempty"`
 	MinAPIVersion string   `json:"min_api_version,omitempty"`
 	Entitlement   string   `json:"entitlement,omitempty"`
+	Changelog     string   `json:"changelog,omitempty"` // What changed in this version
 }
 
 // PublishPackageCommand represents a command to publish a package.
@@ -66,6 +67,7 @@ type PublishPackageHandler struct {
 	packageRepo   domain.PackageRepository
 	versionRepo   domain.VersionRepository
 	publisherRepo domain.PublisherRepository
+	registry      domain.Registry
 }
 
 // NewPublishPackageHandler creates a new publish package handler.
@@ -78,9 +80,16 @@ func NewPublishPackageHandler(
 		packageRepo:   packageRepo,
 		versionRepo:   versionRepo,
 		publisherRepo: publisherRepo,
+		registry:      domain.NewRegistry(""),
 	}
 }
 
+// WithRegistry sets the registry packages are published to.
+func (h *PublishPackageHandler) WithRegistry(registry domain.Registry) *PublishPackageHandler {
+	h.registry = registry
+	return h
+}
+
 // Handle executes the publish package command.
 func (h *PublishPackageHandler) Handle(ctx context.Context, cmd PublishPackageCommand) (*PublishPackageResult, error) {
 	// Read manifest
@@ -158,9 +167,10 @@ func (h *PublishPackageHandler) Handle(ctx context.Context, cmd PublishPackageCo
 	// Create version
 	version := domain.NewVersion(pkg.ID, manifest.Version)
 	version.SetMinAPIVersion(manifest.MinAPIVersion)
+	version.SetChangelog(manifest.Changelog)
 	version.SetChecksum("sha256:" + checksum)
 	// In production, this would upload to storage and get URL
-	version.SetDownloadURL(fmt.Sprintf("https://marketplace.orbita.dev/packages/%s/%s/download", manifest.ID, manifest.Version))
+	version.SetDownloadURL(h.registry.DownloadURL(manifest.ID, manifest.Version))
 
 	// Get archive size
 	if stat, err := os.Stat(archivePath); err == nil {
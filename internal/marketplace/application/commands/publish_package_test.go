@@ -72,6 +72,7 @@ func TestPublishPackageHandler_Handle(t *testing.T) {
 			Version:     "1.0.0",
 			Type:        "orbit",
 			Description: "A test orbit package",
+			Changelog:   "Initial release",
 		}
 
 		packageDir, cleanup := createTestPackageDir(t, manifest)
@@ -81,7 +82,9 @@ func TestPublishPackageHandler_Handle(t *testing.T) {
 		packageRepo.On("GetByPackageID", mock.Anything, manifest.ID).Return(nil, errors.New("not found"))
 		packageRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Package")).Return(nil)
 		publisherRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Publisher")).Return(nil)
-		versionRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Version")).Return(nil)
+		versionRepo.On("Create", mock.Anything, mock.MatchedBy(func(v *domain.Version) bool {
+			return v.Changelog == "Initial release"
+		})).Return(nil)
 
 		cmd := PublishPackageCommand{
 			PackagePath: packageDir,
@@ -2,17 +2,17 @@ package commands
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
 
 	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 )
 
 var (
 	// ErrPackageNotInstalled is returned when trying to uninstall a package that isn't installed.
-	ErrPackageNotInstalled = errors.New("package not installed")
+	ErrPackageNotInstalled = sharedApplication.NewNotFoundError("package not installed")
 )
 
 // UninstallPackageCommand represents a command to uninstall a marketplace package.
@@ -48,6 +48,13 @@ func NewUpdatePackageHandler(
 	}
 }
 
+// WithRegistry sets the registry and mirrors new versions are downloaded
+// from.
+func (h *UpdatePackageHandler) WithRegistry(registry domain.Registry) *UpdatePackageHandler {
+	h.installHandler.WithRegistry(registry)
+	return h
+}
+
 // Handle executes the update package command.
 func (h *UpdatePackageHandler) Handle(ctx context.Context, cmd UpdatePackageCommand) (*UpdatePackageResult, error) {
 	// Get installed package
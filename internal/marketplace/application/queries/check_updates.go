@@ -0,0 +1,71 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	"github.com/google/uuid"
+)
+
+// CheckUpdatesQuery represents a query for installed packages with newer versions.
+type CheckUpdatesQuery struct {
+	UserID uuid.UUID
+}
+
+// CheckUpdatesResult represents the update plan for a user's installed packages.
+type CheckUpdatesResult struct {
+	Updates []*PackageUpdateDTO
+	Checked int
+}
+
+// PackageUpdateDTO describes a single pending package update.
+type PackageUpdateDTO struct {
+	PackageID        string `json:"package_id"`
+	InstalledVersion string `json:"installed_version"`
+	AvailableVersion string `json:"available_version"`
+}
+
+// CheckUpdatesHandler computes which installed packages can be updated.
+type CheckUpdatesHandler struct {
+	installedRepo domain.InstalledPackageRepository
+	packageRepo   domain.PackageRepository
+}
+
+// NewCheckUpdatesHandler creates a new check updates handler.
+func NewCheckUpdatesHandler(installedRepo domain.InstalledPackageRepository, packageRepo domain.PackageRepository) *CheckUpdatesHandler {
+	return &CheckUpdatesHandler{
+		installedRepo: installedRepo,
+		packageRepo:   packageRepo,
+	}
+}
+
+// Handle executes the check updates query. Packages that are no longer listed
+// in the marketplace are skipped. Nothing is modified.
+func (h *CheckUpdatesHandler) Handle(ctx context.Context, query CheckUpdatesQuery) (*CheckUpdatesResult, error) {
+	installed, err := h.installedRepo.ListByUser(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CheckUpdatesResult{
+		Updates: make([]*PackageUpdateDTO, 0),
+		Checked: len(installed),
+	}
+
+	for _, pkg := range installed {
+		latest, err := h.packageRepo.GetByPackageID(ctx, pkg.PackageID)
+		if err != nil || latest == nil {
+			continue // Skip packages not in marketplace
+		}
+
+		if latest.LatestVersion != "" && latest.LatestVersion != pkg.Version {
+			result.Updates = append(result.Updates, &PackageUpdateDTO{
+				PackageID:        pkg.PackageID,
+				InstalledVersion: pkg.Version,
+				AvailableVersion: latest.LatestVersion,
+			})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUpdatesHandler_Handle(t *testing.T) {
+	t.Run("lists packages with a newer version", func(t *testing.T) {
+		installedRepo := new(MockInstalledPackageRepository)
+		packageRepo := new(MockPackageRepository)
+		handler := NewCheckUpdatesHandler(installedRepo, packageRepo)
+
+		userID := uuid.New()
+		outdated := createTestInstalledPackage("acme.focus", domain.PackageTypeOrbit, userID)
+		current := createTestInstalledPackage("acme.current", domain.PackageTypeOrbit, userID)
+		delisted := createTestInstalledPackage("acme.gone", domain.PackageTypeEngine, userID)
+
+		newer := createTestPackage("acme.focus", "Focus", domain.PackageTypeOrbit)
+		newer.LatestVersion = "1.2.0"
+
+		installedRepo.On("ListByUser", mock.Anything, userID).Return([]*domain.InstalledPackage{outdated, current, delisted}, nil)
+		packageRepo.On("GetByPackageID", mock.Anything, "acme.focus").Return(newer, nil)
+		packageRepo.On("GetByPackageID", mock.Anything, "acme.current").Return(createTestPackage("acme.current", "Current", domain.PackageTypeOrbit), nil)
+		packageRepo.On("GetByPackageID", mock.Anything, "acme.gone").Return(nil, errors.New("not found"))
+
+		result, err := handler.Handle(context.Background(), CheckUpdatesQuery{UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Checked)
+		require.Len(t, result.Updates, 1)
+		assert.Equal(t, &PackageUpdateDTO{
+			PackageID:        "acme.focus",
+			InstalledVersion: "1.0.0",
+			AvailableVersion: "1.2.0",
+		}, result.Updates[0])
+		installedRepo.AssertExpectations(t)
+		packageRepo.AssertExpectations(t)
+	})
+
+	t.Run("fails when ListByUser returns error", func(t *testing.T) {
+		installedRepo := new(MockInstalledPackageRepository)
+		handler := NewCheckUpdatesHandler(installedRepo, new(MockPackageRepository))
+
+		userID := uuid.New()
+		installedRepo.On("ListByUser", mock.Anything, userID).Return(nil, errors.New("database error"))
+
+		result, err := handler.Handle(context.Background(), CheckUpdatesQuery{UserID: userID})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultRegistryURL is the public Orbita marketplace.
+const DefaultRegistryURL = "https://marketplace.orbita.dev"
+
+// Registry is the marketplace packages are published to and downloaded
+// from, with mirrors that serve the same paths when it is unavailable.
+type Registry struct {
+	// URL is the base URL of the registry.
+	URL string
+
+	// Mirrors are base URLs tried in order when the registry fails.
+	Mirrors []string
+}
+
+// NewRegistry creates a registry at url, or the public marketplace when url
+// is empty. Empty mirrors are ignored.
+func NewRegistry(url string, mirrors ...string) Registry {
+	registry := Registry{URL: trimBaseURL(url)}
+	if registry.URL == "" {
+		registry.URL = DefaultRegistryURL
+	}
+	for _, mirror := range mirrors {
+		if mirror = trimBaseURL(mirror); mirror != "" {
+			registry.Mirrors = append(registry.Mirrors, mirror)
+		}
+	}
+	return registry
+}
+
+// DownloadURL returns where the archive of a package version is served.
+func (r Registry) DownloadURL(packageID, version string) string {
+	return fmt.Sprintf("%s/packages/%s/%s/download", r.URL, packageID, version)
+}
+
+// Candidates returns the URLs to try for a registry URL in order: the URL
+// itself, then the same path on each mirror. URLs outside the registry are
+// returned alone, since mirrors only copy the registry.
+func (r Registry) Candidates(url string) []string {
+	candidates := []string{url}
+	path, ok := strings.CutPrefix(url, r.URL)
+	if !ok || (path != "" && !strings.HasPrefix(path, "/")) {
+		return candidates
+	}
+	for _, mirror := range r.Mirrors {
+		candidates = append(candidates, mirror+path)
+	}
+	return candidates
+}
+
+func trimBaseURL(url string) string {
+	return strings.TrimRight(strings.TrimSpace(url), "/")
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRegistry(t *testing.T) {
+	registry := NewRegistry("")
+	assert.Equal(t, DefaultRegistryURL, registry.URL)
+	assert.Empty(t, registry.Mirrors)
+
+	registry = NewRegistry("https://plugins.example.com/", " https://mirror.example.com/ ", "")
+	assert.Equal(t, "https://plugins.example.com", registry.URL)
+	assert.Equal(t, []string{"https://mirror.example.com"}, registry.Mirrors)
+}
+
+func TestRegistry_DownloadURL(t *testing.T) {
+	registry := NewRegistry("https://plugins.example.com")
+	assert.Equal(t, "https://plugins.example.com/packages/acme.focus/1.2.0/download", registry.DownloadURL("acme.focus", "1.2.0"))
+}
+
+func TestRegistry_Candidates(t *testing.T) {
+	registry := NewRegistry("https://plugins.example.com", "https://mirror-a.example.com", "https://mirror-b.example.com")
+
+	assert.Equal(t, []string{
+		"https://plugins.example.com/packages/acme.focus/1.2.0/download",
+		"https://mirror-a.example.com/packages/acme.focus/1.2.0/download",
+		"https://mirror-b.example.com/packages/acme.focus/1.2.0/download",
+	}, registry.Candidates("https://plugins.example.com/packages/acme.focus/1.2.0/download"))
+
+	// Mirrors only copy the registry.
+	assert.Equal(t, []string{"https://cdn.example.net/focus.tar.gz"}, registry.Candidates("https://cdn.example.net/focus.tar.gz"))
+	assert.Equal(t, []string{"https://plugins.example.com.evil/x"}, registry.Candidates("https://plugins.example.com.evil/x"))
+}
@@ -0,0 +1,82 @@
+package cliplugin
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+)
+
+// Launcher starts the plugin serving a package's commands.
+type Launcher interface {
+	// Launch connects to the plugin binary of the manifest. The returned
+	// function stops the plugin and must be called when done.
+	Launch(manifest *Manifest) (Commander, func(), error)
+}
+
+// ProcessLauncher runs plugin binaries as child processes.
+type ProcessLauncher struct {
+	logger *slog.Logger
+}
+
+// NewProcessLauncher creates a launcher for plugin processes.
+func NewProcessLauncher(logger *slog.Logger) *ProcessLauncher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ProcessLauncher{logger: logger}
+}
+
+// Launch starts the plugin binary and dispenses its commander.
+func (l *ProcessLauncher) Launch(manifest *Manifest) (Commander, func(), error) {
+	binaryPath, err := filepath.EvalSymlinks(manifest.BinaryPath())
+	if err != nil {
+		return nil, nil, fmt.Errorf("plugin binary not found: %w", err)
+	}
+	dir, err := filepath.EvalSymlinks(manifest.dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve package directory: %w", err)
+	}
+	if rel, err := filepath.Rel(dir, binaryPath); err != nil || !filepath.IsLocal(rel) {
+		return nil, nil, fmt.Errorf("plugin binary escapes package directory: %s", binaryPath)
+	}
+	info, err := os.Stat(binaryPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("plugin binary not found: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, nil, fmt.Errorf("plugin binary is not a regular file: %s", binaryPath)
+	}
+
+	l.logger.Debug("launching cli plugin", "package_id", manifest.ID, "binary", binaryPath)
+
+	// #nosec G204 -- binary path is resolved inside the package directory
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  HandshakeConfig,
+		Plugins:          PluginMap(nil),
+		Cmd:              exec.Command(binaryPath),
+		Logger:           hclog.NewNullLogger(),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolNetRPC},
+	})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, nil, fmt.Errorf("failed to connect to plugin: %w", err)
+	}
+	raw, err := rpcClient.Dispense(PluginName)
+	if err != nil {
+		client.Kill()
+		return nil, nil, fmt.Errorf("failed to dispense plugin: %w", err)
+	}
+	commander, ok := raw.(Commander)
+	if !ok {
+		client.Kill()
+		return nil, nil, fmt.Errorf("plugin does not implement Commander")
+	}
+	return commander, client.Kill, nil
+}
@@ -0,0 +1,196 @@
+// Package cliplugin lets installed marketplace packages contribute CLI
+// commands. A package declares its commands in the "cli" section of its
+// manifest and serves them from a plugin binary over go-plugin's net/rpc
+// protocol.
+package cliplugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/security"
+)
+
+// ManifestFilenames are the manifest files searched in a package directory,
+// in order.
+var ManifestFilenames = []string{"engine.json", "orbit.json"}
+
+// ErrNoManifest is returned when a package directory contains no manifest.
+var ErrNoManifest = errors.New("no package manifest found")
+
+var commandNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Manifest is the part of a package manifest that describes CLI commands.
+type Manifest struct {
+	// ID is the package identifier.
+	ID string `json:"id"`
+
+	// CLI declares the commands the package adds. Nil if it adds none.
+	CLI *CLISpec `json:"cli,omitempty"`
+
+	dir string
+}
+
+// CLISpec declares the CLI commands of a package.
+type CLISpec struct {
+	// Namespace is the command the package's commands are grouped under.
+	// Defaults to the package ID.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Binary is the plugin binary serving the commands, relative to the
+	// package directory.
+	Binary string `json:"binary"`
+
+	// Commands lists the commands the plugin serves.
+	Commands []CommandSpec `json:"commands"`
+}
+
+// CommandSpec describes a single plugin command.
+type CommandSpec struct {
+	Name  string `json:"name"`
+	Short string `json:"short,omitempty"`
+	Long  string `json:"long,omitempty"`
+}
+
+// LoadManifest loads the manifest of the package installed in dir.
+func LoadManifest(dir string) (*Manifest, error) {
+	for _, name := range ManifestFilenames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		data, err := security.SafeReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest: %w", err)
+		}
+
+		var manifest Manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+		manifest.dir = dir
+
+		if err := manifest.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid manifest: %w", err)
+		}
+		return &manifest, nil
+	}
+	return nil, fmt.Errorf("%w in %s", ErrNoManifest, dir)
+}
+
+// Validate checks the CLI section of the manifest.
+func (m *Manifest) Validate() error {
+	if m.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	if m.CLI == nil {
+		return nil
+	}
+	if m.CLI.Binary == "" {
+		return fmt.Errorf("cli.binary is required")
+	}
+	if filepath.IsAbs(m.CLI.Binary) || !filepath.IsLocal(m.CLI.Binary) {
+		return fmt.Errorf("cli.binary must be a path inside the package: %s", m.CLI.Binary)
+	}
+	if m.CLI.Namespace != "" && !commandNamePattern.MatchString(m.CLI.Namespace) {
+		return fmt.Errorf("invalid cli.namespace: %s", m.CLI.Namespace)
+	}
+
+	seen := make(map[string]bool, len(m.CLI.Commands))
+	for _, cmd := range m.CLI.Commands {
+		if !commandNamePattern.MatchString(cmd.Name) {
+			return fmt.Errorf("invalid command name: %q", cmd.Name)
+		}
+		if seen[cmd.Name] {
+			return fmt.Errorf("duplicate command name: %s", cmd.Name)
+		}
+		seen[cmd.Name] = true
+	}
+	return nil
+}
+
+// Namespace returns the command the package's commands are grouped under.
+func (m *Manifest) Namespace() string {
+	if m.CLI != nil && m.CLI.Namespace != "" {
+		return m.CLI.Namespace
+	}
+	return m.ID
+}
+
+// BinaryPath returns the absolute path to the plugin binary.
+func (m *Manifest) BinaryPath() string {
+	if m.CLI == nil {
+		return ""
+	}
+	return filepath.Join(m.dir, m.CLI.Binary)
+}
+
+// FindInstalled returns the directories of the packages installed under
+// installDir, which lays packages out as <type>s/<package id>/<version>.
+// When a package has several versions on disk, the most recently installed
+// one is returned. A missing installDir holds no packages.
+func FindInstalled(installDir string) ([]string, error) {
+	typeDirs, err := os.ReadDir(installDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read install directory: %w", err)
+	}
+
+	var dirs []string
+	for _, typeDir := range typeDirs {
+		if !typeDir.IsDir() {
+			continue
+		}
+		packageDirs, err := os.ReadDir(filepath.Join(installDir, typeDir.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read install directory: %w", err)
+		}
+		for _, packageDir := range packageDirs {
+			if !packageDir.IsDir() {
+				continue
+			}
+			dir, err := latestVersionDir(filepath.Join(installDir, typeDir.Name(), packageDir.Name()))
+			if err != nil {
+				return nil, err
+			}
+			if dir != "" {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// latestVersionDir returns the most recently modified version directory of
+// a package, or "" if it has none.
+func latestVersionDir(packageDir string) (string, error) {
+	versionDirs, err := os.ReadDir(packageDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read package directory: %w", err)
+	}
+
+	var latest string
+	var latestTime time.Time
+	for _, versionDir := range versionDirs {
+		if !versionDir.IsDir() {
+			continue
+		}
+		info, err := versionDir.Info()
+		if err != nil {
+			return "", fmt.Errorf("failed to read package directory: %w", err)
+		}
+		if latest == "" || info.ModTime().After(latestTime) {
+			latest = filepath.Join(packageDir, versionDir.Name())
+			latestTime = info.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,96 @@
+package cliplugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifest(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	return dir
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := writeManifest(t, "engine.json", `{
+		"id": "acme.priority",
+		"cli": {"namespace": "priority", "binary": "bin/cli", "commands": [{"name": "explain"}]}
+	}`)
+
+	manifest, err := LoadManifest(dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, "acme.priority", manifest.ID)
+	assert.Equal(t, "priority", manifest.Namespace())
+	assert.Equal(t, filepath.Join(dir, "bin", "cli"), manifest.BinaryPath())
+	require.Len(t, manifest.CLI.Commands, 1)
+	assert.Equal(t, "explain", manifest.CLI.Commands[0].Name)
+}
+
+func TestLoadManifest_NotFound(t *testing.T) {
+	_, err := LoadManifest(t.TempDir())
+	assert.ErrorIs(t, err, ErrNoManifest)
+}
+
+func TestManifest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest Manifest
+		errMsg   string
+	}{
+		{"no cli section", Manifest{ID: "acme.quiet"}, ""},
+		{"missing id", Manifest{}, "id is required"},
+		{"missing binary", Manifest{ID: "acme.x", CLI: &CLISpec{}}, "cli.binary is required"},
+		{"absolute binary", Manifest{ID: "acme.x", CLI: &CLISpec{Binary: "/usr/bin/env"}}, "inside the package"},
+		{"escaping binary", Manifest{ID: "acme.x", CLI: &CLISpec{Binary: "../bin"}}, "inside the package"},
+		{"invalid namespace", Manifest{ID: "acme.x", CLI: &CLISpec{Binary: "bin", Namespace: "Bad Name"}}, "invalid cli.namespace"},
+		{"invalid command", Manifest{ID: "acme.x", CLI: &CLISpec{Binary: "bin", Commands: []CommandSpec{{Name: "-x"}}}}, "invalid command name"},
+		{"duplicate command", Manifest{ID: "acme.x", CLI: &CLISpec{Binary: "bin", Commands: []CommandSpec{{Name: "run"}, {Name: "run"}}}}, "duplicate command name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestFindInstalled(t *testing.T) {
+	installDir := t.TempDir()
+	mkdir := func(parts ...string) string {
+		dir := filepath.Join(append([]string{installDir}, parts...)...)
+		require.NoError(t, os.MkdirAll(dir, 0750))
+		return dir
+	}
+
+	focus := mkdir("orbits", "acme.focus", "1.0.0")
+	oldPriority := mkdir("engines", "acme.priority", "1.0.0")
+	newPriority := mkdir("engines", "acme.priority", "1.1.0")
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(oldPriority, past, past))
+	mkdir("engines", "acme.empty")
+
+	dirs, err := FindInstalled(installDir)
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{focus, newPriority}, dirs)
+}
+
+func TestFindInstalled_MissingDir(t *testing.T) {
+	dirs, err := FindInstalled(filepath.Join(t.TempDir(), "missing"))
+
+	require.NoError(t, err)
+	assert.Empty(t, dirs)
+}
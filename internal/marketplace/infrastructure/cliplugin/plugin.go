@@ -0,0 +1,98 @@
+package cliplugin
+
+import (
+	"net/rpc"
+
+	"github.com/hashicorp/go-plugin"
+)
+
+// HandshakeConfig is shared by the CLI and command plugins.
+var HandshakeConfig = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "ORBITA_CLI_PLUGIN",
+	MagicCookieValue: "orbita-cli-v1",
+}
+
+// PluginName is the name command plugins are dispensed under.
+const PluginName = "commands"
+
+// CommandRequest is a single command invocation sent to a plugin.
+type CommandRequest struct {
+	// Command is the name of the command from the manifest.
+	Command string
+	// Args are the positional arguments and flags after the command name.
+	Args []string
+	// UserID is the current user, empty if none is configured.
+	UserID string
+}
+
+// CommandResponse is the result of a command invocation.
+type CommandResponse struct {
+	// Output is written to stdout.
+	Output string
+	// Error is written to stderr and fails the command when not empty.
+	Error string
+}
+
+// Commander runs plugin commands. Package authors implement it and pass it
+// to Serve; the CLI receives an RPC-backed implementation from a Launcher.
+type Commander interface {
+	RunCommand(req CommandRequest) (*CommandResponse, error)
+}
+
+// CommandPlugin is the plugin.Plugin implementation for command plugins.
+type CommandPlugin struct {
+	// Impl is the concrete implementation (plugin-side).
+	Impl Commander
+}
+
+// Server returns the RPC server for the plugin side.
+func (p *CommandPlugin) Server(*plugin.MuxBroker) (any, error) {
+	return &commandRPCServer{impl: p.Impl}, nil
+}
+
+// Client returns the RPC client for the host side.
+func (p *CommandPlugin) Client(_ *plugin.MuxBroker, c *rpc.Client) (any, error) {
+	return &commandRPCClient{client: c}, nil
+}
+
+// PluginMap returns the plugin map served by command plugins.
+func PluginMap(impl Commander) map[string]plugin.Plugin {
+	return map[string]plugin.Plugin{PluginName: &CommandPlugin{Impl: impl}}
+}
+
+// Serve serves impl as a command plugin. It is called from the plugin
+// binary's main function and blocks until the CLI disconnects.
+func Serve(impl Commander) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: HandshakeConfig,
+		Plugins:         PluginMap(impl),
+	})
+}
+
+type commandRPCClient struct {
+	client *rpc.Client
+}
+
+func (c *commandRPCClient) RunCommand(req CommandRequest) (*CommandResponse, error) {
+	var resp CommandResponse
+	if err := c.client.Call("Plugin.RunCommand", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+type commandRPCServer struct {
+	impl Commander
+}
+
+func (s *commandRPCServer) RunCommand(req CommandRequest, resp *CommandResponse) error {
+	result, err := s.impl.RunCommand(req)
+	if err != nil {
+		return err
+	}
+	if result != nil {
+		*resp = *result
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package credentials
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+var (
+	// ErrSecretNotFound is returned when the keyring holds no secret for the entry.
+	ErrSecretNotFound = errors.New("secret not found in keyring")
+)
+
+// Keyring stores secrets in an operating system credential store.
+type Keyring interface {
+	Get(service, account string) (string, error)
+	Set(service, account, secret string) error
+	Delete(service, account string) error
+}
+
+// NewSystemKeyring returns the keyring of the current operating system, or nil
+// when none is available. macOS uses the login keychain through `security`;
+// Linux uses the Secret Service through `secret-tool` (libsecret).
+func NewSystemKeyring() Keyring {
+	switch runtime.GOOS {
+	case "darwin":
+		if path, err := exec.LookPath("security"); err == nil {
+			return &keychainKeyring{bin: path}
+		}
+	case "linux":
+		if path, err := exec.LookPath("secret-tool"); err == nil {
+			return &secretServiceKeyring{bin: path}
+		}
+	}
+	return nil
+}
+
+// keychainKeyring stores secrets in the macOS keychain.
+type keychainKeyring struct {
+	bin string
+}
+
+func (k *keychainKeyring) Get(service, account string) (string, error) {
+	// #nosec G204 - binary resolved via LookPath, arguments are not shell-interpreted
+	out, err := exec.Command(k.bin, "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", ErrSecretNotFound
+		}
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+func (k *keychainKeyring) Set(service, account, secret string) error {
+	// `security add-generic-password -w` only accepts the password as an
+	// argument, so the command is fed to `security -i` on stdin instead to
+	// keep the secret out of the process list.
+	line, err := keychainCommandLine("add-generic-password", "-U", "-s", service, "-a", account, "-w", secret)
+	if err != nil {
+		return err
+	}
+	// #nosec G204 - binary resolved via LookPath, arguments are not shell-interpreted
+	cmd := exec.Command(k.bin, "-i")
+	cmd.Stdin = bytes.NewBufferString(line)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("keychain: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+func (k *keychainKeyring) Delete(service, account string) error {
+	// #nosec G204 - binary resolved via LookPath, arguments are not shell-interpreted
+	if err := exec.Command(k.bin, "delete-generic-password", "-s", service, "-a", account).Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return ErrSecretNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// keychainCommandLine builds a line for `security -i`, double-quoting each
+// argument. Arguments that cannot be quoted safely are rejected.
+func keychainCommandLine(args ...string) (string, error) {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, "\"\\\r\n") {
+			return "", fmt.Errorf("keychain: unsupported character in argument %d", i+1)
+		}
+		quoted[i] = `"` + arg + `"`
+	}
+	return strings.Join(quoted, " ") + "\n", nil
+}
+
+// secretServiceKeyring stores secrets through the freedesktop Secret Service.
+type secretServiceKeyring struct {
+	bin string
+}
+
+func (k *secretServiceKeyring) Get(service, account string) (string, error) {
+	// #nosec G204 - binary resolved via LookPath, arguments are not shell-interpreted
+	out, err := exec.Command(k.bin, "lookup", "service", service, "account", account).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", ErrSecretNotFound
+		}
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", ErrSecretNotFound
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+func (k *secretServiceKeyring) Set(service, account, secret string) error {
+	// #nosec G204 - binary resolved via LookPath, arguments are not shell-interpreted
+	cmd := exec.Command(k.bin, "store", "--label", service, "service", service, "account", account)
+	// Pass the secret on stdin so it never appears in the process list.
+	cmd.Stdin = bytes.NewBufferString(secret)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("secret service: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+func (k *secretServiceKeyring) Delete(service, account string) error {
+	// #nosec G204 - binary resolved via LookPath, arguments are not shell-interpreted
+	return exec.Command(k.bin, "clear", "service", service, "account", account).Run()
+}
@@ -0,0 +1,19 @@
+package credentials
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeychainCommandLine(t *testing.T) {
+	line, err := keychainCommandLine("add-generic-password", "-w", "c2VjcmV0+/=")
+	require.NoError(t, err)
+	assert.Equal(t, `"add-generic-password" "-w" "c2VjcmV0+/="`+"\n", line)
+
+	for _, arg := range []string{`a"b`, `a\b`, "a\nb"} {
+		_, err := keychainCommandLine("-w", arg)
+		assert.Error(t, err, arg)
+	}
+}
@@ -0,0 +1,195 @@
+package credentials
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/security"
+)
+
+const (
+	// KeyringService is the service name used for keyring entries.
+	KeyringService = "orbita-marketplace"
+	// KeyringAccount is the account name used for the API token entry.
+	KeyringAccount = "api-token"
+	// KeyringKeyAccount is the account name used for the local encryption key.
+	KeyringKeyAccount = "encryption-key"
+
+	tokenFileName = "marketplace.token"
+	keyFileName   = "marketplace.key"
+)
+
+// ErrTokenNotFound is returned when no token has been stored. It is a
+// not-found error, so callers can test for it with sharedApplication.ErrNotFound.
+var ErrTokenNotFound = sharedApplication.NewNotFoundError("marketplace token not found")
+
+// TokenStore persists the marketplace API token encrypted with AES-GCM.
+// The ciphertext is kept in the OS keyring when one is available and falls
+// back to a 0600 file in the config directory otherwise.
+type TokenStore struct {
+	dir       string
+	encrypter sharedCrypto.Encrypter
+	keyring   Keyring
+	mu        sync.Mutex
+}
+
+// NewTokenStore creates a token store rooted at dir. A nil encrypter makes the
+// store generate a local key on first use (see LocalEncrypter); a nil keyring
+// keeps the token, and any local key, in files only.
+func NewTokenStore(dir string, encrypter sharedCrypto.Encrypter, keyring Keyring) *TokenStore {
+	return &TokenStore{
+		dir:       dir,
+		encrypter: encrypter,
+		keyring:   keyring,
+	}
+}
+
+// Save encrypts and stores the token, replacing any previous one.
+func (s *TokenStore) Save(token string) error {
+	enc, err := s.getEncrypter()
+	if err != nil {
+		return err
+	}
+	ciphertext, err := enc.Encrypt([]byte(token))
+	if err != nil {
+		return fmt.Errorf("encrypt token: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(ciphertext)
+
+	if s.keyring != nil {
+		if err := s.keyring.Set(KeyringService, KeyringAccount, encoded); err == nil {
+			// Don't leave a stale copy behind from an earlier file-based login.
+			return security.SecureRemove(s.tokenPath())
+		}
+	}
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.tokenPath(), []byte(encoded), 0600)
+}
+
+// Load returns the decrypted token, or ErrTokenNotFound.
+func (s *TokenStore) Load() (string, error) {
+	encoded, err := s.loadCiphertext()
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("decode token: %w", err)
+	}
+	enc, err := s.getEncrypter()
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := enc.Decrypt(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decrypt token: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// Erase removes the token from the keyring and overwrites the token file
+// before deleting it. Erasing when nothing is stored is not an error.
+func (s *TokenStore) Erase() error {
+	if s.keyring != nil {
+		if err := s.keyring.Delete(KeyringService, KeyringAccount); err != nil && !errors.Is(err, ErrSecretNotFound) {
+			return fmt.Errorf("remove token from keyring: %w", err)
+		}
+	}
+	return security.SecureRemove(s.tokenPath())
+}
+
+func (s *TokenStore) loadCiphertext() (string, error) {
+	if s.keyring != nil {
+		if encoded, err := s.keyring.Get(KeyringService, KeyringAccount); err == nil && encoded != "" {
+			return encoded, nil
+		}
+	}
+
+	// #nosec G304 - path is internally constructed from the config dir + fixed filename
+	data, err := os.ReadFile(s.tokenPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrTokenNotFound
+		}
+		return "", err
+	}
+	encoded := strings.TrimSpace(string(data))
+	if encoded == "" {
+		return "", ErrTokenNotFound
+	}
+	return encoded, nil
+}
+
+func (s *TokenStore) getEncrypter() (sharedCrypto.Encrypter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.encrypter == nil {
+		enc, err := LocalEncrypter(s.dir, s.keyring)
+		if err != nil {
+			return nil, err
+		}
+		s.encrypter = enc
+	}
+	return s.encrypter, nil
+}
+
+func (s *TokenStore) tokenPath() string {
+	return filepath.Join(s.dir, tokenFileName)
+}
+
+// LocalEncrypter returns an AES-GCM encrypter keyed by a random 32-byte key,
+// creating the key on first use. It is the fallback when no
+// ORBITA_ENCRYPTION_KEY is configured. The key is kept in keyring when one is
+// given, so it never sits next to the token file; without a keyring, or when
+// the keyring rejects it, it is kept in dir.
+func LocalEncrypter(dir string, keyring Keyring) (*sharedCrypto.AESEncrypter, error) {
+	keyPath := filepath.Join(dir, keyFileName)
+
+	if keyring != nil {
+		if encoded, err := keyring.Get(KeyringService, KeyringKeyAccount); err == nil && encoded != "" {
+			return sharedCrypto.NewAESGCMFromBase64Key(encoded)
+		}
+	}
+
+	// #nosec G304 - path is internally constructed from the config dir + fixed filename
+	data, err := os.ReadFile(keyPath)
+	if err == nil {
+		return sharedCrypto.NewAESGCMFromBase64Key(strings.TrimSpace(string(data)))
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(key)
+
+	if keyring != nil {
+		if err := keyring.Set(KeyringService, KeyringKeyAccount, encoded); err == nil {
+			return sharedCrypto.NewAESGCMFromBase64Key(encoded)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(keyPath, []byte(encoded), 0600); err != nil {
+		return nil, err
+	}
+	return sharedCrypto.NewAESGCMFromBase64Key(encoded)
+}
@@ -0,0 +1,165 @@
+package credentials
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeKeyring struct {
+	secrets map[string]string
+	setErr  error
+}
+
+func newFakeKeyring() *fakeKeyring {
+	return &fakeKeyring{secrets: make(map[string]string)}
+}
+
+func (k *fakeKeyring) Get(service, account string) (string, error) {
+	secret, ok := k.secrets[service+"/"+account]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return secret, nil
+}
+
+func (k *fakeKeyring) Set(service, account, secret string) error {
+	if k.setErr != nil {
+		return k.setErr
+	}
+	k.secrets[service+"/"+account] = secret
+	return nil
+}
+
+func (k *fakeKeyring) Delete(service, account string) error {
+	if _, ok := k.secrets[service+"/"+account]; !ok {
+		return ErrSecretNotFound
+	}
+	delete(k.secrets, service+"/"+account)
+	return nil
+}
+
+func newTestEncrypter(t *testing.T) *sharedCrypto.AESEncrypter {
+	t.Helper()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	enc, err := sharedCrypto.NewAESGCMFromBase64Key(base64.StdEncoding.EncodeToString(key))
+	require.NoError(t, err)
+	return enc
+}
+
+func TestTokenStore_FileRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	store := NewTokenStore(dir, newTestEncrypter(t), nil)
+
+	require.NoError(t, store.Save("publisher-secret-token"))
+
+	data, err := os.ReadFile(filepath.Join(dir, tokenFileName))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "publisher-secret-token")
+
+	info, err := os.Stat(filepath.Join(dir, tokenFileName))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	token, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "publisher-secret-token", token)
+}
+
+func TestTokenStore_LocalKey(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, NewTokenStore(dir, nil, nil).Save("token-1"))
+
+	_, err := os.Stat(filepath.Join(dir, keyFileName))
+	require.NoError(t, err)
+
+	// A fresh store picks up the generated key.
+	token, err := NewTokenStore(dir, nil, nil).Load()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+}
+
+func TestTokenStore_LocalKeyInKeyring(t *testing.T) {
+	dir := t.TempDir()
+	keyring := newFakeKeyring()
+	require.NoError(t, NewTokenStore(dir, nil, keyring).Save("token-1"))
+
+	// The key never lands next to the token.
+	assert.NoFileExists(t, filepath.Join(dir, keyFileName))
+	_, err := keyring.Get(KeyringService, KeyringKeyAccount)
+	require.NoError(t, err)
+
+	token, err := NewTokenStore(dir, nil, keyring).Load()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+}
+
+func TestTokenStore_WrongKeyFails(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, NewTokenStore(dir, newTestEncrypter(t), nil).Save("token-1"))
+
+	_, err := NewTokenStore(dir, newTestEncrypter(t), nil).Load()
+	assert.Error(t, err)
+}
+
+func TestTokenStore_Keyring(t *testing.T) {
+	t.Run("stores ciphertext in the keyring", func(t *testing.T) {
+		dir := t.TempDir()
+		keyring := newFakeKeyring()
+		store := NewTokenStore(dir, newTestEncrypter(t), keyring)
+
+		require.NoError(t, store.Save("publisher-secret-token"))
+
+		secret, err := keyring.Get(KeyringService, KeyringAccount)
+		require.NoError(t, err)
+		assert.NotContains(t, secret, "publisher-secret-token")
+		assert.NoFileExists(t, filepath.Join(dir, tokenFileName))
+
+		token, err := store.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "publisher-secret-token", token)
+
+		require.NoError(t, store.Erase())
+		assert.Empty(t, keyring.secrets)
+		_, err = store.Load()
+		assert.ErrorIs(t, err, ErrTokenNotFound)
+	})
+
+	t.Run("falls back to the encrypted file", func(t *testing.T) {
+		dir := t.TempDir()
+		keyring := newFakeKeyring()
+		keyring.setErr = errors.New("keyring locked")
+		store := NewTokenStore(dir, newTestEncrypter(t), keyring)
+
+		require.NoError(t, store.Save("publisher-secret-token"))
+		assert.FileExists(t, filepath.Join(dir, tokenFileName))
+
+		token, err := store.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "publisher-secret-token", token)
+	})
+}
+
+func TestTokenStore_Erase(t *testing.T) {
+	dir := t.TempDir()
+	store := NewTokenStore(dir, newTestEncrypter(t), nil)
+	require.NoError(t, store.Save("publisher-secret-token"))
+
+	require.NoError(t, store.Erase())
+	assert.NoFileExists(t, filepath.Join(dir, tokenFileName))
+
+	_, err := store.Load()
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+
+	// Erasing again is a no-op.
+	assert.NoError(t, store.Erase())
+}
@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/marketplace/application/commands"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresAPITokenRepository implements commands.APITokenRepository using PostgreSQL.
+type PostgresAPITokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresAPITokenRepository creates a new PostgreSQL API token repository.
+func NewPostgresAPITokenRepository(pool *pgxpool.Pool) *PostgresAPITokenRepository {
+	return &PostgresAPITokenRepository{pool: pool}
+}
+
+// Create stores a new API token.
+func (r *PostgresAPITokenRepository) Create(ctx context.Context, token *commands.APIToken) error {
+	query := `
+		INSERT INTO marketplace_api_tokens (
+			id, publisher_id, name, token_hash, scopes, last_used_at, expires_at, created_at, revoked_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	scopes := token.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	_, err := r.pool.Exec(ctx, query,
+		token.ID, token.PublisherID, token.Name, token.TokenHash, scopes,
+		token.LastUsedAt, token.ExpiresAt, token.CreatedAt, token.RevokedAt,
+	)
+	return err
+}
+
+// GetByHash retrieves a token by the hash of its secret.
+func (r *PostgresAPITokenRepository) GetByHash(ctx context.Context, tokenHash string) (*commands.APIToken, error) {
+	query := `
+		SELECT id, publisher_id, name, token_hash, scopes, last_used_at, expires_at, created_at, revoked_at
+		FROM marketplace_api_tokens WHERE token_hash = $1
+	`
+	return r.scanToken(r.pool.QueryRow(ctx, query, tokenHash))
+}
+
+// UpdateLastUsed records that a token was just used.
+func (r *PostgresAPITokenRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, "UPDATE marketplace_api_tokens SET last_used_at = NOW() WHERE id = $1", id)
+	return err
+}
+
+// Revoke marks a token as revoked.
+func (r *PostgresAPITokenRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, "UPDATE marketplace_api_tokens SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL", id)
+	return err
+}
+
+// ListByPublisher retrieves all tokens of a publisher, newest first.
+func (r *PostgresAPITokenRepository) ListByPublisher(ctx context.Context, publisherID uuid.UUID) ([]*commands.APIToken, error) {
+	query := `
+		SELECT id, publisher_id, name, token_hash, scopes, last_used_at, expires_at, created_at, revoked_at
+		FROM marketplace_api_tokens
+		WHERE publisher_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, publisherID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*commands.APIToken
+	for rows.Next() {
+		token, err := r.scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *PostgresAPITokenRepository) scanToken(row pgx.Row) (*commands.APIToken, error) {
+	t := &commands.APIToken{}
+
+	err := row.Scan(
+		&t.ID, &t.PublisherID, &t.Name, &t.TokenHash, &t.Scopes,
+		&t.LastUsedAt, &t.ExpiresAt, &t.CreatedAt, &t.RevokedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	"github.com/google/uuid"
+)
+
+const sqliteInstalledColumns = `id, package_id, version, type, install_path, checksum,
+		       installed_at, updated_at, enabled, user_id`
+
+// SQLiteInstalledPackageRepository implements domain.InstalledPackageRepository using SQLite.
+type SQLiteInstalledPackageRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteInstalledPackageRepository creates a new SQLite installed package repository.
+func NewSQLiteInstalledPackageRepository(db *sql.DB) *SQLiteInstalledPackageRepository {
+	return &SQLiteInstalledPackageRepository{db: db}
+}
+
+// Create saves a new installed package.
+func (r *SQLiteInstalledPackageRepository) Create(ctx context.Context, pkg *domain.InstalledPackage) error {
+	query := `
+		INSERT INTO installed_packages (
+			id, package_id, version, type, install_path, checksum,
+			installed_at, updated_at, enabled, user_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		pkg.ID.String(),
+		pkg.PackageID,
+		pkg.Version,
+		string(pkg.Type),
+		pkg.InstallPath,
+		pkg.Checksum,
+		pkg.InstalledAt.Format(time.RFC3339),
+		pkg.UpdatedAt.Format(time.RFC3339),
+		boolToInt(pkg.Enabled),
+		pkg.UserID.String(),
+	)
+	return err
+}
+
+// Update updates an existing installed package.
+func (r *SQLiteInstalledPackageRepository) Update(ctx context.Context, pkg *domain.InstalledPackage) error {
+	query := `
+		UPDATE installed_packages SET
+			version = ?,
+			install_path = ?,
+			checksum = ?,
+			updated_at = ?,
+			enabled = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		pkg.Version,
+		pkg.InstallPath,
+		pkg.Checksum,
+		pkg.UpdatedAt.Format(time.RFC3339),
+		boolToInt(pkg.Enabled),
+		pkg.ID.String(),
+	)
+	return err
+}
+
+// Delete removes an installed package.
+func (r *SQLiteInstalledPackageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM installed_packages WHERE id = ?", id.String())
+	return err
+}
+
+// GetByID retrieves an installed package by ID.
+func (r *SQLiteInstalledPackageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.InstalledPackage, error) {
+	query := "SELECT " + sqliteInstalledColumns + " FROM installed_packages WHERE id = ?"
+	return r.scanInstalled(r.db.QueryRowContext(ctx, query, id.String()))
+}
+
+// GetByPackageID retrieves an installed package by package ID and user.
+func (r *SQLiteInstalledPackageRepository) GetByPackageID(ctx context.Context, packageID string, userID uuid.UUID) (*domain.InstalledPackage, error) {
+	query := "SELECT " + sqliteInstalledColumns + " FROM installed_packages WHERE package_id = ? AND user_id = ?"
+	return r.scanInstalled(r.db.QueryRowContext(ctx, query, packageID, userID.String()))
+}
+
+// ListByUser retrieves all installed packages for a user.
+func (r *SQLiteInstalledPackageRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.InstalledPackage, error) {
+	query := "SELECT " + sqliteInstalledColumns + `
+		FROM installed_packages
+		WHERE user_id = ?
+		ORDER BY installed_at DESC`
+	return r.list(ctx, query, userID.String())
+}
+
+// ListByType retrieves installed packages by type for a user.
+func (r *SQLiteInstalledPackageRepository) ListByType(ctx context.Context, userID uuid.UUID, pkgType domain.PackageType) ([]*domain.InstalledPackage, error) {
+	query := "SELECT " + sqliteInstalledColumns + `
+		FROM installed_packages
+		WHERE user_id = ? AND type = ?
+		ORDER BY installed_at DESC`
+	return r.list(ctx, query, userID.String(), string(pkgType))
+}
+
+func (r *SQLiteInstalledPackageRepository) list(ctx context.Context, query string, args ...any) ([]*domain.InstalledPackage, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var packages []*domain.InstalledPackage
+	for rows.Next() {
+		pkg, err := r.scanInstalled(rows)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, rows.Err()
+}
+
+func (r *SQLiteInstalledPackageRepository) scanInstalled(row sqliteScanner) (*domain.InstalledPackage, error) {
+	pkg := &domain.InstalledPackage{}
+	var id, pkgType, installedAt, updatedAt, userID string
+
+	err := row.Scan(
+		&id,
+		&pkg.PackageID,
+		&pkg.Version,
+		&pkgType,
+		&pkg.InstallPath,
+		&pkg.Checksum,
+		&installedAt,
+		&updatedAt,
+		&pkg.Enabled,
+		&userID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if pkg.ID, err = uuid.Parse(id); err != nil {
+		return nil, err
+	}
+	if pkg.UserID, err = uuid.Parse(userID); err != nil {
+		return nil, err
+	}
+	pkg.Type = domain.PackageType(pkgType)
+	pkg.InstalledAt, _ = time.Parse(time.RFC3339, installedAt)
+	pkg.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return pkg, nil
+}
@@ -0,0 +1,290 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	"github.com/google/uuid"
+)
+
+const sqlitePackageColumns = `id, package_id, type, name, description, author, homepage, license,
+			tags, latest_version, downloads, rating, rating_count, verified,
+			featured, publisher_id, created_at, updated_at`
+
+// SQLitePackageRepository implements domain.PackageRepository using SQLite.
+type SQLitePackageRepository struct {
+	db *sql.DB
+}
+
+// NewSQLitePackageRepository creates a new SQLite package repository.
+func NewSQLitePackageRepository(db *sql.DB) *SQLitePackageRepository {
+	return &SQLitePackageRepository{db: db}
+}
+
+// Create creates a new package.
+func (r *SQLitePackageRepository) Create(ctx context.Context, pkg *domain.Package) error {
+	tags, err := json.Marshal(nonNilTags(pkg.Tags))
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO marketplace_packages (
+			id, package_id, type, name, description, author, homepage, license,
+			tags, latest_version, downloads, rating, rating_count, verified,
+			featured, publisher_id, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		pkg.ID.String(), pkg.PackageID, string(pkg.Type), pkg.Name, pkg.Description, pkg.Author,
+		pkg.Homepage, pkg.License, string(tags), pkg.LatestVersion, pkg.Downloads,
+		pkg.Rating, pkg.RatingCount, boolToInt(pkg.Verified), boolToInt(pkg.Featured),
+		nullableUUID(pkg.PublisherID),
+		pkg.CreatedAt.Format(time.RFC3339), pkg.UpdatedAt.Format(time.RFC3339),
+	)
+	return err
+}
+
+// Update updates an existing package.
+func (r *SQLitePackageRepository) Update(ctx context.Context, pkg *domain.Package) error {
+	tags, err := json.Marshal(nonNilTags(pkg.Tags))
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE marketplace_packages SET
+			name = ?, description = ?, author = ?, homepage = ?, license = ?,
+			tags = ?, latest_version = ?, downloads = ?, rating = ?,
+			rating_count = ?, verified = ?, featured = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		pkg.Name, pkg.Description, pkg.Author, pkg.Homepage, pkg.License,
+		string(tags), pkg.LatestVersion, pkg.Downloads, pkg.Rating,
+		pkg.RatingCount, boolToInt(pkg.Verified), boolToInt(pkg.Featured),
+		pkg.UpdatedAt.Format(time.RFC3339), pkg.ID.String(),
+	)
+	return err
+}
+
+// Delete deletes a package by ID.
+func (r *SQLitePackageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM marketplace_packages WHERE id = ?", id.String())
+	return err
+}
+
+// GetByID retrieves a package by ID.
+func (r *SQLitePackageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Package, error) {
+	query := "SELECT " + sqlitePackageColumns + " FROM marketplace_packages WHERE id = ?"
+	return r.scanPackage(r.db.QueryRowContext(ctx, query, id.String()))
+}
+
+// GetByPackageID retrieves a package by its package ID.
+func (r *SQLitePackageRepository) GetByPackageID(ctx context.Context, packageID string) (*domain.Package, error) {
+	query := "SELECT " + sqlitePackageColumns + " FROM marketplace_packages WHERE package_id = ?"
+	return r.scanPackage(r.db.QueryRowContext(ctx, query, packageID))
+}
+
+// List retrieves packages with filtering and pagination.
+func (r *SQLitePackageRepository) List(ctx context.Context, filter domain.PackageFilter) ([]*domain.Package, int64, error) {
+	return r.listWhere(ctx, "1=1", nil, filter)
+}
+
+// Search searches packages by query string.
+func (r *SQLitePackageRepository) Search(ctx context.Context, query string, filter domain.PackageFilter) ([]*domain.Package, int64, error) {
+	pattern := "%" + query + "%"
+	return r.listWhere(ctx,
+		"(name LIKE ? OR description LIKE ? OR package_id LIKE ? OR author LIKE ?)",
+		[]any{pattern, pattern, pattern, pattern}, filter)
+}
+
+// GetFeatured retrieves featured packages.
+func (r *SQLitePackageRepository) GetFeatured(ctx context.Context, limit int) ([]*domain.Package, error) {
+	query := "SELECT " + sqlitePackageColumns + `
+		FROM marketplace_packages
+		WHERE featured = 1
+		ORDER BY downloads DESC
+		LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPackages(rows)
+}
+
+// GetByPublisher retrieves packages by publisher ID.
+func (r *SQLitePackageRepository) GetByPublisher(ctx context.Context, publisherID uuid.UUID, filter domain.PackageFilter) ([]*domain.Package, int64, error) {
+	return r.listWhere(ctx, "publisher_id = ?", []any{publisherID.String()}, filter)
+}
+
+// IncrementDownloads increments the download count for a package.
+func (r *SQLitePackageRepository) IncrementDownloads(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE marketplace_packages SET downloads = downloads + 1 WHERE id = ?",
+		id.String(),
+	)
+	return err
+}
+
+// listWhere counts and pages the packages matching where and the filter.
+func (r *SQLitePackageRepository) listWhere(ctx context.Context, where string, args []any, filter domain.PackageFilter) ([]*domain.Package, int64, error) {
+	conditions, args := sqliteFilterConditions(filter, args)
+	baseQuery := "FROM marketplace_packages WHERE " + where + conditions
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) "+baseQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = -1 // no limit
+	}
+	selectQuery := fmt.Sprintf("SELECT %s %s %s LIMIT ? OFFSET ?", sqlitePackageColumns, baseQuery, sqliteOrderClause(filter))
+	rows, err := r.db.QueryContext(ctx, selectQuery, append(args, limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	packages, err := r.scanPackages(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return packages, total, nil
+}
+
+func sqliteFilterConditions(filter domain.PackageFilter, args []any) (string, []any) {
+	var conditions strings.Builder
+
+	if filter.Type != nil {
+		conditions.WriteString(" AND type = ?")
+		args = append(args, string(*filter.Type))
+	}
+
+	if len(filter.Tags) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(filter.Tags)), ", ")
+		conditions.WriteString(" AND EXISTS (SELECT 1 FROM json_each(marketplace_packages.tags) WHERE json_each.value IN (" + placeholders + "))")
+		for _, tag := range filter.Tags {
+			args = append(args, tag)
+		}
+	}
+
+	if filter.Verified != nil {
+		conditions.WriteString(" AND verified = ?")
+		args = append(args, boolToInt(*filter.Verified))
+	}
+
+	if filter.Featured != nil {
+		conditions.WriteString(" AND featured = ?")
+		args = append(args, boolToInt(*filter.Featured))
+	}
+
+	return conditions.String(), args
+}
+
+func sqliteOrderClause(filter domain.PackageFilter) string {
+	sortField := "downloads"
+	switch filter.SortBy {
+	case domain.SortByCreatedAt:
+		sortField = "created_at"
+	case domain.SortByUpdatedAt:
+		sortField = "updated_at"
+	case domain.SortByDownloads:
+		sortField = "downloads"
+	case domain.SortByRating:
+		sortField = "rating"
+	case domain.SortByName:
+		sortField = "name"
+	}
+
+	order := "DESC"
+	if filter.SortOrder == domain.SortAsc {
+		order = "ASC"
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s", sortField, order)
+}
+
+type sqliteScanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *SQLitePackageRepository) scanPackage(row sqliteScanner) (*domain.Package, error) {
+	pkg := &domain.Package{}
+	var id, pkgType, tags, createdAt, updatedAt string
+	var publisherID sql.NullString
+
+	err := row.Scan(
+		&id, &pkg.PackageID, &pkgType, &pkg.Name, &pkg.Description,
+		&pkg.Author, &pkg.Homepage, &pkg.License, &tags,
+		&pkg.LatestVersion, &pkg.Downloads, &pkg.Rating, &pkg.RatingCount,
+		&pkg.Verified, &pkg.Featured, &publisherID, &createdAt, &updatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if pkg.ID, err = uuid.Parse(id); err != nil {
+		return nil, err
+	}
+	pkg.Type = domain.PackageType(pkgType)
+	if err := json.Unmarshal([]byte(tags), &pkg.Tags); err != nil {
+		return nil, err
+	}
+	if publisherID.Valid {
+		pkg.PublisherID, _ = uuid.Parse(publisherID.String)
+	}
+	pkg.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	pkg.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return pkg, nil
+}
+
+func (r *SQLitePackageRepository) scanPackages(rows *sql.Rows) ([]*domain.Package, error) {
+	var packages []*domain.Package
+	for rows.Next() {
+		pkg, err := r.scanPackage(rows)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, rows.Err()
+}
+
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func nullableUUID(id uuid.UUID) sql.NullString {
+	if id == uuid.Nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: id.String(), Valid: true}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/migrations"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func setupSQLiteDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, migrations.RunSQLiteMigrations(context.Background(), db))
+	return db
+}
+
+func TestSQLitePackageRepository_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSQLitePackageRepository(setupSQLiteDB(t))
+
+	pkg := domain.NewPackage("acme.focus", domain.PackageTypeOrbit, "Focus", "Deep work timer")
+	pkg.Tags = []string{"focus", "timer"}
+	pkg.LatestVersion = "1.2.0"
+	require.NoError(t, repo.Create(ctx, pkg))
+
+	got, err := repo.GetByPackageID(ctx, "acme.focus")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, pkg.ID, got.ID)
+	assert.Equal(t, domain.PackageTypeOrbit, got.Type)
+	assert.Equal(t, []string{"focus", "timer"}, got.Tags)
+	assert.Equal(t, "1.2.0", got.LatestVersion)
+
+	got.LatestVersion = "1.3.0"
+	require.NoError(t, repo.Update(ctx, got))
+	got, err = repo.GetByID(ctx, pkg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", got.LatestVersion)
+
+	found, total, err := repo.List(ctx, domain.PackageFilter{Tags: []string{"timer"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, found, 1)
+
+	found, total, err = repo.Search(ctx, "deep work", domain.PackageFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, found, 1)
+
+	missing, err := repo.GetByPackageID(ctx, "acme.missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestSQLiteVersionRepository_LatestStable(t *testing.T) {
+	ctx := context.Background()
+	db := setupSQLiteDB(t)
+	packages := NewSQLitePackageRepository(db)
+	versions := NewSQLiteVersionRepository(db)
+
+	pkg := domain.NewPackage("acme.focus", domain.PackageTypeOrbit, "Focus", "")
+	require.NoError(t, packages.Create(ctx, pkg))
+
+	stable := domain.NewVersion(pkg.ID, "1.0.0")
+	require.NoError(t, versions.Create(ctx, stable))
+	beta := domain.NewVersion(pkg.ID, "2.0.0-beta")
+	beta.Prerelease = true
+	require.NoError(t, versions.Create(ctx, beta))
+
+	latest, err := versions.GetLatestStable(ctx, pkg.ID)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, "1.0.0", latest.Version)
+
+	all, err := versions.ListByPackage(ctx, pkg.ID)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	got, err := versions.GetByPackageAndVersion(ctx, pkg.ID, "2.0.0-beta")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, got.Prerelease)
+}
+
+func TestSQLiteInstalledPackageRepository_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSQLiteInstalledPackageRepository(setupSQLiteDB(t))
+	userID := uuid.New()
+
+	installed := domain.NewInstalledPackage("acme.focus", "1.0.0", domain.PackageTypeOrbit, "/tmp/acme.focus", userID)
+	require.NoError(t, repo.Create(ctx, installed))
+
+	got, err := repo.GetByPackageID(ctx, "acme.focus", userID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, installed.ID, got.ID)
+	assert.True(t, got.Enabled)
+
+	got.UpdateVersion("1.1.0")
+	got.Disable()
+	require.NoError(t, repo.Update(ctx, got))
+
+	list, err := repo.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "1.1.0", list[0].Version)
+	assert.False(t, list[0].Enabled)
+
+	engines, err := repo.ListByType(ctx, userID, domain.PackageTypeEngine)
+	require.NoError(t, err)
+	assert.Empty(t, engines)
+
+	require.NoError(t, repo.Delete(ctx, got.ID))
+	list, err = repo.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
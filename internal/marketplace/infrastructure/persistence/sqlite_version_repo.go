@@ -0,0 +1,153 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/marketplace/domain"
+	"github.com/google/uuid"
+)
+
+const sqliteVersionColumns = `id, package_id, version, min_api_version, changelog, checksum,
+			download_url, size, downloads, prerelease, deprecated,
+			deprecation_message, published_at, created_at`
+
+// SQLiteVersionRepository implements domain.VersionRepository using SQLite.
+type SQLiteVersionRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteVersionRepository creates a new SQLite version repository.
+func NewSQLiteVersionRepository(db *sql.DB) *SQLiteVersionRepository {
+	return &SQLiteVersionRepository{db: db}
+}
+
+// Create creates a new version.
+func (r *SQLiteVersionRepository) Create(ctx context.Context, version *domain.Version) error {
+	query := `
+		INSERT INTO marketplace_versions (
+			id, package_id, version, min_api_version, changelog, checksum,
+			download_url, size, downloads, prerelease, deprecated,
+			deprecation_message, published_at, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		version.ID.String(), version.PackageID.String(), version.Version, version.MinAPIVersion,
+		version.Changelog, version.Checksum, version.DownloadURL, version.Size,
+		version.Downloads, boolToInt(version.Prerelease), boolToInt(version.Deprecated),
+		version.DeprecationMessage,
+		version.PublishedAt.Format(time.RFC3339), version.CreatedAt.Format(time.RFC3339),
+	)
+	return err
+}
+
+// Update updates an existing version.
+func (r *SQLiteVersionRepository) Update(ctx context.Context, version *domain.Version) error {
+	query := `
+		UPDATE marketplace_versions SET
+			changelog = ?, checksum = ?, download_url = ?, size = ?,
+			downloads = ?, prerelease = ?, deprecated = ?, deprecation_message = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		version.Changelog, version.Checksum, version.DownloadURL, version.Size,
+		version.Downloads, boolToInt(version.Prerelease), boolToInt(version.Deprecated),
+		version.DeprecationMessage, version.ID.String(),
+	)
+	return err
+}
+
+// Delete deletes a version by ID.
+func (r *SQLiteVersionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM marketplace_versions WHERE id = ?", id.String())
+	return err
+}
+
+// GetByID retrieves a version by ID.
+func (r *SQLiteVersionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Version, error) {
+	query := "SELECT " + sqliteVersionColumns + " FROM marketplace_versions WHERE id = ?"
+	return r.scanVersion(r.db.QueryRowContext(ctx, query, id.String()))
+}
+
+// GetByPackageAndVersion retrieves a specific version of a package.
+func (r *SQLiteVersionRepository) GetByPackageAndVersion(ctx context.Context, packageID uuid.UUID, version string) (*domain.Version, error) {
+	query := "SELECT " + sqliteVersionColumns + `
+		FROM marketplace_versions
+		WHERE package_id = ? AND version = ?`
+	return r.scanVersion(r.db.QueryRowContext(ctx, query, packageID.String(), version))
+}
+
+// ListByPackage retrieves all versions of a package.
+func (r *SQLiteVersionRepository) ListByPackage(ctx context.Context, packageID uuid.UUID) ([]*domain.Version, error) {
+	query := "SELECT " + sqliteVersionColumns + `
+		FROM marketplace_versions
+		WHERE package_id = ?
+		ORDER BY published_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, packageID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*domain.Version
+	for rows.Next() {
+		v, err := r.scanVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// GetLatestStable retrieves the latest stable version of a package.
+func (r *SQLiteVersionRepository) GetLatestStable(ctx context.Context, packageID uuid.UUID) (*domain.Version, error) {
+	query := "SELECT " + sqliteVersionColumns + `
+		FROM marketplace_versions
+		WHERE package_id = ? AND prerelease = 0 AND deprecated = 0
+		ORDER BY published_at DESC
+		LIMIT 1`
+	return r.scanVersion(r.db.QueryRowContext(ctx, query, packageID.String()))
+}
+
+// IncrementDownloads increments the download count for a version.
+func (r *SQLiteVersionRepository) IncrementDownloads(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE marketplace_versions SET downloads = downloads + 1 WHERE id = ?",
+		id.String(),
+	)
+	return err
+}
+
+func (r *SQLiteVersionRepository) scanVersion(row sqliteScanner) (*domain.Version, error) {
+	v := &domain.Version{}
+	var id, packageID, publishedAt, createdAt string
+
+	err := row.Scan(
+		&id, &packageID, &v.Version, &v.MinAPIVersion, &v.Changelog,
+		&v.Checksum, &v.DownloadURL, &v.Size, &v.Downloads, &v.Prerelease,
+		&v.Deprecated, &v.DeprecationMessage, &publishedAt, &createdAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if v.ID, err = uuid.Parse(id); err != nil {
+		return nil, err
+	}
+	if v.PackageID, err = uuid.Parse(packageID); err != nil {
+		return nil, err
+	}
+	v.PublishedAt, _ = time.Parse(time.RFC3339, publishedAt)
+	v.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+	return v, nil
+}
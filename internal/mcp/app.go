@@ -41,10 +41,44 @@ func NewCLIApp(container *app.Container, currentUser uuid.UUID) *cli.App {
 	)
 
 	cliApp.SetCurrentUserID(currentUser)
+	cliApp.SetWeekStartsOn(container.WeekStartsOn)
+	cliApp.SetMarkMeetingCanceledHandler(container.MarkMeetingCanceledHandler)
+	cliApp.SetTagHandlers(container.BulkTagTasksHandler, container.BulkTagHabitsHandler)
 
 	if container.CalendarSyncer != nil {
 		cliApp.SetCalendarSyncer(container.CalendarSyncer)
 	}
+	if container.GetTaskStatsHandler != nil {
+		cliApp.SetTaskStatsHandler(container.GetTaskStatsHandler)
+	}
+	cliApp.SetHabitPauseHandlers(container.SkipHabitHandler, container.FreezeHabitHandler, container.UnfreezeHabitHandler)
+	if container.RecommendHabitTimeHandler != nil {
+		cliApp.SetRecommendHabitTimeHandler(container.RecommendHabitTimeHandler)
+	}
+	if container.GetScheduleStatsHandler != nil {
+		cliApp.SetScheduleStatsHandler(container.GetScheduleStatsHandler)
+	}
+	if container.EngineRegistry != nil {
+		cliApp.SetEngineRegistry(container.EngineRegistry)
+	}
+	if container.EngineExecutor != nil {
+		cliApp.SetEngineExecutor(container.EngineExecutor)
+	}
+	if container.ImportTaskHandler != nil {
+		cliApp.SetImportTaskHandler(container.ImportTaskHandler)
+	}
+	if container.GetCapacityHandler != nil {
+		cliApp.SetCapacityHandler(container.GetCapacityHandler)
+	}
+	if container.GetDueHabitsHandler != nil {
+		cliApp.SetDueHabitsHandler(container.GetDueHabitsHandler)
+	}
+	if container.SearchEntitiesHandler != nil {
+		cliApp.SetSearchEntitiesHandler(container.SearchEntitiesHandler)
+	}
+	if container.RescheduleDayHandler != nil {
+		cliApp.SetRescheduleDayHandler(container.RescheduleDayHandler)
+	}
 	if container.SettingsService != nil {
 		cliApp.SetSettingsService(container.SettingsService)
 	}
@@ -52,5 +86,22 @@ func NewCLIApp(container *app.Container, currentUser uuid.UUID) *cli.App {
 		cliApp.SetBillingService(container.BillingService)
 	}
 
+	// Quick add links +Project tokens through the project handlers
+	if container.CreateProjectHandler != nil {
+		cliApp.SetProjectHandlers(
+			container.CreateProjectHandler,
+			container.UpdateProjectHandler,
+			container.DeleteProjectHandler,
+			container.ChangeProjectStatusHandler,
+			container.AddMilestoneHandler,
+			container.UpdateMilestoneHandler,
+			container.DeleteMilestoneHandler,
+			container.LinkTaskHandler,
+			container.UnlinkTaskHandler,
+			container.GetProjectHandler,
+			container.ListProjectsHandler,
+		)
+	}
+
 	return cliApp
 }
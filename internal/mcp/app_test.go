@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mcpgo "github.com/felixgeelhaar/mcp-go"
+	"github.com/felixgeelhaar/orbita/adapter/cli"
+	mcplocal "github.com/felixgeelhaar/orbita/adapter/mcp"
+	"github.com/felixgeelhaar/orbita/internal/app"
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
+	habitCommands "github.com/felixgeelhaar/orbita/internal/habits/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer builds the MCP tools on a CLI app from NewCLIApp over a
+// local container, the way the MCP server does. Options adjust the config.
+func newTestServer(t *testing.T, options ...func(*config.Config)) (*mcpgo.Server, *cli.App, context.Context) {
+	t.Helper()
+
+	userID := uuid.New()
+	cfg := &config.Config{
+		AppEnv:         "test",
+		LocalMode:      true,
+		DatabaseDriver: "sqlite",
+		SQLitePath:     filepath.Join(t.TempDir(), "test.db"),
+		LogLevel:       "error",
+		UserID:         userID.String(),
+	}
+	for _, option := range options {
+		option(cfg)
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	ctx := sharedApplication.WithPrincipal(context.Background(), userID)
+	container, err := app.NewLocalContainer(ctx, cfg, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { container.Close() })
+
+	cliApp := NewCLIApp(container, userID)
+	srv := mcpgo.NewServer(mcpgo.ServerInfo{
+		Name:         "test",
+		Version:      "1.0.0",
+		Capabilities: mcpgo.Capabilities{Tools: true},
+	})
+	require.NoError(t, mcplocal.RegisterCLITools(srv, mcplocal.ToolDependencies{App: cliApp}))
+	return srv, cliApp, ctx
+}
+
+// callTool runs an MCP tool as the app's user and decodes its output.
+func callTool(t *testing.T, srv *mcpgo.Server, ctx context.Context, name string, args map[string]any) map[string]any {
+	t.Helper()
+	tool, ok := srv.GetTool(name)
+	require.True(t, ok, "tool %s is registered", name)
+	input, err := json.Marshal(args)
+	require.NoError(t, err)
+
+	result, err := tool.Execute(ctx, input)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(result)
+	require.NoError(t, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &out))
+	return out
+}
+
+func TestNewCLIApp_QuickAddProjectAndContext(t *testing.T) {
+	srv, cliApp, ctx := newTestServer(t)
+
+	out := callTool(t, srv, ctx, "cli.add", map[string]any{"description": "Call the plumber +House @phone"})
+	assert.Equal(t, "House", out["project"])
+	assert.Equal(t, true, out["project_created"])
+	assert.Equal(t, "phone", out["context"])
+
+	tasks, err := cliApp.ListTasksHandler.Handle(ctx, queries.ListTasksQuery{UserID: cliApp.CurrentUserID, Tags: []string{"phone"}})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Call the plumber", tasks[0].Title)
+
+	// A second task joins the existing project.
+	out = callTool(t, srv, ctx, "cli.add", map[string]any{"description": "Fix the gutter +House"})
+	assert.Equal(t, false, out["project_created"])
+}
+
+func TestNewCLIApp_TaskStats(t *testing.T) {
+	srv, _, ctx := newTestServer(t)
+
+	callTool(t, srv, ctx, "cli.add", map[string]any{"description": "Renew passport !!"})
+	callTool(t, srv, ctx, "cli.add", map[string]any{"description": "Book dentist"})
+
+	out := callTool(t, srv, ctx, "cli.stats", map[string]any{})
+	tasks, ok := out["tasks"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, float64(2), tasks["total"])
+	assert.Equal(t, float64(2), tasks["pending"])
+}
+
+func TestNewCLIApp_RecommendHabitTime(t *testing.T) {
+	srv, cliApp, ctx := newTestServer(t)
+
+	created, err := cliApp.CreateHabitHandler.Handle(ctx, habitCommands.CreateHabitCommand{
+		UserID:       cliApp.CurrentUserID,
+		Name:         "Read",
+		Frequency:    "daily",
+		DurationMins: 20,
+	})
+	require.NoError(t, err)
+
+	out := callTool(t, srv, ctx, "habit.recommend_time", map[string]any{"habit_id": created.HabitID.String()})
+	assert.Equal(t, created.HabitID.String(), out["habit_id"])
+	assert.Equal(t, float64(0), out["completions"])
+	assert.Equal(t, false, out["applied"])
+}
+
+func TestNewCLIApp_WeekStartsOn(t *testing.T) {
+	srv, _, ctx := newTestServer(t, func(cfg *config.Config) { cfg.WeekStartsOn = "wednesday" })
+
+	out := callTool(t, srv, ctx, "schedule.week", map[string]any{})
+	weekStart, err := time.Parse(time.RFC3339, out["week_start"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, time.Wednesday, weekStart.Weekday())
+}
+
+func TestNewCLIApp_SkipAndFreezeHabit(t *testing.T) {
+	srv, cliApp, ctx := newTestServer(t)
+
+	created, err := cliApp.CreateHabitHandler.Handle(ctx, habitCommands.CreateHabitCommand{
+		UserID:       cliApp.CurrentUserID,
+		Name:         "Stretch",
+		Frequency:    "daily",
+		DurationMins: 10,
+	})
+	require.NoError(t, err)
+	habitID := created.HabitID.String()
+
+	out := callTool(t, srv, ctx, "habit.skip", map[string]any{"habit_id": habitID})
+	assert.Equal(t, true, out["skipped"])
+
+	until := time.Now().AddDate(0, 0, 5).Format("2006-01-02")
+	out = callTool(t, srv, ctx, "habit.freeze", map[string]any{"habit_id": habitID, "until": until})
+	assert.Equal(t, until, out["frozen_until"])
+
+	out = callTool(t, srv, ctx, "habit.unfreeze", map[string]any{"habit_id": habitID})
+	assert.Equal(t, false, out["frozen"])
+}
+
+func TestNewCLIApp_EngineConfigureDropsCachedResults(t *testing.T) {
+	srv, cliApp, ctx := newTestServer(t, func(cfg *config.Config) {
+		cfg.EngineResultCacheTTL = time.Hour
+	})
+
+	const engineID = "orbita.priority.default"
+	input := types.PriorityInput{ID: uuid.New(), Priority: 4}
+	before, err := cliApp.EngineExecutor.ExecutePriority(ctx, engineID, cliApp.CurrentUserID, input)
+	require.NoError(t, err)
+
+	out := callTool(t, srv, ctx, "engine.configure", map[string]any{
+		"engine_id": engineID,
+		"config":    map[string]any{"priority_weight": 10},
+	})
+	assert.Equal(t, true, out["configured"])
+
+	after, err := cliApp.EngineExecutor.ExecutePriority(ctx, engineID, cliApp.CurrentUserID, input)
+	require.NoError(t, err)
+	assert.Greater(t, after.Score, before.Score, "the new weight applies instead of the cached score")
+}
@@ -7,10 +7,14 @@ import (
 
 	mcpgo "github.com/felixgeelhaar/mcp-go"
 	"github.com/felixgeelhaar/mcp-go/middleware"
+	"github.com/felixgeelhaar/mcp-go/protocol"
 	"github.com/felixgeelhaar/orbita/adapter/cli"
 	mcplocal "github.com/felixgeelhaar/orbita/adapter/mcp"
 	identityOAuth "github.com/felixgeelhaar/orbita/internal/identity/application/oauth"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/pkg/config"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
+	"github.com/google/uuid"
 )
 
 // Serve starts an MCP server that mirrors CLI behavior and blocks until the context is canceled.
@@ -39,6 +43,12 @@ func Serve(ctx context.Context, cfg *config.Config, cliApp *cli.App, authService
 		App:         cliApp,
 		AuthService: authService,
 	}
+	if cliApp.CaptureInboxItemHandler != nil {
+		deps.InboxCapturer = cliApp.CaptureInboxItemHandler
+	}
+	if cliApp.ListInboxItemsHandler != nil {
+		deps.InboxLister = cliApp.ListInboxItemsHandler
+	}
 
 	// Register CLI tools
 	if err := mcplocal.RegisterCLITools(srv, deps); err != nil {
@@ -58,7 +68,7 @@ func Serve(ctx context.Context, cfg *config.Config, cliApp *cli.App, authService
 	}
 
 	adapter := mcpLogger{logger: logger}
-	stack := middleware.DefaultStack(adapter)
+	stack := append(middleware.DefaultStack(adapter), correlationIDs(), principal(cliApp.CurrentUserID))
 
 	if cfg.MCPAuthToken != "" {
 		authenticator := middleware.BearerTokenAuthenticator(middleware.StaticTokens(map[string]*middleware.Identity{
@@ -73,6 +83,30 @@ func Serve(ctx context.Context, cfg *config.Config, cliApp *cli.App, authService
 	return mcpgo.ServeHTTPWithMiddleware(ctx, srv, cfg.MCPAddr, nil, mcpgo.WithMiddleware(stack...))
 }
 
+// correlationIDs gives every MCP request, such as a tool call, its own
+// correlation ID so all lines the handlers log for it share one ID.
+func correlationIDs() middleware.Middleware {
+	return func(next middleware.HandlerFunc) middleware.HandlerFunc {
+		return func(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+			ctx = observability.WithCorrelationID(ctx, "")
+			if id := middleware.RequestIDFromContext(ctx); id != "" {
+				ctx = observability.WithRequestID(ctx, id)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// principal authenticates every MCP request as the user the server acts
+// for, so the repositories accept the calls its handlers make.
+func principal(userID uuid.UUID) middleware.Middleware {
+	return func(next middleware.HandlerFunc) middleware.HandlerFunc {
+		return func(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+			return next(sharedApplication.WithPrincipal(ctx, userID), req)
+		}
+	}
+}
+
 type mcpLogger struct {
 	logger *slog.Logger
 }
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/felixgeelhaar/mcp-go/middleware"
+	"github.com/felixgeelhaar/mcp-go/protocol"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrelationIDs(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	handler := middleware.Chain(middleware.RequestID(), correlationIDs())(
+		func(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+			observability.Logger(ctx, logger).Info("listing tasks")
+			observability.Logger(ctx, logger).Info("tasks listed")
+			return &protocol.Response{}, nil
+		},
+	)
+
+	call := func() []map[string]any {
+		logs.Reset()
+		_, err := handler(context.Background(), &protocol.Request{Method: "tools/call"})
+		require.NoError(t, err)
+
+		var entries []map[string]any
+		for _, line := range bytes.Split(bytes.TrimSpace(logs.Bytes()), []byte("\n")) {
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(line, &entry))
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+
+	first := call()
+	require.Len(t, first, 2)
+	correlationID := first[0][observability.CorrelationIDKey]
+	assert.NotEmpty(t, correlationID)
+	assert.NotEmpty(t, first[0][observability.RequestIDKey])
+	assert.Equal(t, correlationID, first[1][observability.CorrelationIDKey])
+
+	second := call()
+	require.Len(t, second, 2)
+	assert.NotEqual(t, correlationID, second[0][observability.CorrelationIDKey], "each request gets its own ID")
+}
+
+func TestPrincipal(t *testing.T) {
+	userID := uuid.New()
+
+	var got uuid.UUID
+	var ok bool
+	handler := principal(userID)(
+		func(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+			got, ok = sharedApplication.PrincipalFromContext(ctx)
+			return &protocol.Response{}, nil
+		},
+	)
+
+	_, err := handler(context.Background(), &protocol.Request{Method: "tools/call"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, userID, got)
+}
@@ -18,10 +18,34 @@ type AdjustMeetingCadenceCommand struct {
 
 // AdjustMeetingCadenceResult contains the result of adjustment.
 type AdjustMeetingCadenceResult struct {
-	Evaluated int
-	Updated   int
+	Evaluated   int
+	Updated     int
+	Adjustments []MeetingCadenceAdjustment
 }
 
+// MeetingCadenceAdjustment describes a cadence change and the attendance that
+// led to it.
+type MeetingCadenceAdjustment struct {
+	MeetingID uuid.UUID
+	Name      string
+	FromDays  int
+	ToDays    int
+	Held      int
+	Canceled  int
+	// FrequentlyCanceled is true when cancellations, rather than the time
+	// since the meeting was last held, slowed the cadence.
+	FrequentlyCanceled bool
+}
+
+const (
+	// frequentCancellationMin is the fewest cancellations that can count as
+	// frequent, so a meeting skipped once keeps its cadence.
+	frequentCancellationMin = 2
+	// frequentCancellationRate is the share of occurrences that must be
+	// canceled for the meeting to count as frequently canceled.
+	frequentCancellationRate = 0.5
+)
+
 // AdjustMeetingCadenceHandler handles the AdjustMeetingCadenceCommand.
 type AdjustMeetingCadenceHandler struct {
 	repo       domain.Repository
@@ -53,7 +77,7 @@ func (h *AdjustMeetingCadenceHandler) Handle(ctx context.Context, cmd AdjustMeet
 		now := time.Now()
 
 		for _, meeting := range meetings {
-			updated := adjustMeetingCadence(meeting, now)
+			adjustment, updated := adjustMeetingCadence(meeting, now)
 			if !updated {
 				continue
 			}
@@ -63,6 +87,7 @@ func (h *AdjustMeetingCadenceHandler) Handle(ctx context.Context, cmd AdjustMeet
 			}
 
 			result.Updated++
+			result.Adjustments = append(result.Adjustments, adjustment)
 			events = append(events, meeting.DomainEvents()...)
 			meeting.ClearDomainEvents()
 		}
@@ -91,36 +116,67 @@ func (h *AdjustMeetingCadenceHandler) Handle(ctx context.Context, cmd AdjustMeet
 	return result, nil
 }
 
-func adjustMeetingCadence(meeting *domain.Meeting, now time.Time) bool {
-	lastHeld := meeting.LastHeldAt()
-	if lastHeld == nil {
-		return false
-	}
-
-	daysSince := int(now.Sub(*lastHeld).Hours() / 24)
+func adjustMeetingCadence(meeting *domain.Meeting, now time.Time) (MeetingCadenceAdjustment, bool) {
+	attendance := meeting.Attendance()
 	current := meeting.CadenceDays()
+	adjustment := MeetingCadenceAdjustment{
+		MeetingID: meeting.ID(),
+		Name:      meeting.Name(),
+		FromDays:  current,
+		Held:      attendance.Held,
+		Canceled:  attendance.Canceled,
+	}
 	newDays := current
 
-	slowThreshold := int(float64(current) * 2)
-	fastThreshold := int(float64(current) * 0.6)
+	if frequentlyCanceled(attendance) {
+		if current < 30 {
+			newDays = current + 7
+			adjustment.FrequentlyCanceled = true
+		}
+	} else {
+		lastHeld := meeting.LastHeldAt()
+		if lastHeld == nil {
+			return adjustment, false
+		}
+
+		// A cancellation since the meeting was last held explains the gap,
+		// so a meeting skipped once is not slowed down.
+		lastOccurrence := *lastHeld
+		if canceled := attendance.LastCanceledAt; canceled != nil && canceled.After(lastOccurrence) {
+			lastOccurrence = *canceled
+		}
+		daysSince := int(now.Sub(lastOccurrence).Hours() / 24)
+		daysSinceHeld := int(now.Sub(*lastHeld).Hours() / 24)
+
+		slowThreshold := int(float64(current) * 2)
+		fastThreshold := int(float64(current) * 0.6)
 
-	switch {
-	case daysSince >= slowThreshold && current < 30:
-		newDays = current + 7
-	case daysSince <= fastThreshold && current > 7:
-		newDays = current - 7
+		switch {
+		case daysSince >= slowThreshold && current < 30:
+			newDays = current + 7
+		case daysSinceHeld <= fastThreshold && current > 7:
+			newDays = current - 7
+		}
 	}
 
 	if newDays == current {
-		return false
+		return adjustment, false
 	}
 
 	cadence := cadenceFromDays(newDays)
 	if err := meeting.SetCadence(cadence, newDays); err != nil {
-		return false
+		return adjustment, false
 	}
 
-	return true
+	adjustment.ToDays = newDays
+	return adjustment, true
+}
+
+// frequentlyCanceled reports whether enough occurrences were canceled to slow
+// the meeting's cadence.
+func frequentlyCanceled(attendance domain.Attendance) bool {
+	return attendance.Canceled >= frequentCancellationMin &&
+		attendance.CancellationRate() >= frequentCancellationRate
 }
 
 func cadenceFromDays(days int) domain.Cadence {
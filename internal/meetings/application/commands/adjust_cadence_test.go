@@ -267,6 +267,92 @@ func TestAdjustMeetingCadenceHandler_Handle(t *testing.T) {
 	})
 }
 
+func TestAdjustMeetingCadenceHandler_Cancellations(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+
+	// weeklyMeeting returns a weekly meeting with the given attendance,
+	// last held at lastHeld.
+	weeklyMeeting := func(lastHeld time.Time, held, canceled int, lastCanceled time.Time) *domain.Meeting {
+		meeting := domain.RehydrateMeeting(
+			uuid.New(),
+			userID,
+			"Weekly sync",
+			domain.CadenceWeekly,
+			7,
+			30*time.Minute,
+			10*time.Hour,
+			&lastHeld,
+			false,
+			now.Add(-90*24*time.Hour),
+			now,
+		)
+		meeting.RehydrateAttendance(domain.Attendance{Held: held, Canceled: canceled, LastCanceledAt: &lastCanceled})
+		return meeting
+	}
+
+	run := func(t *testing.T, meeting *domain.Meeting) *AdjustMeetingCadenceResult {
+		t.Helper()
+		repo := new(mockMeetingRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewAdjustMeetingCadenceHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindActiveByUserID", txCtx, userID).Return([]*domain.Meeting{meeting}, nil)
+		repo.On("Save", txCtx, meeting).Return(nil).Maybe()
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil).Maybe()
+
+		result, err := handler.Handle(ctx, AdjustMeetingCadenceCommand{UserID: userID})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("slows the cadence of a frequently canceled meeting", func(t *testing.T) {
+		// Held two days ago, which alone would speed it up
+		meeting := weeklyMeeting(now.Add(-2*24*time.Hour), 2, 3, now.Add(-9*24*time.Hour))
+
+		result := run(t, meeting)
+
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, 14, meeting.CadenceDays())
+		require.Len(t, result.Adjustments, 1)
+		adjustment := result.Adjustments[0]
+		assert.True(t, adjustment.FrequentlyCanceled)
+		assert.Equal(t, 7, adjustment.FromDays)
+		assert.Equal(t, 14, adjustment.ToDays)
+		assert.Equal(t, 2, adjustment.Held)
+		assert.Equal(t, 3, adjustment.Canceled)
+
+		// Attendance starts over for the new cadence
+		assert.Zero(t, meeting.Attendance().Occurrences())
+	})
+
+	t.Run("keeps the cadence of a meeting skipped once", func(t *testing.T) {
+		// Last held 15 days ago, but the occurrence in between was canceled
+		meeting := weeklyMeeting(now.Add(-15*24*time.Hour), 4, 1, now.Add(-8*24*time.Hour))
+
+		result := run(t, meeting)
+
+		assert.Zero(t, result.Updated)
+		assert.Empty(t, result.Adjustments)
+		assert.Equal(t, 7, meeting.CadenceDays())
+	})
+
+	t.Run("occasional cancellations do not count as frequent", func(t *testing.T) {
+		meeting := weeklyMeeting(now.Add(-6*24*time.Hour), 6, 2, now.Add(-20*24*time.Hour))
+
+		result := run(t, meeting)
+
+		assert.Zero(t, result.Updated)
+		assert.Equal(t, 7, meeting.CadenceDays())
+	})
+}
+
 func TestNewAdjustMeetingCadenceHandler(t *testing.T) {
 	repo := new(mockMeetingRepo)
 	outboxRepo := new(mockOutboxRepo)
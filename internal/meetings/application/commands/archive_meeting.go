@@ -2,7 +2,6 @@ package commands
 
 import (
 	"context"
-	"errors"
 
 	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
 	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
@@ -11,8 +10,8 @@ import (
 )
 
 var (
-	ErrArchiveMeetingNotFound = errors.New("meeting not found")
-	ErrArchiveMeetingNotOwner = errors.New("user does not own this meeting")
+	ErrArchiveMeetingNotFound = sharedApplication.NewNotFoundError("meeting not found")
+	ErrArchiveMeetingNotOwner = sharedApplication.NewNotFoundError("user does not own this meeting")
 )
 
 // ArchiveMeetingCommand contains the data needed to archive a meeting.
@@ -39,7 +38,7 @@ func NewArchiveMeetingHandler(repo domain.Repository, outboxRepo outbox.Reposito
 
 // Handle executes the ArchiveMeetingCommand.
 func (h *ArchiveMeetingHandler) Handle(ctx context.Context, cmd ArchiveMeetingCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		meeting, err := h.repo.FindByID(txCtx, cmd.MeetingID)
 		if err != nil {
 			return err
@@ -69,4 +68,5 @@ func (h *ArchiveMeetingHandler) Handle(ctx context.Context, cmd ArchiveMeetingCo
 		}
 		return h.outboxRepo.SaveBatch(txCtx, msgs)
 	})
+	return classifyMeetingError(err)
 }
@@ -92,7 +92,7 @@ func (h *CreateMeetingHandler) Handle(ctx context.Context, cmd CreateMeetingComm
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, classifyMeetingError(err)
 	}
 
 	return result, nil
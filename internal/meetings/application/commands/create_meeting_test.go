@@ -48,6 +48,14 @@ func (m *mockMeetingRepo) FindActiveByUserID(ctx context.Context, userID uuid.UU
 	return args.Get(0).([]*domain.Meeting), args.Error(1)
 }
 
+func (m *mockMeetingRepo) FindByExternalSeriesID(ctx context.Context, userID uuid.UUID, seriesID string) (*domain.Meeting, error) {
+	args := m.Called(ctx, userID, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Meeting), args.Error(1)
+}
+
 // mockOutboxRepo is a mock implementation of outbox.Repository.
 type mockOutboxRepo struct {
 	mock.Mock
@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+)
+
+// classifyMeetingError tags meeting domain errors with an application error
+// category. Errors it does not recognise are returned unchanged and count as
+// internal.
+func classifyMeetingError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrMeetingEmptyName),
+		errors.Is(err, domain.ErrMeetingInvalidCadence),
+		errors.Is(err, domain.ErrMeetingInvalidDuration),
+		errors.Is(err, domain.ErrMeetingInvalidTime),
+		errors.Is(err, domain.ErrMeetingInvalidInterval):
+		return sharedApplication.Validation(err)
+	case errors.Is(err, domain.ErrMeetingArchived):
+		return sharedApplication.Conflict(err)
+	}
+	return err
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	calendarApplication "github.com/felixgeelhaar/orbita/internal/calendar/application"
+	"github.com/felixgeelhaar/orbita/internal/calendar/application/workers"
+	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// defaultImportedDurationMins is used when a series has no usable duration.
+const defaultImportedDurationMins = 30
+
+// Ensure ImportMeetingSeriesHandler can create meetings for the calendar import worker.
+var _ workers.MeetingSeriesImporter = (*ImportMeetingSeriesHandler)(nil)
+
+// Ensure ImportMeetingSeriesHandler can report linked series for import previews.
+var _ workers.MeetingSeriesPreviewer = (*ImportMeetingSeriesHandler)(nil)
+
+// ErrMissingExternalSeriesID is returned when a series import has no series ID
+// to match later imports against.
+var ErrMissingExternalSeriesID = errors.New("external series id is required")
+
+// ImportMeetingSeriesCommand creates a meeting for a recurring event series
+// from an external calendar, unless one already exists for the series.
+type ImportMeetingSeriesCommand struct {
+	UserID           uuid.UUID
+	ExternalSeriesID string
+	Name             string
+	Cadence          string
+	CadenceDays      int
+	DurationMins     int
+	PreferredTime    string
+}
+
+// ImportMeetingSeriesResult contains the result of importing a series.
+type ImportMeetingSeriesResult struct {
+	MeetingID uuid.UUID
+	Created   bool // false when the series was already linked to a meeting
+}
+
+// ImportMeetingSeriesHandler handles the ImportMeetingSeriesCommand.
+type ImportMeetingSeriesHandler struct {
+	repo       domain.Repository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+}
+
+// NewImportMeetingSeriesHandler creates a new ImportMeetingSeriesHandler.
+func NewImportMeetingSeriesHandler(repo domain.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *ImportMeetingSeriesHandler {
+	return &ImportMeetingSeriesHandler{
+		repo:       repo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// Handle executes the ImportMeetingSeriesCommand. Importing the same series
+// again returns the existing meeting without changing it, so edits made in
+// Orbita are kept.
+func (h *ImportMeetingSeriesHandler) Handle(ctx context.Context, cmd ImportMeetingSeriesCommand) (*ImportMeetingSeriesResult, error) {
+	seriesID := strings.TrimSpace(cmd.ExternalSeriesID)
+	if seriesID == "" {
+		return nil, sharedApplication.Validation(ErrMissingExternalSeriesID)
+	}
+
+	var result *ImportMeetingSeriesResult
+
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		existing, err := h.repo.FindByExternalSeriesID(txCtx, cmd.UserID, seriesID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			result = &ImportMeetingSeriesResult{MeetingID: existing.ID()}
+			return nil
+		}
+
+		cadence := domain.Cadence(cmd.Cadence)
+		if !cadence.IsValid() {
+			cadence = domain.CadenceWeekly
+		}
+
+		preferred, err := parseTimeOfDay(cmd.PreferredTime)
+		if err != nil {
+			return err
+		}
+
+		meeting, err := domain.NewMeeting(
+			cmd.UserID,
+			cmd.Name,
+			cadence,
+			cmd.CadenceDays,
+			time.Duration(cmd.DurationMins)*time.Minute,
+			preferred,
+		)
+		if err != nil {
+			return err
+		}
+		if err := meeting.LinkExternalSeries(seriesID); err != nil {
+			return err
+		}
+
+		if err := h.repo.Save(txCtx, meeting); err != nil {
+			return err
+		}
+
+		events := meeting.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		if err := h.outboxRepo.SaveBatch(txCtx, msgs); err != nil {
+			return err
+		}
+
+		result = &ImportMeetingSeriesResult{MeetingID: meeting.ID(), Created: true}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyMeetingError(err)
+	}
+
+	return result, nil
+}
+
+// ImportSeries creates a meeting for a recurring event series detected by the
+// calendar import worker, unless one is already linked. The meeting takes the
+// series' inferred cadence, the duration of its first occurrence and that
+// occurrence's start time as the preferred time.
+func (h *ImportMeetingSeriesHandler) ImportSeries(ctx context.Context, userID uuid.UUID, series calendarApplication.RecurringSeries) (bool, error) {
+	durationMins := int(series.Duration.Minutes())
+	if durationMins <= 0 {
+		durationMins = defaultImportedDurationMins
+	}
+
+	result, err := h.Handle(ctx, ImportMeetingSeriesCommand{
+		UserID:           userID,
+		ExternalSeriesID: series.ID,
+		Name:             series.Summary,
+		Cadence:          series.Cadence,
+		CadenceDays:      series.CadenceDays,
+		DurationMins:     durationMins,
+		PreferredTime:    series.StartTime.Format("15:04"),
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.Created, nil
+}
+
+// IsSeriesImported reports whether a meeting is already linked to the series.
+func (h *ImportMeetingSeriesHandler) IsSeriesImported(ctx context.Context, userID uuid.UUID, seriesID string) (bool, error) {
+	meeting, err := h.repo.FindByExternalSeriesID(ctx, userID, seriesID)
+	if err != nil {
+		return false, err
+	}
+	return meeting != nil, nil
+}
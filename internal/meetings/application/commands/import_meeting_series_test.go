@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	calendarApplication "github.com/felixgeelhaar/orbita/internal/calendar/application"
+	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportMeetingSeriesHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+	cmd := ImportMeetingSeriesCommand{
+		UserID:           userID,
+		ExternalSeriesID: "series-123",
+		Name:             "Team Sync",
+		Cadence:          "biweekly",
+		DurationMins:     45,
+		PreferredTime:    "09:30",
+	}
+
+	t.Run("creates a linked meeting for a new series", func(t *testing.T) {
+		repo := new(mockMeetingRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewImportMeetingSeriesHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByExternalSeriesID", txCtx, userID, "series-123").Return(nil, nil)
+		repo.On("Save", txCtx, mock.MatchedBy(func(m *domain.Meeting) bool {
+			return m.ExternalSeriesID() == "series-123" &&
+				m.Cadence() == domain.CadenceBiweekly &&
+				m.Duration() == 45*time.Minute &&
+				m.PreferredTime() == 9*time.Hour+30*time.Minute
+		})).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		result, err := handler.Handle(ctx, cmd)
+
+		require.NoError(t, err)
+		assert.True(t, result.Created)
+		assert.NotEqual(t, uuid.Nil, result.MeetingID)
+
+		repo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("returns the existing meeting for a known series", func(t *testing.T) {
+		repo := new(mockMeetingRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewImportMeetingSeriesHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		existing, err := domain.NewMeeting(userID, "Renamed sync", domain.CadenceWeekly, 0, 30*time.Minute, 9*time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, existing.LinkExternalSeries("series-123"))
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByExternalSeriesID", txCtx, userID, "series-123").Return(existing, nil)
+
+		result, err := handler.Handle(ctx, cmd)
+
+		require.NoError(t, err)
+		assert.False(t, result.Created)
+		assert.Equal(t, existing.ID(), result.MeetingID)
+
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		outboxRepo.AssertNotCalled(t, "SaveBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires a series id", func(t *testing.T) {
+		handler := NewImportMeetingSeriesHandler(new(mockMeetingRepo), new(mockOutboxRepo), new(mockUnitOfWork))
+
+		_, err := handler.Handle(context.Background(), ImportMeetingSeriesCommand{UserID: userID, Name: "Team Sync"})
+
+		assert.ErrorIs(t, err, ErrMissingExternalSeriesID)
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+	})
+}
+
+func TestImportMeetingSeriesHandler_ImportSeries_IsIdempotent(t *testing.T) {
+	userID := uuid.New()
+	repo := new(mockMeetingRepo)
+	outboxRepo := new(mockOutboxRepo)
+	uow := new(mockUnitOfWork)
+	handler := NewImportMeetingSeriesHandler(repo, outboxRepo, uow)
+
+	ctx := context.Background()
+	txCtx := context.WithValue(ctx, "tx", "transaction")
+	series := calendarApplication.RecurringSeries{
+		ID:          "series-123",
+		Summary:     "Sprint review",
+		Cadence:     calendarApplication.SeriesCadenceCustom,
+		CadenceDays: 21,
+		Duration:    50 * time.Minute,
+		StartTime:   time.Date(2024, time.May, 6, 15, 0, 0, 0, time.UTC),
+		Occurrences: 2,
+	}
+
+	var saved *domain.Meeting
+	uow.On("Begin", ctx).Return(txCtx, nil)
+	uow.On("Commit", txCtx).Return(nil)
+	repo.On("FindByExternalSeriesID", txCtx, userID, "series-123").Return(nil, nil).Once()
+	repo.On("Save", txCtx, mock.AnythingOfType("*domain.Meeting")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*domain.Meeting) }).
+		Return(nil).Once()
+	outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil).Once()
+
+	created, err := handler.ImportSeries(ctx, userID, series)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	require.NotNil(t, saved)
+	assert.Equal(t, "Sprint review", saved.Name())
+	assert.Equal(t, domain.CadenceCustom, saved.Cadence())
+	assert.Equal(t, 21, saved.CadenceDays())
+	assert.Equal(t, 50*time.Minute, saved.Duration())
+	assert.Equal(t, 15*time.Hour, saved.PreferredTime())
+	assert.Equal(t, "series-123", saved.ExternalSeriesID())
+
+	// The next import finds the linked meeting and creates nothing.
+	repo.On("FindByExternalSeriesID", txCtx, userID, "series-123").Return(saved, nil).Once()
+
+	created, err = handler.ImportSeries(ctx, userID, series)
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	repo.AssertExpectations(t)
+	outboxRepo.AssertExpectations(t)
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+// MarkMeetingCanceledCommand contains the data needed to record that a
+// meeting occurrence was canceled or was a no-show.
+type MarkMeetingCanceledCommand struct {
+	UserID     uuid.UUID
+	MeetingID  uuid.UUID
+	CanceledAt time.Time
+}
+
+// MarkMeetingCanceledHandler handles the MarkMeetingCanceledCommand.
+type MarkMeetingCanceledHandler struct {
+	repo domain.Repository
+	uow  sharedApplication.UnitOfWork
+}
+
+// NewMarkMeetingCanceledHandler creates a new MarkMeetingCanceledHandler.
+func NewMarkMeetingCanceledHandler(repo domain.Repository, uow sharedApplication.UnitOfWork) *MarkMeetingCanceledHandler {
+	return &MarkMeetingCanceledHandler{repo: repo, uow: uow}
+}
+
+// Handle executes the MarkMeetingCanceledCommand.
+func (h *MarkMeetingCanceledHandler) Handle(ctx context.Context, cmd MarkMeetingCanceledCommand) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		meeting, err := h.repo.FindByID(txCtx, cmd.MeetingID)
+		if err != nil {
+			return err
+		}
+		if meeting == nil {
+			return ErrMarkMeetingNotFound
+		}
+		if meeting.UserID() != cmd.UserID {
+			return ErrMarkMeetingNotOwner
+		}
+
+		if err := meeting.MarkCanceled(cmd.CanceledAt); err != nil {
+			return err
+		}
+
+		return h.repo.Save(txCtx, meeting)
+	})
+	return classifyMeetingError(err)
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkMeetingCanceledHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+	meetingID := uuid.New()
+
+	t.Run("records a cancellation", func(t *testing.T) {
+		repo := new(mockMeetingRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewMarkMeetingCanceledHandler(repo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		meeting := createTestMeeting(userID, "Weekly sync")
+		canceledAt := time.Now()
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, meetingID).Return(meeting, nil)
+		repo.On("Save", txCtx, meeting).Return(nil)
+
+		err := handler.Handle(ctx, MarkMeetingCanceledCommand{
+			UserID:     userID,
+			MeetingID:  meetingID,
+			CanceledAt: canceledAt,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, meeting.Attendance().Canceled)
+		assert.Nil(t, meeting.LastHeldAt())
+		require.NotNil(t, meeting.Attendance().LastCanceledAt)
+		assert.Equal(t, canceledAt, *meeting.Attendance().LastCanceledAt)
+
+		repo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("returns ErrMarkMeetingNotOwner when user does not own meeting", func(t *testing.T) {
+		repo := new(mockMeetingRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewMarkMeetingCanceledHandler(repo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		meeting := createTestMeeting(uuid.New(), "Someone else's meeting")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, meetingID).Return(meeting, nil)
+
+		err := handler.Handle(ctx, MarkMeetingCanceledCommand{
+			UserID:     userID,
+			MeetingID:  meetingID,
+			CanceledAt: time.Now(),
+		})
+
+		assert.ErrorIs(t, err, ErrMarkMeetingNotOwner)
+		assert.Zero(t, meeting.Attendance().Canceled)
+
+		repo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("rejects archived meetings", func(t *testing.T) {
+		repo := new(mockMeetingRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewMarkMeetingCanceledHandler(repo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		meeting := createTestMeeting(userID, "Old sync")
+		meeting.Archive()
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, meetingID).Return(meeting, nil)
+
+		err := handler.Handle(ctx, MarkMeetingCanceledCommand{
+			UserID:     userID,
+			MeetingID:  meetingID,
+			CanceledAt: time.Now(),
+		})
+
+		assert.ErrorIs(t, err, domain.ErrMeetingArchived)
+
+		repo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+}
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
@@ -11,8 +10,8 @@ import (
 )
 
 var (
-	ErrMarkMeetingNotFound = errors.New("meeting not found")
-	ErrMarkMeetingNotOwner = errors.New("user does not own this meeting")
+	ErrMarkMeetingNotFound = sharedApplication.NewNotFoundError("meeting not found")
+	ErrMarkMeetingNotOwner = sharedApplication.NewNotFoundError("user does not own this meeting")
 )
 
 // MarkMeetingHeldCommand contains the data needed to mark a meeting as held.
@@ -35,7 +34,7 @@ func NewMarkMeetingHeldHandler(repo domain.Repository, uow sharedApplication.Uni
 
 // Handle executes the MarkMeetingHeldCommand.
 func (h *MarkMeetingHeldHandler) Handle(ctx context.Context, cmd MarkMeetingHeldCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		meeting, err := h.repo.FindByID(txCtx, cmd.MeetingID)
 		if err != nil {
 			return err
@@ -53,4 +52,5 @@ func (h *MarkMeetingHeldHandler) Handle(ctx context.Context, cmd MarkMeetingHeld
 
 		return h.repo.Save(txCtx, meeting)
 	})
+	return classifyMeetingError(err)
 }
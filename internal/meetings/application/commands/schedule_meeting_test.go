@@ -52,6 +52,14 @@ func (m *mockMeetingRepoSchedule) FindActiveByUserID(ctx context.Context, userID
 	return args.Get(0).([]*domain.Meeting), args.Error(1)
 }
 
+func (m *mockMeetingRepoSchedule) FindByExternalSeriesID(ctx context.Context, userID uuid.UUID, seriesID string) (*domain.Meeting, error) {
+	args := m.Called(ctx, userID, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Meeting), args.Error(1)
+}
+
 func (m *mockMeetingRepoSchedule) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
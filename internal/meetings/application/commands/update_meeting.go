@@ -2,7 +2,6 @@ package commands
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
@@ -12,8 +11,8 @@ import (
 )
 
 var (
-	ErrMeetingNotFound = errors.New("meeting not found")
-	ErrMeetingNotOwner = errors.New("user does not own this meeting")
+	ErrMeetingNotFound = sharedApplication.NewNotFoundError("meeting not found")
+	ErrMeetingNotOwner = sharedApplication.NewNotFoundError("user does not own this meeting")
 )
 
 // UpdateMeetingCommand contains the data needed to update a meeting.
@@ -45,7 +44,7 @@ func NewUpdateMeetingHandler(repo domain.Repository, outboxRepo outbox.Repositor
 
 // Handle executes the UpdateMeetingCommand.
 func (h *UpdateMeetingHandler) Handle(ctx context.Context, cmd UpdateMeetingCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		meeting, err := h.repo.FindByID(txCtx, cmd.MeetingID)
 		if err != nil {
 			return err
@@ -110,4 +109,5 @@ func (h *UpdateMeetingHandler) Handle(ctx context.Context, cmd UpdateMeetingComm
 		}
 		return h.outboxRepo.SaveBatch(txCtx, msgs)
 	})
+	return classifyMeetingError(err)
 }
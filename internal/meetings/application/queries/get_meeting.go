@@ -52,6 +52,8 @@ func (h *GetMeetingHandler) Handle(ctx context.Context, query GetMeetingQuery) (
 		DurationMins:  int(meeting.Duration().Minutes()),
 		PreferredTime: formatTimeOfDay(meeting.PreferredTime()),
 		LastHeldAt:    meeting.LastHeldAt(),
+		HeldCount:     meeting.Attendance().Held,
+		CanceledCount: meeting.Attendance().Canceled,
 		Archived:      meeting.IsArchived(),
 	}
 	if !meeting.IsArchived() {
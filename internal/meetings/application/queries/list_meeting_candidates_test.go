@@ -30,6 +30,10 @@ func (s stubMeetingRepo) FindActiveByUserID(ctx context.Context, userID uuid.UUI
 	return s.meetings, nil
 }
 
+func (s stubMeetingRepo) FindByExternalSeriesID(ctx context.Context, userID uuid.UUID, seriesID string) (*domain.Meeting, error) {
+	return nil, nil
+}
+
 func TestListMeetingCandidatesHandler(t *testing.T) {
 	userID := uuid.New()
 	createdAt := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.UTC)
@@ -10,13 +10,17 @@ import (
 
 // MeetingDTO is a data transfer object for meetings.
 type MeetingDTO struct {
-	ID             uuid.UUID
-	Name           string
-	Cadence        string
-	CadenceDays    int
-	DurationMins   int
-	PreferredTime  string
-	LastHeldAt     *time.Time
+	ID            uuid.UUID
+	Name          string
+	Cadence       string
+	CadenceDays   int
+	DurationMins  int
+	PreferredTime string
+	LastHeldAt    *time.Time
+	// HeldCount and CanceledCount count occurrences since the cadence last
+	// changed; canceled includes no-shows.
+	HeldCount      int
+	CanceledCount  int
 	Archived       bool
 	NextOccurrence *time.Time
 }
@@ -62,6 +66,8 @@ func (h *ListMeetingsHandler) Handle(ctx context.Context, query ListMeetingsQuer
 			DurationMins:  int(meeting.Duration().Minutes()),
 			PreferredTime: formatTimeOfDay(meeting.PreferredTime()),
 			LastHeldAt:    meeting.LastHeldAt(),
+			HeldCount:     meeting.Attendance().Held,
+			CanceledCount: meeting.Attendance().Canceled,
 			Archived:      meeting.IsArchived(),
 		}
 		if !meeting.IsArchived() {
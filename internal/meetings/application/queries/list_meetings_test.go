@@ -47,6 +47,14 @@ func (m *mockMeetingRepo) FindActiveByUserID(ctx context.Context, userID uuid.UU
 	return args.Get(0).([]*domain.Meeting), args.Error(1)
 }
 
+func (m *mockMeetingRepo) FindByExternalSeriesID(ctx context.Context, userID uuid.UUID, seriesID string) (*domain.Meeting, error) {
+	args := m.Called(ctx, userID, seriesID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Meeting), args.Error(1)
+}
+
 func createTestMeeting(userID uuid.UUID, name string, archived bool) *domain.Meeting {
 	now := time.Now()
 	lastHeld := now.Add(-48 * time.Hour)
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// Attendance counts how occurrences of a meeting turned out since its cadence
+// last changed. A canceled occurrence covers both a cancellation and a
+// no-show.
+type Attendance struct {
+	Held     int
+	Canceled int
+	// LastCanceledAt is kept across cadence changes.
+	LastCanceledAt *time.Time
+}
+
+// Occurrences returns the number of occurrences with a recorded outcome.
+func (a Attendance) Occurrences() int {
+	return a.Held + a.Canceled
+}
+
+// CancellationRate returns the share of recorded occurrences that were
+// canceled, or zero when none are recorded.
+func (a Attendance) CancellationRate() float64 {
+	if a.Occurrences() == 0 {
+		return 0
+	}
+	return float64(a.Canceled) / float64(a.Occurrences())
+}
+
+// Attendance returns the meeting's attendance since its cadence last changed.
+func (m *Meeting) Attendance() Attendance {
+	return m.attendance
+}
+
+// MarkCanceled records that an occurrence was canceled or nobody showed up.
+// The occurrence still counts as having come due, so it is not proposed again.
+func (m *Meeting) MarkCanceled(at time.Time) error {
+	if m.archived {
+		return ErrMeetingArchived
+	}
+	m.attendance.Canceled++
+	m.attendance.LastCanceledAt = &at
+	m.Touch()
+	return nil
+}
+
+// RehydrateAttendance restores attendance from persistence.
+func (m *Meeting) RehydrateAttendance(attendance Attendance) {
+	m.attendance = attendance
+}
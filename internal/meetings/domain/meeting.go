@@ -63,6 +63,10 @@ type Meeting struct {
 	preferredTime time.Duration
 	lastHeldAt    *time.Time
 	archived      bool
+	attendance    Attendance
+	// externalSeriesID links the meeting to a recurring event series in an
+	// external calendar it was imported from.
+	externalSeriesID string
 }
 
 // NewMeeting creates a new meeting.
@@ -112,6 +116,7 @@ func (m *Meeting) Duration() time.Duration      { return m.duration }
 func (m *Meeting) PreferredTime() time.Duration { return m.preferredTime }
 func (m *Meeting) LastHeldAt() *time.Time       { return m.lastHeldAt }
 func (m *Meeting) IsArchived() bool             { return m.archived }
+func (m *Meeting) ExternalSeriesID() string     { return m.externalSeriesID }
 
 // SetName updates the meeting name.
 func (m *Meeting) SetName(name string) error {
@@ -146,6 +151,8 @@ func (m *Meeting) SetCadence(cadence Cadence, cadenceDays int) error {
 	if m.cadence != cadence || m.cadenceDays != cadenceDays {
 		m.cadence = cadence
 		m.cadenceDays = cadenceDays
+		// Attendance is judged against the current cadence.
+		m.attendance = Attendance{LastCanceledAt: m.attendance.LastCanceledAt}
 		m.Touch()
 		m.AddDomainEvent(NewMeetingCadenceChanged(m))
 	}
@@ -178,12 +185,24 @@ func (m *Meeting) SetPreferredTime(preferred time.Duration) error {
 	return nil
 }
 
+// LinkExternalSeries records the external recurring event series the meeting
+// mirrors, so later imports of the same series find this meeting.
+func (m *Meeting) LinkExternalSeries(seriesID string) error {
+	if m.archived {
+		return ErrMeetingArchived
+	}
+	m.externalSeriesID = strings.TrimSpace(seriesID)
+	m.Touch()
+	return nil
+}
+
 // MarkHeld updates the last-held timestamp.
 func (m *Meeting) MarkHeld(at time.Time) error {
 	if m.archived {
 		return ErrMeetingArchived
 	}
 	m.lastHeldAt = &at
+	m.attendance.Held++
 	m.Touch()
 	return nil
 }
@@ -216,6 +235,9 @@ func (m *Meeting) NextOccurrence(from time.Time) time.Time {
 	if m.lastHeldAt != nil {
 		base = *m.lastHeldAt
 	}
+	if canceled := m.attendance.LastCanceledAt; canceled != nil && canceled.After(base) {
+		base = *canceled
+	}
 
 	baseDate := time.Date(base.Year(), base.Month(), base.Day(), 0, 0, 0, 0, from.Location())
 	next := baseDate.AddDate(0, 0, m.cadenceDays).Add(m.preferredTime)
@@ -254,3 +276,8 @@ func RehydrateMeeting(
 		archived:          archived,
 	}
 }
+
+// RehydrateExternalSeries restores the linked external series from persistence.
+func (m *Meeting) RehydrateExternalSeries(seriesID string) {
+	m.externalSeriesID = seriesID
+}
@@ -315,6 +315,49 @@ func TestMeeting_MarkHeld_Archived(t *testing.T) {
 	assert.ErrorIs(t, err, ErrMeetingArchived)
 }
 
+func TestMeeting_MarkCanceled(t *testing.T) {
+	meeting, _ := NewMeeting(uuid.New(), "Sync", CadenceWeekly, 0, 30*time.Minute, 9*time.Hour)
+	canceledAt := time.Now()
+
+	require.NoError(t, meeting.MarkHeld(canceledAt.Add(-7*24*time.Hour)))
+	require.NoError(t, meeting.MarkCanceled(canceledAt))
+
+	attendance := meeting.Attendance()
+	assert.Equal(t, 1, attendance.Held)
+	assert.Equal(t, 1, attendance.Canceled)
+	require.NotNil(t, attendance.LastCanceledAt)
+	assert.Equal(t, canceledAt, *attendance.LastCanceledAt)
+	assert.Equal(t, 0.5, attendance.CancellationRate())
+
+	meeting.Archive()
+	assert.ErrorIs(t, meeting.MarkCanceled(time.Now()), ErrMeetingArchived)
+}
+
+func TestMeeting_SetCadence_ResetsAttendance(t *testing.T) {
+	meeting, _ := NewMeeting(uuid.New(), "Sync", CadenceWeekly, 0, 30*time.Minute, 9*time.Hour)
+	canceledAt := time.Now()
+	require.NoError(t, meeting.MarkHeld(canceledAt.Add(-7*24*time.Hour)))
+	require.NoError(t, meeting.MarkCanceled(canceledAt))
+
+	require.NoError(t, meeting.SetCadence(CadenceBiweekly, 0))
+
+	attendance := meeting.Attendance()
+	assert.Zero(t, attendance.Occurrences())
+	assert.Zero(t, attendance.CancellationRate())
+	require.NotNil(t, attendance.LastCanceledAt)
+}
+
+func TestMeeting_LinkExternalSeries(t *testing.T) {
+	meeting, _ := NewMeeting(uuid.New(), "Sync", CadenceWeekly, 0, 30*time.Minute, 9*time.Hour)
+
+	err := meeting.LinkExternalSeries(" series-123 ")
+	require.NoError(t, err)
+	assert.Equal(t, "series-123", meeting.ExternalSeriesID())
+
+	meeting.Archive()
+	assert.ErrorIs(t, meeting.LinkExternalSeries("series-456"), ErrMeetingArchived)
+}
+
 func TestMeeting_Archive(t *testing.T) {
 	meeting, _ := NewMeeting(uuid.New(), "Sync", CadenceWeekly, 0, 30*time.Minute, 9*time.Hour)
 	meeting.ClearDomainEvents()
@@ -399,6 +442,30 @@ func TestMeeting_NextOccurrenceFromLastHeld(t *testing.T) {
 	require.True(t, meeting.IsDueOn(dueDate))
 }
 
+func TestMeeting_NextOccurrenceAfterCancellation(t *testing.T) {
+	createdAt := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.UTC)
+	lastHeld := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+
+	meeting := RehydrateMeeting(
+		uuid.New(),
+		uuid.New(),
+		"Weekly 1:1",
+		CadenceWeekly,
+		7,
+		30*time.Minute,
+		9*time.Hour,
+		&lastHeld,
+		false,
+		createdAt,
+		createdAt,
+	)
+	require.NoError(t, meeting.MarkCanceled(time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC)))
+
+	// The canceled occurrence is not proposed again
+	assert.False(t, meeting.IsDueOn(time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, meeting.IsDueOn(time.Date(2024, time.January, 22, 12, 0, 0, 0, time.UTC)))
+}
+
 func TestMeeting_SetCadenceCustomRequiresInterval(t *testing.T) {
 	meeting, err := NewMeeting(uuid.New(), "Sync", CadenceWeekly, 0, 30*time.Minute, 9*time.Hour)
 	require.NoError(t, err)
@@ -12,4 +12,15 @@ type Repository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*Meeting, error)
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*Meeting, error)
 	FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*Meeting, error)
+	// FindByExternalSeriesID returns the user's meeting linked to the external
+	// recurring event series, or nil if there is none.
+	FindByExternalSeriesID(ctx context.Context, userID uuid.UUID, seriesID string) (*Meeting, error)
+}
+
+// SearchRepository finds a user's meetings by full-text search.
+type SearchRepository interface {
+	// Search returns up to limit of the user's meetings whose name contains
+	// every word of query, most relevant first. Words match as prefixes,
+	// ignoring case.
+	Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*Meeting, error)
 }
@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+// GuardedMeetingRepository wraps a meeting repository and rejects any call
+// that targets a user other than the authenticated principal in the context,
+// or that runs without a principal outside a system context.
+// Queries keyed by user are checked before they reach the database; lookups
+// by ID are checked against the owner of the loaded meeting.
+type GuardedMeetingRepository struct {
+	inner domain.Repository
+}
+
+// NewGuardedMeetingRepository wraps inner with a principal check.
+func NewGuardedMeetingRepository(inner domain.Repository) *GuardedMeetingRepository {
+	return &GuardedMeetingRepository{inner: inner}
+}
+
+// Save persists a meeting owned by the principal.
+func (r *GuardedMeetingRepository) Save(ctx context.Context, m *domain.Meeting) error {
+	if err := sharedApplication.CheckPrincipal(ctx, m.UserID()); err != nil {
+		return err
+	}
+	return r.inner.Save(ctx, m)
+}
+
+// FindByID loads a meeting and rejects it if it belongs to another user.
+func (r *GuardedMeetingRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Meeting, error) {
+	m, err := r.inner.FindByID(ctx, id)
+	if err != nil || m == nil {
+		return m, err
+	}
+	if err := sharedApplication.CheckPrincipal(ctx, m.UserID()); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FindByUserID finds all meetings for the principal.
+func (r *GuardedMeetingRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Meeting, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindByUserID(ctx, userID)
+}
+
+// FindActiveByUserID finds the principal's non-archived meetings.
+func (r *GuardedMeetingRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Meeting, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindActiveByUserID(ctx, userID)
+}
+
+// FindByExternalSeriesID finds the principal's meeting linked to an external
+// recurring event series.
+func (r *GuardedMeetingRepository) FindByExternalSeriesID(ctx context.Context, userID uuid.UUID, seriesID string) (*domain.Meeting, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindByExternalSeriesID(ctx, userID, seriesID)
+}
+
+// Search finds the principal's meetings matching query. It returns nothing
+// when the wrapped repository cannot search.
+func (r *GuardedMeetingRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*domain.Meeting, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	searcher, ok := r.inner.(domain.SearchRepository)
+	if !ok {
+		return nil, nil
+	}
+	return searcher.Search(ctx, userID, query, limit)
+}
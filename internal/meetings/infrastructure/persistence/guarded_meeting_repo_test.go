@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardedMeetingRepository(t *testing.T) {
+	sqlDB := setupMeetingTestDB(t)
+	defer sqlDB.Close()
+
+	owner := uuid.New()
+	other := uuid.New()
+	createMeetingTestUser(t, sqlDB, owner)
+	createMeetingTestUser(t, sqlDB, other)
+
+	repo := NewGuardedMeetingRepository(NewSQLiteMeetingRepository(sqlDB))
+	ownerCtx := sharedApplication.WithPrincipal(context.Background(), owner)
+	otherCtx := sharedApplication.WithPrincipal(context.Background(), other)
+
+	meeting, err := domain.NewMeeting(owner, "1:1", domain.CadenceWeekly, 7, 30*time.Minute, 10*time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ownerCtx, meeting))
+
+	t.Run("owner can read their meetings", func(t *testing.T) {
+		found, err := repo.FindByID(ownerCtx, meeting.ID())
+		require.NoError(t, err)
+		assert.Equal(t, meeting.ID(), found.ID())
+
+		meetings, err := repo.FindByUserID(ownerCtx, owner)
+		require.NoError(t, err)
+		assert.Len(t, meetings, 1)
+	})
+
+	t.Run("rejects another user's meetings", func(t *testing.T) {
+		_, err := repo.FindByID(otherCtx, meeting.ID())
+		assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+		_, err = repo.FindActiveByUserID(otherCtx, owner)
+		assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+		assert.ErrorIs(t, repo.Save(otherCtx, meeting), sharedApplication.ErrPrincipalMismatch)
+	})
+
+	t.Run("rejects a context without a principal", func(t *testing.T) {
+		_, err := repo.FindByExternalSeriesID(context.Background(), owner, "series")
+		assert.ErrorIs(t, err, sharedApplication.ErrNoPrincipal)
+	})
+
+	t.Run("allows a system context", func(t *testing.T) {
+		meetings, err := repo.FindActiveByUserID(sharedApplication.WithSystem(context.Background()), owner)
+		require.NoError(t, err)
+		assert.Len(t, meetings, 1)
+	})
+}
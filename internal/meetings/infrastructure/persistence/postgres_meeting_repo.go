@@ -34,6 +34,10 @@ type meetingRow struct {
 	Archived             bool
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
+	ExternalSeriesID     *string
+	HeldCount            int
+	CanceledCount        int
+	LastCanceledAt       *time.Time
 }
 
 // Save persists a meeting to the database.
@@ -59,8 +63,9 @@ func (r *PostgresMeetingRepository) saveWithTx(ctx context.Context, tx pgx.Tx, m
 	query := `
 		INSERT INTO meetings (
 			id, user_id, name, cadence, cadence_days, duration_minutes,
-			preferred_time_minutes, last_held_at, archived, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+			external_series_id, held_count, canceled_count, last_canceled_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		ON CONFLICT (id) DO UPDATE SET
 			name = EXCLUDED.name,
 			cadence = EXCLUDED.cadence,
@@ -69,6 +74,10 @@ func (r *PostgresMeetingRepository) saveWithTx(ctx context.Context, tx pgx.Tx, m
 			preferred_time_minutes = EXCLUDED.preferred_time_minutes,
 			last_held_at = EXCLUDED.last_held_at,
 			archived = EXCLUDED.archived,
+			external_series_id = EXCLUDED.external_series_id,
+			held_count = EXCLUDED.held_count,
+			canceled_count = EXCLUDED.canceled_count,
+			last_canceled_at = EXCLUDED.last_canceled_at,
 			updated_at = NOW()
 	`
 
@@ -84,15 +93,44 @@ func (r *PostgresMeetingRepository) saveWithTx(ctx context.Context, tx pgx.Tx, m
 		meeting.IsArchived(),
 		meeting.CreatedAt(),
 		meeting.UpdatedAt(),
+		nullableSeriesID(meeting.ExternalSeriesID()),
+		meeting.Attendance().Held,
+		meeting.Attendance().Canceled,
+		meeting.Attendance().LastCanceledAt,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	return sharedPersistence.IndexPostgresSearchDocument(ctx, tx, meetingSearchDocument(meeting))
+}
+
+// Search returns up to limit of the user's meetings matching query, most
+// relevant first.
+func (r *PostgresMeetingRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*domain.Meeting, error) {
+	ids, err := sharedPersistence.SearchPostgresIndex(ctx, sharedPersistence.Executor(ctx, r.pool), sharedPersistence.SearchEntityMeeting, userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	meetings := make([]*domain.Meeting, 0, len(ids))
+	for _, id := range ids {
+		meeting, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if meeting != nil {
+			meetings = append(meetings, meeting)
+		}
+	}
+	return meetings, nil
 }
 
 // FindByID retrieves a meeting by its ID.
 func (r *PostgresMeetingRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Meeting, error) {
 	query := `
 		SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
-		       preferred_time_minutes, last_held_at, archived, created_at, updated_at
+		       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+		       external_series_id, held_count, canceled_count, last_canceled_at
 		FROM meetings
 		WHERE id = $1
 	`
@@ -110,6 +148,49 @@ func (r *PostgresMeetingRepository) FindByID(ctx context.Context, id uuid.UUID)
 		&row.Archived,
 		&row.CreatedAt,
 		&row.UpdatedAt,
+		&row.ExternalSeriesID,
+		&row.HeldCount,
+		&row.CanceledCount,
+		&row.LastCanceledAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.rowToMeeting(row), nil
+}
+
+// FindByExternalSeriesID retrieves the user's meeting linked to an external recurring event series.
+func (r *PostgresMeetingRepository) FindByExternalSeriesID(ctx context.Context, userID uuid.UUID, seriesID string) (*domain.Meeting, error) {
+	query := `
+		SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
+		       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+		       external_series_id, held_count, canceled_count, last_canceled_at
+		FROM meetings
+		WHERE user_id = $1 AND external_series_id = $2
+	`
+
+	var row meetingRow
+	err := r.pool.QueryRow(ctx, query, userID, seriesID).Scan(
+		&row.ID,
+		&row.UserID,
+		&row.Name,
+		&row.Cadence,
+		&row.CadenceDays,
+		&row.DurationMinutes,
+		&row.PreferredTimeMinutes,
+		&row.LastHeldAt,
+		&row.Archived,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+		&row.ExternalSeriesID,
+		&row.HeldCount,
+		&row.CanceledCount,
+		&row.LastCanceledAt,
 	)
 
 	if err != nil {
@@ -126,7 +207,8 @@ func (r *PostgresMeetingRepository) FindByID(ctx context.Context, id uuid.UUID)
 func (r *PostgresMeetingRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Meeting, error) {
 	query := `
 		SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
-		       preferred_time_minutes, last_held_at, archived, created_at, updated_at
+		       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+		       external_series_id, held_count, canceled_count, last_canceled_at
 		FROM meetings
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -145,7 +227,8 @@ func (r *PostgresMeetingRepository) FindByUserID(ctx context.Context, userID uui
 func (r *PostgresMeetingRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Meeting, error) {
 	query := `
 		SELECT id, user_id, name, cadence, cadence_days, duration_minutes,
-		       preferred_time_minutes, last_held_at, archived, created_at, updated_at
+		       preferred_time_minutes, last_held_at, archived, created_at, updated_at,
+		       external_series_id, held_count, canceled_count, last_canceled_at
 		FROM meetings
 		WHERE user_id = $1 AND archived = FALSE
 		ORDER BY created_at DESC
@@ -177,6 +260,10 @@ func (r *PostgresMeetingRepository) scanMeetings(rows pgx.Rows) ([]*domain.Meeti
 			&row.Archived,
 			&row.CreatedAt,
 			&row.UpdatedAt,
+			&row.ExternalSeriesID,
+			&row.HeldCount,
+			&row.CanceledCount,
+			&row.LastCanceledAt,
 		); err != nil {
 			return nil, err
 		}
@@ -191,7 +278,7 @@ func (r *PostgresMeetingRepository) scanMeetings(rows pgx.Rows) ([]*domain.Meeti
 }
 
 func (r *PostgresMeetingRepository) rowToMeeting(row meetingRow) *domain.Meeting {
-	return domain.RehydrateMeeting(
+	meeting := domain.RehydrateMeeting(
 		row.ID,
 		row.UserID,
 		row.Name,
@@ -204,4 +291,20 @@ func (r *PostgresMeetingRepository) rowToMeeting(row meetingRow) *domain.Meeting
 		row.CreatedAt,
 		row.UpdatedAt,
 	)
+	if row.ExternalSeriesID != nil {
+		meeting.RehydrateExternalSeries(*row.ExternalSeriesID)
+	}
+	meeting.RehydrateAttendance(domain.Attendance{
+		Held:           row.HeldCount,
+		Canceled:       row.CanceledCount,
+		LastCanceledAt: row.LastCanceledAt,
+	})
+	return meeting
+}
+
+func nullableSeriesID(seriesID string) *string {
+	if seriesID == "" {
+		return nil
+	}
+	return &seriesID
 }
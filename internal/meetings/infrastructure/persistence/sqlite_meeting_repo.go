@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	db "github.com/felixgeelhaar/orbita/db/generated/sqlite"
 	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	sharedPersistence "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/persistence"
 	"github.com/google/uuid"
 )
@@ -30,21 +32,33 @@ func (r *SQLiteMeetingRepository) getQuerier(ctx context.Context) *db.Queries {
 	return db.New(r.dbConn)
 }
 
+// getDB returns the raw database handle (transaction or connection) based on context.
+func (r *SQLiteMeetingRepository) getDB(ctx context.Context) sharedPersistence.SQLiteSearchExecer {
+	if info, ok := sharedPersistence.SQLiteTxInfoFromContext(ctx); ok {
+		return info.Tx
+	}
+	return r.dbConn
+}
+
 // Save persists a meeting to the database.
 func (r *SQLiteMeetingRepository) Save(ctx context.Context, meeting *domain.Meeting) error {
 	queries := r.getQuerier(ctx)
 	// Check if meeting exists
 	_, err := queries.GetMeetingByID(ctx, meeting.ID().String())
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			// Create new meeting
-			return r.create(ctx, meeting)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		// Create new meeting
+		if err := r.create(ctx, meeting); err != nil {
+			return err
 		}
+	} else if err := r.update(ctx, meeting); err != nil {
+		// Update existing meeting
 		return err
 	}
 
-	// Update existing meeting
-	return r.update(ctx, meeting)
+	return sharedPersistence.IndexSQLiteSearchDocument(ctx, r.getDB(ctx), meetingSearchDocument(meeting))
 }
 
 func (r *SQLiteMeetingRepository) create(ctx context.Context, meeting *domain.Meeting) error {
@@ -66,6 +80,10 @@ func (r *SQLiteMeetingRepository) create(ctx context.Context, meeting *domain.Me
 		Archived:             boolToInt64(meeting.IsArchived()),
 		CreatedAt:            meeting.CreatedAt().Format(time.RFC3339),
 		UpdatedAt:            meeting.UpdatedAt().Format(time.RFC3339),
+		ExternalSeriesID:     externalSeriesID(meeting),
+		HeldCount:            int64(meeting.Attendance().Held),
+		CanceledCount:        int64(meeting.Attendance().Canceled),
+		LastCanceledAt:       lastCanceledAt(meeting),
 	})
 }
 
@@ -76,8 +94,10 @@ func (r *SQLiteMeetingRepository) update(ctx context.Context, meeting *domain.Me
 		lastHeldAt = sql.NullString{String: meeting.LastHeldAt().Format(time.RFC3339), Valid: true}
 	}
 
-	return queries.UpdateMeeting(ctx, db.UpdateMeetingParams{
+	// The update only applies if nobody saved the meeting since it was loaded.
+	affected, err := queries.UpdateMeeting(ctx, db.UpdateMeetingParams{
 		ID:                   meeting.ID().String(),
+		Version:              int64(meeting.Version()),
 		Name:                 meeting.Name(),
 		Cadence:              string(meeting.Cadence()),
 		CadenceDays:          int64(meeting.CadenceDays()),
@@ -86,7 +106,19 @@ func (r *SQLiteMeetingRepository) update(ctx context.Context, meeting *domain.Me
 		LastHeldAt:           lastHeldAt,
 		Archived:             boolToInt64(meeting.IsArchived()),
 		UpdatedAt:            time.Now().Format(time.RFC3339),
+		ExternalSeriesID:     externalSeriesID(meeting),
+		HeldCount:            int64(meeting.Attendance().Held),
+		CanceledCount:        int64(meeting.Attendance().Canceled),
+		LastCanceledAt:       lastCanceledAt(meeting),
 	})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: meeting %s was modified by another process", sharedDomain.ErrConcurrentModification, meeting.ID())
+	}
+	meeting.SetVersion(meeting.Version() + 1)
+	return nil
 }
 
 // FindByID retrieves a meeting by its ID.
@@ -125,6 +157,44 @@ func (r *SQLiteMeetingRepository) FindActiveByUserID(ctx context.Context, userID
 	return r.rowsToMeetings(rows), nil
 }
 
+// FindByExternalSeriesID retrieves the user's meeting linked to an external recurring event series.
+func (r *SQLiteMeetingRepository) FindByExternalSeriesID(ctx context.Context, userID uuid.UUID, seriesID string) (*domain.Meeting, error) {
+	queries := r.getQuerier(ctx)
+	row, err := queries.GetMeetingByExternalSeriesID(ctx, db.GetMeetingByExternalSeriesIDParams{
+		UserID:           userID.String(),
+		ExternalSeriesID: sql.NullString{String: seriesID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.rowToMeeting(row), nil
+}
+
+// Search returns up to limit of the user's meetings matching query, most
+// relevant first.
+func (r *SQLiteMeetingRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*domain.Meeting, error) {
+	ids, err := sharedPersistence.SearchSQLiteIndex(ctx, r.getDB(ctx), sharedPersistence.SearchEntityMeeting, userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	meetings := make([]*domain.Meeting, 0, len(ids))
+	for _, id := range ids {
+		meeting, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if meeting != nil {
+			meetings = append(meetings, meeting)
+		}
+	}
+	return meetings, nil
+}
+
 func (r *SQLiteMeetingRepository) rowsToMeetings(rows []db.Meeting) []*domain.Meeting {
 	meetings := make([]*domain.Meeting, 0, len(rows))
 	for _, row := range rows {
@@ -145,7 +215,7 @@ func (r *SQLiteMeetingRepository) rowToMeeting(row db.Meeting) *domain.Meeting {
 		lastHeldAt = &t
 	}
 
-	return domain.RehydrateMeeting(
+	meeting := domain.RehydrateMeeting(
 		id,
 		userID,
 		row.Name,
@@ -158,6 +228,40 @@ func (r *SQLiteMeetingRepository) rowToMeeting(row db.Meeting) *domain.Meeting {
 		createdAt,
 		updatedAt,
 	)
+	meeting.RehydrateExternalSeries(row.ExternalSeriesID.String)
+	attendance := domain.Attendance{Held: int(row.HeldCount), Canceled: int(row.CanceledCount)}
+	if row.LastCanceledAt.Valid {
+		t, _ := time.Parse(time.RFC3339, row.LastCanceledAt.String)
+		attendance.LastCanceledAt = &t
+	}
+	meeting.RehydrateAttendance(attendance)
+	meeting.SetVersion(int(row.Version))
+	return meeting
+}
+
+// meetingSearchDocument returns the meeting's search index entry.
+func meetingSearchDocument(meeting *domain.Meeting) sharedPersistence.SearchDocument {
+	return sharedPersistence.SearchDocument{
+		EntityType: sharedPersistence.SearchEntityMeeting,
+		EntityID:   meeting.ID(),
+		UserID:     meeting.UserID(),
+		Title:      meeting.Name(),
+	}
+}
+
+func externalSeriesID(meeting *domain.Meeting) sql.NullString {
+	if meeting.ExternalSeriesID() == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: meeting.ExternalSeriesID(), Valid: true}
+}
+
+func lastCanceledAt(meeting *domain.Meeting) sql.NullString {
+	canceledAt := meeting.Attendance().LastCanceledAt
+	if canceledAt == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: canceledAt.Format(time.RFC3339), Valid: true}
 }
 
 // Helper function
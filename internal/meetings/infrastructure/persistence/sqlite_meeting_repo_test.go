@@ -10,6 +10,7 @@ import (
 
 	db "github.com/felixgeelhaar/orbita/db/generated/sqlite"
 	"github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,13 +26,23 @@ func setupMeetingTestDB(t *testing.T) *sql.DB {
 	sqlDB, err := sql.Open("sqlite", ":memory:")
 	require.NoError(t, err)
 
-	// Read and execute the schema
-	schemaPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", "000001_initial_schema.up.sql")
-	schema, err := os.ReadFile(schemaPath)
-	require.NoError(t, err, "Failed to read SQLite schema file")
+	// Apply migrations in order
+	migrations := []string{
+		"000001_initial_schema.up.sql",
+		"000010_meeting_external_series.up.sql",
+		"000017_aggregate_versions.up.sql",
+		"000023_meeting_attendance.up.sql",
+		"000034_search_index.up.sql",
+	}
+
+	for _, migration := range migrations {
+		schemaPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", migration)
+		schema, err := os.ReadFile(schemaPath)
+		require.NoError(t, err, "Failed to read SQLite schema file: %s", migration)
 
-	_, err = sqlDB.Exec(string(schema))
-	require.NoError(t, err, "Failed to apply SQLite schema")
+		_, err = sqlDB.Exec(string(schema))
+		require.NoError(t, err, "Failed to apply SQLite schema: %s", migration)
+	}
 
 	return sqlDB
 }
@@ -116,6 +127,39 @@ func TestSQLiteMeetingRepository_Save_Update(t *testing.T) {
 	assert.Equal(t, 14, updated.CadenceDays())
 }
 
+func TestSQLiteMeetingRepository_Save_StaleWrite(t *testing.T) {
+	sqlDB := setupMeetingTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createMeetingTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteMeetingRepository(sqlDB)
+	ctx := context.Background()
+
+	meeting, err := domain.NewMeeting(userID, "1:1 with Alex", domain.CadenceWeekly, 7, 30*time.Minute, 10*time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, meeting))
+
+	// Two devices load the same meeting
+	first, err := repo.FindByID(ctx, meeting.ID())
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, meeting.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, first.SetName("Weekly 1:1"))
+	require.NoError(t, repo.Save(ctx, first))
+
+	// The second save was based on the old version
+	require.NoError(t, second.SetName("Biweekly 1:1"))
+	err = repo.Save(ctx, second)
+	assert.ErrorIs(t, err, sharedDomain.ErrConcurrentModification)
+
+	retrieved, err := repo.FindByID(ctx, meeting.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly 1:1", retrieved.Name())
+}
+
 func TestSQLiteMeetingRepository_FindByID_NotFound(t *testing.T) {
 	sqlDB := setupMeetingTestDB(t)
 	defer sqlDB.Close()
@@ -224,6 +268,34 @@ func TestSQLiteMeetingRepository_MarkHeld(t *testing.T) {
 	assert.Equal(t, heldTime.Unix(), found.LastHeldAt().Unix())
 }
 
+func TestSQLiteMeetingRepository_Attendance(t *testing.T) {
+	sqlDB := setupMeetingTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createMeetingTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteMeetingRepository(sqlDB)
+	ctx := context.Background()
+
+	meeting, err := domain.NewMeeting(userID, "Recurring Meeting", domain.CadenceWeekly, 7, 30*time.Minute, 10*time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, meeting))
+
+	canceledAt := time.Now().Truncate(time.Second)
+	require.NoError(t, meeting.MarkHeld(canceledAt.Add(-7*24*time.Hour)))
+	require.NoError(t, meeting.MarkCanceled(canceledAt))
+	require.NoError(t, repo.Save(ctx, meeting))
+
+	found, err := repo.FindByID(ctx, meeting.ID())
+	require.NoError(t, err)
+	attendance := found.Attendance()
+	assert.Equal(t, 1, attendance.Held)
+	assert.Equal(t, 1, attendance.Canceled)
+	require.NotNil(t, attendance.LastCanceledAt)
+	assert.Equal(t, canceledAt.Unix(), attendance.LastCanceledAt.Unix())
+}
+
 func TestSQLiteMeetingRepository_Archive(t *testing.T) {
 	sqlDB := setupMeetingTestDB(t)
 	defer sqlDB.Close()
@@ -417,6 +489,51 @@ func TestSQLiteMeetingRepository_MultipleUsers(t *testing.T) {
 	assert.Len(t, meetings2, 1)
 }
 
+func TestSQLiteMeetingRepository_FindByExternalSeriesID(t *testing.T) {
+	sqlDB := setupMeetingTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	createMeetingTestUser(t, sqlDB, userID)
+	createMeetingTestUser(t, sqlDB, otherUserID)
+
+	repo := NewSQLiteMeetingRepository(sqlDB)
+	ctx := context.Background()
+
+	imported, err := domain.NewMeeting(userID, "Team Sync", domain.CadenceWeekly, 0, 30*time.Minute, 9*time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, imported.LinkExternalSeries("series-123"))
+	require.NoError(t, repo.Save(ctx, imported))
+
+	manual, err := domain.NewMeeting(userID, "Coffee", domain.CadenceWeekly, 0, 30*time.Minute, 9*time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, manual))
+
+	found, err := repo.FindByExternalSeriesID(ctx, userID, "series-123")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, imported.ID(), found.ID())
+	assert.Equal(t, "series-123", found.ExternalSeriesID())
+
+	// The link survives updates made through Save.
+	require.NoError(t, found.SetName("Team Sync (renamed)"))
+	require.NoError(t, repo.Save(ctx, found))
+	found, err = repo.FindByExternalSeriesID(ctx, userID, "series-123")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "Team Sync (renamed)", found.Name())
+
+	reloaded, err := repo.FindByID(ctx, manual.ID())
+	require.NoError(t, err)
+	assert.Empty(t, reloaded.ExternalSeriesID())
+
+	// Series are matched per user.
+	missing, err := repo.FindByExternalSeriesID(ctx, otherUserID, "series-123")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
 func TestBoolToInt64(t *testing.T) {
 	assert.Equal(t, int64(1), boolToInt64(true))
 	assert.Equal(t, int64(0), boolToInt64(false))
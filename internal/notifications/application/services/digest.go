@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
+	insightsCommands "github.com/felixgeelhaar/orbita/internal/insights/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	productivityQueries "github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	"github.com/google/uuid"
+)
+
+// TaskLister lists a user's tasks.
+type TaskLister interface {
+	Handle(ctx context.Context, query productivityQueries.ListTasksQuery) ([]productivityQueries.TaskDTO, error)
+}
+
+// ScheduleReader reads a user's schedule for a day.
+type ScheduleReader interface {
+	Handle(ctx context.Context, query scheduleQueries.GetScheduleQuery) (*scheduleQueries.ScheduleDTO, error)
+}
+
+// HabitLister lists a user's habits.
+type HabitLister interface {
+	Handle(ctx context.Context, query habitQueries.ListHabitsQuery) ([]habitQueries.HabitDTO, error)
+}
+
+// WeeklyReviewer computes the summary of a user's week.
+type WeeklyReviewer interface {
+	ComputeWeeklySummary(ctx context.Context, cmd insightsCommands.ComputeWeeklySummaryCommand) (*insightsCommands.ComputeWeeklySummaryResult, error)
+}
+
+// DigestComposer compiles a user's agenda for the day, or their review of the
+// past week, into a single notification. Sources that are not set are left
+// out of the digest.
+type DigestComposer struct {
+	tasks     TaskLister
+	schedules ScheduleReader
+	habits    HabitLister
+	review    WeeklyReviewer
+}
+
+// NewDigestComposer creates a digest composer. The weekly review is added
+// with WithWeeklyReview.
+func NewDigestComposer(tasks TaskLister, schedules ScheduleReader, habits HabitLister) *DigestComposer {
+	return &DigestComposer{tasks: tasks, schedules: schedules, habits: habits}
+}
+
+// WithWeeklyReview sets where weekly digests get their review from.
+func (c *DigestComposer) WithWeeklyReview(review WeeklyReviewer) *DigestComposer {
+	c.review = review
+	return c
+}
+
+// Compose builds the digest notification for a user. at is the time the
+// digest was scheduled for, in the user's time zone: daily digests cover
+// that day and weekly digests the week before it.
+func (c *DigestComposer) Compose(ctx context.Context, userID uuid.UUID, frequency domain.DigestFrequency, at time.Time) (domain.Notification, error) {
+	switch frequency {
+	case domain.DigestDaily:
+		return c.composeDaily(ctx, userID, at)
+	case domain.DigestWeekly:
+		return c.composeWeekly(ctx, userID, at)
+	default:
+		return domain.Notification{}, fmt.Errorf("%w: %s", domain.ErrUnknownDigestFrequency, frequency)
+	}
+}
+
+func (c *DigestComposer) composeDaily(ctx context.Context, userID uuid.UUID, at time.Time) (domain.Notification, error) {
+	loc := at.Location()
+	dayStart := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	var sections []string
+
+	if c.schedules != nil {
+		schedule, err := c.schedules.Handle(ctx, scheduleQueries.GetScheduleQuery{UserID: userID, Date: dayStart})
+		if err != nil {
+			return domain.Notification{}, fmt.Errorf("failed to load schedule: %w", err)
+		}
+		if schedule != nil && len(schedule.Blocks) > 0 {
+			blocks := append([]scheduleQueries.TimeBlockDTO(nil), schedule.Blocks...)
+			sort.Slice(blocks, func(i, j int) bool { return blocks[i].StartTime.Before(blocks[j].StartTime) })
+			lines := make([]string, 0, len(blocks))
+			for _, block := range blocks {
+				lines = append(lines, fmt.Sprintf("%s-%s %s",
+					block.StartTime.In(loc).Format("15:04"),
+					block.EndTime.In(loc).Format("15:04"),
+					block.Title,
+				))
+			}
+			sections = append(sections, digestSection("Schedule", lines))
+		}
+	}
+
+	if c.tasks != nil {
+		tasks, err := c.tasks.Handle(ctx, productivityQueries.ListTasksQuery{
+			UserID:    userID,
+			DueBefore: &dayEnd,
+			SortBy:    "due_date",
+		})
+		if err != nil {
+			return domain.Notification{}, fmt.Errorf("failed to load tasks: %w", err)
+		}
+		var overdue, dueToday []string
+		for _, task := range tasks {
+			if task.DueDate == nil || task.Status == "completed" {
+				continue
+			}
+			line := task.Title
+			if task.Priority == "urgent" || task.Priority == "high" {
+				line += fmt.Sprintf(" [%s]", strings.ToUpper(task.Priority))
+			}
+			if task.DueDate.Before(dayStart) {
+				overdue = append(overdue, line)
+			} else {
+				dueToday = append(dueToday, line)
+			}
+		}
+		if len(overdue) > 0 {
+			sections = append(sections, digestSection("Overdue", overdue))
+		}
+		if len(dueToday) > 0 {
+			sections = append(sections, digestSection("Due today", dueToday))
+		}
+	}
+
+	if c.habits != nil {
+		habits, err := c.habits.Handle(ctx, habitQueries.ListHabitsQuery{UserID: userID, OnlyDueToday: true})
+		if err != nil {
+			return domain.Notification{}, fmt.Errorf("failed to load habits: %w", err)
+		}
+		var lines []string
+		for _, habit := range habits {
+			if habit.CompletedToday {
+				continue
+			}
+			line := habit.Name
+			if habit.Streak > 0 {
+				line += fmt.Sprintf(" (%d day streak)", habit.Streak)
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) > 0 {
+			sections = append(sections, digestSection("Habits", lines))
+		}
+	}
+
+	body := "Nothing scheduled or due today."
+	if len(sections) > 0 {
+		body = strings.Join(sections, "\n\n")
+	}
+	title := "Today's agenda: " + at.Format("Monday, January 2")
+	return domain.NewNotification(userID, title, body, "low"), nil
+}
+
+func (c *DigestComposer) composeWeekly(ctx context.Context, userID uuid.UUID, at time.Time) (domain.Notification, error) {
+	if c.review == nil {
+		return domain.Notification{}, errors.New("weekly review is not available")
+	}
+
+	lastWeek := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location()).AddDate(0, 0, -7)
+	result, err := c.review.ComputeWeeklySummary(ctx, insightsCommands.ComputeWeeklySummaryCommand{
+		UserID:    userID,
+		WeekStart: lastWeek,
+	})
+	if err != nil {
+		return domain.Notification{}, fmt.Errorf("failed to compute weekly review: %w", err)
+	}
+	summary := result.Summary
+
+	lines := []string{
+		fmt.Sprintf("Tasks completed: %d", summary.TotalTasksCompleted),
+		fmt.Sprintf("Habits completed: %d", summary.TotalHabitsCompleted),
+		fmt.Sprintf("Blocks completed: %d", summary.TotalBlocksCompleted),
+		fmt.Sprintf("Focus time: %s", formatDigestMinutes(summary.TotalFocusMinutes)),
+	}
+	if result.DaysWithData > 0 {
+		lines = append(lines, fmt.Sprintf("Average productivity score: %.0f", summary.AvgDailyProductivityScore))
+	}
+	if summary.ProductivityTrend != 0 {
+		lines = append(lines, fmt.Sprintf("Productivity trend: %+.0f%% on the week before", summary.ProductivityTrend))
+	}
+	if summary.AvgFocusScore > 0 {
+		lines = append(lines, fmt.Sprintf("Average focus score: %.0f", summary.AvgFocusScore))
+	}
+	if summary.FocusScoreTrend != 0 {
+		lines = append(lines, fmt.Sprintf("Focus score trend: %+.0f%% on the week before", summary.FocusScoreTrend))
+	}
+	if summary.MostProductiveDay != nil {
+		lines = append(lines, "Most productive day: "+summary.MostProductiveDay.Format("Monday"))
+	}
+	if summary.LongestStreak > 0 {
+		lines = append(lines, fmt.Sprintf("Longest habit streak: %d days", summary.LongestStreak))
+	}
+
+	title := fmt.Sprintf("Weekly review: %s - %s",
+		summary.WeekStart.Format("Jan 2"),
+		summary.WeekEnd.Format("Jan 2"),
+	)
+	return domain.NewNotification(userID, title, digestSection("Last week", lines), "low"), nil
+}
+
+// digestSection formats a heading followed by one bullet per line.
+func digestSection(heading string, lines []string) string {
+	var b strings.Builder
+	b.WriteString(heading)
+	b.WriteString(":")
+	for _, line := range lines {
+		b.WriteString("\n- ")
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+func formatDigestMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	habitQueries "github.com/felixgeelhaar/orbita/internal/habits/application/queries"
+	insightsCommands "github.com/felixgeelhaar/orbita/internal/insights/application/commands"
+	insightsDomain "github.com/felixgeelhaar/orbita/internal/insights/domain"
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	productivityQueries "github.com/felixgeelhaar/orbita/internal/productivity/application/queries"
+	scheduleQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTaskLister struct {
+	tasks []productivityQueries.TaskDTO
+	query productivityQueries.ListTasksQuery
+	err   error
+}
+
+func (s *stubTaskLister) Handle(ctx context.Context, query productivityQueries.ListTasksQuery) ([]productivityQueries.TaskDTO, error) {
+	s.query = query
+	if s.err != nil {
+		return nil, s.err
+	}
+	var tasks []productivityQueries.TaskDTO
+	for _, task := range s.tasks {
+		if task.DueDate != nil && query.DueBefore != nil && !task.DueDate.Before(*query.DueBefore) {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+type stubScheduleReader struct {
+	schedule *scheduleQueries.ScheduleDTO
+	query    scheduleQueries.GetScheduleQuery
+}
+
+func (s *stubScheduleReader) Handle(ctx context.Context, query scheduleQueries.GetScheduleQuery) (*scheduleQueries.ScheduleDTO, error) {
+	s.query = query
+	return s.schedule, nil
+}
+
+type stubHabitLister struct {
+	habits []habitQueries.HabitDTO
+}
+
+func (s stubHabitLister) Handle(ctx context.Context, query habitQueries.ListHabitsQuery) ([]habitQueries.HabitDTO, error) {
+	return s.habits, nil
+}
+
+type stubWeeklyReviewer struct {
+	result *insightsCommands.ComputeWeeklySummaryResult
+	cmd    insightsCommands.ComputeWeeklySummaryCommand
+}
+
+func (s *stubWeeklyReviewer) ComputeWeeklySummary(ctx context.Context, cmd insightsCommands.ComputeWeeklySummaryCommand) (*insightsCommands.ComputeWeeklySummaryResult, error) {
+	s.cmd = cmd
+	return s.result, nil
+}
+
+func TestDigestComposer_Daily(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	userID := uuid.New()
+	at := time.Date(2024, time.March, 12, 7, 0, 0, 0, tokyo)
+	at9 := time.Date(2024, time.March, 12, 9, 0, 0, 0, tokyo)
+	yesterday := at.AddDate(0, 0, -1)
+	tonight := time.Date(2024, time.March, 12, 23, 0, 0, 0, tokyo)
+	tomorrow := at.AddDate(0, 0, 1)
+
+	tasks := &stubTaskLister{tasks: []productivityQueries.TaskDTO{
+		{Title: "File taxes", Priority: "urgent", Status: "pending", DueDate: &yesterday},
+		{Title: "Call the bank", Priority: "medium", Status: "pending", DueDate: &tonight},
+		{Title: "Already done", Priority: "high", Status: "completed", DueDate: &tonight},
+		{Title: "Plan offsite", Priority: "low", Status: "pending", DueDate: &tomorrow},
+	}}
+	schedules := &stubScheduleReader{schedule: &scheduleQueries.ScheduleDTO{Blocks: []scheduleQueries.TimeBlockDTO{
+		// Stored in UTC, listed in the user's time zone and in order.
+		{Title: "Deep work", StartTime: at9.Add(2 * time.Hour).UTC(), EndTime: at9.Add(3 * time.Hour).UTC()},
+		{Title: "Standup", StartTime: at9.UTC(), EndTime: at9.Add(15 * time.Minute).UTC()},
+	}}}
+	habits := stubHabitLister{habits: []habitQueries.HabitDTO{
+		{Name: "Meditate", Streak: 4},
+		{Name: "Stretch", CompletedToday: true},
+	}}
+
+	n, err := NewDigestComposer(tasks, schedules, habits).Compose(context.Background(), userID, domain.DigestDaily, at)
+	require.NoError(t, err)
+
+	assert.Equal(t, userID, n.UserID)
+	assert.Equal(t, "Today's agenda: Tuesday, March 12", n.Title)
+	assert.Equal(t, `Schedule:
+- 09:00-09:15 Standup
+- 11:00-12:00 Deep work
+
+Overdue:
+- File taxes [URGENT]
+
+Due today:
+- Call the bank
+
+Habits:
+- Meditate (4 day streak)`, n.Body)
+
+	assert.Equal(t, 12, schedules.query.Date.Day())
+	require.NotNil(t, tasks.query.DueBefore)
+	assert.Equal(t, time.Date(2024, time.March, 13, 0, 0, 0, 0, tokyo), *tasks.query.DueBefore)
+}
+
+func TestDigestComposer_DailyNothingDue(t *testing.T) {
+	n, err := NewDigestComposer(&stubTaskLister{}, &stubScheduleReader{}, stubHabitLister{}).
+		Compose(context.Background(), uuid.New(), domain.DigestDaily, time.Date(2024, time.March, 12, 7, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "Nothing scheduled or due today.", n.Body)
+}
+
+func TestDigestComposer_DailySourceError(t *testing.T) {
+	_, err := NewDigestComposer(&stubTaskLister{err: errors.New("db down")}, nil, nil).
+		Compose(context.Background(), uuid.New(), domain.DigestDaily, time.Now())
+	assert.ErrorContains(t, err, "db down")
+}
+
+func TestDigestComposer_Weekly(t *testing.T) {
+	userID := uuid.New()
+	weekStart := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	best := time.Date(2024, time.March, 6, 0, 0, 0, 0, time.UTC)
+	reviewer := &stubWeeklyReviewer{result: &insightsCommands.ComputeWeeklySummaryResult{
+		DaysWithData: 5,
+		Summary: &insightsDomain.WeeklySummary{
+			WeekStart:                 weekStart,
+			WeekEnd:                   weekStart.AddDate(0, 0, 6),
+			TotalTasksCompleted:       12,
+			TotalHabitsCompleted:      20,
+			TotalBlocksCompleted:      9,
+			TotalFocusMinutes:         605,
+			AvgDailyProductivityScore: 71.6,
+			ProductivityTrend:         8,
+			AvgFocusScore:             68.4,
+			FocusScoreTrend:           -5,
+			MostProductiveDay:         &best,
+			LongestStreak:             6,
+		},
+	}}
+
+	at := time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)
+	n, err := NewDigestComposer(nil, nil, nil).WithWeeklyReview(reviewer).
+		Compose(context.Background(), userID, domain.DigestWeekly, at)
+	require.NoError(t, err)
+
+	assert.Equal(t, userID, reviewer.cmd.UserID)
+	assert.Equal(t, weekStart, reviewer.cmd.WeekStart, "reviews the week before the digest")
+	assert.Equal(t, "Weekly review: Mar 4 - Mar 10", n.Title)
+	assert.Equal(t, `Last week:
+- Tasks completed: 12
+- Habits completed: 20
+- Blocks completed: 9
+- Focus time: 10h 5m
+- Average productivity score: 72
+- Productivity trend: +8% on the week before
+- Average focus score: 68
+- Focus score trend: -5% on the week before
+- Most productive day: Wednesday
+- Longest habit streak: 6 days`, n.Body)
+}
+
+func TestDigestComposer_WeeklyWithoutReview(t *testing.T) {
+	_, err := NewDigestComposer(nil, nil, nil).Compose(context.Background(), uuid.New(), domain.DigestWeekly, time.Now())
+	assert.Error(t, err)
+
+	_, err = NewDigestComposer(nil, nil, nil).Compose(context.Background(), uuid.New(), domain.DigestOff, time.Now())
+	assert.ErrorIs(t, err, domain.ErrUnknownDigestFrequency)
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/felixgeelhaar/orbita/internal/notifications/infrastructure"
+	"github.com/google/uuid"
+)
+
+// Ensure Dispatcher implements domain.Notifier.
+var _ domain.Notifier = (*Dispatcher)(nil)
+
+// DefaultRateLimit is the default number of notifications per user per window.
+const DefaultRateLimit = 20
+
+// DefaultRateWindow is the default rate limit window.
+const DefaultRateWindow = time.Hour
+
+// ChannelSettings provides each user's notification channel and target.
+type ChannelSettings interface {
+	GetNotificationChannel(ctx context.Context, userID uuid.UUID) (channel, target string, err error)
+}
+
+// QuietHours reports whether a time falls inside a quiet period.
+type QuietHours interface {
+	Contains(t time.Time) bool
+}
+
+// DispatcherConfig configures the notification dispatcher.
+type DispatcherConfig struct {
+	// QuietHours holds notifications back during a quiet period. Nil disables it.
+	QuietHours QuietHours
+	// Location is the time zone quiet hours are evaluated in.
+	Location *time.Location
+	// RateLimit is the maximum number of notifications per user per
+	// RateWindow. Zero or less disables rate limiting.
+	RateLimit  int
+	RateWindow time.Duration
+}
+
+// DefaultDispatcherConfig returns the default configuration.
+func DefaultDispatcherConfig() DispatcherConfig {
+	return DispatcherConfig{
+		Location:   time.Local,
+		RateLimit:  DefaultRateLimit,
+		RateWindow: DefaultRateWindow,
+	}
+}
+
+// Dispatcher delivers notifications on the channel each user selected in
+// their settings. Users without a channel, or whose channel is not
+// configured on this instance, get a no-op channel. Quiet hours and rate
+// limits apply to every channel alike.
+type Dispatcher struct {
+	settings  ChannelSettings
+	notifiers map[domain.Channel]domain.Notifier
+	config    DispatcherConfig
+	logger    *slog.Logger
+	now       func() time.Time
+
+	mu   sync.Mutex
+	sent map[uuid.UUID][]time.Time
+}
+
+// NewDispatcher creates a notification dispatcher. Channels are added with
+// WithNotifier.
+func NewDispatcher(settings ChannelSettings, config DispatcherConfig, logger *slog.Logger) *Dispatcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if config.Location == nil {
+		config.Location = time.Local
+	}
+	if config.RateWindow <= 0 {
+		config.RateWindow = DefaultRateWindow
+	}
+	return &Dispatcher{
+		settings:  settings,
+		notifiers: make(map[domain.Channel]domain.Notifier),
+		config:    config,
+		logger:    logger,
+		now:       time.Now,
+		sent:      make(map[uuid.UUID][]time.Time),
+	}
+}
+
+// WithNotifier makes a delivery channel available to users.
+func (d *Dispatcher) WithNotifier(channel domain.Channel, notifier domain.Notifier) *Dispatcher {
+	d.notifiers[channel] = notifier
+	return d
+}
+
+// NotifierFor returns the notifier for the user's selected channel with the
+// notification target filled in. It falls back to a no-op notifier when the
+// user has no channel, the channel lacks a target, or the channel is not
+// available.
+func (d *Dispatcher) NotifierFor(ctx context.Context, userID uuid.UUID) (domain.Notifier, string, error) {
+	if d.settings == nil {
+		return infrastructure.NoopNotifier{}, "", nil
+	}
+
+	name, target, err := d.settings.GetNotificationChannel(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	channel, err := domain.ParseChannel(name)
+	if err != nil {
+		d.logger.Warn("ignoring unknown notification channel", "user_id", userID, "channel", name)
+		return infrastructure.NoopNotifier{}, "", nil
+	}
+	if channel.NeedsTarget() && target == "" {
+		return infrastructure.NoopNotifier{}, "", nil
+	}
+
+	notifier, ok := d.notifiers[channel]
+	if !ok {
+		if channel != domain.ChannelNone {
+			d.logger.Debug("notification channel not configured", "user_id", userID, "channel", channel)
+		}
+		return infrastructure.NoopNotifier{}, "", nil
+	}
+	return notifier, target, nil
+}
+
+// Send delivers the notification on the user's channel. It returns
+// domain.ErrQuietHours or domain.ErrRateLimited when the notification is
+// held back.
+func (d *Dispatcher) Send(ctx context.Context, n domain.Notification) error {
+	now := d.now()
+	if d.config.QuietHours != nil && d.config.QuietHours.Contains(now.In(d.config.Location)) {
+		return domain.ErrQuietHours
+	}
+
+	notifier, target, err := d.NotifierFor(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !d.reserve(n.UserID, now) {
+		return domain.ErrRateLimited
+	}
+
+	n.Target = target
+	if err := notifier.Send(ctx, n); err != nil {
+		d.release(n.UserID, now)
+		return err
+	}
+	return nil
+}
+
+// reserve records a delivery for the user, unless that would exceed the
+// rate limit.
+func (d *Dispatcher) reserve(userID uuid.UUID, now time.Time) bool {
+	if d.config.RateLimit <= 0 {
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cutoff := now.Add(-d.config.RateWindow)
+	recent := d.sent[userID][:0]
+	for _, at := range d.sent[userID] {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+	if len(recent) >= d.config.RateLimit {
+		d.sent[userID] = recent
+		return false
+	}
+	d.sent[userID] = append(recent, now)
+	return true
+}
+
+// release gives back a delivery that failed, so it does not count toward
+// the rate limit.
+func (d *Dispatcher) release(userID uuid.UUID, at time.Time) {
+	if d.config.RateLimit <= 0 {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sent := d.sent[userID]
+	for i := len(sent) - 1; i >= 0; i-- {
+		if sent[i].Equal(at) {
+			d.sent[userID] = append(sent[:i], sent[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/felixgeelhaar/orbita/internal/notifications/infrastructure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubChannelSettings struct {
+	channels map[uuid.UUID][2]string
+	err      error
+}
+
+func (s stubChannelSettings) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	if s.err != nil {
+		return "", "", s.err
+	}
+	channel := s.channels[userID]
+	return channel[0], channel[1], nil
+}
+
+type recordingNotifier struct {
+	sent []domain.Notification
+	err  error
+}
+
+func (r *recordingNotifier) Send(ctx context.Context, n domain.Notification) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.sent = append(r.sent, n)
+	return nil
+}
+
+// fixedQuietHours is quiet between start and end hours, in UTC.
+type fixedQuietHours struct{ start, end int }
+
+func (q fixedQuietHours) Contains(t time.Time) bool {
+	return t.Hour() >= q.start && t.Hour() < q.end
+}
+
+func TestDispatcher_NotifierFor(t *testing.T) {
+	emailUser, webhookUser, desktopUser := uuid.New(), uuid.New(), uuid.New()
+	noTargetUser, unknownUser, unsetUser := uuid.New(), uuid.New(), uuid.New()
+	settings := stubChannelSettings{channels: map[uuid.UUID][2]string{
+		emailUser:    {"email", "me@example.com"},
+		webhookUser:  {"webhook", "https://example.com/hook"},
+		desktopUser:  {"desktop", ""},
+		noTargetUser: {"email", ""},
+		unknownUser:  {"pager", "123"},
+	}}
+
+	email, webhook := &recordingNotifier{}, &recordingNotifier{}
+	dispatcher := NewDispatcher(settings, DefaultDispatcherConfig(), nil).
+		WithNotifier(domain.ChannelEmail, email).
+		WithNotifier(domain.ChannelWebhook, webhook)
+
+	tests := []struct {
+		name     string
+		userID   uuid.UUID
+		notifier domain.Notifier
+		target   string
+	}{
+		{"email", emailUser, email, "me@example.com"},
+		{"webhook", webhookUser, webhook, "https://example.com/hook"},
+		{"channel not configured", desktopUser, infrastructure.NoopNotifier{}, ""},
+		{"missing target", noTargetUser, infrastructure.NoopNotifier{}, ""},
+		{"unknown channel", unknownUser, infrastructure.NoopNotifier{}, ""},
+		{"no channel selected", unsetUser, infrastructure.NoopNotifier{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier, target, err := dispatcher.NotifierFor(context.Background(), tt.userID)
+			require.NoError(t, err)
+			assert.True(t, tt.notifier == notifier, "unexpected notifier %T", notifier)
+			assert.Equal(t, tt.target, target)
+		})
+	}
+
+	t.Run("settings error", func(t *testing.T) {
+		failing := NewDispatcher(stubChannelSettings{err: errors.New("db down")}, DefaultDispatcherConfig(), nil)
+		_, _, err := failing.NotifierFor(context.Background(), emailUser)
+		assert.Error(t, err)
+	})
+}
+
+func TestDispatcher_Send(t *testing.T) {
+	userID := uuid.New()
+	settings := stubChannelSettings{channels: map[uuid.UUID][2]string{
+		userID: {"webhook", "https://example.com/hook"},
+	}}
+	noon := time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	newDispatcher := func(config DispatcherConfig) (*Dispatcher, *recordingNotifier) {
+		webhook := &recordingNotifier{}
+		config.Location = time.UTC
+		dispatcher := NewDispatcher(settings, config, nil).WithNotifier(domain.ChannelWebhook, webhook)
+		dispatcher.now = func() time.Time { return noon }
+		return dispatcher, webhook
+	}
+
+	t.Run("delivers with the user's target", func(t *testing.T) {
+		dispatcher, webhook := newDispatcher(DefaultDispatcherConfig())
+
+		err := dispatcher.Send(context.Background(), domain.NewNotification(userID, "Standup", "in 5 minutes", "high"))
+		require.NoError(t, err)
+		require.Len(t, webhook.sent, 1)
+		assert.Equal(t, "https://example.com/hook", webhook.sent[0].Target)
+		assert.Equal(t, "Standup", webhook.sent[0].Title)
+	})
+
+	t.Run("drops notifications for unconfigured users", func(t *testing.T) {
+		dispatcher, webhook := newDispatcher(DefaultDispatcherConfig())
+
+		err := dispatcher.Send(context.Background(), domain.NewNotification(uuid.New(), "Standup", "", ""))
+		require.NoError(t, err)
+		assert.Empty(t, webhook.sent)
+	})
+
+	t.Run("holds back notifications during quiet hours", func(t *testing.T) {
+		config := DefaultDispatcherConfig()
+		config.QuietHours = fixedQuietHours{start: 11, end: 13}
+		dispatcher, webhook := newDispatcher(config)
+
+		err := dispatcher.Send(context.Background(), domain.NewNotification(userID, "Standup", "", ""))
+		assert.ErrorIs(t, err, domain.ErrQuietHours)
+		assert.Empty(t, webhook.sent)
+	})
+
+	t.Run("rate limits per user", func(t *testing.T) {
+		config := DefaultDispatcherConfig()
+		config.RateLimit = 2
+		config.RateWindow = time.Hour
+		dispatcher, webhook := newDispatcher(config)
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, dispatcher.Send(context.Background(), domain.NewNotification(userID, "Ping", "", "")))
+		}
+		err := dispatcher.Send(context.Background(), domain.NewNotification(userID, "Ping", "", ""))
+		assert.ErrorIs(t, err, domain.ErrRateLimited)
+		assert.Len(t, webhook.sent, 2)
+
+		// The window slides.
+		dispatcher.now = func() time.Time { return noon.Add(time.Hour + time.Minute) }
+		require.NoError(t, dispatcher.Send(context.Background(), domain.NewNotification(userID, "Ping", "", "")))
+		assert.Len(t, webhook.sent, 3)
+	})
+
+	t.Run("failed deliveries do not count toward the limit", func(t *testing.T) {
+		config := DefaultDispatcherConfig()
+		config.RateLimit = 1
+		dispatcher, webhook := newDispatcher(config)
+
+		webhook.err = errors.New("unreachable")
+		assert.Error(t, dispatcher.Send(context.Background(), domain.NewNotification(userID, "Ping", "", "")))
+
+		webhook.err = nil
+		assert.NoError(t, dispatcher.Send(context.Background(), domain.NewNotification(userID, "Ping", "", "")))
+	})
+}
@@ -0,0 +1,212 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/google/uuid"
+)
+
+// DefaultDigestInterval is the default interval between digest checks.
+const DefaultDigestInterval = 5 * time.Minute
+
+// DefaultDigestMaxDelay is how long after its send time a digest is still
+// sent by default.
+const DefaultDigestMaxDelay = 3 * time.Hour
+
+// DigestStore provides users' digest preferences.
+type DigestStore interface {
+	ListDigests(ctx context.Context) ([]domain.Digest, error)
+	MarkDigestSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error
+}
+
+// DigestComposer builds a digest notification for a user.
+type DigestComposer interface {
+	Compose(ctx context.Context, userID uuid.UUID, frequency domain.DigestFrequency, at time.Time) (domain.Notification, error)
+}
+
+// DigestSenderConfig configures the digest sender.
+type DigestSenderConfig struct {
+	Interval time.Duration
+	// MaxDelay is how long after its send time a digest is still sent, for
+	// example after quiet hours or a restart. Later digests are skipped.
+	MaxDelay time.Duration
+	// Location is the time zone of users who have not set one.
+	Location *time.Location
+	// WeekStart is the day weekly digests are sent on.
+	WeekStart time.Weekday
+}
+
+// DefaultDigestSenderConfig returns the default configuration.
+func DefaultDigestSenderConfig() DigestSenderConfig {
+	return DigestSenderConfig{
+		Interval:  DefaultDigestInterval,
+		MaxDelay:  DefaultDigestMaxDelay,
+		Location:  time.Local,
+		WeekStart: sharedDomain.DefaultWeekStart,
+	}
+}
+
+// DigestSender periodically sends each user their daily agenda or weekly
+// review at the time they chose, in their own time zone.
+type DigestSender struct {
+	store    DigestStore
+	composer DigestComposer
+	notifier domain.Notifier
+	config   DigestSenderConfig
+	logger   *slog.Logger
+	running  atomic.Bool
+	stopCh   chan struct{}
+}
+
+// NewDigestSender creates a new digest sender.
+func NewDigestSender(store DigestStore, composer DigestComposer, notifier domain.Notifier, config DigestSenderConfig, logger *slog.Logger) *DigestSender {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultDigestInterval
+	}
+	if config.MaxDelay <= 0 {
+		config.MaxDelay = DefaultDigestMaxDelay
+	}
+	if config.Location == nil {
+		config.Location = time.Local
+	}
+	return &DigestSender{
+		store:    store,
+		composer: composer,
+		notifier: notifier,
+		config:   config,
+		logger:   logger,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Run starts the sender and blocks until context is cancelled or Stop() is called.
+func (s *DigestSender) Run(ctx context.Context) error {
+	s.running.Store(true)
+	s.logger.Info("digest sender started",
+		"interval", s.config.Interval,
+		"max_delay", s.config.MaxDelay,
+	)
+
+	s.runCycle(ctx)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.running.Store(false)
+			s.logger.Info("digest sender stopped (context cancelled)")
+			return ctx.Err()
+		case <-s.stopCh:
+			s.running.Store(false)
+			s.logger.Info("digest sender stopped (stop signal)")
+			return nil
+		case <-ticker.C:
+			s.runCycle(ctx)
+		}
+	}
+}
+
+// Stop signals the sender to stop gracefully.
+func (s *DigestSender) Stop() {
+	if s.running.Load() {
+		close(s.stopCh)
+	}
+}
+
+// IsRunning returns true if the sender is currently running.
+func (s *DigestSender) IsRunning() bool {
+	return s.running.Load()
+}
+
+func (s *DigestSender) runCycle(ctx context.Context) {
+	sent, err := s.SendDue(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("failed to send digests", "error", err)
+		return
+	}
+	if sent > 0 {
+		s.logger.Info("digests sent", "count", sent)
+	}
+}
+
+// SendDue sends every digest whose send time has passed since it was last
+// delivered and returns the number sent. Digests that fail or are held back
+// are logged and tried again on the next cycle.
+func (s *DigestSender) SendDue(ctx context.Context, now time.Time) (int, error) {
+	digests, err := s.store.ListDigests(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, digest := range digests {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		loc, err := digest.Location(s.config.Location)
+		if err != nil {
+			s.logger.Warn("skipping digest with unknown time zone",
+				"user_id", digest.UserID,
+				"timezone", digest.Timezone,
+			)
+			continue
+		}
+		at, due := digest.DueAt(now, loc, s.config.WeekStart, s.config.MaxDelay)
+		if !due {
+			continue
+		}
+
+		if s.send(ctx, digest, at, now) {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// send composes and delivers one digest and reports whether it went out.
+func (s *DigestSender) send(ctx context.Context, digest domain.Digest, at, now time.Time) bool {
+	notification, err := s.composer.Compose(ctx, digest.UserID, digest.Frequency, at)
+	if err != nil {
+		s.logger.Error("failed to compose digest",
+			"user_id", digest.UserID,
+			"frequency", digest.Frequency,
+			"error", err,
+		)
+		return false
+	}
+
+	err = s.notifier.Send(ctx, notification)
+	if domain.IsHeldBack(err) {
+		s.logger.Debug("digest held back",
+			"user_id", digest.UserID,
+			"reason", err,
+		)
+		return false
+	}
+	if err != nil {
+		s.logger.Error("failed to send digest",
+			"user_id", digest.UserID,
+			"error", err,
+		)
+		return false
+	}
+
+	if err := s.store.MarkDigestSent(ctx, digest.UserID, now); err != nil {
+		s.logger.Error("failed to record digest delivery",
+			"user_id", digest.UserID,
+			"error", err,
+		)
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubDigestStore struct {
+	digests []domain.Digest
+	err     error
+}
+
+func (s *stubDigestStore) ListDigests(ctx context.Context) ([]domain.Digest, error) {
+	return s.digests, s.err
+}
+
+func (s *stubDigestStore) MarkDigestSent(ctx context.Context, userID uuid.UUID, sentAt time.Time) error {
+	for i := range s.digests {
+		if s.digests[i].UserID == userID {
+			s.digests[i].LastSentAt = &sentAt
+		}
+	}
+	return nil
+}
+
+// stubComposer records the time each digest was composed for.
+type stubComposer struct {
+	at  map[uuid.UUID]time.Time
+	err error
+}
+
+func (c *stubComposer) Compose(ctx context.Context, userID uuid.UUID, frequency domain.DigestFrequency, at time.Time) (domain.Notification, error) {
+	if c.err != nil {
+		return domain.Notification{}, c.err
+	}
+	c.at[userID] = at
+	return domain.NewNotification(userID, string(frequency), "", "low"), nil
+}
+
+type recordingNotifier struct {
+	sent []domain.Notification
+	err  error
+}
+
+func (n *recordingNotifier) Send(ctx context.Context, notification domain.Notification) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func newTestDigestSender(store *stubDigestStore, notifier *recordingNotifier) (*DigestSender, *stubComposer) {
+	composer := &stubComposer{at: map[uuid.UUID]time.Time{}}
+	config := DefaultDigestSenderConfig()
+	config.Location = time.UTC
+	return NewDigestSender(store, composer, notifier, config, nil), composer
+}
+
+func TestDigestSender_SendDue_AcrossTimezones(t *testing.T) {
+	newYork, tokyo := uuid.New(), uuid.New()
+	store := &stubDigestStore{digests: []domain.Digest{
+		{UserID: newYork, Frequency: domain.DigestDaily, Hour: 7, Timezone: "America/New_York"},
+		{UserID: tokyo, Frequency: domain.DigestDaily, Hour: 7, Timezone: "Asia/Tokyo"},
+	}}
+	notifier := &recordingNotifier{}
+	sender, composer := newTestDigestSender(store, notifier)
+	ctx := context.Background()
+
+	// 22:30 UTC on 11 March is 07:30 on 12 March in Tokyo and 18:30 in New York.
+	sent, err := sender.SendDue(ctx, time.Date(2024, time.March, 11, 22, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, tokyo, notifier.sent[0].UserID)
+	assert.Equal(t, "2024-03-12 07:00 JST", composer.at[tokyo].Format("2006-01-02 15:04 MST"))
+
+	// 11:15 UTC on 12 March is 07:15 in New York; Tokyo already had its digest.
+	sent, err = sender.SendDue(ctx, time.Date(2024, time.March, 12, 11, 15, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, notifier.sent, 2)
+	assert.Equal(t, newYork, notifier.sent[1].UserID)
+	assert.Equal(t, "2024-03-12 07:00 EDT", composer.at[newYork].Format("2006-01-02 15:04 MST"))
+
+	// Nothing is sent twice.
+	sent, err = sender.SendDue(ctx, time.Date(2024, time.March, 12, 11, 20, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+}
+
+func TestDigestSender_SendDue_Weekly(t *testing.T) {
+	userID := uuid.New()
+	store := &stubDigestStore{digests: []domain.Digest{
+		{UserID: userID, Frequency: domain.DigestWeekly, Hour: 8},
+	}}
+	notifier := &recordingNotifier{}
+	sender, _ := newTestDigestSender(store, notifier)
+
+	// Tuesday: not the first day of the week.
+	sent, err := sender.SendDue(context.Background(), time.Date(2024, time.March, 12, 8, 5, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	sent, err = sender.SendDue(context.Background(), time.Date(2024, time.March, 18, 8, 5, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+}
+
+func TestDigestSender_SendDue_HeldBackIsRetried(t *testing.T) {
+	userID := uuid.New()
+	store := &stubDigestStore{digests: []domain.Digest{
+		{UserID: userID, Frequency: domain.DigestDaily, Hour: 7},
+	}}
+	notifier := &recordingNotifier{err: domain.ErrQuietHours}
+	sender, _ := newTestDigestSender(store, notifier)
+	ctx := context.Background()
+
+	sent, err := sender.SendDue(ctx, time.Date(2024, time.March, 12, 7, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Nil(t, store.digests[0].LastSentAt)
+
+	notifier.err = nil
+	sent, err = sender.SendDue(ctx, time.Date(2024, time.March, 12, 8, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.NotNil(t, store.digests[0].LastSentAt)
+
+	// Past the maximum delay the day's digest is skipped.
+	store.digests[0].LastSentAt = nil
+	sent, err = sender.SendDue(ctx, time.Date(2024, time.March, 12, 10, 1, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+}
+
+func TestDigestSender_SendDue_Failures(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2024, time.March, 12, 7, 0, 0, 0, time.UTC)
+
+	store := &stubDigestStore{digests: []domain.Digest{
+		{UserID: uuid.New(), Frequency: domain.DigestDaily, Hour: 7, Timezone: "Nowhere/Special"},
+		{UserID: userID, Frequency: domain.DigestDaily, Hour: 7},
+	}}
+	notifier := &recordingNotifier{}
+	sender, composer := newTestDigestSender(store, notifier)
+
+	composer.err = errors.New("db down")
+	sent, err := sender.SendDue(context.Background(), now)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Nil(t, store.digests[1].LastSentAt)
+
+	composer.err = nil
+	sent, err = sender.SendDue(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent, "an unknown time zone only skips that user")
+
+	store.err = errors.New("db down")
+	_, err = sender.SendDue(context.Background(), now)
+	assert.Error(t, err)
+}
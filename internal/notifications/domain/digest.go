@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DigestFrequency is how often a user receives a digest.
+type DigestFrequency string
+
+const (
+	// DigestOff sends no digest.
+	DigestOff DigestFrequency = ""
+	// DigestDaily sends today's agenda every day.
+	DigestDaily DigestFrequency = "daily"
+	// DigestWeekly sends a review of the past week on the first day of each week.
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+// ErrUnknownDigestFrequency is returned when a digest frequency is not recognized.
+var ErrUnknownDigestFrequency = errors.New("unknown digest frequency")
+
+// ErrInvalidDigestTime is returned when a digest time is not in HH:MM form.
+var ErrInvalidDigestTime = errors.New("digest time must be HH:MM")
+
+// ParseDigestFrequency parses a digest frequency. "off" and the empty string
+// both turn the digest off.
+func ParseDigestFrequency(value string) (DigestFrequency, error) {
+	switch DigestFrequency(strings.ToLower(strings.TrimSpace(value))) {
+	case DigestOff, "off":
+		return DigestOff, nil
+	case DigestDaily:
+		return DigestDaily, nil
+	case DigestWeekly:
+		return DigestWeekly, nil
+	default:
+		return DigestOff, fmt.Errorf("%w: %s", ErrUnknownDigestFrequency, value)
+	}
+}
+
+// String returns the frequency name, or "off" when no digest is sent.
+func (f DigestFrequency) String() string {
+	if f == DigestOff {
+		return "off"
+	}
+	return string(f)
+}
+
+// Digest is a user's digest preference.
+type Digest struct {
+	UserID    uuid.UUID
+	Frequency DigestFrequency
+	// Hour and Minute are the local time of day the digest is sent at.
+	Hour   int
+	Minute int
+	// Timezone is the IANA time zone the send time is in. Empty means the
+	// server's time zone.
+	Timezone string
+	// LastSentAt is when the digest was last delivered, if ever.
+	LastSentAt *time.Time
+}
+
+// ParseDigestTime parses a time of day in HH:MM form.
+func ParseDigestTime(value string) (hour, minute int, err error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidDigestTime, value)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// Time returns the send time in HH:MM form.
+func (d Digest) Time() string {
+	return fmt.Sprintf("%02d:%02d", d.Hour, d.Minute)
+}
+
+// Enabled reports whether a digest is sent at all.
+func (d Digest) Enabled() bool {
+	return d.Frequency != DigestOff
+}
+
+// Location returns the time zone the digest is scheduled in, or fallback
+// when none is set.
+func (d Digest) Location(fallback *time.Location) (*time.Location, error) {
+	if d.Timezone == "" {
+		return fallback, nil
+	}
+	return time.LoadLocation(d.Timezone)
+}
+
+// ScheduledAt returns the most recent time at or before now the digest was
+// due in loc. Daily digests are due every day at the send time; weekly
+// digests only on weekStart.
+func (d Digest) ScheduledAt(now time.Time, loc *time.Location, weekStart time.Weekday) time.Time {
+	local := now.In(loc)
+	for days := 0; ; days++ {
+		day := local.AddDate(0, 0, -days)
+		at := time.Date(day.Year(), day.Month(), day.Day(), d.Hour, d.Minute, 0, 0, loc)
+		if at.After(local) {
+			continue
+		}
+		if d.Frequency == DigestWeekly && at.Weekday() != weekStart {
+			continue
+		}
+		return at
+	}
+}
+
+// DueAt reports whether the digest should be sent at now and the time it was
+// scheduled for. A digest is due once per scheduled time, and only until
+// maxDelay after it, so a digest missed while the server was down is not
+// sent hours late.
+func (d Digest) DueAt(now time.Time, loc *time.Location, weekStart time.Weekday, maxDelay time.Duration) (time.Time, bool) {
+	if !d.Enabled() {
+		return time.Time{}, false
+	}
+	scheduled := d.ScheduledAt(now, loc, weekStart)
+	if now.Sub(scheduled) > maxDelay {
+		return scheduled, false
+	}
+	if d.LastSentAt != nil && !d.LastSentAt.Before(scheduled) {
+		return scheduled, false
+	}
+	return scheduled, true
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestParseDigestFrequency(t *testing.T) {
+	for value, want := range map[string]DigestFrequency{
+		"":       DigestOff,
+		"off":    DigestOff,
+		"Daily":  DigestDaily,
+		"weekly": DigestWeekly,
+	} {
+		got, err := ParseDigestFrequency(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	_, err := ParseDigestFrequency("hourly")
+	assert.ErrorIs(t, err, ErrUnknownDigestFrequency)
+}
+
+func TestParseDigestTime(t *testing.T) {
+	hour, minute, err := ParseDigestTime("07:45")
+	require.NoError(t, err)
+	assert.Equal(t, 7, hour)
+	assert.Equal(t, 45, minute)
+
+	for _, value := range []string{"", "7pm", "25:00"} {
+		_, _, err := ParseDigestTime(value)
+		assert.ErrorIs(t, err, ErrInvalidDigestTime, value)
+	}
+}
+
+func TestDigest_DueAt_AcrossTimezones(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	daily := Digest{Frequency: DigestDaily, Hour: 7}
+
+	// 12:00 UTC is 08:00 in New York and 21:00 in Tokyo.
+	now := time.Date(2024, time.March, 12, 12, 0, 0, 0, time.UTC)
+
+	at, due := daily.DueAt(now, newYork, time.Monday, 3*time.Hour)
+	assert.True(t, due, "an hour after 07:00 in New York")
+	assert.Equal(t, time.Date(2024, time.March, 12, 7, 0, 0, 0, newYork), at)
+	assert.Equal(t, newYork, at.Location())
+
+	at, due = daily.DueAt(now, tokyo, time.Monday, 3*time.Hour)
+	assert.False(t, due, "14 hours after 07:00 in Tokyo")
+	assert.Equal(t, time.Date(2024, time.March, 12, 7, 0, 0, 0, tokyo), at)
+
+	// 23:00 UTC the day before is 08:00 in Tokyo.
+	at, due = daily.DueAt(time.Date(2024, time.March, 11, 23, 0, 0, 0, time.UTC), tokyo, time.Monday, 3*time.Hour)
+	assert.True(t, due)
+	assert.Equal(t, time.Date(2024, time.March, 12, 7, 0, 0, 0, tokyo), at)
+}
+
+func TestDigest_DueAt_OncePerSendTime(t *testing.T) {
+	daily := Digest{Frequency: DigestDaily, Hour: 7, Minute: 30}
+	now := time.Date(2024, time.March, 12, 7, 40, 0, 0, time.UTC)
+
+	_, due := daily.DueAt(time.Date(2024, time.March, 12, 7, 29, 0, 0, time.UTC), time.UTC, time.Monday, time.Hour)
+	assert.False(t, due, "before the send time, yesterday's digest is past its delay")
+
+	_, due = daily.DueAt(now, time.UTC, time.Monday, time.Hour)
+	assert.True(t, due)
+
+	sent := time.Date(2024, time.March, 12, 7, 31, 0, 0, time.UTC)
+	daily.LastSentAt = &sent
+	_, due = daily.DueAt(now, time.UTC, time.Monday, time.Hour)
+	assert.False(t, due, "already sent today")
+
+	_, due = daily.DueAt(now.AddDate(0, 0, 1), time.UTC, time.Monday, time.Hour)
+	assert.True(t, due, "due again the next day")
+
+	_, due = Digest{Hour: 7}.DueAt(now, time.UTC, time.Monday, time.Hour)
+	assert.False(t, due, "off")
+}
+
+func TestDigest_ScheduledAt_Weekly(t *testing.T) {
+	weekly := Digest{Frequency: DigestWeekly, Hour: 9}
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	// Wednesday 13 March 2024; the last Monday 09:00 was the 11th.
+	now := time.Date(2024, time.March, 13, 12, 0, 0, 0, berlin)
+	assert.Equal(t, time.Date(2024, time.March, 11, 9, 0, 0, 0, berlin), weekly.ScheduledAt(now, berlin, time.Monday))
+	assert.Equal(t, time.Date(2024, time.March, 10, 9, 0, 0, 0, berlin), weekly.ScheduledAt(now, berlin, time.Sunday))
+
+	// Early on Monday it is still last week's send time.
+	early := time.Date(2024, time.March, 11, 8, 0, 0, 0, berlin)
+	assert.Equal(t, time.Date(2024, time.March, 4, 9, 0, 0, 0, berlin), weekly.ScheduledAt(early, berlin, time.Monday))
+}
+
+func TestDigest_ScheduledAt_DaylightSavingChange(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	daily := Digest{Frequency: DigestDaily, Hour: 7}
+
+	// Clocks went forward on 10 March 2024; 07:00 moves from 12:00 to 11:00 UTC.
+	before := daily.ScheduledAt(time.Date(2024, time.March, 9, 13, 0, 0, 0, time.UTC), newYork, time.Monday)
+	after := daily.ScheduledAt(time.Date(2024, time.March, 11, 13, 0, 0, 0, time.UTC), newYork, time.Monday)
+	assert.Equal(t, time.Date(2024, time.March, 9, 12, 0, 0, 0, time.UTC), before.UTC())
+	assert.Equal(t, time.Date(2024, time.March, 11, 11, 0, 0, 0, time.UTC), after.UTC())
+}
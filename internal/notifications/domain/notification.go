@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Channel identifies how notifications reach a user.
+type Channel string
+
+const (
+	// ChannelNone drops notifications. It is used when no channel is configured.
+	ChannelNone Channel = ""
+	// ChannelDesktop shows notifications on the local desktop.
+	ChannelDesktop Channel = "desktop"
+	// ChannelEmail sends notifications by email over SMTP.
+	ChannelEmail Channel = "email"
+	// ChannelWebhook posts notifications as JSON to a URL.
+	ChannelWebhook Channel = "webhook"
+)
+
+// ErrUnknownChannel is returned when a channel name is not recognized.
+var ErrUnknownChannel = errors.New("unknown notification channel")
+
+// ErrMissingTarget is returned when a channel that needs a target, such as an
+// email address or webhook URL, is configured without one.
+var ErrMissingTarget = errors.New("notification channel requires a target")
+
+var (
+	// ErrQuietHours is returned when a notification is held back by quiet hours.
+	ErrQuietHours = errors.New("notification held back by quiet hours")
+	// ErrRateLimited is returned when a user has received too many notifications.
+	ErrRateLimited = errors.New("notification rate limit exceeded")
+)
+
+// IsHeldBack reports whether err means the notification was held back by
+// quiet hours or a rate limit rather than failing, and may be sent later.
+func IsHeldBack(err error) bool {
+	return errors.Is(err, ErrQuietHours) || errors.Is(err, ErrRateLimited)
+}
+
+// ParseChannel parses a channel name. "none" and the empty string both mean
+// no channel.
+func ParseChannel(value string) (Channel, error) {
+	switch Channel(strings.ToLower(strings.TrimSpace(value))) {
+	case ChannelNone, "none":
+		return ChannelNone, nil
+	case ChannelDesktop:
+		return ChannelDesktop, nil
+	case ChannelEmail:
+		return ChannelEmail, nil
+	case ChannelWebhook:
+		return ChannelWebhook, nil
+	default:
+		return ChannelNone, fmt.Errorf("%w: %s", ErrUnknownChannel, value)
+	}
+}
+
+// NeedsTarget reports whether the channel delivers to a per-user target.
+func (c Channel) NeedsTarget() bool {
+	return c == ChannelEmail || c == ChannelWebhook
+}
+
+// String returns the channel name, or "none" for no channel.
+func (c Channel) String() string {
+	if c == ChannelNone {
+		return "none"
+	}
+	return string(c)
+}
+
+// Notification is a message for a single user.
+type Notification struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	Title    string
+	Body     string
+	Priority string
+	// Target is the channel-specific destination, such as an email address
+	// or webhook URL. It is filled in from the user's settings.
+	Target    string
+	CreatedAt time.Time
+}
+
+// NewNotification creates a notification for a user.
+func NewNotification(userID uuid.UUID, title, body, priority string) Notification {
+	return Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Title:     title,
+		Body:      body,
+		Priority:  priority,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Notifier delivers notifications.
+type Notifier interface {
+	Send(ctx context.Context, n Notification) error
+}
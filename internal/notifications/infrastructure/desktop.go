@@ -0,0 +1,56 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+)
+
+// Ensure DesktopNotifier implements domain.Notifier.
+var _ domain.Notifier = (*DesktopNotifier)(nil)
+
+// ErrDesktopUnsupported is returned when desktop notifications are not
+// available on the current platform.
+var ErrDesktopUnsupported = errors.New("desktop notifications are not supported on this platform")
+
+// CommandRunner runs an external command.
+type CommandRunner func(ctx context.Context, name string, args ...string) error
+
+// DesktopNotifier shows notifications on the local machine using
+// notify-send on Linux and osascript on macOS.
+type DesktopNotifier struct {
+	goos string
+	run  CommandRunner
+}
+
+// NewDesktopNotifier creates a desktop notifier for the current platform.
+func NewDesktopNotifier() *DesktopNotifier {
+	return &DesktopNotifier{goos: runtime.GOOS, run: runCommand}
+}
+
+// Send shows the notification on the desktop.
+func (n *DesktopNotifier) Send(ctx context.Context, notification domain.Notification) error {
+	switch n.goos {
+	case "linux":
+		args := []string{notification.Title, notification.Body}
+		if notification.Priority == "urgent" || notification.Priority == "high" {
+			args = append([]string{"--urgency=critical"}, args...)
+		}
+		return n.run(ctx, "notify-send", args...)
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s",
+			strconv.Quote(notification.Body), strconv.Quote(notification.Title))
+		return n.run(ctx, "osascript", "-e", script)
+	default:
+		return ErrDesktopUnsupported
+	}
+}
+
+func runCommand(ctx context.Context, name string, args ...string) error {
+	return exec.CommandContext(ctx, name, args...).Run()
+}
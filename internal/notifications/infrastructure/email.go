@@ -0,0 +1,84 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+)
+
+// Ensure EmailNotifier implements domain.Notifier.
+var _ domain.Notifier = (*EmailNotifier)(nil)
+
+// SMTPConfig holds the SMTP server settings for email notifications.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// IsConfigured reports whether enough settings are present to send email.
+func (c SMTPConfig) IsConfigured() bool {
+	return c.Host != "" && c.From != ""
+}
+
+// sendMailFunc matches smtp.SendMail.
+type sendMailFunc func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailNotifier sends notifications by email to the notification's target.
+type EmailNotifier struct {
+	config   SMTPConfig
+	sendMail sendMailFunc
+}
+
+// NewEmailNotifier creates an email notifier using the given SMTP server.
+func NewEmailNotifier(config SMTPConfig) *EmailNotifier {
+	if config.Port <= 0 {
+		config.Port = 587
+	}
+	return &EmailNotifier{config: config, sendMail: smtp.SendMail}
+}
+
+// Send emails the notification to its target address.
+func (n *EmailNotifier) Send(ctx context.Context, notification domain.Notification) error {
+	if notification.Target == "" {
+		return domain.ErrMissingTarget
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+	}
+
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+	msg := buildEmail(n.config.From, notification)
+	if err := n.sendMail(addr, auth, n.config.From, []string{notification.Target}, msg); err != nil {
+		return fmt.Errorf("failed to send notification email: %w", err)
+	}
+	return nil
+}
+
+// buildEmail renders a plain-text message for the notification.
+func buildEmail(from string, notification domain.Notification) []byte {
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(notification.Title)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", notification.Target)
+	fmt.Fprintf(&b, "Subject: %s\r\n", subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(notification.Body)
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
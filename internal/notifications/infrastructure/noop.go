@@ -0,0 +1,19 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+)
+
+// Ensure NoopNotifier implements domain.Notifier.
+var _ domain.Notifier = NoopNotifier{}
+
+// NoopNotifier drops every notification. It stands in for users without a
+// configured channel.
+type NoopNotifier struct{}
+
+// Send discards the notification.
+func (NoopNotifier) Send(ctx context.Context, n domain.Notification) error {
+	return nil
+}
@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	var received webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received.Title == "fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.Client())
+	n := domain.NewNotification(uuid.New(), "Standup", "in 5 minutes", "high")
+	n.Target = server.URL
+
+	require.NoError(t, notifier.Send(context.Background(), n))
+	assert.Equal(t, n.ID.String(), received.ID)
+	assert.Equal(t, "Standup", received.Title)
+	assert.Equal(t, "in 5 minutes", received.Body)
+
+	n.Title = "fail"
+	assert.Error(t, notifier.Send(context.Background(), n))
+
+	n.Target = ""
+	assert.ErrorIs(t, notifier.Send(context.Background(), n), domain.ErrMissingTarget)
+}
+
+func TestEmailNotifier_Send(t *testing.T) {
+	notifier := NewEmailNotifier(SMTPConfig{Host: "smtp.example.com", From: "orbita@example.com"})
+
+	var addr, from string
+	var to []string
+	var msg []byte
+	notifier.sendMail = func(a string, auth smtp.Auth, f string, t []string, m []byte) error {
+		addr, from, to, msg = a, f, t, m
+		return nil
+	}
+
+	n := domain.NewNotification(uuid.New(), "Standup", "in 5 minutes", "")
+	n.Target = "me@example.com"
+	require.NoError(t, notifier.Send(context.Background(), n))
+
+	assert.Equal(t, "smtp.example.com:587", addr)
+	assert.Equal(t, "orbita@example.com", from)
+	assert.Equal(t, []string{"me@example.com"}, to)
+	assert.Contains(t, string(msg), "Subject: Standup\r\n")
+	assert.Contains(t, string(msg), "in 5 minutes")
+
+	n.Target = ""
+	assert.ErrorIs(t, notifier.Send(context.Background(), n), domain.ErrMissingTarget)
+}
+
+func TestDesktopNotifier_Send(t *testing.T) {
+	var name string
+	var args []string
+	run := func(ctx context.Context, n string, a ...string) error {
+		name, args = n, a
+		return nil
+	}
+	n := domain.NewNotification(uuid.New(), "Standup", "in 5 minutes", "high")
+
+	linux := &DesktopNotifier{goos: "linux", run: run}
+	require.NoError(t, linux.Send(context.Background(), n))
+	assert.Equal(t, "notify-send", name)
+	assert.Equal(t, []string{"--urgency=critical", "Standup", "in 5 minutes"}, args)
+
+	darwin := &DesktopNotifier{goos: "darwin", run: run}
+	require.NoError(t, darwin.Send(context.Background(), n))
+	assert.Equal(t, "osascript", name)
+	assert.Equal(t, []string{"-e", `display notification "in 5 minutes" with title "Standup"`}, args)
+
+	other := &DesktopNotifier{goos: "plan9", run: run}
+	assert.ErrorIs(t, other.Send(context.Background(), n), ErrDesktopUnsupported)
+}
@@ -0,0 +1,78 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/notifications/domain"
+)
+
+// Ensure WebhookNotifier implements domain.Notifier.
+var _ domain.Notifier = (*WebhookNotifier)(nil)
+
+// DefaultWebhookTimeout bounds a single webhook delivery.
+const DefaultWebhookTimeout = 10 * time.Second
+
+// webhookPayload is the JSON body posted to notification webhooks.
+type webhookPayload struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body,omitempty"`
+	Priority  string    `json:"priority,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookNotifier posts notifications as JSON to the notification's target URL.
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a webhook notifier. A nil client uses one with
+// DefaultWebhookTimeout.
+func NewWebhookNotifier(client *http.Client) *WebhookNotifier {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultWebhookTimeout}
+	}
+	return &WebhookNotifier{client: client}
+}
+
+// Send posts the notification to its target URL. Any non-2xx response is an error.
+func (n *WebhookNotifier) Send(ctx context.Context, notification domain.Notification) error {
+	if notification.Target == "" {
+		return domain.ErrMissingTarget
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		ID:        notification.ID.String(),
+		UserID:    notification.UserID.String(),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Priority:  notification.Priority,
+		CreatedAt: notification.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notification.Target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid notification webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call notification webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned %s", resp.Status)
+	}
+	return nil
+}
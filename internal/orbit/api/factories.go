@@ -28,6 +28,9 @@ type APIFactories struct {
 	ListInboxHandler   *inboxQueries.ListInboxItemsHandler
 	GetInboxHandler    *inboxQueries.GetInboxItemHandler
 
+	// WeekStartsOn is the first day of the week returned by ScheduleAPI.GetWeek.
+	WeekStartsOn time.Weekday
+
 	// Redis client for storage (optional, falls back to in-memory)
 	RedisClient *redis.Client
 }
@@ -58,7 +61,7 @@ func (f *APIFactories) ScheduleAPIFactory() func(userID uuid.UUID, caps sdk.Capa
 		if f.ScheduleHandler == nil {
 			return nil
 		}
-		return NewScheduleAPI(f.ScheduleHandler, userID, caps)
+		return NewScheduleAPI(f.ScheduleHandler, userID, caps).WithWeekStart(f.WeekStartsOn)
 	}
 }
 
@@ -44,7 +44,7 @@ func (a *HabitAPIImpl) List(ctx context.Context) ([]sdk.HabitDTO, error) {
 		return nil, err
 	}
 
-	habits, err := a.listHandler.Handle(ctx, habitQueries.ListHabitsQuery{
+	habits, err := a.listHandler.Handle(asUser(ctx, a.userID), habitQueries.ListHabitsQuery{
 		UserID:          a.userID,
 		IncludeArchived: true,
 	})
@@ -66,7 +66,7 @@ func (a *HabitAPIImpl) Get(ctx context.Context, id string) (*sdk.HabitDTO, error
 		return nil, sdk.ErrResourceNotFound
 	}
 
-	habit, err := a.getHandler.Handle(ctx, habitQueries.GetHabitQuery{
+	habit, err := a.getHandler.Handle(asUser(ctx, a.userID), habitQueries.GetHabitQuery{
 		HabitID: habitID,
 		UserID:  a.userID,
 	})
@@ -87,7 +87,7 @@ func (a *HabitAPIImpl) GetActive(ctx context.Context) ([]sdk.HabitDTO, error) {
 		return nil, err
 	}
 
-	habits, err := a.listHandler.Handle(ctx, habitQueries.ListHabitsQuery{
+	habits, err := a.listHandler.Handle(asUser(ctx, a.userID), habitQueries.ListHabitsQuery{
 		UserID:          a.userID,
 		IncludeArchived: false,
 	})
@@ -104,7 +104,7 @@ func (a *HabitAPIImpl) GetDueToday(ctx context.Context) ([]sdk.HabitDTO, error)
 		return nil, err
 	}
 
-	habits, err := a.listHandler.Handle(ctx, habitQueries.ListHabitsQuery{
+	habits, err := a.listHandler.Handle(asUser(ctx, a.userID), habitQueries.ListHabitsQuery{
 		UserID:       a.userID,
 		OnlyDueToday: true,
 	})
@@ -45,7 +45,7 @@ func (a *InboxAPIImpl) List(ctx context.Context) ([]sdk.InboxItemDTO, error) {
 		return nil, err
 	}
 
-	items, err := a.listHandler.Handle(ctx, inboxQueries.ListInboxItemsQuery{
+	items, err := a.listHandler.Handle(asUser(ctx, a.userID), inboxQueries.ListInboxItemsQuery{
 		UserID: a.userID,
 	})
 	if err != nil {
@@ -66,7 +66,7 @@ func (a *InboxAPIImpl) Get(ctx context.Context, id string) (*sdk.InboxItemDTO, e
 		return nil, sdk.ErrResourceNotFound
 	}
 
-	item, err := a.getHandler.Handle(ctx, inboxQueries.GetInboxItemQuery{
+	item, err := a.getHandler.Handle(asUser(ctx, a.userID), inboxQueries.GetInboxItemQuery{
 		ItemID: itemID,
 		UserID: a.userID,
 	})
@@ -87,7 +87,7 @@ func (a *InboxAPIImpl) GetPending(ctx context.Context) ([]sdk.InboxItemDTO, erro
 		return nil, err
 	}
 
-	items, err := a.listHandler.Handle(ctx, inboxQueries.ListInboxItemsQuery{
+	items, err := a.listHandler.Handle(asUser(ctx, a.userID), inboxQueries.ListInboxItemsQuery{
 		UserID: a.userID,
 	})
 	if err != nil {
@@ -111,7 +111,7 @@ func (a *InboxAPIImpl) GetByClassification(ctx context.Context, classification s
 		return nil, err
 	}
 
-	items, err := a.listHandler.Handle(ctx, inboxQueries.ListInboxItemsQuery{
+	items, err := a.listHandler.Handle(asUser(ctx, a.userID), inboxQueries.ListInboxItemsQuery{
 		UserID: a.userID,
 	})
 	if err != nil {
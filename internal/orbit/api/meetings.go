@@ -45,7 +45,7 @@ func (a *MeetingAPIImpl) List(ctx context.Context) ([]sdk.MeetingDTO, error) {
 		return nil, err
 	}
 
-	meetings, err := a.listHandler.Handle(ctx, meetingQueries.ListMeetingsQuery{
+	meetings, err := a.listHandler.Handle(asUser(ctx, a.userID), meetingQueries.ListMeetingsQuery{
 		UserID:          a.userID,
 		IncludeArchived: true,
 	})
@@ -67,7 +67,7 @@ func (a *MeetingAPIImpl) Get(ctx context.Context, id string) (*sdk.MeetingDTO, e
 		return nil, sdk.ErrResourceNotFound
 	}
 
-	meeting, err := a.getHandler.Handle(ctx, meetingQueries.GetMeetingQuery{
+	meeting, err := a.getHandler.Handle(asUser(ctx, a.userID), meetingQueries.GetMeetingQuery{
 		MeetingID: meetingID,
 		UserID:    a.userID,
 	})
@@ -88,7 +88,7 @@ func (a *MeetingAPIImpl) GetActive(ctx context.Context) ([]sdk.MeetingDTO, error
 		return nil, err
 	}
 
-	meetings, err := a.listHandler.Handle(ctx, meetingQueries.ListMeetingsQuery{
+	meetings, err := a.listHandler.Handle(asUser(ctx, a.userID), meetingQueries.ListMeetingsQuery{
 		UserID:          a.userID,
 		IncludeArchived: false,
 	})
@@ -105,7 +105,7 @@ func (a *MeetingAPIImpl) GetUpcoming(ctx context.Context, days int) ([]sdk.Meeti
 		return nil, err
 	}
 
-	meetings, err := a.listHandler.Handle(ctx, meetingQueries.ListMeetingsQuery{
+	meetings, err := a.listHandler.Handle(asUser(ctx, a.userID), meetingQueries.ListMeetingsQuery{
 		UserID:          a.userID,
 		IncludeArchived: false,
 	})
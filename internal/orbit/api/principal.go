@@ -0,0 +1,18 @@
+package api
+
+import (
+	"context"
+
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+// asUser authenticates ctx as the user an orbit API is bound to, unless it
+// already carries a principal, so handlers reach the guarded repositories
+// even when an orbit runs from an event or background context.
+func asUser(ctx context.Context, userID uuid.UUID) context.Context {
+	if _, ok := sharedApplication.PrincipalFromContext(ctx); ok {
+		return ctx
+	}
+	return sharedApplication.WithPrincipal(ctx, userID)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/felixgeelhaar/orbita/internal/orbit/sdk"
 	schedQueries "github.com/felixgeelhaar/orbita/internal/scheduling/application/queries"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
 
@@ -14,6 +15,7 @@ type ScheduleAPIImpl struct {
 	handler      *schedQueries.GetScheduleHandler
 	userID       uuid.UUID
 	capabilities sdk.CapabilitySet
+	weekStartsOn time.Weekday
 }
 
 // NewScheduleAPI creates a new ScheduleAPI implementation.
@@ -26,9 +28,16 @@ func NewScheduleAPI(
 		handler:      handler,
 		userID:       userID,
 		capabilities: caps,
+		weekStartsOn: sharedDomain.DefaultWeekStart,
 	}
 }
 
+// WithWeekStart sets the first day of the week returned by GetWeek.
+func (a *ScheduleAPIImpl) WithWeekStart(day time.Weekday) *ScheduleAPIImpl {
+	a.weekStartsOn = day
+	return a
+}
+
 func (a *ScheduleAPIImpl) checkCapability() error {
 	if !a.capabilities.Has(sdk.CapReadSchedule) {
 		return sdk.ErrCapabilityNotGranted
@@ -42,7 +51,7 @@ func (a *ScheduleAPIImpl) GetForDate(ctx context.Context, date time.Time) (*sdk.
 		return nil, err
 	}
 
-	schedule, err := a.handler.Handle(ctx, schedQueries.GetScheduleQuery{
+	schedule, err := a.handler.Handle(asUser(ctx, a.userID), schedQueries.GetScheduleQuery{
 		UserID: a.userID,
 		Date:   date,
 	})
@@ -64,13 +73,12 @@ func (a *ScheduleAPIImpl) GetWeek(ctx context.Context) ([]sdk.ScheduleDTO, error
 		return nil, err
 	}
 
-	now := time.Now()
-	weekStart := now.AddDate(0, 0, -int(now.Weekday()))
+	weekStart := sharedDomain.StartOfWeek(time.Now(), a.weekStartsOn)
 	schedules := make([]sdk.ScheduleDTO, 7)
 
 	for i := 0; i < 7; i++ {
 		date := weekStart.AddDate(0, 0, i)
-		schedule, err := a.handler.Handle(ctx, schedQueries.GetScheduleQuery{
+		schedule, err := a.handler.Handle(asUser(ctx, a.userID), schedQueries.GetScheduleQuery{
 			UserID: a.userID,
 			Date:   date,
 		})
@@ -53,7 +53,7 @@ func (a *TaskAPIImpl) List(ctx context.Context, filters sdk.TaskFilters) ([]sdk.
 		Limit:     filters.Limit,
 	}
 
-	tasks, err := a.listHandler.Handle(ctx, query)
+	tasks, err := a.listHandler.Handle(asUser(ctx, a.userID), query)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +72,7 @@ func (a *TaskAPIImpl) Get(ctx context.Context, id string) (*sdk.TaskDTO, error)
 		return nil, sdk.ErrResourceNotFound
 	}
 
-	task, err := a.getHandler.Handle(ctx, queries.GetTaskQuery{
+	task, err := a.getHandler.Handle(asUser(ctx, a.userID), queries.GetTaskQuery{
 		TaskID: taskID,
 		UserID: a.userID,
 	})
@@ -98,7 +98,7 @@ func (a *TaskAPIImpl) GetOverdue(ctx context.Context) ([]sdk.TaskDTO, error) {
 		return nil, err
 	}
 
-	tasks, err := a.listHandler.Handle(ctx, queries.ListTasksQuery{
+	tasks, err := a.listHandler.Handle(asUser(ctx, a.userID), queries.ListTasksQuery{
 		UserID:  a.userID,
 		Overdue: true,
 	})
@@ -116,7 +116,7 @@ func (a *TaskAPIImpl) GetDueSoon(ctx context.Context, days int) ([]sdk.TaskDTO,
 	}
 
 	dueBefore := time.Now().AddDate(0, 0, days)
-	tasks, err := a.listHandler.Handle(ctx, queries.ListTasksQuery{
+	tasks, err := a.listHandler.Handle(asUser(ctx, a.userID), queries.ListTasksQuery{
 		UserID:    a.userID,
 		DueBefore: &dueBefore,
 		Status:    "pending",
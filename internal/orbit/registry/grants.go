@@ -0,0 +1,200 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/orbit/sdk"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/security"
+	"github.com/google/uuid"
+)
+
+// Grant records the capabilities a user has allowed an orbit to use.
+type Grant struct {
+	OrbitID      string           `json:"orbit_id"`
+	UserID       uuid.UUID        `json:"user_id"`
+	Capabilities []sdk.Capability `json:"capabilities"`
+	GrantedAt    time.Time        `json:"granted_at"`
+}
+
+// GrantStore persists capability grants.
+type GrantStore interface {
+	// GetGrant returns the user's grant for an orbit, or nil if there is none.
+	GetGrant(ctx context.Context, userID uuid.UUID, orbitID string) (*Grant, error)
+
+	// SaveGrant creates or replaces a grant.
+	SaveGrant(ctx context.Context, grant Grant) error
+
+	// DeleteGrant removes the user's grant for an orbit, if any.
+	DeleteGrant(ctx context.Context, userID uuid.UUID, orbitID string) error
+}
+
+// MemoryGrantStore is an in-memory GrantStore.
+type MemoryGrantStore struct {
+	mu     sync.RWMutex
+	grants map[string]Grant
+}
+
+// NewMemoryGrantStore creates an empty in-memory grant store.
+func NewMemoryGrantStore() *MemoryGrantStore {
+	return &MemoryGrantStore{grants: make(map[string]Grant)}
+}
+
+func grantKey(userID uuid.UUID, orbitID string) string {
+	return userID.String() + "/" + orbitID
+}
+
+// GetGrant returns the user's grant for an orbit, or nil if there is none.
+func (s *MemoryGrantStore) GetGrant(_ context.Context, userID uuid.UUID, orbitID string) (*Grant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	grant, ok := s.grants[grantKey(userID, orbitID)]
+	if !ok {
+		return nil, nil
+	}
+	return &grant, nil
+}
+
+// SaveGrant creates or replaces a grant.
+func (s *MemoryGrantStore) SaveGrant(_ context.Context, grant Grant) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.grants[grantKey(grant.UserID, grant.OrbitID)] = grant
+	return nil
+}
+
+// DeleteGrant removes the user's grant for an orbit, if any.
+func (s *MemoryGrantStore) DeleteGrant(_ context.Context, userID uuid.UUID, orbitID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.grants, grantKey(userID, orbitID))
+	return nil
+}
+
+// FileGrantStore is a GrantStore backed by a JSON file, so grants survive
+// restarts alongside the orbits discovered on disk.
+type FileGrantStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileGrantStore creates a grant store that reads and writes path.
+// The file is created on the first grant.
+func NewFileGrantStore(path string) *FileGrantStore {
+	return &FileGrantStore{path: path}
+}
+
+// DefaultGrantsPath returns the default location of the grants file.
+func DefaultGrantsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".orbita", "orbit-grants.json")
+	}
+	return filepath.Join(home, ".orbita", "orbit-grants.json")
+}
+
+// GetGrant returns the user's grant for an orbit, or nil if there is none.
+func (s *FileGrantStore) GetGrant(_ context.Context, userID uuid.UUID, orbitID string) (*Grant, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grants, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range grants {
+		if grant.UserID == userID && grant.OrbitID == orbitID {
+			return &grant, nil
+		}
+	}
+	return nil, nil
+}
+
+// SaveGrant creates or replaces a grant.
+func (s *FileGrantStore) SaveGrant(_ context.Context, grant Grant) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grants, err := s.load()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range grants {
+		if grants[i].UserID == grant.UserID && grants[i].OrbitID == grant.OrbitID {
+			grants[i] = grant
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		grants = append(grants, grant)
+	}
+	return s.save(grants)
+}
+
+// DeleteGrant removes the user's grant for an orbit, if any.
+func (s *FileGrantStore) DeleteGrant(_ context.Context, userID uuid.UUID, orbitID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grants, err := s.load()
+	if err != nil {
+		return err
+	}
+	kept := grants[:0]
+	for _, grant := range grants {
+		if grant.UserID != userID || grant.OrbitID != orbitID {
+			kept = append(kept, grant)
+		}
+	}
+	if len(kept) == len(grants) {
+		return nil
+	}
+	return s.save(kept)
+}
+
+func (s *FileGrantStore) load() ([]Grant, error) {
+	data, err := security.SafeReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read orbit grants: %w", err)
+	}
+
+	var grants []Grant
+	if err := json.Unmarshal(data, &grants); err != nil {
+		return nil, fmt.Errorf("failed to parse orbit grants: %w", err)
+	}
+	return grants, nil
+}
+
+// save writes the grants through a temporary file so a crash never leaves
+// a truncated grants file behind.
+func (s *FileGrantStore) save(grants []Grant) error {
+	data, err := json.MarshalIndent(grants, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode orbit grants: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create grants directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write orbit grants: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write orbit grants: %w", err)
+	}
+	return nil
+}
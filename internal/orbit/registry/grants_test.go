@@ -0,0 +1,134 @@
+package registry
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/orbit/sdk"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGrantTestRegistry(t *testing.T, store GrantStore) *Registry {
+	t.Helper()
+	reg := NewRegistry(nil, nil).WithGrantStore(store)
+	require.NoError(t, reg.RegisterManifest(&Manifest{
+		ID:           "acme.pomodoro",
+		Name:         "Pomodoro",
+		Version:      "1.0.0",
+		Type:         "orbit",
+		Capabilities: []string{"read:tasks", "read:storage", "write:storage"},
+	}, "/orbits/acme.pomodoro"))
+	require.NoError(t, reg.RegisterBuiltin(newMockOrbit("orbita.builtin", "Builtin", "1.0.0", sdk.CapReadHabits)))
+	return reg
+}
+
+func TestRegistry_GrantCapabilities(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	reg := newGrantTestRegistry(t, NewMemoryGrantStore())
+
+	required, err := reg.RequiresGrant("acme.pomodoro")
+	require.NoError(t, err)
+	assert.True(t, required)
+
+	granted, err := reg.GrantedCapabilities(ctx, "acme.pomodoro", userID)
+	require.NoError(t, err)
+	assert.Empty(t, granted, "nothing is granted up front")
+
+	require.NoError(t, reg.GrantCapabilities(ctx, "acme.pomodoro", userID, sdk.CapReadTasks))
+	granted, err = reg.GrantedCapabilities(ctx, "acme.pomodoro", userID)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.Capability{sdk.CapReadTasks}, granted)
+
+	pending, err := reg.PendingCapabilities(ctx, "acme.pomodoro", userID)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.Capability{sdk.CapReadStorage, sdk.CapWriteStorage}, pending)
+
+	// Grants are per user.
+	granted, err = reg.GrantedCapabilities(ctx, "acme.pomodoro", uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, granted)
+
+	// Only declared capabilities can be granted.
+	err = reg.GrantCapabilities(ctx, "acme.pomodoro", userID, sdk.CapPublishEvents)
+	assert.ErrorIs(t, err, sdk.ErrCapabilityNotDeclared)
+
+	// No capabilities grants everything declared.
+	require.NoError(t, reg.GrantCapabilities(ctx, "acme.pomodoro", userID))
+	pending, err = reg.PendingCapabilities(ctx, "acme.pomodoro", userID)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestRegistry_RevokeCapabilities(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	reg := newGrantTestRegistry(t, NewMemoryGrantStore())
+	require.NoError(t, reg.GrantCapabilities(ctx, "acme.pomodoro", userID))
+
+	require.NoError(t, reg.RevokeCapabilities(ctx, "acme.pomodoro", userID, sdk.CapWriteStorage))
+	granted, err := reg.GrantedCapabilities(ctx, "acme.pomodoro", userID)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.Capability{sdk.CapReadTasks, sdk.CapReadStorage}, granted)
+
+	grant, err := reg.GetGrant(ctx, "acme.pomodoro", userID)
+	require.NoError(t, err)
+	require.NotNil(t, grant, "revoking single capabilities keeps the grant")
+
+	require.NoError(t, reg.RevokeCapabilities(ctx, "acme.pomodoro", userID))
+	grant, err = reg.GetGrant(ctx, "acme.pomodoro", userID)
+	require.NoError(t, err)
+	assert.Nil(t, grant)
+	granted, err = reg.GrantedCapabilities(ctx, "acme.pomodoro", userID)
+	require.NoError(t, err)
+	assert.Empty(t, granted)
+
+	assert.ErrorIs(t, reg.RevokeCapabilities(ctx, "missing", userID), sdk.ErrOrbitNotFound)
+}
+
+func TestRegistry_GrantedCapabilities_NoGrantRequired(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// Built-in orbits are trusted with everything they declare.
+	reg := newGrantTestRegistry(t, NewMemoryGrantStore())
+	required, err := reg.RequiresGrant("orbita.builtin")
+	require.NoError(t, err)
+	assert.False(t, required)
+	granted, err := reg.GrantedCapabilities(ctx, "orbita.builtin", userID)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.Capability{sdk.CapReadHabits}, granted)
+
+	// Without a grant store, declared capabilities apply as before.
+	reg = NewRegistry(nil, nil)
+	require.NoError(t, reg.RegisterManifest(&Manifest{ID: "acme.pomodoro", Capabilities: []string{"read:tasks"}}, ""))
+	required, err = reg.RequiresGrant("acme.pomodoro")
+	require.NoError(t, err)
+	assert.False(t, required)
+	granted, err = reg.GrantedCapabilities(ctx, "acme.pomodoro", userID)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.Capability{sdk.CapReadTasks}, granted)
+}
+
+func TestFileGrantStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "orbita", "grants.json")
+	userID := uuid.New()
+
+	reg := newGrantTestRegistry(t, NewFileGrantStore(path))
+	require.NoError(t, reg.GrantCapabilities(ctx, "acme.pomodoro", userID, sdk.CapReadTasks))
+
+	// A fresh store over the same file sees the saved grant.
+	reg = newGrantTestRegistry(t, NewFileGrantStore(path))
+	granted, err := reg.GrantedCapabilities(ctx, "acme.pomodoro", userID)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.Capability{sdk.CapReadTasks}, granted)
+
+	require.NoError(t, reg.RevokeCapabilities(ctx, "acme.pomodoro", userID))
+	grant, err := NewFileGrantStore(path).GetGrant(ctx, userID, "acme.pomodoro")
+	require.NoError(t, err)
+	assert.Nil(t, grant)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/orbit/sdk"
 	"github.com/google/uuid"
@@ -24,6 +25,7 @@ type Registry struct {
 	orbits      map[string]*OrbitEntry
 	logger      *slog.Logger
 	entitlement EntitlementChecker
+	grants      GrantStore
 }
 
 // OrbitEntry holds a registered orbit and its metadata.
@@ -79,6 +81,13 @@ func NewRegistry(logger *slog.Logger, entitlement EntitlementChecker) *Registry
 	}
 }
 
+// WithGrantStore enables capability grants. Orbits that are not built in
+// may then only use the capabilities a user has granted them.
+func (r *Registry) WithGrantStore(store GrantStore) *Registry {
+	r.grants = store
+	return r
+}
+
 // RegisterBuiltin registers a built-in orbit.
 func (r *Registry) RegisterBuiltin(orbit sdk.Orbit) error {
 	r.mu.Lock()
@@ -433,3 +442,205 @@ func (r *Registry) ValidateCapabilities(id string) error {
 
 	return nil
 }
+
+// RequiresGrant reports whether the user must grant an orbit's declared
+// capabilities before it runs. Built-in orbits are trusted, and nothing
+// needs granting when no grant store is configured.
+func (r *Registry) RequiresGrant(id string) (bool, error) {
+	entry, declared, err := r.declaredCapabilities(id)
+	if err != nil {
+		return false, err
+	}
+	return r.grants != nil && !entry.Builtin && len(declared) > 0, nil
+}
+
+// GetGrant returns the user's stored grant for an orbit, or nil if the user
+// has not granted it anything yet.
+func (r *Registry) GetGrant(ctx context.Context, id string, userID uuid.UUID) (*Grant, error) {
+	if _, _, err := r.declaredCapabilities(id); err != nil {
+		return nil, err
+	}
+	if r.grants == nil {
+		return nil, nil
+	}
+	return r.grants.GetGrant(ctx, userID, id)
+}
+
+// GrantedCapabilities returns the capabilities an orbit may use for a user:
+// the declared capabilities the user has granted, or all declared
+// capabilities when the orbit does not require a grant.
+func (r *Registry) GrantedCapabilities(ctx context.Context, id string, userID uuid.UUID) ([]sdk.Capability, error) {
+	entry, declared, err := r.declaredCapabilities(id)
+	if err != nil {
+		return nil, err
+	}
+	if r.grants == nil || entry.Builtin {
+		return declared, nil
+	}
+
+	grant, err := r.grants.GetGrant(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load orbit grant: %w", err)
+	}
+	if grant == nil {
+		return nil, nil
+	}
+
+	// A grant never extends past the manifest, even if the manifest shrank.
+	granted := sdk.NewCapabilitySet(grant.Capabilities)
+	var caps []sdk.Capability
+	for _, c := range declared {
+		if granted.Has(c) {
+			caps = append(caps, c)
+		}
+	}
+	return caps, nil
+}
+
+// PendingCapabilities returns the declared capabilities a user has not
+// granted an orbit.
+func (r *Registry) PendingCapabilities(ctx context.Context, id string, userID uuid.UUID) ([]sdk.Capability, error) {
+	_, declared, err := r.declaredCapabilities(id)
+	if err != nil {
+		return nil, err
+	}
+	granted, err := r.GrantedCapabilities(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	grantedSet := sdk.NewCapabilitySet(granted)
+	var pending []sdk.Capability
+	for _, c := range declared {
+		if !grantedSet.Has(c) {
+			pending = append(pending, c)
+		}
+	}
+	return pending, nil
+}
+
+// GrantCapabilities grants an orbit capabilities for a user, adding to any
+// existing grant. With no capabilities given, every declared capability is
+// granted. Capabilities the manifest does not declare cannot be granted.
+func (r *Registry) GrantCapabilities(ctx context.Context, id string, userID uuid.UUID, caps ...sdk.Capability) error {
+	_, declared, err := r.declaredCapabilities(id)
+	if err != nil {
+		return err
+	}
+	if r.grants == nil {
+		return nil
+	}
+
+	declaredSet := sdk.NewCapabilitySet(declared)
+	if len(caps) == 0 {
+		caps = declared
+	}
+	for _, c := range caps {
+		if !declaredSet.Has(c) {
+			return fmt.Errorf("%w: %s", sdk.ErrCapabilityNotDeclared, c)
+		}
+	}
+
+	grant, err := r.grants.GetGrant(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("failed to load orbit grant: %w", err)
+	}
+	granted := make(sdk.CapabilitySet)
+	if grant != nil {
+		granted = sdk.NewCapabilitySet(grant.Capabilities)
+	}
+	for _, c := range caps {
+		granted.Add(c)
+	}
+
+	if err := r.grants.SaveGrant(ctx, Grant{
+		OrbitID:      id,
+		UserID:       userID,
+		Capabilities: orderedCapabilities(granted),
+		GrantedAt:    time.Now().UTC(),
+	}); err != nil {
+		return fmt.Errorf("failed to save orbit grant: %w", err)
+	}
+
+	r.logger.Info("granted orbit capabilities",
+		"orbit_id", id,
+		"user_id", userID,
+		"capabilities", caps,
+	)
+	return nil
+}
+
+// RevokeCapabilities revokes capabilities from a user's grant. With no
+// capabilities given, the whole grant is removed and the orbit must be
+// granted again before it runs.
+func (r *Registry) RevokeCapabilities(ctx context.Context, id string, userID uuid.UUID, caps ...sdk.Capability) error {
+	if _, _, err := r.declaredCapabilities(id); err != nil {
+		return err
+	}
+	if r.grants == nil {
+		return nil
+	}
+
+	if len(caps) == 0 {
+		if err := r.grants.DeleteGrant(ctx, userID, id); err != nil {
+			return fmt.Errorf("failed to delete orbit grant: %w", err)
+		}
+		r.logger.Info("revoked orbit grant", "orbit_id", id, "user_id", userID)
+		return nil
+	}
+
+	grant, err := r.grants.GetGrant(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("failed to load orbit grant: %w", err)
+	}
+	if grant == nil {
+		return nil
+	}
+	granted := sdk.NewCapabilitySet(grant.Capabilities)
+	for _, c := range caps {
+		granted.Remove(c)
+	}
+	grant.Capabilities = orderedCapabilities(granted)
+	if err := r.grants.SaveGrant(ctx, *grant); err != nil {
+		return fmt.Errorf("failed to save orbit grant: %w", err)
+	}
+
+	r.logger.Info("revoked orbit capabilities",
+		"orbit_id", id,
+		"user_id", userID,
+		"capabilities", caps,
+	)
+	return nil
+}
+
+// declaredCapabilities returns an orbit's entry and the capabilities its
+// manifest declares.
+func (r *Registry) declaredCapabilities(id string) (*OrbitEntry, []sdk.Capability, error) {
+	r.mu.RLock()
+	entry, exists := r.orbits[id]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, nil, sdk.ErrOrbitNotFound
+	}
+	if entry.Manifest == nil {
+		return entry, nil, nil
+	}
+	caps, err := entry.Manifest.GetCapabilities()
+	if err != nil {
+		return nil, nil, err
+	}
+	return entry, caps, nil
+}
+
+// orderedCapabilities returns the set's capabilities in the canonical order
+// of sdk.AllCapabilities, so stored grants are stable.
+func orderedCapabilities(set sdk.CapabilitySet) []sdk.Capability {
+	caps := make([]sdk.Capability, 0, len(set))
+	for _, c := range sdk.AllCapabilities() {
+		if set.Has(c) {
+			caps = append(caps, c)
+		}
+	}
+	return caps
+}
@@ -7,7 +7,11 @@ import (
 
 	"github.com/felixgeelhaar/orbita/internal/orbit/registry"
 	"github.com/felixgeelhaar/orbita/internal/orbit/sdk"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/telemetry"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Sandbox enforces capability restrictions for orbit execution.
@@ -91,10 +95,14 @@ func (s *Sandbox) CreateContext(
 		return nil, fmt.Errorf("failed to get orbit manifest: %w", err)
 	}
 
-	// Parse capabilities from manifest
-	capabilities, err := manifest.GetCapabilities()
+	if manifest == nil {
+		return nil, fmt.Errorf("failed to get orbit manifest: %w", sdk.ErrManifestNotFound)
+	}
+
+	// Only the declared capabilities the user has granted are available
+	capabilities, err := s.registry.GrantedCapabilities(ctx, orbitID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse capabilities: %w", err)
+		return nil, fmt.Errorf("failed to resolve granted capabilities: %w", err)
 	}
 	capSet := sdk.NewCapabilitySet(capabilities)
 
@@ -106,7 +114,7 @@ func (s *Sandbox) CreateContext(
 		Logger:       s.logger,
 	}
 
-	// Only provide APIs for declared capabilities
+	// Only provide APIs for granted capabilities
 	if capSet.Has(sdk.CapReadTasks) && s.taskAPIFactory != nil {
 		cfg.TaskAPI = s.taskAPIFactory(userID, capSet)
 	}
@@ -159,18 +167,41 @@ func (s *Sandbox) CheckCapability(orbitID string, cap sdk.Capability) (bool, err
 	return capSet.Has(cap), nil
 }
 
+// Span attributes set on orbit executions.
+const (
+	AttrOrbitID   = attribute.Key("orbita.orbit.id")
+	AttrOrbitTool = attribute.Key("orbita.orbit.tool")
+)
+
 // Executor orchestrates orbit execution with proper sandboxing.
 type Executor struct {
 	sandbox  *Sandbox
 	registry *registry.Registry
 	logger   *slog.Logger
+	tracing  *telemetry.Instruments
+	prompt   PermissionPrompt
 }
 
+// PermissionPrompt asks the user to grant an orbit the capabilities it
+// declares. It returns the capabilities the user approved; returning none
+// declines.
+type PermissionPrompt func(ctx context.Context, manifest *registry.Manifest, caps []sdk.Capability) ([]sdk.Capability, error)
+
 // ExecutorConfig holds configuration for the executor.
 type ExecutorConfig struct {
 	Sandbox  *Sandbox
 	Registry *registry.Registry
 	Logger   *slog.Logger
+
+	// Prompt asks for capability grants the first time an orbit that
+	// requires them runs. Without it, such orbits fail with
+	// sdk.ErrPermissionsRequired until granted from the CLI.
+	Prompt PermissionPrompt
+
+	// TracerProvider and MeterProvider receive orbit spans and metrics.
+	// Nil uses the global OpenTelemetry providers.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
 }
 
 // NewExecutor creates a new orbit executor.
@@ -183,6 +214,13 @@ func NewExecutor(cfg ExecutorConfig) *Executor {
 		sandbox:  cfg.Sandbox,
 		registry: cfg.Registry,
 		logger:   cfg.Logger,
+		prompt:   cfg.Prompt,
+		tracing: telemetry.NewInstruments(
+			"github.com/felixgeelhaar/orbita/internal/orbit/runtime",
+			"orbita.orbit",
+			cfg.TracerProvider,
+			cfg.MeterProvider,
+		),
 	}
 }
 
@@ -191,13 +229,20 @@ func (e *Executor) InitializeOrbit(
 	ctx context.Context,
 	orbitID string,
 	userID uuid.UUID,
-) error {
+) (err error) {
+	ctx, call := e.tracing.Start(ctx, "orbit.initialize", AttrOrbitID.String(orbitID))
+	defer func() { call.End(telemetry.OutcomeOf(err), err) }()
+
 	// Get orbit from registry
 	orbit, err := e.registry.Get(ctx, orbitID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to get orbit: %w", err)
 	}
 
+	if err := e.ensureGrant(ctx, orbitID, userID); err != nil {
+		return err
+	}
+
 	// Create sandboxed context
 	orbitCtx, err := e.sandbox.CreateContext(ctx, orbitID, userID)
 	if err != nil {
@@ -217,6 +262,59 @@ func (e *Executor) InitializeOrbit(
 	return nil
 }
 
+// ensureGrant makes sure the user has granted an orbit its capabilities
+// before its first run, prompting for them when a prompt is configured.
+func (e *Executor) ensureGrant(ctx context.Context, orbitID string, userID uuid.UUID) error {
+	required, err := e.registry.RequiresGrant(orbitID)
+	if err != nil || !required {
+		return err
+	}
+	grant, err := e.registry.GetGrant(ctx, orbitID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load orbit grant: %w", err)
+	}
+	if grant != nil {
+		return nil
+	}
+
+	if e.prompt == nil {
+		return sdk.NewOrbitError(orbitID, "initialize", sdk.ErrPermissionsRequired)
+	}
+	manifest, err := e.registry.GetManifest(orbitID)
+	if err != nil {
+		return fmt.Errorf("failed to get orbit manifest: %w", err)
+	}
+	pending, err := e.registry.PendingCapabilities(ctx, orbitID, userID)
+	if err != nil {
+		return err
+	}
+	approved, err := e.prompt(ctx, manifest, pending)
+	if err != nil {
+		return fmt.Errorf("failed to prompt for orbit permissions: %w", err)
+	}
+	if len(approved) == 0 {
+		return sdk.NewOrbitError(orbitID, "initialize", sdk.ErrPermissionsRequired)
+	}
+	if err := e.registry.GrantCapabilities(ctx, orbitID, userID, approved...); err != nil {
+		return fmt.Errorf("failed to grant orbit permissions: %w", err)
+	}
+	return nil
+}
+
+// InstrumentTool wraps an orbit tool handler so each call is traced and
+// counted.
+func (e *Executor) InstrumentTool(orbitID, tool string, handler sdk.ToolHandler) sdk.ToolHandler {
+	return func(ctx context.Context, input map[string]any) (any, error) {
+		ctx, call := e.tracing.Start(ctx, "orbit.tool",
+			AttrOrbitID.String(orbitID),
+			AttrOrbitTool.String(tool),
+		)
+		result, err := handler(ctx, input)
+		call.End(telemetry.OutcomeOf(err), err)
+		return result, err
+	}
+}
+
 // ShutdownOrbit shuts down an orbit.
 func (e *Executor) ShutdownOrbit(ctx context.Context, orbitID string) error {
 	// Registry handles shutdown
@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"testing"
@@ -9,9 +10,15 @@ import (
 
 	"github.com/felixgeelhaar/orbita/internal/orbit/registry"
 	"github.com/felixgeelhaar/orbita/internal/orbit/sdk"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/telemetry"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // mockOrbit implements sdk.Orbit for testing.
@@ -534,6 +541,217 @@ func TestExecutor_GetOrbit(t *testing.T) {
 	})
 }
 
+func TestExecutor_Telemetry(t *testing.T) {
+	newTracedExecutor := func(t *testing.T) (*Executor, *tracetest.SpanRecorder) {
+		t.Helper()
+		logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+		reg := registry.NewRegistry(logger, nil)
+		require.NoError(t, reg.RegisterBuiltin(&mockOrbit{id: "traced.orbit", name: "Traced Orbit", version: "1.0.0"}))
+
+		spans := tracetest.NewSpanRecorder()
+		executor := NewExecutor(ExecutorConfig{
+			Sandbox:        NewSandbox(SandboxConfig{Logger: logger, Registry: reg}),
+			Registry:       reg,
+			Logger:         logger,
+			TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		})
+		return executor, spans
+	}
+
+	attrsOf := func(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+		attrs := make(map[attribute.Key]string)
+		for _, kv := range span.Attributes() {
+			attrs[kv.Key] = kv.Value.Emit()
+		}
+		return attrs
+	}
+
+	t.Run("traces orbit initialization", func(t *testing.T) {
+		executor, spans := newTracedExecutor(t)
+
+		require.NoError(t, executor.InitializeOrbit(context.Background(), "traced.orbit", uuid.New()))
+		assert.Error(t, executor.InitializeOrbit(context.Background(), "nonexistent", uuid.New()))
+
+		ended := spans.Ended()
+		require.Len(t, ended, 2)
+		assert.Equal(t, "orbit.initialize", ended[0].Name())
+		assert.Equal(t, "traced.orbit", attrsOf(ended[0])[AttrOrbitID])
+		assert.Equal(t, telemetry.OutcomeSuccess, attrsOf(ended[0])[telemetry.AttrOutcome])
+		assert.Equal(t, "nonexistent", attrsOf(ended[1])[AttrOrbitID])
+		assert.Equal(t, telemetry.OutcomeError, attrsOf(ended[1])[telemetry.AttrOutcome])
+		assert.Equal(t, codes.Error, ended[1].Status().Code)
+	})
+
+	t.Run("traces tool calls", func(t *testing.T) {
+		executor, spans := newTracedExecutor(t)
+
+		var handlerSpanValid bool
+		ok := executor.InstrumentTool("traced.orbit", "summary", func(ctx context.Context, input map[string]any) (any, error) {
+			handlerSpanValid = trace.SpanContextFromContext(ctx).IsValid()
+			return "done", nil
+		})
+		failing := executor.InstrumentTool("traced.orbit", "broken", func(ctx context.Context, input map[string]any) (any, error) {
+			return nil, errors.New("tool failed")
+		})
+
+		result, err := ok(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "done", result)
+		assert.True(t, handlerSpanValid, "handler should run inside the tool span")
+
+		_, err = failing(context.Background(), nil)
+		assert.EqualError(t, err, "tool failed")
+
+		ended := spans.Ended()
+		require.Len(t, ended, 2)
+		for i, want := range []struct{ tool, outcome string }{
+			{"summary", telemetry.OutcomeSuccess},
+			{"broken", telemetry.OutcomeError},
+		} {
+			attrs := attrsOf(ended[i])
+			assert.Equal(t, "orbit.tool", ended[i].Name())
+			assert.Equal(t, "traced.orbit", attrs[AttrOrbitID])
+			assert.Equal(t, want.tool, attrs[AttrOrbitTool])
+			assert.Equal(t, want.outcome, attrs[telemetry.AttrOutcome])
+		}
+	})
+}
+
+func TestSandbox_GrantEnforcement(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	orbit := &mockOrbit{id: "acme.pomodoro", name: "Pomodoro", version: "1.0.0"}
+	reg := registry.NewRegistry(logger, nil).WithGrantStore(registry.NewMemoryGrantStore())
+	require.NoError(t, reg.RegisterFactory("acme.pomodoro", func() (sdk.Orbit, error) { return orbit, nil }, &registry.Manifest{
+		ID:           "acme.pomodoro",
+		Name:         "Pomodoro",
+		Version:      "1.0.0",
+		Type:         "orbit",
+		Capabilities: []string{"read:tasks", "read:habits"},
+	}))
+
+	sandbox := NewSandbox(SandboxConfig{
+		Logger:   logger,
+		Registry: reg,
+		TaskAPIFactory: func(_ uuid.UUID, _ sdk.CapabilitySet) sdk.TaskAPI {
+			return &mockTaskAPI{}
+		},
+		HabitAPIFactory: func(_ uuid.UUID, _ sdk.CapabilitySet) sdk.HabitAPI {
+			return &mockHabitAPI{}
+		},
+	})
+
+	t.Run("ungranted capabilities are not available", func(t *testing.T) {
+		orbitCtx, err := sandbox.CreateContext(ctx, "acme.pomodoro", userID)
+		require.NoError(t, err)
+		assert.False(t, orbitCtx.HasCapability(sdk.CapReadTasks))
+		_, err = orbitCtx.Tasks().List(ctx, sdk.TaskFilters{})
+		assert.ErrorIs(t, err, sdk.ErrCapabilityNotGranted)
+	})
+
+	t.Run("only granted capabilities are available", func(t *testing.T) {
+		require.NoError(t, reg.GrantCapabilities(ctx, "acme.pomodoro", userID, sdk.CapReadTasks))
+
+		orbitCtx, err := sandbox.CreateContext(ctx, "acme.pomodoro", userID)
+		require.NoError(t, err)
+		assert.True(t, orbitCtx.HasCapability(sdk.CapReadTasks))
+		assert.IsType(t, &mockTaskAPI{}, orbitCtx.Tasks())
+		assert.False(t, orbitCtx.HasCapability(sdk.CapReadHabits))
+		_, err = orbitCtx.Habits().List(ctx)
+		assert.ErrorIs(t, err, sdk.ErrCapabilityNotGranted)
+	})
+
+	t.Run("revoking a capability disables it", func(t *testing.T) {
+		require.NoError(t, reg.RevokeCapabilities(ctx, "acme.pomodoro", userID, sdk.CapReadTasks))
+
+		orbitCtx, err := sandbox.CreateContext(ctx, "acme.pomodoro", userID)
+		require.NoError(t, err)
+		assert.False(t, orbitCtx.HasCapability(sdk.CapReadTasks))
+		_, err = orbitCtx.Tasks().List(ctx, sdk.TaskFilters{})
+		assert.ErrorIs(t, err, sdk.ErrCapabilityNotGranted)
+	})
+}
+
+func TestExecutor_InitializeOrbit_RequiresGrant(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	newExecutor := func(t *testing.T, prompt PermissionPrompt) (*Executor, *registry.Registry, *mockOrbit) {
+		t.Helper()
+		orbit := &mockOrbit{id: "acme.pomodoro", name: "Pomodoro", version: "1.0.0"}
+		reg := registry.NewRegistry(logger, nil).WithGrantStore(registry.NewMemoryGrantStore())
+		require.NoError(t, reg.RegisterFactory("acme.pomodoro", func() (sdk.Orbit, error) { return orbit, nil }, &registry.Manifest{
+			ID:           "acme.pomodoro",
+			Name:         "Pomodoro",
+			Version:      "1.0.0",
+			Type:         "orbit",
+			Capabilities: []string{"read:tasks", "write:storage"},
+		}))
+		executor := NewExecutor(ExecutorConfig{
+			Sandbox:  NewSandbox(SandboxConfig{Logger: logger, Registry: reg}),
+			Registry: reg,
+			Logger:   logger,
+			Prompt:   prompt,
+		})
+		return executor, reg, orbit
+	}
+
+	t.Run("refuses to run without a grant", func(t *testing.T) {
+		executor, reg, orbit := newExecutor(t, nil)
+
+		err := executor.InitializeOrbit(ctx, "acme.pomodoro", userID)
+		assert.ErrorIs(t, err, sdk.ErrPermissionsRequired)
+		assert.False(t, orbit.initialized)
+
+		require.NoError(t, reg.GrantCapabilities(ctx, "acme.pomodoro", userID))
+		require.NoError(t, executor.InitializeOrbit(ctx, "acme.pomodoro", userID))
+		assert.True(t, orbit.initialized)
+
+		// Revoking the grant makes the orbit ask again.
+		require.NoError(t, reg.RevokeCapabilities(ctx, "acme.pomodoro", userID))
+		err = executor.InitializeOrbit(ctx, "acme.pomodoro", userID)
+		assert.ErrorIs(t, err, sdk.ErrPermissionsRequired)
+	})
+
+	t.Run("prompts on first run and persists the grant", func(t *testing.T) {
+		var prompted []sdk.Capability
+		prompts := 0
+		executor, reg, orbit := newExecutor(t, func(_ context.Context, manifest *registry.Manifest, caps []sdk.Capability) ([]sdk.Capability, error) {
+			prompts++
+			assert.Equal(t, "acme.pomodoro", manifest.ID)
+			prompted = caps
+			return []sdk.Capability{sdk.CapReadTasks}, nil
+		})
+
+		require.NoError(t, executor.InitializeOrbit(ctx, "acme.pomodoro", userID))
+		assert.True(t, orbit.initialized)
+		assert.Equal(t, []sdk.Capability{sdk.CapReadTasks, sdk.CapWriteStorage}, prompted)
+
+		granted, err := reg.GrantedCapabilities(ctx, "acme.pomodoro", userID)
+		require.NoError(t, err)
+		assert.Equal(t, []sdk.Capability{sdk.CapReadTasks}, granted)
+
+		require.NoError(t, executor.InitializeOrbit(ctx, "acme.pomodoro", userID))
+		assert.Equal(t, 1, prompts, "the saved grant is reused")
+	})
+
+	t.Run("declining the prompt keeps the orbit from running", func(t *testing.T) {
+		executor, reg, orbit := newExecutor(t, func(context.Context, *registry.Manifest, []sdk.Capability) ([]sdk.Capability, error) {
+			return nil, nil
+		})
+
+		err := executor.InitializeOrbit(ctx, "acme.pomodoro", userID)
+		assert.ErrorIs(t, err, sdk.ErrPermissionsRequired)
+		assert.False(t, orbit.initialized)
+		grant, err := reg.GetGrant(ctx, "acme.pomodoro", userID)
+		require.NoError(t, err)
+		assert.Nil(t, grant)
+	})
+}
+
 // mockMetrics implements sdk.MetricsCollector.
 type mockMetrics struct{}
 
@@ -10,9 +10,11 @@ var (
 	ErrMissingVersion = errors.New("orbit metadata: missing version")
 
 	// Capability errors
-	ErrInvalidCapability    = errors.New("invalid capability")
-	ErrCapabilityNotGranted = errors.New("capability not granted")
-	ErrCapabilityMismatch   = errors.New("declared capabilities do not match required capabilities")
+	ErrInvalidCapability     = errors.New("invalid capability")
+	ErrCapabilityNotGranted  = errors.New("capability not granted")
+	ErrCapabilityMismatch    = errors.New("declared capabilities do not match required capabilities")
+	ErrCapabilityNotDeclared = errors.New("capability not declared in orbit manifest")
+	ErrPermissionsRequired   = errors.New("orbit permissions have not been granted")
 
 	// Lifecycle errors
 	ErrOrbitNotInitialized = errors.New("orbit not initialized")
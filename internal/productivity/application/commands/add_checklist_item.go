@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// AddChecklistItemCommand contains the data needed to add a checklist item to a task.
+type AddChecklistItemCommand struct {
+	TaskID uuid.UUID
+	UserID uuid.UUID
+	Title  string
+}
+
+// AddChecklistItemResult contains the result of adding a checklist item.
+type AddChecklistItemResult struct {
+	ItemID uuid.UUID
+}
+
+// AddChecklistItemHandler handles the AddChecklistItemCommand.
+type AddChecklistItemHandler struct {
+	taskRepo   task.Repository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+}
+
+// NewAddChecklistItemHandler creates a new AddChecklistItemHandler.
+func NewAddChecklistItemHandler(taskRepo task.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *AddChecklistItemHandler {
+	return &AddChecklistItemHandler{
+		taskRepo:   taskRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// Handle executes the AddChecklistItemCommand.
+func (h *AddChecklistItemHandler) Handle(ctx context.Context, cmd AddChecklistItemCommand) (*AddChecklistItemResult, error) {
+	var result *AddChecklistItemResult
+
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		// Find the task
+		t, err := h.taskRepo.FindByID(txCtx, cmd.TaskID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return ErrTaskNotFound
+		}
+
+		// Verify ownership
+		if t.UserID() != cmd.UserID {
+			return ErrTaskNotOwned
+		}
+
+		item, err := t.AddChecklistItem(cmd.Title)
+		if err != nil {
+			return err
+		}
+
+		t.AddDomainEvent(task.NewTaskUpdated(t.ID(), []string{"checklist"}))
+
+		// Save the task
+		if err := h.taskRepo.Save(txCtx, t); err != nil {
+			return err
+		}
+
+		// Save domain events to outbox
+		events := t.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		if err := h.outboxRepo.SaveBatch(txCtx, msgs); err != nil {
+			return err
+		}
+
+		result = &AddChecklistItemResult{ItemID: item.ID}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyTaskError(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newCommitUnitOfWork() *mockUnitOfWork {
+	uow := new(mockUnitOfWork)
+	uow.On("Begin", mock.Anything).Return(context.Background(), nil)
+	uow.On("Commit", mock.Anything).Return(nil)
+	return uow
+}
+
+func TestAddChecklistItemHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("adds the item and records an update", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Release")
+		require.NoError(t, err)
+		existing.ClearDomainEvents()
+
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+		taskRepo.On("Save", mock.Anything, existing).Return(nil)
+		outboxRepo := new(mockOutboxRepo)
+		outboxRepo.On("SaveBatch", mock.Anything, mock.MatchedBy(func(msgs []*outbox.Message) bool {
+			return len(msgs) == 1 && msgs[0].RoutingKey == task.RoutingKeyUpdated
+		})).Return(nil)
+
+		result, err := NewAddChecklistItemHandler(taskRepo, outboxRepo, newCommitUnitOfWork()).
+			Handle(context.Background(), AddChecklistItemCommand{TaskID: existing.ID(), UserID: userID, Title: "Tag version"})
+
+		require.NoError(t, err)
+		items := existing.Checklist()
+		require.Len(t, items, 1)
+		assert.Equal(t, items[0].ID, result.ItemID)
+		assert.Equal(t, "Tag version", items[0].Title)
+		taskRepo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty title is a validation error", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Release")
+		require.NoError(t, err)
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		_, err = NewAddChecklistItemHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), AddChecklistItemCommand{TaskID: existing.ID(), UserID: userID, Title: " "})
+
+		assert.ErrorIs(t, err, task.ErrEmptyChecklistItem)
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+		taskRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("another user's task is not found", func(t *testing.T) {
+		existing, err := task.NewTask(uuid.New(), "Not mine")
+		require.NoError(t, err)
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		_, err = NewAddChecklistItemHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), AddChecklistItemCommand{TaskID: existing.ID(), UserID: userID, Title: "Step"})
+
+		assert.ErrorIs(t, err, ErrTaskNotOwned)
+		assert.Empty(t, existing.Checklist())
+	})
+}
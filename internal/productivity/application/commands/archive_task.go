@@ -2,7 +2,6 @@ package commands
 
 import (
 	"context"
-	"errors"
 
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
 	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
@@ -10,10 +9,6 @@ import (
 	"github.com/google/uuid"
 )
 
-var (
-	ErrTaskNotFound = errors.New("task not found")
-)
-
 // ArchiveTaskCommand contains the data needed to archive a task.
 type ArchiveTaskCommand struct {
 	TaskID uuid.UUID
@@ -38,7 +33,7 @@ func NewArchiveTaskHandler(taskRepo task.Repository, outboxRepo outbox.Repositor
 
 // Handle executes the ArchiveTaskCommand.
 func (h *ArchiveTaskHandler) Handle(ctx context.Context, cmd ArchiveTaskCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		t, err := h.taskRepo.FindByID(txCtx, cmd.TaskID)
 		if err != nil {
 			return err
@@ -49,7 +44,7 @@ func (h *ArchiveTaskHandler) Handle(ctx context.Context, cmd ArchiveTaskCommand)
 
 		// Verify ownership
 		if t.UserID() != cmd.UserID {
-			return errors.New("user does not own this task")
+			return ErrTaskNotOwned
 		}
 
 		// Archive the task
@@ -76,4 +71,5 @@ func (h *ArchiveTaskHandler) Handle(ctx context.Context, cmd ArchiveTaskCommand)
 		}
 		return h.outboxRepo.SaveBatch(txCtx, msgs)
 	})
+	return classifyTaskError(err)
 }
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// BlockTaskCommand contains the data needed to mark a task as blocked.
+type BlockTaskCommand struct {
+	TaskID uuid.UUID
+	UserID uuid.UUID
+	Reason string // Free-form reference to what the task waits on, e.g. a PR URL
+}
+
+// BlockTaskHandler handles the BlockTaskCommand.
+type BlockTaskHandler struct {
+	taskRepo   task.Repository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+}
+
+// NewBlockTaskHandler creates a new BlockTaskHandler.
+func NewBlockTaskHandler(taskRepo task.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *BlockTaskHandler {
+	return &BlockTaskHandler{
+		taskRepo:   taskRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// Handle executes the BlockTaskCommand.
+func (h *BlockTaskHandler) Handle(ctx context.Context, cmd BlockTaskCommand) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		// Find the task
+		t, err := h.taskRepo.FindByID(txCtx, cmd.TaskID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return ErrTaskNotFound
+		}
+
+		// Verify ownership
+		if t.UserID() != cmd.UserID {
+			return ErrTaskNotOwned
+		}
+
+		if err := t.Block(cmd.Reason); err != nil {
+			return err
+		}
+
+		// Save the task
+		if err := h.taskRepo.Save(txCtx, t); err != nil {
+			return err
+		}
+
+		// Save domain events to outbox
+		events := t.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		return h.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+	return classifyTaskError(err)
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockTaskHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("blocks the task with a reason", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Ship release")
+		require.NoError(t, err)
+		existing.ClearDomainEvents()
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+		taskRepo.On("Save", mock.Anything, existing).Return(nil)
+		outboxRepo := new(mockOutboxRepo)
+		outboxRepo.On("SaveBatch", mock.Anything, mock.Anything).Return(nil)
+
+		err = NewBlockTaskHandler(taskRepo, outboxRepo, newCommitUnitOfWork()).
+			Handle(context.Background(), BlockTaskCommand{TaskID: existing.ID(), UserID: userID, Reason: "Waiting on PR #42"})
+
+		require.NoError(t, err)
+		assert.True(t, existing.IsBlocked())
+		assert.Equal(t, "Waiting on PR #42", existing.BlockedReason())
+		taskRepo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("completed task conflicts", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Done already")
+		require.NoError(t, err)
+		require.NoError(t, existing.Complete())
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		err = NewBlockTaskHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), BlockTaskCommand{TaskID: existing.ID(), UserID: userID, Reason: "Email from legal"})
+
+		assert.ErrorIs(t, err, task.ErrTaskAlreadyComplete)
+		assert.ErrorIs(t, err, sharedApplication.ErrConflict)
+	})
+}
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// BulkTagTasksCommand adds and removes the same tags on several tasks.
+type BulkTagTasksCommand struct {
+	UserID  uuid.UUID
+	TaskIDs []uuid.UUID
+	Add     []string
+	Remove  []string
+}
+
+// TaskTagResult reports what a bulk tag change did to one task. Err is set
+// when the task was skipped because it is missing, not owned or archived.
+type TaskTagResult struct {
+	TaskID  uuid.UUID
+	Added   []string
+	Removed []string
+	Tags    []string
+	Err     error
+}
+
+// BulkTagTasksResult contains one result per requested task, in order.
+type BulkTagTasksResult struct {
+	Results []TaskTagResult
+}
+
+// BulkTagTasksHandler handles the BulkTagTasksCommand.
+type BulkTagTasksHandler struct {
+	taskRepo   task.Repository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+}
+
+// NewBulkTagTasksHandler creates a new BulkTagTasksHandler.
+func NewBulkTagTasksHandler(taskRepo task.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *BulkTagTasksHandler {
+	return &BulkTagTasksHandler{
+		taskRepo:   taskRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// Handle applies the tag changes to every task in one transaction. Tasks that
+// cannot be tagged are reported in their result and do not stop the others;
+// a storage failure rolls back the whole batch.
+func (h *BulkTagTasksHandler) Handle(ctx context.Context, cmd BulkTagTasksCommand) (*BulkTagTasksResult, error) {
+	add, remove, err := normalizeTagChanges(cmd.Add, cmd.Remove)
+	if err != nil {
+		return nil, classifyTaskError(err)
+	}
+	if len(cmd.TaskIDs) == 0 {
+		return nil, ErrNoTasksToTag
+	}
+
+	var result *BulkTagTasksResult
+
+	err = sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		result = &BulkTagTasksResult{Results: make([]TaskTagResult, 0, len(cmd.TaskIDs))}
+		var msgs []*outbox.Message
+
+		for _, taskID := range cmd.TaskIDs {
+			item := TaskTagResult{TaskID: taskID}
+
+			t, err := h.taskRepo.FindByID(txCtx, taskID)
+			if err != nil {
+				return err
+			}
+			switch {
+			case t == nil:
+				item.Err = ErrTaskNotFound
+			case t.UserID() != cmd.UserID:
+				item.Err = ErrTaskNotOwned
+			default:
+				item.Added, item.Removed, item.Err = applyTaskTags(t, add, remove)
+			}
+			if item.Err != nil {
+				item.Err = classifyTaskError(item.Err)
+				result.Results = append(result.Results, item)
+				continue
+			}
+			item.Tags = t.Tags()
+			result.Results = append(result.Results, item)
+
+			if len(item.Added) == 0 && len(item.Removed) == 0 {
+				continue
+			}
+
+			t.AddDomainEvent(task.NewTaskUpdated(t.ID(), []string{"tags"}))
+
+			// Save the task
+			if err := h.taskRepo.Save(txCtx, t); err != nil {
+				return err
+			}
+
+			events := t.DomainEvents()
+			sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+			for _, event := range events {
+				msg, err := outbox.NewMessage(event)
+				if err != nil {
+					return err
+				}
+				msgs = append(msgs, msg)
+			}
+		}
+
+		// Save domain events to outbox
+		if len(msgs) == 0 {
+			return nil
+		}
+		return h.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+	if err != nil {
+		return nil, classifyTaskError(err)
+	}
+
+	return result, nil
+}
+
+// applyTaskTags adds and removes the tags on a task and reports which ones
+// actually changed.
+func applyTaskTags(t *task.Task, add, remove []string) (added, removed []string, err error) {
+	for _, tag := range add {
+		changed, err := t.AddTag(tag)
+		if err != nil {
+			return nil, nil, err
+		}
+		if changed {
+			added = append(added, tag)
+		}
+	}
+	for _, tag := range remove {
+		changed, err := t.RemoveTag(tag)
+		if err != nil {
+			return nil, nil, err
+		}
+		if changed {
+			removed = append(removed, tag)
+		}
+	}
+	return added, removed, nil
+}
+
+// normalizeTagChanges normalizes the tags to add and remove and rejects
+// requests that change nothing or both add and remove the same tag.
+func normalizeTagChanges(add, remove []string) ([]string, []string, error) {
+	add, err := sharedDomain.NormalizeTags(add)
+	if err != nil {
+		return nil, nil, err
+	}
+	remove, err = sharedDomain.NormalizeTags(remove)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, nil, ErrNoTagChanges
+	}
+	for _, tag := range remove {
+		for _, other := range add {
+			if tag == other {
+				return nil, nil, fmt.Errorf("%w: %q", ErrConflictingTagChange, tag)
+			}
+		}
+	}
+	return add, remove, nil
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkTagTasksHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	newTask := func(t *testing.T, owner uuid.UUID, title string, tags ...string) *task.Task {
+		t.Helper()
+		tk, err := task.NewTask(owner, title)
+		require.NoError(t, err)
+		tk.RehydrateTags(tags)
+		tk.ClearDomainEvents()
+		return tk
+	}
+
+	t.Run("adds and removes tags across the batch with per-task results", func(t *testing.T) {
+		first := newTask(t, userID, "Write report", "old")
+		second := newTask(t, userID, "Review PR", "work")
+		archived := newTask(t, userID, "Done long ago", "old")
+		archived.Archive()
+		notMine := newTask(t, uuid.New(), "Not mine", "old")
+		missing := uuid.New()
+
+		taskRepo := new(mockTaskRepo)
+		for _, tk := range []*task.Task{first, second, archived, notMine} {
+			taskRepo.On("FindByID", mock.Anything, tk.ID()).Return(tk, nil)
+		}
+		taskRepo.On("FindByID", mock.Anything, missing).Return(nil, nil)
+		taskRepo.On("Save", mock.Anything, first).Return(nil)
+		outboxRepo := new(mockOutboxRepo)
+		outboxRepo.On("SaveBatch", mock.Anything, mock.MatchedBy(func(msgs []*outbox.Message) bool {
+			return len(msgs) == 1 && msgs[0].RoutingKey == task.RoutingKeyUpdated
+		})).Return(nil)
+
+		result, err := NewBulkTagTasksHandler(taskRepo, outboxRepo, newCommitUnitOfWork()).
+			Handle(context.Background(), BulkTagTasksCommand{
+				UserID:  userID,
+				TaskIDs: []uuid.UUID{first.ID(), second.ID(), archived.ID(), notMine.ID(), missing},
+				Add:     []string{"Work"},
+				Remove:  []string{"old"},
+			})
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 5)
+
+		assert.NoError(t, result.Results[0].Err)
+		assert.Equal(t, []string{"work"}, result.Results[0].Added)
+		assert.Equal(t, []string{"old"}, result.Results[0].Removed)
+		assert.Equal(t, []string{"work"}, first.Tags())
+
+		// Already tagged and without the removed tag, so nothing changes.
+		assert.NoError(t, result.Results[1].Err)
+		assert.Empty(t, result.Results[1].Added)
+		assert.Empty(t, result.Results[1].Removed)
+		assert.Equal(t, []string{"work"}, result.Results[1].Tags)
+
+		assert.ErrorIs(t, result.Results[2].Err, task.ErrTaskArchived)
+		assert.ErrorIs(t, result.Results[2].Err, sharedApplication.ErrConflict)
+		assert.Equal(t, []string{"old"}, archived.Tags())
+		assert.ErrorIs(t, result.Results[3].Err, ErrTaskNotOwned)
+		assert.Equal(t, []string{"old"}, notMine.Tags())
+		assert.ErrorIs(t, result.Results[4].Err, ErrTaskNotFound)
+
+		taskRepo.AssertExpectations(t)
+		taskRepo.AssertNotCalled(t, "Save", mock.Anything, second)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("storage failure rolls back the batch", func(t *testing.T) {
+		first := newTask(t, userID, "Write report")
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, first.ID()).Return(first, nil)
+		taskRepo.On("Save", mock.Anything, first).Return(errors.New("disk full"))
+
+		_, err := NewBulkTagTasksHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), BulkTagTasksCommand{UserID: userID, TaskIDs: []uuid.UUID{first.ID()}, Add: []string{"work"}})
+
+		assert.EqualError(t, err, "disk full")
+	})
+
+	t.Run("rejects invalid tag changes before touching any task", func(t *testing.T) {
+		handler := NewBulkTagTasksHandler(new(mockTaskRepo), new(mockOutboxRepo), new(mockUnitOfWork))
+		ids := []uuid.UUID{uuid.New()}
+
+		_, err := handler.Handle(context.Background(), BulkTagTasksCommand{UserID: userID, TaskIDs: ids, Add: []string{"two words"}})
+		assert.ErrorIs(t, err, sharedDomain.ErrInvalidTag)
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+
+		_, err = handler.Handle(context.Background(), BulkTagTasksCommand{UserID: userID, TaskIDs: ids})
+		assert.ErrorIs(t, err, ErrNoTagChanges)
+
+		_, err = handler.Handle(context.Background(), BulkTagTasksCommand{UserID: userID, TaskIDs: ids, Add: []string{"work"}, Remove: []string{"WORK"}})
+		assert.ErrorIs(t, err, ErrConflictingTagChange)
+
+		_, err = handler.Handle(context.Background(), BulkTagTasksCommand{UserID: userID, Add: []string{"work"}})
+		assert.ErrorIs(t, err, ErrNoTasksToTag)
+	})
+}
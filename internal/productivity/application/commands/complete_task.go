@@ -33,16 +33,19 @@ func NewCompleteTaskHandler(taskRepo task.Repository, outboxRepo outbox.Reposito
 
 // Handle executes the CompleteTaskCommand.
 func (h *CompleteTaskHandler) Handle(ctx context.Context, cmd CompleteTaskCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		// Find the task
 		t, err := h.taskRepo.FindByID(txCtx, cmd.TaskID)
 		if err != nil {
 			return err
 		}
+		if t == nil {
+			return ErrTaskNotFound
+		}
 
 		// Verify ownership
 		if t.UserID() != cmd.UserID {
-			return task.ErrTaskArchived // Use a proper error in production
+			return ErrTaskNotOwned
 		}
 
 		// Complete the task
@@ -50,6 +53,15 @@ func (h *CompleteTaskHandler) Handle(ctx context.Context, cmd CompleteTaskComman
 			return err
 		}
 
+		// Spawn the next occurrence of a recurring task
+		var next *task.Task
+		if t.IsRecurring() {
+			next, err = t.NextOccurrence()
+			if err != nil {
+				return err
+			}
+		}
+
 		// Save the task
 		if err := h.taskRepo.Save(txCtx, t); err != nil {
 			return err
@@ -57,6 +69,12 @@ func (h *CompleteTaskHandler) Handle(ctx context.Context, cmd CompleteTaskComman
 
 		// Save domain events to outbox
 		events := t.DomainEvents()
+		if next != nil {
+			if err := h.taskRepo.Save(txCtx, next); err != nil {
+				return err
+			}
+			events = append(events, next.DomainEvents()...)
+		}
 		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
 
 		msgs := make([]*outbox.Message, 0, len(events))
@@ -69,4 +87,5 @@ func (h *CompleteTaskHandler) Handle(ctx context.Context, cmd CompleteTaskComman
 		}
 		return h.outboxRepo.SaveBatch(txCtx, msgs)
 	})
+	return classifyTaskError(err)
 }
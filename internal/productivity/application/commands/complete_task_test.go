@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -218,6 +221,129 @@ func TestCompleteTaskHandler_Handle(t *testing.T) {
 	})
 }
 
+func TestCompleteTaskHandler_Recurrence(t *testing.T) {
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	t.Run("completing a daily recurring task creates tomorrow's instance", func(t *testing.T) {
+		taskRepo := new(mockTaskRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewCompleteTaskHandler(taskRepo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		dueDate := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+		recurrence, err := task.NewRecurrence(task.RecurrenceDaily, 1)
+		require.NoError(t, err)
+
+		existingTask, _ := task.NewTask(userID, "Water plants")
+		require.NoError(t, existingTask.SetDescription("Balcony and kitchen"))
+		require.NoError(t, existingTask.SetPriority(value_objects.PriorityHigh))
+		require.NoError(t, existingTask.SetDueDate(&dueDate))
+		require.NoError(t, existingTask.SetRecurrence(&recurrence))
+		existingTask.ClearDomainEvents()
+
+		var saved []*task.Task
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		taskRepo.On("FindByID", txCtx, taskID).Return(existingTask, nil)
+		taskRepo.On("Save", txCtx, mock.AnythingOfType("*task.Task")).
+			Run(func(args mock.Arguments) { saved = append(saved, args.Get(1).(*task.Task)) }).
+			Return(nil)
+
+		var msgs []*outbox.Message
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).
+			Run(func(args mock.Arguments) { msgs = args.Get(1).([]*outbox.Message) }).
+			Return(nil)
+
+		err = handler.Handle(ctx, CompleteTaskCommand{TaskID: taskID, UserID: userID})
+		require.NoError(t, err)
+
+		require.Len(t, saved, 2)
+		assert.True(t, saved[0].IsCompleted())
+
+		next := saved[1]
+		assert.NotEqual(t, existingTask.ID(), next.ID())
+		assert.Equal(t, task.StatusPending, next.Status())
+		assert.Equal(t, "Water plants", next.Title())
+		assert.Equal(t, "Balcony and kitchen", next.Description())
+		assert.Equal(t, value_objects.PriorityHigh, next.Priority())
+		require.NotNil(t, next.DueDate())
+		assert.Equal(t, dueDate.AddDate(0, 0, 1), *next.DueDate())
+		require.NotNil(t, next.Recurrence())
+		assert.Equal(t, task.RecurrenceDaily, next.Recurrence().Frequency)
+
+		routingKeys := make([]string, 0, len(msgs))
+		for _, msg := range msgs {
+			routingKeys = append(routingKeys, msg.RoutingKey)
+		}
+		assert.Contains(t, routingKeys, task.RoutingKeyCompleted)
+		assert.Contains(t, routingKeys, task.RoutingKeyRecurred)
+		assert.Contains(t, routingKeys, task.RoutingKeyCreated)
+
+		uow.AssertExpectations(t)
+		taskRepo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("non-recurring task does not spawn an occurrence", func(t *testing.T) {
+		taskRepo := new(mockTaskRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewCompleteTaskHandler(taskRepo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		existingTask, _ := task.NewTask(userID, "One-off")
+		existingTask.ClearDomainEvents()
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		taskRepo.On("FindByID", txCtx, taskID).Return(existingTask, nil)
+		taskRepo.On("Save", txCtx, existingTask).Return(nil).Once()
+
+		var msgs []*outbox.Message
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).
+			Run(func(args mock.Arguments) { msgs = args.Get(1).([]*outbox.Message) }).
+			Return(nil)
+
+		err := handler.Handle(ctx, CompleteTaskCommand{TaskID: taskID, UserID: userID})
+		require.NoError(t, err)
+
+		require.Len(t, msgs, 1)
+		assert.Equal(t, task.RoutingKeyCompleted, msgs[0].RoutingKey)
+		taskRepo.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("archived recurring task stops generating occurrences", func(t *testing.T) {
+		taskRepo := new(mockTaskRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewCompleteTaskHandler(taskRepo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		recurrence, err := task.NewRecurrence(task.RecurrenceMonthly, 1)
+		require.NoError(t, err)
+		existingTask, _ := task.NewTask(userID, "Pay rent")
+		require.NoError(t, existingTask.SetRecurrence(&recurrence))
+		require.NoError(t, existingTask.Archive())
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		taskRepo.On("FindByID", txCtx, taskID).Return(existingTask, nil)
+
+		err = handler.Handle(ctx, CompleteTaskCommand{TaskID: taskID, UserID: userID})
+		assert.ErrorIs(t, err, task.ErrTaskArchived)
+		taskRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		outboxRepo.AssertNotCalled(t, "SaveBatch", mock.Anything, mock.Anything)
+	})
+}
+
 func TestNewCompleteTaskHandler(t *testing.T) {
 	taskRepo := new(mockTaskRepo)
 	outboxRepo := new(mockOutboxRepo)
@@ -13,12 +13,26 @@ import (
 
 // CreateTaskCommand contains the data needed to create a task.
 type CreateTaskCommand struct {
-	UserID          uuid.UUID
-	Title           string
-	Description     string
-	Priority        string
+	UserID      uuid.UUID
+	Title       string
+	Description string
+	Priority    string
+	// DurationMinutes overrides the user's default duration for the task's priority.
 	DurationMinutes int
 	DueDate         *time.Time
+	ReminderOffsets []time.Duration // Fire this long before the due date
+	// ExternalID identifies an imported task in the app it came from.
+	ExternalID string
+	// Timezone is an IANA time zone the due date belongs to, for tasks tied
+	// to a place other than where the user currently is.
+	Timezone string
+	// EarliestStart keeps the task off the schedule until then.
+	EarliestStart *time.Time
+	// Recurrence makes the task repeat, such as "monthly" or "every 2
+	// weeks": completing it creates the next occurrence.
+	Recurrence string
+	// Tags are trimmed, lower-cased and deduplicated.
+	Tags []string
 }
 
 // CreateTaskResult contains the result of creating a task.
@@ -26,11 +40,18 @@ type CreateTaskResult struct {
 	TaskID uuid.UUID
 }
 
+// DefaultDurations provides a user's default task duration per priority.
+type DefaultDurations interface {
+	// DefaultDuration returns zero when the user has no default for the priority.
+	DefaultDuration(ctx context.Context, userID uuid.UUID, priority string) (time.Duration, error)
+}
+
 // CreateTaskHandler handles the CreateTaskCommand.
 type CreateTaskHandler struct {
-	taskRepo   task.Repository
-	outboxRepo outbox.Repository
-	uow        sharedApplication.UnitOfWork
+	taskRepo         task.Repository
+	outboxRepo       outbox.Repository
+	uow              sharedApplication.UnitOfWork
+	defaultDurations DefaultDurations
 }
 
 // NewCreateTaskHandler creates a new CreateTaskHandler.
@@ -42,6 +63,13 @@ func NewCreateTaskHandler(taskRepo task.Repository, outboxRepo outbox.Repository
 	}
 }
 
+// WithDefaultDurations gives tasks created without a duration the user's
+// default for their priority, so they can be auto-scheduled.
+func (h *CreateTaskHandler) WithDefaultDurations(defaults DefaultDurations) *CreateTaskHandler {
+	h.defaultDurations = defaults
+	return h
+}
+
 // Handle executes the CreateTaskCommand.
 func (h *CreateTaskHandler) Handle(ctx context.Context, cmd CreateTaskCommand) (*CreateTaskResult, error) {
 	var result *CreateTaskResult
@@ -70,8 +98,12 @@ func (h *CreateTaskHandler) Handle(ctx context.Context, cmd CreateTaskCommand) (
 			}
 		}
 
-		if cmd.DurationMinutes > 0 {
-			duration, err := value_objects.NewDuration(time.Duration(cmd.DurationMinutes) * time.Minute)
+		length := time.Duration(cmd.DurationMinutes) * time.Minute
+		if length <= 0 {
+			length = h.defaultDuration(txCtx, cmd.UserID, t.Priority())
+		}
+		if length > 0 {
+			duration, err := value_objects.NewDuration(length)
 			if err != nil {
 				return err
 			}
@@ -80,12 +112,50 @@ func (h *CreateTaskHandler) Handle(ctx context.Context, cmd CreateTaskCommand) (
 			}
 		}
 
+		if cmd.Timezone != "" {
+			if err := t.SetTimezone(cmd.Timezone); err != nil {
+				return err
+			}
+		}
+
 		if cmd.DueDate != nil {
 			if err := t.SetDueDate(cmd.DueDate); err != nil {
 				return err
 			}
 		}
 
+		if cmd.EarliestStart != nil {
+			if err := t.SetEarliestStart(cmd.EarliestStart); err != nil {
+				return err
+			}
+		}
+
+		if cmd.Recurrence != "" {
+			recurrence, err := task.ParseRecurrence(cmd.Recurrence)
+			if err != nil {
+				return err
+			}
+			if err := t.SetRecurrence(&recurrence); err != nil {
+				return err
+			}
+		}
+
+		if cmd.ExternalID != "" {
+			t.SetExternalID(cmd.ExternalID)
+		}
+
+		for _, tag := range cmd.Tags {
+			if _, err := t.AddTag(tag); err != nil {
+				return err
+			}
+		}
+
+		for _, offset := range cmd.ReminderOffsets {
+			if err := t.AddReminder(offset); err != nil {
+				return err
+			}
+		}
+
 		// Save the task
 		if err := h.taskRepo.Save(txCtx, t); err != nil {
 			return err
@@ -111,8 +181,21 @@ func (h *CreateTaskHandler) Handle(ctx context.Context, cmd CreateTaskCommand) (
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, classifyTaskError(err)
 	}
 
 	return result, nil
 }
+
+// defaultDuration looks up the user's default duration for the priority.
+// A failed lookup leaves the task without a duration rather than failing it.
+func (h *CreateTaskHandler) defaultDuration(ctx context.Context, userID uuid.UUID, priority value_objects.Priority) time.Duration {
+	if h.defaultDurations == nil {
+		return 0
+	}
+	duration, err := h.defaultDurations.DefaultDuration(ctx, userID, priority.String())
+	if err != nil {
+		return 0
+	}
+	return duration
+}
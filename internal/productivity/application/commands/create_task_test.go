@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -192,6 +193,150 @@ func TestCreateTaskHandler_Handle(t *testing.T) {
 		outboxRepo.AssertExpectations(t)
 	})
 
+	t.Run("attaches reminders", func(t *testing.T) {
+		taskRepo := new(mockTaskRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewCreateTaskHandler(taskRepo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		taskRepo.On("Save", txCtx, mock.AnythingOfType("*task.Task")).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		dueDate := time.Now().Add(48 * time.Hour)
+		_, err := handler.Handle(ctx, CreateTaskCommand{
+			UserID:          userID,
+			Title:           "Test task",
+			DueDate:         &dueDate,
+			ReminderOffsets: []time.Duration{time.Hour, 24 * time.Hour},
+		})
+		require.NoError(t, err)
+
+		saved := taskRepo.Calls[0].Arguments.Get(1).(*task.Task)
+		reminders := saved.Reminders()
+		require.Len(t, reminders, 2)
+		assert.Equal(t, 24*time.Hour, reminders[0].Offset)
+		assert.Equal(t, time.Hour, reminders[1].Offset)
+	})
+
+	t.Run("rejects invalid reminder", func(t *testing.T) {
+		taskRepo := new(mockTaskRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewCreateTaskHandler(taskRepo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+
+		_, err := handler.Handle(ctx, CreateTaskCommand{
+			UserID:          userID,
+			Title:           "Test task",
+			ReminderOffsets: []time.Duration{-time.Hour},
+		})
+		assert.ErrorIs(t, err, task.ErrInvalidReminder)
+		taskRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("makes the task recurring", func(t *testing.T) {
+		taskRepo := new(mockTaskRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewCreateTaskHandler(taskRepo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		taskRepo.On("Save", txCtx, mock.AnythingOfType("*task.Task")).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		_, err := handler.Handle(ctx, CreateTaskCommand{
+			UserID:     userID,
+			Title:      "Pay rent",
+			Recurrence: "every 2 weeks",
+		})
+		require.NoError(t, err)
+
+		saved := taskRepo.Calls[0].Arguments.Get(1).(*task.Task)
+		require.NotNil(t, saved.Recurrence())
+		assert.Equal(t, task.Recurrence{Frequency: task.RecurrenceWeekly, Interval: 2}, *saved.Recurrence())
+	})
+
+	t.Run("tags the task with normalized tags", func(t *testing.T) {
+		taskRepo := new(mockTaskRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewCreateTaskHandler(taskRepo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		taskRepo.On("Save", txCtx, mock.AnythingOfType("*task.Task")).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		_, err := handler.Handle(ctx, CreateTaskCommand{
+			UserID: userID,
+			Title:  "Book flights",
+			Tags:   []string{" Travel ", "personal", "travel"},
+		})
+		require.NoError(t, err)
+
+		saved := taskRepo.Calls[0].Arguments.Get(1).(*task.Task)
+		assert.Equal(t, []string{"personal", "travel"}, saved.Tags())
+	})
+
+	t.Run("rejects an empty tag", func(t *testing.T) {
+		taskRepo := new(mockTaskRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewCreateTaskHandler(taskRepo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+
+		_, err := handler.Handle(ctx, CreateTaskCommand{
+			UserID: userID,
+			Title:  "Book flights",
+			Tags:   []string{"  "},
+		})
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+		taskRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects invalid recurrence", func(t *testing.T) {
+		taskRepo := new(mockTaskRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewCreateTaskHandler(taskRepo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+
+		_, err := handler.Handle(ctx, CreateTaskCommand{
+			UserID:     userID,
+			Title:      "Pay rent",
+			Recurrence: "yearly",
+		})
+		assert.ErrorIs(t, err, task.ErrInvalidRecurrence)
+		taskRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
 	t.Run("fails with empty title", func(t *testing.T) {
 		taskRepo := new(mockTaskRepo)
 		outboxRepo := new(mockOutboxRepo)
@@ -327,6 +472,70 @@ func TestCreateTaskHandler_Handle(t *testing.T) {
 	})
 }
 
+// stubDefaultDurations returns fixed default durations per priority.
+type stubDefaultDurations struct {
+	durations map[string]time.Duration
+	err       error
+}
+
+func (s stubDefaultDurations) DefaultDuration(ctx context.Context, userID uuid.UUID, priority string) (time.Duration, error) {
+	return s.durations[priority], s.err
+}
+
+func TestCreateTaskHandler_DefaultDurations(t *testing.T) {
+	userID := uuid.New()
+	defaults := stubDefaultDurations{durations: map[string]time.Duration{
+		"high": 90 * time.Minute,
+		"none": 30 * time.Minute,
+	}}
+
+	create := func(t *testing.T, defaults DefaultDurations, cmd CreateTaskCommand) *task.Task {
+		t.Helper()
+		taskRepo := new(mockTaskRepo)
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		handler := NewCreateTaskHandler(taskRepo, outboxRepo, uow).WithDefaultDurations(defaults)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		taskRepo.On("Save", txCtx, mock.AnythingOfType("*task.Task")).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		cmd.UserID = userID
+		_, err := handler.Handle(ctx, cmd)
+		require.NoError(t, err)
+		return taskRepo.Calls[0].Arguments.Get(1).(*task.Task)
+	}
+
+	t.Run("applies the default for the task's priority", func(t *testing.T) {
+		saved := create(t, defaults, CreateTaskCommand{Title: "Write report", Priority: "high"})
+		assert.Equal(t, 90*time.Minute, saved.Duration().Value())
+	})
+
+	t.Run("applies the default for tasks without a priority", func(t *testing.T) {
+		saved := create(t, defaults, CreateTaskCommand{Title: "Tidy desk"})
+		assert.Equal(t, 30*time.Minute, saved.Duration().Value())
+	})
+
+	t.Run("explicit duration overrides the default", func(t *testing.T) {
+		saved := create(t, defaults, CreateTaskCommand{Title: "Write report", Priority: "high", DurationMinutes: 20})
+		assert.Equal(t, 20*time.Minute, saved.Duration().Value())
+	})
+
+	t.Run("leaves duration unset without a default for the priority", func(t *testing.T) {
+		saved := create(t, defaults, CreateTaskCommand{Title: "Call bank", Priority: "low"})
+		assert.True(t, saved.Duration().IsZero())
+	})
+
+	t.Run("creates the task when the lookup fails", func(t *testing.T) {
+		saved := create(t, stubDefaultDurations{err: errors.New("settings unavailable")}, CreateTaskCommand{Title: "Write report", Priority: "high"})
+		assert.True(t, saved.Duration().IsZero())
+	})
+}
+
 func TestNewCreateTaskHandler(t *testing.T) {
 	taskRepo := new(mockTaskRepo)
 	outboxRepo := new(mockOutboxRepo)
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+)
+
+var (
+	ErrTaskNotFound = sharedApplication.NewNotFoundError("task not found")
+	ErrTaskNotOwned = sharedApplication.NewNotFoundError("user does not own this task")
+
+	ErrNoTasksToTag         = sharedApplication.NewValidationError("no tasks to tag")
+	ErrNoTagChanges         = sharedApplication.NewValidationError("no tags to add or remove")
+	ErrConflictingTagChange = sharedApplication.NewValidationError("tag is both added and removed")
+)
+
+// classifyTaskError tags task domain errors with an application error category.
+// Errors it does not recognise are returned unchanged and count as internal.
+func classifyTaskError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, task.ErrEmptyTitle),
+		errors.Is(err, task.ErrInvalidRecurrence),
+		errors.Is(err, task.ErrInvalidReminder),
+		errors.Is(err, task.ErrEmptyChecklistItem),
+		errors.Is(err, task.ErrEmptyWaitingOn),
+		errors.Is(err, task.ErrInvalidFollowUpDelay),
+		errors.Is(err, task.ErrEarliestStartAfterDue),
+		errors.Is(err, sharedDomain.ErrInvalidTag),
+		errors.Is(err, value_objects.ErrInvalidPriority),
+		errors.Is(err, value_objects.ErrInvalidDuration),
+		errors.Is(err, value_objects.ErrDurationTooLong):
+		return sharedApplication.Validation(err)
+	case errors.Is(err, task.ErrTaskArchived),
+		errors.Is(err, task.ErrTaskAlreadyComplete),
+		errors.Is(err, task.ErrTaskNotRecurring),
+		errors.Is(err, task.ErrTaskNotCompleted),
+		errors.Is(err, task.ErrChecklistIncomplete),
+		errors.Is(err, task.ErrTaskBlocked),
+		errors.Is(err, task.ErrTaskNotBlocked),
+		errors.Is(err, task.ErrTaskWaiting),
+		errors.Is(err, task.ErrTaskNotWaiting):
+		return sharedApplication.Conflict(err)
+	case errors.Is(err, task.ErrChecklistItemNotFound):
+		return sharedApplication.NotFound(err)
+	}
+	return err
+}
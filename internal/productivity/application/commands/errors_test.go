@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRollbackUnitOfWork() *mockUnitOfWork {
+	uow := new(mockUnitOfWork)
+	uow.On("Begin", mock.Anything).Return(context.Background(), nil)
+	uow.On("Rollback", mock.Anything).Return(nil)
+	return uow
+}
+
+func TestTaskHandlers_TypedErrors(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("missing task is not found", func(t *testing.T) {
+		taskRepo := new(mockTaskRepo)
+		taskID := uuid.New()
+		taskRepo.On("FindByID", mock.Anything, taskID).Return(nil, nil)
+
+		err := NewCompleteTaskHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), CompleteTaskCommand{TaskID: taskID, UserID: userID})
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+		assert.ErrorIs(t, err, sharedApplication.ErrNotFound)
+	})
+
+	t.Run("another user's task is not found", func(t *testing.T) {
+		existing, err := task.NewTask(uuid.New(), "Not mine")
+		require.NoError(t, err)
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		err = NewStartTaskHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), StartTaskCommand{TaskID: existing.ID(), UserID: userID})
+
+		assert.ErrorIs(t, err, ErrTaskNotOwned)
+		assert.ErrorIs(t, err, sharedApplication.ErrNotFound)
+		assert.NotErrorIs(t, err, task.ErrTaskArchived)
+	})
+
+	t.Run("completing an archived task conflicts", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Old")
+		require.NoError(t, err)
+		require.NoError(t, existing.Archive())
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		err = NewCompleteTaskHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), CompleteTaskCommand{TaskID: existing.ID(), UserID: userID})
+
+		assert.ErrorIs(t, err, task.ErrTaskArchived)
+		assert.ErrorIs(t, err, sharedApplication.ErrConflict)
+	})
+
+	t.Run("completing with an unfinished required checklist conflicts", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Release")
+		require.NoError(t, err)
+		_, err = existing.AddChecklistItem("Tag version")
+		require.NoError(t, err)
+		require.NoError(t, existing.SetChecklistRequired(true))
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		err = NewCompleteTaskHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), CompleteTaskCommand{TaskID: existing.ID(), UserID: userID})
+
+		assert.ErrorIs(t, err, task.ErrChecklistIncomplete)
+		assert.ErrorIs(t, err, sharedApplication.ErrConflict)
+	})
+
+	t.Run("invalid input is a validation error", func(t *testing.T) {
+		handler := NewCreateTaskHandler(new(mockTaskRepo), new(mockOutboxRepo), newRollbackUnitOfWork())
+
+		_, err := handler.Handle(context.Background(), CreateTaskCommand{UserID: userID, Title: ""})
+		assert.ErrorIs(t, err, task.ErrEmptyTitle)
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+
+		_, err = handler.Handle(context.Background(), CreateTaskCommand{UserID: userID, Title: "Task", Priority: "someday"})
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+	})
+}
@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// ImportOutcome describes what an import did with a task.
+type ImportOutcome string
+
+const (
+	ImportCreated   ImportOutcome = "created"
+	ImportUpdated   ImportOutcome = "updated"
+	ImportUnchanged ImportOutcome = "unchanged"
+)
+
+// ImportTaskResult contains the result of importing a task.
+type ImportTaskResult struct {
+	TaskID  uuid.UUID
+	Outcome ImportOutcome
+}
+
+// ImportTaskHandler creates imported tasks, or updates the task an earlier
+// import created when the command carries the same external ID, so importing
+// the same export twice does not create duplicates.
+type ImportTaskHandler struct {
+	taskRepo   task.Repository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+	create     *CreateTaskHandler
+}
+
+// NewImportTaskHandler creates a new ImportTaskHandler. New tasks are created
+// through create.
+func NewImportTaskHandler(taskRepo task.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork, create *CreateTaskHandler) *ImportTaskHandler {
+	return &ImportTaskHandler{
+		taskRepo:   taskRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+		create:     create,
+	}
+}
+
+// Handle imports the task described by cmd. Commands without an external ID,
+// and repositories that cannot look tasks up by one, always create a task.
+// Completed and archived tasks are left as they are.
+func (h *ImportTaskHandler) Handle(ctx context.Context, cmd CreateTaskCommand) (*ImportTaskResult, error) {
+	existing, err := h.findExisting(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		created, err := h.create.Handle(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+		return &ImportTaskResult{TaskID: created.TaskID, Outcome: ImportCreated}, nil
+	}
+
+	result := &ImportTaskResult{TaskID: existing.ID(), Outcome: ImportUnchanged}
+	if existing.IsCompleted() || existing.IsArchived() {
+		return result, nil
+	}
+
+	err = sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		updatedFields, err := applyImport(existing, cmd)
+		if err != nil {
+			return err
+		}
+		if len(updatedFields) == 0 {
+			return nil
+		}
+
+		existing.AddDomainEvent(task.NewTaskUpdated(existing.ID(), updatedFields))
+		if err := h.taskRepo.Save(txCtx, existing); err != nil {
+			return err
+		}
+
+		events := existing.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		if err := h.outboxRepo.SaveBatch(txCtx, msgs); err != nil {
+			return err
+		}
+
+		result.Outcome = ImportUpdated
+		return nil
+	})
+	if err != nil {
+		return nil, classifyTaskError(err)
+	}
+
+	return result, nil
+}
+
+func (h *ImportTaskHandler) findExisting(ctx context.Context, cmd CreateTaskCommand) (*task.Task, error) {
+	if cmd.ExternalID == "" {
+		return nil, nil
+	}
+	finder, ok := h.taskRepo.(task.ExternalIDRepository)
+	if !ok {
+		return nil, nil
+	}
+	return finder.FindByExternalID(ctx, cmd.UserID, cmd.ExternalID)
+}
+
+// applyImport copies the imported fields onto the task and returns the names
+// of the fields that changed. A duration is only replaced when the import has
+// one, since the existing task may carry the user's default.
+func applyImport(t *task.Task, cmd CreateTaskCommand) ([]string, error) {
+	var updatedFields []string
+
+	if t.Title() != strings.TrimSpace(cmd.Title) {
+		if err := t.SetTitle(cmd.Title); err != nil {
+			return nil, err
+		}
+		updatedFields = append(updatedFields, "title")
+	}
+
+	if t.Description() != strings.TrimSpace(cmd.Description) {
+		if err := t.SetDescription(cmd.Description); err != nil {
+			return nil, err
+		}
+		updatedFields = append(updatedFields, "description")
+	}
+
+	priority := value_objects.PriorityNone
+	if cmd.Priority != "" {
+		var err error
+		if priority, err = value_objects.ParsePriority(cmd.Priority); err != nil {
+			return nil, err
+		}
+	}
+	if t.Priority() != priority {
+		if err := t.SetPriority(priority); err != nil {
+			return nil, err
+		}
+		updatedFields = append(updatedFields, "priority")
+	}
+
+	if cmd.DurationMinutes > 0 && t.Duration().Minutes() != cmd.DurationMinutes {
+		duration, err := value_objects.NewDuration(time.Duration(cmd.DurationMinutes) * time.Minute)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.SetDuration(duration); err != nil {
+			return nil, err
+		}
+		updatedFields = append(updatedFields, "duration")
+	}
+
+	if !sameDueDate(t.DueDate(), cmd.DueDate) {
+		if err := t.SetDueDate(cmd.DueDate); err != nil {
+			return nil, err
+		}
+		updatedFields = append(updatedFields, "due_date")
+	}
+
+	return updatedFields, nil
+}
+
+func sameDueDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockExternalTaskRepo is a task repository that can also find tasks by
+// external ID.
+type mockExternalTaskRepo struct {
+	mockTaskRepo
+}
+
+func (m *mockExternalTaskRepo) FindByExternalID(ctx context.Context, userID uuid.UUID, externalID string) (*task.Task, error) {
+	args := m.Called(ctx, userID, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*task.Task), args.Error(1)
+}
+
+func TestImportTaskHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+	dueDate := time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC)
+
+	newHandler := func(repo task.Repository) (*ImportTaskHandler, *mockOutboxRepo, *mockUnitOfWork) {
+		outboxRepo := new(mockOutboxRepo)
+		uow := new(mockUnitOfWork)
+		create := NewCreateTaskHandler(repo, outboxRepo, uow)
+		return NewImportTaskHandler(repo, outboxRepo, uow, create), outboxRepo, uow
+	}
+
+	importedTask := func(t *testing.T) *task.Task {
+		t.Helper()
+		existing, err := task.NewTask(userID, "Renew passport")
+		require.NoError(t, err)
+		require.NoError(t, existing.SetPriority(value_objects.PriorityHigh))
+		require.NoError(t, existing.SetDueDate(&dueDate))
+		existing.SetExternalID("todoist:42")
+		existing.ClearDomainEvents()
+		return existing
+	}
+
+	cmd := CreateTaskCommand{
+		UserID:     userID,
+		Title:      "Renew passport",
+		Priority:   "high",
+		DueDate:    &dueDate,
+		ExternalID: "todoist:42",
+	}
+
+	t.Run("first import creates the task with its external ID", func(t *testing.T) {
+		repo := new(mockExternalTaskRepo)
+		handler, outboxRepo, uow := newHandler(repo)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		repo.On("FindByExternalID", ctx, userID, "todoist:42").Return(nil, nil)
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("Save", txCtx, mock.MatchedBy(func(saved *task.Task) bool {
+			return saved.ExternalID() == "todoist:42"
+		})).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		result, err := handler.Handle(ctx, cmd)
+
+		require.NoError(t, err)
+		assert.Equal(t, ImportCreated, result.Outcome)
+		assert.NotEqual(t, uuid.Nil, result.TaskID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("re-import updates the task that was imported before", func(t *testing.T) {
+		repo := new(mockExternalTaskRepo)
+		handler, outboxRepo, uow := newHandler(repo)
+		existing := importedTask(t)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		repo.On("FindByExternalID", ctx, userID, "todoist:42").Return(existing, nil)
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("Save", txCtx, existing).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.MatchedBy(func(msgs []*outbox.Message) bool {
+			return len(msgs) == 1 && msgs[0].RoutingKey == task.RoutingKeyUpdated
+		})).Return(nil)
+
+		updated := cmd
+		updated.Title = "Renew passport and ID card"
+		updated.Priority = "urgent"
+		updated.DueDate = nil
+
+		result, err := handler.Handle(ctx, updated)
+
+		require.NoError(t, err)
+		assert.Equal(t, ImportUpdated, result.Outcome)
+		assert.Equal(t, existing.ID(), result.TaskID)
+		assert.Equal(t, "Renew passport and ID card", existing.Title())
+		assert.Equal(t, value_objects.PriorityUrgent, existing.Priority())
+		assert.Nil(t, existing.DueDate())
+		repo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("re-import of an unchanged task saves nothing", func(t *testing.T) {
+		repo := new(mockExternalTaskRepo)
+		handler, outboxRepo, uow := newHandler(repo)
+		existing := importedTask(t)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		repo.On("FindByExternalID", ctx, userID, "todoist:42").Return(existing, nil)
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+
+		result, err := handler.Handle(ctx, cmd)
+
+		require.NoError(t, err)
+		assert.Equal(t, ImportUnchanged, result.Outcome)
+		assert.Equal(t, existing.ID(), result.TaskID)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		outboxRepo.AssertNotCalled(t, "SaveBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("completed tasks are not reopened or changed", func(t *testing.T) {
+		repo := new(mockExternalTaskRepo)
+		handler, _, uow := newHandler(repo)
+		existing := importedTask(t)
+		require.NoError(t, existing.Complete())
+
+		ctx := context.Background()
+		repo.On("FindByExternalID", ctx, userID, "todoist:42").Return(existing, nil)
+
+		updated := cmd
+		updated.Title = "Renamed"
+
+		result, err := handler.Handle(ctx, updated)
+
+		require.NoError(t, err)
+		assert.Equal(t, ImportUnchanged, result.Outcome)
+		assert.Equal(t, "Renew passport", existing.Title())
+		uow.AssertNotCalled(t, "Begin", mock.Anything)
+	})
+
+	t.Run("repositories without external ID lookup always create", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		handler, outboxRepo, uow := newHandler(repo)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("Save", txCtx, mock.AnythingOfType("*task.Task")).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		result, err := handler.Handle(ctx, cmd)
+
+		require.NoError(t, err)
+		assert.Equal(t, ImportCreated, result.Outcome)
+	})
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// ResolveWaitingTaskCommand contains the data needed to stop a task waiting
+// on a person.
+type ResolveWaitingTaskCommand struct {
+	TaskID uuid.UUID
+	UserID uuid.UUID
+	Note   string // How the wait was resolved
+}
+
+// ResolveWaitingTaskHandler handles the ResolveWaitingTaskCommand.
+type ResolveWaitingTaskHandler struct {
+	taskRepo   task.Repository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+}
+
+// NewResolveWaitingTaskHandler creates a new ResolveWaitingTaskHandler.
+func NewResolveWaitingTaskHandler(taskRepo task.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *ResolveWaitingTaskHandler {
+	return &ResolveWaitingTaskHandler{
+		taskRepo:   taskRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// Handle executes the ResolveWaitingTaskCommand.
+func (h *ResolveWaitingTaskHandler) Handle(ctx context.Context, cmd ResolveWaitingTaskCommand) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		// Find the task
+		t, err := h.taskRepo.FindByID(txCtx, cmd.TaskID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return ErrTaskNotFound
+		}
+
+		// Verify ownership
+		if t.UserID() != cmd.UserID {
+			return ErrTaskNotOwned
+		}
+
+		if err := t.ResolveWaiting(cmd.Note); err != nil {
+			return err
+		}
+
+		// Save the task
+		if err := h.taskRepo.Save(txCtx, t); err != nil {
+			return err
+		}
+
+		// Save domain events to outbox
+		events := t.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		return h.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+	return classifyTaskError(err)
+}
@@ -33,16 +33,19 @@ func NewStartTaskHandler(taskRepo task.Repository, outboxRepo outbox.Repository,
 
 // Handle executes the StartTaskCommand.
 func (h *StartTaskHandler) Handle(ctx context.Context, cmd StartTaskCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		// Find the task
 		t, err := h.taskRepo.FindByID(txCtx, cmd.TaskID)
 		if err != nil {
 			return err
 		}
+		if t == nil {
+			return ErrTaskNotFound
+		}
 
 		// Verify ownership
 		if t.UserID() != cmd.UserID {
-			return task.ErrTaskArchived // Use a proper authorization error
+			return ErrTaskNotOwned
 		}
 
 		// Start the task
@@ -73,4 +76,5 @@ func (h *StartTaskHandler) Handle(ctx context.Context, cmd StartTaskCommand) err
 		}
 		return h.outboxRepo.SaveBatch(txCtx, msgs)
 	})
+	return classifyTaskError(err)
 }
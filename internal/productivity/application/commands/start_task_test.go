@@ -82,7 +82,7 @@ func TestStartTaskHandler_Handle(t *testing.T) {
 				UserID: otherUserID,
 			},
 			expectError: true,
-			errorMsg:    "task is archived",
+			errorMsg:    "user does not own this task",
 		},
 		{
 			name: "fails when task is already completed",
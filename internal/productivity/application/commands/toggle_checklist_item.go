@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// ToggleChecklistItemCommand contains the data needed to flip a checklist item's done state.
+type ToggleChecklistItemCommand struct {
+	TaskID uuid.UUID
+	UserID uuid.UUID
+	ItemID uuid.UUID
+}
+
+// ToggleChecklistItemResult contains the item's state after the toggle.
+type ToggleChecklistItemResult struct {
+	Done          bool
+	DoneCount     int
+	TotalCount    int
+	ChecklistDone bool // All items are done
+}
+
+// ToggleChecklistItemHandler handles the ToggleChecklistItemCommand.
+type ToggleChecklistItemHandler struct {
+	taskRepo   task.Repository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+}
+
+// NewToggleChecklistItemHandler creates a new ToggleChecklistItemHandler.
+func NewToggleChecklistItemHandler(taskRepo task.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *ToggleChecklistItemHandler {
+	return &ToggleChecklistItemHandler{
+		taskRepo:   taskRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+	}
+}
+
+// Handle executes the ToggleChecklistItemCommand.
+func (h *ToggleChecklistItemHandler) Handle(ctx context.Context, cmd ToggleChecklistItemCommand) (*ToggleChecklistItemResult, error) {
+	var result *ToggleChecklistItemResult
+
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		// Find the task
+		t, err := h.taskRepo.FindByID(txCtx, cmd.TaskID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return ErrTaskNotFound
+		}
+
+		// Verify ownership
+		if t.UserID() != cmd.UserID {
+			return ErrTaskNotOwned
+		}
+
+		item, err := t.ToggleChecklistItem(cmd.ItemID)
+		if err != nil {
+			return err
+		}
+
+		t.AddDomainEvent(task.NewTaskUpdated(t.ID(), []string{"checklist"}))
+
+		// Save the task
+		if err := h.taskRepo.Save(txCtx, t); err != nil {
+			return err
+		}
+
+		// Save domain events to outbox
+		events := t.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		if err := h.outboxRepo.SaveBatch(txCtx, msgs); err != nil {
+			return err
+		}
+
+		done, total := t.ChecklistProgress()
+		result = &ToggleChecklistItemResult{
+			Done:          item.Done,
+			DoneCount:     done,
+			TotalCount:    total,
+			ChecklistDone: done == total,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyTaskError(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToggleChecklistItemHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	newTaskWithChecklist := func(t *testing.T) (*task.Task, task.ChecklistItem) {
+		existing, err := task.NewTask(userID, "Release")
+		require.NoError(t, err)
+		item, err := existing.AddChecklistItem("Tag version")
+		require.NoError(t, err)
+		_, err = existing.AddChecklistItem("Publish notes")
+		require.NoError(t, err)
+		existing.ClearDomainEvents()
+		return existing, item
+	}
+
+	t.Run("toggles the item and reports progress", func(t *testing.T) {
+		existing, item := newTaskWithChecklist(t)
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+		taskRepo.On("Save", mock.Anything, existing).Return(nil)
+		outboxRepo := new(mockOutboxRepo)
+		outboxRepo.On("SaveBatch", mock.Anything, mock.Anything).Return(nil)
+		handler := NewToggleChecklistItemHandler(taskRepo, outboxRepo, newCommitUnitOfWork())
+
+		result, err := handler.Handle(context.Background(), ToggleChecklistItemCommand{TaskID: existing.ID(), UserID: userID, ItemID: item.ID})
+
+		require.NoError(t, err)
+		assert.Equal(t, &ToggleChecklistItemResult{Done: true, DoneCount: 1, TotalCount: 2}, result)
+		assert.True(t, existing.Checklist()[0].Done)
+		taskRepo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown item is not found", func(t *testing.T) {
+		existing, _ := newTaskWithChecklist(t)
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		_, err := NewToggleChecklistItemHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), ToggleChecklistItemCommand{TaskID: existing.ID(), UserID: userID, ItemID: uuid.New()})
+
+		assert.ErrorIs(t, err, task.ErrChecklistItemNotFound)
+		assert.ErrorIs(t, err, sharedApplication.ErrNotFound)
+	})
+
+	t.Run("completed task conflicts", func(t *testing.T) {
+		existing, item := newTaskWithChecklist(t)
+		require.NoError(t, existing.Complete())
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		_, err := NewToggleChecklistItemHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), ToggleChecklistItemCommand{TaskID: existing.ID(), UserID: userID, ItemID: item.ID})
+
+		assert.ErrorIs(t, err, task.ErrTaskAlreadyComplete)
+		assert.ErrorIs(t, err, sharedApplication.ErrConflict)
+	})
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnblockTaskHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("clears the block", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Ship release")
+		require.NoError(t, err)
+		require.NoError(t, existing.Block("Waiting on PR #42"))
+		existing.ClearDomainEvents()
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+		taskRepo.On("Save", mock.Anything, existing).Return(nil)
+		outboxRepo := new(mockOutboxRepo)
+		outboxRepo.On("SaveBatch", mock.Anything, mock.Anything).Return(nil)
+
+		err = NewUnblockTaskHandler(taskRepo, outboxRepo, newCommitUnitOfWork()).
+			Handle(context.Background(), UnblockTaskCommand{TaskID: existing.ID(), UserID: userID, Note: "PR merged"})
+
+		require.NoError(t, err)
+		assert.Equal(t, task.StatusPending, existing.Status())
+		assert.Empty(t, existing.BlockedReason())
+		taskRepo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("task that is not blocked conflicts", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Free to go")
+		require.NoError(t, err)
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		err = NewUnblockTaskHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), UnblockTaskCommand{TaskID: existing.ID(), UserID: userID})
+
+		assert.ErrorIs(t, err, task.ErrTaskNotBlocked)
+		assert.ErrorIs(t, err, sharedApplication.ErrConflict)
+	})
+}
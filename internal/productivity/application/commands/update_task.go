@@ -13,14 +13,17 @@ import (
 
 // UpdateTaskCommand contains the data needed to update a task.
 type UpdateTaskCommand struct {
-	TaskID          uuid.UUID
-	UserID          uuid.UUID
-	Title           *string    // nil means no change
-	Description     *string    // nil means no change
-	Priority        *string    // nil means no change
-	DurationMinutes *int       // nil means no change
-	DueDate         *time.Time // nil means no change
-	ClearDueDate    bool       // if true, clears the due date
+	TaskID             uuid.UUID
+	UserID             uuid.UUID
+	Title              *string    // nil means no change
+	Description        *string    // nil means no change
+	Priority           *string    // nil means no change
+	DurationMinutes    *int       // nil means no change
+	DueDate            *time.Time // nil means no change
+	ClearDueDate       bool       // if true, clears the due date
+	EarliestStart      *time.Time // nil means no change
+	ClearEarliestStart bool       // if true, clears the earliest start
+	RequireChecklist   *bool      // nil means no change; true blocks completion until all checklist items are done
 }
 
 // UpdateTaskHandler handles the UpdateTaskCommand.
@@ -41,16 +44,19 @@ func NewUpdateTaskHandler(taskRepo task.Repository, outboxRepo outbox.Repository
 
 // Handle executes the UpdateTaskCommand.
 func (h *UpdateTaskHandler) Handle(ctx context.Context, cmd UpdateTaskCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		// Find the task
 		t, err := h.taskRepo.FindByID(txCtx, cmd.TaskID)
 		if err != nil {
 			return err
 		}
+		if t == nil {
+			return ErrTaskNotFound
+		}
 
 		// Verify ownership
 		if t.UserID() != cmd.UserID {
-			return task.ErrTaskArchived // Use a proper authorization error
+			return ErrTaskNotOwned
 		}
 
 		// Track which fields were updated
@@ -96,6 +102,15 @@ func (h *UpdateTaskHandler) Handle(ctx context.Context, cmd UpdateTaskCommand) e
 			updatedFields = append(updatedFields, "duration")
 		}
 
+		// Drop a changing earliest start first so it cannot conflict with
+		// the new due date; the new one is set below.
+		changeEarliestStart := cmd.ClearEarliestStart || cmd.EarliestStart != nil
+		if changeEarliestStart {
+			if err := t.SetEarliestStart(nil); err != nil {
+				return err
+			}
+		}
+
 		// Update due date if provided or clear it
 		if cmd.ClearDueDate {
 			if err := t.SetDueDate(nil); err != nil {
@@ -109,6 +124,24 @@ func (h *UpdateTaskHandler) Handle(ctx context.Context, cmd UpdateTaskCommand) e
 			updatedFields = append(updatedFields, "due_date")
 		}
 
+		// Update earliest start if provided or clear it
+		if changeEarliestStart {
+			if !cmd.ClearEarliestStart {
+				if err := t.SetEarliestStart(cmd.EarliestStart); err != nil {
+					return err
+				}
+			}
+			updatedFields = append(updatedFields, "earliest_start")
+		}
+
+		// Update the checklist completion requirement if provided
+		if cmd.RequireChecklist != nil {
+			if err := t.SetChecklistRequired(*cmd.RequireChecklist); err != nil {
+				return err
+			}
+			updatedFields = append(updatedFields, "checklist_required")
+		}
+
 		// No changes to save
 		if len(updatedFields) == 0 {
 			return nil
@@ -136,4 +169,5 @@ func (h *UpdateTaskHandler) Handle(ctx context.Context, cmd UpdateTaskCommand) e
 		}
 		return h.outboxRepo.SaveBatch(txCtx, msgs)
 	})
+	return classifyTaskError(err)
 }
@@ -69,6 +69,23 @@ func TestUpdateTaskHandler_Handle(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "successfully requires the checklist",
+			setupMocks: func(taskRepo *MockTaskRepository, outboxRepo *MockOutboxRepository, uow *MockUnitOfWork, existingTask *task.Task) {
+				uow.On("Begin", mock.Anything).Return(context.Background(), nil)
+				uow.On("Commit", mock.Anything).Return(nil)
+				taskRepo.On("FindByID", mock.Anything, existingTask.ID()).Return(existingTask, nil)
+				taskRepo.On("Save", mock.Anything, mock.MatchedBy(func(t *task.Task) bool {
+					return t.ChecklistRequired()
+				})).Return(nil)
+				outboxRepo.On("SaveBatch", mock.Anything, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+			},
+			cmd: UpdateTaskCommand{
+				UserID:           userID,
+				RequireChecklist: boolPtr(true),
+			},
+			expectError: false,
+		},
 		{
 			name: "successfully updates duration",
 			setupMocks: func(taskRepo *MockTaskRepository, outboxRepo *MockOutboxRepository, uow *MockUnitOfWork, existingTask *task.Task) {
@@ -175,7 +192,7 @@ func TestUpdateTaskHandler_Handle(t *testing.T) {
 				Title:  stringPtr("Updated Title"),
 			},
 			expectError: true,
-			errorMsg:    "task is archived",
+			errorMsg:    "user does not own this task",
 		},
 		{
 			name: "fails with invalid priority",
@@ -288,3 +305,7 @@ func intPtr(i int) *int {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
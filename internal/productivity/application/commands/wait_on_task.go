@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// WaitOnTaskCommand contains the data needed to mark a task as waiting on a
+// person.
+type WaitOnTaskCommand struct {
+	TaskID    uuid.UUID
+	UserID    uuid.UUID
+	WaitingOn string        // Person or contact the work was delegated to
+	FollowUp  time.Duration // Delay before the follow-up reminder; zero uses the handler default
+}
+
+// WaitOnTaskHandler handles the WaitOnTaskCommand.
+type WaitOnTaskHandler struct {
+	taskRepo      task.Repository
+	outboxRepo    outbox.Repository
+	uow           sharedApplication.UnitOfWork
+	followUpDelay time.Duration
+}
+
+// NewWaitOnTaskHandler creates a new WaitOnTaskHandler.
+func NewWaitOnTaskHandler(taskRepo task.Repository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *WaitOnTaskHandler {
+	return &WaitOnTaskHandler{
+		taskRepo:      taskRepo,
+		outboxRepo:    outboxRepo,
+		uow:           uow,
+		followUpDelay: task.DefaultFollowUpDelay,
+	}
+}
+
+// WithFollowUpDelay sets the follow-up delay used when a command does not
+// give one. Non-positive values keep the current delay.
+func (h *WaitOnTaskHandler) WithFollowUpDelay(delay time.Duration) *WaitOnTaskHandler {
+	if delay > 0 {
+		h.followUpDelay = delay
+	}
+	return h
+}
+
+// Handle executes the WaitOnTaskCommand.
+func (h *WaitOnTaskHandler) Handle(ctx context.Context, cmd WaitOnTaskCommand) error {
+	followUp := cmd.FollowUp
+	if followUp == 0 {
+		followUp = h.followUpDelay
+	}
+
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		// Find the task
+		t, err := h.taskRepo.FindByID(txCtx, cmd.TaskID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return ErrTaskNotFound
+		}
+
+		// Verify ownership
+		if t.UserID() != cmd.UserID {
+			return ErrTaskNotOwned
+		}
+
+		if err := t.WaitOn(cmd.WaitingOn, followUp); err != nil {
+			return err
+		}
+
+		// Save the task
+		if err := h.taskRepo.Save(txCtx, t); err != nil {
+			return err
+		}
+
+		// Save domain events to outbox
+		events := t.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		return h.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+	return classifyTaskError(err)
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitOnTaskHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("waits on the person with the default follow-up", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Contract review")
+		require.NoError(t, err)
+		existing.ClearDomainEvents()
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+		taskRepo.On("Save", mock.Anything, existing).Return(nil)
+		outboxRepo := new(mockOutboxRepo)
+		outboxRepo.On("SaveBatch", mock.Anything, mock.Anything).Return(nil)
+
+		err = NewWaitOnTaskHandler(taskRepo, outboxRepo, newCommitUnitOfWork()).
+			WithFollowUpDelay(24*time.Hour).
+			Handle(context.Background(), WaitOnTaskCommand{TaskID: existing.ID(), UserID: userID, WaitingOn: "Alice"})
+
+		require.NoError(t, err)
+		assert.True(t, existing.IsWaiting())
+		assert.Equal(t, "Alice", existing.WaitingOn())
+		assert.Equal(t, existing.WaitingSince().Add(24*time.Hour), *existing.FollowUpAt())
+		taskRepo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("uses the follow-up given in the command", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Contract review")
+		require.NoError(t, err)
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+		taskRepo.On("Save", mock.Anything, existing).Return(nil)
+		outboxRepo := new(mockOutboxRepo)
+		outboxRepo.On("SaveBatch", mock.Anything, mock.Anything).Return(nil)
+
+		err = NewWaitOnTaskHandler(taskRepo, outboxRepo, newCommitUnitOfWork()).
+			Handle(context.Background(), WaitOnTaskCommand{TaskID: existing.ID(), UserID: userID, WaitingOn: "Alice", FollowUp: 2 * time.Hour})
+
+		require.NoError(t, err)
+		assert.Equal(t, existing.WaitingSince().Add(2*time.Hour), *existing.FollowUpAt())
+	})
+
+	t.Run("missing person is invalid", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Contract review")
+		require.NoError(t, err)
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		err = NewWaitOnTaskHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), WaitOnTaskCommand{TaskID: existing.ID(), UserID: userID})
+
+		assert.ErrorIs(t, err, task.ErrEmptyWaitingOn)
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+	})
+}
+
+func TestResolveWaitingTaskHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("returns the task to pending", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Contract review")
+		require.NoError(t, err)
+		require.NoError(t, existing.WaitOn("Alice", time.Hour))
+		existing.ClearDomainEvents()
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+		taskRepo.On("Save", mock.Anything, existing).Return(nil)
+		outboxRepo := new(mockOutboxRepo)
+		outboxRepo.On("SaveBatch", mock.Anything, mock.Anything).Return(nil)
+
+		err = NewResolveWaitingTaskHandler(taskRepo, outboxRepo, newCommitUnitOfWork()).
+			Handle(context.Background(), ResolveWaitingTaskCommand{TaskID: existing.ID(), UserID: userID, Note: "Signed"})
+
+		require.NoError(t, err)
+		assert.Equal(t, task.StatusPending, existing.Status())
+		assert.Empty(t, existing.WaitingOn())
+		taskRepo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("task that is not waiting conflicts", func(t *testing.T) {
+		existing, err := task.NewTask(userID, "Free to go")
+		require.NoError(t, err)
+		taskRepo := new(mockTaskRepo)
+		taskRepo.On("FindByID", mock.Anything, existing.ID()).Return(existing, nil)
+
+		err = NewResolveWaitingTaskHandler(taskRepo, new(mockOutboxRepo), newRollbackUnitOfWork()).
+			Handle(context.Background(), ResolveWaitingTaskCommand{TaskID: existing.ID(), UserID: userID})
+
+		assert.ErrorIs(t, err, task.ErrTaskNotWaiting)
+		assert.ErrorIs(t, err, sharedApplication.ErrConflict)
+	})
+}
@@ -42,17 +42,7 @@ func (h *GetTaskHandler) Handle(ctx context.Context, query GetTaskQuery) (*TaskD
 		return nil, ErrTaskNotFound
 	}
 
-	dto := TaskDTO{
-		ID:              t.ID(),
-		Title:           t.Title(),
-		Description:     t.Description(),
-		Status:          t.Status().String(),
-		Priority:        t.Priority().String(),
-		DurationMinutes: t.Duration().Minutes(),
-		DueDate:         t.DueDate(),
-		CompletedAt:     t.CompletedAt(),
-		CreatedAt:       t.CreatedAt(),
-	}
+	dto := toTaskDTO(t)
 
 	return &dto, nil
 }
@@ -0,0 +1,114 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/google/uuid"
+)
+
+// TaskStatsDTO holds aggregated task counts for a user.
+type TaskStatsDTO struct {
+	Total          int
+	Pending        int
+	InProgress     int
+	Completed      int
+	Archived       int
+	Blocked        int
+	Waiting        int
+	Urgent         int
+	High           int
+	Medium         int
+	Low            int
+	Overdue        int
+	CompletionRate float64 // Percentage of tasks completed (0-100)
+}
+
+// GetTaskStatsQuery contains the parameters for aggregating task statistics.
+type GetTaskStatsQuery struct {
+	UserID uuid.UUID
+	Now    time.Time // Reference time for overdue checks; defaults to time.Now()
+}
+
+// GetTaskStatsHandler handles the GetTaskStatsQuery.
+type GetTaskStatsHandler struct {
+	taskRepo task.Repository
+}
+
+// NewGetTaskStatsHandler creates a new GetTaskStatsHandler.
+func NewGetTaskStatsHandler(taskRepo task.Repository) *GetTaskStatsHandler {
+	return &GetTaskStatsHandler{taskRepo: taskRepo}
+}
+
+// Handle executes the GetTaskStatsQuery.
+// Tasks are streamed when the repository implements task.Iterator, so memory
+// use does not grow with the number of tasks; otherwise all tasks are loaded.
+func (h *GetTaskStatsHandler) Handle(ctx context.Context, query GetTaskStatsQuery) (*TaskStatsDTO, error) {
+	now := query.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	stats := &TaskStatsDTO{}
+	add := func(t *task.Task) error {
+		stats.add(t, today)
+		return nil
+	}
+
+	if iter, ok := h.taskRepo.(task.Iterator); ok {
+		if err := iter.IterateTasks(ctx, query.UserID, add); err != nil {
+			return nil, err
+		}
+	} else {
+		tasks, err := h.taskRepo.FindByUserID(ctx, query.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			_ = add(t)
+		}
+	}
+
+	if stats.Total > 0 {
+		stats.CompletionRate = float64(stats.Completed) / float64(stats.Total) * 100
+	}
+	return stats, nil
+}
+
+func (s *TaskStatsDTO) add(t *task.Task, today time.Time) {
+	s.Total++
+
+	status := t.Status()
+	switch status {
+	case task.StatusPending:
+		s.Pending++
+	case task.StatusInProgress:
+		s.InProgress++
+	case task.StatusCompleted:
+		s.Completed++
+	case task.StatusArchived:
+		s.Archived++
+	case task.StatusBlocked:
+		s.Blocked++
+	case task.StatusWaiting:
+		s.Waiting++
+	}
+
+	switch t.Priority().String() {
+	case "urgent":
+		s.Urgent++
+	case "high":
+		s.High++
+	case "medium":
+		s.Medium++
+	case "low":
+		s.Low++
+	}
+
+	if due := t.DueDate(); due != nil && due.Before(today) &&
+		status != task.StatusCompleted && status != task.StatusArchived {
+		s.Overdue++
+	}
+}
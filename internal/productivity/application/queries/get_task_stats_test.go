@@ -0,0 +1,121 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// iteratingTaskRepo streams tasks through task.Iterator; FindByUserID is not
+// expected on the embedded mock, so any fallback to it fails the test.
+type iteratingTaskRepo struct {
+	mockTaskRepo
+	tasks []*task.Task
+	err   error
+}
+
+func (r *iteratingTaskRepo) IterateTasks(ctx context.Context, userID uuid.UUID, fn func(*task.Task) error) error {
+	for _, t := range r.tasks {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return r.err
+}
+
+func buildStatsFixture(t *testing.T, userID uuid.UUID, now time.Time) []*task.Task {
+	t.Helper()
+	yesterday := now.AddDate(0, 0, -1)
+	tomorrow := now.AddDate(0, 0, 1)
+
+	newTask := func(title string, priority value_objects.Priority, due *time.Time) *task.Task {
+		tk := createTestTask(userID, title)
+		require.NoError(t, tk.SetPriority(priority))
+		if due != nil {
+			require.NoError(t, tk.SetDueDate(due))
+		}
+		return tk
+	}
+
+	overdue := newTask("Overdue", value_objects.PriorityUrgent, &yesterday)
+	upcoming := newTask("Upcoming", value_objects.PriorityHigh, &tomorrow)
+	started := newTask("Started", value_objects.PriorityHigh, &yesterday)
+	require.NoError(t, started.Start())
+	done := newTask("Done", value_objects.PriorityLow, &yesterday)
+	require.NoError(t, done.Complete())
+	archived := newTask("Archived", value_objects.PriorityMedium, &yesterday)
+	require.NoError(t, archived.Archive())
+
+	return []*task.Task{overdue, upcoming, started, done, archived}
+}
+
+func TestGetTaskStatsHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	tasks := buildStatsFixture(t, userID, now)
+
+	expected := &TaskStatsDTO{
+		Total:          5,
+		Pending:        2,
+		InProgress:     1,
+		Completed:      1,
+		Archived:       1,
+		Urgent:         1,
+		High:           2,
+		Medium:         1,
+		Low:            1,
+		Overdue:        2,
+		CompletionRate: 20,
+	}
+
+	t.Run("streams tasks from an iterating repository", func(t *testing.T) {
+		repo := &iteratingTaskRepo{tasks: tasks}
+		handler := NewGetTaskStatsHandler(repo)
+
+		stats, err := handler.Handle(context.Background(), GetTaskStatsQuery{UserID: userID, Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, stats)
+		repo.AssertNotCalled(t, "FindByUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("matches the in-memory path", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		repo.On("FindByUserID", mock.Anything, userID).Return(tasks, nil)
+		inMemory, err := NewGetTaskStatsHandler(repo).Handle(context.Background(), GetTaskStatsQuery{UserID: userID, Now: now})
+		require.NoError(t, err)
+
+		streamed, err := NewGetTaskStatsHandler(&iteratingTaskRepo{tasks: tasks}).Handle(context.Background(), GetTaskStatsQuery{UserID: userID, Now: now})
+		require.NoError(t, err)
+
+		assert.Equal(t, inMemory, streamed)
+		assert.Equal(t, expected, inMemory)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("returns empty stats for a user without tasks", func(t *testing.T) {
+		handler := NewGetTaskStatsHandler(&iteratingTaskRepo{})
+
+		stats, err := handler.Handle(context.Background(), GetTaskStatsQuery{UserID: userID, Now: now})
+
+		require.NoError(t, err)
+		assert.Equal(t, &TaskStatsDTO{}, stats)
+	})
+
+	t.Run("returns iteration errors", func(t *testing.T) {
+		handler := NewGetTaskStatsHandler(&iteratingTaskRepo{tasks: tasks, err: errors.New("connection lost")})
+
+		stats, err := handler.Handle(context.Background(), GetTaskStatsQuery{UserID: userID, Now: now})
+
+		assert.Error(t, err)
+		assert.Nil(t, stats)
+	})
+}
@@ -102,6 +102,31 @@ func TestGetTaskHandler_Handle(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("returns checklist progress", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		handler := NewGetTaskHandler(repo)
+
+		existingTask, _ := task.NewTask(userID, "Release")
+		first, err := existingTask.AddChecklistItem("Tag version")
+		require.NoError(t, err)
+		_, err = existingTask.AddChecklistItem("Publish notes")
+		require.NoError(t, err)
+		_, err = existingTask.ToggleChecklistItem(first.ID)
+		require.NoError(t, err)
+		require.NoError(t, existingTask.SetChecklistRequired(true))
+
+		repo.On("FindByID", mock.Anything, taskID).Return(existingTask, nil)
+
+		result, err := handler.Handle(context.Background(), GetTaskQuery{TaskID: taskID, UserID: userID})
+
+		require.NoError(t, err)
+		require.Len(t, result.Checklist, 2)
+		assert.Equal(t, ChecklistItemDTO{ID: first.ID, Title: "Tag version", Done: true}, result.Checklist[0])
+		assert.False(t, result.Checklist[1].Done)
+		assert.Equal(t, 1, result.ChecklistDone)
+		assert.True(t, result.ChecklistRequired)
+	})
+
 	t.Run("returns ErrTaskNotFound when task is nil", func(t *testing.T) {
 		repo := new(mockTaskRepo)
 		handler := NewGetTaskHandler(repo)
@@ -1,10 +1,17 @@
 package queries
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
 
@@ -19,39 +26,134 @@ type TaskDTO struct {
 	DueDate         *time.Time
 	CompletedAt     *time.Time
 	CreatedAt       time.Time
+	Tags            []string
+
+	Checklist         []ChecklistItemDTO
+	ChecklistDone     int  // Number of checklist items done
+	ChecklistRequired bool // Completion requires every checklist item to be done
+
+	BlockedReason string     // What a blocked task is waiting on
+	BlockedAt     *time.Time // When the task was blocked
+
+	WaitingOn    string     // Who a waiting task is waiting on
+	WaitingSince *time.Time // When the task started waiting
+	FollowUpAt   *time.Time // When the follow-up reminder fires
+
+	Timezone string // IANA time zone of the due date, empty for the user's
+
+	EarliestStart *time.Time // Not scheduled before this time
+
+	Recurrence string // Repeat rule such as "weekly", empty for one-shot tasks
+}
+
+// ChecklistItemDTO is a data transfer object for a task checklist item.
+type ChecklistItemDTO struct {
+	ID    uuid.UUID
+	Title string
+	Done  bool
 }
 
 // ListTasksQuery contains the parameters for listing tasks.
 type ListTasksQuery struct {
 	UserID     uuid.UUID
-	Status     string // "all", "pending", "completed", "archived"
+	Status     string // "all", "pending", "completed", "archived", "blocked", "waiting"
 	IncludeAll bool
-	Priority   string     // Filter by priority: "urgent", "high", "medium", "low"
-	DueBefore  *time.Time // Tasks due before this date
-	DueAfter   *time.Time // Tasks due after this date
-	Overdue    bool       // Only show overdue tasks
-	DueToday   bool       // Only show tasks due today
-	SortBy     string     // "priority", "due_date", "created_at"
-	SortOrder  string     // "asc", "desc"
-	Limit      int        // Max number of tasks to return (0 = no limit)
+	Priority   string       // Filter by priority: "urgent", "high", "medium", "low"
+	DueBefore  *time.Time   // Tasks due before this date
+	DueAfter   *time.Time   // Tasks due after this date
+	Overdue    bool         // Only show overdue tasks
+	DueToday   bool         // Only show tasks due today
+	Tags       []string     // Only show tasks with these tags
+	TagMatch   TagMatchMode // "all" (default) or "any" of Tags
+	SortBy     string       // "priority", "due_date", "created_at", "title", "score", or fields such as "priority:desc,due_date:asc"
+	SortOrder  string       // "asc", "desc"; applies to every sort field
+	Limit      int          // Max number of tasks to return (0 = no limit)
+}
+
+// TagMatchMode decides whether a task must carry every tag of a tag filter
+// or any one of them.
+type TagMatchMode string
+
+// Tag match modes. The zero value matches all tags.
+const (
+	TagMatchAll TagMatchMode = "all"
+	TagMatchAny TagMatchMode = "any"
+)
+
+// DefaultTaskSort orders task lists for users without a default of their own.
+const DefaultTaskSort = "priority:desc,due_date:asc"
+
+// taskSortFields lists the fields tasks can be sorted by.
+var taskSortFields = []string{"priority", "due_date", "created_at", "title", "score"}
+
+// SortDefaults provides a user's default list sort order.
+type SortDefaults interface {
+	// ListSort returns the sort order of the entity's lists, such as
+	// "priority:desc,due_date:asc" for "tasks".
+	ListSort(ctx context.Context, userID uuid.UUID, entity string) (string, error)
+}
+
+// PriorityScorer scores tasks with the priority engine, evaluating them in
+// parallel.
+type PriorityScorer interface {
+	ExecuteParallelPriority(ctx context.Context, engineID string, userID uuid.UUID, inputs []types.PriorityInput) ([]types.PriorityOutput, error)
 }
 
 // ListTasksHandler handles the ListTasksQuery.
 type ListTasksHandler struct {
-	taskRepo task.Repository
+	taskRepo     task.Repository
+	sortDefaults SortDefaults
+	scorer       PriorityScorer
+	clock        sharedDomain.Clock
 }
 
 // NewListTasksHandler creates a new ListTasksHandler.
 func NewListTasksHandler(taskRepo task.Repository) *ListTasksHandler {
-	return &ListTasksHandler{taskRepo: taskRepo}
+	return &ListTasksHandler{taskRepo: taskRepo, clock: sharedDomain.SystemClock}
+}
+
+// WithClock sets the clock the overdue and due today filters are evaluated
+// against.
+func (h *ListTasksHandler) WithClock(clock sharedDomain.Clock) *ListTasksHandler {
+	h.clock = clock
+	return h
+}
+
+// WithSortDefaults sorts lists without a SortBy in the user's default order
+// instead of DefaultTaskSort.
+func (h *ListTasksHandler) WithSortDefaults(defaults SortDefaults) *ListTasksHandler {
+	h.sortDefaults = defaults
+	return h
+}
+
+// WithPriorityScorer sets the priority engine the "score" sort field ranks
+// tasks by. Without one, "score" sorts by priority.
+func (h *ListTasksHandler) WithPriorityScorer(scorer PriorityScorer) *ListTasksHandler {
+	h.scorer = scorer
+	return h
 }
 
 // Handle executes the ListTasksQuery.
 func (h *ListTasksHandler) Handle(ctx context.Context, query ListTasksQuery) ([]TaskDTO, error) {
+	sortKeys, err := h.sortKeys(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := sharedDomain.NormalizeTags(query.Tags)
+	if err != nil {
+		return nil, sharedApplication.Validation(err)
+	}
+	matchAny, err := parseTagMatch(query.TagMatch)
+	if err != nil {
+		return nil, err
+	}
+
 	var tasks []*task.Task
-	var err error
 
-	if query.IncludeAll || query.Status == "all" {
+	// Blocked and waiting tasks are not pending work, so they are only found
+	// among all tasks.
+	if query.IncludeAll || query.Status == "all" ||
+		query.Status == task.StatusBlocked.String() || query.Status == task.StatusWaiting.String() {
 		tasks, err = h.taskRepo.FindByUserID(ctx, query.UserID)
 	} else {
 		tasks, err = h.taskRepo.FindPending(ctx, query.UserID)
@@ -71,8 +173,13 @@ func (h *ListTasksHandler) Handle(ctx context.Context, query ListTasksQuery) ([]
 		tasks = filterByPriority(tasks, query.Priority)
 	}
 
+	// Filter by tags
+	if len(tags) > 0 {
+		tasks = filterByTags(tasks, tags, matchAny)
+	}
+
 	// Filter by due date
-	now := time.Now()
+	now := h.clock()
 	if query.Overdue {
 		tasks = filterOverdue(tasks, now)
 	}
@@ -87,7 +194,7 @@ func (h *ListTasksHandler) Handle(ctx context.Context, query ListTasksQuery) ([]
 	}
 
 	// Sort tasks
-	tasks = sortTasks(tasks, query.SortBy, query.SortOrder)
+	tasks = sortTasks(tasks, sortKeys, h.scores(ctx, query.UserID, tasks, sortKeys))
 
 	// Apply limit
 	if query.Limit > 0 && len(tasks) > query.Limit {
@@ -117,11 +224,39 @@ func filterByPriority(tasks []*task.Task, priority string) []*task.Task {
 	return filtered
 }
 
+// parseTagMatch reports whether a tag filter matches tasks with any of its
+// tags rather than all of them.
+func parseTagMatch(mode TagMatchMode) (bool, error) {
+	switch TagMatchMode(strings.ToLower(string(mode))) {
+	case "", TagMatchAll:
+		return false, nil
+	case TagMatchAny:
+		return true, nil
+	default:
+		return false, sharedApplication.NewValidationError(fmt.Sprintf("unknown tag match mode %q (use all or any)", mode))
+	}
+}
+
+func filterByTags(tasks []*task.Task, tags []string, matchAny bool) []*task.Task {
+	var filtered []*task.Task
+	for _, t := range tasks {
+		matched := 0
+		for _, tag := range tags {
+			if t.HasTag(tag) {
+				matched++
+			}
+		}
+		if (matchAny && matched > 0) || matched == len(tags) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
 func filterOverdue(tasks []*task.Task, now time.Time) []*task.Task {
 	var filtered []*task.Task
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	for _, t := range tasks {
-		if t.DueDate() != nil && t.DueDate().Before(today) && t.Status().String() != "completed" {
+		if t.IsOverdue(now) {
 			filtered = append(filtered, t)
 		}
 	}
@@ -131,11 +266,8 @@ func filterOverdue(tasks []*task.Task, now time.Time) []*task.Task {
 func filterDueToday(tasks []*task.Task, now time.Time) []*task.Task {
 	var filtered []*task.Task
 	for _, t := range tasks {
-		if t.DueDate() != nil {
-			due := *t.DueDate()
-			if due.Year() == now.Year() && due.Month() == now.Month() && due.Day() == now.Day() {
-				filtered = append(filtered, t)
-			}
+		if t.IsDueToday(now) {
+			filtered = append(filtered, t)
 		}
 	}
 	return filtered
@@ -161,83 +293,171 @@ func filterDueAfter(tasks []*task.Task, after time.Time) []*task.Task {
 	return filtered
 }
 
-func sortTasks(tasks []*task.Task, sortBy, sortOrder string) []*task.Task {
-	if sortBy == "" {
-		sortBy = "priority" // Default sort
+// sortKeys resolves the order of the list: the query's SortBy, or else the
+// user's default. A SortOrder sets the direction of every field.
+func (h *ListTasksHandler) sortKeys(ctx context.Context, query ListTasksQuery) ([]sharedApplication.SortKey, error) {
+	keys := h.defaultSortKeys(ctx, query.UserID)
+	if query.SortBy != "" {
+		var err error
+		keys, err = sharedApplication.ParseSort(query.SortBy, taskSortFields)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sharedApplication.ApplySortOrder(keys, query.SortOrder)
+}
+
+// defaultSortKeys returns the user's default order. A failed lookup or an
+// order that no longer parses falls back to DefaultTaskSort.
+func (h *ListTasksHandler) defaultSortKeys(ctx context.Context, userID uuid.UUID) []sharedApplication.SortKey {
+	if h.sortDefaults != nil {
+		if spec, err := h.sortDefaults.ListSort(ctx, userID, "tasks"); err == nil {
+			if keys, err := sharedApplication.ParseSort(spec, taskSortFields); err == nil {
+				return keys
+			}
+		}
 	}
-	if sortOrder == "" {
-		sortOrder = "desc" // Default order (high priority first)
+	keys, _ := sharedApplication.ParseSort(DefaultTaskSort, taskSortFields)
+	return keys
+}
+
+// scores returns the priority engine score of each task when the list is
+// sorted by score. Without a scorer, or when the engine fails, it returns nil
+// and score sorts by priority.
+func (h *ListTasksHandler) scores(ctx context.Context, userID uuid.UUID, tasks []*task.Task, keys []sharedApplication.SortKey) map[uuid.UUID]float64 {
+	if h.scorer == nil || len(tasks) == 0 || !hasSortField(keys, "score") {
+		return nil
 	}
 
+	inputs := make([]types.PriorityInput, len(tasks))
+	for i, t := range tasks {
+		inputs[i] = types.PriorityInput{
+			ID:        t.ID(),
+			Priority:  t.Priority().EngineLevel(),
+			DueDate:   t.DueDate(),
+			Duration:  t.Duration().Value(),
+			CreatedAt: t.CreatedAt(),
+			Tags:      t.Tags(),
+		}
+	}
+	outputs, err := h.scorer.ExecuteParallelPriority(ctx, "", userID, inputs)
+	if err != nil {
+		return nil
+	}
+
+	scores := make(map[uuid.UUID]float64, len(outputs))
+	for _, output := range outputs {
+		scores[output.ID] = output.Score
+	}
+	return scores
+}
+
+func hasSortField(keys []sharedApplication.SortKey, field string) bool {
+	for _, key := range keys {
+		if key.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// priorityOrder ranks priorities, the most urgent highest.
+var priorityOrder = map[string]int{
+	"urgent": 4,
+	"high":   3,
+	"medium": 2,
+	"low":    1,
+}
+
+func sortTasks(tasks []*task.Task, keys []sharedApplication.SortKey, scores map[uuid.UUID]float64) []*task.Task {
 	// Create a copy to avoid modifying the original slice
 	sorted := make([]*task.Task, len(tasks))
 	copy(sorted, tasks)
 
-	priorityOrder := map[string]int{
-		"urgent": 4,
-		"high":   3,
-		"medium": 2,
-		"low":    1,
-	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		for _, key := range keys {
+			if c := compareTasks(sorted[i], sorted[j], key, scores); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return sorted
+}
 
-	switch sortBy {
+// compareTasks compares two tasks by one sort key. Tasks without a due date
+// go to the end in either direction. Without scores, score compares
+// priorities.
+func compareTasks(a, b *task.Task, key sharedApplication.SortKey, scores map[uuid.UUID]float64) int {
+	var c int
+	switch key.Field {
 	case "priority":
-		for i := 0; i < len(sorted)-1; i++ {
-			for j := i + 1; j < len(sorted); j++ {
-				pi := priorityOrder[sorted[i].Priority().String()]
-				pj := priorityOrder[sorted[j].Priority().String()]
-				shouldSwap := (sortOrder == "desc" && pi < pj) || (sortOrder == "asc" && pi > pj)
-				if shouldSwap {
-					sorted[i], sorted[j] = sorted[j], sorted[i]
-				}
-			}
+		c = priorityOrder[a.Priority().String()] - priorityOrder[b.Priority().String()]
+	case "score":
+		if scores == nil {
+			c = priorityOrder[a.Priority().String()] - priorityOrder[b.Priority().String()]
+			break
 		}
+		c = cmp.Compare(scores[a.ID()], scores[b.ID()])
 	case "due_date":
-		for i := 0; i < len(sorted)-1; i++ {
-			for j := i + 1; j < len(sorted); j++ {
-				di := sorted[i].DueDate()
-				dj := sorted[j].DueDate()
-				// Nil due dates go to the end
-				if di == nil && dj != nil {
-					sorted[i], sorted[j] = sorted[j], sorted[i]
-				} else if di != nil && dj != nil {
-					shouldSwap := (sortOrder == "asc" && di.After(*dj)) || (sortOrder == "desc" && di.Before(*dj))
-					if shouldSwap {
-						sorted[i], sorted[j] = sorted[j], sorted[i]
-					}
-				}
-			}
+		da, db := a.DueDate(), b.DueDate()
+		switch {
+		case da == nil && db == nil:
+			return 0
+		case da == nil:
+			return 1
+		case db == nil:
+			return -1
 		}
+		c = da.Compare(*db)
 	case "created_at":
-		for i := 0; i < len(sorted)-1; i++ {
-			for j := i + 1; j < len(sorted); j++ {
-				ci := sorted[i].CreatedAt()
-				cj := sorted[j].CreatedAt()
-				shouldSwap := (sortOrder == "asc" && ci.After(cj)) || (sortOrder == "desc" && ci.Before(cj))
-				if shouldSwap {
-					sorted[i], sorted[j] = sorted[j], sorted[i]
-				}
-			}
-		}
+		c = a.CreatedAt().Compare(b.CreatedAt())
+	case "title":
+		c = strings.Compare(strings.ToLower(a.Title()), strings.ToLower(b.Title()))
 	}
-
-	return sorted
+	if key.Order == sharedApplication.SortDesc {
+		return -c
+	}
+	return c
 }
 
 func toTaskDTOs(tasks []*task.Task) []TaskDTO {
 	dtos := make([]TaskDTO, len(tasks))
 	for i, t := range tasks {
-		dtos[i] = TaskDTO{
-			ID:              t.ID(),
-			Title:           t.Title(),
-			Description:     t.Description(),
-			Status:          t.Status().String(),
-			Priority:        t.Priority().String(),
-			DurationMinutes: t.Duration().Minutes(),
-			DueDate:         t.DueDate(),
-			CompletedAt:     t.CompletedAt(),
-			CreatedAt:       t.CreatedAt(),
-		}
+		dtos[i] = toTaskDTO(t)
 	}
 	return dtos
 }
+
+func toTaskDTO(t *task.Task) TaskDTO {
+	dto := TaskDTO{
+		ID:                t.ID(),
+		Title:             t.Title(),
+		Description:       t.Description(),
+		Status:            t.Status().String(),
+		Priority:          t.Priority().String(),
+		DurationMinutes:   t.Duration().Minutes(),
+		DueDate:           t.DueDate(),
+		CompletedAt:       t.CompletedAt(),
+		CreatedAt:         t.CreatedAt(),
+		Tags:              t.Tags(),
+		ChecklistRequired: t.ChecklistRequired(),
+		BlockedReason:     t.BlockedReason(),
+		BlockedAt:         t.BlockedAt(),
+		WaitingOn:         t.WaitingOn(),
+		WaitingSince:      t.WaitingSince(),
+		FollowUpAt:        t.FollowUpAt(),
+		Timezone:          t.Timezone(),
+		EarliestStart:     t.EarliestStart(),
+	}
+	if recurrence := t.Recurrence(); recurrence != nil {
+		dto.Recurrence = recurrence.String()
+	}
+	for _, item := range t.Checklist() {
+		dto.Checklist = append(dto.Checklist, ChecklistItemDTO{ID: item.ID, Title: item.Title, Done: item.Done})
+		if item.Done {
+			dto.ChecklistDone++
+		}
+	}
+	return dto
+}
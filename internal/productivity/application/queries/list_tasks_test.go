@@ -6,8 +6,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -120,6 +123,57 @@ func TestListTasksHandler_Handle(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("filters by blocked status without include all", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		handler := NewListTasksHandler(repo)
+
+		task1 := createTestTask(userID, "Pending task")
+		task2 := createTestTask(userID, "Blocked task")
+		require.NoError(t, task2.Block("Waiting on vendor email"))
+		tasks := []*task.Task{task1, task2}
+
+		repo.On("FindByUserID", mock.Anything, userID).Return(tasks, nil)
+
+		result, err := handler.Handle(context.Background(), ListTasksQuery{
+			UserID: userID,
+			Status: "blocked",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "blocked", result[0].Status)
+		assert.Equal(t, "Waiting on vendor email", result[0].BlockedReason)
+		assert.NotNil(t, result[0].BlockedAt)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("filters by waiting status without include all", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		handler := NewListTasksHandler(repo)
+
+		task1 := createTestTask(userID, "Pending task")
+		task2 := createTestTask(userID, "Delegated task")
+		require.NoError(t, task2.WaitOn("Alice", 48*time.Hour))
+		tasks := []*task.Task{task1, task2}
+
+		repo.On("FindByUserID", mock.Anything, userID).Return(tasks, nil)
+
+		result, err := handler.Handle(context.Background(), ListTasksQuery{
+			UserID: userID,
+			Status: "waiting",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "waiting", result[0].Status)
+		assert.Equal(t, "Alice", result[0].WaitingOn)
+		assert.NotNil(t, result[0].WaitingSince)
+		assert.NotNil(t, result[0].FollowUpAt)
+
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("filters by priority", func(t *testing.T) {
 		repo := new(mockTaskRepo)
 		handler := NewListTasksHandler(repo)
@@ -430,6 +484,66 @@ func TestListTasksHandler_FilterDueToday(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestListTasksHandler_DueFiltersUseClock(t *testing.T) {
+	userID := uuid.New()
+	repo := new(mockTaskRepo)
+
+	// Ten minutes before midnight by the handler's clock.
+	now := time.Date(2024, time.March, 4, 23, 50, 0, 0, time.Local)
+	handler := NewListTasksHandler(repo).WithClock(sharedDomain.FixedClock(now))
+
+	yesterday := time.Date(2024, time.March, 3, 9, 0, 0, 0, time.Local)
+	morning := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.Local)
+	afterMidnight := time.Date(2024, time.March, 5, 0, 10, 0, 0, time.Local)
+	task1 := createTestTask(userID, "Due yesterday")
+	_ = task1.SetDueDate(&yesterday)
+	task2 := createTestTask(userID, "Due this morning")
+	_ = task2.SetDueDate(&morning)
+	task3 := createTestTask(userID, "Due after midnight")
+	_ = task3.SetDueDate(&afterMidnight)
+
+	repo.On("FindPending", mock.Anything, userID).Return([]*task.Task{task1, task2, task3}, nil)
+
+	result, err := handler.Handle(context.Background(), ListTasksQuery{UserID: userID, DueToday: true})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "Due this morning", result[0].Title)
+
+	result, err = handler.Handle(context.Background(), ListTasksQuery{UserID: userID, Overdue: true})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "Due yesterday", result[0].Title)
+}
+
+func TestFilterDueToday_TaskTimezone(t *testing.T) {
+	userID := uuid.New()
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	require.NoError(t, err)
+
+	// 23:00 on June 3rd in Berlin is 09:00 on June 4th in Auckland.
+	now := time.Date(2025, 6, 3, 23, 0, 0, 0, berlin)
+
+	aucklandDue := time.Date(2025, 6, 4, 0, 0, 0, 0, auckland)
+	aucklandTask := createTestTask(userID, "Auckland deadline")
+	require.NoError(t, aucklandTask.SetTimezone("Pacific/Auckland"))
+	require.NoError(t, aucklandTask.SetDueDate(&aucklandDue))
+
+	berlinDue := time.Date(2025, 6, 3, 0, 0, 0, 0, berlin)
+	berlinTask := createTestTask(userID, "Berlin deadline")
+	require.NoError(t, berlinTask.SetDueDate(&berlinDue))
+
+	result := filterDueToday([]*task.Task{aucklandTask, berlinTask}, now)
+	require.Len(t, result, 2)
+
+	// Without its override the Auckland task is due tomorrow for the user.
+	require.NoError(t, aucklandTask.SetTimezone(""))
+	result = filterDueToday([]*task.Task{aucklandTask, berlinTask}, now)
+	require.Len(t, result, 1)
+	assert.Equal(t, "Berlin deadline", result[0].Title())
+}
+
 func TestListTasksHandler_SortByDueDate(t *testing.T) {
 	userID := uuid.New()
 	repo := new(mockTaskRepo)
@@ -519,3 +633,232 @@ func TestListTasksHandler_SortByTitle(t *testing.T) {
 
 	repo.AssertExpectations(t)
 }
+
+type stubSortDefaults struct {
+	orders map[string]string
+	err    error
+}
+
+func (s stubSortDefaults) ListSort(ctx context.Context, userID uuid.UUID, entity string) (string, error) {
+	return s.orders[entity], s.err
+}
+
+func TestListTasksHandler_ConfiguredDefaultSort(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	soon := now.Add(24 * time.Hour)
+	later := now.Add(48 * time.Hour)
+
+	newTask := func(title string, priority value_objects.Priority, due *time.Time) *task.Task {
+		tk := createTestTask(userID, title)
+		_ = tk.SetPriority(priority)
+		_ = tk.SetDueDate(due)
+		return tk
+	}
+	tasks := []*task.Task{
+		newTask("High, due later", value_objects.PriorityHigh, &later),
+		newTask("Low, due soon", value_objects.PriorityLow, &soon),
+		newTask("High, due soon", value_objects.PriorityHigh, &soon),
+	}
+	titles := func(result []TaskDTO) []string {
+		var titles []string
+		for _, dto := range result {
+			titles = append(titles, dto.Title)
+		}
+		return titles
+	}
+
+	t.Run("built-in default is priority then due date", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		repo.On("FindPending", mock.Anything, userID).Return(tasks, nil)
+		handler := NewListTasksHandler(repo)
+
+		result, err := handler.Handle(context.Background(), ListTasksQuery{UserID: userID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"High, due soon", "High, due later", "Low, due soon"}, titles(result))
+	})
+
+	t.Run("configured default is applied", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		repo.On("FindPending", mock.Anything, userID).Return(tasks, nil)
+		handler := NewListTasksHandler(repo).
+			WithSortDefaults(stubSortDefaults{orders: map[string]string{"tasks": "due_date:asc,title:asc"}})
+
+		result, err := handler.Handle(context.Background(), ListTasksQuery{UserID: userID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"High, due soon", "Low, due soon", "High, due later"}, titles(result))
+	})
+
+	t.Run("SortBy overrides the configured default", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		repo.On("FindPending", mock.Anything, userID).Return(tasks, nil)
+		handler := NewListTasksHandler(repo).
+			WithSortDefaults(stubSortDefaults{orders: map[string]string{"tasks": "due_date:asc,title:asc"}})
+
+		result, err := handler.Handle(context.Background(), ListTasksQuery{UserID: userID, SortBy: "title", SortOrder: "asc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"High, due later", "High, due soon", "Low, due soon"}, titles(result))
+	})
+
+	t.Run("failed lookup falls back to the built-in default", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		repo.On("FindPending", mock.Anything, userID).Return(tasks, nil)
+		handler := NewListTasksHandler(repo).
+			WithSortDefaults(stubSortDefaults{err: errors.New("settings unavailable")})
+
+		result, err := handler.Handle(context.Background(), ListTasksQuery{UserID: userID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"High, due soon", "High, due later", "Low, due soon"}, titles(result))
+	})
+
+	t.Run("rejects an unknown sort field", func(t *testing.T) {
+		handler := NewListTasksHandler(new(mockTaskRepo))
+
+		_, err := handler.Handle(context.Background(), ListTasksQuery{UserID: userID, SortBy: "colour"})
+		assert.ErrorIs(t, err, sharedApplication.ErrInvalidSort)
+	})
+}
+
+type stubPriorityScorer struct {
+	scores map[uuid.UUID]float64
+	err    error
+	inputs []types.PriorityInput
+}
+
+func (s *stubPriorityScorer) ExecuteParallelPriority(ctx context.Context, engineID string, userID uuid.UUID, inputs []types.PriorityInput) ([]types.PriorityOutput, error) {
+	s.inputs = inputs
+	if s.err != nil {
+		return nil, s.err
+	}
+	outputs := make([]types.PriorityOutput, len(inputs))
+	for i, input := range inputs {
+		outputs[i] = types.PriorityOutput{ID: input.ID, Score: s.scores[input.ID]}
+	}
+	return outputs, nil
+}
+
+func TestListTasksHandler_SortByScore(t *testing.T) {
+	userID := uuid.New()
+	due := time.Now().Add(24 * time.Hour)
+
+	low := createTestTask(userID, "Low, due tomorrow")
+	_ = low.SetPriority(value_objects.PriorityLow)
+	_ = low.SetDueDate(&due)
+	high := createTestTask(userID, "High")
+	_ = high.SetPriority(value_objects.PriorityHigh)
+	tasks := []*task.Task{high, low}
+
+	titles := func(result []TaskDTO) []string {
+		var titles []string
+		for _, dto := range result {
+			titles = append(titles, dto.Title)
+		}
+		return titles
+	}
+
+	t.Run("sorts by engine score", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		repo.On("FindPending", mock.Anything, userID).Return(tasks, nil)
+		scorer := &stubPriorityScorer{scores: map[uuid.UUID]float64{low.ID(): 9, high.ID(): 4}}
+		handler := NewListTasksHandler(repo).WithPriorityScorer(scorer)
+
+		result, err := handler.Handle(context.Background(), ListTasksQuery{UserID: userID, SortBy: "score:desc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Low, due tomorrow", "High"}, titles(result))
+
+		require.Len(t, scorer.inputs, 2)
+		assert.Equal(t, high.ID(), scorer.inputs[0].ID)
+		assert.Equal(t, 2, scorer.inputs[0].Priority)
+		assert.Equal(t, 4, scorer.inputs[1].Priority)
+		assert.Equal(t, &due, scorer.inputs[1].DueDate)
+	})
+
+	t.Run("does not score lists sorted by other fields", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		repo.On("FindPending", mock.Anything, userID).Return(tasks, nil)
+		scorer := &stubPriorityScorer{}
+		handler := NewListTasksHandler(repo).WithPriorityScorer(scorer)
+
+		_, err := handler.Handle(context.Background(), ListTasksQuery{UserID: userID, SortBy: "title"})
+		require.NoError(t, err)
+		assert.Nil(t, scorer.inputs)
+	})
+
+	t.Run("failed scoring falls back to priority", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		repo.On("FindPending", mock.Anything, userID).Return(tasks, nil)
+		handler := NewListTasksHandler(repo).
+			WithPriorityScorer(&stubPriorityScorer{err: errors.New("circuit open")})
+
+		result, err := handler.Handle(context.Background(), ListTasksQuery{UserID: userID, SortBy: "score:desc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"High", "Low, due tomorrow"}, titles(result))
+	})
+
+	t.Run("without a scorer sorts by priority", func(t *testing.T) {
+		repo := new(mockTaskRepo)
+		repo.On("FindPending", mock.Anything, userID).Return([]*task.Task{low, high}, nil)
+		handler := NewListTasksHandler(repo)
+
+		result, err := handler.Handle(context.Background(), ListTasksQuery{UserID: userID, SortBy: "score:desc"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"High", "Low, due tomorrow"}, titles(result))
+	})
+}
+
+func TestListTasksHandler_FilterByTags(t *testing.T) {
+	userID := uuid.New()
+
+	tagged := func(title string, tags ...string) *task.Task {
+		tk := createTestTask(userID, title)
+		for _, tag := range tags {
+			_, err := tk.AddTag(tag)
+			require.NoError(t, err)
+		}
+		return tk
+	}
+	tasks := []*task.Task{
+		tagged("Work and urgent", "work", "urgent"),
+		tagged("Work only", "work"),
+		tagged("Home", "home"),
+		tagged("Untagged"),
+	}
+
+	list := func(query ListTasksQuery) []string {
+		repo := new(mockTaskRepo)
+		repo.On("FindPending", mock.Anything, userID).Return(tasks, nil)
+		query.UserID = userID
+		query.SortBy = "title"
+		query.SortOrder = "asc"
+
+		result, err := NewListTasksHandler(repo).Handle(context.Background(), query)
+		require.NoError(t, err)
+		titles := make([]string, len(result))
+		for i, dto := range result {
+			titles[i] = dto.Title
+		}
+		return titles
+	}
+
+	t.Run("matches all tags by default", func(t *testing.T) {
+		assert.Equal(t, []string{"Work and urgent"}, list(ListTasksQuery{Tags: []string{"work", "urgent"}}))
+	})
+
+	t.Run("matches any tag", func(t *testing.T) {
+		assert.Equal(t, []string{"Home", "Work and urgent"},
+			list(ListTasksQuery{Tags: []string{"urgent", "home"}, TagMatch: TagMatchAny}))
+	})
+
+	t.Run("normalizes filter tags", func(t *testing.T) {
+		assert.Equal(t, []string{"Work and urgent", "Work only"}, list(ListTasksQuery{Tags: []string{" WORK ", "work"}}))
+	})
+
+	t.Run("rejects an unknown match mode", func(t *testing.T) {
+		_, err := NewListTasksHandler(new(mockTaskRepo)).Handle(context.Background(), ListTasksQuery{
+			UserID:   userID,
+			Tags:     []string{"work"},
+			TagMatch: "some",
+		})
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+	})
+}
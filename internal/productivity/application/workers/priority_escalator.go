@@ -0,0 +1,193 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+)
+
+// DefaultEscalationInterval is the default interval between escalation cycles.
+const DefaultEscalationInterval = 15 * time.Minute
+
+// DefaultEscalationBatchSize is the maximum number of tasks loaded per rule and cycle.
+const DefaultEscalationBatchSize = 100
+
+// EscalationTaskRepository is the task persistence the escalator depends on.
+type EscalationTaskRepository interface {
+	task.Repository
+	task.EscalationRepository
+}
+
+// PriorityEscalatorConfig configures the priority escalator.
+type PriorityEscalatorConfig struct {
+	Interval  time.Duration
+	BatchSize int
+	Policy    task.EscalationPolicy
+}
+
+// DefaultPriorityEscalatorConfig returns the default configuration.
+func DefaultPriorityEscalatorConfig() PriorityEscalatorConfig {
+	return PriorityEscalatorConfig{
+		Interval:  DefaultEscalationInterval,
+		BatchSize: DefaultEscalationBatchSize,
+		Policy:    task.DefaultEscalationPolicy(),
+	}
+}
+
+// PriorityEscalator periodically raises the priority of tasks whose due date
+// is approaching, emitting a priority escalated event for each change.
+type PriorityEscalator struct {
+	taskRepo   EscalationTaskRepository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+	config     PriorityEscalatorConfig
+	logger     *slog.Logger
+	running    atomic.Bool
+	stopCh     chan struct{}
+}
+
+// NewPriorityEscalator creates a new priority escalator.
+func NewPriorityEscalator(
+	taskRepo EscalationTaskRepository,
+	outboxRepo outbox.Repository,
+	uow sharedApplication.UnitOfWork,
+	config PriorityEscalatorConfig,
+	logger *slog.Logger,
+) *PriorityEscalator {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultEscalationInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultEscalationBatchSize
+	}
+	return &PriorityEscalator{
+		taskRepo:   taskRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+		config:     config,
+		logger:     logger,
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Run starts the escalator and blocks until context is cancelled or Stop() is called.
+func (e *PriorityEscalator) Run(ctx context.Context) error {
+	e.running.Store(true)
+	e.logger.Info("task priority escalator started",
+		"interval", e.config.Interval,
+		"rules", len(e.config.Policy.Rules),
+	)
+
+	e.runCycle(ctx)
+
+	ticker := time.NewTicker(e.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.running.Store(false)
+			e.logger.Info("task priority escalator stopped (context cancelled)")
+			return ctx.Err()
+		case <-e.stopCh:
+			e.running.Store(false)
+			e.logger.Info("task priority escalator stopped (stop signal)")
+			return nil
+		case <-ticker.C:
+			e.runCycle(ctx)
+		}
+	}
+}
+
+// Stop signals the escalator to stop gracefully.
+func (e *PriorityEscalator) Stop() {
+	if e.running.Load() {
+		close(e.stopCh)
+	}
+}
+
+// IsRunning returns true if the escalator is currently running.
+func (e *PriorityEscalator) IsRunning() bool {
+	return e.running.Load()
+}
+
+func (e *PriorityEscalator) runCycle(ctx context.Context) {
+	escalated, err := e.EscalateDue(ctx, time.Now())
+	if err != nil {
+		e.logger.Error("failed to escalate task priorities", "error", err)
+		return
+	}
+	if escalated > 0 {
+		e.logger.Info("task priorities escalated", "count", escalated)
+	}
+}
+
+// EscalateDue raises the priority of every open task the policy requires at
+// now and returns the number of tasks escalated. Rules are applied from the
+// highest target priority down, so a task crossing several thresholds at once
+// is escalated straight to the highest one.
+func (e *PriorityEscalator) EscalateDue(ctx context.Context, now time.Time) (int, error) {
+	rules := make([]task.EscalationRule, len(e.config.Policy.Rules))
+	copy(rules, e.config.Policy.Rules)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority.Weight() > rules[j].Priority.Weight()
+	})
+
+	escalated := 0
+	for _, rule := range rules {
+		tasks, err := e.taskRepo.FindDueBelowPriority(ctx, now.Add(rule.Before), rule.Priority, e.config.BatchSize)
+		if err != nil {
+			return escalated, err
+		}
+
+		for _, t := range tasks {
+			if err := ctx.Err(); err != nil {
+				return escalated, err
+			}
+			if !t.EscalatePriority(e.config.Policy, now) {
+				continue
+			}
+
+			if err := e.save(ctx, t); err != nil {
+				e.logger.Error("failed to escalate task priority",
+					"task_id", t.ID(),
+					"error", err,
+				)
+				continue
+			}
+			escalated++
+		}
+	}
+
+	return escalated, nil
+}
+
+func (e *PriorityEscalator) save(ctx context.Context, t *task.Task) error {
+	return sharedApplication.WithUnitOfWork(ctx, e.uow, func(txCtx context.Context) error {
+		if err := e.taskRepo.Save(txCtx, t); err != nil {
+			return err
+		}
+
+		events := t.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(t.UserID()))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		return e.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+}
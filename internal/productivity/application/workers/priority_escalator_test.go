@@ -0,0 +1,164 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubEscalationTaskRepo filters its tasks the way the database query does.
+// Like a freshly loaded task, each returned task has no pending events.
+type stubEscalationTaskRepo struct {
+	stubReminderTaskRepo
+}
+
+func (s *stubEscalationTaskRepo) FindDueBelowPriority(ctx context.Context, until time.Time, below value_objects.Priority, limit int) ([]*task.Task, error) {
+	if s.findErr != nil {
+		return nil, s.findErr
+	}
+	var result []*task.Task
+	for _, t := range s.tasks {
+		if t.DueDate() == nil || t.DueDate().After(until) || t.Priority().Weight() >= below.Weight() {
+			continue
+		}
+		if t.IsCompleted() || t.IsArchived() {
+			continue
+		}
+		t.ClearDomainEvents()
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+func newEscalationTask(t *testing.T, due time.Time, priority value_objects.Priority) *task.Task {
+	t.Helper()
+	tk, err := task.NewTask(uuid.New(), "Ship release")
+	require.NoError(t, err)
+	require.NoError(t, tk.SetDueDate(&due))
+	require.NoError(t, tk.SetPriority(priority))
+	tk.ClearDomainEvents()
+	return tk
+}
+
+func TestPriorityEscalator_EscalateDue(t *testing.T) {
+	due := time.Date(2024, time.March, 10, 15, 0, 0, 0, time.UTC)
+
+	t.Run("crosses each escalation threshold", func(t *testing.T) {
+		tk := newEscalationTask(t, due, value_objects.PriorityMedium)
+		repo := &stubEscalationTaskRepo{stubReminderTaskRepo{tasks: []*task.Task{tk}}}
+		outboxRepo := outbox.NewInMemoryRepository()
+		escalator := NewPriorityEscalator(repo, outboxRepo, stubUnitOfWork{}, DefaultPriorityEscalatorConfig(), nil)
+		ctx := context.Background()
+
+		// Two days out nothing changes.
+		escalated, err := escalator.EscalateDue(ctx, due.Add(-48*time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, escalated)
+		assert.Equal(t, value_objects.PriorityMedium, tk.Priority())
+
+		// One day out the task becomes high.
+		escalated, err = escalator.EscalateDue(ctx, due.Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, escalated)
+		assert.Equal(t, value_objects.PriorityHigh, tk.Priority())
+
+		// Still high an hour before it is due.
+		escalated, err = escalator.EscalateDue(ctx, due.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, escalated)
+
+		// Overdue tasks become urgent.
+		escalated, err = escalator.EscalateDue(ctx, due.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, escalated)
+		assert.Equal(t, value_objects.PriorityUrgent, tk.Priority())
+
+		msgs, err := outboxRepo.GetUnpublished(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		for _, msg := range msgs {
+			assert.Equal(t, task.RoutingKeyEscalated, msg.RoutingKey)
+		}
+		assert.Len(t, repo.saved, 2)
+	})
+
+	t.Run("escalates an overdue task straight to urgent", func(t *testing.T) {
+		tk := newEscalationTask(t, due, value_objects.PriorityLow)
+		repo := &stubEscalationTaskRepo{stubReminderTaskRepo{tasks: []*task.Task{tk}}}
+		outboxRepo := outbox.NewInMemoryRepository()
+		escalator := NewPriorityEscalator(repo, outboxRepo, stubUnitOfWork{}, DefaultPriorityEscalatorConfig(), nil)
+
+		escalated, err := escalator.EscalateDue(context.Background(), due.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, escalated)
+		assert.Equal(t, value_objects.PriorityUrgent, tk.Priority())
+
+		msgs, err := outboxRepo.GetUnpublished(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Len(t, msgs, 1)
+	})
+
+	t.Run("uses a custom policy", func(t *testing.T) {
+		tk := newEscalationTask(t, due, value_objects.PriorityNone)
+		repo := &stubEscalationTaskRepo{stubReminderTaskRepo{tasks: []*task.Task{tk}}}
+		config := DefaultPriorityEscalatorConfig()
+		config.Policy = task.EscalationPolicy{Rules: []task.EscalationRule{
+			{Before: 72 * time.Hour, Priority: value_objects.PriorityMedium},
+		}}
+		escalator := NewPriorityEscalator(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, config, nil)
+
+		escalated, err := escalator.EscalateDue(context.Background(), due.Add(-72*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, escalated)
+		assert.Equal(t, value_objects.PriorityMedium, tk.Priority())
+
+		// Without an overdue rule the task is not raised further.
+		escalated, err = escalator.EscalateDue(context.Background(), due.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, escalated)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		repo := &stubEscalationTaskRepo{stubReminderTaskRepo{findErr: errors.New("db error")}}
+		escalator := NewPriorityEscalator(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, DefaultPriorityEscalatorConfig(), nil)
+
+		_, err := escalator.EscalateDue(context.Background(), due)
+		assert.Error(t, err)
+	})
+
+	t.Run("continues past tasks that fail to save", func(t *testing.T) {
+		tk := newEscalationTask(t, due, value_objects.PriorityLow)
+		repo := &stubEscalationTaskRepo{stubReminderTaskRepo{tasks: []*task.Task{tk}, saveErr: errors.New("db error")}}
+		escalator := NewPriorityEscalator(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, DefaultPriorityEscalatorConfig(), nil)
+
+		escalated, err := escalator.EscalateDue(context.Background(), due)
+		require.NoError(t, err)
+		assert.Zero(t, escalated)
+	})
+}
+
+func TestPriorityEscalator_RunAndStop(t *testing.T) {
+	escalator := NewPriorityEscalator(&stubEscalationTaskRepo{}, outbox.NewInMemoryRepository(), stubUnitOfWork{}, PriorityEscalatorConfig{Interval: 10 * time.Millisecond}, nil)
+
+	done := make(chan error, 1)
+	go func() { done <- escalator.Run(context.Background()) }()
+
+	require.Eventually(t, escalator.IsRunning, time.Second, time.Millisecond)
+	escalator.Stop()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("escalator did not stop")
+	}
+	assert.False(t, escalator.IsRunning())
+}
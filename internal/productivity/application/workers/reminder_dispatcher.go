@@ -0,0 +1,379 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// DefaultReminderInterval is the default interval between dispatch cycles.
+const DefaultReminderInterval = time.Minute
+
+// DefaultReminderBatchSize is the maximum number of tasks processed per cycle.
+const DefaultReminderBatchSize = 50
+
+// ReminderTaskRepository is the task persistence the dispatcher depends on.
+type ReminderTaskRepository interface {
+	task.Repository
+	task.ReminderRepository
+	task.FollowUpRepository
+}
+
+// FocusChecker reports whether a user has silenced notifications with focus mode.
+type FocusChecker interface {
+	SuppressesNotifications(ctx context.Context, userID uuid.UUID, at time.Time) (bool, error)
+}
+
+// UserLocations resolves the time zone a user has set, or nil when they
+// have not set one.
+type UserLocations interface {
+	UserLocation(ctx context.Context, userID uuid.UUID) (*time.Location, error)
+}
+
+// ReminderDispatcherConfig configures the reminder dispatcher.
+type ReminderDispatcherConfig struct {
+	Interval   time.Duration
+	BatchSize  int
+	QuietHours task.QuietHours
+	// Location is the time zone of users who have not set one.
+	Location *time.Location
+}
+
+// DefaultReminderDispatcherConfig returns the default configuration.
+func DefaultReminderDispatcherConfig() ReminderDispatcherConfig {
+	return ReminderDispatcherConfig{
+		Interval:  DefaultReminderInterval,
+		BatchSize: DefaultReminderBatchSize,
+		Location:  time.Local,
+	}
+}
+
+// ReminderDispatcher periodically emits reminder events for tasks approaching
+// their due date and follow-up reminders for tasks waiting on someone.
+type ReminderDispatcher struct {
+	taskRepo   ReminderTaskRepository
+	outboxRepo outbox.Repository
+	uow        sharedApplication.UnitOfWork
+	config     ReminderDispatcherConfig
+	focus      FocusChecker
+	locations  UserLocations
+	notifier   notifications.Notifier
+	logger     *slog.Logger
+	running    atomic.Bool
+	stopCh     chan struct{}
+}
+
+// NewReminderDispatcher creates a new reminder dispatcher.
+func NewReminderDispatcher(
+	taskRepo ReminderTaskRepository,
+	outboxRepo outbox.Repository,
+	uow sharedApplication.UnitOfWork,
+	config ReminderDispatcherConfig,
+	logger *slog.Logger,
+) *ReminderDispatcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultReminderInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultReminderBatchSize
+	}
+	if config.Location == nil {
+		config.Location = time.Local
+	}
+	return &ReminderDispatcher{
+		taskRepo:   taskRepo,
+		outboxRepo: outboxRepo,
+		uow:        uow,
+		config:     config,
+		logger:     logger,
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// WithFocusChecker holds reminders back while the user's focus mode silences
+// notifications. They are sent on the first cycle after focus mode ends.
+func (d *ReminderDispatcher) WithFocusChecker(focus FocusChecker) *ReminderDispatcher {
+	d.focus = focus
+	return d
+}
+
+// WithUserLocations evaluates quiet hours in each task owner's time zone
+// instead of the configured Location. A task pinned to a time zone uses
+// that one.
+func (d *ReminderDispatcher) WithUserLocations(locations UserLocations) *ReminderDispatcher {
+	d.locations = locations
+	return d
+}
+
+// WithNotifier delivers each reminder through the notifier as well as the
+// outbox. A reminder the notifier fails to deliver, or holds back, is not
+// marked sent and is retried on the next cycle.
+func (d *ReminderDispatcher) WithNotifier(notifier notifications.Notifier) *ReminderDispatcher {
+	d.notifier = notifier
+	return d
+}
+
+// Run starts the dispatcher and blocks until context is cancelled or Stop() is called.
+func (d *ReminderDispatcher) Run(ctx context.Context) error {
+	d.running.Store(true)
+	d.logger.Info("task reminder dispatcher started",
+		"interval", d.config.Interval,
+		"quiet_hours", d.config.QuietHours.IsEnabled(),
+	)
+
+	d.runCycle(ctx)
+
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.running.Store(false)
+			d.logger.Info("task reminder dispatcher stopped (context cancelled)")
+			return ctx.Err()
+		case <-d.stopCh:
+			d.running.Store(false)
+			d.logger.Info("task reminder dispatcher stopped (stop signal)")
+			return nil
+		case <-ticker.C:
+			d.runCycle(ctx)
+		}
+	}
+}
+
+// Stop signals the dispatcher to stop gracefully.
+func (d *ReminderDispatcher) Stop() {
+	if d.running.Load() {
+		close(d.stopCh)
+	}
+}
+
+// IsRunning returns true if the dispatcher is currently running.
+func (d *ReminderDispatcher) IsRunning() bool {
+	return d.running.Load()
+}
+
+func (d *ReminderDispatcher) runCycle(ctx context.Context) {
+	now := time.Now().In(d.config.Location)
+
+	sent, err := d.DispatchDue(ctx, now)
+	if err != nil {
+		d.logger.Error("failed to dispatch task reminders", "error", err)
+	} else if sent > 0 {
+		d.logger.Info("task reminders dispatched", "count", sent)
+	}
+
+	sent, err = d.DispatchFollowUps(ctx, now)
+	if err != nil {
+		d.logger.Error("failed to dispatch task follow-ups", "error", err)
+	} else if sent > 0 {
+		d.logger.Info("task follow-ups dispatched", "count", sent)
+	}
+}
+
+// DispatchDue emits reminder events for every reminder due at now, deferring
+// those that fall inside quiet hours or focus mode to a later cycle. It returns the number
+// of reminders sent. Quiet hours are evaluated in each task's time zone.
+func (d *ReminderDispatcher) DispatchDue(ctx context.Context, now time.Time) (int, error) {
+	tasks, err := d.taskRepo.FindWithPendingReminders(ctx, now, d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, t := range tasks {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		due := t.DueReminders(d.localTime(ctx, t, now), d.config.QuietHours)
+		if len(due) == 0 {
+			continue
+		}
+
+		if d.inFocus(ctx, t, now) {
+			continue
+		}
+
+		err := d.dispatch(ctx, t, d.reminderNotification(t), func() error {
+			for _, reminder := range due {
+				if err := t.MarkReminderSent(reminder.Offset, now); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if notifications.IsHeldBack(err) {
+			d.logger.Debug("task reminder held back",
+				"task_id", t.ID(),
+				"reason", err,
+			)
+			continue
+		}
+		if err != nil {
+			d.logger.Error("failed to dispatch reminders for task",
+				"task_id", t.ID(),
+				"error", err,
+			)
+			continue
+		}
+
+		sent += len(due)
+	}
+
+	return sent, nil
+}
+
+// DispatchFollowUps emits follow-up events for tasks that have been waiting
+// on someone past their follow-up delay, deferring those that fall inside
+// quiet hours or focus mode to a later cycle. It returns the number of
+// follow-ups sent. Quiet hours are evaluated in each task's time zone.
+func (d *ReminderDispatcher) DispatchFollowUps(ctx context.Context, now time.Time) (int, error) {
+	tasks, err := d.taskRepo.FindWithDueFollowUps(ctx, now, d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, t := range tasks {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		if !t.FollowUpDue(d.localTime(ctx, t, now), d.config.QuietHours) {
+			continue
+		}
+
+		if d.inFocus(ctx, t, now) {
+			continue
+		}
+
+		err := d.dispatch(ctx, t, d.followUpNotification(t), func() error {
+			return t.MarkFollowUpSent(now)
+		})
+		if notifications.IsHeldBack(err) {
+			d.logger.Debug("task follow-up held back",
+				"task_id", t.ID(),
+				"reason", err,
+			)
+			continue
+		}
+		if err != nil {
+			d.logger.Error("failed to dispatch follow-up for task",
+				"task_id", t.ID(),
+				"error", err,
+			)
+			continue
+		}
+
+		sent++
+	}
+
+	return sent, nil
+}
+
+// dispatch marks the task's reminders sent with mark, then saves the task,
+// its events and delivers the notification in one unit of work.
+func (d *ReminderDispatcher) dispatch(ctx context.Context, t *task.Task, notification notifications.Notification, mark func() error) error {
+	return sharedApplication.WithUnitOfWork(ctx, d.uow, func(txCtx context.Context) error {
+		if err := mark(); err != nil {
+			return err
+		}
+
+		if err := d.taskRepo.Save(txCtx, t); err != nil {
+			return err
+		}
+
+		events := t.DomainEvents()
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(t.UserID()))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		if err := d.outboxRepo.SaveBatch(txCtx, msgs); err != nil {
+			return err
+		}
+		return d.notify(txCtx, notification)
+	})
+}
+
+// localTime returns now in the time zone the task's quiet hours apply in:
+// the task's own time zone, else its owner's, else the configured Location.
+func (d *ReminderDispatcher) localTime(ctx context.Context, t *task.Task, now time.Time) time.Time {
+	if t.Timezone() != "" {
+		return t.LocalTime(now)
+	}
+	loc := d.config.Location
+	if d.locations != nil {
+		userLoc, err := d.locations.UserLocation(ctx, t.UserID())
+		if err != nil {
+			d.logger.Warn("failed to resolve user time zone, using default",
+				"task_id", t.ID(),
+				"error", err,
+			)
+		} else if userLoc != nil {
+			loc = userLoc
+		}
+	}
+	return now.In(loc)
+}
+
+// inFocus reports whether focus mode is holding back the task owner's
+// notifications. Reminders are sent if the check fails.
+func (d *ReminderDispatcher) inFocus(ctx context.Context, t *task.Task, now time.Time) bool {
+	if d.focus == nil {
+		return false
+	}
+	suppressed, err := d.focus.SuppressesNotifications(ctx, t.UserID(), now)
+	if err != nil {
+		d.logger.Warn("failed to check focus mode, sending reminders",
+			"task_id", t.ID(),
+			"error", err,
+		)
+		return false
+	}
+	return suppressed
+}
+
+// notify delivers the notification through the notifier, if one is set.
+func (d *ReminderDispatcher) notify(ctx context.Context, notification notifications.Notification) error {
+	if d.notifier == nil {
+		return nil
+	}
+	return d.notifier.Send(ctx, notification)
+}
+
+// reminderNotification is the notification for a task's due date reminder.
+func (d *ReminderDispatcher) reminderNotification(t *task.Task) notifications.Notification {
+	body := ""
+	if due := t.DueDate(); due != nil {
+		body = fmt.Sprintf("Due %s", due.In(d.config.Location).Format("Mon Jan 2 15:04"))
+	}
+	return notifications.NewNotification(t.UserID(), t.Title(), body, t.Priority().String())
+}
+
+// followUpNotification is the notification for a waiting task's follow-up.
+func (d *ReminderDispatcher) followUpNotification(t *task.Task) notifications.Notification {
+	body := fmt.Sprintf("Follow up with %s", t.WaitingOn())
+	if since := t.WaitingSince(); since != nil {
+		body += fmt.Sprintf(", waiting since %s", since.In(d.config.Location).Format("Mon Jan 2"))
+	}
+	return notifications.NewNotification(t.UserID(), t.Title(), body, t.Priority().String())
+}
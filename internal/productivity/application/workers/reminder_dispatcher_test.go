@@ -0,0 +1,347 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	notifications "github.com/felixgeelhaar/orbita/internal/notifications/domain"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubReminderTaskRepo struct {
+	tasks     []*task.Task
+	followUps []*task.Task
+	saved     []*task.Task
+	findErr   error
+	saveErr   error
+}
+
+func (s *stubReminderTaskRepo) Save(ctx context.Context, t *task.Task) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.saved = append(s.saved, t)
+	return nil
+}
+
+func (s *stubReminderTaskRepo) FindByID(ctx context.Context, id uuid.UUID) (*task.Task, error) {
+	return nil, nil
+}
+
+func (s *stubReminderTaskRepo) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*task.Task, error) {
+	return nil, nil
+}
+
+func (s *stubReminderTaskRepo) FindPending(ctx context.Context, userID uuid.UUID) ([]*task.Task, error) {
+	return nil, nil
+}
+
+func (s *stubReminderTaskRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (s *stubReminderTaskRepo) FindWithPendingReminders(ctx context.Context, until time.Time, limit int) ([]*task.Task, error) {
+	return s.tasks, s.findErr
+}
+
+func (s *stubReminderTaskRepo) FindWithDueFollowUps(ctx context.Context, until time.Time, limit int) ([]*task.Task, error) {
+	return s.followUps, s.findErr
+}
+
+type stubUnitOfWork struct{}
+
+func (s stubUnitOfWork) Begin(ctx context.Context) (context.Context, error) { return ctx, nil }
+func (s stubUnitOfWork) Commit(ctx context.Context) error                   { return nil }
+func (s stubUnitOfWork) Rollback(ctx context.Context) error                 { return nil }
+
+// stubFocusChecker silences notifications until the given time.
+type stubFocusChecker struct {
+	until time.Time
+}
+
+func (s stubFocusChecker) SuppressesNotifications(ctx context.Context, userID uuid.UUID, at time.Time) (bool, error) {
+	return at.Before(s.until), nil
+}
+
+// stubUserLocations returns each user's time zone.
+type stubUserLocations map[uuid.UUID]*time.Location
+
+func (s stubUserLocations) UserLocation(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+	return s[userID], nil
+}
+
+type stubNotifier struct {
+	sent []notifications.Notification
+	err  error
+}
+
+func (s *stubNotifier) Send(ctx context.Context, n notifications.Notification) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, n)
+	return nil
+}
+
+func newReminderTask(t *testing.T, due time.Time, offsets ...time.Duration) *task.Task {
+	t.Helper()
+	tk, err := task.NewTask(uuid.New(), "Submit report")
+	require.NoError(t, err)
+	require.NoError(t, tk.SetDueDate(&due))
+	for _, offset := range offsets {
+		require.NoError(t, tk.AddReminder(offset))
+	}
+	tk.ClearDomainEvents()
+	return tk
+}
+
+func TestReminderDispatcher_DispatchDue(t *testing.T) {
+	due := time.Date(2024, time.March, 10, 15, 0, 0, 0, time.UTC)
+
+	t.Run("emits reminder events and marks them sent", func(t *testing.T) {
+		tk := newReminderTask(t, due, 24*time.Hour, time.Hour)
+		repo := &stubReminderTaskRepo{tasks: []*task.Task{tk}}
+		outboxRepo := outbox.NewInMemoryRepository()
+		dispatcher := NewReminderDispatcher(repo, outboxRepo, stubUnitOfWork{}, DefaultReminderDispatcherConfig(), nil)
+
+		sent, err := dispatcher.DispatchDue(context.Background(), due.Add(-2*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.Len(t, repo.saved, 1)
+		assert.True(t, tk.Reminders()[0].IsSent())
+		assert.False(t, tk.Reminders()[1].IsSent())
+
+		msgs, err := outboxRepo.GetUnpublished(context.Background(), 10)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		assert.Equal(t, task.RoutingKeyReminder, msgs[0].RoutingKey)
+
+		// The day-before reminder is not sent again.
+		sent, err = dispatcher.DispatchDue(context.Background(), due.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		assert.True(t, tk.Reminders()[1].IsSent())
+	})
+
+	t.Run("defers reminders during quiet hours", func(t *testing.T) {
+		earlyDue := time.Date(2024, time.March, 11, 8, 0, 0, 0, time.UTC)
+		tk := newReminderTask(t, earlyDue, 9*time.Hour) // 23:00 the night before
+		repo := &stubReminderTaskRepo{tasks: []*task.Task{tk}}
+
+		quiet, err := task.ParseQuietHours("22:00", "07:00")
+		require.NoError(t, err)
+		config := DefaultReminderDispatcherConfig()
+		config.QuietHours = quiet
+		dispatcher := NewReminderDispatcher(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, config, nil)
+
+		sent, err := dispatcher.DispatchDue(context.Background(), earlyDue.Add(-9*time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		assert.Empty(t, repo.saved)
+
+		sent, err = dispatcher.DispatchDue(context.Background(), earlyDue.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		assert.True(t, tk.Reminders()[0].IsSent())
+	})
+
+	t.Run("evaluates quiet hours in the owner's time zone", func(t *testing.T) {
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		require.NoError(t, err)
+		quiet, err := task.ParseQuietHours("22:00", "07:00")
+		require.NoError(t, err)
+		config := DefaultReminderDispatcherConfig()
+		config.QuietHours = quiet
+		config.Location = time.UTC
+
+		// 14:00 UTC is 23:00 in Tokyo.
+		tk := newReminderTask(t, due, time.Hour)
+		repo := &stubReminderTaskRepo{tasks: []*task.Task{tk}}
+		dispatcher := NewReminderDispatcher(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, config, nil).
+			WithUserLocations(stubUserLocations{tk.UserID(): tokyo})
+
+		sent, err := dispatcher.DispatchDue(context.Background(), due.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+
+		// A task pinned to a time zone uses that one instead.
+		require.NoError(t, tk.SetTimezone("Europe/London"))
+		sent, err = dispatcher.DispatchDue(context.Background(), due.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("holds reminders back during focus mode", func(t *testing.T) {
+		tk := newReminderTask(t, due, time.Hour)
+		repo := &stubReminderTaskRepo{tasks: []*task.Task{tk}}
+		dispatcher := NewReminderDispatcher(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, DefaultReminderDispatcherConfig(), nil).
+			WithFocusChecker(stubFocusChecker{until: due.Add(-30 * time.Minute)})
+
+		sent, err := dispatcher.DispatchDue(context.Background(), due.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		assert.False(t, tk.Reminders()[0].IsSent())
+
+		// Focus mode is over, so the held-back reminder goes out.
+		sent, err = dispatcher.DispatchDue(context.Background(), due.Add(-30*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		assert.True(t, tk.Reminders()[0].IsSent())
+	})
+
+	t.Run("delivers reminders through the notifier", func(t *testing.T) {
+		tk := newReminderTask(t, due, time.Hour)
+		repo := &stubReminderTaskRepo{tasks: []*task.Task{tk}}
+		notifier := &stubNotifier{}
+		dispatcher := NewReminderDispatcher(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, DefaultReminderDispatcherConfig(), nil).
+			WithNotifier(notifier)
+
+		sent, err := dispatcher.DispatchDue(context.Background(), due.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, tk.UserID(), notifier.sent[0].UserID)
+		assert.Equal(t, "Submit report", notifier.sent[0].Title)
+	})
+
+	t.Run("does not count reminders the notifier holds back", func(t *testing.T) {
+		tk := newReminderTask(t, due, time.Hour)
+		repo := &stubReminderTaskRepo{tasks: []*task.Task{tk}}
+		notifier := &stubNotifier{err: notifications.ErrRateLimited}
+		dispatcher := NewReminderDispatcher(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, DefaultReminderDispatcherConfig(), nil).
+			WithNotifier(notifier)
+
+		sent, err := dispatcher.DispatchDue(context.Background(), due.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		repo := &stubReminderTaskRepo{findErr: errors.New("db error")}
+		dispatcher := NewReminderDispatcher(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, DefaultReminderDispatcherConfig(), nil)
+
+		_, err := dispatcher.DispatchDue(context.Background(), due)
+		assert.Error(t, err)
+	})
+
+	t.Run("continues past tasks that fail to save", func(t *testing.T) {
+		tk := newReminderTask(t, due, time.Hour)
+		repo := &stubReminderTaskRepo{tasks: []*task.Task{tk}, saveErr: errors.New("db error")}
+		dispatcher := NewReminderDispatcher(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, DefaultReminderDispatcherConfig(), nil)
+
+		sent, err := dispatcher.DispatchDue(context.Background(), due)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+}
+
+func newWaitingTask(t *testing.T, person string, delay time.Duration) *task.Task {
+	t.Helper()
+	tk, err := task.NewTask(uuid.New(), "Contract review")
+	require.NoError(t, err)
+	require.NoError(t, tk.WaitOn(person, delay))
+	tk.ClearDomainEvents()
+	return tk
+}
+
+func TestReminderDispatcher_DispatchFollowUps(t *testing.T) {
+	t.Run("fires the follow-up once the delay has passed", func(t *testing.T) {
+		tk := newWaitingTask(t, "Alice", 48*time.Hour)
+		followUpAt := *tk.FollowUpAt()
+		repo := &stubReminderTaskRepo{followUps: []*task.Task{tk}}
+		outboxRepo := outbox.NewInMemoryRepository()
+		notifier := &stubNotifier{}
+		dispatcher := NewReminderDispatcher(repo, outboxRepo, stubUnitOfWork{}, DefaultReminderDispatcherConfig(), nil).
+			WithNotifier(notifier)
+
+		// Before the delay nothing is sent.
+		sent, err := dispatcher.DispatchFollowUps(context.Background(), followUpAt.Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		assert.Empty(t, repo.saved)
+
+		sent, err = dispatcher.DispatchFollowUps(context.Background(), followUpAt)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.Len(t, repo.saved, 1)
+		require.NotNil(t, tk.FollowUpSentAt())
+		assert.Equal(t, followUpAt, *tk.FollowUpSentAt())
+
+		msgs, err := outboxRepo.GetUnpublished(context.Background(), 10)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		assert.Equal(t, task.RoutingKeyFollowUp, msgs[0].RoutingKey)
+
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, "Contract review", notifier.sent[0].Title)
+		assert.Contains(t, notifier.sent[0].Body, "Follow up with Alice")
+
+		// The follow-up is not sent again.
+		sent, err = dispatcher.DispatchFollowUps(context.Background(), followUpAt.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		assert.Len(t, notifier.sent, 1)
+	})
+
+	t.Run("resolved tasks get no follow-up", func(t *testing.T) {
+		tk := newWaitingTask(t, "Alice", time.Hour)
+		followUpAt := *tk.FollowUpAt()
+		require.NoError(t, tk.ResolveWaiting("replied"))
+		repo := &stubReminderTaskRepo{followUps: []*task.Task{tk}}
+		dispatcher := NewReminderDispatcher(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, DefaultReminderDispatcherConfig(), nil)
+
+		sent, err := dispatcher.DispatchFollowUps(context.Background(), followUpAt.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		assert.Empty(t, repo.saved)
+	})
+
+	t.Run("holds follow-ups back during focus mode", func(t *testing.T) {
+		tk := newWaitingTask(t, "Alice", time.Hour)
+		followUpAt := *tk.FollowUpAt()
+		repo := &stubReminderTaskRepo{followUps: []*task.Task{tk}}
+		dispatcher := NewReminderDispatcher(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, DefaultReminderDispatcherConfig(), nil).
+			WithFocusChecker(stubFocusChecker{until: followUpAt.Add(30 * time.Minute)})
+
+		sent, err := dispatcher.DispatchFollowUps(context.Background(), followUpAt)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		assert.Nil(t, tk.FollowUpSentAt())
+
+		sent, err = dispatcher.DispatchFollowUps(context.Background(), followUpAt.Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		repo := &stubReminderTaskRepo{findErr: errors.New("db down")}
+		dispatcher := NewReminderDispatcher(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, DefaultReminderDispatcherConfig(), nil)
+
+		_, err := dispatcher.DispatchFollowUps(context.Background(), time.Now())
+		assert.Error(t, err)
+	})
+}
+
+func TestReminderDispatcher_RunAndStop(t *testing.T) {
+	dispatcher := NewReminderDispatcher(&stubReminderTaskRepo{}, outbox.NewInMemoryRepository(), stubUnitOfWork{}, ReminderDispatcherConfig{Interval: 10 * time.Millisecond}, nil)
+
+	done := make(chan error, 1)
+	go func() { done <- dispatcher.Run(context.Background()) }()
+
+	require.Eventually(t, dispatcher.IsRunning, time.Second, 5*time.Millisecond)
+	dispatcher.Stop()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("dispatcher did not stop")
+	}
+	assert.False(t, dispatcher.IsRunning())
+}
@@ -0,0 +1,172 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+)
+
+// DefaultRetentionInterval is the default interval between archival cycles.
+const DefaultRetentionInterval = time.Hour
+
+// DefaultRetentionBatchSize is the maximum number of tasks loaded per query.
+const DefaultRetentionBatchSize = 100
+
+// DefaultRetentionPeriod is how long completed tasks stay unarchived by default.
+const DefaultRetentionPeriod = 30 * 24 * time.Hour
+
+// TaskArchiverConfig configures the completed task archiver.
+type TaskArchiverConfig struct {
+	Interval  time.Duration
+	BatchSize int
+	// RetainFor is how long a task stays completed before it is archived.
+	RetainFor time.Duration
+}
+
+// DefaultTaskArchiverConfig returns the default configuration.
+func DefaultTaskArchiverConfig() TaskArchiverConfig {
+	return TaskArchiverConfig{
+		Interval:  DefaultRetentionInterval,
+		BatchSize: DefaultRetentionBatchSize,
+		RetainFor: DefaultRetentionPeriod,
+	}
+}
+
+// TaskArchiver periodically archives tasks that were completed longer ago
+// than the retention period, keeping active task lists short. Archived tasks
+// are kept and still listed when all tasks are requested.
+type TaskArchiver struct {
+	taskRepo       task.RetentionRepository
+	archiveHandler *commands.ArchiveTaskHandler
+	config         TaskArchiverConfig
+	logger         *slog.Logger
+	running        atomic.Bool
+	stopCh         chan struct{}
+}
+
+// NewTaskArchiver creates a new completed task archiver.
+func NewTaskArchiver(
+	taskRepo task.RetentionRepository,
+	archiveHandler *commands.ArchiveTaskHandler,
+	config TaskArchiverConfig,
+	logger *slog.Logger,
+) *TaskArchiver {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultRetentionInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultRetentionBatchSize
+	}
+	if config.RetainFor <= 0 {
+		config.RetainFor = DefaultRetentionPeriod
+	}
+	return &TaskArchiver{
+		taskRepo:       taskRepo,
+		archiveHandler: archiveHandler,
+		config:         config,
+		logger:         logger,
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// Run starts the archiver and blocks until context is cancelled or Stop() is called.
+func (a *TaskArchiver) Run(ctx context.Context) error {
+	a.running.Store(true)
+	a.logger.Info("completed task archiver started",
+		"interval", a.config.Interval,
+		"retain_for", a.config.RetainFor,
+	)
+
+	a.runCycle(ctx)
+
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.running.Store(false)
+			a.logger.Info("completed task archiver stopped (context cancelled)")
+			return ctx.Err()
+		case <-a.stopCh:
+			a.running.Store(false)
+			a.logger.Info("completed task archiver stopped (stop signal)")
+			return nil
+		case <-ticker.C:
+			a.runCycle(ctx)
+		}
+	}
+}
+
+// Stop signals the archiver to stop gracefully.
+func (a *TaskArchiver) Stop() {
+	if a.running.Load() {
+		close(a.stopCh)
+	}
+}
+
+// IsRunning returns true if the archiver is currently running.
+func (a *TaskArchiver) IsRunning() bool {
+	return a.running.Load()
+}
+
+func (a *TaskArchiver) runCycle(ctx context.Context) {
+	archived, err := a.ArchiveExpired(ctx, time.Now())
+	if err != nil {
+		a.logger.Error("failed to archive completed tasks", "error", err)
+		return
+	}
+	if archived > 0 {
+		a.logger.Info("completed tasks archived", "count", archived)
+	}
+}
+
+// ArchiveExpired archives every task completed at or before now minus the
+// retention period and returns the number of tasks archived. Tasks that
+// fail to archive are logged and left for the next cycle.
+func (a *TaskArchiver) ArchiveExpired(ctx context.Context, now time.Time) (int, error) {
+	cutoff := now.Add(-a.config.RetainFor)
+
+	archived := 0
+	for {
+		tasks, err := a.taskRepo.FindCompletedBefore(ctx, cutoff, a.config.BatchSize)
+		if err != nil {
+			return archived, err
+		}
+
+		batchArchived := 0
+		for _, t := range tasks {
+			if err := ctx.Err(); err != nil {
+				return archived, err
+			}
+
+			err := a.archiveHandler.Handle(sharedApplication.WithPrincipal(ctx, t.UserID()), commands.ArchiveTaskCommand{
+				TaskID: t.ID(),
+				UserID: t.UserID(),
+			})
+			if err != nil {
+				a.logger.Error("failed to archive completed task",
+					"task_id", t.ID(),
+					"error", err,
+				)
+				continue
+			}
+			batchArchived++
+		}
+		archived += batchArchived
+
+		// A short batch means nothing is left; a batch that archived nothing
+		// would only return the same tasks again.
+		if len(tasks) < a.config.BatchSize || batchArchived == 0 {
+			return archived, nil
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/application/commands"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRetentionTaskRepo filters its tasks the way the database query does.
+type stubRetentionTaskRepo struct {
+	stubReminderTaskRepo
+}
+
+func (s *stubRetentionTaskRepo) FindByID(ctx context.Context, id uuid.UUID) (*task.Task, error) {
+	for _, t := range s.tasks {
+		if t.ID() == id {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubRetentionTaskRepo) FindCompletedBefore(ctx context.Context, before time.Time, limit int) ([]*task.Task, error) {
+	if s.findErr != nil {
+		return nil, s.findErr
+	}
+	var result []*task.Task
+	for _, t := range s.tasks {
+		if !t.IsCompleted() || t.CompletedAt() == nil || t.CompletedAt().After(before) {
+			continue
+		}
+		result = append(result, t)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func newCompletedTask(t *testing.T, completedAt time.Time) *task.Task {
+	t.Helper()
+	tk, err := task.NewTask(uuid.New(), "Old report")
+	require.NoError(t, err)
+	require.NoError(t, tk.Complete())
+	tk.RehydrateCompletedAt(&completedAt)
+	tk.ClearDomainEvents()
+	return tk
+}
+
+func newTaskArchiver(repo *stubRetentionTaskRepo, config TaskArchiverConfig) (*TaskArchiver, *outbox.InMemoryRepository) {
+	outboxRepo := outbox.NewInMemoryRepository()
+	handler := commands.NewArchiveTaskHandler(repo, outboxRepo, stubUnitOfWork{})
+	return NewTaskArchiver(repo, handler, config, nil), outboxRepo
+}
+
+func TestTaskArchiver_ArchiveExpired(t *testing.T) {
+	now := time.Date(2024, time.June, 30, 12, 0, 0, 0, time.UTC)
+	config := DefaultTaskArchiverConfig()
+	config.RetainFor = 30 * 24 * time.Hour
+
+	t.Run("archives tasks completed before the retention period", func(t *testing.T) {
+		expired := newCompletedTask(t, now.AddDate(0, 0, -45))
+		boundary := newCompletedTask(t, now.AddDate(0, 0, -30))
+		recent := newCompletedTask(t, now.AddDate(0, 0, -29))
+		open, err := task.NewTask(uuid.New(), "Still open")
+		require.NoError(t, err)
+
+		repo := &stubRetentionTaskRepo{stubReminderTaskRepo{tasks: []*task.Task{expired, boundary, recent, open}}}
+		archiver, outboxRepo := newTaskArchiver(repo, config)
+
+		archived, err := archiver.ArchiveExpired(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, archived)
+
+		assert.True(t, expired.IsArchived())
+		assert.True(t, boundary.IsArchived())
+		assert.True(t, recent.IsCompleted())
+		assert.False(t, open.IsArchived())
+		assert.Len(t, repo.saved, 2)
+
+		msgs, err := outboxRepo.GetUnpublished(context.Background(), 10)
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		assert.Equal(t, task.RoutingKeyArchived, msgs[0].RoutingKey)
+
+		// A day later the recent task crosses the threshold too.
+		archived, err = archiver.ArchiveExpired(context.Background(), now.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		assert.Equal(t, 1, archived)
+		assert.True(t, recent.IsArchived())
+	})
+
+	t.Run("works through more tasks than one batch", func(t *testing.T) {
+		var tasks []*task.Task
+		for i := 0; i < 5; i++ {
+			tasks = append(tasks, newCompletedTask(t, now.AddDate(0, -3, i)))
+		}
+		repo := &stubRetentionTaskRepo{stubReminderTaskRepo{tasks: tasks}}
+		batched := config
+		batched.BatchSize = 2
+		archiver, _ := newTaskArchiver(repo, batched)
+
+		archived, err := archiver.ArchiveExpired(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 5, archived)
+	})
+
+	t.Run("leaves tasks that fail to save for the next cycle", func(t *testing.T) {
+		expired := newCompletedTask(t, now.AddDate(0, 0, -45))
+		repo := &stubRetentionTaskRepo{stubReminderTaskRepo{tasks: []*task.Task{expired}, saveErr: errors.New("disk full")}}
+		batched := config
+		batched.BatchSize = 1
+		archiver, _ := newTaskArchiver(repo, batched)
+
+		archived, err := archiver.ArchiveExpired(context.Background(), now)
+		require.NoError(t, err)
+		assert.Zero(t, archived)
+	})
+
+	t.Run("returns query errors", func(t *testing.T) {
+		repo := &stubRetentionTaskRepo{stubReminderTaskRepo{findErr: errors.New("db down")}}
+		archiver, _ := newTaskArchiver(repo, config)
+
+		_, err := archiver.ArchiveExpired(context.Background(), now)
+		assert.EqualError(t, err, "db down")
+	})
+}
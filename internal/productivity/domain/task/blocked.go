@@ -0,0 +1,83 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTaskBlocked    = errors.New("task is blocked")
+	ErrTaskNotBlocked = errors.New("task is not blocked")
+)
+
+// Block marks the task as blocked by something outside Orbita, such as a
+// pull request awaiting review or an email awaiting a reply. The reason is
+// free-form and may be empty. Blocking an already blocked task replaces the
+// reason; a waiting task stops waiting.
+func (t *Task) Block(reason string) error {
+	if t.IsArchived() {
+		return ErrTaskArchived
+	}
+	if t.IsCompleted() {
+		return ErrTaskAlreadyComplete
+	}
+
+	reason = strings.TrimSpace(reason)
+	if !t.IsBlocked() {
+		now := time.Now().UTC()
+		t.status = StatusBlocked
+		t.blockedAt = &now
+	}
+	t.blockedReason = reason
+	t.clearWaiting()
+	t.Touch()
+
+	t.AddDomainEvent(NewTaskBlocked(t.ID(), reason))
+
+	return nil
+}
+
+// Unblock returns a blocked task to pending. The note records how the block
+// was resolved and travels with the TaskUnblocked event.
+func (t *Task) Unblock(note string) error {
+	if !t.IsBlocked() {
+		return ErrTaskNotBlocked
+	}
+
+	reason := t.blockedReason
+	t.status = StatusPending
+	t.clearBlock()
+	t.Touch()
+
+	t.AddDomainEvent(NewTaskUnblocked(t.ID(), reason, strings.TrimSpace(note)))
+
+	return nil
+}
+
+// IsBlocked returns true if the task is waiting on something outside Orbita.
+func (t *Task) IsBlocked() bool {
+	return t.status == StatusBlocked
+}
+
+// BlockedReason returns what the task is blocked by, if anything.
+func (t *Task) BlockedReason() string {
+	return t.blockedReason
+}
+
+// BlockedAt returns when the task was blocked, or nil if it is not blocked.
+func (t *Task) BlockedAt() *time.Time {
+	return t.blockedAt
+}
+
+// RehydrateBlock restores the block reason and when the task was blocked
+// from persistence.
+func (t *Task) RehydrateBlock(reason string, at *time.Time) {
+	t.blockedReason = reason
+	t.blockedAt = at
+}
+
+func (t *Task) clearBlock() {
+	t.blockedReason = ""
+	t.blockedAt = nil
+}
@@ -0,0 +1,103 @@
+package task_test
+
+import (
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_Block(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Deploy")
+	require.NoError(t, err)
+	tk.ClearDomainEvents()
+
+	require.NoError(t, tk.Block("  https://github.com/acme/api/pull/42  "))
+
+	assert.True(t, tk.IsBlocked())
+	assert.Equal(t, task.StatusBlocked, tk.Status())
+	assert.Equal(t, "https://github.com/acme/api/pull/42", tk.BlockedReason())
+	require.NotNil(t, tk.BlockedAt())
+
+	events := tk.DomainEvents()
+	require.Len(t, events, 1)
+	blocked, ok := events[0].(task.TaskBlocked)
+	require.True(t, ok)
+	assert.Equal(t, task.RoutingKeyBlocked, blocked.RoutingKey())
+	assert.Equal(t, "https://github.com/acme/api/pull/42", blocked.Reason)
+}
+
+func TestTask_Block_ReplacesReasonAndKeepsBlockedAt(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Deploy")
+	require.NoError(t, err)
+	require.NoError(t, tk.Block("Waiting on PR"))
+	blockedAt := tk.BlockedAt()
+
+	require.NoError(t, tk.Block("Waiting on security review email"))
+
+	assert.Equal(t, "Waiting on security review email", tk.BlockedReason())
+	assert.Equal(t, blockedAt, tk.BlockedAt())
+}
+
+func TestTask_Block_CompletedOrArchived(t *testing.T) {
+	completed, err := task.NewTask(uuid.New(), "Done")
+	require.NoError(t, err)
+	require.NoError(t, completed.Complete())
+	assert.ErrorIs(t, completed.Block("PR"), task.ErrTaskAlreadyComplete)
+
+	archived, err := task.NewTask(uuid.New(), "Gone")
+	require.NoError(t, err)
+	require.NoError(t, archived.Archive())
+	assert.ErrorIs(t, archived.Block("PR"), task.ErrTaskArchived)
+}
+
+func TestTask_Unblock(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Deploy")
+	require.NoError(t, err)
+	require.NoError(t, tk.Start())
+	require.NoError(t, tk.Block("Waiting on PR"))
+	tk.ClearDomainEvents()
+
+	require.NoError(t, tk.Unblock(" PR merged "))
+
+	assert.False(t, tk.IsBlocked())
+	assert.Equal(t, task.StatusPending, tk.Status())
+	assert.Empty(t, tk.BlockedReason())
+	assert.Nil(t, tk.BlockedAt())
+
+	events := tk.DomainEvents()
+	require.Len(t, events, 1)
+	unblocked, ok := events[0].(task.TaskUnblocked)
+	require.True(t, ok)
+	assert.Equal(t, "Waiting on PR", unblocked.Reason)
+	assert.Equal(t, "PR merged", unblocked.Note)
+}
+
+func TestTask_Unblock_NotBlocked(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Deploy")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, tk.Unblock("nothing to do"), task.ErrTaskNotBlocked)
+}
+
+func TestTask_Blocked_CannotStart(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Deploy")
+	require.NoError(t, err)
+	require.NoError(t, tk.Block("Waiting on PR"))
+
+	assert.ErrorIs(t, tk.Start(), task.ErrTaskBlocked)
+}
+
+func TestTask_Complete_ClearsBlock(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Deploy")
+	require.NoError(t, err)
+	require.NoError(t, tk.Block("Waiting on PR"))
+
+	require.NoError(t, tk.Complete())
+
+	assert.True(t, tk.IsCompleted())
+	assert.Empty(t, tk.BlockedReason())
+	assert.Nil(t, tk.BlockedAt())
+}
@@ -0,0 +1,109 @@
+package task
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmptyChecklistItem    = errors.New("checklist item title cannot be empty")
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrChecklistIncomplete   = errors.New("all checklist items must be done before completing the task")
+)
+
+// ChecklistItem is a lightweight step inside a task.
+type ChecklistItem struct {
+	ID    uuid.UUID
+	Title string
+	Done  bool
+}
+
+// AddChecklistItem appends a new, not yet done, item to the checklist.
+func (t *Task) AddChecklistItem(title string) (ChecklistItem, error) {
+	if err := t.ensureChecklistEditable(); err != nil {
+		return ChecklistItem{}, err
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return ChecklistItem{}, ErrEmptyChecklistItem
+	}
+	item := ChecklistItem{ID: uuid.New(), Title: title}
+	t.checklist = append(t.checklist, item)
+	t.Touch()
+	return item, nil
+}
+
+// ToggleChecklistItem flips the done state of the item with the given ID.
+func (t *Task) ToggleChecklistItem(id uuid.UUID) (ChecklistItem, error) {
+	if err := t.ensureChecklistEditable(); err != nil {
+		return ChecklistItem{}, err
+	}
+	for i := range t.checklist {
+		if t.checklist[i].ID == id {
+			t.checklist[i].Done = !t.checklist[i].Done
+			t.Touch()
+			return t.checklist[i], nil
+		}
+	}
+	return ChecklistItem{}, ErrChecklistItemNotFound
+}
+
+// SetChecklistRequired controls whether every checklist item must be done
+// before the task can be completed.
+func (t *Task) SetChecklistRequired(required bool) error {
+	if t.IsArchived() {
+		return ErrTaskArchived
+	}
+	t.checklistRequired = required
+	t.Touch()
+	return nil
+}
+
+// ChecklistRequired returns true if completion requires all items to be done.
+func (t *Task) ChecklistRequired() bool {
+	return t.checklistRequired
+}
+
+// Checklist returns the task's checklist items in the order they were added.
+func (t *Task) Checklist() []ChecklistItem {
+	items := make([]ChecklistItem, len(t.checklist))
+	copy(items, t.checklist)
+	return items
+}
+
+// ChecklistProgress returns how many items are done out of the total.
+func (t *Task) ChecklistProgress() (done, total int) {
+	for _, item := range t.checklist {
+		if item.Done {
+			done++
+		}
+	}
+	return done, len(t.checklist)
+}
+
+// IsChecklistComplete returns true if every checklist item is done.
+// A task without checklist items is trivially complete.
+func (t *Task) IsChecklistComplete() bool {
+	done, total := t.ChecklistProgress()
+	return done == total
+}
+
+// RehydrateChecklist restores checklist items and the completion requirement
+// from persistence.
+func (t *Task) RehydrateChecklist(items []ChecklistItem, required bool) {
+	t.checklist = make([]ChecklistItem, len(items))
+	copy(t.checklist, items)
+	t.checklistRequired = required
+}
+
+func (t *Task) ensureChecklistEditable() error {
+	if t.IsArchived() {
+		return ErrTaskArchived
+	}
+	if t.IsCompleted() {
+		return ErrTaskAlreadyComplete
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package task_test
+
+import (
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_AddChecklistItem(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Release")
+	require.NoError(t, err)
+
+	first, err := tk.AddChecklistItem("  Tag version  ")
+	require.NoError(t, err)
+	second, err := tk.AddChecklistItem("Publish notes")
+	require.NoError(t, err)
+
+	items := tk.Checklist()
+	require.Len(t, items, 2)
+	assert.Equal(t, first, items[0])
+	assert.Equal(t, "Tag version", items[0].Title)
+	assert.False(t, items[0].Done)
+	assert.Equal(t, second.ID, items[1].ID)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	_, err = tk.AddChecklistItem("   ")
+	assert.ErrorIs(t, err, task.ErrEmptyChecklistItem)
+}
+
+func TestTask_ToggleChecklistItem(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Release")
+	require.NoError(t, err)
+	item, err := tk.AddChecklistItem("Tag version")
+	require.NoError(t, err)
+	_, err = tk.AddChecklistItem("Publish notes")
+	require.NoError(t, err)
+
+	toggled, err := tk.ToggleChecklistItem(item.ID)
+	require.NoError(t, err)
+	assert.True(t, toggled.Done)
+	done, total := tk.ChecklistProgress()
+	assert.Equal(t, 1, done)
+	assert.Equal(t, 2, total)
+
+	toggled, err = tk.ToggleChecklistItem(item.ID)
+	require.NoError(t, err)
+	assert.False(t, toggled.Done)
+	done, _ = tk.ChecklistProgress()
+	assert.Equal(t, 0, done)
+
+	_, err = tk.ToggleChecklistItem(uuid.New())
+	assert.ErrorIs(t, err, task.ErrChecklistItemNotFound)
+}
+
+func TestTask_Checklist_ReturnsCopy(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Release")
+	require.NoError(t, err)
+	_, err = tk.AddChecklistItem("Tag version")
+	require.NoError(t, err)
+
+	items := tk.Checklist()
+	items[0].Done = true
+
+	assert.False(t, tk.Checklist()[0].Done)
+}
+
+func TestTask_Checklist_ClosedTasks(t *testing.T) {
+	t.Run("completed", func(t *testing.T) {
+		tk, err := task.NewTask(uuid.New(), "Release")
+		require.NoError(t, err)
+		item, err := tk.AddChecklistItem("Tag version")
+		require.NoError(t, err)
+		require.NoError(t, tk.Complete())
+
+		_, err = tk.AddChecklistItem("Late step")
+		assert.ErrorIs(t, err, task.ErrTaskAlreadyComplete)
+		_, err = tk.ToggleChecklistItem(item.ID)
+		assert.ErrorIs(t, err, task.ErrTaskAlreadyComplete)
+	})
+
+	t.Run("archived", func(t *testing.T) {
+		tk, err := task.NewTask(uuid.New(), "Release")
+		require.NoError(t, err)
+		require.NoError(t, tk.Archive())
+
+		_, err = tk.AddChecklistItem("Tag version")
+		assert.ErrorIs(t, err, task.ErrTaskArchived)
+		assert.ErrorIs(t, tk.SetChecklistRequired(true), task.ErrTaskArchived)
+	})
+}
+
+func TestTask_Complete_ChecklistRequired(t *testing.T) {
+	newReleaseTask := func(t *testing.T) (*task.Task, task.ChecklistItem, task.ChecklistItem) {
+		tk, err := task.NewTask(uuid.New(), "Release")
+		require.NoError(t, err)
+		first, err := tk.AddChecklistItem("Tag version")
+		require.NoError(t, err)
+		second, err := tk.AddChecklistItem("Publish notes")
+		require.NoError(t, err)
+		return tk, first, second
+	}
+
+	t.Run("optional checklist does not block completion", func(t *testing.T) {
+		tk, _, _ := newReleaseTask(t)
+
+		require.NoError(t, tk.Complete())
+		assert.True(t, tk.IsCompleted())
+	})
+
+	t.Run("required checklist blocks until all items are done", func(t *testing.T) {
+		tk, first, second := newReleaseTask(t)
+		require.NoError(t, tk.SetChecklistRequired(true))
+
+		assert.ErrorIs(t, tk.Complete(), task.ErrChecklistIncomplete)
+
+		_, err := tk.ToggleChecklistItem(first.ID)
+		require.NoError(t, err)
+		assert.ErrorIs(t, tk.Complete(), task.ErrChecklistIncomplete)
+		assert.False(t, tk.IsCompleted())
+
+		_, err = tk.ToggleChecklistItem(second.ID)
+		require.NoError(t, err)
+		require.NoError(t, tk.Complete())
+		assert.True(t, tk.IsCompleted())
+	})
+
+	t.Run("required empty checklist allows completion", func(t *testing.T) {
+		tk, err := task.NewTask(uuid.New(), "Release")
+		require.NoError(t, err)
+		require.NoError(t, tk.SetChecklistRequired(true))
+
+		assert.NoError(t, tk.Complete())
+	})
+}
+
+func TestTask_NextOccurrence_ResetsChecklist(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Weekly review")
+	require.NoError(t, err)
+	require.NoError(t, tk.SetRecurrence(&task.Recurrence{Frequency: task.RecurrenceWeekly, Interval: 1}))
+	item, err := tk.AddChecklistItem("Clear inbox")
+	require.NoError(t, err)
+	require.NoError(t, tk.SetChecklistRequired(true))
+	_, err = tk.ToggleChecklistItem(item.ID)
+	require.NoError(t, err)
+	require.NoError(t, tk.Complete())
+
+	next, err := tk.NextOccurrence()
+	require.NoError(t, err)
+
+	items := next.Checklist()
+	require.Len(t, items, 1)
+	assert.Equal(t, "Clear inbox", items[0].Title)
+	assert.False(t, items[0].Done)
+	assert.NotEqual(t, item.ID, items[0].ID)
+	assert.True(t, next.ChecklistRequired())
+}
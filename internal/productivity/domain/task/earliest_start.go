@@ -0,0 +1,47 @@
+package task
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrEarliestStartAfterDue = errors.New("earliest start must not be after the due date")
+
+// EarliestStart returns the time before which the task must not be
+// scheduled, or nil when it can be scheduled any time.
+func (t *Task) EarliestStart() *time.Time {
+	return t.earliestStart
+}
+
+// SetEarliestStart keeps the task off the schedule until at, for work that
+// needs preparation first. A nil time clears the constraint. Together with
+// the due date it bounds where the task can be placed, so it must fall on or
+// before the due date's day.
+func (t *Task) SetEarliestStart(at *time.Time) error {
+	if t.IsArchived() {
+		return ErrTaskArchived
+	}
+	if !startsByDue(at, t.dueDate, t.LocalTime) {
+		return ErrEarliestStartAfterDue
+	}
+	t.earliestStart = at
+	t.Touch()
+	return nil
+}
+
+// RehydrateEarliestStart restores the earliest start from persistence.
+func (t *Task) RehydrateEarliestStart(at *time.Time) {
+	t.earliestStart = at
+}
+
+// startsByDue reports whether an earliest start falls on or before the day
+// the task is due, read in the task's time zone. Due dates are days, so an
+// earliest start later on the due day is allowed.
+func startsByDue(earliestStart, dueDate *time.Time, local func(time.Time) time.Time) bool {
+	if earliestStart == nil || dueDate == nil {
+		return true
+	}
+	due := local(*dueDate)
+	endOfDueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location()).AddDate(0, 0, 1)
+	return earliestStart.Before(endOfDueDay)
+}
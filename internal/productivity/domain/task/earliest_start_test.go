@@ -0,0 +1,75 @@
+package task_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_SetEarliestStart(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Write the launch post")
+	require.NoError(t, err)
+	assert.Nil(t, tk.EarliestStart())
+
+	due := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, tk.SetDueDate(&due))
+
+	// Later on the due day is still in time.
+	sameDay := time.Date(2025, 6, 10, 14, 0, 0, 0, time.UTC)
+	require.NoError(t, tk.SetEarliestStart(&sameDay))
+	assert.Equal(t, sameDay, *tk.EarliestStart())
+
+	dayAfter := time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC)
+	assert.ErrorIs(t, tk.SetEarliestStart(&dayAfter), task.ErrEarliestStartAfterDue)
+	assert.Equal(t, sameDay, *tk.EarliestStart())
+
+	require.NoError(t, tk.SetEarliestStart(nil))
+	assert.Nil(t, tk.EarliestStart())
+}
+
+func TestTask_SetDueDate_BeforeEarliestStart(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Review the draft")
+	require.NoError(t, err)
+
+	earliestStart := time.Date(2025, 6, 5, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, tk.SetEarliestStart(&earliestStart))
+
+	tooEarly := time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC)
+	assert.ErrorIs(t, tk.SetDueDate(&tooEarly), task.ErrEarliestStartAfterDue)
+	assert.Nil(t, tk.DueDate())
+
+	due := time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, tk.SetDueDate(&due))
+}
+
+func TestTask_SetEarliestStart_Archived(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Old task")
+	require.NoError(t, err)
+	require.NoError(t, tk.Archive())
+
+	at := time.Date(2025, 6, 5, 9, 0, 0, 0, time.UTC)
+	assert.ErrorIs(t, tk.SetEarliestStart(&at), task.ErrTaskArchived)
+}
+
+func TestTask_NextOccurrence_KeepsEarliestStartLead(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Weekly report")
+	require.NoError(t, err)
+	recurrence, err := task.NewRecurrence(task.RecurrenceWeekly, 1)
+	require.NoError(t, err)
+	require.NoError(t, tk.SetRecurrence(&recurrence))
+
+	due := time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, tk.SetDueDate(&due))
+	earliestStart := time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, tk.SetEarliestStart(&earliestStart))
+	require.NoError(t, tk.Complete())
+
+	next, err := tk.NextOccurrence()
+	require.NoError(t, err)
+	require.NotNil(t, next.EarliestStart())
+	assert.Equal(t, time.Date(2025, 6, 11, 9, 0, 0, 0, time.UTC), *next.EarliestStart())
+}
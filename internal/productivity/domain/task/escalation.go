@@ -0,0 +1,99 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+)
+
+// ErrInvalidEscalationRule is returned for malformed escalation rules.
+var ErrInvalidEscalationRule = errors.New("invalid escalation rule")
+
+// EscalationRule raises a task to Priority once its due date is at most
+// Before away. A zero Before applies once the task is due.
+type EscalationRule struct {
+	Before   time.Duration
+	Priority value_objects.Priority
+}
+
+// EscalationPolicy raises task priorities as due dates approach.
+type EscalationPolicy struct {
+	Rules []EscalationRule
+}
+
+// DefaultEscalationPolicy raises tasks to high one day before they are due
+// and to urgent once they are overdue.
+func DefaultEscalationPolicy() EscalationPolicy {
+	return EscalationPolicy{Rules: []EscalationRule{
+		{Before: 24 * time.Hour, Priority: value_objects.PriorityHigh},
+		{Before: 0, Priority: value_objects.PriorityUrgent},
+	}}
+}
+
+// ParseEscalationPolicy parses comma-separated "before:priority" rules, such
+// as "24h:high,0s:urgent". An empty string yields the default policy.
+func ParseEscalationPolicy(rules string) (EscalationPolicy, error) {
+	if strings.TrimSpace(rules) == "" {
+		return DefaultEscalationPolicy(), nil
+	}
+
+	var policy EscalationPolicy
+	for _, raw := range strings.Split(rules, ",") {
+		before, name, ok := strings.Cut(strings.TrimSpace(raw), ":")
+		if !ok {
+			return EscalationPolicy{}, fmt.Errorf("%w: %q", ErrInvalidEscalationRule, raw)
+		}
+		offset, err := time.ParseDuration(before)
+		if err != nil {
+			return EscalationPolicy{}, fmt.Errorf("%w: %q", ErrInvalidEscalationRule, raw)
+		}
+		priority, err := value_objects.ParsePriority(name)
+		if err != nil {
+			return EscalationPolicy{}, fmt.Errorf("%w: %q", ErrInvalidEscalationRule, raw)
+		}
+		policy.Rules = append(policy.Rules, EscalationRule{Before: offset, Priority: priority})
+	}
+	return policy, nil
+}
+
+// PriorityAt returns the highest priority required at now for a task due at
+// dueDate, and false if no rule applies yet.
+func (p EscalationPolicy) PriorityAt(dueDate, now time.Time) (value_objects.Priority, bool) {
+	remaining := dueDate.Sub(now)
+	required := value_objects.PriorityNone
+	matched := false
+	for _, rule := range p.Rules {
+		if remaining > rule.Before {
+			continue
+		}
+		if !matched || rule.Priority.Weight() > required.Weight() {
+			required = rule.Priority
+			matched = true
+		}
+	}
+	return required, matched
+}
+
+// EscalatePriority raises the task priority to what the policy requires at
+// now and emits a TaskPriorityEscalated event. Priorities are never lowered,
+// and tasks without a due date or that are closed are left alone. It returns
+// true if the priority changed.
+func (t *Task) EscalatePriority(policy EscalationPolicy, now time.Time) bool {
+	if t.dueDate == nil || t.IsCompleted() || t.IsArchived() {
+		return false
+	}
+
+	required, ok := policy.PriorityAt(*t.dueDate, now)
+	if !ok || required.Weight() <= t.priority.Weight() {
+		return false
+	}
+
+	previous := t.priority
+	t.priority = required
+	t.Touch()
+	t.AddDomainEvent(NewTaskPriorityEscalated(t.ID(), previous.String(), required.String(), *t.dueDate))
+	return true
+}
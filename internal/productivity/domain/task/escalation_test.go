@@ -0,0 +1,105 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalationPolicy_PriorityAt(t *testing.T) {
+	policy := DefaultEscalationPolicy()
+	due := time.Date(2024, time.March, 10, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected value_objects.Priority
+		matched  bool
+	}{
+		{"more than a day out", due.Add(-25 * time.Hour), value_objects.PriorityNone, false},
+		{"exactly a day out", due.Add(-24 * time.Hour), value_objects.PriorityHigh, true},
+		{"hours before due", due.Add(-time.Hour), value_objects.PriorityHigh, true},
+		{"at the due time", due, value_objects.PriorityUrgent, true},
+		{"overdue", due.Add(48 * time.Hour), value_objects.PriorityUrgent, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priority, matched := policy.PriorityAt(due, tt.now)
+			assert.Equal(t, tt.matched, matched)
+			assert.Equal(t, tt.expected, priority)
+		})
+	}
+}
+
+func TestTask_EscalatePriority(t *testing.T) {
+	due := time.Date(2024, time.March, 10, 15, 0, 0, 0, time.UTC)
+	policy := DefaultEscalationPolicy()
+
+	newTask := func(t *testing.T, priority value_objects.Priority) *Task {
+		t.Helper()
+		tk, err := NewTask(uuid.New(), "Ship release")
+		require.NoError(t, err)
+		require.NoError(t, tk.SetDueDate(&due))
+		require.NoError(t, tk.SetPriority(priority))
+		tk.ClearDomainEvents()
+		return tk
+	}
+
+	t.Run("raises priority and emits an event", func(t *testing.T) {
+		tk := newTask(t, value_objects.PriorityMedium)
+
+		assert.True(t, tk.EscalatePriority(policy, due.Add(-12*time.Hour)))
+		assert.Equal(t, value_objects.PriorityHigh, tk.Priority())
+
+		events := tk.DomainEvents()
+		require.Len(t, events, 1)
+		escalated, ok := events[0].(TaskPriorityEscalated)
+		require.True(t, ok)
+		assert.Equal(t, "medium", escalated.FromPriority)
+		assert.Equal(t, "high", escalated.ToPriority)
+		assert.Equal(t, due, escalated.DueAt)
+		assert.Equal(t, RoutingKeyEscalated, escalated.RoutingKey())
+	})
+
+	t.Run("never lowers priority", func(t *testing.T) {
+		tk := newTask(t, value_objects.PriorityUrgent)
+
+		assert.False(t, tk.EscalatePriority(policy, due.Add(-12*time.Hour)))
+		assert.Equal(t, value_objects.PriorityUrgent, tk.Priority())
+		assert.Empty(t, tk.DomainEvents())
+	})
+
+	t.Run("ignores completed tasks and tasks without due date", func(t *testing.T) {
+		completed := newTask(t, value_objects.PriorityLow)
+		require.NoError(t, completed.Complete())
+		assert.False(t, completed.EscalatePriority(policy, due))
+
+		undated, err := NewTask(uuid.New(), "Someday")
+		require.NoError(t, err)
+		assert.False(t, undated.EscalatePriority(policy, due))
+	})
+}
+
+func TestParseEscalationPolicy(t *testing.T) {
+	policy, err := ParseEscalationPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultEscalationPolicy(), policy)
+
+	policy, err = ParseEscalationPolicy("72h:medium, 24h:high, 0s:urgent")
+	require.NoError(t, err)
+	assert.Equal(t, []EscalationRule{
+		{Before: 72 * time.Hour, Priority: value_objects.PriorityMedium},
+		{Before: 24 * time.Hour, Priority: value_objects.PriorityHigh},
+		{Before: 0, Priority: value_objects.PriorityUrgent},
+	}, policy.Rules)
+
+	for _, invalid := range []string{"24h", "soon:high", "24h:critical"} {
+		_, err := ParseEscalationPolicy(invalid)
+		assert.ErrorIs(t, err, ErrInvalidEscalationRule, invalid)
+	}
+}
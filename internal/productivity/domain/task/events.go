@@ -1,6 +1,8 @@
 package task
 
 import (
+	"time"
+
 	"github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/google/uuid"
 )
@@ -13,6 +15,14 @@ const (
 	RoutingKeyUpdated   = "core.task.updated"
 	RoutingKeyCompleted = "core.task.completed"
 	RoutingKeyArchived  = "core.task.archived"
+	RoutingKeyBlocked   = "core.task.blocked"
+	RoutingKeyUnblocked = "core.task.unblocked"
+	RoutingKeyWaiting   = "core.task.waiting"
+	RoutingKeyResolved  = "core.task.waiting_resolved"
+	RoutingKeyFollowUp  = "core.task.follow_up_due"
+	RoutingKeyRecurred  = "core.task.recurred"
+	RoutingKeyReminder  = "core.task.reminder_due"
+	RoutingKeyEscalated = "core.task.priority_escalated"
 )
 
 // TaskCreated is emitted when a new task is created.
@@ -80,3 +90,137 @@ func NewTaskArchived(taskID uuid.UUID) TaskArchived {
 		BaseEvent: domain.NewBaseEvent(taskID, AggregateType, RoutingKeyArchived),
 	}
 }
+
+// TaskBlocked is emitted when a task is marked as blocked.
+type TaskBlocked struct {
+	domain.BaseEvent
+	Reason string `json:"reason,omitempty"`
+}
+
+// NewTaskBlocked creates a TaskBlocked event.
+func NewTaskBlocked(taskID uuid.UUID, reason string) TaskBlocked {
+	return TaskBlocked{
+		BaseEvent: domain.NewBaseEvent(taskID, AggregateType, RoutingKeyBlocked),
+		Reason:    reason,
+	}
+}
+
+// TaskUnblocked is emitted when a blocked task is unblocked.
+type TaskUnblocked struct {
+	domain.BaseEvent
+	Reason string `json:"reason,omitempty"` // What the task was blocked by
+	Note   string `json:"note,omitempty"`   // How the block was resolved
+}
+
+// NewTaskUnblocked creates a TaskUnblocked event.
+func NewTaskUnblocked(taskID uuid.UUID, reason, note string) TaskUnblocked {
+	return TaskUnblocked{
+		BaseEvent: domain.NewBaseEvent(taskID, AggregateType, RoutingKeyUnblocked),
+		Reason:    reason,
+		Note:      note,
+	}
+}
+
+// TaskWaiting is emitted when a task starts waiting on a person.
+type TaskWaiting struct {
+	domain.BaseEvent
+	WaitingOn  string    `json:"waiting_on"`
+	FollowUpAt time.Time `json:"follow_up_at"`
+}
+
+// NewTaskWaiting creates a TaskWaiting event.
+func NewTaskWaiting(taskID uuid.UUID, waitingOn string, followUpAt time.Time) TaskWaiting {
+	return TaskWaiting{
+		BaseEvent:  domain.NewBaseEvent(taskID, AggregateType, RoutingKeyWaiting),
+		WaitingOn:  waitingOn,
+		FollowUpAt: followUpAt,
+	}
+}
+
+// TaskWaitingResolved is emitted when a waiting task is no longer waiting.
+type TaskWaitingResolved struct {
+	domain.BaseEvent
+	WaitingOn string `json:"waiting_on"`
+	Note      string `json:"note,omitempty"` // How the wait was resolved
+}
+
+// NewTaskWaitingResolved creates a TaskWaitingResolved event.
+func NewTaskWaitingResolved(taskID uuid.UUID, waitingOn, note string) TaskWaitingResolved {
+	return TaskWaitingResolved{
+		BaseEvent: domain.NewBaseEvent(taskID, AggregateType, RoutingKeyResolved),
+		WaitingOn: waitingOn,
+		Note:      note,
+	}
+}
+
+// TaskFollowUpDue is emitted when the follow-up reminder of a waiting task
+// is dispatched.
+type TaskFollowUpDue struct {
+	domain.BaseEvent
+	Title        string    `json:"title"`
+	WaitingOn    string    `json:"waiting_on"`
+	WaitingSince time.Time `json:"waiting_since"`
+}
+
+// NewTaskFollowUpDue creates a TaskFollowUpDue event.
+func NewTaskFollowUpDue(taskID uuid.UUID, title, waitingOn string, waitingSince time.Time) TaskFollowUpDue {
+	return TaskFollowUpDue{
+		BaseEvent:    domain.NewBaseEvent(taskID, AggregateType, RoutingKeyFollowUp),
+		Title:        title,
+		WaitingOn:    waitingOn,
+		WaitingSince: waitingSince,
+	}
+}
+
+// TaskRecurred is emitted when completing a recurring task spawns its next occurrence.
+type TaskRecurred struct {
+	domain.BaseEvent
+	NextTaskID uuid.UUID `json:"next_task_id"`
+	NextDueAt  time.Time `json:"next_due_at"`
+}
+
+// NewTaskRecurred creates a TaskRecurred event.
+func NewTaskRecurred(taskID, nextTaskID uuid.UUID, nextDueAt time.Time) TaskRecurred {
+	return TaskRecurred{
+		BaseEvent:  domain.NewBaseEvent(taskID, AggregateType, RoutingKeyRecurred),
+		NextTaskID: nextTaskID,
+		NextDueAt:  nextDueAt,
+	}
+}
+
+// TaskReminderDue is emitted when a task reminder is dispatched.
+type TaskReminderDue struct {
+	domain.BaseEvent
+	Title         string    `json:"title"`
+	DueAt         time.Time `json:"due_at"`
+	OffsetMinutes int       `json:"offset_minutes"`
+}
+
+// NewTaskReminderDue creates a TaskReminderDue event.
+func NewTaskReminderDue(taskID uuid.UUID, title string, dueAt time.Time, offset time.Duration) TaskReminderDue {
+	return TaskReminderDue{
+		BaseEvent:     domain.NewBaseEvent(taskID, AggregateType, RoutingKeyReminder),
+		Title:         title,
+		DueAt:         dueAt,
+		OffsetMinutes: int(offset / time.Minute),
+	}
+}
+
+// TaskPriorityEscalated is emitted when a task's priority is raised because
+// its due date is approaching.
+type TaskPriorityEscalated struct {
+	domain.BaseEvent
+	FromPriority string    `json:"from_priority"`
+	ToPriority   string    `json:"to_priority"`
+	DueAt        time.Time `json:"due_at"`
+}
+
+// NewTaskPriorityEscalated creates a TaskPriorityEscalated event.
+func NewTaskPriorityEscalated(taskID uuid.UUID, from, to string, dueAt time.Time) TaskPriorityEscalated {
+	return TaskPriorityEscalated{
+		BaseEvent:    domain.NewBaseEvent(taskID, AggregateType, RoutingKeyEscalated),
+		FromPriority: from,
+		ToPriority:   to,
+		DueAt:        dueAt,
+	}
+}
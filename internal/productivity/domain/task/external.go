@@ -0,0 +1,21 @@
+package task
+
+import "strings"
+
+// ExternalID returns the ID the task has in the app it was imported from, or
+// an empty string for tasks created in Orbita.
+func (t *Task) ExternalID() string {
+	return t.externalID
+}
+
+// SetExternalID records the ID the task has in the app it was imported from,
+// so later imports update the task instead of creating a duplicate.
+func (t *Task) SetExternalID(externalID string) {
+	t.externalID = strings.TrimSpace(externalID)
+	t.Touch()
+}
+
+// RehydrateExternalID restores the external ID from persistence.
+func (t *Task) RehydrateExternalID(externalID string) {
+	t.externalID = externalID
+}
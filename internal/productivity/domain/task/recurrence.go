@@ -0,0 +1,114 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRecurrence = errors.New("invalid recurrence")
+	ErrTaskNotRecurring  = errors.New("task is not recurring")
+	ErrTaskNotCompleted  = errors.New("task is not completed")
+)
+
+// RecurrenceFrequency represents how often a recurring task repeats.
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "daily"
+	RecurrenceWeekly  RecurrenceFrequency = "weekly"
+	RecurrenceMonthly RecurrenceFrequency = "monthly"
+)
+
+// IsValid checks if the frequency is valid.
+func (f RecurrenceFrequency) IsValid() bool {
+	switch f {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return true
+	default:
+		return false
+	}
+}
+
+// Recurrence describes how a task repeats, e.g. every 2 weeks.
+type Recurrence struct {
+	Frequency RecurrenceFrequency
+	Interval  int
+}
+
+// NewRecurrence creates a recurrence rule. An interval of 0 is treated as 1.
+func NewRecurrence(frequency RecurrenceFrequency, interval int) (Recurrence, error) {
+	if !frequency.IsValid() || interval < 0 {
+		return Recurrence{}, ErrInvalidRecurrence
+	}
+	if interval == 0 {
+		interval = 1
+	}
+	return Recurrence{Frequency: frequency, Interval: interval}, nil
+}
+
+// Next returns the occurrence following the given time.
+func (r Recurrence) Next(from time.Time) time.Time {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	switch r.Frequency {
+	case RecurrenceWeekly:
+		return from.AddDate(0, 0, 7*interval)
+	case RecurrenceMonthly:
+		return from.AddDate(0, interval, 0)
+	default:
+		return from.AddDate(0, 0, interval)
+	}
+}
+
+// recurrenceUnits maps each frequency to the unit used in "every N <unit>".
+var recurrenceUnits = map[RecurrenceFrequency]string{
+	RecurrenceDaily:   "days",
+	RecurrenceWeekly:  "weeks",
+	RecurrenceMonthly: "months",
+}
+
+// ParseRecurrence parses a rule written as a frequency, such as "weekly", or
+// as an interval, such as "every 2 weeks". It accepts what String returns.
+func ParseRecurrence(value string) (Recurrence, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if frequency := RecurrenceFrequency(value); frequency.IsValid() {
+		return NewRecurrence(frequency, 1)
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) != 3 || fields[0] != "every" {
+		return Recurrence{}, ErrInvalidRecurrence
+	}
+	interval, err := strconv.Atoi(fields[1])
+	if err != nil || interval < 1 {
+		return Recurrence{}, ErrInvalidRecurrence
+	}
+	unit := strings.TrimSuffix(fields[2], "s") + "s"
+	for frequency, name := range recurrenceUnits {
+		if name == unit {
+			return NewRecurrence(frequency, interval)
+		}
+	}
+	return Recurrence{}, ErrInvalidRecurrence
+}
+
+// String returns the rule as ParseRecurrence reads it, e.g. "monthly" or
+// "every 2 weeks".
+func (r Recurrence) String() string {
+	if r.Interval <= 1 {
+		return string(r.Frequency)
+	}
+	return fmt.Sprintf("every %d %s", r.Interval, recurrenceUnits[r.Frequency])
+}
+
+// RehydrateRecurrence restores the recurrence rule from persistence.
+func (t *Task) RehydrateRecurrence(recurrence *Recurrence) {
+	t.recurrence = recurrence
+}
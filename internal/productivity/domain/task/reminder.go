@@ -0,0 +1,205 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	ErrInvalidReminder   = errors.New("reminder offset must be at least one minute")
+	ErrReminderNotFound  = errors.New("reminder not found")
+	ErrInvalidQuietHours = errors.New("invalid quiet hours")
+)
+
+// Reminder notifies the user a fixed offset before the task is due.
+type Reminder struct {
+	Offset time.Duration
+	SentAt *time.Time
+}
+
+// IsSent returns true if the reminder has already been dispatched.
+func (r Reminder) IsSent() bool {
+	return r.SentAt != nil
+}
+
+// RemindAt returns when the reminder should fire for the given due date.
+func (r Reminder) RemindAt(dueDate time.Time) time.Time {
+	return dueDate.Add(-r.Offset)
+}
+
+// QuietHours is a daily window during which reminders are held back.
+// Start and End are offsets from midnight in the location of the time being
+// checked; a window with End before Start spans midnight (e.g. 22:00-07:00).
+// A zero-length window disables quiet hours.
+type QuietHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseQuietHours creates quiet hours from "HH:MM" clock times.
+// Empty values disable quiet hours.
+func ParseQuietHours(start, end string) (QuietHours, error) {
+	if start == "" && end == "" {
+		return QuietHours{}, nil
+	}
+	startOffset, err := parseClock(start)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	endOffset, err := parseClock(end)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	return QuietHours{Start: startOffset, End: endOffset}, nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidQuietHours, value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// IsEnabled returns true if the window has a non-zero length.
+func (q QuietHours) IsEnabled() bool {
+	return q.Start != q.End
+}
+
+// Contains returns true if t falls inside the quiet window.
+func (q QuietHours) Contains(t time.Time) bool {
+	if !q.IsEnabled() {
+		return false
+	}
+	offset := t.Sub(midnight(t))
+	if q.Start < q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	return offset >= q.Start || offset < q.End
+}
+
+// Defer returns t unchanged when it is outside the quiet window, otherwise the
+// moment the window ends.
+func (q QuietHours) Defer(t time.Time) time.Time {
+	if !q.Contains(t) {
+		return t
+	}
+	day := midnight(t)
+	if q.Start > q.End && t.Sub(day) >= q.Start {
+		// Window spans midnight and t is before it, so it ends tomorrow.
+		day = day.AddDate(0, 0, 1)
+	}
+	return day.Add(q.End)
+}
+
+// Release returns when a notification scheduled for at may go out, given the
+// current time now. One that came due earlier is sent now, unless now is
+// inside the quiet window, in which case it waits for the window to end.
+func (q QuietHours) Release(at, now time.Time) time.Time {
+	if at.Before(now) {
+		at = now
+	}
+	return q.Defer(at)
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// AddReminder attaches a reminder that fires offset before the due date.
+// Offsets are truncated to whole minutes; adding an existing offset is a no-op.
+func (t *Task) AddReminder(offset time.Duration) error {
+	if t.IsArchived() {
+		return ErrTaskArchived
+	}
+	offset = offset.Truncate(time.Minute)
+	if offset <= 0 {
+		return ErrInvalidReminder
+	}
+	for _, r := range t.reminders {
+		if r.Offset == offset {
+			return nil
+		}
+	}
+	t.reminders = append(t.reminders, Reminder{Offset: offset})
+	sort.Slice(t.reminders, func(i, j int) bool {
+		return t.reminders[i].Offset > t.reminders[j].Offset
+	})
+	t.Touch()
+	return nil
+}
+
+// RemoveReminder detaches the reminder with the given offset.
+func (t *Task) RemoveReminder(offset time.Duration) error {
+	if t.IsArchived() {
+		return ErrTaskArchived
+	}
+	for i, r := range t.reminders {
+		if r.Offset == offset {
+			t.reminders = append(t.reminders[:i], t.reminders[i+1:]...)
+			t.Touch()
+			return nil
+		}
+	}
+	return ErrReminderNotFound
+}
+
+// Reminders returns the task's reminders, earliest firing first.
+func (t *Task) Reminders() []Reminder {
+	reminders := make([]Reminder, len(t.reminders))
+	copy(reminders, t.reminders)
+	return reminders
+}
+
+// DueReminders returns unsent reminders whose time has come by now. None are
+// returned while now is inside quiet hours, and reminders that would fire
+// inside them are deferred until they end. Tasks without a due date, or
+// that are completed or archived, have no due reminders.
+func (t *Task) DueReminders(now time.Time, quiet QuietHours) []Reminder {
+	if t.dueDate == nil || t.IsCompleted() || t.IsArchived() {
+		return nil
+	}
+	due := make([]Reminder, 0)
+	for _, r := range t.reminders {
+		if r.IsSent() {
+			continue
+		}
+		remindAt := quiet.Release(r.RemindAt(t.dueDate.In(now.Location())), now)
+		if !remindAt.After(now) {
+			due = append(due, r)
+		}
+	}
+	return due
+}
+
+// MarkReminderSent records that the reminder with the given offset was dispatched.
+func (t *Task) MarkReminderSent(offset time.Duration, sentAt time.Time) error {
+	for i, r := range t.reminders {
+		if r.Offset != offset {
+			continue
+		}
+		if r.IsSent() {
+			return nil // Idempotent
+		}
+		t.reminders[i].SentAt = &sentAt
+		t.Touch()
+		var dueAt time.Time
+		if t.dueDate != nil {
+			dueAt = *t.dueDate
+		}
+		t.AddDomainEvent(NewTaskReminderDue(t.ID(), t.title, dueAt, offset))
+		return nil
+	}
+	return ErrReminderNotFound
+}
+
+// RehydrateReminders restores reminders from persistence.
+func (t *Task) RehydrateReminders(reminders []Reminder) {
+	t.reminders = make([]Reminder, len(reminders))
+	copy(t.reminders, reminders)
+	sort.Slice(t.reminders, func(i, j int) bool {
+		return t.reminders[i].Offset > t.reminders[j].Offset
+	})
+}
@@ -0,0 +1,194 @@
+package task_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTaskDueAt(t *testing.T, due time.Time, offsets ...time.Duration) *task.Task {
+	t.Helper()
+	tk, err := task.NewTask(uuid.New(), "Submit report")
+	require.NoError(t, err)
+	require.NoError(t, tk.SetDueDate(&due))
+	for _, offset := range offsets {
+		require.NoError(t, tk.AddReminder(offset))
+	}
+	tk.ClearDomainEvents()
+	return tk
+}
+
+func TestTask_AddReminder(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Task")
+	require.NoError(t, err)
+
+	require.NoError(t, tk.AddReminder(time.Hour))
+	require.NoError(t, tk.AddReminder(24*time.Hour))
+	require.NoError(t, tk.AddReminder(time.Hour+30*time.Second)) // truncated to an existing offset
+
+	reminders := tk.Reminders()
+	require.Len(t, reminders, 2)
+	assert.Equal(t, 24*time.Hour, reminders[0].Offset)
+	assert.Equal(t, time.Hour, reminders[1].Offset)
+
+	assert.ErrorIs(t, tk.AddReminder(30*time.Second), task.ErrInvalidReminder)
+	assert.ErrorIs(t, tk.AddReminder(-time.Hour), task.ErrInvalidReminder)
+
+	require.NoError(t, tk.RemoveReminder(time.Hour))
+	assert.Len(t, tk.Reminders(), 1)
+	assert.ErrorIs(t, tk.RemoveReminder(time.Hour), task.ErrReminderNotFound)
+
+	require.NoError(t, tk.Archive())
+	assert.ErrorIs(t, tk.AddReminder(time.Hour), task.ErrTaskArchived)
+}
+
+func TestTask_DueReminders(t *testing.T) {
+	due := time.Date(2024, time.March, 10, 15, 0, 0, 0, time.UTC)
+
+	t.Run("fires each offset before the due date", func(t *testing.T) {
+		tk := newTaskDueAt(t, due, 24*time.Hour, time.Hour)
+
+		assert.Empty(t, tk.DueReminders(due.Add(-24*time.Hour-time.Minute), task.QuietHours{}))
+
+		dayBefore := tk.DueReminders(due.Add(-24*time.Hour), task.QuietHours{})
+		require.Len(t, dayBefore, 1)
+		assert.Equal(t, 24*time.Hour, dayBefore[0].Offset)
+
+		assert.Len(t, tk.DueReminders(due.Add(-time.Hour), task.QuietHours{}), 2)
+	})
+
+	t.Run("skips sent reminders", func(t *testing.T) {
+		tk := newTaskDueAt(t, due, 24*time.Hour, time.Hour)
+		now := due.Add(-time.Hour)
+
+		require.NoError(t, tk.MarkReminderSent(24*time.Hour, now))
+		pending := tk.DueReminders(now, task.QuietHours{})
+		require.Len(t, pending, 1)
+		assert.Equal(t, time.Hour, pending[0].Offset)
+
+		events := tk.DomainEvents()
+		require.Len(t, events, 1)
+		reminderEvent, ok := events[0].(task.TaskReminderDue)
+		require.True(t, ok)
+		assert.Equal(t, task.RoutingKeyReminder, reminderEvent.RoutingKey())
+		assert.Equal(t, 24*60, reminderEvent.OffsetMinutes)
+		assert.Equal(t, due, reminderEvent.DueAt)
+
+		// Marking twice is a no-op.
+		require.NoError(t, tk.MarkReminderSent(24*time.Hour, now))
+		assert.Len(t, tk.DomainEvents(), 1)
+		assert.ErrorIs(t, tk.MarkReminderSent(2*time.Hour, now), task.ErrReminderNotFound)
+	})
+
+	t.Run("moving the due date re-arms sent reminders", func(t *testing.T) {
+		tk := newTaskDueAt(t, due, time.Hour)
+		require.NoError(t, tk.MarkReminderSent(time.Hour, due.Add(-time.Hour)))
+
+		later := due.AddDate(0, 0, 1)
+		require.NoError(t, tk.SetDueDate(&later))
+		assert.False(t, tk.Reminders()[0].IsSent())
+		assert.Len(t, tk.DueReminders(later.Add(-time.Hour), task.QuietHours{}), 1)
+	})
+
+	t.Run("no reminders without a due date or once completed", func(t *testing.T) {
+		undated, err := task.NewTask(uuid.New(), "Someday")
+		require.NoError(t, err)
+		require.NoError(t, undated.AddReminder(time.Hour))
+		assert.Empty(t, undated.DueReminders(due, task.QuietHours{}))
+
+		done := newTaskDueAt(t, due, time.Hour)
+		require.NoError(t, done.Complete())
+		assert.Empty(t, done.DueReminders(due, task.QuietHours{}))
+	})
+
+	t.Run("defers reminders that fall in quiet hours", func(t *testing.T) {
+		quiet, err := task.ParseQuietHours("22:00", "07:00")
+		require.NoError(t, err)
+
+		// Due at 08:00, a 9h reminder would fire at 23:00 the night before.
+		earlyDue := time.Date(2024, time.March, 11, 8, 0, 0, 0, time.UTC)
+		tk := newTaskDueAt(t, earlyDue, 9*time.Hour)
+
+		assert.Empty(t, tk.DueReminders(earlyDue.Add(-9*time.Hour), quiet))
+		assert.Empty(t, tk.DueReminders(earlyDue.Add(-time.Hour-time.Minute), quiet))
+		assert.Len(t, tk.DueReminders(earlyDue.Add(-time.Hour), quiet), 1)
+	})
+
+	t.Run("holds overdue reminders dispatched inside quiet hours", func(t *testing.T) {
+		quiet, err := task.ParseQuietHours("22:00", "07:00")
+		require.NoError(t, err)
+
+		// The 1h reminder came due at 21:30, before the window, but the
+		// dispatcher only gets to it at 23:00.
+		lateDue := time.Date(2024, time.March, 10, 22, 30, 0, 0, time.UTC)
+		tk := newTaskDueAt(t, lateDue, time.Hour)
+
+		assert.Empty(t, tk.DueReminders(lateDue.Add(30*time.Minute), quiet))
+		assert.Empty(t, tk.DueReminders(lateDue.Add(8*time.Hour), quiet))
+		assert.Len(t, tk.DueReminders(lateDue.Add(8*time.Hour+30*time.Minute), quiet), 1)
+	})
+}
+
+func TestQuietHours_Defer(t *testing.T) {
+	day := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	overnight, err := task.ParseQuietHours("22:00", "07:00")
+	require.NoError(t, err)
+	afternoon, err := task.ParseQuietHours("13:00", "14:30")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		quiet    task.QuietHours
+		at       time.Time
+		expected time.Time
+	}{
+		{"before overnight window", overnight, day.Add(21 * time.Hour), day.Add(21 * time.Hour)},
+		{"late evening", overnight, day.Add(23 * time.Hour), day.AddDate(0, 0, 1).Add(7 * time.Hour)},
+		{"start of window", overnight, day.Add(22 * time.Hour), day.AddDate(0, 0, 1).Add(7 * time.Hour)},
+		{"early morning", overnight, day.Add(3 * time.Hour), day.Add(7 * time.Hour)},
+		{"end of window", overnight, day.Add(7 * time.Hour), day.Add(7 * time.Hour)},
+		{"inside same-day window", afternoon, day.Add(13*time.Hour + 15*time.Minute), day.Add(14*time.Hour + 30*time.Minute)},
+		{"outside same-day window", afternoon, day.Add(15 * time.Hour), day.Add(15 * time.Hour)},
+		{"disabled", task.QuietHours{}, day.Add(23 * time.Hour), day.Add(23 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.quiet.Defer(tt.at))
+		})
+	}
+}
+
+func TestQuietHours_Release(t *testing.T) {
+	day := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	overnight, err := task.ParseQuietHours("22:00", "07:00")
+	require.NoError(t, err)
+
+	// Came due before the window, released inside it
+	assert.Equal(t, day.AddDate(0, 0, 1).Add(7*time.Hour), overnight.Release(day.Add(21*time.Hour), day.Add(23*time.Hour)))
+	// Came due before now, outside the window
+	assert.Equal(t, day.Add(21*time.Hour), overnight.Release(day.Add(20*time.Hour), day.Add(21*time.Hour)))
+	// Not due yet
+	assert.Equal(t, day.Add(21*time.Hour), overnight.Release(day.Add(21*time.Hour), day.Add(20*time.Hour)))
+}
+
+func TestParseQuietHours(t *testing.T) {
+	quiet, err := task.ParseQuietHours("", "")
+	require.NoError(t, err)
+	assert.False(t, quiet.IsEnabled())
+
+	quiet, err = task.ParseQuietHours("22:30", "06:45")
+	require.NoError(t, err)
+	assert.Equal(t, 22*time.Hour+30*time.Minute, quiet.Start)
+	assert.Equal(t, 6*time.Hour+45*time.Minute, quiet.End)
+
+	_, err = task.ParseQuietHours("25:00", "07:00")
+	assert.ErrorIs(t, err, task.ErrInvalidQuietHours)
+	_, err = task.ParseQuietHours("22:00", "")
+	assert.ErrorIs(t, err, task.ErrInvalidQuietHours)
+}
@@ -2,6 +2,9 @@ package task
 
 import (
 	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
 
 	"github.com/google/uuid"
 )
@@ -14,3 +17,50 @@ type Repository interface {
 	FindPending(ctx context.Context, userID uuid.UUID) ([]*Task, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
+
+// ReminderRepository finds open tasks across all users with unsent reminders
+// scheduled at or before the given time.
+type ReminderRepository interface {
+	FindWithPendingReminders(ctx context.Context, until time.Time, limit int) ([]*Task, error)
+}
+
+// FollowUpRepository finds tasks across all users that are waiting on
+// someone and have an unsent follow-up reminder due at or before the given
+// time.
+type FollowUpRepository interface {
+	FindWithDueFollowUps(ctx context.Context, until time.Time, limit int) ([]*Task, error)
+}
+
+// EscalationRepository finds open tasks across all users that are due at or
+// before the given time and have a priority below the given one.
+type EscalationRepository interface {
+	FindDueBelowPriority(ctx context.Context, until time.Time, below value_objects.Priority, limit int) ([]*Task, error)
+}
+
+// RetentionRepository finds completed tasks across all users that were
+// completed at or before the given time.
+type RetentionRepository interface {
+	FindCompletedBefore(ctx context.Context, before time.Time, limit int) ([]*Task, error)
+}
+
+// ExternalIDRepository finds tasks by the ID they carry in the app they were
+// imported from.
+type ExternalIDRepository interface {
+	// FindByExternalID returns nil when the user has no task with the ID.
+	FindByExternalID(ctx context.Context, userID uuid.UUID, externalID string) (*Task, error)
+}
+
+// Iterator streams a user's tasks without loading them all into memory.
+type Iterator interface {
+	// IterateTasks calls fn for each of the user's tasks. Iteration stops at
+	// the first error returned by fn, which IterateTasks then returns.
+	IterateTasks(ctx context.Context, userID uuid.UUID, fn func(*Task) error) error
+}
+
+// SearchRepository finds a user's tasks by full-text search.
+type SearchRepository interface {
+	// Search returns up to limit of the user's tasks whose title or
+	// description contains every word of query, most relevant first. Words
+	// match as prefixes, ignoring case.
+	Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*Task, error)
+}
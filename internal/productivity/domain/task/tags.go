@@ -0,0 +1,77 @@
+package task
+
+import (
+	"sort"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/domain"
+)
+
+// Tags returns the task's tags in alphabetical order.
+func (t *Task) Tags() []string {
+	tags := make([]string, len(t.tags))
+	copy(tags, t.tags)
+	return tags
+}
+
+// HasTag reports whether the task carries the tag.
+func (t *Task) HasTag(tag string) bool {
+	tag, err := domain.NormalizeTag(tag)
+	if err != nil {
+		return false
+	}
+	_, found := t.tagIndex(tag)
+	return found
+}
+
+// AddTag tags the task. It reports false when the task already had the tag.
+func (t *Task) AddTag(tag string) (bool, error) {
+	if t.IsArchived() {
+		return false, ErrTaskArchived
+	}
+	tag, err := domain.NormalizeTag(tag)
+	if err != nil {
+		return false, err
+	}
+
+	i, found := t.tagIndex(tag)
+	if found {
+		return false, nil
+	}
+	t.tags = append(t.tags, "")
+	copy(t.tags[i+1:], t.tags[i:])
+	t.tags[i] = tag
+	t.Touch()
+	return true, nil
+}
+
+// RemoveTag removes a tag from the task. It reports false when the task did
+// not have the tag.
+func (t *Task) RemoveTag(tag string) (bool, error) {
+	if t.IsArchived() {
+		return false, ErrTaskArchived
+	}
+	tag, err := domain.NormalizeTag(tag)
+	if err != nil {
+		return false, err
+	}
+
+	i, found := t.tagIndex(tag)
+	if !found {
+		return false, nil
+	}
+	t.tags = append(t.tags[:i], t.tags[i+1:]...)
+	t.Touch()
+	return true, nil
+}
+
+// RehydrateTags restores the task's tags from persistence.
+func (t *Task) RehydrateTags(tags []string) {
+	t.tags = make([]string, len(tags))
+	copy(t.tags, tags)
+	sort.Strings(t.tags)
+}
+
+func (t *Task) tagIndex(tag string) (int, bool) {
+	i := sort.SearchStrings(t.tags, tag)
+	return i, i < len(t.tags) && t.tags[i] == tag
+}
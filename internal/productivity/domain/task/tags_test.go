@@ -0,0 +1,44 @@
+package task_test
+
+import (
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_Tags(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Release")
+	require.NoError(t, err)
+
+	added, err := tk.AddTag("Work")
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = tk.AddTag("home")
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = tk.AddTag(" work ")
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, []string{"home", "work"}, tk.Tags())
+	assert.True(t, tk.HasTag("WORK"))
+
+	removed, err := tk.RemoveTag("home")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = tk.RemoveTag("home")
+	require.NoError(t, err)
+	assert.False(t, removed)
+	assert.Equal(t, []string{"work"}, tk.Tags())
+
+	_, err = tk.AddTag("two words")
+	assert.ErrorIs(t, err, sharedDomain.ErrInvalidTag)
+
+	require.NoError(t, tk.Archive())
+	_, err = tk.AddTag("later")
+	assert.ErrorIs(t, err, task.ErrTaskArchived)
+}
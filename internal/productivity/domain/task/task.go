@@ -24,6 +24,8 @@ const (
 	StatusInProgress
 	StatusCompleted
 	StatusArchived
+	StatusBlocked
+	StatusWaiting
 )
 
 func (s Status) String() string {
@@ -36,6 +38,10 @@ func (s Status) String() string {
 		return "completed"
 	case StatusArchived:
 		return "archived"
+	case StatusBlocked:
+		return "blocked"
+	case StatusWaiting:
+		return "waiting"
 	default:
 		return "unknown"
 	}
@@ -52,6 +58,31 @@ type Task struct {
 	duration    value_objects.Duration
 	dueDate     *time.Time
 	completedAt *time.Time
+	// earliestStart keeps the task off the schedule until then.
+	earliestStart *time.Time
+	recurrence    *Recurrence
+	reminders     []Reminder
+
+	blockedReason string
+	blockedAt     *time.Time
+
+	waitingOn      string
+	waitingSince   *time.Time
+	followUpAt     *time.Time
+	followUpSentAt *time.Time
+
+	checklist         []ChecklistItem
+	checklistRequired bool
+
+	tags []string
+
+	// externalID identifies the task in the app it was imported from.
+	externalID string
+
+	// timezone overrides the user's time zone for the due date; location is
+	// its resolved form, nil when the task has no override.
+	timezone string
+	location *time.Location
 }
 
 // NewTask creates a new task with the given title.
@@ -77,16 +108,18 @@ func NewTask(userID uuid.UUID, title string) (*Task, error) {
 
 // Getters
 
-func (t *Task) UserID() uuid.UUID                  { return t.userID }
-func (t *Task) Title() string                      { return t.title }
-func (t *Task) Description() string                { return t.description }
-func (t *Task) Status() Status                     { return t.status }
-func (t *Task) Priority() value_objects.Priority   { return t.priority }
-func (t *Task) Duration() value_objects.Duration   { return t.duration }
-func (t *Task) DueDate() *time.Time                { return t.dueDate }
-func (t *Task) CompletedAt() *time.Time            { return t.completedAt }
-func (t *Task) IsCompleted() bool                  { return t.status == StatusCompleted }
-func (t *Task) IsArchived() bool                   { return t.status == StatusArchived }
+func (t *Task) UserID() uuid.UUID                { return t.userID }
+func (t *Task) Title() string                    { return t.title }
+func (t *Task) Description() string              { return t.description }
+func (t *Task) Status() Status                   { return t.status }
+func (t *Task) Priority() value_objects.Priority { return t.priority }
+func (t *Task) Duration() value_objects.Duration { return t.duration }
+func (t *Task) DueDate() *time.Time              { return t.dueDate }
+func (t *Task) CompletedAt() *time.Time          { return t.completedAt }
+func (t *Task) IsCompleted() bool                { return t.status == StatusCompleted }
+func (t *Task) IsArchived() bool                 { return t.status == StatusArchived }
+func (t *Task) Recurrence() *Recurrence          { return t.recurrence }
+func (t *Task) IsRecurring() bool                { return t.recurrence != nil }
 
 // SetTitle updates the task title.
 func (t *Task) SetTitle(title string) error {
@@ -132,16 +165,39 @@ func (t *Task) SetDuration(duration value_objects.Duration) error {
 	return nil
 }
 
-// SetDueDate updates the due date.
+// SetDueDate updates the due date. It must not fall on a day before the
+// earliest start.
+// Moving the due date re-arms reminders that were already sent.
 func (t *Task) SetDueDate(dueDate *time.Time) error {
 	if t.IsArchived() {
 		return ErrTaskArchived
 	}
+	if !startsByDue(t.earliestStart, dueDate, t.LocalTime) {
+		return ErrEarliestStartAfterDue
+	}
+	if !sameTime(t.dueDate, dueDate) {
+		for i := range t.reminders {
+			t.reminders[i].SentAt = nil
+		}
+	}
 	t.dueDate = dueDate
 	t.Touch()
 	return nil
 }
 
+// SetRecurrence updates the recurrence rule. A nil rule makes the task one-shot.
+func (t *Task) SetRecurrence(recurrence *Recurrence) error {
+	if t.IsArchived() {
+		return ErrTaskArchived
+	}
+	if recurrence != nil && !recurrence.Frequency.IsValid() {
+		return ErrInvalidRecurrence
+	}
+	t.recurrence = recurrence
+	t.Touch()
+	return nil
+}
+
 // Start marks the task as in progress.
 func (t *Task) Start() error {
 	if t.IsCompleted() {
@@ -150,6 +206,12 @@ func (t *Task) Start() error {
 	if t.IsArchived() {
 		return ErrTaskArchived
 	}
+	if t.IsBlocked() {
+		return ErrTaskBlocked
+	}
+	if t.IsWaiting() {
+		return ErrTaskWaiting
+	}
 	if t.status == StatusInProgress {
 		return nil // Idempotent
 	}
@@ -159,7 +221,8 @@ func (t *Task) Start() error {
 	return nil
 }
 
-// Complete marks the task as completed.
+// Complete marks the task as completed, clearing any block or wait.
+// When the checklist is required, every item must be done first.
 func (t *Task) Complete() error {
 	if t.IsCompleted() {
 		return ErrTaskAlreadyComplete
@@ -167,10 +230,15 @@ func (t *Task) Complete() error {
 	if t.IsArchived() {
 		return ErrTaskArchived
 	}
+	if t.checklistRequired && !t.IsChecklistComplete() {
+		return ErrChecklistIncomplete
+	}
 
 	now := time.Now().UTC()
 	t.status = StatusCompleted
 	t.completedAt = &now
+	t.clearBlock()
+	t.clearWaiting()
 	t.Touch()
 
 	t.AddDomainEvent(NewTaskCompleted(t.ID()))
@@ -178,16 +246,80 @@ func (t *Task) Complete() error {
 	return nil
 }
 
-// Archive marks the task as archived.
+// Archive marks the task as archived, clearing any block or wait.
 func (t *Task) Archive() error {
 	if t.IsArchived() {
 		return nil // Idempotent
 	}
 
 	t.status = StatusArchived
+	t.clearBlock()
+	t.clearWaiting()
 	t.Touch()
 
 	t.AddDomainEvent(NewTaskArchived(t.ID()))
 
 	return nil
 }
+
+// RehydrateCompletedAt restores when the task was completed from persistence.
+func (t *Task) RehydrateCompletedAt(at *time.Time) {
+	t.completedAt = at
+}
+
+// NextOccurrence creates the follow-up of a completed recurring task.
+// The new task keeps the title, description, priority, duration, time zone,
+// recurrence rule, reminders, tags and checklist (with every item reset to
+// not done); its due date is advanced by the rule from the current due
+// date, or from the completion time when the task had no due date.
+func (t *Task) NextOccurrence() (*Task, error) {
+	if !t.IsRecurring() {
+		return nil, ErrTaskNotRecurring
+	}
+	if !t.IsCompleted() {
+		return nil, ErrTaskNotCompleted
+	}
+
+	next, err := NewTask(t.userID, t.title)
+	if err != nil {
+		return nil, err
+	}
+	next.description = t.description
+	next.priority = t.priority
+	next.duration = t.duration
+	next.timezone = t.timezone
+	next.location = t.location
+	recurrence := *t.recurrence
+	next.recurrence = &recurrence
+	for _, r := range t.reminders {
+		next.reminders = append(next.reminders, Reminder{Offset: r.Offset})
+	}
+	for _, item := range t.checklist {
+		next.checklist = append(next.checklist, ChecklistItem{ID: uuid.New(), Title: item.Title})
+	}
+	next.checklistRequired = t.checklistRequired
+	next.tags = t.Tags()
+
+	from := *t.completedAt
+	if t.dueDate != nil {
+		from = *t.dueDate
+	}
+	dueDate := recurrence.Next(from)
+	next.dueDate = &dueDate
+	// Keep the same lead time before the next due date.
+	if t.earliestStart != nil && t.dueDate != nil {
+		earliestStart := dueDate.Add(t.earliestStart.Sub(*t.dueDate))
+		next.earliestStart = &earliestStart
+	}
+
+	t.AddDomainEvent(NewTaskRecurred(t.ID(), next.ID(), dueDate))
+
+	return next, nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
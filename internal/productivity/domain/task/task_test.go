@@ -225,6 +225,7 @@ func TestStatus_String(t *testing.T) {
 		{task.StatusInProgress, "in_progress"},
 		{task.StatusCompleted, "completed"},
 		{task.StatusArchived, "archived"},
+		{task.StatusBlocked, "blocked"},
 	}
 
 	for _, tt := range tests {
@@ -233,3 +234,103 @@ func TestStatus_String(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRecurrence(t *testing.T) {
+	r, err := task.NewRecurrence(task.RecurrenceWeekly, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, r.Interval)
+
+	_, err = task.NewRecurrence("yearly", 1)
+	assert.ErrorIs(t, err, task.ErrInvalidRecurrence)
+
+	_, err = task.NewRecurrence(task.RecurrenceDaily, -1)
+	assert.ErrorIs(t, err, task.ErrInvalidRecurrence)
+}
+
+func TestRecurrence_Next(t *testing.T) {
+	from := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, from.AddDate(0, 0, 2), task.Recurrence{Frequency: task.RecurrenceDaily, Interval: 2}.Next(from))
+	assert.Equal(t, from.AddDate(0, 0, 7), task.Recurrence{Frequency: task.RecurrenceWeekly, Interval: 1}.Next(from))
+	assert.Equal(t, from.AddDate(0, 1, 0), task.Recurrence{Frequency: task.RecurrenceMonthly, Interval: 1}.Next(from))
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected task.Recurrence
+	}{
+		{"daily", task.Recurrence{Frequency: task.RecurrenceDaily, Interval: 1}},
+		{" Monthly ", task.Recurrence{Frequency: task.RecurrenceMonthly, Interval: 1}},
+		{"every 2 weeks", task.Recurrence{Frequency: task.RecurrenceWeekly, Interval: 2}},
+		{"every 1 day", task.Recurrence{Frequency: task.RecurrenceDaily, Interval: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			r, err := task.ParseRecurrence(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r)
+
+			roundTrip, err := task.ParseRecurrence(r.String())
+			require.NoError(t, err)
+			assert.Equal(t, r, roundTrip)
+		})
+	}
+
+	for _, value := range []string{"", "yearly", "every week", "every 0 days", "every 2 years", "each 2 days"} {
+		_, err := task.ParseRecurrence(value)
+		assert.ErrorIs(t, err, task.ErrInvalidRecurrence, value)
+	}
+}
+
+func TestRecurrence_String(t *testing.T) {
+	assert.Equal(t, "weekly", task.Recurrence{Frequency: task.RecurrenceWeekly, Interval: 1}.String())
+	assert.Equal(t, "every 3 months", task.Recurrence{Frequency: task.RecurrenceMonthly, Interval: 3}.String())
+}
+
+func TestTask_NextOccurrence(t *testing.T) {
+	userID := uuid.New()
+	recurrence, _ := task.NewRecurrence(task.RecurrenceDaily, 1)
+
+	t.Run("requires a recurring task", func(t *testing.T) {
+		tk, _ := task.NewTask(userID, "One-off")
+		_ = tk.Complete()
+
+		_, err := tk.NextOccurrence()
+		assert.ErrorIs(t, err, task.ErrTaskNotRecurring)
+	})
+
+	t.Run("requires a completed task", func(t *testing.T) {
+		tk, _ := task.NewTask(userID, "Daily")
+		require.NoError(t, tk.SetRecurrence(&recurrence))
+
+		_, err := tk.NextOccurrence()
+		assert.ErrorIs(t, err, task.ErrTaskNotCompleted)
+	})
+
+	t.Run("advances from completion time without a due date", func(t *testing.T) {
+		tk, _ := task.NewTask(userID, "Daily")
+		require.NoError(t, tk.SetRecurrence(&recurrence))
+		require.NoError(t, tk.Complete())
+		tk.ClearDomainEvents()
+
+		next, err := tk.NextOccurrence()
+		require.NoError(t, err)
+		require.NotNil(t, next.DueDate())
+		assert.Equal(t, tk.CompletedAt().AddDate(0, 0, 1), *next.DueDate())
+
+		events := tk.DomainEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, task.RoutingKeyRecurred, events[0].RoutingKey())
+	})
+
+	t.Run("stops once archived", func(t *testing.T) {
+		tk, _ := task.NewTask(userID, "Daily")
+		require.NoError(t, tk.SetRecurrence(&recurrence))
+		require.NoError(t, tk.Archive())
+
+		assert.ErrorIs(t, tk.Complete(), task.ErrTaskArchived)
+		_, err := tk.NextOccurrence()
+		assert.ErrorIs(t, err, task.ErrTaskNotCompleted)
+	})
+}
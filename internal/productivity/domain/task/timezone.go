@@ -0,0 +1,66 @@
+package task
+
+import (
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/domain"
+)
+
+// Timezone returns the IANA time zone the task's due date belongs to, or an
+// empty string when the task follows the user's current time zone.
+func (t *Task) Timezone() string {
+	return t.timezone
+}
+
+// SetTimezone pins the task to a time zone, so that "due today" means today
+// where the task happens rather than where the user currently is. An empty
+// name clears the override.
+func (t *Task) SetTimezone(name string) error {
+	loc, err := domain.LoadTimezone(name)
+	if err != nil {
+		return err
+	}
+	t.location = loc
+	t.timezone = ""
+	if loc != nil {
+		t.timezone = loc.String()
+	}
+	t.Touch()
+	return nil
+}
+
+// RehydrateTimezone restores the time zone from persistence. A name the
+// local time zone database does not know is kept but has no effect.
+func (t *Task) RehydrateTimezone(name string) {
+	t.timezone = name
+	t.location, _ = domain.LoadTimezone(name)
+}
+
+// LocalTime returns tm in the task's time zone, or unchanged when the task
+// follows the user's time zone.
+func (t *Task) LocalTime(tm time.Time) time.Time {
+	if t.location == nil {
+		return tm
+	}
+	return tm.In(t.location)
+}
+
+// IsDueToday reports whether the task is due on the day of now, with both
+// read in the task's time zone.
+func (t *Task) IsDueToday(now time.Time) bool {
+	if t.dueDate == nil {
+		return false
+	}
+	return domain.SameDay(t.LocalTime(*t.dueDate), t.LocalTime(now))
+}
+
+// IsOverdue reports whether the task was due before the day of now, with
+// both read in the task's time zone. Completed tasks are never overdue.
+func (t *Task) IsOverdue(now time.Time) bool {
+	if t.dueDate == nil || t.IsCompleted() {
+		return false
+	}
+	now = t.LocalTime(now)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return t.dueDate.Before(today)
+}
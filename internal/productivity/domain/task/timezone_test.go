@@ -0,0 +1,94 @@
+package task_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestTask_SetTimezone(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Call the Tokyo office")
+	require.NoError(t, err)
+	assert.Equal(t, "", tk.Timezone())
+
+	require.NoError(t, tk.SetTimezone(" Asia/Tokyo "))
+	assert.Equal(t, "Asia/Tokyo", tk.Timezone())
+
+	err = tk.SetTimezone("Mars/Olympus")
+	assert.ErrorIs(t, err, sharedDomain.ErrInvalidTimezone)
+	assert.Equal(t, "Asia/Tokyo", tk.Timezone())
+
+	require.NoError(t, tk.SetTimezone(""))
+	assert.Equal(t, "", tk.Timezone())
+}
+
+func TestTask_IsDueToday_TaskTimezone(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	// Late on June 3rd in New York is already June 4th in Tokyo.
+	now := time.Date(2025, 6, 3, 22, 0, 0, 0, newYork)
+
+	tokyoTask, err := task.NewTask(uuid.New(), "Tokyo deliverable")
+	require.NoError(t, err)
+	require.NoError(t, tokyoTask.SetTimezone("Asia/Tokyo"))
+	tokyoDue := time.Date(2025, 6, 4, 0, 0, 0, 0, tokyo)
+	require.NoError(t, tokyoTask.SetDueDate(&tokyoDue))
+
+	localTask, err := task.NewTask(uuid.New(), "Local deliverable")
+	require.NoError(t, err)
+	localDue := time.Date(2025, 6, 4, 0, 0, 0, 0, newYork)
+	require.NoError(t, localTask.SetDueDate(&localDue))
+
+	assert.True(t, tokyoTask.IsDueToday(now), "due today in Tokyo")
+	assert.False(t, localTask.IsDueToday(now), "due tomorrow in the user's time zone")
+
+	// The same due date without the override follows the user's day.
+	require.NoError(t, tokyoTask.SetTimezone(""))
+	assert.False(t, tokyoTask.IsDueToday(now))
+}
+
+func TestTask_IsOverdue_TaskTimezone(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	now := time.Date(2025, 6, 3, 22, 0, 0, 0, newYork)
+	due := time.Date(2025, 6, 3, 20, 0, 0, 0, tokyo)
+
+	tk, err := task.NewTask(uuid.New(), "Tokyo deliverable")
+	require.NoError(t, err)
+	require.NoError(t, tk.SetDueDate(&due))
+	assert.False(t, tk.IsOverdue(now), "still June 3rd for the user")
+
+	require.NoError(t, tk.SetTimezone("Asia/Tokyo"))
+	assert.True(t, tk.IsOverdue(now), "already June 4th in Tokyo")
+
+	require.NoError(t, tk.Complete())
+	assert.False(t, tk.IsOverdue(now))
+}
+
+func TestTask_NextOccurrence_KeepsTimezone(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Weekly report")
+	require.NoError(t, err)
+	require.NoError(t, tk.SetTimezone("Asia/Tokyo"))
+	recurrence, err := task.NewRecurrence(task.RecurrenceWeekly, 1)
+	require.NoError(t, err)
+	require.NoError(t, tk.SetRecurrence(&recurrence))
+	require.NoError(t, tk.Complete())
+
+	next, err := tk.NextOccurrence()
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", next.Timezone())
+}
@@ -0,0 +1,144 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultFollowUpDelay is how long a task waits on someone before the
+// follow-up reminder fires, unless configured otherwise.
+const DefaultFollowUpDelay = 3 * 24 * time.Hour
+
+var (
+	ErrTaskWaiting          = errors.New("task is waiting on someone")
+	ErrTaskNotWaiting       = errors.New("task is not waiting on anyone")
+	ErrEmptyWaitingOn       = errors.New("waiting on cannot be empty")
+	ErrInvalidFollowUpDelay = errors.New("follow-up delay must be at least one minute")
+)
+
+// WaitOn marks the task as waiting on a person, such as a colleague the work
+// was delegated to. A follow-up reminder fires followUpAfter from now unless
+// the wait is resolved first. Waiting again replaces the person and restarts
+// the follow-up delay; a blocked task stops being blocked.
+func (t *Task) WaitOn(person string, followUpAfter time.Duration) error {
+	if t.IsArchived() {
+		return ErrTaskArchived
+	}
+	if t.IsCompleted() {
+		return ErrTaskAlreadyComplete
+	}
+	person = strings.TrimSpace(person)
+	if person == "" {
+		return ErrEmptyWaitingOn
+	}
+	if followUpAfter < time.Minute {
+		return ErrInvalidFollowUpDelay
+	}
+
+	now := time.Now().UTC()
+	if !t.IsWaiting() {
+		t.status = StatusWaiting
+		t.waitingSince = &now
+	}
+	followUpAt := now.Add(followUpAfter)
+	t.waitingOn = person
+	t.followUpAt = &followUpAt
+	t.followUpSentAt = nil
+	t.clearBlock()
+	t.Touch()
+
+	t.AddDomainEvent(NewTaskWaiting(t.ID(), person, followUpAt))
+
+	return nil
+}
+
+// ResolveWaiting returns a waiting task to pending. The note records how the
+// wait was resolved and travels with the TaskWaitingResolved event.
+func (t *Task) ResolveWaiting(note string) error {
+	if !t.IsWaiting() {
+		return ErrTaskNotWaiting
+	}
+
+	person := t.waitingOn
+	t.status = StatusPending
+	t.clearWaiting()
+	t.Touch()
+
+	t.AddDomainEvent(NewTaskWaitingResolved(t.ID(), person, strings.TrimSpace(note)))
+
+	return nil
+}
+
+// IsWaiting returns true if the task is waiting on a person.
+func (t *Task) IsWaiting() bool {
+	return t.status == StatusWaiting
+}
+
+// WaitingOn returns the person the task is waiting on, if anyone.
+func (t *Task) WaitingOn() string {
+	return t.waitingOn
+}
+
+// WaitingSince returns when the task started waiting, or nil if it is not
+// waiting.
+func (t *Task) WaitingSince() *time.Time {
+	return t.waitingSince
+}
+
+// FollowUpAt returns when the follow-up reminder fires, or nil if the task
+// is not waiting.
+func (t *Task) FollowUpAt() *time.Time {
+	return t.followUpAt
+}
+
+// FollowUpSentAt returns when the follow-up reminder was dispatched, or nil
+// if it has not been.
+func (t *Task) FollowUpSentAt() *time.Time {
+	return t.followUpSentAt
+}
+
+// FollowUpDue returns true if the task is still waiting and its unsent
+// follow-up reminder is due by now. It is held back while now is inside
+// quiet hours.
+func (t *Task) FollowUpDue(now time.Time, quiet QuietHours) bool {
+	if !t.IsWaiting() || t.followUpAt == nil || t.followUpSentAt != nil {
+		return false
+	}
+	return !quiet.Release(t.followUpAt.In(now.Location()), now).After(now)
+}
+
+// MarkFollowUpSent records that the follow-up reminder was dispatched.
+func (t *Task) MarkFollowUpSent(sentAt time.Time) error {
+	if !t.IsWaiting() {
+		return ErrTaskNotWaiting
+	}
+	if t.followUpSentAt != nil {
+		return nil // Idempotent
+	}
+	t.followUpSentAt = &sentAt
+	t.Touch()
+
+	var since time.Time
+	if t.waitingSince != nil {
+		since = *t.waitingSince
+	}
+	t.AddDomainEvent(NewTaskFollowUpDue(t.ID(), t.title, t.waitingOn, since))
+	return nil
+}
+
+// RehydrateWaiting restores a waiting task from persistence.
+func (t *Task) RehydrateWaiting(person string, since, followUpAt, followUpSentAt *time.Time) {
+	t.status = StatusWaiting
+	t.waitingOn = person
+	t.waitingSince = since
+	t.followUpAt = followUpAt
+	t.followUpSentAt = followUpSentAt
+}
+
+func (t *Task) clearWaiting() {
+	t.waitingOn = ""
+	t.waitingSince = nil
+	t.followUpAt = nil
+	t.followUpSentAt = nil
+}
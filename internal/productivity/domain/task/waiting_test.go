@@ -0,0 +1,156 @@
+package task_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_WaitOn(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Contract review")
+	require.NoError(t, err)
+	tk.ClearDomainEvents()
+
+	before := time.Now().UTC()
+	require.NoError(t, tk.WaitOn("  Alice (legal)  ", 48*time.Hour))
+
+	assert.True(t, tk.IsWaiting())
+	assert.Equal(t, task.StatusWaiting, tk.Status())
+	assert.Equal(t, "waiting", tk.Status().String())
+	assert.Equal(t, "Alice (legal)", tk.WaitingOn())
+	require.NotNil(t, tk.WaitingSince())
+	require.NotNil(t, tk.FollowUpAt())
+	assert.Equal(t, tk.WaitingSince().Add(48*time.Hour), *tk.FollowUpAt())
+	assert.False(t, tk.WaitingSince().Before(before))
+	assert.Nil(t, tk.FollowUpSentAt())
+
+	events := tk.DomainEvents()
+	require.Len(t, events, 1)
+	waiting, ok := events[0].(task.TaskWaiting)
+	require.True(t, ok)
+	assert.Equal(t, task.RoutingKeyWaiting, waiting.RoutingKey())
+	assert.Equal(t, "Alice (legal)", waiting.WaitingOn)
+	assert.Equal(t, *tk.FollowUpAt(), waiting.FollowUpAt)
+}
+
+func TestTask_WaitOn_Validation(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Contract review")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, tk.WaitOn("   ", time.Hour), task.ErrEmptyWaitingOn)
+	assert.ErrorIs(t, tk.WaitOn("Alice", 30*time.Second), task.ErrInvalidFollowUpDelay)
+	assert.False(t, tk.IsWaiting())
+
+	require.NoError(t, tk.Complete())
+	assert.ErrorIs(t, tk.WaitOn("Alice", time.Hour), task.ErrTaskAlreadyComplete)
+}
+
+func TestTask_WaitOn_AgainRestartsFollowUp(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Contract review")
+	require.NoError(t, err)
+	require.NoError(t, tk.WaitOn("Alice", time.Minute))
+	since := tk.WaitingSince()
+	require.NoError(t, tk.MarkFollowUpSent(time.Now()))
+
+	require.NoError(t, tk.WaitOn("Bob", 24*time.Hour))
+
+	assert.Equal(t, "Bob", tk.WaitingOn())
+	assert.Equal(t, since, tk.WaitingSince())
+	assert.Nil(t, tk.FollowUpSentAt())
+	assert.True(t, tk.FollowUpAt().After(time.Now().Add(23*time.Hour)))
+}
+
+func TestTask_WaitOn_ClearsBlock(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Contract review")
+	require.NoError(t, err)
+	require.NoError(t, tk.Block("PR #42"))
+
+	require.NoError(t, tk.WaitOn("Alice", time.Hour))
+	assert.True(t, tk.IsWaiting())
+	assert.Empty(t, tk.BlockedReason())
+
+	require.NoError(t, tk.Block("PR #43"))
+	assert.True(t, tk.IsBlocked())
+	assert.Empty(t, tk.WaitingOn())
+	assert.Nil(t, tk.FollowUpAt())
+}
+
+func TestTask_ResolveWaiting(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Contract review")
+	require.NoError(t, err)
+	assert.ErrorIs(t, tk.ResolveWaiting(""), task.ErrTaskNotWaiting)
+
+	require.NoError(t, tk.WaitOn("Alice", time.Hour))
+	assert.ErrorIs(t, tk.Start(), task.ErrTaskWaiting)
+	tk.ClearDomainEvents()
+
+	require.NoError(t, tk.ResolveWaiting("  Signed copy received "))
+
+	assert.Equal(t, task.StatusPending, tk.Status())
+	assert.Empty(t, tk.WaitingOn())
+	assert.Nil(t, tk.WaitingSince())
+	assert.Nil(t, tk.FollowUpAt())
+
+	events := tk.DomainEvents()
+	require.Len(t, events, 1)
+	resolved, ok := events[0].(task.TaskWaitingResolved)
+	require.True(t, ok)
+	assert.Equal(t, task.RoutingKeyResolved, resolved.RoutingKey())
+	assert.Equal(t, "Alice", resolved.WaitingOn)
+	assert.Equal(t, "Signed copy received", resolved.Note)
+}
+
+func TestTask_FollowUpDue(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Contract review")
+	require.NoError(t, err)
+	assert.False(t, tk.FollowUpDue(time.Now().Add(time.Hour), task.QuietHours{}))
+
+	require.NoError(t, tk.WaitOn("Alice", 2*time.Hour))
+	followUpAt := *tk.FollowUpAt()
+
+	assert.False(t, tk.FollowUpDue(followUpAt.Add(-time.Minute), task.QuietHours{}))
+	assert.True(t, tk.FollowUpDue(followUpAt, task.QuietHours{}))
+
+	// Quiet hours covering the follow-up hold it back until they end.
+	start := followUpAt.Sub(followUpAt.Truncate(24 * time.Hour)).Truncate(time.Minute)
+	quiet := task.QuietHours{Start: start, End: (start + time.Hour) % (24 * time.Hour)}
+	assert.False(t, tk.FollowUpDue(followUpAt, quiet))
+	assert.True(t, tk.FollowUpDue(followUpAt.Add(time.Hour), quiet))
+
+	// A follow-up that came due before quiet hours is held while they last.
+	later := task.QuietHours{Start: (start + time.Hour) % (24 * time.Hour), End: (start + 2*time.Hour) % (24 * time.Hour)}
+	assert.False(t, tk.FollowUpDue(followUpAt.Add(90*time.Minute), later))
+	assert.True(t, tk.FollowUpDue(followUpAt.Add(2*time.Hour), later))
+
+	tk.ClearDomainEvents()
+	require.NoError(t, tk.MarkFollowUpSent(followUpAt))
+	require.NoError(t, tk.MarkFollowUpSent(followUpAt.Add(time.Minute)))
+	assert.Equal(t, followUpAt, *tk.FollowUpSentAt())
+	assert.False(t, tk.FollowUpDue(followUpAt.Add(time.Hour), task.QuietHours{}))
+
+	events := tk.DomainEvents()
+	require.Len(t, events, 1)
+	due, ok := events[0].(task.TaskFollowUpDue)
+	require.True(t, ok)
+	assert.Equal(t, task.RoutingKeyFollowUp, due.RoutingKey())
+	assert.Equal(t, "Contract review", due.Title)
+	assert.Equal(t, "Alice", due.WaitingOn)
+	assert.Equal(t, *tk.WaitingSince(), due.WaitingSince)
+}
+
+func TestTask_CompleteClearsWaiting(t *testing.T) {
+	tk, err := task.NewTask(uuid.New(), "Contract review")
+	require.NoError(t, err)
+	require.NoError(t, tk.WaitOn("Alice", time.Hour))
+
+	require.NoError(t, tk.Complete())
+
+	assert.Empty(t, tk.WaitingOn())
+	assert.Nil(t, tk.FollowUpAt())
+	assert.False(t, tk.FollowUpDue(time.Now().Add(2*time.Hour), task.QuietHours{}))
+	assert.ErrorIs(t, tk.MarkFollowUpSent(time.Now()), task.ErrTaskNotWaiting)
+}
@@ -36,6 +36,27 @@ var priorityValues = map[string]Priority{
 	"urgent": PriorityUrgent,
 }
 
+// priorityEngineLevels maps priorities to the engine scale (1=urgent to 5=none),
+// matching the PriorityLevel constants in the engine types.
+var priorityEngineLevels = map[Priority]int{
+	PriorityUrgent: 1,
+	PriorityHigh:   2,
+	PriorityMedium: 3,
+	PriorityLow:    4,
+	PriorityNone:   5,
+}
+
+// priorityMarkers maps priorities to the shorthand used in quick capture.
+var priorityMarkers = map[Priority]string{
+	PriorityMedium: "!",
+	PriorityHigh:   "!!",
+	PriorityUrgent: "!!!",
+}
+
+// PriorityMarkers lists the quick capture markers, longest first so that
+// callers scanning text match "!!!" before "!!" and "!".
+var PriorityMarkers = []string{"!!!", "!!", "!"}
+
 // ParsePriority creates a Priority from a string.
 func ParsePriority(s string) (Priority, error) {
 	p, ok := priorityValues[strings.ToLower(s)]
@@ -45,6 +66,36 @@ func ParsePriority(s string) (Priority, error) {
 	return p, nil
 }
 
+// PriorityFromEngineLevel creates a Priority from the engine scale (1=urgent to 5=none).
+func PriorityFromEngineLevel(level int) (Priority, error) {
+	for p, l := range priorityEngineLevels {
+		if l == level {
+			return p, nil
+		}
+	}
+	return PriorityNone, ErrInvalidPriority
+}
+
+// ParsePriorityMarker creates a Priority from a quick capture marker ("!", "!!", "!!!").
+func ParsePriorityMarker(marker string) (Priority, error) {
+	for p, m := range priorityMarkers {
+		if m == marker {
+			return p, nil
+		}
+	}
+	return PriorityNone, ErrInvalidPriority
+}
+
+// EngineLevelFor converts a priority name to the engine scale.
+// Unknown names map to medium.
+func EngineLevelFor(name string) int {
+	p, err := ParsePriority(name)
+	if err != nil {
+		return PriorityMedium.EngineLevel()
+	}
+	return p.EngineLevel()
+}
+
 // String returns the string representation of the priority.
 func (p Priority) String() string {
 	if name, ok := priorityNames[p]; ok {
@@ -59,6 +110,19 @@ func (p Priority) IsValid() bool {
 	return ok
 }
 
+// EngineLevel returns the priority on the engine scale (1=urgent to 5=none).
+func (p Priority) EngineLevel() int {
+	if level, ok := priorityEngineLevels[p]; ok {
+		return level
+	}
+	return priorityEngineLevels[PriorityNone]
+}
+
+// Marker returns the quick capture marker, or "" for low and none.
+func (p Priority) Marker() string {
+	return priorityMarkers[p]
+}
+
 // Weight returns a numeric weight for sorting (higher = more important).
 func (p Priority) Weight() int {
 	return int(p)
@@ -3,6 +3,7 @@ package value_objects_test
 import (
 	"testing"
 
+	"github.com/felixgeelhaar/orbita/internal/engine/types"
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -71,3 +72,54 @@ func TestPriority_Weight(t *testing.T) {
 	assert.Less(t, value_objects.PriorityLow.Weight(), value_objects.PriorityHigh.Weight())
 	assert.Less(t, value_objects.PriorityHigh.Weight(), value_objects.PriorityUrgent.Weight())
 }
+
+func TestPriority_RoundTrip(t *testing.T) {
+	tests := []struct {
+		priority    value_objects.Priority
+		name        string
+		engineLevel int
+		marker      string
+	}{
+		{value_objects.PriorityUrgent, "urgent", types.PriorityLevelUrgent, "!!!"},
+		{value_objects.PriorityHigh, "high", types.PriorityLevelHigh, "!!"},
+		{value_objects.PriorityMedium, "medium", types.PriorityLevelMedium, "!"},
+		{value_objects.PriorityLow, "low", types.PriorityLevelLow, ""},
+		{value_objects.PriorityNone, "none", types.PriorityLevelNone, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fromName, err := value_objects.ParsePriority(tt.priority.String())
+			require.NoError(t, err)
+			assert.Equal(t, tt.priority, fromName)
+			assert.Equal(t, tt.name, tt.priority.String())
+
+			assert.Equal(t, tt.engineLevel, tt.priority.EngineLevel())
+			fromLevel, err := value_objects.PriorityFromEngineLevel(tt.priority.EngineLevel())
+			require.NoError(t, err)
+			assert.Equal(t, tt.priority, fromLevel)
+			assert.Equal(t, tt.engineLevel, value_objects.EngineLevelFor(tt.name))
+
+			assert.Equal(t, tt.marker, tt.priority.Marker())
+			if tt.marker != "" {
+				fromMarker, err := value_objects.ParsePriorityMarker(tt.marker)
+				require.NoError(t, err)
+				assert.Equal(t, tt.priority, fromMarker)
+			}
+		})
+	}
+}
+
+func TestPriority_MappingErrors(t *testing.T) {
+	_, err := value_objects.PriorityFromEngineLevel(0)
+	assert.ErrorIs(t, err, value_objects.ErrInvalidPriority)
+	_, err = value_objects.PriorityFromEngineLevel(6)
+	assert.ErrorIs(t, err, value_objects.ErrInvalidPriority)
+
+	_, err = value_objects.ParsePriorityMarker("!!!!")
+	assert.ErrorIs(t, err, value_objects.ErrInvalidPriority)
+
+	assert.Equal(t, types.PriorityLevelMedium, value_objects.EngineLevelFor("unknown"))
+	assert.Equal(t, types.PriorityLevelNone, value_objects.Priority(99).EngineLevel())
+	assert.Equal(t, []string{"!!!", "!!", "!"}, value_objects.PriorityMarkers)
+}
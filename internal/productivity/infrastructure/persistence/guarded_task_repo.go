@@ -0,0 +1,121 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+// GuardedTaskRepository wraps a task repository and rejects any call that
+// targets a user other than the authenticated principal in the context, or
+// that runs without a principal outside a system context.
+// Queries keyed by user are checked before they reach the database; lookups
+// by ID are checked against the owner of the loaded task.
+type GuardedTaskRepository struct {
+	inner task.Repository
+}
+
+// NewGuardedTaskRepository wraps inner with a principal check.
+func NewGuardedTaskRepository(inner task.Repository) *GuardedTaskRepository {
+	return &GuardedTaskRepository{inner: inner}
+}
+
+// Save persists a task owned by the principal.
+func (r *GuardedTaskRepository) Save(ctx context.Context, t *task.Task) error {
+	if err := sharedApplication.CheckPrincipal(ctx, t.UserID()); err != nil {
+		return err
+	}
+	return r.inner.Save(ctx, t)
+}
+
+// FindByID loads a task and rejects it if it belongs to another user.
+func (r *GuardedTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*task.Task, error) {
+	t, err := r.inner.FindByID(ctx, id)
+	if err != nil || t == nil {
+		return t, err
+	}
+	if err := sharedApplication.CheckPrincipal(ctx, t.UserID()); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// FindByUserID finds all tasks for the principal.
+func (r *GuardedTaskRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*task.Task, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindByUserID(ctx, userID)
+}
+
+// FindPending finds the principal's pending tasks.
+func (r *GuardedTaskRepository) FindPending(ctx context.Context, userID uuid.UUID) ([]*task.Task, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindPending(ctx, userID)
+}
+
+// FindByExternalID finds the principal's task imported with the given
+// external ID. It returns nil when the wrapped repository cannot look tasks
+// up by external ID.
+func (r *GuardedTaskRepository) FindByExternalID(ctx context.Context, userID uuid.UUID, externalID string) (*task.Task, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	finder, ok := r.inner.(task.ExternalIDRepository)
+	if !ok {
+		return nil, nil
+	}
+	return finder.FindByExternalID(ctx, userID, externalID)
+}
+
+// Search finds the principal's tasks matching query. It returns nothing
+// when the wrapped repository cannot search.
+func (r *GuardedTaskRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*task.Task, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	searcher, ok := r.inner.(task.SearchRepository)
+	if !ok {
+		return nil, nil
+	}
+	return searcher.Search(ctx, userID, query, limit)
+}
+
+// Delete removes a task after checking that it belongs to the principal.
+// A system context deletes the task unchecked.
+func (r *GuardedTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := sharedApplication.RequirePrincipal(ctx); err != nil {
+		return err
+	}
+	if _, ok := sharedApplication.PrincipalFromContext(ctx); ok {
+		if _, err := r.FindByID(ctx, id); err != nil {
+			return err
+		}
+	}
+	return r.inner.Delete(ctx, id)
+}
+
+// IterateTasks streams the principal's tasks. It falls back to loading them
+// all when the wrapped repository cannot stream.
+func (r *GuardedTaskRepository) IterateTasks(ctx context.Context, userID uuid.UUID, fn func(*task.Task) error) error {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return err
+	}
+	if iter, ok := r.inner.(task.Iterator); ok {
+		return iter.IterateTasks(ctx, userID, fn)
+	}
+	tasks, err := r.inner.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
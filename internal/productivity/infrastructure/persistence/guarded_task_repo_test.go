@@ -0,0 +1,180 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	"github.com/felixgeelhaar/orbita/internal/productivity/infrastructure/persistence"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTaskRepo is an in-memory task repository that records every call
+// that reaches it.
+type recordingTaskRepo struct {
+	tasks map[uuid.UUID]*task.Task
+	calls []string
+}
+
+func newRecordingTaskRepo(tasks ...*task.Task) *recordingTaskRepo {
+	repo := &recordingTaskRepo{tasks: make(map[uuid.UUID]*task.Task)}
+	for _, t := range tasks {
+		repo.tasks[t.ID()] = t
+	}
+	return repo
+}
+
+func (r *recordingTaskRepo) Save(ctx context.Context, t *task.Task) error {
+	r.calls = append(r.calls, "Save")
+	r.tasks[t.ID()] = t
+	return nil
+}
+
+func (r *recordingTaskRepo) FindByID(ctx context.Context, id uuid.UUID) (*task.Task, error) {
+	r.calls = append(r.calls, "FindByID")
+	return r.tasks[id], nil
+}
+
+func (r *recordingTaskRepo) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*task.Task, error) {
+	r.calls = append(r.calls, "FindByUserID")
+	var result []*task.Task
+	for _, t := range r.tasks {
+		if t.UserID() == userID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (r *recordingTaskRepo) FindPending(ctx context.Context, userID uuid.UUID) ([]*task.Task, error) {
+	r.calls = append(r.calls, "FindPending")
+	return r.FindByUserID(ctx, userID)
+}
+
+func (r *recordingTaskRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.calls = append(r.calls, "Delete")
+	delete(r.tasks, id)
+	return nil
+}
+
+func TestGuardedTaskRepository_RejectsMismatchedUserBeforeQuerying(t *testing.T) {
+	principal := uuid.New()
+	other := uuid.New()
+	ctx := sharedApplication.WithPrincipal(context.Background(), principal)
+
+	otherTask, err := task.NewTask(other, "Someone else's task")
+	require.NoError(t, err)
+
+	inner := newRecordingTaskRepo()
+	repo := persistence.NewGuardedTaskRepository(inner)
+
+	_, err = repo.FindByUserID(ctx, other)
+	assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+	_, err = repo.FindPending(ctx, other)
+	assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+	err = repo.IterateTasks(ctx, other, func(*task.Task) error { return nil })
+	assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+	_, err = repo.FindByExternalID(ctx, other, "todoist:1")
+	assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+	_, err = repo.Search(ctx, other, "report", 10)
+	assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+	err = repo.Save(ctx, otherTask)
+	assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+	assert.Empty(t, inner.calls)
+}
+
+func TestGuardedTaskRepository_RejectsTasksOwnedByAnotherUser(t *testing.T) {
+	principal := uuid.New()
+	ctx := sharedApplication.WithPrincipal(context.Background(), principal)
+
+	otherTask, err := task.NewTask(uuid.New(), "Someone else's task")
+	require.NoError(t, err)
+
+	inner := newRecordingTaskRepo(otherTask)
+	repo := persistence.NewGuardedTaskRepository(inner)
+
+	found, err := repo.FindByID(ctx, otherTask.ID())
+	assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+	assert.Nil(t, found)
+
+	err = repo.Delete(ctx, otherTask.ID())
+	assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+	assert.Contains(t, inner.tasks, otherTask.ID())
+	assert.NotContains(t, inner.calls, "Delete")
+}
+
+func TestGuardedTaskRepository_AllowsPrincipal(t *testing.T) {
+	principal := uuid.New()
+	ctx := sharedApplication.WithPrincipal(context.Background(), principal)
+
+	own, err := task.NewTask(principal, "My task")
+	require.NoError(t, err)
+
+	inner := newRecordingTaskRepo()
+	repo := persistence.NewGuardedTaskRepository(inner)
+
+	require.NoError(t, repo.Save(ctx, own))
+
+	found, err := repo.FindByID(ctx, own.ID())
+	require.NoError(t, err)
+	assert.Same(t, own, found)
+
+	tasks, err := repo.FindByUserID(ctx, principal)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	var iterated int
+	require.NoError(t, repo.IterateTasks(ctx, principal, func(*task.Task) error {
+		iterated++
+		return nil
+	}))
+	assert.Equal(t, 1, iterated)
+
+	require.NoError(t, repo.Delete(ctx, own.ID()))
+	assert.Empty(t, inner.tasks)
+}
+
+func TestGuardedTaskRepository_AllowsSystemContext(t *testing.T) {
+	ctx := sharedApplication.WithSystem(context.Background())
+	userID := uuid.New()
+
+	inner := newRecordingTaskRepo()
+	repo := persistence.NewGuardedTaskRepository(inner)
+
+	_, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, uuid.New()))
+
+	assert.Equal(t, []string{"FindByUserID", "Delete"}, inner.calls)
+}
+
+func TestGuardedTaskRepository_RejectsContextWithoutPrincipal(t *testing.T) {
+	ctx := context.Background()
+
+	own, err := task.NewTask(uuid.New(), "My task")
+	require.NoError(t, err)
+
+	inner := newRecordingTaskRepo(own)
+	repo := persistence.NewGuardedTaskRepository(inner)
+
+	_, err = repo.FindByUserID(ctx, own.UserID())
+	assert.ErrorIs(t, err, sharedApplication.ErrNoPrincipal)
+
+	_, err = repo.FindByID(ctx, own.ID())
+	assert.ErrorIs(t, err, sharedApplication.ErrNoPrincipal)
+
+	assert.ErrorIs(t, repo.Save(ctx, own), sharedApplication.ErrNoPrincipal)
+	assert.ErrorIs(t, repo.Delete(ctx, own.ID()), sharedApplication.ErrNoPrincipal)
+
+	assert.Equal(t, []string{"FindByID"}, inner.calls)
+	assert.Contains(t, inner.tasks, own.ID())
+}
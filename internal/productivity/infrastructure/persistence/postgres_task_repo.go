@@ -9,19 +9,24 @@ import (
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
 	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/database"
+	sharedPersistence "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/persistence"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var (
-	ErrTaskNotFound      = errors.New("task not found")
-	ErrOptimisticLocking = errors.New("optimistic locking conflict")
+	ErrTaskNotFound = errors.New("task not found")
+	// ErrOptimisticLocking is returned when a task was saved by another
+	// process since it was loaded.
+	ErrOptimisticLocking = fmt.Errorf("%w: optimistic locking conflict", sharedDomain.ErrConcurrentModification)
 )
 
 // PostgresTaskRepository implements task.Repository using PostgreSQL.
 type PostgresTaskRepository struct {
-	conn database.Connection
+	conn   database.Connection
+	fields *sharedCrypto.FieldEncrypter
 }
 
 // NewPostgresTaskRepository creates a new PostgreSQL task repository.
@@ -37,6 +42,12 @@ func NewPostgresTaskRepositoryFromPool(pool *pgxpool.Pool) *PostgresTaskReposito
 	return &PostgresTaskRepository{conn: &poolWrapper{pool: pool}}
 }
 
+// WithFieldEncrypter encrypts task descriptions at rest.
+func (r *PostgresTaskRepository) WithFieldEncrypter(fields *sharedCrypto.FieldEncrypter) *PostgresTaskRepository {
+	r.fields = fields
+	return r
+}
+
 // poolWrapper wraps a pgxpool.Pool to implement database.Connection.
 // This is temporary for backward compatibility.
 type poolWrapper struct {
@@ -118,6 +129,54 @@ type taskRow struct {
 	Version         int
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	ChecklistRequired bool
+	BlockedReason     *string
+	BlockedAt         *time.Time
+	ExternalID        *string
+	Timezone          *string
+	WaitingOn         *string
+	WaitingSince      *time.Time
+	FollowUpAt        *time.Time
+	FollowUpSentAt    *time.Time
+	EarliestStart     *time.Time
+	Recurrence        *string
+}
+
+// taskColumns lists the tasks columns read by scanTaskRow, in order.
+const taskColumns = `id, user_id, title, description, status, priority,
+		       duration_minutes, due_date, completed_at, version, created_at, updated_at,
+		       checklist_required, blocked_reason, blocked_at, external_id, timezone,
+		       waiting_on, waiting_since, follow_up_at, follow_up_sent_at, earliest_start, recurrence`
+
+// scanTaskRow reads a row selected with taskColumns.
+func scanTaskRow(scanner database.Row) (taskRow, error) {
+	var row taskRow
+	err := scanner.Scan(
+		&row.ID,
+		&row.UserID,
+		&row.Title,
+		&row.Description,
+		&row.Status,
+		&row.Priority,
+		&row.DurationMinutes,
+		&row.DueDate,
+		&row.CompletedAt,
+		&row.Version,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+		&row.ChecklistRequired,
+		&row.BlockedReason,
+		&row.BlockedAt,
+		&row.ExternalID,
+		&row.Timezone,
+		&row.WaitingOn,
+		&row.WaitingSince,
+		&row.FollowUpAt,
+		&row.FollowUpSentAt,
+		&row.EarliestStart,
+		&row.Recurrence,
+	)
+	return row, err
 }
 
 // Save persists a task to the database.
@@ -130,15 +189,47 @@ func (r *PostgresTaskRepository) Save(ctx context.Context, t *task.Task) error {
 
 	var description *string
 	if t.Description() != "" {
-		desc := t.Description()
+		desc, err := r.fields.Encrypt(t.UserID(), t.Description())
+		if err != nil {
+			return fmt.Errorf("failed to encrypt description: %w", err)
+		}
 		description = &desc
 	}
 
+	var blockedReason *string
+	if t.BlockedReason() != "" {
+		stored, err := r.fields.Encrypt(t.UserID(), t.BlockedReason())
+		if err != nil {
+			return fmt.Errorf("failed to encrypt blocked reason: %w", err)
+		}
+		blockedReason = &stored
+	}
+
+	var waitingOn *string
+	if t.WaitingOn() != "" {
+		stored, err := r.fields.Encrypt(t.UserID(), t.WaitingOn())
+		if err != nil {
+			return fmt.Errorf("failed to encrypt waiting on: %w", err)
+		}
+		waitingOn = &stored
+	}
+
+	var recurrence *string
+	if rule := t.Recurrence(); rule != nil {
+		value := rule.String()
+		recurrence = &value
+	}
+
 	query := `
 		INSERT INTO tasks (
 			id, user_id, title, description, status, priority,
-			duration_minutes, due_date, completed_at, version, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			duration_minutes, due_date, completed_at, version, created_at, updated_at,
+			checklist_required, blocked_reason, blocked_at, external_id, timezone,
+			waiting_on, waiting_since, follow_up_at, follow_up_sent_at, earliest_start, recurrence
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+			$13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
+		)
 		ON CONFLICT (id) DO UPDATE SET
 			title = EXCLUDED.title,
 			description = EXCLUDED.description,
@@ -147,6 +238,17 @@ func (r *PostgresTaskRepository) Save(ctx context.Context, t *task.Task) error {
 			duration_minutes = EXCLUDED.duration_minutes,
 			due_date = EXCLUDED.due_date,
 			completed_at = EXCLUDED.completed_at,
+			checklist_required = EXCLUDED.checklist_required,
+			blocked_reason = EXCLUDED.blocked_reason,
+			blocked_at = EXCLUDED.blocked_at,
+			external_id = EXCLUDED.external_id,
+			timezone = EXCLUDED.timezone,
+			waiting_on = EXCLUDED.waiting_on,
+			waiting_since = EXCLUDED.waiting_since,
+			follow_up_at = EXCLUDED.follow_up_at,
+			follow_up_sent_at = EXCLUDED.follow_up_sent_at,
+			earliest_start = EXCLUDED.earliest_start,
+			recurrence = EXCLUDED.recurrence,
 			version = tasks.version + 1,
 			updated_at = NOW()
 		WHERE tasks.version = $10
@@ -168,44 +270,244 @@ func (r *PostgresTaskRepository) Save(ctx context.Context, t *task.Task) error {
 		t.Version(),
 		t.CreatedAt(),
 		t.UpdatedAt(),
+		t.ChecklistRequired(),
+		blockedReason,
+		t.BlockedAt(),
+		optionalString(t.ExternalID()),
+		optionalString(t.Timezone()),
+		waitingOn,
+		t.WaitingSince(),
+		t.FollowUpAt(),
+		t.FollowUpSentAt(),
+		t.EarliestStart(),
+		recurrence,
 	).Scan(&newVersion)
 
 	if err != nil {
 		if database.IsNoRows(err) {
-			return ErrOptimisticLocking
+			return fmt.Errorf("%w: task %s", ErrOptimisticLocking, t.ID())
+		}
+		return err
+	}
+	t.SetVersion(newVersion)
+
+	if err := r.saveReminders(ctx, t); err != nil {
+		return err
+	}
+	if err := r.saveChecklist(ctx, t); err != nil {
+		return err
+	}
+	if err := r.saveTags(ctx, t); err != nil {
+		return err
+	}
+	return r.saveSearchDocument(ctx, t)
+}
+
+// saveSearchDocument updates the task's search index entry. The description
+// is left out when it is stored encrypted.
+func (r *PostgresTaskRepository) saveSearchDocument(ctx context.Context, t *task.Task) error {
+	doc := sharedPersistence.SearchDocument{
+		EntityType: sharedPersistence.SearchEntityTask,
+		EntityID:   t.ID(),
+		UserID:     t.UserID(),
+		Title:      t.Title(),
+	}
+	if !r.fields.Encrypts() {
+		doc.Body = t.Description()
+	}
+	exec := database.ExecutorFromContext(ctx, r.conn)
+	_, err := exec.Exec(ctx, sharedPersistence.PostgresIndexSearchDocumentSQL, sharedPersistence.PostgresSearchDocumentArgs(doc)...)
+	return err
+}
+
+// saveReminders replaces the task's reminders, storing when each one fires
+// so pending reminders can be found without loading every task.
+func (r *PostgresTaskRepository) saveReminders(ctx context.Context, t *task.Task) error {
+	exec := database.ExecutorFromContext(ctx, r.conn)
+
+	if _, err := exec.Exec(ctx, `DELETE FROM task_reminders WHERE task_id = $1`, t.ID()); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO task_reminders (task_id, offset_minutes, remind_at, sent_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	for _, reminder := range t.Reminders() {
+		var remindAt *time.Time
+		if t.DueDate() != nil {
+			at := reminder.RemindAt(*t.DueDate())
+			remindAt = &at
+		}
+		if _, err := exec.Exec(ctx, query,
+			t.ID(),
+			int(reminder.Offset/time.Minute),
+			remindAt,
+			reminder.SentAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadReminders restores the task's reminders.
+func (r *PostgresTaskRepository) loadReminders(ctx context.Context, t *task.Task) error {
+	query := `
+		SELECT offset_minutes, sent_at
+		FROM task_reminders
+		WHERE task_id = $1
+	`
+
+	exec := database.ExecutorFromContext(ctx, r.conn)
+	rows, err := exec.Query(ctx, query, t.ID())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var reminders []task.Reminder
+	for rows.Next() {
+		var offsetMinutes int
+		var sentAt *time.Time
+		if err := rows.Scan(&offsetMinutes, &sentAt); err != nil {
+			return err
+		}
+		reminders = append(reminders, task.Reminder{
+			Offset: time.Duration(offsetMinutes) * time.Minute,
+			SentAt: sentAt,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	t.RehydrateReminders(reminders)
+	return nil
+}
+
+// saveChecklist replaces the task's checklist items, keeping their order.
+func (r *PostgresTaskRepository) saveChecklist(ctx context.Context, t *task.Task) error {
+	exec := database.ExecutorFromContext(ctx, r.conn)
+
+	if _, err := exec.Exec(ctx, `DELETE FROM task_checklist_items WHERE task_id = $1`, t.ID()); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO task_checklist_items (id, task_id, position, title, done)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	for i, item := range t.Checklist() {
+		if _, err := exec.Exec(ctx, query, item.ID, t.ID(), i, item.Title, item.Done); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadChecklist restores the task's checklist items.
+func (r *PostgresTaskRepository) loadChecklist(ctx context.Context, t *task.Task) error {
+	exec := database.ExecutorFromContext(ctx, r.conn)
+
+	query := `
+		SELECT id, title, done
+		FROM task_checklist_items
+		WHERE task_id = $1
+		ORDER BY position
+	`
+	rows, err := exec.Query(ctx, query, t.ID())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var items []task.ChecklistItem
+	for rows.Next() {
+		var item task.ChecklistItem
+		if err := rows.Scan(&item.ID, &item.Title, &item.Done); err != nil {
+			return err
 		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	t.RehydrateChecklist(items, t.ChecklistRequired())
+	return nil
+}
+
+// saveTags replaces the task's tags.
+func (r *PostgresTaskRepository) saveTags(ctx context.Context, t *task.Task) error {
+	exec := database.ExecutorFromContext(ctx, r.conn)
+
+	if _, err := exec.Exec(ctx, `DELETE FROM task_tags WHERE task_id = $1`, t.ID()); err != nil {
 		return err
 	}
 
+	for _, tag := range t.Tags() {
+		if _, err := exec.Exec(ctx, `INSERT INTO task_tags (task_id, tag) VALUES ($1, $2)`, t.ID(), tag); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// loadTags restores the task's tags.
+func (r *PostgresTaskRepository) loadTags(ctx context.Context, t *task.Task) error {
+	exec := database.ExecutorFromContext(ctx, r.conn)
+	rows, err := exec.Query(ctx, `SELECT tag FROM task_tags WHERE task_id = $1 ORDER BY tag`, t.ID())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return err
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	t.RehydrateTags(tags)
+	return nil
+}
+
+// FindByExternalID retrieves the user's task imported with the given
+// external ID. It returns nil when there is none.
+func (r *PostgresTaskRepository) FindByExternalID(ctx context.Context, userID uuid.UUID, externalID string) (*task.Task, error) {
+	var id uuid.UUID
+	exec := database.ExecutorFromContext(ctx, r.conn)
+	if err := exec.QueryRow(ctx,
+		`SELECT id FROM tasks WHERE user_id = $1 AND external_id = $2`, userID, externalID,
+	).Scan(&id); err != nil {
+		if database.IsNoRows(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.FindByID(ctx, id)
+}
+
 // FindByID retrieves a task by its ID.
 func (r *PostgresTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*task.Task, error) {
 	query := `
-		SELECT id, user_id, title, description, status, priority,
-		       duration_minutes, due_date, completed_at, version, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
 		WHERE id = $1
 	`
 
-	var row taskRow
 	exec := database.ExecutorFromContext(ctx, r.conn)
-	err := exec.QueryRow(ctx, query, id).Scan(
-		&row.ID,
-		&row.UserID,
-		&row.Title,
-		&row.Description,
-		&row.Status,
-		&row.Priority,
-		&row.DurationMinutes,
-		&row.DueDate,
-		&row.CompletedAt,
-		&row.Version,
-		&row.CreatedAt,
-		&row.UpdatedAt,
-	)
-
+	row, err := scanTaskRow(exec.QueryRow(ctx, query, id))
 	if err != nil {
 		if database.IsNoRows(err) {
 			return nil, ErrTaskNotFound
@@ -213,14 +515,27 @@ func (r *PostgresTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*t
 		return nil, err
 	}
 
-	return r.rowToTask(row)
+	t, err := r.rowToTask(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadReminders(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to load reminders: %w", err)
+	}
+	if err := r.loadChecklist(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to load checklist: %w", err)
+	}
+	if err := r.loadTags(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
+
+	return t, nil
 }
 
 // FindByUserID retrieves all tasks for a user.
 func (r *PostgresTaskRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*task.Task, error) {
 	query := `
-		SELECT id, user_id, title, description, status, priority,
-		       duration_minutes, due_date, completed_at, version, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -233,14 +548,51 @@ func (r *PostgresTaskRepository) FindByUserID(ctx context.Context, userID uuid.U
 	}
 	defer rows.Close()
 
-	return r.scanTasks(rows)
+	return r.scanTasks(ctx, rows)
+}
+
+// iterateBatchSize is the number of tasks held in memory at a time by IterateTasks.
+var iterateBatchSize = 500
+
+// IterateTasks streams all tasks for a user in batches ordered by id.
+func (r *PostgresTaskRepository) IterateTasks(ctx context.Context, userID uuid.UUID, fn func(*task.Task) error) error {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE user_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+
+	exec := database.ExecutorFromContext(ctx, r.conn)
+	after := uuid.Nil
+	for {
+		rows, err := exec.Query(ctx, query, userID, after, iterateBatchSize)
+		if err != nil {
+			return err
+		}
+		batch, err := r.scanTasks(ctx, rows)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, t := range batch {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+		if len(batch) < iterateBatchSize {
+			return nil
+		}
+		after = batch[len(batch)-1].ID()
+	}
 }
 
 // FindPending retrieves pending tasks for a user.
 func (r *PostgresTaskRepository) FindPending(ctx context.Context, userID uuid.UUID) ([]*task.Task, error) {
 	query := `
-		SELECT id, user_id, title, description, status, priority,
-		       duration_minutes, due_date, completed_at, version, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
 		WHERE user_id = $1 AND status IN ('pending', 'in_progress')
 		ORDER BY
@@ -262,13 +614,158 @@ func (r *PostgresTaskRepository) FindPending(ctx context.Context, userID uuid.UU
 	}
 	defer rows.Close()
 
-	return r.scanTasks(rows)
+	return r.scanTasks(ctx, rows)
+}
+
+// FindWithPendingReminders retrieves open tasks with unsent reminders due by until.
+func (r *PostgresTaskRepository) FindWithPendingReminders(ctx context.Context, until time.Time, limit int) ([]*task.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks t
+		JOIN (
+			SELECT task_id, MIN(remind_at) AS next_remind_at
+			FROM task_reminders
+			WHERE sent_at IS NULL AND remind_at <= $1
+			GROUP BY task_id
+		) r ON r.task_id = t.id
+		WHERE t.status IN ('pending', 'in_progress')
+		ORDER BY r.next_remind_at
+		LIMIT $2
+	`
+
+	exec := database.ExecutorFromContext(ctx, r.conn)
+	rows, err := exec.Query(ctx, query, until, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTasks(ctx, rows)
+}
+
+// FindWithDueFollowUps retrieves waiting tasks whose unsent follow-up is due
+// by until, earliest first.
+func (r *PostgresTaskRepository) FindWithDueFollowUps(ctx context.Context, until time.Time, limit int) ([]*task.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE status = 'waiting'
+		  AND follow_up_sent_at IS NULL
+		  AND follow_up_at IS NOT NULL
+		  AND follow_up_at <= $1
+		ORDER BY follow_up_at
+		LIMIT $2
+	`
+
+	exec := database.ExecutorFromContext(ctx, r.conn)
+	rows, err := exec.Query(ctx, query, until, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTasks(ctx, rows)
+}
+
+// FindDueBelowPriority retrieves open tasks due by until whose priority is
+// below the given one, soonest due first.
+func (r *PostgresTaskRepository) FindDueBelowPriority(ctx context.Context, until time.Time, below value_objects.Priority, limit int) ([]*task.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE status IN ('pending', 'in_progress')
+		  AND due_date IS NOT NULL
+		  AND due_date <= $1
+		  AND priority = ANY($2)
+		ORDER BY due_date
+		LIMIT $3
+	`
+
+	exec := database.ExecutorFromContext(ctx, r.conn)
+	rows, err := exec.Query(ctx, query, until, prioritiesBelow(below), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTasks(ctx, rows)
+}
+
+// FindCompletedBefore retrieves completed tasks finished at or before the
+// given time, oldest first.
+func (r *PostgresTaskRepository) FindCompletedBefore(ctx context.Context, before time.Time, limit int) ([]*task.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE status = 'completed'
+		  AND completed_at IS NOT NULL
+		  AND completed_at <= $1
+		ORDER BY completed_at
+		LIMIT $2
+	`
+
+	exec := database.ExecutorFromContext(ctx, r.conn)
+	rows, err := exec.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTasks(ctx, rows)
+}
+
+// Search returns up to limit of the user's tasks matching query, most
+// relevant first.
+func (r *PostgresTaskRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*task.Task, error) {
+	terms := sharedPersistence.SearchTerms(query)
+	if len(terms) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	exec := database.ExecutorFromContext(ctx, r.conn)
+	rows, err := exec.Query(ctx, sharedPersistence.PostgresSearchIndexSQL,
+		sharedPersistence.SearchEntityTask, userID, sharedPersistence.PostgresTSQuery(terms), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	tasks := make([]*task.Task, 0, len(ids))
+	for _, id := range ids {
+		t, err := r.FindByID(ctx, id)
+		if errors.Is(err, ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
 }
 
 // Delete removes a task from the database.
 func (r *PostgresTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM tasks WHERE id = $1`
 	exec := database.ExecutorFromContext(ctx, r.conn)
+	if _, err := exec.Exec(ctx, sharedPersistence.PostgresRemoveSearchDocumentSQL, sharedPersistence.SearchEntityTask, id); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM tasks WHERE id = $1`
 	result, err := exec.Exec(ctx, query, id)
 	if err != nil {
 		return err
@@ -283,25 +780,11 @@ func (r *PostgresTaskRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
-func (r *PostgresTaskRepository) scanTasks(rows database.Rows) ([]*task.Task, error) {
+func (r *PostgresTaskRepository) scanTasks(ctx context.Context, rows database.Rows) ([]*task.Task, error) {
 	var tasks []*task.Task
 
 	for rows.Next() {
-		var row taskRow
-		err := rows.Scan(
-			&row.ID,
-			&row.UserID,
-			&row.Title,
-			&row.Description,
-			&row.Status,
-			&row.Priority,
-			&row.DurationMinutes,
-			&row.DueDate,
-			&row.CompletedAt,
-			&row.Version,
-			&row.CreatedAt,
-			&row.UpdatedAt,
-		)
+		row, err := scanTaskRow(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -317,6 +800,20 @@ func (r *PostgresTaskRepository) scanTasks(rows database.Rows) ([]*task.Task, er
 		return nil, err
 	}
 
+	// Reminders, checklist items and tags are loaded once the result set is drained,
+	// since a transaction cannot run a second query while rows are still open.
+	for _, t := range tasks {
+		if err := r.loadReminders(ctx, t); err != nil {
+			return nil, fmt.Errorf("failed to load reminders: %w", err)
+		}
+		if err := r.loadChecklist(ctx, t); err != nil {
+			return nil, fmt.Errorf("failed to load checklist: %w", err)
+		}
+		if err := r.loadTags(ctx, t); err != nil {
+			return nil, fmt.Errorf("failed to load tags: %w", err)
+		}
+	}
+
 	return tasks, nil
 }
 
@@ -330,7 +827,11 @@ func (r *PostgresTaskRepository) rowToTask(row taskRow) (*task.Task, error) {
 
 	// Set additional fields
 	if row.Description != nil {
-		if err := t.SetDescription(*row.Description); err != nil {
+		description, err := r.fields.Decrypt(row.UserID, *row.Description)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt description: %w", err)
+		}
+		if err := t.SetDescription(description); err != nil {
 			return nil, fmt.Errorf("failed to set description: %w", err)
 		}
 	}
@@ -373,6 +874,44 @@ func (r *PostgresTaskRepository) rowToTask(row taskRow) (*task.Task, error) {
 		if err := t.Archive(); err != nil {
 			return nil, fmt.Errorf("failed to restore archived status: %w", err)
 		}
+	case "blocked":
+		if err := t.Block(""); err != nil {
+			return nil, fmt.Errorf("failed to restore blocked status: %w", err)
+		}
+		var reason string
+		if row.BlockedReason != nil {
+			if reason, err = r.fields.Decrypt(row.UserID, *row.BlockedReason); err != nil {
+				return nil, fmt.Errorf("failed to decrypt blocked reason: %w", err)
+			}
+		}
+		t.RehydrateBlock(reason, row.BlockedAt)
+	case "waiting":
+		var person string
+		if row.WaitingOn != nil {
+			if person, err = r.fields.Decrypt(row.UserID, *row.WaitingOn); err != nil {
+				return nil, fmt.Errorf("failed to decrypt waiting on: %w", err)
+			}
+		}
+		t.RehydrateWaiting(person, row.WaitingSince, row.FollowUpAt, row.FollowUpSentAt)
+	}
+	if row.CompletedAt != nil {
+		t.RehydrateCompletedAt(row.CompletedAt)
+	}
+
+	t.RehydrateChecklist(nil, row.ChecklistRequired)
+	if row.ExternalID != nil {
+		t.RehydrateExternalID(*row.ExternalID)
+	}
+	if row.Timezone != nil {
+		t.RehydrateTimezone(*row.Timezone)
+	}
+	t.RehydrateEarliestStart(row.EarliestStart)
+	if row.Recurrence != nil {
+		rule, err := task.ParseRecurrence(*row.Recurrence)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurrence %q: %w", *row.Recurrence, err)
+		}
+		t.RehydrateRecurrence(&rule)
 	}
 
 	// Clear events since we're rehydrating from storage
@@ -385,3 +924,20 @@ func (r *PostgresTaskRepository) rowToTask(row taskRow) (*task.Task, error) {
 
 	return t, nil
 }
+
+// optionalString stores an empty string as NULL.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// prioritiesBelow returns the stored names of the priorities below p.
+func prioritiesBelow(p value_objects.Priority) []string {
+	var names []string
+	for q := value_objects.PriorityNone; q.Weight() < p.Weight(); q++ {
+		names = append(names, q.String())
+	}
+	return names
+}
@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	db "github.com/felixgeelhaar/orbita/db/generated/sqlite"
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
 	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
 	sharedPersistence "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/persistence"
 	"github.com/google/uuid"
 )
@@ -18,6 +20,7 @@ import (
 // SQLiteTaskRepository implements task.Repository using SQLite.
 type SQLiteTaskRepository struct {
 	dbConn *sql.DB
+	fields *sharedCrypto.FieldEncrypter
 }
 
 // NewSQLiteTaskRepository creates a new SQLite task repository.
@@ -25,6 +28,12 @@ func NewSQLiteTaskRepository(dbConn *sql.DB) *SQLiteTaskRepository {
 	return &SQLiteTaskRepository{dbConn: dbConn}
 }
 
+// WithFieldEncrypter encrypts task descriptions at rest.
+func (r *SQLiteTaskRepository) WithFieldEncrypter(fields *sharedCrypto.FieldEncrypter) *SQLiteTaskRepository {
+	r.fields = fields
+	return r
+}
+
 // getQuerier returns the appropriate querier (transaction or connection) based on context.
 func (r *SQLiteTaskRepository) getQuerier(ctx context.Context) *db.Queries {
 	if info, ok := sharedPersistence.SQLiteTxInfoFromContext(ctx); ok {
@@ -33,6 +42,17 @@ func (r *SQLiteTaskRepository) getQuerier(ctx context.Context) *db.Queries {
 	return db.New(r.dbConn)
 }
 
+// getDB returns the raw database handle (transaction or connection) based on context.
+func (r *SQLiteTaskRepository) getDB(ctx context.Context) interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+} {
+	if info, ok := sharedPersistence.SQLiteTxInfoFromContext(ctx); ok {
+		return info.Tx
+	}
+	return r.dbConn
+}
+
 // Save persists a task to the database.
 func (r *SQLiteTaskRepository) Save(ctx context.Context, t *task.Task) error {
 	queries := r.getQuerier(ctx)
@@ -44,7 +64,11 @@ func (r *SQLiteTaskRepository) Save(ctx context.Context, t *task.Task) error {
 
 	var description sql.NullString
 	if t.Description() != "" {
-		description = sql.NullString{String: t.Description(), Valid: true}
+		stored, err := r.fields.Encrypt(t.UserID(), t.Description())
+		if err != nil {
+			return fmt.Errorf("failed to encrypt description: %w", err)
+		}
+		description = sql.NullString{String: stored, Valid: true}
 	}
 
 	var dueDate sql.NullString
@@ -57,48 +81,425 @@ func (r *SQLiteTaskRepository) Save(ctx context.Context, t *task.Task) error {
 		completedAt = sql.NullString{String: t.CompletedAt().Format(time.RFC3339), Valid: true}
 	}
 
+	var blockedReason sql.NullString
+	if t.BlockedReason() != "" {
+		stored, err := r.fields.Encrypt(t.UserID(), t.BlockedReason())
+		if err != nil {
+			return fmt.Errorf("failed to encrypt blocked reason: %w", err)
+		}
+		blockedReason = sql.NullString{String: stored, Valid: true}
+	}
+
+	var waitingOn sql.NullString
+	if t.WaitingOn() != "" {
+		stored, err := r.fields.Encrypt(t.UserID(), t.WaitingOn())
+		if err != nil {
+			return fmt.Errorf("failed to encrypt waiting on: %w", err)
+		}
+		waitingOn = sql.NullString{String: stored, Valid: true}
+	}
+
+	var checklistRequired int64
+	if t.ChecklistRequired() {
+		checklistRequired = 1
+	}
+
+	var recurrence sql.NullString
+	if rule := t.Recurrence(); rule != nil {
+		recurrence = sql.NullString{String: rule.String(), Valid: true}
+	}
+
 	// Try to update first
 	result, err := queries.UpdateTask(ctx, db.UpdateTaskParams{
-		Title:           t.Title(),
-		Description:     description,
-		Status:          t.Status().String(),
-		Priority:        t.Priority().String(),
-		DurationMinutes: durationMinutes,
-		DueDate:         dueDate,
-		CompletedAt:     completedAt,
-		ID:              t.ID().String(),
-		Version:         int64(t.Version()),
+		Title:             t.Title(),
+		Description:       description,
+		Status:            t.Status().String(),
+		Priority:          t.Priority().String(),
+		DurationMinutes:   durationMinutes,
+		DueDate:           dueDate,
+		CompletedAt:       completedAt,
+		ChecklistRequired: checklistRequired,
+		BlockedReason:     blockedReason,
+		BlockedAt:         nullTime(t.BlockedAt()),
+		ExternalID:        nullString(t.ExternalID()),
+		Timezone:          nullString(t.Timezone()),
+		WaitingOn:         waitingOn,
+		WaitingSince:      nullTime(t.WaitingSince()),
+		FollowUpAt:        nullTime(t.FollowUpAt()),
+		FollowUpSentAt:    nullTime(t.FollowUpSentAt()),
+		EarliestStart:     nullTime(t.EarliestStart()),
+		Recurrence:        recurrence,
+		ID:                t.ID().String(),
+		Version:           int64(t.Version()),
 	})
 
+	if err == nil {
+		t.SetVersion(int(result.Version))
+		return r.saveChildren(ctx, t)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	// No row matched the ID and version: either the task is new, or it was
+	// saved by another process since it was loaded.
+	if _, err := queries.GetTaskByID(ctx, t.ID().String()); err == nil {
+		return fmt.Errorf("%w: task %s", ErrOptimisticLocking, t.ID())
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	// Task doesn't exist, create it
+	_, err = queries.CreateTask(ctx, db.CreateTaskParams{
+		ID:                t.ID().String(),
+		UserID:            t.UserID().String(),
+		Title:             t.Title(),
+		Description:       description,
+		Status:            t.Status().String(),
+		Priority:          t.Priority().String(),
+		DurationMinutes:   durationMinutes,
+		DueDate:           dueDate,
+		ChecklistRequired: checklistRequired,
+		BlockedReason:     blockedReason,
+		BlockedAt:         nullTime(t.BlockedAt()),
+		ExternalID:        nullString(t.ExternalID()),
+		Timezone:          nullString(t.Timezone()),
+		WaitingOn:         waitingOn,
+		WaitingSince:      nullTime(t.WaitingSince()),
+		FollowUpAt:        nullTime(t.FollowUpAt()),
+		FollowUpSentAt:    nullTime(t.FollowUpSentAt()),
+		EarliestStart:     nullTime(t.EarliestStart()),
+		Recurrence:        recurrence,
+		Version:           int64(t.Version()),
+		CreatedAt:         t.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:         t.UpdatedAt().Format(time.RFC3339),
+	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			// Task doesn't exist, create it
-			_, err = queries.CreateTask(ctx, db.CreateTaskParams{
-				ID:              t.ID().String(),
-				UserID:          t.UserID().String(),
-				Title:           t.Title(),
-				Description:     description,
-				Status:          t.Status().String(),
-				Priority:        t.Priority().String(),
-				DurationMinutes: durationMinutes,
-				DueDate:         dueDate,
-				Version:         int64(t.Version()),
-				CreatedAt:       t.CreatedAt().Format(time.RFC3339),
-				UpdatedAt:       t.UpdatedAt().Format(time.RFC3339),
-			})
+		return err
+	}
+	return r.saveChildren(ctx, t)
+}
+
+// saveChildren persists the reminders, checklist items and tags stored
+// alongside the task row, and updates the search index.
+func (r *SQLiteTaskRepository) saveChildren(ctx context.Context, t *task.Task) error {
+	if err := r.saveReminders(ctx, t); err != nil {
+		return err
+	}
+	if err := r.saveChecklist(ctx, t); err != nil {
+		return err
+	}
+	if err := r.saveTags(ctx, t); err != nil {
+		return err
+	}
+	return sharedPersistence.IndexSQLiteSearchDocument(ctx, r.getDB(ctx), r.searchDocument(t))
+}
+
+// searchDocument returns the task's search index entry. The description is
+// left out when it is stored encrypted.
+func (r *SQLiteTaskRepository) searchDocument(t *task.Task) sharedPersistence.SearchDocument {
+	doc := sharedPersistence.SearchDocument{
+		EntityType: sharedPersistence.SearchEntityTask,
+		EntityID:   t.ID(),
+		UserID:     t.UserID(),
+		Title:      t.Title(),
+	}
+	if !r.fields.Encrypts() {
+		doc.Body = t.Description()
+	}
+	return doc
+}
+
+// saveReminders replaces the task's reminders, storing when each one fires
+// so pending reminders can be found without loading every task.
+func (r *SQLiteTaskRepository) saveReminders(ctx context.Context, t *task.Task) error {
+	conn := r.getDB(ctx)
+
+	if _, err := conn.ExecContext(ctx, "DELETE FROM task_reminders WHERE task_id = ?", t.ID().String()); err != nil {
+		return err
+	}
+
+	for _, reminder := range t.Reminders() {
+		var remindAt sql.NullString
+		if t.DueDate() != nil {
+			remindAt = sql.NullString{String: reminder.RemindAt(*t.DueDate()).UTC().Format(time.RFC3339), Valid: true}
+		}
+		var sentAt sql.NullString
+		if reminder.SentAt != nil {
+			sentAt = sql.NullString{String: reminder.SentAt.UTC().Format(time.RFC3339), Valid: true}
+		}
+		if _, err := conn.ExecContext(ctx,
+			"INSERT INTO task_reminders (task_id, offset_minutes, remind_at, sent_at) VALUES (?, ?, ?, ?)",
+			t.ID().String(), int64(reminder.Offset/time.Minute), remindAt, sentAt,
+		); err != nil {
 			return err
 		}
+	}
+
+	return nil
+}
+
+// loadReminders restores the reminders of the tasks, keyed by id.
+func (r *SQLiteTaskRepository) loadReminders(ctx context.Context, tasks map[string]*task.Task, ids []any) error {
+	rows, err := r.getDB(ctx).QueryContext(ctx,
+		"SELECT task_id, offset_minutes, sent_at FROM task_reminders WHERE task_id IN ("+placeholders(len(ids))+")", ids...,
+	)
+	if err != nil {
 		return err
 	}
+	defer rows.Close()
+
+	reminders := make(map[string][]task.Reminder, len(ids))
+	for rows.Next() {
+		var taskID string
+		var offsetMinutes int64
+		var sent sql.NullString
+		if err := rows.Scan(&taskID, &offsetMinutes, &sent); err != nil {
+			return err
+		}
+		reminder := task.Reminder{Offset: time.Duration(offsetMinutes) * time.Minute}
+		if reminder.SentAt, err = parseNullTime(sent); err != nil {
+			return fmt.Errorf("invalid reminder sent_at: %w", err)
+		}
+		reminders[taskID] = append(reminders[taskID], reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, t := range tasks {
+		t.RehydrateReminders(reminders[id])
+	}
+	return nil
+}
 
-	// Check if update affected any rows (optimistic locking)
-	if result.Version == int64(t.Version()) {
-		return ErrOptimisticLocking
+// saveChecklist replaces the task's checklist items, keeping their order.
+func (r *SQLiteTaskRepository) saveChecklist(ctx context.Context, t *task.Task) error {
+	conn := r.getDB(ctx)
+
+	if _, err := conn.ExecContext(ctx, "DELETE FROM task_checklist_items WHERE task_id = ?", t.ID().String()); err != nil {
+		return err
+	}
+
+	for i, item := range t.Checklist() {
+		if _, err := conn.ExecContext(ctx,
+			"INSERT INTO task_checklist_items (id, task_id, position, title, done) VALUES (?, ?, ?, ?, ?)",
+			item.ID.String(), t.ID().String(), i, item.Title, item.Done,
+		); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// loadChecklist restores the checklist items of the tasks, keyed by id.
+func (r *SQLiteTaskRepository) loadChecklist(ctx context.Context, tasks map[string]*task.Task, ids []any) error {
+	rows, err := r.getDB(ctx).QueryContext(ctx,
+		"SELECT task_id, id, title, done FROM task_checklist_items WHERE task_id IN ("+placeholders(len(ids))+") ORDER BY task_id, position", ids...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	items := make(map[string][]task.ChecklistItem, len(ids))
+	for rows.Next() {
+		var taskID, id string
+		var item task.ChecklistItem
+		if err := rows.Scan(&taskID, &id, &item.Title, &item.Done); err != nil {
+			return err
+		}
+		if item.ID, err = uuid.Parse(id); err != nil {
+			return fmt.Errorf("invalid checklist item id: %w", err)
+		}
+		items[taskID] = append(items[taskID], item)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, t := range tasks {
+		t.RehydrateChecklist(items[id], t.ChecklistRequired())
+	}
+	return nil
+}
+
+// saveTags replaces the task's tags.
+func (r *SQLiteTaskRepository) saveTags(ctx context.Context, t *task.Task) error {
+	conn := r.getDB(ctx)
+
+	if _, err := conn.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = ?", t.ID().String()); err != nil {
+		return err
+	}
+
+	for _, tag := range t.Tags() {
+		if _, err := conn.ExecContext(ctx,
+			"INSERT INTO task_tags (task_id, tag) VALUES (?, ?)",
+			t.ID().String(), tag,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadTags restores the tags of the tasks, keyed by id.
+func (r *SQLiteTaskRepository) loadTags(ctx context.Context, tasks map[string]*task.Task, ids []any) error {
+	rows, err := r.getDB(ctx).QueryContext(ctx,
+		"SELECT task_id, tag FROM task_tags WHERE task_id IN ("+placeholders(len(ids))+")", ids...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	tags := make(map[string][]string, len(ids))
+	for rows.Next() {
+		var taskID, tag string
+		if err := rows.Scan(&taskID, &tag); err != nil {
+			return err
+		}
+		tags[taskID] = append(tags[taskID], tag)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, t := range tasks {
+		t.RehydrateTags(tags[id])
+	}
+	return nil
+}
+
+// placeholders returns n comma-separated query placeholders.
+func placeholders(n int) string {
+	return "?" + strings.Repeat(", ?", n-1)
+}
+
+// restoreBlock restores the block reason and time of a blocked task.
+func (r *SQLiteTaskRepository) restoreBlock(t *task.Task, row db.Task) error {
+	var reason string
+	if row.BlockedReason.Valid {
+		var err error
+		if reason, err = r.fields.Decrypt(t.UserID(), row.BlockedReason.String); err != nil {
+			return fmt.Errorf("failed to decrypt blocked reason: %w", err)
+		}
+	}
+	blockedAt, err := parseNullTime(row.BlockedAt)
+	if err != nil {
+		return fmt.Errorf("invalid blocked_at: %w", err)
+	}
+
+	t.RehydrateBlock(reason, blockedAt)
+	return nil
+}
+
+// restoreWaiting restores who a waiting task is waiting on and its follow-up.
+func (r *SQLiteTaskRepository) restoreWaiting(t *task.Task, row db.Task) error {
+	var person string
+	if row.WaitingOn.Valid {
+		var err error
+		if person, err = r.fields.Decrypt(t.UserID(), row.WaitingOn.String); err != nil {
+			return fmt.Errorf("failed to decrypt waiting on: %w", err)
+		}
+	}
+	times := make([]*time.Time, 3)
+	for i, value := range []sql.NullString{row.WaitingSince, row.FollowUpAt, row.FollowUpSentAt} {
+		parsed, err := parseNullTime(value)
+		if err != nil {
+			return fmt.Errorf("invalid waiting time: %w", err)
+		}
+		times[i] = parsed
+	}
+
+	t.RehydrateWaiting(person, times[0], times[1], times[2])
+	return nil
+}
+
+// restoreColumns restores the optional settings stored on the task row.
+func (r *SQLiteTaskRepository) restoreColumns(t *task.Task, row db.Task) error {
+	t.RehydrateChecklist(nil, row.ChecklistRequired != 0)
+	t.RehydrateExternalID(row.ExternalID.String)
+	t.RehydrateTimezone(row.Timezone.String)
+
+	earliestStart, err := parseNullTime(row.EarliestStart)
+	if err != nil {
+		return fmt.Errorf("invalid earliest_start: %w", err)
+	}
+	t.RehydrateEarliestStart(earliestStart)
+
+	if row.Recurrence.Valid {
+		rule, err := task.ParseRecurrence(row.Recurrence.String)
+		if err != nil {
+			return fmt.Errorf("invalid recurrence %q: %w", row.Recurrence.String, err)
+		}
+		t.RehydrateRecurrence(&rule)
+	}
+	return nil
+}
+
+// nullTime stores an optional time as an RFC 3339 string in UTC.
+func nullTime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: t.UTC().Format(time.RFC3339), Valid: true}
+}
+
+// parseNullTime reads an optional time stored by nullTime.
+func parseNullTime(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value.String)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// nullString stores an empty string as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// FindByExternalID retrieves the user's task imported with the given
+// external ID. It returns nil when there is none.
+func (r *SQLiteTaskRepository) FindByExternalID(ctx context.Context, userID uuid.UUID, externalID string) (*task.Task, error) {
+	rows, err := r.getDB(ctx).QueryContext(ctx,
+		"SELECT id FROM tasks WHERE user_id = ? AND external_id = ?",
+		userID.String(), externalID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var id string
+	found := rows.Next()
+	if found {
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	if !found {
+		return nil, nil
+	}
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task id: %w", err)
+	}
+	return r.FindByID(ctx, taskID)
+}
+
 // FindByID retrieves a task by its ID.
 func (r *SQLiteTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*task.Task, error) {
 	queries := r.getQuerier(ctx)
@@ -110,7 +511,11 @@ func (r *SQLiteTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*tas
 		return nil, err
 	}
 
-	return r.rowToTask(row)
+	tasks, err := r.rowsToTasks(ctx, []db.Task{row})
+	if err != nil {
+		return nil, err
+	}
+	return tasks[0], nil
 }
 
 // FindByUserID retrieves all tasks for a user.
@@ -121,16 +526,37 @@ func (r *SQLiteTaskRepository) FindByUserID(ctx context.Context, userID uuid.UUI
 		return nil, err
 	}
 
-	tasks := make([]*task.Task, 0, len(rows))
-	for _, row := range rows {
-		t, err := r.rowToTask(row)
+	return r.rowsToTasks(ctx, rows)
+}
+
+// IterateTasks streams all tasks for a user in batches ordered by id.
+func (r *SQLiteTaskRepository) IterateTasks(ctx context.Context, userID uuid.UUID, fn func(*task.Task) error) error {
+	queries := r.getQuerier(ctx)
+	after := ""
+	for {
+		rows, err := queries.GetTasksByUserIDAfter(ctx, db.GetTasksByUserIDAfterParams{
+			UserID: userID.String(),
+			ID:     after,
+			Limit:  int64(iterateBatchSize),
+		})
 		if err != nil {
-			return nil, err
+			return err
+		}
+		batch, err := r.rowsToTasks(ctx, rows)
+		if err != nil {
+			return err
 		}
-		tasks = append(tasks, t)
-	}
 
-	return tasks, nil
+		for _, t := range batch {
+			if err := fn(t); err != nil {
+				return err
+			}
+		}
+		if len(rows) < iterateBatchSize {
+			return nil
+		}
+		after = rows[len(rows)-1].ID
+	}
 }
 
 // FindPending retrieves pending tasks for a user.
@@ -141,9 +567,51 @@ func (r *SQLiteTaskRepository) FindPending(ctx context.Context, userID uuid.UUID
 		return nil, err
 	}
 
-	tasks := make([]*task.Task, 0, len(rows))
-	for _, row := range rows {
-		t, err := r.rowToTask(row)
+	return r.rowsToTasks(ctx, rows)
+}
+
+// FindWithPendingReminders retrieves open tasks with unsent reminders due by until.
+func (r *SQLiteTaskRepository) FindWithPendingReminders(ctx context.Context, until time.Time, limit int) ([]*task.Task, error) {
+	rows, err := r.getDB(ctx).QueryContext(ctx, `
+		SELECT t.id
+		FROM task_reminders r
+		JOIN tasks t ON t.id = r.task_id
+		WHERE r.sent_at IS NULL
+		  AND r.remind_at IS NOT NULL
+		  AND r.remind_at <= ?
+		  AND t.status IN ('pending', 'in_progress')
+		GROUP BY t.id
+		ORDER BY MIN(r.remind_at)
+		LIMIT ?
+	`, until.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		taskID, err := uuid.Parse(id)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("invalid task id: %w", err)
+		}
+		ids = append(ids, taskID)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*task.Task, 0, len(ids))
+	for _, id := range ids {
+		t, err := r.FindByID(ctx, id)
 		if err != nil {
 			return nil, err
 		}
@@ -153,12 +621,239 @@ func (r *SQLiteTaskRepository) FindPending(ctx context.Context, userID uuid.UUID
 	return tasks, nil
 }
 
+// FindWithDueFollowUps retrieves waiting tasks whose unsent follow-up is due
+// by until, earliest first.
+func (r *SQLiteTaskRepository) FindWithDueFollowUps(ctx context.Context, until time.Time, limit int) ([]*task.Task, error) {
+	rows, err := r.getDB(ctx).QueryContext(ctx, `
+		SELECT id
+		FROM tasks
+		WHERE status = 'waiting'
+		  AND follow_up_sent_at IS NULL
+		  AND follow_up_at IS NOT NULL
+		  AND follow_up_at <= ?
+		ORDER BY follow_up_at
+		LIMIT ?
+	`, until.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		taskID, err := uuid.Parse(id)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("invalid task id: %w", err)
+		}
+		ids = append(ids, taskID)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*task.Task, 0, len(ids))
+	for _, id := range ids {
+		t, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+
+	return tasks, nil
+}
+
+// FindDueBelowPriority retrieves open tasks due by until whose priority is
+// below the given one, soonest due first.
+func (r *SQLiteTaskRepository) FindDueBelowPriority(ctx context.Context, until time.Time, below value_objects.Priority, limit int) ([]*task.Task, error) {
+	priorities := prioritiesBelow(below)
+	if len(priorities) == 0 {
+		return []*task.Task{}, nil
+	}
+
+	args := make([]any, 0, len(priorities)+2)
+	args = append(args, until.UTC().Format(time.RFC3339))
+	for _, p := range priorities {
+		args = append(args, p)
+	}
+	args = append(args, limit)
+
+	// Due dates keep their original offset, so compare them as UTC datetimes.
+	query := `
+		SELECT id
+		FROM tasks
+		WHERE status IN ('pending', 'in_progress')
+		  AND due_date IS NOT NULL
+		  AND datetime(due_date) <= datetime(?)
+		  AND priority IN (?` + strings.Repeat(", ?", len(priorities)-1) + `)
+		ORDER BY datetime(due_date)
+		LIMIT ?
+	`
+
+	rows, err := r.getDB(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		taskID, err := uuid.Parse(id)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("invalid task id: %w", err)
+		}
+		ids = append(ids, taskID)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*task.Task, 0, len(ids))
+	for _, id := range ids {
+		t, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+
+	return tasks, nil
+}
+
+// FindCompletedBefore retrieves completed tasks finished at or before the
+// given time, oldest first.
+func (r *SQLiteTaskRepository) FindCompletedBefore(ctx context.Context, before time.Time, limit int) ([]*task.Task, error) {
+	// Completion times keep their original offset, so compare them as UTC datetimes.
+	query := `
+		SELECT id
+		FROM tasks
+		WHERE status = 'completed'
+		  AND completed_at IS NOT NULL
+		  AND datetime(completed_at) <= datetime(?)
+		ORDER BY datetime(completed_at)
+		LIMIT ?
+	`
+
+	rows, err := r.getDB(ctx).QueryContext(ctx, query, before.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		taskID, err := uuid.Parse(id)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("invalid task id: %w", err)
+		}
+		ids = append(ids, taskID)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*task.Task, 0, len(ids))
+	for _, id := range ids {
+		t, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+
+	return tasks, nil
+}
+
+// Search returns up to limit of the user's tasks matching query, most
+// relevant first.
+func (r *SQLiteTaskRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*task.Task, error) {
+	ids, err := sharedPersistence.SearchSQLiteIndex(ctx, r.getDB(ctx), sharedPersistence.SearchEntityTask, userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*task.Task, 0, len(ids))
+	for _, id := range ids {
+		t, err := r.FindByID(ctx, id)
+		if errors.Is(err, ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
 // Delete removes a task from the database.
 func (r *SQLiteTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := sharedPersistence.RemoveSQLiteSearchDocument(ctx, r.getDB(ctx), sharedPersistence.SearchEntityTask, id); err != nil {
+		return err
+	}
 	queries := r.getQuerier(ctx)
 	return queries.DeleteTask(ctx, id.String())
 }
 
+// rowsToTasks restores tasks from their rows, loading their reminders,
+// checklist items and tags with one query per collection and batch.
+func (r *SQLiteTaskRepository) rowsToTasks(ctx context.Context, rows []db.Task) ([]*task.Task, error) {
+	tasks := make([]*task.Task, 0, len(rows))
+	for _, row := range rows {
+		t, err := r.rowToTask(row)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+
+	for start := 0; start < len(rows); start += iterateBatchSize {
+		end := min(start+iterateBatchSize, len(rows))
+		byID := make(map[string]*task.Task, end-start)
+		ids := make([]any, 0, end-start)
+		for i := start; i < end; i++ {
+			byID[rows[i].ID] = tasks[i]
+			ids = append(ids, rows[i].ID)
+		}
+
+		if err := r.loadReminders(ctx, byID, ids); err != nil {
+			return nil, fmt.Errorf("failed to load reminders: %w", err)
+		}
+		if err := r.loadChecklist(ctx, byID, ids); err != nil {
+			return nil, fmt.Errorf("failed to load checklist: %w", err)
+		}
+		if err := r.loadTags(ctx, byID, ids); err != nil {
+			return nil, fmt.Errorf("failed to load tags: %w", err)
+		}
+	}
+
+	return tasks, nil
+}
+
 func (r *SQLiteTaskRepository) rowToTask(row db.Task) (*task.Task, error) {
 	userID, err := uuid.Parse(row.UserID)
 	if err != nil {
@@ -172,7 +867,11 @@ func (r *SQLiteTaskRepository) rowToTask(row db.Task) (*task.Task, error) {
 
 	// Set additional fields
 	if row.Description.Valid {
-		if err := t.SetDescription(row.Description.String); err != nil {
+		description, err := r.fields.Decrypt(userID, row.Description.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt description: %w", err)
+		}
+		if err := t.SetDescription(description); err != nil {
 			return nil, fmt.Errorf("failed to set description: %w", err)
 		}
 	}
@@ -219,6 +918,27 @@ func (r *SQLiteTaskRepository) rowToTask(row db.Task) (*task.Task, error) {
 		if err := t.Archive(); err != nil {
 			return nil, fmt.Errorf("failed to restore archived status: %w", err)
 		}
+	case "blocked":
+		if err := t.Block(""); err != nil {
+			return nil, fmt.Errorf("failed to restore blocked status: %w", err)
+		}
+		if err := r.restoreBlock(t, row); err != nil {
+			return nil, err
+		}
+	case "waiting":
+		if err := r.restoreWaiting(t, row); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.restoreColumns(t, row); err != nil {
+		return nil, err
+	}
+	if row.CompletedAt.Valid {
+		completedAt, err := time.Parse(time.RFC3339, row.CompletedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid completed_at format: %w", err)
+		}
+		t.RehydrateCompletedAt(&completedAt)
 	}
 
 	// Clear events since we're rehydrating from storage
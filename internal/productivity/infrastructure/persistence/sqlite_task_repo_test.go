@@ -3,14 +3,20 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	db "github.com/felixgeelhaar/orbita/db/generated/sqlite"
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
 	"github.com/felixgeelhaar/orbita/internal/productivity/domain/value_objects"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+	sharedCrypto "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/crypto"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,13 +32,29 @@ func setupSQLiteTestDB(t *testing.T) *sql.DB {
 	sqlDB, err := sql.Open("sqlite", ":memory:")
 	require.NoError(t, err)
 
-	// Read and execute the schema
-	schemaPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", "000001_initial_schema.up.sql")
-	schema, err := os.ReadFile(schemaPath)
-	require.NoError(t, err, "Failed to read SQLite schema file")
+	// Apply migrations in order
+	migrations := []string{
+		"000001_initial_schema.up.sql",
+		"000008_task_reminders.up.sql",
+		"000009_task_checklist.up.sql",
+		"000012_tags.up.sql",
+		"000019_task_blocked.up.sql",
+		"000025_task_external_id.up.sql",
+		"000027_task_habit_timezone.up.sql",
+		"000029_task_waiting.up.sql",
+		"000030_task_earliest_start.up.sql",
+		"000033_task_recurrence.up.sql",
+		"000034_search_index.up.sql",
+	}
+
+	for _, migration := range migrations {
+		schemaPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", migration)
+		schema, err := os.ReadFile(schemaPath)
+		require.NoError(t, err, "Failed to read SQLite schema file: %s", migration)
 
-	_, err = sqlDB.Exec(string(schema))
-	require.NoError(t, err, "Failed to apply SQLite schema")
+		_, err = sqlDB.Exec(string(schema))
+		require.NoError(t, err, "Failed to apply SQLite schema: %s", migration)
+	}
 
 	return sqlDB
 }
@@ -111,6 +133,75 @@ func TestSQLiteTaskRepository_Save_Update(t *testing.T) {
 	assert.Equal(t, "Updated description", updated.Description())
 }
 
+func TestSQLiteTaskRepository_Save_StaleWrite(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	newTask, err := task.NewTask(userID, "Write report")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, newTask))
+
+	// Two devices load the same task
+	first, err := repo.FindByID(ctx, newTask.ID())
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, newTask.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, first.SetDescription("Quarterly numbers"))
+	require.NoError(t, repo.Save(ctx, first))
+
+	// The second save was based on the old version
+	require.NoError(t, second.SetDescription("Yearly numbers"))
+	err = repo.Save(ctx, second)
+	assert.ErrorIs(t, err, ErrOptimisticLocking)
+	assert.ErrorIs(t, err, sharedDomain.ErrConcurrentModification)
+
+	retrieved, err := repo.FindByID(ctx, newTask.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Quarterly numbers", retrieved.Description())
+}
+
+func TestSQLiteTaskRepository_Save_StaleWriteKeepsTaskSettings(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	newTask, err := task.NewTask(userID, "Submit expenses")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, newTask))
+
+	first, err := repo.FindByID(ctx, newTask.ID())
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, newTask.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, first.SetTimezone("Asia/Tokyo"))
+	require.NoError(t, first.WaitOn("Dana", 48*time.Hour))
+	require.NoError(t, repo.Save(ctx, first))
+
+	require.NoError(t, second.SetTimezone("Europe/Berlin"))
+	require.NoError(t, second.Block("Waiting on receipts"))
+	assert.ErrorIs(t, repo.Save(ctx, second), ErrOptimisticLocking)
+
+	retrieved, err := repo.FindByID(ctx, newTask.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", retrieved.Timezone())
+	assert.True(t, retrieved.IsWaiting())
+	assert.Equal(t, "Dana", retrieved.WaitingOn())
+	assert.Empty(t, retrieved.BlockedReason())
+}
+
 func TestSQLiteTaskRepository_FindByID_NotFound(t *testing.T) {
 	sqlDB := setupSQLiteTestDB(t)
 	defer sqlDB.Close()
@@ -474,6 +565,86 @@ func TestSQLiteTaskRepository_WithDescription(t *testing.T) {
 	assert.Equal(t, longDescription, found.Description())
 }
 
+// testFieldEncrypter returns a field encrypter with a fixed test key.
+func testFieldEncrypter(t *testing.T) *sharedCrypto.FieldEncrypter {
+	t.Helper()
+	fields, err := sharedCrypto.NewFieldEncrypter(base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	require.NoError(t, err)
+	return fields
+}
+
+func storedDescription(t *testing.T, sqlDB *sql.DB, id uuid.UUID) string {
+	t.Helper()
+	var description string
+	require.NoError(t, sqlDB.QueryRow("SELECT description FROM tasks WHERE id = ?", id.String()).Scan(&description))
+	return description
+}
+
+func TestSQLiteTaskRepository_EncryptedDescription(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB).WithFieldEncrypter(testFieldEncrypter(t))
+	ctx := context.Background()
+
+	newTask, err := task.NewTask(userID, "Call the clinic")
+	require.NoError(t, err)
+	require.NoError(t, newTask.SetDescription("Ask about the test results"))
+	require.NoError(t, repo.Save(ctx, newTask))
+
+	assert.NotContains(t, storedDescription(t, sqlDB, newTask.ID()), "test results")
+
+	found, err := repo.FindByID(ctx, newTask.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Ask about the test results", found.Description())
+
+	// Without the key the description cannot be read back.
+	_, err = NewSQLiteTaskRepository(sqlDB).FindByID(ctx, newTask.ID())
+	assert.ErrorIs(t, err, sharedCrypto.ErrNoFieldKey)
+}
+
+func TestSQLiteTaskRepository_MixedPlaintextAndEncrypted(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+	ctx := context.Background()
+
+	// Written before encryption was turned on
+	oldTask, err := task.NewTask(userID, "Old task")
+	require.NoError(t, err)
+	require.NoError(t, oldTask.SetDescription("written in plaintext"))
+	require.NoError(t, NewSQLiteTaskRepository(sqlDB).Save(ctx, oldTask))
+
+	repo := NewSQLiteTaskRepository(sqlDB).WithFieldEncrypter(testFieldEncrypter(t))
+	newTask, err := task.NewTask(userID, "New task")
+	require.NoError(t, err)
+	require.NoError(t, newTask.SetDescription("written encrypted"))
+	require.NoError(t, repo.Save(ctx, newTask))
+
+	tasks, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	descriptions := map[string]string{}
+	for _, found := range tasks {
+		descriptions[found.Title()] = found.Description()
+	}
+	assert.Equal(t, map[string]string{
+		"Old task": "written in plaintext",
+		"New task": "written encrypted",
+	}, descriptions)
+
+	// The plaintext row is encrypted the next time it is saved.
+	assert.Equal(t, "written in plaintext", storedDescription(t, sqlDB, oldTask.ID()))
+	found, err := repo.FindByID(ctx, oldTask.ID())
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, found))
+	assert.NotEqual(t, "written in plaintext", storedDescription(t, sqlDB, oldTask.ID()))
+}
+
 func TestSQLiteTaskRepository_WithNoDuration(t *testing.T) {
 	sqlDB := setupSQLiteTestDB(t)
 	defer sqlDB.Close()
@@ -540,3 +711,572 @@ func TestSQLiteTaskRepository_AllPriorities(t *testing.T) {
 		})
 	}
 }
+
+func TestSQLiteTaskRepository_Reminders(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	due := time.Date(2024, time.March, 10, 15, 0, 0, 0, time.UTC)
+	withReminders, _ := task.NewTask(userID, "Submit report")
+	require.NoError(t, withReminders.SetDueDate(&due))
+	require.NoError(t, withReminders.AddReminder(24*time.Hour))
+	require.NoError(t, withReminders.AddReminder(time.Hour))
+	require.NoError(t, repo.Save(ctx, withReminders))
+
+	without, _ := task.NewTask(userID, "No reminders")
+	require.NoError(t, without.SetDueDate(&due))
+	require.NoError(t, repo.Save(ctx, without))
+
+	found, err := repo.FindByID(ctx, withReminders.ID())
+	require.NoError(t, err)
+	require.Len(t, found.Reminders(), 2)
+	assert.Equal(t, 24*time.Hour, found.Reminders()[0].Offset)
+	assert.Equal(t, time.Hour, found.Reminders()[1].Offset)
+
+	// Only the day-before reminder is due at noon the day before.
+	pending, err := repo.FindWithPendingReminders(ctx, due.Add(-23*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, withReminders.ID(), pending[0].ID())
+
+	require.NoError(t, pending[0].MarkReminderSent(24*time.Hour, due.Add(-23*time.Hour)))
+	require.NoError(t, repo.Save(ctx, pending[0]))
+
+	pending, err = repo.FindWithPendingReminders(ctx, due.Add(-23*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	found, err = repo.FindByID(ctx, withReminders.ID())
+	require.NoError(t, err)
+	assert.True(t, found.Reminders()[0].IsSent())
+	assert.False(t, found.Reminders()[1].IsSent())
+}
+
+func TestSQLiteTaskRepository_FindDueBelowPriority(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	newDueTask := func(title string, due time.Time, priority value_objects.Priority) *task.Task {
+		tk, err := task.NewTask(userID, title)
+		require.NoError(t, err)
+		require.NoError(t, tk.SetDueDate(&due))
+		require.NoError(t, tk.SetPriority(priority))
+		require.NoError(t, repo.Save(ctx, tk))
+		return tk
+	}
+
+	// Stored with a +02:00 offset, so it is due at 10:00 UTC.
+	overdue := newDueTask("Overdue", time.Date(2024, time.March, 10, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60)), value_objects.PriorityMedium)
+	dueSoon := newDueTask("Due soon", now.Add(6*time.Hour), value_objects.PriorityLow)
+	newDueTask("Already urgent", now.Add(-time.Hour), value_objects.PriorityUrgent)
+	newDueTask("Far out", now.Add(72*time.Hour), value_objects.PriorityNone)
+	completed := newDueTask("Completed", now.Add(-time.Hour), value_objects.PriorityLow)
+	require.NoError(t, completed.Complete())
+	require.NoError(t, repo.Save(ctx, completed))
+
+	found, err := repo.FindDueBelowPriority(ctx, now, value_objects.PriorityUrgent, 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, overdue.ID(), found[0].ID())
+
+	found, err = repo.FindDueBelowPriority(ctx, now.Add(24*time.Hour), value_objects.PriorityHigh, 10)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, overdue.ID(), found[0].ID())
+	assert.Equal(t, dueSoon.ID(), found[1].ID())
+
+	found, err = repo.FindDueBelowPriority(ctx, now.Add(24*time.Hour), value_objects.PriorityNone, 10)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestSQLiteTaskRepository_FindCompletedBefore(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	cutoff := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	newCompletedTask := func(title string, completedAt time.Time) *task.Task {
+		tk, err := task.NewTask(userID, title)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, tk))
+		require.NoError(t, tk.Complete())
+		tk.RehydrateCompletedAt(&completedAt)
+		require.NoError(t, repo.Save(ctx, tk))
+		return tk
+	}
+
+	oldest := newCompletedTask("Oldest", cutoff.AddDate(0, 0, -10))
+	// Stored with a +02:00 offset, so it was completed at 10:00 UTC.
+	older := newCompletedTask("Older", time.Date(2024, time.March, 10, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60)))
+	newCompletedTask("Recent", cutoff.Add(time.Hour))
+	archived, err := repo.FindByID(ctx, newCompletedTask("Archived", cutoff.AddDate(0, 0, -20)).ID())
+	require.NoError(t, err)
+	require.NoError(t, archived.Archive())
+	require.NoError(t, repo.Save(ctx, archived))
+	open, _ := task.NewTask(userID, "Open")
+	require.NoError(t, repo.Save(ctx, open))
+
+	found, err := repo.FindCompletedBefore(ctx, cutoff, 10)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, oldest.ID(), found[0].ID())
+	assert.Equal(t, older.ID(), found[1].ID())
+
+	// The completion time survives a round trip.
+	require.NotNil(t, found[0].CompletedAt())
+	assert.True(t, found[0].CompletedAt().Equal(cutoff.AddDate(0, 0, -10)))
+
+	found, err = repo.FindCompletedBefore(ctx, cutoff, 1)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, oldest.ID(), found[0].ID())
+}
+
+func TestSQLiteTaskRepository_Checklist(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	tk, _ := task.NewTask(userID, "Release")
+	first, err := tk.AddChecklistItem("Tag version")
+	require.NoError(t, err)
+	second, err := tk.AddChecklistItem("Publish notes")
+	require.NoError(t, err)
+	require.NoError(t, tk.SetChecklistRequired(true))
+	require.NoError(t, repo.Save(ctx, tk))
+
+	found, err := repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	assert.True(t, found.ChecklistRequired())
+	assert.Equal(t, []task.ChecklistItem{first, second}, found.Checklist())
+
+	_, err = found.ToggleChecklistItem(second.ID)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, found))
+
+	found, err = repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	items := found.Checklist()
+	require.Len(t, items, 2)
+	assert.False(t, items[0].Done)
+	assert.True(t, items[1].Done)
+	assert.ErrorIs(t, found.Complete(), task.ErrChecklistIncomplete)
+}
+
+func TestSQLiteTaskRepository_Tags(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	tk, _ := task.NewTask(userID, "Release")
+	_, err := tk.AddTag("work")
+	require.NoError(t, err)
+	_, err = tk.AddTag("Urgent")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, tk))
+
+	found, err := repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"urgent", "work"}, found.Tags())
+
+	_, err = found.RemoveTag("urgent")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, found))
+
+	tasks, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, []string{"work"}, tasks[0].Tags())
+}
+
+func TestSQLiteTaskRepository_Blocked(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	tk, _ := task.NewTask(userID, "Deploy")
+	require.NoError(t, tk.Block("https://github.com/acme/api/pull/42"))
+	require.NoError(t, repo.Save(ctx, tk))
+
+	found, err := repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	assert.Equal(t, task.StatusBlocked, found.Status())
+	assert.Equal(t, "https://github.com/acme/api/pull/42", found.BlockedReason())
+	require.NotNil(t, found.BlockedAt())
+	assert.WithinDuration(t, *tk.BlockedAt(), *found.BlockedAt(), time.Second)
+
+	// Blocked tasks are not pending work.
+	pending, err := repo.FindPending(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	require.NoError(t, found.Unblock("merged"))
+	require.NoError(t, repo.Save(ctx, found))
+
+	found, err = repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	assert.Equal(t, task.StatusPending, found.Status())
+	assert.Empty(t, found.BlockedReason())
+	assert.Nil(t, found.BlockedAt())
+}
+
+func TestSQLiteTaskRepository_Waiting(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	tk, _ := task.NewTask(userID, "Contract review")
+	require.NoError(t, tk.WaitOn("Alice (legal)", 48*time.Hour))
+	require.NoError(t, repo.Save(ctx, tk))
+
+	other, _ := task.NewTask(userID, "Budget sign-off")
+	require.NoError(t, other.WaitOn("Bob", 96*time.Hour))
+	require.NoError(t, repo.Save(ctx, other))
+
+	found, err := repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	assert.Equal(t, task.StatusWaiting, found.Status())
+	assert.Equal(t, "Alice (legal)", found.WaitingOn())
+	require.NotNil(t, found.WaitingSince())
+	require.NotNil(t, found.FollowUpAt())
+	assert.WithinDuration(t, *tk.FollowUpAt(), *found.FollowUpAt(), time.Second)
+	assert.Nil(t, found.FollowUpSentAt())
+
+	// Only the follow-up due by then is found.
+	at := tk.FollowUpAt().Add(time.Minute)
+	due, err := repo.FindWithDueFollowUps(ctx, at, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, tk.ID(), due[0].ID())
+
+	require.NoError(t, due[0].MarkFollowUpSent(at))
+	require.NoError(t, repo.Save(ctx, due[0]))
+
+	due, err = repo.FindWithDueFollowUps(ctx, at, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	found, err = repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	require.NotNil(t, found.FollowUpSentAt())
+	assert.True(t, found.FollowUpSentAt().Equal(at.Truncate(time.Second)))
+
+	require.NoError(t, found.ResolveWaiting("signed"))
+	require.NoError(t, repo.Save(ctx, found))
+
+	found, err = repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	assert.Equal(t, task.StatusPending, found.Status())
+	assert.Empty(t, found.WaitingOn())
+	assert.Nil(t, found.FollowUpAt())
+}
+
+func TestSQLiteTaskRepository_ExternalID(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+	createTestUser(t, sqlDB, otherUserID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	imported, _ := task.NewTask(userID, "Renew passport")
+	imported.SetExternalID("todoist:2995104339")
+	require.NoError(t, repo.Save(ctx, imported))
+
+	local, _ := task.NewTask(userID, "Water the plants")
+	require.NoError(t, repo.Save(ctx, local))
+
+	found, err := repo.FindByExternalID(ctx, userID, "todoist:2995104339")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, imported.ID(), found.ID())
+	assert.Equal(t, "todoist:2995104339", found.ExternalID())
+
+	found, err = repo.FindByID(ctx, local.ID())
+	require.NoError(t, err)
+	assert.Empty(t, found.ExternalID())
+
+	// External IDs are scoped to the user.
+	found, err = repo.FindByExternalID(ctx, otherUserID, "todoist:2995104339")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	other, _ := task.NewTask(otherUserID, "Renew passport")
+	other.SetExternalID("todoist:2995104339")
+	require.NoError(t, repo.Save(ctx, other))
+
+	duplicate, _ := task.NewTask(userID, "Renew passport again")
+	duplicate.SetExternalID("todoist:2995104339")
+	assert.Error(t, repo.Save(ctx, duplicate), "an external ID is unique per user")
+}
+
+func TestSQLiteTaskRepository_Timezone(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	traveling, _ := task.NewTask(userID, "Submit Tokyo expenses")
+	require.NoError(t, traveling.SetTimezone("Asia/Tokyo"))
+	require.NoError(t, repo.Save(ctx, traveling))
+
+	local, _ := task.NewTask(userID, "Water the plants")
+	require.NoError(t, repo.Save(ctx, local))
+
+	found, err := repo.FindByID(ctx, traveling.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", found.Timezone())
+
+	found, err = repo.FindByID(ctx, local.ID())
+	require.NoError(t, err)
+	assert.Empty(t, found.Timezone())
+
+	require.NoError(t, traveling.SetTimezone(""))
+	require.NoError(t, repo.Save(ctx, traveling))
+	found, err = repo.FindByID(ctx, traveling.ID())
+	require.NoError(t, err)
+	assert.Empty(t, found.Timezone())
+}
+
+func TestSQLiteTaskRepository_EarliestStart(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	tk, _ := task.NewTask(userID, "Publish the release notes")
+	earliestStart := time.Date(2025, 6, 5, 13, 30, 0, 0, time.UTC)
+	require.NoError(t, tk.SetEarliestStart(&earliestStart))
+	require.NoError(t, repo.Save(ctx, tk))
+
+	found, err := repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	require.NotNil(t, found.EarliestStart())
+	assert.True(t, earliestStart.Equal(*found.EarliestStart()))
+
+	require.NoError(t, tk.SetEarliestStart(nil))
+	require.NoError(t, repo.Save(ctx, tk))
+	found, err = repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	assert.Nil(t, found.EarliestStart())
+}
+
+func TestSQLiteTaskRepository_Recurrence(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	tk, _ := task.NewTask(userID, "Pay rent")
+	recurrence, err := task.NewRecurrence(task.RecurrenceMonthly, 1)
+	require.NoError(t, err)
+	require.NoError(t, tk.SetRecurrence(&recurrence))
+	require.NoError(t, repo.Save(ctx, tk))
+
+	found, err := repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	require.NotNil(t, found.Recurrence())
+	assert.Equal(t, recurrence, *found.Recurrence())
+
+	tasks, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.True(t, tasks[0].IsRecurring())
+
+	require.NoError(t, tk.SetRecurrence(nil))
+	require.NoError(t, repo.Save(ctx, tk))
+	found, err = repo.FindByID(ctx, tk.ID())
+	require.NoError(t, err)
+	assert.Nil(t, found.Recurrence())
+}
+
+func TestSQLiteTaskRepository_Search(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+	createTestUser(t, sqlDB, otherUserID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	report, _ := task.NewTask(userID, "Quarterly Report")
+	mention, _ := task.NewTask(userID, "Email finance")
+	require.NoError(t, mention.SetDescription("Attach the REPORT draft"))
+	unrelated, _ := task.NewTask(userID, "Water plants")
+	other, _ := task.NewTask(otherUserID, "Report for someone else")
+	for _, tk := range []*task.Task{report, mention, unrelated, other} {
+		require.NoError(t, repo.Save(ctx, tk))
+	}
+
+	t.Run("matches partial words ignoring case, titles first", func(t *testing.T) {
+		found, err := repo.Search(ctx, userID, "rep", 10)
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, report.ID(), found[0].ID())
+		assert.Equal(t, mention.ID(), found[1].ID())
+	})
+
+	t.Run("follows renames", func(t *testing.T) {
+		require.NoError(t, unrelated.SetTitle("Water the garden"))
+		require.NoError(t, repo.Save(ctx, unrelated))
+
+		found, err := repo.Search(ctx, userID, "GARD", 10)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, unrelated.ID(), found[0].ID())
+	})
+
+	t.Run("drops deleted tasks", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, unrelated.ID()))
+
+		found, err := repo.Search(ctx, userID, "garden", 10)
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("does not index encrypted descriptions", func(t *testing.T) {
+		encrypted := NewSQLiteTaskRepository(sqlDB).WithFieldEncrypter(testFieldEncrypter(t))
+		secret, _ := task.NewTask(userID, "Call the clinic")
+		require.NoError(t, secret.SetDescription("Ask about the test results"))
+		require.NoError(t, encrypted.Save(ctx, secret))
+
+		found, err := encrypted.Search(ctx, userID, "results", 10)
+		require.NoError(t, err)
+		assert.Empty(t, found)
+
+		found, err = encrypted.Search(ctx, userID, "clinic", 10)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "Ask about the test results", found[0].Description())
+	})
+}
+
+func TestSQLiteTaskRepository_IterateTasks(t *testing.T) {
+	sqlDB := setupSQLiteTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	createTestUser(t, sqlDB, userID)
+	createTestUser(t, sqlDB, otherUserID)
+
+	repo := NewSQLiteTaskRepository(sqlDB)
+	ctx := context.Background()
+
+	prevBatchSize := iterateBatchSize
+	iterateBatchSize = 2
+	defer func() { iterateBatchSize = prevBatchSize }()
+
+	for i := 0; i < 5; i++ {
+		tk, err := task.NewTask(userID, "Task")
+		require.NoError(t, err)
+		_, err = tk.AddTag(fmt.Sprintf("tag-%d", i))
+		require.NoError(t, err)
+		_, err = tk.AddChecklistItem(fmt.Sprintf("Step %d", i))
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, tk))
+	}
+	other, err := task.NewTask(otherUserID, "Other user's task")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, other))
+
+	all, err := repo.FindByUserID(ctx, userID)
+	require.NoError(t, err)
+
+	t.Run("visits every task across batches", func(t *testing.T) {
+		seen := make(map[uuid.UUID]bool)
+		err := repo.IterateTasks(ctx, userID, func(tk *task.Task) error {
+			assert.Equal(t, userID, tk.UserID())
+			seen[tk.ID()] = true
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.Len(t, seen, len(all))
+		for _, tk := range all {
+			assert.True(t, seen[tk.ID()])
+		}
+	})
+
+	t.Run("restores each task's own tags and checklist", func(t *testing.T) {
+		err := repo.IterateTasks(ctx, userID, func(tk *task.Task) error {
+			require.Len(t, tk.Tags(), 1)
+			require.Len(t, tk.Checklist(), 1)
+			assert.Equal(t, "Step "+strings.TrimPrefix(tk.Tags()[0], "tag-"), tk.Checklist()[0].Title)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("stops at the first callback error", func(t *testing.T) {
+		stop := errors.New("stop")
+		visited := 0
+		err := repo.IterateTasks(ctx, userID, func(tk *task.Task) error {
+			visited++
+			if visited == 3 {
+				return stop
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 3, visited)
+	})
+}
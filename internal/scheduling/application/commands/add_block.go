@@ -98,7 +98,7 @@ func (h *AddBlockHandler) Handle(ctx context.Context, cmd AddBlockCommand) (*Add
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, classifyScheduleError(err)
 	}
 
 	return result, nil
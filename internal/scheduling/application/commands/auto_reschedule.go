@@ -9,6 +9,7 @@ import (
 	"github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
 	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
 	"github.com/google/uuid"
 )
@@ -18,12 +19,38 @@ type AutoRescheduleCommand struct {
 	UserID uuid.UUID
 	Date   time.Time
 	After  *time.Time
+	// Automatic applies the missed block policy instead of a user-initiated
+	// reschedule: it does nothing unless the policy opts in, marks task blocks
+	// that ended before After (or now) as missed, and only moves task blocks.
+	Automatic bool
 }
 
 // AutoRescheduleResult contains the reschedule outcome.
 type AutoRescheduleResult struct {
-	Rescheduled int
-	Failed      int
+	Rescheduled   int
+	Failed        int
+	Flagged       int
+	FlaggedBlocks []uuid.UUID
+	// UnmetDependencies holds ordering constraints between blocks that could
+	// not be kept within working hours.
+	UnmetDependencies []domain.BlockDependency
+}
+
+// MissedBlockPolicy controls how missed blocks are rescheduled.
+type MissedBlockPolicy struct {
+	// AutoReschedule opts in to automatic rescheduling of missed task blocks.
+	AutoReschedule bool
+	// MaxAttempts caps how often a block is moved; blocks at the cap are
+	// flagged for review instead. Zero disables the cap.
+	MaxAttempts int
+}
+
+// DefaultMissedBlockPolicy returns the policy used when none is configured.
+func DefaultMissedBlockPolicy() MissedBlockPolicy {
+	return MissedBlockPolicy{
+		AutoReschedule: false,
+		MaxAttempts:    3,
+	}
 }
 
 // AutoRescheduleHandler handles the AutoRescheduleCommand.
@@ -33,6 +60,8 @@ type AutoRescheduleHandler struct {
 	schedulerEngine *services.SchedulerEngine
 	outboxRepo      outbox.Repository
 	uow             sharedApplication.UnitOfWork
+	policy          MissedBlockPolicy
+	clock           sharedDomain.Clock
 }
 
 // NewAutoRescheduleHandler creates a new AutoRescheduleHandler.
@@ -52,15 +81,38 @@ func NewAutoRescheduleHandler(
 		schedulerEngine: schedulerEngine,
 		outboxRepo:      outboxRepo,
 		uow:             uow,
+		policy:          DefaultMissedBlockPolicy(),
+		clock:           sharedDomain.SystemClock,
 	}
 }
 
+// WithClock sets the clock that decides which blocks have ended when an
+// automatic run has no After time, and stamps the reschedule attempts.
+func (h *AutoRescheduleHandler) WithClock(clock sharedDomain.Clock) *AutoRescheduleHandler {
+	h.clock = clock
+	return h
+}
+
+// WithMissedBlockPolicy sets the policy for automatic rescheduling and the reschedule cap.
+func (h *AutoRescheduleHandler) WithMissedBlockPolicy(policy MissedBlockPolicy) *AutoRescheduleHandler {
+	h.policy = policy
+	return h
+}
+
+// MissedBlockPolicy returns the configured missed block policy.
+func (h *AutoRescheduleHandler) MissedBlockPolicy() MissedBlockPolicy {
+	return h.policy
+}
+
 // Handle executes the AutoRescheduleCommand.
 func (h *AutoRescheduleHandler) Handle(ctx context.Context, cmd AutoRescheduleCommand) (*AutoRescheduleResult, error) {
 	if h.attemptRepo == nil {
 		return nil, errors.New("reschedule attempt repository not configured")
 	}
 	result := &AutoRescheduleResult{}
+	if cmd.Automatic && !h.policy.AutoReschedule {
+		return result, nil
+	}
 
 	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		schedule, err := h.scheduleRepo.FindByUserAndDate(txCtx, cmd.UserID, cmd.Date)
@@ -71,11 +123,30 @@ func (h *AutoRescheduleHandler) Handle(ctx context.Context, cmd AutoRescheduleCo
 			return nil
 		}
 
+		if cmd.Automatic {
+			now := h.clock()
+			if cmd.After != nil {
+				now = *cmd.After
+			}
+			if err := markOverdueTaskBlocksMissed(schedule, now); err != nil {
+				return err
+			}
+		}
+
 		missed := collectMissedBlocks(schedule)
+		if cmd.Automatic {
+			missed = filterBlocksByType(missed, domain.BlockTypeTask)
+		}
 		if len(missed) == 0 {
-			return nil
+			return h.saveWithEvents(txCtx, cmd.UserID, schedule)
 		}
 
+		priorAttempts, err := h.attemptRepo.ListByUserAndDate(txCtx, cmd.UserID, cmd.Date)
+		if err != nil {
+			return err
+		}
+		moves, flagged := summarizeAttempts(priorAttempts)
+
 		config := services.DefaultSchedulerConfig()
 		dayStart := time.Date(cmd.Date.Year(), cmd.Date.Month(), cmd.Date.Day(), 0, 0, 0, 0, cmd.Date.Location()).Add(config.DefaultWorkStart)
 		dayEnd := time.Date(cmd.Date.Year(), cmd.Date.Month(), cmd.Date.Day(), 0, 0, 0, 0, cmd.Date.Location()).Add(config.DefaultWorkEnd)
@@ -92,13 +163,31 @@ func (h *AutoRescheduleHandler) Handle(ctx context.Context, cmd AutoRescheduleCo
 				ScheduleID:  schedule.ID(),
 				BlockID:     block.ID(),
 				AttemptType: domain.RescheduleAttemptAutoMissed,
-				AttemptedAt: time.Now().UTC(),
+				AttemptedAt: h.clock().UTC(),
 				OldStart:    block.StartTime(),
 				OldEnd:      block.EndTime(),
 			}
 
+			if h.policy.MaxAttempts > 0 && moves[block.ID()] >= h.policy.MaxAttempts {
+				// Chronically missed: flag for review instead of moving it again.
+				if !flagged[block.ID()] {
+					attempt.Success = false
+					attempt.FailureReason = domain.RescheduleFailureCapReached
+					if err := h.attemptRepo.Create(txCtx, attempt); err != nil {
+						return err
+					}
+				}
+				result.Flagged++
+				result.FlaggedBlocks = append(result.FlaggedBlocks, block.ID())
+				continue
+			}
+
+			after := slotStart
+			if earliest, ok := schedule.EarliestStart(block.ID()); ok && earliest.After(after) {
+				after = earliest
+			}
 			slots := availableSlotsExcluding(schedule.Blocks(), dayStart, dayEnd, block.Duration()+config.MinBreakBetween, block.ID())
-			candidate, ok := selectCandidateSlot(slots, slotStart, dayStart, block.Duration(), config.MinBreakBetween)
+			candidate, ok := selectCandidateSlot(slots, after, dayStart, block.Duration(), config.MinBreakBetween)
 			if !ok {
 				attempt.Success = false
 				attempt.FailureReason = "no available slots"
@@ -127,22 +216,10 @@ func (h *AutoRescheduleHandler) Handle(ctx context.Context, cmd AutoRescheduleCo
 			result.Rescheduled++
 		}
 
-		if err := h.scheduleRepo.Save(txCtx, schedule); err != nil {
-			return err
-		}
-
-		events := schedule.DomainEvents()
-		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+		// Blocks that depend on a moved block follow it.
+		result.UnmetDependencies = schedule.EnforceDependencies(dayEnd)
 
-		msgs := make([]*outbox.Message, 0, len(events))
-		for _, event := range events {
-			msg, err := outbox.NewMessage(event)
-			if err != nil {
-				return err
-			}
-			msgs = append(msgs, msg)
-		}
-		return h.outboxRepo.SaveBatch(txCtx, msgs)
+		return h.saveWithEvents(txCtx, cmd.UserID, schedule)
 	})
 	if err != nil {
 		return nil, err
@@ -151,6 +228,73 @@ func (h *AutoRescheduleHandler) Handle(ctx context.Context, cmd AutoRescheduleCo
 	return result, nil
 }
 
+func (h *AutoRescheduleHandler) saveWithEvents(ctx context.Context, userID uuid.UUID, schedule *domain.Schedule) error {
+	events := schedule.DomainEvents()
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := h.scheduleRepo.Save(ctx, schedule); err != nil {
+		return err
+	}
+
+	sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(userID))
+
+	msgs := make([]*outbox.Message, 0, len(events))
+	for _, event := range events {
+		msg, err := outbox.NewMessage(event)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	return h.outboxRepo.SaveBatch(ctx, msgs)
+}
+
+// markOverdueTaskBlocksMissed marks pending task blocks that ended before now as missed.
+func markOverdueTaskBlocksMissed(schedule *domain.Schedule, now time.Time) error {
+	for _, block := range schedule.Blocks() {
+		if block.BlockType() != domain.BlockTypeTask || block.IsCompleted() || block.IsMissed() {
+			continue
+		}
+		if block.EndTime().After(now) {
+			continue
+		}
+		if err := schedule.MissBlock(block.ID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func filterBlocksByType(blocks []*domain.TimeBlock, blockType domain.BlockType) []*domain.TimeBlock {
+	filtered := make([]*domain.TimeBlock, 0, len(blocks))
+	for _, block := range blocks {
+		if block.BlockType() == blockType {
+			filtered = append(filtered, block)
+		}
+	}
+	return filtered
+}
+
+// summarizeAttempts counts successful missed-block moves per block and
+// records which blocks have already been flagged at the cap.
+func summarizeAttempts(attempts []domain.RescheduleAttempt) (map[uuid.UUID]int, map[uuid.UUID]bool) {
+	moves := make(map[uuid.UUID]int)
+	flagged := make(map[uuid.UUID]bool)
+	for _, attempt := range attempts {
+		if attempt.AttemptType != domain.RescheduleAttemptAutoMissed {
+			continue
+		}
+		if attempt.Success {
+			moves[attempt.BlockID]++
+		} else if attempt.FailureReason == domain.RescheduleFailureCapReached {
+			flagged[attempt.BlockID] = true
+		}
+	}
+	return moves, flagged
+}
+
 func collectMissedBlocks(schedule *domain.Schedule) []*domain.TimeBlock {
 	missed := make([]*domain.TimeBlock, 0)
 	for _, block := range schedule.Blocks() {
@@ -7,8 +7,10 @@ import (
 
 	"github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -126,6 +128,58 @@ func TestAutoReschedule_RespectsAfterTime(t *testing.T) {
 	require.True(t, attemptRepo.attempts[0].Success)
 }
 
+func TestAutoReschedule_KeepsDependentBlocksInOrder(t *testing.T) {
+	userID := uuid.New()
+	date := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return date.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	newSchedule := func(t *testing.T) (*domain.Schedule, *domain.TimeBlock, *domain.TimeBlock, *domain.TimeBlock) {
+		t.Helper()
+		schedule := domain.NewSchedule(userID, date)
+		draft, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Draft", at(9, 0), at(10, 0))
+		require.NoError(t, err)
+		review, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Review", at(10, 30), at(11, 0))
+		require.NoError(t, err)
+		send, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Send", at(11, 0), at(11, 15))
+		require.NoError(t, err)
+		require.NoError(t, schedule.AddDependency(draft.ID(), review.ID()))
+		require.NoError(t, schedule.AddDependency(review.ID(), send.ID()))
+		require.NoError(t, schedule.MissBlock(draft.ID()))
+		schedule.ClearDomainEvents()
+		return schedule, draft, review, send
+	}
+
+	t.Run("moves successors after a rescheduled predecessor", func(t *testing.T) {
+		schedule, draft, review, send := newSchedule(t)
+		handler := NewAutoRescheduleHandler(&stubScheduleRepo{schedule: schedule}, &stubAttemptRepo{}, outbox.NewInMemoryRepository(), stubUnitOfWork{}, services.NewSchedulerEngine(services.DefaultSchedulerConfig()))
+
+		after := at(13, 0)
+		result, err := handler.Handle(context.Background(), AutoRescheduleCommand{UserID: userID, Date: date, After: &after})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Rescheduled)
+		assert.Empty(t, result.UnmetDependencies)
+
+		assert.False(t, draft.StartTime().Before(after))
+		assert.False(t, review.StartTime().Before(draft.EndTime()))
+		assert.False(t, send.StartTime().Before(review.EndTime()))
+		assert.Empty(t, schedule.DependencyViolations())
+	})
+
+	t.Run("warns when successors no longer fit", func(t *testing.T) {
+		schedule, draft, review, _ := newSchedule(t)
+		handler := NewAutoRescheduleHandler(&stubScheduleRepo{schedule: schedule}, &stubAttemptRepo{}, outbox.NewInMemoryRepository(), stubUnitOfWork{}, services.NewSchedulerEngine(services.DefaultSchedulerConfig()))
+
+		after := at(15, 45)
+		result, err := handler.Handle(context.Background(), AutoRescheduleCommand{UserID: userID, Date: date, After: &after})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Rescheduled)
+		require.NotEmpty(t, result.UnmetDependencies)
+		assert.Equal(t, domain.BlockDependency{BeforeID: draft.ID(), AfterID: review.ID()}, result.UnmetDependencies[0])
+	})
+}
+
 func TestAutoReschedule_FailsWhenNoSlots(t *testing.T) {
 	userID := uuid.New()
 	date := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -156,3 +210,147 @@ func TestAutoReschedule_FailsWhenNoSlots(t *testing.T) {
 	require.Len(t, attemptRepo.attempts, 1)
 	require.False(t, attemptRepo.attempts[0].Success)
 }
+
+func TestAutoReschedule_AutomaticRequiresOptIn(t *testing.T) {
+	userID := uuid.New()
+	date := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	schedule := domain.NewSchedule(userID, date)
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	block, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Write report", start, start.Add(time.Hour))
+	require.NoError(t, err)
+	schedule.ClearDomainEvents()
+
+	repo := &stubScheduleRepo{schedule: schedule}
+	attemptRepo := &stubAttemptRepo{}
+	handler := NewAutoRescheduleHandler(repo, attemptRepo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, services.NewSchedulerEngine(services.DefaultSchedulerConfig()))
+
+	now := time.Date(2024, time.January, 1, 11, 0, 0, 0, time.UTC)
+	result, err := handler.Handle(context.Background(), AutoRescheduleCommand{UserID: userID, Date: date, After: &now, Automatic: true})
+	require.NoError(t, err)
+	require.Zero(t, result.Rescheduled)
+	require.False(t, block.IsMissed())
+	require.Equal(t, start, block.StartTime())
+	require.Empty(t, attemptRepo.attempts)
+}
+
+func TestAutoReschedule_AutomaticMovesOverdueTaskBlocks(t *testing.T) {
+	userID := uuid.New()
+	date := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	schedule := domain.NewSchedule(userID, date)
+	taskStart := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	taskBlock, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Write report", taskStart, taskStart.Add(time.Hour))
+	require.NoError(t, err)
+	habitStart := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+	habitBlock, err := schedule.AddBlock(domain.BlockTypeHabit, uuid.New(), "Stretch", habitStart, habitStart.Add(30*time.Minute))
+	require.NoError(t, err)
+	schedule.ClearDomainEvents()
+
+	repo := &stubScheduleRepo{schedule: schedule}
+	attemptRepo := &stubAttemptRepo{}
+	outboxRepo := outbox.NewInMemoryRepository()
+	handler := NewAutoRescheduleHandler(repo, attemptRepo, outboxRepo, stubUnitOfWork{}, services.NewSchedulerEngine(services.DefaultSchedulerConfig())).
+		WithMissedBlockPolicy(MissedBlockPolicy{AutoReschedule: true, MaxAttempts: 3})
+
+	now := time.Date(2024, time.January, 1, 11, 0, 0, 0, time.UTC)
+	result, err := handler.Handle(context.Background(), AutoRescheduleCommand{UserID: userID, Date: date, After: &now, Automatic: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Rescheduled)
+	require.Zero(t, result.Flagged)
+
+	// The overdue task block is moved after now; the habit block is left alone.
+	require.False(t, taskBlock.IsMissed())
+	require.False(t, taskBlock.StartTime().Before(now))
+	require.Equal(t, habitStart, habitBlock.StartTime())
+	require.False(t, habitBlock.IsMissed())
+	require.Len(t, attemptRepo.attempts, 1)
+	require.True(t, attemptRepo.attempts[0].Success)
+
+	pending, err := outboxRepo.GetUnpublished(context.Background(), 10)
+	require.NoError(t, err)
+	routingKeys := make([]string, 0, len(pending))
+	for _, msg := range pending {
+		routingKeys = append(routingKeys, msg.RoutingKey)
+	}
+	require.Contains(t, routingKeys, domain.RoutingKeyBlockMissed)
+	require.Contains(t, routingKeys, domain.RoutingKeyBlockRescheduled)
+}
+
+func TestAutoReschedule_AutomaticUsesClock(t *testing.T) {
+	userID := uuid.New()
+	date := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	taskStart := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	run := func(now time.Time) (*AutoRescheduleResult, *stubAttemptRepo) {
+		schedule := domain.NewSchedule(userID, date)
+		_, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Write report", taskStart, taskStart.Add(time.Hour))
+		require.NoError(t, err)
+		schedule.ClearDomainEvents()
+
+		attemptRepo := &stubAttemptRepo{}
+		handler := NewAutoRescheduleHandler(&stubScheduleRepo{schedule: schedule}, attemptRepo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, nil).
+			WithMissedBlockPolicy(MissedBlockPolicy{AutoReschedule: true, MaxAttempts: 3}).
+			WithClock(sharedDomain.FixedClock(now))
+
+		result, err := handler.Handle(context.Background(), AutoRescheduleCommand{UserID: userID, Date: date, Automatic: true})
+		require.NoError(t, err)
+		return result, attemptRepo
+	}
+
+	// The block is still running by the clock, so it is not missed.
+	result, attemptRepo := run(taskStart.Add(30 * time.Minute))
+	require.Zero(t, result.Rescheduled)
+	require.Empty(t, attemptRepo.attempts)
+
+	// Once the clock is past its end the block is moved.
+	now := taskStart.Add(2 * time.Hour)
+	result, attemptRepo = run(now)
+	require.Equal(t, 1, result.Rescheduled)
+	require.Len(t, attemptRepo.attempts, 1)
+	require.Equal(t, now, attemptRepo.attempts[0].AttemptedAt)
+}
+
+func TestAutoReschedule_FlagsBlocksAtCap(t *testing.T) {
+	userID := uuid.New()
+	date := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	schedule := domain.NewSchedule(userID, date)
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	block, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Dreaded task", start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, schedule.MissBlock(block.ID()))
+	schedule.ClearDomainEvents()
+
+	attemptRepo := &stubAttemptRepo{}
+	for i := 0; i < 2; i++ {
+		attemptRepo.attempts = append(attemptRepo.attempts, domain.RescheduleAttempt{
+			ID:          uuid.New(),
+			UserID:      userID,
+			ScheduleID:  schedule.ID(),
+			BlockID:     block.ID(),
+			AttemptType: domain.RescheduleAttemptAutoMissed,
+			Success:     true,
+		})
+	}
+
+	repo := &stubScheduleRepo{schedule: schedule}
+	handler := NewAutoRescheduleHandler(repo, attemptRepo, outbox.NewInMemoryRepository(), stubUnitOfWork{}, services.NewSchedulerEngine(services.DefaultSchedulerConfig())).
+		WithMissedBlockPolicy(MissedBlockPolicy{AutoReschedule: true, MaxAttempts: 2})
+
+	result, err := handler.Handle(context.Background(), AutoRescheduleCommand{UserID: userID, Date: date})
+	require.NoError(t, err)
+	require.Zero(t, result.Rescheduled)
+	require.Equal(t, 1, result.Flagged)
+	require.Equal(t, []uuid.UUID{block.ID()}, result.FlaggedBlocks)
+	require.True(t, block.IsMissed())
+	require.Equal(t, start, block.StartTime())
+	require.Len(t, attemptRepo.attempts, 3)
+	require.Equal(t, domain.RescheduleFailureCapReached, attemptRepo.attempts[2].FailureReason)
+
+	// Flagging again does not record a duplicate attempt.
+	result, err = handler.Handle(context.Background(), AutoRescheduleCommand{UserID: userID, Date: date})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Flagged)
+	require.Len(t, attemptRepo.attempts, 3)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
 	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
 	"github.com/google/uuid"
 	"log/slog"
 )
@@ -17,6 +18,8 @@ type AutoScheduleCommand struct {
 	UserID uuid.UUID
 	Date   time.Time
 	Tasks  []SchedulableItem
+	// Explain attaches the scheduler's rationale to each item result.
+	Explain bool
 }
 
 // SchedulableItem represents an item that can be scheduled.
@@ -27,6 +30,8 @@ type SchedulableItem struct {
 	Priority int
 	Duration time.Duration
 	DueDate  *time.Time
+	// EarliestStart keeps the item from being placed before this time.
+	EarliestStart *time.Time
 }
 
 // AutoScheduleResult contains the result of auto-scheduling.
@@ -49,6 +54,7 @@ type ItemScheduleResult struct {
 	StartTime time.Time
 	EndTime   time.Time
 	Reason    string
+	Rationale *services.SlotRationale // Only set when the command asks for an explanation
 }
 
 // AutoScheduleHandler handles the AutoScheduleCommand.
@@ -115,11 +121,17 @@ func (h *AutoScheduleHandler) Handle(ctx context.Context, cmd AutoScheduleComman
 				Duration:  item.Duration,
 				DueDate:   item.DueDate,
 				BlockType: blockType,
+
+				EarliestStart: item.EarliestStart,
 			})
 		}
 
 		// Use the scheduler engine to schedule tasks
-		scheduleResults, err := h.schedulerEngine.ScheduleTasks(txCtx, schedule, schedulableTasks)
+		scheduleTasks := h.schedulerEngine.ScheduleTasks
+		if cmd.Explain {
+			scheduleTasks = h.schedulerEngine.ScheduleTasksWithRationale
+		}
+		scheduleResults, err := scheduleTasks(txCtx, schedule, schedulableTasks)
 		if err != nil {
 			return err
 		}
@@ -171,6 +183,7 @@ func (h *AutoScheduleHandler) Handle(ctx context.Context, cmd AutoScheduleComman
 				StartTime: sr.StartTime,
 				EndTime:   sr.EndTime,
 				Reason:    sr.Reason,
+				Rationale: sr.Rationale,
 			}
 
 			// Find the title
@@ -193,7 +206,7 @@ func (h *AutoScheduleHandler) Handle(ctx context.Context, cmd AutoScheduleComman
 		config := services.DefaultSchedulerConfig()
 		result.AvailableTime = config.DefaultWorkEnd - config.DefaultWorkStart
 
-		h.logger.Info("auto-schedule completed",
+		observability.Logger(txCtx, h.logger).Info("auto-schedule completed",
 			"user_id", cmd.UserID,
 			"scheduled", result.ScheduledCount,
 			"failed", result.FailedCount,
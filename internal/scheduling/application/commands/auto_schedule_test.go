@@ -1,14 +1,17 @@
 package commands
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -618,6 +621,53 @@ func TestAutoScheduleHandler_Handle(t *testing.T) {
 		require.NotNil(t, lowPriorityResult)
 		assert.True(t, highPriorityResult.StartTime.Before(lowPriorityResult.StartTime))
 	})
+
+	t.Run("includes rationale when explain is requested", func(t *testing.T) {
+		userID := uuid.New()
+		date := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+		existingSchedule := domain.NewSchedule(userID, date)
+		_, err := existingSchedule.AddBlock(
+			domain.BlockTypeMeeting,
+			uuid.New(),
+			"Workshop",
+			time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC),
+			time.Date(2024, time.January, 15, 13, 0, 0, 0, time.UTC),
+		)
+		require.NoError(t, err)
+
+		scheduleRepo := &mockScheduleRepoForAutoSchedule{schedule: existingSchedule}
+		outboxRepo := outbox.NewInMemoryRepository()
+		engine := services.NewSchedulerEngine(services.DefaultSchedulerConfig())
+		handler := NewAutoScheduleHandler(scheduleRepo, outboxRepo, stubUnitOfWork{}, engine, nil)
+
+		cmd := AutoScheduleCommand{
+			UserID:  userID,
+			Date:    date,
+			Explain: true,
+			Tasks: []SchedulableItem{
+				{
+					ID:       uuid.New(),
+					Type:     "task",
+					Title:    "Review",
+					Priority: 3,
+					Duration: 30 * time.Minute,
+				},
+			},
+		}
+
+		result, err := handler.Handle(context.Background(), cmd)
+
+		require.NoError(t, err)
+		require.Len(t, result.Results, 1)
+		rationale := result.Results[0].Rationale
+		require.NotNil(t, rationale)
+		require.NotNil(t, rationale.Chosen)
+		assert.Equal(t, time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC), rationale.Chosen.Start)
+		require.NotEmpty(t, rationale.Alternatives)
+		assert.Equal(t, time.Date(2024, time.January, 15, 13, 0, 0, 0, time.UTC), rationale.Alternatives[0].Slot.Start)
+		assert.NotEmpty(t, rationale.Alternatives[0].Reason)
+	})
 }
 
 func TestNewAutoScheduleHandler(t *testing.T) {
@@ -629,3 +679,23 @@ func TestNewAutoScheduleHandler(t *testing.T) {
 
 	assert.NotNil(t, handler)
 }
+
+func TestAutoScheduleHandler_LogsCorrelationID(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	engine := services.NewSchedulerEngine(services.DefaultSchedulerConfig())
+	handler := NewAutoScheduleHandler(&mockScheduleRepoForAutoSchedule{}, outbox.NewInMemoryRepository(), stubUnitOfWork{}, engine, logger)
+
+	ctx := observability.WithCorrelationID(context.Background(), "corr-auto-schedule")
+	_, err := handler.Handle(ctx, AutoScheduleCommand{
+		UserID: uuid.New(),
+		Date:   time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+		Tasks: []SchedulableItem{
+			{ID: uuid.New(), Type: "task", Title: "Write report", Priority: 2, Duration: time.Hour},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, logs.String(), "auto-schedule completed")
+	assert.Contains(t, logs.String(), "correlation_id=corr-auto-schedule")
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+// AddBlockAttachmentCommand attaches a link or file path to a block.
+type AddBlockAttachmentCommand struct {
+	UserID  uuid.UUID
+	Date    time.Time
+	BlockID uuid.UUID
+	Target  string
+	Label   string
+}
+
+// AddBlockAttachmentHandler handles the AddBlockAttachmentCommand.
+type AddBlockAttachmentHandler struct {
+	scheduleRepo domain.ScheduleRepository
+	uow          sharedApplication.UnitOfWork
+}
+
+// NewAddBlockAttachmentHandler creates a new AddBlockAttachmentHandler.
+func NewAddBlockAttachmentHandler(scheduleRepo domain.ScheduleRepository, uow sharedApplication.UnitOfWork) *AddBlockAttachmentHandler {
+	return &AddBlockAttachmentHandler{
+		scheduleRepo: scheduleRepo,
+		uow:          uow,
+	}
+}
+
+// Handle adds the attachment and returns the block's attachments.
+func (h *AddBlockAttachmentHandler) Handle(ctx context.Context, cmd AddBlockAttachmentCommand) ([]domain.BlockAttachment, error) {
+	var attachments []domain.BlockAttachment
+
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		schedule, err := h.scheduleRepo.FindByUserAndDate(txCtx, cmd.UserID, cmd.Date)
+		if err != nil {
+			return err
+		}
+		if schedule == nil {
+			return ErrScheduleNotFound
+		}
+		if schedule.UserID() != cmd.UserID {
+			return ErrScheduleNotOwned
+		}
+
+		block, err := schedule.FindBlock(cmd.BlockID)
+		if err != nil {
+			return err
+		}
+		if err := block.AddAttachment(cmd.Target, cmd.Label); err != nil {
+			return err
+		}
+		attachments = block.Attachments()
+
+		return h.scheduleRepo.Save(txCtx, schedule)
+	})
+	if err != nil {
+		return nil, classifyScheduleError(err)
+	}
+
+	return attachments, nil
+}
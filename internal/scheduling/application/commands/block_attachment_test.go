@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddBlockAttachmentHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+	date := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("attaches a link to the block", func(t *testing.T) {
+		repo := new(mockScheduleRepo)
+		uow := new(mockSchedulingUnitOfWork)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		schedule, block := createScheduleWithBlock(userID, date)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByUserAndDate", txCtx, userID, date).Return(schedule, nil)
+		repo.On("Save", txCtx, schedule).Return(nil)
+
+		attachments, err := NewAddBlockAttachmentHandler(repo, uow).Handle(ctx, AddBlockAttachmentCommand{
+			UserID:  userID,
+			Date:    date,
+			BlockID: block.ID(),
+			Target:  " https://docs.example.com/spec ",
+			Label:   "Spec",
+		})
+
+		require.NoError(t, err)
+		expected := []domain.BlockAttachment{{Target: "https://docs.example.com/spec", Label: "Spec"}}
+		assert.Equal(t, expected, attachments)
+		assert.Equal(t, expected, block.Attachments())
+
+		repo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("rejects a duplicate attachment", func(t *testing.T) {
+		repo := new(mockScheduleRepo)
+		uow := new(mockSchedulingUnitOfWork)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		schedule, block := createScheduleWithBlock(userID, date)
+		require.NoError(t, block.AddAttachment("~/notes/focus.md", ""))
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByUserAndDate", txCtx, userID, date).Return(schedule, nil)
+
+		_, err := NewAddBlockAttachmentHandler(repo, uow).Handle(ctx, AddBlockAttachmentCommand{
+			UserID:  userID,
+			Date:    date,
+			BlockID: block.ID(),
+			Target:  "~/notes/focus.md",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrAttachmentExists)
+		assert.ErrorIs(t, err, sharedApplication.ErrConflict)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("returns not found for an unknown block", func(t *testing.T) {
+		repo := new(mockScheduleRepo)
+		uow := new(mockSchedulingUnitOfWork)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		schedule, _ := createScheduleWithBlock(userID, date)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByUserAndDate", txCtx, userID, date).Return(schedule, nil)
+
+		_, err := NewAddBlockAttachmentHandler(repo, uow).Handle(ctx, AddBlockAttachmentCommand{
+			UserID:  userID,
+			Date:    date,
+			BlockID: uuid.New(),
+			Target:  "https://docs.example.com/spec",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrBlockNotFound)
+		assert.ErrorIs(t, err, sharedApplication.ErrNotFound)
+	})
+}
@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// AddBlockDependencyCommand requires one block to finish before another starts.
+type AddBlockDependencyCommand struct {
+	UserID        uuid.UUID
+	Date          time.Time
+	BeforeBlockID uuid.UUID
+	AfterBlockID  uuid.UUID
+}
+
+// AddBlockDependencyResult reports how the schedule was brought in line.
+type AddBlockDependencyResult struct {
+	// Moved holds the blocks moved to follow their predecessors.
+	Moved []uuid.UUID
+	// Unmet holds the constraints that could not be satisfied within working hours.
+	Unmet []domain.BlockDependency
+}
+
+// AddBlockDependencyHandler handles the AddBlockDependencyCommand.
+type AddBlockDependencyHandler struct {
+	scheduleRepo domain.ScheduleRepository
+	outboxRepo   outbox.Repository
+	uow          sharedApplication.UnitOfWork
+	config       services.SchedulerConfig
+}
+
+// NewAddBlockDependencyHandler creates a new AddBlockDependencyHandler.
+func NewAddBlockDependencyHandler(scheduleRepo domain.ScheduleRepository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *AddBlockDependencyHandler {
+	return &AddBlockDependencyHandler{
+		scheduleRepo: scheduleRepo,
+		outboxRepo:   outboxRepo,
+		uow:          uow,
+		config:       services.DefaultSchedulerConfig(),
+	}
+}
+
+// WithSchedulerConfig sets the working hours blocks are kept within.
+func (h *AddBlockDependencyHandler) WithSchedulerConfig(config services.SchedulerConfig) *AddBlockDependencyHandler {
+	h.config = config
+	return h
+}
+
+// Handle adds the dependency and moves the after block, and anything that
+// depends on it, behind the before block if needed.
+func (h *AddBlockDependencyHandler) Handle(ctx context.Context, cmd AddBlockDependencyCommand) (*AddBlockDependencyResult, error) {
+	result := &AddBlockDependencyResult{}
+
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		schedule, err := h.scheduleRepo.FindByUserAndDate(txCtx, cmd.UserID, cmd.Date)
+		if err != nil {
+			return err
+		}
+		if schedule == nil {
+			return ErrScheduleNotFound
+		}
+		if schedule.UserID() != cmd.UserID {
+			return ErrScheduleNotOwned
+		}
+
+		if err := schedule.AddDependency(cmd.BeforeBlockID, cmd.AfterBlockID); err != nil {
+			return err
+		}
+
+		starts := blockStarts(schedule)
+		result.Unmet = schedule.EnforceDependencies(startOfDay(cmd.Date).Add(h.config.DefaultWorkEnd))
+		result.Moved = movedBlocks(schedule, starts)
+
+		return saveScheduleWithEvents(txCtx, h.scheduleRepo, h.outboxRepo, cmd.UserID, schedule)
+	})
+	if err != nil {
+		return nil, classifyScheduleError(err)
+	}
+
+	return result, nil
+}
+
+// RemoveBlockDependencyCommand drops an ordering constraint between two blocks.
+type RemoveBlockDependencyCommand struct {
+	UserID        uuid.UUID
+	Date          time.Time
+	BeforeBlockID uuid.UUID
+	AfterBlockID  uuid.UUID
+}
+
+// RemoveBlockDependencyHandler handles the RemoveBlockDependencyCommand.
+type RemoveBlockDependencyHandler struct {
+	scheduleRepo domain.ScheduleRepository
+	uow          sharedApplication.UnitOfWork
+}
+
+// NewRemoveBlockDependencyHandler creates a new RemoveBlockDependencyHandler.
+func NewRemoveBlockDependencyHandler(scheduleRepo domain.ScheduleRepository, uow sharedApplication.UnitOfWork) *RemoveBlockDependencyHandler {
+	return &RemoveBlockDependencyHandler{
+		scheduleRepo: scheduleRepo,
+		uow:          uow,
+	}
+}
+
+// Handle removes the dependency. Blocks stay where they are.
+func (h *RemoveBlockDependencyHandler) Handle(ctx context.Context, cmd RemoveBlockDependencyCommand) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		schedule, err := h.scheduleRepo.FindByUserAndDate(txCtx, cmd.UserID, cmd.Date)
+		if err != nil {
+			return err
+		}
+		if schedule == nil {
+			return ErrScheduleNotFound
+		}
+		if schedule.UserID() != cmd.UserID {
+			return ErrScheduleNotOwned
+		}
+
+		if err := schedule.RemoveDependency(cmd.BeforeBlockID, cmd.AfterBlockID); err != nil {
+			return err
+		}
+		return h.scheduleRepo.Save(txCtx, schedule)
+	})
+	return classifyScheduleError(err)
+}
+
+// saveScheduleWithEvents saves the schedule and writes its domain events to the outbox.
+func saveScheduleWithEvents(ctx context.Context, scheduleRepo domain.ScheduleRepository, outboxRepo outbox.Repository, userID uuid.UUID, schedule *domain.Schedule) error {
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		return err
+	}
+
+	events := schedule.DomainEvents()
+	sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(userID))
+
+	msgs := make([]*outbox.Message, 0, len(events))
+	for _, event := range events {
+		msg, err := outbox.NewMessage(event)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	return outboxRepo.SaveBatch(ctx, msgs)
+}
+
+// blockStarts records each block's start time.
+func blockStarts(schedule *domain.Schedule) map[uuid.UUID]time.Time {
+	starts := make(map[uuid.UUID]time.Time, len(schedule.Blocks()))
+	for _, block := range schedule.Blocks() {
+		starts[block.ID()] = block.StartTime()
+	}
+	return starts
+}
+
+// movedBlocks returns the blocks whose start differs from the recorded one.
+func movedBlocks(schedule *domain.Schedule, starts map[uuid.UUID]time.Time) []uuid.UUID {
+	var moved []uuid.UUID
+	for _, block := range schedule.Blocks() {
+		if start, ok := starts[block.ID()]; ok && !start.Equal(block.StartTime()) {
+			moved = append(moved, block.ID())
+		}
+	}
+	return moved
+}
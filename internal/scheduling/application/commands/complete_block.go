@@ -2,7 +2,6 @@ package commands
 
 import (
 	"context"
-	"errors"
 
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
 	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
@@ -10,15 +9,15 @@ import (
 	"github.com/google/uuid"
 )
 
-var (
-	ErrScheduleNotFound = errors.New("schedule not found")
-)
-
 // CompleteBlockCommand contains the data needed to complete a block.
 type CompleteBlockCommand struct {
 	ScheduleID uuid.UUID
 	BlockID    uuid.UUID
 	UserID     uuid.UUID
+	// Notes is an optional reflection on how the block went.
+	Notes string
+	// OutcomeRating optionally rates the block from 1 to 5; 0 leaves it unrated.
+	OutcomeRating int
 }
 
 // CompleteBlockHandler handles the CompleteBlockCommand.
@@ -39,7 +38,7 @@ func NewCompleteBlockHandler(scheduleRepo domain.ScheduleRepository, outboxRepo
 
 // Handle executes the CompleteBlockCommand.
 func (h *CompleteBlockHandler) Handle(ctx context.Context, cmd CompleteBlockCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		schedule, err := h.scheduleRepo.FindByID(txCtx, cmd.ScheduleID)
 		if err != nil {
 			return err
@@ -50,11 +49,12 @@ func (h *CompleteBlockHandler) Handle(ctx context.Context, cmd CompleteBlockComm
 
 		// Verify ownership
 		if schedule.UserID() != cmd.UserID {
-			return errors.New("user does not own this schedule")
+			return ErrScheduleNotOwned
 		}
 
 		// Complete the block
-		if err := schedule.CompleteBlock(cmd.BlockID); err != nil {
+		completion := domain.BlockCompletion{Notes: cmd.Notes, Rating: cmd.OutcomeRating}
+		if err := schedule.CompleteBlockWith(cmd.BlockID, completion); err != nil {
 			return err
 		}
 
@@ -77,4 +77,5 @@ func (h *CompleteBlockHandler) Handle(ctx context.Context, cmd CompleteBlockComm
 		}
 		return h.outboxRepo.SaveBatch(txCtx, msgs)
 	})
+	return classifyScheduleError(err)
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -87,6 +88,70 @@ func TestCompleteBlockHandler_Handle(t *testing.T) {
 		uow.AssertExpectations(t)
 	})
 
+	t.Run("records completion notes and outcome rating", func(t *testing.T) {
+		repo := new(mockScheduleRepo)
+		outboxRepo := new(mockSchedulingOutboxRepo)
+		uow := new(mockSchedulingUnitOfWork)
+		handler := NewCompleteBlockHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		schedule, block := createScheduleWithBlock(userID, date)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Commit", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, schedule.ID()).Return(schedule, nil)
+		repo.On("Save", txCtx, schedule).Return(nil)
+		outboxRepo.On("SaveBatch", txCtx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		err := handler.Handle(ctx, CompleteBlockCommand{
+			ScheduleID:    schedule.ID(),
+			BlockID:       block.ID(),
+			UserID:        userID,
+			Notes:         "Shipped the fix",
+			OutcomeRating: 4,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, block.IsCompleted())
+		assert.Equal(t, domain.BlockCompletion{Notes: "Shipped the fix", Rating: 4}, block.Completion())
+
+		repo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+
+	t.Run("rejects an invalid outcome rating", func(t *testing.T) {
+		repo := new(mockScheduleRepo)
+		outboxRepo := new(mockSchedulingOutboxRepo)
+		uow := new(mockSchedulingUnitOfWork)
+		handler := NewCompleteBlockHandler(repo, outboxRepo, uow)
+
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		schedule, block := createScheduleWithBlock(userID, date)
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+		repo.On("FindByID", txCtx, schedule.ID()).Return(schedule, nil)
+
+		err := handler.Handle(ctx, CompleteBlockCommand{
+			ScheduleID:    schedule.ID(),
+			BlockID:       block.ID(),
+			UserID:        userID,
+			OutcomeRating: 9,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOutcomeRating)
+		assert.ErrorIs(t, err, sharedApplication.ErrValidation)
+		assert.False(t, block.IsCompleted())
+
+		repo.AssertExpectations(t)
+		uow.AssertExpectations(t)
+	})
+
 	t.Run("returns ErrScheduleNotFound when schedule does not exist", func(t *testing.T) {
 		repo := new(mockScheduleRepo)
 		outboxRepo := new(mockSchedulingOutboxRepo)
@@ -141,7 +206,7 @@ func TestCompleteBlockHandler_Handle(t *testing.T) {
 
 		err := handler.Handle(ctx, cmd)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, ErrScheduleNotOwned)
 		assert.Contains(t, err.Error(), "user does not own this schedule")
 
 		repo.AssertExpectations(t)
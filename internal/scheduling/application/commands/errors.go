@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+)
+
+var (
+	ErrScheduleNotFound = sharedApplication.NewNotFoundError("schedule not found")
+	ErrScheduleNotOwned = sharedApplication.NewNotFoundError("user does not own this schedule")
+	ErrBlockNotFound    = sharedApplication.NewNotFoundError("block not found")
+
+	ErrInvalidFocusDuration = sharedApplication.NewValidationError("focus mode duration must be positive")
+	ErrNoActiveFocusMode    = sharedApplication.NewNotFoundError("no active focus mode")
+)
+
+// classifyScheduleError tags scheduling domain errors with an application
+// error category. Errors it does not recognise are returned unchanged and
+// count as internal.
+func classifyScheduleError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrInvalidTimeRange),
+		errors.Is(err, domain.ErrTimeBlockInPast),
+		errors.Is(err, domain.ErrTimeBlockTooShort),
+		errors.Is(err, domain.ErrBlockNotProtected),
+		errors.Is(err, domain.ErrSelfDependency),
+		errors.Is(err, domain.ErrDependencyCycle),
+		errors.Is(err, domain.ErrAttachmentTargetRequired),
+		errors.Is(err, domain.ErrInvalidOutcomeRating):
+		return sharedApplication.Validation(err)
+	case errors.Is(err, domain.ErrBlockNotFound),
+		errors.Is(err, domain.ErrDependencyNotFound):
+		return sharedApplication.NotFound(err)
+	case errors.Is(err, domain.ErrTimeBlockOverlap),
+		errors.Is(err, domain.ErrBlockAlreadyExists),
+		errors.Is(err, domain.ErrWindowProtected),
+		errors.Is(err, domain.ErrDependencyExists),
+		errors.Is(err, domain.ErrDependencyOrder),
+		errors.Is(err, domain.ErrAttachmentExists):
+		return sharedApplication.Conflict(err)
+	}
+	return err
+}
@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// DefaultFocusModeTitle is the block title used when none is given.
+const DefaultFocusModeTitle = "Focus mode"
+
+// StartFocusModeCommand protects a window of the schedule for focused work.
+type StartFocusModeCommand struct {
+	UserID    uuid.UUID
+	StartTime time.Time // Defaults to now
+	Duration  time.Duration
+	Title     string
+	TaskID    uuid.UUID // Optional task being focused on
+	// SuppressNotifications silences reminders while focus mode is on.
+	SuppressNotifications bool
+}
+
+// StartFocusModeResult contains the protected window and any blocks that
+// had to be moved out of it.
+type StartFocusModeResult struct {
+	ScheduleID            uuid.UUID
+	BlockID               uuid.UUID
+	StartTime             time.Time
+	EndTime               time.Time
+	SuppressNotifications bool
+	Displaced             []services.ScheduleResult
+}
+
+// StartFocusModeHandler handles the StartFocusModeCommand.
+type StartFocusModeHandler struct {
+	scheduleRepo    domain.ScheduleRepository
+	schedulerEngine *services.SchedulerEngine
+	outboxRepo      outbox.Repository
+	uow             sharedApplication.UnitOfWork
+}
+
+// NewStartFocusModeHandler creates a new StartFocusModeHandler.
+func NewStartFocusModeHandler(
+	scheduleRepo domain.ScheduleRepository,
+	outboxRepo outbox.Repository,
+	uow sharedApplication.UnitOfWork,
+	schedulerEngine *services.SchedulerEngine,
+) *StartFocusModeHandler {
+	return &StartFocusModeHandler{
+		scheduleRepo:    scheduleRepo,
+		schedulerEngine: schedulerEngine,
+		outboxRepo:      outboxRepo,
+		uow:             uow,
+	}
+}
+
+// Handle executes the StartFocusModeCommand. Blocks already planned inside
+// the window are moved to the next free slots; those that cannot be moved
+// are reported as not scheduled.
+func (h *StartFocusModeHandler) Handle(ctx context.Context, cmd StartFocusModeCommand) (*StartFocusModeResult, error) {
+	if cmd.Duration <= 0 {
+		return nil, ErrInvalidFocusDuration
+	}
+	if cmd.StartTime.IsZero() {
+		cmd.StartTime = time.Now()
+	}
+	if cmd.Title == "" {
+		cmd.Title = DefaultFocusModeTitle
+	}
+
+	blockType := domain.BlockTypeFocusMode
+	if cmd.SuppressNotifications {
+		blockType = domain.BlockTypeDoNotDisturb
+	}
+
+	var result *StartFocusModeResult
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		schedule, err := h.scheduleRepo.FindByUserAndDate(txCtx, cmd.UserID, cmd.StartTime)
+		if err != nil {
+			return err
+		}
+		if schedule == nil {
+			schedule = domain.NewSchedule(cmd.UserID, cmd.StartTime)
+		}
+
+		block, err := schedule.AddProtectedBlock(
+			blockType,
+			cmd.TaskID,
+			cmd.Title,
+			cmd.StartTime,
+			cmd.StartTime.Add(cmd.Duration),
+		)
+		if err != nil {
+			return err
+		}
+
+		displaced, err := h.schedulerEngine.RescheduleConflicts(txCtx, schedule, block)
+		if err != nil {
+			return err
+		}
+
+		if err := h.scheduleRepo.Save(txCtx, schedule); err != nil {
+			return err
+		}
+		if err := saveScheduleEvents(txCtx, h.outboxRepo, cmd.UserID, schedule); err != nil {
+			return err
+		}
+
+		result = &StartFocusModeResult{
+			ScheduleID:            schedule.ID(),
+			BlockID:               block.ID(),
+			StartTime:             block.StartTime(),
+			EndTime:               block.EndTime(),
+			SuppressNotifications: block.SuppressesNotifications(),
+			Displaced:             displaced,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyScheduleError(err)
+	}
+
+	return result, nil
+}
+
+// EndFocusModeCommand ends the focus mode window in progress.
+type EndFocusModeCommand struct {
+	UserID uuid.UUID
+	At     time.Time // Defaults to now
+}
+
+// EndFocusModeResult describes the focus mode window that was ended.
+type EndFocusModeResult struct {
+	ScheduleID uuid.UUID
+	BlockID    uuid.UUID
+	StartTime  time.Time
+	EndedAt    time.Time
+	// Removed is true when focus mode ended too soon to keep a block.
+	Removed bool
+}
+
+// EndFocusModeHandler handles the EndFocusModeCommand.
+type EndFocusModeHandler struct {
+	scheduleRepo domain.ScheduleRepository
+	outboxRepo   outbox.Repository
+	uow          sharedApplication.UnitOfWork
+}
+
+// NewEndFocusModeHandler creates a new EndFocusModeHandler.
+func NewEndFocusModeHandler(scheduleRepo domain.ScheduleRepository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *EndFocusModeHandler {
+	return &EndFocusModeHandler{
+		scheduleRepo: scheduleRepo,
+		outboxRepo:   outboxRepo,
+		uow:          uow,
+	}
+}
+
+// Handle executes the EndFocusModeCommand. The rest of the window becomes
+// free for scheduling and notifications resume.
+func (h *EndFocusModeHandler) Handle(ctx context.Context, cmd EndFocusModeCommand) (*EndFocusModeResult, error) {
+	if cmd.At.IsZero() {
+		cmd.At = time.Now()
+	}
+
+	var result *EndFocusModeResult
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		schedule, err := h.scheduleRepo.FindByUserAndDate(txCtx, cmd.UserID, cmd.At)
+		if err != nil {
+			return err
+		}
+		if schedule == nil {
+			return ErrNoActiveFocusMode
+		}
+
+		block := schedule.ProtectedBlockAt(cmd.At)
+		if block == nil {
+			return ErrNoActiveFocusMode
+		}
+
+		result = &EndFocusModeResult{
+			ScheduleID: schedule.ID(),
+			BlockID:    block.ID(),
+			StartTime:  block.StartTime(),
+			EndedAt:    cmd.At,
+		}
+		result.Removed, err = schedule.EndProtectedBlock(block.ID(), cmd.At)
+		if err != nil {
+			return err
+		}
+
+		if err := h.scheduleRepo.Save(txCtx, schedule); err != nil {
+			return err
+		}
+		return saveScheduleEvents(txCtx, h.outboxRepo, cmd.UserID, schedule)
+	})
+	if err != nil {
+		return nil, classifyScheduleError(err)
+	}
+
+	return result, nil
+}
+
+// saveScheduleEvents writes the schedule's pending domain events to the outbox.
+func saveScheduleEvents(ctx context.Context, outboxRepo outbox.Repository, userID uuid.UUID, schedule *domain.Schedule) error {
+	events := schedule.DomainEvents()
+	sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(userID))
+
+	msgs := make([]*outbox.Message, 0, len(events))
+	for _, event := range events {
+		msg, err := outbox.NewMessage(event)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	return outboxRepo.SaveBatch(ctx, msgs)
+}
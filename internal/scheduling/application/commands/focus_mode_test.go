@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFocusMode(t *testing.T) {
+	userID := uuid.New()
+	date := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return date.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	newHandlers := func() (*stubScheduleRepo, *StartFocusModeHandler, *EndFocusModeHandler, *AutoScheduleHandler) {
+		repo := &stubScheduleRepo{}
+		outboxRepo := outbox.NewInMemoryRepository()
+		engine := services.NewSchedulerEngine(services.DefaultSchedulerConfig())
+		return repo,
+			NewStartFocusModeHandler(repo, outboxRepo, stubUnitOfWork{}, engine),
+			NewEndFocusModeHandler(repo, outboxRepo, stubUnitOfWork{}),
+			NewAutoScheduleHandler(repo, outboxRepo, stubUnitOfWork{}, engine, nil)
+	}
+
+	autoSchedule := func(t *testing.T, handler *AutoScheduleHandler, duration time.Duration) ItemScheduleResult {
+		t.Helper()
+		result, err := handler.Handle(context.Background(), AutoScheduleCommand{
+			UserID: userID,
+			Date:   date,
+			Tasks: []SchedulableItem{
+				{ID: uuid.New(), Type: "task", Title: "Email", Priority: 3, Duration: duration},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Results, 1)
+		return result.Results[0]
+	}
+
+	t.Run("protects the window while focus mode is on", func(t *testing.T) {
+		repo, start, _, auto := newHandlers()
+
+		repo.schedule = domain.NewSchedule(userID, date)
+		planned, err := repo.schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Planned", at(10, 0), at(11, 0))
+		require.NoError(t, err)
+
+		result, err := start.Handle(context.Background(), StartFocusModeCommand{
+			UserID:    userID,
+			StartTime: at(9, 0),
+			Duration:  3 * time.Hour,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, at(9, 0), result.StartTime)
+		assert.Equal(t, at(12, 0), result.EndTime)
+		assert.False(t, result.SuppressNotifications)
+
+		// Work already planned in the window is moved out of it.
+		require.Len(t, result.Displaced, 1)
+		assert.Equal(t, planned.ID(), result.Displaced[0].BlockID)
+		assert.True(t, result.Displaced[0].Scheduled)
+		assert.False(t, planned.StartTime().Before(at(12, 0)))
+
+		// Auto-schedule places new work after the window.
+		item := autoSchedule(t, auto, 30*time.Minute)
+		assert.True(t, item.Scheduled)
+		assert.False(t, item.StartTime.Before(at(12, 0)))
+
+		block, err := repo.schedule.FindBlock(result.BlockID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.BlockTypeFocusMode, block.BlockType())
+		assert.True(t, block.IsProtected())
+	})
+
+	t.Run("restores normal scheduling when focus mode ends", func(t *testing.T) {
+		repo, start, end, auto := newHandlers()
+
+		started, err := start.Handle(context.Background(), StartFocusModeCommand{
+			UserID:                userID,
+			StartTime:             at(9, 0),
+			Duration:              3 * time.Hour,
+			SuppressNotifications: true,
+		})
+		require.NoError(t, err)
+		assert.True(t, started.SuppressNotifications)
+
+		ended, err := end.Handle(context.Background(), EndFocusModeCommand{UserID: userID, At: at(10, 0)})
+		require.NoError(t, err)
+		assert.Equal(t, started.BlockID, ended.BlockID)
+		assert.False(t, ended.Removed)
+
+		block, err := repo.schedule.FindBlock(started.BlockID)
+		require.NoError(t, err)
+		assert.Equal(t, at(10, 0), block.EndTime())
+		assert.True(t, block.IsCompleted())
+		assert.False(t, block.IsProtected())
+		assert.False(t, block.SuppressesNotifications())
+		assert.Nil(t, repo.schedule.ProtectedBlockAt(at(10, 30)))
+
+		// The rest of the former window is free again.
+		item := autoSchedule(t, auto, 30*time.Minute)
+		assert.True(t, item.Scheduled)
+		assert.True(t, item.StartTime.Before(at(12, 0)))
+	})
+
+	t.Run("removes focus mode ended right away", func(t *testing.T) {
+		repo, start, end, _ := newHandlers()
+
+		started, err := start.Handle(context.Background(), StartFocusModeCommand{
+			UserID:    userID,
+			StartTime: at(9, 0),
+			Duration:  time.Hour,
+		})
+		require.NoError(t, err)
+
+		ended, err := end.Handle(context.Background(), EndFocusModeCommand{UserID: userID, At: at(9, 2)})
+		require.NoError(t, err)
+		assert.True(t, ended.Removed)
+
+		_, err = repo.schedule.FindBlock(started.BlockID)
+		assert.ErrorIs(t, err, domain.ErrBlockNotFound)
+	})
+
+	t.Run("rejects overlapping focus mode", func(t *testing.T) {
+		_, start, _, _ := newHandlers()
+
+		_, err := start.Handle(context.Background(), StartFocusModeCommand{UserID: userID, StartTime: at(9, 0), Duration: time.Hour})
+		require.NoError(t, err)
+
+		_, err = start.Handle(context.Background(), StartFocusModeCommand{UserID: userID, StartTime: at(9, 30), Duration: time.Hour})
+		assert.ErrorIs(t, err, domain.ErrWindowProtected)
+		assert.True(t, errors.Is(err, sharedApplication.ErrConflict))
+	})
+
+	t.Run("rejects a non-positive duration", func(t *testing.T) {
+		_, start, _, _ := newHandlers()
+
+		_, err := start.Handle(context.Background(), StartFocusModeCommand{UserID: userID, StartTime: at(9, 0)})
+		assert.ErrorIs(t, err, ErrInvalidFocusDuration)
+	})
+
+	t.Run("fails to end without active focus mode", func(t *testing.T) {
+		_, _, end, _ := newHandlers()
+
+		_, err := end.Handle(context.Background(), EndFocusModeCommand{UserID: userID, At: at(9, 0)})
+		assert.ErrorIs(t, err, ErrNoActiveFocusMode)
+		assert.True(t, errors.Is(err, sharedApplication.ErrNotFound))
+	})
+}
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
@@ -11,10 +10,6 @@ import (
 	"github.com/google/uuid"
 )
 
-var (
-	ErrBlockNotFound = errors.New("block not found")
-)
-
 // RemoveBlockCommand contains the data needed to remove a block from a schedule.
 type RemoveBlockCommand struct {
 	UserID  uuid.UUID
@@ -40,7 +35,7 @@ func NewRemoveBlockHandler(scheduleRepo domain.ScheduleRepository, outboxRepo ou
 
 // Handle executes the RemoveBlockCommand.
 func (h *RemoveBlockHandler) Handle(ctx context.Context, cmd RemoveBlockCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		// Find the schedule for the date
 		schedule, err := h.scheduleRepo.FindByUserAndDate(txCtx, cmd.UserID, cmd.Date)
 		if err != nil {
@@ -52,7 +47,7 @@ func (h *RemoveBlockHandler) Handle(ctx context.Context, cmd RemoveBlockCommand)
 
 		// Verify ownership
 		if schedule.UserID() != cmd.UserID {
-			return errors.New("user does not own this schedule")
+			return ErrScheduleNotOwned
 		}
 
 		// Verify block exists
@@ -91,4 +86,5 @@ func (h *RemoveBlockHandler) Handle(ctx context.Context, cmd RemoveBlockCommand)
 		}
 		return h.outboxRepo.SaveBatch(txCtx, msgs)
 	})
+	return classifyScheduleError(err)
 }
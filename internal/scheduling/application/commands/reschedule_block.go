@@ -2,9 +2,9 @@ package commands
 
 import (
 	"context"
-	"errors"
 	"time"
 
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
 	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
@@ -20,11 +20,21 @@ type RescheduleBlockCommand struct {
 	NewEnd   time.Time
 }
 
+// RescheduleBlockResult reports which blocks had to move to keep their
+// dependencies satisfied.
+type RescheduleBlockResult struct {
+	// Moved holds the other blocks moved to follow the rescheduled block.
+	Moved []uuid.UUID
+	// Unmet holds the constraints that could not be satisfied within working hours.
+	Unmet []domain.BlockDependency
+}
+
 // RescheduleBlockHandler handles the RescheduleBlockCommand.
 type RescheduleBlockHandler struct {
 	scheduleRepo domain.ScheduleRepository
 	outboxRepo   outbox.Repository
 	uow          sharedApplication.UnitOfWork
+	config       services.SchedulerConfig
 }
 
 // NewRescheduleBlockHandler creates a new RescheduleBlockHandler.
@@ -33,12 +43,21 @@ func NewRescheduleBlockHandler(scheduleRepo domain.ScheduleRepository, outboxRep
 		scheduleRepo: scheduleRepo,
 		outboxRepo:   outboxRepo,
 		uow:          uow,
+		config:       services.DefaultSchedulerConfig(),
 	}
 }
 
+// WithSchedulerConfig sets the working hours dependent blocks are kept within.
+func (h *RescheduleBlockHandler) WithSchedulerConfig(config services.SchedulerConfig) *RescheduleBlockHandler {
+	h.config = config
+	return h
+}
+
 // Handle executes the RescheduleBlockCommand.
-func (h *RescheduleBlockHandler) Handle(ctx context.Context, cmd RescheduleBlockCommand) error {
-	return sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+func (h *RescheduleBlockHandler) Handle(ctx context.Context, cmd RescheduleBlockCommand) (*RescheduleBlockResult, error) {
+	result := &RescheduleBlockResult{}
+
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
 		// Find the schedule for the date
 		schedule, err := h.scheduleRepo.FindByUserAndDate(txCtx, cmd.UserID, cmd.Date)
 		if err != nil {
@@ -50,13 +69,16 @@ func (h *RescheduleBlockHandler) Handle(ctx context.Context, cmd RescheduleBlock
 
 		// Verify ownership
 		if schedule.UserID() != cmd.UserID {
-			return errors.New("user does not own this schedule")
+			return ErrScheduleNotOwned
 		}
 
-		// Reschedule the block
+		// Reschedule the block, then move anything that must follow it
 		if err := schedule.RescheduleBlock(cmd.BlockID, cmd.NewStart, cmd.NewEnd); err != nil {
 			return err
 		}
+		starts := blockStarts(schedule)
+		result.Unmet = schedule.EnforceDependencies(startOfDay(cmd.Date).Add(h.config.DefaultWorkEnd))
+		result.Moved = movedBlocks(schedule, starts)
 
 		// Save the schedule
 		if err := h.scheduleRepo.Save(txCtx, schedule); err != nil {
@@ -77,4 +99,9 @@ func (h *RescheduleBlockHandler) Handle(ctx context.Context, cmd RescheduleBlock
 		}
 		return h.outboxRepo.SaveBatch(txCtx, msgs)
 	})
+	if err != nil {
+		return nil, classifyScheduleError(err)
+	}
+
+	return result, nil
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -46,7 +47,7 @@ func TestRescheduleBlockHandler_Handle(t *testing.T) {
 			NewEnd:   newEnd,
 		}
 
-		err := handler.Handle(ctx, cmd)
+		_, err := handler.Handle(ctx, cmd)
 
 		require.NoError(t, err)
 		assert.Equal(t, newStart, block.StartTime())
@@ -81,7 +82,7 @@ func TestRescheduleBlockHandler_Handle(t *testing.T) {
 			NewEnd:   newEnd,
 		}
 
-		err := handler.Handle(ctx, cmd)
+		_, err := handler.Handle(ctx, cmd)
 
 		assert.ErrorIs(t, err, ErrScheduleNotFound)
 
@@ -116,7 +117,7 @@ func TestRescheduleBlockHandler_Handle(t *testing.T) {
 			NewEnd:   newEnd,
 		}
 
-		err := handler.Handle(ctx, cmd)
+		_, err := handler.Handle(ctx, cmd)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "user does not own this schedule")
@@ -152,7 +153,7 @@ func TestRescheduleBlockHandler_Handle(t *testing.T) {
 			NewEnd:   newEnd,
 		}
 
-		err := handler.Handle(ctx, cmd)
+		_, err := handler.Handle(ctx, cmd)
 
 		assert.ErrorIs(t, err, domain.ErrBlockNotFound)
 
@@ -187,7 +188,7 @@ func TestRescheduleBlockHandler_Handle(t *testing.T) {
 			NewEnd:   newEnd,
 		}
 
-		err := handler.Handle(ctx, cmd)
+		_, err := handler.Handle(ctx, cmd)
 
 		assert.Error(t, err)
 
@@ -219,7 +220,7 @@ func TestRescheduleBlockHandler_Handle(t *testing.T) {
 			NewEnd:   newEnd,
 		}
 
-		err := handler.Handle(ctx, cmd)
+		_, err := handler.Handle(ctx, cmd)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "database error")
@@ -255,7 +256,7 @@ func TestRescheduleBlockHandler_Handle(t *testing.T) {
 			NewEnd:   newEnd,
 		}
 
-		err := handler.Handle(ctx, cmd)
+		_, err := handler.Handle(ctx, cmd)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "save error")
@@ -285,7 +286,7 @@ func TestRescheduleBlockHandler_Handle(t *testing.T) {
 			NewEnd:   newEnd,
 		}
 
-		err := handler.Handle(ctx, cmd)
+		_, err := handler.Handle(ctx, cmd)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "transaction error")
@@ -303,3 +304,60 @@ func TestNewRescheduleBlockHandler(t *testing.T) {
 
 	require.NotNil(t, handler)
 }
+
+func TestRescheduleBlockHandler_EnforcesDependencies(t *testing.T) {
+	userID := uuid.New()
+	date := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return date.Add(time.Duration(hour) * time.Hour) }
+
+	run := func(t *testing.T, config services.SchedulerConfig) (*RescheduleBlockResult, *domain.TimeBlock) {
+		t.Helper()
+		repo := new(mockScheduleRepo)
+		outboxRepo := new(mockSchedulingOutboxRepo)
+		uow := new(mockSchedulingUnitOfWork)
+		handler := NewRescheduleBlockHandler(repo, outboxRepo, uow).WithSchedulerConfig(config)
+
+		schedule := domain.NewSchedule(userID, date)
+		draft, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Draft", at(9), at(10))
+		require.NoError(t, err)
+		review, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Review", at(10), at(11))
+		require.NoError(t, err)
+		require.NoError(t, schedule.AddDependency(draft.ID(), review.ID()))
+
+		ctx := context.Background()
+		uow.On("Begin", ctx).Return(ctx, nil)
+		uow.On("Commit", ctx).Return(nil)
+		repo.On("FindByUserAndDate", ctx, userID, date).Return(schedule, nil)
+		repo.On("Save", ctx, schedule).Return(nil)
+		outboxRepo.On("SaveBatch", ctx, mock.AnythingOfType("[]*outbox.Message")).Return(nil)
+
+		result, err := handler.Handle(ctx, RescheduleBlockCommand{
+			UserID:   userID,
+			BlockID:  draft.ID(),
+			Date:     date,
+			NewStart: at(16),
+			NewEnd:   at(17),
+		})
+		require.NoError(t, err)
+		return result, review
+	}
+
+	t.Run("reports dependencies that do not fit in working hours", func(t *testing.T) {
+		result, review := run(t, services.DefaultSchedulerConfig())
+
+		assert.Empty(t, result.Moved)
+		require.Len(t, result.Unmet, 1)
+		assert.Equal(t, review.ID(), result.Unmet[0].AfterID)
+		assert.Equal(t, at(10), review.StartTime())
+	})
+
+	t.Run("keeps dependent blocks within the configured working hours", func(t *testing.T) {
+		config := services.DefaultSchedulerConfig()
+		config.DefaultWorkEnd = 18 * time.Hour
+		result, review := run(t, config)
+
+		assert.Equal(t, []uuid.UUID{review.ID()}, result.Moved)
+		assert.Empty(t, result.Unmet)
+		assert.Equal(t, at(17), review.StartTime())
+	})
+}
@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/application/services"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+)
+
+// ErrSameDayReschedule is returned when the source and target dates are the same day.
+var ErrSameDayReschedule = sharedApplication.NewValidationError("target date must differ from source date")
+
+// RescheduleDayCommand contains the data needed to move a day's pending blocks.
+type RescheduleDayCommand struct {
+	UserID   uuid.UUID
+	FromDate time.Time
+	ToDate   time.Time
+}
+
+// MovedBlock describes a block moved to the target day.
+type MovedBlock struct {
+	BlockID  uuid.UUID
+	Title    string
+	OldStart time.Time
+	OldEnd   time.Time
+	NewStart time.Time
+	NewEnd   time.Time
+}
+
+// BlockConflict describes a block that could not be moved and stays on the source day.
+type BlockConflict struct {
+	BlockID uuid.UUID
+	Title   string
+	Reason  string
+}
+
+// RescheduleDayResult contains the outcome of moving a day's blocks.
+type RescheduleDayResult struct {
+	Moved     []MovedBlock
+	Conflicts []BlockConflict
+}
+
+// RescheduleDayHandler handles the RescheduleDayCommand.
+type RescheduleDayHandler struct {
+	scheduleRepo domain.ScheduleRepository
+	outboxRepo   outbox.Repository
+	uow          sharedApplication.UnitOfWork
+	config       services.SchedulerConfig
+}
+
+// NewRescheduleDayHandler creates a new RescheduleDayHandler.
+func NewRescheduleDayHandler(scheduleRepo domain.ScheduleRepository, outboxRepo outbox.Repository, uow sharedApplication.UnitOfWork) *RescheduleDayHandler {
+	return &RescheduleDayHandler{
+		scheduleRepo: scheduleRepo,
+		outboxRepo:   outboxRepo,
+		uow:          uow,
+		config:       services.DefaultSchedulerConfig(),
+	}
+}
+
+// WithSchedulerConfig sets the working hours blocks are kept within.
+func (h *RescheduleDayHandler) WithSchedulerConfig(config services.SchedulerConfig) *RescheduleDayHandler {
+	h.config = config
+	return h
+}
+
+// Handle executes the RescheduleDayCommand.
+// Pending blocks (not completed) are moved in start-time order into the next
+// available slots within working hours on the target day, after any block
+// they depend on. Moved blocks keep their IDs and attachments, and
+// dependencies between moved blocks are kept. Blocks that do not fit are
+// reported as conflicts and left on the source day.
+func (h *RescheduleDayHandler) Handle(ctx context.Context, cmd RescheduleDayCommand) (*RescheduleDayResult, error) {
+	fromDay := startOfDay(cmd.FromDate)
+	toDay := startOfDay(cmd.ToDate)
+	if fromDay.Equal(toDay) {
+		return nil, ErrSameDayReschedule
+	}
+
+	result := &RescheduleDayResult{}
+
+	err := sharedApplication.WithUnitOfWork(ctx, h.uow, func(txCtx context.Context) error {
+		source, err := h.scheduleRepo.FindByUserAndDate(txCtx, cmd.UserID, fromDay)
+		if err != nil {
+			return err
+		}
+		if source == nil {
+			return nil
+		}
+		if source.UserID() != cmd.UserID {
+			return ErrScheduleNotOwned
+		}
+
+		pending := collectPendingBlocks(source)
+		if len(pending) == 0 {
+			return nil
+		}
+
+		target, err := h.scheduleRepo.FindByUserAndDate(txCtx, cmd.UserID, toDay)
+		if err != nil {
+			return err
+		}
+		if target == nil {
+			target = domain.NewSchedule(cmd.UserID, toDay)
+		}
+
+		dayStart := toDay.Add(h.config.DefaultWorkStart)
+		dayEnd := toDay.Add(h.config.DefaultWorkEnd)
+
+		dependencies := source.Dependencies()
+		movedEnds := make(map[uuid.UUID]time.Time)
+
+		for _, block := range pending {
+			after := dayStart
+			for _, dep := range dependencies {
+				if end, ok := movedEnds[dep.BeforeID]; ok && dep.AfterID == block.ID() && end.After(after) {
+					after = end
+				}
+			}
+
+			slots := availableSlotsExcluding(target.Blocks(), dayStart, dayEnd, block.Duration()+h.config.MinBreakBetween, uuid.Nil)
+			candidate, ok := selectCandidateSlot(slots, after, dayStart, block.Duration(), h.config.MinBreakBetween)
+			if !ok {
+				result.Conflicts = append(result.Conflicts, BlockConflict{
+					BlockID: block.ID(),
+					Title:   block.Title(),
+					Reason:  "no available slots",
+				})
+				continue
+			}
+
+			oldStart, oldEnd := block.StartTime(), block.EndTime()
+			if _, err := source.MoveBlockTo(target, block.ID(), candidate.Start, candidate.End); err != nil {
+				result.Conflicts = append(result.Conflicts, BlockConflict{
+					BlockID: block.ID(),
+					Title:   block.Title(),
+					Reason:  err.Error(),
+				})
+				continue
+			}
+			movedEnds[block.ID()] = candidate.End
+
+			result.Moved = append(result.Moved, MovedBlock{
+				BlockID:  block.ID(),
+				Title:    block.Title(),
+				OldStart: oldStart,
+				OldEnd:   oldEnd,
+				NewStart: candidate.Start,
+				NewEnd:   candidate.End,
+			})
+		}
+
+		if len(result.Moved) == 0 {
+			return nil
+		}
+
+		// Keep the order of blocks that moved together.
+		for _, dep := range dependencies {
+			_, beforeMoved := movedEnds[dep.BeforeID]
+			_, afterMoved := movedEnds[dep.AfterID]
+			if beforeMoved && afterMoved {
+				if err := target.AddDependency(dep.BeforeID, dep.AfterID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := h.scheduleRepo.Save(txCtx, source); err != nil {
+			return err
+		}
+		if err := h.scheduleRepo.Save(txCtx, target); err != nil {
+			return err
+		}
+
+		events := append(source.DomainEvents(), target.DomainEvents()...)
+		sharedApplication.ApplyEventMetadata(events, sharedApplication.NewEventMetadata(cmd.UserID))
+
+		msgs := make([]*outbox.Message, 0, len(events))
+		for _, event := range events {
+			msg, err := outbox.NewMessage(event)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		return h.outboxRepo.SaveBatch(txCtx, msgs)
+	})
+	if err != nil {
+		return nil, classifyScheduleError(err)
+	}
+
+	return result, nil
+}
+
+func collectPendingBlocks(schedule *domain.Schedule) []*domain.TimeBlock {
+	pending := make([]*domain.TimeBlock, 0)
+	for _, block := range schedule.Blocks() {
+		if !block.IsCompleted() {
+			pending = append(pending, block)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].StartTime().Before(pending[j].StartTime())
+	})
+	return pending
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRescheduleDayHandler_MovesFullDay(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	source := domain.NewSchedule(userID, from)
+	done, err := source.AddBlock(domain.BlockTypeTask, uuid.New(), "Done", from.Add(9*time.Hour), from.Add(10*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, source.CompleteBlock(done.ID()))
+	_, err = source.AddBlock(domain.BlockTypeTask, uuid.New(), "Write report", from.Add(11*time.Hour), from.Add(12*time.Hour))
+	require.NoError(t, err)
+	_, err = source.AddBlock(domain.BlockTypeHabit, uuid.New(), "Walk", from.Add(14*time.Hour), from.Add(14*time.Hour+30*time.Minute))
+	require.NoError(t, err)
+	source.ClearDomainEvents()
+
+	repo := new(mockScheduleRepo)
+	repo.On("FindByUserAndDate", mock.Anything, userID, from).Return(source, nil)
+	repo.On("FindByUserAndDate", mock.Anything, userID, to).Return(nil, nil)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*domain.Schedule")).Return(nil).Twice()
+	outboxRepo := outbox.NewInMemoryRepository()
+
+	handler := NewRescheduleDayHandler(repo, outboxRepo, stubUnitOfWork{})
+	result, err := handler.Handle(context.Background(), RescheduleDayCommand{
+		UserID:   userID,
+		FromDate: from.Add(15 * time.Hour),
+		ToDate:   to,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Moved, 2)
+	assert.Empty(t, result.Conflicts)
+	assert.Equal(t, "Write report", result.Moved[0].Title)
+	assert.Equal(t, to.Add(9*time.Hour), result.Moved[0].NewStart)
+	assert.Equal(t, to.Add(10*time.Hour), result.Moved[0].NewEnd)
+	assert.Equal(t, "Walk", result.Moved[1].Title)
+	assert.Equal(t, to.Add(10*time.Hour+5*time.Minute), result.Moved[1].NewStart)
+
+	require.Len(t, source.Blocks(), 1)
+	assert.True(t, source.Blocks()[0].IsCompleted())
+
+	savedTarget := repo.Calls[len(repo.Calls)-1].Arguments.Get(1).(*domain.Schedule)
+	require.Len(t, savedTarget.Blocks(), 2)
+	assert.Equal(t, domain.BlockTypeHabit, savedTarget.Blocks()[1].BlockType())
+
+	pending, err := outboxRepo.GetUnpublished(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+	repo.AssertExpectations(t)
+}
+
+func TestRescheduleDayHandler_TargetDayWithLimitedSpace(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	source := domain.NewSchedule(userID, from)
+	short, err := source.AddBlock(domain.BlockTypeTask, uuid.New(), "Short", from.Add(9*time.Hour), from.Add(10*time.Hour))
+	require.NoError(t, err)
+	long, err := source.AddBlock(domain.BlockTypeTask, uuid.New(), "Long", from.Add(11*time.Hour), from.Add(14*time.Hour))
+	require.NoError(t, err)
+
+	// Target day is booked except for 15:00-17:00.
+	target := domain.NewSchedule(userID, to)
+	_, err = target.AddBlock(domain.BlockTypeMeeting, uuid.New(), "Offsite", to.Add(9*time.Hour), to.Add(15*time.Hour))
+	require.NoError(t, err)
+
+	repo := new(mockScheduleRepo)
+	repo.On("FindByUserAndDate", mock.Anything, userID, from).Return(source, nil)
+	repo.On("FindByUserAndDate", mock.Anything, userID, to).Return(target, nil)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*domain.Schedule")).Return(nil).Twice()
+
+	handler := NewRescheduleDayHandler(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{})
+	result, err := handler.Handle(context.Background(), RescheduleDayCommand{
+		UserID:   userID,
+		FromDate: from,
+		ToDate:   to,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Moved, 1)
+	assert.Equal(t, short.ID(), result.Moved[0].BlockID)
+	assert.Equal(t, to.Add(15*time.Hour+5*time.Minute), result.Moved[0].NewStart)
+
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, long.ID(), result.Conflicts[0].BlockID)
+	assert.Equal(t, "no available slots", result.Conflicts[0].Reason)
+
+	require.Len(t, source.Blocks(), 1)
+	assert.Equal(t, long.ID(), source.Blocks()[0].ID())
+	assert.Len(t, target.Blocks(), 2)
+	repo.AssertExpectations(t)
+}
+
+func TestRescheduleDayHandler_KeepsAttachmentsAndDependencies(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	source := domain.NewSchedule(userID, from)
+	draft, err := source.AddBlock(domain.BlockTypeTask, uuid.New(), "Draft proposal", from.Add(9*time.Hour), from.Add(10*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, draft.AddAttachment("https://docs.example.com/proposal", "Proposal"))
+	review, err := source.AddBlock(domain.BlockTypeTask, uuid.New(), "Review proposal", from.Add(10*time.Hour), from.Add(10*time.Hour+20*time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, source.AddDependency(draft.ID(), review.ID()))
+	source.ClearDomainEvents()
+
+	// The review would fit in the free 9:00-9:30 slot, but must follow the
+	// draft, which only fits after the meeting.
+	target := domain.NewSchedule(userID, to)
+	_, err = target.AddBlock(domain.BlockTypeMeeting, uuid.New(), "Standup", to.Add(9*time.Hour+30*time.Minute), to.Add(11*time.Hour))
+	require.NoError(t, err)
+	target.ClearDomainEvents()
+
+	repo := new(mockScheduleRepo)
+	repo.On("FindByUserAndDate", mock.Anything, userID, from).Return(source, nil)
+	repo.On("FindByUserAndDate", mock.Anything, userID, to).Return(target, nil)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*domain.Schedule")).Return(nil).Twice()
+	outboxRepo := outbox.NewInMemoryRepository()
+
+	handler := NewRescheduleDayHandler(repo, outboxRepo, stubUnitOfWork{})
+	result, err := handler.Handle(context.Background(), RescheduleDayCommand{
+		UserID:   userID,
+		FromDate: from,
+		ToDate:   to,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Moved, 2)
+	assert.Empty(t, result.Conflicts)
+	assert.Empty(t, source.Blocks())
+	assert.Empty(t, source.Dependencies())
+
+	movedDraft, err := target.FindBlock(draft.ID())
+	require.NoError(t, err)
+	assert.Equal(t, target.ID(), movedDraft.ScheduleID())
+	assert.Equal(t, to.Add(11*time.Hour+5*time.Minute), movedDraft.StartTime())
+	assert.Equal(t, []domain.BlockAttachment{{Target: "https://docs.example.com/proposal", Label: "Proposal"}}, movedDraft.Attachments())
+
+	movedReview, err := target.FindBlock(review.ID())
+	require.NoError(t, err)
+	assert.Equal(t, to.Add(12*time.Hour+10*time.Minute), movedReview.StartTime())
+	assert.Equal(t, []domain.BlockDependency{{BeforeID: draft.ID(), AfterID: review.ID()}}, target.Dependencies())
+	assert.Empty(t, target.DependencyViolations())
+
+	pending, err := outboxRepo.GetUnpublished(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, domain.RoutingKeyBlockRescheduled, pending[0].RoutingKey)
+	repo.AssertExpectations(t)
+}
+
+func TestRescheduleDayHandler_NothingToMove(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := new(mockScheduleRepo)
+	repo.On("FindByUserAndDate", mock.Anything, userID, from).Return(nil, nil)
+
+	handler := NewRescheduleDayHandler(repo, outbox.NewInMemoryRepository(), stubUnitOfWork{})
+	result, err := handler.Handle(context.Background(), RescheduleDayCommand{
+		UserID:   userID,
+		FromDate: from,
+		ToDate:   from.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Moved)
+	assert.Empty(t, result.Conflicts)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestRescheduleDayHandler_SameDay(t *testing.T) {
+	handler := NewRescheduleDayHandler(new(mockScheduleRepo), outbox.NewInMemoryRepository(), stubUnitOfWork{})
+	day := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.UTC)
+
+	_, err := handler.Handle(context.Background(), RescheduleDayCommand{
+		UserID:   uuid.New(),
+		FromDate: day,
+		ToDate:   day.Add(4 * time.Hour),
+	})
+	assert.ErrorIs(t, err, ErrSameDayReschedule)
+}
@@ -0,0 +1,189 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/application"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+)
+
+// Default working hours used when the query does not set them.
+const (
+	defaultCapacityWorkStart = 9 * time.Hour
+	defaultCapacityWorkEnd   = 17 * time.Hour
+)
+
+// CalendarEventLister lists events from the user's external calendars.
+type CalendarEventLister interface {
+	ListEvents(ctx context.Context, userID uuid.UUID, start, end time.Time, onlyOrbitaEvents bool) ([]application.CalendarEvent, error)
+}
+
+// DayCapacityDTO holds the free capacity for a single day.
+type DayCapacityDTO struct {
+	Date        time.Time
+	WorkingMins int
+	BookedMins  int
+	FreeMins    int
+}
+
+// CapacityDTO holds free capacity per day and across a date range.
+type CapacityDTO struct {
+	StartDate        time.Time
+	EndDate          time.Time
+	TotalWorkingMins int
+	TotalBookedMins  int
+	TotalFreeMins    int
+	Days             []DayCapacityDTO
+}
+
+// GetCapacityQuery contains the parameters for computing free capacity.
+// Both dates are inclusive. WorkStart and WorkEnd are offsets from midnight
+// and default to 09:00-17:00.
+type GetCapacityQuery struct {
+	UserID    uuid.UUID
+	StartDate time.Time
+	EndDate   time.Time
+	WorkStart time.Duration
+	WorkEnd   time.Duration
+}
+
+// GetCapacityHandler handles the GetCapacityQuery.
+type GetCapacityHandler struct {
+	scheduleRepo domain.ScheduleRepository
+	events       CalendarEventLister
+}
+
+// NewGetCapacityHandler creates a new GetCapacityHandler.
+func NewGetCapacityHandler(scheduleRepo domain.ScheduleRepository) *GetCapacityHandler {
+	return &GetCapacityHandler{scheduleRepo: scheduleRepo}
+}
+
+// WithCalendarEvents subtracts events from external calendars from the free
+// capacity.
+func (h *GetCapacityHandler) WithCalendarEvents(events CalendarEventLister) *GetCapacityHandler {
+	h.events = events
+	return h
+}
+
+// Handle executes the GetCapacityQuery.
+// Free time is found per day with the same slot finding used for available
+// slots, then external events are cut out of those slots.
+func (h *GetCapacityHandler) Handle(ctx context.Context, query GetCapacityQuery) (*CapacityDTO, error) {
+	loc := query.StartDate.Location()
+	start := time.Date(query.StartDate.Year(), query.StartDate.Month(), query.StartDate.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(query.EndDate.Year(), query.EndDate.Month(), query.EndDate.Day(), 0, 0, 0, 0, loc)
+	if end.Before(start) {
+		return nil, ErrInvalidDateRange
+	}
+
+	workStart, workEnd := query.WorkStart, query.WorkEnd
+	if workStart == 0 && workEnd == 0 {
+		workStart, workEnd = defaultCapacityWorkStart, defaultCapacityWorkEnd
+	}
+
+	schedules, err := h.scheduleRepo.FindByUserDateRange(ctx, query.UserID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]*domain.Schedule, len(schedules))
+	for _, schedule := range schedules {
+		byDate[schedule.Date().Format(time.DateOnly)] = schedule
+	}
+
+	var busy []domain.TimeSlot
+	if h.events != nil {
+		events, err := h.events.ListEvents(ctx, query.UserID, start.Add(workStart), end.Add(workEnd), false)
+		if err != nil {
+			return nil, err
+		}
+		busy = externalBusyTime(events)
+	}
+
+	capacity := &CapacityDTO{
+		StartDate: start,
+		EndDate:   end,
+		Days:      make([]DayCapacityDTO, 0, int(end.Sub(start).Hours()/24)+1),
+	}
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		dayStart := day.Add(workStart)
+		dayEnd := day.Add(workEnd)
+
+		var free []domain.TimeSlot
+		if schedule, ok := byDate[day.Format(time.DateOnly)]; ok {
+			free = schedule.FindAvailableSlots(dayStart, dayEnd, 0)
+		} else if dayEnd.After(dayStart) {
+			free = []domain.TimeSlot{{Start: dayStart, End: dayEnd}}
+		}
+		for _, b := range busy {
+			free = subtractSlot(free, b)
+		}
+
+		dayCapacity := DayCapacityDTO{Date: day}
+		if dayEnd.After(dayStart) {
+			dayCapacity.WorkingMins = int(dayEnd.Sub(dayStart).Minutes())
+		}
+		for _, slot := range free {
+			slot = clipSlot(slot, dayStart, dayEnd)
+			if slot.End.After(slot.Start) {
+				dayCapacity.FreeMins += int(slot.Duration().Minutes())
+			}
+		}
+		dayCapacity.BookedMins = dayCapacity.WorkingMins - dayCapacity.FreeMins
+
+		capacity.TotalWorkingMins += dayCapacity.WorkingMins
+		capacity.TotalBookedMins += dayCapacity.BookedMins
+		capacity.TotalFreeMins += dayCapacity.FreeMins
+		capacity.Days = append(capacity.Days, dayCapacity)
+	}
+
+	return capacity, nil
+}
+
+// externalBusyTime returns the time taken by external events. Events created
+// by Orbita are already blocks, and all-day and cancelled events do not take
+// up working time.
+func externalBusyTime(events []application.CalendarEvent) []domain.TimeSlot {
+	busy := make([]domain.TimeSlot, 0, len(events))
+	for _, event := range events {
+		if event.IsOrbitaEvent || event.IsAllDay || event.Status == "cancelled" {
+			continue
+		}
+		if !event.EndTime.After(event.StartTime) {
+			continue
+		}
+		busy = append(busy, domain.TimeSlot{Start: event.StartTime, End: event.EndTime})
+	}
+	return busy
+}
+
+// subtractSlot removes the busy interval from each of the free slots.
+func subtractSlot(free []domain.TimeSlot, busy domain.TimeSlot) []domain.TimeSlot {
+	result := make([]domain.TimeSlot, 0, len(free)+1)
+	for _, slot := range free {
+		if !busy.Start.Before(slot.End) || !busy.End.After(slot.Start) {
+			result = append(result, slot)
+			continue
+		}
+		if busy.Start.After(slot.Start) {
+			result = append(result, domain.TimeSlot{Start: slot.Start, End: busy.Start})
+		}
+		if busy.End.Before(slot.End) {
+			result = append(result, domain.TimeSlot{Start: busy.End, End: slot.End})
+		}
+	}
+	return result
+}
+
+func clipSlot(slot domain.TimeSlot, start, end time.Time) domain.TimeSlot {
+	if slot.Start.Before(start) {
+		slot.Start = start
+	}
+	if slot.End.After(end) {
+		slot.End = end
+	}
+	return slot
+}
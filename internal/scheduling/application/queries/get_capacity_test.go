@@ -0,0 +1,138 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/application"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type stubCalendarEvents struct {
+	events []application.CalendarEvent
+	err    error
+}
+
+func (s *stubCalendarEvents) ListEvents(ctx context.Context, userID uuid.UUID, start, end time.Time, onlyOrbitaEvents bool) ([]application.CalendarEvent, error) {
+	return s.events, s.err
+}
+
+func TestGetCapacityHandler_PartiallyBookedWeek(t *testing.T) {
+	userID := uuid.New()
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	friday := monday.AddDate(0, 0, 4)
+	at := func(day time.Time, hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+
+	// Monday: one 60m block.
+	mon := domain.NewSchedule(userID, monday)
+	addStatsBlock(t, mon, 10, 60)
+
+	// Tuesday: a block running past the end of the working day counts
+	// only up to 17:00.
+	tue := domain.NewSchedule(userID, monday.AddDate(0, 0, 1))
+	addStatsBlock(t, tue, 16, 120)
+
+	// Wednesday: 90m + 60m blocks, one of them overlapped by a meeting.
+	wednesday := monday.AddDate(0, 0, 2)
+	wed := domain.NewSchedule(userID, wednesday)
+	addStatsBlock(t, wed, 9, 90)
+	addStatsBlock(t, wed, 14, 60)
+
+	// Thursday: no schedule.
+
+	repo := new(mockScheduleRepo)
+	repo.On("FindByUserDateRange", mock.Anything, userID, monday, friday).
+		Return([]*domain.Schedule{mon, tue, wed}, nil).Once()
+
+	events := &stubCalendarEvents{events: []application.CalendarEvent{
+		// Overlaps the Wednesday 14:00 block by 30m.
+		{StartTime: at(wednesday, 14).Add(30 * time.Minute), EndTime: at(wednesday, 15).Add(30 * time.Minute)},
+		// Friday meeting.
+		{StartTime: at(friday, 13), EndTime: at(friday, 14)},
+		// Ignored: created by Orbita, cancelled, all-day.
+		{StartTime: at(friday, 9), EndTime: at(friday, 10), IsOrbitaEvent: true},
+		{StartTime: at(friday, 10), EndTime: at(friday, 11), Status: "cancelled"},
+		{StartTime: friday, EndTime: friday.AddDate(0, 0, 1), IsAllDay: true},
+	}}
+
+	handler := NewGetCapacityHandler(repo).WithCalendarEvents(events)
+	capacity, err := handler.Handle(context.Background(), GetCapacityQuery{
+		UserID:    userID,
+		StartDate: monday,
+		EndDate:   friday,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, capacity.Days, 5)
+	assert.Equal(t, DayCapacityDTO{Date: monday, WorkingMins: 480, BookedMins: 60, FreeMins: 420}, capacity.Days[0])
+	assert.Equal(t, 60, capacity.Days[1].BookedMins)
+	assert.Equal(t, 180, capacity.Days[2].BookedMins)
+	assert.Equal(t, 480, capacity.Days[3].FreeMins)
+	assert.Equal(t, 60, capacity.Days[4].BookedMins)
+
+	assert.Equal(t, 2400, capacity.TotalWorkingMins)
+	assert.Equal(t, 360, capacity.TotalBookedMins)
+	assert.Equal(t, 2040, capacity.TotalFreeMins)
+	repo.AssertExpectations(t)
+}
+
+func TestGetCapacityHandler_CustomWorkingHours(t *testing.T) {
+	userID := uuid.New()
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	schedule := domain.NewSchedule(userID, day)
+	addStatsBlock(t, schedule, 8, 60)
+
+	repo := new(mockScheduleRepo)
+	repo.On("FindByUserDateRange", mock.Anything, userID, day, day).
+		Return([]*domain.Schedule{schedule}, nil)
+
+	capacity, err := NewGetCapacityHandler(repo).Handle(context.Background(), GetCapacityQuery{
+		UserID:    userID,
+		StartDate: day,
+		EndDate:   day,
+		WorkStart: 8 * time.Hour,
+		WorkEnd:   12 * time.Hour,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 240, capacity.TotalWorkingMins)
+	assert.Equal(t, 180, capacity.TotalFreeMins)
+}
+
+func TestGetCapacityHandler_InvalidRange(t *testing.T) {
+	repo := new(mockScheduleRepo)
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := NewGetCapacityHandler(repo).Handle(context.Background(), GetCapacityQuery{
+		UserID:    uuid.New(),
+		StartDate: start,
+		EndDate:   start.AddDate(0, 0, -1),
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+	repo.AssertNotCalled(t, "FindByUserDateRange")
+}
+
+func TestGetCapacityHandler_CalendarError(t *testing.T) {
+	repo := new(mockScheduleRepo)
+	repo.On("FindByUserDateRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*domain.Schedule{}, nil)
+
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	_, err := NewGetCapacityHandler(repo).
+		WithCalendarEvents(&stubCalendarEvents{err: errors.New("calendar unavailable")}).
+		Handle(context.Background(), GetCapacityQuery{
+			UserID:    uuid.New(),
+			StartDate: day,
+			EndDate:   day.AddDate(0, 0, 6),
+		})
+
+	assert.EqualError(t, err, "calendar unavailable")
+}
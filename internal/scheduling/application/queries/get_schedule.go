@@ -19,6 +19,19 @@ type TimeBlockDTO struct {
 	DurationMin int
 	Completed   bool
 	Missed      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Attachments []AttachmentDTO
+	// CompletionNotes and OutcomeRating hold the reflection captured when
+	// the block was completed; OutcomeRating is 0 when unrated.
+	CompletionNotes string
+	OutcomeRating   int
+}
+
+// AttachmentDTO is a data transfer object for block attachments.
+type AttachmentDTO struct {
+	Target string
+	Label  string
 }
 
 // ScheduleDTO is a data transfer object for schedules.
@@ -75,15 +88,20 @@ func toScheduleDTO(schedule *domain.Schedule) *ScheduleDTO {
 
 	for i, b := range schedule.Blocks() {
 		blocks[i] = TimeBlockDTO{
-			ID:          b.ID(),
-			BlockType:   string(b.BlockType()),
-			ReferenceID: b.ReferenceID(),
-			Title:       b.Title(),
-			StartTime:   b.StartTime(),
-			EndTime:     b.EndTime(),
-			DurationMin: int(b.Duration().Minutes()),
-			Completed:   b.IsCompleted(),
-			Missed:      b.IsMissed(),
+			ID:              b.ID(),
+			BlockType:       string(b.BlockType()),
+			ReferenceID:     b.ReferenceID(),
+			Title:           b.Title(),
+			StartTime:       b.StartTime(),
+			EndTime:         b.EndTime(),
+			DurationMin:     int(b.Duration().Minutes()),
+			Completed:       b.IsCompleted(),
+			Missed:          b.IsMissed(),
+			CreatedAt:       b.CreatedAt(),
+			UpdatedAt:       b.UpdatedAt(),
+			Attachments:     toAttachmentDTOs(b.Attachments()),
+			CompletionNotes: b.Completion().Notes,
+			OutcomeRating:   b.Completion().Rating,
 		}
 
 		totalMins += int(b.Duration().Minutes())
@@ -107,3 +125,14 @@ func toScheduleDTO(schedule *domain.Schedule) *ScheduleDTO {
 		PendingCount:       pendingCount,
 	}
 }
+
+func toAttachmentDTOs(attachments []domain.BlockAttachment) []AttachmentDTO {
+	if len(attachments) == 0 {
+		return nil
+	}
+	dtos := make([]AttachmentDTO, len(attachments))
+	for i, a := range attachments {
+		dtos[i] = AttachmentDTO{Target: a.Target, Label: a.Label}
+	}
+	return dtos
+}
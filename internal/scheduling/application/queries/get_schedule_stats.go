@@ -0,0 +1,112 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidDateRange is returned when the end date precedes the start date.
+var ErrInvalidDateRange = errors.New("end date must not be before start date")
+
+// DayStatsDTO holds block statistics for a single day.
+type DayStatsDTO struct {
+	Date               time.Time
+	TotalBlocks        int
+	TotalScheduledMins int
+	CompletedCount     int
+	MissedCount        int
+	PendingCount       int
+}
+
+// ScheduleStatsDTO holds aggregated block statistics across a date range.
+type ScheduleStatsDTO struct {
+	StartDate          time.Time
+	EndDate            time.Time
+	TotalBlocks        int
+	TotalScheduledMins int
+	CompletedCount     int
+	MissedCount        int
+	PendingCount       int
+	Days               []DayStatsDTO
+}
+
+// GetScheduleStatsQuery contains the parameters for aggregating schedule statistics.
+// Both dates are inclusive.
+type GetScheduleStatsQuery struct {
+	UserID    uuid.UUID
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// GetScheduleStatsHandler handles the GetScheduleStatsQuery.
+type GetScheduleStatsHandler struct {
+	scheduleRepo domain.ScheduleRepository
+}
+
+// NewGetScheduleStatsHandler creates a new GetScheduleStatsHandler.
+func NewGetScheduleStatsHandler(scheduleRepo domain.ScheduleRepository) *GetScheduleStatsHandler {
+	return &GetScheduleStatsHandler{scheduleRepo: scheduleRepo}
+}
+
+// Handle executes the GetScheduleStatsQuery.
+// Schedules are loaded with a single range query; days without a schedule
+// are included in the breakdown with zero counts.
+func (h *GetScheduleStatsHandler) Handle(ctx context.Context, query GetScheduleStatsQuery) (*ScheduleStatsDTO, error) {
+	start := dateOnly(query.StartDate)
+	end := dateOnly(query.EndDate)
+	if end.Before(start) {
+		return nil, ErrInvalidDateRange
+	}
+
+	schedules, err := h.scheduleRepo.FindByUserDateRange(ctx, query.UserID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]*domain.Schedule, len(schedules))
+	for _, schedule := range schedules {
+		byDate[schedule.Date().Format(time.DateOnly)] = schedule
+	}
+
+	stats := &ScheduleStatsDTO{
+		StartDate: start,
+		EndDate:   end,
+		Days:      make([]DayStatsDTO, 0, int(end.Sub(start).Hours()/24)+1),
+	}
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		dayStats := DayStatsDTO{Date: day}
+		if schedule, ok := byDate[day.Format(time.DateOnly)]; ok {
+			dayStats = toDayStatsDTO(day, schedule)
+		}
+
+		stats.TotalBlocks += dayStats.TotalBlocks
+		stats.TotalScheduledMins += dayStats.TotalScheduledMins
+		stats.CompletedCount += dayStats.CompletedCount
+		stats.MissedCount += dayStats.MissedCount
+		stats.PendingCount += dayStats.PendingCount
+		stats.Days = append(stats.Days, dayStats)
+	}
+
+	return stats, nil
+}
+
+func toDayStatsDTO(day time.Time, schedule *domain.Schedule) DayStatsDTO {
+	dto := toScheduleDTO(schedule)
+	return DayStatsDTO{
+		Date:               day,
+		TotalBlocks:        len(dto.Blocks),
+		TotalScheduledMins: dto.TotalScheduledMins,
+		CompletedCount:     dto.CompletedCount,
+		MissedCount:        dto.MissedCount,
+		PendingCount:       dto.PendingCount,
+	}
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
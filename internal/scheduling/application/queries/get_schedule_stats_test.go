@@ -0,0 +1,112 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func addStatsBlock(t *testing.T, schedule *domain.Schedule, startHour, minutes int) *domain.TimeBlock {
+	t.Helper()
+	d := schedule.Date()
+	start := time.Date(d.Year(), d.Month(), d.Day(), startHour, 0, 0, 0, time.UTC)
+	block, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Block", start, start.Add(time.Duration(minutes)*time.Minute))
+	require.NoError(t, err)
+	return block
+}
+
+func TestGetScheduleStatsHandler_AggregatesRange(t *testing.T) {
+	userID := uuid.New()
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 2)
+
+	// Day 1: one completed (60m), one missed (30m)
+	day1 := domain.NewSchedule(userID, start)
+	require.NoError(t, day1.CompleteBlock(addStatsBlock(t, day1, 9, 60).ID()))
+	require.NoError(t, day1.MissBlock(addStatsBlock(t, day1, 11, 30).ID()))
+
+	// Day 2: no schedule.
+
+	// Day 3: two pending (45m + 15m)
+	day3 := domain.NewSchedule(userID, end)
+	addStatsBlock(t, day3, 9, 45)
+	addStatsBlock(t, day3, 13, 15)
+
+	repo := new(mockScheduleRepo)
+	repo.On("FindByUserDateRange", mock.Anything, userID, start, end).
+		Return([]*domain.Schedule{day1, day3}, nil).Once()
+
+	handler := NewGetScheduleStatsHandler(repo)
+	stats, err := handler.Handle(context.Background(), GetScheduleStatsQuery{
+		UserID:    userID,
+		StartDate: start.Add(10 * time.Hour),
+		EndDate:   end.Add(18 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.TotalBlocks)
+	assert.Equal(t, 150, stats.TotalScheduledMins)
+	assert.Equal(t, 1, stats.CompletedCount)
+	assert.Equal(t, 1, stats.MissedCount)
+	assert.Equal(t, 2, stats.PendingCount)
+
+	require.Len(t, stats.Days, 3)
+	assert.Equal(t, DayStatsDTO{Date: start, TotalBlocks: 2, TotalScheduledMins: 90, CompletedCount: 1, MissedCount: 1}, stats.Days[0])
+	assert.Equal(t, DayStatsDTO{Date: start.AddDate(0, 0, 1)}, stats.Days[1])
+	assert.Equal(t, DayStatsDTO{Date: end, TotalBlocks: 2, TotalScheduledMins: 60, PendingCount: 2}, stats.Days[2])
+	repo.AssertExpectations(t)
+}
+
+func TestGetScheduleStatsHandler_SingleDay(t *testing.T) {
+	userID := uuid.New()
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	repo := new(mockScheduleRepo)
+	repo.On("FindByUserDateRange", mock.Anything, userID, day, day).Return([]*domain.Schedule{}, nil)
+
+	stats, err := NewGetScheduleStatsHandler(repo).Handle(context.Background(), GetScheduleStatsQuery{
+		UserID:    userID,
+		StartDate: day,
+		EndDate:   day,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, stats.Days, 1)
+	assert.Zero(t, stats.TotalBlocks)
+}
+
+func TestGetScheduleStatsHandler_InvalidRange(t *testing.T) {
+	repo := new(mockScheduleRepo)
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := NewGetScheduleStatsHandler(repo).Handle(context.Background(), GetScheduleStatsQuery{
+		UserID:    uuid.New(),
+		StartDate: start,
+		EndDate:   start.AddDate(0, 0, -1),
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+	repo.AssertNotCalled(t, "FindByUserDateRange")
+}
+
+func TestGetScheduleStatsHandler_RepoError(t *testing.T) {
+	repo := new(mockScheduleRepo)
+	repo.On("FindByUserDateRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("db down"))
+
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	_, err := NewGetScheduleStatsHandler(repo).Handle(context.Background(), GetScheduleStatsQuery{
+		UserID:    uuid.New(),
+		StartDate: day,
+		EndDate:   day.AddDate(0, 0, 6),
+	})
+
+	assert.EqualError(t, err, "db down")
+}
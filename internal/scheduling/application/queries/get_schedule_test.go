@@ -127,6 +127,8 @@ func TestGetScheduleHandler_Handle(t *testing.T) {
 
 		ctx := context.Background()
 		schedule := createTestScheduleWithBlocks(userID, date)
+		require.NoError(t, schedule.Blocks()[0].AddAttachment("https://docs.example.com/spec", "Spec"))
+		schedule.Blocks()[0].RehydrateCompletion(domain.BlockCompletion{Notes: "Wrapped up early", Rating: 4})
 
 		repo.On("FindByUserAndDate", ctx, userID, date).Return(schedule, nil)
 
@@ -146,6 +148,14 @@ func TestGetScheduleHandler_Handle(t *testing.T) {
 		assert.Equal(t, 1, result.CompletedCount)
 		assert.Equal(t, 1, result.MissedCount)
 		assert.Equal(t, 1, result.PendingCount)
+		assert.Equal(t, schedule.Blocks()[0].CreatedAt(), result.Blocks[0].CreatedAt)
+		assert.Equal(t, schedule.Blocks()[0].UpdatedAt(), result.Blocks[0].UpdatedAt)
+		assert.Equal(t, []AttachmentDTO{{Target: "https://docs.example.com/spec", Label: "Spec"}}, result.Blocks[0].Attachments)
+		assert.Nil(t, result.Blocks[1].Attachments)
+		assert.Equal(t, "Wrapped up early", result.Blocks[0].CompletionNotes)
+		assert.Equal(t, 4, result.Blocks[0].OutcomeRating)
+		assert.Empty(t, result.Blocks[1].CompletionNotes)
+		assert.Zero(t, result.Blocks[1].OutcomeRating)
 
 		repo.AssertExpectations(t)
 	})
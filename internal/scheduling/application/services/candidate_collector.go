@@ -43,6 +43,8 @@ type SchedulingCandidate struct {
 	DueDate     *time.Time
 	Constraints []schedulingDomain.Constraint
 	Source      string // "task", "habit", "meeting"
+	// EarliestStart is the earliest time the item may be scheduled.
+	EarliestStart *time.Time
 }
 
 // CollectForDate collects all unscheduled candidates for a user on a specific date.
@@ -117,6 +119,8 @@ func (c *CandidateCollector) collectTaskCandidates(
 			Duration: duration,
 			DueDate:  t.DueDate(),
 			Source:   "task",
+
+			EarliestStart: t.EarliestStart(),
 		}
 
 		// Add time range constraint if task has due date today
@@ -243,6 +247,8 @@ func (c SchedulingCandidate) ToSchedulableTask() SchedulableTask {
 		DueDate:     c.DueDate,
 		Constraints: c.Constraints,
 		BlockType:   c.Type,
+
+		EarliestStart: c.EarliestStart,
 	}
 }
 
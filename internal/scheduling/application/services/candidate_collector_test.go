@@ -158,6 +158,15 @@ func (m *mockMeetingRepo) FindActiveByUserID(ctx context.Context, userID uuid.UU
 	return result, nil
 }
 
+func (m *mockMeetingRepo) FindByExternalSeriesID(ctx context.Context, userID uuid.UUID, seriesID string) (*meetingsDomain.Meeting, error) {
+	for _, mtg := range m.meetings {
+		if mtg.UserID() == userID && mtg.ExternalSeriesID() == seriesID {
+			return mtg, nil
+		}
+	}
+	return nil, nil
+}
+
 func TestCandidateCollector_CollectForDate_Tasks(t *testing.T) {
 	userID := uuid.New()
 	today := time.Now()
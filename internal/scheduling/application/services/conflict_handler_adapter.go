@@ -13,13 +13,19 @@ import (
 // Ensure ConflictHandlerAdapter implements the ConflictHandler interface.
 var _ workers.ConflictHandler = (*ConflictHandlerAdapter)(nil)
 
+// Ensure ConflictHandlerAdapter can preview conflicts for import previews.
+var _ workers.ConflictPreviewer = (*ConflictHandlerAdapter)(nil)
+
 // ConflictHandlerAdapter bridges the CalendarImportWorker to the ConflictResolver.
 // It detects conflicts between external calendar events and Orbita schedule blocks,
 // then delegates resolution to the ConflictResolver based on the configured strategy.
+// It is safe for concurrent use: resolutions that touch the same block are
+// serialized, others run in parallel.
 type ConflictHandlerAdapter struct {
 	conflictResolver *ConflictResolver
 	scheduleRepo     domain.ScheduleRepository
 	logger           *slog.Logger
+	locks            *scheduleLocks
 }
 
 // NewConflictHandlerAdapter creates a new conflict handler adapter.
@@ -35,6 +41,7 @@ func NewConflictHandlerAdapter(
 		conflictResolver: conflictResolver,
 		scheduleRepo:     scheduleRepo,
 		logger:           logger,
+		locks:            newScheduleLocks(),
 	}
 }
 
@@ -98,7 +105,9 @@ func (a *ConflictHandlerAdapter) HandleConflict(
 	)
 
 	// Resolve all detected conflicts
+	unlock := a.locks.lock(conflicts)
 	results, err := a.conflictResolver.ResolveAll(ctx, conflicts)
+	unlock()
 	if err != nil {
 		a.logger.Error("failed to resolve conflicts",
 			"event_id", external.ID,
@@ -165,7 +174,9 @@ func (a *ConflictHandlerAdapter) HandleConflictForUser(
 	)
 
 	// Resolve all detected conflicts
+	unlock := a.locks.lock(conflicts)
 	results, err := a.conflictResolver.ResolveAll(ctx, conflicts)
+	unlock()
 	if err != nil {
 		a.logger.Error("failed to resolve conflicts",
 			"user_id", userID,
@@ -195,6 +206,40 @@ func (a *ConflictHandlerAdapter) HandleConflictForUser(
 	return nil
 }
 
+// PreviewConflicts reports the conflicts an external event would cause and
+// how the configured strategy would resolve them. Nothing is resolved or saved.
+func (a *ConflictHandlerAdapter) PreviewConflicts(
+	ctx context.Context,
+	userID uuid.UUID,
+	external application.CalendarEvent,
+) ([]workers.ImportConflict, error) {
+	if external.IsOrbitaEvent {
+		return nil, nil
+	}
+
+	conflicts, err := a.conflictResolver.DetectConflicts(ctx, userID, []application.CalendarEvent{external})
+	if err != nil {
+		return nil, err
+	}
+
+	previews := make([]workers.ImportConflict, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		resolution, err := a.conflictResolver.PreviewResolution(ctx, conflict)
+		if err != nil {
+			return nil, err
+		}
+		previews = append(previews, workers.ImportConflict{
+			EventID:      external.ID,
+			EventSummary: external.Summary,
+			BlockID:      conflict.OrbitaBlockID(),
+			BlockStart:   conflict.OrbitaBlockTime().Start,
+			BlockEnd:     conflict.OrbitaBlockTime().End,
+			Resolution:   string(resolution),
+		})
+	}
+	return previews, nil
+}
+
 // ErrConflictsPendingReview is returned when conflicts need manual user review.
 var ErrConflictsPendingReview = &ConflictsPendingError{}
 
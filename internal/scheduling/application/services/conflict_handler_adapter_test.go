@@ -342,6 +342,63 @@ func TestConflictHandlerAdapter_HandleConflictForUser_DetectError(t *testing.T)
 	assert.Contains(t, err.Error(), "database error")
 }
 
+func TestConflictHandlerAdapter_PreviewConflicts(t *testing.T) {
+	repo := newMockScheduleRepoForConflicts()
+	schedulerEngine := NewSchedulerEngine(DefaultSchedulerConfig())
+	config := ConflictResolverConfig{Strategy: domain.StrategyExternalWins}
+	conflictResolver := NewConflictResolver(repo, schedulerEngine, config, nil)
+	adapter := NewConflictHandlerAdapter(conflictResolver, repo, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	today := time.Now().Truncate(24 * time.Hour)
+
+	schedule := domain.NewSchedule(userID, today)
+	block, err := schedule.AddBlock(
+		domain.BlockTypeTask,
+		uuid.New(),
+		"Morning Task",
+		today.Add(10*time.Hour),
+		today.Add(11*time.Hour),
+	)
+	require.NoError(t, err)
+	schedule.ClearDomainEvents()
+	repo.schedules[userID.String()+"_"+today.Format("2006-01-02")] = schedule
+
+	event := application.CalendarEvent{
+		ID:        "external-event-1",
+		Summary:   "Overlapping Meeting",
+		StartTime: today.Add(10*time.Hour + 30*time.Minute),
+		EndTime:   today.Add(11*time.Hour + 30*time.Minute),
+	}
+
+	conflicts, err := adapter.PreviewConflicts(ctx, userID, event)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "external-event-1", conflicts[0].EventID)
+	assert.Equal(t, "Overlapping Meeting", conflicts[0].EventSummary)
+	assert.Equal(t, block.ID(), conflicts[0].BlockID)
+	assert.Equal(t, today.Add(10*time.Hour), conflicts[0].BlockStart)
+	assert.Equal(t, string(domain.ResolutionRescheduled), conflicts[0].Resolution)
+
+	// The block was not moved.
+	assert.Equal(t, today.Add(10*time.Hour), block.StartTime())
+	assert.Empty(t, schedule.DomainEvents())
+}
+
+func TestConflictHandlerAdapter_PreviewConflicts_SkipsOrbitaEvent(t *testing.T) {
+	repo := newMockScheduleRepoForConflicts()
+	conflictResolver := NewConflictResolver(repo, NewSchedulerEngine(DefaultSchedulerConfig()), DefaultConflictResolverConfig(), nil)
+	adapter := NewConflictHandlerAdapter(conflictResolver, repo, nil)
+
+	conflicts, err := adapter.PreviewConflicts(context.Background(), uuid.New(), application.CalendarEvent{
+		ID:            "orbita-event-1",
+		IsOrbitaEvent: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
 func TestConflictsPendingError(t *testing.T) {
 	err := &ConflictsPendingError{}
 	assert.Equal(t, "one or more conflicts require manual review", err.Error())
@@ -8,20 +8,25 @@ import (
 
 	"github.com/felixgeelhaar/orbita/internal/calendar/application"
 	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/felixgeelhaar/orbita/pkg/observability"
 	"github.com/google/uuid"
 )
 
 // ConflictResolver handles detection and resolution of scheduling conflicts.
 type ConflictResolver struct {
-	scheduleRepo domain.ScheduleRepository
-	strategy     domain.ConflictResolutionStrategy
-	scheduler    *SchedulerEngine
-	logger       *slog.Logger
+	scheduleRepo       domain.ScheduleRepository
+	strategy           domain.ConflictResolutionStrategy
+	calendarStrategies map[string]domain.ConflictResolutionStrategy
+	scheduler          *SchedulerEngine
+	logger             *slog.Logger
 }
 
 // ConflictResolverConfig configures the conflict resolver.
 type ConflictResolverConfig struct {
 	Strategy domain.ConflictResolutionStrategy
+	// CalendarStrategies overrides Strategy for conflicts with events from
+	// the given calendar IDs.
+	CalendarStrategies map[string]domain.ConflictResolutionStrategy
 }
 
 // DefaultConflictResolverConfig returns the default configuration.
@@ -41,11 +46,16 @@ func NewConflictResolver(
 	if logger == nil {
 		logger = slog.Default()
 	}
+	calendarStrategies := make(map[string]domain.ConflictResolutionStrategy, len(config.CalendarStrategies))
+	for calendarID, strategy := range config.CalendarStrategies {
+		calendarStrategies[calendarID] = strategy
+	}
 	return &ConflictResolver{
-		scheduleRepo: scheduleRepo,
-		strategy:     config.Strategy,
-		scheduler:    scheduler,
-		logger:       logger,
+		scheduleRepo:       scheduleRepo,
+		strategy:           config.Strategy,
+		calendarStrategies: calendarStrategies,
+		scheduler:          scheduler,
+		logger:             logger,
 	}
 }
 
@@ -119,9 +129,10 @@ func (r *ConflictResolver) DetectConflicts(
 							event.ID,
 							eventTime,
 						)
+						conflict.SetExternalCalendarID(event.CalendarID)
 						conflicts = append(conflicts, conflict)
 
-						r.logger.Debug("conflict detected",
+						observability.Logger(ctx, r.logger).Debug("conflict detected",
 							"user_id", userID,
 							"block_id", block.ID(),
 							"event_id", event.ID,
@@ -138,7 +149,8 @@ func (r *ConflictResolver) DetectConflicts(
 	return conflicts, nil
 }
 
-// ResolveConflict resolves a single conflict based on the configured strategy.
+// ResolveConflict resolves a single conflict using the strategy configured
+// for the external event's calendar.
 func (r *ConflictResolver) ResolveConflict(
 	ctx context.Context,
 	conflict *domain.Conflict,
@@ -148,7 +160,8 @@ func (r *ConflictResolver) ResolveConflict(
 		Conflicts:   []*domain.Conflict{conflict},
 	}
 
-	switch r.strategy {
+	strategy := r.StrategyFor(conflict.ExternalCalendarID())
+	switch strategy {
 	case domain.StrategyOrbitaWins:
 		result = r.resolveOrbitaWins(ctx, conflict)
 	case domain.StrategyExternalWins:
@@ -161,9 +174,10 @@ func (r *ConflictResolver) ResolveConflict(
 		result = r.resolveManual(conflict)
 	}
 
-	r.logger.Info("conflict resolved",
+	observability.Logger(ctx, r.logger).Info("conflict resolved",
 		"conflict_id", conflict.ID(),
-		"strategy", r.strategy,
+		"calendar_id", conflict.ExternalCalendarID(),
+		"strategy", strategy,
 		"resolution", result.Resolution,
 	)
 
@@ -188,6 +202,51 @@ func (r *ConflictResolver) ResolveAll(
 	return results, nil
 }
 
+// PreviewResolution reports how the configured strategy would resolve a
+// conflict without moving blocks, saving schedules or marking the conflict.
+func (r *ConflictResolver) PreviewResolution(
+	ctx context.Context,
+	conflict *domain.Conflict,
+) (domain.ConflictResolution, error) {
+	switch r.StrategyFor(conflict.ExternalCalendarID()) {
+	case domain.StrategyOrbitaWins:
+		return domain.ResolutionKept, nil
+	case domain.StrategyExternalWins:
+		return r.previewExternalWins(ctx, conflict)
+	case domain.StrategyTimeFirst:
+		if conflict.OrbitaBlockTime().Start.After(conflict.ExternalTime().Start) {
+			return domain.ResolutionRescheduled, nil
+		}
+		return domain.ResolutionKept, nil
+	default:
+		return domain.ResolutionPending, nil
+	}
+}
+
+// previewExternalWins mirrors resolveExternalWins without applying the move.
+func (r *ConflictResolver) previewExternalWins(ctx context.Context, conflict *domain.Conflict) (domain.ConflictResolution, error) {
+	blockTime := conflict.OrbitaBlockTime()
+	schedule, err := r.scheduleRepo.FindByUserAndDate(ctx, conflict.UserID(), blockTime.Start.Truncate(24*time.Hour))
+	if err != nil {
+		return "", err
+	}
+	if schedule == nil {
+		return domain.ResolutionPending, nil
+	}
+
+	block, err := schedule.FindBlock(conflict.OrbitaBlockID())
+	if err != nil {
+		return domain.ResolutionPending, nil
+	}
+	if block.IsProtected() {
+		return domain.ResolutionKept, nil
+	}
+	if _, err := r.scheduler.FindOptimalSlot(schedule, block.Duration(), nil); err != nil {
+		return domain.ResolutionPending, nil
+	}
+	return domain.ResolutionRescheduled, nil
+}
+
 // resolveOrbitaWins keeps the Orbita block and marks the conflict.
 // External events are considered informational only.
 func (r *ConflictResolver) resolveOrbitaWins(ctx context.Context, conflict *domain.Conflict) *ConflictResult {
@@ -209,7 +268,7 @@ func (r *ConflictResolver) resolveExternalWins(ctx context.Context, conflict *do
 	blockTime := conflict.OrbitaBlockTime()
 	schedule, err := r.scheduleRepo.FindByUserAndDate(ctx, userID, blockTime.Start.Truncate(24*time.Hour))
 	if err != nil {
-		r.logger.Error("failed to find schedule for rescheduling",
+		observability.Logger(ctx, r.logger).Error("failed to find schedule for rescheduling",
 			"user_id", userID,
 			"date", blockTime.Start,
 			"error", err,
@@ -224,7 +283,7 @@ func (r *ConflictResolver) resolveExternalWins(ctx context.Context, conflict *do
 	}
 
 	if schedule == nil {
-		r.logger.Warn("schedule not found for conflict resolution",
+		observability.Logger(ctx, r.logger).Warn("schedule not found for conflict resolution",
 			"user_id", userID,
 			"block_id", conflict.OrbitaBlockID(),
 		)
@@ -239,7 +298,7 @@ func (r *ConflictResolver) resolveExternalWins(ctx context.Context, conflict *do
 	// 2. Find the block in the schedule
 	block, err := schedule.FindBlock(conflict.OrbitaBlockID())
 	if err != nil {
-		r.logger.Error("block not found in schedule",
+		observability.Logger(ctx, r.logger).Error("block not found in schedule",
 			"block_id", conflict.OrbitaBlockID(),
 			"error", err,
 		)
@@ -251,11 +310,22 @@ func (r *ConflictResolver) resolveExternalWins(ctx context.Context, conflict *do
 		}
 	}
 
+	// Focus mode windows stay put; the external event is only noted.
+	if block.IsProtected() {
+		conflict.MarkKept()
+		return &ConflictResult{
+			HasConflict: true,
+			Conflicts:   []*domain.Conflict{conflict},
+			Resolution:  domain.ResolutionKept,
+			Message:     "Orbita block is protected by focus mode and was kept.",
+		}
+	}
+
 	// 3. Use the scheduler engine to find a new available slot
 	duration := block.Duration()
 	newSlot, err := r.scheduler.FindOptimalSlot(schedule, duration, nil)
 	if err != nil {
-		r.logger.Warn("no available slots for rescheduling",
+		observability.Logger(ctx, r.logger).Warn("no available slots for rescheduling",
 			"block_id", conflict.OrbitaBlockID(),
 			"duration", duration,
 			"error", err,
@@ -274,7 +344,7 @@ func (r *ConflictResolver) resolveExternalWins(ctx context.Context, conflict *do
 	newEnd := newStart.Add(duration)
 
 	if err := schedule.RescheduleBlock(conflict.OrbitaBlockID(), newStart, newEnd); err != nil {
-		r.logger.Error("failed to reschedule block",
+		observability.Logger(ctx, r.logger).Error("failed to reschedule block",
 			"block_id", conflict.OrbitaBlockID(),
 			"new_start", newStart,
 			"new_end", newEnd,
@@ -290,7 +360,7 @@ func (r *ConflictResolver) resolveExternalWins(ctx context.Context, conflict *do
 
 	// 5. Save the updated schedule
 	if err := r.scheduleRepo.Save(ctx, schedule); err != nil {
-		r.logger.Error("failed to save rescheduled schedule",
+		observability.Logger(ctx, r.logger).Error("failed to save rescheduled schedule",
 			"schedule_id", schedule.ID(),
 			"error", err,
 		)
@@ -305,7 +375,7 @@ func (r *ConflictResolver) resolveExternalWins(ctx context.Context, conflict *do
 	// Mark the conflict as resolved
 	conflict.MarkRescheduled()
 
-	r.logger.Info("block rescheduled due to external event conflict",
+	observability.Logger(ctx, r.logger).Info("block rescheduled due to external event conflict",
 		"block_id", conflict.OrbitaBlockID(),
 		"old_start", blockTime.Start,
 		"old_end", blockTime.End,
@@ -371,3 +441,18 @@ func (r *ConflictResolver) SetStrategy(strategy domain.ConflictResolutionStrateg
 func (r *ConflictResolver) Strategy() domain.ConflictResolutionStrategy {
 	return r.strategy
 }
+
+// SetCalendarStrategy overrides the strategy for conflicts with events from
+// the given calendar.
+func (r *ConflictResolver) SetCalendarStrategy(calendarID string, strategy domain.ConflictResolutionStrategy) {
+	r.calendarStrategies[calendarID] = strategy
+}
+
+// StrategyFor returns the strategy used for conflicts with events from the
+// given calendar, falling back to the default strategy.
+func (r *ConflictResolver) StrategyFor(calendarID string) domain.ConflictResolutionStrategy {
+	if strategy, ok := r.calendarStrategies[calendarID]; ok && calendarID != "" {
+		return strategy
+	}
+	return r.strategy
+}
@@ -242,6 +242,41 @@ func TestConflictResolver_ResolveConflict_StrategyExternalWins(t *testing.T) {
 	assert.Contains(t, result.Message, "rescheduled")
 }
 
+func TestConflictResolver_ResolveConflict_ExternalWins_KeepsFocusMode(t *testing.T) {
+	repo := newMockScheduleRepoForConflicts()
+	schedulerEngine := NewSchedulerEngine(DefaultSchedulerConfig())
+	config := ConflictResolverConfig{Strategy: domain.StrategyExternalWins}
+	resolver := NewConflictResolver(repo, schedulerEngine, config, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	today := time.Now().Truncate(24 * time.Hour)
+
+	schedule := domain.NewSchedule(userID, today)
+	blockStart := today.Add(10 * time.Hour)
+	blockEnd := today.Add(12 * time.Hour)
+	block, err := schedule.AddProtectedBlock(domain.BlockTypeFocusMode, uuid.Nil, "Focus mode", blockStart, blockEnd)
+	require.NoError(t, err)
+	repo.schedules[userID.String()+"_"+today.Format("2006-01-02")] = schedule
+
+	conflict := domain.NewConflict(
+		userID,
+		domain.ConflictTypeOverlap,
+		block.ID(),
+		domain.TimeRange{Start: blockStart, End: blockEnd},
+		"external-event-1",
+		domain.TimeRange{Start: blockStart.Add(30 * time.Minute), End: blockEnd},
+	)
+
+	result, err := resolver.ResolveConflict(ctx, conflict)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ResolutionKept, result.Resolution)
+	assert.Contains(t, result.Message, "focus mode")
+	assert.Equal(t, blockStart, block.StartTime())
+	assert.Equal(t, blockEnd, block.EndTime())
+}
+
 func TestConflictResolver_ResolveConflict_StrategyTimeFirst_OrbitaFirst(t *testing.T) {
 	repo := newMockScheduleRepoForConflicts()
 	config := ConflictResolverConfig{Strategy: domain.StrategyTimeFirst}
@@ -374,6 +409,69 @@ func TestConflictResolver_SetStrategy(t *testing.T) {
 	assert.Equal(t, domain.StrategyOrbitaWins, resolver.Strategy())
 }
 
+func TestConflictResolver_ResolveConflict_PerCalendarStrategy(t *testing.T) {
+	repo := newMockScheduleRepoForConflicts()
+	schedulerEngine := NewSchedulerEngine(DefaultSchedulerConfig())
+	config := ConflictResolverConfig{
+		Strategy: domain.StrategyManual,
+		CalendarStrategies: map[string]domain.ConflictResolutionStrategy{
+			"work@example.com": domain.StrategyExternalWins,
+			"personal":         domain.StrategyOrbitaWins,
+		},
+	}
+	resolver := NewConflictResolver(repo, schedulerEngine, config, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	today := time.Now().Truncate(24 * time.Hour)
+
+	schedule := domain.NewSchedule(userID, today)
+	deepWork, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Deep work", today.Add(10*time.Hour), today.Add(11*time.Hour))
+	require.NoError(t, err)
+	review, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Review", today.Add(14*time.Hour), today.Add(15*time.Hour))
+	require.NoError(t, err)
+	planning, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Planning", today.Add(16*time.Hour), today.Add(17*time.Hour))
+	require.NoError(t, err)
+	repo.schedules[userID.String()+"_"+today.Format("2006-01-02")] = schedule
+
+	events := []application.CalendarEvent{
+		{ID: "standup", CalendarID: "work@example.com", StartTime: today.Add(10 * time.Hour), EndTime: today.Add(10*time.Hour + 30*time.Minute)},
+		{ID: "dentist", CalendarID: "personal", StartTime: today.Add(14 * time.Hour), EndTime: today.Add(14*time.Hour + 30*time.Minute)},
+		{ID: "unknown", StartTime: today.Add(16 * time.Hour), EndTime: today.Add(16*time.Hour + 30*time.Minute)},
+	}
+
+	conflicts, err := resolver.DetectConflicts(ctx, userID, events)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 3)
+
+	resolutions := map[uuid.UUID]domain.ConflictResolution{}
+	for _, conflict := range conflicts {
+		result, err := resolver.ResolveConflict(ctx, conflict)
+		require.NoError(t, err)
+		resolutions[conflict.OrbitaBlockID()] = result.Resolution
+	}
+
+	// The work calendar wins, so the block is moved out of the way.
+	assert.Equal(t, domain.ResolutionRescheduled, resolutions[deepWork.ID()])
+	// Personal events are kept alongside the block.
+	assert.Equal(t, domain.ResolutionKept, resolutions[review.ID()])
+	assert.Equal(t, today.Add(14*time.Hour), review.StartTime())
+	// Events from other calendars use the default strategy.
+	assert.Equal(t, domain.ResolutionPending, resolutions[planning.ID()])
+}
+
+func TestConflictResolver_StrategyFor(t *testing.T) {
+	repo := newMockScheduleRepoForConflicts()
+	config := DefaultConflictResolverConfig()
+	resolver := NewConflictResolver(repo, nil, config, nil)
+
+	resolver.SetCalendarStrategy("work", domain.StrategyExternalWins)
+
+	assert.Equal(t, domain.StrategyExternalWins, resolver.StrategyFor("work"))
+	assert.Equal(t, domain.StrategyTimeFirst, resolver.StrategyFor("personal"))
+	assert.Equal(t, domain.StrategyTimeFirst, resolver.StrategyFor(""))
+}
+
 func TestDefaultConflictResolverConfig(t *testing.T) {
 	config := DefaultConflictResolverConfig()
 	assert.Equal(t, domain.StrategyTimeFirst, config.Strategy)
@@ -616,3 +714,64 @@ func TestConflictResolver_ResolveConflict_ExternalWins_FindScheduleError(t *test
 	assert.Equal(t, domain.ResolutionPending, result.Resolution)
 	assert.Contains(t, result.Message, "Failed to find schedule")
 }
+
+func TestConflictResolver_PreviewResolution(t *testing.T) {
+	userID := uuid.New()
+	today := time.Now().Truncate(24 * time.Hour)
+	blockStart := today.Add(10 * time.Hour)
+	blockEnd := today.Add(11 * time.Hour)
+
+	newConflict := func(blockID uuid.UUID, externalStart time.Time) *domain.Conflict {
+		return domain.NewConflict(
+			userID,
+			domain.ConflictTypeOverlap,
+			blockID,
+			domain.TimeRange{Start: blockStart, End: blockEnd},
+			"external-event-1",
+			domain.TimeRange{Start: externalStart, End: externalStart.Add(time.Hour)},
+		)
+	}
+
+	tests := []struct {
+		name          string
+		strategy      domain.ConflictResolutionStrategy
+		protected     bool
+		externalStart time.Time
+		want          domain.ConflictResolution
+	}{
+		{"orbita wins keeps the block", domain.StrategyOrbitaWins, false, blockStart, domain.ResolutionKept},
+		{"external wins moves the block", domain.StrategyExternalWins, false, blockStart, domain.ResolutionRescheduled},
+		{"external wins keeps focus mode", domain.StrategyExternalWins, true, blockStart, domain.ResolutionKept},
+		{"time first keeps the earlier block", domain.StrategyTimeFirst, false, blockStart.Add(30 * time.Minute), domain.ResolutionKept},
+		{"time first moves the later block", domain.StrategyTimeFirst, false, blockStart.Add(-30 * time.Minute), domain.ResolutionRescheduled},
+		{"manual leaves it pending", domain.StrategyManual, false, blockStart, domain.ResolutionPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockScheduleRepoForConflicts()
+			resolver := NewConflictResolver(repo, NewSchedulerEngine(DefaultSchedulerConfig()), ConflictResolverConfig{Strategy: tt.strategy}, nil)
+
+			schedule := domain.NewSchedule(userID, today)
+			var block *domain.TimeBlock
+			var err error
+			if tt.protected {
+				block, err = schedule.AddProtectedBlock(domain.BlockTypeFocusMode, uuid.Nil, "Focus mode", blockStart, blockEnd)
+			} else {
+				block, err = schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Task", blockStart, blockEnd)
+			}
+			require.NoError(t, err)
+			schedule.ClearDomainEvents()
+			repo.schedules[userID.String()+"_"+today.Format("2006-01-02")] = schedule
+
+			conflict := newConflict(block.ID(), tt.externalStart)
+			resolution, err := resolver.PreviewResolution(context.Background(), conflict)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resolution)
+			assert.Equal(t, domain.ResolutionPending, conflict.Resolution())
+			assert.Equal(t, blockStart, block.StartTime())
+			assert.Empty(t, schedule.DomainEvents())
+		})
+	}
+}
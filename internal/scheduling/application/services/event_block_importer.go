@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/application"
+	"github.com/felixgeelhaar/orbita/internal/calendar/application/workers"
+	calendarDomain "github.com/felixgeelhaar/orbita/internal/calendar/domain"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+)
+
+// Ensure EventBlockImporter implements the calendar worker's interface.
+var _ workers.EventBlockImporter = (*EventBlockImporter)(nil)
+
+// defaultImportedBlockTitle is used for external events without a summary.
+const defaultImportedBlockTitle = "Imported event"
+
+// EventBlockImporter keeps one schedule block per imported external event.
+type EventBlockImporter struct {
+	scheduleRepo domain.ScheduleRepository
+	blockType    domain.BlockType
+}
+
+// NewEventBlockImporter creates an importer that adds imported events as
+// meeting blocks.
+func NewEventBlockImporter(scheduleRepo domain.ScheduleRepository) *EventBlockImporter {
+	return &EventBlockImporter{
+		scheduleRepo: scheduleRepo,
+		blockType:    domain.BlockTypeMeeting,
+	}
+}
+
+// ImportEventBlock creates a block for the event or brings the block
+// imported for it earlier in line with the event. A moved event reschedules
+// its block; a renamed event, or one moved to another day, replaces it. A
+// block removed from the schedule since the last import is created again.
+func (i *EventBlockImporter) ImportEventBlock(
+	ctx context.Context,
+	userID uuid.UUID,
+	event application.CalendarEvent,
+	existing *calendarDomain.ImportedBlock,
+) (calendarDomain.ImportedBlock, error) {
+	title := strings.TrimSpace(event.Summary)
+	if title == "" {
+		title = defaultImportedBlockTitle
+	}
+	start := event.StartTime.In(time.Local)
+	end := event.EndTime.In(time.Local)
+
+	if existing != nil {
+		schedule, block, err := i.findBlock(ctx, userID, *existing)
+		if err != nil {
+			return calendarDomain.ImportedBlock{}, err
+		}
+		if block != nil {
+			if block.StartTime().Equal(start) && block.EndTime().Equal(end) && block.Title() == title {
+				return calendarDomain.ImportedBlock{BlockID: block.ID(), Start: start}, nil
+			}
+			if sameDay(block.StartTime(), start) && block.Title() == title {
+				if err := schedule.RescheduleBlock(block.ID(), start, end); err != nil {
+					return calendarDomain.ImportedBlock{}, err
+				}
+				if err := i.scheduleRepo.Save(ctx, schedule); err != nil {
+					return calendarDomain.ImportedBlock{}, err
+				}
+				return calendarDomain.ImportedBlock{BlockID: block.ID(), Start: start}, nil
+			}
+			if err := schedule.RemoveBlock(block.ID()); err != nil {
+				return calendarDomain.ImportedBlock{}, err
+			}
+			if err := i.scheduleRepo.Save(ctx, schedule); err != nil {
+				return calendarDomain.ImportedBlock{}, err
+			}
+		}
+	}
+
+	schedule, err := i.scheduleRepo.FindByUserAndDate(ctx, userID, start)
+	if err != nil {
+		return calendarDomain.ImportedBlock{}, err
+	}
+	if schedule == nil {
+		schedule = domain.NewSchedule(userID, start)
+	}
+
+	block, err := schedule.AddBlock(i.blockType, uuid.Nil, title, start, end)
+	if err != nil {
+		return calendarDomain.ImportedBlock{}, err
+	}
+	if err := i.scheduleRepo.Save(ctx, schedule); err != nil {
+		return calendarDomain.ImportedBlock{}, err
+	}
+
+	return calendarDomain.ImportedBlock{BlockID: block.ID(), Start: start}, nil
+}
+
+// RemoveEventBlock removes the block imported for a deleted event. A block
+// that is already gone is not an error.
+func (i *EventBlockImporter) RemoveEventBlock(ctx context.Context, userID uuid.UUID, imported calendarDomain.ImportedBlock) error {
+	schedule, block, err := i.findBlock(ctx, userID, imported)
+	if err != nil || block == nil {
+		return err
+	}
+
+	if err := schedule.RemoveBlock(block.ID()); err != nil {
+		return err
+	}
+	return i.scheduleRepo.Save(ctx, schedule)
+}
+
+// findBlock loads the schedule holding an imported block. It returns a nil
+// block when the schedule or block no longer exists.
+func (i *EventBlockImporter) findBlock(ctx context.Context, userID uuid.UUID, imported calendarDomain.ImportedBlock) (*domain.Schedule, *domain.TimeBlock, error) {
+	schedule, err := i.scheduleRepo.FindByUserAndDate(ctx, userID, imported.Start.In(time.Local))
+	if err != nil || schedule == nil {
+		return nil, nil, err
+	}
+
+	block, err := schedule.FindBlock(imported.BlockID)
+	if errors.Is(err, domain.ErrBlockNotFound) {
+		return schedule, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return schedule, block, nil
+}
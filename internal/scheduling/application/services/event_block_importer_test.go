@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/calendar/application"
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBlockImporter(t *testing.T) {
+	userID := uuid.New()
+	day := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.Local)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+
+	scheduleFor := func(repo *mockScheduleRepoForConflicts, date time.Time) *domain.Schedule {
+		return repo.schedules[userID.String()+"_"+date.Format("2006-01-02")]
+	}
+	event := application.CalendarEvent{ID: "dentist", Summary: "Dentist", StartTime: at(9), EndTime: at(10)}
+
+	t.Run("re-importing an unchanged event keeps one block", func(t *testing.T) {
+		repo := newMockScheduleRepoForConflicts()
+		importer := NewEventBlockImporter(repo)
+
+		first, err := importer.ImportEventBlock(context.Background(), userID, event, nil)
+		require.NoError(t, err)
+		second, err := importer.ImportEventBlock(context.Background(), userID, event, &first)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.BlockID, second.BlockID)
+		schedule := scheduleFor(repo, day)
+		require.Len(t, schedule.Blocks(), 1)
+		assert.Equal(t, domain.BlockTypeMeeting, schedule.Blocks()[0].BlockType())
+		assert.Equal(t, "Dentist", schedule.Blocks()[0].Title())
+	})
+
+	t.Run("a moved event reschedules its block", func(t *testing.T) {
+		repo := newMockScheduleRepoForConflicts()
+		importer := NewEventBlockImporter(repo)
+
+		first, err := importer.ImportEventBlock(context.Background(), userID, event, nil)
+		require.NoError(t, err)
+
+		moved := event
+		moved.StartTime, moved.EndTime = at(14), at(15)
+		second, err := importer.ImportEventBlock(context.Background(), userID, moved, &first)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.BlockID, second.BlockID)
+		assert.Equal(t, at(14), second.Start)
+		blocks := scheduleFor(repo, day).Blocks()
+		require.Len(t, blocks, 1)
+		assert.Equal(t, at(14), blocks[0].StartTime())
+	})
+
+	t.Run("an event moved to another day replaces its block", func(t *testing.T) {
+		repo := newMockScheduleRepoForConflicts()
+		importer := NewEventBlockImporter(repo)
+
+		first, err := importer.ImportEventBlock(context.Background(), userID, event, nil)
+		require.NoError(t, err)
+
+		moved := event
+		moved.StartTime, moved.EndTime = at(24+9), at(24+10)
+		second, err := importer.ImportEventBlock(context.Background(), userID, moved, &first)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first.BlockID, second.BlockID)
+		assert.Empty(t, scheduleFor(repo, day).Blocks())
+		assert.Len(t, scheduleFor(repo, day.AddDate(0, 0, 1)).Blocks(), 1)
+	})
+
+	t.Run("removes the block of a deleted event", func(t *testing.T) {
+		repo := newMockScheduleRepoForConflicts()
+		importer := NewEventBlockImporter(repo)
+
+		imported, err := importer.ImportEventBlock(context.Background(), userID, event, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, importer.RemoveEventBlock(context.Background(), userID, imported))
+		assert.Empty(t, scheduleFor(repo, day).Blocks())
+
+		// Removing it again is a no-op.
+		assert.NoError(t, importer.RemoveEventBlock(context.Background(), userID, imported))
+	})
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+)
+
+// FocusModeGuard reports whether a user's focus mode is silencing
+// notifications, so that notification senders can hold them back.
+type FocusModeGuard struct {
+	scheduleRepo domain.ScheduleRepository
+}
+
+// NewFocusModeGuard creates a new FocusModeGuard.
+func NewFocusModeGuard(scheduleRepo domain.ScheduleRepository) *FocusModeGuard {
+	return &FocusModeGuard{scheduleRepo: scheduleRepo}
+}
+
+// SuppressesNotifications returns true if the user is in a focus mode
+// window at the given time that silences notifications.
+func (g *FocusModeGuard) SuppressesNotifications(ctx context.Context, userID uuid.UUID, at time.Time) (bool, error) {
+	schedule, err := g.scheduleRepo.FindByUserAndDate(ctx, userID, at)
+	if err != nil {
+		return false, err
+	}
+	if schedule == nil {
+		return false, nil
+	}
+
+	block := schedule.ProtectedBlockAt(at)
+	return block != nil && block.SuppressesNotifications(), nil
+}
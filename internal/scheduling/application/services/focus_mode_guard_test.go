@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFocusModeGuard_SuppressesNotifications(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	start := day.Add(10 * time.Hour)
+
+	newGuard := func(t *testing.T, blockType domain.BlockType) (*FocusModeGuard, *domain.Schedule, *domain.TimeBlock) {
+		repo := newMockScheduleRepoForConflicts()
+		schedule := domain.NewSchedule(userID, day)
+		block, err := schedule.AddProtectedBlock(blockType, uuid.Nil, "Focus mode", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, schedule))
+		return NewFocusModeGuard(repo), schedule, block
+	}
+
+	t.Run("silences notifications during do not disturb", func(t *testing.T) {
+		guard, _, _ := newGuard(t, domain.BlockTypeDoNotDisturb)
+
+		suppressed, err := guard.SuppressesNotifications(ctx, userID, start.Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, suppressed)
+
+		suppressed, err = guard.SuppressesNotifications(ctx, userID, start.Add(time.Hour))
+		require.NoError(t, err)
+		assert.False(t, suppressed)
+	})
+
+	t.Run("lets notifications through when only protecting the window", func(t *testing.T) {
+		guard, _, _ := newGuard(t, domain.BlockTypeFocusMode)
+
+		suppressed, err := guard.SuppressesNotifications(ctx, userID, start.Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.False(t, suppressed)
+	})
+
+	t.Run("lets notifications through once focus mode has ended", func(t *testing.T) {
+		guard, schedule, block := newGuard(t, domain.BlockTypeDoNotDisturb)
+
+		_, err := schedule.EndProtectedBlock(block.ID(), start.Add(20*time.Minute))
+		require.NoError(t, err)
+
+		suppressed, err := guard.SuppressesNotifications(ctx, userID, start.Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.False(t, suppressed)
+	})
+
+	t.Run("returns false without a schedule", func(t *testing.T) {
+		guard := NewFocusModeGuard(newMockScheduleRepoForConflicts())
+
+		suppressed, err := guard.SuppressesNotifications(ctx, userID, start)
+		require.NoError(t, err)
+		assert.False(t, suppressed)
+	})
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+)
+
+// scheduleLocks serializes conflict resolutions that touch the same block.
+// Resolving a conflict loads, changes and saves the whole schedule of the
+// block's day, so the lock is held per schedule: resolutions for blocks on
+// other days run in parallel.
+type scheduleLocks struct {
+	mu    sync.Mutex
+	locks map[string]*scheduleLock
+}
+
+type scheduleLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func newScheduleLocks() *scheduleLocks {
+	return &scheduleLocks{locks: make(map[string]*scheduleLock)}
+}
+
+// lock locks the schedules of the conflicts' blocks and returns the function
+// that unlocks them. Schedules are locked in a fixed order so callers
+// locking overlapping sets cannot deadlock.
+func (l *scheduleLocks) lock(conflicts []*domain.Conflict) (unlock func()) {
+	keys := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		day := conflict.OrbitaBlockTime().Start.Truncate(24 * time.Hour)
+		keys = append(keys, conflict.UserID().String()+"/"+day.Format(time.DateOnly))
+	}
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	held := make([]*scheduleLock, len(keys))
+	for i, key := range keys {
+		l.mu.Lock()
+		entry, ok := l.locks[key]
+		if !ok {
+			entry = &scheduleLock{}
+			l.locks[key] = entry
+		}
+		entry.refs++
+		l.mu.Unlock()
+
+		entry.mu.Lock()
+		held[i] = entry
+	}
+
+	return func() {
+		for i := len(keys) - 1; i >= 0; i-- {
+			held[i].mu.Unlock()
+
+			l.mu.Lock()
+			held[i].refs--
+			if held[i].refs == 0 {
+				delete(l.locks, keys[i])
+			}
+			l.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func lockTestConflict(userID, blockID uuid.UUID, start time.Time) *domain.Conflict {
+	return domain.NewConflict(
+		userID,
+		domain.ConflictTypeOverlap,
+		blockID,
+		domain.TimeRange{Start: start, End: start.Add(time.Hour)},
+		"external-"+blockID.String(),
+		domain.TimeRange{Start: start.Add(30 * time.Minute), End: start.Add(90 * time.Minute)},
+	)
+}
+
+func TestScheduleLocks_SerializesSameBlock(t *testing.T) {
+	locks := newScheduleLocks()
+	userID := uuid.New()
+	blockID := uuid.New()
+	start := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locks.lock([]*domain.Conflict{lockTestConflict(userID, blockID, start)})
+			defer unlock()
+
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, maxInFlight)
+	assert.Empty(t, locks.locks)
+}
+
+func TestScheduleLocks_OtherDaysRunInParallel(t *testing.T) {
+	locks := newScheduleLocks()
+	userID := uuid.New()
+	monday := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+
+	unlockMonday := locks.lock([]*domain.Conflict{lockTestConflict(userID, uuid.New(), monday)})
+	defer unlockMonday()
+
+	acquired := make(chan struct{})
+	go func() {
+		// Tuesday's schedule, and Monday's schedule of another user.
+		unlock := locks.lock([]*domain.Conflict{
+			lockTestConflict(userID, uuid.New(), monday.AddDate(0, 0, 1)),
+			lockTestConflict(uuid.New(), uuid.New(), monday),
+		})
+		unlock()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("lock on another schedule waited for Monday's lock")
+	}
+}
+
+func TestScheduleLocks_OverlappingSetsDoNotDeadlock(t *testing.T) {
+	locks := newScheduleLocks()
+	userID := uuid.New()
+	monday := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+	mondayConflict := lockTestConflict(userID, uuid.New(), monday)
+	tuesdayConflict := lockTestConflict(userID, uuid.New(), monday.AddDate(0, 0, 1))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			locks.lock([]*domain.Conflict{mondayConflict, tuesdayConflict})()
+		}()
+		go func() {
+			defer wg.Done()
+			locks.lock([]*domain.Conflict{tuesdayConflict, mondayConflict, mondayConflict})()
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("overlapping locks deadlocked")
+	}
+	assert.Empty(t, locks.locks)
+}
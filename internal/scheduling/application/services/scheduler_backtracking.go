@@ -0,0 +1,270 @@
+package services
+
+import (
+	"time"
+
+	schedulingDomain "github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+)
+
+// priorityLevels is the number of priorities a packing is scored on.
+// Priorities outside 1-5 count towards the nearest level.
+const priorityLevels = 6
+
+// fitScore counts the tasks a packing schedules at each priority level.
+type fitScore [priorityLevels]int
+
+func priorityLevel(priority int) int {
+	return min(max(priority, 0), priorityLevels-1)
+}
+
+// better reports whether s fits more tasks than other at the highest priority
+// where they differ, so fitting several low-priority tasks never costs an
+// urgent one its slot.
+func (s fitScore) better(other fitScore) bool {
+	for i := range s {
+		if s[i] != other[i] {
+			return s[i] > other[i]
+		}
+	}
+	return false
+}
+
+func (s fitScore) plus(other fitScore) fitScore {
+	for i := range s {
+		s[i] += other[i]
+	}
+	return s
+}
+
+// packTasks looks for an arrangement of tasks in the free gaps of the schedule
+// that fits more of them than the greedy pass. Tasks are already sorted. It
+// reports false and leaves the schedule untouched when the greedy pass fits
+// everything, nothing better is found, or the search runs out of its budget.
+func (e *SchedulerEngine) packTasks(
+	schedule *schedulingDomain.Schedule,
+	tasks []SchedulableTask,
+	workStart, workEnd time.Time,
+	explain bool,
+) ([]ScheduleResult, bool) {
+	// Score the greedy pass on a draft; the search has to beat it.
+	draft := schedule.Draft()
+	var greedy fitScore
+	fitsAll := true
+	for _, task := range tasks {
+		if e.scheduleTask(draft, task, workStart, workEnd, false).Scheduled {
+			greedy[priorityLevel(task.Priority)]++
+		} else {
+			fitsAll = false
+		}
+	}
+	if fitsAll {
+		return nil, false
+	}
+
+	gaps := schedule.FindAvailableSlots(workStart, workEnd, time.Minute)
+	p := newPacker(tasks, gaps, workStart, e.config.MinBreakBetween, greedy)
+	p.deadline = time.Now().Add(e.config.BacktrackBudget)
+	p.search(0)
+	if p.timedOut || !p.found {
+		return nil, false
+	}
+
+	slots := e.packedSlots(tasks, gaps, p.best, workStart)
+
+	// Earliest starts are not part of the search either; keep the greedy
+	// result when the arrangement would start a task too soon.
+	for i, task := range tasks {
+		if p.best[i] >= 0 && slots[i].Start.Before(startWindow(task, workStart)) {
+			return nil, false
+		}
+	}
+
+	// Hard constraints are not part of the search, so try the arrangement on a
+	// draft before touching the schedule.
+	draft = schedule.Draft()
+	for i, task := range tasks {
+		if p.best[i] < 0 {
+			continue
+		}
+		if _, err := draft.AddBlock(blockTypeOf(task), task.ID, task.Title, slots[i].Start, slots[i].End); err != nil {
+			return nil, false
+		}
+	}
+
+	results := make([]ScheduleResult, 0, len(tasks))
+	for i, task := range tasks {
+		var rationale *SlotRationale
+		if explain {
+			rationale = &SlotRationale{Factors: e.rationaleFactors(task, workStart)}
+		}
+
+		if p.best[i] < 0 {
+			if rationale != nil {
+				rationale.Rule = "left out of the arrangement that fits the most tasks"
+			}
+			results = append(results, ScheduleResult{
+				TaskID:    task.ID,
+				Scheduled: false,
+				Reason:    "no available time slots",
+				Rationale: rationale,
+			})
+			continue
+		}
+
+		block, err := schedule.AddBlock(blockTypeOf(task), task.ID, task.Title, slots[i].Start, slots[i].End)
+		if err != nil {
+			// The draft accepted the same blocks, so this only happens if the
+			// schedule changed underneath us.
+			results = append(results, ScheduleResult{
+				TaskID:    task.ID,
+				Scheduled: false,
+				Reason:    err.Error(),
+				Rationale: rationale,
+			})
+			continue
+		}
+		if rationale != nil {
+			gap := gaps[p.best[i]]
+			rationale.Chosen = &gap
+			rationale.Rule = "rearranged with other tasks so more of them fit"
+		}
+		results = append(results, ScheduleResult{
+			TaskID:    task.ID,
+			BlockID:   block.ID(),
+			StartTime: slots[i].Start,
+			EndTime:   slots[i].End,
+			Scheduled: true,
+			Rationale: rationale,
+		})
+	}
+	return results, true
+}
+
+// packedSlots lays out the tasks assigned to each gap back to back, in sorted
+// order, with the configured break before each one.
+func (e *SchedulerEngine) packedSlots(
+	tasks []SchedulableTask,
+	gaps []schedulingDomain.TimeSlot,
+	assignment []int,
+	workStart time.Time,
+) []schedulingDomain.TimeSlot {
+	cursors := make([]time.Time, len(gaps))
+	for g, gap := range gaps {
+		cursors[g] = gap.Start
+	}
+
+	slots := make([]schedulingDomain.TimeSlot, len(tasks))
+	for i, task := range tasks {
+		g := assignment[i]
+		if g < 0 {
+			continue
+		}
+		start := cursors[g]
+		if e.config.MinBreakBetween > 0 && !start.Equal(workStart) {
+			start = start.Add(e.config.MinBreakBetween)
+		}
+		slots[i] = schedulingDomain.TimeSlot{Start: start, End: start.Add(task.Duration)}
+		cursors[g] = slots[i].End
+	}
+	return slots
+}
+
+// packer is a depth-first search over which gap, if any, each task goes in.
+type packer struct {
+	need  []time.Duration // task duration plus the break before it
+	level []int
+	room  []time.Duration // time left in each gap
+	// remaining[i] scores tasks i and later as if they all fit, bounding
+	// what a partial assignment can still reach.
+	remaining []fitScore
+
+	assign    []int
+	score     fitScore
+	best      []int
+	bestScore fitScore
+	found     bool
+
+	deadline time.Time
+	nodes    int
+	timedOut bool
+}
+
+func newPacker(
+	tasks []SchedulableTask,
+	gaps []schedulingDomain.TimeSlot,
+	workStart time.Time,
+	minBreak time.Duration,
+	baseline fitScore,
+) *packer {
+	p := &packer{
+		need:      make([]time.Duration, len(tasks)),
+		level:     make([]int, len(tasks)),
+		room:      make([]time.Duration, len(gaps)),
+		remaining: make([]fitScore, len(tasks)+1),
+		assign:    make([]int, len(tasks)),
+		bestScore: baseline,
+	}
+	for i, task := range tasks {
+		p.need[i] = task.Duration + minBreak
+		p.level[i] = priorityLevel(task.Priority)
+	}
+	for i := len(tasks) - 1; i >= 0; i-- {
+		p.remaining[i] = p.remaining[i+1]
+		p.remaining[i][p.level[i]]++
+	}
+	// The first block of the day needs no break before it.
+	for g, gap := range gaps {
+		p.room[g] = gap.Duration()
+		if gap.Start.Equal(workStart) {
+			p.room[g] += minBreak
+		}
+	}
+	return p
+}
+
+func (p *packer) search(i int) {
+	if p.timedOut {
+		return
+	}
+	if p.nodes%1024 == 0 && !time.Now().Before(p.deadline) {
+		p.timedOut = true
+		return
+	}
+	p.nodes++
+
+	if !p.score.plus(p.remaining[i]).better(p.bestScore) {
+		return
+	}
+	if i == len(p.need) {
+		p.bestScore = p.score
+		p.best = append(p.best[:0], p.assign...)
+		p.found = true
+		return
+	}
+
+	for g := range p.room {
+		if p.room[g] < p.need[i] || p.triedRoom(g) {
+			continue
+		}
+		p.room[g] -= p.need[i]
+		p.score[p.level[i]]++
+		p.assign[i] = g
+		p.search(i + 1)
+		p.score[p.level[i]]--
+		p.room[g] += p.need[i]
+	}
+
+	p.assign[i] = -1
+	p.search(i + 1)
+}
+
+// triedRoom reports whether an earlier gap has the same time left as gap g.
+// Such gaps are interchangeable for the tasks still to place.
+func (p *packer) triedRoom(g int) bool {
+	for h := 0; h < g; h++ {
+		if p.room[h] == p.room[g] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	schedulingDomain "github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tightDay returns a schedule with a free hour from 9:00 and a free hour and
+// a half from 11:00; meetings fill the rest of the working day.
+func tightDay(t *testing.T) *schedulingDomain.Schedule {
+	t.Helper()
+	day := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	schedule := schedulingDomain.NewSchedule(uuid.New(), day)
+	_, err := schedule.AddBlock(schedulingDomain.BlockTypeMeeting, uuid.New(), "Standup", day.Add(10*time.Hour), day.Add(11*time.Hour))
+	require.NoError(t, err)
+	_, err = schedule.AddBlock(schedulingDomain.BlockTypeMeeting, uuid.New(), "Offsite", day.Add(12*time.Hour+30*time.Minute), day.Add(17*time.Hour))
+	require.NoError(t, err)
+	return schedule
+}
+
+// tightDayTasks do not fit if the short high-priority task takes the first gap.
+func tightDayTasks() []SchedulableTask {
+	return []SchedulableTask{
+		{ID: uuid.New(), Title: "Review", Priority: 2, Duration: 30 * time.Minute},
+		{ID: uuid.New(), Title: "Write", Priority: 3, Duration: time.Hour},
+		{ID: uuid.New(), Title: "Plan", Priority: 3, Duration: time.Hour},
+	}
+}
+
+func scheduledCount(results []ScheduleResult) int {
+	count := 0
+	for _, result := range results {
+		if result.Scheduled {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSchedulerEngine_Backtracking_FitsMoreThanGreedy(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultSchedulerConfig()
+	config.MinBreakBetween = 0
+
+	greedy, err := NewSchedulerEngine(config).ScheduleTasks(ctx, tightDay(t), tightDayTasks())
+	require.NoError(t, err)
+
+	config.Backtracking = true
+	schedule := tightDay(t)
+	tasks := tightDayTasks()
+	packed, err := NewSchedulerEngine(config).ScheduleTasks(ctx, schedule, tasks)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, scheduledCount(greedy))
+	assert.Equal(t, 3, scheduledCount(packed))
+	assert.Len(t, schedule.Blocks(), 5)
+
+	day := schedule.Date()
+	starts := map[uuid.UUID]time.Time{}
+	for _, result := range packed {
+		starts[result.TaskID] = result.StartTime
+	}
+	assert.Equal(t, day.Add(11*time.Hour), starts[tasks[0].ID])
+	assert.Equal(t, day.Add(9*time.Hour), starts[tasks[1].ID])
+	assert.Equal(t, day.Add(11*time.Hour+30*time.Minute), starts[tasks[2].ID])
+}
+
+func TestSchedulerEngine_Backtracking_KeepsBreaks(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.Backtracking = true
+	schedule := tightDay(t)
+	tasks := []SchedulableTask{
+		{ID: uuid.New(), Title: "Review", Priority: 2, Duration: 25 * time.Minute},
+		{ID: uuid.New(), Title: "Write", Priority: 3, Duration: time.Hour},
+		{ID: uuid.New(), Title: "Plan", Priority: 3, Duration: 55 * time.Minute},
+	}
+
+	results, err := NewSchedulerEngine(config).ScheduleTasks(context.Background(), schedule, tasks)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, scheduledCount(results))
+	day := schedule.Date()
+	byTask := map[uuid.UUID]ScheduleResult{}
+	for _, result := range results {
+		byTask[result.TaskID] = result
+	}
+	// Only the first block of the day goes without a break before it.
+	assert.Equal(t, day.Add(9*time.Hour), byTask[tasks[1].ID].StartTime)
+	assert.Equal(t, day.Add(11*time.Hour+5*time.Minute), byTask[tasks[0].ID].StartTime)
+	assert.Equal(t, day.Add(11*time.Hour+35*time.Minute), byTask[tasks[2].ID].StartTime)
+	assert.Equal(t, day.Add(12*time.Hour+30*time.Minute), byTask[tasks[2].ID].EndTime)
+}
+
+func TestSchedulerEngine_Backtracking_NeverDropsHigherPriority(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.MinBreakBetween = 0
+	config.Backtracking = true
+	day := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	schedule := schedulingDomain.NewSchedule(uuid.New(), day)
+	_, err := schedule.AddBlock(schedulingDomain.BlockTypeMeeting, uuid.New(), "Offsite", day.Add(10*time.Hour), day.Add(17*time.Hour))
+	require.NoError(t, err)
+
+	urgent := SchedulableTask{ID: uuid.New(), Title: "Incident review", Priority: 1, Duration: time.Hour}
+	results, err := NewSchedulerEngine(config).ScheduleTasks(context.Background(), schedule, []SchedulableTask{
+		{ID: uuid.New(), Title: "Inbox", Priority: 4, Duration: 30 * time.Minute},
+		{ID: uuid.New(), Title: "Expenses", Priority: 4, Duration: 30 * time.Minute},
+		urgent,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, scheduledCount(results))
+	assert.Equal(t, urgent.ID, results[0].TaskID)
+	assert.True(t, results[0].Scheduled)
+}
+
+func TestSchedulerEngine_Backtracking_FallsBackToGreedyOnTimeout(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.MinBreakBetween = 0
+	config.Backtracking = true
+	config.BacktrackBudget = time.Nanosecond
+	schedule := tightDay(t)
+	tasks := tightDayTasks()
+
+	results, err := NewSchedulerEngine(config).ScheduleTasks(context.Background(), schedule, tasks)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, scheduledCount(results))
+	assert.Equal(t, tasks[0].ID, results[0].TaskID)
+	assert.Equal(t, schedule.Date().Add(9*time.Hour), results[0].StartTime, "greedy puts the high-priority task first")
+}
+
+func TestSchedulerEngine_Backtracking_Rationale(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.MinBreakBetween = 0
+	config.Backtracking = true
+
+	results, err := NewSchedulerEngine(config).ScheduleTasksWithRationale(context.Background(), tightDay(t), tightDayTasks())
+	require.NoError(t, err)
+
+	for _, result := range results {
+		require.NotNil(t, result.Rationale)
+		require.NotNil(t, result.Rationale.Chosen)
+		assert.Equal(t, "rearranged with other tasks so more of them fit", result.Rationale.Rule)
+		assert.NotEmpty(t, result.Rationale.Factors)
+	}
+}
+
+func TestSchedulerEngine_Backtracking_HonoursEarliestStart(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	config.MinBreakBetween = 0
+	config.Backtracking = true
+	schedule := tightDay(t)
+	tasks := tightDayTasks()
+	// The arrangement that fits all three starts Write at 9:00.
+	earliestStart := schedule.Date().Add(11 * time.Hour)
+	tasks[1].EarliestStart = &earliestStart
+
+	results, err := NewSchedulerEngine(config).ScheduleTasks(context.Background(), schedule, tasks)
+	require.NoError(t, err)
+
+	for _, result := range results {
+		if result.TaskID == tasks[1].ID {
+			require.True(t, result.Scheduled)
+			assert.False(t, result.StartTime.Before(earliestStart), "starts at %s", result.StartTime)
+		}
+	}
+}
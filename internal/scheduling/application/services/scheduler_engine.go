@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"time"
 
@@ -24,6 +25,8 @@ type SchedulableTask struct {
 	DueDate     *time.Time
 	Constraints []schedulingDomain.Constraint
 	BlockType   schedulingDomain.BlockType
+	// EarliestStart is the earliest time a block for the task may start.
+	EarliestStart *time.Time
 }
 
 // ScheduleResult represents the result of scheduling a task.
@@ -34,6 +37,44 @@ type ScheduleResult struct {
 	EndTime   time.Time
 	Scheduled bool
 	Reason    string
+	Rationale *SlotRationale // Only set when scheduling with rationale
+}
+
+// SlotRationale explains why the scheduler placed a task where it did.
+type SlotRationale struct {
+	Chosen       *schedulingDomain.TimeSlot // nil when the task could not be placed
+	Rule         string
+	Factors      []string
+	Alternatives []RejectedSlot
+}
+
+// RejectedSlot is a free slot the scheduler considered but did not use.
+type RejectedSlot struct {
+	Slot   schedulingDomain.TimeSlot
+	Reason string
+}
+
+// slotRule identifies which rule chooseBestSlot applied.
+type slotRule int
+
+const (
+	slotRuleOnly slotRule = iota
+	slotRuleMorning
+	slotRuleDueToday
+	slotRuleFirst
+)
+
+func (r slotRule) String() string {
+	switch r {
+	case slotRuleMorning:
+		return "earliest morning slot for a high-priority task"
+	case slotRuleDueToday:
+		return "latest slot that fits for a task due today"
+	case slotRuleFirst:
+		return "earliest slot that fits"
+	default:
+		return "only free slot that fits"
+	}
 }
 
 // SchedulerConfig contains configuration for the scheduler.
@@ -42,6 +83,10 @@ type SchedulerConfig struct {
 	DefaultWorkEnd   time.Duration // e.g., 17 * time.Hour for 5 PM
 	MinBreakBetween  time.Duration // minimum break between tasks
 	PreferMorning    bool          // prefer scheduling high-priority tasks in the morning
+	// Backtracking searches for an arrangement that fits more tasks when the
+	// greedy pass leaves some unscheduled.
+	Backtracking    bool
+	BacktrackBudget time.Duration // time allowed for the search before keeping the greedy result
 }
 
 // DefaultSchedulerConfig returns a default configuration.
@@ -51,6 +96,7 @@ func DefaultSchedulerConfig() SchedulerConfig {
 		DefaultWorkEnd:   17 * time.Hour,
 		MinBreakBetween:  5 * time.Minute,
 		PreferMorning:    true,
+		BacktrackBudget:  250 * time.Millisecond,
 	}
 }
 
@@ -72,6 +118,25 @@ func (e *SchedulerEngine) ScheduleTasks(
 	schedule *schedulingDomain.Schedule,
 	tasks []SchedulableTask,
 ) ([]ScheduleResult, error) {
+	return e.scheduleTasks(schedule, tasks, false), nil
+}
+
+// ScheduleTasksWithRationale schedules tasks like ScheduleTasks and attaches a
+// rationale to each result describing the chosen slot, the alternatives that
+// were rejected and the factors that drove the decision.
+func (e *SchedulerEngine) ScheduleTasksWithRationale(
+	ctx context.Context,
+	schedule *schedulingDomain.Schedule,
+	tasks []SchedulableTask,
+) ([]ScheduleResult, error) {
+	return e.scheduleTasks(schedule, tasks, true), nil
+}
+
+func (e *SchedulerEngine) scheduleTasks(
+	schedule *schedulingDomain.Schedule,
+	tasks []SchedulableTask,
+	explain bool,
+) []ScheduleResult {
 	results := make([]ScheduleResult, 0, len(tasks))
 
 	// Sort tasks by priority and due date
@@ -82,12 +147,18 @@ func (e *SchedulerEngine) ScheduleTasks(
 	workStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()).Add(e.config.DefaultWorkStart)
 	workEnd := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()).Add(e.config.DefaultWorkEnd)
 
+	if e.config.Backtracking {
+		if packed, ok := e.packTasks(schedule, sortedTasks, workStart, workEnd, explain); ok {
+			return packed
+		}
+	}
+
 	for _, task := range sortedTasks {
-		result := e.scheduleTask(schedule, task, workStart, workEnd)
+		result := e.scheduleTask(schedule, task, workStart, workEnd, explain)
 		results = append(results, result)
 	}
 
-	return results, nil
+	return results
 }
 
 // ScheduleSingleTask schedules a single task into the next available slot.
@@ -100,7 +171,7 @@ func (e *SchedulerEngine) ScheduleSingleTask(
 	workStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()).Add(e.config.DefaultWorkStart)
 	workEnd := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()).Add(e.config.DefaultWorkEnd)
 
-	result := e.scheduleTask(schedule, task, workStart, workEnd)
+	result := e.scheduleTask(schedule, task, workStart, workEnd, false)
 	return &result, nil
 }
 
@@ -134,10 +205,11 @@ func (e *SchedulerEngine) RescheduleConflicts(
 	schedule *schedulingDomain.Schedule,
 	newBlock *schedulingDomain.TimeBlock,
 ) ([]ScheduleResult, error) {
-	// Find blocks that conflict with the new block
+	// Find blocks that conflict with the new block. Focus mode blocks are
+	// protected and never moved to make room.
 	var conflicts []*schedulingDomain.TimeBlock
 	for _, block := range schedule.Blocks() {
-		if block.OverlapsWith(newBlock) && block.ID() != newBlock.ID() {
+		if block.OverlapsWith(newBlock) && block.ID() != newBlock.ID() && !block.IsProtected() {
 			conflicts = append(conflicts, block)
 		}
 	}
@@ -199,20 +271,52 @@ func (e *SchedulerEngine) scheduleTask(
 	schedule *schedulingDomain.Schedule,
 	task SchedulableTask,
 	workStart, workEnd time.Time,
+	explain bool,
 ) ScheduleResult {
+	var rationale *SlotRationale
+	if explain {
+		rationale = &SlotRationale{Factors: e.rationaleFactors(task, workStart)}
+	}
+
+	searchStart := startWindow(task, workStart)
+	if !searchStart.Before(workEnd) {
+		if rationale != nil {
+			rationale.Rule = "earliest start is after working hours"
+		}
+		return ScheduleResult{
+			TaskID:    task.ID,
+			Scheduled: false,
+			Reason:    "earliest start is after working hours",
+			Rationale: rationale,
+		}
+	}
+
 	// Find available slots
-	slots := schedule.FindAvailableSlots(workStart, workEnd, task.Duration+e.config.MinBreakBetween)
+	slots := availableSlotsFrom(schedule, searchStart, workEnd, task.Duration+e.config.MinBreakBetween)
+	if rationale != nil {
+		rationale.Alternatives = e.tooShortSlots(schedule, task, searchStart, workEnd)
+	}
 
 	if len(slots) == 0 {
+		if rationale != nil {
+			rationale.Rule = "no free slot fits"
+		}
 		return ScheduleResult{
 			TaskID:    task.ID,
 			Scheduled: false,
 			Reason:    "no available time slots",
+			Rationale: rationale,
 		}
 	}
 
 	// Choose the best slot based on task priority
-	slot := e.chooseBestSlot(slots, task, workStart, workEnd)
+	slot, rule := e.chooseBestSlot(slots, task, workStart, workEnd)
+	if rationale != nil {
+		chosen := slot
+		rationale.Chosen = &chosen
+		rationale.Rule = rule.String()
+		rationale.Alternatives = append(e.rejectedSlots(slots, slot, rule, workStart, workEnd), rationale.Alternatives...)
+	}
 
 	// Add the block to the schedule
 	startTime := slot.Start
@@ -221,23 +325,22 @@ func (e *SchedulerEngine) scheduleTask(
 	}
 	endTime := startTime.Add(task.Duration)
 
-	blockType := task.BlockType
-	if blockType == "" {
-		blockType = schedulingDomain.BlockTypeTask
-	}
-
 	block, err := schedule.AddBlock(
-		blockType,
+		blockTypeOf(task),
 		task.ID,
 		task.Title,
 		startTime,
 		endTime,
 	)
 	if err != nil {
+		if rationale != nil {
+			rationale.Chosen = nil
+		}
 		return ScheduleResult{
 			TaskID:    task.ID,
 			Scheduled: false,
 			Reason:    err.Error(),
+			Rationale: rationale,
 		}
 	}
 
@@ -247,9 +350,43 @@ func (e *SchedulerEngine) scheduleTask(
 		StartTime: startTime,
 		EndTime:   endTime,
 		Scheduled: true,
+		Rationale: rationale,
 	}
 }
 
+// startWindow returns when the search for a slot for task begins: the start
+// of working hours, or the task's earliest start if that is later.
+func startWindow(task SchedulableTask, workStart time.Time) time.Time {
+	if task.EarliestStart != nil && task.EarliestStart.After(workStart) {
+		return *task.EarliestStart
+	}
+	return workStart
+}
+
+// availableSlotsFrom finds free slots of at least minDuration between start
+// and end. Gaps that open before start are cut to begin at start.
+func availableSlotsFrom(schedule *schedulingDomain.Schedule, start, end time.Time, minDuration time.Duration) []schedulingDomain.TimeSlot {
+	slots := schedule.FindAvailableSlots(start, end, minDuration)
+	clipped := slots[:0]
+	for _, slot := range slots {
+		if slot.Start.Before(start) {
+			slot.Start = start
+		}
+		if slot.Duration() >= minDuration {
+			clipped = append(clipped, slot)
+		}
+	}
+	return clipped
+}
+
+// blockTypeOf returns the block type a task is scheduled as.
+func blockTypeOf(task SchedulableTask) schedulingDomain.BlockType {
+	if task.BlockType == "" {
+		return schedulingDomain.BlockTypeTask
+	}
+	return task.BlockType
+}
+
 // sortTasks sorts tasks by priority and due date.
 func (e *SchedulerEngine) sortTasks(tasks []SchedulableTask) []SchedulableTask {
 	sorted := make([]SchedulableTask, len(tasks))
@@ -279,42 +416,130 @@ func (e *SchedulerEngine) sortTasks(tasks []SchedulableTask) []SchedulableTask {
 	return sorted
 }
 
-// chooseBestSlot selects the optimal slot for a task.
+// chooseBestSlot selects the optimal slot for a task and reports which rule
+// picked it.
 func (e *SchedulerEngine) chooseBestSlot(
 	slots []schedulingDomain.TimeSlot,
 	task SchedulableTask,
 	workStart, workEnd time.Time,
-) schedulingDomain.TimeSlot {
+) (schedulingDomain.TimeSlot, slotRule) {
 	if len(slots) == 1 {
-		return slots[0]
+		return slots[0], slotRuleOnly
 	}
 
 	// For high-priority tasks, prefer morning if configured
-	if e.config.PreferMorning && task.Priority <= 2 {
+	if e.prefersMorning(task) {
 		midday := workStart.Add((workEnd.Sub(workStart)) / 2)
 		for _, slot := range slots {
 			if slot.Start.Before(midday) {
-				return slot
+				return slot, slotRuleMorning
 			}
 		}
 	}
 
 	// For tasks with due dates on the same day, prefer later slots (procrastination buffer)
-	if task.DueDate != nil {
-		date := workStart
-		dueDate := *task.DueDate
-		if dueDate.Year() == date.Year() && dueDate.Month() == date.Month() && dueDate.Day() == date.Day() {
-			// Return the last slot that fits
-			for i := len(slots) - 1; i >= 0; i-- {
-				if slots[i].End.Sub(slots[i].Start) >= task.Duration {
-					return slots[i]
-				}
+	if isDueOn(task, workStart) {
+		// Return the last slot that fits
+		for i := len(slots) - 1; i >= 0; i-- {
+			if slots[i].End.Sub(slots[i].Start) >= task.Duration {
+				return slots[i], slotRuleDueToday
 			}
 		}
 	}
 
 	// Default: return the first slot
-	return slots[0]
+	return slots[0], slotRuleFirst
+}
+
+// prefersMorning reports whether the task should go in the morning.
+func (e *SchedulerEngine) prefersMorning(task SchedulableTask) bool {
+	return e.config.PreferMorning && task.Priority <= 2
+}
+
+// isDueOn reports whether the task is due on the same day as date.
+func isDueOn(task SchedulableTask, date time.Time) bool {
+	if task.DueDate == nil {
+		return false
+	}
+	dueDate := *task.DueDate
+	return dueDate.Year() == date.Year() && dueDate.Month() == date.Month() && dueDate.Day() == date.Day()
+}
+
+// rationaleFactors lists the task properties that influence slot choice.
+func (e *SchedulerEngine) rationaleFactors(task SchedulableTask, workStart time.Time) []string {
+	factors := []string{
+		fmt.Sprintf("priority %d", task.Priority),
+		fmt.Sprintf("duration %s", task.Duration),
+	}
+	if task.DueDate != nil {
+		if isDueOn(task, workStart) {
+			factors = append(factors, "due today")
+		} else {
+			factors = append(factors, fmt.Sprintf("due %s", task.DueDate.Format("2006-01-02")))
+		}
+	}
+	if task.EarliestStart != nil && task.EarliestStart.After(workStart) {
+		factors = append(factors, fmt.Sprintf("not before %s", task.EarliestStart.In(workStart.Location()).Format("2006-01-02 15:04")))
+	}
+	if e.prefersMorning(task) {
+		factors = append(factors, "high priority prefers the morning")
+	}
+	if e.config.MinBreakBetween > 0 {
+		factors = append(factors, fmt.Sprintf("%s break between blocks", e.config.MinBreakBetween))
+	}
+	return factors
+}
+
+// rejectedSlots explains why each fitting slot other than chosen was passed over.
+func (e *SchedulerEngine) rejectedSlots(
+	slots []schedulingDomain.TimeSlot,
+	chosen schedulingDomain.TimeSlot,
+	rule slotRule,
+	workStart, workEnd time.Time,
+) []RejectedSlot {
+	midday := workStart.Add((workEnd.Sub(workStart)) / 2)
+	rejected := make([]RejectedSlot, 0, len(slots))
+	for _, slot := range slots {
+		if slot.Start.Equal(chosen.Start) {
+			continue
+		}
+
+		var reason string
+		switch rule {
+		case slotRuleMorning:
+			if slot.Start.Before(midday) {
+				reason = "a later morning slot; the earliest one is used"
+			} else {
+				reason = "starts after midday; high-priority tasks go in the morning"
+			}
+		case slotRuleDueToday:
+			reason = "earlier than the latest slot; tasks due today are placed as late as possible"
+		default:
+			reason = "later than the earliest slot that fits"
+		}
+		rejected = append(rejected, RejectedSlot{Slot: slot, Reason: reason})
+	}
+	return rejected
+}
+
+// tooShortSlots lists free gaps in working hours that cannot hold the task.
+func (e *SchedulerEngine) tooShortSlots(
+	schedule *schedulingDomain.Schedule,
+	task SchedulableTask,
+	workStart, workEnd time.Time,
+) []RejectedSlot {
+	needed := task.Duration + e.config.MinBreakBetween
+	var rejected []RejectedSlot
+	for _, gap := range availableSlotsFrom(schedule, workStart, workEnd, time.Minute) {
+		if gap.Duration() >= needed {
+			continue
+		}
+		rejected = append(rejected, RejectedSlot{
+			Slot:   gap,
+			Reason: fmt.Sprintf("only %s free, needs %s", gap.Duration(), needed),
+		})
+	}
+	return rejected
 }
 
 // findClosestSlot finds the slot closest to the preferred time.
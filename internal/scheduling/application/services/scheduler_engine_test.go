@@ -803,3 +803,152 @@ func TestSchedulerEngine_ChooseBestSlot_DefaultFirstSlot(t *testing.T) {
 	assert.Equal(t, workStart, scheduledBlock.StartTime(),
 		"Task should be scheduled in first available slot by default")
 }
+
+func TestSchedulerEngine_ScheduleTasksWithRationale(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	// Leaves 09:00-09:30 (too short), 10:00-11:00 and 14:00-17:00 free.
+	newSchedule := func(t *testing.T) *schedulingDomain.Schedule {
+		schedule := schedulingDomain.NewSchedule(uuid.New(), day)
+		_, err := schedule.AddBlock(schedulingDomain.BlockTypeMeeting, uuid.New(), "Standup", day.Add(9*time.Hour+30*time.Minute), day.Add(10*time.Hour))
+		require.NoError(t, err)
+		_, err = schedule.AddBlock(schedulingDomain.BlockTypeMeeting, uuid.New(), "Workshop", day.Add(11*time.Hour), day.Add(14*time.Hour))
+		require.NoError(t, err)
+		return schedule
+	}
+
+	t.Run("explains the chosen slot and rejected alternatives", func(t *testing.T) {
+		engine := NewSchedulerEngine(DefaultSchedulerConfig())
+		task := SchedulableTask{ID: uuid.New(), Title: "Write report", Priority: 3, Duration: 45 * time.Minute}
+
+		results, err := engine.ScheduleTasksWithRationale(ctx, newSchedule(t), []SchedulableTask{task})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.True(t, results[0].Scheduled)
+
+		rationale := results[0].Rationale
+		require.NotNil(t, rationale)
+		require.NotNil(t, rationale.Chosen)
+		assert.Equal(t, day.Add(10*time.Hour), rationale.Chosen.Start)
+		assert.Equal(t, "earliest slot that fits", rationale.Rule)
+		assert.Contains(t, rationale.Factors, "priority 3")
+		assert.Contains(t, rationale.Factors, "duration 45m0s")
+
+		require.Len(t, rationale.Alternatives, 2)
+		assert.Equal(t, day.Add(14*time.Hour), rationale.Alternatives[0].Slot.Start)
+		assert.Equal(t, "later than the earliest slot that fits", rationale.Alternatives[0].Reason)
+		assert.Equal(t, day.Add(9*time.Hour), rationale.Alternatives[1].Slot.Start)
+		assert.Equal(t, "only 30m0s free, needs 50m0s", rationale.Alternatives[1].Reason)
+	})
+
+	t.Run("explains the latest slot for tasks due today", func(t *testing.T) {
+		engine := NewSchedulerEngine(DefaultSchedulerConfig())
+		due := day.Add(17 * time.Hour)
+		task := SchedulableTask{ID: uuid.New(), Title: "Submit", Priority: 3, Duration: 45 * time.Minute, DueDate: &due}
+
+		results, err := engine.ScheduleTasksWithRationale(ctx, newSchedule(t), []SchedulableTask{task})
+		require.NoError(t, err)
+
+		rationale := results[0].Rationale
+		require.NotNil(t, rationale)
+		require.NotNil(t, rationale.Chosen)
+		assert.Equal(t, day.Add(14*time.Hour), rationale.Chosen.Start)
+		assert.Contains(t, rationale.Factors, "due today")
+		require.NotEmpty(t, rationale.Alternatives)
+		assert.Equal(t, day.Add(10*time.Hour), rationale.Alternatives[0].Slot.Start)
+		assert.Contains(t, rationale.Alternatives[0].Reason, "due today")
+	})
+
+	t.Run("explains morning preference for high priority tasks", func(t *testing.T) {
+		engine := NewSchedulerEngine(DefaultSchedulerConfig())
+		task := SchedulableTask{ID: uuid.New(), Title: "Fix outage", Priority: 1, Duration: 45 * time.Minute}
+
+		results, err := engine.ScheduleTasksWithRationale(ctx, newSchedule(t), []SchedulableTask{task})
+		require.NoError(t, err)
+
+		rationale := results[0].Rationale
+		require.NotNil(t, rationale)
+		assert.Equal(t, "earliest morning slot for a high-priority task", rationale.Rule)
+		assert.Contains(t, rationale.Factors, "high priority prefers the morning")
+		assert.Equal(t, "starts after midday; high-priority tasks go in the morning", rationale.Alternatives[0].Reason)
+	})
+
+	t.Run("explains why a task could not be placed", func(t *testing.T) {
+		engine := NewSchedulerEngine(DefaultSchedulerConfig())
+		task := SchedulableTask{ID: uuid.New(), Title: "Deep work", Priority: 3, Duration: 4 * time.Hour}
+
+		results, err := engine.ScheduleTasksWithRationale(ctx, newSchedule(t), []SchedulableTask{task})
+		require.NoError(t, err)
+		assert.False(t, results[0].Scheduled)
+
+		rationale := results[0].Rationale
+		require.NotNil(t, rationale)
+		assert.Nil(t, rationale.Chosen)
+		assert.Equal(t, "no free slot fits", rationale.Rule)
+		assert.Len(t, rationale.Alternatives, 3)
+	})
+
+	t.Run("omits the rationale by default", func(t *testing.T) {
+		engine := NewSchedulerEngine(DefaultSchedulerConfig())
+		task := SchedulableTask{ID: uuid.New(), Title: "Write report", Priority: 3, Duration: 45 * time.Minute}
+
+		results, err := engine.ScheduleTasks(ctx, newSchedule(t), []SchedulableTask{task})
+		require.NoError(t, err)
+		assert.Nil(t, results[0].Rationale)
+	})
+}
+
+func TestSchedulerEngine_EarliestStart(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	t.Run("never places a task before its earliest start", func(t *testing.T) {
+		schedule := schedulingDomain.NewSchedule(uuid.New(), day)
+		_, err := schedule.AddBlock(schedulingDomain.BlockTypeMeeting, uuid.New(), "Standup", day.Add(9*time.Hour), day.Add(9*time.Hour+30*time.Minute))
+		require.NoError(t, err)
+
+		earliestStart := day.Add(13 * time.Hour)
+		task := SchedulableTask{ID: uuid.New(), Title: "Publish notes", Priority: 1, Duration: time.Hour, EarliestStart: &earliestStart}
+		free := SchedulableTask{ID: uuid.New(), Title: "Inbox zero", Priority: 3, Duration: 30 * time.Minute}
+
+		results, err := NewSchedulerEngine(DefaultSchedulerConfig()).ScheduleTasksWithRationale(ctx, schedule, []SchedulableTask{task, free})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		assert.Equal(t, task.ID, results[0].TaskID)
+		require.True(t, results[0].Scheduled)
+		assert.False(t, results[0].StartTime.Before(earliestStart), "starts at %s", results[0].StartTime)
+		assert.Contains(t, results[0].Rationale.Factors, "not before 2024-03-04 13:00")
+
+		require.True(t, results[1].Scheduled)
+		assert.True(t, results[1].StartTime.Before(earliestStart), "other tasks still use the morning")
+	})
+
+	t.Run("leaves the task unscheduled when it starts after working hours", func(t *testing.T) {
+		schedule := schedulingDomain.NewSchedule(uuid.New(), day)
+		earliestStart := day.AddDate(0, 0, 1).Add(9 * time.Hour)
+		task := SchedulableTask{ID: uuid.New(), Title: "Tomorrow's work", Priority: 1, Duration: time.Hour, EarliestStart: &earliestStart}
+
+		result, err := NewSchedulerEngine(DefaultSchedulerConfig()).ScheduleSingleTask(ctx, schedule, task)
+		require.NoError(t, err)
+		assert.False(t, result.Scheduled)
+		assert.Equal(t, "earliest start is after working hours", result.Reason)
+		assert.Empty(t, schedule.Blocks())
+	})
+
+	t.Run("does not fit into the part of a gap before the earliest start", func(t *testing.T) {
+		schedule := schedulingDomain.NewSchedule(uuid.New(), day)
+		_, err := schedule.AddBlock(schedulingDomain.BlockTypeMeeting, uuid.New(), "Workshop", day.Add(12*time.Hour), day.Add(17*time.Hour))
+		require.NoError(t, err)
+
+		// 9:00-12:00 is free, but only 11:30-12:00 is after the earliest start.
+		earliestStart := day.Add(11*time.Hour + 30*time.Minute)
+		task := SchedulableTask{ID: uuid.New(), Title: "Deep work", Priority: 2, Duration: time.Hour, EarliestStart: &earliestStart}
+
+		result, err := NewSchedulerEngine(DefaultSchedulerConfig()).ScheduleSingleTask(ctx, schedule, task)
+		require.NoError(t, err)
+		assert.False(t, result.Scheduled)
+		assert.Len(t, schedule.Blocks(), 1)
+	})
+}
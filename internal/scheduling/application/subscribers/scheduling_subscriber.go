@@ -139,6 +139,8 @@ func (s *SchedulingSubscriber) handleTaskCreated(ctx context.Context, event *eve
 	scheduleDate := time.Now()
 	if task.DueDate() != nil {
 		scheduleDate = *task.DueDate()
+	} else if start := task.EarliestStart(); start != nil && start.After(scheduleDate) {
+		scheduleDate = *start
 	}
 
 	// Get duration
@@ -152,9 +154,11 @@ func (s *SchedulingSubscriber) handleTaskCreated(ctx context.Context, event *eve
 		ID:       task.ID(),
 		Type:     "task",
 		Title:    task.Title(),
-		Priority: priorityToInt(task.Priority().String()),
+		Priority: task.Priority().EngineLevel(),
 		Duration: duration,
 		DueDate:  task.DueDate(),
+
+		EarliestStart: task.EarliestStart(),
 	}
 
 	// Auto-schedule
@@ -315,19 +319,3 @@ func (s *SchedulingSubscriber) handleMeetingCreated(ctx context.Context, event *
 
 	return nil
 }
-
-// priorityToInt converts a priority string to an integer for sorting.
-func priorityToInt(priority string) int {
-	switch priority {
-	case "urgent":
-		return 0
-	case "high":
-		return 1
-	case "medium":
-		return 2
-	case "low":
-		return 3
-	default:
-		return 2
-	}
-}
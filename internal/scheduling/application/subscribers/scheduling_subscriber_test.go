@@ -76,6 +76,9 @@ func (m *mockMeetingRepo) FindByUserID(ctx context.Context, userID uuid.UUID) ([
 func (m *mockMeetingRepo) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*meetingDomain.Meeting, error) {
 	return nil, nil
 }
+func (m *mockMeetingRepo) FindByExternalSeriesID(ctx context.Context, userID uuid.UUID, seriesID string) (*meetingDomain.Meeting, error) {
+	return nil, nil
+}
 
 type mockScheduleRepo struct {
 	schedule *schedulingDomain.Schedule
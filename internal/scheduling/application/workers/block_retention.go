@@ -0,0 +1,161 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+)
+
+// DefaultRetentionInterval is the default interval between retention cycles.
+const DefaultRetentionInterval = time.Hour
+
+// DefaultRetentionBatchSize is the maximum number of blocks removed per query.
+const DefaultRetentionBatchSize = 500
+
+// DefaultRetentionPeriod is how long finished blocks stay in schedules by default.
+const DefaultRetentionPeriod = 90 * 24 * time.Hour
+
+// BlockRetentionConfig configures the block retention sweeper.
+type BlockRetentionConfig struct {
+	Interval  time.Duration
+	BatchSize int
+	// RetainFor is how long a completed or missed block stays in its
+	// schedule after it ended.
+	RetainFor time.Duration
+	// Action is applied to blocks older than RetainFor.
+	Action domain.BlockRetentionAction
+}
+
+// DefaultBlockRetentionConfig returns the default configuration.
+func DefaultBlockRetentionConfig() BlockRetentionConfig {
+	return BlockRetentionConfig{
+		Interval:  DefaultRetentionInterval,
+		BatchSize: DefaultRetentionBatchSize,
+		RetainFor: DefaultRetentionPeriod,
+		Action:    domain.BlockRetentionArchive,
+	}
+}
+
+// BlockRetentionSweeper periodically archives or deletes completed and missed
+// blocks that ended longer ago than the retention period, so schedules only
+// carry recent blocks. Blocks that are neither completed nor missed are kept.
+type BlockRetentionSweeper struct {
+	repo    domain.BlockRetentionRepository
+	config  BlockRetentionConfig
+	logger  *slog.Logger
+	running atomic.Bool
+	stopCh  chan struct{}
+}
+
+// NewBlockRetentionSweeper creates a new block retention sweeper.
+func NewBlockRetentionSweeper(repo domain.BlockRetentionRepository, config BlockRetentionConfig, logger *slog.Logger) *BlockRetentionSweeper {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultRetentionInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultRetentionBatchSize
+	}
+	if config.RetainFor <= 0 {
+		config.RetainFor = DefaultRetentionPeriod
+	}
+	if config.Action == "" {
+		config.Action = domain.BlockRetentionArchive
+	}
+	return &BlockRetentionSweeper{
+		repo:   repo,
+		config: config,
+		logger: logger,
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Run starts the sweeper and blocks until context is cancelled or Stop() is called.
+func (s *BlockRetentionSweeper) Run(ctx context.Context) error {
+	s.running.Store(true)
+	s.logger.Info("block retention sweeper started",
+		"interval", s.config.Interval,
+		"retain_for", s.config.RetainFor,
+		"action", s.config.Action,
+	)
+
+	s.runCycle(ctx)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.running.Store(false)
+			s.logger.Info("block retention sweeper stopped (context cancelled)")
+			return ctx.Err()
+		case <-s.stopCh:
+			s.running.Store(false)
+			s.logger.Info("block retention sweeper stopped (stop signal)")
+			return nil
+		case <-ticker.C:
+			s.runCycle(ctx)
+		}
+	}
+}
+
+// Stop signals the sweeper to stop gracefully.
+func (s *BlockRetentionSweeper) Stop() {
+	if s.running.Load() {
+		close(s.stopCh)
+	}
+}
+
+// IsRunning returns true if the sweeper is currently running.
+func (s *BlockRetentionSweeper) IsRunning() bool {
+	return s.running.Load()
+}
+
+func (s *BlockRetentionSweeper) runCycle(ctx context.Context) {
+	removed, err := s.Sweep(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("failed to apply block retention", "error", err)
+		return
+	}
+	if removed > 0 {
+		s.logger.Info("finished blocks removed from schedules", "count", removed, "action", s.config.Action)
+	}
+}
+
+// Sweep applies the retention action to every completed or missed block that
+// ended at or before now minus the retention period and returns the number of
+// blocks removed from schedules.
+func (s *BlockRetentionSweeper) Sweep(ctx context.Context, now time.Time) (int, error) {
+	cutoff := now.Add(-s.config.RetainFor)
+
+	removed := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return removed, err
+		}
+
+		batch, err := s.removeBatch(ctx, cutoff)
+		removed += batch
+		if err != nil {
+			return removed, err
+		}
+
+		// A short batch means nothing is left.
+		if batch < s.config.BatchSize {
+			return removed, nil
+		}
+	}
+}
+
+func (s *BlockRetentionSweeper) removeBatch(ctx context.Context, cutoff time.Time) (int, error) {
+	if s.config.Action == domain.BlockRetentionDelete {
+		return s.repo.DeleteFinishedBlocks(ctx, cutoff, s.config.BatchSize)
+	}
+	return s.repo.ArchiveFinishedBlocks(ctx, cutoff, s.config.BatchSize)
+}
@@ -0,0 +1,127 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type storedBlock struct {
+	id       uuid.UUID
+	end      time.Time
+	finished bool
+}
+
+// stubRetentionRepo removes blocks the way the database queries do.
+type stubRetentionRepo struct {
+	blocks   []storedBlock
+	archived []uuid.UUID
+	deleted  []uuid.UUID
+	err      error
+}
+
+func (s *stubRetentionRepo) ArchiveFinishedBlocks(ctx context.Context, before time.Time, limit int) (int, error) {
+	ids, err := s.remove(before, limit)
+	s.archived = append(s.archived, ids...)
+	return len(ids), err
+}
+
+func (s *stubRetentionRepo) DeleteFinishedBlocks(ctx context.Context, before time.Time, limit int) (int, error) {
+	ids, err := s.remove(before, limit)
+	s.deleted = append(s.deleted, ids...)
+	return len(ids), err
+}
+
+func (s *stubRetentionRepo) remove(before time.Time, limit int) ([]uuid.UUID, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	sort.Slice(s.blocks, func(i, j int) bool { return s.blocks[i].end.Before(s.blocks[j].end) })
+
+	var removed []uuid.UUID
+	kept := s.blocks[:0]
+	for _, b := range s.blocks {
+		if b.finished && !b.end.After(before) && len(removed) < limit {
+			removed = append(removed, b.id)
+			continue
+		}
+		kept = append(kept, b)
+	}
+	s.blocks = kept
+	return removed, nil
+}
+
+func TestBlockRetentionSweeper_Sweep(t *testing.T) {
+	now := time.Date(2024, time.June, 30, 12, 0, 0, 0, time.UTC)
+	config := DefaultBlockRetentionConfig()
+	config.RetainFor = 30 * 24 * time.Hour
+
+	block := func(end time.Time, finished bool) storedBlock {
+		return storedBlock{id: uuid.New(), end: end, finished: finished}
+	}
+
+	t.Run("archives finished blocks older than the retention period", func(t *testing.T) {
+		old := block(now.AddDate(0, 0, -45), true)
+		boundary := block(now.AddDate(0, 0, -30), true)
+		recent := block(now.AddDate(0, 0, -29), true)
+		unfinished := block(now.AddDate(0, 0, -60), false)
+
+		repo := &stubRetentionRepo{blocks: []storedBlock{old, boundary, recent, unfinished}}
+		sweeper := NewBlockRetentionSweeper(repo, config, nil)
+
+		removed, err := sweeper.Sweep(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, removed)
+		assert.Equal(t, []uuid.UUID{old.id, boundary.id}, repo.archived)
+		assert.Empty(t, repo.deleted)
+		assert.Len(t, repo.blocks, 2)
+
+		// A day later the recent block crosses the threshold too.
+		removed, err = sweeper.Sweep(context.Background(), now.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.Equal(t, recent.id, repo.archived[2])
+	})
+
+	t.Run("deletes when configured to", func(t *testing.T) {
+		old := block(now.AddDate(0, 0, -45), true)
+		repo := &stubRetentionRepo{blocks: []storedBlock{old}}
+		deleting := config
+		deleting.Action = domain.BlockRetentionDelete
+
+		removed, err := NewBlockRetentionSweeper(repo, deleting, nil).Sweep(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.Equal(t, []uuid.UUID{old.id}, repo.deleted)
+		assert.Empty(t, repo.archived)
+	})
+
+	t.Run("works through more blocks than one batch", func(t *testing.T) {
+		var blocks []storedBlock
+		for i := 0; i < 5; i++ {
+			blocks = append(blocks, block(now.AddDate(0, -3, i), true))
+		}
+		repo := &stubRetentionRepo{blocks: blocks}
+		batched := config
+		batched.BatchSize = 2
+
+		removed, err := NewBlockRetentionSweeper(repo, batched, nil).Sweep(context.Background(), now)
+		require.NoError(t, err)
+		assert.Equal(t, 5, removed)
+		assert.Empty(t, repo.blocks)
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		repo := &stubRetentionRepo{err: errors.New("db down")}
+
+		_, err := NewBlockRetentionSweeper(repo, config, nil).Sweep(context.Background(), now)
+		assert.EqualError(t, err, "db down")
+	})
+}
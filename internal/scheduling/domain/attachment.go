@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrAttachmentTargetRequired = errors.New("attachment link or path is required")
+	ErrAttachmentExists         = errors.New("attachment already added to block")
+)
+
+// BlockAttachment references material for a block, such as a document for a
+// focus session. Target is a URL or a file path.
+type BlockAttachment struct {
+	Target string
+	// Label is an optional display name; the target is shown when empty.
+	Label string
+}
+
+// DisplayName returns the label, or the target when there is none.
+func (a BlockAttachment) DisplayName() string {
+	if a.Label != "" {
+		return a.Label
+	}
+	return a.Target
+}
+
+// Attachments returns the block's attachments in the order they were added.
+func (tb *TimeBlock) Attachments() []BlockAttachment {
+	return append([]BlockAttachment(nil), tb.attachments...)
+}
+
+// AddAttachment attaches a link or file path to the block.
+func (tb *TimeBlock) AddAttachment(target, label string) error {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return ErrAttachmentTargetRequired
+	}
+	for _, existing := range tb.attachments {
+		if existing.Target == target {
+			return ErrAttachmentExists
+		}
+	}
+
+	tb.attachments = append(tb.attachments, BlockAttachment{
+		Target: target,
+		Label:  strings.TrimSpace(label),
+	})
+	tb.Touch()
+	return nil
+}
+
+// RehydrateAttachments restores attachments loaded from storage.
+func (tb *TimeBlock) RehydrateAttachments(attachments []BlockAttachment) {
+	tb.attachments = attachments
+}
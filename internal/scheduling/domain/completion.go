@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Outcome ratings run from 1 (went badly) to 5 (went very well).
+const (
+	MinOutcomeRating = 1
+	MaxOutcomeRating = 5
+)
+
+var ErrInvalidOutcomeRating = errors.New("outcome rating must be between 1 and 5")
+
+// BlockCompletion is the reflection captured when a block is completed.
+// Both fields are optional; a zero Rating means the block was not rated.
+type BlockCompletion struct {
+	Notes  string
+	Rating int
+}
+
+// IsZero returns true if no notes or rating were captured.
+func (c BlockCompletion) IsZero() bool {
+	return c.Notes == "" && c.Rating == 0
+}
+
+// Validate checks that the rating, when given, is within range.
+func (c BlockCompletion) Validate() error {
+	if c.Rating != 0 && (c.Rating < MinOutcomeRating || c.Rating > MaxOutcomeRating) {
+		return ErrInvalidOutcomeRating
+	}
+	return nil
+}
+
+// Completion returns the reflection captured when the block was completed.
+func (tb *TimeBlock) Completion() BlockCompletion {
+	return tb.completion
+}
+
+// CompleteWith marks the block as completed and records the reflection.
+func (tb *TimeBlock) CompleteWith(completion BlockCompletion) error {
+	completion.Notes = strings.TrimSpace(completion.Notes)
+	if err := completion.Validate(); err != nil {
+		return err
+	}
+
+	tb.completion = completion
+	tb.MarkCompleted()
+	return nil
+}
+
+// RehydrateCompletion restores the completion reflection loaded from storage.
+func (tb *TimeBlock) RehydrateCompletion(completion BlockCompletion) {
+	tb.completion = completion
+}
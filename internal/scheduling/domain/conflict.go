@@ -67,6 +67,10 @@ type Conflict struct {
 	resolution      ConflictResolution
 	resolvedAt      *time.Time
 	createdAt       time.Time
+
+	// externalCalendarID is the calendar the external event came from,
+	// empty when unknown.
+	externalCalendarID string
 }
 
 // TimeRange represents a time period with start and end.
@@ -142,6 +146,17 @@ func (c *Conflict) ExternalTime() TimeRange {
 	return c.externalTime
 }
 
+// ExternalCalendarID returns the calendar the external event came from, or
+// an empty string when the source calendar is unknown.
+func (c *Conflict) ExternalCalendarID() string {
+	return c.externalCalendarID
+}
+
+// SetExternalCalendarID records the calendar the external event came from.
+func (c *Conflict) SetExternalCalendarID(calendarID string) {
+	c.externalCalendarID = calendarID
+}
+
 // Resolution returns the current resolution status.
 func (c *Conflict) Resolution() ConflictResolution {
 	return c.resolution
@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSelfDependency     = errors.New("a block cannot depend on itself")
+	ErrDependencyCycle    = errors.New("block dependency would create a cycle")
+	ErrDependencyExists   = errors.New("block dependency already exists")
+	ErrDependencyNotFound = errors.New("block dependency not found")
+	ErrDependencyOrder    = errors.New("block would start before a block it depends on ends")
+)
+
+// BlockDependency requires the Before block to end before the After block starts.
+type BlockDependency struct {
+	BeforeID uuid.UUID
+	AfterID  uuid.UUID
+}
+
+// Dependencies returns the ordering constraints between the schedule's blocks.
+func (s *Schedule) Dependencies() []BlockDependency {
+	return append([]BlockDependency(nil), s.dependencies...)
+}
+
+// AddDependency requires the before block to finish before the after block
+// starts. It does not move any block; call EnforceDependencies to bring the
+// schedule in line.
+func (s *Schedule) AddDependency(beforeID, afterID uuid.UUID) error {
+	if beforeID == afterID {
+		return ErrSelfDependency
+	}
+	if _, err := s.FindBlock(beforeID); err != nil {
+		return err
+	}
+	if _, err := s.FindBlock(afterID); err != nil {
+		return err
+	}
+	for _, dep := range s.dependencies {
+		if dep.BeforeID == beforeID && dep.AfterID == afterID {
+			return ErrDependencyExists
+		}
+	}
+	if s.dependsOn(beforeID, afterID) {
+		return ErrDependencyCycle
+	}
+
+	s.dependencies = append(s.dependencies, BlockDependency{BeforeID: beforeID, AfterID: afterID})
+	s.Touch()
+	return nil
+}
+
+// RemoveDependency drops an ordering constraint.
+func (s *Schedule) RemoveDependency(beforeID, afterID uuid.UUID) error {
+	for i, dep := range s.dependencies {
+		if dep.BeforeID == beforeID && dep.AfterID == afterID {
+			s.dependencies = append(s.dependencies[:i], s.dependencies[i+1:]...)
+			s.Touch()
+			return nil
+		}
+	}
+	return ErrDependencyNotFound
+}
+
+// EarliestStart returns the time the block may start at given its
+// predecessors: the latest end among them. It reports false when the block
+// has no predecessors.
+func (s *Schedule) EarliestStart(blockID uuid.UUID) (time.Time, bool) {
+	var earliest time.Time
+	found := false
+	for _, dep := range s.dependencies {
+		if dep.AfterID != blockID {
+			continue
+		}
+		before, err := s.FindBlock(dep.BeforeID)
+		if err != nil {
+			continue
+		}
+		if !found || before.EndTime().After(earliest) {
+			earliest = before.EndTime()
+			found = true
+		}
+	}
+	return earliest, found
+}
+
+// DependencyViolations returns the constraints the schedule currently breaks.
+func (s *Schedule) DependencyViolations() []BlockDependency {
+	var violations []BlockDependency
+	for _, dep := range s.dependencies {
+		before, err := s.FindBlock(dep.BeforeID)
+		if err != nil {
+			continue
+		}
+		after, err := s.FindBlock(dep.AfterID)
+		if err != nil {
+			continue
+		}
+		if after.StartTime().Before(before.EndTime()) {
+			violations = append(violations, dep)
+		}
+	}
+	return violations
+}
+
+// EnforceDependencies moves every block that starts before one of its
+// predecessors ends to the first free time after them, ending by dayEnd.
+// Blocks are handled in dependency order, so chains stay in order. Completed
+// and focus mode blocks are never moved. It returns the constraints that
+// still cannot be met.
+func (s *Schedule) EnforceDependencies(dayEnd time.Time) []BlockDependency {
+	for _, blockID := range s.dependencyOrder() {
+		block, err := s.FindBlock(blockID)
+		if err != nil || block.IsCompleted() || block.BlockType().IsProtected() {
+			continue
+		}
+		earliest, ok := s.EarliestStart(blockID)
+		if !ok || !block.StartTime().Before(earliest) {
+			continue
+		}
+
+		start, ok := s.firstFreeStart(blockID, earliest, block.Duration(), dayEnd)
+		if !ok {
+			continue
+		}
+		_ = s.RescheduleBlock(blockID, start, start.Add(block.Duration()))
+	}
+	return s.DependencyViolations()
+}
+
+// firstFreeStart finds the earliest start at or after from where a block of
+// the given duration fits before dayEnd without overlapping other blocks.
+func (s *Schedule) firstFreeStart(blockID uuid.UUID, from time.Time, duration time.Duration, dayEnd time.Time) (time.Time, bool) {
+	start := from
+	for _, other := range s.blocks {
+		if other.ID() == blockID {
+			continue
+		}
+		if other.StartTime().Before(start.Add(duration)) && other.EndTime().After(start) {
+			start = other.EndTime()
+		}
+	}
+	if start.Add(duration).After(dayEnd) {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+// dependencyOrder returns the blocks that take part in a dependency with
+// every block after all of its predecessors.
+func (s *Schedule) dependencyOrder() []uuid.UUID {
+	incoming := make(map[uuid.UUID]int)
+	for _, dep := range s.dependencies {
+		incoming[dep.AfterID]++
+		if _, ok := incoming[dep.BeforeID]; !ok {
+			incoming[dep.BeforeID] = 0
+		}
+	}
+
+	var queue []uuid.UUID
+	for _, block := range s.blocks {
+		if n, ok := incoming[block.ID()]; ok && n == 0 {
+			queue = append(queue, block.ID())
+		}
+	}
+
+	order := make([]uuid.UUID, 0, len(incoming))
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		order = append(order, id)
+		for _, dep := range s.dependencies {
+			if dep.BeforeID != id {
+				continue
+			}
+			incoming[dep.AfterID]--
+			if incoming[dep.AfterID] == 0 {
+				queue = append(queue, dep.AfterID)
+			}
+		}
+	}
+	return order
+}
+
+// dependsOn reports whether blockID must follow otherID, directly or through
+// a chain of dependencies.
+func (s *Schedule) dependsOn(blockID, otherID uuid.UUID) bool {
+	seen := map[uuid.UUID]bool{}
+	stack := []uuid.UUID{blockID}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dep := range s.dependencies {
+			if dep.AfterID != id || seen[dep.BeforeID] {
+				continue
+			}
+			if dep.BeforeID == otherID {
+				return true
+			}
+			seen[dep.BeforeID] = true
+			stack = append(stack, dep.BeforeID)
+		}
+	}
+	return false
+}
+
+// checkPredecessors returns ErrDependencyOrder if a block starting at start
+// would begin before one of its predecessors ends.
+func (s *Schedule) checkPredecessors(blockID uuid.UUID, start time.Time) error {
+	if earliest, ok := s.EarliestStart(blockID); ok && start.Before(earliest) {
+		return ErrDependencyOrder
+	}
+	return nil
+}
+
+// removeDependenciesOf drops every constraint that involves the block.
+func (s *Schedule) removeDependenciesOf(blockID uuid.UUID) {
+	kept := s.dependencies[:0]
+	for _, dep := range s.dependencies {
+		if dep.BeforeID != blockID && dep.AfterID != blockID {
+			kept = append(kept, dep)
+		}
+	}
+	s.dependencies = kept
+}
+
+// RehydrateDependencies restores persisted ordering constraints. Constraints
+// that refer to missing blocks are dropped.
+func (s *Schedule) RehydrateDependencies(dependencies []BlockDependency) {
+	s.dependencies = s.dependencies[:0]
+	for _, dep := range dependencies {
+		if _, err := s.FindBlock(dep.BeforeID); err != nil {
+			continue
+		}
+		if _, err := s.FindBlock(dep.AfterID); err != nil {
+			continue
+		}
+		s.dependencies = append(s.dependencies, dep)
+	}
+}
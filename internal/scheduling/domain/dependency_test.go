@@ -0,0 +1,86 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Dependencies(t *testing.T) {
+	date := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return date.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	dayEnd := at(18, 0)
+
+	newSchedule := func(t *testing.T) (*domain.Schedule, *domain.TimeBlock, *domain.TimeBlock, *domain.TimeBlock) {
+		t.Helper()
+		schedule := domain.NewSchedule(uuid.New(), date)
+		draft, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Draft", at(9, 0), at(10, 0))
+		require.NoError(t, err)
+		review, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Review", at(10, 30), at(11, 0))
+		require.NoError(t, err)
+		send, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Send", at(11, 0), at(11, 30))
+		require.NoError(t, err)
+		return schedule, draft, review, send
+	}
+
+	t.Run("rejects invalid dependencies", func(t *testing.T) {
+		schedule, draft, review, send := newSchedule(t)
+		require.NoError(t, schedule.AddDependency(draft.ID(), review.ID()))
+		require.NoError(t, schedule.AddDependency(review.ID(), send.ID()))
+
+		assert.ErrorIs(t, schedule.AddDependency(draft.ID(), draft.ID()), domain.ErrSelfDependency)
+		assert.ErrorIs(t, schedule.AddDependency(draft.ID(), review.ID()), domain.ErrDependencyExists)
+		assert.ErrorIs(t, schedule.AddDependency(send.ID(), draft.ID()), domain.ErrDependencyCycle)
+		assert.ErrorIs(t, schedule.AddDependency(draft.ID(), uuid.New()), domain.ErrBlockNotFound)
+		assert.Len(t, schedule.Dependencies(), 2)
+	})
+
+	t.Run("refuses to move a block before its predecessor", func(t *testing.T) {
+		schedule, draft, review, _ := newSchedule(t)
+		require.NoError(t, schedule.AddDependency(draft.ID(), review.ID()))
+
+		err := schedule.RescheduleBlock(review.ID(), at(8, 0), at(8, 30))
+		assert.ErrorIs(t, err, domain.ErrDependencyOrder)
+		assert.Equal(t, at(10, 30), review.StartTime())
+	})
+
+	t.Run("keeps the chain in order after the predecessor moves", func(t *testing.T) {
+		schedule, draft, review, send := newSchedule(t)
+		require.NoError(t, schedule.AddDependency(draft.ID(), review.ID()))
+		require.NoError(t, schedule.AddDependency(review.ID(), send.ID()))
+
+		require.NoError(t, schedule.RescheduleBlock(draft.ID(), at(13, 0), at(14, 0)))
+		assert.Len(t, schedule.DependencyViolations(), 1)
+
+		unmet := schedule.EnforceDependencies(dayEnd)
+		assert.Empty(t, unmet)
+		assert.Equal(t, at(14, 0), review.StartTime())
+		assert.Equal(t, at(14, 30), send.StartTime())
+	})
+
+	t.Run("reports constraints that cannot be met", func(t *testing.T) {
+		schedule, draft, review, _ := newSchedule(t)
+		require.NoError(t, schedule.AddDependency(draft.ID(), review.ID()))
+
+		require.NoError(t, schedule.RescheduleBlock(draft.ID(), at(17, 0), at(18, 0)))
+		unmet := schedule.EnforceDependencies(dayEnd)
+		require.Len(t, unmet, 1)
+		assert.Equal(t, domain.BlockDependency{BeforeID: draft.ID(), AfterID: review.ID()}, unmet[0])
+		assert.Equal(t, at(10, 30), review.StartTime())
+	})
+
+	t.Run("drops dependencies of removed blocks", func(t *testing.T) {
+		schedule, draft, review, _ := newSchedule(t)
+		require.NoError(t, schedule.AddDependency(draft.ID(), review.ID()))
+
+		require.NoError(t, schedule.RemoveBlock(draft.ID()))
+		assert.Empty(t, schedule.Dependencies())
+		assert.ErrorIs(t, schedule.RemoveDependency(draft.ID(), review.ID()), domain.ErrDependencyNotFound)
+	})
+}
@@ -68,15 +68,22 @@ type BlockCompleted struct {
 	BlockID     uuid.UUID `json:"block_id"`
 	BlockType   string    `json:"block_type"`
 	ReferenceID uuid.UUID `json:"reference_id"`
+	// Notes and OutcomeRating carry the optional reflection captured on
+	// completion; OutcomeRating is 0 when the block was not rated.
+	Notes         string `json:"notes,omitempty"`
+	OutcomeRating int    `json:"outcome_rating,omitempty"`
 }
 
 // NewBlockCompleted creates a BlockCompleted event
 func NewBlockCompleted(scheduleID uuid.UUID, block *TimeBlock) BlockCompleted {
+	completion := block.Completion()
 	return BlockCompleted{
-		BaseEvent:   sharedDomain.NewBaseEvent(scheduleID, AggregateType, RoutingKeyBlockCompleted),
-		BlockID:     block.ID(),
-		BlockType:   string(block.BlockType()),
-		ReferenceID: block.ReferenceID(),
+		BaseEvent:     sharedDomain.NewBaseEvent(scheduleID, AggregateType, RoutingKeyBlockCompleted),
+		BlockID:       block.ID(),
+		BlockType:     string(block.BlockType()),
+		ReferenceID:   block.ReferenceID(),
+		Notes:         completion.Notes,
+		OutcomeRating: completion.Rating,
 	}
 }
 
@@ -25,6 +25,17 @@ type ScheduleRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
+// BlockRetentionRepository removes finished blocks from schedules across all
+// users. Both methods act on up to limit completed or missed blocks that ended
+// at or before the given time, oldest first, and return how many they removed.
+type BlockRetentionRepository interface {
+	// ArchiveFinishedBlocks moves the blocks into the block archive, keeping
+	// them for history and insights.
+	ArchiveFinishedBlocks(ctx context.Context, before time.Time, limit int) (int, error)
+	// DeleteFinishedBlocks deletes the blocks.
+	DeleteFinishedBlocks(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
 // RescheduleAttemptRepository defines persistence for reschedule attempts.
 type RescheduleAttemptRepository interface {
 	// Create stores a new reschedule attempt.
@@ -15,6 +15,10 @@ const (
 	RescheduleAttemptManual       RescheduleAttemptType = "manual"
 )
 
+// RescheduleFailureCapReached is the failure reason recorded when a block has
+// been moved too many times and is flagged for review instead.
+const RescheduleFailureCapReached = "reschedule cap reached"
+
 // RescheduleAttempt captures a reschedule outcome for auditing.
 type RescheduleAttempt struct {
 	ID            uuid.UUID
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// BlockRetentionAction is what happens to finished blocks once they are older
+// than the retention period.
+type BlockRetentionAction string
+
+const (
+	// BlockRetentionArchive moves finished blocks into the block archive.
+	BlockRetentionArchive BlockRetentionAction = "archive"
+	// BlockRetentionDelete deletes finished blocks.
+	BlockRetentionDelete BlockRetentionAction = "delete"
+)
+
+// ErrUnknownBlockRetentionAction is returned when a retention action is not
+// recognized.
+var ErrUnknownBlockRetentionAction = errors.New("unknown block retention action")
+
+// ParseBlockRetentionAction parses a retention action. The empty string means
+// archive.
+func ParseBlockRetentionAction(value string) (BlockRetentionAction, error) {
+	switch BlockRetentionAction(strings.ToLower(strings.TrimSpace(value))) {
+	case "", BlockRetentionArchive:
+		return BlockRetentionArchive, nil
+	case BlockRetentionDelete:
+		return BlockRetentionDelete, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownBlockRetentionAction, value)
+	}
+}
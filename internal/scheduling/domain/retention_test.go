@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlockRetentionAction(t *testing.T) {
+	for input, want := range map[string]BlockRetentionAction{
+		"":         BlockRetentionArchive,
+		"archive":  BlockRetentionArchive,
+		" Delete ": BlockRetentionDelete,
+	} {
+		got, err := ParseBlockRetentionAction(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseBlockRetentionAction("purge")
+	assert.ErrorIs(t, err, ErrUnknownBlockRetentionAction)
+}
@@ -12,6 +12,8 @@ import (
 var (
 	ErrBlockNotFound      = errors.New("time block not found")
 	ErrBlockAlreadyExists = errors.New("overlapping block already exists")
+	ErrBlockNotProtected  = errors.New("block type is not a protected focus mode type")
+	ErrWindowProtected    = errors.New("time window is protected by focus mode")
 )
 
 // Schedule represents a user's daily/weekly schedule
@@ -21,6 +23,8 @@ type Schedule struct {
 	date        time.Time // The date this schedule is for
 	blocks      []*TimeBlock
 	constraints *ConstraintSet
+	// dependencies order blocks within the day.
+	dependencies []BlockDependency
 }
 
 // NewSchedule creates a new schedule for a specific date
@@ -81,6 +85,77 @@ func (s *Schedule) AddBlock(
 	return block, nil
 }
 
+// AddProtectedBlock adds a focus mode block over a window. Unlike AddBlock it
+// may overlap ordinary blocks, which the caller is expected to move; it only
+// refuses to overlap another protected block.
+func (s *Schedule) AddProtectedBlock(
+	blockType BlockType,
+	referenceID uuid.UUID,
+	title string,
+	startTime, endTime time.Time,
+) (*TimeBlock, error) {
+	if !blockType.IsProtected() {
+		return nil, ErrBlockNotProtected
+	}
+
+	block, err := NewTimeBlock(s.userID, s.ID(), blockType, referenceID, title, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range s.blocks {
+		if existing.IsProtected() && existing.OverlapsWith(block) {
+			return nil, ErrWindowProtected
+		}
+	}
+
+	s.blocks = append(s.blocks, block)
+	s.sortBlocks()
+	s.Touch()
+
+	s.AddDomainEvent(NewBlockScheduled(s.ID(), block))
+
+	return block, nil
+}
+
+// ProtectedBlockAt returns the protected block in progress at t, or nil.
+func (s *Schedule) ProtectedBlockAt(t time.Time) *TimeBlock {
+	for _, block := range s.blocks {
+		if block.IsProtected() && block.Contains(t) {
+			return block
+		}
+	}
+	return nil
+}
+
+// EndProtectedBlock ends a focus mode block at t, freeing the rest of its
+// window. A block that has run for less than MinBlockDuration is removed;
+// otherwise it is cut short at t and marked completed. It reports whether the
+// block was removed.
+func (s *Schedule) EndProtectedBlock(blockID uuid.UUID, t time.Time) (bool, error) {
+	block, err := s.FindBlock(blockID)
+	if err != nil {
+		return false, err
+	}
+	if !block.BlockType().IsProtected() {
+		return false, ErrBlockNotProtected
+	}
+
+	if t.Sub(block.StartTime()) < MinBlockDuration {
+		return true, s.RemoveBlock(blockID)
+	}
+
+	if t.Before(block.EndTime()) {
+		oldStart, oldEnd := block.StartTime(), block.EndTime()
+		if err := block.Reschedule(oldStart, t); err != nil {
+			return false, err
+		}
+		s.AddDomainEvent(NewBlockRescheduled(s.ID(), blockID, oldStart, oldEnd, oldStart, t))
+	}
+
+	return false, s.CompleteBlock(blockID)
+}
+
 // FindBlock finds a block by ID
 func (s *Schedule) FindBlock(blockID uuid.UUID) (*TimeBlock, error) {
 	for _, block := range s.blocks {
@@ -111,6 +186,10 @@ func (s *Schedule) RescheduleBlock(blockID uuid.UUID, newStart, newEnd time.Time
 		return errors.New("new time violates hard constraints")
 	}
 
+	if err := s.checkPredecessors(blockID, newStart); err != nil {
+		return err
+	}
+
 	// Check for overlaps with other blocks
 	for _, existing := range s.blocks {
 		if existing.ID() != blockID && existing.OverlapsWith(tempBlock) {
@@ -133,12 +212,20 @@ func (s *Schedule) RescheduleBlock(blockID uuid.UUID, newStart, newEnd time.Time
 
 // CompleteBlock marks a block as completed
 func (s *Schedule) CompleteBlock(blockID uuid.UUID) error {
+	return s.CompleteBlockWith(blockID, BlockCompletion{})
+}
+
+// CompleteBlockWith marks a block as completed and records optional notes
+// and an outcome rating.
+func (s *Schedule) CompleteBlockWith(blockID uuid.UUID, completion BlockCompletion) error {
 	block, err := s.FindBlock(blockID)
 	if err != nil {
 		return err
 	}
 
-	block.MarkCompleted()
+	if err := block.CompleteWith(completion); err != nil {
+		return err
+	}
 	s.Touch()
 
 	s.AddDomainEvent(NewBlockCompleted(s.ID(), block))
@@ -166,6 +253,7 @@ func (s *Schedule) RemoveBlock(blockID uuid.UUID) error {
 	for i, block := range s.blocks {
 		if block.ID() == blockID {
 			s.blocks = append(s.blocks[:i], s.blocks[i+1:]...)
+			s.removeDependenciesOf(blockID)
 			s.Touch()
 			return nil
 		}
@@ -173,6 +261,64 @@ func (s *Schedule) RemoveBlock(blockID uuid.UUID) error {
 	return ErrBlockNotFound
 }
 
+// MoveBlockTo moves a block to another day's schedule at a new time. The
+// block keeps its ID, attachments and completion details. Its dependencies
+// are dropped from this schedule; the caller restores the ones that still
+// hold on the target with AddDependency.
+func (s *Schedule) MoveBlockTo(target *Schedule, blockID uuid.UUID, newStart, newEnd time.Time) (*TimeBlock, error) {
+	block, err := s.FindBlock(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldStart := block.StartTime()
+	oldEnd := block.EndTime()
+
+	tempBlock, err := NewTimeBlock(target.userID, target.ID(), block.BlockType(), block.ReferenceID(), block.Title(), newStart, newEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	if !target.constraints.Validate(tempBlock) {
+		return nil, errors.New("block violates hard constraints")
+	}
+
+	for _, existing := range target.blocks {
+		if existing.OverlapsWith(tempBlock) {
+			return nil, ErrBlockAlreadyExists
+		}
+	}
+
+	if err := block.Reschedule(newStart, newEnd); err != nil {
+		return nil, err
+	}
+	block.ClearMissed()
+
+	if err := s.RemoveBlock(blockID); err != nil {
+		return nil, err
+	}
+	block.scheduleID = target.ID()
+	target.blocks = append(target.blocks, block)
+	target.sortBlocks()
+	target.Touch()
+
+	target.AddDomainEvent(NewBlockRescheduled(target.ID(), blockID, oldStart, oldEnd, newStart, newEnd))
+
+	return block, nil
+}
+
+// Draft returns a copy of the schedule for trying out placements. Blocks added
+// to or removed from the draft leave the schedule and its events untouched.
+func (s *Schedule) Draft() *Schedule {
+	return &Schedule{
+		BaseAggregateRoot: sharedDomain.NewBaseAggregateRoot(),
+		userID:            s.userID,
+		date:              s.date,
+		blocks:            append([]*TimeBlock(nil), s.blocks...),
+		constraints:       s.constraints,
+	}
+}
+
 // FindAvailableSlots finds available time slots of at least minDuration
 func (s *Schedule) FindAvailableSlots(dayStart, dayEnd time.Time, minDuration time.Duration) []TimeSlot {
 	slots := make([]TimeSlot, 0)
@@ -189,17 +335,23 @@ func (s *Schedule) FindAvailableSlots(dayStart, dayEnd time.Time, minDuration ti
 		slots = append(slots, TimeSlot{Start: dayStart, End: s.blocks[0].StartTime()})
 	}
 
-	// Check gaps between blocks
+	// Check gaps between blocks. A focus mode block may briefly overlap the
+	// blocks being moved out of it, so gaps start after the latest end so far.
+	lastEnd := s.blocks[0].EndTime()
 	for i := 0; i < len(s.blocks)-1; i++ {
-		gapStart := s.blocks[i].EndTime()
+		if s.blocks[i].EndTime().After(lastEnd) {
+			lastEnd = s.blocks[i].EndTime()
+		}
 		gapEnd := s.blocks[i+1].StartTime()
-		if gapEnd.Sub(gapStart) >= minDuration {
-			slots = append(slots, TimeSlot{Start: gapStart, End: gapEnd})
+		if gapEnd.Sub(lastEnd) >= minDuration {
+			slots = append(slots, TimeSlot{Start: lastEnd, End: gapEnd})
 		}
 	}
 
 	// Check gap after last block
-	lastEnd := s.blocks[len(s.blocks)-1].EndTime()
+	if s.blocks[len(s.blocks)-1].EndTime().After(lastEnd) {
+		lastEnd = s.blocks[len(s.blocks)-1].EndTime()
+	}
 	if dayEnd.Sub(lastEnd) >= minDuration {
 		slots = append(slots, TimeSlot{Start: lastEnd, End: dayEnd})
 	}
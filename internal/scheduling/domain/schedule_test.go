@@ -166,6 +166,27 @@ func TestSchedule_CompleteBlock(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestSchedule_CompleteBlockWith(t *testing.T) {
+	userID := uuid.New()
+	schedule := domain.NewSchedule(userID, time.Now())
+	start := time.Now().Add(time.Hour)
+
+	block, _ := schedule.AddBlock(domain.BlockTypeFocus, uuid.Nil, "Deep work", start, start.Add(time.Hour))
+	schedule.ClearDomainEvents()
+
+	err := schedule.CompleteBlockWith(block.ID(), domain.BlockCompletion{Notes: "Got through the outline", Rating: 5})
+
+	require.NoError(t, err)
+	assert.True(t, block.IsCompleted())
+
+	events := schedule.DomainEvents()
+	require.Len(t, events, 1)
+	event, ok := events[0].(domain.BlockCompleted)
+	require.True(t, ok)
+	assert.Equal(t, "Got through the outline", event.Notes)
+	assert.Equal(t, 5, event.OutcomeRating)
+}
+
 func TestSchedule_MissBlock(t *testing.T) {
 	userID := uuid.New()
 	schedule := domain.NewSchedule(userID, time.Now())
@@ -201,6 +222,74 @@ func TestSchedule_RemoveBlock(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrBlockNotFound)
 }
 
+func TestSchedule_MoveBlockTo(t *testing.T) {
+	userID := uuid.New()
+	day := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+
+	source := domain.NewSchedule(userID, day)
+	block, err := source.AddBlock(domain.BlockTypeTask, uuid.New(), "Write", day.Add(9*time.Hour), day.Add(10*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, block.AddAttachment("~/notes/write.md", ""))
+	block.MarkMissed()
+	target := domain.NewSchedule(userID, next)
+	busy, err := target.AddBlock(domain.BlockTypeMeeting, uuid.New(), "Busy", next.Add(9*time.Hour), next.Add(10*time.Hour))
+	require.NoError(t, err)
+	target.ClearDomainEvents()
+
+	t.Run("rejects an overlap on the target", func(t *testing.T) {
+		_, err := source.MoveBlockTo(target, block.ID(), busy.StartTime(), busy.EndTime())
+		assert.ErrorIs(t, err, domain.ErrBlockAlreadyExists)
+		assert.Len(t, source.Blocks(), 1)
+		assert.Len(t, target.Blocks(), 1)
+	})
+
+	t.Run("moves the block under its ID", func(t *testing.T) {
+		moved, err := source.MoveBlockTo(target, block.ID(), next.Add(11*time.Hour), next.Add(12*time.Hour))
+		require.NoError(t, err)
+		assert.Same(t, block, moved)
+		assert.Equal(t, target.ID(), moved.ScheduleID())
+		assert.Equal(t, next.Add(11*time.Hour), moved.StartTime())
+		assert.False(t, moved.IsMissed())
+		assert.Len(t, moved.Attachments(), 1)
+
+		assert.Empty(t, source.Blocks())
+		found, err := target.FindBlock(block.ID())
+		require.NoError(t, err)
+		assert.Same(t, block, found)
+
+		events := target.DomainEvents()
+		require.Len(t, events, 1)
+		_, ok := events[0].(domain.BlockRescheduled)
+		assert.True(t, ok)
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := source.MoveBlockTo(target, uuid.New(), next.Add(13*time.Hour), next.Add(14*time.Hour))
+		assert.ErrorIs(t, err, domain.ErrBlockNotFound)
+	})
+}
+
+func TestSchedule_Draft(t *testing.T) {
+	schedule := domain.NewSchedule(uuid.New(), time.Now())
+	start := time.Now().Add(time.Hour)
+	existing, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Existing", start, start.Add(30*time.Minute))
+	require.NoError(t, err)
+	schedule.ClearDomainEvents()
+
+	draft := schedule.Draft()
+	_, err = draft.AddBlock(domain.BlockTypeTask, uuid.New(), "Tried", start.Add(time.Hour), start.Add(90*time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, draft.RemoveBlock(existing.ID()))
+
+	assert.Len(t, schedule.Blocks(), 1)
+	assert.Equal(t, existing.ID(), schedule.Blocks()[0].ID())
+	assert.Empty(t, schedule.DomainEvents())
+
+	_, err = draft.AddBlock(domain.BlockTypeTask, uuid.New(), "Overlap", start.Add(time.Hour), start.Add(90*time.Minute))
+	assert.ErrorIs(t, err, domain.ErrBlockAlreadyExists)
+}
+
 func TestSchedule_FindAvailableSlots(t *testing.T) {
 	userID := uuid.New()
 	schedule := domain.NewSchedule(userID, time.Now())
@@ -290,3 +379,51 @@ func TestSchedule_Constraints(t *testing.T) {
 	// Verify constraint is in set
 	assert.NotNil(t, schedule.Constraints())
 }
+
+func TestSchedule_AddProtectedBlock(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	schedule := domain.NewSchedule(uuid.New(), date)
+
+	_, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Task", date.Add(10*time.Hour), date.Add(11*time.Hour))
+	require.NoError(t, err)
+
+	t.Run("may overlap ordinary blocks", func(t *testing.T) {
+		block, err := schedule.AddProtectedBlock(domain.BlockTypeFocusMode, uuid.Nil, "Focus", date.Add(9*time.Hour), date.Add(12*time.Hour))
+		require.NoError(t, err)
+		assert.True(t, block.IsProtected())
+		assert.Equal(t, block, schedule.ProtectedBlockAt(date.Add(11*time.Hour)))
+
+		// Free time starts after the focus window, not after the overlapped task.
+		slots := schedule.FindAvailableSlots(date.Add(9*time.Hour), date.Add(17*time.Hour), 30*time.Minute)
+		require.Len(t, slots, 1)
+		assert.Equal(t, date.Add(12*time.Hour), slots[0].Start)
+	})
+
+	t.Run("may not overlap another protected block", func(t *testing.T) {
+		_, err := schedule.AddProtectedBlock(domain.BlockTypeDoNotDisturb, uuid.Nil, "Focus", date.Add(11*time.Hour), date.Add(13*time.Hour))
+		assert.ErrorIs(t, err, domain.ErrWindowProtected)
+	})
+
+	t.Run("requires a protected block type", func(t *testing.T) {
+		_, err := schedule.AddProtectedBlock(domain.BlockTypeFocus, uuid.Nil, "Focus", date.Add(14*time.Hour), date.Add(15*time.Hour))
+		assert.ErrorIs(t, err, domain.ErrBlockNotProtected)
+	})
+}
+
+func TestSchedule_EndProtectedBlock(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	schedule := domain.NewSchedule(uuid.New(), date)
+
+	block, err := schedule.AddProtectedBlock(domain.BlockTypeDoNotDisturb, uuid.Nil, "Focus", date.Add(9*time.Hour), date.Add(11*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, block.SuppressesNotifications())
+
+	removed, err := schedule.EndProtectedBlock(block.ID(), date.Add(10*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, removed)
+	assert.Equal(t, date.Add(10*time.Hour), block.EndTime())
+	assert.True(t, block.IsCompleted())
+	assert.False(t, block.IsProtected())
+	assert.False(t, block.SuppressesNotifications())
+	assert.Nil(t, schedule.ProtectedBlockAt(date.Add(9*time.Hour+30*time.Minute)))
+}
@@ -27,8 +27,19 @@ const (
 	BlockTypeMeeting BlockType = "meeting"
 	BlockTypeFocus   BlockType = "focus"
 	BlockTypeBreak   BlockType = "break"
+
+	// BlockTypeFocusMode protects a window from other work while focus mode is on.
+	BlockTypeFocusMode BlockType = "focus_mode"
+	// BlockTypeDoNotDisturb is a focus mode window that also silences notifications.
+	BlockTypeDoNotDisturb BlockType = "do_not_disturb"
 )
 
+// IsProtected returns true for focus mode block types, which other work may
+// not be placed over and which are never moved to make room.
+func (bt BlockType) IsProtected() bool {
+	return bt == BlockTypeFocusMode || bt == BlockTypeDoNotDisturb
+}
+
 // TimeBlock represents a scheduled time slot for an activity
 type TimeBlock struct {
 	sharedDomain.BaseEntity
@@ -41,6 +52,8 @@ type TimeBlock struct {
 	endTime     time.Time
 	completed   bool
 	missed      bool
+	attachments []BlockAttachment
+	completion  BlockCompletion
 }
 
 // NewTimeBlock creates a new time block
@@ -85,6 +98,17 @@ func (tb *TimeBlock) EndTime() time.Time     { return tb.endTime }
 func (tb *TimeBlock) IsCompleted() bool      { return tb.completed }
 func (tb *TimeBlock) IsMissed() bool         { return tb.missed }
 
+// IsProtected returns true if the block is an unfinished focus mode window.
+func (tb *TimeBlock) IsProtected() bool {
+	return tb.blockType.IsProtected() && !tb.completed
+}
+
+// SuppressesNotifications returns true if notifications are silenced while
+// the block is in progress.
+func (tb *TimeBlock) SuppressesNotifications() bool {
+	return tb.blockType == BlockTypeDoNotDisturb && !tb.completed
+}
+
 // Duration returns the block duration
 func (tb *TimeBlock) Duration() time.Duration {
 	return tb.endTime.Sub(tb.startTime)
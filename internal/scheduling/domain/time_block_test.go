@@ -234,3 +234,59 @@ func TestTimeBlock_Reschedule(t *testing.T) {
 	assert.Equal(t, newEnd, block.EndTime())
 	assert.Equal(t, 45*time.Minute, block.Duration())
 }
+
+func TestTimeBlock_AddAttachment(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	block, _ := domain.NewTimeBlock(
+		uuid.New(), uuid.New(), domain.BlockTypeFocus, uuid.Nil,
+		"Deep work", start, start.Add(time.Hour),
+	)
+
+	require.NoError(t, block.AddAttachment("https://docs.example.com/spec", " Spec "))
+	require.NoError(t, block.AddAttachment("~/notes/focus.md", ""))
+
+	assert.ErrorIs(t, block.AddAttachment("  ", "Empty"), domain.ErrAttachmentTargetRequired)
+	assert.ErrorIs(t, block.AddAttachment("~/notes/focus.md", "Again"), domain.ErrAttachmentExists)
+
+	attachments := block.Attachments()
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "Spec", attachments[0].DisplayName())
+	assert.Equal(t, "~/notes/focus.md", attachments[1].DisplayName())
+}
+
+func TestTimeBlock_CompleteWith(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	newBlock := func() *domain.TimeBlock {
+		block, _ := domain.NewTimeBlock(
+			uuid.New(), uuid.New(), domain.BlockTypeFocus, uuid.Nil,
+			"Deep work", start, start.Add(time.Hour),
+		)
+		return block
+	}
+
+	t.Run("records notes and rating", func(t *testing.T) {
+		block := newBlock()
+		require.NoError(t, block.CompleteWith(domain.BlockCompletion{Notes: "  Finished the draft ", Rating: 4}))
+
+		assert.True(t, block.IsCompleted())
+		assert.Equal(t, domain.BlockCompletion{Notes: "Finished the draft", Rating: 4}, block.Completion())
+	})
+
+	t.Run("completes without a reflection", func(t *testing.T) {
+		block := newBlock()
+		require.NoError(t, block.CompleteWith(domain.BlockCompletion{}))
+
+		assert.True(t, block.IsCompleted())
+		assert.True(t, block.Completion().IsZero())
+	})
+
+	t.Run("rejects out of range rating", func(t *testing.T) {
+		for _, rating := range []int{-1, 6} {
+			block := newBlock()
+			err := block.CompleteWith(domain.BlockCompletion{Rating: rating})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidOutcomeRating)
+			assert.False(t, block.IsCompleted())
+		}
+	})
+}
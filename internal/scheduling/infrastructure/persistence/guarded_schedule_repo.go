@@ -0,0 +1,74 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+)
+
+// GuardedScheduleRepository wraps a schedule repository and rejects any call
+// that targets a user other than the authenticated principal in the context,
+// or that runs without a principal outside a system context.
+// Queries keyed by user are checked before they reach the database; lookups
+// by ID are checked against the owner of the loaded schedule.
+type GuardedScheduleRepository struct {
+	inner domain.ScheduleRepository
+}
+
+// NewGuardedScheduleRepository wraps inner with a principal check.
+func NewGuardedScheduleRepository(inner domain.ScheduleRepository) *GuardedScheduleRepository {
+	return &GuardedScheduleRepository{inner: inner}
+}
+
+// Save persists a schedule owned by the principal.
+func (r *GuardedScheduleRepository) Save(ctx context.Context, s *domain.Schedule) error {
+	if err := sharedApplication.CheckPrincipal(ctx, s.UserID()); err != nil {
+		return err
+	}
+	return r.inner.Save(ctx, s)
+}
+
+// FindByID loads a schedule and rejects it if it belongs to another user.
+func (r *GuardedScheduleRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Schedule, error) {
+	s, err := r.inner.FindByID(ctx, id)
+	if err != nil || s == nil {
+		return s, err
+	}
+	if err := sharedApplication.CheckPrincipal(ctx, s.UserID()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// FindByUserAndDate finds the principal's schedule for a date.
+func (r *GuardedScheduleRepository) FindByUserAndDate(ctx context.Context, userID uuid.UUID, date time.Time) (*domain.Schedule, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindByUserAndDate(ctx, userID, date)
+}
+
+// FindByUserDateRange finds the principal's schedules within a date range.
+func (r *GuardedScheduleRepository) FindByUserDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*domain.Schedule, error) {
+	if err := sharedApplication.CheckPrincipal(ctx, userID); err != nil {
+		return nil, err
+	}
+	return r.inner.FindByUserDateRange(ctx, userID, startDate, endDate)
+}
+
+// Delete removes a schedule after checking that it belongs to the principal.
+// A system context deletes the schedule unchecked.
+func (r *GuardedScheduleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := sharedApplication.RequirePrincipal(ctx); err != nil {
+		return err
+	}
+	if _, ok := sharedApplication.PrincipalFromContext(ctx); ok {
+		if _, err := r.FindByID(ctx, id); err != nil {
+			return err
+		}
+	}
+	return r.inner.Delete(ctx, id)
+}
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/scheduling/domain"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardedScheduleRepository(t *testing.T) {
+	sqlDB := setupScheduleTestDB(t)
+	defer sqlDB.Close()
+
+	owner := uuid.New()
+	other := uuid.New()
+	createScheduleTestUser(t, sqlDB, owner)
+	createScheduleTestUser(t, sqlDB, other)
+
+	repo := NewGuardedScheduleRepository(NewSQLiteScheduleRepository(sqlDB))
+	ownerCtx := sharedApplication.WithPrincipal(context.Background(), owner)
+	otherCtx := sharedApplication.WithPrincipal(context.Background(), other)
+
+	date := time.Now().Truncate(24 * time.Hour)
+	schedule := domain.NewSchedule(owner, date)
+	require.NoError(t, repo.Save(ownerCtx, schedule))
+
+	t.Run("owner can read their schedules", func(t *testing.T) {
+		found, err := repo.FindByID(ownerCtx, schedule.ID())
+		require.NoError(t, err)
+		assert.Equal(t, schedule.ID(), found.ID())
+
+		found, err = repo.FindByUserAndDate(ownerCtx, owner, date)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+	})
+
+	t.Run("rejects another user's schedules", func(t *testing.T) {
+		_, err := repo.FindByID(otherCtx, schedule.ID())
+		assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+		_, err = repo.FindByUserDateRange(otherCtx, owner, date, date.AddDate(0, 0, 1))
+		assert.ErrorIs(t, err, sharedApplication.ErrPrincipalMismatch)
+
+		assert.ErrorIs(t, repo.Save(otherCtx, schedule), sharedApplication.ErrPrincipalMismatch)
+		assert.ErrorIs(t, repo.Delete(otherCtx, schedule.ID()), sharedApplication.ErrPrincipalMismatch)
+	})
+
+	t.Run("rejects a context without a principal", func(t *testing.T) {
+		_, err := repo.FindByUserAndDate(context.Background(), owner, date)
+		assert.ErrorIs(t, err, sharedApplication.ErrNoPrincipal)
+
+		assert.ErrorIs(t, repo.Delete(context.Background(), schedule.ID()), sharedApplication.ErrNoPrincipal)
+	})
+
+	t.Run("allows a system context", func(t *testing.T) {
+		found, err := repo.FindByUserAndDate(sharedApplication.WithSystem(context.Background()), owner, date)
+		require.NoError(t, err)
+		assert.NotNil(t, found)
+	})
+}
@@ -129,6 +129,57 @@ func (r *PostgresScheduleRepository) saveWithTx(ctx context.Context, tx pgx.Tx,
 		}
 	}
 
+	// Replace block ordering constraints
+	_, err = tx.Exec(ctx, "DELETE FROM block_dependencies WHERE schedule_id = $1", schedule.ID())
+	if err != nil {
+		return err
+	}
+	for _, dep := range schedule.Dependencies() {
+		_, err = tx.Exec(ctx,
+			"INSERT INTO block_dependencies (schedule_id, before_block_id, after_block_id) VALUES ($1, $2, $3)",
+			schedule.ID(), dep.BeforeID, dep.AfterID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Replace block attachments
+	_, err = tx.Exec(ctx, "DELETE FROM block_attachments WHERE schedule_id = $1", schedule.ID())
+	if err != nil {
+		return err
+	}
+	for _, block := range schedule.Blocks() {
+		for i, attachment := range block.Attachments() {
+			_, err = tx.Exec(ctx,
+				"INSERT INTO block_attachments (schedule_id, block_id, position, target, label) VALUES ($1, $2, $3, $4, $5)",
+				schedule.ID(), block.ID(), i, attachment.Target, attachment.Label,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Replace block completion notes and outcome ratings
+	_, err = tx.Exec(ctx, "DELETE FROM block_completions WHERE schedule_id = $1", schedule.ID())
+	if err != nil {
+		return err
+	}
+	for _, block := range schedule.Blocks() {
+		completion := block.Completion()
+		if completion.IsZero() {
+			continue
+		}
+		_, err = tx.Exec(ctx,
+			"INSERT INTO block_completions (schedule_id, block_id, notes, outcome_rating) VALUES ($1, $2, $3, $4)",
+			schedule.ID(), block.ID(), completion.Notes, completion.Rating,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -162,7 +213,17 @@ func (r *PostgresScheduleRepository) FindByID(ctx context.Context, id uuid.UUID)
 		return nil, err
 	}
 
-	return r.rowToSchedule(row, blocks), nil
+	schedule := r.rowToSchedule(row, blocks)
+	if err := r.loadDependencies(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := r.loadCompletions(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
 }
 
 // FindByUserAndDate finds a schedule for a user on a specific date.
@@ -198,7 +259,17 @@ func (r *PostgresScheduleRepository) FindByUserAndDate(ctx context.Context, user
 		return nil, err
 	}
 
-	return r.rowToSchedule(row, blocks), nil
+	schedule := r.rowToSchedule(row, blocks)
+	if err := r.loadDependencies(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := r.loadCompletions(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
 }
 
 // FindByUserDateRange finds schedules for a user within a date range.
@@ -240,7 +311,17 @@ func (r *PostgresScheduleRepository) FindByUserDateRange(ctx context.Context, us
 			return nil, err
 		}
 
-		schedules = append(schedules, r.rowToSchedule(row, blocks))
+		schedule := r.rowToSchedule(row, blocks)
+		if err := r.loadDependencies(ctx, schedule); err != nil {
+			return nil, err
+		}
+		if err := r.loadAttachments(ctx, schedule); err != nil {
+			return nil, err
+		}
+		if err := r.loadCompletions(ctx, schedule); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -263,6 +344,90 @@ func (r *PostgresScheduleRepository) Delete(ctx context.Context, id uuid.UUID) e
 	return nil
 }
 
+// ArchiveFinishedBlocks moves completed and missed blocks that ended at or
+// before the given time, with their completion notes and ratings, into the
+// block archive.
+func (r *PostgresScheduleRepository) ArchiveFinishedBlocks(ctx context.Context, before time.Time, limit int) (int, error) {
+	return r.removeFinishedBlocks(ctx, before, limit, true)
+}
+
+// DeleteFinishedBlocks deletes completed and missed blocks that ended at or
+// before the given time.
+func (r *PostgresScheduleRepository) DeleteFinishedBlocks(ctx context.Context, before time.Time, limit int) (int, error) {
+	return r.removeFinishedBlocks(ctx, before, limit, false)
+}
+
+func (r *PostgresScheduleRepository) removeFinishedBlocks(ctx context.Context, before time.Time, limit int, archive bool) (int, error) {
+	if info, ok := sharedPersistence.TxInfoFromContext(ctx); ok {
+		return r.removeFinishedBlocksWithTx(ctx, info.Tx, before, limit, archive)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	removed, err := r.removeFinishedBlocksWithTx(ctx, tx, before, limit, archive)
+	if err != nil {
+		return 0, err
+	}
+	return removed, tx.Commit(ctx)
+}
+
+func (r *PostgresScheduleRepository) removeFinishedBlocksWithTx(ctx context.Context, tx pgx.Tx, before time.Time, limit int, archive bool) (int, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id
+		FROM time_blocks
+		WHERE (completed OR missed) AND end_time <= $1
+		ORDER BY end_time
+		LIMIT $2
+	`, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if archive {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO archived_time_blocks (
+				id, user_id, schedule_id, block_type, reference_id, title, start_time, end_time,
+				completed, missed, notes, outcome_rating, created_at, updated_at
+			)
+			SELECT b.id, b.user_id, b.schedule_id, b.block_type, b.reference_id, b.title, b.start_time, b.end_time,
+				b.completed, b.missed, COALESCE(c.notes, ''), COALESCE(c.outcome_rating, 0), b.created_at, b.updated_at
+			FROM time_blocks b
+			LEFT JOIN block_completions c ON c.block_id = b.id
+			WHERE b.id = ANY($1)
+			ON CONFLICT (id) DO NOTHING
+		`, ids); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, query := range []string{
+		`DELETE FROM block_completions WHERE block_id = ANY($1)`,
+		`DELETE FROM block_attachments WHERE block_id = ANY($1)`,
+		`DELETE FROM block_dependencies WHERE before_block_id = ANY($1) OR after_block_id = ANY($1)`,
+	} {
+		if _, err := tx.Exec(ctx, query, ids); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM time_blocks WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
 func (r *PostgresScheduleRepository) loadTimeBlocks(ctx context.Context, scheduleID uuid.UUID) ([]*domain.TimeBlock, error) {
 	query := `
 		SELECT id, user_id, schedule_id, block_type, reference_id, title,
@@ -327,6 +492,89 @@ func (r *PostgresScheduleRepository) loadTimeBlocks(ctx context.Context, schedul
 	return blocks, nil
 }
 
+// loadDependencies restores the schedule's block ordering constraints.
+func (r *PostgresScheduleRepository) loadDependencies(ctx context.Context, schedule *domain.Schedule) error {
+	rows, err := r.pool.Query(ctx,
+		"SELECT before_block_id, after_block_id FROM block_dependencies WHERE schedule_id = $1",
+		schedule.ID(),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var deps []domain.BlockDependency
+	for rows.Next() {
+		var dep domain.BlockDependency
+		if err := rows.Scan(&dep.BeforeID, &dep.AfterID); err != nil {
+			return err
+		}
+		deps = append(deps, dep)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	schedule.RehydrateDependencies(deps)
+	return nil
+}
+
+// loadAttachments restores the links and paths attached to the schedule's blocks.
+func (r *PostgresScheduleRepository) loadAttachments(ctx context.Context, schedule *domain.Schedule) error {
+	rows, err := r.pool.Query(ctx,
+		"SELECT block_id, target, label FROM block_attachments WHERE schedule_id = $1 ORDER BY block_id, position",
+		schedule.ID(),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	attachments := make(map[uuid.UUID][]domain.BlockAttachment)
+	for rows.Next() {
+		var blockID uuid.UUID
+		var attachment domain.BlockAttachment
+		if err := rows.Scan(&blockID, &attachment.Target, &attachment.Label); err != nil {
+			return err
+		}
+		attachments[blockID] = append(attachments[blockID], attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rehydrateAttachments(schedule, attachments)
+	return nil
+}
+
+// loadCompletions restores the notes and outcome ratings of completed blocks.
+func (r *PostgresScheduleRepository) loadCompletions(ctx context.Context, schedule *domain.Schedule) error {
+	rows, err := r.pool.Query(ctx,
+		"SELECT block_id, notes, outcome_rating FROM block_completions WHERE schedule_id = $1",
+		schedule.ID(),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	completions := make(map[uuid.UUID]domain.BlockCompletion)
+	for rows.Next() {
+		var blockID uuid.UUID
+		var completion domain.BlockCompletion
+		if err := rows.Scan(&blockID, &completion.Notes, &completion.Rating); err != nil {
+			return err
+		}
+		completions[blockID] = completion
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rehydrateCompletions(schedule, completions)
+	return nil
+}
+
 func (r *PostgresScheduleRepository) rowToSchedule(row scheduleRow, blocks []*domain.TimeBlock) *domain.Schedule {
 	return domain.RehydrateSchedule(
 		row.ID,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	db "github.com/felixgeelhaar/orbita/db/generated/sqlite"
@@ -30,6 +31,17 @@ func (r *SQLiteScheduleRepository) getQuerier(ctx context.Context) *db.Queries {
 	return db.New(r.dbConn)
 }
 
+// getDB returns the raw database handle (transaction or connection) based on context.
+func (r *SQLiteScheduleRepository) getDB(ctx context.Context) interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+} {
+	if info, ok := sharedPersistence.SQLiteTxInfoFromContext(ctx); ok {
+		return info.Tx
+	}
+	return r.dbConn
+}
+
 // Save persists a schedule to the database.
 func (r *SQLiteScheduleRepository) Save(ctx context.Context, schedule *domain.Schedule) error {
 	// Use existing transaction from context (via UnitOfWork) or direct connection
@@ -96,9 +108,187 @@ func (r *SQLiteScheduleRepository) Save(ctx context.Context, schedule *domain.Sc
 		}
 	}
 
+	if err := r.saveDependencies(ctx, schedule); err != nil {
+		return err
+	}
+	if err := r.saveAttachments(ctx, schedule); err != nil {
+		return err
+	}
+	return r.saveCompletions(ctx, schedule)
+}
+
+// saveDependencies replaces the schedule's block ordering constraints.
+func (r *SQLiteScheduleRepository) saveDependencies(ctx context.Context, schedule *domain.Schedule) error {
+	conn := r.getDB(ctx)
+
+	if _, err := conn.ExecContext(ctx, "DELETE FROM block_dependencies WHERE schedule_id = ?", schedule.ID().String()); err != nil {
+		return err
+	}
+
+	for _, dep := range schedule.Dependencies() {
+		if _, err := conn.ExecContext(ctx,
+			"INSERT INTO block_dependencies (schedule_id, before_block_id, after_block_id) VALUES (?, ?, ?)",
+			schedule.ID().String(), dep.BeforeID.String(), dep.AfterID.String(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadDependencies restores the schedule's block ordering constraints.
+func (r *SQLiteScheduleRepository) loadDependencies(ctx context.Context, schedule *domain.Schedule) error {
+	rows, err := r.getDB(ctx).QueryContext(ctx,
+		"SELECT before_block_id, after_block_id FROM block_dependencies WHERE schedule_id = ?",
+		schedule.ID().String(),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var deps []domain.BlockDependency
+	for rows.Next() {
+		var before, after string
+		if err := rows.Scan(&before, &after); err != nil {
+			return err
+		}
+		beforeID, _ := uuid.Parse(before)
+		afterID, _ := uuid.Parse(after)
+		deps = append(deps, domain.BlockDependency{BeforeID: beforeID, AfterID: afterID})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	schedule.RehydrateDependencies(deps)
+	return nil
+}
+
+// saveAttachments replaces the links and paths attached to the schedule's blocks.
+func (r *SQLiteScheduleRepository) saveAttachments(ctx context.Context, schedule *domain.Schedule) error {
+	conn := r.getDB(ctx)
+
+	if _, err := conn.ExecContext(ctx, "DELETE FROM block_attachments WHERE schedule_id = ?", schedule.ID().String()); err != nil {
+		return err
+	}
+
+	for _, block := range schedule.Blocks() {
+		for i, attachment := range block.Attachments() {
+			if _, err := conn.ExecContext(ctx,
+				"INSERT INTO block_attachments (schedule_id, block_id, position, target, label) VALUES (?, ?, ?, ?, ?)",
+				schedule.ID().String(), block.ID().String(), i, attachment.Target, attachment.Label,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadAttachments restores the links and paths attached to the schedule's blocks.
+func (r *SQLiteScheduleRepository) loadAttachments(ctx context.Context, schedule *domain.Schedule) error {
+	rows, err := r.getDB(ctx).QueryContext(ctx,
+		"SELECT block_id, target, label FROM block_attachments WHERE schedule_id = ? ORDER BY block_id, position",
+		schedule.ID().String(),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	attachments := make(map[uuid.UUID][]domain.BlockAttachment)
+	for rows.Next() {
+		var blockIDStr string
+		var attachment domain.BlockAttachment
+		if err := rows.Scan(&blockIDStr, &attachment.Target, &attachment.Label); err != nil {
+			return err
+		}
+		blockID, _ := uuid.Parse(blockIDStr)
+		attachments[blockID] = append(attachments[blockID], attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rehydrateAttachments(schedule, attachments)
+	return nil
+}
+
+// saveCompletions replaces the notes and outcome ratings captured when the
+// schedule's blocks were completed.
+func (r *SQLiteScheduleRepository) saveCompletions(ctx context.Context, schedule *domain.Schedule) error {
+	conn := r.getDB(ctx)
+
+	if _, err := conn.ExecContext(ctx, "DELETE FROM block_completions WHERE schedule_id = ?", schedule.ID().String()); err != nil {
+		return err
+	}
+
+	for _, block := range schedule.Blocks() {
+		completion := block.Completion()
+		if completion.IsZero() {
+			continue
+		}
+		if _, err := conn.ExecContext(ctx,
+			"INSERT INTO block_completions (schedule_id, block_id, notes, outcome_rating) VALUES (?, ?, ?, ?)",
+			schedule.ID().String(), block.ID().String(), completion.Notes, completion.Rating,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadCompletions restores the notes and outcome ratings of completed blocks.
+func (r *SQLiteScheduleRepository) loadCompletions(ctx context.Context, schedule *domain.Schedule) error {
+	rows, err := r.getDB(ctx).QueryContext(ctx,
+		"SELECT block_id, notes, outcome_rating FROM block_completions WHERE schedule_id = ?",
+		schedule.ID().String(),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	completions := make(map[uuid.UUID]domain.BlockCompletion)
+	for rows.Next() {
+		var blockIDStr string
+		var completion domain.BlockCompletion
+		if err := rows.Scan(&blockIDStr, &completion.Notes, &completion.Rating); err != nil {
+			return err
+		}
+		blockID, _ := uuid.Parse(blockIDStr)
+		completions[blockID] = completion
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rehydrateCompletions(schedule, completions)
 	return nil
 }
 
+// rehydrateCompletions hands loaded completion reflections to their blocks.
+func rehydrateCompletions(schedule *domain.Schedule, completions map[uuid.UUID]domain.BlockCompletion) {
+	for blockID, completion := range completions {
+		if block, err := schedule.FindBlock(blockID); err == nil {
+			block.RehydrateCompletion(completion)
+		}
+	}
+}
+
+// rehydrateAttachments hands loaded attachments to their blocks.
+func rehydrateAttachments(schedule *domain.Schedule, attachments map[uuid.UUID][]domain.BlockAttachment) {
+	for blockID, list := range attachments {
+		if block, err := schedule.FindBlock(blockID); err == nil {
+			block.RehydrateAttachments(list)
+		}
+	}
+}
+
 // FindByID retrieves a schedule by its ID.
 func (r *SQLiteScheduleRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Schedule, error) {
 	queries := r.getQuerier(ctx)
@@ -115,7 +305,17 @@ func (r *SQLiteScheduleRepository) FindByID(ctx context.Context, id uuid.UUID) (
 		return nil, err
 	}
 
-	return r.rowToSchedule(row, blocks), nil
+	schedule := r.rowToSchedule(row, blocks)
+	if err := r.loadDependencies(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := r.loadCompletions(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
 }
 
 // FindByUserAndDate finds a schedule for a user on a specific date.
@@ -141,7 +341,17 @@ func (r *SQLiteScheduleRepository) FindByUserAndDate(ctx context.Context, userID
 		return nil, err
 	}
 
-	return r.rowToSchedule(row, blocks), nil
+	schedule := r.rowToSchedule(row, blocks)
+	if err := r.loadDependencies(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := r.loadCompletions(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
 }
 
 // FindByUserDateRange finds schedules for a user within a date range.
@@ -167,7 +377,17 @@ func (r *SQLiteScheduleRepository) FindByUserDateRange(ctx context.Context, user
 		if err != nil {
 			return nil, err
 		}
-		schedules = append(schedules, r.rowToSchedule(row, blocks))
+		schedule := r.rowToSchedule(row, blocks)
+		if err := r.loadDependencies(ctx, schedule); err != nil {
+			return nil, err
+		}
+		if err := r.loadAttachments(ctx, schedule); err != nil {
+			return nil, err
+		}
+		if err := r.loadCompletions(ctx, schedule); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
 	}
 
 	return schedules, nil
@@ -180,6 +400,113 @@ func (r *SQLiteScheduleRepository) Delete(ctx context.Context, id uuid.UUID) err
 	return queries.DeleteSchedule(ctx, id.String())
 }
 
+// ArchiveFinishedBlocks moves completed and missed blocks that ended at or
+// before the given time, with their completion notes and ratings, into the
+// block archive.
+func (r *SQLiteScheduleRepository) ArchiveFinishedBlocks(ctx context.Context, before time.Time, limit int) (int, error) {
+	return r.removeFinishedBlocks(ctx, before, limit, true)
+}
+
+// DeleteFinishedBlocks deletes completed and missed blocks that ended at or
+// before the given time.
+func (r *SQLiteScheduleRepository) DeleteFinishedBlocks(ctx context.Context, before time.Time, limit int) (int, error) {
+	return r.removeFinishedBlocks(ctx, before, limit, false)
+}
+
+func (r *SQLiteScheduleRepository) removeFinishedBlocks(ctx context.Context, before time.Time, limit int, archive bool) (int, error) {
+	if _, ok := sharedPersistence.SQLiteTxInfoFromContext(ctx); ok {
+		return r.removeFinishedBlocksWith(ctx, r.getDB(ctx), before, limit, archive)
+	}
+
+	tx, err := r.dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	removed, err := r.removeFinishedBlocksWith(ctx, tx, before, limit, archive)
+	if err != nil {
+		return 0, err
+	}
+	return removed, tx.Commit()
+}
+
+func (r *SQLiteScheduleRepository) removeFinishedBlocksWith(ctx context.Context, conn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}, before time.Time, limit int, archive bool) (int, error) {
+	// Block times keep their original offset, so compare them as UTC datetimes.
+	rows, err := conn.QueryContext(ctx, `
+		SELECT id
+		FROM time_blocks
+		WHERE (completed = 1 OR missed = 1)
+		  AND datetime(end_time) <= datetime(?)
+		ORDER BY datetime(end_time)
+		LIMIT ?
+	`, before.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []any
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	in := "(?" + strings.Repeat(", ?", len(ids)-1) + ")"
+	if archive {
+		if _, err := conn.ExecContext(ctx, `
+			INSERT OR IGNORE INTO archived_time_blocks (
+				id, user_id, schedule_id, block_type, reference_id, title, start_time, end_time,
+				completed, missed, notes, outcome_rating, created_at, updated_at
+			)
+			SELECT b.id, b.user_id, b.schedule_id, b.block_type, b.reference_id, b.title, b.start_time, b.end_time,
+				b.completed, b.missed, COALESCE(c.notes, ''), COALESCE(c.outcome_rating, 0), b.created_at, b.updated_at
+			FROM time_blocks b
+			LEFT JOIN block_completions c ON c.block_id = b.id
+			WHERE b.id IN `+in, ids...); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, stmt := range []struct {
+		query string
+		args  []any
+	}{
+		{"DELETE FROM block_completions WHERE block_id IN " + in, ids},
+		{"DELETE FROM block_attachments WHERE block_id IN " + in, ids},
+		{"DELETE FROM block_dependencies WHERE before_block_id IN " + in + " OR after_block_id IN " + in, append(append([]any{}, ids...), ids...)},
+	} {
+		if _, err := conn.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := conn.ExecContext(ctx, "DELETE FROM time_blocks WHERE id IN "+in, ids...)
+	if err != nil {
+		return 0, err
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(removed), nil
+}
+
 func (r *SQLiteScheduleRepository) loadTimeBlocks(ctx context.Context, scheduleID uuid.UUID) ([]*domain.TimeBlock, error) {
 	queries := r.getQuerier(ctx)
 	rows, err := queries.GetTimeBlocksByScheduleID(ctx, scheduleID.String())
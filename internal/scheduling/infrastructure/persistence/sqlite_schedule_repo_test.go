@@ -26,12 +26,14 @@ func setupScheduleTestDB(t *testing.T) *sql.DB {
 	require.NoError(t, err)
 
 	// Read and execute the schema
-	schemaPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", "000001_initial_schema.up.sql")
-	schema, err := os.ReadFile(schemaPath)
-	require.NoError(t, err, "Failed to read SQLite schema file")
+	for _, name := range []string{"000001_initial_schema.up.sql", "000014_block_dependencies.up.sql", "000021_block_attachments.up.sql", "000024_block_completions.up.sql", "000026_archived_time_blocks.up.sql"} {
+		schemaPath := filepath.Join("..", "..", "..", "..", "migrations", "sqlite", name)
+		schema, err := os.ReadFile(schemaPath)
+		require.NoError(t, err, "Failed to read SQLite schema file")
 
-	_, err = sqlDB.Exec(string(schema))
-	require.NoError(t, err, "Failed to apply SQLite schema")
+		_, err = sqlDB.Exec(string(schema))
+		require.NoError(t, err, "Failed to apply SQLite schema")
+	}
 
 	return sqlDB
 }
@@ -112,6 +114,217 @@ func TestSQLiteScheduleRepository_Save_WithBlocks(t *testing.T) {
 	assert.Equal(t, domain.BlockTypeTask, found.Blocks()[0].BlockType())
 }
 
+func TestSQLiteScheduleRepository_Dependencies(t *testing.T) {
+	sqlDB := setupScheduleTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createScheduleTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteScheduleRepository(sqlDB)
+	ctx := context.Background()
+
+	scheduleDate := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	schedule := domain.NewSchedule(userID, scheduleDate)
+	draft, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Draft", scheduleDate.Add(9*time.Hour), scheduleDate.Add(10*time.Hour))
+	require.NoError(t, err)
+	review, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Review", scheduleDate.Add(11*time.Hour), scheduleDate.Add(12*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, schedule.AddDependency(draft.ID(), review.ID()))
+	require.NoError(t, repo.Save(ctx, schedule))
+
+	found, err := repo.FindByUserAndDate(ctx, userID, scheduleDate)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, []domain.BlockDependency{{BeforeID: draft.ID(), AfterID: review.ID()}}, found.Dependencies())
+
+	// Removing the block removes its dependencies.
+	require.NoError(t, found.RemoveBlock(draft.ID()))
+	require.NoError(t, repo.Save(ctx, found))
+
+	found, err = repo.FindByID(ctx, schedule.ID())
+	require.NoError(t, err)
+	assert.Empty(t, found.Dependencies())
+}
+
+func TestSQLiteScheduleRepository_Attachments(t *testing.T) {
+	sqlDB := setupScheduleTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createScheduleTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteScheduleRepository(sqlDB)
+	ctx := context.Background()
+
+	scheduleDate := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	schedule := domain.NewSchedule(userID, scheduleDate)
+	focus, err := schedule.AddBlock(domain.BlockTypeFocus, uuid.Nil, "Deep work", scheduleDate.Add(9*time.Hour), scheduleDate.Add(11*time.Hour))
+	require.NoError(t, err)
+	review, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Review", scheduleDate.Add(13*time.Hour), scheduleDate.Add(14*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, focus.AddAttachment("https://docs.example.com/spec", "Spec"))
+	require.NoError(t, focus.AddAttachment("~/notes/focus.md", ""))
+	require.NoError(t, repo.Save(ctx, schedule))
+
+	found, err := repo.FindByUserAndDate(ctx, userID, scheduleDate)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	block, err := found.FindBlock(focus.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []domain.BlockAttachment{
+		{Target: "https://docs.example.com/spec", Label: "Spec"},
+		{Target: "~/notes/focus.md"},
+	}, block.Attachments())
+	other, err := found.FindBlock(review.ID())
+	require.NoError(t, err)
+	assert.Empty(t, other.Attachments())
+
+	// Removing the block removes its attachments.
+	require.NoError(t, found.RemoveBlock(focus.ID()))
+	require.NoError(t, repo.Save(ctx, found))
+
+	var count int
+	require.NoError(t, sqlDB.QueryRow("SELECT COUNT(*) FROM block_attachments").Scan(&count))
+	assert.Zero(t, count)
+}
+
+func TestSQLiteScheduleRepository_MoveBlockBetweenDays(t *testing.T) {
+	sqlDB := setupScheduleTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createScheduleTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteScheduleRepository(sqlDB)
+	ctx := context.Background()
+
+	day := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+	source := domain.NewSchedule(userID, day)
+	block, err := source.AddBlock(domain.BlockTypeFocus, uuid.Nil, "Deep work", day.Add(9*time.Hour), day.Add(11*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, block.AddAttachment("https://docs.example.com/spec", "Spec"))
+	require.NoError(t, repo.Save(ctx, source))
+
+	target := domain.NewSchedule(userID, next)
+	_, err = source.MoveBlockTo(target, block.ID(), next.Add(13*time.Hour), next.Add(15*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, source))
+	require.NoError(t, repo.Save(ctx, target))
+
+	found, err := repo.FindByUserAndDate(ctx, userID, day)
+	require.NoError(t, err)
+	assert.Empty(t, found.Blocks())
+
+	found, err = repo.FindByUserAndDate(ctx, userID, next)
+	require.NoError(t, err)
+	moved, err := found.FindBlock(block.ID())
+	require.NoError(t, err)
+	assert.Equal(t, next.Add(13*time.Hour), moved.StartTime().UTC())
+	assert.Equal(t, []domain.BlockAttachment{{Target: "https://docs.example.com/spec", Label: "Spec"}}, moved.Attachments())
+}
+
+func TestSQLiteScheduleRepository_Completions(t *testing.T) {
+	sqlDB := setupScheduleTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createScheduleTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteScheduleRepository(sqlDB)
+	ctx := context.Background()
+
+	scheduleDate := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	schedule := domain.NewSchedule(userID, scheduleDate)
+	focus, err := schedule.AddBlock(domain.BlockTypeFocus, uuid.Nil, "Deep work", scheduleDate.Add(9*time.Hour), scheduleDate.Add(11*time.Hour))
+	require.NoError(t, err)
+	review, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Review", scheduleDate.Add(13*time.Hour), scheduleDate.Add(14*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, schedule.CompleteBlockWith(focus.ID(), domain.BlockCompletion{Notes: "Outline done", Rating: 4}))
+	require.NoError(t, schedule.CompleteBlock(review.ID()))
+	require.NoError(t, repo.Save(ctx, schedule))
+
+	found, err := repo.FindByUserAndDate(ctx, userID, scheduleDate)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	block, err := found.FindBlock(focus.ID())
+	require.NoError(t, err)
+	assert.True(t, block.IsCompleted())
+	assert.Equal(t, domain.BlockCompletion{Notes: "Outline done", Rating: 4}, block.Completion())
+	other, err := found.FindBlock(review.ID())
+	require.NoError(t, err)
+	assert.True(t, other.IsCompleted())
+	assert.True(t, other.Completion().IsZero())
+
+	// Only blocks with a reflection are stored.
+	var count int
+	require.NoError(t, sqlDB.QueryRow("SELECT COUNT(*) FROM block_completions").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestSQLiteScheduleRepository_RemoveFinishedBlocks(t *testing.T) {
+	sqlDB := setupScheduleTestDB(t)
+	defer sqlDB.Close()
+
+	userID := uuid.New()
+	createScheduleTestUser(t, sqlDB, userID)
+
+	repo := NewSQLiteScheduleRepository(sqlDB)
+	ctx := context.Background()
+
+	// Times carry an offset, as they do when blocks are created in local time.
+	berlin := time.FixedZone("CEST", 2*60*60)
+	scheduleDate := time.Date(2024, time.May, 1, 0, 0, 0, 0, berlin)
+	schedule := domain.NewSchedule(userID, scheduleDate)
+	done, err := schedule.AddBlock(domain.BlockTypeFocus, uuid.Nil, "Deep work", scheduleDate.Add(9*time.Hour), scheduleDate.Add(11*time.Hour))
+	require.NoError(t, err)
+	missed, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Review", scheduleDate.Add(13*time.Hour), scheduleDate.Add(14*time.Hour))
+	require.NoError(t, err)
+	open, err := schedule.AddBlock(domain.BlockTypeTask, uuid.New(), "Plan", scheduleDate.Add(15*time.Hour), scheduleDate.Add(16*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, schedule.CompleteBlockWith(done.ID(), domain.BlockCompletion{Notes: "Outline done", Rating: 4}))
+	require.NoError(t, schedule.MissBlock(missed.ID()))
+	require.NoError(t, repo.Save(ctx, schedule))
+
+	// The review ended at 12:00 UTC; a cutoff a second earlier keeps it.
+	cutoff := time.Date(2024, time.May, 1, 11, 59, 59, 0, time.UTC)
+	archived, err := repo.ArchiveFinishedBlocks(ctx, cutoff, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+
+	found, err := repo.FindByID(ctx, schedule.ID())
+	require.NoError(t, err)
+	require.Len(t, found.Blocks(), 2)
+	_, err = found.FindBlock(done.ID())
+	assert.Error(t, err)
+
+	var notes string
+	var rating int
+	require.NoError(t, sqlDB.QueryRow(
+		"SELECT notes, outcome_rating FROM archived_time_blocks WHERE id = ?", done.ID().String(),
+	).Scan(&notes, &rating))
+	assert.Equal(t, "Outline done", notes)
+	assert.Equal(t, 4, rating)
+
+	var count int
+	require.NoError(t, sqlDB.QueryRow("SELECT COUNT(*) FROM block_completions").Scan(&count))
+	assert.Zero(t, count)
+
+	// Blocks that are neither completed nor missed are never removed.
+	deleted, err := repo.DeleteFinishedBlocks(ctx, cutoff.AddDate(0, 0, 1), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	found, err = repo.FindByID(ctx, schedule.ID())
+	require.NoError(t, err)
+	require.Len(t, found.Blocks(), 1)
+	assert.Equal(t, open.ID(), found.Blocks()[0].ID())
+
+	require.NoError(t, sqlDB.QueryRow("SELECT COUNT(*) FROM archived_time_blocks").Scan(&count))
+	assert.Equal(t, 1, count, "deleted blocks are not archived")
+}
+
 func TestSQLiteScheduleRepository_Save_Update(t *testing.T) {
 	sqlDB := setupScheduleTestDB(t)
 	defer sqlDB.Close()
@@ -0,0 +1,294 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	habitDomain "github.com/felixgeelhaar/orbita/internal/habits/domain"
+	inboxDomain "github.com/felixgeelhaar/orbita/internal/inbox/domain"
+	meetingDomain "github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	sharedPersistence "github.com/felixgeelhaar/orbita/internal/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// Entity types a search can be limited to.
+const (
+	TypeTask    = sharedPersistence.SearchEntityTask
+	TypeHabit   = sharedPersistence.SearchEntityHabit
+	TypeMeeting = sharedPersistence.SearchEntityMeeting
+	TypeInbox   = sharedPersistence.SearchEntityInbox
+)
+
+// DefaultSearchLimit is how many results a search returns when no limit is
+// given.
+const DefaultSearchLimit = 20
+
+// searchTypes lists every entity type in the order ties are broken.
+var searchTypes = []string{TypeTask, TypeHabit, TypeMeeting, TypeInbox}
+
+// SearchEntitiesQuery contains the parameters for searching a user's data.
+type SearchEntitiesQuery struct {
+	UserID uuid.UUID
+	Query  string
+	// Types limits the search to these entity types. Empty searches them all.
+	Types []string
+	Limit int
+}
+
+// SearchResultDTO is one entity matching a search.
+type SearchResultDTO struct {
+	Type    string
+	ID      uuid.UUID
+	Title   string
+	Snippet string // Description or further content, if any
+	Status  string
+	Score   float64 // Higher is more relevant
+}
+
+// SearchEntitiesHandler searches a user's tasks, habits, meetings and inbox
+// items. Results from every type are ranked together by how well their
+// title and text match the query words, so the ranking is the same whichever
+// database the repositories use.
+type SearchEntitiesHandler struct {
+	tasks    task.SearchRepository
+	habits   habitDomain.SearchRepository
+	meetings meetingDomain.SearchRepository
+	inbox    inboxDomain.SearchRepository
+}
+
+// NewSearchEntitiesHandler creates a new search handler. Any repository may
+// be nil, in which case that type is not searched.
+func NewSearchEntitiesHandler(
+	tasks task.SearchRepository,
+	habits habitDomain.SearchRepository,
+	meetings meetingDomain.SearchRepository,
+	inbox inboxDomain.SearchRepository,
+) *SearchEntitiesHandler {
+	return &SearchEntitiesHandler{tasks: tasks, habits: habits, meetings: meetings, inbox: inbox}
+}
+
+// rankedResult is a result with its position in its repository's own
+// relevance order, used to break score ties.
+type rankedResult struct {
+	SearchResultDTO
+	position  int
+	typeOrder int
+}
+
+// Handle executes the search.
+func (h *SearchEntitiesHandler) Handle(ctx context.Context, query SearchEntitiesQuery) ([]SearchResultDTO, error) {
+	terms := sharedPersistence.SearchTerms(query.Query)
+	if len(terms) == 0 {
+		return nil, sharedApplication.NewValidationError("search query is required")
+	}
+	types, err := normalizeTypes(query.Types)
+	if err != nil {
+		return nil, err
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	var results []rankedResult
+	add := func(typeOrder, position int, dto SearchResultDTO, text string) {
+		dto.Score = relevance(terms, dto.Title, text)
+		results = append(results, rankedResult{SearchResultDTO: dto, position: position, typeOrder: typeOrder})
+	}
+
+	for order, entityType := range searchTypes {
+		if !types[entityType] {
+			continue
+		}
+		switch entityType {
+		case TypeTask:
+			if h.tasks == nil {
+				continue
+			}
+			tasks, err := h.tasks.Search(ctx, query.UserID, query.Query, limit)
+			if err != nil {
+				return nil, err
+			}
+			for i, t := range tasks {
+				add(order, i, SearchResultDTO{
+					Type:    TypeTask,
+					ID:      t.ID(),
+					Title:   t.Title(),
+					Snippet: t.Description(),
+					Status:  t.Status().String(),
+				}, t.Description())
+			}
+		case TypeHabit:
+			if h.habits == nil {
+				continue
+			}
+			habits, err := h.habits.Search(ctx, query.UserID, query.Query, limit)
+			if err != nil {
+				return nil, err
+			}
+			for i, habit := range habits {
+				add(order, i, SearchResultDTO{
+					Type:    TypeHabit,
+					ID:      habit.ID(),
+					Title:   habit.Name(),
+					Snippet: habit.Description(),
+					Status:  activeStatus(habit.IsArchived()),
+				}, habit.Description())
+			}
+		case TypeMeeting:
+			if h.meetings == nil {
+				continue
+			}
+			meetings, err := h.meetings.Search(ctx, query.UserID, query.Query, limit)
+			if err != nil {
+				return nil, err
+			}
+			for i, meeting := range meetings {
+				add(order, i, SearchResultDTO{
+					Type:   TypeMeeting,
+					ID:     meeting.ID(),
+					Title:  meeting.Name(),
+					Status: activeStatus(meeting.IsArchived()),
+				}, "")
+			}
+		case TypeInbox:
+			if h.inbox == nil {
+				continue
+			}
+			items, err := h.inbox.Search(ctx, query.UserID, query.Query, limit)
+			if err != nil {
+				return nil, err
+			}
+			for i, item := range items {
+				title, rest, _ := strings.Cut(strings.TrimSpace(item.Content), "\n")
+				add(order, i, SearchResultDTO{
+					Type:    TypeInbox,
+					ID:      item.ID,
+					Title:   title,
+					Snippet: strings.TrimSpace(rest),
+					Status:  inboxStatus(item),
+				}, rest)
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.position != b.position {
+			return a.position < b.position
+		}
+		return a.typeOrder < b.typeOrder
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	dtos := make([]SearchResultDTO, len(results))
+	for i, result := range results {
+		dtos[i] = result.SearchResultDTO
+	}
+	return dtos, nil
+}
+
+// normalizeTypes returns the set of entity types to search, rejecting
+// unknown ones.
+func normalizeTypes(requested []string) (map[string]bool, error) {
+	types := make(map[string]bool, len(searchTypes))
+	if len(requested) == 0 {
+		for _, entityType := range searchTypes {
+			types[entityType] = true
+		}
+		return types, nil
+	}
+
+	for _, entityType := range requested {
+		entityType = strings.ToLower(strings.TrimSpace(entityType))
+		known := false
+		for _, candidate := range searchTypes {
+			if entityType == candidate {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, sharedApplication.NewValidationError(fmt.Sprintf(
+				"unknown search type %q (use %s)", entityType, strings.Join(searchTypes, ", ")))
+		}
+		types[entityType] = true
+	}
+	return types, nil
+}
+
+// Relevance weights for a query word matching a word of the title or text.
+const (
+	titleWordScore   = 3.0
+	titlePrefixScore = 2.0
+	textWordScore    = 1.0
+	textPrefixScore  = 0.5
+)
+
+// relevance scores how well title and text match the query terms. Each term
+// counts its best match: a whole title word, the start of a title word, a
+// whole word of the text, or the start of one.
+func relevance(terms []string, title, text string) float64 {
+	titleWords := sharedPersistence.SearchTerms(title)
+	textWords := sharedPersistence.SearchTerms(text)
+
+	var score float64
+	for _, term := range terms {
+		switch {
+		case containsWord(titleWords, term):
+			score += titleWordScore
+		case containsPrefix(titleWords, term):
+			score += titlePrefixScore
+		case containsWord(textWords, term):
+			score += textWordScore
+		case containsPrefix(textWords, term):
+			score += textPrefixScore
+		}
+	}
+	return score
+}
+
+func containsWord(words []string, term string) bool {
+	for _, word := range words {
+		if word == term {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPrefix(words []string, term string) bool {
+	for _, word := range words {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
+
+func activeStatus(archived bool) string {
+	if archived {
+		return "archived"
+	}
+	return "active"
+}
+
+func inboxStatus(item inboxDomain.InboxItem) string {
+	switch {
+	case item.Promoted:
+		return "promoted"
+	case item.Archived:
+		return "archived"
+	default:
+		return "open"
+	}
+}
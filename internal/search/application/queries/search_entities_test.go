@@ -0,0 +1,129 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	habitDomain "github.com/felixgeelhaar/orbita/internal/habits/domain"
+	inboxDomain "github.com/felixgeelhaar/orbita/internal/inbox/domain"
+	meetingDomain "github.com/felixgeelhaar/orbita/internal/meetings/domain"
+	"github.com/felixgeelhaar/orbita/internal/productivity/domain/task"
+	sharedApplication "github.com/felixgeelhaar/orbita/internal/shared/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTaskSearch struct {
+	tasks []*task.Task
+	query string
+	limit int
+}
+
+func (s *stubTaskSearch) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*task.Task, error) {
+	s.query, s.limit = query, limit
+	return s.tasks, nil
+}
+
+type stubHabitSearch struct{ habits []*habitDomain.Habit }
+
+func (s *stubHabitSearch) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*habitDomain.Habit, error) {
+	return s.habits, nil
+}
+
+type stubMeetingSearch struct{ meetings []*meetingDomain.Meeting }
+
+func (s *stubMeetingSearch) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]*meetingDomain.Meeting, error) {
+	return s.meetings, nil
+}
+
+type stubInboxSearch struct{ items []inboxDomain.InboxItem }
+
+func (s *stubInboxSearch) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]inboxDomain.InboxItem, error) {
+	return s.items, nil
+}
+
+func TestSearchEntitiesHandler_Handle(t *testing.T) {
+	userID := uuid.New()
+
+	report, err := task.NewTask(userID, "Quarterly report")
+	require.NoError(t, err)
+	followUp, err := task.NewTask(userID, "Email finance")
+	require.NoError(t, err)
+	require.NoError(t, followUp.SetDescription("ask about the report draft"))
+
+	reading, err := habitDomain.NewHabit(userID, "Read reports", habitDomain.FrequencyDaily, 30*time.Minute)
+	require.NoError(t, err)
+
+	review, err := meetingDomain.NewMeeting(userID, "Report review", meetingDomain.CadenceWeekly, 7, 30*time.Minute, 10*time.Hour)
+	require.NoError(t, err)
+
+	idea := inboxDomain.InboxItem{ID: uuid.New(), UserID: userID, Content: "Idea: automate the report\nUse the export API"}
+
+	tasks := &stubTaskSearch{tasks: []*task.Task{report, followUp}}
+	handler := NewSearchEntitiesHandler(
+		tasks,
+		&stubHabitSearch{habits: []*habitDomain.Habit{reading}},
+		&stubMeetingSearch{meetings: []*meetingDomain.Meeting{review}},
+		&stubInboxSearch{items: []inboxDomain.InboxItem{idea}},
+	)
+
+	t.Run("ranks title matches across types above text matches", func(t *testing.T) {
+		results, err := handler.Handle(context.Background(), SearchEntitiesQuery{UserID: userID, Query: "Report"})
+		require.NoError(t, err)
+		require.Len(t, results, 5)
+
+		// Whole-word title matches first, then the prefix match, then the
+		// task that only mentions the word in its description.
+		assert.Equal(t, report.ID(), results[0].ID)
+		assert.Equal(t, TypeTask, results[0].Type)
+		assert.Equal(t, TypeMeeting, results[1].Type)
+		assert.Equal(t, TypeInbox, results[2].Type)
+		assert.Equal(t, "Idea: automate the report", results[2].Title)
+		assert.Equal(t, "Use the export API", results[2].Snippet)
+		assert.Equal(t, TypeHabit, results[3].Type)
+		assert.Equal(t, followUp.ID(), results[4].ID)
+		assert.Greater(t, results[3].Score, results[4].Score)
+	})
+
+	t.Run("limits to the requested types", func(t *testing.T) {
+		results, err := handler.Handle(context.Background(), SearchEntitiesQuery{
+			UserID: userID,
+			Query:  "report",
+			Types:  []string{"Habit", "meeting"},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, TypeMeeting, results[0].Type)
+		assert.Equal(t, TypeHabit, results[1].Type)
+		assert.Equal(t, "active", results[1].Status)
+	})
+
+	t.Run("applies the limit across types", func(t *testing.T) {
+		results, err := handler.Handle(context.Background(), SearchEntitiesQuery{UserID: userID, Query: "report", Limit: 2})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, 2, tasks.limit)
+	})
+
+	t.Run("rejects an empty query", func(t *testing.T) {
+		_, err := handler.Handle(context.Background(), SearchEntitiesQuery{UserID: userID, Query: " ?! "})
+		assert.True(t, errors.Is(err, sharedApplication.ErrValidation))
+	})
+
+	t.Run("rejects unknown types", func(t *testing.T) {
+		_, err := handler.Handle(context.Background(), SearchEntitiesQuery{UserID: userID, Query: "report", Types: []string{"project"}})
+		assert.True(t, errors.Is(err, sharedApplication.ErrValidation))
+	})
+
+	t.Run("skips types without a repository", func(t *testing.T) {
+		handler := NewSearchEntitiesHandler(nil, nil, &stubMeetingSearch{meetings: []*meetingDomain.Meeting{review}}, nil)
+
+		results, err := handler.Handle(context.Background(), SearchEntitiesQuery{UserID: userID, Query: "report"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, review.ID(), results[0].ID)
+	})
+}
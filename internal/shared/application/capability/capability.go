@@ -0,0 +1,103 @@
+// Package capability describes which optional features a running
+// application provides, and why the missing ones are unavailable.
+package capability
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Names of the optional features the application reports on.
+const (
+	Database       = "database"
+	EventDelivery  = "event_delivery"
+	OrbitStorage   = "orbit_storage"
+	CalendarSync   = "calendar_sync"
+	CalendarImport = "calendar_import"
+	Reminders      = "reminders"
+	Escalation     = "escalation"
+	Archiving      = "archiving"
+	BlockRetention = "block_retention"
+	InboxExpiry    = "inbox_expiry"
+	Digests        = "digests"
+)
+
+// ErrUnavailable is matched by every UnavailableError.
+var ErrUnavailable = errors.New("feature unavailable")
+
+// Capability reports whether one feature is available.
+type Capability struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+	// Reason explains why an unavailable feature is missing.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Available reports a working feature.
+func Available(name string) Capability {
+	return Capability{Name: name, Available: true}
+}
+
+// Unavailable reports a missing feature and why.
+func Unavailable(name, reason string) Capability {
+	return Capability{Name: name, Reason: reason}
+}
+
+// Set is the capabilities of a running application, in report order.
+type Set []Capability
+
+// Get returns the named capability.
+func (s Set) Get(name string) (Capability, bool) {
+	for _, c := range s {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Capability{}, false
+}
+
+// Unavailable returns the capabilities that are missing.
+func (s Set) Unavailable() Set {
+	var missing Set
+	for _, c := range s {
+		if !c.Available {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
+
+// Require returns an UnavailableError when the named capability is reported
+// as missing. Capabilities the set does not know about are not required.
+func (s Set) Require(name string) error {
+	c, ok := s.Get(name)
+	if !ok || c.Available {
+		return nil
+	}
+	return &UnavailableError{Name: c.Name, Reason: c.Reason}
+}
+
+// UnavailableError is returned when a command needs a missing feature.
+type UnavailableError struct {
+	Name   string
+	Reason string
+}
+
+func (e *UnavailableError) Error() string {
+	label := Label(e.Name)
+	if e.Reason == "" {
+		return label + " unavailable"
+	}
+	return fmt.Sprintf("%s unavailable because %s", label, e.Reason)
+}
+
+// Is matches ErrUnavailable.
+func (e *UnavailableError) Is(target error) bool {
+	return target == ErrUnavailable
+}
+
+// Label renders a capability name for people, e.g. "calendar sync".
+func Label(name string) string {
+	return strings.ReplaceAll(name, "_", " ")
+}
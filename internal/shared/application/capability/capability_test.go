@@ -0,0 +1,45 @@
+package capability
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet_Require(t *testing.T) {
+	set := Set{
+		Available(Database),
+		Unavailable(CalendarSync, "OAuth is not configured"),
+		Unavailable(Digests, ""),
+	}
+
+	assert.NoError(t, set.Require(Database))
+	assert.NoError(t, set.Require("unknown"), "unknown capabilities are not required")
+
+	err := set.Require(CalendarSync)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrUnavailable))
+	assert.Equal(t, "calendar sync unavailable because OAuth is not configured", err.Error())
+
+	var unavailable *UnavailableError
+	require.ErrorAs(t, set.Require(Digests), &unavailable)
+	assert.Equal(t, Digests, unavailable.Name)
+	assert.Equal(t, "digests unavailable", unavailable.Error())
+}
+
+func TestSet_Unavailable(t *testing.T) {
+	set := Set{
+		Available(Database),
+		Unavailable(CalendarSync, "OAuth is not configured"),
+		Available(Reminders),
+		Unavailable(Digests, "DIGESTS_ENABLED is off"),
+	}
+
+	missing := set.Unavailable()
+	require.Len(t, missing, 2)
+	assert.Equal(t, CalendarSync, missing[0].Name)
+	assert.Equal(t, Digests, missing[1].Name)
+	assert.Empty(t, Set{Available(Database)}.Unavailable())
+}
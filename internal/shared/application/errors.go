@@ -0,0 +1,100 @@
+package application
+
+import "errors"
+
+// Error categories. Command handlers tag their errors with one of these so
+// adapters can map failures to exit codes or protocol errors without string
+// matching. Use errors.Is(err, ErrNotFound) to test the category of an error.
+var (
+	// ErrValidation marks errors caused by invalid input.
+	ErrValidation = errors.New("validation failed")
+	// ErrNotFound marks errors for resources that do not exist or are not
+	// visible to the caller.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict marks errors where the request clashes with current state.
+	ErrConflict = errors.New("conflict")
+	// ErrInternal marks unexpected failures. Untagged errors are treated as internal.
+	ErrInternal = errors.New("internal error")
+)
+
+// Error is an error tagged with a category. It matches both the wrapped error
+// and its category under errors.Is.
+type Error struct {
+	category error
+	err      error
+}
+
+// Error returns the message of the wrapped error.
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error and the category.
+func (e *Error) Unwrap() []error {
+	return []error{e.err, e.category}
+}
+
+// Category returns the category the error was tagged with.
+func (e *Error) Category() error {
+	return e.category
+}
+
+// NewValidationError creates a validation error with the given message.
+func NewValidationError(msg string) error {
+	return &Error{category: ErrValidation, err: errors.New(msg)}
+}
+
+// NewNotFoundError creates a not-found error with the given message.
+func NewNotFoundError(msg string) error {
+	return &Error{category: ErrNotFound, err: errors.New(msg)}
+}
+
+// NewConflictError creates a conflict error with the given message.
+func NewConflictError(msg string) error {
+	return &Error{category: ErrConflict, err: errors.New(msg)}
+}
+
+// Validation tags err as a validation error. A nil err returns nil.
+func Validation(err error) error {
+	return tag(ErrValidation, err)
+}
+
+// NotFound tags err as a not-found error. A nil err returns nil.
+func NotFound(err error) error {
+	return tag(ErrNotFound, err)
+}
+
+// Conflict tags err as a conflict error. A nil err returns nil.
+func Conflict(err error) error {
+	return tag(ErrConflict, err)
+}
+
+// Internal tags err as an internal error. A nil err returns nil.
+func Internal(err error) error {
+	return tag(ErrInternal, err)
+}
+
+func tag(category, err error) error {
+	if err == nil {
+		return nil
+	}
+	// Keep the first category an error was tagged with.
+	var tagged *Error
+	if errors.As(err, &tagged) {
+		return err
+	}
+	return &Error{category: category, err: err}
+}
+
+// CategoryOf returns the category of err: one of ErrValidation, ErrNotFound,
+// ErrConflict or ErrInternal. It returns nil for a nil error.
+func CategoryOf(err error) error {
+	if err == nil {
+		return nil
+	}
+	var tagged *Error
+	if errors.As(err, &tagged) {
+		return tagged.category
+	}
+	return ErrInternal
+}
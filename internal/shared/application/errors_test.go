@@ -0,0 +1,53 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCategories(t *testing.T) {
+	errMissing := NewNotFoundError("widget not found")
+
+	assert.EqualError(t, errMissing, "widget not found")
+	assert.ErrorIs(t, errMissing, ErrNotFound)
+	assert.NotErrorIs(t, errMissing, ErrValidation)
+
+	// Wrapping keeps both the sentinel and its category visible.
+	wrapped := fmt.Errorf("load widget: %w", errMissing)
+	assert.ErrorIs(t, wrapped, errMissing)
+	assert.ErrorIs(t, wrapped, ErrNotFound)
+	assert.Equal(t, ErrNotFound, CategoryOf(wrapped))
+
+	assert.ErrorIs(t, NewValidationError("bad"), ErrValidation)
+	assert.ErrorIs(t, NewConflictError("taken"), ErrConflict)
+}
+
+func TestTaggingExistingErrors(t *testing.T) {
+	errDomain := errors.New("name cannot be empty")
+
+	tagged := Validation(errDomain)
+	assert.EqualError(t, tagged, "name cannot be empty")
+	assert.ErrorIs(t, tagged, errDomain)
+	assert.ErrorIs(t, tagged, ErrValidation)
+
+	// The first category wins.
+	assert.Equal(t, ErrValidation, CategoryOf(Conflict(tagged)))
+
+	assert.ErrorIs(t, NotFound(errDomain), ErrNotFound)
+	assert.ErrorIs(t, Conflict(errDomain), ErrConflict)
+	assert.ErrorIs(t, Internal(errDomain), ErrInternal)
+	assert.NoError(t, Validation(nil))
+}
+
+func TestCategoryOf(t *testing.T) {
+	assert.Nil(t, CategoryOf(nil))
+	assert.Equal(t, ErrInternal, CategoryOf(errors.New("boom")))
+	assert.Equal(t, ErrConflict, CategoryOf(NewConflictError("taken")))
+
+	var tagged *Error
+	assert.True(t, errors.As(NewNotFoundError("gone"), &tagged))
+	assert.Equal(t, ErrNotFound, tagged.Category())
+}
@@ -0,0 +1,154 @@
+// Package health probes the services the application depends on and
+// summarizes their state.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultTimeout bounds each probe.
+const DefaultTimeout = 5 * time.Second
+
+// Status is the outcome of a single probe.
+type Status string
+
+const (
+	// StatusOK means the dependency works.
+	StatusOK Status = "ok"
+	// StatusWarn means the dependency works in a reduced way, or needs attention soon.
+	StatusWarn Status = "warn"
+	// StatusFail means the dependency does not work.
+	StatusFail Status = "fail"
+	// StatusSkipped means the dependency is not configured or not used in this mode.
+	StatusSkipped Status = "skipped"
+)
+
+// Overall is the combined state of all probes.
+type Overall string
+
+const (
+	OverallHealthy   Overall = "healthy"
+	OverallDegraded  Overall = "degraded"
+	OverallUnhealthy Overall = "unhealthy"
+)
+
+// Result is the outcome of probing one dependency.
+type Result struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Hint tells the user how to fix a failing or degraded dependency.
+	Hint string `json:"hint,omitempty"`
+}
+
+// Check probes one dependency.
+type Check struct {
+	Name  string
+	Probe func(ctx context.Context) Result
+}
+
+// Report is the outcome of running a set of checks.
+type Report struct {
+	Status  Overall  `json:"status"`
+	Results []Result `json:"results"`
+}
+
+// Run runs the checks in order, each bounded by timeout, and summarizes them.
+// A probe that panics or overruns its timeout counts as failed.
+func Run(ctx context.Context, checks []Check, timeout time.Duration) Report {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	report := Report{Status: OverallHealthy, Results: make([]Result, 0, len(checks))}
+	for _, check := range checks {
+		result := runOne(ctx, check, timeout)
+		if result.Name == "" {
+			result.Name = check.Name
+		}
+		switch result.Status {
+		case StatusFail:
+			report.Status = OverallUnhealthy
+		case StatusWarn:
+			if report.Status == OverallHealthy {
+				report.Status = OverallDegraded
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+func runOne(ctx context.Context, check Check, timeout time.Duration) Result {
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan Result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- Result{Name: check.Name, Status: StatusFail, Detail: fmt.Sprintf("probe panicked: %v", r)}
+			}
+		}()
+		done <- check.Probe(checkCtx)
+	}()
+
+	select {
+	case result := <-done:
+		return result
+	case <-checkCtx.Done():
+		return Result{Name: check.Name, Status: StatusFail, Detail: fmt.Sprintf("no answer within %s", timeout)}
+	}
+}
+
+// Ping builds a check that calls ping and fails with hint when it errors.
+func Ping(name string, ping func(ctx context.Context) error, hint string) Check {
+	return Check{
+		Name: name,
+		Probe: func(ctx context.Context) Result {
+			if err := ping(ctx); err != nil {
+				return Fail(name, err, hint)
+			}
+			return OK(name, "reachable")
+		},
+	}
+}
+
+// Static builds a check that always reports result, e.g. for a dependency
+// whose state is known up front.
+func Static(result Result) Check {
+	return Check{
+		Name:  result.Name,
+		Probe: func(context.Context) Result { return result },
+	}
+}
+
+// OK reports a working dependency.
+func OK(name, detail string) Result {
+	return Result{Name: name, Status: StatusOK, Detail: detail}
+}
+
+// Warn reports a dependency that needs attention.
+func Warn(name, detail, hint string) Result {
+	return Result{Name: name, Status: StatusWarn, Detail: detail, Hint: hint}
+}
+
+// Fail reports a dependency that does not work.
+func Fail(name string, err error, hint string) Result {
+	detail := "failed"
+	if err != nil {
+		detail = err.Error()
+		if errors.Is(err, context.DeadlineExceeded) {
+			detail = "timed out"
+		}
+	}
+	return Result{Name: name, Status: StatusFail, Detail: detail, Hint: hint}
+}
+
+// Skipped reports a dependency that is not in use.
+func Skipped(name, detail string) Result {
+	return Result{Name: name, Status: StatusSkipped, Detail: detail}
+}
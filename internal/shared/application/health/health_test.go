@@ -0,0 +1,60 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/application/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	ok := health.Ping("database", func(context.Context) error { return nil }, "")
+	down := health.Ping("redis", func(context.Context) error { return errors.New("connection refused") }, "start redis")
+	degraded := health.Static(health.Warn("license", "expired, in grace period", "renew"))
+	skipped := health.Static(health.Skipped("calendar", "no calendars connected"))
+
+	tests := []struct {
+		name   string
+		checks []health.Check
+		want   health.Overall
+	}{
+		{name: "healthy", checks: []health.Check{ok, skipped}, want: health.OverallHealthy},
+		{name: "degraded", checks: []health.Check{ok, degraded, skipped}, want: health.OverallDegraded},
+		{name: "unhealthy", checks: []health.Check{down, degraded}, want: health.OverallUnhealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := health.Run(context.Background(), tt.checks, time.Second)
+			assert.Equal(t, tt.want, report.Status)
+			assert.Len(t, report.Results, len(tt.checks))
+		})
+	}
+
+	report := health.Run(context.Background(), []health.Check{down}, time.Second)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, health.Result{Name: "redis", Status: health.StatusFail, Detail: "connection refused", Hint: "start redis"}, report.Results[0])
+}
+
+func TestRun_FailsSlowAndPanickingProbes(t *testing.T) {
+	slow := health.Check{Name: "rabbitmq", Probe: func(ctx context.Context) health.Result {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return health.OK("rabbitmq", "late")
+	}}
+	panicking := health.Check{Name: "calendar", Probe: func(context.Context) health.Result {
+		panic("boom")
+	}}
+
+	report := health.Run(context.Background(), []health.Check{slow, panicking}, 20*time.Millisecond)
+
+	assert.Equal(t, health.OverallUnhealthy, report.Status)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, health.StatusFail, report.Results[0].Status)
+	assert.Contains(t, report.Results[0].Detail, "no answer within")
+	assert.Equal(t, health.StatusFail, report.Results[1].Status)
+	assert.Contains(t, report.Results[1].Detail, "boom")
+}
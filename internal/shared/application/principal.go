@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrPrincipalMismatch is returned when an operation targets a user other
+// than the authenticated principal in the context.
+var ErrPrincipalMismatch = errors.New("user does not match authenticated principal")
+
+// ErrNoPrincipal is returned when user data is accessed from a context that
+// carries neither an authenticated principal nor the system marker.
+var ErrNoPrincipal = errors.New("no authenticated principal")
+
+type principalKey struct{}
+
+type systemKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated user.
+func WithPrincipal(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, principalKey{}, userID)
+}
+
+// PrincipalFromContext returns the authenticated user carried by ctx, if any.
+func PrincipalFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(principalKey{}).(uuid.UUID)
+	return userID, ok
+}
+
+// WithSystem returns a context for background work acting for every user,
+// such as workers and the event consumers they drive. Such contexts may
+// access any user's data without a principal.
+func WithSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemKey{}, true)
+}
+
+// IsSystem reports whether ctx was marked by WithSystem.
+func IsSystem(ctx context.Context) bool {
+	system, _ := ctx.Value(systemKey{}).(bool)
+	return system
+}
+
+// RequirePrincipal fails unless ctx carries an authenticated principal or
+// is a system context.
+func RequirePrincipal(ctx context.Context) error {
+	if _, ok := PrincipalFromContext(ctx); ok || IsSystem(ctx) {
+		return nil
+	}
+	return Internal(ErrNoPrincipal)
+}
+
+// CheckPrincipal fails unless ctx carries userID as its authenticated
+// principal. A system context without a principal is allowed through; any
+// other context without one is rejected.
+//
+// A mismatch or a missing principal always indicates a bug in the caller,
+// so the error is tagged as internal rather than hidden behind a not-found
+// result.
+func CheckPrincipal(ctx context.Context, userID uuid.UUID) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return RequirePrincipal(ctx)
+	}
+	if principal == userID {
+		return nil
+	}
+	return Internal(fmt.Errorf("%w: got user %s, principal is %s", ErrPrincipalMismatch, userID, principal))
+}
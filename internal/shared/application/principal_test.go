@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipalFromContext(t *testing.T) {
+	_, ok := PrincipalFromContext(context.Background())
+	assert.False(t, ok)
+
+	userID := uuid.New()
+	principal, ok := PrincipalFromContext(WithPrincipal(context.Background(), userID))
+	assert.True(t, ok)
+	assert.Equal(t, userID, principal)
+}
+
+func TestCheckPrincipal(t *testing.T) {
+	userID := uuid.New()
+	ctx := WithPrincipal(context.Background(), userID)
+
+	assert.NoError(t, CheckPrincipal(ctx, userID))
+	// System contexts act for every user.
+	assert.NoError(t, CheckPrincipal(WithSystem(context.Background()), uuid.New()))
+	// Any other context without a principal is rejected.
+	err := CheckPrincipal(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, ErrNoPrincipal)
+	assert.ErrorIs(t, err, ErrInternal)
+
+	err = CheckPrincipal(ctx, uuid.New())
+	assert.ErrorIs(t, err, ErrPrincipalMismatch)
+	assert.ErrorIs(t, err, ErrInternal)
+	assert.Contains(t, err.Error(), userID.String())
+}
+
+func TestRequirePrincipal(t *testing.T) {
+	assert.NoError(t, RequirePrincipal(WithPrincipal(context.Background(), uuid.New())))
+	assert.NoError(t, RequirePrincipal(WithSystem(context.Background())))
+	assert.ErrorIs(t, RequirePrincipal(context.Background()), ErrNoPrincipal)
+}
@@ -0,0 +1,80 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidSort is returned for a sort order naming an unknown field or
+// direction.
+var ErrInvalidSort = errors.New("invalid sort order")
+
+// Sort directions.
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// SortKey is one field of a list sort order.
+type SortKey struct {
+	Field string
+	Order string // SortAsc or SortDesc
+}
+
+// ParseSort parses a sort order such as "priority:desc,due_date:asc": fields
+// in order of precedence, each with an optional direction that defaults to
+// descending. Every field must be one of fields. An empty order parses to no
+// keys.
+func ParseSort(spec string, fields []string) ([]SortKey, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		field, order, _ := strings.Cut(strings.TrimSpace(part), ":")
+		field = strings.ToLower(strings.TrimSpace(field))
+		order = strings.ToLower(strings.TrimSpace(order))
+		if !slices.Contains(fields, field) {
+			return nil, fmt.Errorf("%w: unknown field %q, use %s", ErrInvalidSort, field, strings.Join(fields, ", "))
+		}
+		if order == "" {
+			order = SortDesc
+		}
+		if order != SortAsc && order != SortDesc {
+			return nil, fmt.Errorf("%w: unknown direction %q for %s, use asc or desc", ErrInvalidSort, order, field)
+		}
+		keys = append(keys, SortKey{Field: field, Order: order})
+	}
+	return keys, nil
+}
+
+// FormatSort writes keys in the form ParseSort reads, with every direction
+// spelled out.
+func FormatSort(keys []SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.Field + ":" + key.Order
+	}
+	return strings.Join(parts, ",")
+}
+
+// ApplySortOrder sets the direction of every key to order. An empty order
+// leaves the keys as they are.
+func ApplySortOrder(keys []SortKey, order string) ([]SortKey, error) {
+	if order == "" {
+		return keys, nil
+	}
+	order = strings.ToLower(strings.TrimSpace(order))
+	if order != SortAsc && order != SortDesc {
+		return nil, fmt.Errorf("%w: unknown direction %q, use asc or desc", ErrInvalidSort, order)
+	}
+	applied := make([]SortKey, len(keys))
+	for i, key := range keys {
+		applied[i] = SortKey{Field: key.Field, Order: order}
+	}
+	return applied, nil
+}
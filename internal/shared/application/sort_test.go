@@ -0,0 +1,45 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	fields := []string{"priority", "due_date", "title"}
+
+	keys, err := ParseSort(" Priority , due_date:ASC ", fields)
+	require.NoError(t, err)
+	assert.Equal(t, []SortKey{{Field: "priority", Order: SortDesc}, {Field: "due_date", Order: SortAsc}}, keys)
+	assert.Equal(t, "priority:desc,due_date:asc", FormatSort(keys))
+
+	keys, err = ParseSort("", fields)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = ParseSort("priority,colour", fields)
+	assert.ErrorIs(t, err, ErrInvalidSort)
+	assert.ErrorContains(t, err, `unknown field "colour", use priority, due_date, title`)
+
+	_, err = ParseSort("title:up", fields)
+	assert.ErrorIs(t, err, ErrInvalidSort)
+	assert.ErrorContains(t, err, `unknown direction "up" for title`)
+}
+
+func TestApplySortOrder(t *testing.T) {
+	keys := []SortKey{{Field: "priority", Order: SortDesc}, {Field: "due_date", Order: SortAsc}}
+
+	applied, err := ApplySortOrder(keys, "ASC")
+	require.NoError(t, err)
+	assert.Equal(t, "priority:asc,due_date:asc", FormatSort(applied))
+	assert.Equal(t, SortDesc, keys[0].Order, "keys are not modified")
+
+	applied, err = ApplySortOrder(keys, "")
+	require.NoError(t, err)
+	assert.Equal(t, keys, applied)
+
+	_, err = ApplySortOrder(keys, "sideways")
+	assert.ErrorIs(t, err, ErrInvalidSort)
+}
@@ -1,6 +1,11 @@
 package application
 
-import "context"
+import (
+	"context"
+	"errors"
+
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
+)
 
 // UnitOfWork provides transactional support for aggregating multiple operations.
 type UnitOfWork interface {
@@ -13,6 +18,8 @@ type UnitOfWork interface {
 type UnitOfWorkFunc func(ctx context.Context) error
 
 // WithUnitOfWork executes the given function within a unit of work.
+// A concurrent modification detected while saving rolls the unit back and is
+// returned as a conflict, so callers can reload the aggregate and retry.
 func WithUnitOfWork(ctx context.Context, uow UnitOfWork, fn UnitOfWorkFunc) error {
 	txCtx, err := uow.Begin(ctx)
 	if err != nil {
@@ -21,6 +28,9 @@ func WithUnitOfWork(ctx context.Context, uow UnitOfWork, fn UnitOfWorkFunc) erro
 
 	if err := fn(txCtx); err != nil {
 		_ = uow.Rollback(txCtx)
+		if errors.Is(err, sharedDomain.ErrConcurrentModification) {
+			return Conflict(err)
+		}
 		return err
 	}
 
@@ -3,8 +3,10 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	sharedDomain "github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -72,6 +74,27 @@ func TestWithUnitOfWork(t *testing.T) {
 		uow.AssertNotCalled(t, "Commit", mock.Anything)
 	})
 
+	t.Run("reports concurrent modifications as conflicts", func(t *testing.T) {
+		uow := new(mockUnitOfWork)
+		ctx := context.Background()
+		txCtx := context.WithValue(ctx, "tx", "transaction")
+
+		uow.On("Begin", ctx).Return(txCtx, nil)
+		uow.On("Rollback", txCtx).Return(nil)
+
+		staleErr := fmt.Errorf("%w: habit 42 was modified by another process", sharedDomain.ErrConcurrentModification)
+		err := WithUnitOfWork(ctx, uow, func(ctx context.Context) error {
+			return staleErr
+		})
+
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.ErrorIs(t, err, sharedDomain.ErrConcurrentModification)
+		assert.EqualError(t, err, staleErr.Error())
+
+		uow.AssertExpectations(t)
+		uow.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
 	t.Run("returns error when begin fails", func(t *testing.T) {
 		uow := new(mockUnitOfWork)
 		ctx := context.Background()
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Clock returns the current time. Code that decides what is due, expired or
+// overdue takes a Clock instead of calling time.Now so tests can pin the time.
+type Clock func() time.Time
+
+// SystemClock reads the host clock.
+func SystemClock() time.Time {
+	return time.Now()
+}
+
+// FixedClock returns a clock that always reads t.
+func FixedClock(t time.Time) Clock {
+	return func() time.Time { return t }
+}
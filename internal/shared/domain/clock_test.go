@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedClock(t *testing.T) {
+	at := time.Date(2024, time.March, 4, 9, 30, 0, 0, time.UTC)
+	clock := FixedClock(at)
+
+	assert.Equal(t, at, clock())
+	assert.Equal(t, at, clock())
+}
+
+func TestSystemClock(t *testing.T) {
+	var clock Clock = SystemClock
+	assert.WithinDuration(t, time.Now(), clock(), time.Second)
+}
@@ -14,6 +14,9 @@ type DomainEvent interface {
 	RoutingKey() string
 	OccurredAt() time.Time
 	Metadata() EventMetadata
+	// SchemaVersion is the version of the event's payload shape. It starts
+	// at 1 and is raised whenever the shape changes incompatibly.
+	SchemaVersion() int
 }
 
 // EventMetadata contains tracing and context information for events.
@@ -31,6 +34,7 @@ type BaseEvent struct {
 	routingKey    string
 	occurredAt    time.Time
 	metadata      EventMetadata
+	schemaVersion int
 }
 
 // NewBaseEvent creates a new base event.
@@ -51,6 +55,20 @@ func (e BaseEvent) RoutingKey() string       { return e.routingKey }
 func (e BaseEvent) OccurredAt() time.Time    { return e.occurredAt }
 func (e BaseEvent) Metadata() EventMetadata  { return e.metadata }
 
+// SchemaVersion returns the payload version, 1 unless set otherwise.
+func (e BaseEvent) SchemaVersion() int {
+	if e.schemaVersion < 1 {
+		return 1
+	}
+	return e.schemaVersion
+}
+
+// SetSchemaVersion sets the payload version. Events whose shape changed set
+// their current version when created.
+func (e *BaseEvent) SetSchemaVersion(version int) {
+	e.schemaVersion = version
+}
+
 // SetMetadata sets the event metadata.
 func (e *BaseEvent) SetMetadata(metadata EventMetadata) {
 	e.metadata = metadata
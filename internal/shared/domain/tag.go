@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MaxTagLength is the longest tag accepted, in characters.
+const MaxTagLength = 32
+
+// ErrInvalidTag is returned when a tag is empty, too long or contains
+// whitespace or commas.
+var ErrInvalidTag = errors.New("invalid tag")
+
+// NormalizeTag trims and lowercases a tag so that "Work" and " work " name
+// the same tag.
+func NormalizeTag(value string) (string, error) {
+	tag := strings.ToLower(strings.TrimSpace(value))
+	if tag == "" {
+		return "", fmt.Errorf("%w: tag cannot be empty", ErrInvalidTag)
+	}
+	if len([]rune(tag)) > MaxTagLength {
+		return "", fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidTag, tag, MaxTagLength)
+	}
+	if strings.ContainsFunc(tag, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+		return "", fmt.Errorf("%w: %q contains whitespace or a comma", ErrInvalidTag, tag)
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes each tag and returns them sorted without
+// duplicates.
+func NormalizeTags(values []string) ([]string, error) {
+	seen := make(map[string]bool, len(values))
+	tags := make([]string, 0, len(values))
+	for _, value := range values {
+		tag, err := NormalizeTag(value)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
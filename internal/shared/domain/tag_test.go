@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "work", want: "work"},
+		{value: "  Deep-Work ", want: "deep-work"},
+		{value: "", wantErr: true},
+		{value: "   ", wantErr: true},
+		{value: "two words", wantErr: true},
+		{value: "a,b", wantErr: true},
+		{value: strings.Repeat("x", MaxTagLength), want: strings.Repeat("x", MaxTagLength)},
+		{value: strings.Repeat("x", MaxTagLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := NormalizeTag(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidTag)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{"Work", "home", "work"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"home", "work"}, tags)
+
+	_, err = NormalizeTags([]string{"ok", ""})
+	assert.ErrorIs(t, err, ErrInvalidTag)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTimezone is returned for a time zone that is not a known IANA
+// name.
+var ErrInvalidTimezone = errors.New("invalid time zone")
+
+// LoadTimezone resolves an IANA time zone name such as "Europe/Berlin". An
+// empty name resolves to nil, meaning no override.
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return nil, fmt.Errorf("%w: %q, use an IANA name such as Europe/Berlin", ErrInvalidTimezone, name)
+	}
+	return loc, nil
+}
+
+// SameDay reports whether two times fall on the same calendar day, each
+// read in its own location.
+func SameDay(a, b time.Time) bool {
+	y1, m1, d1 := a.Date()
+	y2, m2, d2 := b.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
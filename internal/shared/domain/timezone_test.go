@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	loc, err := LoadTimezone(" Asia/Tokyo ")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", loc.String())
+
+	loc, err = LoadTimezone("")
+	require.NoError(t, err)
+	assert.Nil(t, loc)
+
+	for _, name := range []string{"Mars/Olympus", "Local"} {
+		_, err = LoadTimezone(name)
+		assert.ErrorIs(t, err, ErrInvalidTimezone, name)
+	}
+}
+
+func TestSameDay(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	utc := time.Date(2025, 6, 3, 20, 0, 0, 0, time.UTC)
+	assert.True(t, SameDay(utc, time.Date(2025, 6, 3, 1, 0, 0, 0, time.UTC)))
+	assert.False(t, SameDay(utc, utc.In(tokyo)))
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultWeekStart is the first day of the week unless configured otherwise.
+const DefaultWeekStart = time.Monday
+
+// ErrInvalidWeekStart is returned when a week start day cannot be parsed.
+var ErrInvalidWeekStart = errors.New("invalid week start")
+
+// ParseWeekStart parses a day name such as "monday" or "sun" into the first
+// day of the week. An empty string yields DefaultWeekStart.
+func ParseWeekStart(value string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(value))
+	if name == "" {
+		return DefaultWeekStart, nil
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, nil
+		}
+	}
+	return DefaultWeekStart, fmt.Errorf("%w: %q", ErrInvalidWeekStart, value)
+}
+
+// StartOfWeek returns midnight on the first day of the week containing t,
+// where weeks begin on weekStart.
+func StartOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// StartOfNextWeek returns midnight on the first day of the week following
+// the one containing t.
+func StartOfNextWeek(t time.Time, weekStart time.Weekday) time.Time {
+	return StartOfWeek(t, weekStart).AddDate(0, 0, 7)
+}
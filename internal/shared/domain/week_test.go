@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWeekStart(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Weekday
+	}{
+		{"", time.Monday},
+		{"monday", time.Monday},
+		{"Sunday", time.Sunday},
+		{" sun ", time.Sunday},
+		{"sat", time.Saturday},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			day, err := ParseWeekStart(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, day)
+		})
+	}
+
+	t.Run("rejects unknown days", func(t *testing.T) {
+		_, err := ParseWeekStart("someday")
+		assert.ErrorIs(t, err, ErrInvalidWeekStart)
+	})
+}
+
+func TestStartOfWeek(t *testing.T) {
+	// Jan 4, 2026 is a Sunday; Jan 10, 2026 is a Saturday.
+	sunday := time.Date(2026, 1, 4, 15, 30, 0, 0, time.UTC)
+	monday := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, 1, 10, 23, 59, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		at        time.Time
+		weekStart time.Weekday
+		expected  time.Time
+	}{
+		{"monday start on sunday", sunday, time.Monday, time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC)},
+		{"monday start on monday", monday, time.Monday, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"monday start on saturday", saturday, time.Monday, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"sunday start on sunday", sunday, time.Sunday, time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"sunday start on monday", monday, time.Sunday, time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"sunday start on saturday", saturday, time.Sunday, time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			start := StartOfWeek(tc.at, tc.weekStart)
+			assert.Equal(t, tc.expected, start)
+			assert.Equal(t, tc.weekStart, start.Weekday())
+		})
+	}
+}
+
+func TestStartOfNextWeek(t *testing.T) {
+	sunday := time.Date(2026, 1, 4, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), StartOfNextWeek(sunday, time.Monday))
+	assert.Equal(t, time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC), StartOfNextWeek(sunday, time.Sunday))
+}
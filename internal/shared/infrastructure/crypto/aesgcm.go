@@ -29,6 +29,11 @@ func NewAESGCMFromBase64Key(encodedKey string) (*AESEncrypter, error) {
 	if err != nil {
 		return nil, err
 	}
+	return NewAESGCM(key)
+}
+
+// NewAESGCM creates an AESEncrypter from a raw 32-byte key.
+func NewAESGCM(key []byte) (*AESEncrypter, error) {
 	if len(key) != 32 {
 		return nil, errors.New("encryption key must be 32 bytes")
 	}
@@ -0,0 +1,126 @@
+package crypto
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// encryptedFieldPrefix marks a stored value as ciphertext. Values without it
+// are plaintext written before encryption was turned on.
+const encryptedFieldPrefix = "enc:v1:"
+
+// ErrNoFieldKey is returned when an encrypted value is read without a key.
+var ErrNoFieldKey = errors.New("field is encrypted but no encryption key is configured")
+
+// FieldEncrypter encrypts free-text fields such as descriptions and notes
+// before they are stored. Each user's fields are sealed with their own key,
+// derived from the master key, so one user's ciphertext cannot be opened
+// with another user's key.
+//
+// A nil *FieldEncrypter stores and returns values unchanged, except that it
+// refuses to return encrypted values it cannot open.
+type FieldEncrypter struct {
+	master   []byte
+	readOnly bool
+
+	mu    sync.Mutex
+	users map[uuid.UUID]*AESEncrypter
+}
+
+// NewFieldEncrypter creates a FieldEncrypter from a base64-encoded 32-byte
+// master key.
+func NewFieldEncrypter(encodedKey string) (*FieldEncrypter, error) {
+	if encodedKey == "" {
+		return nil, errors.New("encryption key is empty")
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != 32 {
+		return nil, errors.New("encryption key must be 32 bytes")
+	}
+	return &FieldEncrypter{master: key, users: make(map[uuid.UUID]*AESEncrypter)}, nil
+}
+
+// ReadOnly returns a FieldEncrypter with the same key that still opens
+// encrypted values but stores new ones as plaintext. It lets encryption be
+// turned off without losing access to what was already written.
+func (f *FieldEncrypter) ReadOnly() *FieldEncrypter {
+	return &FieldEncrypter{master: f.master, readOnly: true, users: make(map[uuid.UUID]*AESEncrypter)}
+}
+
+// Encrypts reports whether new values are stored encrypted. Plaintext copies
+// of such fields, like search index entries, must not be kept.
+func (f *FieldEncrypter) Encrypts() bool {
+	return f != nil && !f.readOnly
+}
+
+// Encrypt returns the value to store for a field owned by userID. Empty
+// values stay empty.
+func (f *FieldEncrypter) Encrypt(userID uuid.UUID, plaintext string) (string, error) {
+	if f == nil || f.readOnly || plaintext == "" {
+		return plaintext, nil
+	}
+	enc, err := f.forUser(userID)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := enc.Encrypt([]byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return encryptedFieldPrefix + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt returns the plaintext of a stored field owned by userID. Values
+// stored before encryption was turned on are returned as they are.
+func (f *FieldEncrypter) Decrypt(userID uuid.UUID, stored string) (string, error) {
+	encoded, ok := strings.CutPrefix(stored, encryptedFieldPrefix)
+	if !ok {
+		return stored, nil
+	}
+	if f == nil {
+		return "", ErrNoFieldKey
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode encrypted field: %w", err)
+	}
+	enc, err := f.forUser(userID)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := enc.Decrypt(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt field: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// forUser returns the encrypter keyed for userID, deriving the key on first use.
+func (f *FieldEncrypter) forUser(userID uuid.UUID) (*AESEncrypter, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if enc, ok := f.users[userID]; ok {
+		return enc, nil
+	}
+	key, err := hkdf.Key(sha256.New, f.master, nil, "orbita field encryption "+userID.String(), 32)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := NewAESGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	f.users[userID] = enc
+	return enc, nil
+}
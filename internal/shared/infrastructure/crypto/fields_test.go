@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldEncrypter(t *testing.T) {
+	fields, err := NewFieldEncrypter(generateValidKey())
+	require.NoError(t, err)
+	alice, bob := uuid.New(), uuid.New()
+
+	t.Run("round-trips a value", func(t *testing.T) {
+		stored, err := fields.Encrypt(alice, "call the bank about the loan")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(stored, encryptedFieldPrefix))
+		assert.NotContains(t, stored, "bank")
+
+		plaintext, err := fields.Decrypt(alice, stored)
+		require.NoError(t, err)
+		assert.Equal(t, "call the bank about the loan", plaintext)
+	})
+
+	t.Run("keeps empty values empty", func(t *testing.T) {
+		stored, err := fields.Encrypt(alice, "")
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+	})
+
+	t.Run("reads plaintext written before encryption", func(t *testing.T) {
+		plaintext, err := fields.Decrypt(alice, "an old note")
+		require.NoError(t, err)
+		assert.Equal(t, "an old note", plaintext)
+	})
+
+	t.Run("keys each user separately", func(t *testing.T) {
+		stored, err := fields.Encrypt(alice, "private")
+		require.NoError(t, err)
+
+		_, err = fields.Decrypt(bob, stored)
+		assert.Error(t, err)
+	})
+
+	t.Run("read-only stores plaintext but still opens ciphertext", func(t *testing.T) {
+		stored, err := fields.Encrypt(alice, "private")
+		require.NoError(t, err)
+
+		readOnly := fields.ReadOnly()
+		plaintext, err := readOnly.Decrypt(alice, stored)
+		require.NoError(t, err)
+		assert.Equal(t, "private", plaintext)
+
+		written, err := readOnly.Encrypt(alice, "new note")
+		require.NoError(t, err)
+		assert.Equal(t, "new note", written)
+		assert.True(t, fields.Encrypts())
+		assert.False(t, readOnly.Encrypts())
+	})
+
+	t.Run("nil encrypter passes plaintext through", func(t *testing.T) {
+		var none *FieldEncrypter
+		assert.False(t, none.Encrypts())
+		stored, err := none.Encrypt(alice, "note")
+		require.NoError(t, err)
+		assert.Equal(t, "note", stored)
+
+		encrypted, err := fields.Encrypt(alice, "note")
+		require.NoError(t, err)
+		_, err = none.Decrypt(alice, encrypted)
+		assert.ErrorIs(t, err, ErrNoFieldKey)
+	})
+}
+
+func TestNewFieldEncrypter_InvalidKey(t *testing.T) {
+	_, err := NewFieldEncrypter("")
+	assert.Error(t, err)
+
+	_, err = NewFieldEncrypter("c2hvcnQ=")
+	assert.ErrorContains(t, err, "32 bytes")
+}
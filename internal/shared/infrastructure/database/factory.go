@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Config holds database configuration.
@@ -24,6 +25,17 @@ type Config struct {
 
 	// MaxConns is the maximum number of connections (PostgreSQL only).
 	MaxConns int
+
+	// MinConns is the number of idle connections kept open (PostgreSQL only).
+	MinConns int
+
+	// MaxConnLifetime is how long a connection may live before it is
+	// replaced (PostgreSQL only).
+	MaxConnLifetime time.Duration
+
+	// MaxConnIdleTime is how long a connection may sit idle before it is
+	// closed (PostgreSQL only).
+	MaxConnIdleTime time.Duration
 }
 
 // NewConnection creates a database connection based on configuration.
@@ -26,18 +26,9 @@ func NewConnection(ctx context.Context, cfg database.Config) (database.Connectio
 		return nil, fmt.Errorf("database URL is required for PostgreSQL")
 	}
 
-	poolConfig, err := pgxpool.ParseConfig(cfg.URL)
+	pool, err := NewPool(ctx, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse database URL: %w", err)
-	}
-
-	if cfg.MaxConns > 0 {
-		poolConfig.MaxConns = int32(cfg.MaxConns)
-	}
-
-	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+		return nil, err
 	}
 
 	return &Connection{pool: pool}, nil
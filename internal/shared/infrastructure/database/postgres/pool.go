@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/database"
+)
+
+// NewPool creates a PostgreSQL connection pool tuned by cfg.
+// Connections are established lazily, so no round trip is made here.
+func NewPool(ctx context.Context, cfg database.Config) (*pgxpool.Pool, error) {
+	poolConfig, err := PoolConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+	}
+	return pool, nil
+}
+
+// PoolConfig parses cfg.URL and applies the pool settings from cfg.
+// Zero values keep the pgx defaults (or whatever the URL specifies).
+func PoolConfig(cfg database.Config) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database URL: %w", err)
+	}
+
+	if cfg.MaxConns > 0 {
+		poolConfig.MaxConns = int32(cfg.MaxConns)
+	}
+	if cfg.MinConns > 0 {
+		poolConfig.MinConns = int32(cfg.MinConns)
+	}
+	if cfg.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+
+	return poolConfig, nil
+}
+
+// PoolStats is a snapshot of connection pool usage.
+type PoolStats struct {
+	MaxConns          int32         `json:"max_conns"`
+	TotalConns        int32         `json:"total_conns"`
+	AcquiredConns     int32         `json:"acquired_conns"`
+	IdleConns         int32         `json:"idle_conns"`
+	ConstructingConns int32         `json:"constructing_conns"`
+	AcquireCount      int64         `json:"acquire_count"`
+	WaitCount         int64         `json:"wait_count"` // Acquires that had to wait for a free connection
+	WaitDuration      time.Duration `json:"wait_duration_ns"`
+	CanceledAcquires  int64         `json:"canceled_acquires"`
+}
+
+// Stats returns a snapshot of pool usage.
+func Stats(pool *pgxpool.Pool) PoolStats {
+	stat := pool.Stat()
+	return PoolStats{
+		MaxConns:          stat.MaxConns(),
+		TotalConns:        stat.TotalConns(),
+		AcquiredConns:     stat.AcquiredConns(),
+		IdleConns:         stat.IdleConns(),
+		ConstructingConns: stat.ConstructingConns(),
+		AcquireCount:      stat.AcquireCount(),
+		WaitCount:         stat.EmptyAcquireCount(),
+		WaitDuration:      stat.EmptyAcquireWaitTime(),
+		CanceledAcquires:  stat.CanceledAcquireCount(),
+	}
+}
+
+// Stats returns a snapshot of the connection's pool usage.
+func (c *Connection) Stats() PoolStats {
+	return Stats(c.pool)
+}
@@ -27,6 +27,16 @@ type ConsumedEvent struct {
 	OccurredAt    time.Time       `json:"occurred_at"`
 	Payload       json.RawMessage `json:"payload"`
 	Metadata      EventMetadata   `json:"metadata,omitempty"`
+	SchemaVersion int             `json:"schema_version,omitempty"`
+}
+
+// Version returns the schema version of the payload. Events published
+// before events were versioned are version 1.
+func (e *ConsumedEvent) Version() int {
+	if e.SchemaVersion < 1 {
+		return 1
+	}
+	return e.SchemaVersion
 }
 
 // EventMetadata contains optional metadata about the event.
@@ -9,6 +9,7 @@ import (
 // ConsumerRegistry manages event consumers and dispatches events to them.
 type ConsumerRegistry struct {
 	consumers map[string][]EventConsumer
+	upcasters *UpcasterRegistry
 	mu        sync.RWMutex
 	logger    *slog.Logger
 }
@@ -37,6 +38,15 @@ func (r *ConsumerRegistry) Register(consumer EventConsumer) {
 	}
 }
 
+// SetUpcasters brings events to their current schema version before they
+// are dispatched.
+func (r *ConsumerRegistry) SetUpcasters(upcasters *UpcasterRegistry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.upcasters = upcasters
+}
+
 // GetConsumers returns all consumers registered for the given event type.
 func (r *ConsumerRegistry) GetConsumers(eventType string) []EventConsumer {
 	r.mu.RLock()
@@ -57,7 +67,8 @@ func (r *ConsumerRegistry) GetAllEventTypes() []string {
 	return types
 }
 
-// Dispatch sends an event to all registered consumers for its event type.
+// Dispatch sends an event to all registered consumers for its event type,
+// upcast to its current schema version first.
 func (r *ConsumerRegistry) Dispatch(ctx context.Context, event *ConsumedEvent) error {
 	consumers := r.GetConsumers(event.RoutingKey)
 
@@ -68,6 +79,23 @@ func (r *ConsumerRegistry) Dispatch(ctx context.Context, event *ConsumedEvent) e
 		return nil
 	}
 
+	r.mu.RLock()
+	upcasters := r.upcasters
+	r.mu.RUnlock()
+	if upcasters != nil {
+		upcast, err := upcasters.Upcast(event)
+		if err != nil {
+			r.logger.Error("failed to upcast event",
+				"routing_key", event.RoutingKey,
+				"event_id", event.EventID,
+				"schema_version", event.Version(),
+				"error", err,
+			)
+			return err
+		}
+		event = upcast
+	}
+
 	var lastErr error
 	for _, consumer := range consumers {
 		if err := consumer.Handle(ctx, event); err != nil {
@@ -35,6 +35,12 @@ func (b *InProcessEventBus) RegisterConsumer(consumer EventConsumer) {
 	b.registry.Register(consumer)
 }
 
+// SetUpcasters brings older events to their current schema version before
+// consumers receive them.
+func (b *InProcessEventBus) SetUpcasters(upcasters *UpcasterRegistry) {
+	b.registry.SetUpcasters(upcasters)
+}
+
 // Publish sends an event to the bus, synchronously dispatching to all registered consumers.
 // Implements the Publisher interface for compatibility with existing code.
 func (b *InProcessEventBus) Publish(ctx context.Context, routingKey string, payload []byte) error {
@@ -86,7 +92,30 @@ func (b *InProcessEventBus) PublishDomainEvent(ctx context.Context, event domain
 	if err != nil {
 		return err
 	}
-	return b.Publish(ctx, event.RoutingKey(), payload)
+
+	metadata := event.Metadata()
+	return b.PublishConsumedEvent(ctx, &ConsumedEvent{
+		EventID:       event.EventID(),
+		AggregateID:   event.AggregateID(),
+		AggregateType: event.AggregateType(),
+		RoutingKey:    event.RoutingKey(),
+		OccurredAt:    event.OccurredAt(),
+		Payload:       payload,
+		Metadata: EventMetadata{
+			UserID:        metadata.UserID,
+			CorrelationID: uuidString(metadata.CorrelationID),
+			CausationID:   uuidString(metadata.CausationID),
+		},
+		SchemaVersion: event.SchemaVersion(),
+	})
+}
+
+// uuidString formats an ID, leaving an unset ID empty.
+func uuidString(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
 }
 
 // PublishConsumedEvent dispatches a consumed event directly.
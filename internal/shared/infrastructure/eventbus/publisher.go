@@ -13,6 +13,22 @@ type Publisher interface {
 	Close() error
 }
 
+// BatchMessage is a message published as part of a batch.
+type BatchMessage struct {
+	RoutingKey string
+	Payload    []byte
+}
+
+// BatchPublisher is implemented by publishers that can send several messages
+// in one round trip.
+type BatchPublisher interface {
+	Publisher
+
+	// PublishBatch sends the messages in order and returns one error per
+	// message, nil for each message the broker accepted.
+	PublishBatch(ctx context.Context, msgs []BatchMessage) []error
+}
+
 // PublishResult represents the result of a publish operation.
 type PublishResult struct {
 	Success bool
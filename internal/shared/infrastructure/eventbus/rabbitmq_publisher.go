@@ -2,6 +2,7 @@ package eventbus
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -13,32 +14,180 @@ import (
 const (
 	// ExchangeName is the name of the topic exchange for domain events
 	ExchangeName = "orbita.domain.events"
+
+	// DefaultReconnectInitialBackoff is the wait after the first failed reconnect.
+	DefaultReconnectInitialBackoff = 500 * time.Millisecond
+
+	// DefaultReconnectMaxBackoff caps the wait between reconnect attempts.
+	DefaultReconnectMaxBackoff = 30 * time.Second
+
+	// DefaultConfirmBatchSize is the number of messages published before
+	// waiting for the broker to confirm them.
+	DefaultConfirmBatchSize = 100
+
+	// DefaultConfirmTimeout is how long to wait for the broker to confirm a batch.
+	DefaultConfirmTimeout = 10 * time.Second
+)
+
+// Ensure RabbitMQPublisher implements BatchPublisher.
+var _ BatchPublisher = (*RabbitMQPublisher)(nil)
+
+var (
+	// ErrPublisherDisconnected is returned when the broker connection is down
+	// and the publisher is waiting out its reconnect backoff.
+	ErrPublisherDisconnected = errors.New("rabbitmq publisher disconnected")
+
+	// ErrPublishBufferFull is returned when the broker connection is down and
+	// the publish buffer has no room left.
+	ErrPublishBufferFull = errors.New("rabbitmq publish buffer full")
+
+	// ErrPublishNacked is returned for a message the broker refused to accept.
+	ErrPublishNacked = errors.New("rabbitmq nacked message")
+
+	// ErrConfirmTimeout is returned for a message the broker did not confirm
+	// in time. Its delivery is unknown.
+	ErrConfirmTimeout = errors.New("rabbitmq publish confirm timed out")
 )
 
-// RabbitMQPublisher publishes events to RabbitMQ.
+// RabbitMQPublisherConfig configures the RabbitMQ publisher.
+type RabbitMQPublisherConfig struct {
+	URL    string
+	Logger *slog.Logger
+
+	// ReconnectInitialBackoff and ReconnectMaxBackoff bound the exponential
+	// backoff between reconnect attempts after the connection drops.
+	ReconnectInitialBackoff time.Duration
+	ReconnectMaxBackoff     time.Duration
+
+	// BufferSize is the number of messages held in memory while disconnected.
+	// They are sent, in order, once the connection is restored. Zero fails
+	// fast instead, leaving retries to the caller (e.g. the outbox processor).
+	BufferSize int
+
+	// ConfirmBatchSize is the most messages PublishBatch sends before waiting
+	// for the broker to confirm them. ConfirmTimeout bounds that wait.
+	ConfirmBatchSize int
+	ConfirmTimeout   time.Duration
+}
+
+// amqpConnection is the subset of *amqp.Connection used by the publisher.
+type amqpConnection interface {
+	IsClosed() bool
+	Close() error
+}
+
+// amqpChannel is the subset of *amqp.Channel used by the publisher.
+type amqpChannel interface {
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	GetNextPublishSeqNo() uint64
+	NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation
+	IsClosed() bool
+	Close() error
+}
+
+// amqpDialer opens a connection and a channel in confirm mode with the
+// exchange declared.
+type amqpDialer func() (amqpConnection, amqpChannel, error)
+
+type bufferedMessage struct {
+	routingKey string
+	payload    []byte
+}
+
+// RabbitMQPublisher publishes events to RabbitMQ. Every message is
+// confirmed by the broker before it counts as published. When the connection
+// or channel closes it reconnects on the next publish, backing off between
+// failed attempts.
 type RabbitMQPublisher struct {
-	conn     *amqp.Connection
-	channel  *amqp.Channel
+	conn     amqpConnection
+	channel  amqpChannel
+	confirms chan amqp.Confirmation
+	dial     amqpDialer
 	exchange string
 	logger   *slog.Logger
 	mu       sync.Mutex
+
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	backoff        time.Duration
+	nextAttempt    time.Time
+	now            func() time.Time
+
+	bufferSize int
+	buffer     []bufferedMessage
+
+	confirmBatchSize int
+	confirmTimeout   time.Duration
 }
 
-// NewRabbitMQPublisher creates a new RabbitMQ publisher.
+// NewRabbitMQPublisher creates a new RabbitMQ publisher with default reconnect settings.
 func NewRabbitMQPublisher(url string, logger *slog.Logger) (*RabbitMQPublisher, error) {
-	if logger == nil {
-		logger = slog.Default()
+	return NewRabbitMQPublisherWithConfig(RabbitMQPublisherConfig{URL: url, Logger: logger})
+}
+
+// NewRabbitMQPublisherWithConfig creates a new RabbitMQ publisher.
+// The initial connection must succeed; later drops are recovered from.
+func NewRabbitMQPublisherWithConfig(cfg RabbitMQPublisherConfig) (*RabbitMQPublisher, error) {
+	url := cfg.URL
+	return newRabbitMQPublisher(cfg, func() (amqpConnection, amqpChannel, error) {
+		return dialRabbitMQ(url)
+	})
+}
+
+func newRabbitMQPublisher(cfg RabbitMQPublisherConfig, dial amqpDialer) (*RabbitMQPublisher, error) {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.ReconnectInitialBackoff <= 0 {
+		cfg.ReconnectInitialBackoff = DefaultReconnectInitialBackoff
+	}
+	if cfg.ReconnectMaxBackoff <= 0 {
+		cfg.ReconnectMaxBackoff = DefaultReconnectMaxBackoff
+	}
+	if cfg.ReconnectMaxBackoff < cfg.ReconnectInitialBackoff {
+		cfg.ReconnectMaxBackoff = cfg.ReconnectInitialBackoff
+	}
+	if cfg.ConfirmBatchSize <= 0 {
+		cfg.ConfirmBatchSize = DefaultConfirmBatchSize
+	}
+	if cfg.ConfirmTimeout <= 0 {
+		cfg.ConfirmTimeout = DefaultConfirmTimeout
 	}
 
+	conn, ch, err := dial()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Logger.Info("RabbitMQ publisher connected",
+		"exchange", ExchangeName,
+	)
+
+	p := &RabbitMQPublisher{
+		dial:             dial,
+		exchange:         ExchangeName,
+		logger:           cfg.Logger,
+		initialBackoff:   cfg.ReconnectInitialBackoff,
+		maxBackoff:       cfg.ReconnectMaxBackoff,
+		now:              time.Now,
+		bufferSize:       cfg.BufferSize,
+		confirmBatchSize: cfg.ConfirmBatchSize,
+		confirmTimeout:   cfg.ConfirmTimeout,
+	}
+	p.attach(conn, ch)
+	return p, nil
+}
+
+func dialRabbitMQ(url string) (amqpConnection, amqpChannel, error) {
 	conn, err := amqp.Dial(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
 	ch, err := conn.Channel()
 	if err != nil {
 		_ = conn.Close() // Best-effort cleanup
-		return nil, fmt.Errorf("failed to open channel: %w", err)
+		return nil, nil, fmt.Errorf("failed to open channel: %w", err)
 	}
 
 	// Declare the topic exchange
@@ -54,31 +203,206 @@ func NewRabbitMQPublisher(url string, logger *slog.Logger) (*RabbitMQPublisher,
 	if err != nil {
 		_ = ch.Close()   // Best-effort cleanup
 		_ = conn.Close() // Best-effort cleanup
-		return nil, fmt.Errorf("failed to declare exchange: %w", err)
+		return nil, nil, fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	logger.Info("RabbitMQ publisher connected",
-		"exchange", ExchangeName,
-	)
+	if err := ch.Confirm(false); err != nil {
+		_ = ch.Close()   // Best-effort cleanup
+		_ = conn.Close() // Best-effort cleanup
+		return nil, nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
 
-	return &RabbitMQPublisher{
-		conn:     conn,
-		channel:  ch,
-		exchange: ExchangeName,
-		logger:   logger,
-	}, nil
+	return conn, ch, nil
+}
+
+// attach starts using a freshly dialled connection and listens for its
+// publisher confirms. The listener holds a full batch, since a batch is
+// published before its confirms are read.
+func (p *RabbitMQPublisher) attach(conn amqpConnection, ch amqpChannel) {
+	p.conn = conn
+	p.channel = ch
+	p.confirms = ch.NotifyPublish(make(chan amqp.Confirmation, p.confirmBatchSize))
 }
 
 // Publish sends a message to the exchange with the given routing key.
+// A closed connection is re-established first; if that is not possible the
+// message is buffered when buffering is enabled, or an error is returned.
 func (p *RabbitMQPublisher) Publish(ctx context.Context, routingKey string, payload []byte) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	err := p.channel.PublishWithContext(ctx,
-		p.exchange,  // exchange
-		routingKey,  // routing key
-		false,       // mandatory
-		false,       // immediate
+	errs := make([]error, 1)
+	p.publishChunk(ctx, []BatchMessage{{RoutingKey: routingKey, Payload: payload}}, errs)
+
+	if err := errs[0]; err != nil {
+		p.logger.Error("failed to publish message",
+			"routing_key", routingKey,
+			"error", err,
+		)
+		return err
+	}
+
+	p.logger.Debug("message published",
+		"routing_key", routingKey,
+		"size", len(payload),
+	)
+
+	return nil
+}
+
+// PublishBatch sends the messages in order, waiting for the broker to
+// confirm each group of up to ConfirmBatchSize messages before sending the
+// next. Confirms are matched to messages by delivery tag, so each message
+// gets its own result: nil once acked, ErrPublishNacked if the broker
+// refused it, or the error that kept it from being confirmed.
+func (p *RabbitMQPublisher) PublishBatch(ctx context.Context, msgs []BatchMessage) []error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	errs := make([]error, len(msgs))
+	for start := 0; start < len(msgs); start += p.confirmBatchSize {
+		end := min(start+p.confirmBatchSize, len(msgs))
+		p.publishChunk(ctx, msgs[start:end], errs[start:end])
+	}
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		p.logger.Warn("batch published with failures",
+			"messages", len(msgs),
+			"failed", failed,
+		)
+	} else {
+		p.logger.Debug("batch published", "messages", len(msgs))
+	}
+
+	return errs
+}
+
+// publishChunk publishes up to a batch of messages and records each result
+// in errs. Messages lost to a dropped connection are retried once on a fresh
+// connection, or buffered when that is not possible.
+func (p *RabbitMQPublisher) publishChunk(ctx context.Context, msgs []BatchMessage, errs []error) {
+	if err := p.ensureConnected(); err != nil {
+		for i, msg := range msgs {
+			errs[i] = p.bufferOrFail(msg.RoutingKey, msg.Payload, err)
+		}
+		return
+	}
+
+	p.publishConfirmed(ctx, msgs, errs)
+	if !p.disconnected() {
+		return
+	}
+
+	// The connection dropped since the last publish; retry the messages it
+	// took down once on a fresh connection before giving up.
+	var retry []int
+	for i, err := range errs {
+		if err != nil && lostWithConnection(ctx, err) {
+			retry = append(retry, i)
+		}
+	}
+	if len(retry) == 0 {
+		return
+	}
+
+	retryMsgs := make([]BatchMessage, len(retry))
+	for j, i := range retry {
+		retryMsgs[j] = msgs[i]
+	}
+	retryErrs := make([]error, len(retry))
+	if err := p.ensureConnected(); err != nil {
+		for j, msg := range retryMsgs {
+			retryErrs[j] = p.bufferOrFail(msg.RoutingKey, msg.Payload, err)
+		}
+	} else {
+		p.publishConfirmed(ctx, retryMsgs, retryErrs)
+	}
+	for j, i := range retry {
+		errs[i] = retryErrs[j]
+	}
+}
+
+// lostWithConnection reports whether a message failed because the
+// connection went away, rather than being refused or left unconfirmed.
+func lostWithConnection(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && !errors.Is(err, ErrPublishNacked) && !errors.Is(err, ErrConfirmTimeout)
+}
+
+// publishConfirmed publishes the messages on the current channel, then waits
+// for the broker to confirm them. A publish that fails takes the rest of the
+// messages down with it, since the channel is no longer usable.
+func (p *RabbitMQPublisher) publishConfirmed(ctx context.Context, msgs []BatchMessage, errs []error) {
+	pending := make(map[uint64]int, len(msgs))
+	for i, msg := range msgs {
+		tag := p.channel.GetNextPublishSeqNo()
+		if err := p.publish(ctx, msg.RoutingKey, msg.Payload); err != nil {
+			for j := i; j < len(msgs); j++ {
+				errs[j] = err
+			}
+			break
+		}
+		pending[tag] = i
+	}
+
+	p.awaitConfirms(ctx, pending, errs)
+}
+
+// awaitConfirms matches broker confirms to the pending delivery tags. If the
+// confirms stop arriving, the channel is closed: its delivery tags can no
+// longer be trusted, so the next publish starts over on a new one.
+func (p *RabbitMQPublisher) awaitConfirms(ctx context.Context, pending map[uint64]int, errs []error) {
+	if len(pending) == 0 {
+		return
+	}
+
+	fail := func(err error) {
+		for _, i := range pending {
+			errs[i] = err
+		}
+	}
+
+	timer := time.NewTimer(p.confirmTimeout)
+	defer timer.Stop()
+
+	for len(pending) > 0 {
+		select {
+		case confirm, ok := <-p.confirms:
+			if !ok {
+				fail(amqp.ErrClosed)
+				return
+			}
+			i, ok := pending[confirm.DeliveryTag]
+			if !ok {
+				continue
+			}
+			delete(pending, confirm.DeliveryTag)
+			if !confirm.Ack {
+				errs[i] = fmt.Errorf("%w (delivery tag %d)", ErrPublishNacked, confirm.DeliveryTag)
+			}
+		case <-timer.C:
+			fail(fmt.Errorf("%w after %s", ErrConfirmTimeout, p.confirmTimeout))
+			p.closeQuietly()
+			return
+		case <-ctx.Done():
+			fail(ctx.Err())
+			p.closeQuietly()
+			return
+		}
+	}
+}
+
+func (p *RabbitMQPublisher) publish(ctx context.Context, routingKey string, payload []byte) error {
+	return p.channel.PublishWithContext(ctx,
+		p.exchange, // exchange
+		routingKey, // routing key
+		false,      // mandatory
+		false,      // immediate
 		amqp.Publishing{
 			ContentType:  "application/json",
 			DeliveryMode: amqp.Persistent,
@@ -86,35 +410,134 @@ func (p *RabbitMQPublisher) Publish(ctx context.Context, routingKey string, payl
 			Body:         payload,
 		},
 	)
+}
+
+func (p *RabbitMQPublisher) disconnected() bool {
+	return p.conn == nil || p.channel == nil || p.conn.IsClosed() || p.channel.IsClosed()
+}
+
+// ensureConnected reconnects if the connection or channel has closed, unless
+// a previous attempt failed and the backoff has not yet elapsed. Buffered
+// messages are flushed after a successful reconnect.
+func (p *RabbitMQPublisher) ensureConnected() error {
+	if !p.disconnected() {
+		return p.flushBuffer()
+	}
+
+	if now := p.now(); now.Before(p.nextAttempt) {
+		return fmt.Errorf("%w: next reconnect in %s", ErrPublisherDisconnected, p.nextAttempt.Sub(now).Round(time.Millisecond))
+	}
+
+	p.closeQuietly()
 
+	conn, ch, err := p.dial()
 	if err != nil {
-		p.logger.Error("failed to publish message",
-			"routing_key", routingKey,
+		if p.backoff == 0 {
+			p.backoff = p.initialBackoff
+		} else {
+			p.backoff = min(p.backoff*2, p.maxBackoff)
+		}
+		p.nextAttempt = p.now().Add(p.backoff)
+		p.logger.Warn("RabbitMQ reconnect failed",
 			"error", err,
+			"retry_in", p.backoff,
 		)
-		return err
+		return fmt.Errorf("%w: %w", ErrPublisherDisconnected, err)
 	}
 
-	p.logger.Debug("message published",
-		"routing_key", routingKey,
-		"size", len(payload),
+	p.attach(conn, ch)
+	p.backoff = 0
+	p.nextAttempt = time.Time{}
+	p.logger.Info("RabbitMQ publisher reconnected",
+		"exchange", p.exchange,
+		"buffered", len(p.buffer),
 	)
 
+	return p.flushBuffer()
+}
+
+// flushBuffer sends buffered messages in order, one confirmed message at a
+// time. Messages that fail stay buffered for the next reconnect.
+func (p *RabbitMQPublisher) flushBuffer() error {
+	for len(p.buffer) > 0 {
+		msg := p.buffer[0]
+		errs := make([]error, 1)
+		p.publishConfirmed(context.Background(), []BatchMessage{{RoutingKey: msg.routingKey, Payload: msg.payload}}, errs)
+		if err := errs[0]; err != nil {
+			p.logger.Warn("failed to flush buffered message",
+				"routing_key", msg.routingKey,
+				"remaining", len(p.buffer),
+				"error", err,
+			)
+			return err
+		}
+		p.buffer = p.buffer[1:]
+	}
+	p.buffer = nil
 	return nil
 }
 
+func (p *RabbitMQPublisher) bufferOrFail(routingKey string, payload []byte, cause error) error {
+	if p.bufferSize <= 0 {
+		return cause
+	}
+	if len(p.buffer) >= p.bufferSize {
+		return fmt.Errorf("%w (%d messages): %w", ErrPublishBufferFull, len(p.buffer), cause)
+	}
+	p.buffer = append(p.buffer, bufferedMessage{
+		routingKey: routingKey,
+		payload:    append([]byte(nil), payload...),
+	})
+	p.logger.Debug("message buffered while disconnected",
+		"routing_key", routingKey,
+		"buffered", len(p.buffer),
+	)
+	return nil
+}
+
+func (p *RabbitMQPublisher) closeQuietly() {
+	if p.channel != nil {
+		_ = p.channel.Close()
+	}
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+	p.channel = nil
+	p.conn = nil
+	p.confirms = nil
+}
+
+// Ping reports whether the broker connection is up, reconnecting first if it
+// has dropped.
+func (p *RabbitMQPublisher) Ping(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ensureConnected()
+}
+
+// Buffered returns the number of messages waiting for the connection to return.
+func (p *RabbitMQPublisher) Buffered() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.buffer)
+}
+
 // Close closes the publisher connection.
 func (p *RabbitMQPublisher) Close() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if p.channel != nil {
+	if len(p.buffer) > 0 {
+		p.logger.Warn("closing publisher with unsent buffered messages", "count", len(p.buffer))
+	}
+
+	if p.channel != nil && !p.channel.IsClosed() {
 		if err := p.channel.Close(); err != nil {
 			p.logger.Warn("error closing channel", "error", err)
 		}
 	}
 
-	if p.conn != nil {
+	if p.conn != nil && !p.conn.IsClosed() {
 		if err := p.conn.Close(); err != nil {
 			return err
 		}
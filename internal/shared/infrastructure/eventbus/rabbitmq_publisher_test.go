@@ -0,0 +1,283 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAMQPConn struct {
+	closed bool
+}
+
+func (c *fakeAMQPConn) IsClosed() bool { return c.closed }
+func (c *fakeAMQPConn) Close() error   { c.closed = true; return nil }
+
+type fakeAMQPChannel struct {
+	conn      *fakeAMQPConn
+	closed    bool
+	published []string
+
+	// The channel confirms every publish in order, nacking the routing keys
+	// in nack and leaving those in unconfirmed without a confirm.
+	tag         uint64
+	confirms    chan amqp.Confirmation
+	nack        map[string]bool
+	unconfirmed map[string]bool
+}
+
+func (ch *fakeAMQPChannel) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	if ch.IsClosed() {
+		return amqp.ErrClosed
+	}
+	ch.tag++
+	ch.published = append(ch.published, key)
+	if ch.confirms != nil && !ch.unconfirmed[key] {
+		ch.confirms <- amqp.Confirmation{DeliveryTag: ch.tag, Ack: !ch.nack[key]}
+	}
+	return nil
+}
+
+func (ch *fakeAMQPChannel) GetNextPublishSeqNo() uint64 { return ch.tag + 1 }
+
+func (ch *fakeAMQPChannel) NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation {
+	ch.confirms = confirm
+	return confirm
+}
+
+func (ch *fakeAMQPChannel) IsClosed() bool { return ch.closed || ch.conn.closed }
+func (ch *fakeAMQPChannel) Close() error   { ch.closed = true; return nil }
+
+// fakeBroker hands out fake connections and can refuse new ones.
+type fakeBroker struct {
+	dials    int
+	down     bool
+	channels []*fakeAMQPChannel
+}
+
+func (b *fakeBroker) dial() (amqpConnection, amqpChannel, error) {
+	b.dials++
+	if b.down {
+		return nil, nil, errors.New("connection refused")
+	}
+	conn := &fakeAMQPConn{}
+	ch := &fakeAMQPChannel{conn: conn}
+	b.channels = append(b.channels, ch)
+	return conn, ch, nil
+}
+
+// drop simulates the broker closing the current connection.
+func (b *fakeBroker) drop() {
+	b.channels[len(b.channels)-1].conn.closed = true
+}
+
+func (b *fakeBroker) current() *fakeAMQPChannel {
+	return b.channels[len(b.channels)-1]
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestPublisher(t *testing.T, broker *fakeBroker, cfg RabbitMQPublisherConfig) (*RabbitMQPublisher, *fakeClock) {
+	t.Helper()
+	publisher, err := newRabbitMQPublisher(cfg, broker.dial)
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	publisher.now = clock.Now
+	return publisher, clock
+}
+
+func TestRabbitMQPublisher_ReconnectsAfterDroppedConnection(t *testing.T) {
+	broker := &fakeBroker{}
+	publisher, _ := newTestPublisher(t, broker, RabbitMQPublisherConfig{})
+	ctx := context.Background()
+
+	require.NoError(t, publisher.Publish(ctx, "task.created", []byte(`{}`)))
+	assert.Equal(t, []string{"task.created"}, broker.current().published)
+
+	broker.drop()
+
+	require.NoError(t, publisher.Publish(ctx, "task.completed", []byte(`{}`)))
+	assert.Equal(t, 2, broker.dials)
+	assert.Equal(t, []string{"task.completed"}, broker.current().published)
+}
+
+func TestRabbitMQPublisher_RetriesWhenChannelClosesMidPublish(t *testing.T) {
+	broker := &fakeBroker{}
+	publisher, _ := newTestPublisher(t, broker, RabbitMQPublisherConfig{})
+
+	// The channel reports open until the publish itself fails.
+	first := broker.current()
+	publisher.channel = &closingChannel{fakeAMQPChannel: first}
+
+	require.NoError(t, publisher.Publish(context.Background(), "task.created", []byte(`{}`)))
+	assert.Equal(t, 2, broker.dials)
+	assert.Empty(t, first.published)
+	assert.Equal(t, []string{"task.created"}, broker.current().published)
+}
+
+// closingChannel closes itself on the first publish, like a channel whose
+// connection drops while a message is in flight.
+type closingChannel struct {
+	*fakeAMQPChannel
+}
+
+func (ch *closingChannel) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	ch.conn.closed = true
+	return amqp.ErrClosed
+}
+
+func TestRabbitMQPublisher_FailsFastDuringBackoff(t *testing.T) {
+	broker := &fakeBroker{}
+	publisher, clock := newTestPublisher(t, broker, RabbitMQPublisherConfig{
+		ReconnectInitialBackoff: time.Second,
+		ReconnectMaxBackoff:     3 * time.Second,
+	})
+	ctx := context.Background()
+
+	broker.drop()
+	broker.down = true
+
+	err := publisher.Publish(ctx, "task.created", []byte(`{}`))
+	assert.ErrorIs(t, err, ErrPublisherDisconnected)
+	assert.Equal(t, 2, broker.dials)
+
+	// Within the backoff window no reconnect is attempted.
+	err = publisher.Publish(ctx, "task.created", []byte(`{}`))
+	assert.ErrorIs(t, err, ErrPublisherDisconnected)
+	assert.Equal(t, 2, broker.dials)
+
+	// The backoff doubles after each failure, up to the maximum.
+	clock.now = clock.now.Add(time.Second)
+	_ = publisher.Publish(ctx, "task.created", []byte(`{}`))
+	assert.Equal(t, 3, broker.dials)
+	assert.Equal(t, 2*time.Second, publisher.backoff)
+
+	clock.now = clock.now.Add(2 * time.Second)
+	_ = publisher.Publish(ctx, "task.created", []byte(`{}`))
+	assert.Equal(t, 4, broker.dials)
+	assert.Equal(t, 3*time.Second, publisher.backoff)
+
+	// Once the broker is back, the next publish after the backoff succeeds.
+	broker.down = false
+	clock.now = clock.now.Add(3 * time.Second)
+	require.NoError(t, publisher.Publish(ctx, "task.created", []byte(`{}`)))
+	assert.Equal(t, 5, broker.dials)
+	assert.Equal(t, []string{"task.created"}, broker.current().published)
+	assert.Zero(t, publisher.backoff)
+}
+
+func TestRabbitMQPublisher_BuffersWhileDisconnected(t *testing.T) {
+	broker := &fakeBroker{}
+	publisher, clock := newTestPublisher(t, broker, RabbitMQPublisherConfig{
+		ReconnectInitialBackoff: time.Second,
+		BufferSize:              2,
+	})
+	ctx := context.Background()
+
+	broker.drop()
+	broker.down = true
+
+	require.NoError(t, publisher.Publish(ctx, "task.created", []byte(`{}`)))
+	require.NoError(t, publisher.Publish(ctx, "task.started", []byte(`{}`)))
+	assert.Equal(t, 2, publisher.Buffered())
+
+	err := publisher.Publish(ctx, "task.completed", []byte(`{}`))
+	assert.ErrorIs(t, err, ErrPublishBufferFull)
+
+	// Buffered messages are flushed in order ahead of the new one.
+	broker.down = false
+	clock.now = clock.now.Add(time.Second)
+	require.NoError(t, publisher.Publish(ctx, "task.completed", []byte(`{}`)))
+	assert.Equal(t, []string{"task.created", "task.started", "task.completed"}, broker.current().published)
+	assert.Zero(t, publisher.Buffered())
+}
+
+func TestRabbitMQPublisher_PublishBatch(t *testing.T) {
+	broker := &fakeBroker{}
+	publisher, _ := newTestPublisher(t, broker, RabbitMQPublisherConfig{ConfirmBatchSize: 2})
+
+	errs := publisher.PublishBatch(context.Background(), []BatchMessage{
+		{RoutingKey: "task.created", Payload: []byte(`{}`)},
+		{RoutingKey: "task.started", Payload: []byte(`{}`)},
+		{RoutingKey: "task.completed", Payload: []byte(`{}`)},
+	})
+
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+	assert.Equal(t, []string{"task.created", "task.started", "task.completed"}, broker.current().published)
+}
+
+func TestRabbitMQPublisher_PublishBatchPartialNack(t *testing.T) {
+	broker := &fakeBroker{}
+	publisher, _ := newTestPublisher(t, broker, RabbitMQPublisherConfig{})
+	broker.current().nack = map[string]bool{"task.started": true}
+
+	errs := publisher.PublishBatch(context.Background(), []BatchMessage{
+		{RoutingKey: "task.created", Payload: []byte(`{}`)},
+		{RoutingKey: "task.started", Payload: []byte(`{}`)},
+		{RoutingKey: "task.completed", Payload: []byte(`{}`)},
+	})
+
+	require.Len(t, errs, 3)
+	assert.NoError(t, errs[0])
+	assert.ErrorIs(t, errs[1], ErrPublishNacked)
+	assert.NoError(t, errs[2])
+
+	// A nack leaves the connection usable.
+	assert.Equal(t, 1, broker.dials)
+	require.NoError(t, publisher.Publish(context.Background(), "task.archived", []byte(`{}`)))
+}
+
+func TestRabbitMQPublisher_PublishBatchConfirmTimeout(t *testing.T) {
+	broker := &fakeBroker{}
+	publisher, _ := newTestPublisher(t, broker, RabbitMQPublisherConfig{ConfirmTimeout: 10 * time.Millisecond})
+	broker.current().unconfirmed = map[string]bool{"task.started": true}
+
+	errs := publisher.PublishBatch(context.Background(), []BatchMessage{
+		{RoutingKey: "task.created", Payload: []byte(`{}`)},
+		{RoutingKey: "task.started", Payload: []byte(`{}`)},
+	})
+
+	require.Len(t, errs, 2)
+	assert.NoError(t, errs[0])
+	assert.ErrorIs(t, errs[1], ErrConfirmTimeout)
+
+	// The unconfirmed message is not retried, but the next publish starts
+	// over on a fresh channel.
+	assert.Equal(t, 1, broker.dials)
+	require.NoError(t, publisher.Publish(context.Background(), "task.completed", []byte(`{}`)))
+	assert.Equal(t, 2, broker.dials)
+}
+
+func TestRabbitMQPublisher_PublishBatchRetriesAfterDroppedConnection(t *testing.T) {
+	broker := &fakeBroker{}
+	publisher, _ := newTestPublisher(t, broker, RabbitMQPublisherConfig{})
+
+	first := broker.current()
+	publisher.channel = &closingChannel{fakeAMQPChannel: first}
+
+	errs := publisher.PublishBatch(context.Background(), []BatchMessage{
+		{RoutingKey: "task.created", Payload: []byte(`{}`)},
+		{RoutingKey: "task.started", Payload: []byte(`{}`)},
+	})
+
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, 2, broker.dials)
+	assert.Equal(t, []string{"task.created", "task.started"}, broker.current().published)
+}
+
+func TestNewRabbitMQPublisher_InitialConnectFailure(t *testing.T) {
+	broker := &fakeBroker{down: true}
+
+	_, err := newRabbitMQPublisher(RabbitMQPublisherConfig{}, broker.dial)
+
+	assert.Error(t, err)
+}
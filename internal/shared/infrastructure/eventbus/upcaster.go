@@ -0,0 +1,81 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUpcastFailed is returned when an event cannot be brought to its current
+// schema version.
+var ErrUpcastFailed = errors.New("event upcast failed")
+
+// Upcaster transforms the payload of one version of an event into the shape
+// of the next version.
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+// UpcasterRegistry holds the upcasters per event type and brings older
+// events to their current version before consumers see them.
+type UpcasterRegistry struct {
+	upcasters map[string]map[int]Upcaster
+	mu        sync.RWMutex
+}
+
+// NewUpcasterRegistry creates an empty upcaster registry.
+func NewUpcasterRegistry() *UpcasterRegistry {
+	return &UpcasterRegistry{
+		upcasters: make(map[string]map[int]Upcaster),
+	}
+}
+
+// Register adds the upcaster that turns version from of the event type into
+// version from+1. Registering the same step again replaces it.
+func (r *UpcasterRegistry) Register(eventType string, from int, upcaster Upcaster) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.upcasters[eventType] == nil {
+		r.upcasters[eventType] = make(map[int]Upcaster)
+	}
+	r.upcasters[eventType][from] = upcaster
+}
+
+// CurrentVersion returns the version events of the type are upcast to: one
+// past the last registered step, or 1 without upcasters.
+func (r *UpcasterRegistry) CurrentVersion(eventType string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	version := 1
+	for r.upcasters[eventType][version] != nil {
+		version++
+	}
+	return version
+}
+
+// Upcast returns the event at its current version, applying the registered
+// steps in order. The event passed in is not modified; an event already at
+// its current version is returned as is.
+func (r *UpcasterRegistry) Upcast(event *ConsumedEvent) (*ConsumedEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	steps := r.upcasters[event.RoutingKey]
+	version := event.Version()
+	if steps[version] == nil {
+		return event, nil
+	}
+
+	upcast := *event
+	for steps[version] != nil {
+		payload, err := steps[version](upcast.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s from v%d: %w", ErrUpcastFailed, event.RoutingKey, version, err)
+		}
+		upcast.Payload = payload
+		version++
+	}
+	upcast.SchemaVersion = version
+	return &upcast, nil
+}
@@ -0,0 +1,142 @@
+package eventbus_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/domain"
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/eventbus"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taskCreatedV1 carried an estimate in hours; v2 carries the duration in
+// minutes instead.
+type taskCreatedV1 struct {
+	Title         string  `json:"title"`
+	EstimateHours float64 `json:"estimate_hours"`
+}
+
+type taskCreatedV2 struct {
+	Title           string `json:"title"`
+	DurationMinutes int    `json:"duration_minutes"`
+}
+
+func upcastTaskCreatedV1(payload json.RawMessage) (json.RawMessage, error) {
+	var v1 taskCreatedV1
+	if err := json.Unmarshal(payload, &v1); err != nil {
+		return nil, err
+	}
+	return json.Marshal(taskCreatedV2{Title: v1.Title, DurationMinutes: int(v1.EstimateHours * 60)})
+}
+
+func TestUpcasterRegistry_Upcast(t *testing.T) {
+	upcasters := eventbus.NewUpcasterRegistry()
+	assert.Equal(t, 1, upcasters.CurrentVersion("core.task.created"))
+
+	upcasters.Register("core.task.created", 1, upcastTaskCreatedV1)
+	upcasters.Register("core.task.created", 2, func(payload json.RawMessage) (json.RawMessage, error) {
+		var v2 map[string]any
+		if err := json.Unmarshal(payload, &v2); err != nil {
+			return nil, err
+		}
+		v2["priority"] = "none"
+		return json.Marshal(v2)
+	})
+	assert.Equal(t, 3, upcasters.CurrentVersion("core.task.created"))
+
+	event := &eventbus.ConsumedEvent{
+		EventID:    uuid.New(),
+		RoutingKey: "core.task.created",
+		Payload:    json.RawMessage(`{"title":"Write report","estimate_hours":1.5}`),
+	}
+	upcast, err := upcasters.Upcast(event)
+	require.NoError(t, err)
+	assert.Equal(t, 3, upcast.SchemaVersion)
+	assert.Equal(t, event.EventID, upcast.EventID)
+	assert.JSONEq(t, `{"title":"Write report","duration_minutes":90,"priority":"none"}`, string(upcast.Payload))
+
+	// The original event is left alone
+	assert.Equal(t, 1, event.Version())
+	assert.JSONEq(t, `{"title":"Write report","estimate_hours":1.5}`, string(event.Payload))
+
+	// Current and unknown events pass through
+	current, err := upcasters.Upcast(upcast)
+	require.NoError(t, err)
+	assert.Same(t, upcast, current)
+	other := &eventbus.ConsumedEvent{RoutingKey: "habits.habit.created"}
+	passed, err := upcasters.Upcast(other)
+	require.NoError(t, err)
+	assert.Same(t, other, passed)
+}
+
+func TestUpcasterRegistry_UpcastFails(t *testing.T) {
+	upcasters := eventbus.NewUpcasterRegistry()
+	upcasters.Register("core.task.created", 1, upcastTaskCreatedV1)
+
+	_, err := upcasters.Upcast(&eventbus.ConsumedEvent{
+		RoutingKey: "core.task.created",
+		Payload:    json.RawMessage(`not json`),
+	})
+	assert.ErrorIs(t, err, eventbus.ErrUpcastFailed)
+	assert.ErrorContains(t, err, "core.task.created from v1")
+}
+
+type versionedTaskCreated struct {
+	domain.BaseEvent
+	Title         string  `json:"title"`
+	EstimateHours float64 `json:"estimate_hours"`
+}
+
+func TestInProcessEventBus_UpcastsBeforeDispatch(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	bus := eventbus.NewInProcessEventBus(logger)
+
+	upcasters := eventbus.NewUpcasterRegistry()
+	upcasters.Register("core.task.created", 1, upcastTaskCreatedV1)
+	bus.SetUpcasters(upcasters)
+
+	consumer := &mockConsumer{eventTypes: []string{"core.task.created"}}
+	bus.RegisterConsumer(consumer)
+
+	// A v1 event, as written before the payload changed
+	event := &versionedTaskCreated{
+		BaseEvent:     domain.NewBaseEvent(uuid.New(), "Task", "core.task.created"),
+		Title:         "Write report",
+		EstimateHours: 0.5,
+	}
+	require.NoError(t, bus.PublishDomainEvent(context.Background(), event))
+
+	require.Len(t, consumer.events, 1)
+	received := consumer.events[0]
+	assert.Equal(t, 2, received.SchemaVersion)
+	assert.Equal(t, event.EventID(), received.EventID)
+	assert.Equal(t, event.AggregateID(), received.AggregateID)
+
+	var payload taskCreatedV2
+	require.NoError(t, json.Unmarshal(received.Payload, &payload))
+	assert.Equal(t, taskCreatedV2{Title: "Write report", DurationMinutes: 30}, payload)
+}
+
+func TestConsumerRegistry_DispatchUpcastFailure(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	registry := eventbus.NewConsumerRegistry(logger)
+
+	upcasters := eventbus.NewUpcasterRegistry()
+	upcasters.Register("core.task.created", 1, func(json.RawMessage) (json.RawMessage, error) {
+		return nil, errors.New("missing title")
+	})
+	registry.SetUpcasters(upcasters)
+
+	consumer := &mockConsumer{eventTypes: []string{"core.task.created"}}
+	registry.Register(consumer)
+
+	err := registry.Dispatch(context.Background(), &eventbus.ConsumedEvent{RoutingKey: "core.task.created"})
+	assert.ErrorIs(t, err, eventbus.ErrUpcastFailed)
+	assert.Empty(t, consumer.events, "consumers never see an event they cannot read")
+}
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 )
 
 //go:embed sqlite/*.sql
 var sqliteFS embed.FS
 
-// RunSQLiteMigrations executes all SQLite migrations in order.
+// RunSQLiteMigrations executes all pending SQLite migrations in order.
+// Applied migrations are recorded in schema_migrations so that migrations
+// which are not idempotent (e.g. ALTER TABLE ADD COLUMN) run exactly once.
 func RunSQLiteMigrations(ctx context.Context, db *sql.DB) error {
 	// Read all migration files
 	entries, err := sqliteFS.ReadDir("sqlite")
@@ -29,18 +32,64 @@ func RunSQLiteMigrations(ctx context.Context, db *sql.DB) error {
 	}
 	sort.Strings(upFiles)
 
-	// Execute each migration in order
+	applied, err := appliedSQLiteMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	// Execute each pending migration in order
 	for _, file := range upFiles {
+		version := strings.TrimSuffix(file, ".up.sql")
+		if applied[version] {
+			continue
+		}
+
 		migration, err := sqliteFS.ReadFile("sqlite/" + file)
 		if err != nil {
 			return fmt.Errorf("failed to read migration %s: %w", file, err)
 		}
 
-		// Execute the migration (CREATE TABLE IF NOT EXISTS is idempotent)
 		if _, err := db.ExecContext(ctx, string(migration)); err != nil {
 			return fmt.Errorf("failed to execute migration %s: %w", file, err)
 		}
+
+		if _, err := db.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)",
+			version, time.Now().UTC().Format(time.RFC3339),
+		); err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", file, err)
+		}
 	}
 
 	return nil
 }
+
+// appliedSQLiteMigrations returns the set of migration versions already applied.
+// Databases created before tracking existed have an empty set; their earlier
+// migrations use CREATE ... IF NOT EXISTS and are safe to re-run once.
+func appliedSQLiteMigrations(ctx context.Context, db *sql.DB) (map[string]bool, error) {
+	if _, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version TEXT PRIMARY KEY,
+			applied_at TEXT NOT NULL
+		)
+	`); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
@@ -0,0 +1,3 @@
+-- Remove trial end and scheduled downgrade tracking
+ALTER TABLE subscriptions DROP COLUMN pending_plan;
+ALTER TABLE subscriptions DROP COLUMN trial_ends_at;
@@ -0,0 +1,3 @@
+-- Trial end and scheduled downgrade tracking for subscriptions
+ALTER TABLE subscriptions ADD COLUMN trial_ends_at TEXT;
+ALTER TABLE subscriptions ADD COLUMN pending_plan TEXT NOT NULL DEFAULT '';
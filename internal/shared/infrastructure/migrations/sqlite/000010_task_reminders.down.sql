@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_task_reminders_pending;
+DROP TABLE IF EXISTS task_reminders;
@@ -0,0 +1,10 @@
+-- Task reminders
+CREATE TABLE IF NOT EXISTS task_reminders (
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    offset_minutes INTEGER NOT NULL CHECK (offset_minutes > 0),
+    remind_at TEXT,
+    sent_at TEXT,
+    PRIMARY KEY (task_id, offset_minutes)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_reminders_pending ON task_reminders (remind_at) WHERE sent_at IS NULL;
@@ -0,0 +1,12 @@
+-- Task checklist items
+ALTER TABLE tasks ADD COLUMN checklist_required INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS task_checklist_items (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    done INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_checklist_items_task ON task_checklist_items (task_id, position);
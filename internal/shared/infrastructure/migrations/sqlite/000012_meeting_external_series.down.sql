@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_meetings_user_external_series;
+ALTER TABLE meetings DROP COLUMN external_series_id;
@@ -0,0 +1 @@
+ALTER TABLE calendar_sync_state DROP COLUMN imported_blocks;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_inbox_items_unprocessed;
+ALTER TABLE inbox_items DROP COLUMN stale_at;
+ALTER TABLE inbox_items DROP COLUMN archived_at;
+ALTER TABLE inbox_items DROP COLUMN archived;
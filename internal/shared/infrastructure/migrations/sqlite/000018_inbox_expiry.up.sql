@@ -0,0 +1,6 @@
+-- Expired inbox items are archived or flagged stale
+ALTER TABLE inbox_items ADD COLUMN archived INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE inbox_items ADD COLUMN archived_at TEXT;
+ALTER TABLE inbox_items ADD COLUMN stale_at TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_inbox_items_unprocessed ON inbox_items (promoted, archived, captured_at);
@@ -0,0 +1,4 @@
+ALTER TABLE user_settings DROP COLUMN digest_last_sent_at;
+ALTER TABLE user_settings DROP COLUMN digest_timezone;
+ALTER TABLE user_settings DROP COLUMN digest_time;
+ALTER TABLE user_settings DROP COLUMN digest_frequency;
@@ -0,0 +1,5 @@
+-- Scheduled digest: frequency, local send time (HH:MM), IANA time zone and last delivery
+ALTER TABLE user_settings ADD COLUMN digest_frequency TEXT NOT NULL DEFAULT '';
+ALTER TABLE user_settings ADD COLUMN digest_time TEXT NOT NULL DEFAULT '';
+ALTER TABLE user_settings ADD COLUMN digest_timezone TEXT NOT NULL DEFAULT '';
+ALTER TABLE user_settings ADD COLUMN digest_last_sent_at TEXT;
@@ -0,0 +1,37 @@
+-- Blocked tasks return to pending
+-- SQLite cannot alter a CHECK constraint, so the tasks table is rebuilt.
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE tasks_new (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    description TEXT,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'in_progress', 'completed', 'archived')),
+    priority TEXT NOT NULL DEFAULT 'none' CHECK (priority IN ('none', 'low', 'medium', 'high', 'urgent')),
+    duration_minutes INTEGER,
+    due_date TEXT,
+    completed_at TEXT,
+    version INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    checklist_required INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO tasks_new (id, user_id, title, description, status, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at, checklist_required)
+SELECT id, user_id, title, description, CASE status WHEN 'blocked' THEN 'pending' ELSE status END, priority, duration_minutes, due_date, completed_at, version, created_at, updated_at, checklist_required
+FROM tasks;
+
+DROP TABLE tasks;
+ALTER TABLE tasks_new RENAME TO tasks;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_user_id ON tasks (user_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_user_status ON tasks (user_id, status);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks (due_date);
+
+CREATE TRIGGER IF NOT EXISTS update_tasks_updated_at AFTER UPDATE ON tasks
+BEGIN
+    UPDATE tasks SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE id = NEW.id;
+END;
+
+PRAGMA foreign_keys = ON;
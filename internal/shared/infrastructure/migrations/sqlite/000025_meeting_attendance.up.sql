@@ -0,0 +1,4 @@
+-- Attendance since the cadence last changed: held and canceled (or no-show) occurrences
+ALTER TABLE meetings ADD COLUMN held_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE meetings ADD COLUMN canceled_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE meetings ADD COLUMN last_canceled_at TEXT;
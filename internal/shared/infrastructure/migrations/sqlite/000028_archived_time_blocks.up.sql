@@ -0,0 +1,21 @@
+-- Completed and missed blocks moved out of schedules by the retention sweep,
+-- kept for history and insights
+CREATE TABLE IF NOT EXISTS archived_time_blocks (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    schedule_id TEXT NOT NULL,
+    block_type TEXT NOT NULL,
+    reference_id TEXT,
+    title TEXT NOT NULL,
+    start_time TEXT NOT NULL,
+    end_time TEXT NOT NULL,
+    completed INTEGER NOT NULL DEFAULT 0,
+    missed INTEGER NOT NULL DEFAULT 0,
+    notes TEXT NOT NULL DEFAULT '',
+    outcome_rating INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    archived_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_archived_time_blocks_user_start ON archived_time_blocks(user_id, start_time);
@@ -0,0 +1,2 @@
+-- Tasks can be kept off the schedule until an earliest start time
+ALTER TABLE tasks ADD COLUMN earliest_start TEXT;
@@ -0,0 +1,2 @@
+-- Hour after midnight at which habit days end, 0 for midnight
+ALTER TABLE user_settings ADD COLUMN habit_day_rollover INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Recurring tasks store their rule, such as "weekly" or "every 2 months"
+ALTER TABLE tasks ADD COLUMN recurrence TEXT;
@@ -0,0 +1,22 @@
+-- Only the default account's tokens fit the one-token-per-provider schema.
+CREATE TABLE oauth_tokens_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    access_token BLOB NOT NULL,
+    refresh_token BLOB,
+    token_type TEXT,
+    expiry TEXT,
+    scopes TEXT,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    UNIQUE (user_id, provider)
+);
+
+INSERT INTO oauth_tokens_old (id, user_id, provider, access_token, refresh_token, token_type, expiry, scopes, created_at, updated_at)
+SELECT id, user_id, provider, access_token, refresh_token, token_type, expiry, scopes, created_at, updated_at
+FROM oauth_tokens
+WHERE account = '';
+
+DROP TABLE oauth_tokens;
+ALTER TABLE oauth_tokens_old RENAME TO oauth_tokens;
@@ -0,0 +1,2 @@
+-- Back off failing calendars individually instead of giving up after five errors
+ALTER TABLE calendar_sync_state ADD COLUMN next_attempt_at TEXT;
@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestRunSQLiteMigrations_RunsPendingOnce(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	require.NoError(t, RunSQLiteMigrations(ctx, db))
+
+	// Non-idempotent migrations (ALTER TABLE) must not be re-applied.
+	require.NoError(t, RunSQLiteMigrations(ctx, db))
+
+	var applied int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&applied))
+	require.Positive(t, applied)
+
+	_, err = db.ExecContext(ctx, "SELECT trial_ends_at, pending_plan FROM subscriptions")
+	require.NoError(t, err)
+}
+
+func TestRunSQLiteMigrations_TaskBlockedRebuildKeepsData(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	require.NoError(t, RunSQLiteMigrations(ctx, db))
+
+	_, err = db.ExecContext(ctx, `
+		PRAGMA foreign_keys = ON;
+		INSERT INTO users (id, email, name) VALUES ('u1', 'u1@example.com', 'User');
+		INSERT INTO tasks (id, user_id, title, status, checklist_required) VALUES ('t1', 'u1', 'Ship', 'in_progress', 1);
+		INSERT INTO task_checklist_items (id, task_id, position, title) VALUES ('c1', 't1', 0, 'Tag');
+		INSERT INTO task_tags (task_id, tag) VALUES ('t1', 'work');
+	`)
+	require.NoError(t, err)
+
+	// Re-run the table rebuild against existing rows.
+	_, err = db.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = '000021_task_blocked'")
+	require.NoError(t, err)
+	require.NoError(t, RunSQLiteMigrations(ctx, db))
+
+	var status string
+	var required bool
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT status, checklist_required FROM tasks WHERE id = 't1'").Scan(&status, &required))
+	require.Equal(t, "in_progress", status)
+	require.True(t, required)
+
+	var items, tags int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM task_checklist_items WHERE task_id = 't1'").Scan(&items))
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM task_tags WHERE task_id = 't1'").Scan(&tags))
+	require.Equal(t, 1, items)
+	require.Equal(t, 1, tags)
+
+	_, err = db.ExecContext(ctx, "UPDATE tasks SET status = 'blocked', blocked_reason = 'PR #42' WHERE id = 't1'")
+	require.NoError(t, err)
+
+	// Foreign keys still cascade after the rebuild.
+	_, err = db.ExecContext(ctx, "DELETE FROM tasks WHERE id = 't1'")
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM task_checklist_items").Scan(&items))
+	require.Equal(t, 0, items)
+}
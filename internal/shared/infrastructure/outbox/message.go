@@ -34,7 +34,10 @@ func NewMessage(event domain.DomainEvent) (*Message, error) {
 		return nil, err
 	}
 
-	metadata, err := json.Marshal(event.Metadata())
+	metadata, err := json.Marshal(messageMetadata{
+		EventMetadata: event.Metadata(),
+		SchemaVersion: event.SchemaVersion(),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +54,23 @@ func NewMessage(event domain.DomainEvent) (*Message, error) {
 	}, nil
 }
 
+// messageMetadata is the stored metadata of a message: the event's metadata
+// and the version of its payload.
+type messageMetadata struct {
+	domain.EventMetadata
+	SchemaVersion int `json:"schema_version"`
+}
+
+// SchemaVersion returns the version of the message's payload. Messages
+// stored before events were versioned are version 1.
+func (m *Message) SchemaVersion() int {
+	var metadata messageMetadata
+	if err := json.Unmarshal(m.Metadata, &metadata); err != nil || metadata.SchemaVersion < 1 {
+		return 1
+	}
+	return metadata.SchemaVersion
+}
+
 // IsPublished returns true if the message has been published.
 func (m *Message) IsPublished() bool {
 	return m.PublishedAt != nil
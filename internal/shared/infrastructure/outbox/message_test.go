@@ -49,6 +49,23 @@ func TestNewMessage(t *testing.T) {
 		assert.Nil(t, msg.DeadLetterReason)
 	})
 
+	t.Run("records the event schema version", func(t *testing.T) {
+		event := newTestEvent(uuid.New(), "test data")
+
+		msg, err := NewMessage(event)
+		require.NoError(t, err)
+		assert.Equal(t, 1, msg.SchemaVersion())
+
+		event.SetSchemaVersion(2)
+		msg, err = NewMessage(event)
+		require.NoError(t, err)
+		assert.Equal(t, 2, msg.SchemaVersion())
+
+		// Messages stored before events were versioned
+		msg.Metadata = json.RawMessage(`{"UserID": "00000000-0000-0000-0000-000000000000"}`)
+		assert.Equal(t, 1, msg.SchemaVersion())
+	})
+
 	t.Run("serializes event payload to JSON", func(t *testing.T) {
 		aggregateID := uuid.New()
 		event := newTestEvent(aggregateID, "test payload data")
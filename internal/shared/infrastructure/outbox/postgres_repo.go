@@ -115,7 +115,8 @@ func (r *PostgresRepository) SaveBatch(ctx context.Context, msgs []*Message) err
 	return tx.Commit(ctx)
 }
 
-// GetUnpublished retrieves unpublished messages ordered by creation time.
+// GetUnpublished retrieves unpublished messages ordered by creation time,
+// skipping messages queued behind a pending retry for the same aggregate.
 func (r *PostgresRepository) GetUnpublished(ctx context.Context, limit int) ([]*Message, error) {
 	query := `
 		SELECT id, event_id, aggregate_type, aggregate_id, event_type, routing_key,
@@ -125,7 +126,16 @@ func (r *PostgresRepository) GetUnpublished(ctx context.Context, limit int) ([]*
 		WHERE published_at IS NULL
 		  AND dead_lettered_at IS NULL
 		  AND (next_retry_at IS NULL OR next_retry_at <= NOW())
-		ORDER BY created_at
+		  AND NOT EXISTS (
+		      SELECT 1 FROM outbox earlier
+		      WHERE earlier.aggregate_type = outbox.aggregate_type
+		        AND earlier.aggregate_id = outbox.aggregate_id
+		        AND earlier.id < outbox.id
+		        AND earlier.published_at IS NULL
+		        AND earlier.dead_lettered_at IS NULL
+		        AND earlier.next_retry_at > NOW()
+		  )
+		ORDER BY created_at, id
 		LIMIT $1
 	`
 
@@ -278,9 +288,15 @@ func (r *InMemoryRepository) SaveBatch(ctx context.Context, msgs []*Message) err
 func (r *InMemoryRepository) GetUnpublished(ctx context.Context, limit int) ([]*Message, error) {
 	var result []*Message
 	now := time.Now()
+	waiting := make(map[aggregateKey]struct{})
 	for _, msg := range r.messages {
 		if msg.PublishedAt == nil && msg.DeadLetteredAt == nil {
+			key := aggregateKeyOf(msg)
+			if _, ok := waiting[key]; ok {
+				continue
+			}
 			if msg.NextRetryAt != nil && msg.NextRetryAt.After(now) {
+				waiting[key] = struct{}{}
 				continue
 			}
 			result = append(result, msg)
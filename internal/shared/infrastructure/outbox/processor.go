@@ -3,6 +3,7 @@ package outbox
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"sync"
 	"time"
@@ -10,8 +11,13 @@ import (
 	"github.com/felixgeelhaar/orbita/internal/shared/domain"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/convert"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/eventbus"
+	"github.com/google/uuid"
 )
 
+// errMissingBatchResult is recorded for a message a batch publisher returned
+// no result for.
+var errMissingBatchResult = errors.New("publisher returned no result for message")
+
 // ProcessorConfig holds configuration for the outbox processor.
 type ProcessorConfig struct {
 	PollInterval     time.Duration
@@ -135,52 +141,142 @@ func (p *Processor) processBatch(ctx context.Context) error {
 
 	p.recordProcessed(messages)
 
+	// Messages for an aggregate are published in order. Once one fails, later
+	// messages for the same aggregate wait for the next batch, where the
+	// repository holds them back until the failed message is published or
+	// dead-lettered. Other aggregates are unaffected.
+	blocked := make(map[aggregateKey]struct{})
+
+	if publisher, ok := p.publisher.(eventbus.BatchPublisher); ok {
+		p.publishInRounds(ctx, publisher, messages, blocked)
+		return nil
+	}
+
 	for _, msg := range messages {
-		metaFields := p.metadataFields(msg)
-		if err := p.publishMessage(ctx, msg); err != nil {
-			p.logger.Warn("failed to publish message",
-				"id", msg.ID,
-				"routing_key", msg.RoutingKey,
-				"event_id", msg.EventID,
-				"correlation_id", metaFields.CorrelationID,
-				"causation_id", metaFields.CausationID,
-				"user_id", metaFields.UserID,
-				"error", err,
-			)
-			errStr := err.Error()
-			if p.shouldDeadLetter(msg) {
-				p.recordDead(err)
-				if markErr := p.repo.MarkDead(ctx, msg.ID, errStr); markErr != nil {
-					p.logger.Error("failed to mark message as dead-lettered",
-						"id", msg.ID,
-						"error", markErr,
-					)
-				}
+		if p.isBlocked(msg, blocked) {
+			continue
+		}
+		p.recordResult(ctx, msg, p.publishMessage(ctx, msg), blocked)
+	}
+
+	return nil
+}
+
+// publishInRounds publishes the messages in batches holding at most one
+// message per aggregate. A batch is confirmed as a whole, so a later message
+// for an aggregate only goes out once the earlier one has been accepted,
+// keeping aggregates in order as in the one-at-a-time path.
+func (p *Processor) publishInRounds(ctx context.Context, publisher eventbus.BatchPublisher, messages []*Message, blocked map[aggregateKey]struct{}) {
+	for len(messages) > 0 {
+		var round, next []*Message
+		inRound := make(map[aggregateKey]struct{})
+		for _, msg := range messages {
+			if p.isBlocked(msg, blocked) {
+				continue
+			}
+			key := aggregateKeyOf(msg)
+			if _, ok := inRound[key]; ok {
+				next = append(next, msg)
+				continue
+			}
+			inRound[key] = struct{}{}
+			round = append(round, msg)
+		}
+		if len(round) == 0 {
+			return
+		}
+
+		batch := make([]eventbus.BatchMessage, len(round))
+		for i, msg := range round {
+			batch[i] = eventbus.BatchMessage{RoutingKey: msg.RoutingKey, Payload: msg.Payload}
+		}
+		errs := publisher.PublishBatch(ctx, batch)
+		for i, msg := range round {
+			var err error
+			if i < len(errs) {
+				err = errs[i]
 			} else {
-				p.recordFailed(err)
-				nextRetryAt := time.Now().Add(p.retryBackoff(msg.RetryCount + 1))
-				if markErr := p.repo.MarkFailed(ctx, msg.ID, errStr, nextRetryAt); markErr != nil {
-					p.logger.Error("failed to mark message as failed",
-						"id", msg.ID,
-						"error", markErr,
-					)
-				}
+				err = errMissingBatchResult
 			}
-			continue
+			p.recordResult(ctx, msg, err, blocked)
 		}
 
-		if err := p.repo.MarkPublished(ctx, msg.ID); err != nil {
-			p.logger.Error("failed to mark message as published",
-				"id", msg.ID,
-				"event_id", msg.EventID,
-				"error", err,
-			)
+		messages = next
+	}
+}
+
+// isBlocked reports whether an earlier message for the same aggregate failed
+// in this batch.
+func (p *Processor) isBlocked(msg *Message, blocked map[aggregateKey]struct{}) bool {
+	if _, ok := blocked[aggregateKeyOf(msg)]; !ok {
+		return false
+	}
+	p.logger.Debug("deferring message behind failed aggregate message",
+		"id", msg.ID,
+		"aggregate_type", msg.AggregateType,
+		"aggregate_id", msg.AggregateID,
+	)
+	return true
+}
+
+// recordResult marks the message published, failed or dead-lettered
+// depending on the publish error. A failed message blocks the rest of its
+// aggregate.
+func (p *Processor) recordResult(ctx context.Context, msg *Message, err error, blocked map[aggregateKey]struct{}) {
+	key := aggregateKeyOf(msg)
+	metaFields := p.metadataFields(msg)
+	if err != nil {
+		p.logger.Warn("failed to publish message",
+			"id", msg.ID,
+			"routing_key", msg.RoutingKey,
+			"event_id", msg.EventID,
+			"correlation_id", metaFields.CorrelationID,
+			"causation_id", metaFields.CausationID,
+			"user_id", metaFields.UserID,
+			"error", err,
+		)
+		errStr := err.Error()
+		if p.shouldDeadLetter(msg) {
+			p.recordDead(err)
+			if markErr := p.repo.MarkDead(ctx, msg.ID, errStr); markErr != nil {
+				p.logger.Error("failed to mark message as dead-lettered",
+					"id", msg.ID,
+					"error", markErr,
+				)
+			}
 		} else {
-			p.recordPublished()
+			blocked[key] = struct{}{}
+			p.recordFailed(err)
+			nextRetryAt := time.Now().Add(p.retryBackoff(msg.RetryCount + 1))
+			if markErr := p.repo.MarkFailed(ctx, msg.ID, errStr, nextRetryAt); markErr != nil {
+				p.logger.Error("failed to mark message as failed",
+					"id", msg.ID,
+					"error", markErr,
+				)
+			}
 		}
+		return
 	}
 
-	return nil
+	if markErr := p.repo.MarkPublished(ctx, msg.ID); markErr != nil {
+		p.logger.Error("failed to mark message as published",
+			"id", msg.ID,
+			"event_id", msg.EventID,
+			"error", markErr,
+		)
+	} else {
+		p.recordPublished()
+	}
+}
+
+// aggregateKey identifies the aggregate whose messages must stay ordered.
+type aggregateKey struct {
+	aggregateType string
+	aggregateID   uuid.UUID
+}
+
+func aggregateKeyOf(msg *Message) aggregateKey {
+	return aggregateKey{aggregateType: msg.AggregateType, aggregateID: msg.AggregateID}
 }
 
 func (p *Processor) publishMessage(ctx context.Context, msg *Message) error {
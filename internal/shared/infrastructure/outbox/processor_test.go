@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/eventbus"
 	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -60,9 +61,14 @@ func (r *mockRepository) GetUnpublished(ctx context.Context, limit int) ([]*outb
 
 	var result []*outbox.Message
 	now := time.Now()
+	waiting := make(map[uuid.UUID]bool)
 	for _, msg := range r.messages {
 		if msg.PublishedAt == nil && msg.DeadLetteredAt == nil {
+			if waiting[msg.AggregateID] {
+				continue
+			}
 			if msg.NextRetryAt != nil && msg.NextRetryAt.After(now) {
+				waiting[msg.AggregateID] = true
 				continue
 			}
 			result = append(result, msg)
@@ -187,6 +193,30 @@ func (p *mockPublisher) PublishedCount() int {
 	return len(p.published)
 }
 
+// mockBatchPublisher is a test double for eventbus.BatchPublisher. Messages
+// with a routing key in failForKeys are nacked.
+type mockBatchPublisher struct {
+	*mockPublisher
+	batches [][]string
+}
+
+func newMockBatchPublisher() *mockBatchPublisher {
+	return &mockBatchPublisher{mockPublisher: newMockPublisher()}
+}
+
+func (p *mockBatchPublisher) PublishBatch(ctx context.Context, msgs []eventbus.BatchMessage) []error {
+	keys := make([]string, 0, len(msgs))
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		keys = append(keys, msg.RoutingKey)
+		errs[i] = p.Publish(ctx, msg.RoutingKey, msg.Payload)
+	}
+	p.mu.Lock()
+	p.batches = append(p.batches, keys)
+	p.mu.Unlock()
+	return errs
+}
+
 func createTestMessage(routingKey string) *outbox.Message {
 	payload, _ := json.Marshal(map[string]string{"test": "data"})
 	return &outbox.Message{
@@ -274,6 +304,137 @@ func TestProcessor_ProcessOnce_DeadLettersAfterMaxRetries(t *testing.T) {
 	assert.Equal(t, uint64(1), stats.DeadCount)
 }
 
+func TestProcessor_ProcessOnce_PreservesPerAggregateOrder(t *testing.T) {
+	repo := newMockRepository()
+	publisher := newMockPublisher()
+	processor := outbox.NewProcessor(repo, publisher, outbox.DefaultProcessorConfig(), nil)
+	ctx := context.Background()
+
+	aggregateA := uuid.New()
+	aggregateB := uuid.New()
+	save := func(aggregateID uuid.UUID, routingKey string) *outbox.Message {
+		msg := createTestMessage(routingKey)
+		msg.AggregateID = aggregateID
+		require.NoError(t, repo.Save(ctx, msg))
+		return msg
+	}
+
+	// Interleaved aggregates; the middle message for A fails.
+	save(aggregateA, "a.one")
+	a2 := save(aggregateA, "a.two")
+	save(aggregateB, "b.one")
+	save(aggregateA, "a.three")
+	save(aggregateB, "b.two")
+	publisher.failForKeys["a.two"] = true
+
+	require.NoError(t, processor.ProcessOnce(ctx))
+
+	// B proceeds; A stops at the failed message and a.three is left untouched.
+	assert.Equal(t, []string{"a.one", "b.one", "b.two"}, publishedKeys(publisher))
+	assert.Equal(t, []int64{a2.ID}, repo.failedIDs)
+
+	// While a.two waits for its retry, a.three stays blocked.
+	require.NoError(t, processor.ProcessOnce(ctx))
+	assert.Equal(t, []string{"a.one", "b.one", "b.two"}, publishedKeys(publisher))
+
+	// Once the retry succeeds, A resumes in order.
+	publisher.failForKeys["a.two"] = false
+	past := time.Now().Add(-time.Second)
+	a2.NextRetryAt = &past
+
+	require.NoError(t, processor.ProcessOnce(ctx))
+
+	assert.Equal(t, []string{"a.one", "b.one", "b.two", "a.two", "a.three"}, publishedKeys(publisher))
+	assert.Equal(t, []int64{a2.ID}, repo.failedIDs)
+}
+
+func TestProcessor_ProcessOnce_DeadLetterUnblocksAggregate(t *testing.T) {
+	repo := newMockRepository()
+	publisher := newMockPublisher()
+	config := outbox.DefaultProcessorConfig()
+	config.MaxRetries = 1
+	processor := outbox.NewProcessor(repo, publisher, config, nil)
+	ctx := context.Background()
+
+	aggregateID := uuid.New()
+	for _, key := range []string{"a.one", "a.two"} {
+		msg := createTestMessage(key)
+		msg.AggregateID = aggregateID
+		require.NoError(t, repo.Save(ctx, msg))
+	}
+	publisher.failForKeys["a.one"] = true
+
+	require.NoError(t, processor.ProcessOnce(ctx))
+
+	// A dead-lettered message is resolved and does not hold back its aggregate.
+	assert.Len(t, repo.deadIDs, 1)
+	assert.Equal(t, []string{"a.two"}, publishedKeys(publisher))
+}
+
+func TestProcessor_ProcessOnce_PublishesInBatches(t *testing.T) {
+	repo := newMockRepository()
+	publisher := newMockBatchPublisher()
+	processor := outbox.NewProcessor(repo, publisher, outbox.DefaultProcessorConfig(), nil)
+	ctx := context.Background()
+
+	aggregateA := uuid.New()
+	for _, key := range []string{"a.one", "a.two"} {
+		msg := createTestMessage(key)
+		msg.AggregateID = aggregateA
+		require.NoError(t, repo.Save(ctx, msg))
+	}
+	require.NoError(t, repo.Save(ctx, createTestMessage("b.one")))
+	require.NoError(t, repo.Save(ctx, createTestMessage("c.one")))
+
+	require.NoError(t, processor.ProcessOnce(ctx))
+
+	// Each batch holds at most one message per aggregate.
+	assert.Equal(t, [][]string{{"a.one", "b.one", "c.one"}, {"a.two"}}, publisher.batches)
+	assert.Len(t, repo.publishedIDs, 4)
+	assert.Equal(t, uint64(4), processor.GetStats().PublishedCount)
+}
+
+func TestProcessor_ProcessOnce_BatchPartialNack(t *testing.T) {
+	repo := newMockRepository()
+	publisher := newMockBatchPublisher()
+	processor := outbox.NewProcessor(repo, publisher, outbox.DefaultProcessorConfig(), nil)
+	ctx := context.Background()
+
+	aggregateA := uuid.New()
+	save := func(aggregateID uuid.UUID, routingKey string) *outbox.Message {
+		msg := createTestMessage(routingKey)
+		msg.AggregateID = aggregateID
+		require.NoError(t, repo.Save(ctx, msg))
+		return msg
+	}
+	a1 := save(aggregateA, "a.one")
+	save(aggregateA, "a.two")
+	b1 := save(uuid.New(), "b.one")
+	c1 := save(uuid.New(), "c.one")
+	publisher.failForKeys["a.one"] = true
+
+	require.NoError(t, processor.ProcessOnce(ctx))
+
+	// Only the nacked message is marked failed; the rest of its batch is
+	// published and a.two waits behind a.one.
+	assert.Equal(t, [][]string{{"a.one", "b.one", "c.one"}}, publisher.batches)
+	assert.Equal(t, []int64{a1.ID}, repo.failedIDs)
+	assert.ElementsMatch(t, []int64{b1.ID, c1.ID}, repo.publishedIDs)
+	stats := processor.GetStats()
+	assert.Equal(t, uint64(2), stats.PublishedCount)
+	assert.Equal(t, uint64(1), stats.FailedCount)
+}
+
+func publishedKeys(p *mockPublisher) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	keys := make([]string, 0, len(p.published))
+	for _, msg := range p.published {
+		keys = append(keys, msg.RoutingKey)
+	}
+	return keys
+}
+
 func TestProcessor_StartStop(t *testing.T) {
 	repo := newMockRepository()
 	publisher := newMockPublisher()
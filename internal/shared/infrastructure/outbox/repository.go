@@ -14,6 +14,8 @@ type Repository interface {
 	SaveBatch(ctx context.Context, msgs []*Message) error
 
 	// GetUnpublished retrieves unpublished messages ordered by creation time.
+	// Messages queued behind an earlier message for the same aggregate that is
+	// waiting to be retried are excluded, so per-aggregate order is preserved.
 	GetUnpublished(ctx context.Context, limit int) ([]*Message, error)
 
 	// MarkPublished marks a message as successfully published.
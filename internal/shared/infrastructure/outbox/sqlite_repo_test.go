@@ -0,0 +1,78 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/orbita/internal/shared/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "modernc.org/sqlite"
+)
+
+func setupSQLiteOutboxDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	schema, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "migrations", "sqlite", "000001_initial_schema.up.sql"))
+	require.NoError(t, err)
+	_, err = sqlDB.Exec(string(schema))
+	require.NoError(t, err)
+
+	return sqlDB
+}
+
+func TestSQLiteRepository_GetUnpublished_HoldsBackAggregateBehindRetry(t *testing.T) {
+	repo := outbox.NewSQLiteRepository(setupSQLiteOutboxDB(t))
+	ctx := context.Background()
+
+	aggregateA := uuid.New()
+	aggregateB := uuid.New()
+	save := func(aggregateID uuid.UUID, routingKey string) *outbox.Message {
+		msg := createTestMessage(routingKey)
+		msg.AggregateID = aggregateID
+		msg.EventID = uuid.New()
+		require.NoError(t, repo.Save(ctx, msg))
+		return msg
+	}
+
+	save(aggregateA, "a.one")
+	a2 := save(aggregateA, "a.two")
+	save(aggregateB, "b.one")
+	save(aggregateA, "a.three")
+
+	messages, err := repo.GetUnpublished(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.one", "a.two", "b.one", "a.three"}, routingKeys(messages))
+
+	require.NoError(t, repo.MarkPublished(ctx, messages[0].ID))
+	require.NoError(t, repo.MarkFailed(ctx, a2.ID, "broker down", time.Now().Add(time.Hour)))
+
+	messages, err = repo.GetUnpublished(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b.one"}, routingKeys(messages))
+
+	// Once the retry is due, the aggregate is released in order.
+	require.NoError(t, repo.MarkFailed(ctx, a2.ID, "broker down", time.Now().Add(-time.Minute)))
+
+	messages, err = repo.GetUnpublished(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.two", "b.one", "a.three"}, routingKeys(messages))
+}
+
+func routingKeys(messages []*outbox.Message) []string {
+	keys := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		keys = append(keys, msg.RoutingKey)
+	}
+	return keys
+}
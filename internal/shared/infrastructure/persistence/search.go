@@ -0,0 +1,194 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Search index entity types.
+const (
+	SearchEntityTask    = "task"
+	SearchEntityHabit   = "habit"
+	SearchEntityMeeting = "meeting"
+	SearchEntityInbox   = "inbox"
+)
+
+// SearchDocument is one entity's entry in the full-text search index.
+// Title matches rank above body matches.
+type SearchDocument struct {
+	EntityType string
+	EntityID   uuid.UUID
+	UserID     uuid.UUID
+	Title      string
+	Body       string
+}
+
+// SearchTerms splits a search query into lower-case words. Anything other
+// than letters and digits separates words, so both drivers see the same
+// terms whatever punctuation the query holds.
+func SearchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// searchText normalizes text the way SearchTerms does before it is indexed,
+// so SQLite and PostgreSQL tokenize it into the same words.
+func searchText(text string) string {
+	return strings.Join(SearchTerms(text), " ")
+}
+
+// sqliteMatchQuery builds an FTS5 query matching documents that contain
+// every term, each as a word prefix.
+func sqliteMatchQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = `"` + term + `"*`
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// PostgresTSQuery builds a tsquery matching documents that contain every
+// term, each as a word prefix. It is used instead of websearch_to_tsquery,
+// which cannot match partial words.
+func PostgresTSQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+// SQLiteSearchExecer is implemented by *sql.DB and *sql.Tx.
+type SQLiteSearchExecer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// IndexSQLiteSearchDocument adds or replaces the document's index entry.
+func IndexSQLiteSearchDocument(ctx context.Context, exec SQLiteSearchExecer, doc SearchDocument) error {
+	if err := RemoveSQLiteSearchDocument(ctx, exec, doc.EntityType, doc.EntityID); err != nil {
+		return err
+	}
+	_, err := exec.ExecContext(ctx,
+		`INSERT INTO search_index (entity_type, entity_id, user_id, title, body) VALUES (?, ?, ?, ?, ?)`,
+		doc.EntityType, doc.EntityID.String(), doc.UserID.String(), searchText(doc.Title), searchText(doc.Body),
+	)
+	return err
+}
+
+// RemoveSQLiteSearchDocument drops an entity from the index.
+func RemoveSQLiteSearchDocument(ctx context.Context, exec SQLiteSearchExecer, entityType string, id uuid.UUID) error {
+	_, err := exec.ExecContext(ctx,
+		`DELETE FROM search_index WHERE entity_type = ? AND entity_id = ?`,
+		entityType, id.String(),
+	)
+	return err
+}
+
+// SearchSQLiteIndex returns the IDs of up to limit of the user's entities
+// of one type matching query, most relevant first.
+func SearchSQLiteIndex(ctx context.Context, exec SQLiteSearchExecer, entityType string, userID uuid.UUID, query string, limit int) ([]uuid.UUID, error) {
+	terms := SearchTerms(query)
+	if len(terms) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	// bm25 weights follow the column order: the unindexed columns, then
+	// title and body.
+	rows, err := exec.QueryContext(ctx, `
+		SELECT entity_id FROM search_index
+		WHERE search_index MATCH ? AND entity_type = ? AND user_id = ?
+		ORDER BY bm25(search_index, 0, 0, 0, 10.0, 1.0)
+		LIMIT ?`,
+		sqliteMatchQuery(terms), entityType, userID.String(), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var raw string
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// PostgreSQL statements for the search index. Repositories run them on
+// their own executor.
+const (
+	// PostgresIndexSearchDocumentSQL takes the PostgresSearchDocumentArgs.
+	PostgresIndexSearchDocumentSQL = `
+		INSERT INTO search_index (entity_type, entity_id, user_id, document)
+		VALUES ($1, $2, $3, setweight(to_tsvector('simple', $4), 'A') || setweight(to_tsvector('simple', $5), 'B'))
+		ON CONFLICT (entity_type, entity_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			document = EXCLUDED.document`
+
+	// PostgresRemoveSearchDocumentSQL takes the entity type and ID.
+	PostgresRemoveSearchDocumentSQL = `DELETE FROM search_index WHERE entity_type = $1 AND entity_id = $2`
+
+	// PostgresSearchIndexSQL takes the entity type, user ID, PostgresTSQuery
+	// and limit, and returns matching entity IDs, most relevant first.
+	PostgresSearchIndexSQL = `
+		SELECT entity_id FROM search_index
+		WHERE entity_type = $1 AND user_id = $2 AND document @@ to_tsquery('simple', $3)
+		ORDER BY ts_rank(document, to_tsquery('simple', $3)) DESC, entity_id
+		LIMIT $4`
+)
+
+// PostgresSearchDocumentArgs returns the arguments for
+// PostgresIndexSearchDocumentSQL.
+func PostgresSearchDocumentArgs(doc SearchDocument) []any {
+	return []any{doc.EntityType, doc.EntityID, doc.UserID, searchText(doc.Title), searchText(doc.Body)}
+}
+
+// IndexPostgresSearchDocument adds or replaces the document's index entry.
+func IndexPostgresSearchDocument(ctx context.Context, exec DBExecutor, doc SearchDocument) error {
+	_, err := exec.Exec(ctx, PostgresIndexSearchDocumentSQL, PostgresSearchDocumentArgs(doc)...)
+	return err
+}
+
+// RemovePostgresSearchDocument drops an entity from the index.
+func RemovePostgresSearchDocument(ctx context.Context, exec DBExecutor, entityType string, id uuid.UUID) error {
+	_, err := exec.Exec(ctx, PostgresRemoveSearchDocumentSQL, entityType, id)
+	return err
+}
+
+// SearchPostgresIndex returns the IDs of up to limit of the user's entities
+// of one type matching query, most relevant first.
+func SearchPostgresIndex(ctx context.Context, exec DBExecutor, entityType string, userID uuid.UUID, query string, limit int) ([]uuid.UUID, error) {
+	terms := SearchTerms(query)
+	if len(terms) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	rows, err := exec.Query(ctx, PostgresSearchIndexSQL, entityType, userID, PostgresTSQuery(terms), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,102 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSearchTestDB creates an in-memory SQLite database with the search
+// index table from the migrations.
+func setupSearchTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	schema, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "migrations", "sqlite", "000034_search_index.up.sql"))
+	require.NoError(t, err)
+
+	// The migration backfills from the entity tables, so create them empty.
+	_, err = db.Exec(`
+		CREATE TABLE tasks (id TEXT, user_id TEXT, title TEXT, description TEXT);
+		CREATE TABLE habits (id TEXT, user_id TEXT, name TEXT, description TEXT);
+		CREATE TABLE meetings (id TEXT, user_id TEXT, name TEXT);
+		CREATE TABLE inbox_items (id TEXT, user_id TEXT, content TEXT);
+	`)
+	require.NoError(t, err)
+	_, err = db.Exec(string(schema))
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"q3", "report", "e", "mail", "café"}, SearchTerms("  Q3 Report: e-mail, CAFÉ! "))
+	assert.Empty(t, SearchTerms(" -- "))
+}
+
+func TestPostgresTSQuery(t *testing.T) {
+	assert.Equal(t, "quart:* & rep:*", PostgresTSQuery(SearchTerms("Quart REP")))
+}
+
+func TestSQLiteSearchIndex(t *testing.T) {
+	db := setupSearchTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	alice, bob := uuid.New(), uuid.New()
+	report := SearchDocument{EntityType: SearchEntityTask, EntityID: uuid.New(), UserID: alice, Title: "Quarterly Report", Body: "send to finance"}
+	notes := SearchDocument{EntityType: SearchEntityTask, EntityID: uuid.New(), UserID: alice, Title: "Meeting notes", Body: "follow up on the report"}
+	habit := SearchDocument{EntityType: SearchEntityHabit, EntityID: uuid.New(), UserID: alice, Title: "Read a report"}
+	other := SearchDocument{EntityType: SearchEntityTask, EntityID: uuid.New(), UserID: bob, Title: "Report for Bob"}
+	for _, doc := range []SearchDocument{report, notes, habit, other} {
+		require.NoError(t, IndexSQLiteSearchDocument(ctx, db, doc))
+	}
+
+	t.Run("matches partial words ignoring case and ranks titles first", func(t *testing.T) {
+		ids, err := SearchSQLiteIndex(ctx, db, SearchEntityTask, alice, "REPO", 10)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{report.EntityID, notes.EntityID}, ids)
+	})
+
+	t.Run("requires every word", func(t *testing.T) {
+		ids, err := SearchSQLiteIndex(ctx, db, SearchEntityTask, alice, "report fin", 10)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{report.EntityID}, ids)
+	})
+
+	t.Run("ignores query syntax", func(t *testing.T) {
+		ids, err := SearchSQLiteIndex(ctx, db, SearchEntityTask, alice, `"quarterly" OR -NEAR(`, 10)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("replaces the entry on reindex", func(t *testing.T) {
+		renamed := report
+		renamed.Title = "Annual summary"
+		require.NoError(t, IndexSQLiteSearchDocument(ctx, db, renamed))
+
+		ids, err := SearchSQLiteIndex(ctx, db, SearchEntityTask, alice, "annual", 10)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{report.EntityID}, ids)
+
+		ids, err = SearchSQLiteIndex(ctx, db, SearchEntityTask, alice, "quarterly", 10)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("removes an entry", func(t *testing.T) {
+		require.NoError(t, RemoveSQLiteSearchDocument(ctx, db, SearchEntityHabit, habit.EntityID))
+
+		ids, err := SearchSQLiteIndex(ctx, db, SearchEntityHabit, alice, "read", 10)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+}
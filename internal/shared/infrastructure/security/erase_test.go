@@ -0,0 +1,19 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecureRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("plaintext-secret"), 0600))
+
+	require.NoError(t, SecureRemove(path))
+	assert.NoFileExists(t, path)
+	assert.NoError(t, SecureRemove(path))
+}
@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AttrOutcome records how an instrumented call ended.
+const AttrOutcome = attribute.Key("orbita.outcome")
+
+// Outcomes of an instrumented call.
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// OutcomeOf returns OutcomeError for a non-nil error and OutcomeSuccess otherwise.
+func OutcomeOf(err error) string {
+	if err != nil {
+		return OutcomeError
+	}
+	return OutcomeSuccess
+}
+
+// Instruments traces one kind of call and records its count and duration.
+// Metrics are named <prefix>.calls and <prefix>.duration.
+type Instruments struct {
+	tracer   trace.Tracer
+	calls    metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// NewInstruments creates instruments for the named instrumentation scope.
+// Nil providers fall back to the global ones installed by Setup.
+func NewInstruments(scope, prefix string, tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) *Instruments {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	meter := meterProvider.Meter(scope)
+
+	// Instrument creation only fails for invalid names; the returned
+	// instruments are usable no-ops in that case.
+	calls, _ := meter.Int64Counter(prefix+".calls",
+		metric.WithDescription("Number of calls"),
+	)
+	duration, _ := meter.Float64Histogram(prefix+".duration",
+		metric.WithDescription("Duration of calls"),
+		metric.WithUnit("s"),
+	)
+
+	return &Instruments{
+		tracer:   tracerProvider.Tracer(scope),
+		calls:    calls,
+		duration: duration,
+	}
+}
+
+// Call is an instrumented call in progress.
+type Call struct {
+	instruments *Instruments
+	ctx         context.Context
+	span        trace.Span
+	attrs       []attribute.KeyValue
+	start       time.Time
+}
+
+// Start starts a span for a call. The attributes are set on the span and on
+// the call's metrics.
+func (i *Instruments) Start(ctx context.Context, spanName string, attrs ...attribute.KeyValue) (context.Context, *Call) {
+	ctx, span := i.tracer.Start(ctx, spanName, trace.WithAttributes(attrs...))
+	return ctx, &Call{instruments: i, ctx: ctx, span: span, attrs: attrs, start: time.Now()}
+}
+
+// End records the outcome and duration of the call and ends its span.
+func (c *Call) End(outcome string, err error) {
+	attrs := append(append([]attribute.KeyValue(nil), c.attrs...), AttrOutcome.String(outcome))
+
+	c.span.SetAttributes(AttrOutcome.String(outcome))
+	if err != nil {
+		c.span.RecordError(err)
+		c.span.SetStatus(codes.Error, err.Error())
+	}
+	c.span.End()
+
+	set := metric.WithAttributes(attrs...)
+	c.instruments.calls.Add(c.ctx, 1, set)
+	c.instruments.duration.Record(c.ctx, time.Since(c.start).Seconds(), set)
+}
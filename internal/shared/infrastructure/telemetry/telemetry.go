@@ -0,0 +1,123 @@
+// Package telemetry exports traces and metrics over OTLP.
+//
+// Exporters are configured through the standard OTEL_* environment variables
+// (OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS, OTEL_SERVICE_NAME,
+// OTEL_RESOURCE_ATTRIBUTES, ...). Until Setup installs providers, the global
+// OpenTelemetry providers are no-ops, so instrumented code costs next to
+// nothing when telemetry is off.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// DefaultServiceName is used when OTEL_SERVICE_NAME is not set.
+const DefaultServiceName = "orbita"
+
+// Protocols accepted in OTEL_EXPORTER_OTLP_PROTOCOL.
+const (
+	ProtocolGRPC         = "grpc"
+	ProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// ErrUnsupportedProtocol is returned for an OTLP protocol other than grpc or http/protobuf.
+var ErrUnsupportedProtocol = errors.New("unsupported OTLP protocol")
+
+// Config configures telemetry export.
+type Config struct {
+	// Enabled turns on export; when false Setup leaves the no-op providers in place.
+	Enabled bool
+	// Protocol is the OTLP protocol, grpc or http/protobuf (default).
+	Protocol string
+	// ServiceVersion is reported as service.version.
+	ServiceVersion string
+}
+
+// ShutdownFunc flushes pending telemetry and stops the exporters.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup installs global tracer and meter providers that export over OTLP.
+// The returned function must be called before the process exits to flush
+// buffered spans and metrics.
+func Setup(ctx context.Context, cfg Config) (ShutdownFunc, error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(DefaultServiceName),
+			semconv.ServiceVersion(cfg.ServiceVersion),
+		),
+		// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults.
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry resource: %w", err)
+	}
+
+	traceExporter, metricExporter, err := newExporters(ctx, cfg.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithResource(res),
+	)
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+		sdkmetric.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return func(ctx context.Context) error {
+		return errors.Join(tracerProvider.Shutdown(ctx), meterProvider.Shutdown(ctx))
+	}, nil
+}
+
+func newExporters(ctx context.Context, protocol string) (sdktrace.SpanExporter, sdkmetric.Exporter, error) {
+	switch protocol {
+	case ProtocolGRPC:
+		traceExporter, err := otlptracegrpc.New(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("otlp trace exporter: %w", err)
+		}
+		metricExporter, err := otlpmetricgrpc.New(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("otlp metric exporter: %w", err)
+		}
+		return traceExporter, metricExporter, nil
+	case "", ProtocolHTTPProtobuf:
+		traceExporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("otlp trace exporter: %w", err)
+		}
+		metricExporter, err := otlpmetrichttp.New(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("otlp metric exporter: %w", err)
+		}
+		return traceExporter, metricExporter, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %q (use grpc or http/protobuf)", ErrUnsupportedProtocol, protocol)
+	}
+}
@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup_Disabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{Enabled: false})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_UnsupportedProtocol(t *testing.T) {
+	_, err := Setup(context.Background(), Config{Enabled: true, Protocol: "http/json"})
+	assert.ErrorIs(t, err, ErrUnsupportedProtocol)
+}
+
+func TestInstruments_RecordsSpanAndMetrics(t *testing.T) {
+	spans := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	instruments := NewInstruments("test", "orbita.test",
+		sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	)
+
+	ctx := context.Background()
+	_, call := instruments.Start(ctx, "test.op", attribute.String("orbita.test.id", "a"))
+	call.End(OutcomeOf(nil), nil)
+	_, call = instruments.Start(ctx, "test.op", attribute.String("orbita.test.id", "a"))
+	call.End(OutcomeOf(errors.New("boom")), errors.New("boom"))
+
+	recorded := spans.GetSpans()
+	require.Len(t, recorded, 2)
+	assert.Equal(t, "test.op", recorded[0].Name)
+	assert.Contains(t, recorded[0].Attributes, attribute.String("orbita.test.id", "a"))
+	assert.Contains(t, recorded[0].Attributes, AttrOutcome.String(OutcomeSuccess))
+	assert.Contains(t, recorded[1].Attributes, AttrOutcome.String(OutcomeError))
+	require.Len(t, recorded[1].Events, 1, "the error should be recorded on the span")
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	names := make(map[string]metricdata.Aggregation)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		names[m.Name] = m.Data
+	}
+	require.Contains(t, names, "orbita.test.calls")
+	require.Contains(t, names, "orbita.test.duration")
+
+	calls := names["orbita.test.calls"].(metricdata.Sum[int64])
+	assert.Len(t, calls.DataPoints, 2, "one series per outcome")
+	durations := names["orbita.test.duration"].(metricdata.Histogram[float64])
+	assert.Len(t, durations.DataPoints, 2)
+}
@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions
+DROP COLUMN IF EXISTS pending_plan,
+DROP COLUMN IF EXISTS trial_ends_at;
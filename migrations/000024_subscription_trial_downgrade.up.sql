@@ -0,0 +1,4 @@
+-- Trial end and scheduled downgrade tracking for subscriptions
+ALTER TABLE subscriptions
+ADD COLUMN trial_ends_at TIMESTAMPTZ,
+ADD COLUMN pending_plan VARCHAR(100) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS habit_skips;
+
+ALTER TABLE habits
+DROP COLUMN IF EXISTS frozen_until;
@@ -0,0 +1,9 @@
+-- Habit skips and freezes
+ALTER TABLE habits
+ADD COLUMN frozen_until DATE;
+
+CREATE TABLE IF NOT EXISTS habit_skips (
+    habit_id UUID NOT NULL REFERENCES habits(id) ON DELETE CASCADE,
+    skip_date DATE NOT NULL,
+    PRIMARY KEY (habit_id, skip_date)
+);
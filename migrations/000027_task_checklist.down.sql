@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_checklist_items_task;
+DROP TABLE IF EXISTS task_checklist_items;
+ALTER TABLE tasks DROP COLUMN IF EXISTS checklist_required;
@@ -0,0 +1,12 @@
+-- Task checklist items
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS checklist_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS task_checklist_items (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_checklist_items_task ON task_checklist_items (task_id, position);
@@ -0,0 +1,2 @@
+-- Link external calendar events to the schedule blocks imported for them
+ALTER TABLE calendar_sync_state ADD COLUMN IF NOT EXISTS imported_blocks JSONB NOT NULL DEFAULT '{}';
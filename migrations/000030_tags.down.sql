@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS habit_tags;
+DROP TABLE IF EXISTS task_tags;
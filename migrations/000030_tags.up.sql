@@ -0,0 +1,16 @@
+-- Tags on tasks and habits
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    tag VARCHAR(32) NOT NULL,
+    PRIMARY KEY (task_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tags_tag ON task_tags (tag);
+
+CREATE TABLE IF NOT EXISTS habit_tags (
+    habit_id UUID NOT NULL REFERENCES habits(id) ON DELETE CASCADE,
+    tag VARCHAR(32) NOT NULL,
+    PRIMARY KEY (habit_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_habit_tags_tag ON habit_tags (tag);
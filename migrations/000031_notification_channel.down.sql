@@ -0,0 +1,3 @@
+ALTER TABLE user_settings
+DROP COLUMN IF EXISTS notification_target,
+DROP COLUMN IF EXISTS notification_channel;
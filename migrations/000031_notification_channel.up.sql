@@ -0,0 +1,3 @@
+ALTER TABLE user_settings
+ADD COLUMN notification_channel TEXT NOT NULL DEFAULT '',
+ADD COLUMN notification_target TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+CREATE TABLE block_dependencies (
+    schedule_id UUID NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    before_block_id UUID NOT NULL,
+    after_block_id UUID NOT NULL,
+    PRIMARY KEY (schedule_id, before_block_id, after_block_id)
+);
@@ -0,0 +1,3 @@
+-- Default task duration in minutes per priority, as a JSON object
+ALTER TABLE user_settings
+ADD COLUMN default_durations TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_inbox_items_unprocessed;
+
+ALTER TABLE inbox_items
+DROP COLUMN IF EXISTS stale_at,
+DROP COLUMN IF EXISTS archived_at,
+DROP COLUMN IF EXISTS archived;
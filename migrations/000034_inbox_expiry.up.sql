@@ -0,0 +1,8 @@
+-- Expired inbox items are archived or flagged stale
+ALTER TABLE inbox_items
+ADD COLUMN archived BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN archived_at TIMESTAMPTZ,
+ADD COLUMN stale_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_inbox_items_unprocessed ON inbox_items(captured_at)
+WHERE promoted = FALSE AND archived = FALSE;
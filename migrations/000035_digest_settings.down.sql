@@ -0,0 +1,5 @@
+ALTER TABLE user_settings
+DROP COLUMN IF EXISTS digest_last_sent_at,
+DROP COLUMN IF EXISTS digest_timezone,
+DROP COLUMN IF EXISTS digest_time,
+DROP COLUMN IF EXISTS digest_frequency;
@@ -0,0 +1,7 @@
+UPDATE tasks SET status = 'pending' WHERE status = 'blocked';
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_status;
+ALTER TABLE tasks ADD CONSTRAINT chk_status CHECK (status IN ('pending', 'in_progress', 'completed', 'archived'));
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS blocked_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS blocked_reason;
@@ -0,0 +1,6 @@
+-- Blocked tasks: waiting on something outside Orbita
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS blocked_reason TEXT;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS blocked_at TIMESTAMPTZ;
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_status;
+ALTER TABLE tasks ADD CONSTRAINT chk_status CHECK (status IN ('pending', 'in_progress', 'completed', 'archived', 'blocked'));
@@ -0,0 +1,2 @@
+-- Track the progress of a full calendar resync so it can resume after an interruption
+ALTER TABLE calendar_sync_state ADD COLUMN IF NOT EXISTS resync_progress JSONB;
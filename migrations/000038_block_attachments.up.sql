@@ -0,0 +1,10 @@
+CREATE TABLE block_attachments (
+    schedule_id UUID NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    block_id UUID NOT NULL,
+    position INTEGER NOT NULL,
+    target TEXT NOT NULL,
+    label TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (block_id, position)
+);
+
+CREATE INDEX idx_block_attachments_schedule ON block_attachments(schedule_id);
@@ -0,0 +1,3 @@
+-- Inbox classifier rules mapping a keyword to a classification, as a JSON object
+ALTER TABLE user_settings
+ADD COLUMN classifier_rules TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE meetings DROP COLUMN IF EXISTS last_canceled_at;
+ALTER TABLE meetings DROP COLUMN IF EXISTS canceled_count;
+ALTER TABLE meetings DROP COLUMN IF EXISTS held_count;
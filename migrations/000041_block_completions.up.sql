@@ -0,0 +1,8 @@
+CREATE TABLE block_completions (
+    schedule_id UUID NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    block_id UUID PRIMARY KEY,
+    notes TEXT NOT NULL DEFAULT '',
+    outcome_rating INTEGER NOT NULL DEFAULT 0 CHECK (outcome_rating BETWEEN 0 AND 5)
+);
+
+CREATE INDEX idx_block_completions_schedule ON block_completions(schedule_id);
@@ -0,0 +1,21 @@
+-- Completed and missed blocks moved out of schedules by the retention sweep,
+-- kept for history and insights
+CREATE TABLE archived_time_blocks (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    schedule_id UUID NOT NULL,
+    block_type VARCHAR(50) NOT NULL,
+    reference_id UUID,
+    title VARCHAR(255) NOT NULL,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    missed BOOLEAN NOT NULL DEFAULT FALSE,
+    notes TEXT NOT NULL DEFAULT '',
+    outcome_rating INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_archived_time_blocks_user_start ON archived_time_blocks(user_id, start_time);
@@ -0,0 +1,2 @@
+ALTER TABLE habits DROP COLUMN IF EXISTS timezone;
+ALTER TABLE tasks DROP COLUMN IF EXISTS timezone;
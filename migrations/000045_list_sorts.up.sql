@@ -0,0 +1,3 @@
+-- Default list sort order per entity, such as {"tasks": "priority:desc,due_date:asc"}, as a JSON object
+ALTER TABLE user_settings
+ADD COLUMN list_sorts TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+UPDATE tasks SET status = 'pending' WHERE status = 'waiting';
+
+DROP INDEX IF EXISTS idx_tasks_follow_up_at;
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_status;
+ALTER TABLE tasks ADD CONSTRAINT chk_status CHECK (status IN ('pending', 'in_progress', 'completed', 'archived', 'blocked'));
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS follow_up_sent_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS follow_up_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS waiting_since;
+ALTER TABLE tasks DROP COLUMN IF EXISTS waiting_on;
@@ -0,0 +1,10 @@
+-- Waiting tasks: delegated work waiting on a person, with a follow-up reminder
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS waiting_on TEXT;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS waiting_since TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS follow_up_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS follow_up_sent_at TIMESTAMPTZ;
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_status;
+ALTER TABLE tasks ADD CONSTRAINT chk_status CHECK (status IN ('pending', 'in_progress', 'completed', 'archived', 'blocked', 'waiting'));
+
+CREATE INDEX IF NOT EXISTS idx_tasks_follow_up_at ON tasks (follow_up_at) WHERE status = 'waiting' AND follow_up_sent_at IS NULL;
@@ -0,0 +1,3 @@
+-- Planning defaults per setting, such as {"day_offset": "0"}, as a JSON object
+ALTER TABLE user_settings
+ADD COLUMN plan_defaults TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,37 @@
+-- Full-text search over tasks, habits, meetings and inbox items. Repositories
+-- keep the index up to date when they save; descriptions stored encrypted
+-- are left out so no plaintext copy is kept.
+CREATE TABLE IF NOT EXISTS search_index (
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    document TSVECTOR NOT NULL,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_index_document ON search_index USING GIN (document);
+CREATE INDEX IF NOT EXISTS idx_search_index_user ON search_index (user_id, entity_type);
+
+INSERT INTO search_index (entity_type, entity_id, user_id, document)
+SELECT 'task', id, user_id,
+       setweight(to_tsvector('simple', title), 'A') ||
+       setweight(to_tsvector('simple', CASE WHEN description LIKE 'enc:v1:%' THEN '' ELSE COALESCE(description, '') END), 'B')
+FROM tasks
+ON CONFLICT DO NOTHING;
+
+INSERT INTO search_index (entity_type, entity_id, user_id, document)
+SELECT 'habit', id, user_id,
+       setweight(to_tsvector('simple', name), 'A') ||
+       setweight(to_tsvector('simple', CASE WHEN description LIKE 'enc:v1:%' THEN '' ELSE COALESCE(description, '') END), 'B')
+FROM habits
+ON CONFLICT DO NOTHING;
+
+INSERT INTO search_index (entity_type, entity_id, user_id, document)
+SELECT 'meeting', id, user_id, setweight(to_tsvector('simple', name), 'A')
+FROM meetings
+ON CONFLICT DO NOTHING;
+
+INSERT INTO search_index (entity_type, entity_id, user_id, document)
+SELECT 'inbox', id, user_id, setweight(to_tsvector('simple', content), 'A')
+FROM inbox_items
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,7 @@
+-- Only the default account's tokens fit the one-token-per-provider schema.
+DELETE FROM oauth_tokens WHERE account <> '';
+
+ALTER TABLE oauth_tokens DROP CONSTRAINT IF EXISTS oauth_tokens_user_id_provider_account_key;
+ALTER TABLE oauth_tokens ADD CONSTRAINT oauth_tokens_user_id_provider_key UNIQUE (user_id, provider);
+
+ALTER TABLE oauth_tokens DROP COLUMN IF EXISTS account;
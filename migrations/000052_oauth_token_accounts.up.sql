@@ -0,0 +1,7 @@
+-- OAuth tokens are stored per account so a user can connect several accounts
+-- of one provider, such as a work and a personal Google account. Existing
+-- tokens keep the default, unnamed account.
+ALTER TABLE oauth_tokens ADD COLUMN IF NOT EXISTS account TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE oauth_tokens DROP CONSTRAINT IF EXISTS oauth_tokens_user_id_provider_key;
+ALTER TABLE oauth_tokens ADD CONSTRAINT oauth_tokens_user_id_provider_account_key UNIQUE (user_id, provider, account);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_calendar_sync_state_next_attempt;
+ALTER TABLE calendar_sync_state DROP COLUMN IF EXISTS next_attempt_at;
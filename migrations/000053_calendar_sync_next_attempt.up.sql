@@ -0,0 +1,4 @@
+-- Back off failing calendars individually instead of giving up after five errors
+ALTER TABLE calendar_sync_state ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_calendar_sync_state_next_attempt ON calendar_sync_state(next_attempt_at);
@@ -0,0 +1,3 @@
+-- Remove habit skips and freezes
+DROP TABLE IF EXISTS habit_skips;
+ALTER TABLE habits DROP COLUMN frozen_until;
@@ -0,0 +1,8 @@
+-- Habit skips and freezes
+ALTER TABLE habits ADD COLUMN frozen_until TEXT;
+
+CREATE TABLE IF NOT EXISTS habit_skips (
+    habit_id TEXT NOT NULL REFERENCES habits(id) ON DELETE CASCADE,
+    skip_date TEXT NOT NULL,
+    PRIMARY KEY (habit_id, skip_date)
+);
@@ -0,0 +1,6 @@
+-- Link meetings imported from recurring external calendar events to their series
+ALTER TABLE meetings ADD COLUMN external_series_id TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_meetings_user_external_series
+    ON meetings (user_id, external_series_id)
+    WHERE external_series_id IS NOT NULL;
@@ -0,0 +1,16 @@
+-- Tags on tasks and habits
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (task_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tags_tag ON task_tags (tag);
+
+CREATE TABLE IF NOT EXISTS habit_tags (
+    habit_id TEXT NOT NULL REFERENCES habits(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (habit_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_habit_tags_tag ON habit_tags (tag);
@@ -0,0 +1,2 @@
+ALTER TABLE user_settings DROP COLUMN notification_target;
+ALTER TABLE user_settings DROP COLUMN notification_channel;
@@ -0,0 +1,3 @@
+-- Per-user notification delivery channel
+ALTER TABLE user_settings ADD COLUMN notification_channel TEXT NOT NULL DEFAULT '';
+ALTER TABLE user_settings ADD COLUMN notification_target TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+-- Ordering constraints between blocks of a schedule
+CREATE TABLE IF NOT EXISTS block_dependencies (
+    schedule_id TEXT NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    before_block_id TEXT NOT NULL,
+    after_block_id TEXT NOT NULL,
+    PRIMARY KEY (schedule_id, before_block_id, after_block_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE meetings DROP COLUMN version;
+ALTER TABLE habits DROP COLUMN version;
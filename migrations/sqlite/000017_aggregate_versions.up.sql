@@ -0,0 +1,4 @@
+-- Optimistic concurrency for habits and meetings, as tasks already have.
+-- Saves only apply when the stored version matches the loaded one.
+ALTER TABLE habits ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE meetings ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+-- Links and file paths attached to schedule blocks
+CREATE TABLE IF NOT EXISTS block_attachments (
+    schedule_id TEXT NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    block_id TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    target TEXT NOT NULL,
+    label TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (block_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_block_attachments_schedule ON block_attachments(schedule_id);
@@ -0,0 +1,3 @@
+ALTER TABLE meetings DROP COLUMN last_canceled_at;
+ALTER TABLE meetings DROP COLUMN canceled_count;
+ALTER TABLE meetings DROP COLUMN held_count;
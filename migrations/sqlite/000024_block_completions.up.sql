@@ -0,0 +1,9 @@
+-- Notes and outcome ratings captured when schedule blocks are completed
+CREATE TABLE IF NOT EXISTS block_completions (
+    schedule_id TEXT NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    block_id TEXT PRIMARY KEY,
+    notes TEXT NOT NULL DEFAULT '',
+    outcome_rating INTEGER NOT NULL DEFAULT 0 CHECK (outcome_rating BETWEEN 0 AND 5)
+);
+
+CREATE INDEX IF NOT EXISTS idx_block_completions_schedule ON block_completions(schedule_id);
@@ -0,0 +1,4 @@
+-- Imported tasks remember their ID in the source app so re-imports update them
+ALTER TABLE tasks ADD COLUMN external_id TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_user_external_id ON tasks (user_id, external_id) WHERE external_id IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE habits DROP COLUMN timezone;
+ALTER TABLE tasks DROP COLUMN timezone;
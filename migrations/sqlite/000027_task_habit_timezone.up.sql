@@ -0,0 +1,3 @@
+-- Tasks and habits can belong to a time zone other than the user's current one
+ALTER TABLE tasks ADD COLUMN timezone TEXT;
+ALTER TABLE habits ADD COLUMN timezone TEXT;
@@ -0,0 +1,27 @@
+-- Full-text search over tasks, habits, meetings and inbox items. Repositories
+-- keep the index up to date when they save; descriptions stored encrypted
+-- are left out so no plaintext copy is kept.
+CREATE VIRTUAL TABLE IF NOT EXISTS search_index USING fts5(
+    entity_type UNINDEXED,
+    entity_id UNINDEXED,
+    user_id UNINDEXED,
+    title,
+    body,
+    tokenize = 'unicode61 remove_diacritics 0'
+);
+
+INSERT INTO search_index (entity_type, entity_id, user_id, title, body)
+SELECT 'task', id, user_id, title,
+       CASE WHEN description LIKE 'enc:v1:%' THEN '' ELSE COALESCE(description, '') END
+FROM tasks;
+
+INSERT INTO search_index (entity_type, entity_id, user_id, title, body)
+SELECT 'habit', id, user_id, name,
+       CASE WHEN description LIKE 'enc:v1:%' THEN '' ELSE COALESCE(description, '') END
+FROM habits;
+
+INSERT INTO search_index (entity_type, entity_id, user_id, title, body)
+SELECT 'meeting', id, user_id, name, '' FROM meetings;
+
+INSERT INTO search_index (entity_type, entity_id, user_id, title, body)
+SELECT 'inbox', id, user_id, content, '' FROM inbox_items;
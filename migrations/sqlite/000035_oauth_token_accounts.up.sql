@@ -0,0 +1,25 @@
+-- OAuth tokens are stored per account so a user can connect several accounts
+-- of one provider, such as a work and a personal Google account. Existing
+-- tokens keep the default, unnamed account.
+-- SQLite cannot alter a UNIQUE constraint, so the table is rebuilt.
+CREATE TABLE oauth_tokens_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    account TEXT NOT NULL DEFAULT '',
+    access_token BLOB NOT NULL,
+    refresh_token BLOB,
+    token_type TEXT,
+    expiry TEXT,
+    scopes TEXT, -- comma-separated list (PostgreSQL uses TEXT[])
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    UNIQUE (user_id, provider, account)
+);
+
+INSERT INTO oauth_tokens_new (id, user_id, provider, access_token, refresh_token, token_type, expiry, scopes, created_at, updated_at)
+SELECT id, user_id, provider, access_token, refresh_token, token_type, expiry, scopes, created_at, updated_at
+FROM oauth_tokens;
+
+DROP TABLE oauth_tokens;
+ALTER TABLE oauth_tokens_new RENAME TO oauth_tokens;
@@ -0,0 +1,35 @@
+DROP TABLE IF EXISTS installed_packages;
+DROP TABLE IF EXISTS marketplace_versions;
+DROP TABLE IF EXISTS marketplace_packages;
+
+CREATE TABLE IF NOT EXISTS marketplace_packages (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    author TEXT NOT NULL,
+    description TEXT,
+    category TEXT NOT NULL,
+    version TEXT NOT NULL,
+    manifest TEXT NOT NULL, -- JSON
+    downloads INTEGER NOT NULL DEFAULT 0,
+    rating REAL,
+    review_count INTEGER NOT NULL DEFAULT 0,
+    published_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_marketplace_packages_category ON marketplace_packages (category);
+CREATE INDEX IF NOT EXISTS idx_marketplace_packages_author ON marketplace_packages (author);
+
+CREATE TABLE IF NOT EXISTS installed_packages (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    package_id TEXT NOT NULL REFERENCES marketplace_packages(id),
+    installed_version TEXT NOT NULL,
+    config TEXT, -- JSON
+    enabled INTEGER NOT NULL DEFAULT 1,
+    installed_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+    UNIQUE (user_id, package_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_installed_packages_user ON installed_packages (user_id);
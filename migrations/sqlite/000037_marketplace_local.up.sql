@@ -0,0 +1,65 @@
+-- Reshape the marketplace tables to the package, version and installed
+-- package model so local mode can track installs and check for updates.
+-- The old tables were never written to.
+DROP TABLE IF EXISTS installed_packages;
+DROP TABLE IF EXISTS marketplace_packages;
+
+CREATE TABLE IF NOT EXISTS marketplace_packages (
+    id TEXT PRIMARY KEY,
+    package_id TEXT NOT NULL UNIQUE,
+    type TEXT NOT NULL CHECK (type IN ('orbit', 'engine')),
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    author TEXT NOT NULL DEFAULT '',
+    homepage TEXT NOT NULL DEFAULT '',
+    license TEXT NOT NULL DEFAULT '',
+    tags TEXT NOT NULL DEFAULT '[]', -- JSON
+    latest_version TEXT NOT NULL DEFAULT '',
+    downloads INTEGER NOT NULL DEFAULT 0,
+    rating REAL NOT NULL DEFAULT 0,
+    rating_count INTEGER NOT NULL DEFAULT 0,
+    verified INTEGER NOT NULL DEFAULT 0,
+    featured INTEGER NOT NULL DEFAULT 0,
+    publisher_id TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_marketplace_packages_type ON marketplace_packages (type);
+CREATE INDEX IF NOT EXISTS idx_marketplace_packages_publisher_id ON marketplace_packages (publisher_id);
+
+CREATE TABLE IF NOT EXISTS marketplace_versions (
+    id TEXT PRIMARY KEY,
+    package_id TEXT NOT NULL REFERENCES marketplace_packages(id) ON DELETE CASCADE,
+    version TEXT NOT NULL,
+    min_api_version TEXT NOT NULL DEFAULT '',
+    changelog TEXT NOT NULL DEFAULT '',
+    checksum TEXT NOT NULL DEFAULT '',
+    download_url TEXT NOT NULL DEFAULT '',
+    size INTEGER NOT NULL DEFAULT 0,
+    downloads INTEGER NOT NULL DEFAULT 0,
+    prerelease INTEGER NOT NULL DEFAULT 0,
+    deprecated INTEGER NOT NULL DEFAULT 0,
+    deprecation_message TEXT NOT NULL DEFAULT '',
+    published_at TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    UNIQUE (package_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_marketplace_versions_package_id ON marketplace_versions (package_id);
+
+CREATE TABLE IF NOT EXISTS installed_packages (
+    id TEXT PRIMARY KEY,
+    package_id TEXT NOT NULL,
+    version TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('orbit', 'engine')),
+    install_path TEXT NOT NULL,
+    checksum TEXT NOT NULL DEFAULT '',
+    installed_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    enabled INTEGER NOT NULL DEFAULT 1,
+    user_id TEXT NOT NULL,
+    UNIQUE (package_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_installed_packages_user ON installed_packages (user_id);
@@ -189,6 +189,33 @@ func (h *attributeHandler) WithGroup(name string) slog.Handler {
 	}
 }
 
+// ContextHandler wraps handler so records logged with a context carry the
+// correlation and request IDs stored in it.
+func ContextHandler(handler slog.Handler) slog.Handler {
+	return &attributeHandler{handler: handler}
+}
+
+// Logger returns base with the correlation and request IDs from ctx
+// attached, so every line logged for one operation shares them. Handlers
+// use it to log through loggers that were not built with ContextHandler.
+// A nil base uses slog.Default().
+func Logger(ctx context.Context, base *slog.Logger) *slog.Logger {
+	if base == nil {
+		base = slog.Default()
+	}
+	var attrs []any
+	if corrID := CorrelationIDFromContext(ctx); corrID != "" {
+		attrs = append(attrs, slog.String(CorrelationIDKey, corrID))
+	}
+	if reqID := RequestIDFromContext(ctx); reqID != "" {
+		attrs = append(attrs, slog.String(RequestIDKey, reqID))
+	}
+	if len(attrs) == 0 {
+		return base
+	}
+	return base.With(attrs...)
+}
+
 // LogOperation creates a logger with operation-specific attributes.
 func LogOperation(logger *slog.Logger, operation string, attrs ...any) *slog.Logger {
 	args := append([]any{"operation", operation}, attrs...)
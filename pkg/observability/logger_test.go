@@ -244,3 +244,43 @@ func TestContextIntegration(t *testing.T) {
 		assert.Contains(t, output, "req-456")
 	})
 }
+
+func TestContextHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(ContextHandler(slog.NewJSONHandler(&buf, nil)))
+
+	logger.InfoContext(WithCorrelationID(context.Background(), "corr-789"), "with context")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "corr-789", entry[CorrelationIDKey])
+}
+
+func TestLogger(t *testing.T) {
+	t.Run("attaches correlation and request IDs", func(t *testing.T) {
+		var buf bytes.Buffer
+		base := slog.New(slog.NewJSONHandler(&buf, nil))
+		ctx := WithRequestID(WithCorrelationID(context.Background(), "corr-123"), "req-456")
+
+		Logger(ctx, base).Info("first")
+		Logger(ctx, base).Warn("second")
+
+		lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+		require.Len(t, lines, 2)
+		for _, line := range lines {
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(line, &entry))
+			assert.Equal(t, "corr-123", entry[CorrelationIDKey])
+			assert.Equal(t, "req-456", entry[RequestIDKey])
+		}
+	})
+
+	t.Run("returns base without IDs in context", func(t *testing.T) {
+		base := slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+		assert.Same(t, base, Logger(context.Background(), base))
+	})
+
+	t.Run("falls back to the default logger", func(t *testing.T) {
+		assert.Same(t, slog.Default(), Logger(context.Background(), nil))
+	})
+}